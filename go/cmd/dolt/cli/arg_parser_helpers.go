@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
@@ -40,6 +41,8 @@ var SupportedLayouts = []string{
 	"2006-01-02",
 	"2006-01-02T15:04:05",
 	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
 }
 
 // Parses a date string. Used by multiple commands.
@@ -55,6 +58,20 @@ func ParseDate(dateStr string) (time.Time, error) {
 	return time.Time{}, errors.New("error: '" + dateStr + "' is not in a supported format.")
 }
 
+// ParseProvenance returns the provenance given by the --source-url, --license and --collected-at options of a commit.
+func ParseProvenance(apr *argparser.ArgParseResults) (doltdb.Provenance, error) {
+	var p doltdb.Provenance
+	p.SourceURL, _ = apr.GetValue(SourceURLParam)
+	p.License, _ = apr.GetValue(LicenseParam)
+	if collectedAt, ok := apr.GetValue(CollectedAtParam); ok {
+		if _, err := ParseDate(collectedAt); err != nil {
+			return doltdb.Provenance{}, fmt.Errorf("error: --%s '%s' is not in a supported format.", CollectedAtParam, collectedAt)
+		}
+		p.CollectedAt = collectedAt
+	}
+	return p, nil
+}
+
 // Parses the author flag for the commit method.
 func ParseAuthor(authorStr string) (string, string, error) {
 	if len(authorStr) == 0 {
@@ -76,54 +93,88 @@ func ParseAuthor(authorStr string) (string, string, error) {
 }
 
 const (
-	AllowEmptyFlag   = "allow-empty"
-	DateParam        = "date"
-	MessageArg       = "message"
-	AuthorParam      = "author"
-	ForceFlag        = "force"
-	DryRunFlag       = "dry-run"
-	SetUpstreamFlag  = "set-upstream"
-	AllFlag          = "all"
-	UpperCaseAllFlag = "ALL"
-	HardResetParam   = "hard"
-	SoftResetParam   = "soft"
-	CheckoutCoBranch = "b"
-	NoFFParam        = "no-ff"
-	SquashParam      = "squash"
-	AbortParam       = "abort"
-	CopyFlag         = "copy"
-	MoveFlag         = "move"
-	DeleteFlag       = "delete"
-	DeleteForceFlag  = "D"
-	OutputOnlyFlag   = "output-only"
-	RemoteParam      = "remote"
-	BranchParam      = "branch"
-	TrackFlag        = "track"
-	AmendFlag        = "amend"
-	CommitFlag       = "commit"
-	NoCommitFlag     = "no-commit"
-	NoEditFlag       = "no-edit"
-	OursFlag         = "ours"
-	TheirsFlag       = "theirs"
-	NumberFlag       = "number"
-	NotFlag          = "not"
-	MergesFlag       = "merges"
-	ParentsFlag      = "parents"
-	MinParentsFlag   = "min-parents"
-	DecorateFlag     = "decorate"
-	OneLineFlag      = "oneline"
-	ShallowFlag      = "shallow"
-	CachedFlag       = "cached"
-	ListFlag         = "list"
-	UserParam        = "user"
-	NoPrettyFlag     = "no-pretty"
-	ShowIgnoredFlag  = "ignored"
+	AllowEmptyFlag     = "allow-empty"
+	DateParam          = "date"
+	MessageArg         = "message"
+	AuthorParam        = "author"
+	ForceFlag          = "force"
+	DryRunFlag         = "dry-run"
+	SetUpstreamFlag    = "set-upstream"
+	AllFlag            = "all"
+	ParallelFlag       = "parallel"
+	UpperCaseAllFlag   = "ALL"
+	HardResetParam     = "hard"
+	SoftResetParam     = "soft"
+	CheckoutCoBranch   = "b"
+	NoFFParam          = "no-ff"
+	SquashParam        = "squash"
+	AbortParam         = "abort"
+	ContinueFlag       = "continue"
+	CopyFlag           = "copy"
+	MoveFlag           = "move"
+	DeleteFlag         = "delete"
+	DeleteForceFlag    = "D"
+	OutputOnlyFlag     = "output-only"
+	RemoteParam        = "remote"
+	BranchParam        = "branch"
+	TrackFlag          = "track"
+	AmendFlag          = "amend"
+	CommitFlag         = "commit"
+	NoCommitFlag       = "no-commit"
+	NoEditFlag         = "no-edit"
+	OursFlag           = "ours"
+	TheirsFlag         = "theirs"
+	NumberFlag         = "number"
+	NotFlag            = "not"
+	MergesFlag         = "merges"
+	ParentsFlag        = "parents"
+	MinParentsFlag     = "min-parents"
+	DecorateFlag       = "decorate"
+	OneLineFlag        = "oneline"
+	ShallowFlag        = "shallow"
+	NewGenFlag         = "new-gen"
+	CachedFlag         = "cached"
+	ListFlag           = "list"
+	UserParam          = "user"
+	NoPrettyFlag       = "no-pretty"
+	ShowIgnoredFlag    = "ignored"
+	VerifyFlag         = "verify"
+	FsckFlag           = "fsck"
+	DeltaFlag          = "delta"
+	RowFlag            = "row"
+	SignKeyParam       = "sign-key"
+	TrustedKeyParam    = "trusted-key"
+	JsonFlag           = "json"
+	DaemonFlag         = "daemon"
+	IntervalParam      = "interval"
+	DisableFlag        = "disable"
+	MaxValuesParam     = "max-values"
+	FKCascadeFlag      = "fk-cascade"
+	WhereParam         = "where"
+	UniqueFlag         = "unique"
+	AsParam            = "as"
+	AtParam            = "at"
+	ReferencesParam    = "references"
+	SourceURLParam     = "source-url"
+	LicenseParam       = "license"
+	CollectedAtParam   = "collected-at"
+	BackgroundFlag     = "background"
+	GpgSignFlag        = "gpg-sign"
+	SignFlag           = "sign"
+	ShowSignatureFlag  = "show-signature"
+	NoVerifyFlag       = "no-verify"
+	ForceWithLeaseFlag = "force-with-lease"
+	SideParam          = "side"
 )
 
+// IgnoreVolatileFlag leaves the columns listed in dolt_volatile_columns out of diffs and the status.
+const IgnoreVolatileFlag = "ignore-volatile"
+
 const (
 	SyncBackupId        = "sync"
 	SyncBackupUrlId     = "sync-url"
 	RestoreBackupId     = "restore"
+	RestoreTableId      = "restore-table"
 	AddBackupId         = "add"
 	RemoveBackupId      = "remove"
 	RemoveBackupShortId = "rm"
@@ -136,6 +187,16 @@ If there were uncommitted working set changes present when the merge started, {{
 
 var branchForceFlagDesc = "Reset {{.LessThan}}branchname{{.GreaterThan}} to {{.LessThan}}startpoint{{.GreaterThan}}, even if {{.LessThan}}branchname{{.GreaterThan}} exists already. Without {{.EmphasisLeft}}-f{{.EmphasisRight}}, {{.EmphasisLeft}}dolt branch{{.EmphasisRight}} refuses to change an existing branch. In combination with {{.EmphasisLeft}}-d{{.EmphasisRight}} (or {{.EmphasisLeft}}--delete{{.EmphasisRight}}), allow deleting the branch irrespective of its merged status. In combination with -m (or {{.EmphasisLeft}}--move{{.EmphasisRight}}), allow renaming the branch even if the new branch name already exists, the same applies for {{.EmphasisLeft}}-c{{.EmphasisRight}} (or {{.EmphasisLeft}}--copy{{.EmphasisRight}})."
 
+var jsonProgressFlagDesc = "Report progress as a stream of JSON objects, one per line, instead of a progress display."
+
+var verifyFlagDesc = "Recompute the hash of every fetched chunk after decompression and abort if any chunk does not match its address."
+
+var fsckFlagDesc = "Before recording the fetched chunks, check that every chunk reachable from the fetched commits is present locally, and abort without changing the database if any is missing."
+
+var trustedKeyParamDesc = "Path to a PEM encoded ed25519 public key. The remote's manifest must carry an attestation signed by the corresponding private key, and must match it exactly, or nothing is fetched."
+
+var deltaFlagDesc = "Fetch chunks as deltas against older versions of them that are already present locally, when the remote supports it. Chunks without a local base are fetched in full."
+
 // CreateCommitArgParser creates the argparser shared dolt commit cli and DOLT_COMMIT.
 func CreateCommitArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("commit", 0)
@@ -147,6 +208,12 @@ func CreateCommitArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(AllFlag, "a", "Adds all existing, changed tables (but not new tables) in the working set to the staged set.")
 	ap.SupportsFlag(UpperCaseAllFlag, "A", "Adds all tables (including new tables) in the working set to the staged set.")
 	ap.SupportsFlag(AmendFlag, "", "Amend previous commit")
+	ap.SupportsString(SourceURLParam, "", "url", "Record the URL the committed data was collected from as a {{.EmphasisLeft}}Source-URL{{.EmphasisRight}} trailer of the commit message.")
+	ap.SupportsString(LicenseParam, "", "license", "Record the license of the committed data, e.g. {{.EmphasisLeft}}CC-BY-4.0{{.EmphasisRight}}, as a {{.EmphasisLeft}}License{{.EmphasisRight}} trailer of the commit message.")
+	ap.SupportsString(CollectedAtParam, "", "date", "Record the date the committed data was collected as a {{.EmphasisLeft}}Collected-At{{.EmphasisRight}} trailer of the commit message.")
+	ap.SupportsFlag(GpgSignFlag, "S", "Sign the commit with gpg, using the key of the {{.EmphasisLeft}}user.signingkey{{.EmphasisRight}} config and the program of the {{.EmphasisLeft}}gpg.program{{.EmphasisRight}} config.")
+	ap.SupportsString(SignKeyParam, "", "key_id", "Sign the commit with the gpg key {{.LessThan}}key_id{{.GreaterThan}}, rather than the configured key.")
+	ap.SupportsFlag(NoVerifyFlag, "", "Bypass the pre-commit hooks.")
 	return ap
 }
 
@@ -154,6 +221,9 @@ func CreateConflictsResolveArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("conflicts resolve")
 	ap.SupportsFlag(OursFlag, "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag(TheirsFlag, "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsStringList(RowFlag, "", "row_id", "Only resolve the conflict with the given {{.EmphasisLeft}}dolt_row_id{{.EmphasisRight}} in a keyless table. Several row ids may follow the flag.")
+	ap.SupportsString(WhereParam, "", "predicate", "Only resolve the conflicts of a single table whose row in its {{.EmphasisLeft}}dolt_conflicts{{.EmphasisRight}} table matches the predicate, e.g. {{.EmphasisLeft}}their_updated_at > our_updated_at{{.EmphasisRight}}.")
+	ap.SupportsString(SideParam, "", "expression", "Instead of {{.EmphasisLeft}}--ours{{.EmphasisRight}} or {{.EmphasisLeft}}--theirs{{.EmphasisRight}}, take the version named by the value of the expression, {{.EmphasisLeft}}'ours'{{.EmphasisRight}} or {{.EmphasisLeft}}'theirs'{{.EmphasisRight}}, for each conflict of a single table.")
 	return ap
 }
 
@@ -166,18 +236,24 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(SquashParam, "", "Merge changes to the working set without updating the commit history")
 	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message.")
 	ap.SupportsFlag(AbortParam, "", mergeAbortDetails)
+	ap.SupportsFlag(ContinueFlag, "", "Stage the tables of the current merge and commit it, once its conflicts, constraint violations and schema conflicts are resolved.")
 	ap.SupportsFlag(CommitFlag, "", "Perform the merge and commit the result. This is the default option, but can be overridden with the --no-commit flag. Note that this option does not affect fast-forward merges, which don't create a new merge commit, and if any merge conflicts or constraint violations are detected, no commit will be attempted.")
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(FKCascadeFlag, "", "When the merge deletes a parent row of a foreign key whose child rows were added or changed on the other side of the merge, apply the foreign key's ON DELETE CASCADE or ON DELETE SET NULL action to the child rows instead of reporting them as constraint violations.")
 
 	return ap
 }
 
 func CreatePushArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs("push", 2)
+	ap := argparser.NewArgParserWithVariableArgs("push")
 	ap.SupportsFlag(SetUpstreamFlag, "u", "For every branch that is up to date or successfully pushed, add upstream (tracking) reference, used by argument-less {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} and other commands.")
 	ap.SupportsFlag(ForceFlag, "f", "Update the remote with local history, overwriting any conflicting history in the remote.")
+	ap.SupportsAttachedOptionalString(ForceWithLeaseFlag, "expected_hash", "Like {{.EmphasisLeft}}--force{{.EmphasisRight}}, but only overwrites the remote branch if it's at {{.LessThan}}expected_hash{{.GreaterThan}}, or where its remote tracking branch last saw it when no hash is given, so that history pushed by others since is never overwritten.")
+	ap.SupportsString(UserParam, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
+	ap.SupportsString(SignKeyParam, "", "key_file", "Path to a PEM encoded ed25519 private key. After the push, the remote's manifest is signed with it so that clients can fetch with {{.EmphasisLeft}}--trusted-key{{.EmphasisRight}}.")
+	ap.SupportsFlag(NoVerifyFlag, "", "Bypass the pre-push hooks.")
 	return ap
 }
 
@@ -198,6 +274,7 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
+	addS3CompatibleArgs(ap)
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file.")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
 	ap.SupportsString(UserParam, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
@@ -217,9 +294,19 @@ func CreateRemoteArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	addS3CompatibleArgs(ap)
+	ap.SupportsValidatedString(dbfactory.CompressionParam, "", "codec", "Compression used for chunks pushed to the remote. Valid options are snappy and zstd. Older versions of dolt can't read chunks compressed with zstd, so it also requires {{.EmphasisLeft}}--allow-incompatible-compression{{.EmphasisRight}}.", argparser.ValidatorFromStrList(dbfactory.CompressionParam, dbfactory.CompressionCodecs))
+	ap.SupportsFlag(dbfactory.AllowIncompatibleCompressionParam, "", "Allow a {{.EmphasisLeft}}--compression{{.EmphasisRight}} codec that older versions of dolt can't read. Clients of those versions fail to fetch, pull or clone from the remote once chunks are pushed to it.")
 	return ap
 }
 
+// addS3CompatibleArgs adds the options of s3 and aws remotes stored in S3 compatible object stores to |ap|.
+func addS3CompatibleArgs(ap *argparser.ArgParser) {
+	ap.SupportsString(dbfactory.AWSEndpointParam, "", "url", "URL of an S3 compatible object store, such as MinIO, Ceph or Cloudflare R2, to use instead of AWS S3. Buckets are addressed by path.")
+	ap.SupportsString(dbfactory.AWSAccessKeyIDParam, "", "key-id", "Access key id of the static credentials used with {{.EmphasisLeft}}--aws-creds-type static{{.EmphasisRight}}.")
+	ap.SupportsString(dbfactory.AWSSecretAccessKeyParam, "", "secret", "Secret access key of the static credentials used with {{.EmphasisLeft}}--aws-creds-type static{{.EmphasisRight}}. It's saved with the remote's other parameters in the repository.")
+}
+
 func CreateCleanArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("clean")
 	ap.SupportsFlag(DryRunFlag, "", "Tests removing untracked tables without modifying the working set.")
@@ -245,6 +332,15 @@ func CreateCherryPickArgParser() *argparser.ArgParser {
 func CreateFetchArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("fetch")
 	ap.SupportsString(UserParam, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
+	ap.SupportsFlag(VerifyFlag, "", verifyFlagDesc)
+	ap.SupportsFlag(FsckFlag, "", fsckFlagDesc)
+	ap.SupportsFlag(DeltaFlag, "", deltaFlagDesc)
+	ap.SupportsString(TrustedKeyParam, "", "key_file", trustedKeyParamDesc)
+	ap.SupportsFlag(JsonFlag, "", jsonProgressFlagDesc)
+	ap.SupportsFlag(AllFlag, "", "Fetch from all remotes.")
+	ap.SupportsFlag(ParallelFlag, "", "With {{.EmphasisLeft}}--all{{.EmphasisRight}}, fetch from all remotes at the same time. Chunks common to several remotes are only downloaded once.")
+	ap.SupportsFlag(DaemonFlag, "", "Keep running, fetching again every {{.EmphasisLeft}}--interval{{.EmphasisRight}} seconds until interrupted.")
+	ap.SupportsInt(IntervalParam, "", "seconds", "With {{.EmphasisLeft}}--daemon{{.EmphasisRight}}, the number of seconds between fetches. Defaults to 300.")
 	return ap
 }
 
@@ -268,6 +364,11 @@ func CreatePullArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(UserParam, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
+	ap.SupportsFlag(VerifyFlag, "", verifyFlagDesc)
+	ap.SupportsFlag(FsckFlag, "", fsckFlagDesc)
+	ap.SupportsFlag(DeltaFlag, "", deltaFlagDesc)
+	ap.SupportsString(TrustedKeyParam, "", "key_file", trustedKeyParamDesc)
+	ap.SupportsFlag(JsonFlag, "", jsonProgressFlagDesc)
 	return ap
 }
 
@@ -283,6 +384,13 @@ func CreateBranchArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateSnapshotArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("snapshot", 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the snapshot."})
+	ap.SupportsFlag(DeleteFlag, "d", "Delete a snapshot.")
+	return ap
+}
+
 func CreateTagArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("tag")
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A commit ref that the tag should point at."})
@@ -290,6 +398,16 @@ func CreateTagArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(VerboseFlag, "v", "list tags along with their metadata.")
 	ap.SupportsFlag(DeleteFlag, "d", "Delete a tag.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(SignFlag, "s", "Sign the tag with gpg, using the key of the {{.EmphasisLeft}}user.signingkey{{.EmphasisRight}} config and the program of the {{.EmphasisLeft}}gpg.program{{.EmphasisRight}} config.")
+	ap.SupportsString(SignKeyParam, "", "key_id", "Sign the tag with the gpg key {{.LessThan}}key_id{{.GreaterThan}}, rather than the configured key.")
+	return ap
+}
+
+func CreateVerifySignaturesArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("verify_signatures")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision", "A commit or tag whose signature is verified. Defaults to HEAD."})
+	ap.SupportsString(TrustedKeyParam, "", "fingerprints", "Comma separated fingerprints of the gpg keys whose signatures are trusted, rather than the keys of the {{.EmphasisLeft}}signing.trustedkeys{{.EmphasisRight}} config, or the keys the gpg keyring fully trusts if it isn't set.")
+	ap.SupportsFlag(AllFlag, "a", "Verify the signature of every commit in the history of each revision, rather than just the revision.")
 	return ap
 }
 
@@ -303,9 +421,16 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	AddRestoreTableArgs(ap)
 	return ap
 }
 
+// AddRestoreTableArgs adds the options of the restore-table subcommand of the backup and remote commands to |ap|.
+func AddRestoreTableArgs(ap *argparser.ArgParser) {
+	ap.SupportsString(AsParam, "", "new_name", "Name of the restored table, if not the name of the table in the backup or remote.")
+	ap.SupportsString(AtParam, "", "commit", "Commit to restore the table from. Defaults to the current branch of the backup or remote.")
+}
+
 func CreateVerifyConstraintsArgParser(name string) *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs(name)
 	ap.SupportsFlag(AllFlag, "a", "Verifies that all rows in the database do not violate constraints instead of just rows modified or inserted in the working set.")
@@ -314,6 +439,35 @@ func CreateVerifyConstraintsArgParser(name string) *argparser.ArgParser {
 	return ap
 }
 
+func CreateColumnDictionaryArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("column_dictionary", 2)
+	ap.SupportsFlag(DisableFlag, "", "Stores the column's strings in full again, and drops its dictionary.")
+	ap.SupportsInt(MaxValuesParam, "", "count", "The most strings in the dictionary. The most frequent strings of the column are kept. Defaults to 4096.")
+	ap.SupportsFlag(BackgroundFlag, "", "Returns at once and rewrites the rows of the table in the background, then updates the working set. Sessions writing the table meanwhile merge their changes with the rewrite when they commit.")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table of the column."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"column", "The string column to dictionary encode."})
+	return ap
+}
+
+func CreatePartialIndexArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("create_partial_index")
+	ap.SupportsString(WhereParam, "", "predicate", "The rows to index. Only rows matching the predicate are stored in the index.")
+	ap.SupportsFlag(UniqueFlag, "", "Creates a unique index, whose uniqueness is only enforced among the rows matching the predicate.")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table to index."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"index", "The name of the new index."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"column", "The columns of the index, in order."})
+	return ap
+}
+
+func CreateVirtualForeignKeyArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("create_virtual_foreign_key")
+	ap.SupportsString(ReferencesParam, "", "parent(columns)", "The referenced table and its columns, e.g. 'parent(id)'.")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table declaring the foreign key."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the new foreign key."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"column", "The columns of the foreign key, in order."})
+	return ap
+}
+
 func CreateLogArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("log")
 	ap.SupportsInt(NumberFlag, "n", "num_commits", "Limit the number of commits to output.")
@@ -329,10 +483,12 @@ func CreateLogArgParser() *argparser.ArgParser {
 func CreateGCArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("gc", 0)
 	ap.SupportsFlag(ShallowFlag, "s", "perform a fast, but incomplete garbage collection pass")
+	ap.SupportsFlag(NewGenFlag, "", "only collect the data written since the last full garbage collection")
 	return ap
 }
 
-var awsParams = []string{dbfactory.AWSRegionParam, dbfactory.AWSCredsTypeParam, dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile}
+var awsParams = []string{dbfactory.AWSRegionParam, dbfactory.AWSCredsTypeParam, dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile,
+	dbfactory.AWSEndpointParam, dbfactory.AWSAccessKeyIDParam, dbfactory.AWSSecretAccessKeyParam}
 var ossParams = []string{dbfactory.OSSCredsFileParam, dbfactory.OSSCredsProfile}
 
 func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string) (map[string]string, error) {
@@ -340,7 +496,7 @@ func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string)
 
 	var err error
 	switch scheme {
-	case dbfactory.AWSScheme:
+	case dbfactory.AWSScheme, dbfactory.S3Scheme:
 		err = AddAWSParams(backupUrl, apr, params)
 	case dbfactory.OSSScheme:
 		err = AddOSSParams(backupUrl, apr, params)
@@ -351,12 +507,12 @@ func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string)
 }
 
 func AddAWSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
-	isAWS := strings.HasPrefix(remoteUrl, "aws")
+	isAWS := strings.HasPrefix(remoteUrl, "aws") || strings.HasPrefix(remoteUrl, "s3")
 
 	if !isAWS {
 		for _, p := range awsParams {
 			if _, ok := apr.GetValue(p); ok {
-				return fmt.Errorf("%s param is only valid for aws cloud remotes in the format aws://dynamo-table:s3-bucket/database", p)
+				return fmt.Errorf("%s param is only valid for aws cloud remotes in the format aws://dynamo-table:s3-bucket/database or s3://s3-bucket/database", p)
 			}
 		}
 	}
@@ -390,6 +546,27 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+// ErrIncompatibleCompression is returned when a remote is given a compression codec that older versions of dolt can't
+// read without --allow-incompatible-compression.
+var ErrIncompatibleCompression = errors.New("older versions of dolt can't read chunks compressed with zstd; use --" + dbfactory.AllowIncompatibleCompressionParam + " to compress the chunks pushed to this remote with it anyway")
+
+// AddCompressionParam adds the chunk compression codec given in |apr|, if any, to |params|. It is valid for remotes
+// of any scheme. It returns ErrIncompatibleCompression for a codec other than snappy, unless |apr| also allows it.
+func AddCompressionParam(apr *argparser.ArgParseResults, params map[string]string) error {
+	codec, ok := apr.GetValue(dbfactory.CompressionParam)
+	if !ok {
+		return nil
+	}
+	if !strings.EqualFold(codec, "snappy") {
+		if !apr.Contains(dbfactory.AllowIncompatibleCompressionParam) {
+			return ErrIncompatibleCompression
+		}
+		params[dbfactory.AllowIncompatibleCompressionParam] = "true"
+	}
+	params[dbfactory.CompressionParam] = codec
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
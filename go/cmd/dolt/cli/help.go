@@ -188,6 +188,8 @@ func OptionsUsage(ap *argparser.ArgParser, indent string, lineLen int) string {
 			argHelpFmt = "-%[1]s <%[3]s>, --%[2]s=<%[3]s>"
 		} else if supOpt.Abbrev != "" {
 			argHelpFmt = "-%[1]s, --%[2]s"
+		} else if supOpt.OptType == argparser.OptionalAttachedValue {
+			argHelpFmt = "--%[2]s[=<%[3]s>]"
 		} else if supOpt.ValDesc != "" {
 			argHelpFmt = "--%[2]s=<%[3]s>"
 		}
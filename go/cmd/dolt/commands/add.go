@@ -32,9 +32,11 @@ This command updates the list of tables using the current content found in the w
 
 This command can be performed multiple times before a commit. It only adds the content of the specified table(s) at the time the add command is run; if you want subsequent changes included in the next commit, then you must run dolt add again to add the new content to the index.
 
-The dolt status command can be used to obtain a summary of which tables have changes that are staged for the next commit.`,
+The dolt status command can be used to obtain a summary of which tables have changes that are staged for the next commit.
+
+Tables may be given as patterns, as in dolt_ignore, where {{.EmphasisLeft}}*{{.EmphasisRight}} matches any number of characters and {{.EmphasisLeft}}?{{.EmphasisRight}} matches a single character. Use {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} to list the tables they match without adding them.`,
 	Synopsis: []string{
-		`[{{.LessThan}}table{{.GreaterThan}}...]`,
+		`[--dry-run] [{{.LessThan}}table{{.GreaterThan}}...]`,
 	},
 }
 
@@ -51,17 +53,19 @@ func (cmd AddCmd) Description() string {
 }
 
 func (cmd AddCmd) Docs() *cli.CommandDocumentation {
-	ap := cli.CreateAddArgParser()
+	ap := cmd.ArgParser()
 	return cli.NewCommandDocumentation(addDocs, ap)
 }
 
 func (cmd AddCmd) ArgParser() *argparser.ArgParser {
-	return cli.CreateAddArgParser()
+	ap := cli.CreateAddArgParser()
+	ap.SupportsFlag(cli.DryRunFlag, "", DryRunTablesHelp)
+	return ap
 }
 
 // Exec executes the command
 func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
-	ap := cli.CreateAddArgParser()
+	ap := cmd.ArgParser()
 	helpPr, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, addDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, helpPr)
 
@@ -76,6 +80,11 @@ func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		return HandleStageError(err)
 	}
 
+	if apr.Contains(cli.DryRunFlag) && (allFlag || apr.NArg() == 0 || apr.NArg() == 1 && apr.Arg(0) == ".") {
+		cli.PrintErrln("error: --dry-run can only be used with table arguments")
+		return 1
+	}
+
 	if apr.NArg() == 0 && !allFlag {
 		cli.Println("Nothing specified, nothing added.\n Maybe you wanted to say 'dolt add .'?")
 	} else if allFlag || apr.NArg() == 1 && apr.Arg(0) == "." {
@@ -84,7 +93,15 @@ func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 			return HandleStageError(err)
 		}
 	} else {
-		roots, err = actions.StageTables(ctx, roots, apr.Args, !apr.Contains(cli.ForceFlag))
+		tables, err := ResolveTablePatterns(ctx, apr.Args, roots.Working, roots.Staged)
+		if err != nil {
+			return HandleStageError(err)
+		}
+		if apr.Contains(cli.DryRunFlag) {
+			PrintResolvedTables(tables)
+			return 0
+		}
+		roots, err = actions.StageTables(ctx, roots, tables, !apr.Contains(cli.ForceFlag))
 		if err != nil {
 			return HandleStageError(err)
 		}
@@ -21,4 +21,10 @@ import (
 var Commands = cli.NewHiddenSubCommandHandler("admin", "Commands for directly working with Dolt storage for purposes of testing or database recovery", []cli.Command{
 	SetRefCmd{},
 	ShowRootCmd{},
+	TruncateHistoryCmd{},
+	ArchiveCommands,
+	ConjoinCmd{},
+	StorageCommands,
+	RestoreCmd{},
+	PromoteCmd{},
 })
@@ -0,0 +1,92 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"os"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var ArchiveCommands = cli.NewSubCommandHandler("archive", "Commands for single file archives of a database, which clones can be seeded from", []cli.Command{
+	ArchiveCreateCmd{},
+})
+
+type ArchiveCreateCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ArchiveCreateCmd) Name() string {
+	return "create"
+}
+
+// Description returns a description of the command
+func (cmd ArchiveCreateCmd) Description() string {
+	return "Writes the manifest and table files of the database to a single archive file, for dolt clone --from-archive"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd ArchiveCreateCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd ArchiveCreateCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd ArchiveCreateCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "the archive file to write"})
+	return ap
+}
+
+func (cmd ArchiveCreateCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd ArchiveCreateCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("an archive file is required").SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	path := apr.Arg(0)
+
+	f, err := os.Create(path)
+	if err != nil {
+		verr := errhand.BuildDError("failed to create archive %s", path).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	err = dEnv.DoltDB.WriteArchive(ctx, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		verr := errhand.BuildDError("failed to write archive %s", path).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	return 0
+}
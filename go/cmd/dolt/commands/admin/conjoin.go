@@ -0,0 +1,89 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const targetSizeFlag = "target-size"
+
+type ConjoinCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ConjoinCmd) Name() string {
+	return "conjoin"
+}
+
+// Description returns a description of the command
+func (cmd ConjoinCmd) Description() string {
+	return "Conjoins the table files of the database into fewer, larger table files"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd ConjoinCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd ConjoinCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd ConjoinCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsString(targetSizeFlag, "", "size", "the size, e.g. 256MB, to grow conjoined table files to. Table files at least this large are left alone. Every table file is conjoined into one if it isn't given")
+	return ap
+}
+
+func (cmd ConjoinCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd ConjoinCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	var targetSize uint64
+	if s, ok := apr.GetValue(targetSizeFlag); ok {
+		var err error
+		targetSize, err = humanize.ParseBytes(s)
+		if err != nil || targetSize == 0 {
+			verr := errhand.BuildDError("invalid --%s: %s", targetSizeFlag, s).SetPrintUsage().Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	n, err := dEnv.DoltDB.ConjoinTableFiles(ctx, targetSize)
+	if err != nil {
+		verr := errhand.BuildDError("failed to conjoin table files").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Printf("conjoined table files, %d table files left\n", n)
+	return 0
+}
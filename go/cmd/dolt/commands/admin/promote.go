@@ -0,0 +1,104 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+// PromoteCmd promotes the read replica in the current directory to a primary, like calling dolt_promote() on a
+// running sql-server does. The remote of the replica is fenced at a new epoch, so that the previous primary can no
+// longer replicate to it. Remotes served over remotesapi and aws:// remotes can't be fenced, so replicas of them
+// can't be promoted.
+type PromoteCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd PromoteCmd) Name() string {
+	return "promote"
+}
+
+// Description returns a description of the command
+func (cmd PromoteCmd) Description() string {
+	return "Promotes a read replica to a primary which replicates to its remote, fencing the previous primary"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd PromoteCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd PromoteCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd PromoteCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+func (cmd PromoteCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd PromoteCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	cli.ParseArgsOrDie(ap, args, usage)
+
+	epoch, err := promote(ctx, dEnv)
+	if err != nil {
+		verr := errhand.BuildDError("failed to promote the read replica").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Printf("promoted to primary at epoch %d\n", epoch)
+	return 0
+}
+
+func promote(ctx context.Context, dEnv *env.DoltEnv) (int64, error) {
+	se, dbName, err := engine.NewSqlEngineForEnv(ctx, dEnv)
+	if err != nil {
+		return 0, err
+	}
+	defer se.Close()
+
+	sqlCtx, err := se.NewLocalContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sqlCtx.SetCurrentDatabase(dbName)
+
+	_, iter, err := se.Query(sqlCtx, "CALL dolt_promote()")
+	if err != nil {
+		return 0, err
+	}
+	rows, err := sql.RowIterToRows(sqlCtx, nil, iter)
+	if err != nil {
+		return 0, err
+	}
+	return rows[0][0].(int64), nil
+}
@@ -0,0 +1,290 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+const (
+	fromParam        = "from"
+	toTimestampParam = "to-timestamp"
+	toOffsetParam    = "to-offset"
+	asOfParam        = "as-of"
+	listFlag         = "list"
+)
+
+type RestoreCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RestoreCmd) Name() string {
+	return "restore"
+}
+
+// Description returns a description of the command
+func (cmd RestoreCmd) Description() string {
+	return "Restores a database in the current directory from a chunk journal replica made by sql-server, or restores its branches as of a point in time"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd RestoreCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd RestoreCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd RestoreCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsString(fromParam, "", "url", "the s3://, gs:// or file:// url of the journal replica of the database")
+	ap.SupportsString(toTimestampParam, "", "timestamp", "restore the last root committed at or before this time, e.g. 2023-06-01T12:30:00Z. The latest root is restored if neither --to-timestamp nor --to-offset is given")
+	ap.SupportsInt(toOffsetParam, "", "offset", "restore the root whose record ends at this offset of the chunk journal")
+	ap.SupportsString(asOfParam, "", "timestamp", "restore every branch of the database in the current directory to the newest commit made at or before this time, e.g. '2023-06-01 12:30'. Branches that were since reset or deleted are restored from the roots in its chunk journal, and branches made after this time are deleted. Uncommitted changes to restored branches are discarded")
+	ap.SupportsFlag(listFlag, "", "list the roots the replica can be restored to, or with --as-of the changes to the branches, instead of restoring it")
+	return ap
+}
+
+func (cmd RestoreCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd RestoreCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	if asOfStr, ok := apr.GetValue(asOfParam); ok {
+		if apr.Contains(fromParam) || apr.Contains(toTimestampParam) || apr.Contains(toOffsetParam) {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("--%s can't be used with --%s, --%s or --%s", asOfParam, fromParam, toTimestampParam, toOffsetParam).SetPrintUsage().Build(), usage)
+		}
+		asOf, err := cli.ParseDate(asOfStr)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("invalid --%s", asOfParam).AddCause(err).Build(), usage)
+		}
+		return commands.HandleVErrAndExitCode(restoreAsOf(ctx, dEnv, asOf, apr.Contains(listFlag)), usage)
+	}
+
+	from, ok := apr.GetValue(fromParam)
+	if !ok {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("--%s is required", fromParam).SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(toTimestampParam) && apr.Contains(toOffsetParam) {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("--%s and --%s can't be used together", toTimestampParam, toOffsetParam).SetPrintUsage().Build(), usage)
+	}
+
+	bs, err := dbfactory.OpenBlobstore(ctx, from, nil)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("failed to open journal replica %s", from).AddCause(err).Build(), usage)
+	}
+	points, err := nbs.ReadJournalReplica(ctx, bs)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("failed to read journal replica %s", from).AddCause(err).Build(), usage)
+	} else if len(points) == 0 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("journal replica %s is empty", from).Build(), usage)
+	}
+
+	if apr.Contains(listFlag) {
+		for _, p := range points {
+			cli.Printf("%d\t%s\t%s\n", p.Offset, p.Time.UTC().Format(time.RFC3339Nano), p.Root.String())
+		}
+		return 0
+	}
+
+	point, verr := chooseRestorePoint(apr, points)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	if dEnv.HasDoltDir() {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a database already exists in this directory").Build(), usage)
+	}
+	if verr := restoreJournalReplica(ctx, dEnv, bs, point); verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Printf("Restored root %s committed at %s (journal offset %d)\n", point.Root.String(),
+		point.Time.UTC().Format(time.RFC3339Nano), point.Offset)
+	return 0
+}
+
+// chooseRestorePoint returns the point of |points| selected by the --to-timestamp or --to-offset arguments of |apr|,
+// or the latest point if neither is given.
+func chooseRestorePoint(apr *argparser.ArgParseResults, points []nbs.JournalRestorePoint) (nbs.JournalRestorePoint, errhand.VerboseError) {
+	if tsStr, ok := apr.GetValue(toTimestampParam); ok {
+		ts, err := cli.ParseDate(tsStr)
+		if err != nil {
+			return nbs.JournalRestorePoint{}, errhand.BuildDError("invalid --%s", toTimestampParam).AddCause(err).Build()
+		}
+		var found *nbs.JournalRestorePoint
+		for i := range points {
+			if !points[i].Time.After(ts) {
+				found = &points[i]
+			}
+		}
+		if found == nil {
+			return nbs.JournalRestorePoint{}, errhand.BuildDError("the journal replica has no root committed at or before %s; its first root was committed at %s",
+				ts.UTC().Format(time.RFC3339), points[0].Time.UTC().Format(time.RFC3339Nano)).Build()
+		}
+		return *found, nil
+	}
+
+	if offStr, ok := apr.GetValue(toOffsetParam); ok {
+		off, err := strconv.ParseInt(offStr, 10, 64)
+		if err != nil {
+			return nbs.JournalRestorePoint{}, errhand.BuildDError("invalid --%s", toOffsetParam).AddCause(err).Build()
+		}
+		for _, p := range points {
+			if p.Offset == off {
+				return p, nil
+			}
+		}
+		return nbs.JournalRestorePoint{}, errhand.BuildDError("the journal replica has no root ending at offset %d; use --%s to list them", off, listFlag).Build()
+	}
+
+	return points[len(points)-1], nil
+}
+
+// restoreJournalReplica restores |point| of the journal replica |bs| to a new database in the directory of |dEnv|,
+// checking out its default branch.
+func restoreJournalReplica(ctx context.Context, dEnv *env.DoltEnv, bs blobstore.Blobstore, point nbs.JournalRestorePoint) errhand.VerboseError {
+	dataDir, err := dEnv.FS.Abs(dbfactory.DoltDataDir)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if err = dEnv.FS.MkDirs(dataDir); err != nil {
+		return errhand.BuildDError("unable to make directory '%s'", dataDir).AddCause(err).Build()
+	}
+
+	err = nbs.RestoreJournalReplica(ctx, bs, dataDir, point.Offset)
+	if err != nil {
+		_ = dEnv.FS.Delete(dbfactory.DoltDir, true)
+		return errhand.BuildDError("failed to restore journal replica").AddCause(err).Build()
+	}
+
+	restored := env.Load(ctx, env.GetCurrentUserHomeDir, dEnv.FS, doltdb.LocalDirDoltDB, dEnv.Version)
+	if restored.DBLoadError != nil {
+		return errhand.BuildDError("failed to load the restored database").AddCause(restored.DBLoadError).Build()
+	}
+	branches, err := restored.DoltDB.GetBranches(ctx)
+	if err != nil {
+		return errhand.BuildDError("failed to read the branches of the restored database").AddCause(err).Build()
+	} else if len(branches) == 0 {
+		return errhand.BuildDError("the restored database has no branches").Build()
+	}
+
+	branch := ref.NewBranchRef(env.GetDefaultBranch(restored, branches))
+	if _, err = env.CreateRepoState(dEnv.FS, branch.String()); err != nil {
+		return errhand.BuildDError("failed to write the repo state of the restored database").AddCause(err).Build()
+	}
+	return nil
+}
+
+// restoreAsOf restores every branch of the database of |dEnv| to the newest commit made at or before |asOf|, or only
+// lists the changes to the branches if |list| is true.
+func restoreAsOf(ctx context.Context, dEnv *env.DoltEnv, asOf time.Time, list bool) errhand.VerboseError {
+	if !dEnv.HasDoltDir() {
+		return errhand.BuildDError("--%s must be run in a database directory", asOfParam).Build()
+	} else if dEnv.DBLoadError != nil {
+		return errhand.BuildDError("failed to load the database").AddCause(dEnv.DBLoadError).Build()
+	}
+
+	dataDir, err := dEnv.FS.Abs(dbfactory.DoltDataDir)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	roots, err := nbs.ReadJournalRoots(ctx, dataDir)
+	if err != nil {
+		return errhand.BuildDError("failed to read the chunk journal").AddCause(err).Build()
+	}
+
+	restores, err := actions.BranchesAsOf(ctx, dEnv.DoltDB, roots, asOf)
+	if err != nil {
+		return errhand.BuildDError("failed to find the branches as of %s", asOf.UTC().Format(time.RFC3339)).AddCause(err).Build()
+	}
+	if len(restores) == 0 {
+		cli.Printf("Every branch is already as of %s\n", asOf.UTC().Format(time.RFC3339))
+		return nil
+	}
+
+	remaining := make(map[string]struct{})
+	branches, err := dEnv.DoltDB.GetBranches(ctx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	for _, b := range branches {
+		remaining[b.GetPath()] = struct{}{}
+	}
+	for _, r := range restores {
+		if r.To.IsEmpty() {
+			delete(remaining, r.Branch.GetPath())
+		} else {
+			remaining[r.Branch.GetPath()] = struct{}{}
+		}
+	}
+	if len(remaining) == 0 {
+		return errhand.BuildDError("the database has no commits made at or before %s", asOf.UTC().Format(time.RFC3339)).Build()
+	}
+
+	for _, r := range restores {
+		switch {
+		case r.To.IsEmpty():
+			cli.Printf("%s: deleted (was %s)\n", r.Branch.GetPath(), r.From.String())
+		case r.From.IsEmpty():
+			cli.Printf("%s: restored at %s, committed at %s\n", r.Branch.GetPath(), r.To.String(), r.ToTime.UTC().Format(time.RFC3339))
+		default:
+			cli.Printf("%s: %s -> %s, committed at %s\n", r.Branch.GetPath(), r.From.String(), r.To.String(), r.ToTime.UTC().Format(time.RFC3339))
+		}
+	}
+	if list {
+		return nil
+	}
+
+	if err = actions.RestoreBranches(ctx, dEnv.DoltDB, restores); err != nil {
+		return errhand.BuildDError("failed to restore the branches").AddCause(err).Build()
+	}
+
+	// the checked out branch may have been deleted
+	if _, ok := remaining[dEnv.RepoStateReader().CWBHeadRef().GetPath()]; !ok {
+		names := make([]ref.DoltRef, 0, len(remaining))
+		for name := range remaining {
+			names = append(names, ref.NewBranchRef(name))
+		}
+		branch := ref.NewBranchRef(env.GetDefaultBranch(dEnv, names))
+		if err = dEnv.RepoStateWriter().SetCWBHeadRef(ctx, ref.MarshalableRef{Ref: branch}); err != nil {
+			return errhand.BuildDError("failed to check out %s", branch.GetPath()).AddCause(err).Build()
+		}
+		cli.Printf("Checked out %s\n", branch.GetPath())
+	}
+	return nil
+}
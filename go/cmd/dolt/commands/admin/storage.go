@@ -0,0 +1,165 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+const (
+	refsFlag = "refs"
+	// maxRefsShown is the number of refs listed for each table file
+	maxRefsShown = 3
+)
+
+var StorageCommands = cli.NewSubCommandHandler("storage", "Commands for inspecting the table files of a database", []cli.Command{
+	StorageInspectCmd{},
+})
+
+type StorageInspectCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd StorageInspectCmd) Name() string {
+	return "inspect"
+}
+
+// Description returns a description of the command
+func (cmd StorageInspectCmd) Description() string {
+	return "Prints the chunk counts, sizes, chunk size histogram and age of every table file of the database"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd StorageInspectCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd StorageInspectCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd StorageInspectCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsFlag(refsFlag, "", "also print the refs whose chunks are stored in each table file. Every chunk of the database is read")
+	return ap
+}
+
+func (cmd StorageInspectCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd StorageInspectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	stats, err := dEnv.DoltDB.InspectTableFiles(ctx)
+	if err != nil {
+		verr := errhand.BuildDError("failed to inspect table files").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	var refChunks map[string]map[string]uint64
+	if apr.Contains(refsFlag) {
+		refChunks, err = dEnv.DoltDB.TableFileRefChunks(ctx)
+		if err != nil {
+			verr := errhand.BuildDError("failed to find the chunks of refs").AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	var totalChunks, totalSize, totalUncompressed uint64
+	for _, s := range stats {
+		printTableFileStats(s, refChunks)
+		totalChunks += uint64(s.Chunks)
+		totalSize += s.Size
+		totalUncompressed += s.UncompressedSize
+	}
+
+	cli.Printf("%d table files, %d chunks, %s (%s uncompressed)\n", len(stats), totalChunks,
+		humanize.IBytes(totalSize), humanize.IBytes(totalUncompressed))
+	return 0
+}
+
+func printTableFileStats(s nbs.TableFileStats, refChunks map[string]map[string]uint64) {
+	kind := "table file"
+	if s.Journal {
+		kind = "chunk journal"
+	}
+	if s.Generation != "" {
+		cli.Printf("%s %s (%s)\n", kind, s.Name, s.Generation)
+	} else {
+		cli.Printf("%s %s\n", kind, s.Name)
+	}
+
+	cli.Printf("\tchunks:       %d\n", s.Chunks)
+	cli.Printf("\tsize:         %s\n", humanize.IBytes(s.Size))
+	cli.Printf("\tuncompressed: %s\n", humanize.IBytes(s.UncompressedSize))
+	if !s.ModTime.IsZero() {
+		cli.Printf("\tmodified:     %s (%s)\n", s.ModTime.Format("2006-01-02 15:04:05"), humanize.Time(s.ModTime))
+	}
+
+	cli.Println("\tchunk sizes:")
+	for i, n := range s.ChunkSizes {
+		if n == 0 {
+			continue
+		}
+		cli.Printf("\t\t%9s - %-9s %d\n", humanize.IBytes(1<<i), humanize.IBytes(1<<(i+1)), n)
+	}
+
+	if refChunks != nil {
+		type refCount struct {
+			ref   string
+			count uint64
+		}
+		var counts []refCount
+		for r, n := range refChunks[s.Name] {
+			counts = append(counts, refCount{r, n})
+		}
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].count != counts[j].count {
+				return counts[i].count > counts[j].count
+			}
+			return counts[i].ref < counts[j].ref
+		})
+
+		if len(counts) == 0 {
+			cli.Println("\trefs: none, the chunks of this table file are unreachable")
+		} else {
+			cli.Println("\trefs:")
+		}
+		for i, c := range counts {
+			if i == maxRefsShown {
+				cli.Printf("\t\t...and %d more\n", len(counts)-maxRefsShown)
+				break
+			}
+			cli.Printf("\t\t%s: %d chunks (%.1f%%)\n", c.ref, c.count, 100*float64(c.count)/float64(s.Chunks))
+		}
+	}
+	cli.Println()
+}
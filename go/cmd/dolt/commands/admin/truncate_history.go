@@ -0,0 +1,143 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+const (
+	beforeParam  = "before"
+	archiveParam = "archive"
+	skipGCFlag   = "skip-gc"
+)
+
+type TruncateHistoryCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd TruncateHistoryCmd) Name() string {
+	return "truncate-history"
+}
+
+// Description returns a description of the command
+func (cmd TruncateHistoryCmd) Description() string {
+	return "Rewrites every ref so that a commit becomes a root commit, and garbage collects the history before it"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd TruncateHistoryCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd TruncateHistoryCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd TruncateHistoryCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsString(beforeParam, "", "commit", "the commit that becomes a root commit. History before it is no longer reachable from any branch, tag or remote ref that has it in its history.")
+	ap.SupportsString(archiveParam, "", "tag", "create a tag with this name at the commit as it was before truncation, which keeps the old history reachable")
+	ap.SupportsFlag(skipGCFlag, "", "don't garbage collect after truncating history")
+	return ap
+}
+
+func (cmd TruncateHistoryCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd TruncateHistoryCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	if dEnv.IsLocked() {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(env.ErrActiveServerLock.New(dEnv.LockFile())), usage)
+	}
+
+	before, ok := apr.GetValue(beforeParam)
+	if !ok {
+		verr := errhand.BuildDError("--%s is required", beforeParam).SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cs, err := doltdb.NewCommitSpec(before)
+	if err != nil {
+		verr := errhand.BuildDError("invalid commit: %s", before).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	graft, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoStateReader().CWBHeadRef())
+	if err != nil {
+		verr := errhand.BuildDError("could not resolve commit: %s", before).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	graftHash, err := graft.HashOf()
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	truncated, err := rebase.TruncateHistory(ctx, dEnv, graft)
+	if err != nil {
+		verr := errhand.BuildDError("failed to truncate history before %s", before).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	for _, r := range truncated {
+		cli.Printf("rewrote %s\n", r.String())
+	}
+
+	if archive, ok := apr.GetValue(archiveParam); ok {
+		name, email, err := env.GetNameAndEmail(dEnv.Config)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		props := actions.TagProps{
+			TaggerName:  name,
+			TaggerEmail: email,
+			Description: fmt.Sprintf("history before truncating at %s", graftHash.String()),
+		}
+		if err = actions.CreateTag(ctx, dEnv, archive, graftHash.String(), props); err != nil {
+			verr := errhand.BuildDError("failed to create archive tag %s", archive).AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	if apr.Contains(skipGCFlag) {
+		return 0
+	}
+	err = dEnv.DoltDB.GC(ctx, nil)
+	if errors.Is(err, chunks.ErrNothingToCollect) {
+		cli.PrintErrln(color.YellowString("Nothing to collect."))
+	} else if err != nil {
+		verr := errhand.BuildDError("history was truncated, but an error occurred during garbage collection").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	return 0
+}
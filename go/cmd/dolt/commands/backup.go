@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/fatih/color"
+
 	"github.com/dolthub/dolt/go/store/types"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -58,8 +60,11 @@ Remove the backup named {{.LessThan}}name{{.GreaterThan}}. All configuration set
 {{.EmphasisLeft}}restore{{.EmphasisRight}}
 Restore a Dolt database from a given {{.LessThan}}url{{.GreaterThan}} into a specified directory {{.LessThan}}url{{.GreaterThan}}.
 
+{{.EmphasisLeft}}restore-table{{.EmphasisRight}}
+Restore the table {{.LessThan}}table{{.GreaterThan}} from the backup named {{.LessThan}}name{{.GreaterThan}} into the working set, without restoring the rest of the database. Only the chunks of the table are downloaded, which makes it possible to recover an accidentally dropped table without a second copy of the database. The table is restored from the current branch of the backup, or from {{.EmphasisLeft}}--at{{.EmphasisRight}} {{.LessThan}}commit{{.GreaterThan}}, and is named {{.LessThan}}table{{.GreaterThan}} unless {{.EmphasisLeft}}--as{{.EmphasisRight}} {{.LessThan}}new_name{{.GreaterThan}} is given. A table of the same name must not already exist. {{.EmphasisLeft}}dolt remote restore-table{{.EmphasisRight}} restores a table from a remote the same way.
+
 {{.EmphasisLeft}}sync{{.EmphasisRight}}
-Snapshot the database and upload to the backup {{.LessThan}}name{{.GreaterThan}}. This includes branches, tags, working sets, and remote tracking refs.
+Snapshot the database and upload to the backup {{.LessThan}}name{{.GreaterThan}}. This includes branches, tags, working sets, and remote tracking refs. Only the chunks the backup is missing are uploaded, so syncing after {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} doesn't upload the rewritten table files again. If the backup then stores chunks the database garbage collected, the backup is garbage collected as well when its storage supports it, as {{.EmphasisLeft}}file://{{.EmphasisRight}} backups do.
 	
 {{.EmphasisLeft}}sync-url{{.EmphasisRight}}
 Snapshot the database and upload the backup to {{.LessThan}}url{{.GreaterThan}}. Like sync, this includes branches, tags, working sets, and remote tracking refs, but it does not require you to create a named backup`,
@@ -69,6 +74,7 @@ Snapshot the database and upload the backup to {{.LessThan}}url{{.GreaterThan}}.
 		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
 		"restore {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+		"restore-table [--as {{.LessThan}}new_name{{.GreaterThan}}] [--at {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}}",
 		"sync {{.LessThan}}name{{.GreaterThan}}",
 		"sync-url [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}}",
 	},
@@ -127,6 +133,8 @@ func (cmd BackupCmd) Exec(ctx context.Context, commandStr string, args []string,
 		verr = syncBackupUrl(ctx, dEnv, apr)
 	case apr.Arg(0) == cli.RestoreBackupId:
 		verr = restoreBackup(ctx, dEnv, apr)
+	case apr.Arg(0) == cli.RestoreTableId:
+		verr = restoreTableFromBackup(ctx, dEnv, apr)
 	default:
 		verr = errhand.BuildDError("").SetPrintUsage().Build()
 	}
@@ -257,6 +265,24 @@ func syncBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseR
 	return backup(ctx, dEnv, b)
 }
 
+func restoreTableFromBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	backupName := strings.TrimSpace(apr.Arg(1))
+	backups, err := dEnv.GetBackups()
+	if err != nil {
+		return errhand.BuildDError("Unable to get backups from the local directory").AddCause(err).Build()
+	}
+	b, ok := backups[backupName]
+	if !ok {
+		return errhand.BuildDError("error: unknown backup: '%s' ", backupName).Build()
+	}
+
+	return restoreTable(ctx, dEnv, b, apr.Arg(2), apr)
+}
+
 func backup(ctx context.Context, dEnv *env.DoltEnv, b env.Remote) errhand.VerboseError {
 	destDb, err := b.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format(), dEnv)
 	if err != nil {
@@ -269,9 +295,15 @@ func backup(ctx context.Context, dEnv *env.DoltEnv, b env.Remote) errhand.Verbos
 	err = actions.SyncRoots(ctx, dEnv.DoltDB, destDb, tmpDir, buildProgStarter(defaultLanguage), stopProgFuncs)
 
 	switch err {
-	case nil:
-		return nil
-	case pull.ErrDBUpToDate:
+	case nil, pull.ErrDBUpToDate:
+		compacted, err := actions.CompactBackup(ctx, dEnv.DoltDB, destDb)
+		if err == actions.ErrBackupNotCompactable {
+			cli.PrintErrln(color.YellowString("warning: %s", err.Error()))
+		} else if err != nil {
+			return errhand.BuildDError("error: unable to garbage collect the backup.").AddCause(err).Build()
+		} else if compacted {
+			cli.Println("Garbage collected the backup.")
+		}
 		return nil
 	case env.ErrBackupAlreadyExists:
 		return errhand.BuildDError("error: a backup named '%s' already exists.", b.Name).AddDetails("remove it before running this command again").Build()
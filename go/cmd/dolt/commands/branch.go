@@ -46,19 +46,23 @@ With a {{.EmphasisLeft}}-m{{.EmphasisRight}}, {{.LessThan}}oldbranch{{.GreaterTh
 
 The {{.EmphasisLeft}}-c{{.EmphasisRight}} options have the exact same semantics as {{.EmphasisLeft}}-m{{.EmphasisRight}}, except instead of the branch being renamed it will be copied to a new name.
 
-With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.`,
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.
+
+With {{.EmphasisLeft}}--bulk-file{{.EmphasisRight}}, the branch operations listed in the given JSON file are applied together: either all of them succeed or no branch is changed. The file holds an array of operations such as {{.EmphasisLeft}}{"op": "create", "branch": "b1", "start_point": "main"}{{.EmphasisRight}}, {{.EmphasisLeft}}{"op": "delete", "branch": "b2"}{{.EmphasisRight}} and {{.EmphasisLeft}}{"op": "rename", "branch": "b3", "new_branch": "b4"}{{.EmphasisRight}}, each of which may set {{.EmphasisLeft}}"force": true{{.EmphasisRight}}. Operations are applied in order and see the changes of the operations before them.`,
 	Synopsis: []string{
 		`[--list] [-v] [-a] [-r]`,
 		`[-f] {{.LessThan}}branchname{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`-m [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-c [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-d [-f] [-r] {{.LessThan}}branchname{{.GreaterThan}}...`,
+		`--bulk-file {{.LessThan}}file{{.GreaterThan}}`,
 	},
 }
 
 const (
 	datasetsFlag    = "datasets"
 	showCurrentFlag = "show-current"
+	bulkFileParam   = "bulk-file"
 )
 
 var ErrUnmergedBranchDelete = errors.New("The branch '%s' is not fully merged.\nIf you are sure you want to delete it, run 'dolt branch -D %s'.")
@@ -89,6 +93,7 @@ func (cmd BranchCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(datasetsFlag, "", "List all datasets in the database")
 	ap.SupportsFlag(cli.RemoteParam, "r", "When in list mode, show only remote tracked branches. When with -d, delete a remote tracking branch.")
 	ap.SupportsFlag(showCurrentFlag, "", "Print the name of the current branch")
+	ap.SupportsString(bulkFileParam, "", "file", "Apply the branch operations listed in the JSON file atomically")
 	return ap
 }
 
@@ -104,6 +109,8 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
 	switch {
+	case apr.Contains(bulkFileParam):
+		return bulkUpdateBranches(ctx, dEnv, apr, usage)
 	case apr.Contains(cli.MoveFlag):
 		return moveBranch(ctx, dEnv, apr, usage)
 	case apr.Contains(cli.CopyFlag):
@@ -245,7 +252,7 @@ func moveBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseR
 	force := apr.Contains(cli.ForceFlag)
 	src := apr.Arg(0)
 	dest := apr.Arg(1)
-	err := actions.RenameBranch(ctx, dEnv.DbData(), src, apr.Arg(1), dEnv, force)
+	err := actions.RenameBranch(ctx, dEnv.DbData(), src, apr.Arg(1), dEnv, force, env.GetBranchProtection(dEnv.Config))
 
 	var verr errhand.VerboseError
 	if err != nil {
@@ -304,8 +311,9 @@ func deleteBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPa
 		brName := apr.Arg(i)
 
 		err := actions.DeleteBranch(ctx, dEnv.DbData(), brName, actions.DeleteOptions{
-			Force:  force,
-			Remote: apr.Contains(cli.RemoteParam),
+			Force:      force,
+			Remote:     apr.Contains(cli.RemoteParam),
+			Protection: env.GetBranchProtection(dEnv.Config),
 		}, dEnv)
 
 		if err != nil {
@@ -316,6 +324,8 @@ func deleteBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPa
 				verr = errhand.BuildDError(ErrUnmergedBranchDelete.Error(), brName, brName).Build()
 			} else if err == actions.ErrCOBranchDelete {
 				verr = errhand.BuildDError("error: Cannot delete checked out branch '%s'", brName).Build()
+			} else if errors.Is(err, doltdb.ErrProtectedBranch) {
+				verr = errhand.BuildDError("error: Cannot delete protected branch '%s'. Remove it from the %s config to delete it", brName, env.ProtectedBranchesKey).Build()
 			} else {
 				bdr := errhand.BuildDError("fatal: Unexpected error deleting '%s'", brName)
 				verr = bdr.AddCause(err).Build()
@@ -327,6 +337,29 @@ func deleteBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPa
 	return HandleVErrAndExitCode(nil, usage)
 }
 
+func bulkUpdateBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() != 0 {
+		usage()
+		return 1
+	}
+
+	path := apr.MustGetValue(bulkFileParam)
+	data, err := dEnv.FS.ReadFile(path)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("fatal: unable to read '%s'", path).AddCause(err).Build(), usage)
+	}
+	ops, err := actions.ParseBranchOps(data)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("fatal: unable to parse '%s'", path).AddCause(err).Build(), usage)
+	}
+
+	err = actions.ApplyBranchOps(ctx, dEnv.DbData(), ops, dEnv)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("fatal: no branch was changed").AddCause(err).Build(), usage)
+	}
+	return HandleVErrAndExitCode(nil, usage)
+}
+
 func createBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
 	if apr.NArg() == 0 || apr.NArg() > 2 {
 		usage()
@@ -379,7 +412,7 @@ func createBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 		}
 	}
 
-	err := actions.CreateBranchWithStartPt(ctx, dEnv.DbData(), newBranch, startPt, apr.Contains(cli.ForceFlag))
+	err := actions.CreateBranchWithStartPt(ctx, dEnv.DbData(), newBranch, startPt, apr.Contains(cli.ForceFlag), env.GetBranchProtection(dEnv.Config))
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.BuildDError(err.Error()).Build(), usage)
 	}
@@ -42,10 +42,10 @@ dolt checkout -b {{.LessThan}}new_branch{{.GreaterThan}} [{{.LessThan}}start_poi
    Specifying -b causes a new branch to be created as if dolt branch were called and then checked out.
 
 dolt checkout {{.LessThan}}table{{.GreaterThan}}...
-  To update table(s) with their values in HEAD `,
+  To update table(s) with their values in HEAD. Tables may be given as patterns, as in dolt_ignore, where {{.EmphasisLeft}}*{{.EmphasisRight}} matches any number of characters and {{.EmphasisLeft}}?{{.EmphasisRight}} matches a single character. Use {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} to list the tables they match without updating them.`,
 	Synopsis: []string{
 		`{{.LessThan}}branch{{.GreaterThan}}`,
-		`{{.LessThan}}table{{.GreaterThan}}...`,
+		`[--dry-run] {{.LessThan}}table{{.GreaterThan}}...`,
 		`-b {{.LessThan}}new-branch{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`--track {{.LessThan}}remote{{.GreaterThan}}/{{.LessThan}}branch{{.GreaterThan}}`,
 	},
@@ -64,12 +64,14 @@ func (cmd CheckoutCmd) Description() string {
 }
 
 func (cmd CheckoutCmd) Docs() *cli.CommandDocumentation {
-	ap := cli.CreateCheckoutArgParser()
+	ap := cmd.ArgParser()
 	return cli.NewCommandDocumentation(checkoutDocs, ap)
 }
 
 func (cmd CheckoutCmd) ArgParser() *argparser.ArgParser {
-	return cli.CreateCheckoutArgParser()
+	ap := cli.CreateCheckoutArgParser()
+	ap.SupportsFlag(cli.DryRunFlag, "", DryRunTablesHelp)
+	return ap
 }
 
 // EventType returns the type of the event to log
@@ -79,7 +81,7 @@ func (cmd CheckoutCmd) EventType() eventsapi.ClientEventType {
 
 // Exec executes the command
 func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
-	ap := cli.CreateCheckoutArgParser()
+	ap := cmd.ArgParser()
 	helpPrt, usagePrt := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, checkoutDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, helpPrt)
 	if dEnv.IsLocked() {
@@ -128,8 +130,8 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 		return handleResetError(verr, usagePrt)
 	}
 
-	verr := checkoutTables(ctx, dEnv, args)
-	if verr != nil && apr.NArg() == 1 {
+	verr := checkoutTables(ctx, dEnv, apr.Args, apr.Contains(cli.DryRunFlag))
+	if verr != nil && apr.NArg() == 1 && !doltdb.IsTablePattern(name) {
 		verr = checkoutRemoteBranchOrSuggestNew(ctx, dEnv, name)
 	}
 
@@ -238,7 +240,7 @@ func checkoutRemoteBranchOrSuggestNew(ctx context.Context, dEnv *env.DoltEnv, na
 }
 
 func checkoutNewBranchFromStartPt(ctx context.Context, dEnv *env.DoltEnv, newBranch, startPt string) errhand.VerboseError {
-	err := actions.CreateBranchWithStartPt(ctx, dEnv.DbData(), newBranch, startPt, false)
+	err := actions.CreateBranchWithStartPt(ctx, dEnv.DbData(), newBranch, startPt, false, env.GetBranchProtection(dEnv.Config))
 	if err != nil {
 		return errhand.BuildDError(err.Error()).Build()
 	}
@@ -246,12 +248,21 @@ func checkoutNewBranchFromStartPt(ctx context.Context, dEnv *env.DoltEnv, newBra
 	return checkoutBranch(ctx, dEnv, newBranch, false)
 }
 
-func checkoutTables(ctx context.Context, dEnv *env.DoltEnv, tables []string) errhand.VerboseError {
+func checkoutTables(ctx context.Context, dEnv *env.DoltEnv, tables []string, dryRun bool) errhand.VerboseError {
 	roots, err := dEnv.Roots(ctx)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
 
+	tables, err = ResolveTablePatterns(ctx, tables, roots.Working, roots.Staged, roots.Head)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if dryRun {
+		PrintResolvedTables(tables)
+		return nil
+	}
+
 	roots, err = actions.CheckoutTables(ctx, roots, tables)
 	if err != nil {
 		if doltdb.IsRootValUnreachable(err) {
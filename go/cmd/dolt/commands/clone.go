@@ -16,8 +16,12 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/dolthub/dolt/go/store/types"
@@ -30,6 +34,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/migrate"
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
@@ -42,12 +47,22 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+A remote in an older storage format is cloned in that format. With {{.EmphasisLeft}}--migrate{{.EmphasisRight}}, the clone is converted to the current storage format, as if {{.EmphasisLeft}}dolt migrate{{.EmphasisRight}} had been run on it.
+
+With {{.EmphasisLeft}}--from-archive{{.EmphasisRight}}, the clone is seeded from a single archive file, which is cheap to serve from a CDN, and then brought up to date with the remote.
 `,
 	Synopsis: []string{
 		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
+const (
+	cloneMigrateFlag      = "migrate"
+	cloneFromArchiveParam = "from-archive"
+	cloneMirrorsParam     = "mirrors"
+)
+
 type CloneCmd struct{}
 
 // Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
@@ -72,7 +87,11 @@ func (cmd CloneCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd CloneCmd) ArgParser() *argparser.ArgParser {
-	return cli.CreateCloneArgParser()
+	ap := cli.CreateCloneArgParser()
+	ap.SupportsFlag(cloneMigrateFlag, "", "Convert a remote in an older storage format to the current storage format while cloning it.")
+	ap.SupportsString(cloneFromArchiveParam, "", "archive-url", "Seed the clone from an archive of the remote made with {{.EmphasisLeft}}dolt admin archive create{{.EmphasisRight}}, then fetch only what changed on the remote since. The archive may be a local path, or a file, http or https url.")
+	ap.SupportsString(cloneMirrorsParam, "", "urls", "Comma separated urls of mirrors of the remote, which the clone fails over to when the remote can't be read from. They are added to the remote of the clone, as by {{.EmphasisLeft}}dolt remote add-mirror{{.EmphasisRight}}.")
+	return ap
 }
 
 // EventType returns the type of the event to log
@@ -126,15 +145,32 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 		return verr
 	}
 
+	var mirrors []string
+	if mirrorUrls, ok := apr.GetValue(cloneMirrorsParam); ok {
+		for _, mirrorUrl := range strings.Split(mirrorUrls, ",") {
+			_, absMirrorUrl, err := env.GetAbsRemoteUrl(dEnv.FS, dEnv.Config, strings.TrimSpace(mirrorUrl))
+			if err != nil {
+				return errhand.BuildDError("error: '%s' is not valid.", mirrorUrl).Build()
+			}
+			mirrors = append(mirrors, absMirrorUrl)
+		}
+	}
+
 	var r env.Remote
 	var srcDB *doltdb.DoltDB
-	r, srcDB, verr = createRemote(ctx, remoteName, remoteUrl, params, dEnv)
+	r, srcDB, verr = createRemote(ctx, remoteName, remoteUrl, mirrors, params, dEnv)
 	if verr != nil {
 		return verr
 	}
 
+	nbf := srcDB.ValueReadWriter().Format()
+	migrating := apr.Contains(cloneMigrateFlag) && !types.IsFormat_DOLT(nbf)
+	if migrating {
+		nbf = types.Format_DOLT
+	}
+
 	// Create a new Dolt env for the clone
-	clonedEnv, err := actions.EnvForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version, env.GetCurrentUserHomeDir)
+	clonedEnv, err := actions.EnvForClone(ctx, nbf, r, dir, dEnv.FS, dEnv.Version, env.GetCurrentUserHomeDir)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
@@ -142,7 +178,22 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	// Nil out the old Dolt env so we don't accidentally operate on the wrong database
 	dEnv = nil
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, clonedEnv)
+	if archiveUrl, ok := apr.GetValue(cloneFromArchiveParam); ok {
+		if migrating {
+			return errhand.BuildDError("error: --%s can't be used with --%s", cloneFromArchiveParam, cloneMigrateFlag).Build()
+		}
+		var archive io.ReadCloser
+		archive, err = openArchive(ctx, archiveUrl)
+		if err == nil {
+			cli.Printf("seeding clone from %s\n", archiveUrl)
+			err = actions.CloneRemoteFromArchive(ctx, archive, srcDB, remoteName, branch, clonedEnv)
+			archive.Close()
+		}
+	} else if migrating {
+		err = migrate.CloneRemote(ctx, srcDB, r, branch, clonedEnv, env.GetCurrentUserHomeDir)
+	} else {
+		err = actions.CloneRemote(ctx, srcDB, remoteName, branch, clonedEnv)
+	}
 	if err != nil {
 		// If we're cloning into a directory that already exists do not erase it. Otherwise
 		// make best effort to delete the directory we created.
@@ -173,6 +224,34 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	return nil
 }
 
+// openArchive opens the archive at |archiveUrl|, which is a local path, or a file, http or https url.
+func openArchive(ctx context.Context, archiveUrl string) (io.ReadCloser, error) {
+	switch {
+	case strings.HasPrefix(archiveUrl, dbfactory.HTTPScheme+"://"), strings.HasPrefix(archiveUrl, dbfactory.HTTPSScheme+"://"):
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to download archive %s: %s", archiveUrl, resp.Status)
+		}
+		return resp.Body, nil
+	case strings.HasPrefix(archiveUrl, dbfactory.FileScheme+"://"):
+		u, err := earl.Parse(archiveUrl)
+		if err != nil {
+			return nil, err
+		}
+		return os.Open(u.Host + filepath.FromSlash(u.Path))
+	default:
+		return os.Open(archiveUrl)
+	}
+}
+
 func parseArgs(apr *argparser.ArgParseResults) (string, string, errhand.VerboseError) {
 	if apr.NArg() < 1 || apr.NArg() > 2 {
 		return "", "", errhand.BuildDError("").SetPrintUsage().Build()
@@ -200,10 +279,11 @@ func parseArgs(apr *argparser.ArgParseResults) (string, string, errhand.VerboseE
 	return dir, urlStr, nil
 }
 
-func createRemote(ctx context.Context, remoteName, remoteUrl string, params map[string]string, dEnv *env.DoltEnv) (env.Remote, *doltdb.DoltDB, errhand.VerboseError) {
+func createRemote(ctx context.Context, remoteName, remoteUrl string, mirrors []string, params map[string]string, dEnv *env.DoltEnv) (env.Remote, *doltdb.DoltDB, errhand.VerboseError) {
 	cli.Printf("cloning %s\n", remoteUrl)
 
 	r := env.NewRemote(remoteName, remoteUrl, params)
+	r.Mirrors = mirrors
 	ddb, err := r.GetRemoteDB(ctx, types.Format_Default, dEnv)
 	if err != nil {
 		bdr := errhand.BuildDError("error: failed to get remote db").AddCause(err)
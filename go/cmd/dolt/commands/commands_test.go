@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -31,6 +32,19 @@ const (
 	workingDir  = "/user/bheni/datasets/addresses"
 )
 
+// TestMain removes the history file which the tests running a SQL shell write to the working directory, unless it
+// existed before the tests ran.
+func TestMain(m *testing.M) {
+	const historyFile = ".sqlhistory"
+	_, err := os.Stat(historyFile)
+	historyExisted := err == nil
+	code := m.Run()
+	if !historyExisted {
+		os.Remove(historyFile)
+	}
+	os.Exit(code)
+}
+
 func testHomeDirFunc() (string, error) {
 	return testHomeDir, nil
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
@@ -32,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -48,7 +50,9 @@ The content to be added can be specified by using dolt add to incrementally \"ad
 
 The log message can be added with the parameter {{.EmphasisLeft}}-m <msg>{{.EmphasisRight}}.  If the {{.LessThan}}-m{{.GreaterThan}} parameter is not provided an editor will be opened where you can review the commit and provide a log message.
 
-The commit timestamp can be modified using the --date parameter.  Dates can be specified in the formats {{.LessThan}}YYYY-MM-DD{{.GreaterThan}}, {{.LessThan}}YYYY-MM-DDTHH:MM:SS{{.GreaterThan}}, or {{.LessThan}}YYYY-MM-DDTHH:MM:SSZ07:00{{.GreaterThan}} (where {{.LessThan}}07:00{{.GreaterThan}} is the time zone offset)."`,
+The commit timestamp can be modified using the --date parameter.  Dates can be specified in the formats {{.LessThan}}YYYY-MM-DD{{.GreaterThan}}, {{.LessThan}}YYYY-MM-DDTHH:MM:SS{{.GreaterThan}}, or {{.LessThan}}YYYY-MM-DDTHH:MM:SSZ07:00{{.GreaterThan}} (where {{.LessThan}}07:00{{.GreaterThan}} is the time zone offset).
+
+If the database has an executable {{.EmphasisLeft}}.dolt/hooks/pre-commit{{.EmphasisRight}} script, it is run before the commit is made, and the commit is aborted if it exits with a non-zero status. An executable {{.EmphasisLeft}}.dolt/hooks/post-commit{{.EmphasisRight}} script is run after the commit is made. The {{.EmphasisLeft}}--no-verify{{.EmphasisRight}} parameter skips the pre-commit hook."`,
 	Synopsis: []string{
 		"[options]",
 	},
@@ -150,6 +154,12 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		}
 	}
 
+	provenance, err := cli.ParseProvenance(apr)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: invalid provenance").AddCause(err).Build(), usage)
+	}
+	msg = doltdb.WithProvenanceTrailers(msg, provenance)
+
 	t := datas.CommitNowFunc()
 	if commitTimeStr, ok := apr.GetValue(cli.DateParam); ok {
 		var err error
@@ -160,6 +170,22 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		}
 	}
 
+	var signer datas.CommitSigner
+	if keyID, ok := apr.GetValue(cli.SignKeyParam); ok || apr.Contains(cli.GpgSignFlag) {
+		gpg, err := actions.ConfiguredGPGSigner(dEnv.Config, keyID)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		signer = gpg.CommitSigner()
+	}
+
+	if !apr.Contains(cli.NoVerifyFlag) {
+		verr := runHooks(ctx, dEnv, actions.HookEvent{Type: actions.PreCommitHook, Message: msg})
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
 	var parentsHeadForAmend []*doltdb.Commit
 	if apr.Contains(cli.AmendFlag) {
 		numParentsHeadForAmend := headCommit.NumParents()
@@ -198,6 +224,15 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		mergeParentCommits = parentsHeadForAmend
 	}
 
+	if refresh, err := strconv.ParseBool(dEnv.Config.GetStringOrDefault(env.DocsDataDictionaryKey, "false")); err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("invalid value for %s", env.DocsDataDictionaryKey).AddCause(err).Build(), usage)
+	} else if refresh {
+		roots, err = dtables.RefreshDataDictionary(ctx, roots)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("Couldn't generate the data dictionary").AddCause(err).Build(), usage)
+		}
+	}
+
 	pendingCommit, err := actions.GetCommitStaged(ctx, roots, ws, mergeParentCommits, dEnv.DbData().Ddb, actions.CommitStagedProps{
 		Message:    msg,
 		Date:       t,
@@ -205,6 +240,7 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		Force:      apr.Contains(cli.ForceFlag),
 		Name:       name,
 		Email:      email,
+		Signer:     signer,
 	})
 	if err != nil {
 		if apr.Contains(cli.AmendFlag) {
@@ -221,7 +257,7 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		return handleCommitErr(ctx, dEnv, err, usage)
 	}
 
-	_, err = dEnv.DoltDB.CommitWithWorkingSet(
+	newCommit, err := dEnv.DoltDB.CommitWithWorkingSet(
 		ctx,
 		dEnv.RepoStateReader().CWBHeadRef(),
 		ws.Ref(),
@@ -245,9 +281,34 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		return HandleVErrAndExitCode(errhand.BuildDError("Couldn't commit").AddCause(err).Build(), usage)
 	}
 
+	commitHash, err := newCommit.HashOf()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if verr := runHooks(ctx, dEnv, actions.HookEvent{Type: actions.PostCommitHook, Message: msg, Commit: commitHash}); verr != nil {
+		cli.PrintErrln(color.YellowString("warning: %s", verr.Verbose()))
+	}
+
 	return 0
 }
 
+// runHooks runs the hooks of the type of |ev| for the database of |dEnv|, printing their output.
+func runHooks(ctx context.Context, dEnv *env.DoltEnv, ev actions.HookEvent) errhand.VerboseError {
+	dbName, verr := getActiveDatabaseName(ctx, dEnv)
+	if verr != nil {
+		return verr
+	}
+	ev.Database = dbName
+	ev.Branch = dEnv.RepoStateReader().CWBHeadRef().GetPath()
+
+	out, err := actions.RunHooks(ctx, dEnv.FS, ev)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	cli.PrintErr(out)
+	return nil
+}
+
 func handleCommitErr(ctx context.Context, dEnv *env.DoltEnv, err error, usage cli.UsagePrinter) int {
 	if err == nil {
 		return 0
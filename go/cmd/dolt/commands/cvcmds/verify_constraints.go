@@ -38,8 +38,9 @@ var verifyConstraintsDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Verifies that working set changes satisfy table constraints`,
 	LongDesc: `Verifies that inserted or modified rows in the working set satisfy the defined table constraints.
                If any constraints are violated, they are documented in the dolt_constraint_violations system table.
-               By default, this command does not consider row changes that have been previously committed.`,
-	Synopsis: []string{`[--all] [--output-only] [{{.LessThan}}table{{.GreaterThan}}...]`},
+               By default, this command does not consider row changes that have been previously committed.
+               Tables may be given as patterns, as in dolt_ignore, where {{.EmphasisLeft}}*{{.EmphasisRight}} matches any number of characters and {{.EmphasisLeft}}?{{.EmphasisRight}} matches a single character.`,
+	Synopsis: []string{`[--all] [--output-only] [--dry-run] [{{.LessThan}}table{{.GreaterThan}}...]`},
 }
 
 type VerifyConstraintsCmd struct{}
@@ -64,7 +65,9 @@ func (cmd VerifyConstraintsCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd VerifyConstraintsCmd) ArgParser() *argparser.ArgParser {
-	return cli.CreateVerifyConstraintsArgParser(cmd.Name())
+	ap := cli.CreateVerifyConstraintsArgParser(cmd.Name())
+	ap.SupportsFlag(cli.DryRunFlag, "", commands.DryRunTablesHelp)
+	return ap
 }
 
 func (cmd VerifyConstraintsCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
@@ -78,7 +81,10 @@ func (cmd VerifyConstraintsCmd) Exec(ctx context.Context, commandStr string, arg
 	if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get working.").AddCause(err).Build(), nil)
 	}
-	tableNames := apr.Args
+	tableNames, err := commands.ResolveTablePatterns(ctx, apr.Args, working)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to resolve table names.").AddCause(err).Build(), nil)
+	}
 	if len(tableNames) == 0 {
 		tableNames, err = working.GetTableNames(ctx)
 		if err != nil {
@@ -86,16 +92,9 @@ func (cmd VerifyConstraintsCmd) Exec(ctx context.Context, commandStr string, arg
 		}
 	}
 	tableSet := set.NewStrSet(tableNames)
-
-	comparingRoot, err := dEnv.HeadRoot(ctx)
-	if err != nil {
-		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get head root.").AddCause(err).Build(), nil)
-	}
-	if verifyAllRows {
-		comparingRoot, err = doltdb.EmptyRootValue(ctx, comparingRoot.VRW(), comparingRoot.NodeStore())
-		if err != nil {
-			return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to create an empty root.").AddCause(err).Build(), nil)
-		}
+	if apr.Contains(cli.DryRunFlag) {
+		commands.PrintResolvedTables(tableSet.AsSortedSlice())
+		return 0
 	}
 
 	cm, err := dEnv.HeadCommit(ctx)
@@ -107,7 +106,18 @@ func (cmd VerifyConstraintsCmd) Exec(ctx context.Context, commandStr string, arg
 		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get head commit hash.").AddCause(err).Build(), nil)
 	}
 
-	endRoot, tablesWithViolations, err := merge.AddForeignKeyViolations(ctx, working, comparingRoot, tableSet, h)
+	var endRoot *doltdb.RootValue
+	var tablesWithViolations *set.StrSet
+	if verifyAllRows {
+		endRoot, tablesWithViolations, err = merge.AddAllForeignKeyViolations(ctx, working, tableSet, h)
+	} else {
+		var headRoot *doltdb.RootValue
+		headRoot, err = dEnv.HeadRoot(ctx)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get head root.").AddCause(err).Build(), nil)
+		}
+		endRoot, tablesWithViolations, err = merge.AddForeignKeyViolations(ctx, working, headRoot, tableSet, h)
+	}
 	if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to process constraint violations.").AddCause(err).Build(), nil)
 	}
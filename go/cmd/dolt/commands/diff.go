@@ -64,6 +64,11 @@ const (
 	SkinnyFlag  = "skinny"
 	MergeBase   = "merge-base"
 	DiffMode    = "diff-mode"
+
+	PredictConflictsFlag = "predict-conflicts"
+
+	// predictedConflictColumn is the column added to the rows of a diff by --predict-conflicts
+	predictedConflictColumn = "would_conflict"
 )
 
 var diffDocs = cli.CommandDocumentationContent{
@@ -86,6 +91,12 @@ Show changes between the working and staged tables, changes between the working
 {{.EmphasisLeft}}dolt diff [--options] <commit>...<commit> [<tables>...]{{.EmphasisRight}}
    This is to view the changes on the branch containing and up to the second {{.LessThan}}commit{{.GreaterThan}}, starting at a common ancestor of both {{.LessThan}}commit{{.GreaterThan}}. {{.EmphasisLeft}}dolt diff A...B{{.EmphasisRight}} is equivalent to {{.EmphasisLeft}}dolt diff $(dolt merge-base A B) B{{.EmphasisRight}} and {{.EmphasisLeft}}dolt diff --merge-base A B{{.EmphasisRight}}. You can omit any one of {{.LessThan}}commit{{.GreaterThan}}, which has the same effect as using HEAD instead.
 
+With {{.EmphasisLeft}}--ignore-volatile{{.EmphasisRight}}, changes to only the columns listed in the {{.EmphasisLeft}}dolt_volatile_columns{{.EmphasisRight}} table, such as timestamps maintained by a pipeline, are left out of the diff, as are those columns.
+
+Tables may be given as patterns, as in dolt_ignore, where {{.EmphasisLeft}}*{{.EmphasisRight}} matches any number of characters and {{.EmphasisLeft}}?{{.EmphasisRight}} matches a single character. Use {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} to list the tables that would be diffed.
+
+With {{.EmphasisLeft}}--predict-conflicts{{.EmphasisRight}}, a diff against a merge base, given with {{.EmphasisLeft}}--merge-base{{.EmphasisRight}} or as {{.EmphasisLeft}}A...B{{.EmphasisRight}}, has an additional {{.EmphasisLeft}}would_conflict{{.EmphasisRight}} column predicting which changed rows would conflict if {{.EmphasisLeft}}A{{.EmphasisRight}} and {{.EmphasisLeft}}B{{.EmphasisRight}} were merged. A row is predicted to conflict if a row with the same primary key, or the same values for keyless tables, was also changed on {{.EmphasisLeft}}A{{.EmphasisRight}} since the merge base. Rows changed the same way on both sides don't actually conflict when merged. Predictions aren't supported by the sql output format.
+
 The diffs displayed can be limited to show the first N by providing the parameter {{.EmphasisLeft}}--limit N{{.EmphasisRight}} where {{.EmphasisLeft}}N{{.EmphasisRight}} is the number of diffs to display.
 
 To filter which data rows are displayed, use {{.EmphasisLeft}}--where <SQL expression>{{.EmphasisRight}}. Table column names in the filter expression must be prefixed with {{.EmphasisLeft}}from_{{.EmphasisRight}} or {{.EmphasisLeft}}to_{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}to_COLUMN_NAME > 100{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME + to_COLUMN_NAME = 0{{.EmphasisRight}}.
@@ -105,6 +116,10 @@ type diffDisplaySettings struct {
 	limit      int
 	where      string
 	skinny     bool
+	// predictConflicts adds a column predicting whether each row would conflict with the other side of a merge base diff
+	predictConflicts bool
+	// ignoreVolatile leaves out the columns listed in the dolt_volatile_columns table, like ignored columns
+	ignoreVolatile bool
 }
 
 type diffDatasets struct {
@@ -112,12 +127,19 @@ type diffDatasets struct {
 	toRoot   *doltdb.RootValue
 	fromRef  string
 	toRef    string
+	// otherRoot and otherRef are the revision whose changes since the merge base |fromRoot| conflicts are predicted
+	// with, for a merge base diff
+	otherRoot *doltdb.RootValue
+	otherRef  string
 }
 
 type diffArgs struct {
 	*diffDisplaySettings
 	*diffDatasets
 	tableSet *set.StrSet
+	// ignoredColumns are the columns listed in the dolt_ignore_columns table of the working set, and in its
+	// dolt_volatile_columns table with --ignore-volatile, whose changes aren't shown
+	ignoredColumns doltdb.IgnoredColumns
 }
 
 type DiffCmd struct{}
@@ -155,6 +177,9 @@ func (cmd DiffCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(SkinnyFlag, "sk", "Shows only primary key columns and any columns with data changes.")
 	ap.SupportsFlag(MergeBase, "", "Uses merge base of the first commit and second commit (or HEAD if not supplied) as the first commit")
 	ap.SupportsString(DiffMode, "", "diff mode", "Determines how to display modified rows with tabular output. Valid values are row, line, in-place, context. Defaults to context.")
+	ap.SupportsFlag(cli.DryRunFlag, "", "List the tables that would be diffed without diffing them.")
+	ap.SupportsFlag(PredictConflictsFlag, "", "Adds a column predicting whether each row of a diff against a merge base would conflict with the changes of the other revision.")
+	ap.SupportsFlag(cli.IgnoreVolatileFlag, "", "Leave out the columns listed in dolt_volatile_columns, and the rows whose only changes are to these columns.")
 	return ap
 }
 
@@ -174,6 +199,11 @@ func (cmd DiffCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	if apr.Contains(cli.DryRunFlag) {
+		PrintResolvedTables(dArgs.tableSet.AsSortedSlice())
+		return 0
+	}
+
 	verr = diffUserTables(ctx, dEnv, dArgs)
 	return HandleVErrAndExitCode(verr, usage)
 }
@@ -192,6 +222,10 @@ func (cmd DiffCmd) validateArgs(apr *argparser.ArgParseResults) errhand.VerboseE
 		return errhand.BuildDError("invalid output format: %s", f).Build()
 	}
 
+	if apr.Contains(PredictConflictsFlag) && strings.ToLower(f) == "sql" {
+		return errhand.BuildDError("invalid Arguments: --%s cannot be used with sql output", PredictConflictsFlag).Build()
+	}
+
 	return nil
 }
 
@@ -210,6 +244,8 @@ func parseDiffDisplaySettings(ctx context.Context, dEnv *env.DoltEnv, apr *argpa
 	}
 
 	displaySettings.skinny = apr.Contains(SkinnyFlag)
+	displaySettings.predictConflicts = apr.Contains(PredictConflictsFlag)
+	displaySettings.ignoreVolatile = apr.Contains(cli.IgnoreVolatileFlag)
 
 	f := apr.GetValueOrDefault(FormatFlag, "tabular")
 	switch strings.ToLower(f) {
@@ -246,6 +282,9 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	if err != nil {
 		return nil, err
 	}
+	if dArgs.predictConflicts && dArgs.otherRoot == nil {
+		return nil, fmt.Errorf("--%s requires a diff against a merge base, given with --%s or as A...B", PredictConflictsFlag, MergeBase)
+	}
 
 	tableSet, err := parseDiffTableSet(ctx, dEnv, dArgs.diffDatasets, tableNames)
 	if err != nil {
@@ -261,6 +300,11 @@ func parseDiffTableSet(ctx context.Context, dEnv *env.DoltEnv, datasets *diffDat
 
 	tableSet := set.NewStrSet(nil)
 
+	tableNames, err := ResolveTablePatterns(ctx, tableNames, datasets.fromRoot, datasets.toRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, tableName := range tableNames {
 		// verify table args exist in at least one root
 		_, ok, err := datasets.fromRoot.GetTable(ctx, tableName)
@@ -415,6 +459,13 @@ func (dArgs *diffArgs) applyMergeBase(ctx context.Context, dEnv *env.DoltEnv, le
 	dArgs.fromRoot = fromRoot
 	dArgs.fromRef = mergeBaseStr
 
+	otherRoot, ok := diff.MaybeResolveRoot(ctx, dEnv.RepoStateReader(), dEnv.DoltDB, leftStr)
+	if !ok {
+		return fmt.Errorf("invalid ref %s", leftStr)
+	}
+	dArgs.otherRoot = otherRoot
+	dArgs.otherRef = leftStr
+
 	return nil
 }
 
@@ -527,6 +578,26 @@ func diffUserTables(ctx context.Context, dEnv *env.DoltEnv, dArgs *diffArgs) err
 		return errhand.BuildDError("error: unable to diff tables").AddCause(err).Build()
 	}
 
+	workingRoot, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: unable to read the working set").AddCause(err).Build()
+	}
+	dArgs.ignoredColumns, err = doltdb.GetIgnoredColumns(ctx, workingRoot)
+	if err != nil {
+		return errhand.BuildDError("error: unable to read %s", doltdb.IgnoreColumnsTableName).AddCause(err).Build()
+	}
+	if dArgs.ignoreVolatile {
+		volatile, err := doltdb.GetVolatileColumns(ctx, workingRoot)
+		if err != nil {
+			return errhand.BuildDError("error: unable to read %s", doltdb.VolatileColumnsTableName).AddCause(err).Build()
+		}
+		dArgs.ignoredColumns = append(dArgs.ignoredColumns, volatile...)
+	}
+	tableDeltas, err = diff.FilterIgnoredColumnChanges(ctx, tableDeltas, dArgs.ignoredColumns)
+	if err != nil {
+		return errhand.BuildDError("error: unable to diff tables").AddCause(err).Build()
+	}
+
 	sqlEng, dbName, err := engine.NewSqlEngineForEnv(ctx, dEnv)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
@@ -748,10 +819,28 @@ func diffRows(
 		toSch = pkSch.Schema
 	}
 
-	unionSch := unionSchemas(fromSch, toSch)
+	// tableName is the name of the table in the diff query
+	tableName := td.ToName
+	if len(tableName) == 0 {
+		tableName = td.FromName
+	}
+	ignoredCols, err := dArgs.ignoredColumns.ColumnsForTable(td.CurName())
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	unionSch := withoutIgnoredColumns(unionSchemas(fromSch, toSch), ignoredCols)
+
+	var predictor *conflictPredictor
+	if dArgs.predictConflicts && diffable && dArgs.diffParts&DataOnlyDiff != 0 {
+		var verr errhand.VerboseError
+		predictor, verr = newConflictPredictor(ctx, sqlEng, td, unionSch, dArgs)
+		if verr != nil {
+			return verr
+		}
+	}
 
 	// We always instantiate a RowWriter in case the diffWriter needs it to close off any work from schema output
-	rowWriter, err := dw.RowWriter(ctx, td, unionSch)
+	rowWriter, err := dw.RowWriter(ctx, td, predictor.withColumn(unionSch))
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
@@ -785,12 +874,7 @@ func diffRows(
 	}
 
 	// do the data diff
-	tableName := td.ToName
-	if len(tableName) == 0 {
-		tableName = td.FromName
-	}
-
-	columns := getColumnNamesString(td.FromSch, td.ToSch)
+	columns := getColumnNamesString(td.FromSch, td.ToSch, ignoredCols)
 	query := fmt.Sprintf("select %s, %s from dolt_diff('%s', '%s', '%s')", columns, "diff_type", dArgs.fromRef, dArgs.toRef, tableName)
 
 	if len(dArgs.where) > 0 {
@@ -829,7 +913,7 @@ func diffRows(
 		}
 
 		// instantiate a new RowWriter with the new schema that only contains the columns with changes
-		rowWriter, err = dw.RowWriter(ctx, td, filteredUnionSch)
+		rowWriter, err = dw.RowWriter(ctx, td, predictor.withColumn(filteredUnionSch))
 		if err != nil {
 			return errhand.VerboseErrorFromError(err)
 		}
@@ -849,7 +933,7 @@ func diffRows(
 		}
 	}
 
-	err = writeDiffResults(ctx, sch, unionSch, rowIter, rowWriter, modifiedColNames, dArgs)
+	err = writeDiffResults(ctx, sch, unionSch, rowIter, rowWriter, modifiedColNames, predictor, dArgs)
 	if err != nil {
 		return errhand.BuildDError("Error running diff query:\n%s", query).AddCause(err).Build()
 	}
@@ -870,17 +954,35 @@ func unionSchemas(s1 sql.Schema, s2 sql.Schema) sql.Schema {
 	return union
 }
 
-func getColumnNamesString(fromSch, toSch schema.Schema) string {
+// withoutIgnoredColumns returns |sch| without the non primary key columns in |ignoredCols|.
+func withoutIgnoredColumns(sch sql.Schema, ignoredCols *set.StrSet) sql.Schema {
+	if ignoredCols.Size() == 0 {
+		return sch
+	}
+	var visible sql.Schema
+	for _, col := range sch {
+		if col.PrimaryKey || !ignoredCols.Contains(col.Name) {
+			visible = append(visible, col)
+		}
+	}
+	return visible
+}
+
+func getColumnNamesString(fromSch, toSch schema.Schema, ignoredCols *set.StrSet) string {
 	var cols []string
 	if fromSch != nil {
 		fromSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-			cols = append(cols, fmt.Sprintf("`from_%s`", col.Name))
+			if col.IsPartOfPK || !ignoredCols.Contains(col.Name) {
+				cols = append(cols, fmt.Sprintf("`from_%s`", col.Name))
+			}
 			return false, nil
 		})
 	}
 	if toSch != nil {
 		toSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-			cols = append(cols, fmt.Sprintf("`to_%s`", col.Name))
+			if col.IsPartOfPK || !ignoredCols.Contains(col.Name) {
+				cols = append(cols, fmt.Sprintf("`to_%s`", col.Name))
+			}
 			return false, nil
 		})
 	}
@@ -894,6 +996,7 @@ func writeDiffResults(
 	iter sql.RowIter,
 	writer diff.SqlRowDiffWriter,
 	modifiedColNames map[string]bool,
+	predictor *conflictPredictor,
 	dArgs *diffArgs,
 ) error {
 	ds, err := diff.NewDiffSplitter(diffQuerySch, targetSch)
@@ -913,6 +1016,11 @@ func writeDiffResults(
 		if err != nil {
 			return err
 		}
+		if oldRow.RowDiff == diff.ModifiedOld && !hasChangedColumns(newRow) {
+			// only ignored columns of the row changed
+			continue
+		}
+		wouldConflict := predictor.wouldConflict(oldRow, newRow)
 
 		if dArgs.skinny {
 			var filteredOldRow, filteredNewRow diff.RowDiff
@@ -936,6 +1044,11 @@ func writeDiffResults(
 			newRow = filteredNewRow
 		}
 
+		if predictor != nil {
+			oldRow = appendPredictedConflict(oldRow, wouldConflict)
+			newRow = appendPredictedConflict(newRow, wouldConflict)
+		}
+
 		// We are guaranteed to have "ModeRow" for writers that do not support combined rows
 		if dArgs.diffMode != diff.ModeRow && oldRow.RowDiff == diff.ModifiedOld && newRow.RowDiff == diff.ModifiedNew {
 			if err = writer.WriteCombinedRow(ctx, oldRow.Row, newRow.Row, dArgs.diffMode); err != nil {
@@ -956,6 +1069,16 @@ func writeDiffResults(
 	}
 }
 
+// hasChangedColumns returns whether any column of |rd| changed.
+func hasChangedColumns(rd diff.RowDiff) bool {
+	for _, changeType := range rd.ColDiffs {
+		if changeType != diff.None {
+			return true
+		}
+	}
+	return false
+}
+
 // getModifiedCols returns a set of the names of columns that are modified, as well as the name of the primary key for a particular row iterator and schema.
 // In the case where rows are added or removed, all columns will be included
 // unionSch refers to a joint schema between the schema before and after any schema changes pertaining to the diff,
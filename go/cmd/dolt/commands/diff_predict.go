@@ -0,0 +1,152 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+)
+
+// conflictPredictor predicts whether the rows of a diff against a merge base would conflict with the changes made
+// since the merge base on the other side of the merge. A row is predicted to conflict if the other side changed a row
+// with the same key, which is the primary key of the row, or all of its values for keyless tables.
+type conflictPredictor struct {
+	// keyIdxs are the indexes of the key columns in the schema of the diff rows
+	keyIdxs []int
+	// keys are the keys of the rows changed on the other side
+	keys map[string]struct{}
+}
+
+// newConflictPredictor returns a conflictPredictor for the diff of the table of |td|, whose rows have the schema
+// |unionSch|, by diffing the table from the merge base to the other revision of |dArgs|.
+func newConflictPredictor(
+	ctx *sql.Context,
+	sqlEng *engine.SqlEngine,
+	td diff.TableDelta,
+	unionSch sql.Schema,
+	dArgs *diffArgs,
+) (*conflictPredictor, errhand.VerboseError) {
+	predictor := &conflictPredictor{keys: make(map[string]struct{})}
+	for i, col := range unionSch {
+		if col.PrimaryKey {
+			predictor.keyIdxs = append(predictor.keyIdxs, i)
+		}
+	}
+	if len(predictor.keyIdxs) == 0 {
+		for i := range unionSch {
+			predictor.keyIdxs = append(predictor.keyIdxs, i)
+		}
+	}
+
+	tableName := td.ToName
+	if len(tableName) == 0 {
+		tableName = td.FromName
+	}
+	inBase, err := dArgs.fromRoot.HasTable(ctx, tableName)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	inOther, err := dArgs.otherRoot.HasTable(ctx, tableName)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+	if !inBase && !inOther {
+		// the other side never had the table, so it changed none of its rows
+		return predictor, nil
+	}
+
+	var fromCols, toCols []string
+	for _, i := range predictor.keyIdxs {
+		fromCols = append(fromCols, fmt.Sprintf("`from_%s`", unionSch[i].Name))
+		toCols = append(toCols, fmt.Sprintf("`to_%s`", unionSch[i].Name))
+	}
+	query := fmt.Sprintf("select %s, %s, diff_type from dolt_diff('%s', '%s', '%s')",
+		strings.Join(fromCols, ","), strings.Join(toCols, ","), dArgs.fromRef, dArgs.otherRef, tableName)
+
+	_, rowIter, err := sqlEng.Query(ctx, query)
+	if err != nil {
+		return nil, errhand.BuildDError("Unable to predict conflicts with %s for table '%s'", dArgs.otherRef, tableName).AddCause(err).Build()
+	}
+	defer rowIter.Close(ctx)
+
+	n := len(predictor.keyIdxs)
+	for {
+		r, err := rowIter.Next(ctx)
+		if err == io.EOF {
+			return predictor, nil
+		} else if err != nil {
+			return nil, errhand.BuildDError("Unable to predict conflicts with %s for table '%s'", dArgs.otherRef, tableName).AddCause(err).Build()
+		}
+		if r[len(r)-1] == "removed" {
+			predictor.keys[predictionKey(r[:n])] = struct{}{}
+		} else {
+			predictor.keys[predictionKey(r[n:2*n])] = struct{}{}
+		}
+	}
+}
+
+// wouldConflict returns whether the row of a diff split into |oldRow| and |newRow| would conflict with the changes on
+// the other side. It's always false for a nil conflictPredictor.
+func (cp *conflictPredictor) wouldConflict(oldRow, newRow diff.RowDiff) bool {
+	if cp == nil {
+		return false
+	}
+	row := newRow.Row
+	if row == nil {
+		row = oldRow.Row
+	}
+	key := make(sql.Row, len(cp.keyIdxs))
+	for i, idx := range cp.keyIdxs {
+		key[i] = row[idx]
+	}
+	_, ok := cp.keys[predictionKey(key)]
+	return ok
+}
+
+// withColumn returns |sch| with the column predicting conflicts, unless |cp| is nil.
+func (cp *conflictPredictor) withColumn(sch sql.Schema) sql.Schema {
+	if cp == nil {
+		return sch
+	}
+	withCol := make(sql.Schema, len(sch), len(sch)+1)
+	copy(withCol, sch)
+	return append(withCol, &sql.Column{Name: predictedConflictColumn, Type: types.Boolean, Nullable: false})
+}
+
+func predictionKey(vals sql.Row) string {
+	return fmt.Sprintf("%v", []interface{}(vals))
+}
+
+// appendPredictedConflict appends the value of the column predicting conflicts to |rd|, if it has a row.
+func appendPredictedConflict(rd diff.RowDiff, wouldConflict bool) diff.RowDiff {
+	if rd.Row == nil {
+		return rd
+	}
+	changeType := diff.None
+	if rd.RowDiff == diff.Added || rd.RowDiff == diff.Removed {
+		changeType = rd.RowDiff
+	}
+	rd.Row = append(rd.Row, wouldConflict)
+	rd.ColDiffs = append(rd.ColDiffs, changeType)
+	return rd
+}
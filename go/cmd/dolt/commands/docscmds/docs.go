@@ -22,4 +22,5 @@ var Commands = cli.NewSubCommandHandler("docs", "Commands for working with Dolt
 	DiffCmd{},
 	PrintCmd{},
 	UploadCmd{},
+	GenerateDictionaryCmd{},
 })
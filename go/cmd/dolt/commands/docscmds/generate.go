@@ -0,0 +1,98 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docscmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var generateDictionaryDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Generates the data dictionary of the database into Dolt Docs",
+	LongDesc: `Renders the data dictionary of the tables at the current commit into the {{.EmphasisLeft}}` + doltdb.DataDictionaryDoc + `{{.EmphasisRight}} doc of the working set. The dictionary lists the columns of every table with their types and comments, the foreign keys of every table, and the graph of the foreign keys between the tables.
+
+To regenerate the dictionary whenever a commit is made, so that every commit holds the dictionary of its own schemas, set the {{.EmphasisLeft}}` + env.DocsDataDictionaryKey + `{{.EmphasisRight}} config to true. When both sides of a merge changed the dictionary, resolve its conflict with either side, and committing the merge regenerates it.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type GenerateDictionaryCmd struct{}
+
+// Name implements cli.Command.
+func (cmd GenerateDictionaryCmd) Name() string {
+	return "generate-dictionary"
+}
+
+// Description implements cli.Command.
+func (cmd GenerateDictionaryCmd) Description() string {
+	return generateDictionaryDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command.
+func (cmd GenerateDictionaryCmd) RequiresRepo() bool {
+	return true
+}
+
+// Docs implements cli.Command.
+func (cmd GenerateDictionaryCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(generateDictionaryDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd GenerateDictionaryCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+// Exec implements cli.Command.
+func (cmd GenerateDictionaryCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, generateDictionaryDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	var verr errhand.VerboseError
+	if err := generateDataDictionary(ctx, dEnv); err != nil {
+		verr = errhand.VerboseErrorFromError(err)
+	}
+
+	return commands.HandleVErrAndExitCode(verr, usage)
+}
+
+func generateDataDictionary(ctx context.Context, dEnv *env.DoltEnv) error {
+	head, err := dEnv.HeadRoot(ctx)
+	if err != nil {
+		return err
+	}
+	dictionary, err := dtables.GenerateDataDictionary(ctx, head)
+	if err != nil {
+		return err
+	}
+
+	eng, dbName, err := engine.NewSqlEngineForEnv(ctx, dEnv)
+	if err != nil {
+		return err
+	}
+
+	return writeDocToTable(ctx, eng, dbName, doltdb.DataDictionaryDoc, dictionary)
+}
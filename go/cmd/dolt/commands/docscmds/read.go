@@ -155,6 +155,7 @@ func writeDocToTable(ctx context.Context, eng *engine.SqlEngine, dbName, docName
 		return err
 	}
 
+	content = strings.ReplaceAll(content, `\`, `\\`)
 	content = strings.ReplaceAll(content, `"`, `\"`)
 	update := fmt.Sprintf(writeDocTemplate, docName, content)
 
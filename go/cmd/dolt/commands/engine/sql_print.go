@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/tabular"
@@ -41,6 +42,7 @@ const (
 	FormatJson
 	FormatNull // used for profiling
 	FormatVertical
+	FormatArrow
 )
 
 type PrintSummaryBehavior byte
@@ -96,6 +98,12 @@ func prettyPrintResultsWithSummary(ctx *sql.Context, resultFormat PrintResultFor
 		wr = nullWriter{}
 	case FormatVertical:
 		wr = newVerticalRowWriter(iohelp.NopWrCloser(cli.CliOut), sqlSch)
+	case FormatArrow:
+		var err error
+		wr, err = arrow.NewArrowSqlWriter(iohelp.NopWrCloser(cli.CliOut), sqlSch)
+		if err != nil {
+			return err
+		}
 	}
 
 	numRows, err := writeResultSet(ctx, rowIter, wr)
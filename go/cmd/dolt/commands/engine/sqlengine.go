@@ -20,6 +20,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	gms "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -29,16 +30,22 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/rowexec"
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"go.opentelemetry.io/otel"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/indexusage"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	dblr "github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/mysql_file_handler"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -68,6 +75,17 @@ type SqlEngineConfig struct {
 	JwksConfig              []JwksConfig
 	ClusterController       *cluster.Controller
 	BinlogReplicaController binlogreplication.BinlogReplicaController
+	// Quotas are the per-database resource quotas to enforce, keyed by database name. Nil enforces no quotas.
+	Quotas map[string]quota.Limits
+	// FetchInterval is how often the remotes of every database are fetched in the background. Zero disables
+	// background fetches.
+	FetchInterval time.Duration
+	// FetchRemotes are the names of the remotes fetched in the background, or empty to fetch every remote.
+	FetchRemotes []string
+	// Backups are the schedules every database is backed up on. Nil disables scheduled backups.
+	Backups []actions.BackupSchedule
+	// Webhooks are the URLs webhooks are sent to when the branches and tags of any database change. Nil sends none.
+	Webhooks []actions.WebhookConfig
 }
 
 // NewSqlEngine returns a SqlEngine
@@ -123,10 +141,113 @@ func NewSqlEngine(
 	}
 	pro = pro.WithRemoteDialer(mrEnv.RemoteDialProvider())
 
+	var quotas *quota.Controller
+	if config.Quotas != nil {
+		quotas = quota.NewController(config.Quotas)
+		pro = pro.WithQuotas(quotas)
+		// disk usage is measured periodically rather than on every write
+		err = bThreads.Add("quota monitor", func(ctx context.Context) {
+			quota.RunMonitor(ctx, quota.MonitorInterval, quotas, pro.DatabaseFileSystems)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.FetchInterval > 0 {
+		scheduler := actions.NewFetchScheduler(config.FetchInterval, config.FetchRemotes, pro.FetchTargets,
+			func(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote) (*doltdb.DoltDB, error) {
+				return pro.GetRemoteDB(ctx, nbf, r, false)
+			})
+		if quotas != nil {
+			// background fetches count against the background job quota of the database they fetch into
+			scheduler = scheduler.WithJobHook(func(db string) (func(), error) {
+				done, _, err := quotas.StartBackgroundJob(db)
+				return done, err
+			})
+		}
+		pro = pro.WithFetchScheduler(scheduler)
+		err = bThreads.Add("remote fetch scheduler", scheduler.Run)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.Backups) > 0 {
+		dialer := mrEnv.RemoteDialProvider()
+		scheduler, err := actions.NewBackupScheduler(config.Backups, pro.FetchTargets,
+			func(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote) (*doltdb.DoltDB, error) {
+				// backups are created the first time they're synced to
+				if err := r.Prepare(ctx, nbf, dialer); err != nil {
+					return nil, err
+				}
+				return r.GetRemoteDBWithoutCaching(ctx, nbf, dialer)
+			})
+		if err != nil {
+			return nil, err
+		}
+		if quotas != nil {
+			// scheduled backups count against the background job quota of the database they back up
+			scheduler = scheduler.WithJobHook(func(db string) (func(), error) {
+				done, _, err := quotas.StartBackgroundJob(db)
+				return done, err
+			})
+		}
+		pro = pro.WithBackupScheduler(scheduler)
+		err = bThreads.Add("backup scheduler", scheduler.Run)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	config.ClusterController.RegisterStoredProcedures(pro)
 	pro.InitDatabaseHook = cluster.NewInitDatabaseHook(config.ClusterController, bThreads, pro.InitDatabaseHook)
+
+	if len(config.Webhooks) > 0 {
+		dispatcher, err := actions.NewWebhookDispatcher(config.Webhooks)
+		if err != nil {
+			return nil, err
+		}
+		addWebhookHook := func(ctx context.Context, name string, dEnv *env.DoltEnv) error {
+			hook, err := dispatcher.CommitHook(ctx, name, dEnv.DoltDB, dEnv.FS)
+			if err != nil {
+				return err
+			}
+			dEnv.DoltDB.PrependCommitHook(ctx, hook)
+			return nil
+		}
+		err = mrEnv.Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+			if dEnv.DoltDB == nil {
+				return false, nil
+			}
+			return false, addWebhookHook(ctx, name, dEnv)
+		})
+		if err != nil {
+			return nil, err
+		}
+		// databases created while the server runs send webhooks too
+		initDatabaseHook := pro.InitDatabaseHook
+		pro.InitDatabaseHook = func(ctx *sql.Context, pro dsqle.DoltDatabaseProvider, name string, dEnv *env.DoltEnv) error {
+			if err := initDatabaseHook(ctx, pro, name, dEnv); err != nil {
+				return err
+			}
+			return addWebhookHook(ctx, name, dEnv)
+		}
+		err = bThreads.Add("webhook dispatcher", dispatcher.Run)
+		if err != nil {
+			return nil, err
+		}
+	}
 	config.ClusterController.ManageDatabaseProvider(pro)
 
+	// index usage is persisted periodically while the engine runs, and once more when it's closed
+	err = bThreads.Add("index usage flusher", func(ctx context.Context) {
+		indexusage.RunFlusher(ctx, indexusage.FlushInterval, pro.IndexUsageTrackers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Load in privileges from file, if it exists
 	persister := mysql_file_handler.NewPersister(config.PrivFilePath, config.DoltCfgDirPath)
 	data, err := persister.LoadData()
@@ -319,8 +440,11 @@ func configureBinlogReplicaController(config *SqlEngineConfig, engine *gms.Engin
 // sqlContextFactory returns a contextFactory that creates a new sql.Context with the initial database provided
 func sqlContextFactory() contextFactory {
 	return func(ctx context.Context, session sql.Session) (*sql.Context, error) {
-		sqlCtx := sql.NewContext(ctx, sql.WithSession(session))
-		return sqlCtx, nil
+		opts := []sql.ContextOption{sql.WithSession(session)}
+		if tracing.Enabled() {
+			opts = append(opts, sql.WithTracer(otel.Tracer("github.com/dolthub/dolt/go/cmd/dolt/commands/engine")))
+		}
+		return sql.NewContext(ctx, opts...), nil
 	}
 }
 
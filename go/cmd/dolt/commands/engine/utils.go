@@ -79,16 +79,22 @@ func CollectDBs(ctx context.Context, mrEnv *env.MultiRepoEnv, useBulkEditor bool
 func GetCommitHooks(ctx context.Context, dEnv *env.DoltEnv) ([]doltdb.CommitHook, error) {
 	postCommitHooks := make([]doltdb.CommitHook, 0)
 
-	if hook, err := getPushOnWriteHook(ctx, dEnv); err != nil {
-		path, _ := dEnv.FS.Abs(".")
-		err = fmt.Errorf("failure loading hook for database at %s; %w", path, err)
-		if dsess.IgnoreReplicationErrors() {
-			postCommitHooks = append(postCommitHooks, doltdb.NewLogHook([]byte(err.Error()+"\n")))
+	targets, err := dsess.GetReplicationTargets()
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		if hook, err := getPushOnWriteHook(ctx, dEnv, target.Remote); err != nil {
+			path, _ := dEnv.FS.Abs(".")
+			err = fmt.Errorf("failure loading hook for database at %s; %w", path, err)
+			if dsess.IgnoreReplicationErrors() {
+				postCommitHooks = append(postCommitHooks, doltdb.NewLogHook([]byte(err.Error()+"\n")))
+			} else {
+				return nil, err
+			}
 		} else {
-			return nil, err
+			postCommitHooks = append(postCommitHooks, hook)
 		}
-	} else if hook != nil {
-		postCommitHooks = append(postCommitHooks, hook)
 	}
 
 	return postCommitHooks, nil
@@ -140,19 +146,7 @@ func newReplicaDatabase(ctx context.Context, name string, remoteName string, dEn
 	return rrd, nil
 }
 
-func getPushOnWriteHook(ctx context.Context, dEnv *env.DoltEnv) (*doltdb.PushOnWriteHook, error) {
-	_, val, ok := sql.SystemVariables.GetGlobal(dsess.ReplicateToRemote)
-	if !ok {
-		return nil, sql.ErrUnknownSystemVariable.New(dsess.ReplicateToRemote)
-	} else if val == "" {
-		return nil, nil
-	}
-
-	remoteName, ok := val.(string)
-	if !ok {
-		return nil, sql.ErrInvalidSystemVariableValue.New(val)
-	}
-
+func getPushOnWriteHook(ctx context.Context, dEnv *env.DoltEnv, remoteName string) (*doltdb.PushOnWriteHook, error) {
 	remotes, err := dEnv.GetRemotes()
 	if err != nil {
 		return nil, err
@@ -167,10 +161,13 @@ func getPushOnWriteHook(ctx context.Context, dEnv *env.DoltEnv) (*doltdb.PushOnW
 	if err != nil {
 		return nil, err
 	}
+	if err = ddb.SetReplicationEpoch(ctx, dsess.GetReplicationEpoch()); err != nil {
+		return nil, err
+	}
 	tmpDir, err := dEnv.TempTableFilesDir()
 	if err != nil {
 		return nil, err
 	}
-	pushHook := doltdb.NewPushOnWriteHook(ddb, tmpDir)
+	pushHook := doltdb.NewPushOnWriteHook(remoteName, ddb, tmpDir)
 	return pushHook, nil
 }
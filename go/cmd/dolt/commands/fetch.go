@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"time"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
@@ -25,6 +26,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 var fetchDocs = cli.CommandDocumentationContent{
@@ -34,10 +37,18 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+A refspec is a branch of the remote, or {{.LessThan}}src{{.GreaterThan}}:{{.LessThan}}dst{{.GreaterThan}} to fetch the branch {{.LessThan}}src{{.GreaterThan}} of the remote into the remote-tracking branch {{.LessThan}}dst{{.GreaterThan}}. Both sides may be patterns with a single {{.EmphasisLeft}}*{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}refs/heads/release/*:refs/remotes/origin/release/*{{.EmphasisRight}}. A negative refspec prefixed with {{.EmphasisLeft}}^{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}^refs/heads/wip/*{{.EmphasisRight}}, excludes the branches it matches; given only negative refspecs, the fetch_specs of the remote are fetched without the branches they exclude. The fetch_specs of a remote are set with {{.EmphasisLeft}}dolt remote set-fetch{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--all{{.EmphasisRight}}, every remote is fetched using its fetch_specs. Adding {{.EmphasisLeft}}--parallel{{.EmphasisRight}} fetches all remotes at the same time, and chunks reachable from more than one remote are only downloaded once. Progress is not displayed for parallel fetches.
+
+With {{.EmphasisLeft}}--daemon{{.EmphasisRight}}, the remote, or every remote with {{.EmphasisLeft}}--all{{.EmphasisRight}}, is fetched using its fetch_specs every {{.EmphasisLeft}}--interval{{.EmphasisRight}} seconds until the command is interrupted. A failed fetch is reported and retried at the next interval. To fetch in the background of a running sql-server, configure {{.EmphasisLeft}}remote_fetch{{.EmphasisRight}} in its config file instead.
 `,
 
 	Synopsis: []string{
 		"[{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"--all [--parallel]",
+		"--daemon [--interval {{.LessThan}}seconds{{.GreaterThan}}] [--all | {{.LessThan}}remote{{.GreaterThan}}]",
 	},
 }
 
@@ -73,9 +84,17 @@ func (cmd FetchCmd) Exec(ctx context.Context, commandStr string, args []string,
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, fetchDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
-	r, refSpecs, err := env.NewFetchOpts(apr.Args, dEnv.RepoStateReader())
-	if err != nil {
-		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	if apr.Contains(cli.ParallelFlag) && !apr.Contains(cli.AllFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s requires --%s", cli.ParallelFlag, cli.AllFlag).SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(cli.AllFlag) && apr.NArg() > 0 {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s does not take a remote or refspecs", cli.AllFlag).SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(cli.DaemonFlag) && (apr.NArg() > 1 || apr.Contains(cli.ParallelFlag)) {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s does not take refspecs or --%s", cli.DaemonFlag, cli.ParallelFlag).SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(cli.IntervalParam) && !apr.Contains(cli.DaemonFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s requires --%s", cli.IntervalParam, cli.DaemonFlag).SetPrintUsage().Build(), usage)
 	}
 
 	var verr errhand.VerboseError
@@ -84,14 +103,128 @@ func (cmd FetchCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
+	var pullOpts []pull.Option
+	if apr.Contains(cli.VerifyFlag) {
+		pullOpts = append(pullOpts, pull.WithChunkVerification())
+	}
+	if apr.Contains(cli.FsckFlag) {
+		pullOpts = append(pullOpts, pull.WithReachabilityCheck())
+	}
+	if apr.Contains(cli.DeltaFlag) {
+		pullOpts = append(pullOpts, pull.WithDeltaTransfer())
+	}
+	if keyPath, ok := apr.GetValue(cli.TrustedKeyParam); ok {
+		key, err := actions.LoadTrustedKey(dEnv.FS, keyPath)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		pullOpts = append(pullOpts, pull.WithTrustedKey(key))
+	}
+
+	if apr.Contains(cli.DaemonFlag) {
+		return HandleVErrAndExitCode(fetchDaemon(ctx, dEnv, apr, pullOpts), usage)
+	}
+	if apr.Contains(cli.AllFlag) {
+		return HandleVErrAndExitCode(fetchAll(ctx, dEnv, apr, pullOpts), usage)
+	}
+
+	r, refSpecs, err := env.NewFetchOpts(apr.Args, dEnv.RepoStateReader())
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
 	srcDB, err := r.GetRemoteDBWithoutCaching(ctx, dEnv.DbData().Ddb.ValueReadWriter().Format(), dEnv)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	err = actions.FetchRefSpecs(ctx, dEnv.DbData(), srcDB, refSpecs, r, ref.UpdateMode{Force: true}, buildProgStarter(downloadLanguage), stopProgFuncs)
+	err = actions.FetchRefSpecs(ctx, dEnv.DbData(), srcDB, refSpecs, r, ref.UpdateMode{Force: true}, progStarterForArgs(apr, downloadLanguage), stopProgFuncs, pullOpts...)
 	if err != nil && err != doltdb.ErrUpToDate {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 	return HandleVErrAndExitCode(nil, usage)
 }
+
+// fetchAll fetches every remote of |dEnv| with |pullOpts|, concurrently if --parallel was given.
+func fetchAll(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, pullOpts []pull.Option) errhand.VerboseError {
+	remotes, refSpecs, err := env.NewFetchAllOpts(dEnv.RepoStateReader())
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	fetches := make([]actions.RemoteFetch, len(remotes))
+	for i, r := range remotes {
+		srcDB, err := r.GetRemoteDBWithoutCaching(ctx, dEnv.DbData().Ddb.ValueReadWriter().Format(), dEnv)
+		if err != nil {
+			return errhand.BuildDError("error: failed to get remote db for '%s'", r.Name).AddCause(err).Build()
+		}
+		fetches[i] = actions.RemoteFetch{Remote: r, SrcDB: srcDB, RefSpecs: refSpecs[i]}
+	}
+
+	parallel := apr.Contains(cli.ParallelFlag)
+	progStarter := progStarterForArgs(apr, downloadLanguage)
+	if parallel {
+		// progress from concurrent fetches can't be told apart
+		progStarter = buildQuietProgStarter()
+	}
+
+	err = actions.FetchRemotes(ctx, dEnv.DbData(), fetches, ref.UpdateMode{Force: true}, parallel, progStarter, stopProgFuncs, pullOpts...)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	return nil
+}
+
+// defaultFetchDaemonInterval is how often fetch --daemon fetches when no --interval is given.
+const defaultFetchDaemonInterval = 5 * time.Minute
+
+// fetchDaemon fetches the remote given in |apr|, or every remote with --all, with |pullOpts| every --interval until
+// |ctx| is done.
+func fetchDaemon(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, pullOpts []pull.Option) errhand.VerboseError {
+	interval := defaultFetchDaemonInterval
+	if secs, ok := apr.GetInt(cli.IntervalParam); ok {
+		if secs <= 0 {
+			return errhand.BuildDError("error: --%s must be a positive number of seconds", cli.IntervalParam).SetPrintUsage().Build()
+		}
+		interval = time.Duration(secs) * time.Second
+	}
+
+	var remotes []string
+	if !apr.Contains(cli.AllFlag) {
+		r, _, err := env.NewFetchOpts(apr.Args, dEnv.RepoStateReader())
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		remotes = []string{r.Name}
+	} else if _, _, err := env.NewFetchAllOpts(dEnv.RepoStateReader()); err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	targets := func() []actions.FetchTarget {
+		return []actions.FetchTarget{{DbData: dEnv.DbData()}}
+	}
+	remoteDB := func(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote) (*doltdb.DoltDB, error) {
+		return r.GetRemoteDBWithoutCaching(ctx, nbf, dEnv)
+	}
+	scheduler := actions.NewFetchScheduler(interval, remotes, targets, remoteDB).WithPullOptions(pullOpts...)
+
+	cli.Printf("fetching every %s, press Ctrl+C to stop\n", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		scheduler.FetchAll(ctx)
+		for _, s := range scheduler.Status("") {
+			if s.LastError != "" {
+				cli.PrintErrf("%s failed to fetch from '%s': %s\n", s.LastAttempt.Format(time.RFC3339), s.Remote, s.LastError)
+			} else {
+				cli.Printf("%s fetched from '%s' in %s\n", s.LastAttempt.Format(time.RFC3339), s.Remote, s.LastDuration.Round(time.Millisecond))
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
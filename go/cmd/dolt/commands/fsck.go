@@ -0,0 +1,113 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/fsck"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	fsckQuickFlag   = "quick"
+	fsckAllRefsFlag = "all-refs"
+)
+
+var fsckDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Checks the repository for storage inconsistencies.",
+	LongDesc: `Checks the working, staged and head root values of every branch for dangling chunks, secondary indexes whose contents don't match their tables, child rows which don't reference a row of the parent table of their foreign key, and unresolved constraint violations. Every problem found is reported, and the command exits with a non-zero status if there were any.
+
+If the {{.EmphasisLeft}}--quick{{.EmphasisRight}} flag is supplied, secondary indexes are not checked against the rows of their tables, nor foreign keys against the rows of their child tables, which reads every row of the repository.
+
+If the {{.EmphasisLeft}}--all-refs{{.EmphasisRight}} flag is supplied, the commits of tags, remote refs and workspaces are checked as well as branches.`,
+	Synopsis: []string{
+		"[--quick] [--all-refs]",
+	},
+}
+
+type FsckCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FsckCmd) Name() string {
+	return "fsck"
+}
+
+// Description returns a description of the command
+func (cmd FsckCmd) Description() string {
+	return fsckDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd FsckCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd FsckCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(fsckDocs, ap)
+}
+
+func (cmd FsckCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsFlag(fsckQuickFlag, "", "Skips checking secondary indexes against the rows of their tables, and foreign keys against the rows of their child tables.")
+	ap.SupportsFlag(fsckAllRefsFlag, "", "Checks the commits of tags, remote refs and workspaces as well as branches.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd FsckCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, fsckDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	p := cli.NewEphemeralPrinter()
+	opts := fsck.Options{
+		Quick:   apr.Contains(fsckQuickFlag),
+		AllRefs: apr.Contains(fsckAllRefsFlag),
+		Progress: func(prog fsck.Progress) {
+			p.Printf("Checked %d of %d tables.", prog.TablesChecked, prog.TablesTotal)
+			p.Display()
+		},
+	}
+	// tables are checked concurrently, problems are printed in order once the check is done
+	var problems []string
+	err := fsck.Check(ctx, dEnv.DoltDB, opts, func(p fsck.Problem) {
+		problems = append(problems, p.String())
+	})
+	p.Display()
+	if err != nil {
+		verr := errhand.BuildDError("error: failed to check the repository").AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		for _, problem := range problems {
+			cli.Println(color.RedString(problem))
+		}
+		cli.PrintErrln(color.RedString("%d problems found", len(problems)))
+		return 1
+	}
+	cli.Println("No problems found.")
+	return 0
+}
@@ -36,9 +36,13 @@ var gcDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Cleans up unreferenced data from the repository.",
 	LongDesc: `Searches the repository for data that is no longer referenced and no longer needed.
 
-If the {{.EmphasisLeft}}--shallow{{.EmphasisRight}} flag is supplied, a faster but less thorough garbage collection will be performed.`,
+If the {{.EmphasisLeft}}--shallow{{.EmphasisRight}} flag is supplied, a faster but less thorough garbage collection will be performed.
+
+If the {{.EmphasisLeft}}--new-gen{{.EmphasisRight}} flag is supplied, only the table files written since the last full garbage collection are collected. Its running time and the time writes are blocked for depend on the amount of data written since then, rather than on the size of the database, so it can be run more often than a full garbage collection on large databases.
+
+Garbage can't be collected one table at a time. Tables are stored as chunks shared by every table, commit and branch with the same content, so a chunk can only be collected once nothing in the repository references it, which takes walking all of its history.`,
 	Synopsis: []string{
-		"[--shallow]",
+		"[--shallow | --new-gen]",
 	},
 }
 
@@ -92,6 +96,11 @@ func (cmd GarbageCollectionCmd) Exec(ctx context.Context, commandStr string, arg
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(env.ErrActiveServerLock.New(dEnv.LockFile())), help)
 	}
 
+	if apr.Contains(cli.ShallowFlag) && apr.Contains(cli.NewGenFlag) {
+		verr = errhand.BuildDError("--%s and --%s are mutually exclusive", cli.ShallowFlag, cli.NewGenFlag).SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
 	var err error
 	if apr.Contains(cli.ShallowFlag) {
 		err = dEnv.DoltDB.ShallowGC(ctx)
@@ -110,10 +119,16 @@ func (cmd GarbageCollectionCmd) Exec(ctx context.Context, commandStr string, arg
 			return HandleVErrAndExitCode(verr, usage)
 		}
 
-		err = dEnv.DoltDB.GC(ctx, nil)
+		if apr.Contains(cli.NewGenFlag) {
+			err = dEnv.DoltDB.NewGenGC(ctx, nil)
+		} else {
+			err = dEnv.DoltDB.GC(ctx, nil)
+		}
 		if err != nil {
 			if errors.Is(err, chunks.ErrNothingToCollect) {
 				cli.PrintErrln(color.YellowString("Nothing to collect."))
+			} else if err == chunks.ErrUnsupportedOperation {
+				verr = errhand.BuildDError("this database does not support new generation garbage collection").Build()
 			} else {
 				verr = errhand.BuildDError("an error occurred during garbage collection").AddCause(err).Build()
 			}
@@ -108,7 +108,11 @@ func (cmd LsCmd) Exec(ctx context.Context, commandStr string, args []string, dEn
 				output = append(output, fmt.Sprintf("%s:", tableName))
 			}
 			for _, index := range sch.Indexes().AllIndexes() {
-				output = append(output, fmt.Sprintf("    %s(%s)", index.Name(), strings.Join(index.ColumnNames(), ", ")))
+				line := fmt.Sprintf("    %s(%s)", index.Name(), strings.Join(index.ColumnNames(), ", "))
+				if pred := index.Predicate(); len(pred) > 0 {
+					line += " WHERE " + pred.String(sch.GetAllCols())
+				}
+				output = append(output, line)
 			}
 		}
 	}
@@ -29,11 +29,11 @@ import (
 var rebuildDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Rebuild the contents of an index`,
 	LongDesc: IndexCmdWarning + `
-This command will clear the contents that are currently in an index, and rebuild them from the working set. If the index were to ever get out of sync (which is a bug), this would allow for a temporary fix to get the index functioning properly again, while the root cause is being debugged.
+This command will clear the contents that are currently in an index, and rebuild them from the working set. If no index is given, every index of the table is rebuilt. If the index were to ever get out of sync (which is a bug), this would allow for a temporary fix to get the index functioning properly again, while the root cause is being debugged.
 
 In most cases, running this command should not have any overall effect, as the rebuilt index will be the same as the current index.`,
 	Synopsis: []string{
-		`{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}index{{.GreaterThan}}`,
+		`{{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}index{{.GreaterThan}}]`,
 	},
 }
 
@@ -54,7 +54,7 @@ func (cmd RebuildCmd) Docs() *cli.CommandDocumentation {
 func (cmd RebuildCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table that the given index belongs to."})
-	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"index", "The name of the index to rebuild."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"index", "The name of the index to rebuild. Every index of the table is rebuilt if none is given."})
 	return ap
 }
 
@@ -66,8 +66,6 @@ func (cmd RebuildCmd) Exec(ctx context.Context, commandStr string, args []string
 	if apr.NArg() == 0 {
 		usage()
 		return 0
-	} else if apr.NArg() != 2 {
-		return HandleErr(errhand.BuildDError("Both the table and index names must be provided.").Build(), usage)
 	}
 
 	if dEnv.IsLocked() {
@@ -80,7 +78,7 @@ func (cmd RebuildCmd) Exec(ctx context.Context, commandStr string, args []string
 	}
 
 	tableName := apr.Arg(0)
-	indexName := apr.Arg(1)
+	indexNames := apr.Args[1:]
 
 	table, ok, err := working.GetTable(ctx, tableName)
 	if err != nil {
@@ -94,21 +92,9 @@ func (cmd RebuildCmd) Exec(ctx context.Context, commandStr string, args []string
 		return HandleErr(errhand.BuildDError("error: ").AddCause(err).Build(), nil)
 	}
 	opts := editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir}
-	sch, err := table.GetSchema(ctx)
+	updatedTable, err := creation.RebuildSecondaryIndexes(ctx, table, indexNames, opts)
 	if err != nil {
-		return HandleErr(errhand.BuildDError("could not get table schema").AddCause(err).Build(), nil)
-	}
-	idxSch := sch.Indexes().GetByName(indexName)
-	if idxSch == nil {
-		return HandleErr(errhand.BuildDError("the index `%s` does not exist on table `%s`", indexName, tableName).Build(), nil)
-	}
-	indexRowData, err := creation.BuildSecondaryIndex(ctx, table, idxSch, opts)
-	if err != nil {
-		return HandleErr(errhand.BuildDError("Unable to rebuild index `%s` on table `%s`.", indexName, tableName).AddCause(err).Build(), nil)
-	}
-	updatedTable, err := table.SetIndexRows(ctx, indexName, indexRowData)
-	if err != nil {
-		return HandleErr(errhand.BuildDError("Unable to set rebuilt index.").AddCause(err).Build(), nil)
+		return HandleErr(errhand.BuildDError("Unable to rebuild the indexes of table `%s`.", tableName).AddCause(err).Build(), nil)
 	}
 	working, err = working.PutTable(ctx, tableName, updatedTable)
 	if err != nil {
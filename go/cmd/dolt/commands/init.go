@@ -17,13 +17,17 @@ package commands
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/types"
@@ -36,6 +40,7 @@ const (
 	newFormatFlag       = "new-format"
 	oldFormatFlag       = "old-format"
 	funHashFlag         = "fun"
+	templateParamName   = "template"
 )
 
 var initDocs = cli.CommandDocumentationContent{
@@ -43,10 +48,12 @@ var initDocs = cli.CommandDocumentationContent{
 	LongDesc: `This command creates an empty Dolt data repository in the current directory.
 
 Running dolt init in an already initialized directory will fail.
+
+If the {{.EmphasisLeft}}--template{{.EmphasisRight}} option is supplied, the tables of the default branch of the template database are copied to the new database and committed after its initial commit. The template is the path of a directory containing a dolt database, or the url of a remote, and its tables can include schemas, saved queries in {{.EmphasisLeft}}dolt_query_catalog{{.EmphasisRight}}, ignore patterns in {{.EmphasisLeft}}dolt_ignore{{.EmphasisRight}} and any other system table, so that new databases start out the same way. The history of the template isn't copied.
 `,
 
 	Synopsis: []string{
-		"",
+		"[--template {{.LessThan}}path-or-url{{.GreaterThan}}]",
 	},
 }
 
@@ -81,6 +88,7 @@ func (cmd InitCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(initBranchParamName, "b", "branch", fmt.Sprintf("The branch name used to initialize this database. If not provided will be taken from {{.EmphasisLeft}}%s{{.EmphasisRight}} in the global config. If unset, the default initialized branch will be named '%s'.", env.InitBranchName, env.DefaultInitBranch))
 	ap.SupportsFlag(newFormatFlag, "", fmt.Sprintf("Specify this flag to use the new storage format (%s).", types.Format_DOLT.VersionString()))
 	ap.SupportsFlag(oldFormatFlag, "", fmt.Sprintf("Specify this flag to use the old storage format (%s).", types.Format_LD_1.VersionString()))
+	ap.SupportsString(templateParamName, "", "path-or-url", "The dolt database directory or remote url of a template whose tables are copied to the new database.")
 	ap.SupportsFlag(funHashFlag, "", "") // This flag is an easter egg. We can't currently prevent it from being listed in the help, but the description is deliberately left blank.
 	return ap
 }
@@ -154,6 +162,20 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		}
 	}
 
+	var template *doltdb.DoltDB
+	templateSrc, useTemplate := apr.GetValue(templateParamName)
+	if useTemplate {
+		var verr errhand.VerboseError
+		template, verr = openTemplate(ctx, dEnv, templateSrc)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+		// unless a format is asked for, the new database uses the template's
+		if !apr.Contains(newFormatFlag) && !apr.Contains(oldFormatFlag) {
+			types.Format_Default = template.Format()
+		}
+	}
+
 	requiresFunHash := apr.Contains(funHashFlag)
 	commitMetaGenerator := datas.MakeCommitMetaGenerator(name, email, t)
 	if requiresFunHash {
@@ -166,6 +188,17 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return 1
 	}
 
+	if useTemplate {
+		meta, err := datas.NewCommitMetaWithUserTS(name, email, fmt.Sprintf("Initialize data repository from template %s", templateSrc), t)
+		if err == nil {
+			err = actions.ApplyTemplate(ctx, dEnv, template, meta)
+		}
+		if err != nil {
+			cli.PrintErrln(color.RedString("Failed to copy the tables of template %s. %s", templateSrc, err.Error()))
+			return 1
+		}
+	}
+
 	configuration := make(map[string]string)
 	if apr.Contains(usernameParamName) {
 		configuration[env.UserNameKey] = name
@@ -184,3 +217,30 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	cli.Println(color.CyanString("Successfully initialized dolt data repository."))
 	return 0
 }
+
+// openTemplate opens the template database |src|, which is either the path of a directory containing a dolt database
+// or the url of a remote.
+func openTemplate(ctx context.Context, dEnv *env.DoltEnv, src string) (*doltdb.DoltDB, errhand.VerboseError) {
+	if exists, isDir := dEnv.FS.Exists(filepath.Join(src, dbfactory.DoltDir)); exists && isDir {
+		fs, err := dEnv.FS.WithWorkingDir(src)
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to open template %s", src).AddCause(err).Build()
+		}
+		ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.LocalDirDoltDB, fs)
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to open template %s", src).AddCause(err).Build()
+		}
+		return ddb, nil
+	}
+
+	_, remoteUrl, err := env.GetAbsRemoteUrl(dEnv.FS, dEnv.Config, src)
+	if err != nil {
+		return nil, errhand.BuildDError("error: '%s' is not a dolt database directory or a valid remote url.", src).Build()
+	}
+	r := env.NewRemote("template", remoteUrl, nil)
+	ddb, err := r.GetRemoteDB(ctx, types.Format_Default, dEnv)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to open template %s", src).AddCause(err).Build()
+	}
+	return ddb, nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/indexusage"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	unusedIndexesFlag      = "unused-indexes"
+	virtualForeignKeysFlag = "virtual-foreign-keys"
+)
+
+var lintDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Check the database for common problems",
+	LongDesc: `Runs checks against the working set of the current branch and prints suggestions for improving it. With no options every check is run.
+
+If the {{.EmphasisLeft}}--unused-indexes{{.EmphasisRight}} flag is supplied, secondary indexes that have never been read by a query on the current branch are listed as candidates for removal. Every index adds to the cost of writing to its table, so dropping indexes nothing reads reduces write amplification. Index reads are counted by {{.EmphasisLeft}}dolt sql{{.EmphasisRight}} and {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}}, and can also be queried in the {{.EmphasisLeft}}dolt_index_usage{{.EmphasisRight}} system table. A running server persists its counts periodically, so its most recent reads may not be reflected yet.
+
+If the {{.EmphasisLeft}}--virtual-foreign-keys{{.EmphasisRight}} flag is supplied, the rows of the working set which don't reference a row of the parent table of a virtual foreign key are counted. Virtual foreign keys are created with {{.EmphasisLeft}}dolt_create_virtual_foreign_key(){{.EmphasisRight}} and are never enforced, so this is the only check of the relationships they declare.
+`,
+
+	Synopsis: []string{
+		"[--unused-indexes] [--virtual-foreign-keys]",
+	},
+}
+
+type LintCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd LintCmd) Name() string {
+	return "lint"
+}
+
+// Description returns a description of the command
+func (cmd LintCmd) Description() string {
+	return "Check the database for common problems."
+}
+
+func (cmd LintCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(lintDocs, ap)
+}
+
+func (cmd LintCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsFlag(unusedIndexesFlag, "", "list secondary indexes that have never been read on the current branch")
+	ap.SupportsFlag(virtualForeignKeysFlag, "", "count the rows which don't satisfy the virtual foreign keys of the working set")
+	return ap
+}
+
+// Exec executes the command
+func (cmd LintCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, lintDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	// with no checks selected, every check is run
+	runAll := !apr.Contains(unusedIndexesFlag) && !apr.Contains(virtualForeignKeysFlag)
+
+	var verr errhand.VerboseError
+	if runAll || apr.Contains(unusedIndexesFlag) {
+		verr = lintUnusedIndexes(ctx, dEnv)
+	}
+	if verr == nil && (runAll || apr.Contains(virtualForeignKeysFlag)) {
+		verr = lintVirtualForeignKeys(ctx, dEnv)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+// lintUnusedIndexes prints the secondary indexes of the working set that have never been read on the current branch.
+func lintUnusedIndexes(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	tracker, err := indexusage.Load(dEnv.FS)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read index usage").AddCause(err).Build()
+	}
+
+	root, verr := GetWorkingWithVErr(dEnv)
+	if verr != nil {
+		return verr
+	}
+
+	branch := dEnv.RepoStateReader().CWBHeadRef().GetPath()
+	stats, err := tracker.Report(ctx, branch, root)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read indexes").AddCause(err).Build()
+	}
+
+	var unused []indexusage.Stat
+	for _, s := range stats {
+		if s.Reads == 0 {
+			unused = append(unused, s)
+		}
+	}
+
+	if len(unused) == 0 {
+		cli.Printf("No unused indexes on branch '%s'.\n", branch)
+		return nil
+	}
+
+	cli.Printf("Indexes never read on branch '%s':\n", branch)
+	for _, s := range unused {
+		cli.Printf("\t%s.%s\n", s.Table, s.Index)
+	}
+	cli.Println()
+	cli.Println("Every index adds to the cost of writes to its table. If these indexes are not needed, consider dropping them:")
+	for _, s := range unused {
+		cli.Printf("\tALTER TABLE %s DROP INDEX %s;\n", sql.QuoteIdentifier(s.Table), sql.QuoteIdentifier(s.Index))
+	}
+
+	return nil
+}
+
+// lintVirtualForeignKeys prints the number of rows of the working set which violate each virtual foreign key: the rows
+// with no NULL in the foreign key columns and no matching row in the parent table.
+func lintVirtualForeignKeys(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	root, verr := GetWorkingWithVErr(dEnv)
+	if verr != nil {
+		return verr
+	}
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read foreign keys").AddCause(err).Build()
+	}
+	var virtualFks []doltdb.ForeignKey
+	for _, fk := range fkc.AllKeys() {
+		if fk.NotEnforced {
+			virtualFks = append(virtualFks, fk)
+		}
+	}
+	if len(virtualFks) == 0 {
+		return nil
+	}
+
+	eng, dbName, err := engine.NewSqlEngineForEnv(ctx, dEnv)
+	if err != nil {
+		return errhand.BuildDError("error: failed to build sql engine").AddCause(err).Build()
+	}
+	defer eng.Close()
+	sqlCtx, err := eng.NewLocalContext(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: failed to build sql context").AddCause(err).Build()
+	}
+	sqlCtx.SetCurrentDatabase(dbName)
+
+	branch := dEnv.RepoStateReader().CWBHeadRef().GetPath()
+	violated := false
+	for _, fk := range virtualFks {
+		count, err := countVirtualForeignKeyViolations(sqlCtx, eng, root, fk)
+		if err != nil {
+			return errhand.BuildDError("error: failed to check virtual foreign key %s", fk.Name).AddCause(err).Build()
+		}
+		if count == 0 {
+			continue
+		}
+		if !violated {
+			cli.Printf("Virtual foreign keys violated on branch '%s':\n", branch)
+			violated = true
+		}
+		cli.Printf("\t%s.%s: %d rows without a parent row in %s\n", fk.TableName, fk.Name, count, fk.ReferencedTableName)
+	}
+	if !violated {
+		cli.Printf("No virtual foreign keys violated on branch '%s'.\n", branch)
+	}
+
+	return nil
+}
+
+// countVirtualForeignKeyViolations returns the number of rows of the child table of |fk| which reference no row of
+// its parent table.
+func countVirtualForeignKeyViolations(ctx *sql.Context, eng *engine.SqlEngine, root *doltdb.RootValue, fk doltdb.ForeignKey) (int64, error) {
+	cols, err := columnNamesForTags(ctx, root, fk.TableName, fk.TableColumns)
+	if err != nil {
+		return 0, err
+	}
+	parentCols, err := columnNamesForTags(ctx, root, fk.ReferencedTableName, fk.ReferencedTableColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	var conds, matches []string
+	for i, col := range cols {
+		parentCol := parentCols[i]
+		conds = append(conds, fmt.Sprintf("c.%s IS NOT NULL", sql.QuoteIdentifier(col)))
+		matches = append(matches, fmt.Sprintf("p.%s = c.%s", sql.QuoteIdentifier(parentCol), sql.QuoteIdentifier(col)))
+	}
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s AS c WHERE %s AND NOT EXISTS (SELECT 1 FROM %s AS p WHERE %s)",
+		sql.QuoteIdentifier(fk.TableName), strings.Join(conds, " AND "),
+		sql.QuoteIdentifier(fk.ReferencedTableName), strings.Join(matches, " AND "))
+
+	sch, iter, err := eng.Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := sql.RowIterToRows(ctx, sch, iter)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, fmt.Errorf("unexpected result of query %s", query)
+	}
+	return rows[0][0].(int64), nil
+}
+
+// columnNamesForTags returns the names of the columns of |tblName| with the tags |tags|.
+func columnNamesForTags(ctx context.Context, root *doltdb.RootValue, tblName string, tags []uint64) ([]string, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		col, ok := sch.GetAllCols().GetByTag(tag)
+		if !ok {
+			return nil, fmt.Errorf("table %s has no column with tag %d", tblName, tag)
+		}
+		names[i] = col.Name
+	}
+	return names, nil
+}
@@ -44,6 +44,8 @@ type logOpts struct {
 	excludingCommitSpecs []*doltdb.CommitSpec
 	commitSpecs          []*doltdb.CommitSpec
 	tableName            string
+	// verifier verifies the signatures of commits, if they're shown
+	verifier *actions.GPGVerifier
 }
 
 type logNode struct {
@@ -52,6 +54,8 @@ type logNode struct {
 	parentHashes []hash.Hash
 	branchNames  []string
 	isHead       bool
+	// signature is the verification of the commit's signature, if it's shown
+	signature *actions.SignatureVerification
 }
 
 var logDocs = cli.CommandDocumentationContent{
@@ -105,7 +109,9 @@ func (cmd LogCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd LogCmd) ArgParser() *argparser.ArgParser {
-	return cli.CreateLogArgParser()
+	ap := cli.CreateLogArgParser()
+	ap.SupportsFlag(cli.ShowSignatureFlag, "", "Verify the signature of each signed commit with gpg, and show the result. Signatures are trusted if they're made by a key in the {{.EmphasisLeft}}signing.trustedkeys{{.EmphasisRight}} config, or by a key the gpg keyring fully trusts if it isn't set.")
+	return ap
 }
 
 // Exec executes the command
@@ -152,6 +158,10 @@ func parseLogArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 		decoration:  decorateOption,
 	}
 
+	if apr.Contains(cli.ShowSignatureFlag) {
+		opts.verifier = actions.ConfiguredGPGVerifier(dEnv.Config, "")
+	}
+
 	err := opts.parseRefsAndTable(ctx, apr, dEnv)
 	if err != nil {
 		return nil, err
@@ -411,12 +421,19 @@ func logCommits(ctx context.Context, dEnv *env.DoltEnv, opts *logOpts) int {
 			return 1
 		}
 
+		sig, sErr := opts.verifySignature(comm)
+		if sErr != nil {
+			cli.PrintErrln("error: failed to verify commit signature")
+			return 1
+		}
+
 		commitsInfo = append(commitsInfo, logNode{
 			commitMeta:   meta,
 			commitHash:   cmHash,
 			parentHashes: pHashes,
 			branchNames:  cHashToRefs[cmHash],
-			isHead:       cmHash == *cwbHash})
+			isHead:       cmHash == *cwbHash,
+			signature:    sig})
 	}
 
 	logToStdOut(opts, commitsInfo)
@@ -424,6 +441,18 @@ func logCommits(ctx context.Context, dEnv *env.DoltEnv, opts *logOpts) int {
 	return 0
 }
 
+// verifySignature returns the verification of the signature of |cm|, or nil if signatures aren't shown.
+func (opts *logOpts) verifySignature(cm *doltdb.Commit) (*actions.SignatureVerification, error) {
+	if opts.verifier == nil {
+		return nil, nil
+	}
+	v, err := actions.VerifyCommitSignature(cm, opts.verifier)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
 func tableExists(ctx context.Context, commit *doltdb.Commit, tableName string) (bool, error) {
 	rv, err := commit.GetRootValue(ctx)
 	if err != nil {
@@ -524,10 +553,16 @@ func logTableCommits(ctx context.Context, dEnv *env.DoltEnv, opts *logOpts) erro
 				return err
 			}
 
+			sig, err := opts.verifySignature(prevCommit)
+			if err != nil {
+				return err
+			}
+
 			commitsInfo = append(commitsInfo, logNode{
 				commitMeta:   meta,
 				commitHash:   prevHash,
-				parentHashes: ph})
+				parentHashes: ph,
+				signature:    sig})
 
 			numLines--
 		}
@@ -601,6 +636,10 @@ func PrintCommit(pager *outputpager.Pager, minParents int, showParents bool, dec
 		logRefs(pager, comm)
 	}
 
+	if comm.signature != nil {
+		pager.Writer.Write([]byte("\n" + formatSignature(*comm.signature)))
+	}
+
 	if len(comm.parentHashes) > 1 {
 		pager.Writer.Write([]byte(fmt.Sprintf("\nMerge:")))
 		for _, h := range comm.parentHashes {
@@ -617,6 +656,25 @@ func PrintCommit(pager *outputpager.Pager, minParents int, showParents bool, dec
 	pager.Writer.Write([]byte(fmt.Sprintf("%s", formattedDesc)))
 }
 
+// formatSignature returns the line describing the verification of a commit's signature.
+func formatSignature(v actions.SignatureVerification) string {
+	switch v.Status {
+	case actions.SignatureGood:
+		return color.GreenString("Good signature from key %s", v.Key)
+	case actions.SignatureUntrusted:
+		return color.YellowString("Good signature from untrusted key %s", v.Key)
+	case actions.SignatureBad:
+		if v.Key == "" {
+			return color.RedString("BAD signature")
+		}
+		return color.RedString("BAD signature from key %s", v.Key)
+	case actions.SignatureUnknownKey:
+		return color.YellowString("Can't check signature: no public key %s", v.Key)
+	default:
+		return "No signature"
+	}
+}
+
 func logDefault(pager *outputpager.Pager, opts *logOpts, commits []logNode) {
 	for _, comm := range commits {
 		PrintCommit(pager, opts.minParents, opts.showParents, opts.decoration, comm)
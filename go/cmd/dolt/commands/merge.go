@@ -42,12 +42,17 @@ var mergeDocs = cli.CommandDocumentationContent{
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
+
+The third syntax ({{.LessThan}}dolt merge --continue{{.GreaterThan}}) stages the tables of a merge which resulted in conflicts and commits it, once they and any constraint violations are resolved. The state of each table of the merge is shown by the {{.EmphasisLeft}}dolt_merge_state{{.EmphasisRight}} system table.
+
+If the {{.EmphasisLeft}}--fk-cascade{{.EmphasisRight}} flag is supplied, child rows of foreign keys whose parent rows were deleted by one side of the merge are deleted, or have their foreign key columns set to NULL, according to the foreign key's ON DELETE action, instead of being reported as constraint violations. The rows affected are listed in the output of the merge.
 `,
 
 	Synopsis: []string{
 		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
+		"--continue [-m message]",
 	},
 }
 
@@ -111,6 +116,8 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 		}
 
 		verr = abortMerge(ctx, dEnv)
+	} else if apr.Contains(cli.ContinueFlag) {
+		return continueMerge(ctx, dEnv, apr, usage)
 	} else {
 		if apr.NArg() != 1 {
 			usage()
@@ -176,6 +183,7 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 				cli.Println("Everything up-to-date")
 				return handleCommitErr(ctx, dEnv, nil, usage)
 			}
+			spec.CascadeForeignKeys = apr.Contains(cli.FKCascadeFlag)
 
 			err = validateMergeSpec(ctx, spec)
 			if err != nil {
@@ -191,6 +199,59 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return handleCommitErr(ctx, dEnv, verr, usage)
 }
 
+// continueMerge stages the tables of the active merge and commits it, once none of them are left unresolved.
+func continueMerge(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if !ws.MergeActive() {
+		cli.PrintErrln("fatal: There is no merge to continue")
+		return 1
+	}
+
+	unresolved, err := ws.UnresolvedTables(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if len(unresolved) > 0 {
+		cli.PrintErrln("error: Committing is not possible because these tables are unresolved:")
+		for _, tbl := range unresolved {
+			cli.PrintErrln("\t" + tbl)
+		}
+		cli.PrintErrln("hint: resolve them with 'dolt conflicts resolve' or by fixing their constraint violations")
+		return 1
+	}
+
+	head, err := dEnv.HeadCommit(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	ancestor, err := doltdb.GetCommitAncestor(ctx, head, ws.MergeState().Commit())
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	tables, err := merge.MergedTables(ctx, ws, ancestor)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if len(tables) > 0 {
+		if res := (AddCmd{}).Exec(ctx, "add", tables, dEnv, nil); res != 0 {
+			return res
+		}
+	}
+
+	msg := fmt.Sprintf("Merge branch '%s' into %s", ws.MergeState().CommitSpecStr(), dEnv.RepoStateReader().CWBHeadRef().GetPath())
+	if m, ok := apr.GetValue(cli.MessageArg); ok {
+		msg = m
+	}
+	commitParams := []string{"-m", msg}
+	if author, ok := apr.GetValue(cli.AuthorParam); ok {
+		commitParams = append(commitParams, "--author", author)
+	}
+	return CommitCmd{}.Exec(ctx, "commit", commitParams, dEnv, nil)
+}
+
 func isMergeActive(ctx context.Context, denv *env.DoltEnv) (bool, error) {
 	ws, err := denv.WorkingSet(ctx)
 	if err != nil {
@@ -291,6 +352,7 @@ func printSuccessStats(tblToStats map[string]*merge.MergeStats) (conflicts bool,
 	printModifications(tblToStats)
 	printAdditions(tblToStats)
 	printDeletions(tblToStats)
+	printCascades(tblToStats)
 	return printConflictsAndViolations(tblToStats)
 }
 
@@ -310,6 +372,27 @@ func printDeletions(tblToStats map[string]*merge.MergeStats) {
 	}
 }
 
+// printCascades prints the rows changed by the ON DELETE actions of foreign keys during the merge.
+func printCascades(tblToStats map[string]*merge.MergeStats) {
+	var tbls []string
+	for tblName, stats := range tblToStats {
+		if stats.CascadedDeletes > 0 || stats.CascadedSetNulls > 0 {
+			tbls = append(tbls, tblName)
+		}
+	}
+	sort.Strings(tbls)
+
+	for _, tblName := range tbls {
+		stats := tblToStats[tblName]
+		if stats.CascadedDeletes > 0 {
+			cli.Printf("CASCADE (foreign key): %d rows deleted from %s\n", stats.CascadedDeletes, tblName)
+		}
+		if stats.CascadedSetNulls > 0 {
+			cli.Printf("SET NULL (foreign key): %d rows updated in %s\n", stats.CascadedSetNulls, tblName)
+		}
+	}
+}
+
 func printConflictsAndViolations(tblToStats map[string]*merge.MergeStats) (conflicts bool, constraintViolations bool) {
 	hasConflicts := false
 	hasConstraintViolations := false
@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/dolthub/dolt/go/store/types"
 
@@ -33,6 +34,7 @@ const (
 	migrationMsg    = "Migrating database to the latest data format"
 
 	migrateDropConflictsFlag = "drop-conflicts"
+	migrateBranchParam       = "branch"
 )
 
 var migrateDocs = cli.CommandDocumentationContent{
@@ -40,10 +42,18 @@ var migrateDocs = cli.CommandDocumentationContent{
 	LongDesc: `Migrate is a multi-purpose command to update the data format of a Dolt database. Over time, development 
 on Dolt requires changes to the on-disk data format. These changes are necessary to improve Database performance and 
 correctness. Migrating to the latest format is therefore necessary for compatibility with the latest Dolt clients, and
-to take advantage of the newly released Dolt features.`,
+to take advantage of the newly released Dolt features.
+
+The migration reports each commit it migrates, along with the number of commits and tables done and chunks written so
+far. Its progress is saved after every commit: if a migration is interrupted, running {{.EmphasisLeft}}dolt migrate{{.EmphasisRight}} again resumes it
+after the last migrated commit.
+
+With {{.EmphasisLeft}}--branch{{.EmphasisRight}}, only the history and working set of the given branch are migrated, and the database keeps using the old
+format. Large databases can be migrated one branch at a time this way; a final {{.EmphasisLeft}}dolt migrate{{.EmphasisRight}} migrates the remaining refs,
+skipping the commits that were already migrated, and switches the database to the new format.`,
 
 	Synopsis: []string{
-		"[ --push ] [ --pull ]",
+		"[--drop-conflicts] [--branch {{.LessThan}}branch{{.GreaterThan}}]",
 	},
 }
 
@@ -66,6 +76,7 @@ func (cmd MigrateCmd) Docs() *cli.CommandDocumentation {
 func (cmd MigrateCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
 	ap.SupportsFlag(migrateDropConflictsFlag, "", "Drop any conflicts visited during the migration")
+	ap.SupportsString(migrateBranchParam, "", "branch", "Only migrate the given branch, saving the migration to be completed later")
 	return ap
 }
 
@@ -81,7 +92,13 @@ func (cmd MigrateCmd) Exec(ctx context.Context, commandStr string, args []string
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
 	dropConflicts := apr.Contains(migrateDropConflictsFlag)
-	if err := MigrateDatabase(ctx, dEnv, dropConflicts); err != nil {
+	var err error
+	if branch, ok := apr.GetValue(migrateBranchParam); ok {
+		err = MigrateBranches(ctx, dEnv, dropConflicts, []string{branch})
+	} else {
+		err = MigrateDatabase(ctx, dEnv, dropConflicts)
+	}
+	if err != nil {
 		verr := errhand.BuildDError("migration failed").AddCause(err).Build()
 		return HandleVErrAndExitCode(verr, usage)
 	}
@@ -91,27 +108,58 @@ func (cmd MigrateCmd) Exec(ctx context.Context, commandStr string, args []string
 
 // MigrateDatabase migrates the NomsBinFormat of |dEnv.DoltDB|.
 func MigrateDatabase(ctx context.Context, dEnv *env.DoltEnv, dropConflicts bool) error {
-	menv, err := migrate.NewEnvironment(ctx, dEnv)
-	if err != nil {
+	menv, ok, err := newMigrationEnvironment(ctx, dEnv, dropConflicts)
+	if err != nil || !ok {
 		return err
 	}
-	menv.DropConflicts = dropConflicts
 
-	if curr := menv.Existing.DoltDB.Format(); types.IsFormat_DOLT(curr) {
-		cli.Println("database is already migrated")
-		return nil
+	err = migrate.TraverseDAG(ctx, menv, menv.Existing.DoltDB, menv.Migration.DoltDB)
+	if err != nil {
+		return err
 	}
 
-	p, err := menv.Migration.FS.Abs(".")
-	if err != nil {
+	return migrate.SwapChunkStores(ctx, menv)
+}
+
+// MigrateBranches migrates the history and working sets of the |branches| of |dEnv.DoltDB|. The database keeps its
+// NomsBinFormat until it's migrated by MigrateDatabase, which resumes the migration.
+func MigrateBranches(ctx context.Context, dEnv *env.DoltEnv, dropConflicts bool, branches []string) error {
+	menv, ok, err := newMigrationEnvironment(ctx, dEnv, dropConflicts)
+	if err != nil || !ok {
 		return err
 	}
-	cli.Println("migrating database at tmp dir: ", p)
+	menv.Branches = branches
 
 	err = migrate.TraverseDAG(ctx, menv, menv.Existing.DoltDB, menv.Migration.DoltDB)
 	if err != nil {
 		return err
 	}
 
-	return migrate.SwapChunkStores(ctx, menv)
+	cli.Printf("migrated branches %s; run \"dolt migrate\" to migrate the rest of the database\n", strings.Join(branches, ", "))
+	return nil
+}
+
+// newMigrationEnvironment creates the migration environment of |dEnv|, or returns false if it's already migrated.
+func newMigrationEnvironment(ctx context.Context, dEnv *env.DoltEnv, dropConflicts bool) (migrate.Environment, bool, error) {
+	if curr := dEnv.DoltDB.Format(); types.IsFormat_DOLT(curr) {
+		cli.Println("database is already migrated")
+		return migrate.Environment{}, false, nil
+	}
+
+	menv, err := migrate.NewEnvironment(ctx, dEnv)
+	if err != nil {
+		return migrate.Environment{}, false, err
+	}
+	menv.DropConflicts = dropConflicts
+
+	p, err := menv.Migration.FS.Abs(".")
+	if err != nil {
+		return migrate.Environment{}, false, err
+	}
+	if menv.Resumed {
+		cli.Println("resuming migration of database at tmp dir: ", p)
+	} else {
+		cli.Println("migrating database at tmp dir: ", p)
+	}
+	return menv, true, nil
 }
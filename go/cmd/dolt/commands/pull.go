@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
 var pullDocs = cli.CommandDocumentationContent{
@@ -102,7 +103,27 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	err = pullHelper(ctx, dEnv, pullSpec)
+	var pullOpts []pull.Option
+	if apr.Contains(cli.VerifyFlag) {
+		pullOpts = append(pullOpts, pull.WithChunkVerification())
+	}
+	if apr.Contains(cli.FsckFlag) {
+		pullOpts = append(pullOpts, pull.WithReachabilityCheck())
+	}
+	if apr.Contains(cli.DeltaFlag) {
+		pullOpts = append(pullOpts, pull.WithDeltaTransfer())
+	}
+	if keyPath, ok := apr.GetValue(cli.TrustedKeyParam); ok {
+		key, err := actions.LoadTrustedKey(dEnv.FS, keyPath)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		pullOpts = append(pullOpts, pull.WithTrustedKey(key))
+	}
+
+	recordOpt, recordPull := actions.StartTransferRecord(dEnv.RepoStateWriter(), env.TransferPull, pullSpec.Remote.Name)
+	err = pullHelper(ctx, dEnv, pullSpec, progStarterForArgs(apr, downloadLanguage), append(pullOpts, recordOpt))
+	recordPull(err)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
@@ -110,7 +131,7 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 }
 
 // pullHelper splits pull into fetch, prepare merge, and merge to interleave printing
-func pullHelper(ctx context.Context, dEnv *env.DoltEnv, pullSpec *env.PullSpec) error {
+func pullHelper(ctx context.Context, dEnv *env.DoltEnv, pullSpec *env.PullSpec, progStarter actions.ProgStarter, pullOpts []pull.Option) error {
 	srcDB, err := pullSpec.Remote.GetRemoteDBWithoutCaching(ctx, dEnv.DoltDB.ValueReadWriter().Format(), dEnv)
 	if err != nil {
 		return fmt.Errorf("failed to get remote db; %w", err)
@@ -132,6 +153,10 @@ func pullHelper(ctx context.Context, dEnv *env.DoltEnv, pullSpec *env.PullSpec)
 
 	// Go through every reference and every branch in each reference
 	for _, rs := range pullSpec.RefSpecs {
+		if _, ok := rs.(ref.NegativeRefSpec); ok {
+			continue
+		}
+
 		rsSeen := false // track invalid refSpecs
 		for _, branchRef := range branchRefs {
 			remoteTrackRef := rs.DestRef(branchRef)
@@ -140,16 +165,23 @@ func pullHelper(ctx context.Context, dEnv *env.DoltEnv, pullSpec *env.PullSpec)
 			}
 
 			rsSeen = true
+			if ref.IsExcluded(branchRef, pullSpec.RefSpecs) {
+				continue
+			}
 			tmpDir, err := dEnv.TempTableFilesDir()
 			if err != nil {
 				return err
 			}
-			srcDBCommit, err := actions.FetchRemoteBranch(ctx, tmpDir, pullSpec.Remote, srcDB, dEnv.DoltDB, branchRef, buildProgStarter(downloadLanguage), stopProgFuncs)
+			srcDBCommit, err := actions.FetchRemoteBranch(ctx, tmpDir, pullSpec.Remote, srcDB, dEnv.DoltDB, branchRef, progStarter, stopProgFuncs, pullOpts...)
 			if err != nil {
 				return err
 			}
 
-			err = dEnv.DoltDB.FastForward(ctx, remoteTrackRef, srcDBCommit)
+			if rs.Forced() {
+				err = dEnv.DoltDB.SetHeadToCommit(ctx, remoteTrackRef, srcDBCommit)
+			} else {
+				err = dEnv.DoltDB.FastForward(ctx, remoteTrackRef, srcDBCommit)
+			}
 			if err != nil {
 				return fmt.Errorf("fetch failed; %w", err)
 			}
@@ -221,7 +253,7 @@ func pullHelper(ctx context.Context, dEnv *env.DoltEnv, pullSpec *env.PullSpec)
 	if err != nil {
 		return err
 	}
-	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dEnv.DoltDB, buildProgStarter(downloadLanguage), stopProgFuncs)
+	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dEnv.DoltDB, progStarter, stopProgFuncs, pullOpts...)
 	if err != nil {
 		return err
 	}
@@ -16,11 +16,16 @@ package commands
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -46,10 +51,18 @@ When the command line does not specify where to push with the {{.LessThan}}remot
 When the command line does not specify what to push with {{.LessThan}}refspec{{.GreaterThan}}... then the current branch will be used.
 
 When neither the command-line does not specify what to push, the default behavior is used, which corresponds to the current branch being pushed to the corresponding upstream branch, but as a safety measure, the push is aborted if the upstream branch does not have the same name as the local one.
+
+A {{.LessThan}}refspec{{.GreaterThan}} is a branch or tag, or {{.LessThan}}src{{.GreaterThan}}:{{.LessThan}}dst{{.GreaterThan}} to push the local ref {{.LessThan}}src{{.GreaterThan}} to the remote ref {{.LessThan}}dst{{.GreaterThan}}. Both sides may be patterns with a single {{.EmphasisLeft}}*{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}refs/heads/release/*:refs/heads/release/*{{.EmphasisRight}}, to push every branch or tag matching the pattern. A refspec prefixed with {{.EmphasisLeft}}+{{.EmphasisRight}} updates its remote ref even if it isn't a fast-forward, as {{.EmphasisLeft}}--force{{.EmphasisRight}} does for every refspec. A negative refspec prefixed with {{.EmphasisLeft}}^{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}^refs/heads/wip/*{{.EmphasisRight}}, excludes the refs it matches from the push. When no refspec is given and the remote has push specs, set with {{.EmphasisLeft}}dolt remote set-push{{.EmphasisRight}}, they are pushed instead of the current branch.
+
+When the remote is listed in the {{.EmphasisLeft}}push.requirelicense{{.EmphasisRight}} config, a comma separated list of remote names or {{.EmphasisLeft}}*{{.EmphasisRight}} for every remote, the push is aborted unless every table of the pushed commit has a license in {{.EmphasisLeft}}dolt_provenance{{.EmphasisRight}}.
+
+The branches listed in the {{.EmphasisLeft}}branch.protected{{.EmphasisRight}} config, a comma separated list of branch names or {{.EmphasisLeft}}*{{.EmphasisRight}} for every branch, are protected: the push is aborted if it deletes a remote branch with a protected name, or moves it to a commit which doesn't descend from its commit, even with {{.EmphasisLeft}}--force{{.EmphasisRight}}. The push to a branch listed in the {{.EmphasisLeft}}branch.noconflicts{{.EmphasisRight}} config is aborted if the pushed commit has unresolved merge conflicts. A sql-server enforces the protection configured for its databases on the pushes it receives through its remotesapi, and protected branches can't be deleted with {{.EmphasisLeft}}dolt branch -d{{.EmphasisRight}}.
+
+If the database has an executable {{.EmphasisLeft}}.dolt/hooks/pre-push{{.EmphasisRight}} script, it is run before the push with the name and url of the remote as its arguments, and a line on its input for each ref pushed: {{.LessThan}}local ref{{.GreaterThan}} {{.LessThan}}local hash{{.GreaterThan}} {{.LessThan}}remote ref{{.GreaterThan}} {{.LessThan}}remote hash{{.GreaterThan}}. The push is aborted if it exits with a non-zero status, unless {{.EmphasisLeft}}--no-verify{{.EmphasisRight}} is given.
 `,
 
 	Synopsis: []string{
-		"[-u | --set-upstream] [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}}]",
+		"[-u | --set-upstream] [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}}...]",
 	},
 }
 
@@ -85,13 +98,19 @@ func (cmd PushCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, pushDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
+	var verr errhand.VerboseError
+	dEnv.UserPassConfig, verr = getRemoteUserAndPassConfig(apr)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
 	autoSetUpRemote := dEnv.Config.GetStringOrDefault(env.PushAutoSetupRemote, "false")
 	pushAutoSetUpRemote, err := strconv.ParseBool(autoSetUpRemote)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	opts, err := env.NewPushOpts(ctx, apr, dEnv.RepoStateReader(), dEnv.DoltDB, apr.Contains(cli.ForceFlag), apr.Contains(cli.SetUpstreamFlag), pushAutoSetUpRemote)
+	pushes, err := env.NewPushOpts(ctx, apr, dEnv.RepoStateReader(), dEnv.DoltDB, apr.Contains(cli.ForceFlag), apr.Contains(cli.SetUpstreamFlag), pushAutoSetUpRemote)
 	if err != nil {
 		var verr errhand.VerboseError
 		switch err {
@@ -114,24 +133,99 @@ func (cmd PushCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		}
 		return HandleVErrAndExitCode(verr, usage)
 	}
+	if expected, ok := apr.GetValue(cli.ForceWithLeaseFlag); ok {
+		if apr.Contains(cli.ForceFlag) {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: --%s and --%s cannot be used together", cli.ForceFlag, cli.ForceWithLeaseFlag).SetPrintUsage().Build(), usage)
+		}
+		if len(pushes) != 1 {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: --%s can only be used to push a single ref", cli.ForceWithLeaseFlag).SetPrintUsage().Build(), usage)
+		}
+		if err := pushes[0].SetLease(ctx, dEnv.DoltDB, expected); err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
 
-	remoteDB, err := opts.Remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format(), dEnv)
+	for _, opts := range pushes {
+		err = actions.CheckPushLicense(ctx, dEnv.DoltDB, opts, dEnv.Config.GetStringOrDefault(env.PushRequireLicenseKey, ""))
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
+	var signKey ed25519.PrivateKey
+	if keyPath, ok := apr.GetValue(cli.SignKeyParam); ok {
+		signKey, err = actions.LoadSigningKey(dEnv.FS, keyPath)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
+	// the push is made to the remote's URL, and then copied to its mirrors
+	remote := pushes[0].Remote.WithoutMirrors()
+	remoteDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format(), dEnv)
 	if err != nil {
-		err = actions.HandleInitRemoteStorageClientErr(opts.Remote.Name, opts.Remote.Url, err)
+		err = actions.HandleInitRemoteStorageClientErr(remote.Name, remote.Url, err)
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	protection := env.GetBranchProtection(dEnv.Config)
+	for _, opts := range pushes {
+		err = actions.CheckPushProtection(ctx, dEnv.DoltDB, remoteDB, opts, protection)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
+	if !apr.Contains(cli.NoVerifyFlag) {
+		var updates []actions.HookRefUpdate
+		for _, opts := range pushes {
+			refUpdates, err := actions.PushHookUpdates(ctx, dEnv.DoltDB, remoteDB, opts)
+			if err != nil {
+				return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+			}
+			updates = append(updates, refUpdates...)
+		}
+		verr := runHooks(ctx, dEnv, actions.HookEvent{Type: actions.PrePushHook, Remote: pushes[0].Remote, Updates: updates})
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
 	tmpDir, err := dEnv.TempTableFilesDir()
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
-	var verr errhand.VerboseError
-	err = actions.DoPush(ctx, dEnv.RepoStateReader(), dEnv.RepoStateWriter(), dEnv.DoltDB, remoteDB, tmpDir, opts, buildProgStarter(defaultLanguage), stopProgFuncs)
-	if err != nil {
-		verr = printInfoForPushError(err, opts.Remote, opts.DestRef, opts.RemoteRef)
+	pushed := false
+	for _, opts := range pushes {
+		err = actions.DoPush(ctx, dEnv.RepoStateReader(), dEnv.RepoStateWriter(), dEnv.DoltDB, remoteDB, tmpDir, opts, buildProgStarter(defaultLanguage), stopProgFuncs)
+		if err == nil {
+			pushed = true
+		} else if len(pushes) > 1 && err == doltdb.ErrUpToDate {
+			// the other refs may still be pushed, and up-to-date is only reported if none of them are
+			continue
+		} else if verr = printInfoForPushError(err, opts.Remote, opts.DestRef, opts.RemoteRef); verr != nil {
+			break
+		}
+	}
+	if verr == nil && !pushed && len(pushes) > 1 {
+		cli.Println("Everything up-to-date")
+	} else if verr == nil && pushed && signKey != nil {
+		if err = remoteDB.AttestManifest(ctx, signKey); err != nil {
+			verr = errhand.BuildDError("error: failed to sign the manifest of '%s'", remote.Url).AddCause(err).Build()
+		}
+	}
+	if verr == nil {
+		mirrorDB := func(ctx context.Context, mirror env.Remote) (*doltdb.DoltDB, error) {
+			return mirror.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format(), dEnv)
+		}
+		for _, opts := range pushes {
+			for _, mirrorErr := range actions.PushToMirrors(ctx, dEnv.RepoStateReader(), dEnv.RepoStateWriter(), dEnv.DoltDB, tmpDir, opts, mirrorDB) {
+				cli.PrintErrln(color.YellowString("warning: %s", mirrorErr.Error()))
+			}
+		}
 	}
 
-	if opts.SetUpstream {
+	if pushes[0].SetUpstream {
 		err := dEnv.RepoState.Save(dEnv.FS)
 		if err != nil {
 			err = fmt.Errorf("%w; %s", actions.ErrFailedToSaveRepoState, err.Error())
@@ -142,6 +236,15 @@ func (cmd PushCmd) Exec(ctx context.Context, commandStr string, args []string, d
 }
 
 func printInfoForPushError(err error, remote env.Remote, destRef, remoteRef ref.DoltRef) errhand.VerboseError {
+	if errors.Is(err, actions.ErrStaleLease) {
+		cli.Printf("To %s\n", remote.Url)
+		cli.Printf("! [rejected]          %s -> %s (stale info)\n", destRef.String(), remoteRef.String())
+		cli.Printf("error: failed to push some refs to '%s'\n", remote.Url)
+		cli.Println("hint: The remote branch has changed since it was last fetched. Fetch it, and check that")
+		cli.Println("hint: the changes can be overwritten before pushing again.")
+		return errhand.BuildDError("error: %s", err.Error()).Build()
+	}
+
 	switch err {
 	case doltdb.ErrUpToDate:
 		cli.Println("Everything up-to-date")
@@ -194,11 +297,98 @@ func pullerProgFunc(ctx context.Context, statsCh chan pull.Stats, language progL
 					humanize.SIWithDigits(stats.SendBytesPerSec, 2, "B"),
 				)
 			}
+			for _, ps := range stats.Phases {
+				p.Printf("\n  %-17s %s", ps.Phase.String()+":", formatPhaseProgress(ps))
+			}
 			p.Display()
 		}
 	}
 }
 
+// formatPhaseProgress returns a one line description of the progress of a single pull phase.
+func formatPhaseProgress(ps pull.PhaseStats) string {
+	if !ps.Started {
+		return "waiting"
+	}
+	if ps.Phase == pull.PhaseManifestUpdate {
+		if ps.Finished {
+			return "done"
+		}
+		return "updating"
+	}
+
+	var progress string
+	if ps.Phase.Unit() == "bytes" {
+		progress = fmt.Sprintf("%s of %s", humanize.Bytes(ps.Done), humanize.Bytes(ps.Total))
+	} else {
+		progress = fmt.Sprintf("%s of %s %s", humanize.Comma(int64(ps.Done)), humanize.Comma(int64(ps.Total)), ps.Phase.Unit())
+	}
+
+	if ps.Finished {
+		return progress + ", done"
+	} else if ps.ETA > 0 {
+		return fmt.Sprintf("%s, ETA %s", progress, ps.ETA.Round(time.Second))
+	}
+	return progress
+}
+
+type jsonPhaseStats struct {
+	Phase      string  `json:"phase"`
+	Unit       string  `json:"unit"`
+	Done       uint64  `json:"done"`
+	Total      uint64  `json:"total"`
+	Started    bool    `json:"started"`
+	Finished   bool    `json:"finished"`
+	ETASeconds float64 `json:"eta_seconds"`
+}
+
+type jsonPullStats struct {
+	FinishedSendBytes        uint64           `json:"finished_send_bytes"`
+	BufferedSendBytes        uint64           `json:"buffered_send_bytes"`
+	SendBytesPerSec          float64          `json:"send_bytes_per_sec"`
+	TotalSourceChunks        uint64           `json:"total_source_chunks"`
+	FetchedSourceChunks      uint64           `json:"fetched_source_chunks"`
+	FetchedSourceBytes       uint64           `json:"fetched_source_bytes"`
+	FetchedSourceBytesPerSec float64          `json:"fetched_source_bytes_per_sec"`
+	Phases                   []jsonPhaseStats `json:"phases"`
+}
+
+// jsonPullerProgFunc writes every update received on |statsCh| to stdout as a single line of JSON. It reads until
+// |statsCh| is closed rather than until the pull's context is canceled, since stopProgFuncs cancels the context first
+// and the final update would otherwise be dropped.
+func jsonPullerProgFunc(statsCh chan pull.Stats) {
+	for stats := range statsCh {
+		js := jsonPullStats{
+			FinishedSendBytes:        stats.FinishedSendBytes,
+			BufferedSendBytes:        stats.BufferedSendBytes,
+			SendBytesPerSec:          stats.SendBytesPerSec,
+			TotalSourceChunks:        stats.TotalSourceChunks,
+			FetchedSourceChunks:      stats.FetchedSourceChunks,
+			FetchedSourceBytes:       stats.FetchedSourceBytes,
+			FetchedSourceBytesPerSec: stats.FetchedSourceBytesPerSec,
+			Phases:                   make([]jsonPhaseStats, len(stats.Phases)),
+		}
+		for i, ps := range stats.Phases {
+			js.Phases[i] = jsonPhaseStats{
+				Phase:      ps.Phase.String(),
+				Unit:       ps.Phase.Unit(),
+				Done:       ps.Done,
+				Total:      ps.Total,
+				Started:    ps.Started,
+				Finished:   ps.Finished,
+				ETASeconds: ps.ETA.Seconds(),
+			}
+		}
+
+		data, err := json.Marshal(js)
+		if err != nil {
+			cli.PrintErrln(err.Error())
+			continue
+		}
+		cli.Println(string(data))
+	}
+}
+
 // progLanguage is the language to use when displaying progress for a pull from a src db to a sink db.
 type progLanguage int
 
@@ -222,6 +412,47 @@ func buildProgStarter(language progLanguage) actions.ProgStarter {
 	}
 }
 
+// buildJsonProgStarter returns a ProgStarter that reports progress as a stream of JSON objects, one per line.
+func buildJsonProgStarter() actions.ProgStarter {
+	return func(ctx context.Context) (*sync.WaitGroup, chan pull.Stats) {
+		statsCh := make(chan pull.Stats, 128)
+		wg := &sync.WaitGroup{}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jsonPullerProgFunc(statsCh)
+		}()
+
+		return wg, statsCh
+	}
+}
+
+// buildQuietProgStarter returns a ProgStarter that discards progress.
+func buildQuietProgStarter() actions.ProgStarter {
+	return func(ctx context.Context) (*sync.WaitGroup, chan pull.Stats) {
+		statsCh := make(chan pull.Stats, 128)
+		wg := &sync.WaitGroup{}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range statsCh {
+			}
+		}()
+
+		return wg, statsCh
+	}
+}
+
+// progStarterForArgs returns the ProgStarter to use for the command with the args given.
+func progStarterForArgs(apr *argparser.ArgParseResults, language progLanguage) actions.ProgStarter {
+	if apr.Contains(cli.JsonFlag) {
+		return buildJsonProgStarter()
+	}
+	return buildProgStarter(language)
+}
+
 func stopProgFuncs(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh chan pull.Stats) {
 	cancel()
 	close(statsCh)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"reflect"
 	"strings"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -37,27 +38,52 @@ var remoteDocs = cli.CommandDocumentationContent{
 {{.EmphasisLeft}}add{{.EmphasisRight}}
 Adds a remote named {{.LessThan}}name{{.GreaterThan}} for the repository at {{.LessThan}}url{{.GreaterThan}}. The command dolt fetch {{.LessThan}}name{{.GreaterThan}} can then be used to create and update remote-tracking branches {{.EmphasisLeft}}<name>/<branch>{{.EmphasisRight}}.
 
-The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
+The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, s3, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
 
 AWS cloud remote urls should be of the form {{.EmphasisLeft}}aws://[dynamo-table:s3-bucket]/database{{.EmphasisRight}}.  You may configure your aws cloud remote using the optional parameters {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-type{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-file{{.EmphasisRight}}.
 
-aws-creds-type specifies the means by which credentials should be retrieved in order to access the specified cloud resources (specifically the dynamo table, and the s3 bucket). Valid values are 'role', 'env', or 'file'.
+aws-creds-type specifies the means by which credentials should be retrieved in order to access the specified cloud resources (specifically the dynamo table, and the s3 bucket). Valid values are 'role', 'env', 'file', or 'static'.
 
 	role: Use the credentials installed for the current user
 	env: Looks for environment variables AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
 	file: Uses the credentials file specified by the parameter aws-creds-file
+	static: Uses the access key given by the parameters aws-access-key-id and aws-secret-access-key, which are saved in the repository
 	
+S3 remote urls should be of the form {{.EmphasisLeft}}s3://s3-bucket/database{{.EmphasisRight}}. They only need an S3 bucket, and take the same aws parameters as AWS cloud remotes. To use an S3 compatible object store such as MinIO, Ceph or Cloudflare R2, set the optional parameter {{.EmphasisLeft}}endpoint{{.EmphasisRight}} to its url, e.g. {{.EmphasisLeft}}dolt remote add --endpoint http://localhost:9000 --aws-creds-type env origin s3://bucket/database{{.EmphasisRight}}. Buckets of a custom endpoint are addressed by path, and requests are signed for the us-east-1 region unless aws-region is given.
+
 GCP remote urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google.
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+The optional parameter {{.EmphasisLeft}}compression{{.EmphasisRight}} sets the codec chunks are compressed with when they are pushed to the remote. Valid values are 'snappy', the default, and 'zstd'. zstd is typically 30-50% smaller than snappy for text heavy data, but versions of dolt which don't support it can't read chunks compressed with it: they fail to fetch, pull or clone from the remote once such chunks are pushed to it. Because of that, zstd also requires the flag {{.EmphasisLeft}}allow-incompatible-compression{{.EmphasisRight}}. Only use it once every client of the remote has been upgraded.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
-Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
+Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.
+
+{{.EmphasisLeft}}add-mirror{{.EmphasisRight}}
+Adds {{.LessThan}}url{{.GreaterThan}} as a mirror of the remote named {{.LessThan}}name{{.GreaterThan}}, another copy of the remote's database such as an S3 bucket mirroring a DoltHub remote. Fetches and pulls, and clones made with {{.EmphasisLeft}}dolt clone --mirrors{{.EmphasisRight}}, read from the remote's url, and fail over to its mirrors, in the order they were added, when it can't be read from. An operation that fails over carries on from the chunks it already has rather than starting over. Pushes are made to the remote's url, and then copied to each mirror. A mirror that can't be pushed to doesn't fail the push, but a warning is printed. Mirrors take the same parameters as the remote.
+
+{{.EmphasisLeft}}remove-mirror{{.EmphasisRight}}
+Removes {{.LessThan}}url{{.GreaterThan}} from the mirrors of the remote named {{.LessThan}}name{{.GreaterThan}}.
+
+{{.EmphasisLeft}}set-fetch{{.EmphasisRight}}
+Replaces the refspecs fetched from the remote named {{.LessThan}}name{{.GreaterThan}} by {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} and {{.EmphasisLeft}}dolt pull{{.EmphasisRight}}. A refspec maps branches of the remote, or a pattern of branches with a single {{.EmphasisLeft}}*{{.EmphasisRight}}, to remote-tracking branches, e.g. {{.EmphasisLeft}}refs/heads/release/*:refs/remotes/origin/release/*{{.EmphasisRight}}. A refspec prefixed with {{.EmphasisLeft}}+{{.EmphasisRight}} force updates its remote-tracking branches when they can't be fast-forwarded, and a negative refspec prefixed with {{.EmphasisLeft}}^{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}^refs/heads/wip/*{{.EmphasisRight}}, excludes the branches it matches. With no refspecs, every branch of the remote is fetched again.
+
+{{.EmphasisLeft}}set-push{{.EmphasisRight}}
+Sets the refspecs pushed to the remote named {{.LessThan}}name{{.GreaterThan}} by a {{.EmphasisLeft}}dolt push{{.EmphasisRight}} which doesn't name any refspecs. A refspec maps local branches or tags, or patterns of them, to those of the remote, e.g. {{.EmphasisLeft}}+refs/heads/*:refs/heads/*{{.EmphasisRight}} to mirror every branch, and may be forced with {{.EmphasisLeft}}+{{.EmphasisRight}} or negative with {{.EmphasisLeft}}^{{.EmphasisRight}}. With no refspecs, such pushes push the current branch again.
+
+{{.EmphasisLeft}}restore-table{{.EmphasisRight}}
+Restore the table {{.LessThan}}table{{.GreaterThan}} from the remote named {{.LessThan}}name{{.GreaterThan}} into the working set, without fetching anything else from the remote. The table is restored from the current branch of the remote, or from {{.EmphasisLeft}}--at{{.EmphasisRight}} {{.LessThan}}commit{{.GreaterThan}}, and is named {{.LessThan}}table{{.GreaterThan}} unless {{.EmphasisLeft}}--as{{.EmphasisRight}} {{.LessThan}}new_name{{.GreaterThan}} is given. A table of the same name must not already exist.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
-		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--endpoint {{.LessThan}}url{{.GreaterThan}}] [--compression {{.LessThan}}codec{{.GreaterThan}} [--allow-incompatible-compression]] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
+		"add-mirror {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"remove-mirror {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"set-fetch {{.LessThan}}name{{.GreaterThan}} [{{.LessThan}}refspec{{.GreaterThan}}...]",
+		"set-push {{.LessThan}}name{{.GreaterThan}} [{{.LessThan}}refspec{{.GreaterThan}}...]",
+		"restore-table [--as {{.LessThan}}new_name{{.GreaterThan}}] [--at {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}}",
 	},
 }
 
@@ -65,6 +91,10 @@ const (
 	addRemoteId         = "add"
 	removeRemoteId      = "remove"
 	removeRemoteShortId = "rm"
+	addMirrorId         = "add-mirror"
+	removeMirrorId      = "remove-mirror"
+	setFetchId          = "set-fetch"
+	setPushId           = "set-push"
 )
 
 type RemoteCmd struct{}
@@ -92,6 +122,7 @@ func (cmd RemoteCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(cli.VerboseFlag, "v", "When printing the list of remotes adds additional details.")
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use")
+	cli.AddRestoreTableArgs(ap)
 	return ap
 }
 
@@ -117,6 +148,16 @@ func (cmd RemoteCmd) Exec(ctx context.Context, commandStr string, args []string,
 		verr = removeRemote(ctx, dEnv, apr)
 	case apr.Arg(0) == removeRemoteShortId:
 		verr = removeRemote(ctx, dEnv, apr)
+	case apr.Arg(0) == addMirrorId:
+		verr = addRemoteMirror(dEnv, apr)
+	case apr.Arg(0) == removeMirrorId:
+		verr = removeRemoteMirror(dEnv, apr)
+	case apr.Arg(0) == setFetchId:
+		verr = setRemoteRefSpecs(dEnv, apr, dEnv.SetRemoteFetchSpecs)
+	case apr.Arg(0) == setPushId:
+		verr = setRemoteRefSpecs(dEnv, apr, dEnv.SetRemotePushSpecs)
+	case apr.Arg(0) == cli.RestoreTableId:
+		verr = restoreTableFromRemote(ctx, dEnv, apr)
 	default:
 		verr = errhand.BuildDError("").SetPrintUsage().Build()
 	}
@@ -124,6 +165,24 @@ func (cmd RemoteCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return HandleVErrAndExitCode(verr, usage)
 }
 
+func restoreTableFromRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	remoteName := strings.TrimSpace(apr.Arg(1))
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return errhand.BuildDError("Unable to get remotes from the local directory").AddCause(err).Build()
+	}
+	r, ok := remotes[remoteName]
+	if !ok {
+		return errhand.BuildDError("error: unknown remote: '%s' ", remoteName).Build()
+	}
+
+	return restoreTable(ctx, dEnv, r, apr.Arg(2), apr)
+}
+
 func removeRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
 	if apr.NArg() != 2 {
 		return errhand.BuildDError("").SetPrintUsage().Build()
@@ -185,12 +244,79 @@ func addRemote(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.Verbos
 	}
 }
 
+func addRemoteMirror(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	remoteName := strings.TrimSpace(apr.Arg(1))
+	mirrorUrl := apr.Arg(2)
+	err := dEnv.AddRemoteMirror(remoteName, mirrorUrl)
+
+	switch {
+	case err == nil:
+		return nil
+	case err == env.ErrRemoteNotFound:
+		return errhand.BuildDError("error: unknown remote: '%s' ", remoteName).Build()
+	case err == env.ErrMirrorAlreadyExists:
+		return errhand.BuildDError("error: '%s' is already a mirror of remote '%s'.", mirrorUrl, remoteName).Build()
+	case errors.Is(err, env.ErrInvalidRemoteURL):
+		return errhand.BuildDError("error: '%s' is not valid.", mirrorUrl).AddCause(err).Build()
+	case errors.Is(err, env.ErrRemoteAddressConflict):
+		return errhand.BuildDError("error: '%s' is already a backup.", mirrorUrl).AddCause(err).Build()
+	default:
+		return errhand.BuildDError("error: Unable to save changes.").AddCause(err).Build()
+	}
+}
+
+func removeRemoteMirror(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	remoteName := strings.TrimSpace(apr.Arg(1))
+	mirrorUrl := apr.Arg(2)
+	err := dEnv.RemoveRemoteMirror(remoteName, mirrorUrl)
+
+	switch err {
+	case nil:
+		return nil
+	case env.ErrRemoteNotFound:
+		return errhand.BuildDError("error: unknown remote: '%s' ", remoteName).Build()
+	case env.ErrMirrorNotFound:
+		return errhand.BuildDError("error: '%s' is not a mirror of remote '%s'.", mirrorUrl, remoteName).Build()
+	default:
+		return errhand.BuildDError("error: Unable to save changes.").AddCause(err).Build()
+	}
+}
+
+// setRemoteRefSpecs replaces the fetch or push specs of a remote with |setSpecs|.
+func setRemoteRefSpecs(dEnv *env.DoltEnv, apr *argparser.ArgParseResults, setSpecs func(name string, specs []string) error) errhand.VerboseError {
+	if apr.NArg() < 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	remoteName := strings.TrimSpace(apr.Arg(1))
+	err := setSpecs(remoteName, apr.Args[2:])
+
+	switch {
+	case err == nil:
+		return nil
+	case err == env.ErrRemoteNotFound:
+		return errhand.BuildDError("error: unknown remote: '%s' ", remoteName).Build()
+	case errors.Is(err, env.ErrInvalidFetchSpec), errors.Is(err, env.ErrInvalidPushSpec):
+		return errhand.BuildDError("error: %s", err.Error()).Build()
+	default:
+		return errhand.BuildDError("error: Unable to save changes.").AddCause(err).Build()
+	}
+}
+
 func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (map[string]string, errhand.VerboseError) {
 	params := map[string]string{}
 
 	var err error
 	switch scheme {
-	case dbfactory.AWSScheme:
+	case dbfactory.AWSScheme, dbfactory.S3Scheme:
 		err = cli.AddAWSParams(remoteUrl, apr, params)
 	case dbfactory.OSSScheme:
 		err = cli.AddOSSParams(remoteUrl, apr, params)
@@ -200,6 +326,9 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 	if err != nil {
 		return nil, errhand.VerboseErrorFromError(err)
 	}
+	if err = cli.AddCompressionParam(apr, params); err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
 
 	return params, nil
 }
@@ -219,6 +348,15 @@ func printRemotes(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.Ver
 			}
 
 			cli.Printf("%s %s %s\n", r.Name, r.Url, paramStr)
+			for _, m := range r.Mirrors {
+				cli.Printf("%s %s (mirror)\n", r.Name, m)
+			}
+			if !reflect.DeepEqual(r.FetchSpecs, env.NewRemote(r.Name, r.Url, nil).FetchSpecs) {
+				cli.Printf("%s %s (fetch)\n", r.Name, strings.Join(r.FetchSpecs, " "))
+			}
+			if len(r.PushSpecs) > 0 {
+				cli.Printf("%s %s (push)\n", r.Name, strings.Join(r.PushSpecs, " "))
+			}
 		} else {
 			cli.Println(r.Name)
 		}
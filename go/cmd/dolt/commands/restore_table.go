@@ -0,0 +1,83 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+// restoreTable restores the table |tblName| of the backup or remote |r| into the working set of |dEnv|, pulling only
+// the chunks of the table. The table is restored from the commit given with --at, which defaults to the current
+// branch, as the table given with --as, which defaults to |tblName|.
+func restoreTable(ctx context.Context, dEnv *env.DoltEnv, r env.Remote, tblName string, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if !dEnv.Valid() {
+		return errhand.BuildDError("error: restore-table must be run from within a dolt data repository").Build()
+	}
+
+	destTblName := apr.GetValueOrDefault(cli.AsParam, tblName)
+	if !doltdb.IsValidTableName(destTblName) {
+		return errhand.BuildDError("error: invalid table name '%s'", destTblName).Build()
+	}
+
+	destRoot, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return errhand.BuildDError("Failed to read working root").AddCause(err).Build()
+	}
+	if ok, err := destRoot.HasTable(ctx, destTblName); err != nil {
+		return errhand.BuildDError("Failed to read working root").AddCause(err).Build()
+	} else if ok {
+		return errhand.BuildDError("error: table '%s' already exists", destTblName).
+			AddDetails("use --%s to restore it under another name", cli.AsParam).Build()
+	}
+
+	commitStr, ok := apr.GetValue(cli.AtParam)
+	if !ok {
+		commitStr = dEnv.RepoStateReader().CWBHeadRef().GetPath()
+	}
+
+	srcDB, err := r.GetRemoteDB(ctx, dEnv.DoltDB.Format(), dEnv)
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+	cs, err := doltdb.NewCommitSpec(commitStr)
+	if err != nil {
+		return errhand.BuildDError("Invalid Commit '%s'", commitStr).Build()
+	}
+	cm, err := srcDB.Resolve(ctx, cs, nil)
+	if err != nil {
+		return errhand.BuildDError("Failed to find commit '%s'", commitStr).AddCause(err).Build()
+	}
+	srcRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return errhand.BuildDError("Failed to read from database").AddCause(err).Build()
+	}
+
+	destRoot, verr := pullTableValue(ctx, dEnv, srcDB, srcRoot, destRoot, downloadLanguage, tblName, destTblName, commitStr)
+	if verr != nil {
+		return verr
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, destRoot)
+	if err != nil {
+		return errhand.BuildDError("Unable to update the working root for local database.").AddCause(err).Build()
+	}
+	return nil
+}
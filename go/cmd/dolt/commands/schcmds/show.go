@@ -16,6 +16,7 @@ package schcmds
 
 import (
 	"context"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -26,7 +27,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
@@ -155,6 +158,10 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 				if err != nil {
 					return errhand.VerboseErrorFromError(err)
 				}
+				stmt, err = withVirtualForeignKeys(ctx, root, tblName, stmt)
+				if err != nil {
+					return errhand.VerboseErrorFromError(err)
+				}
 				cli.Println(stmt)
 				cli.Println()
 			}
@@ -167,3 +174,46 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 
 	return verr
 }
+
+// withVirtualForeignKeys adds the virtual foreign keys declared by |tblName| to its CREATE TABLE statement |stmt|. The
+// engine never sees virtual foreign keys, so they're missing from the statements it generates.
+func withVirtualForeignKeys(ctx context.Context, root *doltdb.RootValue, tblName, stmt string) (string, error) {
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return "", err
+	}
+	declared, _ := fkc.KeysForTable(tblName)
+
+	var defs []string
+	for _, fk := range declared {
+		if !fk.NotEnforced {
+			continue
+		}
+		sch, err := getSchema(ctx, root, fk.TableName)
+		if err != nil {
+			return "", err
+		}
+		parentSch, err := getSchema(ctx, root, fk.ReferencedTableName)
+		if err != nil {
+			return "", err
+		}
+		defs = append(defs, sqlfmt.GenerateCreateTableForeignKeyDefinition(fk, sch, parentSch))
+	}
+
+	// the table definitions end at the last closing parenthesis on a line of its own
+	end := strings.LastIndex(stmt, "\n)")
+	if len(defs) == 0 || end < 0 {
+		return stmt, nil
+	}
+	return stmt[:end] + ",\n" + strings.Join(defs, ",\n") + stmt[end:], nil
+}
+
+func getSchema(ctx context.Context, root *doltdb.RootValue, tblName string) (schema.Schema, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+	return tbl.GetSchema(ctx)
+}
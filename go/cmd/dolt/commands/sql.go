@@ -146,7 +146,7 @@ func (cmd SqlCmd) Docs() *cli.CommandDocumentation {
 func (cmd SqlCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
 	ap.SupportsString(QueryFlag, "q", "SQL query to run", "Runs a single query and exits.")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json, vertical. Defaults to tabular.")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json, vertical, arrow. Defaults to tabular. arrow writes an Arrow IPC stream, which can be read by pyarrow, pandas and Polars.")
 	ap.SupportsString(saveFlag, "s", "saved query name", "Used with --query, save the query to the query catalog with the name provided. Saved queries can be examined in the dolt_query_catalog system table.")
 	ap.SupportsString(executeFlag, "x", "saved query name", "Executes a saved query with the given name.")
 	ap.SupportsFlag(listSavedFlag, "l", "List all saved queries.")
@@ -156,6 +156,9 @@ func (cmd SqlCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(MultiDBDirFlag, "", "directory", "Defines a directory whose subdirectories should all be dolt data repositories accessible as independent databases within. Defaults to the current directory. This is deprecated, you should use `--data-dir` instead")
 	ap.SupportsString(CfgDirFlag, "", "directory", "Defines a directory that contains configuration files for dolt. Defaults to `$data-dir/.doltcfg`. Will only be created if there is a change that affect configuration settings.")
 	ap.SupportsFlag(continueFlag, "c", "Continue running queries on an error. Used for batch mode only.")
+	ap.SupportsInt(chunkStatementsFlag, "", "count", "Used with --batch, executes the script in a series of transactions of at most this many statements each.")
+	ap.SupportsInt(chunkBytesFlag, "", "bytes", "Used with --batch, executes the script in a series of transactions of at most this many bytes of script each.")
+	ap.SupportsString(checkpointFlag, "", "file", "Used with --chunk-statements or --chunk-bytes, records progress in the file given after every committed transaction. If the file exists, statements it records as committed are skipped, resuming a failed script.")
 	ap.SupportsString(fileInputFlag, "f", "input file", "Execute statements from the file given.")
 	ap.SupportsString(PrivsFilePathFlag, "", "privilege file", "Path to a file to load and store users and grants. Defaults to `$doltcfg-dir/privileges.db`. Will only be created if there is a change to privileges.")
 	ap.SupportsString(BranchCtrlPathFlag, "", "branch control file", "Path to a file to load and store branch control permissions. Defaults to `$doltcfg-dir/branch_control.db`. Will only be created if there is a change to branch control permissions.")
@@ -323,6 +326,14 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 
 		_, continueOnError := apr.GetValue(continueFlag)
 
+		chunkOpts, runInChunks, verr := chunkedBatchOptsFromArgs(apr)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+		if runInChunks && continueOnError {
+			return HandleVErrAndExitCode(errhand.BuildDError("Invalid Argument: --%s is not compatible with --%s or --%s", continueFlag, chunkStatementsFlag, chunkBytesFlag).Build(), usage)
+		}
+
 		input := os.Stdin
 		if fileInput, ok := apr.GetValue(fileInputFlag); ok {
 			isTty = false
@@ -345,6 +356,11 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 			if err != nil {
 				return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 			}
+		} else if runInChunks {
+			verr = execChunkedBatch(sqlCtx, se, input, chunkOpts)
+			if verr != nil {
+				return HandleVErrAndExitCode(verr, usage)
+			}
 		} else if runInBatchMode {
 			verr = execBatch(sqlCtx, se, input, continueOnError)
 			if verr != nil {
@@ -653,8 +669,10 @@ func GetResultFormat(format string) (engine.PrintResultFormat, errhand.VerboseEr
 		return engine.FormatNull, nil
 	case "vertical":
 		return engine.FormatVertical, nil
+	case "arrow":
+		return engine.FormatArrow, nil
 	default:
-		return engine.FormatTabular, errhand.BuildDError("Invalid argument for --result-format. Valid values are tabular, csv, json").Build()
+		return engine.FormatTabular, errhand.BuildDError("Invalid argument for --result-format. Valid values are tabular, csv, json, arrow").Build()
 	}
 }
 
@@ -0,0 +1,270 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/dustin/go-humanize"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	chunkStatementsFlag = "chunk-statements"
+	chunkBytesFlag      = "chunk-bytes"
+	checkpointFlag      = "checkpoint"
+)
+
+var errTransactionStatementInChunk = errors.New("transaction control statements are not supported when running in chunks")
+
+// chunkedBatchOpts configures how a batch script is split into transactions.
+type chunkedBatchOpts struct {
+	// maxStatements is the number of statements after which a chunk is committed, or 0 for no limit
+	maxStatements int
+	// maxBytes is the number of bytes of script after which a chunk is committed, or 0 for no limit
+	maxBytes int64
+	// checkpointPath is the file that records the progress of the script after every committed chunk. Optional.
+	checkpointPath string
+}
+
+// batchCheckpoint records how much of a script has been committed by a chunked batch.
+type batchCheckpoint struct {
+	Chunks     int   `json:"chunks"`
+	Statements int   `json:"statements"`
+	Bytes      int64 `json:"bytes"`
+}
+
+// chunkedBatchOptsFromArgs returns the chunking options given on the command line, and whether chunking was requested.
+func chunkedBatchOptsFromArgs(apr *argparser.ArgParseResults) (chunkedBatchOpts, bool, errhand.VerboseError) {
+	var opts chunkedBatchOpts
+	stmts, hasStmts := apr.GetInt(chunkStatementsFlag)
+	bytes, hasBytes := apr.GetInt(chunkBytesFlag)
+	checkpoint, hasCheckpoint := apr.GetValue(checkpointFlag)
+
+	if !hasStmts && !hasBytes {
+		if hasCheckpoint {
+			return opts, false, errhand.BuildDError("Invalid Argument: --%s requires --%s or --%s", checkpointFlag, chunkStatementsFlag, chunkBytesFlag).Build()
+		}
+		return opts, false, nil
+	}
+	if !apr.Contains(BatchFlag) {
+		return opts, false, errhand.BuildDError("Invalid Argument: --%s and --%s are only used with --batch|-b", chunkStatementsFlag, chunkBytesFlag).Build()
+	}
+	if (hasStmts && stmts <= 0) || (hasBytes && bytes <= 0) {
+		return opts, false, errhand.BuildDError("Invalid Argument: --%s and --%s must be positive", chunkStatementsFlag, chunkBytesFlag).Build()
+	}
+
+	opts.maxStatements = stmts
+	opts.maxBytes = int64(bytes)
+	opts.checkpointPath = checkpoint
+	return opts, true, nil
+}
+
+func readBatchCheckpoint(path string) (batchCheckpoint, error) {
+	var cp batchCheckpoint
+	if path == "" {
+		return cp, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	} else if err != nil {
+		return cp, err
+	}
+
+	err = json.Unmarshal(data, &cp)
+	if err != nil {
+		return cp, fmt.Errorf("invalid checkpoint file %s: %w", path, err)
+	}
+	return cp, nil
+}
+
+func writeBatchCheckpoint(path string, cp batchCheckpoint) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	// write and rename so that a crash never leaves a partially written checkpoint behind
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// execChunkedBatch streams the statements in |input| and executes them in a series of transactions, each one bounded by
+// the limits in |opts|. If |opts| has a checkpoint file, progress is recorded there after every committed chunk, and
+// statements recorded as committed by a previous run are skipped. On an error the current chunk is rolled back.
+func execChunkedBatch(ctx *sql.Context, se *engine.SqlEngine, input io.Reader, opts chunkedBatchOpts) errhand.VerboseError {
+	cp, err := readBatchCheckpoint(opts.checkpointPath)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	resumeFrom := cp.Statements
+	if resumeFrom > 0 {
+		cli.Printf("Resuming after %s committed statements\n", humanize.Comma(int64(resumeFrom)))
+	}
+
+	p := cli.NewEphemeralPrinter()
+	start := time.Now()
+	printProgress := func() {
+		p.Printf("Committed %s chunks, %s statements, %s of script in %s.",
+			humanize.Comma(int64(cp.Chunks)),
+			humanize.Comma(int64(cp.Statements)),
+			humanize.Bytes(uint64(cp.Bytes)),
+			time.Since(start).Round(time.Second),
+		)
+		p.Display()
+	}
+
+	var stmtNum int
+	var inChunk bool
+	var chunkStatements int
+	var chunkBytes int64
+
+	commitChunk := func() error {
+		if !inChunk {
+			return nil
+		}
+		if err := execTransactionStatement(ctx, se, "COMMIT"); err != nil {
+			return err
+		}
+		inChunk = false
+		cp.Chunks++
+		cp.Statements += chunkStatements
+		cp.Bytes += chunkBytes
+		chunkStatements, chunkBytes = 0, 0
+		printProgress()
+		return writeBatchCheckpoint(opts.checkpointPath, cp)
+	}
+
+	fail := func(line int, query string, err error) errhand.VerboseError {
+		if inChunk {
+			if rbErr := execTransactionStatement(ctx, se, "ROLLBACK"); rbErr != nil {
+				cli.PrintErrln(rbErr.Error())
+			}
+		}
+		cli.Println()
+		bdr := errhand.BuildDError("error on line %d for query %s", line, query).AddCause(err)
+		if opts.checkpointPath != "" {
+			bdr = bdr.AddDetails("%s statements were committed; run the same command again to resume from %s", humanize.Comma(int64(cp.Statements)), opts.checkpointPath)
+		}
+		return bdr.Build()
+	}
+
+	scanner := NewSqlStatementScanner(input)
+	for scanner.Scan() {
+		query := scanner.Text()
+		sqlStatement, err := sqlparser.Parse(query)
+		if err == sqlparser.ErrEmpty {
+			continue
+		} else if err != nil {
+			return fail(scanner.statementStartLine, query, err)
+		}
+
+		stmtNum++
+		if stmtNum <= resumeFrom {
+			continue
+		}
+
+		switch sqlStatement.(type) {
+		case *sqlparser.Begin, *sqlparser.Commit, *sqlparser.Rollback:
+			return fail(scanner.statementStartLine, query, errTransactionStatementInChunk)
+		}
+
+		if !inChunk {
+			if err := execTransactionStatement(ctx, se, "START TRANSACTION"); err != nil {
+				return fail(scanner.statementStartLine, query, err)
+			}
+			inChunk = true
+		}
+
+		ctx.SetQueryTime(time.Now())
+		if err := execChunkedStatement(ctx, se, query, sqlStatement); err != nil {
+			return fail(scanner.statementStartLine, query, err)
+		}
+		chunkStatements++
+		chunkBytes += int64(len(scanner.Bytes()))
+
+		if (opts.maxStatements > 0 && chunkStatements >= opts.maxStatements) || (opts.maxBytes > 0 && chunkBytes >= opts.maxBytes) {
+			if err := commitChunk(); err != nil {
+				return fail(scanner.statementStartLine, query, err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fail(scanner.statementStartLine, "", err)
+	}
+	if err := commitChunk(); err != nil {
+		return fail(scanner.statementStartLine, "", err)
+	}
+
+	cli.Println()
+
+	// the whole script is committed, so a later run with the same checkpoint should start from the beginning
+	if opts.checkpointPath != "" {
+		if err := os.Remove(opts.checkpointPath); err != nil && !os.IsNotExist(err) {
+			return errhand.VerboseErrorFromError(err)
+		}
+	}
+
+	return nil
+}
+
+// execChunkedStatement executes a single statement of a chunked batch. Results are only printed for statements that
+// read data.
+func execChunkedStatement(ctx *sql.Context, se *engine.SqlEngine, query string, sqlStatement sqlparser.Statement) error {
+	sqlSch, rowIter, err := processParsedQuery(ctx, query, se, sqlStatement)
+	if err != nil || rowIter == nil {
+		return err
+	}
+
+	switch sqlStatement.(type) {
+	case *sqlparser.Select, *sqlparser.OtherRead, *sqlparser.Show, *sqlparser.Explain, *sqlparser.Union:
+		cli.Println()
+		return engine.PrettyPrintResults(ctx, se.GetResultFormat(), sqlSch, rowIter)
+	default:
+		_, err = sql.RowIterToRows(ctx, nil, rowIter)
+		return err
+	}
+}
+
+func execTransactionStatement(ctx *sql.Context, se *engine.SqlEngine, query string) error {
+	_, rowIter, err := se.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	_, err = sql.RowIterToRows(ctx, nil, rowIter)
+	return err
+}
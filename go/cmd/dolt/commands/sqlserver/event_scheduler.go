@@ -0,0 +1,607 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+const (
+	eventSchedulerInterval = time.Second
+	eventSchedulerName     = "event_scheduler"
+	eventSchedulerEmail    = "event_scheduler@localhost"
+
+	// maxEventCommitRetries is how many times committing the writes of an event is retried when the branch
+	// changes meanwhile
+	maxEventCommitRetries = 5
+)
+
+var (
+	// ErrEventConflict is returned when the writes of an event conflict with changes made to its branch while it ran.
+	ErrEventConflict = errors.New("the writes of the event conflict with other changes to its branch")
+	// ErrUnsupportedEventStatement is returned for events whose body uses procedural statements.
+	ErrUnsupportedEventStatement = errors.New("event bodies can't use procedural statements")
+)
+
+// eventScheduler runs the events of the branches named by @@dolt_event_scheduler_branches. Events are read from the
+// dolt_schemas table of each branch, so each branch runs the events defined on it. Events run with the privileges of
+// their DEFINER, on the HEAD of their branch, and the changes they make are committed to it with the event scheduler
+// as the author. Uncommitted changes in the working set of the branch are left out of these commits.
+type eventScheduler struct {
+	sqlEngine *engine.SqlEngine
+	lgr       *logrus.Entry
+
+	// schedules are the schedules of the recurring events found so far, keyed by their revision database and name
+	schedules map[string]eventSchedule
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// eventSchedule is when a recurring event runs next.
+type eventSchedule struct {
+	// fragment is the CREATE EVENT statement the schedule was computed from
+	fragment string
+	next     time.Time
+	// done is set once the event is past its ENDS time
+	done bool
+}
+
+// eventBranch is a branch of a database events run on.
+type eventBranch struct {
+	db     dsess.SqlDatabase
+	branch string
+}
+
+// revisionDatabase returns the name of the revision database of the branch.
+func (b eventBranch) revisionDatabase() string {
+	return b.db.Name() + dsess.DbRevisionDelimiter + b.branch
+}
+
+// scheduledEvent is an enabled event of a branch.
+type scheduledEvent struct {
+	name     string
+	fragment string
+	created  time.Time
+	details  sql.EventDetails
+	// every is the interval between the runs of a recurring event, and nil for events that run once
+	every *expression.TimeDelta
+	// statements are the statements of the body of the event, see eventStatements
+	statements []sql.Node
+}
+
+func newEventScheduler(sqlEngine *engine.SqlEngine, lgr *logrus.Logger) *eventScheduler {
+	return &eventScheduler{
+		sqlEngine: sqlEngine,
+		lgr:       logrus.NewEntry(lgr).WithField("component", "event_scheduler"),
+		schedules: make(map[string]eventSchedule),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Run starts running events in the background until Stop is called.
+func (es *eventScheduler) Run(ctx context.Context) {
+	es.wg.Add(1)
+	go func() {
+		defer es.wg.Done()
+		ticker := time.NewTicker(eventSchedulerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-es.stop:
+				return
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				es.runDueEvents(ctx, now)
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler, waiting for any running event to finish.
+func (es *eventScheduler) Stop() {
+	close(es.stop)
+	es.wg.Wait()
+}
+
+// eventSchedulerBranches returns the branches events run on. An entry of @@dolt_event_scheduler_branches is either
+// a branch name, for the branch of every database, or a |db/branch| name.
+func eventSchedulerBranches() []string {
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.EventSchedulerBranches)
+	if !ok {
+		return nil
+	}
+	s, _ := val.(string)
+	var branches []string
+	for _, b := range strings.Split(s, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			branches = append(branches, b)
+		}
+	}
+	return branches
+}
+
+func (es *eventScheduler) runDueEvents(ctx context.Context, now time.Time) {
+	branches := eventSchedulerBranches()
+	if len(branches) == 0 {
+		return
+	}
+
+	sqlCtx, err := es.sqlEngine.NewLocalContext(ctx)
+	if err != nil {
+		es.lgr.Errorf("error creating context: %v", err)
+		return
+	}
+	schedules := make(map[string]eventSchedule)
+	for _, b := range es.eventBranches(sqlCtx, branches) {
+		revDb := b.revisionDatabase()
+		events, err := es.branchEvents(ctx, revDb)
+		if err != nil {
+			es.lgr.Warnf("error reading the events of %s: %v", revDb, err)
+			continue
+		}
+		for _, ev := range events {
+			key := revDb + "." + ev.name
+			run, complete := es.isDue(key, ev, now, schedules)
+			if run {
+				if err = es.runEvent(ctx, b, ev); err != nil {
+					es.lgr.Warnf("error running event %s on %s: %v", ev.name, revDb, err)
+				}
+			}
+			if complete {
+				if err = es.completeEvent(ctx, revDb, ev); err != nil {
+					es.lgr.Warnf("error completing event %s on %s: %v", ev.name, revDb, err)
+				}
+			}
+		}
+	}
+	es.schedules = schedules
+}
+
+// isDue returns whether the event |ev| runs at |now|, and whether it has completed, in which case it is dropped, or
+// disabled if it is defined with ON COMPLETION PRESERVE. Events that run once are due from their AT time on. Recurring
+// events run at their STARTS time and every interval after it until their ENDS time. Runs of recurring events that
+// were due before they were found, e.g. while the server was down, are skipped, like MySQL does. The schedules of
+// recurring events are recorded in |schedules|.
+func (es *eventScheduler) isDue(key string, ev scheduledEvent, now time.Time, schedules map[string]eventSchedule) (run, complete bool) {
+	if ev.every == nil {
+		due := !now.Before(ev.details.ExecuteAt)
+		return due, due
+	}
+
+	sched, ok := es.schedules[key]
+	if !ok || sched.fragment != ev.fragment {
+		sched = ev.schedule(now)
+	}
+	if !sched.done && now.Before(sched.next) {
+		schedules[key] = sched
+		return false, false
+	}
+
+	run = !sched.done
+	sched = ev.schedule(now.Add(time.Nanosecond))
+	schedules[key] = sched
+	return run, sched.done
+}
+
+// schedule returns the first run of the recurring event |ev| at or after |after|.
+func (ev scheduledEvent) schedule(after time.Time) eventSchedule {
+	next := nextEventRun(ev.details.Starts, *ev.every, after)
+	return eventSchedule{
+		fragment: ev.fragment,
+		next:     next,
+		done:     ev.details.HasEnds && next.After(ev.details.Ends),
+	}
+}
+
+// nextEventRun returns the first time at or after |after| which is |starts| plus a multiple of |every|.
+func nextEventRun(starts time.Time, every expression.TimeDelta, after time.Time) time.Time {
+	if !after.After(starts) {
+		return starts
+	}
+	if every.Years == 0 && every.Months == 0 {
+		d := every.Add(starts).Sub(starts)
+		n := after.Sub(starts) / d
+		if next := starts.Add(n * d); !next.Before(after) {
+			return next
+		}
+		return starts.Add((n + 1) * d)
+	}
+	// months have different lengths, so each run is computed from |starts|
+	for n := int64(1); ; n++ {
+		next := expression.TimeDelta{
+			Years:        n * every.Years,
+			Months:       n * every.Months,
+			Days:         n * every.Days,
+			Hours:        n * every.Hours,
+			Minutes:      n * every.Minutes,
+			Seconds:      n * every.Seconds,
+			Microseconds: n * every.Microseconds,
+		}.Add(starts)
+		if !next.Before(after) {
+			return next
+		}
+	}
+}
+
+// eventBranches returns the branches named by |branches| that exist.
+func (es *eventScheduler) eventBranches(ctx *sql.Context, branches []string) []eventBranch {
+	var ebs []eventBranch
+	for _, db := range es.sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.AllDatabases(ctx) {
+		if pdb, ok := db.(mysql_db.PrivilegedDatabase); ok {
+			db = pdb.Unwrap()
+		}
+		sqlDb, ok := db.(dsess.SqlDatabase)
+		if !ok || sqlDb.Revision() != "" {
+			continue
+		}
+		for _, branch := range branches {
+			if dbName, b, ok := strings.Cut(branch, dsess.DbRevisionDelimiter); ok {
+				if !strings.EqualFold(dbName, sqlDb.Name()) {
+					continue
+				}
+				branch = b
+			}
+			branchName, exists, err := sqlDb.DbData().Ddb.HasBranch(ctx, branch)
+			if err != nil {
+				es.lgr.Warnf("error reading the branches of %s: %v", sqlDb.Name(), err)
+				continue
+			}
+			if exists {
+				ebs = append(ebs, eventBranch{db: sqlDb, branch: branchName})
+			}
+		}
+	}
+	return ebs
+}
+
+// branchEvents returns the enabled events defined on the revision database |revDb|. Events that can't be run are
+// logged and skipped.
+func (es *eventScheduler) branchEvents(ctx context.Context, revDb string) ([]scheduledEvent, error) {
+	sqlCtx, err := es.sqlEngine.NewLocalContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = es.query(sqlCtx, fmt.Sprintf("USE `%s`", revDb)); err != nil {
+		return nil, err
+	}
+	db, err := es.sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.Database(sqlCtx, revDb)
+	if err != nil {
+		return nil, err
+	}
+	eventDb, ok := db.(sql.EventDatabase)
+	if !ok {
+		return nil, nil
+	}
+	definitions, err := eventDb.GetEvents(sqlCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []scheduledEvent
+	for _, ed := range definitions {
+		ev, ok, err := parseScheduledEvent(sqlCtx, ed)
+		if err != nil {
+			es.lgr.Warnf("skipping event %s on %s: %v", ed.Name, revDb, err)
+		} else if ok {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+// parseScheduledEvent parses the CREATE EVENT statement of the event |ed| with the current database of |ctx|. It
+// returns false for events that aren't enabled, and an error for events whose statement can't be run.
+func parseScheduledEvent(ctx *sql.Context, ed sql.EventDefinition) (scheduledEvent, bool, error) {
+	node, err := parse.Parse(ctx, ed.CreateStatement)
+	if err != nil {
+		return scheduledEvent{}, false, err
+	}
+	ce, ok := node.(*plan.CreateEvent)
+	if !ok {
+		return scheduledEvent{}, false, sql.ErrEventCreateStatementInvalid.New(ed.CreateStatement)
+	}
+	if ce.Status != plan.EventStatus_Enable {
+		return scheduledEvent{}, false, nil
+	}
+	details, err := ce.GetEventDetails(ctx, ed.CreatedAt)
+	if err != nil {
+		return scheduledEvent{}, false, err
+	}
+
+	ev := scheduledEvent{
+		name:     ed.Name,
+		fragment: ed.CreateStatement,
+		created:  ed.CreatedAt,
+		details:  details,
+	}
+	ev.statements, err = eventStatements(ce.DefinitionNode)
+	if err != nil {
+		return scheduledEvent{}, false, err
+	}
+	if ce.Every != nil {
+		ev.every, err = ce.Every.EvalDelta(ctx, nil)
+		if err != nil {
+			return scheduledEvent{}, false, err
+		}
+		if ev.every == nil || !ev.every.Add(details.Starts).After(details.Starts) {
+			return scheduledEvent{}, false, fmt.Errorf("event %s must run every positive interval", ed.Name)
+		}
+	}
+	return ev, true, nil
+}
+
+// eventStatements returns the statements run by the event body |body|. The statements of a BEGIN ... END body are
+// run one after the other. Procedural statements, such as DECLARE, IF or loops, need the stored procedure runtime and
+// aren't supported in event bodies.
+func eventStatements(body sql.Node) ([]sql.Node, error) {
+	statements := []sql.Node{body}
+	if block, ok := body.(*plan.BeginEndBlock); ok {
+		statements = block.Children()
+	}
+	for _, stmt := range statements {
+		var unsupported sql.Node
+		transform.Inspect(stmt, func(n sql.Node) bool {
+			switch n.(type) {
+			case expression.ProcedureReferencable, *plan.IfElseBlock, *plan.CaseStatement, *plan.Loop, *plan.Repeat,
+				*plan.While, *plan.Iterate, *plan.Leave, *plan.Signal:
+				unsupported = n
+				return false
+			}
+			return true
+		})
+		if unsupported != nil {
+			return nil, fmt.Errorf("%w: %T", ErrUnsupportedEventStatement, unsupported)
+		}
+	}
+	return statements, nil
+}
+
+// runEvent runs the body of |ev| on the branch |b| with the privileges of the definer of the event. The body runs on
+// the HEAD of the branch rather than its working set, so that only the writes of the event are committed.
+func (es *eventScheduler) runEvent(ctx context.Context, b eventBranch, ev scheduledEvent) error {
+	dbName := b.db.Name()
+	sqlCtx, err := es.sqlEngine.NewDefaultContext(ctx)
+	if err != nil {
+		return err
+	}
+	// the privileges of the definer are granted on the database, not on its revision databases, so the session
+	// switches to the branch of the event like DOLT_CHECKOUT does
+	sqlCtx.Session.SetClient(eventDefiner(ev.details.Definer))
+	if _, err = es.query(sqlCtx, fmt.Sprintf("USE `%s`", dbName)); err != nil {
+		return err
+	}
+	// the writes of the event are committed by commitEventWrites, never by the transaction
+	if _, err = es.query(sqlCtx, "START TRANSACTION"); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := es.query(sqlCtx, "ROLLBACK"); err != nil {
+			es.lgr.Warnf("error rolling back event %s on %s: %v", ev.name, b.revisionDatabase(), err)
+		}
+	}()
+
+	dSess := dsess.DSessFromSess(sqlCtx.Session)
+	wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef(b.branch))
+	if err != nil {
+		return err
+	}
+	if err = dSess.SwitchWorkingSet(sqlCtx, dbName, wsRef); err != nil {
+		return err
+	}
+	roots, ok := dSess.GetRoots(sqlCtx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	if err = dSess.SetRoot(sqlCtx, dbName, roots.Head); err != nil {
+		return err
+	}
+
+	for _, stmt := range ev.statements {
+		sch, iter, err := es.sqlEngine.GetUnderlyingEngine().QueryNodeWithBindings(sqlCtx, ev.details.Definition, stmt, nil)
+		if err != nil {
+			return err
+		}
+		if _, err = sql.RowIterToRows(sqlCtx, sch, iter); err != nil {
+			return err
+		}
+	}
+
+	after, ok := dSess.GetRoots(sqlCtx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return err
+	}
+	afterHash, err := after.Working.HashOf()
+	if err != nil || headHash == afterHash {
+		return err
+	}
+	return es.commitEventWrites(sqlCtx, b, roots.Head, after.Working, fmt.Sprintf("Event %s on branch %s", ev.name, b.branch))
+}
+
+// completeEvent drops the event |ev| of the revision database |revDb|, or disables it if it is defined with ON
+// COMPLETION PRESERVE. Events are read from the working set of their branch, so that is where they are completed.
+func (es *eventScheduler) completeEvent(ctx context.Context, revDb string, ev scheduledEvent) error {
+	sqlCtx, err := es.sqlEngine.NewLocalContext(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = es.query(sqlCtx, fmt.Sprintf("USE `%s`", revDb)); err != nil {
+		return err
+	}
+	if _, err = es.query(sqlCtx, "START TRANSACTION"); err != nil {
+		return err
+	}
+	db, err := es.sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.Database(sqlCtx, revDb)
+	if err != nil {
+		return err
+	}
+	eventDb, ok := db.(sql.EventDatabase)
+	if !ok {
+		return sql.ErrEventsNotSupported.New(revDb)
+	}
+	if ev.details.OnCompletionPreserve {
+		details := ev.details
+		details.Status = plan.EventStatus_Disable.String()
+		err = eventDb.UpdateEvent(sqlCtx, sql.EventDefinition{
+			Name:            ev.name,
+			CreateStatement: details.CreateEventStatement(),
+			CreatedAt:       ev.created,
+			LastAltered:     time.Now(),
+		})
+	} else {
+		err = eventDb.DropEvent(sqlCtx, ev.name)
+	}
+	if err != nil {
+		_, _ = es.query(sqlCtx, "ROLLBACK")
+		return err
+	}
+	_, err = es.query(sqlCtx, "COMMIT")
+	return err
+}
+
+// commitEventWrites commits the changes an event made from |base|, the HEAD root of the branch |b| it ran on, to
+// |eventRoot|. The changes are merged into the HEAD and the working set of the branch as they are when committing, so
+// that the uncommitted changes of other sessions stay in the working set and out of the commit.
+func (es *eventScheduler) commitEventWrites(ctx *sql.Context, b eventBranch, base, eventRoot *doltdb.RootValue, msg string) error {
+	ddb := b.db.DbData().Ddb
+	headRef := ref.NewBranchRef(b.branch)
+	wsRef, err := ref.WorkingSetRefForHead(headRef)
+	if err != nil {
+		return err
+	}
+	var opts editor.Options
+	if db, ok := b.db.(interface{ EditOptions() editor.Options }); ok {
+		opts = db.EditOptions()
+	}
+
+	for i := 0; i < maxEventCommitRetries; i++ {
+		head, err := ddb.ResolveCommitRef(ctx, headRef)
+		if err != nil {
+			return err
+		}
+		headRoot, err := head.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err != nil {
+			return err
+		}
+		prevHash, err := ws.HashOf()
+		if err != nil {
+			return err
+		}
+
+		committed, err := mergeEventWrites(ctx, headRoot, eventRoot, base, head, opts)
+		if err != nil {
+			return err
+		}
+		working, err := mergeEventWrites(ctx, ws.WorkingRoot(), eventRoot, base, head, opts)
+		if err != nil {
+			return err
+		}
+		staged, err := mergeEventWrites(ctx, ws.StagedRoot(), eventRoot, base, head, opts)
+		if err != nil {
+			return err
+		}
+
+		meta, err := datas.NewCommitMeta(eventSchedulerName, eventSchedulerEmail, msg)
+		if err != nil {
+			return err
+		}
+		pending, err := ddb.NewPendingCommit(ctx, doltdb.Roots{Head: headRoot, Working: working, Staged: committed}, nil, meta)
+		if err != nil {
+			return err
+		}
+		_, err = ddb.CommitWithWorkingSet(ctx, headRef, wsRef, pending, ws.WithWorkingRoot(working).WithStagedRoot(staged), prevHash, &datas.WorkingSetMeta{
+			Name:        eventSchedulerName,
+			Email:       eventSchedulerEmail,
+			Timestamp:   uint64(time.Now().Unix()),
+			Description: msg,
+		})
+		if errors.Is(err, datas.ErrOptimisticLockFailed) || errors.Is(err, datas.ErrMergeNeeded) {
+			continue
+		}
+		return err
+	}
+	return datas.ErrOptimisticLockFailed
+}
+
+// mergeEventWrites applies the changes made by an event from |base| to |eventRoot| to |root|.
+func mergeEventWrites(ctx *sql.Context, root, eventRoot, base *doltdb.RootValue, baseCommit *doltdb.Commit, opts editor.Options) (*doltdb.RootValue, error) {
+	rootHash, err := root.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	baseHash, err := base.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if rootHash == baseHash {
+		return eventRoot, nil
+	}
+
+	result, err := merge.MergeRoots(ctx, root, eventRoot, base, baseCommit, baseCommit, opts, merge.MergeOpts{})
+	if err != nil {
+		return nil, err
+	}
+	if result.HasMergeArtifacts() {
+		return nil, ErrEventConflict
+	}
+	return result.Root, nil
+}
+
+// eventDefiner returns the client that runs the events defined by |definer|, such as `user`@`host`.
+func eventDefiner(definer string) sql.Client {
+	user, host := definer, "%"
+	if i := strings.LastIndex(definer, "@"); i >= 0 {
+		user, host = definer[:i], definer[i+1:]
+	}
+	return sql.Client{User: strings.Trim(user, "`'\""), Address: strings.Trim(host, "`'\"")}
+}
+
+func (es *eventScheduler) query(ctx *sql.Context, query string) ([]sql.Row, error) {
+	sch, iter, err := es.sqlEngine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, sch, iter)
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduledEvent(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		fragment   string
+		ok         bool
+		err        bool
+		every      *expression.TimeDelta
+		at         time.Time
+		starts     time.Time
+		ends       time.Time
+		preserve   bool
+		body       string
+		statements int
+	}{
+		{
+			name:       "every minute",
+			fragment:   "CREATE EVENT e1 ON SCHEDULE EVERY 1 MINUTE DO INSERT INTO t VALUES (now())",
+			ok:         true,
+			every:      &expression.TimeDelta{Minutes: 1},
+			starts:     created,
+			body:       "INSERT INTO t VALUES (now())",
+			statements: 1,
+		},
+		{
+			name:       "stored statement",
+			fragment:   "CREATE DEFINER = `root`@`localhost` EVENT `e2` ON SCHEDULE EVERY 2 HOUR STARTS '2023-02-01 00:00:00' ENDS '2023-03-01 00:00:00' ON COMPLETION PRESERVE ENABLE COMMENT 'cleanup' DO delete from t",
+			ok:         true,
+			every:      &expression.TimeDelta{Hours: 2},
+			starts:     time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			ends:       time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			preserve:   true,
+			body:       "delete from t",
+			statements: 1,
+		},
+		{
+			name:       "one time",
+			fragment:   "CREATE EVENT e3 ON SCHEDULE AT '2023-01-02 00:00:00' DO DELETE FROM t",
+			ok:         true,
+			at:         time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			body:       "DELETE FROM t",
+			statements: 1,
+		},
+		{
+			name:       "months",
+			fragment:   "CREATE EVENT e4 ON SCHEDULE EVERY 1 MONTH DO DELETE FROM t",
+			ok:         true,
+			every:      &expression.TimeDelta{Months: 1},
+			starts:     created,
+			body:       "DELETE FROM t",
+			statements: 1,
+		},
+		{
+			name:       "compound body",
+			fragment:   "CREATE EVENT e5 ON SCHEDULE EVERY 1 DAY DO BEGIN DELETE FROM t; INSERT INTO t VALUES (now()); END",
+			ok:         true,
+			every:      &expression.TimeDelta{Days: 1},
+			starts:     created,
+			body:       "BEGIN DELETE FROM t; INSERT INTO t VALUES (now()); END",
+			statements: 2,
+		},
+		{
+			name:     "procedural body",
+			fragment: "CREATE EVENT e9 ON SCHEDULE EVERY 1 DAY DO BEGIN DECLARE n INT; SET n = 1; DELETE FROM t WHERE id = n; END",
+			err:      true,
+		},
+		{
+			name:     "conditional body",
+			fragment: "CREATE EVENT e10 ON SCHEDULE EVERY 1 DAY DO BEGIN IF 1 = 1 THEN DELETE FROM t; END IF; END",
+			err:      true,
+		},
+		{
+			name:     "disabled",
+			fragment: "CREATE EVENT e6 ON SCHEDULE EVERY 1 DAY DISABLE DO DELETE FROM t",
+		},
+		{
+			name:     "disabled on replicas",
+			fragment: "CREATE EVENT e7 ON SCHEDULE EVERY 1 DAY DISABLE ON SLAVE DO DELETE FROM t",
+		},
+		{
+			name:     "zero interval",
+			fragment: "CREATE EVENT e8 ON SCHEDULE EVERY 0 SECOND DO DELETE FROM t",
+			err:      true,
+		},
+		{
+			name:     "not an event",
+			fragment: "DELETE FROM t",
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := sql.NewEmptyContext()
+			ctx.SetCurrentDatabase("mydb")
+			ev, ok, err := parseScheduledEvent(ctx, sql.EventDefinition{Name: "e", CreateStatement: test.fragment, CreatedAt: created})
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.ok, ok)
+			if !test.ok {
+				return
+			}
+			assert.Equal(t, test.every, ev.every)
+			assert.Equal(t, test.body, ev.details.Definition)
+			assert.Len(t, ev.statements, test.statements)
+			assert.Equal(t, test.preserve, ev.details.OnCompletionPreserve)
+			if test.every == nil {
+				assert.True(t, test.at.Equal(ev.details.ExecuteAt), "at %s", ev.details.ExecuteAt)
+			} else {
+				assert.True(t, test.starts.Equal(ev.details.Starts), "starts %s", ev.details.Starts)
+				assert.Equal(t, !test.ends.IsZero(), ev.details.HasEnds)
+				if ev.details.HasEnds {
+					assert.True(t, test.ends.Equal(ev.details.Ends), "ends %s", ev.details.Ends)
+				}
+			}
+		})
+	}
+}
+
+func TestNextEventRun(t *testing.T) {
+	starts := time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		every    expression.TimeDelta
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "before starts",
+			every:    expression.TimeDelta{Hours: 1},
+			after:    starts.Add(-time.Hour),
+			expected: starts,
+		},
+		{
+			name:     "at starts",
+			every:    expression.TimeDelta{Hours: 1},
+			after:    starts,
+			expected: starts,
+		},
+		{
+			name:     "between runs",
+			every:    expression.TimeDelta{Minutes: 10},
+			after:    starts.Add(25 * time.Minute),
+			expected: starts.Add(30 * time.Minute),
+		},
+		{
+			name:     "on a run",
+			every:    expression.TimeDelta{Minutes: 10},
+			after:    starts.Add(30 * time.Minute),
+			expected: starts.Add(30 * time.Minute),
+		},
+		{
+			name:     "months from the end of a month",
+			every:    expression.TimeDelta{Months: 1},
+			after:    starts.Add(24 * time.Hour),
+			expected: time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "months do not drift",
+			every:    expression.TimeDelta{Months: 1},
+			after:    time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 3, 31, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := nextEventRun(starts, test.every, test.after)
+			assert.True(t, test.expected.Equal(next), "expected %s, got %s", test.expected, next)
+		})
+	}
+}
+
+func TestEventDefiner(t *testing.T) {
+	assert.Equal(t, sql.Client{User: "root", Address: "localhost"}, eventDefiner("`root`@`localhost`"))
+	assert.Equal(t, sql.Client{User: "app", Address: "%"}, eventDefiner("'app'@'%'"))
+	assert.Equal(t, sql.Client{User: "app", Address: "%"}, eventDefiner("app"))
+}
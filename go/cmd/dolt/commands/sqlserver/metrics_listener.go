@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/utils/version"
 
 	"github.com/dolthub/go-mysql-server/server"
@@ -119,3 +120,40 @@ func (ml *metricsListener) Close() {
 	prometheus.Unregister(ml.gaugeConcurrentQueries)
 	prometheus.Unregister(ml.histQueryDur)
 }
+
+// registerRemotesapiLoadMetrics registers metrics of the download queue of |srv|, the remotesapi server of this
+// instance of dolt sql server.
+func registerRemotesapiLoadMetrics(labels prometheus.Labels, srv *remotesrv.Server) {
+	stat := func(f func(remotesrv.LoadStats) float64) func() float64 {
+		return func() float64 {
+			stats, _ := srv.LoadStats()
+			return f(stats)
+		}
+	}
+
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "dss_remotesapi_downloads_in_flight",
+		Help:        "Number of table file downloads being served by the remotesapi server",
+		ConstLabels: labels,
+	}, stat(func(s remotesrv.LoadStats) float64 { return float64(s.InFlight) })))
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "dss_remotesapi_download_queue_depth",
+		Help:        "Number of table file downloads waiting to be served by the remotesapi server",
+		ConstLabels: labels,
+	}, stat(func(s remotesrv.LoadStats) float64 { return float64(s.Queued) })))
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "dss_remotesapi_download_clients",
+		Help:        "Number of clients with table file downloads being served or waiting to be served by the remotesapi server",
+		ConstLabels: labels,
+	}, stat(func(s remotesrv.LoadStats) float64 { return float64(s.Clients) })))
+	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "dss_remotesapi_downloads_served",
+		Help:        "Count of table file downloads served by the remotesapi server",
+		ConstLabels: labels,
+	}, stat(func(s remotesrv.LoadStats) float64 { return float64(s.Served) })))
+	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "dss_remotesapi_downloads_shed",
+		Help:        "Count of table file downloads rejected by the remotesapi server because its queue was full or they waited too long",
+		ConstLabels: labels,
+	}, stat(func(s remotesrv.LoadStats) float64 { return float64(s.Shed) })))
+}
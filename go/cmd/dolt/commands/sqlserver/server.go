@@ -19,10 +19,12 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/server"
@@ -30,17 +32,23 @@ import (
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/flightsrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 // Serve starts a MySQL-compatible server. Returns any errors that were encountered.
@@ -127,6 +135,45 @@ func Serve(
 		}
 	}
 
+	// Databases created by the server pick up the default conjoin policy, and the ones already loaded are changed
+	nbs.DefaultConjoinPolicy = serverConfig.ConjoinPolicy()
+	err = mrEnv.Iter(func(name string, dbEnv *env.DoltEnv) (stop bool, err error) {
+		if dbEnv.DoltDB != nil {
+			dbEnv.DoltDB.SetConjoinPolicy(serverConfig.ConjoinPolicy())
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err, nil
+	}
+	nbs.SetChunkCacheSize(serverConfig.ChunkCacheSize())
+
+	if tc := serverConfig.Tracing(); tc != nil {
+		shutdown, err := tracing.StartOTLP(ctx, version, *tc)
+		if err != nil {
+			return err, nil
+		}
+		defer func() {
+			if err := shutdown(context.Background()); err != nil {
+				lgr.Errorf("error exporting the last spans: %v", err)
+			}
+		}()
+	}
+
+	if replURL := serverConfig.JournalReplicationURL(); replURL != "" {
+		replicators, err := startJournalReplication(ctx, mrEnv, replURL, serverConfig.JournalReplicationInterval())
+		if err != nil {
+			return err, nil
+		}
+		defer func() {
+			for name, r := range replicators {
+				if err := r.Close(); err != nil {
+					lgr.Errorf("error replicating the chunk journal of database %s: %v", name, err)
+				}
+			}
+		}()
+	}
+
 	clusterController, err := cluster.NewController(lgr, serverConfig.ClusterConfig(), mrEnv.Config())
 	if err != nil {
 		return err, nil
@@ -138,6 +185,10 @@ func Serve(
 	} else if sErr != nil {
 		return sErr, nil
 	}
+	if serverConfig.Tracing() != nil {
+		// queries are traced from the server's handler, as the root spans of their traces
+		serverConf.Tracer = otel.Tracer("github.com/dolthub/dolt/go/cmd/dolt/commands/sqlserver")
+	}
 
 	// Create SQL Engine with users
 	config := &engine.SqlEngineConfig{
@@ -152,6 +203,11 @@ func Serve(
 		JwksConfig:              serverConfig.JwksConfig(),
 		ClusterController:       clusterController,
 		BinlogReplicaController: binlogreplication.DoltBinlogReplicaController,
+		Quotas:                  serverConfig.Quotas(),
+		FetchInterval:           serverConfig.FetchInterval(),
+		FetchRemotes:            serverConfig.FetchRemotes(),
+		Backups:                 serverConfig.Backups(),
+		Webhooks:                serverConfig.Webhooks(),
 	}
 	sqlEngine, err := engine.NewSqlEngine(
 		ctx,
@@ -237,18 +293,23 @@ func Serve(
 			listenaddr := fmt.Sprintf(":%d", port)
 			args := sqle.RemoteSrvServerArgs(remoteSrvSqlCtx, remotesrv.ServerArgs{
 				Logger:         logrus.NewEntry(lgr),
-				ReadOnly:       true,
+				ReadOnly:       serverConfig.RemotesapiReadOnly(),
 				HttpListenAddr: listenaddr,
 				GrpcListenAddr: listenaddr,
 			})
 			args = sqle.WithUserPasswordAuth(args, remotesrv.UserAuth{User: serverConfig.User(), Password: serverConfig.Password()})
 			args.TLSConfig = serverConf.TLSConfig
+			args.LoadShedding = serverConfig.RemotesapiLoadShedding()
+			args.BranchProtection = branchProtection(mrEnv)
 			remoteSrv, err = remotesrv.NewServer(args)
 			if err != nil {
 				lgr.Errorf("error creating remotesapi server on port %d: %v", port, err)
 				startError = err
 				return
 			}
+			if metSrv != nil && args.LoadShedding != nil {
+				registerRemotesapiLoadMetrics(labels, remoteSrv)
+			}
 			listeners, err := remoteSrv.Listeners()
 			if err != nil {
 				lgr.Errorf("error starting remotesapi server listeners on port %d: %v", port, err)
@@ -264,6 +325,32 @@ func Serve(
 		}
 	}
 
+	var flightSrv *flightsrv.Server
+	if serverConfig.FlightSQLPort() != nil {
+		port := *serverConfig.FlightSQLPort()
+		flightSrv = flightsrv.NewServer(flightsrv.ServerArgs{
+			Logger:     logrus.NewEntry(lgr),
+			ListenAddr: fmt.Sprintf(":%d", port),
+			Engine:     sqlEngine.GetUnderlyingEngine(),
+			NewContext: func(ctx context.Context, client sql.Client) (*sql.Context, error) {
+				sqlCtx, err := sqlEngine.NewDefaultContext(ctx)
+				if err != nil {
+					return nil, err
+				}
+				sqlCtx.Session.SetClient(client)
+				return sqlCtx, nil
+			},
+			TLSConfig: serverConf.TLSConfig,
+		})
+		listener, err := flightSrv.Listener()
+		if err != nil {
+			lgr.Errorf("error starting Flight SQL server listener on port %d: %v", port, err)
+			startError = err
+			return
+		}
+		flightSrv.Serve(listener)
+	}
+
 	var clusterRemoteSrv *remotesrv.Server
 	if clusterController != nil {
 		if remoteSrvSqlCtx, err := sqlEngine.NewDefaultContext(ctx); err == nil {
@@ -319,6 +406,9 @@ func Serve(
 		return
 	}
 
+	scheduler := newEventScheduler(sqlEngine, lgr)
+	scheduler.Run(ctx)
+
 	serverController.registerCloseFunction(startError, func() error {
 		if metSrv != nil {
 			metSrv.Close()
@@ -326,12 +416,16 @@ func Serve(
 		if remoteSrv != nil {
 			remoteSrv.GracefulStop()
 		}
+		if flightSrv != nil {
+			flightSrv.GracefulStop()
+		}
 		if clusterRemoteSrv != nil {
 			clusterRemoteSrv.GracefulStop()
 		}
 		if clusterController != nil {
 			clusterController.GracefulStop()
 		}
+		scheduler.Stop()
 
 		return mySQLServer.Close()
 	})
@@ -504,3 +598,42 @@ func checkForUnixSocket(config ServerConfig) (string, bool, error) {
 
 	return "", false, nil
 }
+
+// branchProtection returns the protection of the branches of a database of |mrEnv|, which is configured in the config
+// of the database, or in the config of the server for the databases created since it started.
+func branchProtection(mrEnv *env.MultiRepoEnv) func(string) doltdb.BranchProtection {
+	return func(name string) doltdb.BranchProtection {
+		if dbEnv := mrEnv.GetEnv(name); dbEnv != nil && dbEnv.Config != nil {
+			return env.GetBranchProtection(dbEnv.Config)
+		}
+		return env.GetBranchProtection(mrEnv.Config())
+	}
+}
+
+// startJournalReplication starts replicating the chunk journal of every database of |mrEnv| to a directory named
+// after the database under |replURL|. Databases created after the server starts aren't replicated.
+func startJournalReplication(ctx context.Context, mrEnv *env.MultiRepoEnv, replURL string, interval time.Duration) (map[string]io.Closer, error) {
+	replicators := make(map[string]io.Closer)
+	err := mrEnv.Iter(func(name string, dbEnv *env.DoltEnv) (stop bool, err error) {
+		if dbEnv.DoltDB == nil {
+			return false, nil
+		}
+		bs, err := dbfactory.OpenBlobstore(ctx, strings.TrimSuffix(replURL, "/")+"/"+name, nil)
+		if err != nil {
+			return true, err
+		}
+		r, err := dbEnv.DoltDB.ReplicateJournal(ctx, bs, interval)
+		if err != nil {
+			return true, fmt.Errorf("error replicating the chunk journal of database %s: %w", name, err)
+		}
+		replicators[name] = r
+		return false, nil
+	})
+	if err != nil {
+		for _, r := range replicators {
+			r.Close()
+		}
+		return nil, err
+	}
+	return replicators, nil
+}
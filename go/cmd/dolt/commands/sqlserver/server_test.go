@@ -60,6 +60,18 @@ var (
 	rob  = testPerson{"Rob Robertson", 21, false, ""}
 )
 
+// TestMain removes the config directory which servers started with the default config create in the working
+// directory, unless it existed before the tests ran.
+func TestMain(m *testing.M) {
+	_, err := os.Stat(defaultCfgDir)
+	cfgDirExisted := err == nil
+	code := m.Run()
+	if !cfgDirExisted {
+		os.RemoveAll(defaultCfgDir)
+	}
+	os.Exit(code)
+}
+
 func TestServerArgs(t *testing.T) {
 	serverController := NewServerController()
 	dEnv, err := sqle.CreateEnvWithSeedData()
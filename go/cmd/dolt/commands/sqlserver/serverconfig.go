@@ -21,10 +21,16 @@ import (
 	"net"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 // LogLevel defines the available levels of logging for the server.
@@ -46,6 +52,7 @@ const (
 	defaultPass                    = ""
 	defaultTimeout                 = 8 * 60 * 60 * 1000 // 8 hours, same as MySQL
 	defaultReadOnly                = false
+	defaultRemotesapiReadOnly      = true
 	defaultLogLevel                = LogLevel_Info
 	defaultAutoCommit              = true
 	defaultMaxConnections          = 100
@@ -162,8 +169,42 @@ type ServerConfig interface {
 	// as a dolt remote for things like `clone`, `fetch` and read
 	// replication.
 	RemotesapiPort() *int
+	// RemotesapiReadOnly is true if the remotesapi interface rejects pushes. Pushes are authenticated with the user and
+	// password of the server.
+	RemotesapiReadOnly() bool
+	// RemotesapiLoadShedding returns the limits of the table file downloads served by the remotesapi interface, or nil
+	// if they aren't limited.
+	RemotesapiLoadShedding() *remotesrv.LoadSheddingConfig
+	// FlightSQLPort is the port to use for serving the databases of this sql-server over Arrow Flight SQL, which
+	// streams query results as Arrow record batches. Flight SQL isn't served if it's nil.
+	FlightSQLPort() *int
 	// ClusterConfig is the configuration for clustering in this sql-server.
 	ClusterConfig() cluster.Config
+	// Quotas returns the per-database resource quotas of this sql-server, keyed by database name. The quotas keyed by
+	// "*" apply to every database without quotas of its own.
+	Quotas() map[string]quota.Limits
+	// FetchInterval is how often the remotes of every database are fetched in the background. Zero disables
+	// background fetches.
+	FetchInterval() time.Duration
+	// FetchRemotes are the names of the remotes fetched in the background. Every remote is fetched if it's empty.
+	FetchRemotes() []string
+	// Backups are the schedules every database is backed up on. Databases aren't backed up if it's empty.
+	Backups() []actions.BackupSchedule
+	// Webhooks are the URLs webhooks are POSTed to when the branches and tags of any database change. No webhooks are
+	// sent if it's empty.
+	Webhooks() []actions.WebhookConfig
+	// ConjoinPolicy is the policy that decides when the table files of every database are conjoined.
+	ConjoinPolicy() nbs.ConjoinPolicy
+	// ChunkCacheSize is the number of bytes of decompressed chunks that are cached for reads. Zero disables the cache.
+	ChunkCacheSize() uint64
+	// JournalReplicationURL is the URL the chunk journal of every database is replicated under, in a directory named
+	// after the database. Journals aren't replicated if it's empty.
+	JournalReplicationURL() string
+	// JournalReplicationInterval is how often new chunk journal records are replicated.
+	JournalReplicationInterval() time.Duration
+	// Tracing is the configuration of the export of OpenTelemetry spans to an OTLP collector. Spans aren't exported if
+	// it's nil.
+	Tracing() *tracing.OTLPConfig
 }
 
 type validatingServerConfig interface {
@@ -198,6 +239,8 @@ type commandLineServerConfig struct {
 	allowCleartextPasswords bool
 	socket                  string
 	remotesapiPort          *int
+	remotesapiReadOnly      bool
+	flightSQLPort           *int
 	goldenMysqlConn         string
 }
 
@@ -316,10 +359,62 @@ func (cfg *commandLineServerConfig) RemotesapiPort() *int {
 	return cfg.remotesapiPort
 }
 
+func (cfg *commandLineServerConfig) RemotesapiReadOnly() bool {
+	return cfg.remotesapiReadOnly
+}
+
+func (cfg *commandLineServerConfig) RemotesapiLoadShedding() *remotesrv.LoadSheddingConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) FlightSQLPort() *int {
+	return cfg.flightSQLPort
+}
+
 func (cfg *commandLineServerConfig) ClusterConfig() cluster.Config {
 	return nil
 }
 
+func (cfg *commandLineServerConfig) Quotas() map[string]quota.Limits {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) FetchInterval() time.Duration {
+	return 0
+}
+
+func (cfg *commandLineServerConfig) FetchRemotes() []string {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) Backups() []actions.BackupSchedule {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) Webhooks() []actions.WebhookConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) ConjoinPolicy() nbs.ConjoinPolicy {
+	return nbs.DefaultConjoinPolicy
+}
+
+func (cfg *commandLineServerConfig) ChunkCacheSize() uint64 {
+	return 0
+}
+
+func (cfg *commandLineServerConfig) JournalReplicationURL() string {
+	return ""
+}
+
+func (cfg *commandLineServerConfig) JournalReplicationInterval() time.Duration {
+	return nbs.DefaultJournalReplicationInterval
+}
+
+func (cfg *commandLineServerConfig) Tracing() *tracing.OTLPConfig {
+	return nil
+}
+
 // PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 // JSON string.
 func (cfg *commandLineServerConfig) PrivilegeFilePath() string {
@@ -474,6 +569,18 @@ func (cfg *commandLineServerConfig) WithRemotesapiPort(port *int) *commandLineSe
 	return cfg
 }
 
+// WithRemotesapiReadOnly sets whether the remotesapi interface rejects pushes.
+func (cfg *commandLineServerConfig) WithRemotesapiReadOnly(readOnly bool) *commandLineServerConfig {
+	cfg.remotesapiReadOnly = readOnly
+	return cfg
+}
+
+// WithFlightSQLPort sets the Flight SQL port to use.
+func (cfg *commandLineServerConfig) WithFlightSQLPort(port *int) *commandLineServerConfig {
+	cfg.flightSQLPort = port
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) goldenMysqlConnectionString() string {
 	return cfg.goldenMysqlConn
 }
@@ -502,6 +609,7 @@ func DefaultServerConfig() *commandLineServerConfig {
 		branchControlFilePath:   filepath.Join(defaultDataDir, defaultCfgDir, defaultBranchControlFilePath),
 		allowCleartextPasswords: defaultAllowCleartextPasswords,
 		maxLoggedQueryLen:       defaultMaxLoggedQueryLen,
+		remotesapiReadOnly:      defaultRemotesapiReadOnly,
 	}
 }
 
@@ -522,9 +630,79 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if config.RemotesapiPort() != nil && !config.RemotesapiReadOnly() {
+		if config.ReadOnly() {
+			return fmt.Errorf("remotesapi: read_only: can only be `false` when the server isn't read only")
+		}
+		if config.User() == "" {
+			return fmt.Errorf("remotesapi: read_only: can only be `false` when a user is provided to authenticate pushes")
+		}
+	}
+	if err := ValidateQuotas(config.Quotas()); err != nil {
+		return err
+	}
+	if err := ValidateBackups(config.Backups()); err != nil {
+		return err
+	}
+	if err := ValidateWebhooks(config.Webhooks()); err != nil {
+		return err
+	}
+	if tc := config.Tracing(); tc != nil {
+		if err := tc.Validate(); err != nil {
+			return fmt.Errorf("tracing: %w", err)
+		}
+	}
+	if config.ConjoinPolicy().MaxTables < 2 {
+		return fmt.Errorf("conjoin: max_table_files: is %d but must be at least 2", config.ConjoinPolicy().MaxTables)
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
+// ValidateQuotas returns an error if the soft limit of any quota is greater than its hard limit.
+func ValidateQuotas(quotas map[string]quota.Limits) error {
+	for db, limits := range quotas {
+		if db == "" {
+			return errors.New("quotas: database: Cannot be empty")
+		}
+		for resource, l := range map[string]quota.Limit{
+			"max_disk_bytes":             limits.DiskBytes,
+			"max_branches":               limits.Branches,
+			"max_connections":            limits.Connections,
+			"max_background_cpu_seconds": limits.BackgroundCPU,
+		} {
+			if l.Soft != 0 && l.Hard != 0 && l.Soft > l.Hard {
+				return fmt.Errorf("quotas: %s: %s: soft limit %d is greater than hard limit %d", db, resource, l.Soft, l.Hard)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateBackups returns an error if any backup schedule is invalid, or if two back up to the same remote.
+func ValidateBackups(backups []actions.BackupSchedule) error {
+	remotes := make(map[string]struct{}, len(backups))
+	for _, bs := range backups {
+		if err := bs.Validate(); err != nil {
+			return fmt.Errorf("backups: %w", err)
+		}
+		if _, ok := remotes[bs.Remote]; ok {
+			return fmt.Errorf("backups: remote: %s is scheduled more than once", bs.Remote)
+		}
+		remotes[bs.Remote] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateWebhooks returns an error if any webhook config is invalid.
+func ValidateWebhooks(webhooks []actions.WebhookConfig) error {
+	for i, wc := range webhooks {
+		if err := wc.Validate(); err != nil {
+			return fmt.Errorf("webhooks[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func ValidateClusterConfig(config cluster.Config) error {
 	if config == nil {
 		return nil
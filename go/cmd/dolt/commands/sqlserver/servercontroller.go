@@ -66,6 +66,12 @@ func (controller *ServerController) serverStopped(closeError error) {
 // StopServer stops the server if it is running. Only the first call will trigger the stop, thus it is safe for
 // multiple goroutines to call this function.
 func (controller *ServerController) StopServer() {
+	// |closeFunction| is set before |startCh| is closed, so it's only read once the server has registered it.
+	select {
+	case <-controller.startCh:
+	default:
+		return
+	}
 	if controller.closeFunction != nil {
 		controller.closeCalled.Do(func() {
 			if err := controller.closeFunction(); err != nil {
@@ -47,6 +47,8 @@ const (
 	allowCleartextPasswordsFlag = "allow-cleartext-passwords"
 	socketFlag                  = "socket"
 	remotesapiPortFlag          = "remotesapi-port"
+	remotesapiReadWriteFlag     = "remotesapi-read-write"
+	flightSQLPortFlag           = "flight-sql-port"
 	goldenMysqlConn             = "golden"
 )
 
@@ -155,6 +157,8 @@ func (cmd SqlServerCmd) ArgParserWithName(name string) *argparser.ArgParser {
 	ap.SupportsString(allowCleartextPasswordsFlag, "", "allow-cleartext-passwords", "Allows use of cleartext passwords. Defaults to false.")
 	ap.SupportsOptionalString(socketFlag, "", "socket file", "Path for the unix socket file. Defaults to '/tmp/mysql.sock'.")
 	ap.SupportsUint(remotesapiPortFlag, "", "remotesapi port", "Sets the port for a server which can expose the databases in this sql-server over remotesapi.")
+	ap.SupportsFlag(remotesapiReadWriteFlag, "", "Allows pushes to the remotesapi server. Pushes are authenticated with the server's {{.EmphasisLeft}}--user{{.EmphasisRight}} and {{.EmphasisLeft}}--password{{.EmphasisRight}}.")
+	ap.SupportsUint(flightSQLPortFlag, "", "Flight SQL port", "Sets the port for a server which serves query results over Arrow Flight SQL. Clients authenticate as the users of this sql-server.")
 	ap.SupportsString(goldenMysqlConn, "", "mysql connection string", "Provides a connection string to a MySQL instance to be used to validate query results")
 	return ap
 }
@@ -381,6 +385,14 @@ func getCommandLineServerConfig(dEnv *env.DoltEnv, apr *argparser.ArgParseResult
 		serverConfig.WithRemotesapiPort(&port)
 	}
 
+	if apr.Contains(remotesapiReadWriteFlag) {
+		serverConfig.WithRemotesapiReadOnly(false)
+	}
+
+	if port, ok := apr.GetInt(flightSQLPortFlag); ok {
+		serverConfig.WithFlightSQLPort(&port)
+	}
+
 	if persistenceBehavior, ok := apr.GetValue(persistenceBehaviorFlag); ok {
 		serverConfig.withPersistenceBehavior(persistenceBehavior)
 	}
@@ -17,6 +17,7 @@ package sqlserver
 import (
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -24,7 +25,12 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 func strPtr(s string) *string {
@@ -106,6 +112,9 @@ type ListenerYAMLConfig struct {
 // PerformanceYAMLConfig contains configuration parameters for performance tweaking
 type PerformanceYAMLConfig struct {
 	QueryParallelism *int `yaml:"query_parallelism"`
+	// ChunkCacheSize is the number of bytes of decompressed chunks that are cached for reads. Chunks aren't cached if
+	// it isn't set.
+	ChunkCacheSize *uint64 `yaml:"chunk_cache_size,omitempty"`
 }
 
 type MetricsYAMLConfig struct {
@@ -116,12 +125,127 @@ type MetricsYAMLConfig struct {
 
 type RemotesapiYAMLConfig struct {
 	Port_ *int `yaml:"port"`
+	// ReadOnly is false if the remotesapi server accepts pushes authenticated with the user and password of the server.
+	// It's read only if it isn't set.
+	ReadOnly *bool `yaml:"read_only,omitempty"`
+	// LoadShedding limits the table file downloads served by the remotesapi server. Downloads aren't limited if it
+	// isn't set.
+	LoadShedding *RemotesapiLoadSheddingYAMLConfig `yaml:"load_shedding,omitempty"`
+}
+
+// FlightSQLYAMLConfig contains the configuration of the Arrow Flight SQL server.
+type FlightSQLYAMLConfig struct {
+	Port_ *int `yaml:"port"`
+}
+
+// RemotesapiLoadSheddingYAMLConfig contains the limits of the table file downloads served by the remotesapi server.
+// Zero values are unlimited.
+type RemotesapiLoadSheddingYAMLConfig struct {
+	MaxConcurrentDownloads          uint   `yaml:"max_concurrent_downloads,omitempty"`
+	MaxConcurrentDownloadsPerClient uint   `yaml:"max_concurrent_downloads_per_client,omitempty"`
+	MaxQueuedDownloads              uint   `yaml:"max_queued_downloads,omitempty"`
+	QueueTimeoutMillis              uint64 `yaml:"queue_timeout_millis,omitempty"`
+	ClientBytesPerSecond            uint64 `yaml:"client_bytes_per_second,omitempty"`
+	ClientBurstBytes                uint64 `yaml:"client_burst_bytes,omitempty"`
 }
 
 func (r RemotesapiYAMLConfig) Port() int {
 	return *r.Port_
 }
 
+// RemoteFetchYAMLConfig contains the configuration of background fetches of the remotes of every database
+type RemoteFetchYAMLConfig struct {
+	// IntervalSeconds is how often remotes are fetched. Remotes are not fetched in the background if it isn't set.
+	IntervalSeconds *uint64 `yaml:"interval_seconds,omitempty"`
+	// Remotes are the names of the remotes to fetch. Every remote is fetched if it's empty.
+	Remotes []string `yaml:"remotes,omitempty"`
+}
+
+// BackupYAMLConfig contains the configuration of the scheduled backups of every database to a remote
+type BackupYAMLConfig struct {
+	// Remote is the URL every database is backed up under, each in a directory named after the database.
+	Remote string `yaml:"remote"`
+	// Cron is a cron expression of when the backups are made, e.g. "0 3 * * *" or "@daily".
+	Cron string `yaml:"cron"`
+	// Retention is the number of backups of each database to keep, each in a directory named after the time it was
+	// made. If it isn't set, a single backup of each database is synced in place.
+	Retention *int `yaml:"retention,omitempty"`
+}
+
+// WebhookYAMLConfig contains the configuration of a URL that webhooks are POSTed to when the branches and tags of any
+// database change
+type WebhookYAMLConfig struct {
+	URL string `yaml:"url"`
+	// Events are the events sent to the URL, any of commit, merge, branch and tag. Every event is sent if it's empty.
+	Events []string `yaml:"events,omitempty"`
+	// Secret is the key of the HMAC-SHA256 signature of each webhook, sent in the X-Dolt-Signature-256 header.
+	Secret string `yaml:"secret,omitempty"`
+	// MaxAttempts is the number of times a webhook is tried before it's recorded in dolt_webhook_dead_letters.
+	MaxAttempts *int `yaml:"max_attempts,omitempty"`
+}
+
+// ConjoinYAMLConfig contains the configuration of when the table files of every database are conjoined
+type ConjoinYAMLConfig struct {
+	// MaxTableFiles is the number of table files a database may have before some of them are conjoined.
+	MaxTableFiles *int `yaml:"max_table_files,omitempty"`
+	// TargetFileSize is the size, in bytes, that conjoined table files are grown to. Table files at least this large
+	// are never conjoined.
+	TargetFileSize *uint64 `yaml:"target_file_size,omitempty"`
+}
+
+// JournalReplYAMLConfig contains the configuration of the replication of the chunk journal of every database to
+// object storage
+type JournalReplYAMLConfig struct {
+	// URL is the s3://, gs:// or file:// URL the journal of each database is replicated under, in a directory named
+	// after the database. Journals aren't replicated if it isn't set.
+	URL *string `yaml:"url,omitempty"`
+	// IntervalMillis is how often new journal records are copied. It defaults to one second.
+	IntervalMillis *uint64 `yaml:"interval_millis,omitempty"`
+}
+
+// TracingYAMLConfig contains the configuration of the export of OpenTelemetry spans to an OTLP collector
+type TracingYAMLConfig struct {
+	// OTLPEndpoint is the http or https URL of the OTLP collector spans are exported to. Spans aren't exported if it
+	// isn't set.
+	OTLPEndpoint *string `yaml:"otlp_endpoint,omitempty"`
+	// Headers are sent with every export, e.g. to authenticate with the collector.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// SampleRatio is the fraction of traces that are sampled. Every trace is sampled if it isn't set.
+	SampleRatio *float64 `yaml:"sample_ratio,omitempty"`
+	// ServiceName is the service name of the spans. It's "dolt" if it isn't set.
+	ServiceName *string `yaml:"service_name,omitempty"`
+}
+
+// QuotaLimitYAMLConfig is the soft and hard limit of a single resource quota
+type QuotaLimitYAMLConfig struct {
+	Soft *uint64 `yaml:"soft,omitempty"`
+	Hard *uint64 `yaml:"hard,omitempty"`
+}
+
+func (l *QuotaLimitYAMLConfig) limit() quota.Limit {
+	var limit quota.Limit
+	if l == nil {
+		return limit
+	}
+	if l.Soft != nil {
+		limit.Soft = *l.Soft
+	}
+	if l.Hard != nil {
+		limit.Hard = *l.Hard
+	}
+	return limit
+}
+
+// QuotaYAMLConfig contains the resource quotas of a database, or of every database without quotas of its own if
+// Database is "*"
+type QuotaYAMLConfig struct {
+	Database         string                `yaml:"database"`
+	MaxDiskBytes     *QuotaLimitYAMLConfig `yaml:"max_disk_bytes,omitempty"`
+	MaxBranches      *QuotaLimitYAMLConfig `yaml:"max_branches,omitempty"`
+	MaxConnections   *QuotaLimitYAMLConfig `yaml:"max_connections,omitempty"`
+	MaxBackgroundCPU *QuotaLimitYAMLConfig `yaml:"max_background_cpu_seconds,omitempty"`
+}
+
 type UserSessionVars struct {
 	Name string            `yaml:"name"`
 	Vars map[string]string `yaml:"vars"`
@@ -141,12 +265,20 @@ type YAMLConfig struct {
 	CfgDirStr         *string               `yaml:"cfg_dir,omitempty"`
 	MetricsConfig     MetricsYAMLConfig     `yaml:"metrics"`
 	RemotesapiConfig  RemotesapiYAMLConfig  `yaml:"remotesapi"`
+	FlightSQLConfig   FlightSQLYAMLConfig   `yaml:"flight_sql,omitempty"`
 	ClusterCfg        *ClusterYAMLConfig    `yaml:"cluster,omitempty"`
 	PrivilegeFile     *string               `yaml:"privilege_file,omitempty"`
 	BranchControlFile *string               `yaml:"branch_control_file,omitempty"`
 	Vars              []UserSessionVars     `yaml:"user_session_vars"`
 	Jwks              []engine.JwksConfig   `yaml:"jwks"`
 	GoldenMysqlConn   *string               `yaml:"golden_mysql_conn,omitempty"`
+	QuotasConfig      []QuotaYAMLConfig     `yaml:"quotas,omitempty"`
+	RemoteFetchConfig RemoteFetchYAMLConfig `yaml:"remote_fetch,omitempty"`
+	BackupsConfig     []BackupYAMLConfig    `yaml:"backups,omitempty"`
+	WebhooksConfig    []WebhookYAMLConfig   `yaml:"webhooks,omitempty"`
+	ConjoinConfig     ConjoinYAMLConfig     `yaml:"conjoin,omitempty"`
+	JournalReplConfig JournalReplYAMLConfig `yaml:"journal_replication,omitempty"`
+	TracingConfig     TracingYAMLConfig     `yaml:"tracing,omitempty"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -358,6 +490,36 @@ func (cfg YAMLConfig) RemotesapiPort() *int {
 	return cfg.RemotesapiConfig.Port_
 }
 
+// RemotesapiReadOnly is true if the remotesapi server rejects pushes.
+func (cfg YAMLConfig) RemotesapiReadOnly() bool {
+	if cfg.RemotesapiConfig.ReadOnly == nil {
+		return defaultRemotesapiReadOnly
+	}
+	return *cfg.RemotesapiConfig.ReadOnly
+}
+
+// FlightSQLPort is the port of the Arrow Flight SQL server, or nil if it isn't served.
+func (cfg YAMLConfig) FlightSQLPort() *int {
+	return cfg.FlightSQLConfig.Port_
+}
+
+// RemotesapiLoadShedding returns the limits of the table file downloads served by the remotesapi server, or nil if
+// they aren't limited.
+func (cfg YAMLConfig) RemotesapiLoadShedding() *remotesrv.LoadSheddingConfig {
+	ls := cfg.RemotesapiConfig.LoadShedding
+	if ls == nil {
+		return nil
+	}
+	return &remotesrv.LoadSheddingConfig{
+		MaxConcurrentDownloads:          int(ls.MaxConcurrentDownloads),
+		MaxConcurrentDownloadsPerClient: int(ls.MaxConcurrentDownloadsPerClient),
+		MaxQueuedDownloads:              int(ls.MaxQueuedDownloads),
+		QueueTimeout:                    time.Duration(ls.QueueTimeoutMillis) * time.Millisecond,
+		ClientBytesPerSecond:            int64(ls.ClientBytesPerSecond),
+		ClientBurstBytes:                int64(ls.ClientBurstBytes),
+	}
+}
+
 // PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 // JSON string.
 func (cfg YAMLConfig) PrivilegeFilePath() string {
@@ -494,6 +656,129 @@ func (cfg YAMLConfig) goldenMysqlConnectionString() (s string) {
 	return
 }
 
+// Quotas returns the per-database resource quotas, keyed by database name, or nil if there are none.
+func (cfg YAMLConfig) Quotas() map[string]quota.Limits {
+	if len(cfg.QuotasConfig) == 0 {
+		return nil
+	}
+
+	quotas := make(map[string]quota.Limits, len(cfg.QuotasConfig))
+	for _, q := range cfg.QuotasConfig {
+		quotas[q.Database] = quota.Limits{
+			DiskBytes:     q.MaxDiskBytes.limit(),
+			Branches:      q.MaxBranches.limit(),
+			Connections:   q.MaxConnections.limit(),
+			BackgroundCPU: q.MaxBackgroundCPU.limit(),
+		}
+	}
+	return quotas
+}
+
+// FetchInterval returns how often the remotes of every database are fetched in the background, or zero if they
+// aren't.
+func (cfg YAMLConfig) FetchInterval() time.Duration {
+	if cfg.RemoteFetchConfig.IntervalSeconds == nil {
+		return 0
+	}
+	return time.Duration(*cfg.RemoteFetchConfig.IntervalSeconds) * time.Second
+}
+
+// FetchRemotes returns the names of the remotes fetched in the background, or nil if every remote is fetched.
+func (cfg YAMLConfig) FetchRemotes() []string {
+	return cfg.RemoteFetchConfig.Remotes
+}
+
+// Backups returns the schedules every database is backed up on, or nil if they aren't backed up.
+func (cfg YAMLConfig) Backups() []actions.BackupSchedule {
+	if len(cfg.BackupsConfig) == 0 {
+		return nil
+	}
+
+	backups := make([]actions.BackupSchedule, len(cfg.BackupsConfig))
+	for i, b := range cfg.BackupsConfig {
+		backups[i] = actions.BackupSchedule{Remote: b.Remote, Cron: b.Cron}
+		if b.Retention != nil {
+			backups[i].Retention = *b.Retention
+		}
+	}
+	return backups
+}
+
+// Webhooks returns the URLs webhooks are sent to, or nil if none are sent.
+func (cfg YAMLConfig) Webhooks() []actions.WebhookConfig {
+	if len(cfg.WebhooksConfig) == 0 {
+		return nil
+	}
+
+	webhooks := make([]actions.WebhookConfig, len(cfg.WebhooksConfig))
+	for i, w := range cfg.WebhooksConfig {
+		webhooks[i] = actions.WebhookConfig{URL: w.URL, Secret: w.Secret}
+		for _, e := range w.Events {
+			webhooks[i].Events = append(webhooks[i].Events, actions.WebhookEventType(e))
+		}
+		if w.MaxAttempts != nil {
+			webhooks[i].MaxAttempts = *w.MaxAttempts
+		}
+	}
+	return webhooks
+}
+
+// ChunkCacheSize returns the number of bytes of decompressed chunks that are cached for reads, or zero if they aren't.
+func (cfg YAMLConfig) ChunkCacheSize() uint64 {
+	if cfg.PerformanceConfig.ChunkCacheSize == nil {
+		return 0
+	}
+	return *cfg.PerformanceConfig.ChunkCacheSize
+}
+
+// JournalReplicationURL returns the URL the chunk journal of every database is replicated under, or an empty string
+// if it isn't.
+func (cfg YAMLConfig) JournalReplicationURL() string {
+	if cfg.JournalReplConfig.URL == nil {
+		return ""
+	}
+	return *cfg.JournalReplConfig.URL
+}
+
+// JournalReplicationInterval returns how often new chunk journal records are replicated.
+func (cfg YAMLConfig) JournalReplicationInterval() time.Duration {
+	if cfg.JournalReplConfig.IntervalMillis == nil {
+		return nbs.DefaultJournalReplicationInterval
+	}
+	return time.Duration(*cfg.JournalReplConfig.IntervalMillis) * time.Millisecond
+}
+
+// Tracing returns the configuration of the export of spans to an OTLP collector, or nil if spans aren't exported.
+func (cfg YAMLConfig) Tracing() *tracing.OTLPConfig {
+	if cfg.TracingConfig.OTLPEndpoint == nil {
+		return nil
+	}
+	tc := &tracing.OTLPConfig{
+		Endpoint:    *cfg.TracingConfig.OTLPEndpoint,
+		Headers:     cfg.TracingConfig.Headers,
+		SampleRatio: 1,
+	}
+	if cfg.TracingConfig.SampleRatio != nil {
+		tc.SampleRatio = *cfg.TracingConfig.SampleRatio
+	}
+	if cfg.TracingConfig.ServiceName != nil {
+		tc.ServiceName = *cfg.TracingConfig.ServiceName
+	}
+	return tc
+}
+
+// ConjoinPolicy returns the policy that decides when the table files of every database are conjoined.
+func (cfg YAMLConfig) ConjoinPolicy() nbs.ConjoinPolicy {
+	policy := nbs.DefaultConjoinPolicy
+	if cfg.ConjoinConfig.MaxTableFiles != nil {
+		policy.MaxTables = *cfg.ConjoinConfig.MaxTableFiles
+	}
+	if cfg.ConjoinConfig.TargetFileSize != nil {
+		policy.TargetFileSize = *cfg.ConjoinConfig.TargetFileSize
+	}
+	return policy
+}
+
 func (cfg YAMLConfig) ClusterConfig() cluster.Config {
 	if cfg.ClusterCfg == nil {
 		return nil
@@ -16,12 +16,18 @@ package sqlserver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 func TestUnmarshall(t *testing.T) {
@@ -161,6 +167,157 @@ remotesapi:
 	require.Equal(t, 8000, *config.RemotesapiPort())
 }
 
+func TestUnmarshallFlightSQLPort(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	require.Nil(t, config.FlightSQLPort())
+
+	testStr := `
+flight_sql:
+  port: 32010
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.NotNil(t, config.FlightSQLPort())
+	require.Equal(t, 32010, *config.FlightSQLPort())
+}
+
+func TestUnmarshallBackups(t *testing.T) {
+	testStr := `
+backups:
+  - remote: file:///var/backups/dolt
+    cron: "0 3 * * *"
+    retention: 7
+  - remote: aws://[table:bucket]/dolt
+    cron: "@hourly"
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.Equal(t, []actions.BackupSchedule{
+		{Remote: "file:///var/backups/dolt", Cron: "0 3 * * *", Retention: 7},
+		{Remote: "aws://[table:bucket]/dolt", Cron: "@hourly"},
+	}, config.Backups())
+	require.NoError(t, ValidateConfig(config))
+
+	for _, invalid := range []string{`
+backups:
+  - remote: file:///var/backups/dolt
+    cron: "0 3 * *"
+`, `
+backups:
+  - remote: aws://[table:bucket]/dolt
+    cron: "@daily"
+    retention: 7
+`, `
+backups:
+  - remote: file:///var/backups/dolt
+    cron: "@daily"
+  - remote: file:///var/backups/dolt
+    cron: "@hourly"
+`} {
+		config, err = NewYamlConfig([]byte(invalid))
+		require.NoError(t, err)
+		require.Error(t, ValidateConfig(config))
+	}
+}
+
+func TestUnmarshallWebhooks(t *testing.T) {
+	testStr := `
+webhooks:
+  - url: https://example.com/dolt
+    events: [commit, merge]
+    secret: s3cr3t
+    max_attempts: 5
+  - url: http://localhost:8080/hook
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.Equal(t, []actions.WebhookConfig{
+		{URL: "https://example.com/dolt", Events: []actions.WebhookEventType{actions.WebhookCommitEvent, actions.WebhookMergeEvent}, Secret: "s3cr3t", MaxAttempts: 5},
+		{URL: "http://localhost:8080/hook"},
+	}, config.Webhooks())
+	require.NoError(t, ValidateConfig(config))
+
+	for _, invalid := range []string{`
+webhooks:
+  - url: ftp://example.com/dolt
+`, `
+webhooks:
+  - url: https://example.com/dolt
+    events: [push]
+`, `
+webhooks:
+  - url: https://example.com/dolt
+    max_attempts: -1
+`} {
+		config, err = NewYamlConfig([]byte(invalid))
+		require.NoError(t, err)
+		require.Error(t, ValidateConfig(config))
+	}
+}
+
+func TestUnmarshallRemotesapiReadOnly(t *testing.T) {
+	testStr := `
+remotesapi:
+  port: 8000
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.True(t, config.RemotesapiReadOnly())
+	require.NoError(t, ValidateConfig(config))
+
+	testStr = `
+remotesapi:
+  port: 8000
+  read_only: false
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.False(t, config.RemotesapiReadOnly())
+	require.NoError(t, ValidateConfig(config))
+
+	testStr = `
+behavior:
+  read_only: true
+remotesapi:
+  port: 8000
+  read_only: false
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.Error(t, ValidateConfig(config))
+}
+
+func TestUnmarshallRemotesapiLoadShedding(t *testing.T) {
+	testStr := `
+remotesapi:
+  port: 8000
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.Nil(t, config.RemotesapiLoadShedding())
+
+	testStr = `
+remotesapi:
+  port: 8000
+  load_shedding:
+    max_concurrent_downloads: 64
+    max_concurrent_downloads_per_client: 8
+    max_queued_downloads: 1024
+    queue_timeout_millis: 30000
+    client_bytes_per_second: 1048576
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.Equal(t, &remotesrv.LoadSheddingConfig{
+		MaxConcurrentDownloads:          64,
+		MaxConcurrentDownloadsPerClient: 8,
+		MaxQueuedDownloads:              1024,
+		QueueTimeout:                    30 * time.Second,
+		ClientBytesPerSecond:            1048576,
+	}, config.RemotesapiLoadShedding())
+}
+
 func TestUnmarshallCluster(t *testing.T) {
 	testStr := `
 cluster:
@@ -346,6 +503,7 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Nil(t, cfg.MetricsConfig.Labels)
 	assert.Equal(t, defaultAllowCleartextPasswords, cfg.AllowCleartextPasswords())
 	assert.Nil(t, cfg.RemotesapiPort())
+	assert.True(t, cfg.RemotesapiReadOnly())
 
 	c, err := LoadTLSConfig(cfg)
 	assert.NoError(t, err)
@@ -417,3 +575,128 @@ listener:
 	err = ValidateConfig(cfg)
 	assert.Error(t, err)
 }
+
+func TestUnmarshallQuotas(t *testing.T) {
+	testStr := `
+quotas:
+  - database: tenant
+    max_disk_bytes:
+      soft: 1000
+      hard: 2000
+    max_connections:
+      hard: 5
+  - database: "*"
+    max_branches:
+      soft: 10
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.NoError(t, ValidateQuotas(config.Quotas()))
+	assert.Equal(t, map[string]quota.Limits{
+		"tenant": {
+			DiskBytes:   quota.Limit{Soft: 1000, Hard: 2000},
+			Connections: quota.Limit{Hard: 5},
+		},
+		"*": {
+			Branches: quota.Limit{Soft: 10},
+		},
+	}, config.Quotas())
+
+	config, err = NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.Quotas())
+
+	config, err = NewYamlConfig([]byte(`
+quotas:
+  - database: tenant
+    max_branches:
+      soft: 10
+      hard: 5
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateQuotas(config.Quotas()))
+}
+
+func TestUnmarshallConjoin(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Equal(t, nbs.DefaultConjoinPolicy, config.ConjoinPolicy())
+
+	testStr := `
+conjoin:
+  max_table_files: 64
+  target_file_size: 268435456
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	assert.Equal(t, nbs.ConjoinPolicy{MaxTables: 64, TargetFileSize: 268435456}, config.ConjoinPolicy())
+	assert.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+conjoin:
+  max_table_files: 1
+`))
+	require.NoError(t, err)
+	assert.Error(t, ValidateConfig(config))
+}
+
+func TestUnmarshallChunkCacheSize(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), config.ChunkCacheSize())
+
+	config, err = NewYamlConfig([]byte(`
+performance:
+  chunk_cache_size: 16777216
+`))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(16777216), config.ChunkCacheSize())
+}
+
+func TestUnmarshallJournalReplication(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Equal(t, "", config.JournalReplicationURL())
+	assert.Equal(t, nbs.DefaultJournalReplicationInterval, config.JournalReplicationInterval())
+
+	config, err = NewYamlConfig([]byte(`
+journal_replication:
+  url: s3://backups/dolt
+  interval_millis: 250
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "s3://backups/dolt", config.JournalReplicationURL())
+	assert.Equal(t, 250*time.Millisecond, config.JournalReplicationInterval())
+}
+
+func TestUnmarshallTracing(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.Tracing())
+
+	config, err = NewYamlConfig([]byte(`
+tracing:
+  otlp_endpoint: https://collector:4318
+  headers:
+    authorization: Bearer token
+  sample_ratio: 0.25
+  service_name: dolt-primary
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &tracing.OTLPConfig{
+		Endpoint:    "https://collector:4318",
+		Headers:     map[string]string{"authorization": "Bearer token"},
+		SampleRatio: 0.25,
+		ServiceName: "dolt-primary",
+	}, config.Tracing())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte(`
+tracing:
+  otlp_endpoint: collector:4318
+  sample_ratio: 2
+`))
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), config.Tracing().SampleRatio)
+	assert.Error(t, ValidateConfig(config))
+}
@@ -58,6 +58,7 @@ func (cmd StatusCmd) Docs() *cli.CommandDocumentation {
 func (cmd StatusCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
 	ap.SupportsFlag(cli.ShowIgnoredFlag, "", "Show tables that are ignored (according to dolt_ignore)")
+	ap.SupportsFlag(cli.IgnoreVolatileFlag, "", "Don't show tables whose only changes are to the columns listed in dolt_volatile_columns")
 	return ap
 }
 
@@ -76,6 +77,12 @@ func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string,
 	if err != nil {
 		return handleStatusVErr(err)
 	}
+	if apr.Contains(cli.IgnoreVolatileFlag) {
+		staged, notStaged, err = withoutVolatileChanges(ctx, roots, staged, notStaged)
+		if err != nil {
+			return handleStatusVErr(err)
+		}
+	}
 
 	ws, err := dEnv.WorkingSet(ctx)
 	if err != nil {
@@ -132,6 +139,24 @@ func PrintStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTb
 	return nil
 }
 
+// withoutVolatileChanges removes the deltas of |staged| and |notStaged| whose only changes are to the columns listed in
+// the dolt_volatile_columns table of the working root of |roots|.
+func withoutVolatileChanges(ctx context.Context, roots doltdb.Roots, staged, notStaged []diff.TableDelta) ([]diff.TableDelta, []diff.TableDelta, error) {
+	volatile, err := doltdb.GetVolatileColumns(ctx, roots.Working)
+	if err != nil {
+		return nil, nil, err
+	}
+	staged, err = diff.FilterIgnoredColumnChanges(ctx, staged, volatile)
+	if err != nil {
+		return nil, nil, err
+	}
+	notStaged, err = diff.FilterIgnoredColumnChanges(ctx, notStaged, volatile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return staged, notStaged, nil
+}
+
 func handleStatusVErr(err error) int {
 	cli.PrintErrln(errhand.VerboseErrorFromError(err).Verbose())
 	return 1
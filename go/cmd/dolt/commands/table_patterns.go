@@ -0,0 +1,86 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// TablePatternsHelp is the help for the table arguments of the commands which accept table name patterns.
+const TablePatternsHelp = "Table names may be patterns, as in dolt_ignore, where * matches any number of characters and ? matches a single character."
+
+// DryRunTablesHelp is the help for the --dry-run flag of the commands which accept table name patterns.
+const DryRunTablesHelp = "List the tables the arguments resolve to without doing anything."
+
+// ResolveTablePatterns replaces every pattern in |args| with the names of the tables in any of |roots| that it
+// matches, sorted, and returns the resulting table names without duplicates. Other arguments are kept as they are.
+// Patterns only match system tables if they start with the dolt_ prefix themselves. It's an error for a pattern to
+// match no table.
+func ResolveTablePatterns(ctx context.Context, args []string, roots ...*doltdb.RootValue) ([]string, error) {
+	var tableNames []string
+	var resolved []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			resolved = append(resolved, name)
+		}
+	}
+
+	for _, arg := range args {
+		if !doltdb.IsTablePattern(arg) {
+			add(arg)
+			continue
+		}
+
+		if tableNames == nil {
+			var err error
+			if tableNames, err = doltdb.UnionTableNames(ctx, roots...); err != nil {
+				return nil, err
+			}
+			sort.Strings(tableNames)
+		}
+
+		matches, err := doltdb.MatchTablePattern(arg, tableNames)
+		if err != nil {
+			return nil, err
+		}
+		matched := false
+		for _, name := range matches {
+			if doltdb.HasDoltPrefix(name) && !doltdb.HasDoltPrefix(arg) {
+				continue
+			}
+			add(name)
+			matched = true
+		}
+		if !matched {
+			return nil, fmt.Errorf("no tables match pattern '%s'", arg)
+		}
+	}
+
+	return resolved, nil
+}
+
+// PrintResolvedTables prints |tables|, the tables the arguments of a command run with --dry-run resolved to, one per line.
+func PrintResolvedTables(tables []string) {
+	for _, name := range tables {
+		cli.Println(name)
+	}
+}
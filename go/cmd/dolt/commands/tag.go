@@ -150,6 +150,13 @@ func getTagProps(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (props actio
 		Description: msg,
 	}
 
+	if keyID, ok := apr.GetValue(cli.SignKeyParam); ok || apr.Contains(cli.SignFlag) {
+		props.Signer, err = actions.ConfiguredGPGSigner(dEnv.Config, keyID)
+	}
+	if err != nil {
+		return props, err
+	}
+
 	return props, nil
 }
 
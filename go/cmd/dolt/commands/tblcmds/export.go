@@ -41,17 +41,24 @@ var exportDocs = cli.CommandDocumentationContent{
 	LongDesc: `{{.EmphasisLeft}}dolt table export{{.EmphasisRight}} will export the contents of {{.LessThan}}table{{.GreaterThan}} to {{.LessThan}}|file{{.GreaterThan}}
 
 See the help for {{.EmphasisLeft}}dolt table import{{.EmphasisRight}} as the options are the same.
+
+{{.LessThan}}table{{.GreaterThan}} may be a pattern, as in dolt_ignore, where {{.EmphasisLeft}}*{{.EmphasisRight}} matches any number of characters and {{.EmphasisLeft}}?{{.EmphasisRight}} matches a single character. Every table it matches is exported to a file named after it in the directory {{.LessThan}}dir{{.GreaterThan}}, in the format given by {{.EmphasisLeft}}--file-type{{.EmphasisRight}}, which defaults to csv. Use {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} to list the tables it matches without exporting them.
 `,
 	Synopsis: []string{
 		"[-f] [-pk {{.LessThan}}field{{.GreaterThan}}] [-schema {{.LessThan}}file{{.GreaterThan}}] [-map {{.LessThan}}file{{.GreaterThan}}] [-continue] [-file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"[-f] [--dry-run] [-file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}pattern{{.GreaterThan}} {{.LessThan}}dir{{.GreaterThan}}",
 	},
 }
 
 type exportOptions struct {
 	tableName  string
 	force      bool
+	dryRun     bool
 	dest       mvdata.DataLocation
 	srcOptions interface{}
+	// destDir is the directory the tables matching tableName are exported to when it's a pattern
+	destDir  string
+	fileType mvdata.DataFormat
 }
 
 func (m exportOptions) checkOverwrite(ctx context.Context, root *doltdb.RootValue, fs filesys.ReadableFS) (bool, error) {
@@ -132,6 +139,9 @@ func parseExportArgs(ap *argparser.ArgParser, commandStr string, args []string)
 	}
 
 	tableName := apr.Arg(0)
+	if doltdb.IsTablePattern(tableName) {
+		return parseExportPatternArgs(apr, tableName, usage)
+	}
 	if !doltdb.IsValidTableName(tableName) {
 		usage()
 		cli.PrintErrln(
@@ -149,10 +159,38 @@ func parseExportArgs(ap *argparser.ArgParser, commandStr string, args []string)
 	return &exportOptions{
 		tableName: tableName,
 		force:     apr.Contains(forceParam),
+		dryRun:    apr.Contains(cli.DryRunFlag),
 		dest:      fileLoc,
 	}, nil
 }
 
+// parseExportPatternArgs parses the arguments of an export of the tables matching |pattern|, which are exported to
+// files named after them in the directory given in place of a file.
+func parseExportPatternArgs(apr *argparser.ArgParseResults, pattern string, usage cli.UsagePrinter) (*exportOptions, errhand.VerboseError) {
+	dryRun := apr.Contains(cli.DryRunFlag)
+	if apr.NArg() < 2 && !dryRun {
+		usage()
+		return nil, errhand.BuildDError("a directory is required to export the tables matching '%s'", pattern).Build()
+	}
+
+	fileType := mvdata.DFFromString(apr.GetValueOrDefault(fileTypeParam, "csv"))
+	if fileType == mvdata.InvalidDataFormat {
+		return nil, errhand.BuildDError("invalid file type '%s'", apr.GetValueOrDefault(fileTypeParam, "")).Build()
+	}
+
+	var destDir string
+	if apr.NArg() > 1 {
+		destDir = apr.Arg(1)
+	}
+	return &exportOptions{
+		tableName: pattern,
+		force:     apr.Contains(forceParam),
+		dryRun:    dryRun,
+		destDir:   destDir,
+		fileType:  fileType,
+	}, nil
+}
+
 type ExportCmd struct{}
 
 // Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
@@ -176,6 +214,7 @@ func (cmd ExportCmd) ArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The file being output to."})
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
+	ap.SupportsFlag(cli.DryRunFlag, "", commands.DryRunTablesHelp)
 	return ap
 }
 
@@ -199,25 +238,70 @@ func (cmd ExportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
+	if doltdb.IsTablePattern(exOpts.tableName) {
+		verr = exportMatchingTables(ctx, dEnv, root, exOpts)
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if exOpts.dryRun {
+		commands.PrintResolvedTables([]string{exOpts.tableName})
+		return 0
+	}
+
+	verr = exportTable(ctx, dEnv, root, exOpts)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.PrintErrln(color.CyanString("Successfully exported data."))
+	return 0
+}
+
+// exportMatchingTables exports every table matching the pattern of |exOpts| to its own file in its destination
+// directory.
+func exportMatchingTables(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, exOpts *exportOptions) errhand.VerboseError {
+	tableNames, err := commands.ResolveTablePatterns(ctx, []string{exOpts.tableName}, root)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if exOpts.dryRun {
+		commands.PrintResolvedTables(tableNames)
+		return nil
+	}
+
+	for _, tableName := range tableNames {
+		path := filepath.Join(exOpts.destDir, tableName+string(exOpts.fileType))
+		verr := exportTable(ctx, dEnv, root, &exportOptions{
+			tableName: tableName,
+			force:     exOpts.force,
+			dest:      mvdata.NewDataLocation(path, string(exOpts.fileType)),
+		})
+		if verr != nil {
+			return verr
+		}
+	}
+
+	cli.PrintErrln(color.CyanString("Successfully exported %d tables.", len(tableNames)))
+	return nil
+}
+
+func exportTable(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, exOpts *exportOptions) errhand.VerboseError {
 	rd, err := mvdata.NewSqlEngineReader(ctx, dEnv, exOpts.tableName)
 	if err != nil {
-		return commands.HandleVErrAndExitCode(errhand.BuildDError("Error creating reader for %s.", exOpts.SrcName()).AddCause(err).Build(), usage)
+		return errhand.BuildDError("Error creating reader for %s.", exOpts.SrcName()).AddCause(err).Build()
 	}
 
 	wr, verr := getTableWriter(ctx, root, dEnv, rd.GetSchema(), exOpts)
 	if verr != nil {
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return verr
 	}
 
 	pipeline := mvdata.NewDataMoverPipeline(ctx, rd, wr)
 
 	err = pipeline.Execute()
 	if err != nil {
-		return commands.HandleVErrAndExitCode(errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build(), usage)
+		return errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
 	}
-
-	cli.PrintErrln(color.CyanString("Successfully exported data."))
-	return 0
+	return nil
 }
 
 func getTableWriter(ctx context.Context, root *doltdb.RootValue, dEnv *env.DoltEnv, rdSchema schema.Schema, exOpts *exportOptions) (table.SqlRowWriter, errhand.VerboseError) {
@@ -66,6 +66,7 @@ const (
 	quiet             = "quiet"
 	ignoreSkippedRows = "ignore-skipped-rows" // alias for quiet
 	disableFkChecks   = "disable-fk-checks"
+	skipUnchangedRows = "skip-unchanged"
 )
 
 var jsonInputFileHelp = "The expected JSON input file format is:" + `
@@ -92,6 +93,8 @@ If {{.EmphasisLeft}}--update-table | -u{{.EmphasisRight}} is given the operation
 
 During import, if there is an error importing any row, the import will be aborted by default. Use the {{.EmphasisLeft}}--continue{{.EmphasisRight}} flag to continue importing when an error is encountered. You can add the {{.EmphasisLeft}}--quiet{{.EmphasisRight}} flag to prevent the import utility from printing all the skipped rows. 
 
+Updates can be repeated with overlapping data using the {{.EmphasisLeft}}--skip-unchanged{{.EmphasisRight}} flag, which leaves out the rows that are identical to the existing rows with the same primary key. These rows are counted as having had no effect, and are not rewritten.
+
 If {{.EmphasisLeft}}--replace-table | -r{{.EmphasisRight}} is given the operation will replace {{.LessThan}}table{{.GreaterThan}} with the contents of the file. The table's existing schema will be used, and field names will be used to match file fields with table fields unless a mapping file is specified.
 
 If the schema for the existing table does not match the schema for the new file, the import will be aborted by default. To overwrite both the table and the schema, use {{.EmphasisLeft}}-c -f{{.EmphasisRight}}.
@@ -106,7 +109,7 @@ In create, update, and replace scenarios the file's extension is used to infer t
 
 	Synopsis: []string{
 		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue]  [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
-		"-u [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--quiet] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"-u [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--quiet] [--skip-unchanged] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
 		"-r [--map {{.LessThan}}file{{.GreaterThan}}] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
 	},
 }
@@ -125,6 +128,7 @@ type importOptions struct {
 	srcOptions      interface{}
 	quiet           bool
 	disableFkChecks bool
+	skipUnchanged   bool
 }
 
 func (m importOptions) IsBatched() bool {
@@ -267,6 +271,7 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		srcOptions:      srcOpts,
 		quiet:           quiet,
 		disableFkChecks: disableFks,
+		skipUnchanged:   apr.Contains(skipUnchangedRows),
 	}, nil
 
 }
@@ -284,6 +289,10 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		return errhand.BuildDError("Must include '-c' for initial table import or -u to update existing table or -r to replace existing table.").Build()
 	}
 
+	if apr.Contains(skipUnchangedRows) && !apr.Contains(updateParam) {
+		return errhand.BuildDError("fatal: " + skipUnchangedRows + " is only supported for update operations").Build()
+	}
+
 	if apr.Contains(schemaParam) && !apr.Contains(createParam) {
 		return errhand.BuildDError("fatal: " + schemaParam + " is not supported for update or replace operations").Build()
 	}
@@ -358,6 +367,7 @@ func (cmd ImportCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(quiet, "", "Suppress any warning messages about invalid rows when using the --continue flag.")
 	ap.SupportsAlias(ignoreSkippedRows, quiet)
 	ap.SupportsFlag(disableFkChecks, "", "Disables foreign key checks.")
+	ap.SupportsFlag(skipUnchangedRows, "", "Skip the rows that are identical to the existing rows with the same primary key when updating a table.")
 	ap.SupportsString(schemaParam, "s", "schema_file", "The schema for the output data.")
 	ap.SupportsString(mappingFileParam, "m", "mapping_file", "A file that lays out how fields should be mapped from input data to output data.")
 	ap.SupportsString(primaryKeyParam, "pk", "primary_key", "Explicitly define the name of the field in the schema which should be used as the primary key.")
@@ -467,7 +477,7 @@ func newImportDataReader(ctx context.Context, root *doltdb.RootValue, dEnv *env.
 }
 
 func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema schema.Schema, imOpts *importOptions) (*mvdata.SqlEngineTableWriter, *mvdata.DataMoverCreationError) {
-	moveOps := &mvdata.MoverOptions{Force: imOpts.force, TableToWriteTo: imOpts.destTableName, ContinueOnErr: imOpts.contOnErr, Operation: imOpts.operation, DisableFks: imOpts.disableFkChecks}
+	moveOps := &mvdata.MoverOptions{Force: imOpts.force, TableToWriteTo: imOpts.destTableName, ContinueOnErr: imOpts.contOnErr, Operation: imOpts.operation, DisableFks: imOpts.disableFkChecks, SkipUnchanged: imOpts.skipUnchanged}
 
 	// Returns the schema of the table to be created or the existing schema
 	tableSchema, dmce := getImportSchema(ctx, dEnv, imOpts)
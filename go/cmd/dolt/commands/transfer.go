@@ -0,0 +1,160 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+const transferReadOnlyFlag = "read-only"
+
+var transferDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Serve the remotes API for a repository over stdin and stdout",
+	LongDesc: `Serves the remotes API for the dolt repository at {{.LessThan}}path{{.GreaterThan}} over stdin and stdout. This command is run on the remote host by clients using {{.EmphasisLeft}}ssh://{{.EmphasisRight}} remotes, and is not meant to be run by hand.
+
+A path beginning with {{.EmphasisLeft}}/~/{{.EmphasisRight}} is resolved relative to the home directory of the user running the command.`,
+	Synopsis: []string{
+		"[--read-only] {{.LessThan}}path{{.GreaterThan}}",
+	},
+}
+
+type singletonDBCache struct {
+	s remotesrv.RemoteSrvStore
+}
+
+func (c singletonDBCache) Get(path, nbfVerStr string) (remotesrv.RemoteSrvStore, error) {
+	return c.s, nil
+}
+
+type TransferCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd TransferCmd) Name() string {
+	return dbfactory.SSHTransferCommand
+}
+
+// Description returns a description of the command
+func (cmd TransferCmd) Description() string {
+	return transferDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd TransferCmd) RequiresRepo() bool {
+	return false
+}
+
+// Hidden should return true if this command should be hidden from the help text
+func (cmd TransferCmd) Hidden() bool {
+	return true
+}
+
+func (cmd TransferCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(transferDocs, ap)
+}
+
+func (cmd TransferCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.SupportsFlag(transferReadOnlyFlag, "", "Reject pushes to the repository.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd TransferCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, transferDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	// stdout carries the protocol, so nothing else may be written to it
+	logrus.SetOutput(os.Stderr)
+
+	return HandleVErrAndExitCode(serveTransfer(ctx, dEnv, apr.Arg(0), apr.Contains(transferReadOnlyFlag)), usage)
+}
+
+func serveTransfer(ctx context.Context, dEnv *env.DoltEnv, path string, readOnly bool) errhand.VerboseError {
+	if strings.HasPrefix(path, "/~/") {
+		path = path[len("/~/"):]
+	}
+
+	fs, err := filesys.LocalFilesysWithWorkingDir(path)
+	if err != nil {
+		return errhand.BuildDError("error: could not access '%s'", path).AddCause(err).Build()
+	}
+	repoEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, dEnv.Version)
+	if !repoEnv.Valid() {
+		if repoEnv.DBLoadError != nil {
+			return errhand.BuildDError("error: could not load the repository at '%s'", path).AddCause(repoEnv.DBLoadError).Build()
+		}
+		return errhand.BuildDError("error: '%s' is not a dolt repository", path).Build()
+	}
+
+	cs, ok := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(repoEnv.DoltDB)).(remotesrv.RemoteSrvStore)
+	if !ok {
+		return errhand.BuildDError("error: the repository at '%s' cannot be served as a remote", path).Build()
+	}
+
+	// Both services share the one connection, and the host in generated URLs is ignored by ssh clients.
+	srv, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		Logger:         logrus.NewEntry(logrus.StandardLogger()),
+		HttpHost:       "ssh",
+		HttpListenAddr: "stdio",
+		GrpcListenAddr: "stdio",
+		FS:             fs,
+		DBCache:        singletonDBCache{cs},
+		ReadOnly:       readOnly,
+	})
+	if err != nil {
+		return errhand.BuildDError("error: could not start the remotes API").AddCause(err).Build()
+	}
+
+	conn := iohelp.NewPipeConn(os.Stdin, nopWriteCloser{os.Stdout}, nil)
+	go func() {
+		<-conn.Done()
+		srv.GracefulStop()
+	}()
+	srv.Serve(remotesrv.ConnListeners(conn))
+
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
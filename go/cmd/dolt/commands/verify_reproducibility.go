@@ -0,0 +1,474 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var verifyReproducibilityDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Checks that a SQL script builds the same database every time it's run",
+	LongDesc: `Runs the SQL script {{.LessThan}}file{{.GreaterThan}} against two new, empty databases and compares the commit and working set hashes of every branch of the results. Both databases are created with the same initial commit, so a script that builds the same data, schema and history produces identical hashes. Scripts can import data with {{.EmphasisLeft}}LOAD DATA{{.EmphasisRight}}.
+
+If the hashes differ, the command reports the tables whose schema or rows differ, along with likely sources of nondeterminism: columns with defaults such as {{.EmphasisLeft}}CURRENT_TIMESTAMP{{.EmphasisRight}} or {{.EmphasisLeft}}UUID(){{.EmphasisRight}}, {{.EmphasisLeft}}AUTO_INCREMENT{{.EmphasisRight}} columns whose values depend on the order rows are inserted in, statements of the script which call time or random functions, and commits whose only difference is the time they were made. Commits made by the script should supply a date, as in {{.EmphasisLeft}}CALL dolt_commit('-m', 'message', '--date', '2023-01-01T00:00:00Z'){{.EmphasisRight}}. The time at which each commit is written is not compared.
+
+The command exits with a non-zero status if the hashes differ. The current directory does not need to be a dolt database, and no database is modified.`,
+	Synopsis: []string{
+		"{{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+// nondeterministicFuncs matches the SQL functions whose results vary from one run of a script to the next.
+var nondeterministicFuncs = regexp.MustCompile(`(?i)\b(now|sysdate|curdate|curtime|current_date|current_time|current_timestamp|localtime|localtimestamp|utc_date|utc_time|utc_timestamp|unix_timestamp|rand|uuid|uuid_short|connection_id)\b`)
+
+type VerifyReproducibilityCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd VerifyReproducibilityCmd) Name() string {
+	return "verify-reproducibility"
+}
+
+// Description returns a description of the command
+func (cmd VerifyReproducibilityCmd) Description() string {
+	return "Check that a SQL script builds the same database every time it's run."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd VerifyReproducibilityCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd VerifyReproducibilityCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(verifyReproducibilityDocs, ap)
+}
+
+func (cmd VerifyReproducibilityCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The SQL script to run."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd VerifyReproducibilityCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, verifyReproducibilityDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	path := apr.Arg(0)
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read %s", path).AddCause(err).Build(), usage)
+	}
+
+	// both replays must start from the same initial commit. Commits record the time they were made as well as their
+	// date, so the clock is stopped for both replays, leaving the dates the script gives its commits to decide them.
+	initTime := datas.CommitNowFunc()
+	defer func(now func() time.Time) { datas.CommitNowFunc = now }(datas.CommitNowFunc)
+	datas.CommitNowFunc = func() time.Time { return initTime }
+	var replays [2]*scriptReplay
+	for i := range replays {
+		replays[i], err = replayScript(ctx, dEnv, script, initTime)
+		if replays[i] != nil {
+			defer replays[i].close()
+		}
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to run %s", path).AddCause(err).Build(), usage)
+		}
+	}
+
+	differences, err := compareReplays(ctx, replays[0], replays[1])
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to compare the databases built by %s", path).AddCause(err).Build(), usage)
+	}
+
+	if len(differences) == 0 {
+		cli.Printf("%s is reproducible. Both runs built identical branches:\n", path)
+		for _, name := range replays[0].branchNames() {
+			cli.Printf("\t%s\t%s\n", name, replays[0].branches[name].commit.String())
+		}
+		return 0
+	}
+
+	cli.PrintErrf("%s is not reproducible. The databases built by two runs differ:\n", path)
+	for _, d := range differences {
+		cli.PrintErrln("\t" + d)
+	}
+	if len(replays[0].nondeterministic) > 0 {
+		cli.PrintErrln()
+		cli.PrintErrln("These statements call functions whose results vary from run to run:")
+		for _, s := range replays[0].nondeterministic {
+			cli.PrintErrln("\t" + s)
+		}
+	}
+	return 1
+}
+
+// branchState is the state of a branch of a database built by a script.
+type branchState struct {
+	commit  hash.Hash
+	head    *doltdb.RootValue
+	working *doltdb.RootValue
+}
+
+// scriptReplay is a database built by running a script against an empty database.
+type scriptReplay struct {
+	dir      string
+	se       *engine.SqlEngine
+	branches map[string]branchState
+	// nondeterministic are the statements of the script which call nondeterministic functions, by line
+	nondeterministic []string
+}
+
+// replayScript runs |script| against a new database, created in a temporary directory with an initial commit made
+// at |initTime|, and returns the resulting branches.
+func replayScript(ctx context.Context, dEnv *env.DoltEnv, script []byte, initTime time.Time) (*scriptReplay, error) {
+	dir, err := os.MkdirTemp("", "dolt-verify-reproducibility-")
+	if err != nil {
+		return nil, err
+	}
+	r := &scriptReplay{dir: dir, branches: make(map[string]branchState)}
+
+	fs, err := filesys.LocalFS.WithWorkingDir(dir)
+	if err != nil {
+		return r, err
+	}
+	name := dEnv.Config.IfEmptyUseConfig("", env.UserNameKey)
+	email := dEnv.Config.IfEmptyUseConfig("", env.UserEmailKey)
+	if name == "" || email == "" {
+		return r, fmt.Errorf("%s and %s must be set in the global config", env.UserNameKey, env.UserEmailKey)
+	}
+
+	replayEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, dEnv.Version)
+	err = replayEnv.InitRepoWithTime(ctx, types.Format_Default, name, email, env.GetDefaultInitBranch(dEnv.Config), initTime)
+	if err != nil {
+		return r, err
+	}
+
+	mrEnv, err := env.MultiEnvForDirectory(ctx, replayEnv.Config.WriteableConfig(), replayEnv.FS, replayEnv.Version, replayEnv.IgnoreLockFile, replayEnv)
+	if err != nil {
+		return r, err
+	}
+	r.se, err = engine.NewSqlEngine(ctx, mrEnv, engine.FormatNull, &engine.SqlEngineConfig{
+		ServerUser: "root",
+		ServerHost: "localhost",
+		Autocommit: true,
+	})
+	if err != nil {
+		return r, err
+	}
+	sqlCtx, err := r.se.NewLocalContext(ctx)
+	if err != nil {
+		return r, err
+	}
+	sqlCtx.SetCurrentDatabase(mrEnv.GetFirstDatabase())
+
+	r.nondeterministic, err = execScriptSilently(sqlCtx, r.se, bytes.NewReader(script))
+	if err != nil {
+		return r, err
+	}
+
+	err = r.loadBranches(ctx, replayEnv.DoltDB)
+	return r, err
+}
+
+// execScriptSilently runs the statements of |input|, discarding their results, and returns the statements which call
+// nondeterministic functions.
+func execScriptSilently(ctx *sql.Context, se *engine.SqlEngine, input io.Reader) ([]string, error) {
+	var nondeterministic []string
+	scanner := NewSqlStatementScanner(input)
+	var query string
+	for scanner.Scan() {
+		query += scanner.Text()
+		if len(query) == 0 || query == "\n" {
+			continue
+		}
+		sqlStatement, err := sqlparser.Parse(query)
+		if err == sqlparser.ErrEmpty {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error on line %d for query %s: %w", scanner.statementStartLine, query, err)
+		}
+
+		if nondeterministicFuncs.MatchString(query) {
+			stmt := strings.Join(strings.Fields(query), " ")
+			nondeterministic = append(nondeterministic, fmt.Sprintf("line %d: %s", scanner.statementStartLine, stmt))
+		}
+
+		ctx.SetQueryTime(time.Now())
+		_, rowIter, err := processParsedQuery(ctx, query, se, sqlStatement)
+		if err == nil && rowIter != nil {
+			_, err = sql.RowIterToRows(ctx, nil, rowIter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error on line %d for query %s: %w", scanner.statementStartLine, query, err)
+		}
+		query = ""
+	}
+
+	return nondeterministic, scanner.Err()
+}
+
+// loadBranches records the commit, head root and working root of every branch of |ddb|.
+func (r *scriptReplay) loadBranches(ctx context.Context, ddb *doltdb.DoltDB) error {
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, br := range branches {
+		cm, err := ddb.ResolveCommitRef(ctx, br)
+		if err != nil {
+			return err
+		}
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		head, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+
+		working := head
+		wsRef, err := ref.WorkingSetRefForHead(br)
+		if err != nil {
+			return err
+		}
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == nil {
+			working = ws.WorkingRoot()
+		} else if err != doltdb.ErrWorkingSetNotFound {
+			return err
+		}
+
+		r.branches[br.GetPath()] = branchState{commit: h, head: head, working: working}
+	}
+	return nil
+}
+
+func (r *scriptReplay) branchNames() []string {
+	names := make([]string, 0, len(r.branches))
+	for name := range r.branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *scriptReplay) close() {
+	if r.se != nil {
+		r.se.Close()
+	}
+	os.RemoveAll(r.dir)
+}
+
+// compareReplays returns a description of every difference between the branches of |left| and |right|.
+func compareReplays(ctx context.Context, left, right *scriptReplay) ([]string, error) {
+	var differences []string
+	for _, name := range left.branchNames() {
+		if _, ok := right.branches[name]; !ok {
+			differences = append(differences, fmt.Sprintf("branch %s was only created by the first run", name))
+		}
+	}
+	for _, name := range right.branchNames() {
+		if _, ok := left.branches[name]; !ok {
+			differences = append(differences, fmt.Sprintf("branch %s was only created by the second run", name))
+		}
+	}
+
+	for _, name := range left.branchNames() {
+		l := left.branches[name]
+		r, ok := right.branches[name]
+		if !ok {
+			continue
+		}
+
+		if l.commit != r.commit {
+			diffs, err := compareRoots(ctx, l.head, r.head)
+			if err != nil {
+				return nil, err
+			}
+			if len(diffs) == 0 {
+				differences = append(differences, fmt.Sprintf("branch %s: the commits have the same data but different metadata, such as the time they were made", name))
+			}
+			for _, d := range diffs {
+				differences = append(differences, fmt.Sprintf("branch %s: %s", name, d))
+			}
+		}
+
+		diffs, err := compareRoots(ctx, l.working, r.working)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range diffs {
+			differences = append(differences, fmt.Sprintf("branch %s, working set: %s", name, d))
+		}
+	}
+	return differences, nil
+}
+
+// compareRoots returns a description of every difference between |left| and |right|.
+func compareRoots(ctx context.Context, left, right *doltdb.RootValue) ([]string, error) {
+	lh, err := left.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	rh, err := right.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if lh == rh {
+		return nil, nil
+	}
+
+	lNames, err := left.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rNames, err := right.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{})
+	for _, name := range append(lNames, rNames...) {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var differences []string
+	for _, name := range sorted {
+		diffs, err := compareTables(ctx, name, left, right)
+		if err != nil {
+			return nil, err
+		}
+		differences = append(differences, diffs...)
+	}
+	if len(differences) == 0 {
+		differences = append(differences, "the tables are identical, but the foreign keys or other database metadata differ")
+	}
+	return differences, nil
+}
+
+// compareTables returns a description of every difference between the table |name| of |left| and that of |right|,
+// and of the columns which may have caused them.
+func compareTables(ctx context.Context, name string, left, right *doltdb.RootValue) ([]string, error) {
+	lt, lok, err := left.GetTable(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	rt, rok, err := right.GetTable(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !lok {
+		return []string{fmt.Sprintf("table %s was only created by the second run", name)}, nil
+	} else if !rok {
+		return []string{fmt.Sprintf("table %s was only created by the first run", name)}, nil
+	}
+
+	lh, err := lt.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	rh, err := rt.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if lh == rh {
+		return nil, nil
+	}
+
+	var differences []string
+	lSchHash, err := lt.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rSchHash, err := rt.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if lSchHash != rSchHash {
+		differences = append(differences, fmt.Sprintf("table %s: the schemas differ", name))
+	}
+
+	lRowHash, err := lt.GetRowDataHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rRowHash, err := rt.GetRowDataHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if lRowHash != rRowHash {
+		differences = append(differences, fmt.Sprintf("table %s: the rows differ", name))
+
+		sch, err := lt.GetSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			if col.Default != "" && nondeterministicFuncs.MatchString(col.Default) {
+				differences = append(differences, fmt.Sprintf("table %s: column %s has a nondeterministic default: %s", name, col.Name, col.Default))
+			}
+			if col.AutoIncrement {
+				differences = append(differences, fmt.Sprintf("table %s: column %s is AUTO_INCREMENT, so its values depend on the order rows are inserted in", name, col.Name))
+			}
+			return false, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(differences) == 0 {
+		differences = append(differences, fmt.Sprintf("table %s: the secondary indexes, constraints or AUTO_INCREMENT counters differ", name))
+	}
+	return differences, nil
+}
@@ -56,6 +56,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/util/tempfiles"
 )
@@ -97,16 +98,20 @@ var doltSubCommands = []cli.Command{
 	commands.LoginCmd{},
 	credcmds.Commands,
 	commands.LsCmd{},
+	commands.LintCmd{},
+	commands.VerifyReproducibilityCmd{},
 	schcmds.Commands,
 	tblcmds.Commands,
 	commands.TagCmd{},
 	commands.BlameCmd{},
 	cvcmds.Commands,
 	commands.SendMetricsCmd{},
+	commands.TransferCmd{},
 	commands.MigrateCmd{},
 	indexcmds.Commands,
 	commands.ReadTablesCmd{},
 	commands.GarbageCollectionCmd{},
+	commands.FsckCmd{},
 	commands.FilterBranchCmd{},
 	commands.MergeBaseCmd{},
 	commands.RootsCmd{},
@@ -132,6 +137,7 @@ func init() {
 const pprofServerFlag = "--pprof-server"
 const chdirFlag = "--chdir"
 const jaegerFlag = "--jaeger"
+const otlpFlag = "--otlp"
 const profFlag = "--prof"
 const csMetricsFlag = "--csmetrics"
 const stdInFlag = "--stdin"
@@ -247,6 +253,18 @@ func runMain() int {
 					defer tp.Shutdown(context.Background())
 					args = args[1:]
 				}
+
+			// Export the spans of this run of Dolt to an OTLP collector over HTTP. The collector is configured with the
+			// standard OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_EXPORTER_OTLP_HEADERS environment variables, and is at
+			// localhost:4318 by default.
+			case otlpFlag:
+				shutdown, err := tracing.StartOTLP(context.Background(), Version, tracing.OTLPConfig{SampleRatio: 1})
+				if err != nil {
+					cli.Println(color.YellowString("could not create otlp exporter: %v", err))
+				} else {
+					defer shutdown(context.Background())
+				}
+				args = args[1:]
 			// Currently goland doesn't support running with a different working directory when using go modules.
 			// This is a hack that allows a different working directory to be set after the application starts using
 			// chdir=<DIR>.  The syntax is not flexible and must match exactly this.
@@ -434,7 +452,21 @@ func runMain() int {
 		cliCtx = tmpCliContext{globalArgs: apr}
 	}
 
+	// ignore patterns set in the config apply along with the dolt_ignore table of the current branch
+	var branch string
+	if dEnv.RSLoadErr == nil && dEnv.RepoState != nil && dEnv.RepoState.CWBHeadRef() != nil {
+		branch = dEnv.RepoState.CWBHeadRef().GetPath()
+	}
+	ctx = doltdb.WithConfiguredIgnorePatterns(ctx, env.ConfiguredIgnorePatterns(dEnv, branch))
+
+	// the spans of the command are the children of a span of the whole command, when they're exported
+	spanName := "dolt"
+	if len(args) > 0 {
+		spanName += " " + args[0]
+	}
+	ctx, span := otel.Tracer("github.com/dolthub/dolt/go/cmd/dolt").Start(ctx, spanName)
 	res := doltCommand.Exec(ctx, "dolt", args, dEnv, cliCtx)
+	span.End()
 	stop()
 
 	if err = dbfactory.CloseAllLocalDatabases(); err != nil {
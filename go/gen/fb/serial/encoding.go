@@ -47,11 +47,13 @@ const (
 	EncodingStringAddr Encoding = 23
 	EncodingJSONAddr   Encoding = 24
 	EncodingCell       Encoding = 25
+	EncodingDecimal64  Encoding = 26
 	EncodingString     Encoding = 128
 	EncodingBytes      Encoding = 129
 	EncodingDecimal    Encoding = 130
 	EncodingJSON       Encoding = 131
 	EncodingGeometry   Encoding = 133
+	EncodingStringDict Encoding = 134
 )
 
 var EnumNamesEncoding = map[Encoding]string{
@@ -79,11 +81,13 @@ var EnumNamesEncoding = map[Encoding]string{
 	EncodingStringAddr: "StringAddr",
 	EncodingJSONAddr:   "JSONAddr",
 	EncodingCell:       "Cell",
+	EncodingDecimal64:  "Decimal64",
 	EncodingString:     "String",
 	EncodingBytes:      "Bytes",
 	EncodingDecimal:    "Decimal",
 	EncodingJSON:       "JSON",
 	EncodingGeometry:   "Geometry",
+	EncodingStringDict: "StringDict",
 }
 
 var EnumValuesEncoding = map[string]Encoding{
@@ -111,11 +115,13 @@ var EnumValuesEncoding = map[string]Encoding{
 	"StringAddr": EncodingStringAddr,
 	"JSONAddr":   EncodingJSONAddr,
 	"Cell":       EncodingCell,
+	"Decimal64":  EncodingDecimal64,
 	"String":     EncodingString,
 	"Bytes":      EncodingBytes,
 	"Decimal":    EncodingDecimal,
 	"JSON":       EncodingJSON,
 	"Geometry":   EncodingGeometry,
+	"StringDict": EncodingStringDict,
 }
 
 func (v Encoding) String() string {
@@ -343,7 +343,19 @@ func (rcv *ForeignKey) UnresolvedParentColumnsLength() int {
 	return 0
 }
 
-const ForeignKeyNumFields = 11
+func (rcv *ForeignKey) NotEnforced() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(26))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *ForeignKey) MutateNotEnforced(n bool) bool {
+	return rcv._tab.MutateBoolSlot(26, n)
+}
+
+const ForeignKeyNumFields = 12
 
 func ForeignKeyStart(builder *flatbuffers.Builder) {
 	builder.StartObject(ForeignKeyNumFields)
@@ -393,6 +405,9 @@ func ForeignKeyAddUnresolvedParentColumns(builder *flatbuffers.Builder, unresolv
 func ForeignKeyStartUnresolvedParentColumnsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(4, numElems, 4)
 }
+func ForeignKeyAddNotEnforced(builder *flatbuffers.Builder, notEnforced bool) {
+	builder.PrependBoolSlot(11, notEnforced, false)
+}
 func ForeignKeyEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -427,7 +427,24 @@ func (rcv *Column) MutateVirtual(n bool) bool {
 	return rcv._tab.MutateBoolSlot(28, n)
 }
 
-const ColumnNumFields = 13
+func (rcv *Column) Dictionary(j int) []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(30))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.ByteVector(a + flatbuffers.UOffsetT(j*4))
+	}
+	return nil
+}
+
+func (rcv *Column) DictionaryLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(30))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+const ColumnNumFields = 14
 
 func ColumnStart(builder *flatbuffers.Builder) {
 	builder.StartObject(ColumnNumFields)
@@ -471,6 +488,12 @@ func ColumnAddGenerated(builder *flatbuffers.Builder, generated bool) {
 func ColumnAddVirtual(builder *flatbuffers.Builder, virtual bool) {
 	builder.PrependBoolSlot(12, virtual, false)
 }
+func ColumnAddDictionary(builder *flatbuffers.Builder, dictionary flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(13, flatbuffers.UOffsetT(dictionary), 0)
+}
+func ColumnStartDictionaryVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
 func ColumnEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -687,7 +710,15 @@ func (rcv *Index) MutateSpatialKey(n bool) bool {
 	return rcv._tab.MutateBoolSlot(22, n)
 }
 
-const IndexNumFields = 10
+func (rcv *Index) Predicate() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(24))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+const IndexNumFields = 11
 
 func IndexStart(builder *flatbuffers.Builder) {
 	builder.StartObject(IndexNumFields)
@@ -734,6 +765,9 @@ func IndexStartPrefixLengthsVector(builder *flatbuffers.Builder, numElems int) f
 func IndexAddSpatialKey(builder *flatbuffers.Builder, spatialKey bool) {
 	builder.PrependBoolSlot(9, spatialKey, false)
 }
+func IndexAddPredicate(builder *flatbuffers.Builder, predicate flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(10, flatbuffers.UOffsetT(predicate), 0)
+}
 func IndexEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -0,0 +1,973 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// <p>
+// http://www.apache.org/licenses/LICENSE-2.0
+// <p>
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.22.0
+// source: arrow/flight/protocol/sql/flight_sql.proto
+
+package flightsql
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a SQL query. Used in the command member of FlightDescriptor
+// for the following RPC calls:
+//   - GetSchema: return the Arrow schema of the query.
+//     Fields on this schema may contain the following metadata:
+//   - ARROW:FLIGHT:SQL:CATALOG_NAME      - Table's catalog name
+//   - ARROW:FLIGHT:SQL:DB_SCHEMA_NAME    - Database schema name
+//   - ARROW:FLIGHT:SQL:TABLE_NAME        - Table name
+//   - ARROW:FLIGHT:SQL:TYPE_NAME         - The data source-specific name for the data type of the column.
+//   - ARROW:FLIGHT:SQL:PRECISION         - Column precision/size
+//   - ARROW:FLIGHT:SQL:SCALE             - Column scale/decimal digits if applicable
+//   - ARROW:FLIGHT:SQL:IS_AUTO_INCREMENT - "1" indicates if the column is auto incremented, "0" otherwise.
+//   - ARROW:FLIGHT:SQL:IS_CASE_SENSITIVE - "1" indicates if the column is case sensitive, "0" otherwise.
+//   - ARROW:FLIGHT:SQL:IS_READ_ONLY      - "1" indicates if the column is read only, "0" otherwise.
+//   - ARROW:FLIGHT:SQL:IS_SEARCHABLE     - "1" indicates if the column is searchable via WHERE clause, "0" otherwise.
+//   - GetFlightInfo: execute the query.
+type CommandStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The SQL syntax.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Include the query as part of this transaction (if unset, the query is auto-committed).
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3,oneof" json:"transaction_id,omitempty"`
+}
+
+func (x *CommandStatementQuery) Reset() {
+	*x = CommandStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandStatementQuery) ProtoMessage() {}
+
+func (x *CommandStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandStatementQuery.ProtoReflect.Descriptor instead.
+func (*CommandStatementQuery) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CommandStatementQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CommandStatementQuery) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// *
+// Represents a ticket resulting from GetFlightInfo with a CommandStatementQuery.
+// This should be used only once and treated as an opaque value, that is, clients should not attempt to parse this.
+type TicketStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique identifier for the instance of the statement to execute.
+	StatementHandle []byte `protobuf:"bytes,1,opt,name=statement_handle,json=statementHandle,proto3" json:"statement_handle,omitempty"`
+}
+
+func (x *TicketStatementQuery) Reset() {
+	*x = TicketStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TicketStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketStatementQuery) ProtoMessage() {}
+
+func (x *TicketStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketStatementQuery.ProtoReflect.Descriptor instead.
+func (*TicketStatementQuery) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TicketStatementQuery) GetStatementHandle() []byte {
+	if x != nil {
+		return x.StatementHandle
+	}
+	return nil
+}
+
+// Represents a request to retrieve the list of catalogs on a Flight SQL enabled backend.
+// The definition of a catalog depends on vendor/implementation. It is usually the database itself
+// Used in the command member of FlightDescriptor for the following RPC calls:
+//   - GetSchema: return the Arrow schema of the query.
+//   - GetFlightInfo: execute the catalog metadata request.
+//
+// The returned Arrow schema will be:
+// <
+//
+//	catalog_name: utf8 not null
+//
+// >
+// The returned data should be ordered by catalog_name.
+type CommandGetCatalogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommandGetCatalogs) Reset() {
+	*x = CommandGetCatalogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetCatalogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetCatalogs) ProtoMessage() {}
+
+func (x *CommandGetCatalogs) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetCatalogs.ProtoReflect.Descriptor instead.
+func (*CommandGetCatalogs) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{2}
+}
+
+// Represents a request to retrieve the list of database schemas on a Flight SQL enabled backend.
+// The definition of a database schema depends on vendor/implementation. It is usually a collection of tables.
+// Used in the command member of FlightDescriptor for the following RPC calls:
+//   - GetSchema: return the Arrow schema of the query.
+//   - GetFlightInfo: execute the catalog metadata request.
+//
+// The returned Arrow schema will be:
+// <
+//
+//	catalog_name: utf8,
+//	db_schema_name: utf8 not null
+//
+// >
+// The returned data should be ordered by catalog_name, then db_schema_name.
+type CommandGetDbSchemas struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// Specifies the Catalog to search for the tables.
+	// An empty string retrieves those without a catalog.
+	// If omitted the catalog name should not be used to narrow the search.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	//
+	// Specifies a filter pattern for schemas to search for.
+	// When no db_schema_filter_pattern is provided, the pattern will not be used to narrow the search.
+	// In the pattern string, two special characters can be used to denote matching rules:
+	//    - "%" means to match any substring with 0 or more characters.
+	//    - "_" means to match any one character.
+	DbSchemaFilterPattern *string `protobuf:"bytes,2,opt,name=db_schema_filter_pattern,json=dbSchemaFilterPattern,proto3,oneof" json:"db_schema_filter_pattern,omitempty"`
+}
+
+func (x *CommandGetDbSchemas) Reset() {
+	*x = CommandGetDbSchemas{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetDbSchemas) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetDbSchemas) ProtoMessage() {}
+
+func (x *CommandGetDbSchemas) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetDbSchemas.ProtoReflect.Descriptor instead.
+func (*CommandGetDbSchemas) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CommandGetDbSchemas) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetDbSchemas) GetDbSchemaFilterPattern() string {
+	if x != nil && x.DbSchemaFilterPattern != nil {
+		return *x.DbSchemaFilterPattern
+	}
+	return ""
+}
+
+// Represents a request to retrieve the list of tables, and optionally their schemas, on a Flight SQL enabled backend.
+// Used in the command member of FlightDescriptor for the following RPC calls:
+//   - GetSchema: return the Arrow schema of the query.
+//   - GetFlightInfo: execute the catalog metadata request.
+//
+// The returned Arrow schema will be:
+// <
+//
+//	catalog_name: utf8,
+//	db_schema_name: utf8,
+//	table_name: utf8 not null,
+//	table_type: utf8 not null,
+//	[optional] table_schema: bytes not null (schema of the table as described in Schema.fbs::Schema,
+//	                                         it is serialized as an IPC message.)
+//
+// >
+// The returned data should be ordered by catalog_name, db_schema_name, table_name, then table_type, followed by table_schema if requested.
+type CommandGetTables struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// Specifies the Catalog to search for the tables.
+	// An empty string retrieves those without a catalog.
+	// If omitted the catalog name should not be used to narrow the search.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	//
+	// Specifies a filter pattern for schemas to search for.
+	// When no db_schema_filter_pattern is provided, all schemas matching other filters are searched.
+	// In the pattern string, two special characters can be used to denote matching rules:
+	//    - "%" means to match any substring with 0 or more characters.
+	//    - "_" means to match any one character.
+	DbSchemaFilterPattern *string `protobuf:"bytes,2,opt,name=db_schema_filter_pattern,json=dbSchemaFilterPattern,proto3,oneof" json:"db_schema_filter_pattern,omitempty"`
+	//
+	// Specifies a filter pattern for tables to search for.
+	// When no table_name_filter_pattern is provided, all tables matching other filters are searched.
+	// In the pattern string, two special characters can be used to denote matching rules:
+	//    - "%" means to match any substring with 0 or more characters.
+	//    - "_" means to match any one character.
+	TableNameFilterPattern *string `protobuf:"bytes,3,opt,name=table_name_filter_pattern,json=tableNameFilterPattern,proto3,oneof" json:"table_name_filter_pattern,omitempty"`
+	//
+	// Specifies a filter of table types which must match.
+	// The table types depend on vendor/implementation. It is usually used to separate tables from views or system tables.
+	// TABLE, VIEW, and SYSTEM TABLE are commonly supported.
+	TableTypes []string `protobuf:"bytes,4,rep,name=table_types,json=tableTypes,proto3" json:"table_types,omitempty"`
+	// Specifies if the Arrow schema should be returned for found tables.
+	IncludeSchema bool `protobuf:"varint,5,opt,name=include_schema,json=includeSchema,proto3" json:"include_schema,omitempty"`
+}
+
+func (x *CommandGetTables) Reset() {
+	*x = CommandGetTables{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetTables) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetTables) ProtoMessage() {}
+
+func (x *CommandGetTables) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetTables.ProtoReflect.Descriptor instead.
+func (*CommandGetTables) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CommandGetTables) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetDbSchemaFilterPattern() string {
+	if x != nil && x.DbSchemaFilterPattern != nil {
+		return *x.DbSchemaFilterPattern
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetTableNameFilterPattern() string {
+	if x != nil && x.TableNameFilterPattern != nil {
+		return *x.TableNameFilterPattern
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetTableTypes() []string {
+	if x != nil {
+		return x.TableTypes
+	}
+	return nil
+}
+
+func (x *CommandGetTables) GetIncludeSchema() bool {
+	if x != nil {
+		return x.IncludeSchema
+	}
+	return false
+}
+
+// Represents a request to retrieve the list of table types on a Flight SQL enabled backend.
+// The table types depend on vendor/implementation. It is usually used to separate tables from views or system tables.
+// TABLE, VIEW, and SYSTEM TABLE are commonly supported.
+// Used in the command member of FlightDescriptor for the following RPC calls:
+//   - GetSchema: return the Arrow schema of the query.
+//   - GetFlightInfo: execute the catalog metadata request.
+//
+// The returned Arrow schema will be:
+// <
+//
+//	table_type: utf8 not null
+//
+// >
+// The returned data should be ordered by table_type.
+type CommandGetTableTypes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommandGetTableTypes) Reset() {
+	*x = CommandGetTableTypes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetTableTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetTableTypes) ProtoMessage() {}
+
+func (x *CommandGetTableTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetTableTypes.ProtoReflect.Descriptor instead.
+func (*CommandGetTableTypes) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{5}
+}
+
+// Request message for the "CreatePreparedStatement" action on a Flight SQL enabled backend.
+type ActionCreatePreparedStatementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The valid SQL string to create a prepared statement for.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Create/execute the prepared statement as part of this transaction (if
+	// unset, executions of the prepared statement will be auto-committed).
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3,oneof" json:"transaction_id,omitempty"`
+}
+
+func (x *ActionCreatePreparedStatementRequest) Reset() {
+	*x = ActionCreatePreparedStatementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCreatePreparedStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCreatePreparedStatementRequest) ProtoMessage() {}
+
+func (x *ActionCreatePreparedStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCreatePreparedStatementRequest.ProtoReflect.Descriptor instead.
+func (*ActionCreatePreparedStatementRequest) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ActionCreatePreparedStatementRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ActionCreatePreparedStatementRequest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// Wrap the result of a "CreatePreparedStatement" action.
+//
+// The resultant PreparedStatement can be closed either:
+// - Manually, through the "ClosePreparedStatement" action;
+// - Automatically, by a server timeout.
+//
+// The result should be wrapped in a google.protobuf.Any message.
+type ActionCreatePreparedStatementResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+	// If a result set generating query was provided, dataset_schema contains the
+	// schema of the result set.  It should be an IPC-encapsulated Schema, as described in Schema.fbs.
+	// For some queries, the schema of the results may depend on the schema of the parameters.  The server
+	// should provide its best guess as to the schema at this point.  Clients must not assume that this
+	// schema, if provided, will be accurate.
+	DatasetSchema []byte `protobuf:"bytes,2,opt,name=dataset_schema,json=datasetSchema,proto3" json:"dataset_schema,omitempty"`
+	// If the query provided contained parameters, parameter_schema contains the
+	// schema of the expected parameters.  It should be an IPC-encapsulated Schema, as described in Schema.fbs.
+	ParameterSchema []byte `protobuf:"bytes,3,opt,name=parameter_schema,json=parameterSchema,proto3" json:"parameter_schema,omitempty"`
+}
+
+func (x *ActionCreatePreparedStatementResult) Reset() {
+	*x = ActionCreatePreparedStatementResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCreatePreparedStatementResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCreatePreparedStatementResult) ProtoMessage() {}
+
+func (x *ActionCreatePreparedStatementResult) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCreatePreparedStatementResult.ProtoReflect.Descriptor instead.
+func (*ActionCreatePreparedStatementResult) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ActionCreatePreparedStatementResult) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+func (x *ActionCreatePreparedStatementResult) GetDatasetSchema() []byte {
+	if x != nil {
+		return x.DatasetSchema
+	}
+	return nil
+}
+
+func (x *ActionCreatePreparedStatementResult) GetParameterSchema() []byte {
+	if x != nil {
+		return x.ParameterSchema
+	}
+	return nil
+}
+
+// Request message for the "ClosePreparedStatement" action on a Flight SQL enabled backend.
+// Closes server resources associated with the prepared statement handle.
+type ActionClosePreparedStatementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+}
+
+func (x *ActionClosePreparedStatementRequest) Reset() {
+	*x = ActionClosePreparedStatementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionClosePreparedStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionClosePreparedStatementRequest) ProtoMessage() {}
+
+func (x *ActionClosePreparedStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionClosePreparedStatementRequest.ProtoReflect.Descriptor instead.
+func (*ActionClosePreparedStatementRequest) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ActionClosePreparedStatementRequest) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+// Represents an instance of executing a prepared statement. Used in the command member of FlightDescriptor for
+// the following RPC calls:
+//   - GetSchema: return the Arrow schema of the query.
+//   - DoPut: bind parameter values. All of the bound parameter sets will be executed as a single atomic execution.
+//   - GetFlightInfo: execute the prepared statement instance.
+type CommandPreparedStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+}
+
+func (x *CommandPreparedStatementQuery) Reset() {
+	*x = CommandPreparedStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandPreparedStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandPreparedStatementQuery) ProtoMessage() {}
+
+func (x *CommandPreparedStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandPreparedStatementQuery.ProtoReflect.Descriptor instead.
+func (*CommandPreparedStatementQuery) Descriptor() ([]byte, []int) {
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CommandPreparedStatementQuery) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+var File_arrow_flight_protocol_sql_flight_sql_proto protoreflect.FileDescriptor
+
+var file_arrow_flight_protocol_sql_flight_sql_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x71, 0x6c, 0x2f, 0x66, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x73, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x61, 0x72,
+	0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x22, 0x6c, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2a, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x22, 0x41, 0x0a, 0x14, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x29, 0x0a,
+	0x10, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0x9b,
+	0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x44, 0x62, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x18, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x15, 0x64, 0x62, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x42,
+	0x1b, 0x0a, 0x19, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0xbe, 0x02, 0x0a,
+	0x10, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01,
+	0x12, 0x3c, 0x0a, 0x18, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x15, 0x64, 0x62, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3e,
+	0x0a, 0x19, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x02, 0x52, 0x16, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42,
+	0x1c, 0x0a, 0x1a, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x16, 0x0a,
+	0x14, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x7b, 0x0a, 0x24, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x2a, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0d, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42,
+	0x11, 0x0a, 0x0f, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x22, 0xb3, 0x01, 0x0a, 0x23, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3a, 0x0a, 0x19, 0x70, 0x72,
+	0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x70,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x29, 0x0a,
+	0x10, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x61, 0x0a, 0x23, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3a, 0x0a, 0x19, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x17, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x5b, 0x0a, 0x1d, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x3a, 0x0a, 0x19,
+	0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x17, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x42, 0x4a, 0x5a, 0x48, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x6f, 0x6c, 0x74, 0x68, 0x75, 0x62, 0x2f, 0x64,
+	0x6f, 0x6c, 0x74, 0x2f, 0x67, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x71, 0x6c, 0x3b, 0x66, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x73, 0x71, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_arrow_flight_protocol_sql_flight_sql_proto_rawDescOnce sync.Once
+	file_arrow_flight_protocol_sql_flight_sql_proto_rawDescData = file_arrow_flight_protocol_sql_flight_sql_proto_rawDesc
+)
+
+func file_arrow_flight_protocol_sql_flight_sql_proto_rawDescGZIP() []byte {
+	file_arrow_flight_protocol_sql_flight_sql_proto_rawDescOnce.Do(func() {
+		file_arrow_flight_protocol_sql_flight_sql_proto_rawDescData = protoimpl.X.CompressGZIP(file_arrow_flight_protocol_sql_flight_sql_proto_rawDescData)
+	})
+	return file_arrow_flight_protocol_sql_flight_sql_proto_rawDescData
+}
+
+var file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_arrow_flight_protocol_sql_flight_sql_proto_goTypes = []interface{}{
+	(*CommandStatementQuery)(nil),                // 0: arrow.flight.protocol.sql.CommandStatementQuery
+	(*TicketStatementQuery)(nil),                 // 1: arrow.flight.protocol.sql.TicketStatementQuery
+	(*CommandGetCatalogs)(nil),                   // 2: arrow.flight.protocol.sql.CommandGetCatalogs
+	(*CommandGetDbSchemas)(nil),                  // 3: arrow.flight.protocol.sql.CommandGetDbSchemas
+	(*CommandGetTables)(nil),                     // 4: arrow.flight.protocol.sql.CommandGetTables
+	(*CommandGetTableTypes)(nil),                 // 5: arrow.flight.protocol.sql.CommandGetTableTypes
+	(*ActionCreatePreparedStatementRequest)(nil), // 6: arrow.flight.protocol.sql.ActionCreatePreparedStatementRequest
+	(*ActionCreatePreparedStatementResult)(nil),  // 7: arrow.flight.protocol.sql.ActionCreatePreparedStatementResult
+	(*ActionClosePreparedStatementRequest)(nil),  // 8: arrow.flight.protocol.sql.ActionClosePreparedStatementRequest
+	(*CommandPreparedStatementQuery)(nil),        // 9: arrow.flight.protocol.sql.CommandPreparedStatementQuery
+}
+var file_arrow_flight_protocol_sql_flight_sql_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_arrow_flight_protocol_sql_flight_sql_proto_init() }
+func file_arrow_flight_protocol_sql_flight_sql_proto_init() {
+	if File_arrow_flight_protocol_sql_flight_sql_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetCatalogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetDbSchemas); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetTables); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetTableTypes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionCreatePreparedStatementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionCreatePreparedStatementResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionClosePreparedStatementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandPreparedStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_arrow_flight_protocol_sql_flight_sql_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_arrow_flight_protocol_sql_flight_sql_proto_goTypes,
+		DependencyIndexes: file_arrow_flight_protocol_sql_flight_sql_proto_depIdxs,
+		MessageInfos:      file_arrow_flight_protocol_sql_flight_sql_proto_msgTypes,
+	}.Build()
+	File_arrow_flight_protocol_sql_flight_sql_proto = out.File
+	file_arrow_flight_protocol_sql_flight_sql_proto_rawDesc = nil
+	file_arrow_flight_protocol_sql_flight_sql_proto_goTypes = nil
+	file_arrow_flight_protocol_sql_flight_sql_proto_depIdxs = nil
+}
@@ -17,6 +17,7 @@ package dbfactory
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"strings"
@@ -47,9 +48,24 @@ const (
 
 	//AWSCredsProfile is a creation parameter that can be used to specify which AWS profile to use.
 	AWSCredsProfile = "aws-creds-profile"
+
+	// AWSEndpointParam is a creation parameter that can be used to set the URL of an S3 compatible object store, such as
+	// MinIO, Ceph or Cloudflare R2, to use instead of AWS S3. The buckets of a custom endpoint are addressed by path.
+	AWSEndpointParam = "endpoint"
+
+	// AWSAccessKeyIDParam is a creation parameter that can be used to set the access key id of static credentials.
+	AWSAccessKeyIDParam = "aws-access-key-id"
+
+	// AWSSecretAccessKeyParam is a creation parameter that can be used to set the secret access key of static
+	// credentials.
+	AWSSecretAccessKeyParam = "aws-secret-access-key"
+
+	// defaultS3EndpointRegion is the region requests to a custom endpoint are signed for if no region is given. Most S3
+	// compatible stores accept any region.
+	defaultS3EndpointRegion = "us-east-1"
 )
 
-var AWSCredTypes = []string{RoleCS.String(), EnvCS.String(), FileCS.String()}
+var AWSCredTypes = []string{RoleCS.String(), EnvCS.String(), FileCS.String(), StaticCS.String()}
 
 // AWSCredentialSource is an enum type representing the different credential sources (auto, role, env, file, or invalid)
 type AWSCredentialSource int
@@ -68,6 +84,9 @@ const (
 
 	// Uses credentials stored in a file
 	FileCS
+
+	// Static uses the access key given by AWSAccessKeyIDParam and AWSSecretAccessKeyParam
+	StaticCS
 )
 
 // String returns the string representation of the of an AWSCredentialSource
@@ -81,6 +100,8 @@ func (ct AWSCredentialSource) String() string {
 		return "auto"
 	case FileCS:
 		return "file"
+	case StaticCS:
+		return "static"
 	default:
 		return "invalid"
 	}
@@ -98,6 +119,8 @@ func AWSCredentialSourceFromStr(str string) AWSCredentialSource {
 		return EnvCS
 	case "file":
 		return FileCS
+	case "static":
+		return StaticCS
 	default:
 		return InvalidCS
 	}
@@ -153,7 +176,21 @@ func (fact AWSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinForm
 	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	return nbs.NewAWSStore(ctx, nbf.VersionString(), parts[0], dbName, parts[1], s3.New(sess), dynamodb.New(sess), defaultMemTableSize, q)
+	return nbs.NewAWSStore(ctx, nbf.VersionString(), parts[0], dbName, parts[1], newS3Client(sess, params), dynamodb.New(sess), defaultMemTableSize, q)
+}
+
+// newS3Client returns an S3 client for |sess|, which uses the custom endpoint set in |params|, if any.
+func newS3Client(sess *session.Session, params map[string]interface{}) *s3.S3 {
+	endpoint, ok := params[AWSEndpointParam]
+	if !ok || len(endpoint.(string)) == 0 {
+		return s3.New(sess)
+	}
+
+	cfg := aws.NewConfig().WithEndpoint(endpoint.(string)).WithS3ForcePathStyle(true)
+	if aws.StringValue(sess.Config.Region) == "" {
+		cfg = cfg.WithRegion(defaultS3EndpointRegion)
+	}
+	return s3.New(sess, cfg)
 }
 
 func validatePath(path string) (string, error) {
@@ -202,6 +239,9 @@ func awsConfigFromParams(params map[string]interface{}) (session.Options, error)
 	if ok && len(filePath.(string)) != 0 && awsCredsSource == RoleCS {
 		awsCredsSource = FileCS
 	}
+	if _, ok := params[AWSAccessKeyIDParam]; ok && awsCredsSource == RoleCS {
+		awsCredsSource = StaticCS
+	}
 
 	switch awsCredsSource {
 	case EnvCS:
@@ -213,6 +253,13 @@ func awsConfigFromParams(params map[string]interface{}) (session.Options, error)
 			creds := credentials.NewSharedCredentials(filePath.(string), profile)
 			awsConfig = awsConfig.WithCredentials(creds)
 		}
+	case StaticCS:
+		keyID, _ := params[AWSAccessKeyIDParam].(string)
+		secret, _ := params[AWSSecretAccessKeyParam].(string)
+		if len(keyID) == 0 || len(secret) == 0 {
+			return opts, fmt.Errorf("%s and %s are required for static credentials", AWSAccessKeyIDParam, AWSSecretAccessKeyParam)
+		}
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(keyID, secret, ""))
 	case AutoCS:
 		// start by trying to get the credentials from the environment
 		envCreds := credentials.NewEnvCredentials()
@@ -17,7 +17,10 @@ package dbfactory
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAWSPathValidation(t *testing.T) {
@@ -79,3 +82,28 @@ func TestAWSPathValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSStaticCredentials(t *testing.T) {
+	opts, err := awsConfigFromParams(map[string]interface{}{
+		AWSAccessKeyIDParam:     "key-id",
+		AWSSecretAccessKeyParam: "secret",
+	})
+	require.NoError(t, err)
+	creds, err := opts.Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "key-id", creds.AccessKeyID)
+	assert.Equal(t, "secret", creds.SecretAccessKey)
+
+	_, err = awsConfigFromParams(map[string]interface{}{
+		AWSCredsTypeParam:   StaticCS.String(),
+		AWSAccessKeyIDParam: "key-id",
+	})
+	assert.Error(t, err)
+}
+
+func TestS3ClientEndpoint(t *testing.T) {
+	sess := session.Must(session.NewSession())
+	client := newS3Client(sess, map[string]interface{}{AWSEndpointParam: "http://localhost:9000"})
+	assert.Equal(t, "http://localhost:9000", client.Endpoint)
+	assert.True(t, aws.BoolValue(client.Config.S3ForcePathStyle))
+}
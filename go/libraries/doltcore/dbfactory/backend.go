@@ -0,0 +1,76 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// StorageBackend is the minimal storage a database needs. A backend only stores named blobs: the chunk store of a
+// database is a NomsBlockStore which keeps its table files and its manifest as blobs of the blobstore its backend
+// opens, like it does for gs:// and oci:// databases, so that branches, merges, diffs and every other feature of Dolt
+// work on any backend. Backends don't implement the table file storage and the manifest of the chunk store themselves,
+// those are internal to package nbs.
+//
+// Backends are registered at compile time with RegisterStorageBackend, e.g. from the init function of a program
+// embedding Dolt.
+type StorageBackend interface {
+	// Open returns the blobstore of the database at |u|, creating the database if it doesn't exist.
+	Open(ctx context.Context, u *url.URL, params map[string]interface{}) (blobstore.Blobstore, error)
+}
+
+// BackendFactory is a DBFactory implementation for creating databases kept in a StorageBackend.
+type BackendFactory struct {
+	Backend StorageBackend
+}
+
+// RegisterStorageBackend makes the databases at the URLs of |scheme| be kept in |backend|. It replaces the factory
+// already registered for |scheme|, if there is one.
+func RegisterStorageBackend(scheme string, backend StorageBackend) {
+	DBFactories[strings.ToLower(scheme)] = BackendFactory{Backend: backend}
+}
+
+func (fact BackendFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	_, err := fact.Backend.Open(ctx, urlObj, params)
+	return err
+}
+
+// CreateDB creates a database backed by the blobstore the backend of the factory opens for |urlObj|
+func (fact BackendFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	bs, err := fact.Backend.Open(ctx, urlObj, params)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to open the storage of '%s': %w", urlObj.String(), err)
+	}
+
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	cs, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+	return db, vrw, ns, nil
+}
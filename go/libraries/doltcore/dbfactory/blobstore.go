@@ -0,0 +1,66 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// S3Scheme is the scheme of the URLs of S3 buckets, see S3Factory.
+const S3Scheme = "s3"
+
+// OpenBlobstore opens the blobstore at |urlStr|, which is one of s3://bucket/prefix, gs://bucket/prefix or
+// file:///path. The directory of a file URL is created if it doesn't exist. The AWS credentials of an S3 bucket are
+// configured by |params|, as they are for aws remotes.
+func OpenBlobstore(ctx context.Context, urlStr string, params map[string]interface{}) (blobstore.Blobstore, error) {
+	urlObj, err := earl.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(urlObj.Scheme) {
+	case S3Scheme:
+		return newS3Blobstore(urlObj, params)
+
+	case GSScheme:
+		gcs, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path), nil
+
+	case FileScheme:
+		absPath, err := filepath.Abs(filepath.Join(urlObj.Host, filepath.FromSlash(urlObj.Path)))
+		if err != nil {
+			return nil, err
+		}
+		if err = os.MkdirAll(absPath, os.ModePerm); err != nil {
+			return nil, err
+		}
+		return blobstore.NewLocalBlobstore(absPath), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported blobstore url scheme: '%s'", urlObj.Scheme)
+	}
+}
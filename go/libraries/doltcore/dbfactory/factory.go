@@ -50,10 +50,28 @@ const (
 
 	OSSScheme = "oss"
 
+	// SSHScheme
+	SSHScheme = "ssh"
+
+	// OCIScheme
+	OCIScheme = "oci"
+
+	// CompressionParam is a remote parameter naming the codec chunks are compressed with when they are pushed to the
+	// remote. See nbs.ChunkCodec.
+	CompressionParam = "compression"
+
+	// AllowIncompatibleCompressionParam is a remote parameter acknowledging that a CompressionParam codec other than
+	// snappy makes the chunks pushed to the remote unreadable by older versions of dolt. It's required to push chunks
+	// compressed with such a codec.
+	AllowIncompatibleCompressionParam = "allow-incompatible-compression"
+
 	defaultScheme       = HTTPSScheme
 	defaultMemTableSize = 256 * 1024 * 1024
 )
 
+// CompressionCodecs are the valid values of CompressionParam.
+var CompressionCodecs = []string{"snappy", "zstd"}
+
 // DBFactory is an interface for creating concrete datas.Database instances from different backing stores
 type DBFactory interface {
 	// CreateDB returns the database located at the URL given and its associated data access interfaces
@@ -67,11 +85,14 @@ type DBFactory interface {
 // from external packages.
 var DBFactories = map[string]DBFactory{
 	AWSScheme:     AWSFactory{},
+	S3Scheme:      S3Factory{},
 	OSSScheme:     OSSFactory{},
 	GSScheme:      GSFactory{},
 	FileScheme:    FileFactory{},
 	MemScheme:     MemFactory{},
 	LocalBSScheme: LocalBSFactory{},
+	SSHScheme:     SSHFactory{},
+	OCIScheme:     OCIFactory{},
 	HTTPScheme:    NewDoltRemoteFactory(true),
 	HTTPSScheme:   NewDoltRemoteFactory(false),
 }
@@ -16,10 +16,14 @@ package dbfactory
 
 import (
 	"context"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -32,3 +36,36 @@ func TestCreateMemDB(t *testing.T) {
 	assert.NotNil(t, vrw)
 	assert.NotNil(t, ns)
 }
+
+type testBackend struct {
+	bs blobstore.Blobstore
+}
+
+func (b testBackend) Open(ctx context.Context, u *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
+	return b.bs, nil
+}
+
+func TestRegisterStorageBackend(t *testing.T) {
+	ctx := context.Background()
+	RegisterStorageBackend("TestBS", testBackend{bs: blobstore.NewInMemoryBlobstore("")})
+	defer delete(DBFactories, "testbs")
+
+	db, _, _, err := CreateDB(ctx, types.Format_Default, "testbs://db", nil)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	_, err = datas.CommitValue(ctx, db, ds, types.Int(42))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// the database is read back from the blobs of the backend
+	db, _, _, err = CreateDB(ctx, types.Format_Default, "testbs://db", nil)
+	require.NoError(t, err)
+	defer db.Close()
+	ds, err = db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	val, ok, err := ds.MaybeHeadValue()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, types.Int(42), val)
+}
@@ -35,10 +35,20 @@ func init() {
 	if os.Getenv("DOLT_DISABLE_CHUNK_JOURNAL") != "" {
 		chunkJournalFeatureFlag = false
 	}
+	chunkPoolDir = os.Getenv(ChunkPoolDirEnvVar)
 }
 
 var chunkJournalFeatureFlag = true
 
+// ChunkPoolDirEnvVar names a directory, on the same filesystem as the databases, that all local databases opened by
+// the process share pushed table files through. See nbs.ChunkPool.
+const ChunkPoolDirEnvVar = "DOLT_CHUNK_POOL_DIR"
+
+var chunkPoolDir string
+
+// chunkPool is opened by the first CreateDB call after |chunkPoolDir| is set, and is protected by |singletonLock|.
+var chunkPool *nbs.ChunkPool
+
 const (
 	// DoltDir defines the directory used to hold the dolt repo data within the filesys
 	DoltDir = ".dolt"
@@ -73,6 +83,12 @@ func CloseAllLocalDatabases() (err error) {
 			err = fmt.Errorf("error closing DB %s (%s)", name, cerr)
 		}
 	}
+	if chunkPool != nil {
+		if cerr := chunkPool.Close(); cerr != nil {
+			err = fmt.Errorf("error closing chunk pool (%s)", cerr)
+		}
+		chunkPool = nil
+	}
 	return
 }
 
@@ -148,6 +164,18 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		return nil, nil, nil, err
 	}
 
+	if chunkPoolDir != "" {
+		if chunkPool == nil {
+			chunkPool, err = nbs.NewChunkPool(ctx, chunkPoolDir, nbs.NewUnlimitedMemQuotaProvider())
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		if err = newGenSt.SetChunkPool(chunkPool); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	oldgenPath := filepath.Join(path, "oldgen")
 	err = validateDir(oldgenPath)
 	if err != nil {
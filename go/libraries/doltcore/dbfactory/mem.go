@@ -16,39 +16,100 @@ package dbfactory
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/datas"
-	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
-// MemFactory is a DBFactory implementation for creating in memory backed databases
+// MemFactory is a DBFactory implementation for creating in memory backed databases. Every open of a mem:// URL creates
+// a new empty database, unless the named URL, like mem://name, is kept with KeepMemDB. The database at a kept URL lives
+// for the life of the process, so that it can be opened again, e.g. as the remote of another database, until it's
+// dropped with DropMemDB.
 type MemFactory struct {
 }
 
 func (fact MemFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
-	// nothing to prepare
-	return nil
+	return BackendFactory{Backend: memBackend}.PrepareDB(ctx, nbf, urlObj, params)
 }
 
 // CreateDB creates an in memory backed database
 func (fact MemFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
-	var db datas.Database
+	return BackendFactory{Backend: memBackend}.CreateDB(ctx, nbf, urlObj, params)
+}
+
+// KeepMemDB keeps the in memory database at the named URL |urlStr|, e.g. mem://name, so that every open of the URL opens
+// the same database until it's dropped with DropMemDB. Its memory isn't freed before then, even when it's closed.
+func KeepMemDB(urlStr string) error {
+	u, err := earl.Parse(urlStr)
+	if err != nil {
+		return err
+	}
+	name := memDBName(u)
+	if name == "" {
+		return fmt.Errorf("cannot keep the unnamed in memory database at '%s'", urlStr)
+	}
+	memBackend.keep(name)
+	return nil
+}
 
-	bs := blobstore.NewInMemoryBlobstore(uuid.New().String())
-	q := nbs.NewUnlimitedMemQuotaProvider()
-	cs, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+// DropMemDB drops the in memory database at the named URL |urlStr|, e.g. mem://name, freeing its memory. The next open
+// of the URL creates a new empty database.
+func DropMemDB(urlStr string) error {
+	u, err := earl.Parse(urlStr)
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
+	memBackend.drop(memDBName(u))
+	return nil
+}
+
+var memBackend = &memStorageBackend{stores: make(map[string]*blobstore.InMemoryBlobstore)}
 
-	vrw := types.NewValueStore(cs)
-	ns := tree.NewNodeStore(cs)
-	db = datas.NewTypesDatabase(vrw, ns)
-	return db, vrw, ns, nil
+// memStorageBackend is the StorageBackend of mem:// URLs, keeping databases in memory without a filesystem.
+type memStorageBackend struct {
+	mu     sync.Mutex
+	stores map[string]*blobstore.InMemoryBlobstore
+}
+
+var _ StorageBackend = &memStorageBackend{}
+
+// Open returns the blobstore of the database with the name of |u| if it's kept, and a new blobstore otherwise.
+func (b *memStorageBackend) Open(_ context.Context, u *url.URL, _ map[string]interface{}) (blobstore.Blobstore, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if bs, ok := b.stores[memDBName(u)]; ok {
+		return bs, nil
+	}
+	return blobstore.NewInMemoryBlobstore(uuid.New().String()), nil
+}
+
+func (b *memStorageBackend) keep(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.stores[name]; !ok {
+		b.stores[name] = blobstore.NewInMemoryBlobstore(name)
+	}
+}
+
+func (b *memStorageBackend) drop(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.stores, name)
+}
+
+// memDBName returns the name of the database at |u|, or "" if it's unnamed. A nil |u| is an unnamed database.
+func memDBName(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return strings.Trim(u.Host+u.Path, "/")
 }
@@ -0,0 +1,96 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	ociUsernameEnvKey = "OCI_USERNAME"
+	ociPasswordEnvKey = "OCI_PASSWORD"
+
+	// ociManifestKey is the blobstore key of the NBS manifest, which is the only blob rewritten in place
+	ociManifestKey = "manifest"
+)
+
+// OCIFactory is a DBFactory implementation for creating databases stored as artifacts in an OCI registry
+type OCIFactory struct {
+}
+
+// PrepareDB prepares an OCI backed database
+func (fact OCIFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// nothing to prepare, the artifact is created by the first push
+	return nil
+}
+
+// CreateDB creates an OCI backed database
+func (fact OCIFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	ociStore, err := fact.newChunkStore(ctx, nbf, urlObj, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(ociStore)
+	ns := tree.NewNodeStore(ociStore)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+func (fact OCIFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (chunks.ChunkStore, error) {
+	// oci://[registry]/[repository][:tag]
+	repo, tag := splitOCIReference(urlObj.Path)
+	if repo == "" {
+		return nil, fmt.Errorf("oci url '%s' does not name a repository", urlObj.String())
+	}
+
+	creds := blobstore.OCICredentials{
+		Username: os.Getenv(ociUsernameEnvKey),
+		Password: os.Getenv(ociPasswordEnvKey),
+	}
+	if urlObj.User != nil {
+		creds.Username = urlObj.User.Username()
+		if pass, ok := urlObj.User.Password(); ok {
+			creds.Password = pass
+		}
+	}
+
+	bs := blobstore.NewOCIBlobstore(http.DefaultClient, urlObj.Host, repo, tag, creds, ociManifestKey)
+
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+}
+
+// splitOCIReference splits a url path like /org/repo:tag into its repository and tag.
+func splitOCIReference(p string) (repo, tag string) {
+	repo = strings.Trim(p, "/")
+	if i := strings.LastIndexByte(repo, ':'); i > strings.LastIndexByte(repo, '/') {
+		return repo[:i], repo[i+1:]
+	}
+	return repo, ""
+}
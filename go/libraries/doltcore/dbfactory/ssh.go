@@ -0,0 +1,153 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/events"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// SSHTransferCommand is the dolt subcommand run on the remote host to serve the remotes API over stdio
+	SSHTransferCommand = "transfer"
+
+	// sshCommandEnv overrides the local ssh command, e.g. "ssh -i ~/.ssh/dolt_key"
+	sshCommandEnv = "DOLT_SSH"
+
+	// sshExecPathEnv overrides the path of the dolt binary on the remote host
+	sshExecPathEnv = "DOLT_SSH_EXEC_PATH"
+)
+
+// SSHFactory is a DBFactory implementation for creating databases backed by a dolt repository on another host, reached
+// by tunneling the remotes API over the stdio of an ssh session. Every connection the client needs runs its own
+// `dolt transfer` process on the remote host.
+type SSHFactory struct {
+}
+
+func (fact SSHFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	return fmt.Errorf("ssh scheme cannot support this operation")
+}
+
+// CreateDB creates a database backed by the dolt repository at the path of |urlObj| on the host of |urlObj|
+func (fact SSHFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	dialer := newSSHDialer(urlObj)
+
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", addr)
+		}),
+		grpc.WithChainUnaryInterceptor(remotestorage.EventsUnaryClientInterceptor(events.GlobalCollector)),
+		grpc.WithChainUnaryInterceptor(remotestorage.RetryingUnaryClientInterceptor),
+	}
+	conn, err := grpc.DialContext(ctx, "passthrough:///"+urlObj.Host, opts...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	csClient := remotesapi.NewChunkStoreServiceClient(conn)
+	cs, err := remotestorage.NewDoltChunkStoreFromPath(ctx, nbf, urlObj.Path, urlObj.Host, csClient)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not access dolt url '%s': %w", urlObj.String(), err)
+	}
+
+	// Table file URLs handed out by the remote name a host which is only meaningful on the other end of the tunnel,
+	// so downloads dial through ssh as well, regardless of the host in the URL.
+	cs = cs.WithHTTPFetcher(&http.Client{
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	})
+
+	if _, ok := params[NoCachingParameter]; ok {
+		cs = cs.WithNoopChunkCache()
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+type sshDialer struct {
+	command []string
+}
+
+func newSSHDialer(urlObj *url.URL) sshDialer {
+	command := strings.Fields(os.Getenv(sshCommandEnv))
+	if len(command) == 0 {
+		command = []string{"ssh"}
+	}
+	if port := urlObj.Port(); port != "" {
+		command = append(command, "-p", port)
+	}
+
+	host := urlObj.Hostname()
+	if urlObj.User != nil && urlObj.User.Username() != "" {
+		host = urlObj.User.Username() + "@" + host
+	}
+
+	remoteDolt := os.Getenv(sshExecPathEnv)
+	if remoteDolt == "" {
+		remoteDolt = "dolt"
+	}
+
+	command = append(command, host, remoteDolt, SSHTransferCommand, shellQuote(urlObj.Path))
+	return sshDialer{command: command}
+}
+
+// DialContext starts a new ssh session and returns a net.Conn over its stdio. The address is ignored.
+func (d sshDialer) DialContext(ctx context.Context, _ string, _ string) (net.Conn, error) {
+	cmd := exec.Command(d.command[0], d.command[1:]...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("could not start ssh: %w", err)
+	}
+
+	return iohelp.NewPipeConn(stdout, stdin, func() error {
+		// the remote end exits once its stdin is closed
+		_ = cmd.Wait()
+		return nil
+	}), nil
+}
+
+// shellQuote quotes |s| for the remote user's login shell, which ssh runs the remote command with.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -208,6 +208,10 @@ func reportPkChanges(ctx context.Context, vMapping val.OrdinalMapping, fromD, to
 		stat.Removes++
 	case tree.ModifiedDiff:
 		stat.CellChanges = prollyCountCellDiff(vMapping, fromD, toD, val.Tuple(change.From), val.Tuple(change.To))
+		if stat.CellChanges == 0 && val.EncodingsDiffer(fromD, toD) {
+			// only the encoding of the row changed
+			return nil
+		}
 		stat.Changes++
 	default:
 		return errors.New("unknown change type")
@@ -262,14 +266,8 @@ func prollyCountCellDiff(mapping val.OrdinalMapping, fromD, toD val.TupleDesc, f
 			continue
 		}
 
-		if fromD.Types[i].Enc != toD.Types[j].Enc {
-			// column type is different
-			changed++
-			continue
-		}
-
-		if fromD.CompareField(toD.GetField(j, to), i, from) != 0 {
-			// column was modified
+		if !val.EqualFields(fromD, i, from, toD, j, to) {
+			// column was modified, or its type is different
 			changed++
 			continue
 		}
@@ -0,0 +1,119 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var errVisibleChange = errors.New("visible change")
+
+// FilterIgnoredColumnChanges removes the deltas of |deltas| whose only changes are to the values of columns listed
+// in the dolt_ignore_columns table.
+func FilterIgnoredColumnChanges(ctx context.Context, deltas []TableDelta, ignored doltdb.IgnoredColumns) ([]TableDelta, error) {
+	if len(ignored) == 0 {
+		return deltas, nil
+	}
+	filtered := make([]TableDelta, 0, len(deltas))
+	for _, td := range deltas {
+		cols, err := ignored.ColumnsForTable(td.CurName())
+		if err != nil {
+			return nil, err
+		}
+		onlyIgnored, err := onlyIgnoredColumnsChanged(ctx, td, cols)
+		if err != nil {
+			return nil, err
+		}
+		if !onlyIgnored {
+			filtered = append(filtered, td)
+		}
+	}
+	return filtered, nil
+}
+
+// onlyIgnoredColumnsChanged returns whether the rows of |td| differ only in the values of the columns |cols|.
+// Added, dropped and renamed tables, and tables whose schema changed, always have visible changes.
+func onlyIgnoredColumnsChanged(ctx context.Context, td TableDelta, cols *set.StrSet) (bool, error) {
+	if cols.Size() == 0 || td.IsAdd() || td.IsDrop() || td.IsRename() || td.HasFKChanges() {
+		return false, nil
+	}
+	if !types.IsFormat_DOLT(td.Format()) || schema.IsKeyless(td.ToSch) {
+		return false, nil
+	}
+	if changed, err := td.HasSchemaChanged(ctx); err != nil || changed {
+		return false, err
+	}
+
+	fromHash, err := td.FromTable.GetRowDataHash(ctx)
+	if err != nil {
+		return false, err
+	}
+	toHash, err := td.ToTable.GetRowDataHash(ctx)
+	if err != nil {
+		return false, err
+	}
+	if fromHash.Equal(toHash) {
+		// the table changed in something other than its rows
+		return false, nil
+	}
+
+	visible := VisibleValueFields(td.ToSch, cols)
+	from, to, err := td.GetRowData(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, vd := td.ToSch.GetMapDescriptors()
+	err = prolly.DiffMaps(ctx, durable.ProllyMapFromIndex(from), durable.ProllyMapFromIndex(to), func(ctx context.Context, d tree.Diff) error {
+		if d.Type != tree.ModifiedDiff {
+			return errVisibleChange
+		}
+		for _, i := range visible {
+			if !bytes.Equal(vd.GetField(i, val.Tuple(d.From)), vd.GetField(i, val.Tuple(d.To))) {
+				return errVisibleChange
+			}
+		}
+		return nil
+	})
+	if err == errVisibleChange {
+		return false, nil
+	} else if err != nil && err != io.EOF {
+		return false, err
+	}
+	return true, nil
+}
+
+// VisibleValueFields returns the indexes of the value tuple fields of the rows of the keyed schema |sch| that
+// aren't in the ignored columns |cols|.
+func VisibleValueFields(sch schema.Schema, cols *set.StrSet) []int {
+	var visible []int
+	for i, col := range sch.GetNonPKCols().GetColumns() {
+		if !cols.Contains(col.Name) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
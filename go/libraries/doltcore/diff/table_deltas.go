@@ -70,7 +70,8 @@ type TableDeltaSummary struct {
 	ToTableName   string
 }
 
-// GetStagedUnstagedTableDeltas represents staged and unstaged changes as TableDelta slices.
+// GetStagedUnstagedTableDeltas represents staged and unstaged changes as TableDelta slices. Unstaged changes to only
+// the columns listed in the dolt_ignore_columns table are left out.
 func GetStagedUnstagedTableDeltas(ctx context.Context, roots doltdb.Roots) (staged, unstaged []TableDelta, err error) {
 	staged, err = GetTableDeltas(ctx, roots.Head, roots.Staged)
 	if err != nil {
@@ -82,6 +83,15 @@ func GetStagedUnstagedTableDeltas(ctx context.Context, roots doltdb.Roots) (stag
 		return nil, nil, err
 	}
 
+	ignored, err := doltdb.GetIgnoredColumns(ctx, roots.Working)
+	if err != nil {
+		return nil, nil, err
+	}
+	unstaged, err = FilterIgnoredColumnChanges(ctx, unstaged, ignored)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return staged, unstaged, nil
 }
 
@@ -570,15 +580,11 @@ func SqlSchemaDiff(ctx context.Context, td TableDelta, toSchemas map[string]sche
 	if td.IsDrop() {
 		ddlStatements = append(ddlStatements, sqlfmt.DropTableStmt(td.FromName))
 	} else if td.IsAdd() {
-		toPkSch, err := sqlutil.FromDoltSchema(td.ToName, td.ToSch)
-		if err != nil {
-			return nil, err
-		}
-		stmt, err := GenerateCreateTableStatement(td.ToName, td.ToSch, toPkSch, td.ToFks, td.ToFksParentSch)
+		stmts, err := GenerateCreateTableStatements(td)
 		if err != nil {
 			return nil, errhand.VerboseErrorFromError(err)
 		}
-		ddlStatements = append(ddlStatements, stmt)
+		ddlStatements = append(ddlStatements, stmts...)
 	} else {
 		stmts, err := GetNonCreateNonDropTableSqlSchemaDiff(td, toSchemas, fromSch, toSch)
 		if err != nil {
@@ -694,6 +700,34 @@ func GetDataDiffStatement(tableName string, sch schema.Schema, row sql.Row, rowD
 	}
 }
 
+// GenerateCreateTableStatements returns the CREATE TABLE statement of the table added by |td|, followed by the
+// statements creating its virtual foreign keys, which can't be declared in a CREATE TABLE statement.
+func GenerateCreateTableStatements(td TableDelta) ([]string, error) {
+	toPkSch, err := sqlutil.FromDoltSchema(td.ToName, td.ToSch)
+	if err != nil {
+		return nil, err
+	}
+
+	var fks, virtualFks []doltdb.ForeignKey
+	for _, fk := range td.ToFks {
+		if fk.NotEnforced {
+			virtualFks = append(virtualFks, fk)
+		} else {
+			fks = append(fks, fk)
+		}
+	}
+
+	stmt, err := GenerateCreateTableStatement(td.ToName, td.ToSch, toPkSch, fks, td.ToFksParentSch)
+	if err != nil {
+		return nil, err
+	}
+	stmts := []string{stmt}
+	for _, fk := range virtualFks {
+		stmts = append(stmts, sqlfmt.AlterTableAddForeignKeyStmt(fk, td.ToSch, td.ToFksParentSch[fk.ReferencedTableName]))
+	}
+	return stmts, nil
+}
+
 // GenerateCreateTableStatement returns CREATE TABLE statement for given table. This function was made to share the same
 // 'create table' statement logic as GMS. We initially were running `SHOW CREATE TABLE` query to get the statement;
 // however, it cannot be done for cases that need this statement in sql shell mode. Dolt uses its own Schema and
@@ -0,0 +1,137 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrProtectedBranch = errors.New("protected branch")
+
+// BranchProtection is the protection of the branches of a database from the updates which rewrite their history.
+type BranchProtection struct {
+	// Protected are the names of the branches which can't be deleted, or moved to a commit which doesn't descend from
+	// the commit they point to. "*" protects every branch.
+	Protected []string
+	// NoConflicts are the names of the branches which can't be moved to a commit with unresolved merge conflicts. "*"
+	// applies to every branch.
+	NoConflicts []string
+}
+
+// ParseBranchProtection returns the BranchProtection of the comma separated lists of branch names |protected| and
+// |noConflicts|.
+func ParseBranchProtection(protected, noConflicts string) BranchProtection {
+	return BranchProtection{
+		Protected:   parseBranchList(protected),
+		NoConflicts: parseBranchList(noConflicts),
+	}
+}
+
+func parseBranchList(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// IsEmpty returns whether |bp| doesn't protect any branch.
+func (bp BranchProtection) IsEmpty() bool {
+	return len(bp.Protected) == 0 && len(bp.NoConflicts) == 0
+}
+
+// IsProtected returns whether |branch| can't be deleted or moved to a commit which doesn't descend from its commit.
+func (bp BranchProtection) IsProtected(branch string) bool {
+	return containsBranch(bp.Protected, branch)
+}
+
+// RequiresNoConflicts returns whether |branch| can't be moved to a commit with unresolved merge conflicts.
+func (bp BranchProtection) RequiresNoConflicts(branch string) bool {
+	return containsBranch(bp.NoConflicts, branch)
+}
+
+func containsBranch(names []string, branch string) bool {
+	for _, name := range names {
+		if name == "*" || name == branch {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckBranchUpdate returns an ErrProtectedBranch error if moving |branch| from the commit |from| to the commit |to|
+// breaks its protection. A nil |from| creates the branch, and a nil |to| deletes it. The commits can be in different
+// databases, e.g. when |to| is pushed to the database of |from|.
+func (bp BranchProtection) CheckBranchUpdate(ctx context.Context, branch string, from, to *Commit) error {
+	if to == nil {
+		if bp.IsProtected(branch) {
+			return fmt.Errorf("%w: branch '%s' is protected and can't be deleted", ErrProtectedBranch, branch)
+		}
+		return nil
+	}
+
+	if from != nil && bp.IsProtected(branch) {
+		fromHash, err := from.HashOf()
+		if err != nil {
+			return err
+		}
+		toHash, err := to.HashOf()
+		if err != nil {
+			return err
+		}
+		if fromHash != toHash {
+			fastForward := false
+			ancestor, err := GetCommitAncestor(ctx, from, to)
+			if err == nil {
+				ancestorHash, err := ancestor.HashOf()
+				if err != nil {
+					return err
+				}
+				fastForward = ancestorHash == fromHash
+			} else if !errors.Is(err, ErrNoCommonAncestor) {
+				return err
+			}
+			if !fastForward {
+				return fmt.Errorf("%w: branch '%s' is protected and can only be fast-forwarded, but commit %s doesn't descend from its commit %s",
+					ErrProtectedBranch, branch, toHash.String(), fromHash.String())
+			}
+		}
+	}
+
+	if bp.RequiresNoConflicts(branch) {
+		root, err := to.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		conflicted, err := root.TablesWithDataConflicts(ctx)
+		if err != nil {
+			return err
+		}
+		if len(conflicted) > 0 {
+			toHash, err := to.HashOf()
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: branch '%s' can't be moved to commit %s, which has unresolved merge conflicts in tables: %s",
+				ErrProtectedBranch, branch, toHash.String(), strings.Join(conflicted, ", "))
+		}
+	}
+	return nil
+}
@@ -30,27 +30,43 @@ import (
 )
 
 type PushOnWriteHook struct {
-	destDB datas.Database
-	tmpDir string
-	out    io.Writer
-	fmt    *types.NomsBinFormat
+	destDB  datas.Database
+	tmpDir  string
+	out     io.Writer
+	fmt     *types.NomsBinFormat
+	tracker *replicationTracker
 }
 
-var _ CommitHook = (*PushOnWriteHook)(nil)
+var _ ReplicationHook = (*PushOnWriteHook)(nil)
 
 // NewPushOnWriteHook creates a ReplicateHook, parameterizaed by the backup database
-// and a local tempfile for pushing
-func NewPushOnWriteHook(destDB *DoltDB, tmpDir string) *PushOnWriteHook {
+// and a local tempfile for pushing. |remoteName| is the name of the remote of the backup
+// database, which the replication status is reported for.
+func NewPushOnWriteHook(remoteName string, destDB *DoltDB, tmpDir string) *PushOnWriteHook {
 	return &PushOnWriteHook{
-		destDB: destDB.db,
-		tmpDir: tmpDir,
-		fmt:    destDB.Format(),
+		destDB:  destDB.db,
+		tmpDir:  tmpDir,
+		fmt:     destDB.Format(),
+		tracker: newReplicationTracker(remoteName, false),
 	}
 }
 
 // Execute implements CommitHook, replicates head updates to the destDb field
 func (ph *PushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) error {
-	return pushDataset(ctx, ph.destDB, db, ds, ph.tmpDir)
+	addr, _ := ds.MaybeHeadAddr()
+	ph.tracker.updated(ds.ID(), addr)
+	err := pushDataset(ctx, ph.destDB, db, ds, ph.tmpDir)
+	if err != nil {
+		ph.tracker.failed(ds.ID(), err)
+		return err
+	}
+	ph.tracker.replicated(ds.ID(), addr)
+	return nil
+}
+
+// ReplicationStatus implements ReplicationHook
+func (ph *PushOnWriteHook) ReplicationStatus() []ReplicationStatus {
+	return ph.tracker.status()
 }
 
 func pushDataset(ctx context.Context, destDB, srcDB datas.Database, ds datas.Dataset, tmpDir string) error {
@@ -60,7 +76,7 @@ func pushDataset(ctx context.Context, destDB, srcDB datas.Database, ds datas.Dat
 		return err
 	}
 
-	err := pullHash(ctx, destDB, srcDB, []hash.Hash{addr}, tmpDir, nil)
+	err := pullHash(ctx, destDB, srcDB, []hash.Hash{addr}, tmpDir, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -107,8 +123,9 @@ type PushArg struct {
 }
 
 type AsyncPushOnWriteHook struct {
-	out io.Writer
-	ch  chan PushArg
+	out     io.Writer
+	ch      chan PushArg
+	tracker *replicationTracker
 }
 
 const (
@@ -118,16 +135,17 @@ const (
 	asyncPushSyncReplica   = "async_push_sync_replica"
 )
 
-var _ CommitHook = (*AsyncPushOnWriteHook)(nil)
+var _ ReplicationHook = (*AsyncPushOnWriteHook)(nil)
 
-// NewAsyncPushOnWriteHook creates a AsyncReplicateHook
-func NewAsyncPushOnWriteHook(bThreads *sql.BackgroundThreads, destDB *DoltDB, tmpDir string, logger io.Writer) (*AsyncPushOnWriteHook, error) {
+// NewAsyncPushOnWriteHook creates a AsyncReplicateHook, which pushes to the database of the remote |remoteName|.
+func NewAsyncPushOnWriteHook(bThreads *sql.BackgroundThreads, remoteName string, destDB *DoltDB, tmpDir string, logger io.Writer) (*AsyncPushOnWriteHook, error) {
 	ch := make(chan PushArg, asyncPushBufferSize)
-	err := RunAsyncReplicationThreads(bThreads, ch, destDB, tmpDir, logger)
+	tracker := newReplicationTracker(remoteName, true)
+	err := runAsyncReplicationThreads(bThreads, ch, destDB, tmpDir, logger, tracker)
 	if err != nil {
 		return nil, err
 	}
-	return &AsyncPushOnWriteHook{ch: ch}, nil
+	return &AsyncPushOnWriteHook{ch: ch, tracker: tracker}, nil
 }
 
 func (*AsyncPushOnWriteHook) ExecuteForWorkingSets() bool {
@@ -137,6 +155,7 @@ func (*AsyncPushOnWriteHook) ExecuteForWorkingSets() bool {
 // Execute implements CommitHook, replicates head updates to the destDb field
 func (ah *AsyncPushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) error {
 	addr, _ := ds.MaybeHeadAddr()
+	ah.tracker.updated(ds.ID(), addr)
 
 	select {
 	case ah.ch <- PushArg{ds: ds, db: db, hash: addr}:
@@ -161,6 +180,11 @@ func (ah *AsyncPushOnWriteHook) SetLogger(ctx context.Context, wr io.Writer) err
 	return nil
 }
 
+// ReplicationStatus implements ReplicationHook
+func (ah *AsyncPushOnWriteHook) ReplicationStatus() []ReplicationStatus {
+	return ah.tracker.status()
+}
+
 type LogHook struct {
 	msg []byte
 	out io.Writer
@@ -200,7 +224,7 @@ func (*LogHook) ExecuteForWorkingSets() bool {
 	return false
 }
 
-func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ch chan PushArg, destDB *DoltDB, tmpDir string, logger io.Writer) error {
+func runAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ch chan PushArg, destDB *DoltDB, tmpDir string, logger io.Writer, tracker *replicationTracker) error {
 	mu := &sync.Mutex{}
 	var newHeads = make(map[string]PushArg, asyncPushBufferSize)
 
@@ -264,6 +288,9 @@ func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ch chan PushArg
 				err := pushDataset(context.Background(), destDB.db, newCm.db, newCm.ds, tmpDir)
 				if err != nil {
 					logger.Write([]byte("replication failed: " + err.Error()))
+					tracker.failed(id, err)
+				} else {
+					tracker.replicated(id, newCm.hash)
 				}
 				if newCm.hash.IsEmpty() {
 					delete(latestHeads, id)
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/stretchr/testify/assert"
@@ -126,7 +127,7 @@ func TestPushOnWriteHook(t *testing.T) {
 	}
 
 	// setup hook
-	hook := NewPushOnWriteHook(destDB, tmpDir)
+	hook := NewPushOnWriteHook("origin", destDB, tmpDir)
 	ddb.SetCommitHooks(ctx, []CommitHook{hook})
 
 	t.Run("replicate to remote", func(t *testing.T) {
@@ -145,6 +146,15 @@ func TestPushOnWriteHook(t *testing.T) {
 		srcHash, _ := srcCommit.HashOf()
 		destHash, _ := destCommit.HashOf()
 		assert.Equal(t, srcHash, destHash)
+
+		statuses := ddb.ReplicationStatus()
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "origin", statuses[0].Remote)
+		assert.Equal(t, "refs/heads/main", statuses[0].Ref)
+		assert.Equal(t, srcHash, statuses[0].Head)
+		assert.Equal(t, srcHash, statuses[0].Replicated)
+		assert.Zero(t, statuses[0].Lag(time.Now()))
+		assert.NoError(t, statuses[0].LastError)
 	})
 
 	t.Run("replicate handle error logs to writer", func(t *testing.T) {
@@ -218,7 +228,7 @@ func TestAsyncPushOnWrite(t *testing.T) {
 
 	// setup hook
 	bThreads := sql.NewBackgroundThreads()
-	hook, err := NewAsyncPushOnWriteHook(bThreads, destDB, tmpDir, &buffer.Buffer{})
+	hook, err := NewAsyncPushOnWriteHook(bThreads, "origin", destDB, tmpDir, &buffer.Buffer{})
 	if err != nil {
 		t.Fatal("Unexpected error creating push hook", err)
 	}
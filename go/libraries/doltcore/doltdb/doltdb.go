@@ -16,22 +16,33 @@ package doltdb
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/types/edits"
@@ -57,9 +68,12 @@ const (
 // LocalDirDoltDB stores the db in the current directory
 var LocalDirDoltDB = "file://./" + dbfactory.DoltDataDir
 
-// InMemDoltDB stores the DoltDB db in memory and is primarily used for testing
+// InMemDoltDB stores the DoltDB db in memory and is primarily used for testing. Every load of it creates a new db. Named
+// URLs like mem://name do too, unless they're kept with dbfactory.KeepMemDB.
 var InMemDoltDB = "mem://"
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/libraries/doltcore/doltdb")
+
 var ErrNoRootValAtHash = errors.New("there is no dolt root value at that hash")
 var ErrCannotDeleteLastBranch = errors.New("cannot delete the last branch")
 
@@ -642,6 +656,13 @@ func (ddb *DoltDB) SetHead(ctx context.Context, ref ref.DoltRef, addr hash.Hash)
 	return err
 }
 
+// CompareAndSetHead sets |ref| to point at |addr|, like SetHead, but only if it currently points at |expected|. The
+// empty hash as |expected| means that |ref| must not exist, and as |addr| deletes it. If |ref| doesn't point at
+// |expected|, it isn't changed and datas.ErrMergeNeeded is returned.
+func (ddb *DoltDB) CompareAndSetHead(ctx context.Context, ref ref.DoltRef, expected, addr hash.Hash) error {
+	return ddb.db.UpdateDatasets(ctx, []datas.DatasetUpdate{{ID: ref.String(), Prev: expected, Head: addr}})
+}
+
 // CommitWithParentSpecs commits the value hash given to the branch given, using the list of parent hashes given. Returns an
 // error if the value or any parents can't be resolved, or if anything goes wrong accessing the underlying storage.
 func (ddb *DoltDB) CommitWithParentSpecs(ctx context.Context, valHash hash.Hash, dref ref.DoltRef, parentCmSpecs []*CommitSpec, cm *datas.CommitMeta) (*Commit, error) {
@@ -697,6 +718,9 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 }
 
 func (ddb *DoltDB) CommitValue(ctx context.Context, dref ref.DoltRef, val types.Value, commitOpts datas.CommitOptions) (*Commit, error) {
+	ctx, span := tracer.Start(ctx, "doltdb.CommitValue", trace.WithAttributes(attribute.String("ref", dref.String())))
+	defer span.End()
+
 	ds, err := ddb.db.GetDataset(ctx, dref.String())
 	if err != nil {
 		return nil, err
@@ -1161,6 +1185,73 @@ func (ddb *DoltDB) DeleteBranch(ctx context.Context, branch ref.DoltRef) error {
 	return ddb.deleteRef(ctx, branch)
 }
 
+// BranchUpdate is the change to a single branch made by UpdateBranches.
+type BranchUpdate struct {
+	Branch ref.BranchRef
+	// Commit is the new head of the branch, or nil to delete the branch and its working set.
+	Commit *Commit
+	// WorkingSet is the new working set of the branch. If it's nil, the working set is reset to the root of |Commit|.
+	WorkingSet *WorkingSet
+}
+
+// UpdateBranches atomically applies |updates|, and the changes to the working sets of their branches, in a single
+// update of the database. Each branch may only be updated once. If any of the branches or working sets is changed
+// concurrently, nothing is updated and datas.ErrMergeNeeded is returned.
+func (ddb *DoltDB) UpdateBranches(ctx context.Context, updates []BranchUpdate) error {
+	dsUpdates := make([]datas.DatasetUpdate, 0, 2*len(updates))
+	for _, u := range updates {
+		ds, err := ddb.db.GetDataset(ctx, u.Branch.String())
+		if err != nil {
+			return err
+		}
+		prev, _ := ds.MaybeHeadAddr()
+
+		wsRef, err := ref.WorkingSetRefForHead(u.Branch)
+		if err != nil {
+			return err
+		}
+		wsDs, err := ddb.db.GetDataset(ctx, wsRef.String())
+		if err != nil {
+			return err
+		}
+		wsPrev, _ := wsDs.MaybeHeadAddr()
+
+		if u.Commit == nil {
+			dsUpdates = append(dsUpdates,
+				datas.DatasetUpdate{ID: u.Branch.String(), Prev: prev},
+				datas.DatasetUpdate{ID: wsRef.String(), Prev: wsPrev})
+			continue
+		}
+
+		addr, err := u.Commit.HashOf()
+		if err != nil {
+			return err
+		}
+		ws := u.WorkingSet
+		if ws == nil {
+			root, err := u.Commit.GetRootValue(ctx)
+			if err != nil {
+				return err
+			}
+			ws = EmptyWorkingSet(wsRef).WithWorkingRoot(root).WithStagedRoot(root)
+		}
+		workingRootRef, stagedRef, mergeState, err := ws.writeValues(ctx, ddb)
+		if err != nil {
+			return err
+		}
+
+		dsUpdates = append(dsUpdates,
+			datas.DatasetUpdate{ID: u.Branch.String(), Prev: prev, Head: addr},
+			datas.DatasetUpdate{ID: wsRef.String(), Prev: wsPrev, WorkingSet: &datas.WorkingSetSpec{
+				Meta:        TodoWorkingSetMeta(),
+				WorkingRoot: workingRootRef,
+				StagedRoot:  stagedRef,
+				MergeState:  mergeState,
+			}})
+	}
+	return ddb.db.UpdateDatasets(ctx, dsUpdates)
+}
+
 func (ddb *DoltDB) deleteRef(ctx context.Context, dref ref.DoltRef) error {
 	ds, err := ddb.db.GetDataset(ctx, dref.String())
 
@@ -1254,6 +1345,9 @@ func (ddb *DoltDB) CommitWithWorkingSet(
 	prevHash hash.Hash,
 	meta *datas.WorkingSetMeta,
 ) (*Commit, error) {
+	ctx, span := tracer.Start(ctx, "doltdb.CommitWithWorkingSet", trace.WithAttributes(attribute.String("ref", headRef.String())))
+	defer span.End()
+
 	wsDs, err := ddb.db.GetDataset(ctx, workingSetRef.String())
 	if err != nil {
 		return nil, err
@@ -1352,15 +1446,15 @@ func (ddb *DoltDB) Rebase(ctx context.Context) error {
 
 // GC performs garbage collection on this ddb.
 //
-// If |safepointF| is non-nil, it will be called at some point after the GC begins
+// If |safepoint| is non-nil, it will be called at some points after the GC begins
 // and before the GC ends. It will be called without
 // Database/ValueStore/NomsBlockStore locks held. If should establish
 // safepoints in every application-level in-progress read and write workflow
-// against this DoltDB. Examples of doing this include, for example, blocking
-// until no possibly-stale ChunkStore state is retained in memory, or failing
-// certain in-progress operations which cannot be finalized in a timely manner,
-// etc.
-func (ddb *DoltDB) GC(ctx context.Context, safepointF func() error) error {
+// against this DoltDB. Examples of doing this include, for example, writing
+// the values held in memory so that the GC keeps them, blocking until no
+// possibly-stale ChunkStore state is retained in memory, or failing certain
+// in-progress operations which cannot be finalized in a timely manner, etc.
+func (ddb *DoltDB) GC(ctx context.Context, safepoint types.GCSafepointController) error {
 	collector, ok := ddb.db.Database.(datas.GarbageCollector)
 	if !ok {
 		return fmt.Errorf("this database does not support garbage collection")
@@ -1404,7 +1498,38 @@ func (ddb *DoltDB) GC(ctx context.Context, safepointF func() error) error {
 		return err
 	}
 
-	return collector.GC(ctx, oldGen, newGen, safepointF)
+	return collector.GC(ctx, oldGen, newGen, safepoint)
+}
+
+// NewGenGC collects the garbage of the newest generation of table files only, which holds the data written since the
+// last full GC. It is faster than GC, and its pause for writes is bounded by the size of the new generation, but it
+// leaves the garbage of the old generation in place.
+func (ddb *DoltDB) NewGenGC(ctx context.Context, safepoint types.GCSafepointController) error {
+	collector, ok := ddb.db.Database.(datas.GarbageCollector)
+	if !ok {
+		return fmt.Errorf("this database does not support garbage collection")
+	}
+
+	err := ddb.pruneUnreferencedDatasets(ctx)
+	if err != nil {
+		return err
+	}
+
+	datasets, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return err
+	}
+
+	refs := make(hash.HashSet)
+	err = datasets.IterAll(ctx, func(_ string, h hash.Hash) error {
+		refs.Insert(h)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.NewGenGC(ctx, refs, safepoint)
 }
 
 func (ddb *DoltDB) ShallowGC(ctx context.Context) error {
@@ -1447,30 +1572,42 @@ func (ddb *DoltDB) pruneUnreferencedDatasets(ctx context.Context) error {
 
 // PullChunks initiates a pull into this database from the source database
 // given, pulling all chunks reachable from the given targetHash. Pull progress
-// is communicated over the provided channel.
+// is communicated over the provided channel. |opts| configure the pull.Puller.
 func (ddb *DoltDB) PullChunks(
 	ctx context.Context,
 	tempDir string,
 	srcDB *DoltDB,
 	targetHashes []hash.Hash,
 	statsCh chan pull.Stats,
+	opts ...pull.Option,
 ) error {
-	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh)
+	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, opts)
 }
 
+// pullWalkMemoryLimitEnvVar sets the number of discovered chunk addresses a pull keeps in memory, for pulls which
+// don't set one. See pull.WithWalkMemoryLimit.
+const pullWalkMemoryLimitEnvVar = "DOLT_PULL_WALK_MEMORY_LIMIT"
+
 func pullHash(
 	ctx context.Context,
 	destDB, srcDB datas.Database,
 	targetHashes []hash.Hash,
 	tempDir string,
 	statsCh chan pull.Stats,
+	opts []pull.Option,
 ) error {
 	srcCS := datas.ChunkStoreFromDatabase(srcDB)
 	destCS := datas.ChunkStoreFromDatabase(destDB)
 	waf := types.WalkAddrsForNBF(srcDB.Format())
 
+	if v, ok := os.LookupEnv(pullWalkMemoryLimitEnvVar); ok {
+		if limit, err := strconv.Atoi(v); err == nil {
+			opts = append([]pull.Option{pull.WithWalkMemoryLimit(limit)}, opts...)
+		}
+	}
+
 	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
-		puller, err := pull.NewPuller(ctx, tempDir, defaultChunksPerTF, srcCS, destCS, waf, targetHashes, statsCh)
+		puller, err := pull.NewPuller(ctx, tempDir, defaultChunksPerTF, srcCS, destCS, waf, targetHashes, statsCh, opts...)
 		if err == pull.ErrDBUpToDate {
 			return nil
 		} else if err != nil {
@@ -1483,8 +1620,241 @@ func pullHash(
 	}
 }
 
-func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- pull.TableFileEvent) error {
-	return pull.Clone(ctx, datas.ChunkStoreFromDatabase(ddb.db), datas.ChunkStoreFromDatabase(destDB.db), eventCh)
+// Clone copies the table files of |ddb| to |destDB|. Of the |opts|, only pull.WithTrustedKey applies.
+func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- pull.TableFileEvent, opts ...pull.Option) error {
+	return pull.Clone(ctx, datas.ChunkStoreFromDatabase(ddb.db), datas.ChunkStoreFromDatabase(destDB.db), eventCh, opts...)
+}
+
+// WriteArchive writes the root and table files of |ddb| to |w| as a single archive. See pull.WriteArchive.
+func (ddb *DoltDB) WriteArchive(ctx context.Context, w io.Writer) error {
+	return pull.WriteArchive(ctx, datas.ChunkStoreFromDatabase(ddb.db), w)
+}
+
+// ReadArchive seeds |ddb|, which should be empty, with the archive read from |r|. See pull.ReadArchive.
+func (ddb *DoltDB) ReadArchive(ctx context.Context, r io.Reader) error {
+	return pull.ReadArchive(ctx, r, datas.ChunkStoreFromDatabase(ddb.db))
+}
+
+// AttestManifest signs the current root and table files of |ddb| with |key| and stores the attestation alongside its
+// manifest, so that clients fetching from it can check it against the corresponding public key. See
+// pull.WithTrustedKey.
+func (ddb *DoltDB) AttestManifest(ctx context.Context, key ed25519.PrivateKey) error {
+	return nbs.AttestSources(ctx, datas.ChunkStoreFromDatabase(ddb.db), key)
+}
+
+// ReplicationEpoch returns the epoch the storage of |ddb| was fenced at, or 0 if it never was. It returns nbs.ErrNoEpoch
+// if the storage of |ddb| can't be fenced.
+func (ddb *DoltDB) ReplicationEpoch(ctx context.Context) (uint64, error) {
+	es, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.EpochStore)
+	if !ok {
+		return 0, nbs.ErrNoEpoch
+	}
+	return es.ReadEpoch(ctx)
+}
+
+// FenceReplicationEpoch fences the storage of |ddb| at |epoch| and makes |ddb| write at |epoch|. Servers replicating to
+// the same storage at a lower epoch can no longer update it.
+func (ddb *DoltDB) FenceReplicationEpoch(ctx context.Context, epoch uint64) error {
+	es, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.EpochStore)
+	if !ok {
+		return nbs.ErrNoEpoch
+	}
+	if err := es.FenceEpoch(ctx, epoch); err != nil {
+		return err
+	}
+	es.SetWriterEpoch(epoch)
+	return nil
+}
+
+// SetReplicationEpoch makes |ddb| write at |epoch|: its updates fail with nbs.ErrFencedEpoch once its storage is
+// fenced at a higher epoch. If the storage of |ddb| can't be fenced, it returns nbs.ErrNoEpoch for a non-zero |epoch|,
+// and leaves |ddb| as it is otherwise.
+func (ddb *DoltDB) SetReplicationEpoch(ctx context.Context, epoch uint64) error {
+	es, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.EpochStore)
+	if ok {
+		_, err := es.ReadEpoch(ctx)
+		ok = !errors.Is(err, nbs.ErrNoEpoch)
+		if ok && err != nil {
+			return err
+		}
+	}
+	if !ok {
+		if epoch != 0 {
+			return nbs.ErrNoEpoch
+		}
+		return nil
+	}
+	es.SetWriterEpoch(epoch)
+	return nil
+}
+
+// SampleChunkAccesses samples one in every |rate| chunks read from the storage of |ddb|, or stops sampling them if
+// |rate| is zero. It does nothing if the storage of |ddb| can't sample its reads.
+func (ddb *DoltDB) SampleChunkAccesses(rate int) {
+	if als, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.AccessLogStore); ok {
+		als.SampleAccesses(rate)
+	}
+}
+
+// SampledChunkAccesses returns the distinct chunks sampled from the latest reads of the storage of |ddb|, most recent
+// first. See SampleChunkAccesses.
+func (ddb *DoltDB) SampledChunkAccesses() []hash.Hash {
+	if als, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.AccessLogStore); ok {
+		return als.SampledAccesses()
+	}
+	return nil
+}
+
+// ReadChunkAccessLog returns the chunk access log published to the storage of |ddb|. It returns nbs.ErrNoAccessLog if
+// none was published, or the storage of |ddb| can't store one.
+func (ddb *DoltDB) ReadChunkAccessLog(ctx context.Context) ([]hash.Hash, error) {
+	als, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.AccessLogStore)
+	if !ok {
+		return nil, nbs.ErrNoAccessLog
+	}
+	return als.ReadAccessLog(ctx)
+}
+
+// PublishChunkAccessLog stores |hashes| as the chunk access log of the storage of |ddb|, for the read replicas that
+// pull from it to warm up with.
+func (ddb *DoltDB) PublishChunkAccessLog(ctx context.Context, hashes []hash.Hash) error {
+	als, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.AccessLogStore)
+	if !ok {
+		return nbs.ErrNoAccessLog
+	}
+	return als.WriteAccessLog(ctx, hashes)
+}
+
+// WarmChunks reads the chunks |hashes| that are in the storage of |ddb|, so that later reads of them are served from
+// the chunk cache and the page cache of the OS. It returns the number of chunks read.
+func (ddb *DoltDB) WarmChunks(ctx context.Context, hashes []hash.Hash) (int, error) {
+	var found int64
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	err := cs.GetMany(ctx, hash.NewHashSet(hashes...), func(context.Context, *chunks.Chunk) {
+		atomic.AddInt64(&found, 1)
+	})
+	return int(found), err
+}
+
+// SetConjoinPolicy changes the policy that decides when the table files of |ddb| are conjoined. It does nothing if the
+// chunk store of |ddb| doesn't conjoin table files.
+func (ddb *DoltDB) SetConjoinPolicy(p nbs.ConjoinPolicy) {
+	if c, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.TableFileConjoiner); ok {
+		c.SetConjoinPolicy(p)
+	}
+}
+
+// ConjoinTableFiles conjoins the table files of |ddb| that are smaller than |targetSize| bytes into table files of
+// about |targetSize| bytes, and returns the number of table files left. See nbs.TableFileConjoiner.
+func (ddb *DoltDB) ConjoinTableFiles(ctx context.Context, targetSize uint64) (int, error) {
+	c, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.TableFileConjoiner)
+	if !ok {
+		return 0, nbs.ErrConjoinUnsupported
+	}
+	return c.ConjoinTableFiles(ctx, targetSize)
+}
+
+// InspectTableFiles returns the statistics of the table files of |ddb|. See nbs.TableFileInspector.
+func (ddb *DoltDB) InspectTableFiles(ctx context.Context) ([]nbs.TableFileStats, error) {
+	insp, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.TableFileInspector)
+	if !ok {
+		return nil, nbs.ErrInspectUnsupported
+	}
+	return insp.InspectTableFiles(ctx)
+}
+
+// ReplicateJournal starts copying the chunk journal of |ddb| to a journal replica in |bs| every |interval|, returning
+// a closer which stops replication. See nbs.JournalReplicator.
+func (ddb *DoltDB) ReplicateJournal(ctx context.Context, bs blobstore.Blobstore, interval time.Duration) (io.Closer, error) {
+	type journalReplicator interface {
+		ReplicateJournal(ctx context.Context, bs blobstore.Blobstore, interval time.Duration) (*nbs.JournalReplicator, error)
+	}
+	jr, ok := datas.ChunkStoreFromDatabase(ddb.db).(journalReplicator)
+	if !ok {
+		return nil, nbs.ErrJournalReplicationUnsupported
+	}
+	return jr.ReplicateJournal(ctx, bs, interval)
+}
+
+// TableFileRefChunks returns the number of chunks each ref of |ddb| stores in each of its table files, keyed by the
+// table file's name and then by the ref. Working sets count as refs. A chunk reachable from several refs is counted
+// for the first of them in lexicographic order, so branches come before remote refs, tags and working sets. Every
+// chunk of the database is read.
+func (ddb *DoltDB) TableFileRefChunks(ctx context.Context) (map[string]map[string]uint64, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	insp, ok := cs.(nbs.TableFileInspector)
+	if !ok {
+		return nil, nbs.ErrInspectUnsupported
+	}
+
+	dss, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	heads := make(map[string]hash.Hash)
+	err = dss.IterAll(ctx, func(key string, addr hash.Hash) error {
+		refs = append(refs, key)
+		heads[key] = addr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(refs)
+
+	waf := types.WalkAddrsForNBF(ddb.Format())
+	counts := make(map[string]map[string]uint64)
+	visited := make(hash.HashSet)
+	for _, r := range refs {
+		next := make(hash.HashSet)
+		if !visited.Has(heads[r]) {
+			visited.Insert(heads[r])
+			next.Insert(heads[r])
+		}
+
+		for len(next) > 0 {
+			locs, err := insp.GetChunkLocations(next)
+			if err != nil {
+				return nil, err
+			}
+			for tf, ranges := range locs {
+				if len(ranges) == 0 {
+					continue
+				}
+				if counts[tf.String()] == nil {
+					counts[tf.String()] = make(map[string]uint64)
+				}
+				counts[tf.String()][r] += uint64(len(ranges))
+			}
+
+			var mu sync.Mutex
+			var walkErr error
+			children := make(hash.HashSet)
+			err = cs.GetMany(ctx, next, func(ctx context.Context, c *chunks.Chunk) {
+				mu.Lock()
+				defer mu.Unlock()
+				err := waf(*c, func(h hash.Hash, _ bool) error {
+					if !visited.Has(h) {
+						visited.Insert(h)
+						children.Insert(h)
+					}
+					return nil
+				})
+				if walkErr == nil {
+					walkErr = err
+				}
+			})
+			if err != nil {
+				return nil, err
+			}
+			if walkErr != nil {
+				return nil, walkErr
+			}
+			next = children
+		}
+	}
+	return counts, nil
 }
 
 func (ddb *DoltDB) SetCommitHooks(ctx context.Context, postHooks []CommitHook) *DoltDB {
@@ -209,6 +209,50 @@ func TestEmptyInMemoryRepoCreation(t *testing.T) {
 	}
 }
 
+func TestNamedInMemoryRepo(t *testing.T) {
+	ctx := context.Background()
+	const urlStr = "mem://TestNamedInMemoryRepo"
+
+	// the database isn't kept in memory unless it's asked to be
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, urlStr, filesys.EmptyInMemFS("/"))
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	require.NoError(t, ddb.Close())
+	ddb, err = LoadDoltDB(ctx, types.Format_Default, urlStr, filesys.EmptyInMemFS("/"))
+	require.NoError(t, err)
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, branches)
+	require.NoError(t, ddb.Close())
+
+	require.NoError(t, dbfactory.KeepMemDB(urlStr))
+	defer dbfactory.DropMemDB(urlStr)
+	ddb, err = LoadDoltDB(ctx, types.Format_Default, urlStr, filesys.EmptyInMemFS("/"))
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	cs, _ := NewCommitSpec("main")
+	commit, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), commit))
+	require.NoError(t, ddb.Close())
+
+	// the database is kept in memory until it's dropped
+	ddb, err = LoadDoltDB(ctx, types.Format_Default, urlStr, filesys.EmptyInMemFS("/"))
+	require.NoError(t, err)
+	branches, err = ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ref.DoltRef{ref.NewBranchRef("main"), ref.NewBranchRef("feature")}, branches)
+	require.NoError(t, ddb.Close())
+
+	require.NoError(t, dbfactory.DropMemDB(urlStr))
+	ddb, err = LoadDoltDB(ctx, types.Format_Default, urlStr, filesys.EmptyInMemFS("/"))
+	require.NoError(t, err)
+	defer ddb.Close()
+	branches, err = ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, branches)
+}
+
 func TestLoadNonExistentLocalFSRepo(t *testing.T) {
 	_, err := test.ChangeToTestDir("TestLoadRepo")
 
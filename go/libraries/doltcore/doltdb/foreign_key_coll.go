@@ -95,6 +95,9 @@ type ForeignKey struct {
 	OnUpdate               ForeignKeyReferentialAction `noms:"on_update" json:"on_update"`
 	OnDelete               ForeignKeyReferentialAction `noms:"on_delete" json:"on_delete"`
 	UnresolvedFKDetails    UnresolvedFKDetails         `noms:"unres_fk,omitempty" json:"unres_fk,omitempty"`
+	// NotEnforced marks a virtual foreign key, which records the relationship between its tables without checking
+	// their rows. Virtual foreign keys don't require an index on either table.
+	NotEnforced bool `noms:"not_enforced,omitempty" json:"not_enforced,omitempty"`
 }
 
 // UnresolvedFKDetails contains any details necessary for an unresolved foreign key to resolve to a valid foreign key.
@@ -121,7 +124,8 @@ func (fk ForeignKey) EqualDefs(other ForeignKey) bool {
 	}
 	return fk.Name == other.Name &&
 		fk.OnUpdate == other.OnUpdate &&
-		fk.OnDelete == other.OnDelete
+		fk.OnDelete == other.OnDelete &&
+		fk.NotEnforced == other.NotEnforced
 }
 
 // DeepEquals compares all attributes of a foreign key to another, including name and table names.
@@ -157,6 +161,10 @@ func (fk ForeignKey) HashOf() hash.Hash {
 	for _, col := range fk.UnresolvedFKDetails.ReferencedTableColumns {
 		_ = binary.Write(&bb, binary.LittleEndian, col)
 	}
+	// only written for virtual foreign keys, so the hashes of existing foreign keys don't change
+	if fk.NotEnforced {
+		bb.Write([]byte("not_enforced"))
+	}
 
 	return hash.Of(bb.Bytes())
 }
@@ -202,6 +210,9 @@ func (fk ForeignKey) ValidateReferencedTableSchema(sch schema.Schema) error {
 		}
 	}
 
+	if fk.NotEnforced {
+		return nil
+	}
 	if (fk.ReferencedTableIndex != "" && !sch.Indexes().Contains(fk.ReferencedTableIndex)) || (fk.ReferencedTableIndex == "" && sch.GetPKCols().Size() < len(fk.ReferencedTableColumns)) {
 		return fmt.Errorf("foreign key `%s` has entered an invalid state, referenced table `%s` is missing the index `%s`",
 			fk.Name, fk.ReferencedTableName, fk.ReferencedTableIndex)
@@ -222,6 +233,9 @@ func (fk ForeignKey) ValidateTableSchema(sch schema.Schema) error {
 			return fmt.Errorf("foreign key `%s` has entered an invalid state, table `%s` has unexpected schema", fk.Name, fk.TableName)
 		}
 	}
+	if fk.NotEnforced {
+		return nil
+	}
 	if (fk.TableIndex != "" && !sch.Indexes().Contains(fk.TableIndex)) || (fk.TableIndex == "" && sch.GetPKCols().Size() < len(fk.TableColumns)) {
 		return fmt.Errorf("foreign key `%s` has entered an invalid state, table `%s` is missing the index `%s`",
 			fk.Name, fk.TableName, fk.TableIndex)
@@ -142,6 +142,7 @@ func deserializeFlatbufferForeignKeys(msg types.SerialMessage) (*ForeignKeyColle
 				TableColumns:           childUnresolved,
 				ReferencedTableColumns: parentUnresolved,
 			},
+			NotEnforced: fk.NotEnforced(),
 		})
 		if err != nil {
 			return nil, err
@@ -196,6 +197,7 @@ func serializeFlatbufferForeignKeys(fkc *ForeignKeyCollection) types.SerialMessa
 		serial.ForeignKeyAddUnresolvedParentColumns(b, unresolvedParent)
 		serial.ForeignKeyAddOnUpdate(b, serial.ForeignKeyReferentialAction(fk.OnUpdate))
 		serial.ForeignKeyAddOnDelete(b, serial.ForeignKeyReferentialAction(fk.OnDelete))
+		serial.ForeignKeyAddNotEnforced(b, fk.NotEnforced)
 		offsets[i] = serial.ForeignKeyEnd(b)
 	}
 
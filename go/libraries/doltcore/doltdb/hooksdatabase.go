@@ -120,6 +120,14 @@ func (db hooksDatabase) FastForward(ctx context.Context, ds datas.Dataset, newHe
 	return ds, err
 }
 
+func (db hooksDatabase) Tag(ctx context.Context, ds datas.Dataset, commitAddr hash.Hash, opts datas.TagOptions) (datas.Dataset, error) {
+	ds, err := db.Database.Tag(ctx, ds, commitAddr, opts)
+	if err == nil {
+		db.ExecuteCommitHooks(ctx, ds, false)
+	}
+	return ds, err
+}
+
 func (db hooksDatabase) Delete(ctx context.Context, ds datas.Dataset) (datas.Dataset, error) {
 	ds, err := db.Database.Delete(ctx, ds)
 	if err == nil {
@@ -135,3 +143,18 @@ func (db hooksDatabase) UpdateWorkingSet(ctx context.Context, ds datas.Dataset,
 	}
 	return ds, err
 }
+
+func (db hooksDatabase) UpdateDatasets(ctx context.Context, updates []datas.DatasetUpdate) error {
+	err := db.Database.UpdateDatasets(ctx, updates)
+	if err != nil {
+		return err
+	}
+	for _, u := range updates {
+		ds, err := db.Database.GetDataset(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+		db.ExecuteCommitHooks(ctx, ds, u.WorkingSet != nil)
+	}
+	return nil
+}
@@ -22,13 +22,149 @@ import (
 	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
 type ignorePattern struct {
-	pattern string
-	ignore  bool
+	pattern     string
+	ignore      bool
+	mergePolicy IgnoreMergePolicy
+	source      ignorePatternSource
+	// regex is the compiled pattern, set by newIgnorePatterns
+	regex *regexp.Regexp
+}
+
+// compiled returns the regular expression matching the table names matched by the pattern.
+func (p ignorePattern) compiled() (*regexp.Regexp, error) {
+	if p.regex != nil {
+		return p.regex, nil
+	}
+	return compilePattern(p.pattern)
+}
+
+// ignorePatternSource is where an ignore pattern is defined. Patterns are matched against a table name one source at
+// a time, in order of precedence, and the first source with a pattern matching the table decides whether it's ignored.
+type ignorePatternSource int
+
+const (
+	// ignoreTableSource patterns are the rows of the dolt_ignore table. They take precedence over every config.
+	ignoreTableSource ignorePatternSource = iota
+	// branchConfigSource patterns are configured for the current branch.
+	branchConfigSource
+	// databaseConfigSource patterns are configured in the local config of the database.
+	databaseConfigSource
+	// globalConfigSource patterns are configured in the global config, for every database.
+	globalConfigSource
+)
+
+// ignorePatternSources are the sources of ignore patterns, in order of precedence.
+var ignorePatternSources = []ignorePatternSource{ignoreTableSource, branchConfigSource, databaseConfigSource, globalConfigSource}
+
+// ConfiguredIgnorePatterns are the ignore patterns set in the config, rather than the dolt_ignore table, which lets
+// tables such as scratch tables be ignored without committing a dolt_ignore row. Each pattern ignores the tables it
+// matches, unless it's negated, in which case the tables it matches are not ignored.
+type ConfiguredIgnorePatterns struct {
+	// Global patterns apply to every database.
+	Global []string
+	// Database patterns apply to the current database, and override the global patterns.
+	Database []string
+	// Branch patterns apply to the current branch, and override the database and global patterns.
+	Branch []string
+}
+
+type configuredIgnorePatternsKey struct{}
+
+// WithConfiguredIgnorePatterns returns a copy of |ctx| carrying |patterns|, which GetIgnoredTablePatterns returns
+// along with the patterns of the dolt_ignore table.
+func WithConfiguredIgnorePatterns(ctx context.Context, patterns ConfiguredIgnorePatterns) context.Context {
+	return context.WithValue(ctx, configuredIgnorePatternsKey{}, patterns)
+}
+
+// appendConfiguredIgnorePatterns appends the configured ignore patterns carried by |ctx| to |ignorePatterns|.
+func appendConfiguredIgnorePatterns(ctx context.Context, ignorePatterns []ignorePattern) []ignorePattern {
+	configured, ok := ctx.Value(configuredIgnorePatternsKey{}).(ConfiguredIgnorePatterns)
+	if !ok {
+		return ignorePatterns
+	}
+	for _, sp := range []struct {
+		source   ignorePatternSource
+		patterns []string
+	}{
+		{branchConfigSource, configured.Branch},
+		{databaseConfigSource, configured.Database},
+		{globalConfigSource, configured.Global},
+	} {
+		for _, pattern := range sp.patterns {
+			ignorePatterns = append(ignorePatterns, ignorePattern{pattern: pattern, ignore: true, source: sp.source})
+		}
+	}
+	return ignorePatterns
+}
+
+// negatedPatternPrefix starts a negated dolt_ignore pattern, which reverses its ignored column, as in .gitignore.
+// Negated patterns are applied after every other pattern, so the last kind of pattern matching a table wins, and
+// patterns like '*' and '!important_*' ignore every table except the important ones.
+const negatedPatternPrefix = "!"
+
+// isNegated returns whether the pattern is negated.
+func (p ignorePattern) isNegated() bool {
+	return strings.HasPrefix(p.pattern, negatedPatternPrefix)
+}
+
+// ignoresTable returns whether a table matching the pattern is ignored by it.
+func (p ignorePattern) ignoresTable() bool {
+	return p.ignore != p.isNegated()
+}
+
+// patternBody returns the table name pattern of the dolt_ignore pattern |pattern|, without its negation.
+func patternBody(pattern string) string {
+	return strings.TrimPrefix(pattern, negatedPatternPrefix)
+}
+
+// regexPatternPrefix is the prefix of patterns that are RE2 regular expressions, rather than patterns using the * and
+// ? wildcards. A regular expression must match the whole table name.
+const regexPatternPrefix = "re:"
+
+// isRegexPattern returns whether the dolt_ignore pattern |pattern| is a regular expression.
+func isRegexPattern(pattern string) bool {
+	return strings.HasPrefix(patternBody(pattern), regexPatternPrefix)
+}
+
+// ValidateIgnorePattern returns an error if |pattern| isn't a valid dolt_ignore pattern.
+func ValidateIgnorePattern(pattern string) error {
+	if _, err := compilePattern(pattern); err != nil {
+		return fmt.Errorf("invalid dolt_ignore pattern '%s': %w", pattern, err)
+	}
+	return nil
+}
+
+// IgnoreMergePolicy is how a merge treats tables ignored by a dolt_ignore pattern, set in its merge_policy column.
+type IgnoreMergePolicy string
+
+const (
+	// MergePolicyDefault merges ignored tables like any other table.
+	MergePolicyDefault IgnoreMergePolicy = ""
+	// MergePolicyExclude leaves ignored tables out of merges, keeping our version of them.
+	MergePolicyExclude IgnoreMergePolicy = "exclude"
+	// MergePolicyOurs merges ignored tables, resolving any conflict with our version.
+	MergePolicyOurs IgnoreMergePolicy = "ours"
+	// MergePolicyTheirs merges ignored tables, resolving any conflict with their version.
+	MergePolicyTheirs IgnoreMergePolicy = "theirs"
+)
+
+// ParseIgnoreMergePolicy returns the merge policy named |name|, which is case-insensitive. An empty name is the
+// default policy.
+func ParseIgnoreMergePolicy(name string) (IgnoreMergePolicy, error) {
+	switch policy := IgnoreMergePolicy(strings.ToLower(name)); policy {
+	case MergePolicyDefault, MergePolicyExclude, MergePolicyOurs, MergePolicyTheirs:
+		return policy, nil
+	default:
+		return MergePolicyDefault, fmt.Errorf("invalid dolt_ignore merge policy '%s', must be one of '%s', '%s' or '%s'",
+			name, MergePolicyExclude, MergePolicyOurs, MergePolicyTheirs)
+	}
 }
 
 // IgnoredTables contains the results of comparing a series of tables to a set of dolt_ignore patterns.
@@ -50,7 +186,33 @@ const (
 
 type IgnorePatterns []ignorePattern
 
+// newIgnorePatterns returns the IgnorePatterns of |patterns|, with each pattern compiled once so that matching table
+// names against them doesn't compile them again. It returns an error for an invalid pattern.
+func newIgnorePatterns(patterns []ignorePattern) (IgnorePatterns, error) {
+	ip := make(IgnorePatterns, len(patterns))
+	for i, p := range patterns {
+		regex, err := compilePattern(p.pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dolt_ignore pattern '%s': %w", p.pattern, err)
+		}
+		p.regex = regex
+		ip[i] = p
+	}
+	return ip, nil
+}
+
+// GetIgnoredTablePatterns returns the patterns of the dolt_ignore table of the working set of |roots|, followed by the
+// configured ignore patterns carried by |ctx|.
 func GetIgnoredTablePatterns(ctx context.Context, roots Roots) (IgnorePatterns, error) {
+	ignorePatterns, err := getIgnoreTablePatterns(ctx, roots)
+	if err != nil {
+		return nil, err
+	}
+	return newIgnorePatterns(appendConfiguredIgnorePatterns(ctx, ignorePatterns))
+}
+
+// getIgnoreTablePatterns returns the patterns of the dolt_ignore table of the working set of |roots|.
+func getIgnoreTablePatterns(ctx context.Context, roots Roots) ([]ignorePattern, error) {
 	var ignorePatterns []ignorePattern
 	workingSet := roots.Working
 	table, found, err := workingSet.GetTable(ctx, IgnoreTableName)
@@ -62,10 +224,6 @@ func GetIgnoredTablePatterns(ctx context.Context, roots Roots) (IgnorePatterns,
 		return ignorePatterns, nil
 	}
 	index, err := table.GetRowData(ctx)
-	if table.Format() == types.Format_LD_1 {
-		// dolt_ignore is not supported for the legacy storage format.
-		return ignorePatterns, nil
-	}
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +231,18 @@ func GetIgnoredTablePatterns(ctx context.Context, roots Roots) (IgnorePatterns,
 	if err != nil {
 		return nil, err
 	}
+	if table.Format() == types.Format_LD_1 {
+		return getLegacyIgnoreTablePatterns(ctx, ignoreTableSchema, durable.NomsMapFromIndex(index))
+	}
 	keyDesc, valueDesc := ignoreTableSchema.GetMapDescriptors()
 
 	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc})) {
 		return nil, fmt.Errorf("dolt_ignore had unexpected key type, this should never happen")
 	}
-	if !valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.Int8Enc, Nullable: true})) {
+	// dolt_ignore tables created before the merge_policy column was added only have the ignored column
+	hasMergePolicy := valueDesc.Count() == 2
+	if !valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.Int8Enc, Nullable: true})) &&
+		!valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.Int8Enc, Nullable: true}, val.Type{Enc: val.StringEnc, Nullable: true})) {
 		return nil, fmt.Errorf("dolt_ignore had unexpected value type, this should never happen")
 	}
 
@@ -100,14 +264,58 @@ func GetIgnoredTablePatterns(ctx context.Context, roots Roots) (IgnorePatterns,
 			return nil, fmt.Errorf("could not read pattern")
 		}
 		ignore, ok := valueDesc.GetBool(0, valueTuple)
-		ignorePatterns = append(ignorePatterns, ignorePattern{pattern, ignore})
+		var mergePolicy IgnoreMergePolicy
+		if hasMergePolicy {
+			if name, ok := valueDesc.GetString(1, valueTuple); ok {
+				if mergePolicy, err = ParseIgnoreMergePolicy(name); err != nil {
+					return nil, err
+				}
+			}
+		}
+		ignorePatterns = append(ignorePatterns, ignorePattern{pattern: pattern, ignore: ignore, mergePolicy: mergePolicy, source: ignoreTableSource})
+	}
+	return ignorePatterns, nil
+}
+
+// getLegacyIgnoreTablePatterns returns the patterns of the rows |rows| of a dolt_ignore table with the schema |sch|,
+// stored in the legacy storage format.
+func getLegacyIgnoreTablePatterns(ctx context.Context, sch schema.Schema, rows types.Map) ([]ignorePattern, error) {
+	var ignorePatterns []ignorePattern
+	err := rows.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+
+		pattern, ok := r.GetColVal(schema.DoltIgnorePatternTag)
+		if !ok {
+			return true, fmt.Errorf("could not read pattern")
+		}
+		var ignore bool
+		if v, ok := r.GetColVal(schema.DoltIgnoreIgnoredTag); ok && !types.IsNull(v) {
+			ignore = bool(v.(types.Bool))
+		}
+		var mergePolicy IgnoreMergePolicy
+		if v, ok := r.GetColVal(schema.DoltIgnoreMergePolicyTag); ok && !types.IsNull(v) {
+			if mergePolicy, err = ParseIgnoreMergePolicy(string(v.(types.String))); err != nil {
+				return true, err
+			}
+		}
+		ignorePatterns = append(ignorePatterns, ignorePattern{pattern: string(pattern.(types.String)), ignore: ignore, mergePolicy: mergePolicy, source: ignoreTableSource})
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return ignorePatterns, nil
 }
 
 // compilePattern takes a dolt_ignore pattern and generate a Regexp that matches against the same table names as the pattern.
 func compilePattern(pattern string) (*regexp.Regexp, error) {
-	pattern = "^" + regexp.QuoteMeta(pattern) + "$"
+	if isRegexPattern(pattern) {
+		return regexp.Compile("^(?:" + strings.TrimPrefix(patternBody(pattern), regexPatternPrefix) + ")$")
+	}
+	pattern = "^" + regexp.QuoteMeta(patternBody(pattern)) + "$"
 	pattern = strings.Replace(pattern, "\\?", ".", -1)
 	pattern = strings.Replace(pattern, "\\*", ".*", -1)
 	return regexp.Compile(pattern)
@@ -117,34 +325,48 @@ func compilePattern(pattern string) (*regexp.Regexp, error) {
 // that are "more specific" than it. (a pattern A is more specific than a pattern B if all names that match A also
 // match pattern B, but not vice versa.)
 func getMoreSpecificPatterns(lessSpecific string) (*regexp.Regexp, error) {
-	pattern := "^" + regexp.QuoteMeta(lessSpecific) + "$"
+	pattern := "^" + regexp.QuoteMeta(patternBody(lessSpecific)) + "$"
 	// A ? can expand to any character except for a *, since that also has special meaning in patterns.
 	pattern = strings.Replace(pattern, "\\?", "[^\\*]", -1)
 	pattern = strings.Replace(pattern, "\\*", ".*", -1)
 	return regexp.Compile(pattern)
 }
 
+// isMoreSpecificPattern returns whether the dolt_ignore pattern |pattern| is more specific than |than|. Regular
+// expressions can't be compared with other patterns, so they are never more or less specific than another pattern,
+// and a table matched by a regular expression and a pattern with the opposite effect is a conflict.
+func isMoreSpecificPattern(pattern, than string) (bool, error) {
+	if isRegexPattern(pattern) || isRegexPattern(than) {
+		return false, nil
+	}
+	moreSpecific, err := getMoreSpecificPatterns(than)
+	if err != nil {
+		return false, err
+	}
+	return moreSpecific.MatchString(patternBody(pattern)), nil
+}
+
 func resolveConflictingPatterns(trueMatches, falseMatches []string, tableName string) (IgnoreResult, error) {
 	trueMatchesToRemove := map[string]struct{}{}
 	falseMatchesToRemove := map[string]struct{}{}
 	for _, trueMatch := range trueMatches {
-		trueMatchRegExp, err := getMoreSpecificPatterns(trueMatch)
-		if err != nil {
-			return ErrorOccurred, err
-		}
 		for _, falseMatch := range falseMatches {
-			if trueMatchRegExp.MatchString(falseMatch) {
+			moreSpecific, err := isMoreSpecificPattern(falseMatch, trueMatch)
+			if err != nil {
+				return ErrorOccurred, err
+			}
+			if moreSpecific {
 				trueMatchesToRemove[trueMatch] = struct{}{}
 			}
 		}
 	}
 	for _, falseMatch := range falseMatches {
-		falseMatchRegExp, err := getMoreSpecificPatterns(falseMatch)
-		if err != nil {
-			return ErrorOccurred, err
-		}
 		for _, trueMatch := range trueMatches {
-			if falseMatchRegExp.MatchString(trueMatch) {
+			moreSpecific, err := isMoreSpecificPattern(trueMatch, falseMatch)
+			if err != nil {
+				return ErrorOccurred, err
+			}
+			if moreSpecific {
 				falseMatchesToRemove[falseMatch] = struct{}{}
 			}
 		}
@@ -176,31 +398,134 @@ func resolveConflictingPatterns(trueMatches, falseMatches []string, tableName st
 	return IgnorePatternConflict, DoltIgnoreConflictError{Table: tableName, TruePatterns: conflictingTrueMatches, FalsePatterns: conflictingFalseMatches}
 }
 
+// IsTableNameIgnored returns whether the table named |tableName| is ignored. Only the patterns of the source with the
+// highest precedence that has a pattern matching the table apply: the dolt_ignore table, then the branch, database and
+// global configs. Within a source, negated patterns are applied after the other patterns, so a table matching a
+// negated pattern is only ignored according to the negated patterns. Among patterns of the same kind, more specific
+// patterns override less specific patterns.
 func (ip *IgnorePatterns) IsTableNameIgnored(tableName string) (IgnoreResult, error) {
+	for _, source := range ignorePatternSources {
+		matched, result, err := ip.matchTableName(tableName, source, true)
+		if err != nil || matched {
+			return result, err
+		}
+		matched, result, err = ip.matchTableName(tableName, source, false)
+		if err != nil || matched {
+			return result, err
+		}
+	}
+	return DontIgnore, nil
+}
+
+// matchTableName matches |tableName| against the patterns of |source| which are negated, or not, according to
+// |negated|, and returns whether any of them matched it.
+func (ip *IgnorePatterns) matchTableName(tableName string, source ignorePatternSource, negated bool) (bool, IgnoreResult, error) {
 	trueMatches := []string{}
 	falseMatches := []string{}
 	for _, patternIgnore := range *ip {
+		if patternIgnore.source != source || patternIgnore.isNegated() != negated {
+			continue
+		}
 		pattern := patternIgnore.pattern
-		ignore := patternIgnore.ignore
-		patternRegExp, err := compilePattern(pattern)
+		patternRegExp, err := patternIgnore.compiled()
 		if err != nil {
-			return ErrorOccurred, err
+			return false, ErrorOccurred, err
 		}
 		if patternRegExp.MatchString(tableName) {
-			if ignore {
+			if patternIgnore.ignoresTable() {
 				trueMatches = append(trueMatches, pattern)
 			} else {
 				falseMatches = append(falseMatches, pattern)
 			}
 		}
 	}
+	if len(trueMatches) == 0 && len(falseMatches) == 0 {
+		return false, DontIgnore, nil
+	}
 	if len(trueMatches) == 0 {
-		return DontIgnore, nil
+		return true, DontIgnore, nil
 	}
 	if len(falseMatches) == 0 {
-		return Ignore, nil
+		return true, Ignore, nil
 	}
 	// The table name matched both positive and negative patterns.
 	// More specific patterns override less specific patterns.
-	return resolveConflictingPatterns(trueMatches, falseMatches, tableName)
+	result, err := resolveConflictingPatterns(trueMatches, falseMatches, tableName)
+	return true, result, err
+}
+
+// MergePolicy returns the merge policy of the table named |tableName|. Only tables that are ignored have a policy other
+// than the default one, which is that of the most specific ignoring pattern that sets one. It's an error for equally
+// specific patterns to set different policies.
+func (ip *IgnorePatterns) MergePolicy(tableName string) (IgnoreMergePolicy, error) {
+	if len(*ip) == 0 {
+		return MergePolicyDefault, nil
+	}
+	result, err := ip.IsTableNameIgnored(tableName)
+	if err != nil || result != Ignore {
+		return MergePolicyDefault, err
+	}
+
+	var matches []ignorePattern
+	for _, p := range *ip {
+		if !p.ignoresTable() || p.mergePolicy == MergePolicyDefault {
+			continue
+		}
+		patternRegExp, err := p.compiled()
+		if err != nil {
+			return MergePolicyDefault, err
+		}
+		if patternRegExp.MatchString(tableName) {
+			matches = append(matches, p)
+		}
+	}
+
+	policy := MergePolicyDefault
+	for _, p := range matches {
+		overridden := false
+		for _, other := range matches {
+			if other.pattern == p.pattern {
+				continue
+			}
+			moreSpecific, err := isMoreSpecificPattern(other.pattern, p.pattern)
+			if err != nil {
+				return MergePolicyDefault, err
+			}
+			if moreSpecific {
+				overridden = true
+				break
+			}
+		}
+		if overridden {
+			continue
+		}
+		if policy != MergePolicyDefault && policy != p.mergePolicy {
+			return MergePolicyDefault, fmt.Errorf("dolt_ignore has conflicting merge policies for %s", tableName)
+		}
+		policy = p.mergePolicy
+	}
+	return policy, nil
+}
+
+// IsTablePattern returns whether |s| is a pattern matching table names, rather than a table name, using the same
+// syntax as dolt_ignore patterns: * matches any number of characters, ? matches a single character, and patterns
+// prefixed with re: are regular expressions.
+func IsTablePattern(s string) bool {
+	return strings.ContainsAny(s, "*?") || strings.HasPrefix(s, regexPatternPrefix)
+}
+
+// MatchTablePattern returns the names in |tableNames| matched by |pattern|, which uses the same syntax as dolt_ignore
+// patterns.
+func MatchTablePattern(pattern string, tableNames []string) ([]string, error) {
+	patternRegExp, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, name := range tableNames {
+		if patternRegExp.MatchString(name) {
+			matches = append(matches, name)
+		}
+	}
+	return matches, nil
 }
@@ -0,0 +1,108 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ignoredColumn is a row of the dolt_ignore_columns or dolt_volatile_columns tables. Its table name is a pattern, as
+// in dolt_ignore.
+type ignoredColumn struct {
+	tablePattern string
+	column       string
+}
+
+// IgnoredColumns are the columns listed in the dolt_ignore_columns table. Changes to only ignored columns of a row
+// aren't shown by diffs, don't make a table modified in the status, and aren't staged.
+type IgnoredColumns []ignoredColumn
+
+// GetIgnoredColumns returns the columns listed in the dolt_ignore_columns table of |root|. Ignored columns are only
+// supported by the __DOLT__ storage format.
+func GetIgnoredColumns(ctx context.Context, root *RootValue) (IgnoredColumns, error) {
+	return readColumnPatterns(ctx, root, IgnoreColumnsTableName)
+}
+
+// GetVolatileColumns returns the columns listed in the dolt_volatile_columns table of |root|. Changes to only
+// volatile columns of a row are left out of diffs and the status when asked for with --ignore-volatile, and are
+// merged by taking the value of the most recent commit instead of conflicting. Volatile columns are only supported by
+// the __DOLT__ storage format.
+func GetVolatileColumns(ctx context.Context, root *RootValue) (IgnoredColumns, error) {
+	return readColumnPatterns(ctx, root, VolatileColumnsTableName)
+}
+
+// readColumnPatterns reads the (table pattern, column) rows of the system table |tableName| of |root|.
+func readColumnPatterns(ctx context.Context, root *RootValue, tableName string) (IgnoredColumns, error) {
+	table, found, err := root.GetTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !found || !types.IsFormat_DOLT(table.Format()) {
+		return nil, nil
+	}
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, _ := sch.GetMapDescriptors()
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ignored IgnoredColumns
+	for {
+		k, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			return ignored, nil
+		} else if err != nil {
+			return nil, err
+		}
+		tablePattern, ok := keyDesc.GetString(0, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name")
+		}
+		column, ok := keyDesc.GetString(1, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read column name")
+		}
+		ignored = append(ignored, ignoredColumn{tablePattern: tablePattern, column: column})
+	}
+}
+
+// ColumnsForTable returns the case-insensitive set of the ignored columns of the table named |tableName|.
+func (ic IgnoredColumns) ColumnsForTable(tableName string) (*set.StrSet, error) {
+	columns := set.NewCaseInsensitiveStrSet(nil)
+	for _, c := range ic {
+		patternRegExp, err := compilePattern(c.tablePattern)
+		if err != nil {
+			return nil, err
+		}
+		if patternRegExp.MatchString(tableName) {
+			columns.Add(c.column)
+		}
+	}
+	return columns, nil
+}
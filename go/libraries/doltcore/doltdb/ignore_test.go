@@ -0,0 +1,73 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIgnorePatterns(t *testing.T) {
+	ip, err := newIgnorePatterns([]ignorePattern{
+		{pattern: "tmp_*", ignore: true},
+		{pattern: "tmp_keep", ignore: false},
+		{pattern: "re:log_[0-9]+", ignore: true},
+	})
+	require.NoError(t, err)
+	for _, p := range ip {
+		assert.NotNil(t, p.regex)
+	}
+
+	tests := []struct {
+		table    string
+		expected IgnoreResult
+	}{
+		{"tmp_scratch", Ignore},
+		{"tmp_keep", DontIgnore},
+		{"log_12", Ignore},
+		{"log_ab", DontIgnore},
+		{"users", DontIgnore},
+	}
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			result, err := ip.IsTableNameIgnored(test.table)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+
+	_, err = newIgnorePatterns([]ignorePattern{{pattern: "re:tmp_(", ignore: true}})
+	assert.Error(t, err)
+}
+
+func BenchmarkIsTableNameIgnored(b *testing.B) {
+	patterns := make([]ignorePattern, 100)
+	for i := range patterns {
+		patterns[i] = ignorePattern{pattern: fmt.Sprintf("scratch_%d_*", i), ignore: true}
+	}
+	ip, err := newIgnorePatterns(patterns)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err = ip.IsTableNameIgnored(fmt.Sprintf("table_%d", i%1000))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
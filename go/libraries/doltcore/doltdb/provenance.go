@@ -0,0 +1,187 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// The trailers of a commit message which hold the provenance of the commit
+const (
+	SourceURLTrailer   = "Source-URL"
+	LicenseTrailer     = "License"
+	CollectedAtTrailer = "Collected-At"
+)
+
+// Provenance is where the data of a commit or a table comes from, and under which license it can be used.
+type Provenance struct {
+	SourceURL   string
+	License     string
+	CollectedAt string
+}
+
+// IsEmpty returns whether none of the fields of |p| are set.
+func (p Provenance) IsEmpty() bool {
+	return p == Provenance{}
+}
+
+func (p Provenance) trailers() [][2]string {
+	var trailers [][2]string
+	for _, t := range [][2]string{
+		{SourceURLTrailer, p.SourceURL},
+		{LicenseTrailer, p.License},
+		{CollectedAtTrailer, p.CollectedAt},
+	} {
+		if t[1] != "" {
+			trailers = append(trailers, t)
+		}
+	}
+	return trailers
+}
+
+// WithProvenanceTrailers returns the commit message |msg| with the fields set in |p| as trailers, lines like
+// "License: CC-BY-4.0" in the last paragraph of the message. The provenance trailers |msg| already has are replaced.
+func WithProvenanceTrailers(msg string, p Provenance) string {
+	if p.IsEmpty() {
+		return msg
+	}
+
+	old := ParseProvenanceTrailers(msg)
+	if p.SourceURL == "" {
+		p.SourceURL = old.SourceURL
+	}
+	if p.License == "" {
+		p.License = old.License
+	}
+	if p.CollectedAt == "" {
+		p.CollectedAt = old.CollectedAt
+	}
+
+	body, trailers := splitTrailers(msg)
+	var kept []string
+	for _, line := range trailers {
+		if key, _, ok := parseTrailer(line); !ok || !isProvenanceTrailer(key) {
+			kept = append(kept, line)
+		}
+	}
+	for _, t := range p.trailers() {
+		kept = append(kept, t[0]+": "+t[1])
+	}
+
+	return body + "\n\n" + strings.Join(kept, "\n")
+}
+
+// ParseProvenanceTrailers returns the provenance in the trailers of the commit message |msg|.
+func ParseProvenanceTrailers(msg string) Provenance {
+	var p Provenance
+	_, trailers := splitTrailers(msg)
+	for _, line := range trailers {
+		key, value, ok := parseTrailer(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.EqualFold(key, SourceURLTrailer):
+			p.SourceURL = value
+		case strings.EqualFold(key, LicenseTrailer):
+			p.License = value
+		case strings.EqualFold(key, CollectedAtTrailer):
+			p.CollectedAt = value
+		}
+	}
+	return p
+}
+
+// splitTrailers splits |msg| into its body and the lines of its last paragraph, if every line of it is a trailer. As
+// in git, the first paragraph of a message is never trailers.
+func splitTrailers(msg string) (string, []string) {
+	msg = strings.TrimRight(msg, "\n")
+	i := strings.LastIndex(msg, "\n\n")
+	if i < 0 {
+		return msg, nil
+	}
+	body, last := strings.TrimRight(msg[:i], "\n"), msg[i+2:]
+
+	lines := strings.Split(last, "\n")
+	for _, line := range lines {
+		if _, _, ok := parseTrailer(line); !ok {
+			return msg, nil
+		}
+	}
+	return body, lines
+}
+
+func parseTrailer(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(line, ": ")
+	if !ok || key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+func isProvenanceTrailer(key string) bool {
+	return strings.EqualFold(key, SourceURLTrailer) || strings.EqualFold(key, LicenseTrailer) || strings.EqualFold(key, CollectedAtTrailer)
+}
+
+// GetTableProvenance returns the provenance of each table listed in the dolt_table_provenance table of |root|, by
+// table name. Table provenance is only supported by the __DOLT__ storage format.
+func GetTableProvenance(ctx context.Context, root *RootValue) (map[string]Provenance, error) {
+	table, found, err := root.GetTable(ctx, TableProvenanceTableName)
+	if err != nil {
+		return nil, err
+	}
+	if !found || !types.IsFormat_DOLT(table.Format()) {
+		return nil, nil
+	}
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valDesc := sch.GetMapDescriptors()
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provenance := make(map[string]Provenance)
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return provenance, nil
+		} else if err != nil {
+			return nil, err
+		}
+		tableName, ok := keyDesc.GetString(0, k)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name")
+		}
+		// unset fields are NULL
+		var p Provenance
+		p.SourceURL, _ = valDesc.GetString(0, v)
+		p.License, _ = valDesc.GetString(1, v)
+		p.CollectedAt, _ = valDesc.GetString(2, v)
+		provenance[tableName] = p
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProvenanceTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected Provenance
+	}{
+		{"no trailers", "add rows", Provenance{}},
+		{"subject is never trailers", "License: MIT", Provenance{}},
+		{"trailers", "add rows\n\nSource-URL: https://example.com\nLicense: MIT\nCollected-At: 2023-05-01\n", Provenance{SourceURL: "https://example.com", License: "MIT", CollectedAt: "2023-05-01"}},
+		{"case insensitive keys", "add rows\n\nlicense: MIT", Provenance{License: "MIT"}},
+		{"last paragraph isn't trailers", "add rows\n\nLicense: MIT\nsee the readme", Provenance{}},
+		{"trailers in an earlier paragraph", "add rows\n\nLicense: MIT\n\nmore about the rows", Provenance{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseProvenanceTrailers(test.msg))
+		})
+	}
+}
+
+func TestWithProvenanceTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		p        Provenance
+		expected string
+	}{
+		{"no provenance", "add rows", Provenance{}, "add rows"},
+		{"new trailers", "add rows", Provenance{License: "MIT", SourceURL: "https://example.com"}, "add rows\n\nSource-URL: https://example.com\nLicense: MIT"},
+		{"other trailers are kept", "add rows\n\nSigned-off-by: a <a@b.c>", Provenance{License: "MIT"}, "add rows\n\nSigned-off-by: a <a@b.c>\nLicense: MIT"},
+		{"trailers are replaced", "add rows\n\nLicense: MIT\nCollected-At: 2023-05-01", Provenance{License: "Apache-2.0"}, "add rows\n\nLicense: Apache-2.0\nCollected-At: 2023-05-01"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, WithProvenanceTrailers(test.msg, test.p))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ReplicationStatus is the state of the replication of a ref of a database to a remote by a replication hook.
+type ReplicationStatus struct {
+	Remote string
+	Async  bool
+	Ref    string
+	// Head is the latest head of the ref, and Replicated the latest head of the ref pushed to the remote. They're
+	// empty hashes after the ref is deleted.
+	Head       hash.Hash
+	Replicated hash.Hash
+	// PendingSince is when the ref was first updated after its last head was replicated, or zero if its head is
+	// replicated.
+	PendingSince   time.Time
+	LastReplicated time.Time
+	LastError      error
+	LastErrorAt    time.Time
+}
+
+// Lag returns how long the ref has been waiting for its head to be replicated at |now|.
+func (s ReplicationStatus) Lag(now time.Time) time.Duration {
+	if s.PendingSince.IsZero() {
+		return 0
+	}
+	return now.Sub(s.PendingSince)
+}
+
+// ReplicationHook is a CommitHook which pushes the updates of the refs of a database to a remote.
+type ReplicationHook interface {
+	CommitHook
+	// ReplicationStatus returns the state of the replication of each ref updated since the hook was created.
+	ReplicationStatus() []ReplicationStatus
+}
+
+// ReplicationStatus returns the state of the replication of the refs of |ddb| by its replication hooks.
+func (ddb *DoltDB) ReplicationStatus() []ReplicationStatus {
+	var statuses []ReplicationStatus
+	for _, hook := range ddb.db.PostCommitHooks() {
+		if rh, ok := hook.(ReplicationHook); ok {
+			statuses = append(statuses, rh.ReplicationStatus()...)
+		}
+	}
+	return statuses
+}
+
+// replicationTracker records the state of the replication of the refs of a database to a remote.
+type replicationTracker struct {
+	remote string
+	async  bool
+
+	mu   sync.Mutex
+	refs map[string]*ReplicationStatus
+}
+
+func newReplicationTracker(remote string, async bool) *replicationTracker {
+	return &replicationTracker{remote: remote, async: async, refs: make(map[string]*ReplicationStatus)}
+}
+
+func (t *replicationTracker) get(ref string) *ReplicationStatus {
+	s, ok := t.refs[ref]
+	if !ok {
+		s = &ReplicationStatus{Remote: t.remote, Async: t.async, Ref: ref}
+		t.refs[ref] = s
+	}
+	return s
+}
+
+// updated records that |ref| was updated to |h|, and still has to be replicated.
+func (t *replicationTracker) updated(ref string, h hash.Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.get(ref)
+	s.Head = h
+	if s.PendingSince.IsZero() && s.Replicated != h {
+		s.PendingSince = time.Now()
+	}
+}
+
+// replicated records that |ref| was replicated at |h|.
+func (t *replicationTracker) replicated(ref string, h hash.Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.get(ref)
+	s.Replicated = h
+	s.LastReplicated = time.Now()
+	if s.Head == h {
+		s.PendingSince = time.Time{}
+	}
+}
+
+// failed records that the replication of |ref| failed with |err|.
+func (t *replicationTracker) failed(ref string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.get(ref)
+	s.LastError = err
+	s.LastErrorAt = time.Now()
+}
+
+// status returns the state of the replication of each ref, sorted by ref.
+func (t *replicationTracker) status() []ReplicationStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	statuses := make([]ReplicationStatus, 0, len(t.refs))
+	for _, s := range t.refs {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Ref < statuses[j].Ref
+	})
+	return statuses
+}
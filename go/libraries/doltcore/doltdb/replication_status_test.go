@@ -0,0 +1,63 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestReplicationTracker(t *testing.T) {
+	tracker := newReplicationTracker("standby", true)
+	first := hash.Of([]byte("first"))
+	second := hash.Of([]byte("second"))
+
+	tracker.updated("refs/heads/main", first)
+	tracker.updated("refs/heads/main", second)
+	tracker.updated("refs/heads/feature", first)
+	statuses := tracker.status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "refs/heads/feature", statuses[0].Ref)
+	main := statuses[1]
+	assert.Equal(t, "standby", main.Remote)
+	assert.True(t, main.Async)
+	assert.Equal(t, second, main.Head)
+	assert.True(t, main.Replicated.IsEmpty())
+	assert.False(t, main.PendingSince.IsZero())
+	assert.Equal(t, time.Second, main.Lag(main.PendingSince.Add(time.Second)))
+
+	// replicating an older head leaves the ref pending
+	tracker.replicated("refs/heads/main", first)
+	main = tracker.status()[1]
+	assert.Equal(t, first, main.Replicated)
+	assert.False(t, main.PendingSince.IsZero())
+
+	tracker.failed("refs/heads/main", errors.New("remote is down"))
+	main = tracker.status()[1]
+	assert.EqualError(t, main.LastError, "remote is down")
+	assert.False(t, main.LastErrorAt.IsZero())
+
+	tracker.replicated("refs/heads/main", second)
+	main = tracker.status()[1]
+	assert.Equal(t, second, main.Replicated)
+	assert.Zero(t, main.Lag(time.Now()))
+	assert.False(t, main.LastReplicated.IsZero())
+}
@@ -50,7 +50,7 @@ type FeatureVersion int64
 
 // DoltFeatureVersion is described in feature_version.md.
 // only variable for testing.
-var DoltFeatureVersion FeatureVersion = 3 // last bumped when storing creation time for triggers
+var DoltFeatureVersion FeatureVersion = 4 // last bumped when adding the Decimal64 and StringDict encodings
 
 // RootValue is the value of the Database and is the committed value in every Dolt commit.
 type RootValue struct {
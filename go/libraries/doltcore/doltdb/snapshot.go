@@ -0,0 +1,79 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// snapshotWorkingSetPrefix is the prefix of the working sets snapshots are stored in. Snapshots aren't the working
+// set of any head, so they are never checked out, listed as branches, or pushed.
+const snapshotWorkingSetPrefix = "snapshots/"
+
+// SnapshotRef returns the ref of the working set the snapshot named |name| is stored in.
+func SnapshotRef(name string) ref.WorkingSetRef {
+	return ref.NewWorkingSetRef(snapshotWorkingSetPrefix + name)
+}
+
+// PutSnapshot stores the working and staged roots of |roots| in the snapshot named |name|, replacing the snapshot
+// of that name if there is one.
+func (ddb *DoltDB) PutSnapshot(ctx context.Context, name string, roots Roots, meta *datas.WorkingSetMeta) error {
+	if !ref.IsValidBranchName(name) {
+		return ErrInvSnapshotName
+	}
+
+	wsRef := SnapshotRef(name)
+	var prevHash hash.Hash
+	prev, err := ddb.ResolveWorkingSet(ctx, wsRef)
+	if err == nil {
+		prevHash, err = prev.HashOf()
+		if err != nil {
+			return err
+		}
+	} else if !errors.Is(err, ErrWorkingSetNotFound) {
+		return err
+	}
+
+	ws := EmptyWorkingSet(wsRef).WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged)
+	return ddb.UpdateWorkingSet(ctx, wsRef, ws, prevHash, meta)
+}
+
+// ResolveSnapshot returns the working and staged roots stored in the snapshot named |name|, or ErrSnapshotNotFound.
+func (ddb *DoltDB) ResolveSnapshot(ctx context.Context, name string) (working, staged *RootValue, err error) {
+	if !ref.IsValidBranchName(name) {
+		return nil, nil, ErrInvSnapshotName
+	}
+
+	ws, err := ddb.ResolveWorkingSet(ctx, SnapshotRef(name))
+	if errors.Is(err, ErrWorkingSetNotFound) {
+		return nil, nil, ErrSnapshotNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return ws.WorkingRoot(), ws.StagedRoot(), nil
+}
+
+// DeleteSnapshot deletes the snapshot named |name|, or returns ErrSnapshotNotFound.
+func (ddb *DoltDB) DeleteSnapshot(ctx context.Context, name string) error {
+	if _, _, err := ddb.ResolveSnapshot(ctx, name); err != nil {
+		return err
+	}
+	return ddb.DeleteWorkingSet(ctx, SnapshotRef(name))
+}
@@ -151,6 +151,9 @@ var writeableSystemTables = []string{
 	SchemasTableName,
 	ProceduresTableName,
 	IgnoreTableName,
+	IgnoreColumnsTableName,
+	VolatileColumnsTableName,
+	TableProvenanceTableName,
 }
 
 var persistedSystemTables = []string{
@@ -159,6 +162,9 @@ var persistedSystemTables = []string{
 	SchemasTableName,
 	ProceduresTableName,
 	IgnoreTableName,
+	IgnoreColumnsTableName,
+	VolatileColumnsTableName,
+	TableProvenanceTableName,
 }
 
 var generatedSystemTables = []string{
@@ -171,6 +177,7 @@ var generatedSystemTables = []string{
 	CommitAncestorsTableName,
 	StatusTableName,
 	RemotesTableName,
+	ProvenanceTableName,
 }
 
 var generatedSystemViewPrefixes = []string{
@@ -180,6 +187,7 @@ var generatedSystemViewPrefixes = []string{
 var generatedSystemTablePrefixes = []string{
 	DoltDiffTablePrefix,
 	DoltCommitDiffTablePrefix,
+	DoltColumnDiffTablePrefix,
 	DoltHistoryTablePrefix,
 	DoltConfTablePrefix,
 	DoltConstViolTablePrefix,
@@ -190,6 +198,8 @@ const (
 	LicenseDoc = "LICENSE.md"
 	// ReadmeDoc is the key for accessing the readme within the docs table
 	ReadmeDoc = "README.md"
+	// DataDictionaryDoc is the key for accessing the generated data dictionary within the docs table
+	DataDictionaryDoc = "DATA_DICTIONARY.md"
 )
 
 var DocsMaybeCreateTableStmt = `
@@ -257,6 +267,8 @@ const (
 	DoltDiffTablePrefix = "dolt_diff_"
 	// DoltCommitDiffTablePrefix is the prefix assigned to all the generated commit diff tables
 	DoltCommitDiffTablePrefix = "dolt_commit_diff_"
+	// DoltColumnDiffTablePrefix is the prefix assigned to all the generated column diff tables
+	DoltColumnDiffTablePrefix = "dolt_column_diff_"
 	// DoltConfTablePrefix is the prefix assigned to all the generated conflict tables
 	DoltConfTablePrefix = "dolt_conflicts_"
 	// DoltConstViolTablePrefix is the prefix assigned to all the generated constraint violation tables
@@ -303,10 +315,66 @@ const (
 	// MergeStatusTableName is the merge status system table name.
 	MergeStatusTableName = "dolt_merge_status"
 
+	// MergeStateTableName is the system table name of the state of each table of an active merge
+	MergeStateTableName = "dolt_merge_state"
+
 	// TagsTableName is the tags table name
 	TagsTableName = "dolt_tags"
 
 	IgnoreTableName = "dolt_ignore"
+
+	// IgnoreColumnsTableName is the system table listing the columns excluded from diffs, status and staging
+	IgnoreColumnsTableName = "dolt_ignore_columns"
+
+	// VolatileColumnsTableName is the system table listing the columns whose changes can be left out of diffs and
+	// status, and which are merged by last writer wins
+	VolatileColumnsTableName = "dolt_volatile_columns"
+
+	// TableProvenanceTableName is the system table listing the source, license and collection date of tables
+	TableProvenanceTableName = "dolt_table_provenance"
+
+	// ProvenanceTableName is the provenance system table name, which has the provenance of each table from
+	// dolt_table_provenance or from the last commit with provenance
+	ProvenanceTableName = "dolt_provenance"
+
+	// IndexUsageTableName is the index usage system table name
+	IndexUsageTableName = "dolt_index_usage"
+
+	// QuotasTableName is the resource quotas system table name
+	QuotasTableName = "dolt_quotas"
+
+	// RemoteStatusTableName is the background fetch status system table name
+	RemoteStatusTableName = "dolt_remote_status"
+
+	// BackupStatusTableName is the scheduled backup status system table name
+	BackupStatusTableName = "dolt_backup_status"
+
+	// FetchHistoryTableName is the fetch, pull and push history system table name
+	FetchHistoryTableName = "dolt_fetch_history"
+
+	// WebhookDeadLettersTableName is the system table name of the webhooks the sql-server failed to deliver
+	WebhookDeadLettersTableName = "dolt_webhook_dead_letters"
+
+	// ConflictResolutionsTableName is the system table name of the journal of the conflicts resolved by deleting them
+	// from dolt_conflicts tables
+	ConflictResolutionsTableName = "dolt_conflict_resolutions"
+
+	// AuditLogTableName is the system table name of the log of the statements which changed the data or the schema of
+	// the database while its audit log was enabled
+	AuditLogTableName = "dolt_audit_log"
+
+	// ReplicationStatusTableName is the system table name of the state of the replication of the database to the
+	// remotes of dolt_replicate_to_remote
+	ReplicationStatusTableName = "dolt_replication_status"
+
+	// StorageTableName is the large value storage system table name
+	StorageTableName = "dolt_storage"
+
+	// StorageStatsTableName is the chunk cache statistics system table name
+	StorageStatsTableName = "dolt_storage_stats"
+
+	// ProjectionCacheTableName is the columnar projection cache system table name
+	ProjectionCacheTableName = "dolt_projection_cache"
 )
 
 const (
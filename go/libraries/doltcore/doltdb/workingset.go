@@ -17,11 +17,13 @@ package doltdb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -221,6 +223,28 @@ func (ws *WorkingSet) MergeActive() bool {
 	return ws.mergeState != nil
 }
 
+// UnresolvedTables returns the sorted names of the tables of the working root with data conflicts or constraint
+// violations, and of the tables with schema conflicts if a merge is active.
+func (ws *WorkingSet) UnresolvedTables(ctx context.Context) ([]string, error) {
+	inConflict, err := ws.workingRoot.TablesWithDataConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	withViolations, err := ws.workingRoot.TablesWithConstraintViolations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := set.NewStrSet(inConflict)
+	tables.Add(withViolations...)
+	if ws.MergeActive() {
+		tables.Add(ws.mergeState.TablesWithSchemaConflicts()...)
+	}
+	names := tables.AsSlice()
+	sort.Strings(names)
+	return names, nil
+}
+
 func (ws WorkingSet) Meta() *datas.WorkingSetMeta {
 	return ws.meta
 }
@@ -0,0 +1,72 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// LoadSigningKey reads a PEM encoded PKCS #8 ed25519 private key, as written by
+// `openssl genpkey -algorithm ed25519`, from |path|. It is used to sign remote manifests on push.
+func LoadSigningKey(fs filesys.ReadableFS, path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(fs, path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %s: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid signing key %s: not an ed25519 key", path)
+	}
+	return edKey, nil
+}
+
+// LoadTrustedKey reads a PEM encoded PKIX ed25519 public key, as written by `openssl pkey -pubout`, from |path|.
+// It is used to verify remote manifests on fetch.
+func LoadTrustedKey(fs filesys.ReadableFS, path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(fs, path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted key %s: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid trusted key %s: not an ed25519 key", path)
+	}
+	return edKey, nil
+}
+
+func readPEM(fs filesys.ReadableFS, path, blockType string) ([]byte, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("%s does not contain a PEM encoded %s", path, blockType)
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,367 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+)
+
+// BackupTimestampFormat is the format of the names of the directories of the backups of a BackupSchedule with a
+// retention, which are named after the time they were made.
+const BackupTimestampFormat = "20060102T150405Z"
+
+// BackupSchedule is the configuration of the backups of every database to a remote, made on a cron schedule.
+type BackupSchedule struct {
+	// Remote is the URL every database is backed up under, each in a directory named after the database.
+	Remote string
+	// Cron is a standard five field cron expression, or a descriptor such as @daily, of when backups are made.
+	Cron string
+	// Retention is the number of backups of each database that are kept. Each backup is made in a new directory, named
+	// after the time it was made, and the oldest are deleted once there are more than this many. If it's zero, a
+	// single backup of each database is synced in place, like dolt backup sync.
+	Retention int
+}
+
+// Validate returns an error if the schedule's cron expression can't be parsed, or if it has a retention but its
+// backups can't be listed and deleted.
+func (bs BackupSchedule) Validate() error {
+	if bs.Remote == "" {
+		return errors.New("remote: Cannot be empty")
+	}
+	if _, err := cron.ParseStandard(bs.Cron); err != nil {
+		return fmt.Errorf("cron: %w", err)
+	}
+	if bs.Retention < 0 {
+		return fmt.Errorf("retention: is %d but cannot be negative", bs.Retention)
+	}
+	if bs.Retention > 0 {
+		u, err := url.Parse(bs.Remote)
+		if err != nil {
+			return fmt.Errorf("remote: %w", err)
+		}
+		if u.Scheme != dbfactory.FileScheme {
+			return fmt.Errorf("retention: is only supported for %s:// remotes, not %s", dbfactory.FileScheme, bs.Remote)
+		}
+	}
+	return nil
+}
+
+// BackupStatus is the outcome of the backups of one database to the remote of one BackupSchedule.
+type BackupStatus struct {
+	Database     string
+	Remote       string
+	LastAttempt  time.Time
+	LastSuccess  time.Time
+	LastDuration time.Duration
+	// LastBackup is the URL of the last backup that was made
+	LastBackup string
+	// LastError is the error of the last backup, or empty if it succeeded
+	LastError string
+	Successes uint64
+	Failures  uint64
+}
+
+type scheduledBackup struct {
+	BackupSchedule
+	schedule cron.Schedule
+	next     time.Time
+}
+
+type backupStatusKey struct {
+	db, remote string
+}
+
+// BackupScheduler backs up every database to the remotes of a set of BackupSchedules, each whenever its cron schedule
+// fires, and deletes the backups beyond each schedule's retention. It keeps the outcome of the last backup of every
+// database to every remote.
+type BackupScheduler struct {
+	backups  []*scheduledBackup
+	targets  func() []FetchTarget
+	remoteDB RemoteDBFunc
+	startJob func(db string) (func(), error)
+
+	mu     sync.Mutex
+	status map[backupStatusKey]*BackupStatus
+	now    func() time.Time
+}
+
+// NewBackupScheduler returns a BackupScheduler that backs up the databases returned by |targets| on each of
+// |schedules|. |remoteDB| returns the database of a backup, which it must create if it doesn't exist.
+func NewBackupScheduler(schedules []BackupSchedule, targets func() []FetchTarget, remoteDB RemoteDBFunc) (*BackupScheduler, error) {
+	backups := make([]*scheduledBackup, len(schedules))
+	for i, bs := range schedules {
+		if err := bs.Validate(); err != nil {
+			return nil, err
+		}
+		schedule, err := cron.ParseStandard(bs.Cron)
+		if err != nil {
+			return nil, err
+		}
+		backups[i] = &scheduledBackup{BackupSchedule: bs, schedule: schedule}
+	}
+	return &BackupScheduler{
+		backups:  backups,
+		targets:  targets,
+		remoteDB: remoteDB,
+		status:   make(map[backupStatusKey]*BackupStatus),
+		now:      time.Now,
+	}, nil
+}
+
+// WithJobHook returns the scheduler after setting |startJob| to be called before each database is backed up. If it
+// returns an error, the database is not backed up, and the error is recorded as the outcome of the backup. Otherwise,
+// the function it returns is called once the database has been backed up.
+func (s *BackupScheduler) WithJobHook(startJob func(db string) (func(), error)) *BackupScheduler {
+	s.startJob = startJob
+	return s
+}
+
+// Schedules returns the schedules of the scheduler's backups.
+func (s *BackupScheduler) Schedules() []BackupSchedule {
+	schedules := make([]BackupSchedule, len(s.backups))
+	for i, b := range s.backups {
+		schedules[i] = b.BackupSchedule
+	}
+	return schedules
+}
+
+// NextRun returns when the backups to |remote| will next be made, or the zero time if they aren't scheduled yet.
+func (s *BackupScheduler) NextRun(remote string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.backups {
+		if b.Remote == remote {
+			return b.next
+		}
+	}
+	return time.Time{}
+}
+
+// Run backs up every target on each schedule whenever it fires, until |ctx| is done.
+func (s *BackupScheduler) Run(ctx context.Context) {
+	if len(s.backups) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	for _, b := range s.backups {
+		b.next = b.schedule.Next(s.now())
+	}
+	s.mu.Unlock()
+
+	for {
+		s.mu.Lock()
+		next := s.backups[0].next
+		for _, b := range s.backups[1:] {
+			if b.next.Before(next) {
+				next = b.next
+			}
+		}
+		s.mu.Unlock()
+
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		for _, b := range s.backups {
+			if b.next.After(next) {
+				continue
+			}
+			s.BackupAll(ctx, b.BackupSchedule)
+			s.mu.Lock()
+			b.next = b.schedule.Next(s.now())
+			s.mu.Unlock()
+		}
+	}
+}
+
+// BackupAll backs up every target to the remote of |bs| once, then deletes the backups beyond its retention. Failures
+// are recorded in the status of the backup of each database, rather than returned.
+func (s *BackupScheduler) BackupAll(ctx context.Context, bs BackupSchedule) {
+	for _, t := range s.targets() {
+		if ctx.Err() != nil {
+			return
+		}
+		s.backupTarget(ctx, bs, t)
+	}
+}
+
+func (s *BackupScheduler) backupTarget(ctx context.Context, bs BackupSchedule, t FetchTarget) {
+	start := s.now()
+	if s.startJob != nil {
+		done, err := s.startJob(t.Name)
+		if err != nil {
+			s.record(t.Name, bs.Remote, start, "", err)
+			return
+		}
+		defer done()
+	}
+
+	backupUrl := strings.TrimSuffix(bs.Remote, "/") + "/" + t.Name
+	if bs.Retention > 0 {
+		backupUrl += "/" + start.UTC().Format(BackupTimestampFormat)
+	}
+
+	err := s.backup(ctx, t, backupUrl, bs.Retention > 0)
+	if err == nil && bs.Retention > 0 {
+		err = pruneBackups(strings.TrimSuffix(bs.Remote, "/")+"/"+t.Name, bs.Retention)
+	}
+	s.record(t.Name, bs.Remote, start, backupUrl, err)
+}
+
+// backup syncs the database of |t| to the backup at |backupUrl|, as dolt backup sync does. If |snapshot| is true, the
+// backup is a new one which won't be synced to again, so it's closed once it's made.
+func (s *BackupScheduler) backup(ctx context.Context, t FetchTarget, backupUrl string, snapshot bool) error {
+	destDb, err := s.remoteDB(ctx, t.DbData.Ddb.Format(), env.NewRemote("__backup__", backupUrl, nil))
+	if err != nil {
+		return fmt.Errorf("error loading backup destination: %w", err)
+	}
+	if snapshot {
+		defer func() {
+			if u, err := url.Parse(backupUrl); err == nil {
+				dbfactory.DeleteFromSingletonCache(u.Path)
+			}
+			destDb.Close()
+		}()
+	}
+
+	tmpDir, err := t.DbData.Rsw.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+
+	err = SyncRoots(ctx, t.DbData.Ddb, destDb, tmpDir, quietProgStarter, quietProgStopper)
+	if err != nil && err != pull.ErrDBUpToDate {
+		return fmt.Errorf("error syncing backup: %w", err)
+	}
+
+	if !snapshot {
+		_, err = CompactBackup(ctx, t.DbData.Ddb, destDb)
+		if err != nil && err != ErrBackupNotCompactable {
+			return fmt.Errorf("error garbage collecting backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// pruneBackups deletes all but the newest |retention| backups in the directory at the file URL |dirUrl|. Only
+// directories named with BackupTimestampFormat are considered backups.
+func pruneBackups(dirUrl string, retention int) error {
+	u, err := url.Parse(dirUrl)
+	if err != nil {
+		return err
+	}
+	path, err := url.PathUnescape(u.Path)
+	if err != nil {
+		return err
+	}
+	dir := u.Host + filepath.FromSlash(path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var backups []string
+	for _, e := range entries {
+		if _, err := time.Parse(BackupTimestampFormat, e.Name()); err == nil && e.IsDir() {
+			backups = append(backups, e.Name())
+		}
+	}
+	if len(backups) <= retention {
+		return nil
+	}
+
+	// the names sort in the order the backups were made
+	sort.Strings(backups)
+	for _, name := range backups[:len(backups)-retention] {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("error deleting backup %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *BackupScheduler) record(db, remote string, start time.Time, backupUrl string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := backupStatusKey{db: strings.ToLower(db), remote: remote}
+	st, ok := s.status[key]
+	if !ok {
+		st = &BackupStatus{Database: db, Remote: remote}
+		s.status[key] = st
+	}
+	st.LastAttempt = start
+	st.LastDuration = s.now().Sub(start)
+	if err != nil {
+		st.LastError = err.Error()
+		st.Failures++
+	} else {
+		st.LastError = ""
+		st.LastSuccess = start
+		st.LastBackup = backupUrl
+		st.Successes++
+	}
+}
+
+// Status returns the outcome of the backups of the database named |db| that the scheduler has attempted, ordered by
+// remote.
+func (s *BackupScheduler) Status(db string) []BackupStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var statuses []BackupStatus
+	for key, st := range s.status {
+		if key.db == strings.ToLower(db) {
+			statuses = append(statuses, *st)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Remote < statuses[j].Remote
+	})
+	return statuses
+}
+
+// Forget discards the status of the backups of the database named |db|, e.g. when it's dropped.
+func (s *BackupScheduler) Forget(db string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.status {
+		if key.db == strings.ToLower(db) {
+			delete(s.status, key)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBackupScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule BackupSchedule
+		valid    bool
+	}{
+		{"cron", BackupSchedule{Remote: "file:///backups", Cron: "30 2 * * 1-5"}, true},
+		{"descriptor", BackupSchedule{Remote: "aws://[table:bucket]/backups", Cron: "@daily"}, true},
+		{"file retention", BackupSchedule{Remote: "file:///backups", Cron: "@daily", Retention: 3}, true},
+		{"no remote", BackupSchedule{Cron: "@daily"}, false},
+		{"bad cron", BackupSchedule{Remote: "file:///backups", Cron: "every day"}, false},
+		{"negative retention", BackupSchedule{Remote: "file:///backups", Cron: "@daily", Retention: -1}, false},
+		{"remote retention", BackupSchedule{Remote: "aws://[table:bucket]/backups", Cron: "@daily", Retention: 3}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.schedule.Validate()
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestBackupSchedulerRetention(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	dbData := dEnv.DbData()
+	// the test environment's file system is in memory, but backups are synced through table files on disk
+	dbData.Rsw = tempDirRepoStateWriter{RepoStateWriter: dbData.Rsw, dir: t.TempDir()}
+
+	dir := t.TempDir()
+	bs := BackupSchedule{Remote: "file://" + filepath.ToSlash(dir), Cron: "@daily", Retention: 2}
+	targets := func() []FetchTarget {
+		return []FetchTarget{{Name: "db", DbData: dbData}}
+	}
+	s, err := NewBackupScheduler([]BackupSchedule{bs}, targets, func(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote) (*doltdb.DoltDB, error) {
+		if err := r.Prepare(ctx, nbf, nil); err != nil {
+			return nil, err
+		}
+		return r.GetRemoteDBWithoutCaching(ctx, nbf, nil)
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2023, 6, 1, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		now := start.Add(time.Duration(i) * 24 * time.Hour)
+		s.now = func() time.Time { return now }
+		s.BackupAll(ctx, bs)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "db"))
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{"20230602T030000Z", "20230603T030000Z"}, names)
+
+	statuses := s.Status("DB")
+	require.Len(t, statuses, 1)
+	assert.Empty(t, statuses[0].LastError)
+	assert.Equal(t, uint64(3), statuses[0].Successes)
+	assert.Equal(t, bs.Remote+"/db/20230603T030000Z", statuses[0].LastBackup)
+
+	s.Forget("db")
+	assert.Empty(t, s.Status("db"))
+}
+
+type tempDirRepoStateWriter struct {
+	env.RepoStateWriter
+	dir string
+}
+
+func (w tempDirRepoStateWriter) TempTableFilesDir() (string, error) {
+	return w.dir, nil
+}
@@ -31,11 +31,18 @@ var ErrCOBranchDelete = errors.New("attempted to delete checked out branch")
 var ErrUnmergedBranch = errors.New("branch is not fully merged")
 var ErrWorkingSetsOnBothBranches = errors.New("checkout would overwrite uncommitted changes on target branch")
 
-func RenameBranch(ctx context.Context, dbData env.DbData, oldBranch, newBranch string, remoteDbPro env.RemoteDbProvider, force bool) error {
+// RenameBranch renames |oldBranch| to |newBranch|. Renaming deletes |oldBranch|, and with |force| may overwrite
+// |newBranch|, so both must be allowed by |protection|.
+func RenameBranch(ctx context.Context, dbData env.DbData, oldBranch, newBranch string, remoteDbPro env.RemoteDbProvider, force bool, protection doltdb.BranchProtection) error {
 	oldRef := ref.NewBranchRef(oldBranch)
 	newRef := ref.NewBranchRef(newBranch)
 
-	err := CopyBranchOnDB(ctx, dbData.Ddb, oldBranch, newBranch, force)
+	err := protection.CheckBranchUpdate(ctx, oldBranch, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	err = CopyBranchOnDB(ctx, dbData.Ddb, oldBranch, newBranch, force, protection)
 	if err != nil {
 		return err
 	}
@@ -66,14 +73,16 @@ func RenameBranch(ctx context.Context, dbData env.DbData, oldBranch, newBranch s
 		}
 	}
 
-	return DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true}, remoteDbPro)
+	return DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true, Protection: protection}, remoteDbPro)
 }
 
 func CopyBranch(ctx context.Context, dEnv *env.DoltEnv, oldBranch, newBranch string, force bool) error {
-	return CopyBranchOnDB(ctx, dEnv.DoltDB, oldBranch, newBranch, force)
+	return CopyBranchOnDB(ctx, dEnv.DoltDB, oldBranch, newBranch, force, env.GetBranchProtection(dEnv.Config))
 }
 
-func CopyBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, oldBranch, newBranch string, force bool) error {
+// CopyBranchOnDB creates |newBranch| at the commit of |oldBranch|. With |force|, an existing |newBranch| is
+// overwritten if |protection| allows moving it to that commit.
+func CopyBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, oldBranch, newBranch string, force bool, protection doltdb.BranchProtection) error {
 	oldRef := ref.NewBranchRef(oldBranch)
 	newRef := ref.NewBranchRef(newBranch)
 
@@ -104,12 +113,35 @@ func CopyBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, oldBranch, newBranc
 		return err
 	}
 
+	if err = checkBranchOverwrite(ctx, ddb, newRef, hasNew, cm, protection); err != nil {
+		return err
+	}
+
 	return ddb.NewBranchAtCommit(ctx, newRef, cm)
 }
 
+// checkBranchOverwrite returns an error if |protection| doesn't allow pointing |branchRef|, which exists if |exists| is
+// true, at |cm|.
+func checkBranchOverwrite(ctx context.Context, ddb *doltdb.DoltDB, branchRef ref.DoltRef, exists bool, cm *doltdb.Commit, protection doltdb.BranchProtection) error {
+	if protection.IsEmpty() {
+		return nil
+	}
+	var from *doltdb.Commit
+	if exists {
+		var err error
+		from, err = ddb.ResolveCommitRef(ctx, branchRef)
+		if err != nil {
+			return err
+		}
+	}
+	return protection.CheckBranchUpdate(ctx, branchRef.GetPath(), from, cm)
+}
+
 type DeleteOptions struct {
 	Force  bool
 	Remote bool
+	// Protection, if it protects the branch, makes the delete fail even if it's forced.
+	Protection doltdb.BranchProtection
 }
 
 func DeleteBranch(ctx context.Context, dbData env.DbData, brName string, opts DeleteOptions, remoteDbPro env.RemoteDbProvider) error {
@@ -140,24 +172,17 @@ func DeleteBranchOnDB(ctx context.Context, dbdata env.DbData, branchRef ref.Dolt
 		return doltdb.ErrBranchNotFound
 	}
 
-	if !opts.Force && !opts.Remote {
-		// check to see if the branch is fully merged into its parent
-		trackedBranches, err := dbdata.Rsr.GetBranches()
+	if !opts.Remote {
+		err = opts.Protection.CheckBranchUpdate(ctx, branchRef.GetPath(), nil, nil)
 		if err != nil {
 			return err
 		}
+	}
 
-		trackedBranch, hasUpstream := trackedBranches[branchRef.GetPath()]
-		if hasUpstream {
-			err = validateBranchMergedIntoUpstream(ctx, dbdata, branchRef, trackedBranch.Remote, pro)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = validateBranchMergedIntoCurrentWorkingBranch(ctx, dbdata, branchRef)
-			if err != nil {
-				return err
-			}
+	if !opts.Force && !opts.Remote {
+		err = validateBranchMerged(ctx, dbdata, branchRef, pro)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -176,6 +201,21 @@ func DeleteBranchOnDB(ctx context.Context, dbdata env.DbData, branchRef ref.Dolt
 	return ddb.DeleteBranch(ctx, branchRef)
 }
 
+// validateBranchMerged returns an error if the branch given is not fully merged into its upstream branch, or into the
+// current branch if it has no upstream.
+func validateBranchMerged(ctx context.Context, dbdata env.DbData, branchRef ref.DoltRef, pro env.RemoteDbProvider) error {
+	trackedBranches, err := dbdata.Rsr.GetBranches()
+	if err != nil {
+		return err
+	}
+
+	trackedBranch, hasUpstream := trackedBranches[branchRef.GetPath()]
+	if hasUpstream {
+		return validateBranchMergedIntoUpstream(ctx, dbdata, branchRef, trackedBranch.Remote, pro)
+	}
+	return validateBranchMergedIntoCurrentWorkingBranch(ctx, dbdata, branchRef)
+}
+
 // validateBranchMergedIntoCurrentWorkingBranch returns an error if the given branch is not fully merged into the HEAD of the current branch.
 func validateBranchMergedIntoCurrentWorkingBranch(ctx context.Context, dbdata env.DbData, branch ref.DoltRef) error {
 	branchSpec, err := doltdb.NewCommitSpec(branch.GetPath())
@@ -267,11 +307,15 @@ func validateBranchMergedIntoUpstream(ctx context.Context, dbdata env.DbData, br
 	return nil
 }
 
-func CreateBranchWithStartPt(ctx context.Context, dbData env.DbData, newBranch, startPt string, force bool) error {
-	err := createBranch(ctx, dbData, newBranch, startPt, force)
+// CreateBranchWithStartPt creates |newBranch| at |startPt|. With |force|, an existing |newBranch| is overwritten if
+// |protection| allows moving it to |startPt|.
+func CreateBranchWithStartPt(ctx context.Context, dbData env.DbData, newBranch, startPt string, force bool, protection doltdb.BranchProtection) error {
+	err := createBranch(ctx, dbData, newBranch, startPt, force, protection)
 
 	if err != nil {
-		if err == ErrAlreadyExists {
+		if errors.Is(err, doltdb.ErrProtectedBranch) {
+			return err
+		} else if err == ErrAlreadyExists {
 			return fmt.Errorf("fatal: A branch named '%s' already exists.", newBranch)
 		} else if err == doltdb.ErrInvBranchName {
 			return fmt.Errorf("fatal: '%s' is an invalid branch name.", newBranch)
@@ -289,7 +333,7 @@ func CreateBranchWithStartPt(ctx context.Context, dbData env.DbData, newBranch,
 	return nil
 }
 
-func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, startingPoint string, force bool, headRef ref.DoltRef) error {
+func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, startingPoint string, force bool, headRef ref.DoltRef, protection doltdb.BranchProtection) error {
 	branchRef := ref.NewBranchRef(newBranch)
 	hasRef, err := ddb.HasRef(ctx, branchRef)
 	if err != nil {
@@ -314,6 +358,11 @@ func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, starti
 		return err
 	}
 
+	err = checkBranchOverwrite(ctx, ddb, branchRef, hasRef, cm, protection)
+	if err != nil {
+		return err
+	}
+
 	err = ddb.NewBranchAtCommit(ctx, branchRef, cm)
 	if err != nil {
 		return err
@@ -322,8 +371,8 @@ func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, starti
 	return nil
 }
 
-func createBranch(ctx context.Context, dbData env.DbData, newBranch, startingPoint string, force bool) error {
-	return CreateBranchOnDB(ctx, dbData.Ddb, newBranch, startingPoint, force, dbData.Rsr.CWBHeadRef())
+func createBranch(ctx context.Context, dbData env.DbData, newBranch, startingPoint string, force bool, protection doltdb.BranchProtection) error {
+	return CreateBranchOnDB(ctx, dbData.Ddb, newBranch, startingPoint, force, dbData.Rsr.CWBHeadRef(), protection)
 }
 
 var emptyHash = hash.Hash{}
@@ -0,0 +1,298 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+const (
+	BranchOpCreate = "create"
+	BranchOpDelete = "delete"
+	BranchOpRename = "rename"
+)
+
+// BranchOp is one of the operations of a bulk branch update made by ApplyBranchOps. Lists of operations are written
+// as JSON arrays, e.g. [{"op": "create", "branch": "b1", "start_point": "main"}, {"op": "delete", "branch": "b2"}].
+type BranchOp struct {
+	// Op is one of BranchOpCreate, BranchOpDelete or BranchOpRename
+	Op string `json:"op"`
+	// Branch is the branch created, deleted or renamed
+	Branch string `json:"branch"`
+	// NewBranch is the new name of a renamed branch
+	NewBranch string `json:"new_branch,omitempty"`
+	// StartPoint is the commit a created branch points at, HEAD if it's empty
+	StartPoint string `json:"start_point,omitempty"`
+	// Force overwrites an existing branch when creating or renaming a branch, and deletes branches that aren't
+	// fully merged
+	Force bool `json:"force,omitempty"`
+}
+
+// ParseBranchOps parses the JSON array of branch operations |data|.
+func ParseBranchOps(data []byte) ([]BranchOp, error) {
+	var ops []BranchOp
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ops); err != nil {
+		return nil, fmt.Errorf("invalid branch operations: %w", err)
+	}
+
+	for i, op := range ops {
+		switch op.Op {
+		case BranchOpCreate, BranchOpDelete:
+		case BranchOpRename:
+			if op.NewBranch == "" {
+				return nil, fmt.Errorf("invalid branch operation %d: new_branch is required to rename a branch", i)
+			}
+		default:
+			return nil, fmt.Errorf("invalid branch operation %d: unknown op '%s'", i, op.Op)
+		}
+		if op.Branch == "" {
+			return nil, fmt.Errorf("invalid branch operation %d: branch is required", i)
+		}
+	}
+	return ops, nil
+}
+
+// ApplyBranchOps applies |ops| in order, and then updates every branch they changed at once: either all the
+// operations succeed or no branch is changed. Each operation sees the changes of the operations before it, so a
+// branch can be created and renamed in the same list. Created and renamed branches keep the working sets they would
+// have after the equivalent dolt branch commands.
+func ApplyBranchOps(ctx context.Context, dbData env.DbData, ops []BranchOp, pro env.RemoteDbProvider) error {
+	b := &bulkBranches{
+		dbData:   dbData,
+		pro:      pro,
+		head:     dbData.Rsr.CWBHeadRef(),
+		branches: make(map[string]*bulkBranch),
+	}
+	for i, op := range ops {
+		if err := b.apply(ctx, op); err != nil {
+			return fmt.Errorf("branch operation %d (%s '%s') failed: %w", i, op.Op, op.Branch, err)
+		}
+	}
+
+	branches, err := dbData.Ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	count := len(branches)
+	var updates []doltdb.BranchUpdate
+	var added []string
+	for _, name := range b.order {
+		br := b.branches[name]
+		if br.stored || (!br.existed && br.commit == nil) {
+			continue
+		}
+		if br.existed && br.commit == nil {
+			count--
+		} else if !br.existed {
+			count++
+		}
+		if br.commit != nil {
+			added = append(added, name)
+		}
+		updates = append(updates, doltdb.BranchUpdate{Branch: ref.NewBranchRef(name), Commit: br.commit, WorkingSet: br.ws})
+	}
+	if count == 0 {
+		return doltdb.ErrCannotDeleteLastBranch
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err = dbData.Ddb.UpdateBranches(ctx, updates); err != nil {
+		return err
+	}
+	if !ref.Equals(b.head, dbData.Rsr.CWBHeadRef()) {
+		if err = dbData.Rsw.SetCWBHeadRef(ctx, ref.MarshalableRef{Ref: b.head}); err != nil {
+			return err
+		}
+	}
+	for _, name := range added {
+		if err = branch_control.AddAdminForContext(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkBranch is the state of a branch while a list of branch operations is applied.
+type bulkBranch struct {
+	// commit is the head of the branch, or nil if the branch doesn't exist
+	commit *doltdb.Commit
+	// ws is the working set of a renamed branch. The working sets of other branches are reset to |commit|.
+	ws *doltdb.WorkingSet
+	// existed is whether the branch exists in the database, and stored whether it's still unchanged
+	existed, stored bool
+}
+
+type bulkBranches struct {
+	dbData env.DbData
+	pro    env.RemoteDbProvider
+	// head is the checked out branch, which follows renames
+	head     ref.DoltRef
+	branches map[string]*bulkBranch
+	// order is the order in which |branches| were first used
+	order []string
+}
+
+// get returns the current state of the branch |name|.
+func (b *bulkBranches) get(ctx context.Context, name string) (*bulkBranch, error) {
+	if br, ok := b.branches[name]; ok {
+		return br, nil
+	}
+
+	br := &bulkBranch{}
+	branchRef := ref.NewBranchRef(name)
+	ok, err := b.dbData.Ddb.HasRef(ctx, branchRef)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		br.commit, err = b.dbData.Ddb.ResolveCommitRef(ctx, branchRef)
+		if err != nil {
+			return nil, err
+		}
+		br.existed, br.stored = true, true
+	}
+	b.branches[name] = br
+	b.order = append(b.order, name)
+	return br, nil
+}
+
+func (b *bulkBranches) apply(ctx context.Context, op BranchOp) error {
+	switch op.Op {
+	case BranchOpCreate:
+		return b.create(ctx, op)
+	case BranchOpDelete:
+		return b.delete(ctx, op)
+	case BranchOpRename:
+		return b.rename(ctx, op)
+	default:
+		return fmt.Errorf("unknown op '%s'", op.Op)
+	}
+}
+
+func (b *bulkBranches) create(ctx context.Context, op BranchOp) error {
+	if !doltdb.IsValidUserBranchName(op.Branch) {
+		return doltdb.ErrInvBranchName
+	}
+	br, err := b.get(ctx, op.Branch)
+	if err != nil {
+		return err
+	}
+	if br.commit != nil && !op.Force {
+		return ErrAlreadyExists
+	}
+
+	startPt := op.StartPoint
+	if startPt == "" {
+		startPt = "HEAD"
+	}
+	var cm *doltdb.Commit
+	if sp, ok := b.branches[startPt]; ok && !sp.stored {
+		// the start point is a branch changed by an earlier operation
+		if sp.commit == nil {
+			return doltdb.ErrBranchNotFound
+		}
+		cm = sp.commit
+	} else {
+		cs, err := doltdb.NewCommitSpec(startPt)
+		if err != nil {
+			return err
+		}
+		cm, err = b.dbData.Ddb.Resolve(ctx, cs, b.head)
+		if err != nil {
+			return err
+		}
+	}
+
+	br.commit, br.ws, br.stored = cm, nil, false
+	return nil
+}
+
+func (b *bulkBranches) delete(ctx context.Context, op BranchOp) error {
+	br, err := b.get(ctx, op.Branch)
+	if err != nil {
+		return err
+	}
+	if br.commit == nil {
+		return doltdb.ErrBranchNotFound
+	}
+	branchRef := ref.NewBranchRef(op.Branch)
+	if ref.Equals(b.head, branchRef) {
+		return ErrCOBranchDelete
+	}
+	if !op.Force && br.stored {
+		if err = validateBranchMerged(ctx, b.dbData, branchRef, b.pro); err != nil {
+			return err
+		}
+	}
+
+	br.commit, br.ws, br.stored = nil, nil, false
+	return nil
+}
+
+func (b *bulkBranches) rename(ctx context.Context, op BranchOp) error {
+	if !doltdb.IsValidUserBranchName(op.NewBranch) {
+		return doltdb.ErrInvBranchName
+	}
+	old, err := b.get(ctx, op.Branch)
+	if err != nil {
+		return err
+	}
+	if old.commit == nil {
+		return doltdb.ErrBranchNotFound
+	}
+	if op.NewBranch == op.Branch {
+		return nil
+	}
+	br, err := b.get(ctx, op.NewBranch)
+	if err != nil {
+		return err
+	}
+	if br.commit != nil && !op.Force {
+		return ErrAlreadyExists
+	}
+
+	ws := old.ws
+	if old.stored {
+		wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef(op.Branch))
+		if err != nil {
+			return err
+		}
+		ws, err = b.dbData.Ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			ws = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	br.commit, br.ws, br.stored = old.commit, ws, false
+	old.commit, old.ws, old.stored = nil, nil, false
+	if ref.Equals(b.head, ref.NewBranchRef(op.Branch)) {
+		b.head = ref.NewBranchRef(op.NewBranch)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"sort"
 	"sync"
@@ -35,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -178,6 +180,50 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
 	}
 
+	return checkoutClonedBranch(ctx, remoteName, branch, dEnv)
+}
+
+// CloneRemoteFromArchive clones |srcDB| into |dEnv| like CloneRemote, but first seeds |dEnv| with the archive read from
+// |archive|, so that only the chunks written to |srcDB| since the archive was created are fetched from it. See
+// doltdb.DoltDB.WriteArchive.
+func CloneRemoteFromArchive(ctx context.Context, archive io.Reader, srcDB *doltdb.DoltDB, remoteName, branch string, dEnv *env.DoltEnv) error {
+	err := dEnv.DoltDB.ReadArchive(ctx, archive)
+	if err != nil {
+		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+	}
+
+	srcRoot, err := srcDB.NomsRoot(ctx)
+	if err != nil {
+		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+	}
+	archiveRoot, err := dEnv.DoltDB.NomsRoot(ctx)
+	if err != nil {
+		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+	}
+
+	if srcRoot != archiveRoot {
+		tmpDir, err := dEnv.TempTableFilesDir()
+		if err != nil {
+			return err
+		}
+		err = dEnv.DoltDB.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{srcRoot}, nil)
+		if err != nil && err != pull.ErrDBUpToDate {
+			return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+		}
+		ok, err := dEnv.DoltDB.CommitRoot(ctx, srcRoot, archiveRoot)
+		if err != nil {
+			return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+		} else if !ok {
+			return fmt.Errorf("%w; database changed while cloning", ErrCloneFailed)
+		}
+	}
+
+	return checkoutClonedBranch(ctx, remoteName, branch, dEnv)
+}
+
+// checkoutClonedBranch turns the branches of a newly cloned database into remote refs of |remoteName|, except for
+// |branch|, and checks out |branch|.
+func checkoutClonedBranch(ctx context.Context, remoteName, branch string, dEnv *env.DoltEnv) error {
 	branches, err := dEnv.DoltDB.GetBranches(ctx)
 	if err != nil {
 		return fmt.Errorf("%w; %s", ErrFailedToListBranches, err.Error())
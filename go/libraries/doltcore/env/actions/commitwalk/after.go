@@ -0,0 +1,209 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitwalk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+)
+
+// GetTopologicalOrderIteratorAfter returns an iterator for the commits GetTopologicalOrderIterator returns for
+// |startCommitHash| which come after the commit |after|. |after| doesn't have to be reachable from |startCommitHash|,
+// so a page of a log can be continued from its last commit even if the log has changed since.
+//
+// Rather than walking every commit before |after|, the iterator seeks to the height of |after| in the commit closure
+// of |startCommitHash|, and lists the closure one height at a time from there.
+func GetTopologicalOrderIteratorAfter(ctx context.Context, ddb *doltdb.DoltDB, startCommitHash hash.Hash, after hash.Hash, matchFn func(*doltdb.Commit) (bool, error)) (doltdb.CommitItr, error) {
+	start, err := load(ctx, ddb, startCommitHash)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := start.GetCommitClosure(ctx)
+	if err != nil {
+		// the old format has no commit closures, so its commits are walked until |after|
+		itr, err := GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{startCommitHash}, matchFn)
+		if err != nil {
+			return nil, err
+		}
+		return GetCommitsAfter(ctx, ddb, itr, after)
+	}
+
+	itr := &closureCommiterator{
+		ddb:     ddb,
+		closure: cc,
+		matchFn: matchFn,
+	}
+	if itr.start, err = newC(ctx, ddb, startCommitHash, start); err != nil {
+		return nil, err
+	}
+	afterCommit, err := load(ctx, ddb, after)
+	if err != nil {
+		return nil, err
+	}
+	if itr.after, err = newC(ctx, ddb, after, afterCommit); err != nil {
+		return nil, err
+	}
+	if err = itr.Reset(ctx); err != nil {
+		return nil, err
+	}
+	return itr, nil
+}
+
+// GetCommitsAfter returns an iterator for the commits of |itr| which come after the commit |after|. |itr| must list
+// commits in the order of the topological order iterators of this package, and |after| doesn't have to be one of
+// them. Every commit of |itr| before |after| is still walked, so GetTopologicalOrderIteratorAfter should be preferred
+// when there's a single start commit.
+func GetCommitsAfter(ctx context.Context, ddb *doltdb.DoltDB, itr doltdb.CommitItr, after hash.Hash) (doltdb.CommitItr, error) {
+	afterCommit, err := load(ctx, ddb, after)
+	if err != nil {
+		return nil, err
+	}
+	afterC, err := newC(ctx, ddb, after, afterCommit)
+	if err != nil {
+		return nil, err
+	}
+	return &afterCommiterator{child: itr, after: afterC}, nil
+}
+
+type afterCommiterator struct {
+	child doltdb.CommitItr
+	after *c
+	found bool
+}
+
+var _ doltdb.CommitItr = (*afterCommiterator)(nil)
+
+// Next implements doltdb.CommitItr
+func (i *afterCommiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, error) {
+	for {
+		h, cm, err := i.child.Next(ctx)
+		if err != nil {
+			return hash.Hash{}, nil, err
+		}
+		if i.found {
+			return h, cm, nil
+		}
+
+		next, err := newC(ctx, i.after.ddb, h, cm)
+		if err != nil {
+			return hash.Hash{}, nil, err
+		}
+		if i.after.before(next) {
+			i.found = true
+			return h, cm, nil
+		}
+	}
+}
+
+// Reset implements doltdb.CommitItr
+func (i *afterCommiterator) Reset(ctx context.Context) error {
+	i.found = false
+	return i.child.Reset(ctx)
+}
+
+// closureCommiterator lists the commit |start| and the commits of its closure which come after the commit |after|.
+// The commits of a height are loaded together and sorted, and heights are listed from the highest one down.
+type closureCommiterator struct {
+	ddb     *doltdb.DoltDB
+	closure prolly.CommitClosure
+	start   *c
+	after   *c
+	matchFn func(*doltdb.Commit) (bool, error)
+
+	// height is the next height of the closure to load
+	height  uint64
+	pending []*c
+}
+
+var _ doltdb.CommitItr = (*closureCommiterator)(nil)
+
+// Next implements doltdb.CommitItr
+func (i *closureCommiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, error) {
+	for {
+		for len(i.pending) == 0 {
+			if i.height == 0 {
+				return hash.Hash{}, nil, io.EOF
+			}
+			if err := i.loadHeight(ctx, i.height); err != nil {
+				return hash.Hash{}, nil, err
+			}
+			i.height--
+		}
+
+		next := i.pending[0]
+		i.pending = i.pending[1:]
+		if i.matchFn != nil {
+			matches, err := i.matchFn(next.commit)
+			if err != nil {
+				return hash.Hash{}, nil, err
+			}
+			if !matches {
+				continue
+			}
+		}
+		return next.hash, next.commit, nil
+	}
+}
+
+func (i *closureCommiterator) loadHeight(ctx context.Context, height uint64) error {
+	iter, err := i.closure.IterHeight(ctx, height)
+	if err != nil {
+		return err
+	}
+	for {
+		k, _, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		cm, err := load(ctx, i.ddb, k.Addr())
+		if err != nil {
+			return err
+		}
+		next, err := newC(ctx, i.ddb, k.Addr(), cm)
+		if err != nil {
+			return err
+		}
+		if i.after.before(next) {
+			i.pending = append(i.pending, next)
+		}
+	}
+	sort.Slice(i.pending, func(a, b int) bool {
+		return i.pending[a].before(i.pending[b])
+	})
+	return nil
+}
+
+// Reset implements doltdb.CommitItr
+func (i *closureCommiterator) Reset(ctx context.Context) error {
+	// the closure doesn't include the start commit, which is higher than every commit in it
+	i.pending = nil
+	if i.after.before(i.start) {
+		i.pending = append(i.pending, i.start)
+	}
+	i.height = i.start.height - 1
+	if i.after.height < i.height {
+		i.height = i.after.height
+	}
+	return nil
+}
@@ -64,13 +64,19 @@ func (q *q) Swap(i, j int) {
 }
 
 func (q *q) Less(i, j int) bool {
-	if q.pending[i].height > q.pending[j].height {
-		return true
+	return q.pending[i].before(q.pending[j])
+}
+
+// before returns whether |cm| comes before |other| in a log. Higher commits come first, then newer commits, and the
+// remaining ties are broken by hash, so that commits are walked in the same order every time.
+func (cm *c) before(other *c) bool {
+	if cm.height != other.height {
+		return cm.height > other.height
 	}
-	if q.pending[i].height == q.pending[j].height {
-		return q.pending[i].meta.UserTimestamp > q.pending[j].meta.UserTimestamp
+	if cm.meta.UserTimestamp != other.meta.UserTimestamp {
+		return cm.meta.UserTimestamp > other.meta.UserTimestamp
 	}
-	return false
+	return cm.hash.Less(other.hash)
 }
 
 func (q *q) PopPending() *c {
@@ -131,18 +137,24 @@ func (q *q) Get(ctx context.Context, ddb *doltdb.DoltDB, id hash.Hash) (*c, erro
 	if err != nil {
 		return nil, err
 	}
-	h, err := l.Height()
+	c, err := newC(ctx, ddb, id, l)
+	if err != nil {
+		return nil, err
+	}
+	q.loaded[id] = c
+	return c, nil
+}
+
+func newC(ctx context.Context, ddb *doltdb.DoltDB, id hash.Hash, commit *doltdb.Commit) (*c, error) {
+	h, err := commit.Height()
 	if err != nil {
 		return nil, err
 	}
-	meta, err := l.GetCommitMeta(ctx)
+	meta, err := commit.GetCommitMeta(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	c := &c{ddb: ddb, commit: l, meta: meta, height: h, hash: id}
-	q.loaded[id] = c
-	return c, nil
+	return &c{ddb: ddb, commit: commit, meta: meta, height: h, hash: id}, nil
 }
 
 func newQueue() *q {
@@ -155,7 +167,8 @@ func newQueue() *q {
 // to `num` commits, in reverse topological order starting at `includedHeads`,
 // with tie breaking based on the height of commit graph between
 // concurrent commits --- higher commits appear first. Remaining
-// ties are broken by timestamp; newer commits appear first.
+// ties are broken by timestamp; newer commits appear first, and then
+// by hash.
 //
 // Roughly mimics `git log main..feature` or `git log main...feature` (if
 // more than one `includedHead` is provided).
@@ -182,7 +195,7 @@ func GetDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, included
 
 // GetTopologicalOrderCommits returns the commits reachable from the commits in `startCommitHashes`
 // in reverse topological order, with tiebreaking done by the height of the commit graph -- higher commits
-// appear first. Remaining ties are broken by timestamp; newer commits appear first.
+// appear first. Remaining ties are broken by timestamp; newer commits appear first, and then by hash.
 func GetTopologicalOrderCommits(ctx context.Context, ddb *doltdb.DoltDB, startCommitHashes []hash.Hash) ([]*doltdb.Commit, error) {
 	return GetTopNTopoOrderedCommitsMatching(ctx, ddb, startCommitHashes, -1, nil)
 }
@@ -264,7 +277,7 @@ func (i *commiterator) Reset(ctx context.Context) error {
 
 // GetTopNTopoOrderedCommitsMatching returns the first N commits (If N <= 0 then all commits) reachable from the commits in
 // `startCommitHashes` in reverse topological order, with tiebreaking done by the height of the commit graph -- higher
-// commits appear first. Remaining ties are broken by timestamp; newer commits appear first.
+// commits appear first. Remaining ties are broken by timestamp; newer commits appear first, and then by hash.
 func GetTopNTopoOrderedCommitsMatching(ctx context.Context, ddb *doltdb.DoltDB, startCommitHashes []hash.Hash, n int, matchFn func(*doltdb.Commit) (bool, error)) ([]*doltdb.Commit, error) {
 	itr, err := GetTopologicalOrderIterator(ctx, ddb, startCommitHashes, matchFn)
 	if err != nil {
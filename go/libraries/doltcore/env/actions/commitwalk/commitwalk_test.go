@@ -16,6 +16,8 @@ package commitwalk
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -283,3 +285,98 @@ func mustGetHash(t *testing.T, c *doltdb.Commit) hash.Hash {
 	require.NoError(t, err)
 	return h
 }
+
+func TestGetTopologicalOrderIteratorAfter(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createUninitializedEnv()
+	err := dEnv.InitRepo(ctx, types.Format_Default, "Bill Billerson", "bill@billerson.com", env.DefaultInitBranch)
+	require.NoError(t, err)
+
+	cs, err := doltdb.NewCommitSpec(env.DefaultInitBranch)
+	require.NoError(t, err)
+	first, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	rv, err := first.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, rvh, err := dEnv.DoltDB.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+
+	// Every commit has the same timestamp, and branches are merged back and forth, so that commits are often tied on
+	// height and timestamp.
+	ts := time.UnixMilli(1672531200000)
+	n := 0
+	commit := func(bn string, parents ...*doltdb.Commit) *doltdb.Commit {
+		n++
+		cm, err := datas.NewCommitMetaWithUserTS("Bill Billerson", "bill@billerson.com", fmt.Sprintf("commit %d", n), ts)
+		require.NoError(t, err)
+		pcs := make([]*doltdb.CommitSpec, 0, len(parents))
+		for _, parent := range parents {
+			cs, err := doltdb.NewCommitSpec(mustGetHash(t, parent).String())
+			require.NoError(t, err)
+			pcs = append(pcs, cs)
+		}
+		c, err := dEnv.DoltDB.CommitWithParentSpecs(ctx, rvh, ref.NewBranchRef(bn), pcs, cm)
+		require.NoError(t, err)
+		return c
+	}
+
+	mainHead := commit(env.DefaultInitBranch, first)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("a"), mainHead))
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("b"), mainHead))
+	a, b := mainHead, mainHead
+	for i := 0; i < 5; i++ {
+		a = commit("a", a)
+		b = commit("b", b)
+		a = commit("a", a)
+		mainHead = commit(env.DefaultInitBranch, mainHead, a, b)
+		b = commit("b", b, mainHead)
+	}
+	other := commit("a", a)
+
+	head := mustGetHash(t, mainHead)
+	log, err := GetTopologicalOrderCommits(ctx, dEnv.DoltDB, []hash.Hash{head})
+	require.NoError(t, err)
+	again, err := GetTopologicalOrderCommits(ctx, dEnv.DoltDB, []hash.Hash{head})
+	require.NoError(t, err)
+	require.Equal(t, commitHashes(t, log), commitHashes(t, again))
+
+	walk := func(itr doltdb.CommitItr) []hash.Hash {
+		hashes := []hash.Hash{}
+		for {
+			h, _, err := itr.Next(ctx)
+			if err == io.EOF {
+				return hashes
+			}
+			require.NoError(t, err)
+			hashes = append(hashes, h)
+		}
+	}
+
+	for i, c := range log {
+		expected := commitHashes(t, log[i+1:])
+		itr, err := GetTopologicalOrderIteratorAfter(ctx, dEnv.DoltDB, head, mustGetHash(t, c), nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, walk(itr))
+
+		itr, err = GetTopologicalOrderIterator(ctx, dEnv.DoltDB, []hash.Hash{head}, nil)
+		require.NoError(t, err)
+		itr, err = GetCommitsAfter(ctx, dEnv.DoltDB, itr, mustGetHash(t, c))
+		require.NoError(t, err)
+		assert.Equal(t, expected, walk(itr))
+	}
+
+	// a commit which isn't in the log continues it from where it would be
+	itr, err := GetTopologicalOrderIteratorAfter(ctx, dEnv.DoltDB, head, mustGetHash(t, other), nil)
+	require.NoError(t, err)
+	hashes := walk(itr)
+	require.NotEmpty(t, hashes)
+	assert.Equal(t, commitHashes(t, log[len(log)-len(hashes):]), hashes)
+}
+
+func commitHashes(t *testing.T, commits []*doltdb.Commit) []hash.Hash {
+	hashes := make([]hash.Hash, len(commits))
+	for i, c := range commits {
+		hashes[i] = mustGetHash(t, c)
+	}
+	return hashes
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+)
+
+// StartTransferRecord starts recording a transfer of kind |op| with the remote named |remote| in the fetch history of
+// |rsw|. It returns the option to create the Pullers of the transfer with, so that their totals are recorded, and the
+// function to call with the outcome of the transfer once it's done. Nothing is recorded if |rsw| doesn't keep a fetch
+// history.
+func StartTransferRecord(rsw env.RepoStateWriter, op env.TransferOperation, remote string) (pull.Option, func(error)) {
+	hw, ok := rsw.(env.FetchHistoryWriter)
+	if !ok {
+		return pull.WithTransferTotals(nil), func(error) {}
+	}
+
+	totals := &pull.TransferTotals{}
+	start := time.Now()
+	return pull.WithTransferTotals(totals), func(err error) {
+		entry := env.FetchHistoryEntry{
+			Operation: op,
+			Remote:    remote,
+			StartTime: start.UTC(),
+			Duration:  time.Since(start),
+			Bytes:     totals.FetchedBytes(),
+			Chunks:    totals.FetchedChunks(),
+		}
+		if op == env.TransferPush {
+			entry.Bytes = totals.SentBytes()
+		}
+		if err != nil && !errors.Is(err, doltdb.ErrUpToDate) && !errors.Is(err, pull.ErrDBUpToDate) {
+			entry.Error = err.Error()
+		}
+
+		// failing to record the transfer doesn't fail it
+		_ = hw.AppendFetchHistory(entry)
+	}
+}
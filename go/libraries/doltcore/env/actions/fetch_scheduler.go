@@ -0,0 +1,255 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// FetchTarget is a database whose remotes are fetched by a FetchScheduler.
+type FetchTarget struct {
+	Name   string
+	DbData env.DbData
+}
+
+// RemoteDBFunc returns the database of |remote|, to fetch from into a database of format |nbf|.
+type RemoteDBFunc func(ctx context.Context, nbf *types.NomsBinFormat, remote env.Remote) (*doltdb.DoltDB, error)
+
+// RemoteFetchStatus is the outcome of the fetches of one remote of one database by a FetchScheduler.
+type RemoteFetchStatus struct {
+	Database     string
+	Remote       string
+	LastAttempt  time.Time
+	LastSuccess  time.Time
+	LastDuration time.Duration
+	// LastError is the error of the last fetch, or empty if it succeeded
+	LastError string
+	Successes uint64
+	Failures  uint64
+}
+
+type fetchStatusKey struct {
+	db, remote string
+}
+
+// FetchScheduler periodically fetches the remotes of a set of databases, updating their remote tracking refs, so
+// that they are up to date when they're used. It keeps the outcome of the last fetch of every remote.
+type FetchScheduler struct {
+	interval time.Duration
+	// remotes are the names of the remotes to fetch, or empty to fetch every remote
+	remotes  map[string]struct{}
+	targets  func() []FetchTarget
+	remoteDB RemoteDBFunc
+	startJob func(db string) (func(), error)
+	pullOpts []pull.Option
+
+	mu     sync.Mutex
+	status map[fetchStatusKey]*RemoteFetchStatus
+	now    func() time.Time
+}
+
+// NewFetchScheduler returns a FetchScheduler that fetches the remotes named |remotes| of the databases returned by
+// |targets| every |interval|. Every remote of each database is fetched if |remotes| is empty. Databases without a
+// remote of one of the names are skipped.
+func NewFetchScheduler(interval time.Duration, remotes []string, targets func() []FetchTarget, remoteDB RemoteDBFunc) *FetchScheduler {
+	names := make(map[string]struct{}, len(remotes))
+	for _, r := range remotes {
+		names[r] = struct{}{}
+	}
+	return &FetchScheduler{
+		interval: interval,
+		remotes:  names,
+		targets:  targets,
+		remoteDB: remoteDB,
+		status:   make(map[fetchStatusKey]*RemoteFetchStatus),
+		now:      time.Now,
+	}
+}
+
+// WithJobHook returns the scheduler after setting |startJob| to be called before the remotes of each database are
+// fetched. If it returns an error, the database is not fetched, and the error is recorded as the outcome of the fetch
+// of each of its remotes. Otherwise, the function it returns is called once the database has been fetched.
+func (s *FetchScheduler) WithJobHook(startJob func(db string) (func(), error)) *FetchScheduler {
+	s.startJob = startJob
+	return s
+}
+
+// WithPullOptions returns the scheduler after setting |opts| to configure the pulls of every fetch.
+func (s *FetchScheduler) WithPullOptions(opts ...pull.Option) *FetchScheduler {
+	s.pullOpts = opts
+	return s
+}
+
+// Interval returns how often the scheduler fetches.
+func (s *FetchScheduler) Interval() time.Duration {
+	return s.interval
+}
+
+// Run fetches every target, then does so again every interval, until |ctx| is done.
+func (s *FetchScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.FetchAll(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			s.FetchAll(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// FetchAll fetches the remotes of every target once. Failures are recorded in the status of each remote, rather than
+// returned.
+func (s *FetchScheduler) FetchAll(ctx context.Context) {
+	for _, t := range s.targets() {
+		if ctx.Err() != nil {
+			return
+		}
+		s.fetchTarget(ctx, t)
+	}
+}
+
+func (s *FetchScheduler) fetchTarget(ctx context.Context, t FetchTarget) {
+	remotes, err := t.DbData.Rsr.GetRemotes()
+	if err != nil {
+		return
+	}
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		if _, ok := s.remotes[name]; ok || len(s.remotes) == 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	if s.startJob != nil {
+		done, err := s.startJob(t.Name)
+		if err != nil {
+			for _, name := range names {
+				s.record(t.Name, name, s.now(), err)
+			}
+			return
+		}
+		defer done()
+	}
+
+	for _, name := range names {
+		start := s.now()
+		s.record(t.Name, name, start, s.fetchRemote(ctx, t, remotes[name]))
+	}
+}
+
+func (s *FetchScheduler) fetchRemote(ctx context.Context, t FetchTarget, remote env.Remote) error {
+	refSpecs, err := env.GetRefSpecs(t.DbData.Rsr, remote.Name)
+	if err != nil {
+		return err
+	}
+	srcDB, err := s.remoteDB(ctx, t.DbData.Ddb.Format(), remote)
+	if err != nil {
+		return err
+	}
+	err = FetchRefSpecs(ctx, t.DbData, srcDB, refSpecs, remote, ref.UpdateMode{Force: true}, quietProgStarter, quietProgStopper, s.pullOpts...)
+	if err == doltdb.ErrUpToDate {
+		return nil
+	}
+	return err
+}
+
+func (s *FetchScheduler) record(db, remote string, start time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fetchStatusKey{db: strings.ToLower(db), remote: remote}
+	st, ok := s.status[key]
+	if !ok {
+		st = &RemoteFetchStatus{Database: db, Remote: remote}
+		s.status[key] = st
+	}
+	st.LastAttempt = start
+	st.LastDuration = s.now().Sub(start)
+	if err != nil {
+		st.LastError = err.Error()
+		st.Failures++
+	} else {
+		st.LastError = ""
+		st.LastSuccess = start
+		st.Successes++
+	}
+}
+
+// Status returns the outcome of the fetches of every remote of the database named |db| that the scheduler has
+// attempted, ordered by remote name.
+func (s *FetchScheduler) Status(db string) []RemoteFetchStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var statuses []RemoteFetchStatus
+	for key, st := range s.status {
+		if key.db == strings.ToLower(db) {
+			statuses = append(statuses, *st)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Remote < statuses[j].Remote
+	})
+	return statuses
+}
+
+// Forget discards the status of the remotes of the database named |db|, e.g. when it's dropped.
+func (s *FetchScheduler) Forget(db string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.status {
+		if key.db == strings.ToLower(db) {
+			delete(s.status, key)
+		}
+	}
+}
+
+// quietProgStarter discards the progress of background fetches, which have nowhere to report it.
+func quietProgStarter(ctx context.Context) (*sync.WaitGroup, chan pull.Stats) {
+	statsCh := make(chan pull.Stats)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range statsCh {
+		}
+	}()
+	return wg, statsCh
+}
+
+func quietProgStopper(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh chan pull.Stats) {
+	cancel()
+	close(statsCh)
+	wg.Wait()
+}
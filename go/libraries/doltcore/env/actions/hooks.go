@@ -0,0 +1,254 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// HookType is the point at which a hook runs. A database's script for a hook is the executable file named after its
+// type in the .dolt/hooks directory.
+type HookType string
+
+const (
+	// PreCommitHook runs before a commit is made, and blocks the commit if it fails.
+	PreCommitHook HookType = "pre-commit"
+	// PostCommitHook runs after a commit is made. Its failures are only reported.
+	PostCommitHook HookType = "post-commit"
+	// PrePushHook runs before a ref is pushed to a remote, and blocks the push if it fails.
+	PrePushHook HookType = "pre-push"
+	// PostResolveHook runs after conflicting rows are resolved by deleting them from a dolt_conflicts table. Its
+	// failures are only reported.
+	PostResolveHook HookType = "post-resolve"
+)
+
+// HooksDir is the directory of a database's hook scripts, relative to the database's directory.
+var HooksDir = filepath.Join(dbfactory.DoltDir, "hooks")
+
+// HookEvent describes what a hook is run for.
+type HookEvent struct {
+	Type     HookType
+	Database string
+	// Branch is the checked out branch the commit is made on, or the push is made from.
+	Branch string
+	// Message is the message of the commit, for commit hooks.
+	Message string
+	// Commit is the commit that was made, for post-commit hooks.
+	Commit hash.Hash
+	// Remote is the remote pushed to, for pre-push hooks.
+	Remote env.Remote
+	// Updates are the refs pushed, for pre-push hooks.
+	Updates []HookRefUpdate
+	// Resolutions are the conflicts resolved, for post-resolve hooks.
+	Resolutions []env.ConflictResolution
+}
+
+// HookRefUpdate is the update of a remote ref made by a push.
+type HookRefUpdate struct {
+	LocalRef string
+	// LocalHash is the commit pushed, or empty if the remote ref is deleted.
+	LocalHash hash.Hash
+	RemoteRef string
+	// RemoteHash is the commit the remote ref points at before the push, or empty if it doesn't exist.
+	RemoteHash hash.Hash
+}
+
+// HookFunc is a hook registered with RegisterHook. The output it returns is shown to the user. If a hook of a pre-
+// HookType returns an error, the commit or push is blocked.
+type HookFunc func(ctx context.Context, ev HookEvent) (output string, err error)
+
+// HookError is the error of a hook that failed.
+type HookError struct {
+	Type HookType
+	// Name is the name the hook was registered with, or the path of its script.
+	Name   string
+	Output string
+	Err    error
+}
+
+func (e *HookError) Error() string {
+	msg := fmt.Sprintf("%s hook %s failed: %s", e.Type, e.Name, e.Err.Error())
+	if out := strings.TrimSpace(e.Output); out != "" {
+		msg += "\n" + out
+	}
+	return msg
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+type registeredHook struct {
+	name string
+	f    HookFunc
+}
+
+var hookRegistry = struct {
+	mu    sync.Mutex
+	hooks map[HookType][]registeredHook
+}{hooks: make(map[HookType][]registeredHook)}
+
+// RegisterHook registers |f| to run as a hook of type |t| for every database, before the database's own script.
+// Registering a hook with the name of a hook already registered for |t| replaces it.
+func RegisterHook(t HookType, name string, f HookFunc) {
+	hookRegistry.mu.Lock()
+	defer hookRegistry.mu.Unlock()
+	unregisterHookLocked(t, name)
+	hookRegistry.hooks[t] = append(hookRegistry.hooks[t], registeredHook{name: name, f: f})
+}
+
+// UnregisterHook removes the hook of type |t| registered with |name|, if there is one.
+func UnregisterHook(t HookType, name string) {
+	hookRegistry.mu.Lock()
+	defer hookRegistry.mu.Unlock()
+	unregisterHookLocked(t, name)
+}
+
+func unregisterHookLocked(t HookType, name string) {
+	hooks := hookRegistry.hooks[t]
+	for i, h := range hooks {
+		if h.name == name {
+			hookRegistry.hooks[t] = append(hooks[:i:i], hooks[i+1:]...)
+			return
+		}
+	}
+}
+
+// RunHooks runs the registered hooks of the type of |ev|, then the script for it in the hooks directory of the
+// database whose directory is the working directory of |fs|, if |fs| isn't nil. It returns the output of every hook
+// that ran. The first hook that fails stops the rest from running, and its *HookError is returned.
+func RunHooks(ctx context.Context, fs filesys.Filesys, ev HookEvent) (string, error) {
+	hookRegistry.mu.Lock()
+	hooks := append([]registeredHook(nil), hookRegistry.hooks[ev.Type]...)
+	hookRegistry.mu.Unlock()
+
+	var output strings.Builder
+	for _, h := range hooks {
+		out, err := h.f(ctx, ev)
+		output.WriteString(out)
+		if err != nil {
+			return output.String(), &HookError{Type: ev.Type, Name: h.name, Output: out, Err: err}
+		}
+	}
+
+	if fs == nil {
+		return output.String(), nil
+	}
+	script, ok := hookScript(fs, ev.Type)
+	if !ok {
+		return output.String(), nil
+	}
+	out, err := runHookScript(ctx, fs, script, ev)
+	output.WriteString(out)
+	if err != nil {
+		return output.String(), &HookError{Type: ev.Type, Name: script, Output: out, Err: err}
+	}
+	return output.String(), nil
+}
+
+// hookScript returns the absolute path of the script for |t| in the database at |fs|, if it exists and is executable.
+func hookScript(fs filesys.Filesys, t HookType) (string, bool) {
+	path, err := fs.Abs(filepath.Join(HooksDir, string(t)))
+	if err != nil {
+		return "", false
+	}
+	// hook scripts can only be run from the local filesystem
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return "", false
+	}
+	return path, true
+}
+
+// runHookScript runs |script| in the database's directory, the way git runs its hooks. The event is described by
+// environment variables, and for pre-push hooks, the name and URL of the remote are its arguments and each ref it
+// updates is a line of its input: <local ref> <local hash> <remote ref> <remote hash>. For post-resolve hooks, each
+// conflict resolved is a line of its input: <table> <conflict id> <winner> <JSON primary key>.
+func runHookScript(ctx context.Context, fs filesys.Filesys, script string, ev HookEvent) (string, error) {
+	dir, err := fs.Abs(".")
+	if err != nil {
+		return "", err
+	}
+
+	var args []string
+	var stdin bytes.Buffer
+	if ev.Type == PrePushHook {
+		args = []string{ev.Remote.Name, ev.Remote.Url}
+		for _, u := range ev.Updates {
+			fmt.Fprintf(&stdin, "%s %s %s %s\n", u.LocalRef, u.LocalHash.String(), u.RemoteRef, u.RemoteHash.String())
+		}
+	}
+	for _, r := range ev.Resolutions {
+		fmt.Fprintf(&stdin, "%s %s %s %s\n", r.Table, r.ConflictID, r.Winner, string(r.Key))
+	}
+
+	cmd := exec.CommandContext(ctx, script, args...)
+	cmd.Dir = dir
+	cmd.Stdin = &stdin
+	cmd.Env = append(os.Environ(),
+		"DOLT_HOOK="+string(ev.Type),
+		"DOLT_DATABASE="+ev.Database,
+		"DOLT_BRANCH="+ev.Branch,
+	)
+	if ev.Message != "" {
+		cmd.Env = append(cmd.Env, "DOLT_COMMIT_MESSAGE="+ev.Message)
+	}
+	if !ev.Commit.IsEmpty() {
+		cmd.Env = append(cmd.Env, "DOLT_COMMIT="+ev.Commit.String())
+	}
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+	return out.String(), err
+}
+
+// PushHookUpdates returns the update made to the remote by pushing with |opts|, for the event of a pre-push hook.
+func PushHookUpdates(ctx context.Context, srcDB, destDB *doltdb.DoltDB, opts *env.PushOpts) ([]HookRefUpdate, error) {
+	u := HookRefUpdate{RemoteRef: opts.DestRef.String()}
+	if opts.SrcRef != ref.EmptyBranchRef {
+		u.LocalRef = opts.SrcRef.String()
+		h, err := srcDB.GetHashForRefStr(ctx, opts.SrcRef.String())
+		if err != nil {
+			return nil, err
+		}
+		u.LocalHash = *h
+	}
+	if ok, err := destDB.HasRef(ctx, opts.DestRef); err != nil {
+		return nil, err
+	} else if ok {
+		h, err := destDB.GetHashForRefStr(ctx, opts.DestRef.String())
+		if err != nil {
+			return nil, err
+		}
+		u.RemoteHash = *h
+	}
+	return []HookRefUpdate{u}, nil
+}
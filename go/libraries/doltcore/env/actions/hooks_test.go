@@ -0,0 +1,110 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestRegisteredHooks(t *testing.T) {
+	ctx := context.Background()
+	var ran []string
+	RegisterHook(PreCommitHook, "lint", func(ctx context.Context, ev HookEvent) (string, error) {
+		ran = append(ran, "lint")
+		return "linted " + ev.Message + "\n", nil
+	})
+	defer UnregisterHook(PreCommitHook, "lint")
+	RegisterHook(PreCommitHook, "validate", func(ctx context.Context, ev HookEvent) (string, error) {
+		ran = append(ran, "validate")
+		return "invalid data\n", errors.New("validation failed")
+	})
+	defer UnregisterHook(PreCommitHook, "validate")
+
+	out, err := RunHooks(ctx, nil, HookEvent{Type: PreCommitHook, Message: "msg"})
+	var hookErr *HookError
+	require.ErrorAs(t, err, &hookErr)
+	assert.Equal(t, "validate", hookErr.Name)
+	assert.Contains(t, err.Error(), "invalid data")
+	assert.Equal(t, "linted msg\ninvalid data\n", out)
+	assert.Equal(t, []string{"lint", "validate"}, ran)
+
+	// hooks of other types don't run
+	ran = nil
+	_, err = RunHooks(ctx, nil, HookEvent{Type: PostCommitHook})
+	require.NoError(t, err)
+	assert.Empty(t, ran)
+
+	UnregisterHook(PreCommitHook, "validate")
+	out, err = RunHooks(ctx, nil, HookEvent{Type: PreCommitHook, Message: "msg"})
+	require.NoError(t, err)
+	assert.Equal(t, "linted msg\n", out)
+}
+
+func TestHookScripts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts are shell scripts")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	fs, err := filesys.LocalFS.WithWorkingDir(dir)
+	require.NoError(t, err)
+	hooksDir := filepath.Join(dir, HooksDir)
+	require.NoError(t, os.MkdirAll(hooksDir, os.ModePerm))
+
+	writeScript := func(hook HookType, script string, mode os.FileMode) {
+		require.NoError(t, os.WriteFile(filepath.Join(hooksDir, string(hook)), []byte("#!/bin/sh\n"+script), mode))
+	}
+
+	writeScript(PreCommitHook, `echo "checking $DOLT_BRANCH: $DOLT_COMMIT_MESSAGE"; exit 1`, 0755)
+	out, err := RunHooks(ctx, fs, HookEvent{Type: PreCommitHook, Branch: "main", Message: "msg"})
+	assert.Error(t, err)
+	assert.Equal(t, "checking main: msg\n", out)
+
+	// scripts which aren't executable don't run
+	writeScript(PostCommitHook, `exit 1`, 0644)
+	_, err = RunHooks(ctx, fs, HookEvent{Type: PostCommitHook})
+	assert.NoError(t, err)
+
+	writeScript(PrePushHook, `echo "$1 $2"; cat`, 0755)
+	ev := HookEvent{
+		Type:    PrePushHook,
+		Remote:  env.Remote{Name: "origin", Url: "file:///remote"},
+		Updates: []HookRefUpdate{{LocalRef: "refs/heads/main", RemoteRef: "refs/heads/main"}},
+	}
+	out, err = RunHooks(ctx, fs, ev)
+	require.NoError(t, err)
+	zero := "00000000000000000000000000000000"
+	assert.Equal(t, "origin file:///remote\nrefs/heads/main "+zero+" refs/heads/main "+zero+"\n", out)
+
+	writeScript(PostResolveHook, `cat`, 0755)
+	ev = HookEvent{
+		Type:        PostResolveHook,
+		Resolutions: []env.ConflictResolution{{Table: "t", ConflictID: "id", Winner: env.ConflictWinnerTheirs, Key: []byte(`{"pk":1}`)}},
+	}
+	out, err = RunHooks(ctx, fs, ev)
+	require.NoError(t, err)
+	assert.Equal(t, "t id theirs {\"pk\":1}\n", out)
+}
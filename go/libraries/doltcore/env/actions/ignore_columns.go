@@ -0,0 +1,160 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// stageTablesWithIgnoredColumns stages the tables of |tbls| that have columns listed in the dolt_ignore_columns table
+// of |working|. The rows of these tables are staged with the values of their ignored columns left as they are in
+// |staged|. It returns the new staged root and the tables of |tbls| that still need to be staged.
+func stageTablesWithIgnoredColumns(ctx context.Context, tbls []string, working, staged *doltdb.RootValue) (*doltdb.RootValue, []string, error) {
+	ignored, err := doltdb.GetIgnoredColumns(ctx, working)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ignored) == 0 {
+		return staged, tbls, nil
+	}
+
+	var remaining []string
+	for _, tblName := range tbls {
+		cols, err := ignored.ColumnsForTable(tblName)
+		if err != nil {
+			return nil, nil, err
+		}
+		var tbl *doltdb.Table
+		if cols.Size() > 0 {
+			tbl, err = stageRowsWithIgnoredColumns(ctx, tblName, cols, working, staged)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if tbl == nil {
+			remaining = append(remaining, tblName)
+			continue
+		}
+		staged, err = staged.PutTable(ctx, tblName, tbl)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return staged, remaining, nil
+}
+
+// stageRowsWithIgnoredColumns returns the working table |tblName| with the rows it would have after staging its
+// changes to the columns not in |cols|, or nil if the table must be staged as a whole. Rows are only merged when the
+// table exists in both roots with the same schema.
+func stageRowsWithIgnoredColumns(ctx context.Context, tblName string, cols *set.StrSet, working, staged *doltdb.RootValue) (*doltdb.Table, error) {
+	workingTbl, ok, err := working.GetTable(ctx, tblName)
+	if err != nil || !ok || !types.IsFormat_DOLT(workingTbl.Format()) {
+		return nil, err
+	}
+	stagedTbl, ok, err := staged.GetTable(ctx, tblName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	workingSchHash, err := workingTbl.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stagedSchHash, err := stagedTbl.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !workingSchHash.Equal(stagedSchHash) {
+		return nil, nil
+	}
+	sch, err := workingTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil
+	}
+
+	ignoredFields := make(map[int]bool)
+	for i, col := range sch.GetNonPKCols().GetColumns() {
+		if cols.Contains(col.Name) {
+			ignoredFields[i] = true
+		}
+	}
+	if len(ignoredFields) == 0 {
+		return nil, nil
+	}
+
+	workingRows, err := workingTbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stagedRows, err := stagedTbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from, to := durable.ProllyMapFromIndex(stagedRows), durable.ProllyMapFromIndex(workingRows)
+
+	_, vd := from.Descriptors()
+	tb := val.NewTupleBuilder(vd)
+	pool := workingTbl.NodeStore().Pool()
+	mut := from.Mutate()
+	err = prolly.DiffMaps(ctx, from, to, func(ctx context.Context, d tree.Diff) error {
+		switch d.Type {
+		case tree.AddedDiff:
+			return mut.Put(ctx, val.Tuple(d.Key), val.Tuple(d.To))
+		case tree.RemovedDiff:
+			return mut.Delete(ctx, val.Tuple(d.Key))
+		default:
+			for i := 0; i < vd.Count(); i++ {
+				if ignoredFields[i] {
+					tb.PutRaw(i, vd.GetField(i, val.Tuple(d.From)))
+				} else {
+					tb.PutRaw(i, vd.GetField(i, val.Tuple(d.To)))
+				}
+			}
+			v := tb.Build(pool)
+			if bytes.Equal(v, val.Tuple(d.From)) {
+				return nil
+			}
+			return mut.Put(ctx, val.Tuple(d.Key), v)
+		}
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	rows, err := mut.Map(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err := workingTbl.UpdateRows(ctx, durable.IndexFromProllyMap(rows))
+	if err != nil {
+		return nil, err
+	}
+	return creation.RebuildSecondaryIndexes(ctx, tbl, nil, editor.Options{})
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrMissingLicense = errors.New("license metadata is missing")
+
+// TableProvenance is the provenance of a user table.
+type TableProvenance struct {
+	TableName string
+	doltdb.Provenance
+	// Commit is the commit whose trailers the provenance comes from, or the empty hash when the table has its own row
+	// in dolt_table_provenance.
+	Commit hash.Hash
+}
+
+// GetProvenance returns the provenance of each user table of |root|, sorted by table name. A table listed in the
+// dolt_table_provenance table of |root| has the provenance of its row there. Any other table has the provenance of the
+// most recent commit in the history of |head| with provenance trailers, or none if there's no such commit.
+func GetProvenance(ctx context.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, root *doltdb.RootValue) ([]TableProvenance, error) {
+	tblNames, err := doltdb.GetNonSystemTableNames(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	tblProvenance, err := doltdb.GetTableProvenance(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var commitProvenance doltdb.Provenance
+	var commitHash hash.Hash
+	loaded := false
+
+	provenance := make([]TableProvenance, len(tblNames))
+	for i, tblName := range tblNames {
+		provenance[i].TableName = tblName
+		if p, ok := tblProvenance[tblName]; ok {
+			provenance[i].Provenance = p
+			continue
+		}
+		// the history is only walked if a table needs it
+		if !loaded && head != nil {
+			commitHash, commitProvenance, err = getLastCommitProvenance(ctx, ddb, head)
+			if err != nil {
+				return nil, err
+			}
+			loaded = true
+		}
+		provenance[i].Provenance = commitProvenance
+		provenance[i].Commit = commitHash
+	}
+	return provenance, nil
+}
+
+// getLastCommitProvenance returns the most recent commit in the history of |head| with provenance trailers, and its
+// provenance.
+func getLastCommitProvenance(ctx context.Context, ddb *doltdb.DoltDB, head *doltdb.Commit) (hash.Hash, doltdb.Provenance, error) {
+	h, err := head.HashOf()
+	if err != nil {
+		return hash.Hash{}, doltdb.Provenance{}, err
+	}
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{h}, nil)
+	if err != nil {
+		return hash.Hash{}, doltdb.Provenance{}, err
+	}
+	for {
+		cmHash, cm, err := itr.Next(ctx)
+		if err == io.EOF {
+			return hash.Hash{}, doltdb.Provenance{}, nil
+		} else if err != nil {
+			return hash.Hash{}, doltdb.Provenance{}, err
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return hash.Hash{}, doltdb.Provenance{}, err
+		}
+		if p := doltdb.ParseProvenanceTrailers(meta.Description); !p.IsEmpty() {
+			return cmHash, p, nil
+		}
+	}
+}
+
+// RequiresLicense returns whether pushes to the remote |remoteName| need license metadata, according to |setting|,
+// the value of the push.requirelicense config: a comma separated list of remote names, or * for every remote.
+func RequiresLicense(remoteName, setting string) bool {
+	for _, name := range strings.Split(setting, ",") {
+		name = strings.TrimSpace(name)
+		if name == "*" || name == remoteName {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPushLicense returns ErrMissingLicense if the push of |opts| needs license metadata, according to |setting|, and
+// a user table of the pushed commit has no license in its provenance. Deletes of remote branches are always allowed.
+func CheckPushLicense(ctx context.Context, ddb *doltdb.DoltDB, opts *env.PushOpts, setting string) error {
+	if !RequiresLicense(opts.Remote.Name, setting) || opts.SrcRef == ref.EmptyBranchRef {
+		return nil
+	}
+
+	var cm *doltdb.Commit
+	var err error
+	switch opts.SrcRef.GetType() {
+	case ref.BranchRefType:
+		cm, err = ddb.ResolveCommitRef(ctx, opts.SrcRef)
+	case ref.TagRefType:
+		var tag *doltdb.Tag
+		tag, err = ddb.ResolveTag(ctx, opts.SrcRef.(ref.TagRef))
+		if err == nil {
+			cm = tag.Commit
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	provenance, err := GetProvenance(ctx, ddb, cm, root)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, p := range provenance {
+		if p.License == "" {
+			missing = append(missing, p.TableName)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: pushes to '%s' require a license for every table, and these tables have none: %s. Set one with `dolt commit --license` or in the dolt_table_provenance table",
+			ErrMissingLicense, opts.Remote.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -23,14 +23,17 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 var ErrCantFF = errors.New("can't fast forward merge")
@@ -42,6 +45,9 @@ var ErrFailedToGetRemoteDb = errors.New("failed to get remote db")
 var ErrFailedToDeleteBackup = errors.New("failed to delete backup")
 var ErrFailedToGetBackupDb = errors.New("failed to get backup db")
 var ErrUnknownPushErr = errors.New("unknown push error")
+var ErrIncompatibleCompression = errors.New("older versions of dolt can't read chunks compressed with this codec")
+var ErrBackupNotCompactable = errors.New("the backup stores chunks which the database garbage collected, but its storage can't be garbage collected by dolt")
+var ErrStaleLease = errors.New("stale info")
 
 type ProgStarter func(ctx context.Context) (*sync.WaitGroup, chan pull.Stats)
 type ProgStopper func(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh chan pull.Stats)
@@ -50,10 +56,11 @@ type ProgStopper func(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh cha
 // This is accomplished first by verifying that the remote tracking reference for the source database can be updated to
 // the given commit via a fast forward merge.  If this is the case, an attempt will be made to update the branch in the
 // destination db to the given commit via fast forward move.  If that succeeds the tracking branch is updated in the
-// source db.
-func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef ref.BranchRef, remoteRef ref.RemoteRef, srcDB, destDB *doltdb.DoltDB, commit *doltdb.Commit, statsCh chan pull.Stats) error {
+// source db. If |mode| has a lease, the branch is force updated only if it's still at the head of the lease, and
+// ErrStaleLease is returned otherwise.
+func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef ref.BranchRef, remoteRef ref.RemoteRef, srcDB, destDB *doltdb.DoltDB, commit *doltdb.Commit, statsCh chan pull.Stats, opts ...pull.Option) error {
 	var err error
-	if mode == ref.FastForwardOnly {
+	if !mode.Force {
 		canFF, err := srcDB.CanFastForward(ctx, remoteRef, commit)
 
 		if err != nil {
@@ -61,6 +68,12 @@ func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef
 		} else if !canFF {
 			return ErrCantFF
 		}
+	} else if mode.Lease != nil {
+		// checked before the push so that nothing is uploaded for a push that will be rejected
+		err = checkLease(ctx, destDB, destRef, *mode.Lease)
+		if err != nil {
+			return err
+		}
 	}
 
 	h, err := commit.HashOf()
@@ -68,20 +81,30 @@ func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef
 		return err
 	}
 
-	err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{h}, statsCh)
+	err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{h}, statsCh, opts...)
 
 	if err != nil {
 		return err
 	}
 
-	switch mode {
-	case ref.ForceUpdate:
+	switch {
+	case mode.Lease != nil:
+		err = destDB.CompareAndSetHead(ctx, destRef, *mode.Lease, h)
+		if errors.Is(err, datas.ErrMergeNeeded) {
+			// the branch was moved after the lease was checked
+			err = checkLease(ctx, destDB, destRef, *mode.Lease)
+		}
+		if err != nil {
+			return err
+		}
+		err = srcDB.SetHeadToCommit(ctx, remoteRef, commit)
+	case mode.Force:
 		err = destDB.SetHeadToCommit(ctx, destRef, commit)
 		if err != nil {
 			return err
 		}
 		err = srcDB.SetHeadToCommit(ctx, remoteRef, commit)
-	case ref.FastForwardOnly:
+	default:
 		err = destDB.FastForward(ctx, destRef, commit)
 		if err != nil {
 			return err
@@ -92,18 +115,87 @@ func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef
 	return err
 }
 
-func DoPush(ctx context.Context, rsr env.RepoStateReader, rsw env.RepoStateWriter, srcDB, destDB *doltdb.DoltDB, tempTableDir string, opts *env.PushOpts, progStarter ProgStarter, progStopper ProgStopper) error {
-	var err error
+// checkLease returns an ErrStaleLease if |branch| in |destDB| isn't at |lease|, or exists when |lease| is empty.
+func checkLease(ctx context.Context, destDB *doltdb.DoltDB, branch ref.DoltRef, lease hash.Hash) error {
+	var curr hash.Hash
+	if ok, err := destDB.HasRef(ctx, branch); err != nil {
+		return err
+	} else if ok {
+		h, err := destDB.GetHashForRefStr(ctx, branch.String())
+		if err != nil {
+			return err
+		}
+		curr = *h
+	}
+	if curr == lease {
+		return nil
+	}
+	if lease.IsEmpty() {
+		return fmt.Errorf("%w: %s exists at %s, but was expected not to exist", ErrStaleLease, branch.String(), curr.String())
+	} else if curr.IsEmpty() {
+		return fmt.Errorf("%w: %s does not exist, but was expected at %s", ErrStaleLease, branch.String(), lease.String())
+	}
+	return fmt.Errorf("%w: %s is at %s, but was expected at %s", ErrStaleLease, branch.String(), curr.String(), lease.String())
+}
+
+// CheckPushProtection returns a doltdb.ErrProtectedBranch error if the push of |opts| from |srcDB| to |destDB| would
+// delete a branch of |destDB| which |protection| protects, move it to a commit which doesn't descend from its commit,
+// or move it to a commit with unresolved merge conflicts.
+func CheckPushProtection(ctx context.Context, srcDB, destDB *doltdb.DoltDB, opts *env.PushOpts, protection doltdb.BranchProtection) error {
+	if protection.IsEmpty() || opts.SrcRef.GetType() != ref.BranchRefType || opts.DestRef.GetType() != ref.BranchRefType {
+		return nil
+	}
+
+	var from, to *doltdb.Commit
+	if ok, err := destDB.HasRef(ctx, opts.DestRef); err != nil {
+		return err
+	} else if ok {
+		from, err = destDB.ResolveCommitRef(ctx, opts.DestRef)
+		if err != nil {
+			return err
+		}
+	}
+	if opts.SrcRef != ref.EmptyBranchRef {
+		var err error
+		to, err = srcDB.ResolveCommitRef(ctx, opts.SrcRef)
+		if err != nil {
+			return err
+		}
+	} else if from == nil {
+		// deleting a branch which doesn't exist fails on its own
+		return nil
+	}
+	return protection.CheckBranchUpdate(ctx, opts.DestRef.GetPath(), from, to)
+}
+
+func DoPush(ctx context.Context, rsr env.RepoStateReader, rsw env.RepoStateWriter, srcDB, destDB *doltdb.DoltDB, tempTableDir string, opts *env.PushOpts, progStarter ProgStarter, progStopper ProgStopper) (err error) {
+	recordOpt, recordPush := StartTransferRecord(rsw, env.TransferPush, opts.Remote.Name)
+	defer func() {
+		recordPush(err)
+	}()
+
+	pullOpts := []pull.Option{recordOpt}
+	if name, ok := opts.Remote.Params[dbfactory.CompressionParam]; ok {
+		codec, err := nbs.ParseChunkCodec(name)
+		if err != nil {
+			return err
+		}
+		// the remote's config may have been written by hand, so the codec is checked again before pushing
+		if codec != nbs.SnappyCodec && opts.Remote.Params[dbfactory.AllowIncompatibleCompressionParam] != "true" {
+			return fmt.Errorf("%w: remote '%s' has %s=%s but not %s", ErrIncompatibleCompression, opts.Remote.Name, dbfactory.CompressionParam, name, dbfactory.AllowIncompatibleCompressionParam)
+		}
+		pullOpts = append(pullOpts, pull.WithChunkCodec(codec))
+	}
 
 	switch opts.SrcRef.GetType() {
 	case ref.BranchRefType:
 		if opts.SrcRef == ref.EmptyBranchRef {
-			err = deleteRemoteBranch(ctx, opts.DestRef, opts.RemoteRef, srcDB, destDB, opts.Remote)
+			err = deleteRemoteBranch(ctx, opts.DestRef, opts.RemoteRef, srcDB, destDB, opts.Remote, opts.Mode.Lease)
 		} else {
-			err = PushToRemoteBranch(ctx, rsr, tempTableDir, opts.Mode, opts.SrcRef, opts.DestRef, opts.RemoteRef, srcDB, destDB, opts.Remote, progStarter, progStopper)
+			err = PushToRemoteBranch(ctx, rsr, tempTableDir, opts.Mode, opts.SrcRef, opts.DestRef, opts.RemoteRef, srcDB, destDB, opts.Remote, progStarter, progStopper, pullOpts...)
 		}
 	case ref.TagRefType:
-		err = pushTagToRemote(ctx, tempTableDir, opts.SrcRef, opts.DestRef, srcDB, destDB, progStarter, progStopper)
+		err = pushTagToRemote(ctx, tempTableDir, opts.SrcRef, opts.DestRef, srcDB, destDB, progStarter, progStopper, pullOpts...)
 	default:
 		err = fmt.Errorf("%w: %s of type %s", ErrCannotPushRef, opts.SrcRef.String(), opts.SrcRef.GetType())
 	}
@@ -125,8 +217,43 @@ func DoPush(ctx context.Context, rsr env.RepoStateReader, rsw env.RepoStateWrite
 	return err
 }
 
+// PushToMirrors copies the push described by |opts|, which has been made to its remote, to each of the remote's
+// mirrors. The mirrors are force updated, so that they match the remote even if they missed earlier pushes. The
+// mirrors that couldn't be pushed to don't fail the push, and their errors are returned instead. |remoteDB| returns
+// the database of a mirror.
+func PushToMirrors(ctx context.Context, rsr env.RepoStateReader, rsw env.RepoStateWriter, srcDB *doltdb.DoltDB, tempTableDir string, opts *env.PushOpts, remoteDB func(ctx context.Context, mirror env.Remote) (*doltdb.DoltDB, error)) []error {
+	var errs []error
+	for _, mirror := range opts.Remote.MirrorRemotes() {
+		destDB, err := remoteDB(ctx, mirror)
+		if err == nil {
+			err = pushToMirror(ctx, rsr, rsw, srcDB, destDB, tempTableDir, opts, mirror)
+		}
+		if err != nil && !errors.Is(err, doltdb.ErrUpToDate) && !errors.Is(err, pull.ErrDBUpToDate) {
+			errs = append(errs, fmt.Errorf("failed to push to mirror '%s' of remote '%s': %w", mirror.Url, mirror.Name, err))
+		}
+	}
+	return errs
+}
+
+func pushToMirror(ctx context.Context, rsr env.RepoStateReader, rsw env.RepoStateWriter, srcDB, destDB *doltdb.DoltDB, tempTableDir string, opts *env.PushOpts, mirror env.Remote) error {
+	if opts.SrcRef == ref.EmptyBranchRef {
+		// the remote tracking branch was deleted by the push to the remote, so only the mirror's branch is deleted
+		hasRef, err := destDB.HasRef(ctx, opts.DestRef)
+		if err != nil || !hasRef {
+			return err
+		}
+		return destDB.DeleteBranch(ctx, opts.DestRef)
+	}
+
+	mirrorOpts := *opts
+	mirrorOpts.Remote = mirror
+	mirrorOpts.Mode = ref.ForceUpdate
+	mirrorOpts.SetUpstream = false
+	return DoPush(ctx, rsr, rsw, srcDB, destDB, tempTableDir, &mirrorOpts, quietProgStarter, quietProgStopper)
+}
+
 // PushTag pushes a commit tag and all underlying data from a local source database to a remote destination database.
-func PushTag(ctx context.Context, tempTableDir string, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, statsCh chan pull.Stats) error {
+func PushTag(ctx context.Context, tempTableDir string, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, statsCh chan pull.Stats, opts ...pull.Option) error {
 	var err error
 
 	addr, err := tag.GetAddr()
@@ -134,7 +261,7 @@ func PushTag(ctx context.Context, tempTableDir string, destRef ref.TagRef, srcDB
 		return err
 	}
 
-	err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{addr}, statsCh)
+	err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{addr}, statsCh, opts...)
 
 	if err != nil {
 		return err
@@ -143,8 +270,16 @@ func PushTag(ctx context.Context, tempTableDir string, destRef ref.TagRef, srcDB
 	return destDB.SetHead(ctx, destRef, addr)
 }
 
-func deleteRemoteBranch(ctx context.Context, toDelete, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote) error {
-	err := DeleteRemoteBranch(ctx, toDelete.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB)
+func deleteRemoteBranch(ctx context.Context, toDelete, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote, lease *hash.Hash) error {
+	var err error
+	if lease != nil {
+		err = deleteRemoteBranchWithLease(ctx, toDelete, remoteRef, localDB, remoteDB, *lease)
+		if errors.Is(err, ErrStaleLease) {
+			return err
+		}
+	} else {
+		err = DeleteRemoteBranch(ctx, toDelete.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB)
+	}
 
 	if err != nil {
 		return fmt.Errorf("%w; '%s' from remote '%s'; %s", ErrFailedToDeleteRemote, toDelete.String(), remote.Name, err)
@@ -153,7 +288,20 @@ func deleteRemoteBranch(ctx context.Context, toDelete, remoteRef ref.DoltRef, lo
 	return nil
 }
 
-func PushToRemoteBranch(ctx context.Context, rsr env.RepoStateReader, tempTableDir string, mode ref.UpdateMode, srcRef, destRef, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote, progStarter ProgStarter, progStopper ProgStopper) error {
+// deleteRemoteBranchWithLease deletes |toDelete| from |remoteDB| only if it's at |lease|, and then deletes the remote
+// tracking branch from |localDB|.
+func deleteRemoteBranchWithLease(ctx context.Context, toDelete, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, lease hash.Hash) error {
+	err := remoteDB.CompareAndSetHead(ctx, toDelete, lease, hash.Hash{})
+	if errors.Is(err, datas.ErrMergeNeeded) {
+		err = checkLease(ctx, remoteDB, toDelete, lease)
+	}
+	if err != nil {
+		return err
+	}
+	return localDB.DeleteBranch(ctx, remoteRef)
+}
+
+func PushToRemoteBranch(ctx context.Context, rsr env.RepoStateReader, tempTableDir string, mode ref.UpdateMode, srcRef, destRef, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote, progStarter ProgStarter, progStopper ProgStopper, opts ...pull.Option) error {
 	evt := events.GetEventFromContext(ctx)
 
 	u, err := earl.Parse(remote.Url)
@@ -174,9 +322,13 @@ func PushToRemoteBranch(ctx context.Context, rsr env.RepoStateReader, tempTableD
 
 	newCtx, cancelFunc := context.WithCancel(ctx)
 	wg, statsCh := progStarter(newCtx)
-	err = Push(ctx, tempTableDir, mode, destRef.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB, cm, statsCh)
+	err = Push(ctx, tempTableDir, mode, destRef.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB, cm, statsCh, opts...)
 	progStopper(cancelFunc, wg, statsCh)
 
+	if errors.Is(err, ErrStaleLease) {
+		return err
+	}
+
 	switch err {
 	case nil:
 		cli.Println()
@@ -188,7 +340,7 @@ func PushToRemoteBranch(ctx context.Context, rsr env.RepoStateReader, tempTableD
 	}
 }
 
-func pushTagToRemote(ctx context.Context, tempTableDir string, srcRef, destRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) error {
+func pushTagToRemote(ctx context.Context, tempTableDir string, srcRef, destRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper, opts ...pull.Option) error {
 	tg, err := localDB.ResolveTag(ctx, srcRef.(ref.TagRef))
 
 	if err != nil {
@@ -197,7 +349,7 @@ func pushTagToRemote(ctx context.Context, tempTableDir string, srcRef, destRef r
 
 	newCtx, cancelFunc := context.WithCancel(ctx)
 	wg, statsCh := progStarter(newCtx)
-	err = PushTag(ctx, tempTableDir, destRef.(ref.TagRef), localDB, remoteDB, tg, statsCh)
+	err = PushTag(ctx, tempTableDir, destRef.(ref.TagRef), localDB, remoteDB, tg, statsCh, opts...)
 	progStopper(cancelFunc, wg, statsCh)
 
 	if err != nil {
@@ -235,34 +387,34 @@ func DeleteRemoteBranch(ctx context.Context, targetRef ref.BranchRef, remoteRef
 }
 
 // FetchCommit takes a fetches a commit and all underlying data from a remote source database to the local destination database.
-func FetchCommit(ctx context.Context, tempTablesDir string, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, statsCh chan pull.Stats) error {
+func FetchCommit(ctx context.Context, tempTablesDir string, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, statsCh chan pull.Stats, opts ...pull.Option) error {
 	h, err := srcDBCommit.HashOf()
 	if err != nil {
 		return err
 	}
 
-	return destDB.PullChunks(ctx, tempTablesDir, srcDB, []hash.Hash{h}, statsCh)
+	return destDB.PullChunks(ctx, tempTablesDir, srcDB, []hash.Hash{h}, statsCh, opts...)
 }
 
 // FetchTag takes a fetches a commit tag and all underlying data from a remote source database to the local destination database.
-func FetchTag(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, srcDBTag *doltdb.Tag, statsCh chan pull.Stats) error {
+func FetchTag(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, srcDBTag *doltdb.Tag, statsCh chan pull.Stats, opts ...pull.Option) error {
 	addr, err := srcDBTag.GetAddr()
 	if err != nil {
 		return err
 	}
 
-	return destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{addr}, statsCh)
+	return destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{addr}, statsCh, opts...)
 }
 
 // Clone pulls all data from a remote source database to a local destination database.
-func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- pull.TableFileEvent) error {
-	return srcDB.Clone(ctx, destDB, eventCh)
+func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- pull.TableFileEvent, opts ...pull.Option) error {
+	return srcDB.Clone(ctx, destDB, eventCh, opts...)
 }
 
 // FetchFollowTags fetches all tags from the source DB whose commits have already
 // been fetched into the destination DB.
 // todo: potentially too expensive to iterate over all srcDB tags
-func FetchFollowTags(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) error {
+func FetchFollowTags(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper, opts ...pull.Option) error {
 	err := IterResolvedTags(ctx, srcDB, func(tag *doltdb.Tag) (stop bool, err error) {
 		tagHash, err := tag.GetAddr()
 		if err != nil {
@@ -294,7 +446,7 @@ func FetchFollowTags(ctx context.Context, tempTableDir string, srcDB, destDB *do
 
 		newCtx, cancelFunc := context.WithCancel(ctx)
 		wg, statsCh := progStarter(newCtx)
-		err = FetchTag(ctx, tempTableDir, srcDB, destDB, tag, statsCh)
+		err = FetchTag(ctx, tempTableDir, srcDB, destDB, tag, statsCh, opts...)
 		progStopper(cancelFunc, wg, statsCh)
 		if err == nil {
 			cli.Println()
@@ -328,6 +480,7 @@ func FetchRemoteBranch(
 	srcRef ref.DoltRef,
 	progStarter ProgStarter,
 	progStopper ProgStopper,
+	opts ...pull.Option,
 ) (*doltdb.Commit, error) {
 	evt := events.GetEventFromContext(ctx)
 
@@ -353,7 +506,7 @@ func FetchRemoteBranch(
 		wg, statsCh := progStarter(newCtx)
 		defer progStopper(cancelFunc, wg, statsCh)
 
-		err = FetchCommit(ctx, tempTablesDir, srcDB, destDB, srcDBCommit, statsCh)
+		err = FetchCommit(ctx, tempTablesDir, srcDB, destDB, srcDBCommit, statsCh, opts...)
 
 		if err == pull.ErrDBUpToDate {
 			err = nil
@@ -366,7 +519,7 @@ func FetchRemoteBranch(
 		return srcDBCommit, nil
 	}
 
-	err = FetchCommit(ctx, tempTablesDir, srcDB, destDB, srcDBCommit, nil)
+	err = FetchCommit(ctx, tempTablesDir, srcDB, destDB, srcDBCommit, nil, opts...)
 
 	if err == pull.ErrDBUpToDate {
 		err = nil
@@ -382,30 +535,51 @@ func FetchRemoteBranch(
 // FetchRefSpecs is the common SQL and CLI entrypoint for fetching branches, tags, and heads from a remote.
 // This function takes dbData which is a env.DbData object for handling repoState read and write, and srcDB is
 // a remote *doltdb.DoltDB object that is used to fetch remote branches from.
-func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec, remote env.Remote, mode ref.UpdateMode, progStarter ProgStarter, progStopper ProgStopper) error {
+// Each call is recorded in the fetch history of |dbData|, if it keeps one. |opts| configure the pulls of the fetch.
+// Branches excluded by the negative refspecs of |refSpecs| aren't fetched, and the remote tracking branches of forced
+// refspecs are force updated regardless of |mode|.
+func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec, remote env.Remote, mode ref.UpdateMode, progStarter ProgStarter, progStopper ProgStopper, opts ...pull.Option) error {
+	recordOpt, recordFetch := StartTransferRecord(dbData.Rsw, env.TransferFetch, remote.Name)
+	err := fetchRefSpecs(ctx, dbData, srcDB, refSpecs, remote, mode, progStarter, progStopper, append([]pull.Option{recordOpt}, opts...))
+	recordFetch(err)
+	return err
+}
+
+func fetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec, remote env.Remote, mode ref.UpdateMode, progStarter ProgStarter, progStopper ProgStopper, opts []pull.Option) error {
 	branchRefs, err := srcDB.GetHeadRefs(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
 	}
 
 	for _, rs := range refSpecs {
+		if _, ok := rs.(ref.NegativeRefSpec); ok {
+			continue
+		}
+
 		rsSeen := false
+		rsMode := mode
+		if rs.Forced() {
+			rsMode = ref.ForceUpdate
+		}
 
 		for _, branchRef := range branchRefs {
 			remoteTrackRef := rs.DestRef(branchRef)
 
 			if remoteTrackRef != nil {
 				rsSeen = true
+				if ref.IsExcluded(branchRef, refSpecs) {
+					continue
+				}
 				tmpDir, err := dbData.Rsw.TempTableFilesDir()
 				if err != nil {
 					return err
 				}
-				srcDBCommit, err := FetchRemoteBranch(ctx, tmpDir, remote, srcDB, dbData.Ddb, branchRef, progStarter, progStopper)
+				srcDBCommit, err := FetchRemoteBranch(ctx, tmpDir, remote, srcDB, dbData.Ddb, branchRef, progStarter, progStopper, opts...)
 				if err != nil {
 					return err
 				}
 
-				switch mode {
+				switch rsMode {
 				case ref.ForceUpdate:
 					// TODO: can't be used safely in a SQL context
 					err := dbData.Ddb.SetHeadToCommit(ctx, remoteTrackRef, srcDBCommit)
@@ -444,7 +618,7 @@ func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB,
 	if err != nil {
 		return err
 	}
-	err = FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, progStarter, progStopper)
+	err = FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, progStarter, progStopper, opts...)
 	if err != nil {
 		return err
 	}
@@ -452,6 +626,55 @@ func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB,
 	return nil
 }
 
+// RemoteFetch is a remote to fetch from, its database, and the refspecs to fetch.
+type RemoteFetch struct {
+	Remote   env.Remote
+	SrcDB    *doltdb.DoltDB
+	RefSpecs []ref.RemoteRefSpec
+}
+
+// FetchRemotes fetches |fetches| into |dbData|, one remote after another, or all at once if |parallel| is true.
+// Parallel fetches share the chunks they download, so a chunk reachable from more than one remote is only fetched
+// once. All remotes are fetched even if some fail, and the error of the first remote that failed is returned.
+func FetchRemotes(ctx context.Context, dbData env.DbData, fetches []RemoteFetch, mode ref.UpdateMode, parallel bool, progStarter ProgStarter, progStopper ProgStopper, opts ...pull.Option) error {
+	errs := make([]error, len(fetches))
+	fetch := func(i int, opts []pull.Option) {
+		f := fetches[i]
+		err := FetchRefSpecs(ctx, dbData, f.SrcDB, f.RefSpecs, f.Remote, mode, progStarter, progStopper, opts...)
+		if err != nil && err != doltdb.ErrUpToDate {
+			errs[i] = fmt.Errorf("failed to fetch from '%s': %w", f.Remote.Name, err)
+		}
+	}
+
+	if !parallel {
+		for i := range fetches {
+			fetch(i, opts)
+		}
+		return firstError(errs)
+	}
+
+	sharedOpts := append([]pull.Option{pull.WithSharedFetch(pull.NewSharedFetch())}, opts...)
+	wg := &sync.WaitGroup{}
+	for i := range fetches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fetch(i, sharedOpts)
+		}(i)
+	}
+	wg.Wait()
+	return firstError(errs)
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SyncRoots copies the entire chunkstore from srcDb to destDb and rewrites the remote manifest. Used to
 // streamline database backup and restores.
 // TODO: this should read/write a backup lock file specific to the client who created the backup
@@ -491,6 +714,57 @@ func SyncRoots(ctx context.Context, srcDb, destDb *doltdb.DoltDB, tempTableDir s
 	return nil
 }
 
+// CompactBackup garbage collects |destDb|, a backup synced from |srcDb|, if it stores more chunks than |srcDb|.
+// SyncRoots only sends the chunks a backup is missing, whichever table files they're in, so after |srcDb| is garbage
+// collected nothing is sent again, but the backup keeps the chunks which were collected. It returns whether the backup
+// was garbage collected, or ErrBackupNotCompactable if it needed to be but its storage doesn't support it.
+func CompactBackup(ctx context.Context, srcDb, destDb *doltdb.DoltDB) (bool, error) {
+	srcChunks, ok, err := countChunks(ctx, srcDb)
+	if err != nil || !ok {
+		return false, err
+	}
+	destChunks, ok, err := countChunks(ctx, destDb)
+	if err != nil || !ok {
+		return false, err
+	}
+	if destChunks <= srcChunks {
+		return false, nil
+	}
+
+	destCS := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(destDb))
+	_, isGenerational := destCS.(chunks.GenerationalCS)
+	_, isCollector := destCS.(chunks.ChunkStoreGarbageCollector)
+	if !isGenerational && !isCollector {
+		return false, ErrBackupNotCompactable
+	}
+
+	err = destDb.GC(ctx, nil)
+	if errors.Is(err, chunks.ErrNothingToCollect) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// countChunks returns the number of chunks in the table files of |ddb|, or false if its storage has no table files.
+func countChunks(ctx context.Context, ddb *doltdb.DoltDB) (int, bool, error) {
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	tfs, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return 0, false, nil
+	}
+	_, tableFiles, _, err := tfs.Sources(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	count := 0
+	for _, tf := range tableFiles {
+		count += tf.NumChunks()
+	}
+	return count, true, nil
+}
+
 func HandleInitRemoteStorageClientErr(name, url string, err error) error {
 	var detail = fmt.Sprintf("the remote: %s '%s' could not be accessed", name, url)
 	return fmt.Errorf("%w; %s; %s", ErrFailedToGetRemoteDb, detail, err.Error())
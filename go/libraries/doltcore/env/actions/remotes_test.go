@@ -0,0 +1,93 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestPushWithLease(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+	ddb := dEnv.DoltDB
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+
+	main := ref.NewBranchRef("main")
+	head, err := ddb.ResolveCommitRef(ctx, main)
+	require.NoError(t, err)
+	rv, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+	commit := func(msg string) *doltdb.Commit {
+		meta, err := datas.NewCommitMeta("test", "test@test.com", msg)
+		require.NoError(t, err)
+		cm, err := ddb.Commit(ctx, valHash, main, meta)
+		require.NoError(t, err)
+		return cm
+	}
+	addr := func(cm *doltdb.Commit) hash.Hash {
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		return h
+	}
+
+	// the database is its own remote, with "pushed" as the remote branch
+	dest := ref.NewBranchRef("pushed")
+	tracking := ref.NewRemoteRef("origin", "pushed")
+	push := func(cm *doltdb.Commit, lease hash.Hash) error {
+		return Push(ctx, tmpDir, ref.UpdateMode{Force: true, Lease: &lease}, dest, tracking, ddb, ddb, cm, nil)
+	}
+
+	c1 := commit("c1")
+	err = push(c1, addr(head))
+	assert.ErrorIs(t, err, ErrStaleLease, "the branch doesn't exist yet")
+	require.NoError(t, push(c1, hash.Hash{}))
+
+	c2 := commit("c2")
+	require.NoError(t, push(c2, addr(c1)))
+	pushed, err := ddb.ResolveCommitRef(ctx, dest)
+	require.NoError(t, err)
+	assert.Equal(t, addr(c2), addr(pushed))
+
+	// a rewrite of history is only pushed if the branch is still where it's expected to be
+	require.NoError(t, ddb.SetHeadToCommit(ctx, main, c1))
+	c3 := commit("c3")
+	err = push(c3, addr(c1))
+	assert.ErrorIs(t, err, ErrStaleLease)
+	pushed, err = ddb.ResolveCommitRef(ctx, dest)
+	require.NoError(t, err)
+	assert.Equal(t, addr(c2), addr(pushed))
+	require.NoError(t, push(c3, addr(c2)))
+
+	err = deleteRemoteBranchWithLease(ctx, dest, tracking, ddb, ddb, addr(c2))
+	assert.ErrorIs(t, err, ErrStaleLease)
+	require.NoError(t, deleteRemoteBranchWithLease(ctx, dest, tracking, ddb, ddb, addr(c3)))
+	ok, err := ddb.HasRef(ctx, dest)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
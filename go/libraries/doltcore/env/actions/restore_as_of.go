@@ -0,0 +1,173 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// BranchRestore is the change to one branch made to restore a database to its state as of a point in time.
+type BranchRestore struct {
+	Branch ref.BranchRef
+	// From is the current head of the branch, or empty if the branch was deleted since.
+	From hash.Hash
+	// To is the head of the branch as of the point in time, or empty if the branch didn't exist yet.
+	To hash.Hash
+	// ToTime is the time the commit |To| was made.
+	ToTime time.Time
+}
+
+// BranchesAsOf returns how each branch of |ddb| must change to point at the newest commit that was made at or before
+// |asOf|. The heads each branch has had are read from |roots|, the earlier roots of the database in the order they
+// were committed, e.g. from its chunk journal, so that branches which were since reset or deleted are restored too.
+// Each head's first parents are searched for the newest commit at or before |asOf|. Branches whose commits were all
+// made after |asOf| are deleted. Branches that don't change are omitted.
+func BranchesAsOf(ctx context.Context, ddb *doltdb.DoltDB, roots []hash.Hash, asOf time.Time) ([]BranchRestore, error) {
+	current := make(map[string]hash.Hash)
+	heads := make(map[string][]hash.Hash)
+	addHead := func(b ref.DoltRef, h hash.Hash) {
+		for _, seen := range heads[b.GetPath()] {
+			if seen == h {
+				return
+			}
+		}
+		heads[b.GetPath()] = append(heads[b.GetPath()], h)
+	}
+
+	for _, root := range roots {
+		refs, err := ddb.GetBranchesByRootHash(ctx, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the branches of root %s: %w", root.String(), err)
+		}
+		for _, r := range refs {
+			if r.Ref.GetType() == ref.BranchRefType {
+				addHead(r.Ref, r.Hash)
+			}
+		}
+	}
+
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		current[b.Ref.GetPath()] = b.Hash
+		addHead(b.Ref, b.Hash)
+	}
+
+	s := asOfSearch{ddb: ddb, asOf: asOf, found: make(map[hash.Hash]asOfCommit)}
+	var restores []BranchRestore
+	for name, hs := range heads {
+		var newest asOfCommit
+		for _, h := range hs {
+			c, err := s.newestAsOf(ctx, h)
+			if err != nil {
+				return nil, err
+			}
+			if !c.addr.IsEmpty() && (newest.addr.IsEmpty() || c.time.After(newest.time)) {
+				newest = c
+			}
+		}
+		if newest.addr == current[name] {
+			continue
+		}
+		restores = append(restores, BranchRestore{
+			Branch: ref.NewBranchRef(name),
+			From:   current[name],
+			To:     newest.addr,
+			ToTime: newest.time,
+		})
+	}
+
+	sort.Slice(restores, func(i, j int) bool {
+		return restores[i].Branch.GetPath() < restores[j].Branch.GetPath()
+	})
+	return restores, nil
+}
+
+// RestoreBranches applies |restores| to |ddb| atomically. The working set of each restored branch is reset to its new
+// head, discarding any uncommitted changes.
+func RestoreBranches(ctx context.Context, ddb *doltdb.DoltDB, restores []BranchRestore) error {
+	updates := make([]doltdb.BranchUpdate, len(restores))
+	for i, r := range restores {
+		updates[i].Branch = r.Branch
+		if r.To.IsEmpty() {
+			continue
+		}
+		cm, err := ddb.ReadCommit(ctx, r.To)
+		if err != nil {
+			return err
+		}
+		updates[i].Commit = cm
+	}
+	return ddb.UpdateBranches(ctx, updates)
+}
+
+type asOfCommit struct {
+	addr hash.Hash
+	time time.Time
+}
+
+// asOfSearch finds the newest first parent of commits made at or before a point in time, remembering the result for
+// every commit it visits, since the histories of the heads of a branch mostly overlap.
+type asOfSearch struct {
+	ddb   *doltdb.DoltDB
+	asOf  time.Time
+	found map[hash.Hash]asOfCommit
+}
+
+func (s asOfSearch) newestAsOf(ctx context.Context, h hash.Hash) (asOfCommit, error) {
+	var visited []hash.Hash
+	var result asOfCommit
+	for {
+		if c, ok := s.found[h]; ok {
+			result = c
+			break
+		}
+		cm, err := s.ddb.ReadCommit(ctx, h)
+		if err != nil {
+			return asOfCommit{}, err
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return asOfCommit{}, err
+		}
+		visited = append(visited, h)
+		if !meta.Time().After(s.asOf) {
+			result = asOfCommit{addr: h, time: meta.Time()}
+			break
+		}
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return asOfCommit{}, err
+		}
+		if len(parents) == 0 {
+			break
+		}
+		h = parents[0]
+	}
+
+	for _, v := range visited {
+		s.found[v] = result
+	}
+	return result, nil
+}
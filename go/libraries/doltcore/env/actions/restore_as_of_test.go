@@ -0,0 +1,115 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestBranchesAsOf(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+	ddb := dEnv.DoltDB
+
+	main := ref.NewBranchRef("main")
+	head, err := ddb.ResolveCommitRef(ctx, main)
+	require.NoError(t, err)
+	rv, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+
+	var roots []hash.Hash
+	snapshot := func() {
+		root, err := ddb.NomsRoot(ctx)
+		require.NoError(t, err)
+		roots = append(roots, root)
+	}
+	commit := func(b ref.BranchRef, day int) *doltdb.Commit {
+		meta, err := datas.NewCommitMetaWithUserTS("test", "test@test.com", "commit", time.Date(2024, 1, day, 10, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		cm, err := ddb.Commit(ctx, valHash, b, meta)
+		require.NoError(t, err)
+		snapshot()
+		return cm
+	}
+	addr := func(cm *doltdb.Commit) hash.Hash {
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		return h
+	}
+
+	c1 := commit(main, 1)
+	c2 := commit(main, 2)
+	old := ref.NewBranchRef("old")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, old, c2))
+	snapshot()
+	c3 := commit(main, 3)
+	// main is reset to c2 and old is deleted after January 3rd
+	require.NoError(t, ddb.SetHeadToCommit(ctx, main, c2))
+	require.NoError(t, ddb.DeleteBranch(ctx, old))
+	snapshot()
+	newer := ref.NewBranchRef("newer")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, newer, c2))
+	c5 := commit(newer, 5)
+
+	t.Run("journal", func(t *testing.T) {
+		restores, err := BranchesAsOf(ctx, ddb, roots, time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, []BranchRestore{
+			{Branch: main, From: addr(c2), To: addr(c3), ToTime: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC).Local()},
+			{Branch: newer, From: addr(c5), To: addr(c2), ToTime: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC).Local()},
+			{Branch: old, To: addr(c2), ToTime: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC).Local()},
+		}, restores)
+
+		require.NoError(t, RestoreBranches(ctx, ddb, restores))
+		for _, r := range restores {
+			cm, err := ddb.ResolveCommitRef(ctx, r.Branch)
+			require.NoError(t, err)
+			assert.Equal(t, r.To, addr(cm))
+		}
+	})
+
+	t.Run("no journal", func(t *testing.T) {
+		// without earlier roots, only the current heads' histories are searched
+		restores, err := BranchesAsOf(ctx, ddb, nil, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, restores, 3)
+		for _, r := range restores {
+			assert.Equal(t, addr(c1), r.To)
+		}
+	})
+
+	t.Run("before every commit", func(t *testing.T) {
+		restores, err := BranchesAsOf(ctx, ddb, roots, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, restores, 3)
+		for _, r := range restores {
+			assert.True(t, r.To.IsEmpty())
+		}
+	})
+}
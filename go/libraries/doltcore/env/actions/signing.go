@@ -0,0 +1,269 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// defaultGPGProgram is the program commits and tags are signed and verified with if the gpg.program config isn't set.
+const defaultGPGProgram = "gpg"
+
+// minTrustedKeyIDLen is the length of the shortest key id a trusted key can be given by, that of a long key id. Short
+// key ids are too easy to collide with.
+const minTrustedKeyIDLen = 16
+
+// gpgStatusPrefix starts each line gpg writes to its status file descriptor.
+const gpgStatusPrefix = "[GNUPG:] "
+
+// SignatureStatus is the outcome of verifying the signature of a commit or tag.
+type SignatureStatus string
+
+const (
+	// SignatureGood is the status of a valid signature made by a trusted key.
+	SignatureGood SignatureStatus = "good"
+	// SignatureUntrusted is the status of a valid signature made by a key that isn't trusted.
+	SignatureUntrusted SignatureStatus = "untrusted"
+	// SignatureBad is the status of a signature that doesn't match what it signed, can't be parsed, or was made by an
+	// expired or revoked key.
+	SignatureBad SignatureStatus = "bad"
+	// SignatureUnknownKey is the status of a signature made by a key that isn't in the gpg keyring, so that it can't
+	// be checked.
+	SignatureUnknownKey SignatureStatus = "unknown key"
+	// SignatureUnsigned is the status of a commit or tag without a signature.
+	SignatureUnsigned SignatureStatus = "unsigned"
+)
+
+// SignatureVerification is the result of verifying a signature.
+type SignatureVerification struct {
+	Status SignatureStatus
+	// Key is the fingerprint of the key that made the signature, or its key id if it isn't in the keyring. It's empty
+	// if the commit or tag is unsigned or the signature can't be parsed.
+	Key string
+}
+
+// GPGSigner signs commits and tags with a key of the gpg program, the way git does. Signatures are ASCII armored
+// detached OpenPGP signatures of the signing payload of a commit or tag, see datas.CommitSigningPayload and
+// datas.TagSigningPayload, so they can be checked with `gpg --verify` as well as with GPGVerifier.
+type GPGSigner struct {
+	// Program is the gpg program that's run, from the gpg.program config.
+	Program string
+	// KeyID is the key id, fingerprint or user id of the key that signs, from the user.signingkey config.
+	KeyID string
+}
+
+// ConfiguredGPGSigner returns the GPGSigner of the gpg.program config of |cfg| which signs with the key |keyID|, or
+// with the key of the user.signingkey config if |keyID| is empty.
+func ConfiguredGPGSigner(cfg config.ReadableConfig, keyID string) (*GPGSigner, error) {
+	if keyID == "" {
+		keyID = cfg.GetStringOrDefault(env.UserSigningKey, "")
+	}
+	if keyID == "" {
+		return nil, fmt.Errorf("cannot sign: no signing key is configured. Set one with `dolt config --global --add %s <key_id>`", env.UserSigningKey)
+	}
+	return &GPGSigner{Program: cfg.GetStringOrDefault(env.GPGProgramKey, defaultGPGProgram), KeyID: keyID}, nil
+}
+
+// Sign returns the ASCII armored detached signature of |payload|.
+func (s *GPGSigner) Sign(payload []byte) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.Program, "--status-fd=2", "-bsau", s.KeyID)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	// like git, a signature only counts if gpg reports that it made one
+	if err != nil || !strings.Contains(stderr.String(), "\n"+gpgStatusPrefix+"SIG_CREATED ") {
+		msg := strings.TrimSpace(stderr.String())
+		if err != nil && msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("%s failed to sign the data: %s", s.Program, msg)
+	}
+	return stdout.String(), nil
+}
+
+// CommitSigner returns a datas.CommitSigner that signs commits with |s|.
+func (s *GPGSigner) CommitSigner() datas.CommitSigner {
+	return s.Sign
+}
+
+// SignTagMeta signs the tag named |name| of the commit |commitAddr|, storing the signature in |meta|.
+func (s *GPGSigner) SignTagMeta(name string, commitAddr hash.Hash, meta *datas.TagMeta) error {
+	sig, err := s.Sign(datas.TagSigningPayload(name, commitAddr, meta))
+	if err != nil {
+		return err
+	}
+	meta.Signature = sig
+	return nil
+}
+
+// GPGVerifier verifies signatures made by GPGSigner with the gpg program.
+type GPGVerifier struct {
+	// Program is the gpg program that's run, from the gpg.program config.
+	Program string
+	// TrustedKeys are the fingerprints or long key ids of the keys whose signatures are good. If there are none, the keys
+	// the gpg keyring trusts fully or ultimately are, like `git verify-commit` does.
+	TrustedKeys []string
+}
+
+// ConfiguredGPGVerifier returns the GPGVerifier of the gpg.program config of |cfg| which trusts the comma separated
+// keys |trusted|, or the keys of the signing.trustedkeys config if |trusted| is empty.
+func ConfiguredGPGVerifier(cfg config.ReadableConfig, trusted string) *GPGVerifier {
+	if trusted == "" {
+		trusted = cfg.GetStringOrDefault(env.SigningTrustedKeysKey, "")
+	}
+	v := &GPGVerifier{Program: cfg.GetStringOrDefault(env.GPGProgramKey, defaultGPGProgram)}
+	for _, k := range strings.Split(trusted, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			v.TrustedKeys = append(v.TrustedKeys, k)
+		}
+	}
+	return v
+}
+
+// Verify verifies that |signature| was made over |payload|, and whether the key that made it is trusted.
+func (v *GPGVerifier) Verify(signature string, payload []byte) (SignatureVerification, error) {
+	if signature == "" {
+		return SignatureVerification{Status: SignatureUnsigned}, nil
+	}
+
+	sigFile, err := os.CreateTemp("", "dolt-signature-*.asc")
+	if err != nil {
+		return SignatureVerification{}, err
+	}
+	defer os.Remove(sigFile.Name())
+	_, err = sigFile.WriteString(signature)
+	if cerr := sigFile.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return SignatureVerification{}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(v.Program, "--status-fd=1", "--verify", sigFile.Name(), "-")
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// gpg exits with an error for bad signatures too, so its status output is what tells them apart from failures
+	runErr := cmd.Run()
+	res, ok := parseGPGVerifyStatus(stdout.String(), v.TrustedKeys)
+	if !ok {
+		if runErr != nil {
+			msg := strings.TrimSpace(stderr.String())
+			if msg == "" {
+				msg = runErr.Error()
+			}
+			if _, isExit := runErr.(*exec.ExitError); !isExit {
+				return SignatureVerification{}, fmt.Errorf("failed to run %s: %s", v.Program, msg)
+			}
+		}
+		// gpg ran, but found no signature it could check
+		return SignatureVerification{Status: SignatureBad}, nil
+	}
+	return res, nil
+}
+
+// parseGPGVerifyStatus returns the verification described by the |status| output of `gpg --verify`, and false if it
+// doesn't describe one.
+func parseGPGVerifyStatus(status string, trusted []string) (SignatureVerification, bool) {
+	var res SignatureVerification
+	var found bool
+	var fingerprints []string
+	var trustedByKeyring bool
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, gpgStatusPrefix) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, gpgStatusPrefix))
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "GOODSIG":
+			res, found = SignatureVerification{Status: SignatureUntrusted, Key: fields[1]}, true
+		case "BADSIG", "EXPSIG", "EXPKEYSIG", "REVKEYSIG":
+			return SignatureVerification{Status: SignatureBad, Key: fields[1]}, true
+		case "ERRSIG":
+			res, found = SignatureVerification{Status: SignatureUnknownKey, Key: fields[1]}, true
+		case "VALIDSIG":
+			// the fingerprint of the signing key, and the one of its primary key last
+			fingerprints = append(fingerprints, fields[1], fields[len(fields)-1])
+		case "TRUST_FULLY", "TRUST_ULTIMATE":
+			trustedByKeyring = true
+		}
+	}
+	if !found || res.Status != SignatureUntrusted {
+		return res, found
+	}
+	if len(fingerprints) > 0 {
+		res.Key = fingerprints[0]
+	}
+	if len(trusted) == 0 {
+		if trustedByKeyring {
+			res.Status = SignatureGood
+		}
+		return res, true
+	}
+	for _, t := range trusted {
+		t = strings.ToUpper(strings.TrimPrefix(strings.ReplaceAll(t, " ", ""), "0x"))
+		if len(t) < minTrustedKeyIDLen {
+			continue
+		}
+		for _, fpr := range fingerprints {
+			// key ids are the last digits of fingerprints
+			if strings.HasSuffix(strings.ToUpper(fpr), t) {
+				res.Status = SignatureGood
+				return res, true
+			}
+		}
+	}
+	return res, true
+}
+
+// VerifyCommitSignature verifies the signature of |cm| with |v|.
+func VerifyCommitSignature(cm *doltdb.Commit, v *GPGVerifier) (SignatureVerification, error) {
+	sig, payload, err := datas.GetCommitSignature(cm.Value())
+	if err != nil {
+		return SignatureVerification{}, err
+	}
+	return v.Verify(sig, payload)
+}
+
+// VerifyTagSignature verifies the signature of |t| with |v|.
+func VerifyTagSignature(t *doltdb.Tag, v *GPGVerifier) (SignatureVerification, error) {
+	if t.Meta.Signature == "" {
+		return SignatureVerification{Status: SignatureUnsigned}, nil
+	}
+	addr, err := t.Commit.HashOf()
+	if err != nil {
+		return SignatureVerification{}, err
+	}
+	return v.Verify(t.Meta.Signature, datas.TagSigningPayload(t.Name, addr, t.Meta))
+}
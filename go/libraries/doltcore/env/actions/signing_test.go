@@ -0,0 +1,179 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// newTestGPGKey generates a gpg key for |uid| in the keyring of the test, returning its fingerprint.
+func newTestGPGKey(t *testing.T, uid string) string {
+	out, err := exec.Command("gpg", "--batch", "--passphrase", "", "--quick-gen-key", uid, "ed25519", "sign", "never").CombinedOutput()
+	require.NoError(t, err, string(out))
+	out, err = exec.Command("gpg", "--with-colons", "--list-keys", uid).Output()
+	require.NoError(t, err)
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "fpr:") {
+			return strings.Split(line, ":")[9]
+		}
+	}
+	require.Fail(t, "no fingerprint for "+uid)
+	return ""
+}
+
+func TestSignedCommitsAndTags(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not installed")
+	}
+	t.Setenv("GNUPGHOME", t.TempDir())
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--kill", "gpg-agent").Run()
+	})
+	keyFpr := newTestGPGKey(t, "Signer <signer@test.com>")
+	otherFpr := newTestGPGKey(t, "Other <other@test.com>")
+	signer := &GPGSigner{Program: "gpg", KeyID: keyFpr}
+
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+	ddb := dEnv.DoltDB
+
+	main := ref.NewBranchRef("main")
+	head, err := ddb.ResolveCommitRef(ctx, main)
+	require.NoError(t, err)
+	rv, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+	val, err := ddb.ValueReadWriter().ReadValue(ctx, valHash)
+	require.NoError(t, err)
+	headHash, err := head.HashOf()
+	require.NoError(t, err)
+
+	commit := func(msg string, signer datas.CommitSigner) *doltdb.Commit {
+		meta, err := datas.NewCommitMeta("test", "test@test.com", msg)
+		require.NoError(t, err)
+		cm, err := ddb.CommitDangling(ctx, val, datas.CommitOptions{Parents: []hash.Hash{headHash}, Meta: meta, Signer: signer})
+		require.NoError(t, err)
+		return cm
+	}
+	trusting := func(keys ...string) *GPGVerifier {
+		return &GPGVerifier{Program: "gpg", TrustedKeys: keys}
+	}
+
+	t.Run("commits", func(t *testing.T) {
+		v, err := VerifyCommitSignature(commit("unsigned", nil), trusting(keyFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureVerification{Status: SignatureUnsigned}, v)
+
+		signed := commit("signed", signer.CommitSigner())
+		v, err = VerifyCommitSignature(signed, trusting(otherFpr, keyFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureVerification{Status: SignatureGood, Key: keyFpr}, v)
+
+		// long key ids are the last 16 digits of fingerprints
+		v, err = VerifyCommitSignature(signed, trusting(keyFpr[len(keyFpr)-16:]))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureGood, v.Status)
+
+		v, err = VerifyCommitSignature(signed, trusting(otherFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureVerification{Status: SignatureUntrusted, Key: keyFpr}, v)
+
+		// without trusted keys, the keyring's trust is used, and it ultimately trusts the keys it generated
+		v, err = VerifyCommitSignature(signed, trusting())
+		require.NoError(t, err)
+		assert.Equal(t, SignatureVerification{Status: SignatureGood, Key: keyFpr}, v)
+
+		// a signature copied from another commit doesn't verify
+		meta, err := signed.GetCommitMeta(ctx)
+		require.NoError(t, err)
+		forged := commit("forged", func(payload []byte) (string, error) {
+			return meta.Signature, nil
+		})
+		v, err = VerifyCommitSignature(forged, trusting(keyFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureBad, v.Status)
+
+		_, err = (&GPGSigner{Program: "gpg", KeyID: "nobody@test.com"}).Sign([]byte("payload"))
+		assert.Error(t, err)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		err := CreateTagOnDB(ctx, ddb, "signed", "main", TagProps{TaggerName: "test", TaggerEmail: "test@test.com", Description: "signed", Signer: signer}, main)
+		require.NoError(t, err)
+		err = CreateTagOnDB(ctx, ddb, "unsigned", "main", TagProps{TaggerName: "test", TaggerEmail: "test@test.com", Description: "unsigned"}, main)
+		require.NoError(t, err)
+
+		signed, err := ddb.ResolveTag(ctx, ref.NewTagRef("signed"))
+		require.NoError(t, err)
+		v, err := VerifyTagSignature(signed, trusting(keyFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureVerification{Status: SignatureGood, Key: keyFpr}, v)
+
+		// the signature covers the tag's name
+		signed.Name = "renamed"
+		v, err = VerifyTagSignature(signed, trusting(keyFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureBad, v.Status)
+
+		unsigned, err := ddb.ResolveTag(ctx, ref.NewTagRef("unsigned"))
+		require.NoError(t, err)
+		v, err = VerifyTagSignature(unsigned, trusting(keyFpr))
+		require.NoError(t, err)
+		assert.Equal(t, SignatureUnsigned, v.Status)
+	})
+}
+
+func TestParseGPGVerifyStatus(t *testing.T) {
+	const fpr = "D961E57E34B8647F714F06123F973F9B3088B78E"
+	good := `[GNUPG:] NEWSIG
+[GNUPG:] GOODSIG 3F973F9B3088B78E Test <t@t.com>
+[GNUPG:] VALIDSIG ` + fpr + ` 2023-05-01 1682899200 0 4 0 22 8 00 ` + fpr + `
+[GNUPG:] TRUST_UNDEFINED 0 pgp
+`
+	v, ok := parseGPGVerifyStatus(good, nil)
+	assert.True(t, ok)
+	assert.Equal(t, SignatureVerification{Status: SignatureUntrusted, Key: fpr}, v)
+
+	// short key ids aren't trusted
+	v, _ = parseGPGVerifyStatus(good, []string{"3088B78E"})
+	assert.Equal(t, SignatureUntrusted, v.Status)
+	v, _ = parseGPGVerifyStatus(good, []string{"0x3F973F9B3088B78E"})
+	assert.Equal(t, SignatureGood, v.Status)
+
+	v, ok = parseGPGVerifyStatus("[GNUPG:] ERRSIG 3F973F9B3088B78E 22 8 00 1682899200 9 -\n[GNUPG:] NO_PUBKEY 3F973F9B3088B78E\n", nil)
+	assert.True(t, ok)
+	assert.Equal(t, SignatureVerification{Status: SignatureUnknownKey, Key: "3F973F9B3088B78E"}, v)
+
+	v, ok = parseGPGVerifyStatus("[GNUPG:] EXPKEYSIG 3F973F9B3088B78E Test <t@t.com>\n", nil)
+	assert.True(t, ok)
+	assert.Equal(t, SignatureBad, v.Status)
+
+	_, ok = parseGPGVerifyStatus("[GNUPG:] NODATA 1\n", nil)
+	assert.False(t, ok)
+}
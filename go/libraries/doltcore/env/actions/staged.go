@@ -78,6 +78,11 @@ func stageTables(
 		return doltdb.Roots{}, err
 	}
 
+	roots.Staged, tbls, err = stageTablesWithIgnoredColumns(ctx, tbls, roots.Working, roots.Staged)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
 	roots.Staged, err = MoveTablesBetweenRoots(ctx, tbls, roots.Working, roots.Staged)
 	if err != nil {
 		return doltdb.Roots{}, err
@@ -29,6 +29,8 @@ type TagProps struct {
 	TaggerName  string
 	TaggerEmail string
 	Description string
+	// Signer, if set, signs the tag.
+	Signer *GPGSigner
 }
 
 func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startPoint string, props TagProps) error {
@@ -65,6 +67,15 @@ func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint
 	}
 
 	meta := datas.NewTagMeta(props.TaggerName, props.TaggerEmail, props.Description)
+	if props.Signer != nil {
+		addr, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		if err = props.Signer.SignTagMeta(tagName, addr, meta); err != nil {
+			return err
+		}
+	}
 
 	return ddb.NewTagAtCommit(ctx, tagRef, cm, meta)
 }
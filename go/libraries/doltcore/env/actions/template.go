@@ -0,0 +1,80 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrTemplateHasNoBranches = errors.New("template database has no branches")
+
+// ApplyTemplate copies the tables of the default branch of |template|, including system tables such as dolt_ignore,
+// dolt_query_catalog and dolt_schemas, to the newly initialized database of |dEnv|. They're committed to its current
+// branch with the metadata |meta| and become its staged and working tables. The history of the template isn't copied.
+func ApplyTemplate(ctx context.Context, dEnv *env.DoltEnv, template *doltdb.DoltDB, meta *datas.CommitMeta) error {
+	if template.Format() != dEnv.DoltDB.Format() {
+		return fmt.Errorf("template database uses the storage format %s, but the new database uses %s",
+			template.Format().VersionString(), dEnv.DoltDB.Format().VersionString())
+	}
+
+	branches, err := template.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		return ErrTemplateHasNoBranches
+	}
+
+	cm, err := template.ResolveCommitRef(ctx, ref.NewBranchRef(env.GetDefaultBranch(dEnv, branches)))
+	if err != nil {
+		return err
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	h, err := root.HashOf()
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+	err = dEnv.DoltDB.PullChunks(ctx, tmpDir, template, []hash.Hash{h}, nil)
+	if err != nil {
+		return err
+	}
+
+	root, err = dEnv.DoltDB.ReadRootValue(ctx, h)
+	if err != nil {
+		return err
+	}
+	_, err = dEnv.DoltDB.Commit(ctx, h, dEnv.RepoStateReader().CWBHeadRef(), meta)
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateRoots(ctx, doltdb.Roots{Head: root, Working: root, Staged: root})
+}
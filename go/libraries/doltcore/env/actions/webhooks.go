@@ -0,0 +1,482 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// WebhookEventType is the kind of change a webhook is sent for.
+type WebhookEventType string
+
+const (
+	// WebhookCommitEvent is sent when a branch moves to a commit that isn't a merge.
+	WebhookCommitEvent WebhookEventType = "commit"
+	// WebhookMergeEvent is sent when a branch moves to a merge commit.
+	WebhookMergeEvent WebhookEventType = "merge"
+	// WebhookBranchEvent is sent when a branch is created or deleted.
+	WebhookBranchEvent WebhookEventType = "branch"
+	// WebhookTagEvent is sent when a tag is created or deleted.
+	WebhookTagEvent WebhookEventType = "tag"
+)
+
+// WebhookAction is what happened to the ref of a webhook.
+type WebhookAction string
+
+const (
+	WebhookCreate WebhookAction = "create"
+	WebhookUpdate WebhookAction = "update"
+	WebhookDelete WebhookAction = "delete"
+)
+
+// WebhookSignatureHeader is the header of the HMAC-SHA256 of the body of webhooks with a secret, as sha256=<hex>.
+const WebhookSignatureHeader = "X-Dolt-Signature-256"
+
+// WebhookEventHeader is the header of the event type of webhooks.
+const WebhookEventHeader = "X-Dolt-Event"
+
+const (
+	defaultWebhookMaxAttempts = 3
+	webhookQueueSize          = 1024
+	webhookTimeout            = 10 * time.Second
+)
+
+// WebhookConfig is the configuration of a URL that webhooks are POSTed to.
+type WebhookConfig struct {
+	URL string
+	// Events are the events sent to the URL. If it's empty, every event is sent.
+	Events []WebhookEventType
+	// Secret, if it isn't empty, is the key the body of each webhook is signed with in the WebhookSignatureHeader.
+	Secret string
+	// MaxAttempts is the number of times a webhook is tried before it's recorded as a dead letter. If it's zero, a
+	// webhook is tried 3 times.
+	MaxAttempts int
+}
+
+// Validate returns an error if the config's URL isn't an http or https URL, or if it has an unknown event.
+func (wc WebhookConfig) Validate() error {
+	u, err := url.Parse(wc.URL)
+	if err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url: %q is not an http or https URL", wc.URL)
+	}
+	for _, e := range wc.Events {
+		switch e {
+		case WebhookCommitEvent, WebhookMergeEvent, WebhookBranchEvent, WebhookTagEvent:
+		default:
+			return fmt.Errorf("events: unknown event %q, must be one of commit, merge, branch or tag", e)
+		}
+	}
+	if wc.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts: is %d but cannot be negative", wc.MaxAttempts)
+	}
+	return nil
+}
+
+func (wc WebhookConfig) sends(event WebhookEventType) bool {
+	if len(wc.Events) == 0 {
+		return true
+	}
+	for _, e := range wc.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (wc WebhookConfig) maxAttempts() int {
+	if wc.MaxAttempts == 0 {
+		return defaultWebhookMaxAttempts
+	}
+	return wc.MaxAttempts
+}
+
+// WebhookAuthor is the author of the commit, or the tagger of the tag, of a webhook.
+type WebhookAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookPayload is the JSON body of a webhook.
+type WebhookPayload struct {
+	Event    WebhookEventType `json:"event"`
+	Action   WebhookAction    `json:"action"`
+	Database string           `json:"database"`
+	Ref      string           `json:"ref"`
+	// OldHash is the commit the ref pointed at before the change, or empty if it was created.
+	OldHash string `json:"old_hash"`
+	// NewHash is the commit the ref points at after the change, or empty if it was deleted.
+	NewHash string         `json:"new_hash"`
+	Author  *WebhookAuthor `json:"author,omitempty"`
+	Message string         `json:"message,omitempty"`
+	// TablesChanged are the tables that differ between the old and new commits of commit and merge events.
+	TablesChanged []string  `json:"tables_changed"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// webhookChange is a change of a ref observed by a WebhookDispatcher's commit hook, which is turned into a payload
+// by the dispatcher's worker.
+type webhookChange struct {
+	database string
+	ddb      *doltdb.DoltDB
+	fs       filesys.ReadWriteFS
+	ref      ref.DoltRef
+	old, new hash.Hash
+	tag      *datas.TagMeta
+	at       time.Time
+}
+
+// WebhookDispatcher POSTs webhooks to a set of URLs when the refs of the databases it's hooked into change. Webhooks
+// are sent in the background, in the order their changes were made, by a single worker. A webhook that still fails
+// after the max attempts of its URL, with an exponential backoff between attempts, is recorded in the dead letters of
+// its database, as is every webhook of a change that's made while the dispatcher's queue is full.
+type WebhookDispatcher struct {
+	configs []WebhookConfig
+	queue   chan webhookChange
+	client  *http.Client
+	backoff time.Duration
+	now     func() time.Time
+}
+
+// NewWebhookDispatcher returns a WebhookDispatcher that sends webhooks to every URL of |configs|.
+func NewWebhookDispatcher(configs []WebhookConfig) (*WebhookDispatcher, error) {
+	for _, wc := range configs {
+		if err := wc.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &WebhookDispatcher{
+		configs: configs,
+		queue:   make(chan webhookChange, webhookQueueSize),
+		client:  &http.Client{Timeout: webhookTimeout},
+		backoff: time.Second,
+		now:     time.Now,
+	}, nil
+}
+
+// Run sends the webhooks of every change made to the hooked databases until |ctx| is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case c := <-d.queue:
+			d.dispatch(ctx, c)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CommitHook returns a commit hook which queues the webhooks of the changes to the branches and tags of |ddb|, the
+// database named |database| whose files are in |fs|.
+func (d *WebhookDispatcher) CommitHook(ctx context.Context, database string, ddb *doltdb.DoltDB, fs filesys.ReadWriteFS) (doltdb.CommitHook, error) {
+	heads := make(map[string]hash.Hash)
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		heads[b.Ref.String()] = b.Hash
+	}
+	tags, err := ddb.GetTagsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		heads[t.Tag.GetDoltRef().String()] = t.Hash
+	}
+	return &webhookHook{d: d, database: database, ddb: ddb, fs: fs, heads: heads}, nil
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context, c webhookChange) {
+	payload, err := d.payload(ctx, c)
+	if err != nil {
+		d.deadLetterAll(c, err)
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	for _, wc := range d.configs {
+		if !wc.sends(payload.Event) {
+			continue
+		}
+		attempts, err := d.deliver(ctx, wc, payload.Event, body)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			d.deadLetter(c, wc, payload.Event, body, attempts, err)
+		}
+	}
+}
+
+// payload returns the payload of the webhook of |c|.
+func (d *WebhookDispatcher) payload(ctx context.Context, c webhookChange) (WebhookPayload, error) {
+	p := WebhookPayload{
+		Database:      c.database,
+		Ref:           c.ref.String(),
+		TablesChanged: []string{},
+		Timestamp:     c.at.UTC(),
+	}
+	if !c.old.IsEmpty() {
+		p.OldHash = c.old.String()
+	}
+	if !c.new.IsEmpty() {
+		p.NewHash = c.new.String()
+	}
+	switch {
+	case c.new.IsEmpty():
+		p.Action = WebhookDelete
+	case c.old.IsEmpty():
+		p.Action = WebhookCreate
+	default:
+		p.Action = WebhookUpdate
+	}
+
+	if c.ref.GetType() == ref.TagRefType {
+		p.Event = WebhookTagEvent
+		if c.tag != nil {
+			p.Author = &WebhookAuthor{Name: c.tag.Name, Email: c.tag.Email}
+			p.Message = c.tag.Description
+		}
+		return p, nil
+	}
+
+	p.Event = WebhookBranchEvent
+	if p.Action == WebhookDelete {
+		return p, nil
+	}
+	cm, err := c.ddb.ReadCommit(ctx, c.new)
+	if err != nil {
+		return WebhookPayload{}, err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return WebhookPayload{}, err
+	}
+	p.Author = &WebhookAuthor{Name: meta.Name, Email: meta.Email}
+	p.Message = meta.Description
+	if p.Action == WebhookCreate {
+		return p, nil
+	}
+
+	p.Event = WebhookCommitEvent
+	if cm.NumParents() > 1 {
+		p.Event = WebhookMergeEvent
+	}
+	old, err := c.ddb.ReadCommit(ctx, c.old)
+	if err != nil {
+		return WebhookPayload{}, err
+	}
+	oldRoot, err := old.GetRootValue(ctx)
+	if err != nil {
+		return WebhookPayload{}, err
+	}
+	newRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return WebhookPayload{}, err
+	}
+	deltas, err := diff.GetTableDeltas(ctx, oldRoot, newRoot)
+	if err != nil {
+		return WebhookPayload{}, err
+	}
+	for _, td := range deltas {
+		changed, err := td.HasChanges()
+		if err != nil {
+			return WebhookPayload{}, err
+		}
+		if changed {
+			p.TablesChanged = append(p.TablesChanged, td.CurName())
+		}
+	}
+	return p, nil
+}
+
+// deliver POSTs |body| to the URL of |wc| until it's accepted or the max attempts of |wc| are made. It returns the
+// number of attempts made, and the error of the last if none succeeded.
+func (d *WebhookDispatcher) deliver(ctx context.Context, wc WebhookConfig, event WebhookEventType, body []byte) (int, error) {
+	var err error
+	backoff := d.backoff
+	for attempt := 1; ; attempt++ {
+		err = d.post(ctx, wc, event, body)
+		if err == nil || attempt == wc.maxAttempts() {
+			return attempt, err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+func (d *WebhookDispatcher) post(ctx context.Context, wc WebhookConfig, event WebhookEventType, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wc.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(event))
+	if wc.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(wc.Secret))
+		mac.Write(body)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s responded with status %s", wc.URL, resp.Status)
+	}
+	return nil
+}
+
+// deadLetterAll records the webhooks of |c| that couldn't be made as dead letters, for every URL they'd be sent to.
+// Merges can't be told apart from other commits without reading the new commit, so they're recorded as commits.
+func (d *WebhookDispatcher) deadLetterAll(c webhookChange, err error) {
+	event := WebhookCommitEvent
+	if c.ref.GetType() == ref.TagRefType {
+		event = WebhookTagEvent
+	} else if c.old.IsEmpty() || c.new.IsEmpty() {
+		event = WebhookBranchEvent
+	}
+	for _, wc := range d.configs {
+		if wc.sends(event) || (event == WebhookCommitEvent && wc.sends(WebhookMergeEvent)) {
+			d.deadLetter(c, wc, event, nil, 0, err)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deadLetter(c webhookChange, wc WebhookConfig, event WebhookEventType, body []byte, attempts int, err error) {
+	// the dead letter is all that's left of the webhook, so there's nothing more to do if it can't be written
+	_ = env.AppendWebhookDeadLetter(c.fs, env.WebhookDeadLetter{
+		URL:      wc.URL,
+		Event:    string(event),
+		Ref:      c.ref.String(),
+		Payload:  string(body),
+		Attempts: attempts,
+		Error:    err.Error(),
+		FailedAt: d.now().UTC(),
+	})
+}
+
+// errWebhookQueueFull is the error of the dead letters of the changes made while a WebhookDispatcher's queue is full.
+var errWebhookQueueFull = errors.New("webhook queue is full")
+
+// webhookHook is the commit hook of a WebhookDispatcher for one database. It keeps the heads of the database's
+// branches and tags, to find the old head of each ref that changes.
+type webhookHook struct {
+	d        *WebhookDispatcher
+	database string
+	ddb      *doltdb.DoltDB
+	fs       filesys.ReadWriteFS
+
+	mu    sync.Mutex
+	heads map[string]hash.Hash
+	out   io.Writer
+}
+
+var _ doltdb.CommitHook = (*webhookHook)(nil)
+
+// Execute implements doltdb.CommitHook. It queues the change of |ds| if it's a branch or tag whose head moved.
+func (h *webhookHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) error {
+	r, err := ref.Parse(ds.ID())
+	if err != nil || (r.GetType() != ref.BranchRefType && r.GetType() != ref.TagRefType) {
+		return nil
+	}
+
+	c := webhookChange{database: h.database, ddb: h.ddb, fs: h.fs, ref: r, at: h.d.now()}
+	if ds.IsTag() {
+		c.tag, c.new, err = ds.HeadTag()
+		if err != nil {
+			return err
+		}
+	} else if addr, ok := ds.MaybeHeadAddr(); ok {
+		c.new = addr
+	}
+
+	h.mu.Lock()
+	c.old = h.heads[r.String()]
+	if c.old == c.new {
+		h.mu.Unlock()
+		return nil
+	}
+	if c.new.IsEmpty() {
+		delete(h.heads, r.String())
+	} else {
+		h.heads[r.String()] = c.new
+	}
+	h.mu.Unlock()
+
+	select {
+	case h.d.queue <- c:
+	default:
+		h.d.deadLetterAll(c, errWebhookQueueFull)
+	}
+	return nil
+}
+
+// HandleError implements doltdb.CommitHook
+func (h *webhookHook) HandleError(ctx context.Context, err error) error {
+	if h.out != nil {
+		_, err := h.out.Write([]byte(fmt.Sprintf("error queueing webhook: %+v", err)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLogger implements doltdb.CommitHook
+func (h *webhookHook) SetLogger(ctx context.Context, wr io.Writer) error {
+	h.out = wr
+	return nil
+}
+
+// ExecuteForWorkingSets implements doltdb.CommitHook
+func (h *webhookHook) ExecuteForWorkingSets() bool {
+	return false
+}
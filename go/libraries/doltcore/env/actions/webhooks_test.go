@@ -0,0 +1,202 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestWebhookConfigValidate(t *testing.T) {
+	assert.NoError(t, WebhookConfig{URL: "https://example.com/hook", Events: []WebhookEventType{WebhookCommitEvent, WebhookTagEvent}}.Validate())
+	assert.Error(t, WebhookConfig{URL: "ftp://example.com/hook"}.Validate())
+	assert.Error(t, WebhookConfig{URL: "example.com"}.Validate())
+	assert.Error(t, WebhookConfig{URL: "http://example.com", Events: []WebhookEventType{"push"}}.Validate())
+	assert.Error(t, WebhookConfig{URL: "http://example.com", MaxAttempts: -1}.Validate())
+}
+
+type webhookRequest struct {
+	event     string
+	signature string
+	payload   WebhookPayload
+	body      []byte
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var received []webhookRequest
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := webhookRequest{event: r.Header.Get(WebhookEventHeader), signature: r.Header.Get(WebhookSignatureHeader), body: body}
+		require.NoError(t, json.Unmarshal(body, &req.payload))
+		mu.Lock()
+		received = append(received, req)
+		mu.Unlock()
+	}))
+	defer ok.Close()
+	var failures int
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		failures++
+		mu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+	ddb := dEnv.DoltDB
+
+	d, err := NewWebhookDispatcher([]WebhookConfig{
+		{URL: ok.URL, Secret: "secret"},
+		{URL: failing.URL, Events: []WebhookEventType{WebhookTagEvent}, MaxAttempts: 2},
+	})
+	require.NoError(t, err)
+	d.backoff = time.Millisecond
+	hook, err := d.CommitHook(ctx, "db", ddb, dEnv.FS)
+	require.NoError(t, err)
+	ddb.PrependCommitHook(ctx, hook)
+	go d.Run(ctx)
+
+	waitFor := func(n int) []webhookRequest {
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(received) >= n
+		}, 5*time.Second, 10*time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest(nil), received...)
+	}
+
+	main := ref.NewBranchRef("main")
+	head, err := ddb.ResolveCommitRef(ctx, main)
+	require.NoError(t, err)
+	headHash, err := head.HashOf()
+	require.NoError(t, err)
+
+	err = ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), head)
+	require.NoError(t, err)
+	reqs := waitFor(1)
+	assert.Equal(t, "branch", reqs[0].event)
+	assert.Equal(t, WebhookBranchEvent, reqs[0].payload.Event)
+	assert.Equal(t, WebhookCreate, reqs[0].payload.Action)
+	assert.Equal(t, "db", reqs[0].payload.Database)
+	assert.Equal(t, "refs/heads/feature", reqs[0].payload.Ref)
+	assert.Equal(t, "", reqs[0].payload.OldHash)
+	assert.Equal(t, headHash.String(), reqs[0].payload.NewHash)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(reqs[0].body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), reqs[0].signature)
+
+	commit := func(parents ...hash.Hash) hash.Hash {
+		rv, err := head.GetRootValue(ctx)
+		require.NoError(t, err)
+		rv, err = rv.PutTable(ctx, "t", mustTable(t, ctx, rv))
+		require.NoError(t, err)
+		_, valHash, err := ddb.WriteRootValue(ctx, rv)
+		require.NoError(t, err)
+		val, err := ddb.ValueReadWriter().ReadValue(ctx, valHash)
+		require.NoError(t, err)
+		meta, err := datas.NewCommitMeta("bob", "bob@example.com", "add t")
+		require.NoError(t, err)
+		cm, err := ddb.CommitDangling(ctx, val, datas.CommitOptions{Parents: parents, Meta: meta})
+		require.NoError(t, err)
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		return h
+	}
+
+	added := commit(headHash)
+	require.NoError(t, ddb.SetHead(ctx, main, added))
+	reqs = waitFor(2)
+	assert.Equal(t, WebhookCommitEvent, reqs[1].payload.Event)
+	assert.Equal(t, WebhookUpdate, reqs[1].payload.Action)
+	assert.Equal(t, headHash.String(), reqs[1].payload.OldHash)
+	assert.Equal(t, added.String(), reqs[1].payload.NewHash)
+	assert.Equal(t, &WebhookAuthor{Name: "bob", Email: "bob@example.com"}, reqs[1].payload.Author)
+	assert.Equal(t, "add t", reqs[1].payload.Message)
+	assert.Equal(t, []string{"t"}, reqs[1].payload.TablesChanged)
+
+	merged := commit(added, headHash)
+	require.NoError(t, ddb.SetHead(ctx, ref.NewBranchRef("feature"), merged))
+	reqs = waitFor(3)
+	assert.Equal(t, WebhookMergeEvent, reqs[2].payload.Event)
+	assert.Equal(t, []string{"t"}, reqs[2].payload.TablesChanged)
+
+	require.NoError(t, ddb.DeleteBranch(ctx, ref.NewBranchRef("feature")))
+	reqs = waitFor(4)
+	assert.Equal(t, WebhookBranchEvent, reqs[3].payload.Event)
+	assert.Equal(t, WebhookDelete, reqs[3].payload.Action)
+	assert.Equal(t, merged.String(), reqs[3].payload.OldHash)
+	assert.Equal(t, "", reqs[3].payload.NewHash)
+
+	// the tag is sent to both URLs, and the failing one records a dead letter
+	err = CreateTagOnDB(ctx, ddb, "v1", "main", TagProps{TaggerName: "alice", TaggerEmail: "alice@example.com", Description: "v1"}, main)
+	require.NoError(t, err)
+	reqs = waitFor(5)
+	assert.Equal(t, WebhookTagEvent, reqs[4].payload.Event)
+	assert.Equal(t, "refs/tags/v1", reqs[4].payload.Ref)
+	assert.Equal(t, added.String(), reqs[4].payload.NewHash)
+	assert.Equal(t, &WebhookAuthor{Name: "alice", Email: "alice@example.com"}, reqs[4].payload.Author)
+
+	var letters []env.WebhookDeadLetter
+	require.Eventually(t, func() bool {
+		letters, err = env.LoadWebhookDeadLetters(dEnv.FS)
+		require.NoError(t, err)
+		return len(letters) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, letters, 1)
+	assert.Equal(t, failing.URL, letters[0].URL)
+	assert.Equal(t, "tag", letters[0].Event)
+	assert.Equal(t, "refs/tags/v1", letters[0].Ref)
+	assert.Equal(t, 2, letters[0].Attempts)
+	assert.Contains(t, letters[0].Error, "500")
+	assert.JSONEq(t, string(reqs[4].body), letters[0].Payload)
+	mu.Lock()
+	assert.Equal(t, 2, failures)
+	mu.Unlock()
+}
+
+func mustTable(t *testing.T, ctx context.Context, rv *doltdb.RootValue) *doltdb.Table {
+	sch, err := dtestutils.Schema()
+	require.NoError(t, err)
+	tbl, err := doltdb.NewEmptyTable(ctx, rv.VRW(), rv.NodeStore(), sch)
+	require.NoError(t, err)
+	return tbl
+}
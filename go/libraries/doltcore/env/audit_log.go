@@ -0,0 +1,100 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// AuditLogEntry records a statement which changed the rows or the schema of a table.
+type AuditLogEntry struct {
+	User      string    `json:"user"`
+	Timestamp time.Time `json:"timestamp"`
+	Branch    string    `json:"branch"`
+	// Operation is INSERT, UPDATE, DELETE, REPLACE or TRUNCATE for the statements which changed rows, and DDL for the
+	// statements which changed the schema.
+	Operation string `json:"operation"`
+	Table     string `json:"table"`
+	Query     string `json:"query"`
+	Rows      int64  `json:"rows"`
+}
+
+// auditLogMu serializes the appends to audit logs, so that the entries of concurrent statements don't interleave.
+var auditLogMu sync.Mutex
+
+func getAuditLogFile() string {
+	return filepath.Join(dbfactory.DoltDir, auditLogFile)
+}
+
+// LoadAuditLog returns the audit log of the repository at the root of |fs|, oldest first.
+func LoadAuditLog(fs filesys.ReadableFS) ([]AuditLogEntry, error) {
+	path := getAuditLogFile()
+	if exists, _ := fs.Exists(path); !exists {
+		return nil, nil
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []AuditLogEntry
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var entry AuditLogEntry
+		err = dec.Decode(&entry)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// AppendAuditLog adds |entries| to the audit log of the repository at the root of |fs|. The log is only ever appended
+// to, one JSON object per line.
+func AppendAuditLog(fs filesys.WritableFS, entries ...AuditLogEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	auditLogMu.Lock()
+	defer auditLogMu.Unlock()
+
+	wr, err := fs.OpenForWriteAppend(getAuditLogFile(), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	_, err = wr.Write(buf.Bytes())
+	if cerr := wr.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
@@ -32,6 +33,12 @@ const (
 
 	UserEmailKey = "user.email"
 	UserNameKey  = "user.name"
+	// UserSigningKey is the gpg key id that commits and tags are signed with.
+	UserSigningKey = "user.signingkey"
+	// GPGProgramKey is the gpg program commits and tags are signed and verified with, gpg if it isn't set.
+	GPGProgramKey = "gpg.program"
+	// SigningTrustedKeysKey is a comma separated list of the fingerprints of the gpg keys whose signatures are trusted.
+	SigningTrustedKeysKey = "signing.trustedkeys"
 
 	// should be able to have remote specific creds?
 	UserCreds = "user.creds"
@@ -52,6 +59,27 @@ const (
 	MetricsInsecure = "metrics.insecure"
 
 	PushAutoSetupRemote = "push.autosetupremote"
+	// PushRequireLicenseKey is a comma separated list of the remotes, or * for every remote, which are only pushed to
+	// when every table of the pushed commit has a license in its provenance.
+	PushRequireLicenseKey = "push.requirelicense"
+
+	// IgnorePatternsKey is a comma separated list of dolt_ignore patterns. Set in the global config, it applies to every
+	// database, and set in the local config, it applies to the database and overrides the global config.
+	IgnorePatternsKey = "ignore.patterns"
+	// IgnoreBranchPatternsKeyPrefix followed by a branch name is a comma separated list of dolt_ignore patterns which
+	// apply to the branch, and override the patterns of IgnorePatternsKey.
+	IgnoreBranchPatternsKeyPrefix = "ignore.branch."
+
+	// DocsDataDictionaryKey, if true, regenerates the data dictionary doc of a database whenever a commit is made, so
+	// that every commit holds the dictionary of its own schemas.
+	DocsDataDictionaryKey = "docs.datadictionary"
+
+	// ProtectedBranchesKey is a comma separated list of the branches, or * for every branch, which can't be deleted or
+	// moved to a commit which doesn't descend from their commit, by pushes and by the remotesapi of sql-server.
+	ProtectedBranchesKey = "branch.protected"
+	// NoConflictsBranchesKey is a comma separated list of the branches, or * for every branch, which can't be moved to
+	// a commit with unresolved merge conflicts by pushes and by the remotesapi of sql-server.
+	NoConflictsBranchesKey = "branch.noconflicts"
 )
 
 var LocalConfigWhitelist = set.NewStrSet([]string{UserNameKey, UserEmailKey})
@@ -194,6 +222,40 @@ func (dcc *DoltCliConfig) IfEmptyUseConfig(val, key string) string {
 	return cfgVal
 }
 
+// ConfiguredIgnorePatterns returns the ignore patterns set in the global and local configs of |dEnv|, and for the
+// branch |branch|, if it isn't empty.
+func ConfiguredIgnorePatterns(dEnv *DoltEnv, branch string) doltdb.ConfiguredIgnorePatterns {
+	var patterns doltdb.ConfiguredIgnorePatterns
+	if dEnv.Config == nil {
+		return patterns
+	}
+	if cfg, ok := dEnv.Config.GetConfig(GlobalConfig); ok {
+		patterns.Global = splitIgnorePatterns(GetStringOrDefault(cfg, IgnorePatternsKey, ""))
+	}
+	if cfg, ok := dEnv.Config.GetConfig(LocalConfig); ok {
+		patterns.Database = splitIgnorePatterns(GetStringOrDefault(cfg, IgnorePatternsKey, ""))
+	}
+	if branch != "" {
+		patterns.Branch = splitIgnorePatterns(dEnv.Config.GetStringOrDefault(IgnoreBranchPatternsKeyPrefix+branch, ""))
+	}
+	return patterns
+}
+
+func splitIgnorePatterns(list string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// GetBranchProtection returns the protection of the branches configured in |cfg|.
+func GetBranchProtection(cfg config.ReadableConfig) doltdb.BranchProtection {
+	return doltdb.ParseBranchProtection(GetStringOrDefault(cfg, ProtectedBranchesKey, ""), GetStringOrDefault(cfg, NoConflictsBranchesKey, ""))
+}
+
 func GetStringOrDefault(cfg config.ReadableConfig, key, defStr string) string {
 	val, err := cfg.GetString(key)
 
@@ -0,0 +1,105 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// ConflictWinner is the version of a conflicting row that was kept when the conflict was resolved.
+type ConflictWinner string
+
+const (
+	// ConflictWinnerOurs means the row in the working table, our version or an edit of it, was kept.
+	ConflictWinnerOurs ConflictWinner = "ours"
+	// ConflictWinnerTheirs means the row in the working table is their version.
+	ConflictWinnerTheirs ConflictWinner = "theirs"
+	// ConflictWinnerBase means the row in the working table is the version of the merge base.
+	ConflictWinnerBase ConflictWinner = "base"
+)
+
+// maxConflictResolutions is the number of resolutions kept in the resolution journal. The oldest are dropped beyond it.
+const maxConflictResolutions = 1000
+
+// ConflictResolution records the resolution of one conflicting row.
+type ConflictResolution struct {
+	Database   string `json:"database"`
+	Branch     string `json:"branch"`
+	Table      string `json:"table"`
+	ConflictID string `json:"conflict_id"`
+	// Key is a JSON object of the primary key of the row, or empty for keyless tables.
+	Key    json.RawMessage `json:"key,omitempty"`
+	Winner ConflictWinner  `json:"winner"`
+	// Base, Ours and Theirs are JSON objects of each version of the row, or empty if the version doesn't have the row.
+	Base       json.RawMessage `json:"base,omitempty"`
+	Ours       json.RawMessage `json:"ours,omitempty"`
+	Theirs     json.RawMessage `json:"theirs,omitempty"`
+	User       string          `json:"user"`
+	ResolvedAt time.Time       `json:"resolved_at"`
+}
+
+// conflictResolutionsMu serializes the updates of resolution journals, which are rewritten as a whole.
+var conflictResolutionsMu sync.Mutex
+
+func getConflictResolutionsFile() string {
+	return filepath.Join(dbfactory.DoltDir, conflictResolutionsFile)
+}
+
+// LoadConflictResolutions returns the resolution journal of the repository at the root of |fs|, oldest first.
+func LoadConflictResolutions(fs filesys.ReadableFS) ([]ConflictResolution, error) {
+	path := getConflictResolutionsFile()
+	if exists, _ := fs.Exists(path); !exists {
+		return nil, nil
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolutions []ConflictResolution
+	err = json.Unmarshal(data, &resolutions)
+	if err != nil {
+		return nil, err
+	}
+	return resolutions, nil
+}
+
+// AppendConflictResolutions adds |resolutions| to the resolution journal of the repository at the root of |fs|.
+func AppendConflictResolutions(fs filesys.ReadWriteFS, resolutions []ConflictResolution) error {
+	conflictResolutionsMu.Lock()
+	defer conflictResolutionsMu.Unlock()
+
+	journal, err := LoadConflictResolutions(fs)
+	if err != nil {
+		return err
+	}
+	journal = append(journal, resolutions...)
+	if len(journal) > maxConflictResolutions {
+		journal = journal[len(journal)-maxConflictResolutions:]
+	}
+
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(getConflictResolutionsFile(), data)
+}
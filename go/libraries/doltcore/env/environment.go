@@ -67,6 +67,8 @@ var ErrRemoteAlreadyExists = errors.New("remote already exists")
 var ErrInvalidRemoteURL = errors.New("remote URL invalid")
 var ErrRemoteNotFound = errors.New("remote not found")
 var ErrInvalidRemoteName = errors.New("remote name invalid")
+var ErrMirrorAlreadyExists = errors.New("mirror already exists")
+var ErrMirrorNotFound = errors.New("mirror not found")
 var ErrBackupAlreadyExists = errors.New("backup already exists")
 var ErrInvalidBackupURL = errors.New("backup URL invalid")
 var ErrBackupNotFound = errors.New("backup not found")
@@ -863,6 +865,127 @@ func (dEnv *DoltEnv) AddRemote(r Remote) error {
 	return dEnv.RepoState.Save(dEnv.FS)
 }
 
+// AddRemoteMirror adds |mirrorUrl| to the mirrors of the remote named |name|.
+func (dEnv *DoltEnv) AddRemoteMirror(name, mirrorUrl string) error {
+	r, ok := dEnv.RepoState.Remotes[name]
+	if !ok {
+		return ErrRemoteNotFound
+	}
+
+	_, absMirrorUrl, err := GetAbsRemoteUrl(dEnv.FS, dEnv.Config, mirrorUrl)
+	if err != nil {
+		return fmt.Errorf("%w; %s", ErrInvalidRemoteURL, err.Error())
+	}
+	if absMirrorUrl == r.Url {
+		return ErrMirrorAlreadyExists
+	}
+	for _, m := range r.Mirrors {
+		if m == absMirrorUrl {
+			return ErrMirrorAlreadyExists
+		}
+	}
+
+	if rem, found := CheckRemoteAddressConflict(absMirrorUrl, nil, dEnv.RepoState.Backups); found {
+		return fmt.Errorf("%w: '%s' -> %s", ErrRemoteAddressConflict, rem.Name, rem.Url)
+	}
+
+	r.Mirrors = append(append([]string(nil), r.Mirrors...), absMirrorUrl)
+	dEnv.RepoState.AddRemote(r)
+	return dEnv.RepoState.Save(dEnv.FS)
+}
+
+// RemoveRemoteMirror removes |mirrorUrl| from the mirrors of the remote named |name|.
+func (dEnv *DoltEnv) RemoveRemoteMirror(name, mirrorUrl string) error {
+	r, ok := dEnv.RepoState.Remotes[name]
+	if !ok {
+		return ErrRemoteNotFound
+	}
+
+	absMirrorUrl := mirrorUrl
+	if _, u, err := GetAbsRemoteUrl(dEnv.FS, dEnv.Config, mirrorUrl); err == nil {
+		absMirrorUrl = u
+	}
+
+	var mirrors []string
+	for _, m := range r.Mirrors {
+		if m != mirrorUrl && m != absMirrorUrl {
+			mirrors = append(mirrors, m)
+		}
+	}
+	if len(mirrors) == len(r.Mirrors) {
+		return ErrMirrorNotFound
+	}
+
+	r.Mirrors = mirrors
+	dEnv.RepoState.AddRemote(r)
+	return dEnv.RepoState.Save(dEnv.FS)
+}
+
+// SetRemoteFetchSpecs replaces the refspecs fetched from the remote named |name| with |fetchSpecs|. Fetch specs map
+// branches of the remote to its remote tracking branches, or are negative refspecs excluding branches from the fetch.
+// If |fetchSpecs| is empty, the remote's branches are all fetched again.
+func (dEnv *DoltEnv) SetRemoteFetchSpecs(name string, fetchSpecs []string) error {
+	r, ok := dEnv.RepoState.Remotes[name]
+	if !ok {
+		return ErrRemoteNotFound
+	}
+
+	if len(fetchSpecs) == 0 {
+		fetchSpecs = NewRemote(name, r.Url, nil).FetchSpecs
+	}
+
+	positive := false
+	for _, fs := range fetchSpecs {
+		rs, err := ref.ParseRefSpecForRemote(name, fs)
+		if err != nil {
+			return fmt.Errorf("%w: '%s'", ErrInvalidFetchSpec, fs)
+		}
+		switch rs.(type) {
+		case ref.NegativeRefSpec:
+		case ref.RemoteRefSpec:
+			positive = true
+		default:
+			return fmt.Errorf("%w: '%s' doesn't map to remote tracking branches of '%s'", ErrInvalidFetchSpec, fs, name)
+		}
+	}
+	if !positive {
+		return fmt.Errorf("%w: the fetch specs of '%s' only exclude branches", ErrInvalidFetchSpec, name)
+	}
+
+	r.FetchSpecs = fetchSpecs
+	dEnv.RepoState.AddRemote(r)
+	return dEnv.RepoState.Save(dEnv.FS)
+}
+
+// SetRemotePushSpecs replaces the refspecs pushed to the remote named |name|, when a push to it doesn't name any
+// refspecs, with |pushSpecs|. Push specs map branches and tags to those of the remote, or are negative refspecs
+// excluding refs from the push. If |pushSpecs| is empty, pushes without refspecs push the current branch again.
+func (dEnv *DoltEnv) SetRemotePushSpecs(name string, pushSpecs []string) error {
+	r, ok := dEnv.RepoState.Remotes[name]
+	if !ok {
+		return ErrRemoteNotFound
+	}
+
+	for _, ps := range pushSpecs {
+		rs, err := ref.ParseRefSpec(ps)
+		if err != nil {
+			return fmt.Errorf("%w: '%s'", ErrInvalidPushSpec, ps)
+		}
+		switch rs.(type) {
+		case ref.BranchToBranchRefSpec, ref.TagToTagRefSpec, ref.NegativeRefSpec:
+		default:
+			return fmt.Errorf("%w: '%s' doesn't map to branches or tags of '%s'", ErrInvalidPushSpec, ps, name)
+		}
+	}
+
+	r.PushSpecs = pushSpecs
+	if len(pushSpecs) == 0 {
+		r.PushSpecs = nil
+	}
+	dEnv.RepoState.AddRemote(r)
+	return dEnv.RepoState.Save(dEnv.FS)
+}
+
 func (dEnv *DoltEnv) GetBackups() (map[string]Remote, error) {
 	if dEnv.RSLoadErr != nil {
 		return nil, dEnv.RSLoadErr
@@ -1102,6 +1225,11 @@ func (dEnv *DoltEnv) GetUserHomeDir() (string, error) {
 	return getHomeDir(dEnv.hdp)
 }
 
+// AppendFetchHistory adds |entry| to the fetch history of the repository. See FetchHistoryWriter.
+func (dEnv *DoltEnv) AppendFetchHistory(entry FetchHistoryEntry) error {
+	return AppendFetchHistory(dEnv.FS, entry)
+}
+
 func (dEnv *DoltEnv) TempTableFilesDir() (string, error) {
 	doltDir := dEnv.GetDoltDir()
 	if doltDir == "" {
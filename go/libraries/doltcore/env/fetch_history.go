@@ -0,0 +1,104 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// TransferOperation is the kind of transfer with a remote recorded in the fetch history.
+type TransferOperation string
+
+const (
+	TransferFetch TransferOperation = "fetch"
+	TransferPull  TransferOperation = "pull"
+	TransferPush  TransferOperation = "push"
+)
+
+// maxFetchHistoryEntries is the number of transfers kept in the fetch history. The oldest are dropped beyond it.
+const maxFetchHistoryEntries = 1000
+
+// FetchHistoryEntry records one fetch, pull or push of a repository.
+type FetchHistoryEntry struct {
+	Operation TransferOperation `json:"operation"`
+	Remote    string            `json:"remote"`
+	StartTime time.Time         `json:"start_time"`
+	Duration  time.Duration     `json:"duration"`
+	// Bytes are the bytes of the chunks fetched by fetches and pulls, or of the table files uploaded by pushes.
+	Bytes  uint64 `json:"bytes"`
+	Chunks uint64 `json:"chunks"`
+	// Error is the error the transfer failed with, or empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// FetchHistoryWriter is implemented by RepoStateWriters which record the fetches, pulls and pushes of their
+// repository.
+type FetchHistoryWriter interface {
+	AppendFetchHistory(entry FetchHistoryEntry) error
+}
+
+// fetchHistoryMu serializes the updates of fetch history files, which are rewritten as a whole.
+var fetchHistoryMu sync.Mutex
+
+func getFetchHistoryFile() string {
+	return filepath.Join(dbfactory.DoltDir, fetchHistoryFile)
+}
+
+// LoadFetchHistory returns the fetch history of the repository at the root of |fs|, oldest first.
+func LoadFetchHistory(fs filesys.ReadableFS) ([]FetchHistoryEntry, error) {
+	path := getFetchHistoryFile()
+	if exists, _ := fs.Exists(path); !exists {
+		return nil, nil
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []FetchHistoryEntry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// AppendFetchHistory adds |entry| to the fetch history of the repository at the root of |fs|.
+func AppendFetchHistory(fs filesys.ReadWriteFS, entry FetchHistoryEntry) error {
+	fetchHistoryMu.Lock()
+	defer fetchHistoryMu.Unlock()
+
+	entries, err := LoadFetchHistory(fs)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > maxFetchHistoryEntries {
+		entries = entries[len(entries)-maxFetchHistoryEntries:]
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(getFetchHistoryFile(), data)
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestFetchHistory(t *testing.T) {
+	fs := filesys.NewInMemFS([]string{"/repo/.dolt"}, nil, "/repo")
+
+	entries, err := LoadFetchHistory(fs)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxFetchHistoryEntries+2; i++ {
+		err = AppendFetchHistory(fs, FetchHistoryEntry{
+			Operation: TransferFetch,
+			Remote:    "origin",
+			StartTime: start.Add(time.Duration(i) * time.Minute),
+			Duration:  time.Second,
+			Bytes:     uint64(i),
+		})
+		require.NoError(t, err)
+	}
+
+	entries, err = LoadFetchHistory(fs)
+	require.NoError(t, err)
+	require.Len(t, entries, maxFetchHistoryEntries)
+	assert.Equal(t, uint64(2), entries[0].Bytes)
+	assert.Equal(t, FetchHistoryEntry{
+		Operation: TransferFetch,
+		Remote:    "origin",
+		StartTime: start.Add(time.Duration(maxFetchHistoryEntries+1) * time.Minute),
+		Duration:  time.Second,
+		Bytes:     maxFetchHistoryEntries + 1,
+	}, entries[maxFetchHistoryEntries-1])
+}
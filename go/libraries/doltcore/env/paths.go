@@ -31,7 +31,11 @@ const (
 	configFile   = "config.json"
 	globalConfig = "config_global.json"
 
-	repoStateFile = "repo_state.json"
+	repoStateFile           = "repo_state.json"
+	fetchHistoryFile        = "fetch_history.json"
+	webhookDeadLettersFile  = "webhook_dead_letters.json"
+	conflictResolutionsFile = "conflict_resolutions.json"
+	auditLogFile            = "audit_log.jsonl"
 )
 
 // HomeDirProvider is a function that returns the users home directory.  This is where global dolt state is stored for
@@ -27,10 +27,14 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	filesys2 "github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -46,6 +50,8 @@ var ErrCannotPushRef = errors.New("cannot push ref")
 var ErrNoRefSpecForRemote = errors.New("no refspec for remote")
 var ErrInvalidSetUpstreamArgs = errors.New("invalid set-upstream arguments")
 var ErrInvalidFetchSpec = errors.New("invalid fetch spec")
+var ErrInvalidPushSpec = errors.New("invalid push spec")
+var ErrInvalidLease = errors.New("invalid lease")
 var ErrPullWithRemoteNoUpstream = errors.New("You asked to pull from the remote '%s', but did not specify a branch. Because this is not the default configured remote for your current branch, you must specify a branch.")
 var ErrPullWithNoRemoteAndNoUpstream = errors.New("There is no tracking information for the current branch.\nPlease specify which branch you want to merge with.\n\n\tdolt pull <remote> <branch>\n\nIf you wish to set tracking information for this branch you can do so with:\n\n\t dolt push --set-upstream <remote> <branch>\n")
 
@@ -58,10 +64,31 @@ type Remote struct {
 	Url        string            `json:"url"`
 	FetchSpecs []string          `json:"fetch_specs"`
 	Params     map[string]string `json:"params"`
+	// Mirrors are the URLs of other copies of the remote's database, such as an S3 bucket mirroring a gRPC remote.
+	// Reads fail over to them in order when the remote's URL is unavailable, and pushes are copied to them.
+	Mirrors []string `json:"mirrors,omitempty"`
+	// PushSpecs are the refspecs pushed to the remote when a push to it doesn't name any refspecs.
+	PushSpecs []string `json:"push_specs,omitempty"`
 }
 
 func NewRemote(name, url string, params map[string]string) Remote {
-	return Remote{name, url, []string{"refs/heads/*:refs/remotes/" + name + "/*"}, params}
+	return Remote{Name: name, Url: url, FetchSpecs: []string{"refs/heads/*:refs/remotes/" + name + "/*"}, Params: params}
+}
+
+// WithoutMirrors returns a copy of the remote that reads from and writes to its URL only.
+func (r Remote) WithoutMirrors() Remote {
+	r.Mirrors = nil
+	return r
+}
+
+// MirrorRemotes returns a remote for each of the remote's mirrors, with the remote's name and params.
+func (r Remote) MirrorRemotes() []Remote {
+	mirrors := make([]Remote, len(r.Mirrors))
+	for i, u := range r.Mirrors {
+		mirrors[i] = r.WithoutMirrors()
+		mirrors[i].Url = u
+	}
+	return mirrors
 }
 
 func (r *Remote) GetParam(pName string) (string, bool) {
@@ -87,9 +114,44 @@ func (r *Remote) GetRemoteDB(ctx context.Context, nbf *types.NomsBinFormat, dial
 
 	params[dbfactory.GRPCDialProviderParam] = dialer
 
+	if len(r.Mirrors) > 0 {
+		return r.getFailoverDB(ctx, nbf, params)
+	}
 	return doltdb.LoadDoltDBWithParams(ctx, nbf, r.Url, filesys2.LocalFS, params)
 }
 
+// getFailoverDB returns the database of a remote with mirrors, which reads from the first of its URL and mirrors that
+// is available, failing over to the next when reads fail. The database of each is loaded without caching, since it's
+// closed when it's failed over from.
+func (r *Remote) getFailoverDB(ctx context.Context, nbf *types.NomsBinFormat, params map[string]interface{}) (*doltdb.DoltDB, error) {
+	urls := append([]string{r.Url}, r.Mirrors...)
+	endpoints := make([]remotestorage.FailoverEndpoint, len(urls))
+	for i, u := range urls {
+		u := u
+		endpoints[i] = remotestorage.FailoverEndpoint{
+			Url: u,
+			Load: func(ctx context.Context) (chunks.ChunkStore, error) {
+				endpointParams := make(map[string]interface{}, len(params)+1)
+				for k, v := range params {
+					endpointParams[k] = v
+				}
+				endpointParams[dbfactory.NoCachingParameter] = "true"
+				db, _, _, err := dbfactory.CreateDB(ctx, nbf, u, endpointParams)
+				if err != nil {
+					return nil, err
+				}
+				return datas.ChunkStoreFromDatabase(db), nil
+			},
+		}
+	}
+
+	cs, err := remotestorage.NewFailoverChunkStore(ctx, endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return doltdb.DoltDBFromCS(cs), nil
+}
+
 // Prepare does whatever work is necessary to prepare the remote given to receive pushes. Not all remote types can
 // support this operations and must be prepared manually. For existing remotes, no work is done.
 func (r *Remote) Prepare(ctx context.Context, nbf *types.NomsBinFormat, dialer dbfactory.GRPCDialProvider) error {
@@ -111,6 +173,9 @@ func (r *Remote) GetRemoteDBWithoutCaching(ctx context.Context, nbf *types.NomsB
 	params[dbfactory.NoCachingParameter] = "true"
 	params[dbfactory.GRPCDialProviderParam] = dialer
 
+	if len(r.Mirrors) > 0 {
+		return r.getFailoverDB(ctx, nbf, params)
+	}
 	return doltdb.LoadDoltDBWithParams(ctx, nbf, r.Url, filesys2.LocalFS, params)
 }
 
@@ -123,7 +188,10 @@ type PushOpts struct {
 	SetUpstream bool
 }
 
-func NewPushOpts(ctx context.Context, apr *argparser.ArgParseResults, rsr RepoStateReader, ddb *doltdb.DoltDB, force bool, setUpstream bool, pushAutoSetupRemote bool) (*PushOpts, error) {
+// NewPushOpts returns the pushes of the refs given by the arguments of |apr| to a remote. Most pushes are of a single
+// ref, but refspecs with patterns, several refspecs, or the push specs of the remote push every ref they map, except
+// for the refs excluded by negative refspecs.
+func NewPushOpts(ctx context.Context, apr *argparser.ArgParseResults, rsr RepoStateReader, ddb *doltdb.DoltDB, force bool, setUpstream bool, pushAutoSetupRemote bool) ([]*PushOpts, error) {
 	var err error
 	remotes, err := rsr.GetRemotes()
 	if err != nil {
@@ -148,6 +216,33 @@ func NewPushOpts(ctx context.Context, apr *argparser.ArgParseResults, rsr RepoSt
 	}
 	upstream, hasUpstream := branches[currentBranch.GetPath()]
 
+	var refSpecStrs []string
+	switch {
+	case len(args) > 2 || (len(args) == 2 && isMultiRefSpec(args[1])):
+		remoteName, refSpecStrs = args[0], args[1:]
+	case len(args) == 1 && isMultiRefSpec(args[0]):
+		refSpecStrs = args
+	case len(args) == 0 && !setUpstream:
+		name := remoteName
+		if len(apr.Args) == 0 && hasUpstream {
+			name = upstream.Remote
+		}
+		if r, ok := remotes[name]; ok && len(r.PushSpecs) > 0 {
+			remoteName, refSpecStrs = name, r.PushSpecs
+		}
+	}
+
+	if refSpecStrs != nil {
+		if setUpstream {
+			return nil, ErrInvalidSetUpstreamArgs
+		}
+		remote, remoteOK = remotes[remoteName]
+		if !remoteOK {
+			return nil, fmt.Errorf("%w: '%s'", ErrUnknownRemote, remoteName)
+		}
+		return newPushOptsForRefSpecs(ctx, ddb, currentBranch, remote, refSpecStrs, force)
+	}
+
 	var refSpec ref.RefSpec
 	if remoteOK && len(args) == 1 {
 		refSpec, err = getRefSpecFromStr(ctx, ddb, args[0])
@@ -231,14 +326,123 @@ func NewPushOpts(ctx context.Context, apr *argparser.ArgParseResults, rsr RepoSt
 		RemoteRef: remoteRef,
 		Remote:    remote,
 		Mode: ref.UpdateMode{
-			Force: force,
+			Force: force || refSpec.Forced(),
 		},
 		SetUpstream: setUpstream,
 	}
 
+	return []*PushOpts{opts}, nil
+}
+
+// isMultiRefSpec returns whether |refSpecStr| is a pattern or a negative refspec, which can match more than one ref.
+func isMultiRefSpec(refSpecStr string) bool {
+	return strings.Contains(refSpecStr, "*") || strings.HasPrefix(refSpecStr, "^")
+}
+
+// newPushOptsForRefSpecs returns the pushes of every local branch and tag mapped by |refSpecStrs| to |remote|,
+// except for the refs excluded by the negative refspecs of |refSpecStrs|.
+func newPushOptsForRefSpecs(ctx context.Context, ddb *doltdb.DoltDB, currentBranch ref.DoltRef, remote Remote, refSpecStrs []string, force bool) ([]*PushOpts, error) {
+	var refSpecs []ref.RefSpec
+	var negatives []ref.RemoteRefSpec
+	for _, refSpecStr := range refSpecStrs {
+		rs, err := getRefSpecFromStr(ctx, ddb, refSpecStr)
+		if err != nil {
+			return nil, err
+		}
+		if neg, ok := rs.(ref.NegativeRefSpec); ok {
+			negatives = append(negatives, neg)
+		} else if _, ok := rs.(ref.RemoteRefSpec); ok {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidPushSpec, refSpecStr)
+		} else {
+			refSpecs = append(refSpecs, rs)
+		}
+	}
+
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFailedToReadDb, err.Error())
+	}
+	tags, err := ddb.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFailedToReadDb, err.Error())
+	}
+	localRefs := append(branches, tags...)
+
+	var opts []*PushOpts
+	for _, rs := range refSpecs {
+		srcs := []ref.DoltRef{rs.SrcRef(currentBranch)}
+		if ref.IsPattern(rs) {
+			srcs = localRefs
+		}
+
+		for _, src := range srcs {
+			dest := rs.DestRef(src)
+			if dest == nil || ref.IsExcluded(src, negatives) {
+				continue
+			}
+
+			var remoteRef ref.DoltRef
+			switch src.GetType() {
+			case ref.BranchRefType:
+				remoteRef, err = GetTrackingRef(dest, remote)
+				if err == nil && remoteRef == nil {
+					err = fmt.Errorf("%w: '%s' has no remote tracking branch for remote '%s'", ErrCannotPushRef, dest.String(), remote.Name)
+				}
+			case ref.TagRefType:
+			default:
+				err = fmt.Errorf("%w: '%s' of type '%s'", ErrCannotPushRef, src.String(), src.GetType())
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			opts = append(opts, &PushOpts{
+				SrcRef:    src,
+				DestRef:   dest,
+				RemoteRef: remoteRef,
+				Remote:    remote,
+				Mode: ref.UpdateMode{
+					Force: force || rs.Forced(),
+				},
+			})
+		}
+	}
+
+	if len(opts) == 0 {
+		return nil, fmt.Errorf("%w: no refs match '%s'", ref.ErrInvalidRefSpec, strings.Join(refSpecStrs, " "))
+	}
 	return opts, nil
 }
 
+// SetLease makes the push of |opts| a forced update that's only made if the remote's branch is at |expected|, the
+// hash of a commit. If |expected| is empty, the remote's branch must be where the remote tracking branch in |ddb|
+// last saw it, or not exist if there is no remote tracking branch.
+func (opts *PushOpts) SetLease(ctx context.Context, ddb *doltdb.DoltDB, expected string) error {
+	if opts.SrcRef.GetType() != ref.BranchRefType {
+		return fmt.Errorf("%w: only branches can be pushed with a lease", ErrInvalidLease)
+	}
+
+	var lease hash.Hash
+	if expected != "" {
+		var ok bool
+		lease, ok = hash.MaybeParse(strings.TrimPrefix(expected, "#"))
+		if !ok {
+			return fmt.Errorf("%w: '%s' is not a commit hash", ErrInvalidLease, expected)
+		}
+	} else if ok, err := ddb.HasRef(ctx, opts.RemoteRef); err != nil {
+		return err
+	} else if ok {
+		h, err := ddb.GetHashForRefStr(ctx, opts.RemoteRef.String())
+		if err != nil {
+			return err
+		}
+		lease = *h
+	}
+
+	opts.Mode = ref.UpdateMode{Force: true, Lease: &lease}
+	return nil
+}
+
 // NewFetchOpts returns remote and refSpec for given remote name. If remote name is not defined,
 // default remote is used. Default remote is "origin" if there are multiple remotes for now.
 func NewFetchOpts(args []string, rsr RepoStateReader) (Remote, []ref.RemoteRefSpec, error) {
@@ -269,6 +473,12 @@ func NewFetchOpts(args []string, rsr RepoStateReader) (Remote, []ref.RemoteRefSp
 	var rs []ref.RemoteRefSpec
 	if len(args) != 0 {
 		rs, err = ParseRSFromArgs(remName, args)
+		if err == nil && onlyNegativeRefSpecs(rs) {
+			// negative refspecs exclude refs from the fetch specs of the remote
+			var fetchSpecs []ref.RemoteRefSpec
+			fetchSpecs, err = GetRefSpecs(rsr, remName)
+			rs = append(fetchSpecs, rs...)
+		}
 	} else {
 		rs, err = GetRefSpecs(rsr, remName)
 	}
@@ -280,6 +490,34 @@ func NewFetchOpts(args []string, rsr RepoStateReader) (Remote, []ref.RemoteRefSp
 	return remote, rs, err
 }
 
+// NewFetchAllOpts returns every remote of |rsr|, ordered by name, and the refspecs to fetch from each of them.
+func NewFetchAllOpts(rsr RepoStateReader) ([]Remote, [][]ref.RemoteRefSpec, error) {
+	remotes, err := rsr.GetRemotes()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(remotes) == 0 {
+		return nil, nil, ErrNoRemote
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rems := make([]Remote, len(names))
+	refSpecs := make([][]ref.RemoteRefSpec, len(names))
+	for i, name := range names {
+		rems[i] = remotes[name]
+		refSpecs[i], err = GetRefSpecs(rsr, name)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return rems, refSpecs, nil
+}
+
 func ParseRSFromArgs(remName string, args []string) ([]ref.RemoteRefSpec, error) {
 	var refSpecs []ref.RemoteRefSpec
 	for i := 0; i < len(args); i++ {
@@ -291,9 +529,14 @@ func ParseRSFromArgs(remName string, args []string) ([]ref.RemoteRefSpec, error)
 		}
 
 		if _, ok := rs.(ref.BranchToBranchRefSpec); ok {
-			local := "refs/heads/" + rsStr
-			remTracking := "remotes/" + remName + "/" + rsStr
-			rs2, err := ref.ParseRefSpec(local + ":" + remTracking)
+			// a branch, or a pattern of branches, is fetched into the remote tracking branches of the remote
+			prefix, name := "", rsStr
+			if rs.Forced() {
+				prefix, name = "+", rsStr[1:]
+			}
+			local := "refs/heads/" + name
+			remTracking := "remotes/" + remName + "/" + name
+			rs2, err := ref.ParseRefSpec(prefix + local + ":" + remTracking)
 
 			if err == nil {
 				rs = rs2
@@ -311,9 +554,24 @@ func ParseRSFromArgs(remName string, args []string) ([]ref.RemoteRefSpec, error)
 	return refSpecs, nil
 }
 
-// if possible, convert refs to full spec names. prefer branches over tags.
-// eg "main" -> "refs/heads/main", "v1" -> "refs/tags/v1"
+// onlyNegativeRefSpecs returns whether every refspec of |refSpecs| is a negative refspec.
+func onlyNegativeRefSpecs(refSpecs []ref.RemoteRefSpec) bool {
+	for _, rs := range refSpecs {
+		if _, ok := rs.(ref.NegativeRefSpec); !ok {
+			return false
+		}
+	}
+	return len(refSpecs) > 0
+}
+
+// if possible, convert refs to full spec names. prefer branches over tags. A '+' or '^' prefix is kept.
+// eg "main" -> "refs/heads/main", "+v1" -> "+refs/tags/v1"
 func disambiguateRefSpecStr(ctx context.Context, ddb *doltdb.DoltDB, refSpecStr string) (string, error) {
+	if strings.HasPrefix(refSpecStr, "+") || strings.HasPrefix(refSpecStr, "^") {
+		s, err := disambiguateRefSpecStr(ctx, ddb, refSpecStr[1:])
+		return refSpecStr[:1] + s, err
+	}
+
 	brachRefs, err := ddb.GetBranches(ctx)
 
 	if err != nil {
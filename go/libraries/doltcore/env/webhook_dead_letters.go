@@ -0,0 +1,88 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// maxWebhookDeadLetters is the number of undelivered webhooks kept. The oldest are dropped beyond it.
+const maxWebhookDeadLetters = 1000
+
+// WebhookDeadLetter records a webhook that could not be delivered.
+type WebhookDeadLetter struct {
+	URL   string `json:"url"`
+	Event string `json:"event"`
+	Ref   string `json:"ref"`
+	// Payload is the JSON body of the webhook.
+	Payload  string    `json:"payload"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// webhookDeadLettersMu serializes the updates of dead letter files, which are rewritten as a whole.
+var webhookDeadLettersMu sync.Mutex
+
+func getWebhookDeadLettersFile() string {
+	return filepath.Join(dbfactory.DoltDir, webhookDeadLettersFile)
+}
+
+// LoadWebhookDeadLetters returns the undelivered webhooks of the repository at the root of |fs|, oldest first.
+func LoadWebhookDeadLetters(fs filesys.ReadableFS) ([]WebhookDeadLetter, error) {
+	path := getWebhookDeadLettersFile()
+	if exists, _ := fs.Exists(path); !exists {
+		return nil, nil
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var letters []WebhookDeadLetter
+	err = json.Unmarshal(data, &letters)
+	if err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// AppendWebhookDeadLetter adds |letter| to the undelivered webhooks of the repository at the root of |fs|.
+func AppendWebhookDeadLetter(fs filesys.ReadWriteFS, letter WebhookDeadLetter) error {
+	webhookDeadLettersMu.Lock()
+	defer webhookDeadLettersMu.Unlock()
+
+	letters, err := LoadWebhookDeadLetters(fs)
+	if err != nil {
+		return err
+	}
+	letters = append(letters, letter)
+	if len(letters) > maxWebhookDeadLetters {
+		letters = letters[len(letters)-maxWebhookDeadLetters:]
+	}
+
+	data, err := json.MarshalIndent(letters, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(getWebhookDeadLettersFile(), data)
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsrv
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/vitess/go/mysql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authHeader   = "authorization"
+	basicPrefix  = "Basic "
+	bearerPrefix = "Bearer "
+
+	// tokenTTL is how long the bearer tokens returned by Handshake can be used.
+	tokenTTL = time.Hour
+)
+
+// authenticator authenticates the requests of Flight SQL clients. Clients send the user and password of a SQL user
+// in a basic authorization header, either with each request or with a Handshake, which returns a bearer token for
+// the requests that follow it, as the Flight SQL drivers do.
+type authenticator struct {
+	db *mysql_db.MySQLDb
+
+	mu     sync.Mutex
+	tokens map[string]token
+}
+
+type token struct {
+	client  sql.Client
+	expires time.Time
+}
+
+type clientKey struct{}
+
+func newAuthenticator(db *mysql_db.MySQLDb) *authenticator {
+	return &authenticator{db: db, tokens: make(map[string]token)}
+}
+
+// clientFromContext returns the client that the authenticator authenticated for the request of |ctx|.
+func clientFromContext(ctx context.Context) sql.Client {
+	client, _ := ctx.Value(clientKey{}).(sql.Client)
+	return client
+}
+
+func (a *authenticator) Options() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx, err := a.authenticate(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := a.authenticate(ss.Context())
+			if err != nil {
+				return err
+			}
+			return handler(srv, authenticatedStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate returns |ctx| with the client of its request, or an Unauthenticated error if the request doesn't have
+// valid credentials.
+func (a *authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	auths := md.Get(authHeader)
+	if len(auths) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	var client sql.Client
+	var ok bool
+	switch auth := auths[0]; {
+	case strings.HasPrefix(auth, bearerPrefix):
+		client, ok = a.tokenClient(strings.TrimPrefix(auth, bearerPrefix))
+	case strings.HasPrefix(auth, basicPrefix):
+		client, ok = a.basicClient(ctx, strings.TrimPrefix(auth, basicPrefix))
+	}
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	return context.WithValue(ctx, clientKey{}, client), nil
+}
+
+// basicClient returns the client with the credentials |creds| of a basic authorization header, if they're the user
+// and password of a SQL user.
+func (a *authenticator) basicClient(ctx context.Context, creds string) (sql.Client, bool) {
+	dec, err := base64.StdEncoding.DecodeString(creds)
+	if err != nil {
+		return sql.Client{}, false
+	}
+	user, password, ok := strings.Cut(string(dec), ":")
+	if !ok {
+		return sql.Client{}, false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return sql.Client{}, false
+	}
+
+	// the password is checked the way the MySQL protocol checks it, so that it's checked against the same users
+	salt, err := mysql.NewSalt()
+	if err != nil {
+		return sql.Client{}, false
+	}
+	var scramble []byte
+	if password != "" {
+		scramble = mysql.ScrambleMysqlNativePassword(salt, []byte(password))
+	}
+	if _, err = a.db.ValidateHash(salt, user, scramble, p.Addr); err != nil {
+		return sql.Client{}, false
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return sql.Client{}, false
+	}
+	return sql.Client{User: user, Address: host}, true
+}
+
+// newToken returns a new bearer token for |client|.
+func (a *authenticator) newToken(client sql.Client) (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	tok := hex.EncodeToString(b[:])
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	for t, entry := range a.tokens {
+		if now.After(entry.expires) {
+			delete(a.tokens, t)
+		}
+	}
+	a.tokens[tok] = token{client: client, expires: now.Add(tokenTTL)}
+	return tok, nil
+}
+
+func (a *authenticator) tokenClient(tok string) (sql.Client, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, ok := a.tokens[tok]
+	if !ok || time.Now().After(entry.expires) {
+		return sql.Client{}, false
+	}
+	return entry.client, true
+}
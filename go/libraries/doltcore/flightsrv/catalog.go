@@ -0,0 +1,175 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsrv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"google.golang.org/protobuf/proto"
+
+	flightsql "github.com/dolthub/dolt/go/gen/proto/arrow/flight/protocol/sql"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+)
+
+// The catalog commands describe the databases of the server as the catalogs of Flight SQL, like the MySQL JDBC driver
+// does. Databases have no schemas, so the db_schema_name of tables is null and GetDbSchemas returns no schemas. The
+// results are read from information_schema in a session of the client, so they only describe the databases and
+// tables its user can see.
+
+// The schemas of the results of the catalog commands, see flight_sql.proto.
+var (
+	catalogsSchema = sql.Schema{
+		{Name: "catalog_name", Type: types.LongText},
+	}
+	dbSchemasSchema = sql.Schema{
+		{Name: "catalog_name", Type: types.LongText, Nullable: true},
+		{Name: "db_schema_name", Type: types.LongText},
+	}
+	tablesSchema = sql.Schema{
+		{Name: "catalog_name", Type: types.LongText, Nullable: true},
+		{Name: "db_schema_name", Type: types.LongText, Nullable: true},
+		{Name: "table_name", Type: types.LongText},
+		{Name: "table_type", Type: types.LongText},
+	}
+	tablesWithSchemaSchema = sql.Schema{
+		{Name: "catalog_name", Type: types.LongText, Nullable: true},
+		{Name: "db_schema_name", Type: types.LongText, Nullable: true},
+		{Name: "table_name", Type: types.LongText},
+		{Name: "table_type", Type: types.LongText},
+		{Name: "table_schema", Type: types.LongBlob},
+	}
+	tableTypesSchema = sql.Schema{
+		{Name: "table_type", Type: types.LongText},
+	}
+)
+
+// tableTypes are the table types of information_schema.tables, in order. Base tables are named TABLE, like Flight SQL
+// names them.
+var tableTypes = []string{"SYSTEM VIEW", "TABLE", "VIEW"}
+
+// catalogSchema returns the schema of the results of |cmd|, or false if it isn't a catalog command.
+func catalogSchema(cmd proto.Message) (sql.Schema, bool) {
+	switch cmd := cmd.(type) {
+	case *flightsql.CommandGetCatalogs:
+		return catalogsSchema, true
+	case *flightsql.CommandGetDbSchemas:
+		return dbSchemasSchema, true
+	case *flightsql.CommandGetTables:
+		if cmd.IncludeSchema {
+			return tablesWithSchemaSchema, true
+		}
+		return tablesSchema, true
+	case *flightsql.CommandGetTableTypes:
+		return tableTypesSchema, true
+	default:
+		return nil, false
+	}
+}
+
+// catalogRows returns the results of the catalog command |cmd|.
+func (s *flightSqlService) catalogRows(ctx *sql.Context, cmd proto.Message) ([]sql.Row, error) {
+	switch cmd := cmd.(type) {
+	case *flightsql.CommandGetCatalogs:
+		return s.queryRows(ctx, "SELECT schema_name FROM information_schema.schemata ORDER BY schema_name")
+	case *flightsql.CommandGetDbSchemas:
+		return nil, nil
+	case *flightsql.CommandGetTables:
+		return s.tableRows(ctx, cmd)
+	case *flightsql.CommandGetTableTypes:
+		rows := make([]sql.Row, len(tableTypes))
+		for i, typ := range tableTypes {
+			rows[i] = sql.Row{typ}
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unexpected catalog command %T", cmd)
+	}
+}
+
+func (s *flightSqlService) tableRows(ctx *sql.Context, cmd *flightsql.CommandGetTables) ([]sql.Row, error) {
+	// tables have no db schema, which only matches the patterns which match empty names
+	if cmd.DbSchemaFilterPattern != nil && strings.Trim(*cmd.DbSchemaFilterPattern, "%") != "" {
+		return nil, nil
+	}
+
+	var conds, args []string
+	if cmd.Catalog != nil {
+		conds = append(conds, "table_schema = ?")
+		args = append(args, *cmd.Catalog)
+	}
+	if cmd.TableNameFilterPattern != nil {
+		conds = append(conds, "table_name LIKE ?")
+		args = append(args, *cmd.TableNameFilterPattern)
+	}
+	if len(cmd.TableTypes) > 0 {
+		conds = append(conds, "table_type IN (?"+strings.Repeat(", ?", len(cmd.TableTypes)-1)+")")
+		for _, typ := range cmd.TableTypes {
+			if typ == "TABLE" {
+				typ = "BASE TABLE"
+			}
+			args = append(args, typ)
+		}
+	}
+	query := "SELECT table_schema, table_name, " +
+		"CASE table_type WHEN 'BASE TABLE' THEN 'TABLE' ELSE table_type END AS table_type " +
+		"FROM information_schema.tables"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY table_schema, table_name"
+
+	tables, err := s.queryRows(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sql.Row, len(tables))
+	for i, t := range tables {
+		rows[i] = sql.Row{t[0], nil, t[1], t[2]}
+		if cmd.IncludeSchema {
+			q := fmt.Sprintf("SELECT * FROM %s.%s", sql.QuoteIdentifier(t[0].(string)), sql.QuoteIdentifier(t[1].(string)))
+			analyzed, err := s.engine.AnalyzeQuery(ctx, q)
+			if err != nil {
+				return nil, queryError(err)
+			}
+			rows[i] = append(rows[i], arrow.EncapsulatedSchema(analyzed.Schema()))
+		}
+	}
+	return rows, nil
+}
+
+// queryRows runs |query|, binding |args| to its parameters, and returns its results.
+func (s *flightSqlService) queryRows(ctx *sql.Context, query string, args ...string) ([]sql.Row, error) {
+	var bindings map[string]sql.Expression
+	if len(args) > 0 {
+		bindings = make(map[string]sql.Expression, len(args))
+		for i, arg := range args {
+			bindings["v"+strconv.Itoa(i+1)] = expression.NewLiteral(arg, types.LongText)
+		}
+	}
+	_, iter, err := s.engine.QueryWithBindings(ctx, query, bindings)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return rows, nil
+}
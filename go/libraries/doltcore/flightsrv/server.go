@@ -0,0 +1,109 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsrv
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	flight "github.com/dolthub/dolt/go/gen/proto/arrow/flight/protocol"
+)
+
+// Server serves the databases of a SQL engine over Arrow Flight SQL, which streams query results to clients as Arrow
+// record batches. Clients like the ADBC and JDBC Flight SQL drivers, pyarrow and Spark can read the results straight
+// into columnar data frames, without decoding the rows of the MySQL protocol.
+type Server struct {
+	wg       sync.WaitGroup
+	stopChan chan struct{}
+
+	listenAddr string
+	tlsConfig  *tls.Config
+	grpcSrv    *grpc.Server
+	lgr        *logrus.Entry
+}
+
+type ServerArgs struct {
+	Logger     *logrus.Entry
+	ListenAddr string
+
+	// Engine runs the queries of the clients. Clients authenticate as the users of its MySQLDb, and their queries run
+	// with the privileges of their user.
+	Engine *gms.Engine
+	// NewContext returns a context with a new session for a query of |client|.
+	NewContext func(ctx context.Context, client sql.Client) (*sql.Context, error)
+
+	// If supplied, the listener returned from Listener() will be a TLS
+	// listener.
+	TLSConfig *tls.Config
+}
+
+func NewServer(args ServerArgs) *Server {
+	if args.Logger == nil {
+		args.Logger = logrus.NewEntry(logrus.StandardLogger())
+	}
+
+	auth := newAuthenticator(args.Engine.Analyzer.Catalog.MySQLDb)
+	s := &Server{
+		stopChan:   make(chan struct{}),
+		listenAddr: args.ListenAddr,
+		tlsConfig:  args.TLSConfig,
+		grpcSrv:    grpc.NewServer(auth.Options()...),
+		lgr:        args.Logger,
+	}
+	flight.RegisterFlightServiceServer(s.grpcSrv, &flightSqlService{
+		lgr:        args.Logger,
+		engine:     args.Engine,
+		newContext: args.NewContext,
+		auth:       auth,
+	})
+	return s
+}
+
+// Listener returns the listener of the server on its listen address.
+func (s *Server) Listener() (net.Listener, error) {
+	if s.tlsConfig != nil {
+		return tls.Listen("tcp", s.listenAddr, s.tlsConfig)
+	}
+	return net.Listen("tcp", s.listenAddr)
+}
+
+// Serve serves Flight SQL requests on |listener| until GracefulStop is called.
+func (s *Server) Serve(listener net.Listener) {
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.lgr.Println("Starting Flight SQL server on", s.listenAddr)
+		err := s.grpcSrv.Serve(listener)
+		s.lgr.Println("Flight SQL server exited. error:", err)
+	}()
+	go func() {
+		defer s.wg.Done()
+		<-s.stopChan
+		s.grpcSrv.GracefulStop()
+	}()
+}
+
+// GracefulStop stops the server once the queries it is serving are done.
+func (s *Server) GracefulStop() {
+	close(s.stopChan)
+	s.wg.Wait()
+}
@@ -0,0 +1,419 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	flight "github.com/dolthub/dolt/go/gen/proto/arrow/flight/protocol"
+	flightsql "github.com/dolthub/dolt/go/gen/proto/arrow/flight/protocol/sql"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+func newTestServer(t *testing.T) flight.FlightServiceClient {
+	engine := gms.NewDefault(memory.NewDBProvider(memory.NewDatabase("mydb")))
+	engine.Analyzer.Catalog.MySQLDb.SetPersister(&mysql_db.NoopPersister{})
+	engine.Analyzer.Catalog.MySQLDb.AddSuperUser("root", "localhost", "secret")
+	newContext := func(ctx context.Context, client sql.Client) (*sql.Context, error) {
+		sess := sql.NewBaseSessionWithClientServer("", client, 1)
+		return sql.NewContext(ctx, sql.WithSession(sess)), nil
+	}
+
+	ctx, err := newContext(context.Background(), sql.Client{User: "root", Address: "localhost"})
+	require.NoError(t, err)
+	for _, q := range []string{
+		"CREATE TABLE mydb.t (id int primary key, name varchar(20), score double)",
+		"INSERT INTO mydb.t VALUES (1, 'one', 1.5), (2, NULL, 2.5), (3, 'three', NULL)",
+		"CREATE TABLE mydb.secret (id int primary key)",
+		"CREATE USER reader@'%' IDENTIFIED BY 'pw'",
+		"GRANT SELECT ON mydb.t TO reader@'%'",
+		"USE mydb",
+		"CREATE VIEW v AS SELECT id FROM t",
+	} {
+		_, iter, err := engine.Query(ctx, q)
+		require.NoError(t, err, q)
+		_, err = sql.RowIterToRows(ctx, nil, iter)
+		require.NoError(t, err, q)
+	}
+
+	srv := NewServer(ServerArgs{Engine: engine, NewContext: newContext, ListenAddr: "127.0.0.1:0"})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv.Serve(listener)
+	t.Cleanup(srv.GracefulStop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return flight.NewFlightServiceClient(conn)
+}
+
+func basicAuth(ctx context.Context, user, password string) context.Context {
+	creds := base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+	return metadata.AppendToOutgoingContext(ctx, authHeader, basicPrefix+creds)
+}
+
+func statementDescriptor(t *testing.T, query string) *flight.FlightDescriptor {
+	return commandDescriptor(t, &flightsql.CommandStatementQuery{Query: query})
+}
+
+func commandDescriptor(t *testing.T, msg proto.Message) *flight.FlightDescriptor {
+	cmd, err := anypb.New(msg)
+	require.NoError(t, err)
+	b, err := proto.Marshal(cmd)
+	require.NoError(t, err)
+	return &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: b}
+}
+
+// doAction returns the results of the action |typ| with the body |msg|.
+func doAction(ctx context.Context, client flight.FlightServiceClient, typ string, msg proto.Message) ([]*flight.Result, error) {
+	body, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	b, err := proto.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := client.DoAction(ctx, &flight.Action{Type: typ, Body: b})
+	if err != nil {
+		return nil, err
+	}
+	var results []*flight.Result
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+}
+
+// doGet returns the FlightData of the DoGet stream of |ticket|, as an Arrow IPC stream.
+func doGet(ctx context.Context, client flight.FlightServiceClient, ticket *flight.Ticket) ([]byte, error) {
+	stream, err := client.DoGet(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for {
+		data, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		metaLen := len(data.DataHeader) + (8-(8+len(data.DataHeader))%8)%8
+		var prefix [8]byte
+		binary.LittleEndian.PutUint32(prefix[:4], 0xFFFFFFFF)
+		binary.LittleEndian.PutUint32(prefix[4:], uint32(metaLen))
+		buf.Write(prefix[:])
+		buf.Write(data.DataHeader)
+		buf.Write(make([]byte, metaLen-len(data.DataHeader)))
+		buf.Write(data.DataBody)
+	}
+	buf.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0})
+	return buf.Bytes(), nil
+}
+
+// arrowStream returns the Arrow IPC stream of |rows| of |sch|.
+func arrowStream(t *testing.T, sch sql.Schema, rows ...sql.Row) []byte {
+	var buf bytes.Buffer
+	wr, err := arrow.NewArrowSqlWriter(iohelp.NopWrCloser(&buf), sch)
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, wr.WriteSqlRow(context.Background(), r))
+	}
+	require.NoError(t, wr.Close(context.Background()))
+	return buf.Bytes()
+}
+
+func TestStatementQuery(t *testing.T) {
+	client := newTestServer(t)
+	ctx := metadata.AppendToOutgoingContext(basicAuth(context.Background(), "root", "secret"), databaseHeader, "mydb")
+
+	info, err := client.GetFlightInfo(ctx, statementDescriptor(t, "SELECT id, name, score FROM t ORDER BY id"))
+	require.NoError(t, err)
+	require.Len(t, info.Endpoint, 1)
+
+	stream, err := doGet(ctx, client, info.Endpoint[0].Ticket)
+	require.NoError(t, err)
+
+	sch := sql.Schema{
+		{Name: "id", Type: types.Int32},
+		{Name: "name", Type: types.Text},
+		{Name: "score", Type: types.Float64},
+	}
+	assert.Equal(t, arrow.EncapsulatedSchema(sch), info.Schema)
+	assert.Equal(t, arrowStream(t, sch,
+		sql.Row{int32(1), "one", 1.5},
+		sql.Row{int32(2), nil, 2.5},
+		sql.Row{int32(3), "three", nil},
+	), stream)
+
+	res, err := client.GetSchema(ctx, statementDescriptor(t, "SELECT id, name, score FROM t"))
+	require.NoError(t, err)
+	assert.Equal(t, info.Schema, res.Schema)
+}
+
+func TestPreparedStatement(t *testing.T) {
+	client := newTestServer(t)
+	ctx := metadata.AppendToOutgoingContext(basicAuth(context.Background(), "root", "secret"), databaseHeader, "mydb")
+
+	results, err := doAction(ctx, client, createPreparedStatementAction, &flightsql.ActionCreatePreparedStatementRequest{
+		Query: "SELECT id, name FROM t WHERE id > 1 ORDER BY id",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	var body anypb.Any
+	require.NoError(t, proto.Unmarshal(results[0].Body, &body))
+	var res flightsql.ActionCreatePreparedStatementResult
+	require.NoError(t, body.UnmarshalTo(&res))
+
+	sch := sql.Schema{
+		{Name: "id", Type: types.Int32},
+		{Name: "name", Type: types.Text},
+	}
+	assert.Equal(t, arrow.EncapsulatedSchema(sch), res.DatasetSchema)
+	assert.Empty(t, res.ParameterSchema)
+
+	desc := commandDescriptor(t, &flightsql.CommandPreparedStatementQuery{PreparedStatementHandle: res.PreparedStatementHandle})
+	schRes, err := client.GetSchema(ctx, desc)
+	require.NoError(t, err)
+	assert.Equal(t, res.DatasetSchema, schRes.Schema)
+
+	// the statement runs each time its ticket is read
+	info, err := client.GetFlightInfo(ctx, desc)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		stream, err := doGet(ctx, client, info.Endpoint[0].Ticket)
+		require.NoError(t, err)
+		assert.Equal(t, arrowStream(t, sch, sql.Row{int32(2), nil}, sql.Row{int32(3), "three"}), stream)
+	}
+
+	results, err = doAction(ctx, client, closePreparedStatementAction, &flightsql.ActionClosePreparedStatementRequest{
+		PreparedStatementHandle: res.PreparedStatementHandle,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = doAction(ctx, client, createPreparedStatementAction, &flightsql.ActionCreatePreparedStatementRequest{
+		Query: "SELECT * FROM t WHERE id = ?",
+	})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = doAction(ctx, client, createPreparedStatementAction, &flightsql.ActionCreatePreparedStatementRequest{
+		Query: "SELECT * FROM mydb.secret",
+	})
+	assert.NoError(t, err)
+	_, err = doAction(basicAuth(context.Background(), "reader", "pw"), client, createPreparedStatementAction,
+		&flightsql.ActionCreatePreparedStatementRequest{Query: "SELECT * FROM mydb.secret"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	stream, err := client.ListActions(ctx, &flight.Empty{})
+	require.NoError(t, err)
+	var actions []string
+	for {
+		typ, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		actions = append(actions, typ.Type)
+	}
+	assert.Equal(t, []string{createPreparedStatementAction, closePreparedStatementAction}, actions)
+}
+
+func TestCatalogCommands(t *testing.T) {
+	client := newTestServer(t)
+	root := basicAuth(context.Background(), "root", "secret")
+	reader := basicAuth(context.Background(), "reader", "pw")
+	mydb := proto.String("mydb")
+
+	tableSchema := sql.Schema{
+		{Name: "id", Type: types.Int32},
+		{Name: "name", Type: types.Text},
+		{Name: "score", Type: types.Float64},
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		cmd  proto.Message
+		sch  sql.Schema
+		rows []sql.Row
+	}{
+		{
+			name: "catalogs",
+			ctx:  reader,
+			cmd:  &flightsql.CommandGetCatalogs{},
+			sch:  catalogsSchema,
+			rows: []sql.Row{{"information_schema"}, {"mydb"}},
+		},
+		{
+			name: "db schemas",
+			ctx:  root,
+			cmd:  &flightsql.CommandGetDbSchemas{},
+			sch:  dbSchemasSchema,
+		},
+		{
+			name: "tables",
+			ctx:  root,
+			cmd:  &flightsql.CommandGetTables{Catalog: mydb},
+			sch:  tablesSchema,
+			rows: []sql.Row{{"mydb", nil, "secret", "TABLE"}, {"mydb", nil, "t", "TABLE"}, {"mydb", nil, "v", "VIEW"}},
+		},
+		{
+			name: "tables of user",
+			ctx:  reader,
+			cmd:  &flightsql.CommandGetTables{Catalog: mydb, TableTypes: []string{"TABLE"}},
+			sch:  tablesSchema,
+			rows: []sql.Row{{"mydb", nil, "t", "TABLE"}},
+		},
+		{
+			name: "tables matching filters",
+			ctx:  root,
+			cmd: &flightsql.CommandGetTables{
+				Catalog:                mydb,
+				DbSchemaFilterPattern:  proto.String("%"),
+				TableNameFilterPattern: proto.String("_"),
+				TableTypes:             []string{"TABLE"},
+			},
+			sch:  tablesSchema,
+			rows: []sql.Row{{"mydb", nil, "t", "TABLE"}},
+		},
+		{
+			name: "tables in db schema",
+			ctx:  root,
+			cmd:  &flightsql.CommandGetTables{Catalog: mydb, DbSchemaFilterPattern: proto.String("mydb")},
+			sch:  tablesSchema,
+		},
+		{
+			name: "tables with schema",
+			ctx:  root,
+			cmd:  &flightsql.CommandGetTables{Catalog: mydb, TableNameFilterPattern: proto.String("t"), IncludeSchema: true},
+			sch:  tablesWithSchemaSchema,
+			rows: []sql.Row{{"mydb", nil, "t", "TABLE", arrow.EncapsulatedSchema(tableSchema)}},
+		},
+		{
+			name: "table types",
+			ctx:  root,
+			cmd:  &flightsql.CommandGetTableTypes{},
+			sch:  tableTypesSchema,
+			rows: []sql.Row{{"SYSTEM VIEW"}, {"TABLE"}, {"VIEW"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := client.GetFlightInfo(test.ctx, commandDescriptor(t, test.cmd))
+			require.NoError(t, err)
+			assert.Equal(t, arrow.EncapsulatedSchema(test.sch), info.Schema)
+
+			stream, err := doGet(test.ctx, client, info.Endpoint[0].Ticket)
+			require.NoError(t, err)
+			assert.Equal(t, arrowStream(t, test.sch, test.rows...), stream)
+		})
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	client := newTestServer(t)
+	ctx := context.Background()
+
+	hs, err := client.Handshake(basicAuth(ctx, "reader", "pw"))
+	require.NoError(t, err)
+	require.NoError(t, hs.Send(&flight.HandshakeRequest{}))
+	require.NoError(t, hs.CloseSend())
+	resp, err := hs.Recv()
+	require.NoError(t, err)
+	header, err := hs.Header()
+	require.NoError(t, err)
+	require.Equal(t, []string{bearerPrefix + string(resp.Payload)}, header.Get(authHeader))
+
+	ctx = metadata.AppendToOutgoingContext(ctx, authHeader, bearerPrefix+string(resp.Payload))
+	info, err := client.GetFlightInfo(ctx, statementDescriptor(t, "SELECT count(*) FROM mydb.t"))
+	require.NoError(t, err)
+	_, err = doGet(ctx, client, info.Endpoint[0].Ticket)
+	require.NoError(t, err)
+
+	// queries run with the privileges of the user
+	_, err = client.GetFlightInfo(ctx, statementDescriptor(t, "SELECT * FROM mydb.secret"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthentication(t *testing.T) {
+	client := newTestServer(t)
+	desc := statementDescriptor(t, "SELECT 1")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"no credentials", context.Background()},
+		{"wrong password", basicAuth(context.Background(), "root", "wrong")},
+		{"unknown user", basicAuth(context.Background(), "nobody", "")},
+		{"unknown token", metadata.AppendToOutgoingContext(context.Background(), authHeader, bearerPrefix+"abc")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.GetFlightInfo(test.ctx, desc)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestUnsupportedCommands(t *testing.T) {
+	client := newTestServer(t)
+	ctx := basicAuth(context.Background(), "root", "secret")
+
+	_, err := client.GetFlightInfo(ctx, &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"t"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	cmd, err := anypb.New(&flightsql.TicketStatementQuery{})
+	require.NoError(t, err)
+	b, err := proto.Marshal(cmd)
+	require.NoError(t, err)
+	_, err = client.GetFlightInfo(ctx, &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: b})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = client.GetFlightInfo(ctx, statementDescriptor(t, "SELECT * FROM nope"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = doAction(ctx, client, "CancelQuery", &flightsql.CommandStatementQuery{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,370 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsrv
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	flight "github.com/dolthub/dolt/go/gen/proto/arrow/flight/protocol"
+	flightsql "github.com/dolthub/dolt/go/gen/proto/arrow/flight/protocol/sql"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+)
+
+// databaseHeader is the request header which names the database that queries run in. Queries with no database
+// header must qualify the tables they read with their database.
+const databaseHeader = "database"
+
+// The Flight SQL actions of DoAction.
+const (
+	createPreparedStatementAction = "CreatePreparedStatement"
+	closePreparedStatementAction  = "ClosePreparedStatement"
+)
+
+// flightSqlService is the Flight service of the server. It serves the Flight SQL commands which run queries and
+// prepared statements, and the catalog commands: GetFlightInfo and GetSchema return the schema of the results of a
+// command, and a ticket whose DoGet streams them. DoAction creates prepared statements. Other commands, including
+// updates and the DoPut of parameters, are unimplemented.
+type flightSqlService struct {
+	flight.UnimplementedFlightServiceServer
+
+	lgr        *logrus.Entry
+	engine     *gms.Engine
+	newContext func(ctx context.Context, client sql.Client) (*sql.Context, error)
+	auth       *authenticator
+}
+
+// Handshake returns a bearer token for the client, which authenticated with basic authorization, in the
+// authorization header of the response and in the payload of its HandshakeResponse.
+func (s *flightSqlService) Handshake(stream flight.FlightService_HandshakeServer) error {
+	if _, err := stream.Recv(); err != nil && err != io.EOF {
+		return err
+	}
+	tok, err := s.auth.newToken(clientFromContext(stream.Context()))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err = stream.SetHeader(metadata.Pairs(authHeader, bearerPrefix+tok)); err != nil {
+		return err
+	}
+	return stream.Send(&flight.HandshakeResponse{Payload: []byte(tok)})
+}
+
+func (s *flightSqlService) GetFlightInfo(ctx context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	sch, ticket, err := s.describe(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := anypb.New(ticket)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ticketBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &flight.FlightInfo{
+		Schema:           arrow.EncapsulatedSchema(sch),
+		FlightDescriptor: desc,
+		Endpoint:         []*flight.FlightEndpoint{{Ticket: &flight.Ticket{Ticket: ticketBytes}}},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}, nil
+}
+
+func (s *flightSqlService) GetSchema(ctx context.Context, desc *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+	sch, _, err := s.describe(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	return &flight.SchemaResult{Schema: arrow.EncapsulatedSchema(sch)}, nil
+}
+
+// describe returns the schema of the results of the command of |desc|, and the ticket whose DoGet returns them.
+// Catalog commands are their own tickets.
+func (s *flightSqlService) describe(ctx context.Context, desc *flight.FlightDescriptor) (sql.Schema, proto.Message, error) {
+	cmd, err := command(desc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sch, ok := catalogSchema(cmd); ok {
+		return sch, cmd, nil
+	}
+
+	var database, query string
+	switch cmd := cmd.(type) {
+	case *flightsql.CommandStatementQuery:
+		if cmd.TransactionId != nil {
+			return nil, nil, status.Error(codes.Unimplemented, "transactions are not supported")
+		}
+		database, query = requestDatabase(ctx), cmd.Query
+	case *flightsql.CommandPreparedStatementQuery:
+		var ok bool
+		database, query, ok = decodeStatementHandle(cmd.PreparedStatementHandle)
+		if !ok {
+			return nil, nil, status.Error(codes.InvalidArgument, "invalid prepared statement handle")
+		}
+	default:
+		return nil, nil, status.Errorf(codes.Unimplemented, "unsupported command: %s", cmd.ProtoReflect().Descriptor().FullName())
+	}
+	sch, err := s.querySchema(ctx, database, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sch, &flightsql.TicketStatementQuery{StatementHandle: encodeStatementHandle(database, query)}, nil
+}
+
+// DoGet returns the results of a ticket returned by GetFlightInfo as Arrow record batches. The query of a statement
+// ticket runs as its results are streamed.
+func (s *flightSqlService) DoGet(ticket *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	var msg anypb.Any
+	if err := proto.Unmarshal(ticket.Ticket, &msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid ticket: %v", err)
+	}
+	tkt, err := msg.UnmarshalNew()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "unsupported ticket: %s", msg.TypeUrl)
+	}
+
+	if sch, ok := catalogSchema(tkt); ok {
+		sqlCtx, err := s.queryContext(stream.Context(), "")
+		if err != nil {
+			return err
+		}
+		rows, err := s.catalogRows(sqlCtx, tkt)
+		if err != nil {
+			return err
+		}
+		return writeResults(sqlCtx, stream, sch, sql.RowsToRowIter(rows...))
+	}
+
+	tsq, ok := tkt.(*flightsql.TicketStatementQuery)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported ticket: %s", msg.TypeUrl)
+	}
+	database, query, ok := decodeStatementHandle(tsq.StatementHandle)
+	if !ok {
+		return status.Error(codes.InvalidArgument, "invalid statement handle")
+	}
+	sqlCtx, err := s.queryContext(stream.Context(), database)
+	if err != nil {
+		return err
+	}
+	sch, iter, err := s.engine.Query(sqlCtx, query)
+	if err != nil {
+		return queryError(err)
+	}
+	return writeResults(sqlCtx, stream, sch, iter)
+}
+
+// writeResults writes the rows of |iter| of |sch| to |stream| as Arrow record batches, and closes |iter|.
+func writeResults(ctx *sql.Context, stream flight.FlightService_DoGetServer, sch sql.Schema, iter sql.RowIter) error {
+	wr, err := arrow.NewArrowMessageWriter(flightDataWriter{stream}, sch)
+	if err != nil {
+		iter.Close(ctx)
+		return err
+	}
+	for {
+		var row sql.Row
+		row, err = iter.Next(ctx)
+		if err != nil {
+			break
+		}
+		if err = wr.WriteSqlRow(ctx, row); err != nil {
+			break
+		}
+	}
+	if err != io.EOF {
+		iter.Close(ctx)
+		return queryError(err)
+	}
+	if err = iter.Close(ctx); err != nil {
+		return queryError(err)
+	}
+	return wr.Close(ctx)
+}
+
+// ListActions returns the actions of DoAction.
+func (s *flightSqlService) ListActions(_ *flight.Empty, stream flight.FlightService_ListActionsServer) error {
+	for _, typ := range []*flight.ActionType{
+		{Type: createPreparedStatementAction, Description: "Creates a prepared statement for a query without parameters"},
+		{Type: closePreparedStatementAction, Description: "Closes a prepared statement"},
+	} {
+		if err := stream.Send(typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DoAction creates and closes prepared statements. The handle of a prepared statement is the statement handle of its
+// query, so the server keeps no state for it and closing it does nothing. Queries with parameters are unsupported,
+// since clients bind parameters with a DoPut of Arrow record batches, which the server doesn't read.
+func (s *flightSqlService) DoAction(action *flight.Action, stream flight.FlightService_DoActionServer) error {
+	switch action.Type {
+	case createPreparedStatementAction:
+		var req flightsql.ActionCreatePreparedStatementRequest
+		if err := actionBody(action, &req); err != nil {
+			return err
+		}
+		if req.TransactionId != nil {
+			return status.Error(codes.Unimplemented, "transactions are not supported")
+		}
+		stmt, err := sqlparser.Parse(req.Query)
+		if err != nil {
+			return queryError(err)
+		}
+		if len(sqlparser.GetBindvars(stmt)) > 0 {
+			return status.Error(codes.Unimplemented, "prepared statements with parameters are not supported")
+		}
+
+		ctx := stream.Context()
+		database := requestDatabase(ctx)
+		sch, err := s.querySchema(ctx, database, req.Query)
+		if err != nil {
+			return err
+		}
+		res, err := anypb.New(&flightsql.ActionCreatePreparedStatementResult{
+			PreparedStatementHandle: encodeStatementHandle(database, req.Query),
+			DatasetSchema:           arrow.EncapsulatedSchema(sch),
+		})
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		body, err := proto.Marshal(res)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return stream.Send(&flight.Result{Body: body})
+
+	case closePreparedStatementAction:
+		var req flightsql.ActionClosePreparedStatementRequest
+		if err := actionBody(action, &req); err != nil {
+			return err
+		}
+		if _, _, ok := decodeStatementHandle(req.PreparedStatementHandle); !ok {
+			return status.Error(codes.InvalidArgument, "invalid prepared statement handle")
+		}
+		return nil
+
+	default:
+		return status.Errorf(codes.Unimplemented, "unsupported action: %s", action.Type)
+	}
+}
+
+// querySchema returns the schema of the results of |query| in |database|, without running it.
+func (s *flightSqlService) querySchema(ctx context.Context, database, query string) (sql.Schema, error) {
+	sqlCtx, err := s.queryContext(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	analyzed, err := s.engine.AnalyzeQuery(sqlCtx, query)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return analyzed.Schema(), nil
+}
+
+// queryContext returns a context with a new session of the client of |ctx|, which uses |database|.
+func (s *flightSqlService) queryContext(ctx context.Context, database string) (*sql.Context, error) {
+	sqlCtx, err := s.newContext(ctx, clientFromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if database != "" {
+		sqlCtx.SetCurrentDatabase(database)
+	}
+	return sqlCtx, nil
+}
+
+// command returns the Flight SQL command of |desc|, or an error if |desc| isn't one.
+func command(desc *flight.FlightDescriptor) (proto.Message, error) {
+	if desc.Type != flight.FlightDescriptor_CMD {
+		return nil, status.Error(codes.InvalidArgument, "flight descriptors must be Flight SQL commands")
+	}
+	var msg anypb.Any
+	if err := proto.Unmarshal(desc.Cmd, &msg); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid command: %v", err)
+	}
+	cmd, err := msg.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unsupported command: %s", msg.TypeUrl)
+	}
+	return cmd, nil
+}
+
+// actionBody unmarshals the body of |action| into |msg|.
+func actionBody(action *flight.Action, msg proto.Message) error {
+	var body anypb.Any
+	if err := proto.Unmarshal(action.Body, &body); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid action body: %v", err)
+	}
+	if err := body.UnmarshalTo(msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unexpected action body: %s", body.TypeUrl)
+	}
+	return nil
+}
+
+// requestDatabase returns the database named by the database header of the request of |ctx|.
+func requestDatabase(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if dbs := md.Get(databaseHeader); len(dbs) > 0 {
+		return dbs[0]
+	}
+	return ""
+}
+
+// encodeStatementHandle returns the statement handle of a ticket for |query| in |database|. Database names can't
+// contain NUL.
+func encodeStatementHandle(database, query string) []byte {
+	return []byte(database + "\x00" + query)
+}
+
+func decodeStatementHandle(handle []byte) (database, query string, ok bool) {
+	return strings.Cut(string(handle), "\x00")
+}
+
+// queryError returns the error of a query as a gRPC status.
+func queryError(err error) error {
+	if sql.ErrPrivilegeCheckFailed.Is(err) || sql.ErrDatabaseAccessDeniedForUser.Is(err) || sql.ErrTableAccessDeniedForUser.Is(err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// flightDataWriter writes the messages of an Arrow stream as the FlightData of a DoGet stream.
+type flightDataWriter struct {
+	stream grpc.ServerStream
+}
+
+func (w flightDataWriter) WriteMessage(meta, body []byte) error {
+	return w.stream.SendMsg(&flight.FlightData{DataHeader: meta, DataBody: body})
+}
+
+func (w flightDataWriter) Close() error {
+	return nil
+}
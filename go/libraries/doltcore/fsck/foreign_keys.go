@@ -0,0 +1,66 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// CheckForeignKeys checks that every child row of the foreign keys of |root| references a parent row. |violation| is
+// called with the child table and a description of each foreign key with child rows that don't. Rows which are
+// already recorded as constraint violations are reported as well.
+func CheckForeignKeys(ctx context.Context, root *doltdb.RootValue, violation func(table string, err error)) error {
+	counter := &foreignKeyViolationCounter{violation: violation}
+	return merge.ValidateForeignKeys(ctx, root, nil, counter)
+}
+
+// foreignKeyViolationCounter is a merge.FKViolationReceiver which counts the violations of each foreign key.
+type foreignKeyViolationCounter struct {
+	violation func(table string, err error)
+	fk        doltdb.ForeignKey
+	count     int
+}
+
+var _ merge.FKViolationReceiver = (*foreignKeyViolationCounter)(nil)
+
+func (c *foreignKeyViolationCounter) StartFK(ctx context.Context, fk doltdb.ForeignKey) error {
+	c.fk = fk
+	c.count = 0
+	return nil
+}
+
+func (c *foreignKeyViolationCounter) EndCurrFK(ctx context.Context) error {
+	if c.count > 0 {
+		c.violation(c.fk.TableName, fmt.Errorf("%d rows violate foreign key %s referencing table %s",
+			c.count, c.fk.Name, c.fk.ReferencedTableName))
+	}
+	return nil
+}
+
+func (c *foreignKeyViolationCounter) NomsFKViolationFound(ctx context.Context, rowKey, rowValue types.Tuple) error {
+	c.count++
+	return nil
+}
+
+func (c *foreignKeyViolationCounter) ProllyFKViolationFound(ctx context.Context, rowKey, rowValue val.Tuple) error {
+	c.count++
+	return nil
+}
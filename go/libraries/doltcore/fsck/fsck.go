@@ -0,0 +1,301 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fsck checks the storage of a Dolt database for inconsistencies.
+package fsck
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ProblemKind is the kind of inconsistency a Problem describes.
+type ProblemKind string
+
+const (
+	// DanglingChunk is a chunk which is referenced, but can't be read.
+	DanglingChunk ProblemKind = "dangling chunk"
+	// IndexInconsistency is a secondary index whose contents don't match the rows of its table.
+	IndexInconsistency ProblemKind = "index inconsistency"
+	// ConstraintViolation is a table which has unresolved constraint violations.
+	ConstraintViolation ProblemKind = "constraint violation"
+	// ForeignKeyViolation is a table with rows which don't reference a row of the parent table of a foreign key.
+	ForeignKeyViolation ProblemKind = "foreign key violation"
+)
+
+// Problem is an inconsistency found by Check.
+type Problem struct {
+	Kind ProblemKind
+	// Ref is the ref whose root value the problem was found in
+	Ref string
+	// Root is the root value of |Ref| the problem was found in, one of "working", "staged" or "head"
+	Root string
+	// Table is the table the problem was found in, or empty if the root value itself couldn't be read
+	Table string
+	Err   error
+}
+
+func (p Problem) String() string {
+	if p.Table == "" {
+		return fmt.Sprintf("%s: %s (%s): %s", p.Kind, p.Ref, p.Root, p.Err)
+	}
+	return fmt.Sprintf("%s: %s (%s), table %s: %s", p.Kind, p.Ref, p.Root, p.Table, p.Err)
+}
+
+// Options are the options of Check.
+type Options struct {
+	// Quick skips checking secondary indexes against the rows of their tables, and foreign keys against the rows of
+	// their child tables, which read every row of the database.
+	Quick bool
+	// AllRefs checks the commits of tags, remote refs and workspaces, as well as branches.
+	AllRefs bool
+	// Parallelism is the number of tables checked at once. It defaults to the number of CPUs.
+	Parallelism int
+	// Progress, if set, is called each time a table has been checked.
+	Progress func(Progress)
+}
+
+// Progress is the progress of a Check.
+type Progress struct {
+	TablesChecked int
+	TablesTotal   int
+}
+
+// Check checks the branches of |ddb| for dangling chunks, secondary indexes which don't match their tables, child
+// rows which violate their foreign keys, and unresolved constraint violations. The working, staged and head root
+// values of each branch are checked. |report| is called with every problem found. A table which is shared between
+// root values is only checked, and reported, once, as are the foreign keys of a root value shared between refs. Tables are checked concurrently, but |report| and |opts.Progress| are never called concurrently. Check only
+// returns an error if it couldn't go on checking.
+func Check(ctx context.Context, ddb *doltdb.DoltDB, opts Options, report func(Problem)) error {
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return fmt.Errorf("fsck is only supported in the %s storage format", types.Format_DOLT.VersionString())
+	}
+
+	refTypes := map[ref.RefType]struct{}{ref.BranchRefType: {}}
+	if opts.AllRefs {
+		refTypes[ref.TagRefType] = struct{}{}
+		refTypes[ref.RemoteRefType] = struct{}{}
+		refTypes[ref.WorkspaceRefType] = struct{}{}
+	}
+	refs, err := ddb.GetRefsOfType(ctx, refTypes)
+	if err != nil {
+		return err
+	}
+
+	c := &checker{opts: opts, report: report, roots: make(hash.HashSet), tables: make(hash.HashSet)}
+	for _, r := range refs {
+		if err = c.checkRef(ctx, ddb, r); err != nil {
+			return err
+		}
+	}
+	return c.checkTables(ctx)
+}
+
+type checker struct {
+	opts   Options
+	report func(Problem)
+	// mu serializes calls to |report| and |opts.Progress|
+	mu sync.Mutex
+	// roots and tables are the hashes of the root values and tables already found
+	roots  hash.HashSet
+	tables hash.HashSet
+	// pending are the tables to check
+	pending []tableToCheck
+	// pendingRoots are the root values whose foreign keys to check
+	pendingRoots []rootToCheck
+}
+
+// tableToCheck is a table found in the root value |rootName| of |refName|.
+type tableToCheck struct {
+	refName  string
+	rootName string
+	name     string
+	tbl      *doltdb.Table
+}
+
+// rootToCheck is the root value |rootName| of |refName|.
+type rootToCheck struct {
+	refName  string
+	rootName string
+	root     *doltdb.RootValue
+}
+
+func (c *checker) problem(p Problem) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report(p)
+}
+
+func (c *checker) checkRef(ctx context.Context, ddb *doltdb.DoltDB, r ref.DoltRef) error {
+	if r.GetType() == ref.BranchRefType {
+		wsRef, err := ref.WorkingSetRefForHead(r)
+		if err != nil {
+			return err
+		}
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == nil {
+			c.checkRoot(ctx, r.String(), "working", ws.WorkingRoot())
+			c.checkRoot(ctx, r.String(), "staged", ws.StagedRoot())
+		} else if err != doltdb.ErrWorkingSetNotFound {
+			c.problem(Problem{Kind: DanglingChunk, Ref: r.String(), Root: "working", Err: err})
+		}
+	}
+
+	var cm *doltdb.Commit
+	var err error
+	if r.GetType() == ref.TagRefType {
+		var tag *doltdb.Tag
+		if tag, err = ddb.ResolveTag(ctx, ref.NewTagRef(r.GetPath())); err == nil {
+			cm = tag.Commit
+		}
+	} else {
+		cm, err = ddb.ResolveCommitRef(ctx, r)
+	}
+	var root *doltdb.RootValue
+	if err == nil {
+		root, err = cm.GetRootValue(ctx)
+	}
+	if err != nil {
+		c.problem(Problem{Kind: DanglingChunk, Ref: r.String(), Root: "head", Err: err})
+		return nil
+	}
+	c.checkRoot(ctx, r.String(), "head", root)
+	return nil
+}
+
+// checkRoot adds the tables of |root| which haven't been found in another root value to the tables to check, and
+// |root| to the root values whose foreign keys to check.
+func (c *checker) checkRoot(ctx context.Context, refName, rootName string, root *doltdb.RootValue) {
+	problem := func(table string, err error) {
+		c.problem(Problem{Kind: DanglingChunk, Ref: refName, Root: rootName, Table: table, Err: err})
+	}
+
+	h, err := root.HashOf()
+	if err != nil {
+		problem("", err)
+		return
+	}
+	if c.roots.Has(h) {
+		return
+	}
+	c.roots.Insert(h)
+	if !c.opts.Quick {
+		c.pendingRoots = append(c.pendingRoots, rootToCheck{refName: refName, rootName: rootName, root: root})
+	}
+
+	names, err := root.GetTableNames(ctx)
+	if err != nil {
+		problem("", err)
+		return
+	}
+	for _, name := range names {
+		tbl, _, err := root.GetTable(ctx, name)
+		if err != nil {
+			problem(name, err)
+			continue
+		}
+		h, err := tbl.HashOf()
+		if err != nil {
+			problem(name, err)
+			continue
+		}
+		if c.tables.Has(h) {
+			continue
+		}
+		c.tables.Insert(h)
+		c.pending = append(c.pending, tableToCheck{refName: refName, rootName: rootName, name: name, tbl: tbl})
+	}
+}
+
+// checkTables checks the pending tables, and then the foreign keys of the pending root values, |opts.Parallelism| at
+// a time.
+func (c *checker) checkTables(ctx context.Context) error {
+	parallelism := c.opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	checked := 0
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(parallelism)
+	for _, t := range c.pending {
+		t := t
+		eg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			c.checkTable(ctx, t)
+
+			if c.opts.Progress != nil {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				checked++
+				c.opts.Progress(Progress{TablesChecked: checked, TablesTotal: len(c.pending)})
+			}
+			return nil
+		})
+	}
+	for _, r := range c.pendingRoots {
+		r := r
+		eg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			c.checkForeignKeys(ctx, r)
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+func (c *checker) checkForeignKeys(ctx context.Context, r rootToCheck) {
+	err := CheckForeignKeys(ctx, r.root, func(table string, err error) {
+		c.problem(Problem{Kind: ForeignKeyViolation, Ref: r.refName, Root: r.rootName, Table: table, Err: err})
+	})
+	if err != nil {
+		// the rows of a table couldn't be read
+		c.problem(Problem{Kind: DanglingChunk, Ref: r.refName, Root: r.rootName, Err: err})
+	}
+}
+
+func (c *checker) checkTable(ctx context.Context, t tableToCheck) {
+	problem := func(kind ProblemKind, err error) {
+		c.problem(Problem{Kind: kind, Ref: t.refName, Root: t.rootName, Table: t.name, Err: err})
+	}
+
+	if err := CheckChunkReferences(ctx, t.tbl); err != nil {
+		// the table's indexes can't be read, so there's nothing else to check
+		problem(DanglingChunk, err)
+		return
+	}
+	if !c.opts.Quick {
+		if err := CheckSecondaryIndexes(ctx, t.tbl); err != nil {
+			problem(IndexInconsistency, err)
+		}
+	}
+	if n, err := t.tbl.NumConstraintViolations(ctx); err != nil {
+		problem(DanglingChunk, err)
+	} else if n > 0 {
+		problem(ConstraintViolation, fmt.Errorf("%d unresolved constraint violations", n))
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsck
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// CheckChunkReferences checks that every chunk of the rows and secondary indexes of |tbl| can be read.
+func CheckChunkReferences(ctx context.Context, tbl *doltdb.Table) error {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkIndex := func(ctx context.Context, idx durable.Index) error {
+		pm := durable.ProllyMapFromIndex(idx)
+		return pm.WalkNodes(ctx, func(ctx context.Context, nd tree.Node) error {
+			if nd.Size() <= 0 {
+				return fmt.Errorf("encountered nil tree.Node")
+			}
+			return nil
+		})
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	if err = checkIndex(ctx, rows); err != nil {
+		return err
+	}
+
+	indexes, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	return durable.IterAllIndexes(ctx, sch, indexes, func(_ string, idx durable.Index) error {
+		return checkIndex(ctx, idx)
+	})
+}
+
+// CheckSecondaryIndexes checks that the contents of the secondary indexes of |tbl| are consistent with its rows.
+func CheckSecondaryIndexes(ctx context.Context, tbl *doltdb.Table) error {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	primary := durable.ProllyMapFromIndex(rows)
+
+	set, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	for _, def := range sch.Indexes().AllIndexes() {
+		idx, err := set.GetIndex(ctx, sch, def.Name())
+		if err != nil {
+			return err
+		}
+		secondary := durable.ProllyMapFromIndex(idx)
+
+		if err = checkIndexConsistency(ctx, sch, def, primary, secondary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkIndexConsistency(
+	ctx context.Context,
+	sch schema.Schema,
+	def schema.Index,
+	primary, secondary prolly.Map,
+) error {
+	// TODO: the descriptors in the primary key are different
+	// than the ones in the secondary key; this check assumes
+	// they're the same
+	if len(def.PrefixLengths()) > 0 {
+		return nil
+	}
+
+	if schema.IsKeyless(sch) {
+		return checkKeylessIndex(ctx, sch, def, primary, secondary)
+	}
+
+	return checkPkIndex(ctx, sch, def, primary, secondary)
+}
+
+func checkKeylessIndex(ctx context.Context, sch schema.Schema, def schema.Index, primary, secondary prolly.Map) error {
+	secondary = prolly.ConvertToSecondaryKeylessIndex(secondary)
+	idxDesc, _ := secondary.Descriptors()
+	_, valDesc := primary.Descriptors()
+	builder := val.NewTupleBuilder(idxDesc)
+	mapping, err := ordinalMappingsForSecondaryIndex(sch, def)
+	if err != nil {
+		return err
+	}
+	filter, err := index.NewPartialIndexFilter(ctx, def, idxDesc)
+	if err != nil {
+		return err
+	}
+
+	iter, err := primary.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		hashId, value, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// make secondary index key
+		for i := range mapping {
+			j := mapping.MapOrdinal(i)
+			// first field in |value| is cardinality
+			field, err := valDesc.GetPlainField(j+1, value)
+			if err != nil {
+				return err
+			}
+			if def.IsSpatial() {
+				geom, _, err := sqltypes.GeometryType{}.Convert(field[:len(field)-1])
+				if err != nil {
+					return err
+				}
+				cell := index.ZCell(geom.(sqltypes.GeometryValue))
+				field = cell[:]
+			}
+			builder.PutRaw(i, field)
+		}
+		builder.PutRaw(idxDesc.Count()-1, hashId.GetField(0))
+		k := builder.Build(primary.Pool())
+		if filter != nil && !filter.Matches(k) {
+			continue
+		}
+
+		ok, err := secondary.Has(ctx, k)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("index key %s not found in index %s", builder.Desc.Format(k), def.Name())
+		}
+	}
+}
+
+func checkPkIndex(ctx context.Context, sch schema.Schema, def schema.Index, primary, secondary prolly.Map) error {
+	// secondary indexes have empty values
+	idxDesc, _ := secondary.Descriptors()
+	builder := val.NewTupleBuilder(idxDesc)
+	mapping, err := ordinalMappingsForSecondaryIndex(sch, def)
+	if err != nil {
+		return err
+	}
+	filter, err := index.NewPartialIndexFilter(ctx, def, idxDesc)
+	if err != nil {
+		return err
+	}
+
+	// Before we walk through the primary index data and validate that every row in the primary index exists in the
+	// secondary index, we also check that the primary index and secondary index have the same number of rows.
+	// Otherwise, we won't catch if the secondary index has extra, bogus data in it.
+	totalSecondaryCount, err := secondary.Count()
+	if err != nil {
+		return err
+	}
+	totalPrimaryCount, err := primary.Count()
+	if err != nil {
+		return err
+	}
+	// partial indexes only contain the rows matching their predicate, which are counted as we go
+	if filter == nil && totalSecondaryCount != totalPrimaryCount {
+		return fmt.Errorf("primary index row count (%d) does not match secondary index row count (%d)",
+			totalPrimaryCount, totalSecondaryCount)
+	}
+
+	kd, valDesc := primary.Descriptors()
+	pkSize := kd.Count()
+	iter, err := primary.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	matched := 0
+	for {
+		key, value, err := iter.Next(ctx)
+		if err == io.EOF {
+			if filter != nil && matched != totalSecondaryCount {
+				return fmt.Errorf("matching row count (%d) does not match partial index row count (%d)",
+					matched, totalSecondaryCount)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// make secondary index key
+		for i := range mapping {
+			j := mapping.MapOrdinal(i)
+			if j < pkSize {
+				builder.PutRaw(i, key.GetField(j))
+			} else {
+				field, err := valDesc.GetPlainField(j-pkSize, value)
+				if err != nil {
+					return err
+				}
+				if def.IsSpatial() {
+					geom, _, err := sqltypes.GeometryType{}.Convert(field[:len(field)-1])
+					if err != nil {
+						return err
+					}
+					cell := index.ZCell(geom.(sqltypes.GeometryValue))
+					field = cell[:]
+				}
+				builder.PutRaw(i, field)
+			}
+		}
+		k := builder.Build(primary.Pool())
+		if filter != nil && !filter.Matches(k) {
+			continue
+		}
+		matched++
+
+		ok, err := secondary.Has(ctx, k)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("index key %v not found in index %s", builder.Desc.Format(k), def.Name())
+		}
+	}
+}
+
+func ordinalMappingsForSecondaryIndex(sch schema.Schema, def schema.Index) (ord val.OrdinalMapping, err error) {
+	// assert empty values for secondary indexes
+	if def.Schema().GetNonPKCols().Size() > 0 {
+		return nil, fmt.Errorf("expected empty secondary index values")
+	}
+
+	secondary := def.Schema().GetPKCols()
+	ord = make(val.OrdinalMapping, secondary.Size())
+
+	for i := range ord {
+		name := secondary.GetByIndex(i).Name
+		ord[i] = -1
+
+		pks := sch.GetPKCols().GetColumns()
+		for j, col := range pks {
+			if col.Name == name {
+				ord[i] = j
+			}
+		}
+		vals := sch.GetNonPKCols().GetColumns()
+		for j, col := range vals {
+			if col.Name == name {
+				ord[i] = j + len(pks)
+			}
+		}
+		if ord[i] < 0 {
+			return nil, fmt.Errorf("column %s of index %s not found", name, def.Name())
+		}
+	}
+	return
+}
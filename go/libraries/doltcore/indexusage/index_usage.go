@@ -0,0 +1,249 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package indexusage keeps lightweight counters of how often the indexes of a database are read, so that indexes
+// which are never used can be found and dropped.
+package indexusage
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// FileName is the name of the file, in the .dolt directory of a database, that index usage is persisted to.
+const FileName = "index_usage.json"
+
+// FlushInterval is how often a running server persists index usage.
+const FlushInterval = time.Minute
+
+// Stat is the usage of a single index on a single branch.
+type Stat struct {
+	Branch   string    `json:"branch"`
+	Table    string    `json:"table"`
+	Index    string    `json:"index"`
+	Reads    uint64    `json:"reads"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+type key struct {
+	branch, table, index string
+}
+
+func newKey(branch, table, index string) key {
+	return key{branch: branch, table: strings.ToLower(table), index: strings.ToLower(index)}
+}
+
+// Tracker counts the reads of the indexes of one database. It is safe for concurrent use. Counts are kept in memory
+// and written to the database's .dolt directory by Flush.
+type Tracker struct {
+	mu    sync.Mutex
+	fs    filesys.Filesys
+	stats map[key]*Stat
+	dirty bool
+}
+
+// New returns an empty Tracker for the database rooted at |fs|. A nil |fs| returns a Tracker that is never persisted.
+func New(fs filesys.Filesys) *Tracker {
+	return &Tracker{fs: fs, stats: make(map[key]*Stat)}
+}
+
+// Load returns a Tracker for the database rooted at |fs|, initialized with any usage previously persisted there. A nil
+// |fs| returns a Tracker that is never persisted.
+func Load(fs filesys.Filesys) (*Tracker, error) {
+	t := New(fs)
+	if fs == nil {
+		return t, nil
+	}
+
+	if exists, isDir := fs.Exists(usageFile()); !exists || isDir {
+		return t, nil
+	}
+
+	data, err := fs.ReadFile(usageFile())
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []Stat
+	if err = json.Unmarshal(data, &stats); err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		s := stats[i]
+		t.stats[newKey(s.Branch, s.Table, s.Index)] = &s
+	}
+
+	return t, nil
+}
+
+// RecordRead counts a read of |index| on |table| by a query on |branch|.
+func (t *Tracker) RecordRead(branch, table, index string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := newKey(branch, table, index)
+	s, ok := t.stats[k]
+	if !ok {
+		s = &Stat{Branch: branch, Table: table, Index: index}
+		t.stats[k] = s
+	}
+	s.Reads++
+	s.LastUsed = time.Now().UTC()
+	t.dirty = true
+}
+
+// Usage returns the usage of |index| on |table| on |branch|, and whether the index has ever been read there.
+func (t *Tracker) Usage(branch, table, index string) (Stat, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.stats[newKey(branch, table, index)]
+	if !ok {
+		return Stat{}, false
+	}
+	return *s, true
+}
+
+// Stats returns the usage of every index that has been read, ordered by branch, table and index.
+func (t *Tracker) Stats() []Stat {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]Stat, 0, len(t.stats))
+	for _, s := range t.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Branch != stats[j].Branch {
+			return stats[i].Branch < stats[j].Branch
+		}
+		if stats[i].Table != stats[j].Table {
+			return stats[i].Table < stats[j].Table
+		}
+		return stats[i].Index < stats[j].Index
+	})
+
+	return stats
+}
+
+// Report returns the usage on |branch| of every secondary index of the tables in |root|, which is the root of
+// |branch|. Indexes that have never been read there are included with no reads.
+func (t *Tracker) Report(ctx context.Context, branch string, root *doltdb.RootValue) ([]Stat, error) {
+	tblNames, err := root.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(tblNames)
+
+	var stats []Stat
+	for _, tblName := range tblNames {
+		if doltdb.HasDoltPrefix(tblName) {
+			continue
+		}
+
+		tbl, ok, err := root.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, idx := range sch.Indexes().AllIndexes() {
+			s, ok := t.Usage(branch, tblName, idx.Name())
+			if !ok {
+				s = Stat{Branch: branch, Table: tblName, Index: idx.Name()}
+			}
+			stats = append(stats, s)
+		}
+	}
+
+	return stats, nil
+}
+
+// Flush persists the usage counted since the last flush. It does nothing if nothing has changed.
+func (t *Tracker) Flush() error {
+	if t.fs == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	dirty := t.dirty
+	t.dirty = false
+	t.mu.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	err := t.write()
+	if err != nil {
+		t.mu.Lock()
+		t.dirty = true
+		t.mu.Unlock()
+	}
+	return err
+}
+
+func (t *Tracker) write() error {
+	data, err := json.MarshalIndent(t.Stats(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file and move it into place, so that a crash never leaves a truncated file behind
+	tmp := usageFile() + ".tmp"
+	if err = t.fs.WriteFile(tmp, data); err != nil {
+		return err
+	}
+	return t.fs.MoveFile(tmp, usageFile())
+}
+
+// RunFlusher flushes the usage of |trackers| every |interval| until |ctx| is done, then flushes one last time.
+func RunFlusher(ctx context.Context, interval time.Duration, trackers func() []*Tracker) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	flushAll := func() {
+		for _, t := range trackers() {
+			// usage is advisory, a failed flush is retried on the next tick
+			_ = t.Flush()
+		}
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			flushAll()
+		case <-ctx.Done():
+			flushAll()
+			return
+		}
+	}
+}
+
+func usageFile() string {
+	return filepath.Join(dbfactory.DoltDir, FileName)
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexusage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestTrackerPersistence(t *testing.T) {
+	fs := filesys.NewInMemFS([]string{"/db/" + dbfactory.DoltDir}, nil, "/db")
+
+	tracker, err := Load(fs)
+	require.NoError(t, err)
+	tracker.RecordRead("main", "t", "idx_a")
+	tracker.RecordRead("main", "T", "IDX_A")
+	tracker.RecordRead("main", "t", "idx_b")
+	tracker.RecordRead("other", "t", "idx_a")
+
+	// nothing is persisted until flushed
+	reloaded, err := Load(fs)
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.Stats())
+
+	require.NoError(t, tracker.Flush())
+	reloaded, err = Load(fs)
+	require.NoError(t, err)
+	assert.Equal(t, tracker.Stats(), reloaded.Stats())
+
+	s, ok := reloaded.Usage("main", "t", "idx_a")
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), s.Reads)
+	assert.False(t, s.LastUsed.IsZero())
+
+	s, ok = reloaded.Usage("other", "t", "idx_a")
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), s.Reads)
+
+	_, ok = reloaded.Usage("other", "t", "idx_b")
+	assert.False(t, ok)
+}
+
+func TestTrackerWithoutFilesys(t *testing.T) {
+	tracker, err := Load(nil)
+	require.NoError(t, err)
+	tracker.RecordRead("main", "t", "idx_a")
+	require.NoError(t, tracker.Flush())
+
+	stats := tracker.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "idx_a", stats[0].Index)
+}
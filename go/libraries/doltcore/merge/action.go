@@ -51,6 +51,9 @@ type MergeSpec struct {
 	Email           string
 	Name            string
 	Date            time.Time
+	// CascadeForeignKeys applies the ON DELETE actions of foreign keys to child rows whose parent rows were deleted
+	// by the merge
+	CascadeForeignKeys bool
 }
 
 // NewMergeSpec returns MergeSpec object using arguments passed into this function, which are doltdb.Roots, username,
@@ -180,7 +183,7 @@ func ExecuteMerge(ctx context.Context, dEnv *env.DoltEnv, spec *MergeSpec) (map[
 		return nil, err
 	}
 	opts := editor.Options{Deaf: dEnv.BulkDbEaFactory(), Tempdir: tmpDir}
-	result, err := MergeCommits(ctx, spec.HeadC, spec.MergeC, opts)
+	result, err := MergeCommits(ctx, spec.HeadC, spec.MergeC, opts, spec.CascadeForeignKeys)
 	if err != nil {
 		switch err {
 		case doltdb.ErrUpToDate:
@@ -33,7 +33,10 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	dtu "github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 )
 
 func TestMerge(t *testing.T) {
@@ -415,3 +418,49 @@ func generateTestData() string {
 	sb.WriteString(");")
 	return sb.String()
 }
+
+const manyTablesScale = 1000
+
+// BenchmarkMergeManyTables merges two branches of a database with |manyTablesScale| tables, only one of which was
+// changed on both branches. The unchanged tables should not be read by the merge.
+func BenchmarkMergeManyTables(b *testing.B) {
+	ctx := context.Background()
+	dEnv := dtu.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	var sb strings.Builder
+	for i := 0; i < manyTablesScale; i++ {
+		fmt.Fprintf(&sb, "CREATE TABLE t%d (pk int PRIMARY KEY, c0 int); INSERT INTO t%d VALUES (1, 1);", i, i)
+	}
+	setup := []testCommand{
+		{cmd.SqlCmd{}, args{"-q", sb.String()}},
+		{cmd.AddCmd{}, args{"."}},
+		{cmd.CommitCmd{}, args{"-am", "created tables"}},
+		{cmd.CheckoutCmd{}, args{"-b", "other"}},
+		{cmd.SqlCmd{}, args{"-q", "INSERT INTO t0 VALUES (2, 2);"}},
+		{cmd.CommitCmd{}, args{"-am", "added a row on other"}},
+		{cmd.CheckoutCmd{}, args{env.DefaultInitBranch}},
+		{cmd.SqlCmd{}, args{"-q", "INSERT INTO t0 VALUES (3, 3);"}},
+		{cmd.CommitCmd{}, args{"-am", "added a row on main"}},
+	}
+	for _, tc := range setup {
+		exit := tc.cmd.Exec(ctx, tc.cmd.Name(), tc.args, dEnv, cmd.BuildEmptyCliContext())
+		require.Equal(b, 0, exit)
+	}
+
+	ours, err := dEnv.DoltDB.ResolveCommitRef(ctx, ref.NewBranchRef(env.DefaultInitBranch))
+	require.NoError(b, err)
+	theirs, err := dEnv.DoltDB.ResolveCommitRef(ctx, ref.NewBranchRef("other"))
+	require.NoError(b, err)
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(b, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := merge.MergeCommits(ctx, ours, theirs, opts, false)
+		require.NoError(b, err)
+		require.Equal(b, merge.TableModified, result.Stats["t0"].Operation)
+	}
+}
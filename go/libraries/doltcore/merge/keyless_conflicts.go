@@ -0,0 +1,47 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// KeylessConflictRowIdColumn is the name of the column of a keyless table's conflicts table that holds the stable
+// identifier of each conflicting row.
+const KeylessConflictRowIdColumn = "dolt_row_id"
+
+// KeylessConflictRowIds assigns stable identifiers to the conflicts of a keyless table. Rows of a keyless table are
+// keyed by the hash of their contents, and the same row may conflict more than once if it was merged from more than
+// one root. The identifier of a conflict is the row hash followed by the ordinal of the conflict among the conflicts
+// for that row. Conflicts must be passed to Next in the order of the artifacts table, which orders conflicts for the
+// same row by the root they were merged from.
+type KeylessConflictRowIds struct {
+	prev    string
+	ordinal int
+}
+
+// Next returns the identifier of the conflict for the keyless row |key|.
+func (ids *KeylessConflictRowIds) Next(key val.Tuple) string {
+	h := hex.EncodeToString(key.GetField(0))
+	if h == ids.prev {
+		ids.ordinal++
+	} else {
+		ids.prev, ids.ordinal = h, 0
+	}
+	return fmt.Sprintf("%s:%d", h, ids.ordinal)
+}
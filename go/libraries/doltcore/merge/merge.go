@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -27,6 +29,8 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/libraries/doltcore/merge")
+
 var ErrFastForward = errors.New("fast forward")
 var ErrTableDeletedAndModified = errors.New("conflict: table with same name deleted and modified ")
 var ErrSchemaConflict = goerrors.NewKind("schema conflict found, merge aborted. Please alter schema to prevent schema conflicts before merging: %s")
@@ -45,7 +49,10 @@ var ErrMultipleViolationsForRow = errors.New("multiple violations for row not su
 
 var ErrSameTblAddedTwice = goerrors.NewKind("table with same name '%s' added in 2 commits can't be merged")
 
-func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
+// MergeCommits three-way merges |commit| and |mergeCommit| with their common ancestor. If |cascadeForeignKeys| is
+// set, the ON DELETE actions of foreign keys are applied to child rows whose parent rows were deleted by the merge.
+// Volatile columns changed on both sides take the values of the most recent of the two commits.
+func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit, opts editor.Options, cascadeForeignKeys bool) (*Result, error) {
 	ancCommit, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 	if err != nil {
 		return nil, err
@@ -66,9 +73,22 @@ func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit, opts
 		return nil, err
 	}
 
+	ourMeta, err := commit.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	theirMeta, err := mergeCommit.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	mo := MergeOpts{
 		IsCherryPick:        false,
 		KeepSchemaConflicts: true,
+		ApplyIgnorePolicies: true,
+		CascadeForeignKeys:  cascadeForeignKeys,
+		TheirsAreNewer:      theirMeta.Time().After(ourMeta.Time()),
 	}
 	return MergeRoots(ctx, ourRoot, theirRoot, ancRoot, mergeCommit, ancCommit, opts, mo)
 }
@@ -123,6 +143,9 @@ func MergeRoots(
 	opts editor.Options,
 	mergeOpts MergeOpts,
 ) (*Result, error) {
+	ctx, span := tracer.Start(ctx, "merge.MergeRoots")
+	defer span.End()
+
 	var (
 		conflictStash  *conflictStash
 		violationStash *violationStash
@@ -154,6 +177,13 @@ func MergeRoots(
 		return nil, err
 	}
 
+	// Parent tables of foreign keys are merged before their child tables
+	tblNames, err = sortTablesByForeignKeys(ctx, tblNames, ourRoot, theirRoot)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("num_tables", len(tblNames)))
+
 	tblToStats := make(map[string]*MergeStats)
 
 	mergedRoot := ourRoot
@@ -169,9 +199,47 @@ func MergeRoots(
 		return nil, err
 	}
 
+	// Tables ignored by dolt_ignore are merged according to the merge policies of our side of the merge
+	var ignorePatterns doltdb.IgnorePatterns
+	if mergeOpts.ApplyIgnorePolicies {
+		ignorePatterns, err = doltdb.GetIgnoredTablePatterns(ctx, doltdb.Roots{Working: ourRoot})
+		if err != nil {
+			return nil, err
+		}
+
+		// as are the columns listed in dolt_volatile_columns
+		merger.volatile, err = doltdb.GetVolatileColumns(ctx, ourRoot)
+		if err != nil {
+			return nil, err
+		}
+		merger.rightIsNewer = mergeOpts.TheirsAreNewer
+	}
+
 	var schConflicts []SchemaConflict
 	for _, tblName := range tblNames {
-		mergedTable, stats, err := merger.MergeTable(ctx, tblName, opts, mergeOpts)
+		// Tables they didn't change keep our version whatever the merge policy, so they aren't loaded at all
+		unchanged, err := merger.theirTableUnchanged(ctx, tblName)
+		if err != nil {
+			return nil, err
+		}
+		if unchanged {
+			if ok, err := ourRoot.HasTable(ctx, tblName); err != nil {
+				return nil, err
+			} else if ok {
+				tblToStats[tblName] = &MergeStats{Operation: TableUnmodified}
+			}
+			continue
+		}
+
+		policy := doltdb.MergePolicyDefault
+		if !doltdb.HasDoltPrefix(tblName) {
+			policy, err = ignorePatterns.MergePolicy(tblName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		mergedTable, stats, err := merger.MergeIgnoredTable(ctx, tblName, policy, opts, mergeOpts)
 		if err != nil {
 			return nil, err
 		}
@@ -216,25 +284,7 @@ func MergeRoots(
 		}
 	}
 
-	mergedFKColl, conflicts, err := ForeignKeysMerge(ctx, mergedRoot, ourRoot, theirRoot, ancRoot)
-	if err != nil {
-		return nil, err
-	}
-	if len(conflicts) > 0 {
-		return nil, fmt.Errorf("foreign key conflicts")
-	}
-
-	mergedRoot, err = mergedRoot.PutForeignKeyCollection(ctx, mergedFKColl)
-	if err != nil {
-		return nil, err
-	}
-
-	h, err := merger.rightSrc.HashOf()
-	if err != nil {
-		return nil, err
-	}
-
-	mergedRoot, _, err = AddForeignKeyViolations(ctx, mergedRoot, ancRoot, nil, h)
+	mergedRoot, err = mergeForeignKeys(ctx, mergedRoot, ourRoot, theirRoot, ancRoot, merger.rightSrc, mergeOpts, tblToStats)
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +329,47 @@ func MergeRoots(
 	}, nil
 }
 
+// mergeForeignKeys merges the foreign keys of the roots into |mergedRoot|, applies the ON DELETE actions of the
+// foreign keys if |mergeOpts| cascades them, and records the foreign key violations of the merged rows.
+func mergeForeignKeys(
+	ctx context.Context,
+	mergedRoot, ourRoot, theirRoot, ancRoot *doltdb.RootValue,
+	theirs doltdb.Rootish,
+	mergeOpts MergeOpts,
+	tblToStats map[string]*MergeStats,
+) (*doltdb.RootValue, error) {
+	ctx, span := tracer.Start(ctx, "merge.ForeignKeys")
+	defer span.End()
+
+	mergedFKColl, conflicts, err := ForeignKeysMerge(ctx, mergedRoot, ourRoot, theirRoot, ancRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("foreign key conflicts")
+	}
+
+	mergedRoot, err = mergedRoot.PutForeignKeyCollection(ctx, mergedFKColl)
+	if err != nil {
+		return nil, err
+	}
+
+	if mergeOpts.CascadeForeignKeys {
+		mergedRoot, err = cascadeForeignKeys(ctx, mergedRoot, ancRoot, tblToStats)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	h, err := theirs.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	mergedRoot, _, err = AddForeignKeyViolations(ctx, mergedRoot, ancRoot, nil, h)
+	return mergedRoot, err
+}
+
 // mergeCVsWithStash merges the table constraint violations in |stash| with |root|.
 // Returns an updated root with all the merged CVs.
 func mergeCVsWithStash(ctx context.Context, root *doltdb.RootValue, stash *violationStash) (*doltdb.RootValue, error) {
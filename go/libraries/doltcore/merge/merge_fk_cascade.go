@@ -0,0 +1,366 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// sortTablesByForeignKeys orders |tblNames| so that the parent tables of the foreign keys of |roots| come before
+// their child tables. Tables are otherwise kept in their given order, as are tables whose foreign keys form a cycle.
+func sortTablesByForeignKeys(ctx context.Context, tblNames []string, roots ...*doltdb.RootValue) ([]string, error) {
+	pos := make(map[string]int, len(tblNames))
+	for i, name := range tblNames {
+		pos[strings.ToLower(name)] = i
+	}
+
+	// parents[i] are the positions of the parent tables of tblNames[i]
+	parents := make([]map[int]struct{}, len(tblNames))
+	for _, root := range roots {
+		fkColl, err := root.GetForeignKeyCollection(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, fk := range fkColl.AllKeys() {
+			child, ok := pos[strings.ToLower(fk.TableName)]
+			if !ok {
+				continue
+			}
+			parent, ok := pos[strings.ToLower(fk.ReferencedTableName)]
+			if !ok || parent == child {
+				continue
+			}
+			if parents[child] == nil {
+				parents[child] = make(map[int]struct{})
+			}
+			parents[child][parent] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(tblNames))
+	done := make([]bool, len(tblNames))
+	for len(sorted) < len(tblNames) {
+		next := -1
+		for i := range tblNames {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for p := range parents[i] {
+				if !done[p] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// the remaining tables form a cycle, take the first of them
+			for i := range tblNames {
+				if !done[i] {
+					next = i
+					break
+				}
+			}
+		}
+		done[next] = true
+		sorted = append(sorted, tblNames[next])
+	}
+	return sorted, nil
+}
+
+// cascadeForeignKeys applies the ON DELETE actions of the foreign keys of |root|, the result of a merge with
+// |ancRoot| as its ancestor, to the child rows left without a parent row by the merge. When one side of a merge
+// deletes a parent row and the other side adds or modifies its child rows, those child rows are deleted for an
+// ON DELETE CASCADE foreign key, and have their foreign key columns set to NULL for an ON DELETE SET NULL foreign
+// key, rather than being reported as constraint violations. The cascaded rows are counted in the MergeStats of
+// their table in |tblToStats|.
+//
+// Child rows whose parent row exists in neither |ancRoot| nor |root| are left alone, as are child rows which can't be
+// set to NULL, and are reported as constraint violations as usual. Cascades are repeated until no more rows are
+// affected, so deletes cascade to the children of child tables.
+func cascadeForeignKeys(ctx context.Context, root, ancRoot *doltdb.RootValue, tblToStats map[string]*MergeStats) (*doltdb.RootValue, error) {
+	if !types.IsFormat_DOLT(root.VRW().Format()) {
+		return root, nil
+	}
+	// parent rows deleted by earlier cascades exist in the merged root
+	preRoots := []*doltdb.RootValue{ancRoot, root}
+	for {
+		c := &foreignKeyCascader{root: root, preRoots: preRoots, stats: tblToStats}
+		err := GetForeignKeyViolations(ctx, root, ancRoot, nil, c)
+		if err != nil {
+			return nil, err
+		}
+		if c.cascaded == 0 {
+			return c.root, nil
+		}
+		root = c.root
+	}
+}
+
+// foreignKeyCascader is a FKViolationReceiver which applies the ON DELETE action of a foreign key to the child rows
+// whose parent row was deleted.
+type foreignKeyCascader struct {
+	root *doltdb.RootValue
+	// preRoots are the root values in which the parent rows of cascaded child rows must have existed
+	preRoots []*doltdb.RootValue
+	stats    map[string]*MergeStats
+	cascaded int
+
+	currFk      doltdb.ForeignKey
+	active      bool
+	childTbl    string
+	childSch    schema.Schema
+	childIdx    schema.Index
+	preParents  []prolly.Map
+	prefixDescs []val.TupleDesc
+	kb          *val.TupleBuilder
+	keys        []val.Tuple
+	seen        map[string]struct{}
+}
+
+var _ FKViolationReceiver = (*foreignKeyCascader)(nil)
+
+func (c *foreignKeyCascader) StartFK(ctx context.Context, fk doltdb.ForeignKey) error {
+	c.currFk = fk
+	c.active = false
+	c.keys = nil
+	c.seen = make(map[string]struct{})
+
+	if fk.OnDelete != doltdb.ForeignKeyReferentialAction_Cascade && fk.OnDelete != doltdb.ForeignKeyReferentialAction_SetNull {
+		return nil
+	}
+
+	c.preParents, c.prefixDescs = nil, nil
+	for _, root := range c.preRoots {
+		parent, ok, err := newConstraintViolationsLoadedTable(ctx, fk.ReferencedTableName, fk.ReferencedTableIndex, root)
+		if err == doltdb.ErrTableNotFound || (err == nil && !ok) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		m := durable.ProllyMapFromIndex(parent.IndexData)
+		kd, _ := m.Descriptors()
+		c.preParents = append(c.preParents, m)
+		c.prefixDescs = append(c.prefixDescs, kd.PrefixDesc(len(fk.TableColumns)))
+	}
+	if len(c.preParents) == 0 {
+		// no parent rows could have been deleted
+		return nil
+	}
+
+	child, ok, err := newConstraintViolationsLoadedTable(ctx, fk.TableName, fk.TableIndex, c.root)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	if fk.OnDelete == doltdb.ForeignKeyReferentialAction_SetNull {
+		// keyless rows are addressed by their contents, so they can't be updated in place
+		if schema.IsKeyless(child.Schema) {
+			return nil
+		}
+		for _, tag := range fk.TableColumns {
+			col, ok := child.Schema.GetNonPKCols().GetByTag(tag)
+			if !ok || !col.IsNullable() {
+				return nil
+			}
+		}
+	}
+
+	c.kb = val.NewTupleBuilder(c.prefixDescs[len(c.prefixDescs)-1])
+	c.childTbl = child.TableName
+	c.childSch = child.Schema
+	c.childIdx = child.Index
+	c.active = true
+	return nil
+}
+
+func (c *foreignKeyCascader) NomsFKViolationFound(ctx context.Context, rowKey, rowValue types.Tuple) error {
+	return nil
+}
+
+func (c *foreignKeyCascader) ProllyFKViolationFound(ctx context.Context, rowKey, rowValue val.Tuple) error {
+	if !c.active {
+		return nil
+	}
+	if _, ok := c.seen[string(rowKey)]; ok {
+		return nil
+	}
+
+	parentKey, hasNulls, err := makePartialKey(c.kb, c.currFk.TableColumns, c.childIdx, c.childSch, rowKey, rowValue, c.preParents[0].Pool())
+	if err != nil {
+		return err
+	}
+	if hasNulls {
+		return nil
+	}
+	// only rows whose parent row was deleted are cascaded, rows which never had a parent remain violations
+	hadParent := false
+	for i, m := range c.preParents {
+		ok, err := m.HasPrefix(ctx, parentKey, c.prefixDescs[i])
+		if err != nil {
+			return err
+		}
+		if ok {
+			hadParent = true
+			break
+		}
+	}
+	if !hadParent {
+		return nil
+	}
+
+	c.seen[string(rowKey)] = struct{}{}
+	c.keys = append(c.keys, rowKey)
+	return nil
+}
+
+func (c *foreignKeyCascader) EndCurrFK(ctx context.Context) error {
+	if len(c.keys) == 0 {
+		return nil
+	}
+
+	// the child table may have been changed by the cascades of another foreign key, so rows are read again
+	tbl, ok, err := c.root.GetTable(ctx, c.childTbl)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	m := durable.ProllyMapFromIndex(rowData)
+	idxSet, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	mutIdxs, err := GetMutableSecondaryIdxs(ctx, sch, idxSet)
+	if err != nil {
+		return err
+	}
+
+	nullFields := make(map[int]struct{}, len(c.currFk.TableColumns))
+	for _, tag := range c.currFk.TableColumns {
+		nullFields[sch.GetNonPKCols().TagToIdx[tag]] = struct{}{}
+	}
+	_, vd := m.Descriptors()
+	vb := val.NewTupleBuilder(vd)
+
+	deletes, setNulls := 0, 0
+	mut := m.Mutate()
+	for _, k := range c.keys {
+		var v val.Tuple
+		err = m.Get(ctx, k, func(_, value val.Tuple) error {
+			v = value
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+
+		if c.currFk.OnDelete == doltdb.ForeignKeyReferentialAction_Cascade {
+			if err = mut.Delete(ctx, k); err != nil {
+				return err
+			}
+			for _, mutIdx := range mutIdxs {
+				if err = mutIdx.DeleteEntry(ctx, k, v); err != nil {
+					return err
+				}
+			}
+			deletes++
+			continue
+		}
+
+		for i := 0; i < vd.Count(); i++ {
+			if _, ok := nullFields[i]; !ok {
+				vb.PutRaw(i, v.GetField(i))
+			}
+		}
+		newV := vb.Build(m.Pool())
+		if err = mut.Put(ctx, k, newV); err != nil {
+			return err
+		}
+		for _, mutIdx := range mutIdxs {
+			if err = mutIdx.UpdateEntry(ctx, k, v, newV); err != nil {
+				return err
+			}
+		}
+		setNulls++
+	}
+	if deletes+setNulls == 0 {
+		return nil
+	}
+
+	m, err = mut.Map(ctx)
+	if err != nil {
+		return err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(m))
+	if err != nil {
+		return err
+	}
+	for _, mutIdx := range mutIdxs {
+		idx, err := mutIdx.Map(ctx)
+		if err != nil {
+			return err
+		}
+		idxSet, err = idxSet.PutIndex(ctx, mutIdx.Name, durable.IndexFromProllyMap(idx))
+		if err != nil {
+			return err
+		}
+	}
+	tbl, err = tbl.SetIndexSet(ctx, idxSet)
+	if err != nil {
+		return err
+	}
+	c.root, err = c.root.PutTable(ctx, c.childTbl, tbl)
+	if err != nil {
+		return err
+	}
+
+	stats, ok := c.stats[c.childTbl]
+	if !ok {
+		stats = &MergeStats{Operation: TableModified}
+		c.stats[c.childTbl] = stats
+	} else if stats.Operation == TableUnmodified {
+		stats.Operation = TableModified
+	}
+	stats.CascadedDeletes += deletes
+	stats.CascadedSetNulls += setNulls
+	c.cascaded += deletes + setNulls
+	return nil
+}
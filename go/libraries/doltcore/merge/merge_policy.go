@@ -0,0 +1,224 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MergeIgnoredTable merges the table |tblName|, which is ignored by dolt_ignore, according to the merge policy of the
+// pattern that ignores it. Tables with the exclude policy keep our version, and tables with the ours and theirs
+// policies are merged like any other table, but conflicts are resolved with our or their version instead of being
+// recorded.
+func (rm *RootMerger) MergeIgnoredTable(ctx context.Context, tblName string, policy doltdb.IgnoreMergePolicy, opts editor.Options, mergeOpts MergeOpts) (*MergedTable, *MergeStats, error) {
+	if policy == doltdb.MergePolicyDefault {
+		return rm.MergeTable(ctx, tblName, opts, mergeOpts)
+	}
+
+	tm, err := rm.makeTableMerger(ctx, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy == doltdb.MergePolicyExclude {
+		return keepTableVersion(ctx, tm, tm.leftTbl)
+	}
+
+	preferred := tm.leftTbl
+	if policy == doltdb.MergePolicyTheirs {
+		preferred = tm.rightTbl
+	}
+
+	mergeOpts.KeepSchemaConflicts = true
+	mergedTable, stats, err := rm.MergeTable(ctx, tblName, opts, mergeOpts)
+	if errors.Is(err, ErrTableDeletedAndModified) || ErrSameTblAddedTwice.Is(err) {
+		return keepTableVersion(ctx, tm, preferred)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if mergedTable.conflict.Count() > 0 {
+		return keepTableVersion(ctx, tm, preferred)
+	}
+	if mergedTable.table == nil || stats.DataConflicts == 0 {
+		return mergedTable, stats, nil
+	}
+
+	var tbl *doltdb.Table
+	if policy == doltdb.MergePolicyOurs {
+		tbl, err = mergedTable.table.ClearConflicts(ctx)
+	} else {
+		tbl, err = resolveConflictsWithTheirs(ctx, tm, mergedTable.table)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	stats.DataConflicts = 0
+	return &MergedTable{table: tbl}, stats, nil
+}
+
+// keepTableVersion returns |tbl|, either our or their version of the table merged by |tm|, as the result of the merge.
+func keepTableVersion(ctx context.Context, tm *TableMerger, tbl *doltdb.Table) (*MergedTable, *MergeStats, error) {
+	switch {
+	case tbl == nil:
+		return &MergedTable{}, &MergeStats{Operation: TableRemoved}, nil
+	case tbl == tm.leftTbl:
+		return &MergedTable{table: tbl}, &MergeStats{Operation: TableUnmodified}, nil
+	case tm.leftTbl == nil:
+		return &MergedTable{table: tbl}, &MergeStats{Operation: TableAdded}, nil
+	}
+
+	stats, err := calcTableMergeStats(ctx, tm.leftTbl, tbl)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &MergedTable{table: tbl}, &stats, nil
+}
+
+// resolveConflictsWithTheirs replaces every row of |tbl|, the result of the merge of |tm|, which conflicts with the
+// right side of the merge with its version on the right side, then clears the conflicts of the table.
+func resolveConflictsWithTheirs(ctx context.Context, tm *TableMerger, tbl *doltdb.Table) (*doltdb.Table, error) {
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return nil, fmt.Errorf("merge policy '%s' of table %s is not supported for the legacy storage format", doltdb.MergePolicyTheirs, tm.name)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !schema.SchemasAreEqual(sch, tm.rightSch) {
+		return nil, fmt.Errorf("merge policy '%s' of table %s can't resolve conflicts after its schema changed", doltdb.MergePolicyTheirs, tm.name)
+	}
+
+	rightHash, err := tm.rightSrc.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	artIdx, err := tbl.GetArtifacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := durable.ProllyMapFromArtifactIndex(artIdx).IterAllConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	leftIdx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leftRows := durable.ProllyMapFromIndex(leftIdx)
+	rightIdx, err := tm.rightTbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rightRows := durable.ProllyMapFromIndex(rightIdx)
+	mut := leftRows.Mutate()
+
+	idxSet, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mutIdxs, err := GetMutableSecondaryIdxs(ctx, sch, idxSet)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		cnf, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// conflicts recorded by an earlier merge are with another right side
+		if cnf.TheirRootIsh != rightHash {
+			continue
+		}
+
+		var ourRow, theirRow val.Tuple
+		err = leftRows.Get(ctx, cnf.Key, func(_, v val.Tuple) error {
+			ourRow = v
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = rightRows.Get(ctx, cnf.Key, func(_, v val.Tuple) error {
+			theirRow = v
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(theirRow) == 0 {
+			err = mut.Delete(ctx, cnf.Key)
+		} else {
+			err = mut.Put(ctx, cnf.Key, theirRow)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mutIdx := range mutIdxs {
+			if len(ourRow) == 0 {
+				err = mutIdx.InsertEntry(ctx, cnf.Key, theirRow)
+			} else if len(theirRow) == 0 {
+				err = mutIdx.DeleteEntry(ctx, cnf.Key, ourRow)
+			} else {
+				err = mutIdx.UpdateEntry(ctx, cnf.Key, ourRow, theirRow)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	m, err := mut.Map(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(m))
+	if err != nil {
+		return nil, err
+	}
+	for _, mutIdx := range mutIdxs {
+		m, err := mutIdx.Map(ctx)
+		if err != nil {
+			return nil, err
+		}
+		idxSet, err = idxSet.PutIndex(ctx, mutIdx.Name, durable.IndexFromProllyMap(m))
+		if err != nil {
+			return nil, err
+		}
+	}
+	tbl, err = tbl.SetIndexSet(ctx, idxSet)
+	if err != nil {
+		return nil, err
+	}
+	return tbl.ClearConflicts(ctx)
+}
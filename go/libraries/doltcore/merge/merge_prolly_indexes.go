@@ -81,8 +81,11 @@ func mergeProllySecondaryIndexes(
 			return nil, err
 		}
 
+		// a partial index whose predicate was changed on the right must be rebuilt to hold the rows it now matches
+		predicateChanged := rootOK && !tm.leftSch.Indexes().GetByName(index.Name()).Predicate().Equals(index.Predicate())
+
 		mergedIndex, err := func() (durable.Index, error) {
-			if !rootOK || !mergeOK || !ancOK {
+			if !rootOK || !mergeOK || !ancOK || predicateChanged {
 				return buildIndex(ctx, tm.vrw, tm.ns, finalSch, index, mergedM, artifacts, tm.rightSrc, tm.name)
 			}
 			return durable.IndexFromProllyMap(left), nil
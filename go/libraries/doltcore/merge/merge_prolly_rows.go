@@ -22,11 +22,15 @@ import (
 	"fmt"
 	"io"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/pool"
 	"github.com/dolthub/dolt/go/store/prolly"
@@ -40,6 +44,9 @@ import (
 // conflicts), migrate any existing table data to the specified |mergedSch|, and merge table data from both sides
 // of the merge together.
 func mergeProllyTable(ctx context.Context, tm *TableMerger, mergedSch schema.Schema) (*doltdb.Table, *MergeStats, error) {
+	ctx, span := tracer.Start(ctx, "merge.mergeProllyTable", trace.WithAttributes(attribute.String("table", tm.name)))
+	defer span.End()
+
 	err := maybeAbortDueToUnmergeableIndexes(tm.name, tm.leftSch, tm.rightSch, mergedSch)
 	if err != nil {
 		return nil, nil, err
@@ -61,11 +68,12 @@ func mergeProllyTable(ctx context.Context, tm *TableMerger, mergedSch schema.Sch
 	}
 	leftRows := durable.ProllyMapFromIndex(lr)
 	valueMerger := newValueMerger(mergedSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool())
+	valueMerger.volatile, valueMerger.rightWins = volatileFields(mergedSch, tm.volatileCols), tm.rightIsNewer
 	leftMapping := valueMerger.leftMapping
 
 	// Migrate primary index data to rewrite the values on the left side of the merge if necessary
 	schemasDifferentSize := len(tm.leftSch.GetAllCols().GetColumns()) != len(mergedSch.GetAllCols().GetColumns())
-	if schemasDifferentSize || leftMapping.IsIdentityMapping() == false {
+	if schemasDifferentSize || leftMapping.IsIdentityMapping() == false || valueMerger.leftReencoded {
 		if err := migrateDataToMergedSchema(ctx, tm, valueMerger, mergedSch); err != nil {
 			return nil, nil, err
 		}
@@ -73,6 +81,7 @@ func mergeProllyTable(ctx context.Context, tm *TableMerger, mergedSch schema.Sch
 		// After we migrate the data on the left-side to the new, merged schema, we reset
 		// the left mapping to an identity mapping, since it's a direct mapping now.
 		valueMerger.leftMapping = val.NewIdentityOrdinalMapping(len(valueMerger.leftMapping))
+		valueMerger.leftVD, valueMerger.leftReencoded = valueMerger.vD, false
 	}
 
 	// After we've migrated the existing data to the new schema, it's safe for us to update the schema on the table
@@ -355,7 +364,7 @@ func newUniqValidator(ctx context.Context, sch schema.Schema, tm *TableMerger, v
 		}
 		secondary := durable.ProllyMapFromIndex(idx)
 
-		u, err := newUniqIndex(sch, def, clustered, secondary)
+		u, err := newUniqIndex(ctx, sch, def, clustered, secondary)
 		if err != nil {
 			return uniqValidator{}, err
 		}
@@ -369,19 +378,21 @@ func (uv uniqValidator) validateDiff(ctx context.Context, diff tree.ThreeWayDiff
 	switch diff.Op {
 	case tree.DiffOpRightAdd, tree.DiffOpRightModify:
 		value = diff.Right
+		// Don't remap the value to the merged schema if the table is keyless (since they
+		// don't allow schema changes) or if the mapping is an identity mapping of the same encodings.
+		if !uv.valueMerger.keyless && (!uv.valueMerger.rightMapping.IsIdentityMapping() || uv.valueMerger.rightReencoded) {
+			value, err = uv.valueMerger.remapRight(value, uv.tm.rightSch.GetValueDescriptor())
+			if err != nil {
+				return 0, err
+			}
+		}
 	case tree.DiffOpDivergentModifyResolved:
+		// the merged value is already in the merged schema
 		value = diff.Merged
 	default:
 		return
 	}
 
-	// Don't remap the value to the merged schema if the table is keyless (since they
-	// don't allow schema changes) or if the mapping is an identity mapping.
-	if !uv.valueMerger.keyless && !uv.valueMerger.rightMapping.IsIdentityMapping() {
-		modifiedValue := remapTuple(value, uv.tm.rightSch.GetValueDescriptor(), uv.valueMerger.rightMapping)
-		value = val.NewTuple(uv.valueMerger.syncPool, modifiedValue...)
-	}
-
 	for _, idx := range uv.indexes {
 		err = idx.findCollisions(ctx, diff.Key, value, func(k, v val.Tuple) error {
 			conflicts++
@@ -411,9 +422,10 @@ type uniqIndex struct {
 	prefixDesc   val.TupleDesc
 	secondaryBld index.SecondaryKeyBuilder
 	clusteredBld index.ClusteredKeyBuilder
+	filter       *index.PartialIndexFilter
 }
 
-func newUniqIndex(sch schema.Schema, def schema.Index, clusterd, secondary prolly.Map) (uniqIndex, error) {
+func newUniqIndex(ctx context.Context, sch schema.Schema, def schema.Index, clusterd, secondary prolly.Map) (uniqIndex, error) {
 	meta, err := makeUniqViolMeta(sch, def)
 	if err != nil {
 		return uniqIndex{}, err
@@ -427,6 +439,10 @@ func newUniqIndex(sch schema.Schema, def schema.Index, clusterd, secondary proll
 	prefixDesc := secondary.KeyDesc().PrefixDesc(def.Count())
 	secondaryBld := index.NewSecondaryKeyBuilder(sch, def, secondary.KeyDesc(), p)
 	clusteredBld := index.NewClusteredKeyBuilder(def, sch, clusterd.KeyDesc(), p)
+	filter, err := index.NewPartialIndexFilter(ctx, def, secondary.KeyDesc())
+	if err != nil {
+		return uniqIndex{}, err
+	}
 
 	return uniqIndex{
 		def:          def,
@@ -436,19 +452,25 @@ func newUniqIndex(sch schema.Schema, def schema.Index, clusterd, secondary proll
 		prefixDesc:   prefixDesc,
 		secondaryBld: secondaryBld,
 		clusteredBld: clusteredBld,
+		filter:       filter,
 	}, nil
 }
 
 type collisionFn func(key, value val.Tuple) error
 
 func (idx uniqIndex) findCollisions(ctx context.Context, key, value val.Tuple, cb collisionFn) error {
-	indexKey := idx.secondaryBld.SecondaryKeyFromRow(key, value)
+	indexKey, err := idx.secondaryBld.SecondaryKeyFromRow(key, value)
+	if err != nil {
+		return err
+	}
 	if idx.prefixDesc.HasNulls(indexKey) {
 		return nil // NULLs cannot cause unique violations
+	} else if !idx.filter.Matches(indexKey) {
+		return nil // rows outside of a partial index cannot cause unique violations
 	}
 
 	var collision val.Tuple
-	err := idx.secondary.GetPrefix(ctx, indexKey, idx.prefixDesc, func(k, _ val.Tuple) (err error) {
+	err = idx.secondary.GetPrefix(ctx, indexKey, idx.prefixDesc, func(k, _ val.Tuple) (err error) {
 		collision = k
 		return
 	})
@@ -585,8 +607,11 @@ func (m *primaryMerger) merge(ctx context.Context, diff tree.ThreeWayDiff, sourc
 				return fmt.Errorf("cannot merge keyless tables with reordered columns")
 			}
 		} else {
-			modifiedValue := remapTuple(diff.Right, sourceSch.GetValueDescriptor(), m.valueMerger.rightMapping)
-			newTupleValue = val.NewTuple(m.valueMerger.syncPool, modifiedValue...)
+			var err error
+			newTupleValue, err = m.valueMerger.remapRight(diff.Right, sourceSch.GetValueDescriptor())
+			if err != nil {
+				return err
+			}
 		}
 		return m.mut.Put(ctx, diff.Key, newTupleValue)
 	case tree.DiffOpRightDelete:
@@ -662,8 +687,10 @@ func (m *secondaryMerger) merge(ctx context.Context, diff tree.ThreeWayDiff, sou
 					return fmt.Errorf("cannot merge keyless tables with reordered columns")
 				}
 			} else {
-				valueMappedToMergeSchema := remapTuple(diff.Right, sourceSch.GetValueDescriptor(), m.valueMerger.rightMapping)
-				newTupleValue = val.NewTuple(m.valueMerger.syncPool, valueMappedToMergeSchema...)
+				newTupleValue, err = m.valueMerger.remapRight(diff.Right, sourceSch.GetValueDescriptor())
+				if err != nil {
+					return err
+				}
 			}
 
 			err = applyEdit(ctx, idx, diff.Key, diff.Base, newTupleValue)
@@ -699,17 +726,37 @@ func (m *secondaryMerger) finalize(ctx context.Context) (durable.IndexSet, durab
 }
 
 // remapTuple takes the given |tuple| and the |desc| that describes its data, and uses |mapping| to map the tuple's
-// data into a new [][]byte, as indicated by the specified ordinal mapping.
-func remapTuple(tuple val.Tuple, desc val.TupleDesc, mapping val.OrdinalMapping) [][]byte {
-	result := make([][]byte, len(mapping))
+// data into |dst|, as indicated by the specified ordinal mapping. Fields that |toDesc| stores with another encoding
+// than |desc| are re-encoded. |dst| must have room for len(|mapping|) fields and is reused by callers across rows;
+// the returned fields alias |tuple| rather than copying it, unless they're re-encoded.
+func remapTuple(dst [][]byte, tuple val.Tuple, desc val.TupleDesc, mapping val.OrdinalMapping, toDesc val.TupleDesc) ([][]byte, error) {
+	dst = dst[:len(mapping)]
 	for to, from := range mapping {
 		if from == -1 {
+			dst[to] = nil
 			continue
 		}
-		result[to] = desc.GetField(from, tuple)
+		field, err := val.ConvertField(desc, from, toDesc, to, desc.GetField(from, tuple))
+		if err != nil {
+			return nil, err
+		}
+		dst[to] = field
 	}
 
-	return result
+	return dst, nil
+}
+
+// reencodes returns whether any field mapped by |mapping| from |from| is stored with another encoding by |to|.
+func reencodes(mapping val.OrdinalMapping, from, to val.TupleDesc) bool {
+	for i, j := range mapping {
+		if j == -1 {
+			continue
+		}
+		if val.FieldEncodingsDiffer(from, j, to, i) {
+			return true
+		}
+	}
+	return false
 }
 
 func mergeTableArtifacts(ctx context.Context, tm *TableMerger, mergeTbl *doltdb.Table) (*doltdb.Table, error) {
@@ -762,20 +809,67 @@ type valueMerger struct {
 	leftMapping, rightMapping, baseMapping val.OrdinalMapping
 	syncPool                               pool.BuffPool
 	keyless                                bool
+
+	// leftVD, rightVD and baseVD describe the values of each side of the merge. The fields of a side that
+	// stores some columns with other encodings than the merged schema, because it has a column dictionary
+	// or fixed-point decimals the other doesn't, are re-encoded, see val.ConvertField.
+	leftVD, rightVD, baseVD       val.TupleDesc
+	leftReencoded, rightReencoded bool
+	baseReencoded                 bool
+
+	// scratch holds the fields of the tuple being merged or remapped. val.NewTuple copies
+	// fields out of it, so it is reused for every row instead of being allocated per row.
+	scratch [][]byte
+	// lastRemapFrom and lastRemapTo cache the most recent result of remapRight, since the
+	// primary and secondary mergers remap the same right-side value for every diff.
+	lastRemapFrom, lastRemapTo val.Tuple
+
+	// volatile marks the columns of the merged schema listed in dolt_volatile_columns. Conflicting
+	// values of these columns are resolved by last writer wins: the right value is taken if
+	// |rightWins|, otherwise the left one.
+	volatile  []bool
+	rightWins bool
 }
 
 func newValueMerger(merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool) *valueMerger {
 	leftMapping, rightMapping, baseMapping := generateSchemaMappings(merged, leftSch, rightSch, baseSch)
+	vD := merged.GetValueDescriptor()
+	leftVD, rightVD, baseVD := leftSch.GetValueDescriptor(), rightSch.GetValueDescriptor(), baseSch.GetValueDescriptor()
+	keyless := schema.IsKeyless(merged)
 
 	return &valueMerger{
-		numCols:      merged.GetNonPKCols().Size(),
-		vD:           merged.GetValueDescriptor(),
-		leftMapping:  leftMapping,
-		rightMapping: rightMapping,
-		baseMapping:  baseMapping,
-		syncPool:     syncPool,
-		keyless:      schema.IsKeyless(merged),
+		numCols:        merged.GetNonPKCols().Size(),
+		vD:             vD,
+		leftMapping:    leftMapping,
+		rightMapping:   rightMapping,
+		baseMapping:    baseMapping,
+		syncPool:       syncPool,
+		keyless:        keyless,
+		leftVD:         leftVD,
+		rightVD:        rightVD,
+		baseVD:         baseVD,
+		leftReencoded:  !keyless && reencodes(leftMapping, leftVD, vD),
+		rightReencoded: !keyless && reencodes(rightMapping, rightVD, vD),
+		baseReencoded:  !keyless && reencodes(baseMapping, baseVD, vD),
+		scratch:        make([][]byte, merged.GetNonPKCols().Size()),
+	}
+}
+
+// volatileFields returns which of the non primary key columns of |sch| are in |cols|, or nil if none are.
+func volatileFields(sch schema.Schema, cols *set.StrSet) []bool {
+	if cols == nil || cols.Size() == 0 {
+		return nil
 	}
+	var volatile []bool
+	for i, col := range sch.GetNonPKCols().GetColumns() {
+		if cols.Contains(col.Name) {
+			if volatile == nil {
+				volatile = make([]bool, sch.GetNonPKCols().Size())
+			}
+			volatile[i] = true
+		}
+	}
+	return volatile
 }
 
 // generateSchemaMappings returns three schema mappings: 1) mapping the |leftSch| to |mergedSch|,
@@ -839,6 +933,7 @@ func migrateDataToMergedSchema(ctx context.Context, tm *TableMerger, vm *valueMe
 		return err
 	}
 	valueDescriptor := leftSch.GetValueDescriptor()
+	fields := make([][]byte, len(vm.leftMapping))
 
 	for {
 		key, value, err := mapIter.Next(ctx)
@@ -848,7 +943,10 @@ func migrateDataToMergedSchema(ctx context.Context, tm *TableMerger, vm *valueMe
 			return err
 		}
 		pool := vm.syncPool
-		modifiedValue := remapTuple(value, valueDescriptor, vm.leftMapping)
+		modifiedValue, err := remapTuple(fields, value, valueDescriptor, vm.leftMapping, vm.vD)
+		if err != nil {
+			return err
+		}
 		modifiedValueAsTuple := val.NewTuple(pool, modifiedValue...)
 		err = mut.Put(ctx, key, modifiedValueAsTuple)
 		if err != nil {
@@ -883,17 +981,17 @@ func migrateDataToMergedSchema(ctx context.Context, tm *TableMerger, vm *valueMe
 // tuples. It returns the merged cell value tuple and a bool indicating if a
 // conflict occurred. tryMerge should only be called if left and right produce
 // non-identical diffs against base.
-func (m *valueMerger) tryMerge(left, right, base val.Tuple) (val.Tuple, bool) {
+func (m *valueMerger) tryMerge(left, right, base val.Tuple) (val.Tuple, bool, error) {
 	// If we're merging a keyless table and the keys match, but the values are different,
 	// that means that the row data is the same, but the cardinality has changed, and if the
 	// cardinality has changed in different ways on each merge side, we can't auto resolve.
 	if m.keyless {
-		return nil, false
+		return nil, false, nil
 	}
 
 	if base != nil && (left == nil) != (right == nil) {
 		// One row deleted, the other modified
-		return nil, false
+		return nil, false, nil
 	}
 
 	// Because we have non-identical diffs, left and right are guaranteed to be
@@ -902,43 +1000,82 @@ func (m *valueMerger) tryMerge(left, right, base val.Tuple) (val.Tuple, bool) {
 		panic("found nil left / right which should never occur")
 	}
 
-	mergedValues := make([][]byte, m.numCols)
+	mergedValues := m.scratch[:m.numCols]
 	for i := 0; i < m.numCols; i++ {
-		v, isConflict := m.processColumn(i, left, right, base)
+		v, isConflict, err := m.processColumn(i, left, right, base)
+		if err != nil {
+			return nil, false, err
+		}
 		if isConflict {
-			return nil, false
+			return nil, false, nil
 		}
 		mergedValues[i] = v
 	}
 
-	return val.NewTuple(m.syncPool, mergedValues...), true
+	return val.NewTuple(m.syncPool, mergedValues...), true, nil
+}
+
+// remapRight maps |value|, a value tuple of the right side described by |desc|, to the merged
+// schema. The fields are sliced from |value| without copying until the final tuple is built.
+func (m *valueMerger) remapRight(value val.Tuple, desc val.TupleDesc) (val.Tuple, error) {
+	if m.lastRemapFrom != nil && sameTuple(value, m.lastRemapFrom) {
+		return m.lastRemapTo, nil
+	}
+	fields, err := remapTuple(m.scratch, value, desc, m.rightMapping, m.vD)
+	if err != nil {
+		return nil, err
+	}
+	m.lastRemapFrom, m.lastRemapTo = value, val.NewTuple(m.syncPool, fields...)
+	return m.lastRemapTo, nil
+}
+
+// sameTuple returns whether |a| and |b| are the same slice of the same backing array.
+func sameTuple(a, b val.Tuple) bool {
+	return len(a) == len(b) && len(a) > 0 && &a[0] == &b[0]
 }
 
 // processColumn returns the merged value of column |i| of the merged schema,
 // based on the |left|, |right|, and |base| schema.
-func (m *valueMerger) processColumn(i int, left, right, base val.Tuple) ([]byte, bool) {
+func (m *valueMerger) processColumn(i int, left, right, base val.Tuple) ([]byte, bool, error) {
 	// missing columns are coerced into NULL column values
-	var leftCol []byte
+	var leftCol, rightCol, baseVal []byte
+	var err error
 	if l := m.leftMapping[i]; l != -1 {
 		leftCol = left.GetField(l)
+		if m.leftReencoded {
+			if leftCol, err = val.ConvertField(m.leftVD, l, m.vD, i, leftCol); err != nil {
+				return nil, false, err
+			}
+		}
 	}
-	var rightCol []byte
 	if r := m.rightMapping[i]; r != -1 {
 		rightCol = right.GetField(r)
+		if m.rightReencoded {
+			if rightCol, err = val.ConvertField(m.rightVD, r, m.vD, i, rightCol); err != nil {
+				return nil, false, err
+			}
+		}
 	}
 
 	if m.vD.Comparator().CompareValues(i, leftCol, rightCol, m.vD.Types[i]) == 0 {
-		return leftCol, false
+		return leftCol, false, nil
 	}
 
 	if base == nil {
+		if m.isVolatile(i) {
+			return m.lastWriter(leftCol, rightCol), false, nil
+		}
 		// Conflicting insert
-		return nil, true
+		return nil, true, nil
 	}
 
-	var baseVal []byte
 	if b := m.baseMapping[i]; b != -1 {
 		baseVal = base.GetField(b)
+		if m.baseReencoded {
+			if baseVal, err = val.ConvertField(m.baseVD, b, m.vD, i, baseVal); err != nil {
+				return nil, false, err
+			}
+		}
 	}
 
 	leftModified := m.vD.Comparator().CompareValues(i, leftCol, baseVal, m.vD.Types[i]) != 0
@@ -946,10 +1083,26 @@ func (m *valueMerger) processColumn(i int, left, right, base val.Tuple) ([]byte,
 
 	switch {
 	case leftModified && rightModified:
-		return nil, true
+		if m.isVolatile(i) {
+			return m.lastWriter(leftCol, rightCol), false, nil
+		}
+		return nil, true, nil
 	case leftModified:
-		return leftCol, false
+		return leftCol, false, nil
 	default:
-		return rightCol, false
+		return rightCol, false, nil
+	}
+}
+
+// isVolatile returns whether column |i| of the merged schema is merged by last writer wins.
+func (m *valueMerger) isVolatile(i int) bool {
+	return m.volatile != nil && m.volatile[i]
+}
+
+// lastWriter returns the value of the side of the merge that was written last.
+func (m *valueMerger) lastWriter(left, right []byte) []byte {
+	if m.rightWins {
+		return right
 	}
+	return left
 }
@@ -20,6 +20,8 @@ import (
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/conflict"
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
@@ -27,10 +29,12 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/atomicerr"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
 )
 
 type MergeOpts struct {
@@ -39,6 +43,17 @@ type MergeOpts struct {
 	// KeepSchemaConflicts if schema conflicts should be
 	// stored, otherwise we end the merge with an error.
 	KeepSchemaConflicts bool
+	// ApplyIgnorePolicies is set for merges of commits, to merge tables
+	// ignored by dolt_ignore according to their merge policies.
+	ApplyIgnorePolicies bool
+	// CascadeForeignKeys applies the ON DELETE actions of foreign keys to
+	// child rows whose parent rows were deleted by the merge, instead of
+	// reporting them as constraint violations.
+	CascadeForeignKeys bool
+	// TheirsAreNewer is set when their side of the merge was committed after
+	// ours. Columns listed in dolt_volatile_columns that were changed on both
+	// sides are merged by last writer wins, taking the value of the newer side.
+	TheirsAreNewer bool
 }
 
 type TableMerger struct {
@@ -55,6 +70,11 @@ type TableMerger struct {
 	rightSrc    doltdb.Rootish
 	ancestorSrc doltdb.Rootish
 
+	// volatileCols are the columns of the table listed in dolt_volatile_columns, which are merged by last writer
+	// wins instead of conflicting. Their values are taken from the right side if |rightIsNewer|.
+	volatileCols *set.StrSet
+	rightIsNewer bool
+
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
 }
@@ -86,6 +106,10 @@ type RootMerger struct {
 	rightSrc doltdb.Rootish
 	ancSrc   doltdb.Rootish
 
+	// volatile are the columns listed in the dolt_volatile_columns table of the left root, for merges of commits
+	volatile     doltdb.IgnoredColumns
+	rightIsNewer bool
+
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
 }
@@ -108,6 +132,20 @@ func NewMerger(
 	}, nil
 }
 
+// theirTableUnchanged returns whether the table |tblName| is the same in the right root and the ancestor root, or is
+// missing from both. The merge of such a table is the left version of it, so it can be skipped without reading it.
+func (rm *RootMerger) theirTableUnchanged(ctx context.Context, tblName string) (bool, error) {
+	rightHash, _, err := rm.right.GetTableHash(ctx, tblName)
+	if err != nil {
+		return false, err
+	}
+	ancHash, _, err := rm.anc.GetTableHash(ctx, tblName)
+	if err != nil {
+		return false, err
+	}
+	return rightHash == ancHash, nil
+}
+
 type MergedTable struct {
 	table    *doltdb.Table
 	conflict SchemaConflict
@@ -116,6 +154,9 @@ type MergedTable struct {
 // MergeTable merges schema and table data for the table tblName.
 // TODO: this code will loop infinitely when merging certain schema changes
 func (rm *RootMerger) MergeTable(ctx context.Context, tblName string, opts editor.Options, mergeOpts MergeOpts) (*MergedTable, *MergeStats, error) {
+	ctx, span := tracer.Start(ctx, "merge.MergeTable", trace.WithAttributes(attribute.String("table", tblName)))
+	defer span.End()
+
 	tm, err := rm.makeTableMerger(ctx, tblName)
 	if err != nil {
 		return nil, nil, err
@@ -176,6 +217,12 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName string) (*Tab
 	var ok bool
 	var err error
 
+	tm.rightIsNewer = rm.rightIsNewer
+	tm.volatileCols, err = rm.volatile.ColumnsForTable(tblName)
+	if err != nil {
+		return nil, err
+	}
+
 	tm.leftTbl, ok, err = rm.left.GetTable(ctx, tblName)
 	if err != nil {
 		return nil, err
@@ -313,8 +360,9 @@ func validateTupleFields(existingSch schema.Schema, targetSch schema.Schema) (bo
 			continue
 		}
 
-		// If the field types have changed between existing and target, bail.
-		if existingVD.Types[existingIndex].Enc != targetVD.Types[targetIndex].Enc {
+		// If the field types have changed between existing and target, bail. Fields that only changed how they
+		// store their values are re-encoded during the merge.
+		if !val.ConvertibleEncodings(existingVD.Types[existingIndex].Enc, targetVD.Types[targetIndex].Enc) {
 			return false, nil
 		}
 
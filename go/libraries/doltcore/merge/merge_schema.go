@@ -23,6 +23,8 @@ import (
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -40,6 +42,8 @@ const (
 	// DuplicateIndexColumnSet represent a schema conflict where multiple indexes cover the same set of columns, and
 	// we're unable to accurately match them up on each side of the merge, so the user has to manually resolve.
 	DuplicateIndexColumnSet
+	// CommentCollision represents a schema conflict where both sides changed the comment of a column differently.
+	CommentCollision
 )
 
 type SchemaConflict struct {
@@ -99,6 +103,8 @@ func (c ColConflict) String() string {
 		return fmt.Sprintf("incompatible column types for column '%s': %s and %s", c.Ours.Name, c.Ours.TypeInfo, c.Theirs.TypeInfo)
 	case TagCollision:
 		return fmt.Sprintf("different column definitions for our column %s and their column %s", c.Ours.Name, c.Theirs.Name)
+	case CommentCollision:
+		return fmt.Sprintf("different comments for column '%s': '%s' and '%s'", c.Ours.Name, c.Ours.Comment, c.Theirs.Comment)
 	}
 	return ""
 }
@@ -149,6 +155,9 @@ var ErrMergeWithDifferentPks = errors.New("error: cannot merge two tables with d
 
 // SchemaMerge performs a three-way merge of ourSch, theirSch, and ancSch.
 func SchemaMerge(ctx context.Context, format *types.NomsBinFormat, ourSch, theirSch, ancSch schema.Schema, tblName string) (sch schema.Schema, sc SchemaConflict, err error) {
+	_, span := tracer.Start(ctx, "merge.SchemaMerge", trace.WithAttributes(attribute.String("table", tblName)))
+	defer span.End()
+
 	// (sch - ancSch) ∪ (mergeSch - ancSch) ∪ (sch ∩ mergeSch)
 	sc = SchemaConflict{
 		TableName: tblName,
@@ -240,7 +249,8 @@ func ForeignKeysMerge(ctx context.Context, mergedRoot, ourRoot, theirRoot, ancRo
 		return nil, nil, err
 	}
 
-	ancSchs, err := ancRoot.GetAllSchemas(ctx)
+	// only the schemas of the tables of the ancestor's foreign keys are used to match foreign keys
+	ancSchs, err := getTableSchemas(ctx, ancRoot, anc.Tables())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -349,14 +359,15 @@ func mergeColumns(format *types.NomsBinFormat, ourCC, theirCC, ancCC *schema.Col
 			// if the column is deleted on both sides... just let it fall out
 		case ours != nil && theirs != nil:
 			// otherwise, we have two valid columns and we need to figure out which one to use
+			merged := len(mergedColumns)
 			if anc != nil {
-				oursChanged := !anc.Equals(*ours)
-				theirsChanged := !anc.Equals(*theirs)
+				oursChanged := !anc.EqualsIgnoringStorage(*ours)
+				theirsChanged := !anc.EqualsIgnoringStorage(*theirs)
 				if oursChanged && theirsChanged {
 					// This is a schema change conflict and has already been handled by checkSchemaConflicts
 				} else if theirsChanged {
 					if columnTypesAreCompatible(format, *ours, *theirs) {
-						mergedColumns = append(mergedColumns, *theirs)
+						mergedColumns = append(mergedColumns, mergeColumnStorage(*theirs, ours, theirs, anc))
 					} else {
 						conflicts = append(conflicts, ColConflict{
 							Kind:   NameCollision,
@@ -366,7 +377,7 @@ func mergeColumns(format *types.NomsBinFormat, ourCC, theirCC, ancCC *schema.Col
 					}
 				} else {
 					if columnTypesAreCompatible(format, *theirs, *ours) {
-						mergedColumns = append(mergedColumns, *ours)
+						mergedColumns = append(mergedColumns, mergeColumnStorage(*ours, ours, theirs, anc))
 					} else {
 						conflicts = append(conflicts, ColConflict{
 							Kind:   NameCollision,
@@ -375,10 +386,25 @@ func mergeColumns(format *types.NomsBinFormat, ourCC, theirCC, ancCC *schema.Col
 						})
 					}
 				}
-			} else if ours.Equals(*theirs) {
+			} else if ours.EqualsIgnoringStorage(*theirs) {
 				// if the columns are identical, just use ours
-				mergedColumns = append(mergedColumns, *ours)
+				mergedColumns = append(mergedColumns, mergeColumnStorage(*ours, ours, theirs, anc))
+			}
+			if len(mergedColumns) == merged {
+				continue
 			}
+
+			// comments aren't part of a column's definition for the checks above, so they're merged on their own
+			comment, ok := mergeColumnComments(ours, theirs, anc)
+			if !ok {
+				conflicts = append(conflicts, ColConflict{
+					Kind:   CommentCollision,
+					Ours:   *ours,
+					Theirs: *theirs,
+				})
+				continue
+			}
+			mergedColumns[merged].Comment = comment
 		}
 	}
 
@@ -391,6 +417,21 @@ func mergeColumns(format *types.NomsBinFormat, ourCC, theirCC, ancCC *schema.Col
 	return schema.NewColCollection(mergedColumns...), nil, nil
 }
 
+// mergeColumnComments three-way merges the comments of a column that exists on both sides of a merge. It returns false
+// if both sides changed the comment differently.
+func mergeColumnComments(ours, theirs, anc *schema.Column) (string, bool) {
+	switch {
+	case ours.Comment == theirs.Comment:
+		return ours.Comment, true
+	case anc != nil && ours.Comment == anc.Comment:
+		return theirs.Comment, true
+	case anc != nil && theirs.Comment == anc.Comment:
+		return ours.Comment, true
+	default:
+		return "", false
+	}
+}
+
 // checkForColumnConflicts iterates over |mergedColumns|, checks for duplicate column names or column tags, and returns
 // a slice of ColConflicts for any conflicts found.
 func checkForColumnConflicts(mergedColumns []schema.Column) []ColConflict {
@@ -435,7 +476,7 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 		if ours != nil {
 			// If the column is identical on both sides, no need to check any more conflict cases,
 			// just move on to the next column
-			if theirs != nil && theirs.Equals(*ours) {
+			if theirs != nil && theirs.EqualsIgnoringStorage(*ours) {
 				continue
 			}
 
@@ -443,7 +484,7 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 			case theirs == nil && anc != nil:
 				// Column doesn't exist on their side, but does exist in ancestor
 				// This means the column was deleted on theirs side
-				if !anc.Equals(*ours) {
+				if !anc.EqualsIgnoringStorage(*ours) {
 					// col altered on our branch and deleted on their branch
 					conflicts = append(conflicts, ColConflict{
 						Kind: NameCollision,
@@ -453,7 +494,7 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 			case theirs != nil && anc != nil:
 				// Column exists on their side and in ancestor
 				// If the column differs from the ancestor on both sides, then we have a conflict
-				if !anc.Equals(*ours) && !anc.Equals(*theirs) {
+				if !anc.EqualsIgnoringStorage(*ours) && !anc.EqualsIgnoringStorage(*theirs) {
 					conflicts = append(conflicts, ColConflict{
 						Kind:   TagCollision,
 						Ours:   *ours,
@@ -483,7 +524,7 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 			case theirs != nil && anc != nil:
 				// Column exists on their side and in ancestor
 				// If ancs doesn't match theirs, the column was altered on both sides
-				if !anc.Equals(*theirs) {
+				if !anc.EqualsIgnoringStorage(*theirs) {
 					// col deleted on our branch and altered on their branch
 					conflicts = append(conflicts, ColConflict{
 						Kind:   NameCollision,
@@ -504,6 +545,18 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 	return conflicts, nil
 }
 
+// mergeColumnStorage returns |col| storing its values the way |theirs| does if only their side changed how the
+// column is stored since |anc|, and the way |ours| does otherwise. How a column is stored never conflicts: the rows of
+// every side are re-encoded for the merged schema.
+func mergeColumnStorage(col schema.Column, ours, theirs, anc *schema.Column) schema.Column {
+	from := ours
+	if anc != nil && anc.StorageEquals(*ours) {
+		from = theirs
+	}
+	col.Dictionary, col.FixedPointDecimal = from.Dictionary, from.FixedPointDecimal
+	return col
+}
+
 // columnTypesAreCompatible returns true if the change from |from| to |to| is a compatible type change.
 // Currently, no type change for the DOLT storage format is considered compatible, but over time we will
 // widen this to include safe type migrations (e.g. smallint to bigint, varchar(100) to varchar(200)), which
@@ -688,7 +741,7 @@ func indexesInCommon(mergedCC *schema.ColCollection, ours, theirs, anc schema.In
 			return false, nil
 		}
 
-		ancIdx, ok := anc.GetIndexByTags(idxTags...)
+		ancIdx, ok := findAncestorIndex(ourIdx, anc)
 
 		if !ok {
 			// index added on our branch and their branch with different defs, conflict
@@ -770,6 +823,17 @@ func findIndexInCollectionByTags(idx schema.Index, idxColl schema.IndexCollectio
 	}
 }
 
+// findAncestorIndex returns the index of |anc| which |idx| was derived from. An index with the same columns and name is
+// preferred, as partial indexes may share their columns with other indexes.
+func findAncestorIndex(idx schema.Index, anc schema.IndexCollection) (schema.Index, bool) {
+	for _, ancIdx := range anc.GetIndexesByTags(idx.IndexedColumnTags()...) {
+		if strings.EqualFold(ancIdx.Name(), idx.Name()) {
+			return ancIdx, true
+		}
+	}
+	return anc.GetIndexByTags(idx.IndexedColumnTags()...)
+}
+
 func indexCollSetDifference(left, right schema.IndexCollection, cc *schema.ColCollection) (d schema.IndexCollection) {
 	d = schema.NewIndexCollection(cc, nil)
 	_ = left.Iter(func(idx schema.Index) (stop bool, err error) {
@@ -781,10 +845,13 @@ func indexCollSetDifference(left, right schema.IndexCollection, cc *schema.ColCo
 			}
 		}
 
-		_, ok := right.GetIndexByTags(idxTags...)
-		if !ok {
-			d.AddIndex(idx)
+		// indexes over the same columns are only the same index if they contain the same rows
+		for _, other := range right.GetIndexesByTags(idxTags...) {
+			if other.Predicate().Equals(idx.Predicate()) {
+				return false, nil
+			}
 		}
+		d.AddIndex(idx)
 		return false, nil
 	})
 	return d
@@ -879,6 +946,24 @@ func fkCollSetDifference(fkColl, ancestorFkColl *doltdb.ForeignKeyCollection, an
 	return d, nil
 }
 
+// getTableSchemas returns the schemas of the |tables| of |root|, leaving out the ones that don't exist.
+func getTableSchemas(ctx context.Context, root *doltdb.RootValue, tables map[string]struct{}) (map[string]schema.Schema, error) {
+	schs := make(map[string]schema.Schema, len(tables))
+	for name := range tables {
+		tbl, ok, err := root.GetTable(ctx, name)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		schs[name], err = tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return schs, nil
+}
+
 // pruneInvalidForeignKeys removes from a ForeignKeyCollection any ForeignKey whose parent/child table/columns have been removed.
 func pruneInvalidForeignKeys(ctx context.Context, fkColl *doltdb.ForeignKeyCollection, mergedRoot *doltdb.RootValue) (pruned *doltdb.ForeignKeyCollection, err error) {
 	pruned, _ = doltdb.NewForeignKeyCollection()
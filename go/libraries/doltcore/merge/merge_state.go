@@ -0,0 +1,65 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+)
+
+// MergedTables returns the sorted names of the tables of the active merge of |ws|: the tables changed by the commits
+// merged since |ancestor|, their common ancestor with HEAD, and the tables the merge left unresolved.
+func MergedTables(ctx context.Context, ws *doltdb.WorkingSet, ancestor *doltdb.Commit) ([]string, error) {
+	ancRoot, err := ancestor.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mergeRoot, err := ws.MergeState().Commit().GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := doltdb.UnionTableNames(ctx, ancRoot, mergeRoot)
+	if err != nil {
+		return nil, err
+	}
+	tables := set.NewStrSet(nil)
+	for _, name := range names {
+		ancHash, inAnc, err := ancRoot.GetTableHash(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		mergeHash, inMerge, err := mergeRoot.GetTableHash(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if inAnc != inMerge || ancHash != mergeHash {
+			tables.Add(name)
+		}
+	}
+
+	unresolved, err := ws.UnresolvedTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables.Add(unresolved...)
+
+	sorted := tables.AsSlice()
+	sort.Strings(sorted)
+	return sorted, nil
+}
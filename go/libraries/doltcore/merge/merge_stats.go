@@ -31,6 +31,10 @@ type MergeStats struct {
 	DataConflicts        int
 	SchemaConflicts      int
 	ConstraintViolations int
+	// CascadedDeletes and CascadedSetNulls are the rows deleted, or whose foreign key columns were set to NULL, by
+	// the ON DELETE actions of foreign keys whose parent rows were deleted by the merge
+	CascadedDeletes  int
+	CascadedSetNulls int
 }
 
 func (ms *MergeStats) HasConflicts() bool {
@@ -36,7 +36,10 @@ func GetMutableSecondaryIdxs(ctx context.Context, sch schema.Schema, indexes dur
 		if schema.IsKeyless(sch) {
 			m = prolly.ConvertToSecondaryKeylessIndex(m)
 		}
-		mods[i] = NewMutableSecondaryIdx(m, sch, index)
+		mods[i], err = NewMutableSecondaryIdx(ctx, m, sch, index)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return mods, nil
 }
@@ -69,7 +72,10 @@ func GetMutableSecondaryIdxsWithPending(ctx context.Context, sch schema.Schema,
 		if schema.IsKeyless(sch) {
 			m = prolly.ConvertToSecondaryKeylessIndex(m)
 		}
-		newMutableSecondaryIdx := NewMutableSecondaryIdx(m, sch, index)
+		newMutableSecondaryIdx, err := NewMutableSecondaryIdx(ctx, m, sch, index)
+		if err != nil {
+			return nil, err
+		}
 		newMutableSecondaryIdx.mut = newMutableSecondaryIdx.mut.WithMaxPending(pendingSize)
 		mods = append(mods, newMutableSecondaryIdx)
 	}
@@ -83,23 +89,35 @@ type MutableSecondaryIdx struct {
 	Name    string
 	mut     *prolly.MutableMap
 	builder index.SecondaryKeyBuilder
+	filter  *index.PartialIndexFilter
 }
 
 // NewMutableSecondaryIdx returns a MutableSecondaryIdx. |m| is the secondary idx data.
-func NewMutableSecondaryIdx(idx prolly.Map, sch schema.Schema, def schema.Index) MutableSecondaryIdx {
+func NewMutableSecondaryIdx(ctx context.Context, idx prolly.Map, sch schema.Schema, def schema.Index) (MutableSecondaryIdx, error) {
 	b := index.NewSecondaryKeyBuilder(sch, def, idx.KeyDesc(), idx.Pool())
+	filter, err := index.NewPartialIndexFilter(ctx, def, idx.KeyDesc())
+	if err != nil {
+		return MutableSecondaryIdx{}, err
+	}
 	return MutableSecondaryIdx{
 		Name:    def.Name(),
 		mut:     idx.Mutate(),
 		builder: b,
-	}
+		filter:  filter,
+	}, nil
 }
 
 // InsertEntry inserts a secondary index entry given the key and new value
 // of the primary row.
 func (m MutableSecondaryIdx) InsertEntry(ctx context.Context, key, newValue val.Tuple) error {
-	newKey := m.builder.SecondaryKeyFromRow(key, newValue)
-	err := m.mut.Put(ctx, newKey, val.EmptyTuple)
+	newKey, err := m.builder.SecondaryKeyFromRow(key, newValue)
+	if err != nil {
+		return err
+	}
+	if !m.filter.Matches(newKey) {
+		return nil
+	}
+	err = m.mut.Put(ctx, newKey, val.EmptyTuple)
 	if err != nil {
 		return nil
 	}
@@ -109,13 +127,22 @@ func (m MutableSecondaryIdx) InsertEntry(ctx context.Context, key, newValue val.
 // UpdateEntry modifies the corresponding secondary index entry given the key
 // and curr/new values of the primary row.
 func (m MutableSecondaryIdx) UpdateEntry(ctx context.Context, key, currValue, newValue val.Tuple) error {
-	currKey := m.builder.SecondaryKeyFromRow(key, currValue)
-	newKey := m.builder.SecondaryKeyFromRow(key, newValue)
+	currKey, err := m.builder.SecondaryKeyFromRow(key, currValue)
+	if err != nil {
+		return err
+	}
+	newKey, err := m.builder.SecondaryKeyFromRow(key, newValue)
+	if err != nil {
+		return err
+	}
 
-	err := m.mut.Delete(ctx, currKey)
+	err = m.mut.Delete(ctx, currKey)
 	if err != nil {
 		return nil
 	}
+	if !m.filter.Matches(newKey) {
+		return nil
+	}
 	err = m.mut.Put(ctx, newKey, val.EmptyTuple)
 	if err != nil {
 		return nil
@@ -126,8 +153,11 @@ func (m MutableSecondaryIdx) UpdateEntry(ctx context.Context, key, currValue, ne
 
 // DeleteEntry deletes a secondary index entry given they key and value of the primary row.
 func (m MutableSecondaryIdx) DeleteEntry(ctx context.Context, key val.Tuple, value val.Tuple) error {
-	currKey := m.builder.SecondaryKeyFromRow(key, value)
-	err := m.mut.Delete(ctx, currKey)
+	currKey, err := m.builder.SecondaryKeyFromRow(key, value)
+	if err != nil {
+		return err
+	}
+	err = m.mut.Delete(ctx, currKey)
 	if err != nil {
 		return nil
 	}
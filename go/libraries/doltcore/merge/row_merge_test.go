@@ -19,9 +19,13 @@ import (
 	"strconv"
 	"testing"
 
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/val"
 )
@@ -220,7 +224,8 @@ func TestRowMerge(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			v := newValueMerger(test.mergedSch, test.leftSch, test.rightSch, test.baseSch, syncPool)
 
-			merged, ok := v.tryMerge(test.row, test.mergeRow, test.ancRow)
+			merged, ok, err := v.tryMerge(test.row, test.mergeRow, test.ancRow)
+			assert.NoError(t, err)
 			assert.Equal(t, test.expectConflict, !ok)
 			vD := test.mergedSch.GetValueDescriptor()
 			assert.Equal(t, vD.Format(test.expectedResult), vD.Format(merged))
@@ -228,6 +233,153 @@ func TestRowMerge(t *testing.T) {
 	}
 }
 
+func TestRemapRight(t *testing.T) {
+	if types.Format_Default != types.Format_DOLT {
+		t.Skip()
+	}
+
+	sch := calcSchema(3)
+	v := newValueMerger(sch, sch, sch, sch, syncPool)
+	v.rightMapping = val.OrdinalMapping{2, 1, 0}
+	vD := sch.GetValueDescriptor()
+
+	first := buildTup(sch, build(1, 2, 3))
+	second := buildTup(sch, build(4, 5, 6))
+
+	// alternate between rows so that reused scratch space and the cached
+	// result are both exercised
+	for i := 0; i < 3; i++ {
+		remapped, err := v.remapRight(first, vD)
+		assert.NoError(t, err)
+		assert.Equal(t, vD.Format(buildTup(sch, build(3, 2, 1))), vD.Format(remapped))
+		remapped, err = v.remapRight(second, vD)
+		assert.NoError(t, err)
+		assert.Equal(t, vD.Format(buildTup(sch, build(6, 5, 4))), vD.Format(remapped))
+	}
+}
+
+func TestReencodedRowMerge(t *testing.T) {
+	if types.Format_Default != types.Format_DOLT {
+		t.Skip()
+	}
+
+	decimalSchema := func(fixed bool) schema.Schema {
+		typ, err := typeinfo.FromSqlType(gmstypes.MustCreateDecimalType(10, 2))
+		assert.NoError(t, err)
+		d, err := schema.NewColumnWithTypeInfo("d", 2, typ, false, "", false, "")
+		assert.NoError(t, err)
+		d.FixedPointDecimal = fixed
+		cols := schema.NewColCollection(schema.NewColumn("pk", 0, types.IntKind, true), schema.NewColumn("i", 1, types.IntKind, false), d)
+		return schema.MustSchemaFromCols(cols)
+	}
+	decimalTup := func(sch schema.Schema, i int64, d string) val.Tuple {
+		vB := val.NewTupleBuilder(sch.GetValueDescriptor())
+		vB.PutInt64(0, i)
+		vB.PutDecimal(1, decimal.RequireFromString(d))
+		return vB.Build(syncPool)
+	}
+
+	// the left side switched to fixed-point decimals, which rewrote every row
+	plain, fixed := decimalSchema(false), decimalSchema(true)
+	v := newValueMerger(fixed, fixed, plain, plain, syncPool)
+	base := decimalTup(plain, 1, "1.50")
+
+	// left changed |d|, right changed |i|
+	merged, ok, err := v.tryMerge(decimalTup(fixed, 1, "2.50"), decimalTup(plain, 2, "1.50"), base)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, decimalTup(fixed, 2, "2.50"), merged)
+
+	// only the encoding of the left row changed
+	merged, ok, err = v.tryMerge(decimalTup(fixed, 1, "1.50"), decimalTup(plain, 1, "3.25"), base)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, decimalTup(fixed, 1, "3.25"), merged)
+
+	remapped, err := v.remapRight(decimalTup(plain, 3, "4.00"), plain.GetValueDescriptor())
+	assert.NoError(t, err)
+	assert.Equal(t, decimalTup(fixed, 3, "4.00"), remapped)
+}
+
+func TestVolatileRowMerge(t *testing.T) {
+	if types.Format_Default != types.Format_DOLT {
+		t.Skip()
+	}
+
+	sch := calcSchema(3)
+	vD := sch.GetValueDescriptor()
+	volatile := set.NewCaseInsensitiveStrSet([]string{"3"})
+	base := buildTup(sch, build(1, 1, 1))
+
+	for _, rightWins := range []bool{false, true} {
+		v := newValueMerger(sch, sch, sch, sch, syncPool)
+		v.volatile, v.rightWins = volatileFields(sch, volatile), rightWins
+		winner := 5
+		if rightWins {
+			winner = 6
+		}
+
+		// the volatile column was changed on both sides
+		merged, ok, err := v.tryMerge(buildTup(sch, build(2, 1, 5)), buildTup(sch, build(1, 1, 6)), base)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, vD.Format(buildTup(sch, build(2, 1, winner))), vD.Format(merged))
+
+		// the row was inserted on both sides
+		merged, ok, err = v.tryMerge(buildTup(sch, build(1, 1, 5)), buildTup(sch, build(1, 1, 6)), nil)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, vD.Format(buildTup(sch, build(1, 1, winner))), vD.Format(merged))
+
+		// other columns changed on both sides still conflict
+		_, ok, err = v.tryMerge(buildTup(sch, build(2, 1, 5)), buildTup(sch, build(3, 1, 6)), base)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
+
+func BenchmarkTryMerge(b *testing.B) {
+	if types.Format_Default != types.Format_DOLT {
+		b.Skip()
+	}
+
+	for _, tc := range testCases {
+		test := createRowMergeStruct(tc)
+		if test.expectConflict {
+			continue
+		}
+		b.Run(test.name, func(b *testing.B) {
+			v := newValueMerger(test.mergedSch, test.leftSch, test.rightSch, test.baseSch, syncPool)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				v.tryMerge(test.row, test.mergeRow, test.ancRow)
+			}
+		})
+	}
+}
+
+func BenchmarkRemapRight(b *testing.B) {
+	if types.Format_Default != types.Format_DOLT {
+		b.Skip()
+	}
+
+	sch := calcSchema(8)
+	v := newValueMerger(sch, sch, sch, sch, syncPool)
+	v.rightMapping = val.OrdinalMapping{7, 6, 5, 4, 3, 2, 1, 0}
+	vD := sch.GetValueDescriptor()
+	rows := []val.Tuple{
+		buildTup(sch, build(1, 2, 3, 4, 5, 6, 7, 8)),
+		buildTup(sch, build(8, 7, 6, 5, 4, 3, 2, 1)),
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.remapRight(rows[i%2], vD)
+	}
+}
+
 func TestNomsRowMerge(t *testing.T) {
 	if types.Format_Default == types.Format_DOLT {
 		t.Skip()
@@ -69,6 +69,9 @@ func TestSchemaMerge(t *testing.T) {
 	t.Run("column type change tests", func(t *testing.T) {
 		testSchemaMerge(t, typeChangeTests)
 	})
+	t.Run("column comment tests", func(t *testing.T) {
+		testSchemaMerge(t, columnCommentTests)
+	})
 	t.Run("column reordering tests", func(t *testing.T) {
 		testSchemaMerge(t, columnReorderingTests)
 	})
@@ -288,6 +291,44 @@ var columnDefaultTests = []schemaMergeTest{
 
 var columnReorderingTests = []schemaMergeTest{}
 
+var columnCommentTests = []schemaMergeTest{
+	{
+		name:     "left side add comment",
+		ancestor: tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int)              "), row(1, 2)),
+		left:     tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')"), row(1, 2)),
+		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int)              "), row(1, 2), row(3, 4)),
+		merged:   tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')"), row(1, 2), row(3, 4)),
+	},
+	{
+		name:     "left side change primary key comment",
+		ancestor: tbl(sch("CREATE TABLE t (id int PRIMARY KEY COMMENT 'id', a int)   "), row(1, 2)),
+		left:     tbl(sch("CREATE TABLE t (id int PRIMARY KEY COMMENT 'key', a int)  "), row(1, 2)),
+		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY COMMENT 'id', a int)   "), row(1, 2), row(3, 4)),
+		merged:   tbl(sch("CREATE TABLE t (id int PRIMARY KEY COMMENT 'key', a int)  "), row(1, 2), row(3, 4)),
+	},
+	{
+		name:     "left side change comment, right side add default",
+		ancestor: tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')           "), row(1, 2)),
+		left:     tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'bb')           "), row(1, 2)),
+		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 42 COMMENT 'aa')"), row(1, 2)),
+		merged:   tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 42 COMMENT 'bb')"), row(1, 2)),
+	},
+	{
+		name:     "convergent comment changes",
+		ancestor: tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int)              "), row(1, 2)),
+		left:     tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')"), row(1, 2)),
+		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')"), row(1, 2), row(3, 4)),
+		merged:   tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')"), row(1, 2), row(3, 4)),
+	},
+	{
+		name:     "conflicting comment changes",
+		ancestor: tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int)              "), row(1, 2)),
+		left:     tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'aa')"), row(1, 2)),
+		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int COMMENT 'bb')"), row(1, 2), row(3, 4)),
+		conflict: true,
+	},
+}
+
 var typeChangeTests = []schemaMergeTest{
 	{
 		name:     "modify column type on the left side",
@@ -57,6 +57,7 @@ const (
 	CvType_ForeignKey CvType = iota + 1
 	CvType_UniqueIndex
 	CvType_CheckConstraint
+	CvType_TypeConversion
 )
 
 type FKViolationReceiver interface {
@@ -74,7 +75,15 @@ func GetForeignKeyViolations(ctx context.Context, newRoot, baseRoot *doltdb.Root
 		return err
 	}
 	for _, foreignKey := range fkColl.AllKeys() {
-		if !foreignKey.IsResolved() || (tables.Size() != 0 && !tables.Contains(foreignKey.TableName)) {
+		// virtual foreign keys aren't enforced, so their rows can't violate them
+		if !foreignKey.IsResolved() || foreignKey.NotEnforced || (tables.Size() != 0 && !tables.Contains(foreignKey.TableName)) {
+			continue
+		}
+		// violations are found by diffing the parent and child tables, so there are none if neither changed
+		unchanged, err := tablesUnchanged(ctx, newRoot, baseRoot, foreignKey.ReferencedTableName, foreignKey.TableName)
+		if err != nil {
+			return err
+		} else if unchanged {
 			continue
 		}
 
@@ -153,6 +162,24 @@ func GetForeignKeyViolations(ctx context.Context, newRoot, baseRoot *doltdb.Root
 	return nil
 }
 
+// tablesUnchanged returns whether each of |tblNames| exists in both |newRoot| and |baseRoot| with the same hash.
+func tablesUnchanged(ctx context.Context, newRoot, baseRoot *doltdb.RootValue, tblNames ...string) (bool, error) {
+	for _, name := range tblNames {
+		newHash, ok, err := newRoot.GetTableHash(ctx, name)
+		if err != nil || !ok {
+			return false, err
+		}
+		baseHash, ok, err := baseRoot.GetTableHash(ctx, name)
+		if err != nil || !ok {
+			return false, err
+		}
+		if newHash != baseHash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // AddForeignKeyViolations adds foreign key constraint violations to each table.
 // todo(andy): pass doltdb.Rootish
 func AddForeignKeyViolations(ctx context.Context, newRoot, baseRoot *doltdb.RootValue, tables *set.StrSet, theirRootIsh hash.Hash) (*doltdb.RootValue, *set.StrSet, error) {
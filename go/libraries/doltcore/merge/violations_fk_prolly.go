@@ -50,12 +50,16 @@ func prollyParentSecDiffFkConstraintViolations(
 
 	childPriIdx := durable.ProllyMapFromIndex(postChild.RowData)
 	childPriKD, _ := childPriIdx.Descriptors()
+	childPriKB := val.NewTupleBuilder(childPriKD)
 
 	var err error
 	err = prolly.DiffMaps(ctx, preParentSecIdx, postParentSecIdx, func(ctx context.Context, diff tree.Diff) error {
 		switch diff.Type {
 		case tree.RemovedDiff, tree.ModifiedDiff:
-			toSecKey, hadNulls := makePartialKey(partialKB, foreignKey.ReferencedTableColumns, postParent.Index, postParent.IndexSchema, val.Tuple(diff.Key), val.Tuple(diff.From), preParentSecIdx.Pool())
+			toSecKey, hadNulls, err := makePartialKey(partialKB, foreignKey.ReferencedTableColumns, postParent.Index, postParent.IndexSchema, val.Tuple(diff.Key), val.Tuple(diff.From), preParentSecIdx.Pool())
+			if err != nil {
+				return err
+			}
 			if hadNulls {
 				// row had some nulls previously, so it couldn't have been a parent
 				return nil
@@ -71,7 +75,7 @@ func prollyParentSecDiffFkConstraintViolations(
 
 			// All equivalent parents were deleted, let's check for dangling children.
 			// We search for matching keys in the child's secondary index
-			err = createCVsForPartialKeyMatches(ctx, toSecKey, parentPrefixKD, childPriKD, childPriIdx, childSecIdx, postParentRowData.Pool(), receiver)
+			err = createCVsForPartialKeyMatches(ctx, toSecKey, parentPrefixKD, childPriKD, childPriKB, childPriIdx, childSecIdx, postParentRowData.Pool(), receiver)
 			if err != nil {
 				return err
 			}
@@ -104,11 +108,15 @@ func prollyParentPriDiffFkConstraintViolations(
 	childPriIdx := durable.ProllyMapFromIndex(postChild.RowData)
 	childScndryIdx := durable.ProllyMapFromIndex(postChild.IndexData)
 	primaryKD, _ := childPriIdx.Descriptors()
+	primaryKB := val.NewTupleBuilder(primaryKD)
 
 	err := prolly.DiffMaps(ctx, preParentRowData, postParentRowData, func(ctx context.Context, diff tree.Diff) error {
 		switch diff.Type {
 		case tree.RemovedDiff, tree.ModifiedDiff:
-			partialKey, hadNulls := makePartialKey(partialKB, foreignKey.ReferencedTableColumns, postParent.Index, postParent.Schema, val.Tuple(diff.Key), val.Tuple(diff.From), preParentRowData.Pool())
+			partialKey, hadNulls, err := makePartialKey(partialKB, foreignKey.ReferencedTableColumns, postParent.Index, postParent.Schema, val.Tuple(diff.Key), val.Tuple(diff.From), preParentRowData.Pool())
+			if err != nil {
+				return err
+			}
 			if hadNulls {
 				// row had some nulls previously, so it couldn't have been a parent
 				return nil
@@ -131,7 +139,7 @@ func prollyParentPriDiffFkConstraintViolations(
 
 			// All equivalent parents were deleted, let's check for dangling children.
 			// We search for matching keys in the child's secondary index
-			err = createCVsForPartialKeyMatches(ctx, partialKey, partialDesc, primaryKD, childPriIdx, childScndryIdx, childPriIdx.Pool(), receiver)
+			err = createCVsForPartialKeyMatches(ctx, partialKey, partialDesc, primaryKD, primaryKB, childPriIdx, childScndryIdx, childPriIdx.Pool(), receiver)
 			if err != nil {
 				return err
 			}
@@ -167,7 +175,7 @@ func prollyChildPriDiffFkConstraintViolations(
 		switch diff.Type {
 		case tree.AddedDiff, tree.ModifiedDiff:
 			k, v := val.Tuple(diff.Key), val.Tuple(diff.To)
-			partialKey, hasNulls := makePartialKey(
+			partialKey, hasNulls, err := makePartialKey(
 				partialKB,
 				foreignKey.TableColumns,
 				postChild.Index,
@@ -175,11 +183,14 @@ func prollyChildPriDiffFkConstraintViolations(
 				k,
 				v,
 				preChildRowData.Pool())
+			if err != nil {
+				return err
+			}
 			if hasNulls {
 				return nil
 			}
 
-			err := createCVIfNoPartialKeyMatchesPri(ctx, k, v, partialKey, partialDesc, parentScndryIdx, receiver)
+			err = createCVIfNoPartialKeyMatchesPri(ctx, k, v, partialKey, partialDesc, parentScndryIdx, receiver)
 			if err != nil {
 				return err
 			}
@@ -298,6 +309,7 @@ func createCVsForPartialKeyMatches(
 	partialKey val.Tuple,
 	partialKeyDesc val.TupleDesc,
 	primaryKD val.TupleDesc,
+	kb *val.TupleBuilder,
 	primaryIdx prolly.Map,
 	secondaryIdx prolly.Map,
 	pool pool.BuffPool,
@@ -309,8 +321,6 @@ func createCVsForPartialKeyMatches(
 		return err
 	}
 
-	kb := val.NewTupleBuilder(primaryKD)
-
 	for k, _, err := itr.Next(ctx); err == nil; k, _, err = itr.Next(ctx) {
 
 		// convert secondary idx entry to primary row key
@@ -343,7 +353,7 @@ func createCVsForPartialKeyMatches(
 	return nil
 }
 
-func makePartialKey(kb *val.TupleBuilder, tags []uint64, idxSch schema.Index, tblSch schema.Schema, k, v val.Tuple, pool pool.BuffPool) (val.Tuple, bool) {
+func makePartialKey(kb *val.TupleBuilder, tags []uint64, idxSch schema.Index, tblSch schema.Schema, k, v val.Tuple, pool pool.BuffPool) (val.Tuple, bool, error) {
 	// Possible that the parent index (idxSch) is longer than the partial key (tags).
 	if idxSch.Name() != "" && len(idxSch.IndexedColumnTags()) <= len(tags) {
 		tags = idxSch.IndexedColumnTags()
@@ -351,7 +361,7 @@ func makePartialKey(kb *val.TupleBuilder, tags []uint64, idxSch schema.Index, tb
 	for i, tag := range tags {
 		if j, ok := tblSch.GetPKCols().TagToIdx[tag]; ok {
 			if k.FieldIsNull(j) {
-				return nil, true
+				return nil, true, nil
 			}
 			kb.PutRaw(i, k.GetField(j))
 			continue
@@ -359,16 +369,24 @@ func makePartialKey(kb *val.TupleBuilder, tags []uint64, idxSch schema.Index, tb
 
 		j, _ := tblSch.GetNonPKCols().TagToIdx[tag]
 		if v.FieldIsNull(j) {
-			return nil, true
+			return nil, true, nil
 		}
 		if schema.IsKeyless(tblSch) {
-			kb.PutRaw(i, v.GetField(j+1))
+			j++
+		}
+		if col, _ := tblSch.GetNonPKCols().GetByTag(tag); len(col.Dictionary) > 0 || col.FixedPointDecimal {
+			// index keys never hold string dictionary codes or fixed-point decimals
+			field, err := tblSch.GetValueDescriptor().GetPlainField(j, v)
+			if err != nil {
+				return nil, false, err
+			}
+			kb.PutRaw(i, field)
 		} else {
 			kb.PutRaw(i, v.GetField(j))
 		}
 	}
 
-	return kb.Build(pool), false
+	return kb.Build(pool), false, nil
 }
 
 // TODO: Change json.NomsJson string marshalling to match json.Marshall
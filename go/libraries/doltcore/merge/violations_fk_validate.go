@@ -0,0 +1,194 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ValidateForeignKeys checks that the foreign key columns of every row of the child tables of the foreign keys of
+// |root| reference a row of their parent table, and sends the rows which don't to |receiver|. Rows with a NULL
+// foreign key column never violate their foreign key. Unlike GetForeignKeyViolations, which checks the rows changed
+// since a base root value, every child row is checked, and parent tables are only read to look up child rows. Child
+// rows are read from the secondary index of their foreign key, if the child table has one. Only the foreign keys of
+// the child tables in |tables| are checked, unless it's empty.
+func ValidateForeignKeys(ctx context.Context, root *doltdb.RootValue, tables *set.StrSet, receiver FKViolationReceiver) error {
+	if !types.IsFormat_DOLT(root.VRW().Format()) {
+		// every row of |root| was added since the empty root value
+		empty, err := doltdb.EmptyRootValue(ctx, root.VRW(), root.NodeStore())
+		if err != nil {
+			return err
+		}
+		return GetForeignKeyViolations(ctx, root, empty, tables, receiver)
+	}
+
+	fkColl, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return err
+	}
+	for _, foreignKey := range fkColl.AllKeys() {
+		if !foreignKey.IsResolved() || foreignKey.NotEnforced || (tables.Size() != 0 && !tables.Contains(foreignKey.TableName)) {
+			continue
+		}
+
+		err = receiver.StartFK(ctx, foreignKey)
+		if err != nil {
+			return err
+		}
+
+		parent, ok, err := newConstraintViolationsLoadedTable(ctx, foreignKey.ReferencedTableName, foreignKey.ReferencedTableIndex, root)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("foreign key %s should have index %s on table %s but it cannot be found",
+				foreignKey.Name, foreignKey.ReferencedTableIndex, foreignKey.ReferencedTableName)
+		}
+
+		child, ok, err := newConstraintViolationsLoadedTable(ctx, foreignKey.TableName, foreignKey.TableIndex, root)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("foreign key %s should have index %s on table %s but it cannot be found",
+				foreignKey.Name, foreignKey.TableIndex, foreignKey.TableName)
+		}
+
+		if child.Index.Name() != "" && !schema.IsKeyless(child.Schema) {
+			err = validateChildSecIdxForeignKey(ctx, foreignKey, parent, child, receiver)
+		} else {
+			err = validateChildRowsForeignKey(ctx, foreignKey, parent, child, receiver)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = receiver.EndCurrFK(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddAllForeignKeyViolations adds a foreign key constraint violation for every row of |root| found by
+// ValidateForeignKeys, and returns the tables they were added to.
+func AddAllForeignKeyViolations(ctx context.Context, root *doltdb.RootValue, tables *set.StrSet, theirRootIsh hash.Hash) (*doltdb.RootValue, *set.StrSet, error) {
+	violationWriter := &foreignKeyViolationWriter{rootValue: root, theirRootIsh: theirRootIsh, violatedTables: set.NewStrSet(nil)}
+	err := ValidateForeignKeys(ctx, root, tables, violationWriter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return violationWriter.rootValue, violationWriter.violatedTables, nil
+}
+
+// validateChildSecIdxForeignKey looks up the foreign key columns of every entry of the child's secondary index in the
+// parent's index. The foreign key columns are the leading columns of both indexes.
+func validateChildSecIdxForeignKey(
+	ctx context.Context,
+	foreignKey doltdb.ForeignKey,
+	parent, child *constraintViolationsLoadedTable,
+	receiver FKViolationReceiver) error {
+	childRowData := durable.ProllyMapFromIndex(child.RowData)
+	childSecIdx := durable.ProllyMapFromIndex(child.IndexData)
+	parentSecIdx := durable.ProllyMapFromIndex(parent.IndexData)
+
+	parentSecIdxDesc, _ := parentSecIdx.Descriptors()
+	prefixDesc := parentSecIdxDesc.PrefixDesc(len(foreignKey.TableColumns))
+	childPriKD, _ := childRowData.Descriptors()
+	childPriKB := val.NewTupleBuilder(childPriKD)
+
+	iter, err := childSecIdx.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		k, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		hasNulls := false
+		for i := range foreignKey.TableColumns {
+			hasNulls = hasNulls || k.FieldIsNull(i)
+		}
+		if hasNulls {
+			continue
+		}
+
+		ok, err := parentSecIdx.HasPrefix(ctx, k, prefixDesc)
+		if err != nil {
+			return err
+		} else if ok {
+			continue
+		}
+		err = createCVForSecIdx(ctx, k, childPriKD, childPriKB, childRowData, childRowData.Pool(), receiver)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// validateChildRowsForeignKey looks up the foreign key columns of every child row in the parent's index, for child
+// tables which are keyless or whose foreign key uses their primary key.
+func validateChildRowsForeignKey(
+	ctx context.Context,
+	foreignKey doltdb.ForeignKey,
+	parent, child *constraintViolationsLoadedTable,
+	receiver FKViolationReceiver) error {
+	childRowData := durable.ProllyMapFromIndex(child.RowData)
+	parentSecIdx := durable.ProllyMapFromIndex(parent.IndexData)
+
+	idxDesc, _ := parentSecIdx.Descriptors()
+	partialDesc := idxDesc.PrefixDesc(len(foreignKey.TableColumns))
+	partialKB := val.NewTupleBuilder(partialDesc)
+
+	iter, err := childRowData.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		partialKey, hasNulls, err := makePartialKey(partialKB, foreignKey.TableColumns, child.Index, child.Schema, k, v, childRowData.Pool())
+		if err != nil {
+			return err
+		}
+		if hasNulls {
+			continue
+		}
+		err = createCVIfNoPartialKeyMatchesPri(ctx, k, v, partialKey, partialDesc, parentSecIdx, receiver)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"encoding/json"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// TypeConvCVMeta is the violation info of a row left out of its table because the value of |Column| couldn't be
+// converted to the column's new type |Type|. The row is recorded with NULL in |Column|, and |Value| holds the value
+// that failed to convert.
+type TypeConvCVMeta struct {
+	Column string `json:"Column"`
+	Error  string `json:"Error"`
+	Type   string `json:"Type"`
+	Value  string `json:"Value"`
+}
+
+var _ types.JSONValue = TypeConvCVMeta{}
+
+func (m TypeConvCVMeta) Unmarshall(ctx *sql.Context) (val types.JSONDocument, err error) {
+	return types.JSONDocument{Val: m}, nil
+}
+
+func (m TypeConvCVMeta) Compare(ctx *sql.Context, v types.JSONValue) (cmp int, err error) {
+	ours := types.JSONDocument{Val: m}
+	return ours.Compare(ctx, v)
+}
+
+func (m TypeConvCVMeta) ToString(ctx *sql.Context) (string, error) {
+	return m.PrettyPrint(), nil
+}
+
+func (m TypeConvCVMeta) PrettyPrint() string {
+	// the value and the error can contain any character, so they have to be escaped
+	str := func(s string) string {
+		b, _ := json.Marshal(s)
+		return string(b)
+	}
+	return `{` +
+		`"Column": ` + str(m.Column) + `, ` +
+		`"Error": ` + str(m.Error) + `, ` +
+		`"Type": ` + str(m.Type) + `, ` +
+		`"Value": ` + str(m.Value) + `}`
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const cloneStagingDir = "clone-staging"
+
+// CloneRemote clones |srcDB|, a database in an older storage format, into |dEnv|, which must be a new repository in
+// the current format. Chunks can't be pulled across formats, so the remote is first cloned into a staging database
+// in its own format, and the staging database's history is then migrated into |dEnv|. The staging database is
+// removed afterwards.
+func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, r env.Remote, branch string, dEnv *env.DoltEnv, homeProvider env.HomeDirProvider) (err error) {
+	if !types.IsFormat_DOLT(dEnv.DoltDB.Format()) {
+		return fmt.Errorf("cannot migrate a clone into a database in format %s", dEnv.DoltDB.Format().VersionString())
+	}
+	if types.IsFormat_DOLT(srcDB.Format()) {
+		return actions.CloneRemote(ctx, srcDB, r.Name, branch, dEnv)
+	}
+
+	tmp, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(tmp, cloneStagingDir)
+	defer func() {
+		if rmErr := dEnv.FS.Delete(dir, true); err == nil {
+			err = rmErr
+		}
+	}()
+
+	staging, err := actions.EnvForClone(ctx, srcDB.Format(), r, dir, dEnv.FS, dEnv.Version, homeProvider)
+	if err != nil {
+		return err
+	}
+	if err = actions.CloneRemote(ctx, srcDB, r.Name, branch, staging); err != nil {
+		return err
+	}
+
+	menv := Environment{
+		Migration: dEnv,
+		Existing:  staging,
+	}
+	// TraverseDAG closes both databases when it's done
+	if err = TraverseDAG(ctx, menv, staging.DoltDB, dEnv.DoltDB); err != nil {
+		return err
+	}
+
+	dEnv.DoltDB, err = doltdb.LoadDoltDB(ctx, targetFormat, doltdb.LocalDirDoltDB, dEnv.FS)
+	if err != nil {
+		return err
+	}
+
+	return dEnv.RepoStateWriter().SetCWBHeadRef(ctx, ref.MarshalableRef{Ref: staging.RepoState.CWBHeadRef()})
+}
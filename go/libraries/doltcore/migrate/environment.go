@@ -16,6 +16,7 @@ package migrate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -38,6 +39,9 @@ const (
 
 	manifestFile = "manifest"
 	migrationRef = "migration"
+
+	// migrationStateFile is the file of the existing database that records a migration in progress
+	migrationStateFile = "migration_state.json"
 )
 
 var (
@@ -50,17 +54,88 @@ type Environment struct {
 	Migration     *env.DoltEnv
 	Existing      *env.DoltEnv
 	DropConflicts bool
+
+	// Branches limits the migration to the history and working sets of these branches. The migration is left in
+	// progress, and the remaining refs are migrated by a later migration of the whole database.
+	Branches []string
+	// Resumed is whether the environment continues a migration that was interrupted or limited to some branches
+	Resumed bool
+
+	state *migrationState
+}
+
+// migrationState records a migration in progress in the existing database, so that it can be resumed.
+type migrationState struct {
+	// Dir is the directory of the migrated database
+	Dir string `json:"dir"`
+	// CommitMapping is the address of the commit mapping saved by the last checkpoint of the migration
+	CommitMapping string `json:"commit_mapping,omitempty"`
+}
+
+func loadMigrationState(fs filesys.Filesys) (*migrationState, error) {
+	path := filepath.Join(doltDir, migrationStateFile)
+	if ok, _ := fs.Exists(path); !ok {
+		return nil, nil
+	}
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state migrationState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid migration state in %s: %w", path, err)
+	}
+	return &state, nil
 }
 
-// NewEnvironment creates a migration Environment for |existing|.
+func (s *migrationState) save(fs filesys.Filesys) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(doltDir, migrationStateFile), data)
+}
+
+// NewEnvironment creates a migration Environment for |existing|. If a migration of |existing| was interrupted, or
+// limited to some branches, the environment resumes it.
 func NewEnvironment(ctx context.Context, existing *env.DoltEnv) (Environment, error) {
-	mfs, err := getMigrateFS(existing.FS)
+	state, err := loadMigrationState(existing.FS)
 	if err != nil {
 		return Environment{}, err
 	}
 
-	if err = initMigrationDB(ctx, existing, existing.FS, mfs); err != nil {
-		return Environment{}, err
+	var mfs filesys.Filesys
+	if state != nil {
+		// the migration can't be resumed if its directory is gone, start over
+		if ok, isDir := existing.FS.Exists(filepath.Join(state.Dir, doltDir)); !ok || !isDir {
+			state = nil
+		}
+	}
+	resumed := state != nil
+
+	if resumed {
+		mfs, err = filesys.LocalFilesysWithWorkingDir(state.Dir)
+		if err != nil {
+			return Environment{}, err
+		}
+	} else {
+		mfs, err = getMigrateFS(existing.FS)
+		if err != nil {
+			return Environment{}, err
+		}
+
+		if err = initMigrationDB(ctx, existing, existing.FS, mfs); err != nil {
+			return Environment{}, err
+		}
+
+		dir, err := mfs.Abs(".")
+		if err != nil {
+			return Environment{}, err
+		}
+		state = &migrationState{Dir: dir}
+		if err = state.save(existing.FS); err != nil {
+			return Environment{}, err
+		}
 	}
 
 	mdb, err := doltdb.LoadDoltDB(ctx, targetFormat, doltdb.LocalDirDoltDB, mfs)
@@ -86,6 +161,8 @@ func NewEnvironment(ctx context.Context, existing *env.DoltEnv) (Environment, er
 	return Environment{
 		Migration: migration,
 		Existing:  existing,
+		Resumed:   resumed,
+		state:     state,
 	}, nil
 }
 
@@ -210,7 +287,15 @@ func SwapChunkStores(ctx context.Context, menv Environment) error {
 		return cpErr
 	}
 
-	return swapManifests(ctx, src, dest)
+	if err = swapManifests(ctx, src, dest); err != nil {
+		return err
+	}
+
+	// the migration is complete
+	if ok, _ := dest.Exists(filepath.Join(doltDir, migrationStateFile)); ok {
+		return dest.DeleteFile(filepath.Join(doltDir, migrationStateFile))
+	}
+	return nil
 }
 
 func swapManifests(ctx context.Context, src, dest filesys.Filesys) (err error) {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/pool"
@@ -60,11 +62,20 @@ type progress struct {
 	kb, vb   *val.TupleBuilder
 	buffPool pool.BuffPool
 
+	// commits and tables count the commits and tables migrated by this process
+	commits, tables int
+
+	// state is the saved state of the migration, which is updated by checkpoints
+	state   *migrationState
+	stateFS filesys.Filesys
+
 	vs *types.ValueStore
 	cs chunks.ChunkStore
 }
 
-func newProgress(ctx context.Context, cs chunks.ChunkStore) (*progress, error) {
+// newProgress creates the progress of the migration |menv|, starting from the commit mapping of its last checkpoint
+// if it's resumed.
+func newProgress(ctx context.Context, menv Environment, cs chunks.ChunkStore) (*progress, error) {
 	kd := val.NewTupleDescriptor(val.Type{
 		Enc:      val.ByteStringEnc,
 		Nullable: false,
@@ -77,24 +88,49 @@ func newProgress(ctx context.Context, cs chunks.ChunkStore) (*progress, error) {
 	ns := tree.NewNodeStore(cs)
 	vs := types.NewValueStore(cs)
 
-	mapping, err := prolly.NewMapFromTuples(ctx, ns, kd, vd)
-	if err != nil {
-		return nil, err
+	var mapping prolly.Map
+	if menv.state != nil && menv.state.CommitMapping != "" {
+		addr, ok := hash.MaybeParse(menv.state.CommitMapping)
+		if !ok {
+			return nil, fmt.Errorf("invalid commit mapping address in migration state: %s", menv.state.CommitMapping)
+		}
+		v, err := vs.ReadValue(ctx, addr)
+		if err != nil {
+			return nil, err
+		} else if v == nil {
+			return nil, fmt.Errorf("commit mapping of migration state not found: %s", addr.String())
+		}
+		root, err := shim.NodeFromValue(v)
+		if err != nil {
+			return nil, err
+		}
+		mapping = prolly.NewMap(root, ns, kd, vd)
+	} else {
+		var err error
+		mapping, err = prolly.NewMapFromTuples(ctx, ns, kd, vd)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	mut := mapping.Mutate()
 	kb := val.NewTupleBuilder(kd)
 	vb := val.NewTupleBuilder(vd)
 
-	return &progress{
+	p := &progress{
 		stack:    make([]*doltdb.Commit, 0, 128),
 		mapping:  mut,
 		kb:       kb,
 		vb:       vb,
 		buffPool: ns.Pool(),
+		state:    menv.state,
 		vs:       vs,
 		cs:       cs,
-	}, nil
+	}
+	if menv.Existing != nil {
+		p.stateFS = menv.Existing.FS
+	}
+	return p, nil
 }
 
 func (p *progress) Has(ctx context.Context, addr hash.Hash) (ok bool, err error) {
@@ -147,28 +183,80 @@ func (p *progress) Log(ctx context.Context, format string, args ...any) {
 	cli.Println(time.Now().UTC().String() + " " + fmt.Sprintf(format, args...))
 }
 
-func (p *progress) Finalize(ctx context.Context) (prolly.Map, error) {
+// CommitMigrated records that the commit |old| was migrated to |new|, and reports the progress of the migration.
+func (p *progress) CommitMigrated(ctx context.Context, old, new hash.Hash) error {
+	if err := p.Put(ctx, old, new); err != nil {
+		return err
+	}
+	p.commits++
+
+	written := "unknown"
+	if counter, ok := p.cs.(interface{ Count() (uint32, error) }); ok {
+		if n, err := counter.Count(); err == nil {
+			written = strconv.FormatUint(uint64(n), 10)
+		}
+	}
+	p.Log(ctx, "migrated commit %s to %s (commits done: %d, tables done: %d, chunks written: %s)",
+		old.String(), new.String(), p.commits, p.tables, written)
+	return nil
+}
+
+// TableMigrated records that a table of a commit or working set was migrated.
+func (p *progress) TableMigrated() {
+	p.tables++
+}
+
+// Checkpoint saves the commit mapping to the migration state, so that an interrupted migration resumes after the
+// commits migrated so far.
+func (p *progress) Checkpoint(ctx context.Context) error {
+	if p.state == nil || p.stateFS == nil {
+		return nil
+	}
 	m, err := p.mapping.Map(ctx)
 	if err != nil {
-		return prolly.Map{}, err
+		return err
+	}
+	addr, err := p.writeMapping(ctx, m)
+	if err != nil {
+		return err
 	}
+	p.mapping = m.Mutate()
+
+	p.state.CommitMapping = addr.String()
+	return p.state.save(p.stateFS)
+}
+
+// writeMapping writes |m| to the migrated database and returns its address.
+func (p *progress) writeMapping(ctx context.Context, m prolly.Map) (hash.Hash, error) {
 	v := shim.ValueFromMap(m)
 	ref, err := p.vs.WriteValue(ctx, v)
 	if err != nil {
-		return prolly.Map{}, err
+		return hash.Hash{}, err
 	}
 	last, err := p.vs.Root(ctx)
 	if err != nil {
-		return prolly.Map{}, err
+		return hash.Hash{}, err
 	}
 	ok, err := p.vs.Commit(ctx, last, last)
 	if err != nil {
-		return prolly.Map{}, err
+		return hash.Hash{}, err
 	} else if !ok {
-		return prolly.Map{}, fmt.Errorf("failed to commit, manifest swapped out beneath us")
+		return hash.Hash{}, fmt.Errorf("failed to commit, manifest swapped out beneath us")
+	}
+	return ref.TargetHash(), nil
+}
+
+func (p *progress) Finalize(ctx context.Context) (prolly.Map, error) {
+	m, err := p.mapping.Map(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	addr, err := p.writeMapping(ctx, m)
+	if err != nil {
+		return prolly.Map{}, err
 	}
 
-	p.Log(ctx, "Wrote commit mapping!! [commit_mapping_ref: %s]", ref.TargetHash().String())
+	p.Log(ctx, "Wrote commit mapping!! [commit_mapping_ref: %s]", addr.String())
 	p.Log(ctx, "Commit mapping allow mapping pre-migration commit hashes to post-migration commit hashes, "+
 		"it is available on branch '%s' in table '%s'", MigratedCommitsBranch, MigratedCommitsTable)
 	return m, nil
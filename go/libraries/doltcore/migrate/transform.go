@@ -42,7 +42,7 @@ var (
 	flushRef = ref.NewInternalRef("migration-flush")
 )
 
-func migrateWorkingSet(ctx context.Context, menv Environment, brRef ref.BranchRef, wsRef ref.WorkingSetRef, old, new *doltdb.DoltDB) error {
+func migrateWorkingSet(ctx context.Context, menv Environment, brRef ref.BranchRef, wsRef ref.WorkingSetRef, old, new *doltdb.DoltDB, prog *progress) error {
 	oldHead, err := old.ResolveCommitRef(ctx, brRef)
 	if err != nil {
 		return err
@@ -71,12 +71,12 @@ func migrateWorkingSet(ctx context.Context, menv Environment, brRef ref.BranchRe
 		return err
 	}
 
-	wr, err := migrateRoot(ctx, menv, oldHeadRoot, oldWs.WorkingRoot(), newHeadRoot)
+	wr, err := migrateRoot(ctx, menv, oldHeadRoot, oldWs.WorkingRoot(), newHeadRoot, prog)
 	if err != nil {
 		return err
 	}
 
-	sr, err := migrateRoot(ctx, menv, oldHeadRoot, oldWs.StagedRoot(), newHeadRoot)
+	sr, err := migrateRoot(ctx, menv, oldHeadRoot, oldWs.StagedRoot(), newHeadRoot, prog)
 	if err != nil {
 		return err
 	}
@@ -93,7 +93,17 @@ func migrateWorkingSet(ctx context.Context, menv Environment, brRef ref.BranchRe
 
 	newWs := doltdb.EmptyWorkingSet(wsRef).WithWorkingRoot(wr).WithStagedRoot(sr)
 
-	return new.UpdateWorkingSet(ctx, wsRef, newWs, hash.Hash{}, oldWs.Meta())
+	// a resumed migration may have migrated the working set already
+	var prev hash.Hash
+	if ws, err := new.ResolveWorkingSet(ctx, wsRef); err == nil {
+		if prev, err = ws.HashOf(); err != nil {
+			return err
+		}
+	} else if err != doltdb.ErrWorkingSetNotFound {
+		return err
+	}
+
+	return new.UpdateWorkingSet(ctx, wsRef, newWs, prev, oldWs.Meta())
 }
 
 func migrateCommit(ctx context.Context, menv Environment, oldCm *doltdb.Commit, new *doltdb.DoltDB, prog *progress) error {
@@ -154,7 +164,7 @@ func migrateCommit(ctx context.Context, menv Environment, oldCm *doltdb.Commit,
 		return err
 	}
 
-	mRoot, err := migrateRoot(ctx, menv, oldParentRoot, oldRoot, newParentRoot)
+	mRoot, err := migrateRoot(ctx, menv, oldParentRoot, oldRoot, newParentRoot, prog)
 	if err != nil {
 		return err
 	}
@@ -182,7 +192,7 @@ func migrateCommit(ctx context.Context, menv Environment, oldCm *doltdb.Commit,
 	if err != nil {
 		return err
 	}
-	if err = prog.Put(ctx, oldHash, newHash); err != nil {
+	if err = prog.CommitMigrated(ctx, oldHash, newHash); err != nil {
 		return err
 	}
 
@@ -194,6 +204,9 @@ func migrateCommit(ctx context.Context, menv Environment, oldCm *doltdb.Commit,
 	if err != nil && err != chunks.ErrUnsupportedOperation {
 		return err
 	}
+	if err = prog.Checkpoint(ctx); err != nil {
+		return err
+	}
 
 	// validate root after we flush the ChunkStore to facilitate
 	// investigating failed migrations
@@ -272,7 +285,7 @@ func migrateCommitOptions(ctx context.Context, oldCm *doltdb.Commit, prog *progr
 	}, nil
 }
 
-func migrateRoot(ctx context.Context, menv Environment, oldParent, oldRoot, newParent *doltdb.RootValue) (*doltdb.RootValue, error) {
+func migrateRoot(ctx context.Context, menv Environment, oldParent, oldRoot, newParent *doltdb.RootValue, prog *progress) (*doltdb.RootValue, error) {
 	migrated := newParent
 
 	fkc, err := oldRoot.GetForeignKeyCollection(ctx)
@@ -354,6 +367,7 @@ func migrateRoot(ctx context.Context, menv Environment, oldParent, oldRoot, newP
 		if err != nil {
 			return true, err
 		}
+		prog.TableMigrated()
 		return false, nil
 	})
 	if err != nil {
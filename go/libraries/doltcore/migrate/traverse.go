@@ -25,12 +25,17 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 )
 
-// TraverseDAG traverses |old|, migrating values to |new|.
+// TraverseDAG traverses |old|, migrating values to |new|. If |menv| is limited to some branches, only their refs are
+// migrated, and the migration is saved to be resumed.
 func TraverseDAG(ctx context.Context, menv Environment, old, new *doltdb.DoltDB) (err error) {
 	var heads []ref.DoltRef
 	var prog *progress
 
-	heads, err = old.GetHeadRefs(ctx)
+	if len(menv.Branches) > 0 {
+		heads, err = getBranchRefs(ctx, old, menv.Branches)
+	} else {
+		heads, err = old.GetHeadRefs(ctx)
+	}
 	if err != nil {
 		return err
 	}
@@ -38,7 +43,7 @@ func TraverseDAG(ctx context.Context, menv Environment, old, new *doltdb.DoltDB)
 	datasdb := doltdb.HackDatasDatabaseFromDoltDB(new)
 	cs := datas.ChunkStoreFromDatabase(datasdb)
 
-	prog, err = newProgress(ctx, cs)
+	prog, err = newProgress(ctx, menv, cs)
 	if err != nil {
 		return err
 	}
@@ -49,6 +54,16 @@ func TraverseDAG(ctx context.Context, menv Environment, old, new *doltdb.DoltDB)
 		}
 	}
 
+	if len(menv.Branches) > 0 {
+		if err = prog.Checkpoint(ctx); err != nil {
+			return err
+		}
+		if err = old.Close(); err != nil {
+			return err
+		}
+		return new.Close()
+	}
+
 	if err = validateBranchMapping(ctx, old, new); err != nil {
 		return err
 	}
@@ -81,7 +96,7 @@ func traverseRefHistory(ctx context.Context, menv Environment, r ref.DoltRef, ol
 		if err != nil {
 			return err
 		}
-		return migrateWorkingSet(ctx, menv, r.(ref.BranchRef), wsRef, old, new)
+		return migrateWorkingSet(ctx, menv, r.(ref.BranchRef), wsRef, old, new, prog)
 
 	case ref.TagRefType:
 		return traverseTagHistory(ctx, menv, r.(ref.TagRef), old, new, prog)
@@ -128,6 +143,11 @@ func traverseTagHistory(ctx context.Context, menv Environment, r ref.TagRef, old
 		return err
 	}
 
+	// a resumed migration may have migrated the tag already
+	if ok, err := new.HasRef(ctx, r); err != nil || ok {
+		return err
+	}
+
 	oldHash, err := t.Commit.HashOf()
 	if err != nil {
 		return err
@@ -190,6 +210,21 @@ func traverseCommitHistory(ctx context.Context, menv Environment, cm *doltdb.Com
 	}
 }
 
+// getBranchRefs returns the refs of the |branches| of |ddb|.
+func getBranchRefs(ctx context.Context, ddb *doltdb.DoltDB, branches []string) ([]ref.DoltRef, error) {
+	refs := make([]ref.DoltRef, len(branches))
+	for i, name := range branches {
+		refs[i] = ref.NewBranchRef(name)
+		ok, err := ddb.HasRef(ctx, refs[i])
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("branch '%s' not found", name)
+		}
+	}
+	return refs, nil
+}
+
 func firstAbsent(ctx context.Context, p *progress, addrs []hash.Hash) (int, error) {
 	for i := range addrs {
 		ok, err := p.Has(ctx, addrs[i])
@@ -53,6 +53,7 @@ type MoverOptions struct {
 	TableToWriteTo string
 	Operation      TableImportOp
 	DisableFks     bool
+	SkipUnchanged  bool
 }
 
 type DataMoverOptions interface {
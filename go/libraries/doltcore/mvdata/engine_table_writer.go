@@ -50,15 +50,18 @@ type SqlEngineTableWriter struct {
 	se     *engine.SqlEngine
 	sqlCtx *sql.Context
 
-	tableName  string
-	database   string
-	contOnErr  bool
-	force      bool
-	disableFks bool
+	tableName     string
+	database      string
+	contOnErr     bool
+	force         bool
+	disableFks    bool
+	skipUnchanged bool
 
 	statsCB noms.StatsCB
 	stats   types.AppliedEditStats
 	statOps int32
+	// skipped is the number of rows left out because they match the existing rows
+	skipped int64
 
 	importOption       TableImportOp
 	tableSchema        sql.PrimaryKeySchema
@@ -118,11 +121,12 @@ func NewSqlEngineTableWriter(ctx context.Context, dEnv *env.DoltEnv, createTable
 	}
 
 	return &SqlEngineTableWriter{
-		se:         se,
-		sqlCtx:     sqlCtx,
-		contOnErr:  options.ContinueOnErr,
-		force:      options.Force,
-		disableFks: options.DisableFks,
+		se:            se,
+		sqlCtx:        sqlCtx,
+		contOnErr:     options.ContinueOnErr,
+		force:         options.Force,
+		disableFks:    options.DisableFks,
+		skipUnchanged: options.SkipUnchanged,
 
 		database:  dbName,
 		tableName: options.TableToWriteTo,
@@ -158,11 +162,12 @@ func NewSqlEngineTableWriterWithEngine(ctx *sql.Context, eng *sqle.Engine, db ds
 	}
 
 	return &SqlEngineTableWriter{
-		se:         engine.NewRebasedSqlEngine(eng, map[string]dsess.SqlDatabase{db.Name(): db}),
-		sqlCtx:     ctx,
-		contOnErr:  options.ContinueOnErr,
-		force:      options.Force,
-		disableFks: options.DisableFks,
+		se:            engine.NewRebasedSqlEngine(eng, map[string]dsess.SqlDatabase{db.Name(): db}),
+		sqlCtx:        ctx,
+		contOnErr:     options.ContinueOnErr,
+		force:         options.Force,
+		disableFks:    options.DisableFks,
+		skipUnchanged: options.SkipUnchanged,
 
 		database:  db.Name(),
 		tableName: options.TableToWriteTo,
@@ -219,6 +224,16 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		}
 	}
 
+	if s.skipUnchanged && s.importOption == UpdateOp {
+		filter, err := newUnchangedRowFilter(s.sqlCtx, s.database, s.tableName, s.rowOperationSchema.Schema)
+		if err != nil {
+			return err
+		}
+		if filter != nil {
+			inputChannel = filter.Filter(ctx, inputChannel, &s.skipped)
+		}
+	}
+
 	insertOrUpdateOperation, err := s.getInsertNode(inputChannel)
 	if err != nil {
 		return err
@@ -239,7 +254,7 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 	for {
 		if s.statsCB != nil && atomic.LoadInt32(&s.statOps) >= tableWriterStatUpdateRate {
 			atomic.StoreInt32(&s.statOps, 0)
-			s.statsCB(s.stats)
+			s.statsCB(s.currentStats())
 		}
 
 		row, err := iter.Next(s.sqlCtx)
@@ -252,7 +267,7 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 			atomic.LoadInt32(&s.statOps)
 			atomic.StoreInt32(&s.statOps, 0)
 			if s.statsCB != nil {
-				s.statsCB(s.stats)
+				s.statsCB(s.currentStats())
 			}
 
 			return err
@@ -273,6 +288,14 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 	}
 }
 
+// currentStats returns the stats of the rows written so far. Rows skipped because they match the existing rows count
+// as having had no effect.
+func (s *SqlEngineTableWriter) currentStats() types.AppliedEditStats {
+	stats := s.stats
+	stats.SameVal += atomic.LoadInt64(&s.skipped)
+	return stats
+}
+
 func (s *SqlEngineTableWriter) Commit(ctx context.Context) error {
 	_, _, err := s.se.Query(s.sqlCtx, "COMMIT")
 	return err
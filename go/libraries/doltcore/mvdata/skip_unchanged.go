@@ -0,0 +1,228 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// skipUnchangedBatchSize is the number of import rows whose keys are looked up together.
+const skipUnchangedBatchSize = 1024
+
+// unchangedRowFilter drops the rows of an update import that are identical to the rows already in the table, so
+// that loading the same data again doesn't rewrite them. Rows are compared with the table as it was when the import
+// started; a row whose key appeared earlier in the import is always written.
+type unchangedRowFilter struct {
+	rows prolly.Map
+	ns   tree.NodeStore
+	kb   *val.TupleBuilder
+
+	sch sql.Schema
+	// keyOrds are the ordinals in the import rows of the primary key columns, in key order
+	keyOrds []int
+	// valOrds are the ordinals in the import rows of the non primary key columns, or -1 for columns not imported
+	valOrds []int
+
+	written map[string]struct{}
+}
+
+// newUnchangedRowFilter returns a filter for the rows imported into |tableName|. It returns nil if the rows of the
+// table can't be compared, in which case every row is written.
+func newUnchangedRowFilter(ctx *sql.Context, dbName, tableName string, rowSch sql.Schema) (*unchangedRowFilter, error) {
+	roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, dbName)
+	if !ok {
+		return nil, nil
+	}
+	tbl, ok, err := roots.Working.GetTable(ctx, tableName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return nil, nil
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := durable.ProllyMapFromIndex(idx)
+
+	f := &unchangedRowFilter{
+		rows:    rows,
+		ns:      rows.NodeStore(),
+		kb:      val.NewTupleBuilder(rows.KeyDesc()),
+		sch:     rowSch,
+		written: make(map[string]struct{}),
+	}
+	for _, col := range sch.GetPKCols().GetColumns() {
+		ord := rowSch.IndexOfColName(col.Name)
+		if ord < 0 {
+			return nil, nil
+		}
+		f.keyOrds = append(f.keyOrds, ord)
+	}
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		f.valOrds = append(f.valOrds, rowSch.IndexOfColName(col.Name))
+	}
+	return f, nil
+}
+
+// Filter forwards the rows of |in| that aren't identical to the existing rows to the returned channel, adding the
+// number of rows dropped to |skipped|. Rows are read in batches, and the keys of a batch are looked up in order.
+func (f *unchangedRowFilter) Filter(ctx context.Context, in chan sql.Row, skipped *int64) chan sql.Row {
+	out := make(chan sql.Row)
+	go func() {
+		defer close(out)
+		batch := make([]sql.Row, 0, skipUnchangedBatchSize)
+		for {
+			row, ok := <-in
+			if ok {
+				batch = append(batch, row)
+				if len(batch) < skipUnchangedBatchSize {
+					continue
+				}
+			}
+
+			changed := f.changedRows(ctx, batch)
+			for i, row := range batch {
+				if !changed[i] {
+					atomic.AddInt64(skipped, 1)
+					continue
+				}
+				select {
+				case out <- row:
+				case <-ctx.Done():
+					return
+				}
+			}
+			batch = batch[:0]
+
+			if !ok {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// changedRows returns whether each row of |batch| differs from the existing row with its key.
+func (f *unchangedRowFilter) changedRows(ctx context.Context, batch []sql.Row) []bool {
+	changed := make([]bool, len(batch))
+	keys := make([]val.Tuple, len(batch))
+	order := make([]int, 0, len(batch))
+	for i, row := range batch {
+		key, err := f.keyTuple(ctx, row)
+		if err != nil {
+			// leave the row for the insert to report
+			changed[i] = true
+			continue
+		}
+		keys[i] = key
+		order = append(order, i)
+	}
+
+	kd := f.rows.KeyDesc()
+	sort.SliceStable(order, func(a, b int) bool {
+		return kd.Compare(keys[order[a]], keys[order[b]]) < 0
+	})
+
+	for _, i := range order {
+		if _, ok := f.written[string(keys[i])]; ok {
+			changed[i] = true
+			continue
+		}
+		var existing val.Tuple
+		err := f.rows.Get(ctx, keys[i], func(k, v val.Tuple) error {
+			if k != nil {
+				existing = v
+			}
+			return nil
+		})
+		if err != nil || existing == nil {
+			changed[i] = true
+		} else {
+			changed[i] = f.rowChanged(ctx, batch[i], existing)
+		}
+	}
+
+	// rows are written in the order of the import, so a later row with the same key can't be skipped
+	for i := range batch {
+		if changed[i] && keys[i] != nil {
+			f.written[string(keys[i])] = struct{}{}
+		}
+	}
+	return changed
+}
+
+func (f *unchangedRowFilter) keyTuple(ctx context.Context, row sql.Row) (val.Tuple, error) {
+	for i, ord := range f.keyOrds {
+		v, _, err := f.sch[ord].Type.Convert(row[ord])
+		if err == nil && v == nil {
+			err = fmt.Errorf("primary key column %s is null", f.sch[ord].Name)
+		}
+		if err != nil {
+			f.kb.Recycle()
+			return nil, err
+		}
+		if err = index.PutField(ctx, f.ns, f.kb, i, v); err != nil {
+			f.kb.Recycle()
+			return nil, err
+		}
+	}
+	return f.kb.Build(f.ns.Pool()), nil
+}
+
+// rowChanged returns whether any imported column of |row| has a value other than its value in |existing|.
+func (f *unchangedRowFilter) rowChanged(ctx context.Context, row sql.Row, existing val.Tuple) bool {
+	vd := f.rows.ValDesc()
+	for i, ord := range f.valOrds {
+		if ord < 0 {
+			continue
+		}
+		typ := f.sch[ord].Type
+		v, _, err := typ.Convert(row[ord])
+		if err != nil {
+			return true
+		}
+		old, err := index.GetField(ctx, vd, i, existing, f.ns)
+		if err != nil {
+			return true
+		}
+		if cmp, err := typ.Compare(old, v); err != nil || cmp != 0 {
+			return true
+		}
+	}
+	return false
+}
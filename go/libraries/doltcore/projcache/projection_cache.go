@@ -0,0 +1,288 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projcache caches the columns read by repeated scans of wide tables. A projection, the values of a few of
+// the columns of every row of a table, is kept in a columnar layout keyed by the hash of the table value, so it's
+// reused by every scan of those columns until the table changes.
+package projcache
+
+import (
+	"container/list"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Key identifies a cached projection: the columns, by tag, of the rows in the ordinal range [Start, End) of a table.
+type Key struct {
+	Table      hash.Hash
+	Columns    string
+	Start, End uint64
+}
+
+// NewKey returns the Key of the projection of the columns |tags| of the rows in [|start|, |end|) of the table value
+// whose hash is |table|.
+func NewKey(table hash.Hash, tags []uint64, start, end uint64) Key {
+	cols := make([]string, len(tags))
+	for i, t := range tags {
+		cols[i] = strconv.FormatUint(t, 10)
+	}
+	return Key{Table: table, Columns: strings.Join(cols, ","), Start: start, End: end}
+}
+
+// Stat describes the cached projections of some columns of a table.
+type Stat struct {
+	Database  string
+	Table     string
+	Columns   []string
+	TableHash hash.Hash
+	Rows      uint64
+	Bytes     uint64
+	Hits      uint64
+}
+
+type projection struct {
+	key       Key
+	db, table string
+	colNames  []string
+	columns   [][]interface{}
+	rows      int
+	size      uint64
+	hits      uint64
+	elem      *list.Element
+}
+
+// Cache is a size-bounded cache of projections, evicting the least recently used ones first. It is safe for
+// concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[Key]*projection
+	lru     *list.List
+	size    uint64
+}
+
+// New returns an empty Cache.
+func New() *Cache {
+	return &Cache{entries: make(map[Key]*projection), lru: list.New()}
+}
+
+// Get returns an iterator over the rows of the cached projection for |key|, or false if it isn't cached.
+func (c *Cache) Get(key Key) (sql.RowIter, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	p.hits++
+	c.lru.MoveToFront(p.elem)
+	return &columnarIter{p: p}, true
+}
+
+// Wrap returns an iterator over the rows of |iter|, which are the projection for |key| of the columns |colNames| of
+// |table| in |db|. The projection is cached once |iter| is exhausted, unless it's larger than |maxSize| bytes, and
+// projections are evicted until the cache holds no more than |maxSize| bytes.
+func (c *Cache) Wrap(key Key, db, table string, colNames []string, iter sql.RowIter, maxSize uint64) sql.RowIter {
+	p := &projection{
+		key:      key,
+		db:       db,
+		table:    table,
+		colNames: colNames,
+		columns:  make([][]interface{}, len(colNames)),
+	}
+	return &buildingIter{c: c, iter: iter, p: p, maxSize: maxSize}
+}
+
+// Clear evicts the cached projections of |table| in |db|, or of every table in |db| if |table| is empty, and returns
+// the number of projections evicted. Names are compared case-insensitively.
+func (c *Cache) Clear(db, table string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, p := range c.entries {
+		if !strings.EqualFold(p.db, db) || (table != "" && !strings.EqualFold(p.table, table)) {
+			continue
+		}
+		c.remove(p)
+		n++
+	}
+	return n
+}
+
+// Size returns the number of bytes held by the cache.
+func (c *Cache) Size() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Stats returns the cached projections of the tables in |db|, ordered by table and columns. The partitions of a
+// projection are combined; its hits are the most any partition has had.
+func (c *Cache) Stats(db string) []Stat {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	type statKey struct {
+		table   string
+		hash    hash.Hash
+		columns string
+	}
+	byKey := make(map[statKey]*Stat)
+	for _, p := range c.entries {
+		if !strings.EqualFold(p.db, db) {
+			continue
+		}
+		k := statKey{table: p.table, hash: p.key.Table, columns: p.key.Columns}
+		s, ok := byKey[k]
+		if !ok {
+			s = &Stat{Database: p.db, Table: p.table, Columns: p.colNames, TableHash: p.key.Table}
+			byKey[k] = s
+		}
+		s.Rows += uint64(p.rows)
+		s.Bytes += p.size
+		if p.hits > s.Hits {
+			s.Hits = p.hits
+		}
+	}
+
+	stats := make([]Stat, 0, len(byKey))
+	for _, s := range byKey {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Table != stats[j].Table {
+			return stats[i].Table < stats[j].Table
+		}
+		ci, cj := strings.Join(stats[i].Columns, ","), strings.Join(stats[j].Columns, ",")
+		if ci != cj {
+			return ci < cj
+		}
+		return stats[i].TableHash.Less(stats[j].TableHash)
+	})
+	return stats
+}
+
+func (c *Cache) put(p *projection, maxSize uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p.size > maxSize {
+		return
+	}
+	if old, ok := c.entries[p.key]; ok {
+		c.remove(old)
+	}
+	for c.size+p.size > maxSize && c.lru.Len() > 0 {
+		c.remove(c.lru.Back().Value.(*projection))
+	}
+	p.elem = c.lru.PushFront(p)
+	c.entries[p.key] = p
+	c.size += p.size
+}
+
+func (c *Cache) remove(p *projection) {
+	c.lru.Remove(p.elem)
+	delete(c.entries, p.key)
+	c.size -= p.size
+}
+
+// valueSize estimates the memory used by |v| in a column.
+func valueSize(v interface{}) uint64 {
+	switch v := v.(type) {
+	case string:
+		return 16 + uint64(len(v))
+	case []byte:
+		return 40 + uint64(len(v))
+	default:
+		return 16
+	}
+}
+
+// buildingIter returns the rows of a projection while collecting them into columns.
+type buildingIter struct {
+	c       *Cache
+	iter    sql.RowIter
+	p       *projection
+	maxSize uint64
+	// abandoned is set once the projection has grown too large to be cached
+	abandoned bool
+}
+
+var _ sql.RowIter = (*buildingIter)(nil)
+
+func (b *buildingIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := b.iter.Next(ctx)
+	if err == io.EOF {
+		if !b.abandoned {
+			b.c.put(b.p, b.maxSize)
+			b.abandoned = true
+		}
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !b.abandoned {
+		for i, v := range r {
+			if bs, ok := v.([]byte); ok {
+				// rows can share their buffers with the chunks they were read from
+				v = append([]byte(nil), bs...)
+			}
+			b.p.columns[i] = append(b.p.columns[i], v)
+			b.p.size += valueSize(v)
+		}
+		b.p.rows++
+		if b.p.size > b.maxSize {
+			b.abandoned = true
+			b.p.columns = nil
+		}
+	}
+	return r, nil
+}
+
+func (b *buildingIter) Close(ctx *sql.Context) error {
+	return b.iter.Close(ctx)
+}
+
+// columnarIter returns the rows of a cached projection.
+type columnarIter struct {
+	p *projection
+	i int
+}
+
+var _ sql.RowIter = (*columnarIter)(nil)
+
+func (c *columnarIter) Next(*sql.Context) (sql.Row, error) {
+	if c.i >= c.p.rows {
+		return nil, io.EOF
+	}
+	r := make(sql.Row, len(c.p.columns))
+	for j, col := range c.p.columns {
+		r[j] = col[c.i]
+	}
+	c.i++
+	return r, nil
+}
+
+func (c *columnarIter) Close(*sql.Context) error {
+	return nil
+}
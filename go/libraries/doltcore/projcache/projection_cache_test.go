@@ -0,0 +1,99 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projcache
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func drain(t *testing.T, iter sql.RowIter) []sql.Row {
+	ctx := sql.NewEmptyContext()
+	rows, err := sql.RowIterToRows(ctx, nil, iter)
+	require.NoError(t, err)
+	return rows
+}
+
+func TestCacheGetAndWrap(t *testing.T) {
+	c := New()
+	h := hash.Of([]byte("table"))
+	key := NewKey(h, []uint64{1, 3}, 0, 3)
+	rows := []sql.Row{{int64(1), "one"}, {int64(2), nil}, {int64(3), []byte("three")}}
+
+	_, ok := c.Get(key)
+	require.False(t, ok)
+
+	// a partially read projection isn't cached
+	iter := c.Wrap(key, "db", "t", []string{"a", "c"}, sql.RowsToRowIter(rows...), 1<<20)
+	_, err := iter.Next(sql.NewEmptyContext())
+	require.NoError(t, err)
+	require.NoError(t, iter.Close(sql.NewEmptyContext()))
+	_, ok = c.Get(key)
+	require.False(t, ok)
+
+	assert.Equal(t, rows, drain(t, c.Wrap(key, "db", "t", []string{"a", "c"}, sql.RowsToRowIter(rows...), 1<<20)))
+	cached, ok := c.Get(key)
+	require.True(t, ok)
+	assert.Equal(t, rows, drain(t, cached))
+	assert.NotZero(t, c.Size())
+
+	stats := c.Stats("DB")
+	require.Len(t, stats, 1)
+	assert.Equal(t, Stat{Database: "db", Table: "t", Columns: []string{"a", "c"}, TableHash: h, Rows: 3, Bytes: c.Size(), Hits: 1}, stats[0])
+	assert.Empty(t, c.Stats("other"))
+
+	assert.Equal(t, 0, c.Clear("db", "other"))
+	assert.Equal(t, 1, c.Clear("db", "T"))
+	_, ok = c.Get(key)
+	assert.False(t, ok)
+	assert.Zero(t, c.Size())
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := New()
+	rows := []sql.Row{{"aaaaaaaaaaaaaaaa"}, {"bbbbbbbbbbbbbbbb"}}
+	size := 2 * valueSize("aaaaaaaaaaaaaaaa")
+	keys := []Key{
+		NewKey(hash.Of([]byte("1")), []uint64{1}, 0, 2),
+		NewKey(hash.Of([]byte("2")), []uint64{1}, 0, 2),
+		NewKey(hash.Of([]byte("3")), []uint64{1}, 0, 2),
+	}
+
+	drain(t, c.Wrap(keys[0], "db", "t", []string{"a"}, sql.RowsToRowIter(rows...), 2*size))
+	drain(t, c.Wrap(keys[1], "db", "t", []string{"a"}, sql.RowsToRowIter(rows...), 2*size))
+	// keys[0] becomes the most recently used
+	_, ok := c.Get(keys[0])
+	require.True(t, ok)
+	drain(t, c.Wrap(keys[2], "db", "t", []string{"a"}, sql.RowsToRowIter(rows...), 2*size))
+
+	_, ok = c.Get(keys[0])
+	assert.True(t, ok)
+	_, ok = c.Get(keys[1])
+	assert.False(t, ok)
+	_, ok = c.Get(keys[2])
+	assert.True(t, ok)
+	assert.Equal(t, 2*size, c.Size())
+
+	// projections larger than the cache are never cached
+	big := NewKey(hash.Of([]byte("big")), []uint64{1}, 0, 2)
+	assert.Equal(t, rows, drain(t, c.Wrap(big, "db", "t", []string{"a"}, sql.RowsToRowIter(rows...), size-1)))
+	_, ok = c.Get(big)
+	assert.False(t, ok)
+}
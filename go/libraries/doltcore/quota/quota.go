@@ -0,0 +1,287 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota enforces per-database resource quotas, so that a single sql-server can host the databases of many
+// tenants without one of them starving the others. Each quota has a soft limit, past which operations succeed with a
+// warning, and a hard limit, past which they fail.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// The resources that can be limited by a quota.
+const (
+	// DiskBytes is the size on disk of a database's .dolt directory.
+	DiskBytes = "disk_bytes"
+	// Branches is the number of branches of a database.
+	Branches = "branches"
+	// Connections is the number of connections whose current database is the database.
+	Connections = "connections"
+	// BackgroundCPU is the number of seconds spent running background jobs for a database, such as garbage collection,
+	// over the last BackgroundWindow.
+	BackgroundCPU = "background_cpu_seconds"
+)
+
+// The states of a Status.
+const (
+	StateOK       = "ok"
+	StateWarning  = "warning"
+	StateExceeded = "exceeded"
+)
+
+// AllDatabases is the database name whose Limits apply to every database that has no Limits of its own.
+const AllDatabases = "*"
+
+// MonitorInterval is how often a running server measures the disk usage of its databases.
+const MonitorInterval = 10 * time.Second
+
+// BackgroundWindow is the period over which background job time is counted against the BackgroundCPU quota.
+const BackgroundWindow = time.Hour
+
+// WarningCode is the code of the warnings reported for operations past a soft limit. It is the code of MySQL's
+// ER_USER_LIMIT_REACHED.
+const WarningCode = 1226
+
+// ErrQuotaExceeded is returned for operations that would take a database past the hard limit of one of its quotas.
+var ErrQuotaExceeded = errors.NewKind("database %s is over its %s quota: usage of %d is more than the hard limit of %d")
+
+// Limit is the soft and hard limit of a single quota. A zero limit is no limit.
+type Limit struct {
+	Soft uint64
+	Hard uint64
+}
+
+// IsSet returns whether either limit of |l| is set.
+func (l Limit) IsSet() bool {
+	return l.Soft != 0 || l.Hard != 0
+}
+
+// Limits are the quotas of a single database.
+type Limits struct {
+	DiskBytes     Limit
+	Branches      Limit
+	Connections   Limit
+	BackgroundCPU Limit
+}
+
+func (l Limits) forResource(resource string) Limit {
+	switch resource {
+	case DiskBytes:
+		return l.DiskBytes
+	case Branches:
+		return l.Branches
+	case Connections:
+		return l.Connections
+	case BackgroundCPU:
+		return l.BackgroundCPU
+	default:
+		panic(fmt.Sprintf("unknown quota resource %s", resource))
+	}
+}
+
+// Status is the usage of a single resource by a database, compared to its quota.
+type Status struct {
+	Resource string
+	Usage    uint64
+	Limit    Limit
+}
+
+// State returns StateExceeded if the usage of |s| is past its hard limit, StateWarning if it's past its soft limit,
+// and StateOK otherwise.
+func (s Status) State() string {
+	if s.Limit.Hard != 0 && s.Usage > s.Limit.Hard {
+		return StateExceeded
+	} else if s.Limit.Soft != 0 && s.Usage > s.Limit.Soft {
+		return StateWarning
+	}
+	return StateOK
+}
+
+type usage struct {
+	diskBytes   uint64
+	cpu         time.Duration
+	windowStart time.Time
+}
+
+// Controller holds the quotas of the databases of a server, and the usage of the resources that are measured rather
+// than counted on demand. It is safe for concurrent use.
+type Controller struct {
+	mu     sync.Mutex
+	limits map[string]Limits
+	usage  map[string]*usage
+	now    func() time.Time
+}
+
+// NewController returns a Controller enforcing |limits|, keyed by database name. The limits keyed by AllDatabases
+// apply to every other database.
+func NewController(limits map[string]Limits) *Controller {
+	c := &Controller{
+		limits: make(map[string]Limits, len(limits)),
+		usage:  make(map[string]*usage),
+		now:    time.Now,
+	}
+	for db, l := range limits {
+		c.limits[strings.ToLower(db)] = l
+	}
+	return c
+}
+
+// Limits returns the quotas of the database named |db|.
+func (c *Controller) Limits(db string) Limits {
+	if l, ok := c.limits[strings.ToLower(db)]; ok {
+		return l
+	}
+	return c.limits[AllDatabases]
+}
+
+// Check checks |used|, the usage of |resource| by |db| an operation would result in, against its quota. It returns
+// ErrQuotaExceeded if |used| is past the hard limit, and a warning message to report if it's past the soft limit.
+func (c *Controller) Check(db, resource string, used uint64) (string, error) {
+	s := Status{Resource: resource, Usage: used, Limit: c.Limits(db).forResource(resource)}
+	switch s.State() {
+	case StateExceeded:
+		return "", ErrQuotaExceeded.New(db, resource, used, s.Limit.Hard)
+	case StateWarning:
+		return fmt.Sprintf("database %s is over its %s quota: usage of %d is more than the soft limit of %d", db, resource, used, s.Limit.Soft), nil
+	default:
+		return "", nil
+	}
+}
+
+// SetDiskUsage records that the .dolt directory of |db| takes up |bytes| on disk.
+func (c *Controller) SetDiskUsage(db string, bytes uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usageFor(db).diskBytes = bytes
+}
+
+// DiskUsage returns the disk usage of |db| as of the last call to SetDiskUsage.
+func (c *Controller) DiskUsage(db string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.usageFor(db).diskBytes
+}
+
+// BackgroundUsage returns the time spent running background jobs for |db| in the current BackgroundWindow.
+func (c *Controller) BackgroundUsage(db string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.usageFor(db).cpu
+}
+
+// StartBackgroundJob checks the BackgroundCPU quota of |db| before a background job for it runs. If the job may run,
+// it returns a warning message to report if |db| is past its soft limit, and a function to call when the job is done,
+// which counts the time it ran against the quota.
+func (c *Controller) StartBackgroundJob(db string) (func(), string, error) {
+	warning, err := c.Check(db, BackgroundCPU, uint64(c.BackgroundUsage(db).Seconds()))
+	if err != nil {
+		return nil, "", err
+	}
+
+	start := c.now()
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.usageFor(db).cpu += c.now().Sub(start)
+	}, warning, nil
+}
+
+// Release forgets the usage of |db|, e.g. when it's dropped.
+func (c *Controller) Release(db string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.usage, strings.ToLower(db))
+}
+
+// Status returns the usage of every resource by |db| compared to its quotas. Branch and connection counts aren't kept
+// by the Controller, and are given as |branches| and |connections|.
+func (c *Controller) Status(db string, branches, connections uint64) []Status {
+	l := c.Limits(db)
+	c.mu.Lock()
+	u := *c.usageFor(db)
+	c.mu.Unlock()
+
+	return []Status{
+		{Resource: DiskBytes, Usage: u.diskBytes, Limit: l.DiskBytes},
+		{Resource: Branches, Usage: branches, Limit: l.Branches},
+		{Resource: Connections, Usage: connections, Limit: l.Connections},
+		{Resource: BackgroundCPU, Usage: uint64(u.cpu.Seconds()), Limit: l.BackgroundCPU},
+	}
+}
+
+// usageFor returns the usage of |db|, starting a new BackgroundWindow if the last one is over. Callers must hold |mu|.
+func (c *Controller) usageFor(db string) *usage {
+	key := strings.ToLower(db)
+	u, ok := c.usage[key]
+	if !ok {
+		u = &usage{windowStart: c.now()}
+		c.usage[key] = u
+	}
+	if c.now().Sub(u.windowStart) >= BackgroundWindow {
+		u.cpu = 0
+		u.windowStart = c.now()
+	}
+	return u
+}
+
+// MeasureDiskUsage returns the size of the files in the .dolt directory of the database rooted at |fs|.
+func MeasureDiskUsage(fs filesys.Filesys) (uint64, error) {
+	var total uint64
+	err := fs.Iter(dbfactory.DoltDir, true, func(path string, size int64, isDir bool) (stop bool) {
+		if !isDir {
+			total += uint64(size)
+		}
+		return false
+	})
+	return total, err
+}
+
+// RunMonitor measures the disk usage of |dbs|, keyed by database name, every |interval| until |ctx| is done, and
+// records it in |c|.
+func RunMonitor(ctx context.Context, interval time.Duration, c *Controller, dbs func() map[string]filesys.Filesys) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	measureAll := func() {
+		for db, fs := range dbs() {
+			if fs == nil {
+				continue
+			}
+			// a database that can't be measured keeps its last measurement until the next tick
+			if bytes, err := MeasureDiskUsage(fs); err == nil {
+				c.SetDiskUsage(db, bytes)
+			}
+		}
+	}
+
+	measureAll()
+	for {
+		select {
+		case <-ticker.C:
+			measureAll()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
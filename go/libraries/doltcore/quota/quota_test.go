@@ -0,0 +1,114 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestControllerCheck(t *testing.T) {
+	c := NewController(map[string]Limits{
+		"Tenant":     {Branches: Limit{Soft: 2, Hard: 3}},
+		AllDatabases: {Branches: Limit{Hard: 10}},
+	})
+
+	warning, err := c.Check("tenant", Branches, 2)
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+
+	warning, err = c.Check("tenant", Branches, 3)
+	require.NoError(t, err)
+	assert.Contains(t, warning, "soft limit of 2")
+
+	_, err = c.Check("tenant", Branches, 4)
+	require.Error(t, err)
+	assert.True(t, ErrQuotaExceeded.Is(err))
+
+	// databases without limits of their own get the default ones
+	_, err = c.Check("other", Branches, 10)
+	require.NoError(t, err)
+	_, err = c.Check("other", Branches, 11)
+	assert.True(t, ErrQuotaExceeded.Is(err))
+
+	// and unlimited resources are never exceeded
+	warning, err = c.Check("tenant", Connections, 1000)
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+}
+
+func TestControllerBackgroundJobs(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewController(map[string]Limits{"db": {BackgroundCPU: Limit{Soft: 60, Hard: 120}}})
+	c.now = func() time.Time { return now }
+
+	done, warning, err := c.StartBackgroundJob("db")
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+	now = now.Add(90 * time.Second)
+	done()
+	assert.Equal(t, 90*time.Second, c.BackgroundUsage("db"))
+
+	done, warning, err = c.StartBackgroundJob("db")
+	require.NoError(t, err)
+	assert.NotEmpty(t, warning)
+	now = now.Add(time.Minute)
+	done()
+
+	_, _, err = c.StartBackgroundJob("db")
+	assert.True(t, ErrQuotaExceeded.Is(err))
+
+	// usage is forgotten once the window is over
+	now = now.Add(BackgroundWindow)
+	assert.Zero(t, c.BackgroundUsage("db"))
+	_, _, err = c.StartBackgroundJob("db")
+	require.NoError(t, err)
+}
+
+func TestControllerStatus(t *testing.T) {
+	c := NewController(map[string]Limits{"db": {DiskBytes: Limit{Soft: 10, Hard: 20}, Connections: Limit{Hard: 1}}})
+	c.SetDiskUsage("db", 15)
+
+	statuses := c.Status("db", 3, 2)
+	require.Len(t, statuses, 4)
+	assert.Equal(t, Status{Resource: DiskBytes, Usage: 15, Limit: Limit{Soft: 10, Hard: 20}}, statuses[0])
+	assert.Equal(t, StateWarning, statuses[0].State())
+	assert.Equal(t, StateOK, statuses[1].State())
+	assert.Equal(t, StateExceeded, statuses[2].State())
+	assert.Equal(t, StateOK, statuses[3].State())
+
+	c.Release("db")
+	assert.Zero(t, c.DiskUsage("db"))
+}
+
+func TestMeasureDiskUsage(t *testing.T) {
+	noms := filepath.Join("/db", dbfactory.DoltDir, dbfactory.DataDir)
+	fs := filesys.NewInMemFS([]string{noms}, map[string][]byte{
+		filepath.Join(noms, "manifest"): make([]byte, 10),
+		filepath.Join(noms, "table"):    make([]byte, 100),
+		"/db/outside":                   make([]byte, 1000),
+	}, "/db")
+
+	bytes, err := MeasureDiskUsage(fs)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(110), bytes)
+}
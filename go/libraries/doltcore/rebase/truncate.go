@@ -0,0 +1,202 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrNothingToTruncate is returned from TruncateHistory when no branch, tag or remote ref has the graft commit in its
+// history.
+var ErrNothingToTruncate = errors.New("no ref has the commit in its history")
+
+// TruncateHistory rewrites the history of every branch, tag and remote ref that has |graft| in its history so that
+// |graft| becomes a root commit, and returns the refs it rewrote. Parents of rewritten merge commits that don't have
+// |graft| in their history are dropped, so that none of the history before |graft| stays reachable from the
+// rewritten refs. The root values and metadata of the rewritten commits are unchanged. Refs that don't have |graft| in
+// their history are left as they are.
+func TruncateHistory(ctx context.Context, dEnv *env.DoltEnv, graft *doltdb.Commit) ([]ref.DoltRef, error) {
+	ddb := dEnv.DoltDB
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := ddb.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := ddb.GetRemoteRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	graftHash, err := graft.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	graftHeight, err := graft.Height()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := graft.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := graft.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, valueHash, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	newGraft, err := ddb.CommitDanglingWithParentCommits(ctx, valueHash, nil, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	t := truncater{
+		ddb:         ddb,
+		graftHash:   graftHash,
+		graftHeight: graftHeight,
+		rewritten:   map[hash.Hash]*doltdb.Commit{graftHash: newGraft},
+		kept:        make(hash.HashSet),
+	}
+
+	var truncated []ref.DoltRef
+	for _, r := range append(append(branches, tags...), remotes...) {
+		head, err := ddb.ResolveCommitRef(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		newHead, ok, err := t.truncate(ctx, head)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		switch dRef := r.(type) {
+		case ref.BranchRef:
+			err = ddb.NewBranchAtCommit(ctx, dRef, newHead)
+		case ref.TagRef:
+			var tag *doltdb.Tag
+			if tag, err = ddb.ResolveTag(ctx, dRef); err != nil {
+				return nil, err
+			}
+			if err = ddb.DeleteTag(ctx, dRef); err != nil {
+				return nil, err
+			}
+			err = ddb.NewTagAtCommit(ctx, dRef, newHead, tag.Meta)
+		case ref.RemoteRef:
+			var h hash.Hash
+			if h, err = newHead.HashOf(); err != nil {
+				return nil, err
+			}
+			err = ddb.SetHead(ctx, dRef, h)
+		default:
+			err = fmt.Errorf("cannot truncate the history of ref: %s", ref.String(dRef))
+		}
+		if err != nil {
+			return nil, err
+		}
+		truncated = append(truncated, r)
+	}
+
+	if len(truncated) == 0 {
+		return nil, ErrNothingToTruncate
+	}
+	return truncated, nil
+}
+
+// truncater rewrites commits that have the graft commit in their history. |rewritten| maps the commits rewritten so
+// far to their rewrites, and |kept| holds the commits found not to have the graft commit in their history.
+type truncater struct {
+	ddb         *doltdb.DoltDB
+	graftHash   hash.Hash
+	graftHeight uint64
+	rewritten   map[hash.Hash]*doltdb.Commit
+	kept        hash.HashSet
+}
+
+// truncate returns the rewrite of |commit| and true if it has the graft commit in its history, and false otherwise.
+func (t truncater) truncate(ctx context.Context, commit *doltdb.Commit) (*doltdb.Commit, bool, error) {
+	h, err := commit.HashOf()
+	if err != nil {
+		return nil, false, err
+	}
+	if rc, ok := t.rewritten[h]; ok {
+		return rc, true, nil
+	}
+	if t.kept.Has(h) {
+		return nil, false, nil
+	}
+
+	// commits no higher than the graft commit can't have it in their history
+	height, err := commit.Height()
+	if err != nil {
+		return nil, false, err
+	}
+	if height <= t.graftHeight {
+		t.kept.Insert(h)
+		return nil, false, nil
+	}
+
+	parents, err := t.ddb.ResolveAllParents(ctx, commit)
+	if err != nil {
+		return nil, false, err
+	}
+	var newParents []*doltdb.Commit
+	for _, p := range parents {
+		rp, ok, err := t.truncate(ctx, p)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			newParents = append(newParents, rp)
+		}
+	}
+	if len(newParents) == 0 {
+		t.kept.Insert(h)
+		return nil, false, nil
+	}
+
+	meta, err := commit.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	root, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	_, valueHash, err := t.ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return nil, false, err
+	}
+	rc, err := t.ddb.CommitDanglingWithParentCommits(ctx, valueHash, newParents, meta)
+	if err != nil {
+		return nil, false, err
+	}
+	t.rewritten[h] = rc
+	return rc, true, nil
+}
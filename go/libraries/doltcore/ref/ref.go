@@ -18,6 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 // ErrUnknownRefType is the error returned when parsing a ref in the format refs/type/... where type is unknown
@@ -75,10 +77,13 @@ func PrefixForType(refType RefType) string {
 
 type UpdateMode struct {
 	Force bool
+	// Lease, if not nil, is the head a forced update expects the ref to have. The update is only made if the ref
+	// hasn't moved from it, and the empty hash means the ref must not exist.
+	Lease *hash.Hash
 }
 
-var ForceUpdate = UpdateMode{true}
-var FastForwardOnly = UpdateMode{false}
+var ForceUpdate = UpdateMode{Force: true}
+var FastForwardOnly = UpdateMode{Force: false}
 
 // DoltRef is a reference to a commit.
 type DoltRef interface {
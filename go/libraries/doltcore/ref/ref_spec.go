@@ -41,6 +41,10 @@ type RefSpec interface {
 	// DestRef will take a source reference and return a reference to what shat should be used for the destination
 	// reference of an operation involving a reference spec.
 	DestRef(srcRef DoltRef) DoltRef
+
+	// Forced returns whether the refspec is prefixed with a '+', which allows the refs it maps to be updated even when
+	// the update isn't a fast-forward.
+	Forced() bool
 }
 
 // RemoteRefSpec is an interface that embeds the RefSpec interface and provides an additional method to get the name
@@ -57,8 +61,42 @@ func ParseRefSpec(refSpecStr string) (RefSpec, error) {
 }
 
 // ParseRefSpecForRemote takes the name of a remote and a refspec, and parses that refspec, verifying that it refers
-// to the appropriate remote
+// to the appropriate remote. A refspec prefixed with a '+' is forced, and one prefixed with a '^' is a negative
+// refspec. The source and destination of a refspec may both be patterns with a single '*'.
 func ParseRefSpecForRemote(remote, refSpecStr string) (RefSpec, error) {
+	if strings.HasPrefix(refSpecStr, "^") {
+		return newNegativeRefSpec(remote, refSpecStr[1:])
+	}
+
+	force := strings.HasPrefix(refSpecStr, "+")
+	if force {
+		refSpecStr = refSpecStr[1:]
+		if strings.HasPrefix(refSpecStr, "^") {
+			return nil, ErrInvalidRefSpec
+		}
+	}
+
+	rs, err := parseRefSpecForRemote(remote, refSpecStr)
+	if err != nil || !force {
+		return rs, err
+	}
+
+	switch rs := rs.(type) {
+	case BranchToBranchRefSpec:
+		rs.force = true
+		return rs, nil
+	case TagToTagRefSpec:
+		rs.force = true
+		return rs, nil
+	case BranchToTrackingBranchRefSpec:
+		rs.force = true
+		return rs, nil
+	}
+
+	return rs, nil
+}
+
+func parseRefSpecForRemote(remote, refSpecStr string) (RefSpec, error) {
 	var fromRef DoltRef
 	var toRef DoltRef
 	var err error
@@ -103,14 +141,67 @@ func ParseRefSpecForRemote(remote, refSpecStr string) (RefSpec, error) {
 	if fromRef.GetType() == BranchRefType && toRef.GetType() == RemoteRefType {
 		return newLocalToRemoteTrackingRef(remote, fromRef.(BranchRef), toRef.(RemoteRef))
 	} else if fromRef.GetType() == BranchRefType && toRef.GetType() == BranchRefType {
+		if !validPatterns(fromRef, toRef) {
+			return nil, ErrInvalidRefSpec
+		}
 		return NewBranchToBranchRefSpec(fromRef.(BranchRef), toRef.(BranchRef))
 	} else if fromRef.GetType() == TagRefType && toRef.GetType() == TagRefType {
+		if !validPatterns(fromRef, toRef) {
+			return nil, ErrInvalidRefSpec
+		}
 		return NewTagToTagRefSpec(fromRef.(TagRef), toRef.(TagRef))
 	}
 
 	return nil, ErrUnsupportedMapping
 }
 
+// validPatterns returns whether |src| and |dest| are both refs, or both patterns with a single '*'.
+func validPatterns(src, dest DoltRef) bool {
+	srcWCs := strings.Count(src.GetPath(), "*")
+	return srcWCs <= 1 && srcWCs == strings.Count(dest.GetPath(), "*")
+}
+
+// mapRefPath maps |path| to the path of a destination ref if it matches |srcPath|. If |srcPath| is a pattern, the part
+// of |path| matched by its '*' replaces the '*' of |destPath|.
+func mapRefPath(srcPath, destPath, path string) (string, bool) {
+	if !strings.Contains(srcPath, "*") {
+		return destPath, path == srcPath
+	}
+
+	captured, ok := newWildcardPattern(srcPath).matches(path)
+	if !ok {
+		return "", false
+	}
+	return newWildcardBranchMapper(destPath).mapBranch(captured), true
+}
+
+// IsPattern returns whether |rs| maps every ref matching a pattern with a '*', rather than a single ref.
+func IsPattern(rs RefSpec) bool {
+	switch rs := rs.(type) {
+	case BranchToBranchRefSpec:
+		return strings.Contains(rs.srcRef.GetPath(), "*")
+	case TagToTagRefSpec:
+		return strings.Contains(rs.srcRef.GetPath(), "*")
+	case BranchToTrackingBranchRefSpec:
+		_, ok := rs.localPattern.(wcPattern)
+		return ok
+	case NegativeRefSpec:
+		_, ok := rs.pattern.(wcPattern)
+		return ok
+	}
+	return false
+}
+
+// IsExcluded returns whether |r| is excluded by any of the negative refspecs in |refSpecs|.
+func IsExcluded(r DoltRef, refSpecs []RemoteRefSpec) bool {
+	for _, rs := range refSpecs {
+		if neg, ok := rs.(NegativeRefSpec); ok && neg.Excludes(r) {
+			return true
+		}
+	}
+	return false
+}
+
 type branchMapper interface {
 	mapBranch(string) string
 }
@@ -140,10 +231,12 @@ func (wcbm wcBranchMapper) mapBranch(s string) string {
 	return wcbm.prefix + s + wcbm.suffix
 }
 
-// BranchToBranchRefSpec maps one branch to another.
+// BranchToBranchRefSpec maps one branch to another, or the branches matching a pattern to the branches of another
+// pattern.
 type BranchToBranchRefSpec struct {
 	srcRef  DoltRef
 	destRef DoltRef
+	force   bool
 }
 
 // NewBranchToBranchRefSpec takes a source and destination BranchRef and returns a RefSpec that maps source to dest.
@@ -166,12 +259,25 @@ func (rs BranchToBranchRefSpec) DestRef(r DoltRef) DoltRef {
 		return rs.destRef
 	}
 
+	if r != nil && r.GetType() == BranchRefType && strings.Contains(rs.srcRef.GetPath(), "*") {
+		if path, ok := mapRefPath(rs.srcRef.GetPath(), rs.destRef.GetPath(), r.GetPath()); ok {
+			return NewBranchRef(path)
+		}
+	}
+
 	return nil
 }
 
+// Forced returns whether the refspec is prefixed with a '+'.
+func (rs BranchToBranchRefSpec) Forced() bool {
+	return rs.force
+}
+
+// TagToTagRefSpec maps one tag to another, or the tags matching a pattern to the tags of another pattern.
 type TagToTagRefSpec struct {
 	srcRef  DoltRef
 	destRef DoltRef
+	force   bool
 }
 
 // NewTagToTagRefSpec takes a source and destination TagRef and returns a RefSpec that maps source to dest.
@@ -194,9 +300,20 @@ func (rs TagToTagRefSpec) DestRef(r DoltRef) DoltRef {
 		return rs.destRef
 	}
 
+	if r != nil && r.GetType() == TagRefType && strings.Contains(rs.srcRef.GetPath(), "*") {
+		if path, ok := mapRefPath(rs.srcRef.GetPath(), rs.destRef.GetPath(), r.GetPath()); ok {
+			return NewTagRef(path)
+		}
+	}
+
 	return nil
 }
 
+// Forced returns whether the refspec is prefixed with a '+'.
+func (rs TagToTagRefSpec) Forced() bool {
+	return rs.force
+}
+
 // BranchToTrackingBranchRefSpec maps a branch to the branch that should be tracking it
 type BranchToTrackingBranchRefSpec struct {
 	localPattern  pattern
@@ -204,6 +321,7 @@ type BranchToTrackingBranchRefSpec struct {
 	remote        string
 	localToRemRef branchMapper
 	remRefToLocal branchMapper
+	force         bool
 }
 
 func newLocalToRemoteTrackingRef(remote string, srcRef BranchRef, destRef RemoteRef) (RefSpec, error) {
@@ -277,6 +395,11 @@ func (rs BranchToTrackingBranchRefSpec) DestRef(branchRef DoltRef) DoltRef {
 	return nil
 }
 
+// Forced returns whether the refspec is prefixed with a '+'.
+func (rs BranchToTrackingBranchRefSpec) Forced() bool {
+	return rs.force
+}
+
 // GetRemote returns the name of the remote being operated on.
 func (rs BranchToTrackingBranchRefSpec) GetRemote() string {
 	return rs.remote
@@ -286,3 +409,76 @@ func (rs BranchToTrackingBranchRefSpec) GetRemote() string {
 func (rs BranchToTrackingBranchRefSpec) GetRemRefToLocal() branchMapper {
 	return rs.remRefToLocal
 }
+
+// NegativeRefSpec is a refspec prefixed with a '^'. It doesn't map any refs, and instead excludes the branches or tags
+// matching its pattern from the refs mapped by the refspecs it's used with.
+type NegativeRefSpec struct {
+	refType RefType
+	pattern pattern
+	remote  string
+	str     string
+}
+
+func newNegativeRefSpec(remote, refSpecStr string) (RefSpec, error) {
+	if len(refSpecStr) == 0 || strings.Contains(refSpecStr, ":") || strings.Count(refSpecStr, "*") > 1 {
+		return nil, ErrInvalidRefSpec
+	}
+
+	r, err := Parse(refSpecStr)
+	if err != nil {
+		return nil, ErrInvalidRefSpec
+	} else if r.GetType() != BranchRefType && r.GetType() != TagRefType {
+		return nil, ErrUnsupportedMapping
+	}
+
+	var p pattern = strPattern(r.GetPath())
+	if strings.Contains(r.GetPath(), "*") {
+		p = newWildcardPattern(r.GetPath())
+	}
+
+	return NegativeRefSpec{
+		refType: r.GetType(),
+		pattern: p,
+		remote:  remote,
+		str:     r.String(),
+	}, nil
+}
+
+// SrcRef always returns nil, as a negative refspec doesn't map any refs.
+func (rs NegativeRefSpec) SrcRef(_ DoltRef) DoltRef {
+	return nil
+}
+
+// DestRef always returns nil, as a negative refspec doesn't map any refs.
+func (rs NegativeRefSpec) DestRef(_ DoltRef) DoltRef {
+	return nil
+}
+
+// Forced always returns false, as a negative refspec can't be forced.
+func (rs NegativeRefSpec) Forced() bool {
+	return false
+}
+
+// GetRemote returns the name of the remote the refspec was parsed for.
+func (rs NegativeRefSpec) GetRemote() string {
+	return rs.remote
+}
+
+// GetRemRefToLocal returns the pattern of the refs the refspec excludes.
+func (rs NegativeRefSpec) GetRemRefToLocal() branchMapper {
+	return identityBranchMapper(rs.str)
+}
+
+// Excludes returns whether |r| matches the pattern of the refspec.
+func (rs NegativeRefSpec) Excludes(r DoltRef) bool {
+	if r == nil || r.GetType() != rs.refType {
+		return false
+	}
+	_, matches := rs.pattern.matches(r.GetPath())
+	return matches
+}
+
+// String returns the refspec, with its '^' prefix.
+func (rs NegativeRefSpec) String() string {
+	return "^" + rs.str
+}
@@ -85,6 +85,59 @@ func TestRefSpec(t *testing.T) {
 			"refs/heads/*/*:refs/remotes/origin/*/*",
 			false,
 			nil,
+		}, {
+			"origin",
+			"+refs/heads/*:refs/remotes/origin/*",
+			true,
+			map[string]string{
+				"refs/heads/main":    "refs/remotes/origin/main",
+				"refs/heads/feature": "refs/remotes/origin/feature",
+			},
+		}, {
+			"",
+			"refs/heads/feature/*:refs/heads/mirror/*",
+			true,
+			map[string]string{
+				"refs/heads/feature/a": "refs/heads/mirror/a",
+				"refs/heads/main":      "refs/nil/",
+				"refs/tags/feature/a":  "refs/nil/",
+			},
+		}, {
+			"",
+			"refs/tags/v*:refs/tags/release-*",
+			true,
+			map[string]string{
+				"refs/tags/v1.0":  "refs/tags/release-1.0",
+				"refs/heads/v1.0": "refs/nil/",
+			},
+		}, {
+			"",
+			"^refs/heads/wip/*",
+			true,
+			map[string]string{
+				"refs/heads/wip/a": "refs/nil/",
+				"refs/heads/main":  "refs/nil/",
+			},
+		}, {
+			"",
+			"refs/heads/*:refs/heads/main",
+			false,
+			nil,
+		}, {
+			"",
+			"^refs/heads/wip/*:refs/heads/other/*",
+			false,
+			nil,
+		}, {
+			"",
+			"^refs/remotes/origin/main",
+			false,
+			nil,
+		}, {
+			"",
+			"+^main",
+			false,
+			nil,
 		},
 	}
 
@@ -114,3 +167,46 @@ func TestRefSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestForcedAndNegativeRefSpecs(t *testing.T) {
+	rs, err := ParseRefSpecForRemote("origin", "+refs/heads/*:refs/remotes/origin/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rs.Forced() || !IsPattern(rs) {
+		t.Error("expected a forced pattern refspec")
+	}
+
+	rs, err = ParseRefSpec("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Forced() || IsPattern(rs) {
+		t.Error("expected a refspec which isn't forced and isn't a pattern")
+	}
+
+	rs, err = ParseRefSpec("+main:other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rs.Forced() || !Equals(rs.DestRef(NewBranchRef("main")), NewBranchRef("other")) {
+		t.Error("expected a forced refspec mapping main to other")
+	}
+
+	rs, err = ParseRefSpecForRemote("origin", "^wip/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	neg, ok := rs.(NegativeRefSpec)
+	if !ok {
+		t.Fatal("expected a negative refspec")
+	}
+	if !neg.Excludes(NewBranchRef("wip/a")) || neg.Excludes(NewBranchRef("main")) || neg.Excludes(NewTagRef("wip/a")) {
+		t.Error("negative refspec excluded the wrong refs")
+	}
+
+	refSpecs := []RemoteRefSpec{neg}
+	if !IsExcluded(NewBranchRef("wip/b"), refSpecs) || IsExcluded(NewBranchRef("feature"), refSpecs) {
+		t.Error("IsExcluded excluded the wrong refs")
+	}
+}
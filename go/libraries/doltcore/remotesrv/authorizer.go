@@ -0,0 +1,171 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Operation is an operation of a client on a repository of the server, which an Authorizer allows or denies.
+type Operation string
+
+const (
+	// OperationFetch reads the chunks of a repository, as fetches, pulls and clones do.
+	OperationFetch Operation = "fetch"
+	// OperationPush creates a ref, or moves it to a descendant of the commit it points to.
+	OperationPush Operation = "push"
+	// OperationForcePush deletes a ref, or moves it to a commit which doesn't descend from the commit it points to.
+	OperationForcePush Operation = "force-push"
+)
+
+// AuthRequest is an operation of a client which the server asks an Authorizer about.
+type AuthRequest struct {
+	// RepoPath is the path of the repository, e.g. "org/repo", or the name of the database for sql-server.
+	RepoPath string
+	// Ref is the ref the operation updates, e.g. "refs/heads/main". It's empty when the operation isn't limited to a
+	// ref yet: chunks are fetched for any ref, and they're uploaded before the refs of a push are known. A push is
+	// asked about again for each of its refs before the refs are updated.
+	Ref string
+	// Operation is the operation of the request.
+	Operation Operation
+}
+
+// Authorizer decides which operations the clients of the server can do. The context has the incoming gRPC metadata
+// of the request, so an Authorizer can identify the client from its credentials.
+type Authorizer interface {
+	// Authorize returns nil if the client can do the operation of |req|, or an error otherwise. Errors which aren't
+	// gRPC statuses are returned to the client as PermissionDenied.
+	Authorize(ctx context.Context, req AuthRequest) error
+}
+
+// refUpdate is the move of a ref by a commit to a chunk store. |from| is empty for a new ref, and |to| for a deleted
+// one.
+type refUpdate struct {
+	ref   string
+	force bool
+	from  hash.Hash
+	to    hash.Hash
+}
+
+// getRefUpdates returns the refs which moved between the roots |last| and |curr| of |cs|, sorted by ref.
+func getRefUpdates(ctx context.Context, cs chunks.ChunkStore, last, curr hash.Hash) ([]refUpdate, error) {
+	vs := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vs, ns)
+
+	lastRefs, err := getRefs(ctx, db, last)
+	if err != nil {
+		return nil, err
+	}
+	currRefs, err := getRefs(ctx, db, curr)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []refUpdate
+	for ref, from := range lastRefs {
+		if _, ok := currRefs[ref]; !ok {
+			updates = append(updates, refUpdate{ref: ref, force: true, from: from})
+		}
+	}
+	for ref, to := range currRefs {
+		from, ok := lastRefs[ref]
+		if from == to {
+			continue
+		}
+		force := false
+		if ok {
+			force, err = isForcedMove(ctx, vs, ns, from, to)
+			if err != nil {
+				return nil, err
+			}
+		}
+		updates = append(updates, refUpdate{ref: ref, force: force, from: from, to: to})
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].ref < updates[j].ref
+	})
+	return updates, nil
+}
+
+func getRefs(ctx context.Context, db datas.Database, root hash.Hash) (map[string]hash.Hash, error) {
+	refs := make(map[string]hash.Hash)
+	if root.IsEmpty() {
+		return refs, nil
+	}
+	dss, err := db.DatasetsByRootHash(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	err = dss.IterAll(ctx, func(id string, addr hash.Hash) error {
+		refs[id] = addr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// isForcedMove returns whether moving a ref from |from| to |to| isn't a fast-forward. Refs which don't point to
+// commits, like tags, can't be fast-forwarded, so any move of them is forced.
+func isForcedMove(ctx context.Context, vs *types.ValueStore, ns tree.NodeStore, from, to hash.Hash) (bool, error) {
+	fromCommit, err := datas.LoadCommitAddr(ctx, vs, from)
+	if errors.Is(err, datas.ErrNotACommit) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	toCommit, err := datas.LoadCommitAddr(ctx, vs, to)
+	if errors.Is(err, datas.ErrNotACommit) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	ancestor, ok, err := datas.FindCommonAncestor(ctx, fromCommit, toCommit, vs, vs, ns, ns)
+	if err != nil {
+		return false, err
+	}
+	return !ok || ancestor != from, nil
+}
+
+// authorizeRefUpdates asks |authorizer| about each ref moved by a commit to |cs|.
+func authorizeRefUpdates(ctx context.Context, authorizer Authorizer, cs chunks.ChunkStore, repoPath string, last, curr hash.Hash) error {
+	updates, err := getRefUpdates(ctx, cs, last, curr)
+	if err != nil {
+		return fmt.Errorf("error reading the refs of the commit: %w", err)
+	}
+	for _, update := range updates {
+		op := OperationPush
+		if update.force {
+			op = OperationForcePush
+		}
+		err = authorizer.Authorize(ctx, AuthRequest{RepoPath: repoPath, Ref: update.ref, Operation: op})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
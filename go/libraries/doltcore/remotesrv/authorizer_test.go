@@ -0,0 +1,93 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestGetRefUpdates(t *testing.T) {
+	ctx := context.Background()
+	storage := &chunks.TestStorage{}
+	cs := storage.NewViewWithDefaultFormat()
+	db := datas.NewDatabase(cs)
+	defer db.Close()
+
+	commit := func(id, val string, parents ...hash.Hash) hash.Hash {
+		ds, err := db.GetDataset(ctx, id)
+		require.NoError(t, err)
+		ds, err = db.Commit(ctx, ds, types.String(val), datas.CommitOptions{Parents: parents})
+		require.NoError(t, err)
+		addr, ok := ds.MaybeHeadAddr()
+		require.True(t, ok)
+		return addr
+	}
+	setHead := func(id string, addr hash.Hash) {
+		ds, err := db.GetDataset(ctx, id)
+		require.NoError(t, err)
+		_, err = db.SetHead(ctx, ds, addr)
+		require.NoError(t, err)
+	}
+	root := func() hash.Hash {
+		h, err := cs.Root(ctx)
+		require.NoError(t, err)
+		return h
+	}
+
+	a1 := commit("refs/heads/main", "a1")
+	b1 := commit("refs/heads/other", "b1", a1)
+	empty := hash.Hash{}
+	first := root()
+
+	updates, err := getRefUpdates(ctx, cs, empty, first)
+	require.NoError(t, err)
+	assert.Equal(t, []refUpdate{{ref: "refs/heads/main", to: a1}, {ref: "refs/heads/other", to: b1}}, updates)
+
+	// main is fast-forwarded, other is reset to a commit which isn't its descendant, and tmp is created
+	a2 := commit("refs/heads/main", "a2")
+	setHead("refs/heads/other", a1)
+	c1 := commit("refs/heads/tmp", "c1")
+	second := root()
+
+	updates, err = getRefUpdates(ctx, cs, first, second)
+	require.NoError(t, err)
+	assert.Equal(t, []refUpdate{
+		{ref: "refs/heads/main", from: a1, to: a2},
+		{ref: "refs/heads/other", force: true, from: b1, to: a1},
+		{ref: "refs/heads/tmp", to: c1},
+	}, updates)
+
+	ds, err := db.GetDataset(ctx, "refs/heads/tmp")
+	require.NoError(t, err)
+	_, err = db.Delete(ctx, ds)
+	require.NoError(t, err)
+
+	updates, err = getRefUpdates(ctx, cs, second, root())
+	require.NoError(t, err)
+	assert.Equal(t, []refUpdate{{ref: "refs/heads/tmp", force: true, from: c1}}, updates)
+
+	updates, err = getRefUpdates(ctx, cs, second, second)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+}
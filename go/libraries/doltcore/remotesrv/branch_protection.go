@@ -0,0 +1,64 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// checkBranchProtection returns a doltdb.ErrProtectedBranch error if a commit to |cs| from the root |last| to |curr|
+// moves a branch in a way |protection| forbids.
+func checkBranchProtection(ctx context.Context, protection doltdb.BranchProtection, cs chunks.ChunkStore, last, curr hash.Hash) error {
+	if protection.IsEmpty() {
+		return nil
+	}
+	updates, err := getRefUpdates(ctx, cs, last, curr)
+	if err != nil {
+		return fmt.Errorf("error reading the refs of the commit: %w", err)
+	}
+
+	ddb := doltdb.DoltDBFromCS(cs)
+	readCommit := func(h hash.Hash) (*doltdb.Commit, error) {
+		if h.IsEmpty() {
+			return nil, nil
+		}
+		return ddb.ReadCommit(ctx, h)
+	}
+	for _, update := range updates {
+		dref, err := ref.Parse(update.ref)
+		if err != nil || dref.GetType() != ref.BranchRefType {
+			continue
+		}
+		from, err := readCommit(update.from)
+		if err != nil {
+			return err
+		}
+		to, err := readCommit(update.to)
+		if err != nil {
+			return err
+		}
+		err = protection.CheckBranchUpdate(ctx, dref.GetPath(), from, to)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
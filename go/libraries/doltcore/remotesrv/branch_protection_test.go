@@ -0,0 +1,84 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestCheckBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	storage := &chunks.TestStorage{}
+	cs := storage.NewViewWithDefaultFormat()
+	db := datas.NewDatabase(cs)
+	defer db.Close()
+
+	commit := func(id, val string, parents ...hash.Hash) hash.Hash {
+		ds, err := db.GetDataset(ctx, id)
+		require.NoError(t, err)
+		ds, err = db.Commit(ctx, ds, types.String(val), datas.CommitOptions{Parents: parents})
+		require.NoError(t, err)
+		addr, ok := ds.MaybeHeadAddr()
+		require.True(t, ok)
+		return addr
+	}
+	root := func() hash.Hash {
+		h, err := cs.Root(ctx)
+		require.NoError(t, err)
+		return h
+	}
+	protection := doltdb.ParseBranchProtection("main", "")
+
+	a1 := commit("refs/heads/main", "a1")
+	commit("refs/heads/other", "b1", a1)
+	first := root()
+	assert.NoError(t, checkBranchProtection(ctx, protection, cs, hash.Hash{}, first))
+
+	// main and other are fast-forwarded
+	commit("refs/heads/main", "a2")
+	commit("refs/heads/other", "b2")
+	second := root()
+	assert.NoError(t, checkBranchProtection(ctx, protection, cs, first, second))
+
+	// main and other are reset to their first commits
+	assert.ErrorIs(t, checkBranchProtection(ctx, protection, cs, second, first), doltdb.ErrProtectedBranch)
+	assert.NoError(t, checkBranchProtection(ctx, doltdb.ParseBranchProtection("", ""), cs, second, first))
+	assert.NoError(t, checkBranchProtection(ctx, doltdb.ParseBranchProtection("feature", ""), cs, second, first))
+
+	// other is deleted, and then main
+	ds, err := db.GetDataset(ctx, "refs/heads/other")
+	require.NoError(t, err)
+	_, err = db.Delete(ctx, ds)
+	require.NoError(t, err)
+	third := root()
+	assert.NoError(t, checkBranchProtection(ctx, protection, cs, second, third))
+	ds, err = db.GetDataset(ctx, "refs/heads/main")
+	require.NoError(t, err)
+	_, err = db.Delete(ctx, ds)
+	require.NoError(t, err)
+	err = checkBranchProtection(ctx, protection, cs, third, root())
+	assert.ErrorIs(t, err, doltdb.ErrProtectedBranch)
+	assert.ErrorIs(t, checkBranchProtection(ctx, doltdb.ParseBranchProtection("*", ""), cs, second, third), doltdb.ErrProtectedBranch)
+}
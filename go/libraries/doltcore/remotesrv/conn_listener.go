@@ -0,0 +1,63 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"net"
+	"sync"
+)
+
+// ConnListeners returns Listeners which serve exactly one, already established connection. It is used to serve the
+// remotes API over a transport like the stdio of an ssh session. The server must have been created with the same
+// HttpListenAddr and GrpcListenAddr, so that gRPC and HTTP requests are multiplexed over |conn|.
+func ConnListeners(conn net.Conn) Listeners {
+	return Listeners{http: newConnListener(conn)}
+}
+
+// connListener is a net.Listener which returns its connection from the first call to Accept and blocks all
+// subsequent calls until the listener is closed.
+type connListener struct {
+	conn net.Conn
+	ch   chan net.Conn
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	ch := make(chan net.Conn, 1)
+	ch <- conn
+	return &connListener{conn: conn, ch: ch, closed: make(chan struct{})}
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.ch:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *connListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
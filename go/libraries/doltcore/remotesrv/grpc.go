@@ -32,6 +32,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -52,6 +53,10 @@ type RemoteChunkStore struct {
 	fs      filesys.Filesys
 	lgr     *logrus.Entry
 	sealer  Sealer
+	// authorizer, if set, is asked about the operations of each request
+	authorizer Authorizer
+	// branchProtection, if set, returns the protection of the branches of a repository, which pushes can't break
+	branchProtection func(repoPath string) doltdb.BranchProtection
 	remotesapi.UnimplementedChunkStoreServiceServer
 }
 
@@ -93,6 +98,10 @@ func (rs *RemoteChunkStore) HasChunks(ctx context.Context, req *remotesapi.HasCh
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -151,6 +160,10 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -255,6 +268,10 @@ func (rs *RemoteChunkStore) StreamDownloadLocations(stream remotesapi.ChunkStore
 		if nextPath != repoPath {
 			repoPath = nextPath
 			logger = ologger.WithField(RepoPathField, repoPath)
+			err = rs.authorize(stream.Context(), logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch})
+			if err != nil {
+				return err
+			}
 			cs, err = rs.getStore(logger, repoPath)
 			if err != nil {
 				return err
@@ -368,6 +385,10 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationPush}); err != nil {
+		return nil, err
+	}
+
 	_, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -426,6 +447,10 @@ func (rs *RemoteChunkStore) Rebase(ctx context.Context, req *remotesapi.RebaseRe
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch}); err != nil {
+		return nil, err
+	}
+
 	_, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -443,6 +468,10 @@ func (rs *RemoteChunkStore) Root(ctx context.Context, req *remotesapi.RootReques
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -466,6 +495,10 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationPush}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -485,6 +518,21 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	currHash := hash.New(req.Current)
 	lastHash := hash.New(req.Last)
 
+	if rs.authorizer != nil {
+		err = authorizeRefUpdates(ctx, rs.authorizer, cs, repoPath, lastHash, currHash)
+		if err != nil {
+			return nil, authError(logger, err)
+		}
+	}
+
+	if rs.branchProtection != nil {
+		err = checkBranchProtection(ctx, rs.branchProtection(repoPath), cs, lastHash, currHash)
+		if err != nil {
+			logger.WithError(err).Info("push was rejected by branch protection")
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
 	var ok bool
 	ok, err = cs.Commit(ctx, currHash, lastHash)
 	if err != nil {
@@ -508,6 +556,10 @@ func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getOrCreateStore(logger, repoPath, req.ClientRepoFormat.NbfVersion)
 	if err != nil {
 		return nil, err
@@ -535,6 +587,10 @@ func (rs *RemoteChunkStore) ListTableFiles(ctx context.Context, req *remotesapi.
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationFetch}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -613,6 +669,10 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
+	if err := rs.authorize(ctx, logger, AuthRequest{RepoPath: repoPath, Operation: OperationPush}); err != nil {
+		return nil, err
+	}
+
 	cs, err := rs.getStore(logger, repoPath)
 	if err != nil {
 		return nil, err
@@ -636,6 +696,26 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 	return &remotesapi.AddTableFilesResponse{Success: true}, nil
 }
 
+// authorize asks the authorizer of |rs|, if it has one, about |req|.
+func (rs *RemoteChunkStore) authorize(ctx context.Context, logger *logrus.Entry, req AuthRequest) error {
+	if rs.authorizer == nil {
+		return nil
+	}
+	if err := rs.authorizer.Authorize(ctx, req); err != nil {
+		return authError(logger, err)
+	}
+	return nil
+}
+
+// authError returns |err| of an Authorizer as a gRPC status.
+func authError(logger *logrus.Entry, err error) error {
+	logger.WithError(err).Info("request was not authorized")
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
 func (rs *RemoteChunkStore) getStore(logger *logrus.Entry, repoPath string) (RemoteSrvStore, error) {
 	return rs.getOrCreateStore(logger, repoPath, types.Format_Default.VersionString())
 }
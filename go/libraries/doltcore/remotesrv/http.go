@@ -31,6 +31,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -107,6 +108,30 @@ func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 		respWr.Header().Add("Accept-Ranges", "bytes")
 		logger, statusCode = readTableFile(logger, abs, respWr, req.Header.Get("Range"))
 
+	case http.MethodHead:
+		// a HEAD request to an upload URL asks how many bytes of a table file uploaded in parts have been received
+		if fh.readOnly {
+			respWr.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		i := strings.LastIndex(path, "/")
+		if i < 0 || len(path[i:]) != 33 {
+			logger = logger.WithField("status", http.StatusNotFound)
+			respWr.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		received, err := stagedUploadSize(path[:i], path[i+1:])
+		if err != nil {
+			logger = logger.WithField("status", http.StatusBadRequest)
+			logger.WithError(err).Warn("bad request: could not get size of staged upload")
+			respWr.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		respWr.Header().Set(remotestorage.UploadOffsetHeader, strconv.FormatUint(received, 10))
+		statusCode = http.StatusOK
+
 	case http.MethodPost, http.MethodPut:
 		if fh.readOnly {
 			respWr.WriteHeader(http.StatusForbidden)
@@ -168,6 +193,21 @@ func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 			return
 		}
 
+		if offsetStr := req.Header.Get(remotestorage.UploadOffsetHeader); offsetStr != "" {
+			offset, err := strconv.ParseUint(offsetStr, 10, 64)
+			if err != nil {
+				logger = logger.WithField("status", http.StatusBadRequest)
+				logger.WithError(err).Warn("bad request: upload offset header did not parse")
+				respWr.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			var received uint64
+			logger, statusCode, received = writeTableFilePart(req.Context(), logger, fh.dbCache, filepath, file, num_chunks, content_hash, uint64(content_length), offset, req.Body)
+			respWr.Header().Set(remotestorage.UploadOffsetHeader, strconv.FormatUint(received, 10))
+			break
+		}
+
 		logger, statusCode = writeTableFile(req.Context(), logger, fh.dbCache, filepath, file, num_chunks, content_hash, uint64(content_length), req.Body)
 	}
 
@@ -0,0 +1,385 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LoadSheddingConfig limits the table file downloads served by a Server, so that a burst of clones can't saturate the
+// host and one heavy client can't starve the others. Zero values are unlimited.
+type LoadSheddingConfig struct {
+	// MaxConcurrentDownloads is the most table file downloads served at once.
+	MaxConcurrentDownloads int
+	// MaxConcurrentDownloadsPerClient is the most table file downloads served to a single client at once.
+	MaxConcurrentDownloadsPerClient int
+	// MaxQueuedDownloads is the most downloads waiting to be served. Downloads beyond it are rejected.
+	MaxQueuedDownloads int
+	// QueueTimeout is how long a download waits to be served before it's rejected.
+	QueueTimeout time.Duration
+	// ClientBytesPerSecond is the rate at which the downloads of a single client are sent.
+	ClientBytesPerSecond int64
+	// ClientBurstBytes is the most bytes a client's downloads are sent beyond ClientBytesPerSecond after they've been
+	// idle. It defaults to ClientBytesPerSecond.
+	ClientBurstBytes int64
+}
+
+// LoadStats are the current state of the download queue of a Server, and the totals of the downloads it has served
+// and rejected.
+type LoadStats struct {
+	InFlight int
+	Queued   int
+	Clients  int
+	Served   uint64
+	Shed     uint64
+}
+
+var errDownloadShed = errors.New("download rejected by load shedding")
+
+// loadShedder serves table file downloads up to the limits of its LoadSheddingConfig, queueing the ones beyond them.
+// Queued downloads are served round-robin by client, so that clients with many queued downloads don't delay the
+// downloads of the others.
+type loadShedder struct {
+	cfg LoadSheddingConfig
+	lgr *logrus.Entry
+	now func() time.Time
+
+	mu       sync.Mutex
+	clients  map[string]*clientLoad
+	ring     []string
+	inFlight int
+	queued   int
+	served   uint64
+	shed     uint64
+}
+
+type clientLoad struct {
+	active  int
+	waiters []*downloadWaiter
+	bucket  *tokenBucket
+}
+
+type downloadWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+func newLoadShedder(lgr *logrus.Entry, cfg LoadSheddingConfig) *loadShedder {
+	if cfg.ClientBurstBytes <= 0 {
+		cfg.ClientBurstBytes = cfg.ClientBytesPerSecond
+	}
+	return &loadShedder{
+		cfg:     cfg,
+		lgr:     lgr,
+		now:     time.Now,
+		clients: make(map[string]*clientLoad),
+	}
+}
+
+// Wrap returns a handler which serves the GET requests of |h|, which download table files, up to the limits of the
+// shedder. Other requests are served without limits.
+func (ls *loadShedder) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWr http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			h.ServeHTTP(respWr, req)
+			return
+		}
+
+		client := clientKey(req)
+		release, err := ls.acquire(req.Context(), client)
+		if err != nil {
+			ls.lgr.WithField("client", client).WithError(err).Warn("rejected table file download")
+			respWr.Header().Set("Retry-After", "1")
+			respWr.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		defer release()
+
+		if bucket := ls.bucket(client); bucket != nil {
+			respWr = &throttledResponseWriter{ResponseWriter: respWr, ctx: req.Context(), bucket: bucket}
+		}
+		h.ServeHTTP(respWr, req)
+	})
+}
+
+// clientKey identifies the client which sent |req| by its address.
+func clientKey(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// Stats returns the current state of the download queue.
+func (ls *loadShedder) Stats() LoadStats {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return LoadStats{
+		InFlight: ls.inFlight,
+		Queued:   ls.queued,
+		Clients:  len(ls.clients),
+		Served:   ls.served,
+		Shed:     ls.shed,
+	}
+}
+
+// acquire waits until the download of |client| can be served, returning the function to call once it has been. It
+// returns an error if the queue is full, or the download waited longer than the queue timeout.
+func (ls *loadShedder) acquire(ctx context.Context, client string) (func(), error) {
+	ls.mu.Lock()
+	cl := ls.client(client)
+	w := &downloadWaiter{ready: make(chan struct{})}
+	if len(cl.waiters) == 0 {
+		ls.ring = append(ls.ring, client)
+	}
+	cl.waiters = append(cl.waiters, w)
+	ls.queued++
+	ls.dispatch()
+
+	if !w.granted && ls.cfg.MaxQueuedDownloads > 0 && ls.queued > ls.cfg.MaxQueuedDownloads {
+		ls.dequeue(client, w)
+		ls.shed++
+		ls.mu.Unlock()
+		return nil, errDownloadShed
+	}
+	ls.mu.Unlock()
+
+	release := func() {
+		ls.release(client)
+	}
+
+	var timeout <-chan time.Time
+	if ls.cfg.QueueTimeout > 0 {
+		timer := time.NewTimer(ls.cfg.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+	select {
+	case <-w.ready:
+		return release, nil
+	case <-timeout:
+		err = errDownloadShed
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if w.granted {
+		// the download was granted as it timed out
+		return release, nil
+	}
+	ls.dequeue(client, w)
+	ls.shed++
+	return nil, err
+}
+
+func (ls *loadShedder) release(client string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.served++
+	ls.inFlight--
+	cl := ls.clients[client]
+	cl.active--
+	ls.dispatch()
+	ls.forgetIdle(client)
+}
+
+// client returns the load of |client|, creating it if it doesn't exist. |ls.mu| must be held.
+func (ls *loadShedder) client(client string) *clientLoad {
+	cl, ok := ls.clients[client]
+	if !ok {
+		cl = &clientLoad{}
+		if ls.cfg.ClientBytesPerSecond > 0 {
+			cl.bucket = newTokenBucket(ls.cfg.ClientBytesPerSecond, ls.cfg.ClientBurstBytes, ls.now)
+		}
+		ls.clients[client] = cl
+	}
+	return cl
+}
+
+func (ls *loadShedder) bucket(client string) *tokenBucket {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if cl, ok := ls.clients[client]; ok {
+		return cl.bucket
+	}
+	return nil
+}
+
+// dispatch grants queued downloads while there are free slots, taking turns between the clients with queued
+// downloads that are under their own limit. |ls.mu| must be held.
+func (ls *loadShedder) dispatch() {
+	for ls.cfg.MaxConcurrentDownloads <= 0 || ls.inFlight < ls.cfg.MaxConcurrentDownloads {
+		granted := false
+		for i := 0; i < len(ls.ring); i++ {
+			client := ls.ring[i]
+			cl := ls.clients[client]
+			if ls.cfg.MaxConcurrentDownloadsPerClient > 0 && cl.active >= ls.cfg.MaxConcurrentDownloadsPerClient {
+				continue
+			}
+
+			w := cl.waiters[0]
+			cl.waiters = cl.waiters[1:]
+			ls.ring = append(ls.ring[:i], ls.ring[i+1:]...)
+			if len(cl.waiters) > 0 {
+				// the client goes to the back of the line for its next download
+				ls.ring = append(ls.ring, client)
+			}
+
+			ls.queued--
+			ls.inFlight++
+			cl.active++
+			w.granted = true
+			close(w.ready)
+			granted = true
+			break
+		}
+		if !granted {
+			return
+		}
+	}
+}
+
+// dequeue removes |w|, which hasn't been granted, from the queue of |client|. |ls.mu| must be held.
+func (ls *loadShedder) dequeue(client string, w *downloadWaiter) {
+	cl := ls.clients[client]
+	for i, cw := range cl.waiters {
+		if cw == w {
+			cl.waiters = append(cl.waiters[:i], cl.waiters[i+1:]...)
+			ls.queued--
+			break
+		}
+	}
+	if len(cl.waiters) == 0 {
+		for i, c := range ls.ring {
+			if c == client {
+				ls.ring = append(ls.ring[:i], ls.ring[i+1:]...)
+				break
+			}
+		}
+	}
+	ls.forgetIdle(client)
+}
+
+// forgetIdle forgets |client| if it has no downloads and a full token bucket, so that it's served as a new client
+// the next time. |ls.mu| must be held.
+func (ls *loadShedder) forgetIdle(client string) {
+	cl := ls.clients[client]
+	if cl.active > 0 || len(cl.waiters) > 0 {
+		return
+	}
+	if cl.bucket != nil && !cl.bucket.full() {
+		return
+	}
+	delete(ls.clients, client)
+}
+
+// tokenBucket limits the rate at which bytes are sent to a client. Tokens are reserved ahead of time, so a write
+// waits for the writes reserved before it, and the writes of concurrent downloads are interleaved.
+type tokenBucket struct {
+	rate  int64
+	burst int64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int64, now func() time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: burst, now: now, tokens: float64(burst), last: now()}
+}
+
+func (b *tokenBucket) refill() {
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * float64(b.rate)
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+}
+
+func (b *tokenBucket) full() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	return b.tokens >= float64(b.burst)
+}
+
+// reserve takes |n| tokens from the bucket, returning how long to wait before they're available.
+func (b *tokenBucket) reserve(n int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / float64(b.rate) * float64(time.Second))
+}
+
+// wait blocks until |n| tokens are available, or |ctx| is done.
+func (b *tokenBucket) wait(ctx context.Context, n int) error {
+	d := b.reserve(n)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttledResponseWriter sends the body of a response at the rate of a tokenBucket.
+type throttledResponseWriter struct {
+	http.ResponseWriter
+	ctx    context.Context
+	bucket *tokenBucket
+}
+
+func (w *throttledResponseWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		n := len(p)
+		if int64(n) > w.bucket.burst {
+			n = int(w.bucket.burst)
+		}
+		if err := w.bucket.wait(w.ctx, n); err != nil {
+			return written, err
+		}
+		m, err := w.ResponseWriter.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
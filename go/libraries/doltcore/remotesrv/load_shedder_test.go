@@ -0,0 +1,167 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoadShedder(cfg LoadSheddingConfig) *loadShedder {
+	return newLoadShedder(logrus.NewEntry(logrus.New()), cfg)
+}
+
+// acquireAsync acquires a download for |client| in the background, sending its release to the returned channel once
+// it's granted.
+func acquireAsync(t *testing.T, ls *loadShedder, client string) <-chan func() {
+	ch := make(chan func(), 1)
+	queued := ls.Stats().Queued
+	go func() {
+		release, err := ls.acquire(context.Background(), client)
+		assert.NoError(t, err)
+		ch <- release
+	}()
+	require.Eventually(t, func() bool {
+		return ls.Stats().Queued > queued
+	}, time.Second, time.Millisecond)
+	return ch
+}
+
+func TestLoadShedderRoundRobin(t *testing.T) {
+	ls := newTestLoadShedder(LoadSheddingConfig{MaxConcurrentDownloads: 1})
+	ctx := context.Background()
+
+	releaseA1, err := ls.acquire(ctx, "a")
+	require.NoError(t, err)
+	a2 := acquireAsync(t, ls, "a")
+	a3 := acquireAsync(t, ls, "a")
+	b1 := acquireAsync(t, ls, "b")
+	assert.Equal(t, LoadStats{InFlight: 1, Queued: 3, Clients: 2}, ls.Stats())
+
+	releaseA1()
+	releaseA2 := <-a2
+
+	// b's download is served before a's next one, although it was queued after it
+	releaseA2()
+	releaseB1 := <-b1
+	select {
+	case <-a3:
+		t.Fatal("a's third download was served before b's")
+	default:
+	}
+
+	releaseB1()
+	releaseA3 := <-a3
+	releaseA3()
+	assert.Equal(t, LoadStats{Served: 4}, ls.Stats())
+}
+
+func TestLoadShedderPerClientLimit(t *testing.T) {
+	ls := newTestLoadShedder(LoadSheddingConfig{MaxConcurrentDownloadsPerClient: 1})
+	ctx := context.Background()
+
+	releaseA1, err := ls.acquire(ctx, "a")
+	require.NoError(t, err)
+	a2 := acquireAsync(t, ls, "a")
+
+	releaseB1, err := ls.acquire(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, LoadStats{InFlight: 2, Queued: 1, Clients: 2}, ls.Stats())
+
+	releaseB1()
+	releaseA1()
+	releaseA2 := <-a2
+	releaseA2()
+}
+
+func TestLoadShedderRejectsWhenQueueIsFull(t *testing.T) {
+	ls := newTestLoadShedder(LoadSheddingConfig{MaxConcurrentDownloads: 1, MaxQueuedDownloads: 1})
+	ctx := context.Background()
+
+	releaseA1, err := ls.acquire(ctx, "a")
+	require.NoError(t, err)
+	b1 := acquireAsync(t, ls, "b")
+
+	_, err = ls.acquire(ctx, "c")
+	assert.ErrorIs(t, err, errDownloadShed)
+	assert.Equal(t, uint64(1), ls.Stats().Shed)
+
+	releaseA1()
+	releaseB1 := <-b1
+	releaseB1()
+}
+
+func TestLoadShedderQueueTimeout(t *testing.T) {
+	ls := newTestLoadShedder(LoadSheddingConfig{MaxConcurrentDownloads: 1, QueueTimeout: 10 * time.Millisecond})
+	ctx := context.Background()
+
+	releaseA1, err := ls.acquire(ctx, "a")
+	require.NoError(t, err)
+	_, err = ls.acquire(ctx, "b")
+	assert.ErrorIs(t, err, errDownloadShed)
+	assert.Equal(t, LoadStats{InFlight: 1, Clients: 1, Shed: 1}, ls.Stats())
+	releaseA1()
+}
+
+func TestLoadShedderHandler(t *testing.T) {
+	ls := newTestLoadShedder(LoadSheddingConfig{MaxConcurrentDownloads: 1, MaxQueuedDownloads: 0, QueueTimeout: time.Millisecond})
+	release, err := ls.acquire(context.Background(), "192.0.2.1")
+	require.NoError(t, err)
+
+	served := false
+	h := ls.Wrap(http.HandlerFunc(func(respWr http.ResponseWriter, req *http.Request) {
+		served = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/repo/db/file", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.False(t, served)
+
+	// uploads are not limited
+	req = httptest.NewRequest(http.MethodPut, "/repo/db/file", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.True(t, served)
+
+	release()
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(100, 200, func() time.Time {
+		return now
+	})
+
+	assert.Equal(t, time.Duration(0), b.reserve(200))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(50))
+	assert.False(t, b.full())
+
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), b.reserve(50))
+	now = now.Add(10 * time.Second)
+	assert.True(t, b.full())
+}
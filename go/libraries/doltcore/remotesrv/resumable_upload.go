@@ -0,0 +1,150 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// uploadStagingDir is the directory the parts of table files uploaded in parts are written to until the whole table
+// file has been received.
+var uploadStagingDir = filepath.Join(os.TempDir(), "dolt_remotesrv_uploads")
+
+// stagedUploadLocks serializes the requests writing to each staged table file, keyed by its path.
+var stagedUploadLocks sync.Map
+
+func stagedUploadPath(repoPath, fileId string) string {
+	return filepath.Join(uploadStagingDir, hash.Of([]byte(repoPath)).String(), fileId)
+}
+
+func lockStagedUpload(path string) func() {
+	l, _ := stagedUploadLocks.LoadOrStore(path, &sync.Mutex{})
+	mu := l.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// stagedUploadSize returns the number of bytes received of the table file |fileId| of the repository at |repoPath|
+// which is being uploaded in parts.
+func stagedUploadSize(repoPath, fileId string) (uint64, error) {
+	if _, ok := hash.MaybeParse(fileId); !ok {
+		return 0, errors.New("invalid table file id " + fileId)
+	}
+
+	path := stagedUploadPath(repoPath, fileId)
+	defer lockStagedUpload(path)()
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return uint64(info.Size()), nil
+}
+
+// writeTableFilePart appends |body|, the part of the table file |fileId| starting at |offset|, to the table file staged
+// for the repository at |path|. Once all |contentLength| bytes of the table file have been received, it's written to
+// the repository. It returns the number of bytes of the table file received, which is the offset the next part must
+// start at. Bytes of a part that were received before a failure are kept, so that the upload can resume after them.
+func writeTableFilePart(ctx context.Context, logger *logrus.Entry, dbCache DBCache, path, fileId string, numChunks int, contentHash []byte, contentLength, offset uint64, body io.Reader) (*logrus.Entry, int, uint64) {
+	if _, ok := hash.MaybeParse(fileId); !ok {
+		logger = logger.WithField("status", http.StatusBadRequest)
+		logger.Warnf("%s is not a valid hash", fileId)
+		return logger, http.StatusBadRequest, 0
+	}
+
+	staged := stagedUploadPath(path, fileId)
+	defer lockStagedUpload(staged)()
+
+	logger = logger.WithField("upload_offset", offset)
+	received, err := appendTableFilePart(staged, offset, body)
+	if errors.Is(err, errUploadOffsetMismatch) {
+		logger = logger.WithField("status", http.StatusConflict)
+		logger.Warnf("upload part starts at %d, but %d bytes have been received", offset, received)
+		return logger, http.StatusConflict, received
+	}
+	if err != nil {
+		logger = logger.WithField("status", http.StatusInternalServerError)
+		logger.WithError(err).Error("failed to write upload part")
+		return logger, http.StatusInternalServerError, received
+	}
+
+	if received > contentLength {
+		_ = os.Remove(staged)
+		logger = logger.WithField("status", http.StatusBadRequest)
+		logger.Warn("bad request: body length mismatch")
+		return logger, http.StatusBadRequest, 0
+	}
+	if received < contentLength {
+		return logger, http.StatusOK, received
+	}
+
+	f, err := os.Open(staged)
+	if err != nil {
+		logger = logger.WithField("status", http.StatusInternalServerError)
+		logger.WithError(err).Error("failed to open staged upload")
+		return logger, http.StatusInternalServerError, received
+	}
+	logger, status := writeTableFile(ctx, logger, dbCache, path, fileId, numChunks, contentHash, contentLength, f)
+	_ = f.Close()
+	if status == http.StatusBadRequest {
+		// the table file didn't match its length or hash, so the upload has to start over
+		received = 0
+	}
+	if status != http.StatusInternalServerError {
+		_ = os.Remove(staged)
+	}
+	return logger, status, received
+}
+
+var errUploadOffsetMismatch = errors.New("upload part offset does not match the bytes received")
+
+// appendTableFilePart appends |body| to the staged table file at |path| if it starts at |offset|, the size of the
+// staged table file. It returns the size of the staged table file after appending.
+func appendTableFilePart(path string, offset uint64, body io.Reader) (uint64, error) {
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := uint64(info.Size())
+	if size != offset {
+		return size, errUploadOffsetMismatch
+	}
+
+	n, err := io.Copy(f, body)
+	return size + uint64(n), err
+}
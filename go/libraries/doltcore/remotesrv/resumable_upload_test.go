@@ -0,0 +1,140 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+type tableFileRecorder struct {
+	RemoteSrvStore
+	written map[string][]byte
+}
+
+func (r *tableFileRecorder) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
+	rd, _, err := getRd()
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return err
+	}
+	if err = rd.Close(); err != nil {
+		return err
+	}
+	r.written[fileId] = data
+	return nil
+}
+
+type singleStoreCache struct {
+	store RemoteSrvStore
+}
+
+func (c singleStoreCache) Get(path, nbfVerStr string) (RemoteSrvStore, error) {
+	return c.store, nil
+}
+
+func TestResumableUpload(t *testing.T) {
+	uploadStagingDir = t.TempDir()
+	ctx := context.Background()
+
+	recorder := &tableFileRecorder{written: make(map[string][]byte)}
+	fh := newFileHandler(logrus.NewEntry(logrus.New()), singleStoreCache{recorder}, filesys.LocalFS, false, identitySealer{})
+	srv := httptest.NewServer(fh)
+	defer srv.Close()
+
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	sum := md5.Sum(data)
+	fileId := hash.Of(data).String()
+	post := &remotesapi.HttpPostTableFile{
+		Url: fmt.Sprintf("%s/repo/db/%s?num_chunks=1&content_length=%d&content_hash=%s", srv.URL, fileId, len(data), base64.RawURLEncoding.EncodeToString(sum[:])),
+	}
+
+	offset, resumable, err := remotestorage.QueryUploadOffset(ctx, http.DefaultClient, post)
+	require.NoError(t, err)
+	assert.True(t, resumable)
+	assert.Equal(t, uint64(0), offset)
+
+	offset, err = remotestorage.HttpPutUploadPart(ctx, http.DefaultClient, post, 0, 400, bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(400), offset)
+
+	// a part which doesn't start at the bytes received is rejected, giving the offset to resume from
+	offset, err = remotestorage.HttpPutUploadPart(ctx, http.DefaultClient, post, 200, 400, bytes.NewReader(data[200:]))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(400), offset)
+
+	offset, resumable, err = remotestorage.QueryUploadOffset(ctx, http.DefaultClient, post)
+	require.NoError(t, err)
+	assert.True(t, resumable)
+	assert.Equal(t, uint64(400), offset)
+	assert.Empty(t, recorder.written)
+
+	offset, err = remotestorage.HttpPutUploadPart(ctx, http.DefaultClient, post, 400, 600, bytes.NewReader(data[400:]))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), offset)
+	assert.Equal(t, data, recorder.written[fileId])
+
+	// the staged table file is removed once it's been written
+	offset, _, err = remotestorage.QueryUploadOffset(ctx, http.DefaultClient, post)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), offset)
+}
+
+func TestResumableUploadHashMismatch(t *testing.T) {
+	uploadStagingDir = t.TempDir()
+	ctx := context.Background()
+
+	recorder := &tableFileRecorder{written: make(map[string][]byte)}
+	fh := newFileHandler(logrus.NewEntry(logrus.New()), singleStoreCache{recorder}, filesys.LocalFS, false, identitySealer{})
+	srv := httptest.NewServer(fh)
+	defer srv.Close()
+
+	data := []byte("not the table file that was hashed")
+	sum := md5.Sum([]byte("the table file"))
+	fileId := hash.Of(data).String()
+	post := &remotesapi.HttpPostTableFile{
+		Url: fmt.Sprintf("%s/repo/db/%s?num_chunks=1&content_length=%d&content_hash=%s", srv.URL, fileId, len(data), base64.RawURLEncoding.EncodeToString(sum[:])),
+	}
+
+	_, err := remotestorage.HttpPutUploadPart(ctx, http.DefaultClient, post, 0, int64(len(data)), bytes.NewReader(data))
+	assert.Error(t, err)
+	assert.Empty(t, recorder.written)
+
+	offset, _, err := remotestorage.QueryUploadOffset(ctx, http.DefaultClient, post)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), offset)
+}
@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
 
@@ -42,6 +43,8 @@ type Server struct {
 	httpSrv http.Server
 
 	tlsConfig *tls.Config
+
+	loadShedder *loadShedder
 }
 
 func (s *Server) GracefulStop() {
@@ -49,6 +52,14 @@ func (s *Server) GracefulStop() {
 	s.wg.Wait()
 }
 
+// LoadStats returns the state of the download queue of the server, or false if it doesn't shed load.
+func (s *Server) LoadStats() (LoadStats, bool) {
+	if s.loadShedder == nil {
+		return LoadStats{}, false
+	}
+	return s.loadShedder.Stats(), true
+}
+
 type ServerArgs struct {
 	Logger   *logrus.Entry
 	HttpHost string
@@ -63,6 +74,18 @@ type ServerArgs struct {
 
 	HttpInterceptor func(http.Handler) http.Handler
 
+	// If supplied, it's asked about the fetches and pushes of every
+	// request, and about each ref a push updates.
+	Authorizer Authorizer
+
+	// If supplied, it returns the protection of the branches of a
+	// repository, and pushes which break it are rejected.
+	BranchProtection func(repoPath string) doltdb.BranchProtection
+
+	// If supplied, table file downloads are served up to its limits, and
+	// the ones beyond them are queued or rejected.
+	LoadShedding *LoadSheddingConfig
+
 	// If supplied, the listener(s) returned from Listeners() will be TLS
 	// listeners. The scheme used in the URLs returned from the gRPC server
 	// will be https.
@@ -91,13 +114,20 @@ func NewServer(args ServerArgs) (*Server, error) {
 	s.wg.Add(2)
 	s.grpcListenAddr = args.GrpcListenAddr
 	s.grpcSrv = grpc.NewServer(append([]grpc.ServerOption{grpc.MaxRecvMsgSize(128 * 1024 * 1024)}, args.Options...)...)
-	var chnkSt remotesapi.ChunkStoreServiceServer = NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, sealer)
+	rcs := NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, sealer)
+	rcs.authorizer = args.Authorizer
+	rcs.branchProtection = args.BranchProtection
+	var chnkSt remotesapi.ChunkStoreServiceServer = rcs
 	if args.ReadOnly {
 		chnkSt = ReadOnlyChunkStore{chnkSt}
 	}
 	remotesapi.RegisterChunkStoreServiceServer(s.grpcSrv, chnkSt)
 
 	var handler http.Handler = newFileHandler(args.Logger, args.DBCache, args.FS, args.ReadOnly, sealer)
+	if args.LoadShedding != nil {
+		s.loadShedder = newLoadShedder(args.Logger, *args.LoadShedding)
+		handler = s.loadShedder.Wrap(handler)
+	}
 	if args.HttpInterceptor != nil {
 		handler = args.HttpInterceptor(handler)
 	}
@@ -0,0 +1,189 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenRule grants the operations |ops| on the refs matching |ref| of the repositories matching |repo| to the clients
+// with the token |token|.
+type tokenRule struct {
+	token string
+	repo  string
+	ref   string
+	ops   map[Operation]struct{}
+}
+
+// TokenFileAuthorizer is an Authorizer with rules read from a token file. Each line of the file is a rule with four
+// fields separated by whitespace:
+//
+//	<token> <repo pattern> <ref pattern> <operations>
+//
+// The patterns are matched with path.Match against the path and each of its parent directories, so "org/*" matches
+// every repository of org, "refs/heads/*" every branch, and "*" everything. The operations are a comma separated list
+// of fetch, push and force-push. A push fetches the root of the repository and the chunks it already has, so push
+// grants fetch too, and force-push grants both.
+// Empty lines and lines starting with # are ignored.
+//
+// A client presents its token as a bearer token, or as the password of basic authentication, which is what dolt sends
+// for `dolt push --user` with DOLT_REMOTE_PASSWORD.
+type TokenFileAuthorizer struct {
+	rules []tokenRule
+}
+
+var _ Authorizer = (*TokenFileAuthorizer)(nil)
+
+// NewTokenFileAuthorizer reads the rules of the token file at |path|.
+func NewTokenFileAuthorizer(path string) (*TokenFileAuthorizer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	a, err := ReadTokenFileAuthorizer(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading token file %s: %w", path, err)
+	}
+	return a, nil
+}
+
+// ReadTokenFileAuthorizer reads the rules of a token file from |r|.
+func ReadTokenFileAuthorizer(r io.Reader) (*TokenFileAuthorizer, error) {
+	a := &TokenFileAuthorizer{}
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: expected <token> <repo pattern> <ref pattern> <operations>, found %d fields", lineNum, len(fields))
+		}
+		rule := tokenRule{token: fields[0], repo: fields[1], ref: fields[2], ops: make(map[Operation]struct{})}
+		for _, pattern := range []string{rule.repo, rule.ref} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("line %d: invalid pattern '%s'", lineNum, pattern)
+			}
+		}
+		for _, op := range strings.Split(fields[3], ",") {
+			switch Operation(op) {
+			case OperationFetch:
+				rule.ops[OperationFetch] = struct{}{}
+			case OperationPush:
+				rule.ops[OperationFetch] = struct{}{}
+				rule.ops[OperationPush] = struct{}{}
+			case OperationForcePush:
+				rule.ops[OperationFetch] = struct{}{}
+				rule.ops[OperationPush] = struct{}{}
+				rule.ops[OperationForcePush] = struct{}{}
+			default:
+				return nil, fmt.Errorf("line %d: unknown operation '%s'", lineNum, op)
+			}
+		}
+		a.rules = append(a.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Authorize implements Authorizer
+func (a *TokenFileAuthorizer) Authorize(ctx context.Context, req AuthRequest) error {
+	token, ok := getRequestToken(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	for _, rule := range a.rules {
+		if subtle.ConstantTimeCompare([]byte(rule.token), []byte(token)) == 0 {
+			continue
+		}
+		if _, ok := rule.ops[req.Operation]; !ok {
+			continue
+		}
+		if !matchPath(rule.repo, req.RepoPath) {
+			continue
+		}
+		// requests which aren't limited to a ref yet are allowed if any ref is
+		if req.Ref == "" {
+			return nil
+		}
+		if matchPath(rule.ref, req.Ref) {
+			return nil
+		}
+	}
+
+	if req.Ref == "" {
+		return status.Errorf(codes.PermissionDenied, "%s of %s is not allowed", req.Operation, req.RepoPath)
+	}
+	return status.Errorf(codes.PermissionDenied, "%s to %s of %s is not allowed", req.Operation, req.Ref, req.RepoPath)
+}
+
+// matchPath returns whether |pattern| matches |name| or one of its parent directories.
+func matchPath(pattern, name string) bool {
+	for {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		i := strings.LastIndexByte(name, '/')
+		if i < 0 {
+			return false
+		}
+		name = name[:i]
+	}
+}
+
+// getRequestToken returns the bearer token or the basic authentication password of the incoming request of |ctx|.
+func getRequestToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	auths := md.Get("authorization")
+	if len(auths) != 1 {
+		return "", false
+	}
+
+	auth := auths[0]
+	if strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer "), true
+	}
+	if strings.HasPrefix(auth, "Basic ") {
+		dec, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(auth, "Basic "))
+		if err != nil {
+			return "", false
+		}
+		_, password, ok := strings.Cut(string(dec), ":")
+		return password, ok
+	}
+	return "", false
+}
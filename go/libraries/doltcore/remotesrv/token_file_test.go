@@ -0,0 +1,86 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testTokenFile = `
+# token   repo       ref              operations
+reader    org/*      *                fetch
+dev       org/repo   refs/heads/dev*  push
+admin     org/repo   refs/*           force-push
+`
+
+func TestTokenFileAuthorizer(t *testing.T) {
+	a, err := ReadTokenFileAuthorizer(strings.NewReader(testTokenFile))
+	require.NoError(t, err)
+
+	bearer := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	basic := func(user, password string) context.Context {
+		auth := base64.URLEncoding.EncodeToString([]byte(user + ":" + password))
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic "+auth))
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  AuthRequest
+		code codes.Code
+	}{
+		{"fetch", bearer("reader"), AuthRequest{RepoPath: "org/other", Operation: OperationFetch}, codes.OK},
+		{"fetch of another org", bearer("reader"), AuthRequest{RepoPath: "other/repo", Operation: OperationFetch}, codes.PermissionDenied},
+		{"push without push", bearer("reader"), AuthRequest{RepoPath: "org/repo", Operation: OperationPush}, codes.PermissionDenied},
+		{"push grants fetch", bearer("dev"), AuthRequest{RepoPath: "org/repo", Operation: OperationFetch}, codes.OK},
+		{"push to any ref", bearer("dev"), AuthRequest{RepoPath: "org/repo", Operation: OperationPush}, codes.OK},
+		{"push to a matching ref", bearer("dev"), AuthRequest{RepoPath: "org/repo", Ref: "refs/heads/dev-1", Operation: OperationPush}, codes.OK},
+		{"push to another ref", bearer("dev"), AuthRequest{RepoPath: "org/repo", Ref: "refs/heads/main", Operation: OperationPush}, codes.PermissionDenied},
+		{"force-push without force-push", bearer("dev"), AuthRequest{RepoPath: "org/repo", Ref: "refs/heads/dev-1", Operation: OperationForcePush}, codes.PermissionDenied},
+		{"force-push", basic("user", "admin"), AuthRequest{RepoPath: "org/repo", Ref: "refs/heads/main", Operation: OperationForcePush}, codes.OK},
+		{"push to a ref of a branch directory", bearer("dev"), AuthRequest{RepoPath: "org/repo", Ref: "refs/heads/dev/1", Operation: OperationPush}, codes.OK},
+		{"force-push grants push", basic("user", "admin"), AuthRequest{RepoPath: "org/repo", Ref: "refs/tags/v1", Operation: OperationPush}, codes.OK},
+		{"unknown token", bearer("other"), AuthRequest{RepoPath: "org/repo", Operation: OperationFetch}, codes.PermissionDenied},
+		{"no token", context.Background(), AuthRequest{RepoPath: "org/repo", Operation: OperationFetch}, codes.Unauthenticated},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := a.Authorize(test.ctx, test.req)
+			assert.Equal(t, test.code, status.Code(err))
+		})
+	}
+}
+
+func TestReadTokenFileAuthorizerErrors(t *testing.T) {
+	for _, file := range []string{
+		"token org/repo fetch",
+		"token org/repo * fetch,delete",
+		"token org/[repo * fetch",
+	} {
+		_, err := ReadTokenFileAuthorizer(strings.NewReader(file))
+		assert.Error(t, err, file)
+	}
+}
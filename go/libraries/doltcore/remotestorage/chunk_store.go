@@ -62,6 +62,7 @@ var ErrUploadFailed = errors.New("upload failed")
 var globalHttpFetcher HTTPFetcher = &http.Client{}
 
 var _ chunks.TableFileStore = (*DoltChunkStore)(nil)
+var _ chunks.ResumableTableFileWriter = (*DoltChunkStore)(nil)
 var _ nbs.NBSCompressedChunkStore = (*DoltChunkStore)(nil)
 var _ chunks.ChunkStore = (*DoltChunkStore)(nil)
 var _ chunks.LoggingChunkStore = (*DoltChunkStore)(nil)
@@ -980,9 +981,9 @@ func (dcs *DoltChunkStore) uploadChunks(ctx context.Context) (map[hash.Hash]int,
 
 	for h, contentHash := range hashToContentHash {
 		// Can parallelize this in the future if needed
-		err := dcs.uploadTableFileWithRetries(ctx, h, uint64(hashToCount[h]), contentHash, func() (io.ReadCloser, uint64, error) {
-			data := hashToData[h]
-			return io.NopCloser(bytes.NewReader(data)), uint64(len(data)), nil
+		data := hashToData[h]
+		err := dcs.uploadTableFileWithRetries(ctx, h, uint64(hashToCount[h]), contentHash, uint64(len(data)), func(offset uint64) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data[offset:])), nil
 		})
 		if err != nil {
 			return map[hash.Hash]int{}, err
@@ -992,13 +993,11 @@ func (dcs *DoltChunkStore) uploadChunks(ctx context.Context) (map[hash.Hash]int,
 	return hashToCount, nil
 }
 
-func (dcs *DoltChunkStore) uploadTableFileWithRetries(ctx context.Context, tableFileId hash.Hash, numChunks uint64, tableFileContentHash []byte, getContent func() (io.ReadCloser, uint64, error)) error {
+// uploadTableFileWithRetries uploads the table file of |contentLength| bytes read from the readers returned by
+// |getContent|, which start at the offset they're called with. If the server supports it, the table file is uploaded in
+// parts, and retries resume from the bytes the server has received rather than from the first byte.
+func (dcs *DoltChunkStore) uploadTableFileWithRetries(ctx context.Context, tableFileId hash.Hash, numChunks uint64, tableFileContentHash []byte, contentLength uint64, getContent func(offset uint64) (io.ReadCloser, error)) error {
 	op := func() error {
-		body, contentLength, err := getContent()
-		if err != nil {
-			return err
-		}
-
 		tbfd := &remotesapi.TableFileDetails{
 			Id:            tableFileId[:],
 			ContentLength: contentLength,
@@ -1037,6 +1036,27 @@ func (dcs *DoltChunkStore) uploadTableFileWithRetries(ctx context.Context, table
 				urlStr = urlStr[:qmIdx]
 			}
 
+			if contentLength > 0 {
+				offset, resumable, err := QueryUploadOffset(ctx, dcs.httpFetcher, typedLoc.HttpPost)
+				if err != nil {
+					return err
+				}
+				if resumable {
+					dcs.logf("uploading file %s to %s in parts from offset %d", tableFileId.String(), urlStr, offset)
+					err = dcs.uploadParts(ctx, typedLoc.HttpPost, contentLength, offset, getContent)
+					if err != nil {
+						dcs.logf("failed to upload file %s to %s, err: %v", tableFileId.String(), urlStr, err)
+						return err
+					}
+					dcs.logf("successfully uploaded file %s to %s", tableFileId.String(), urlStr)
+					return nil
+				}
+			}
+
+			body, err := getContent(0)
+			if err != nil {
+				return err
+			}
 			dcs.logf("uploading file %s to %s", tableFileId.String(), urlStr)
 			err = dcs.httpPostUpload(ctx, typedLoc.HttpPost, tableFileContentHash, int64(contentLength), body)
 			if err != nil {
@@ -1286,12 +1306,34 @@ func (dcs *DoltChunkStore) SupportedOperations() chunks.TableFileStoreOps {
 
 // WriteTableFile reads a table file from the provided reader and writes it to the chunk store.
 func (dcs *DoltChunkStore) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
-	fileIdBytes := hash.Parse(fileId)
-	err := dcs.uploadTableFileWithRetries(ctx, fileIdBytes, uint64(numChunks), contentHash, getRd)
+	// the length of the table file is only known once it's been opened
+	first, contentLength, err := getRd()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer func() {
+		if first != nil {
+			_ = first.Close()
+		}
+	}()
+
+	return dcs.WriteTableFileResumable(ctx, fileId, numChunks, contentHash, contentLength, func(offset uint64) (io.ReadCloser, error) {
+		rd := first
+		first = nil
+		if rd == nil {
+			if rd, _, err = getRd(); err != nil {
+				return nil, err
+			}
+		}
+		return chunks.ReaderFromOffset(rd, offset)
+	})
+}
+
+// WriteTableFileResumable reads a table file from the readers returned by |getRd| and writes it to the remote. If the
+// remote supports it, a failed upload resumes from the bytes it has received rather than from the first byte.
+func (dcs *DoltChunkStore) WriteTableFileResumable(ctx context.Context, fileId string, numChunks int, contentHash []byte, contentLength uint64, getRd func(offset uint64) (io.ReadCloser, error)) error {
+	fileIdBytes := hash.Parse(fileId)
+	return dcs.uploadTableFileWithRetries(ctx, fileIdBytes, uint64(numChunks), contentHash, contentLength, getRd)
 }
 
 // AddTableFilesToManifest adds table files to the manifest
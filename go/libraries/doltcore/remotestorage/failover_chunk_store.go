@@ -0,0 +1,386 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// ErrMirrorReadOnly is returned by a FailoverChunkStore for writes once it has failed over from its primary endpoint.
+var ErrMirrorReadOnly = errors.New("mirrors of a remote can't be written to through it; push to them directly")
+
+// FailoverEndpoint is one of the endpoints of a remote, such as its primary URL or one of its mirrors.
+type FailoverEndpoint struct {
+	Url string
+	// Load opens the chunk store of the endpoint. It's called the first time the endpoint is used.
+	Load func(ctx context.Context) (chunks.ChunkStore, error)
+}
+
+// FailoverChunkStore is a chunks.ChunkStore over a remote with several endpoints serving the same database, e.g. a
+// gRPC remote and its S3 mirror. It reads from one endpoint at a time, starting with the first, and fails over to the
+// next when a read fails, so an operation in progress carries on from the chunks it already has rather than starting
+// over. Writes always go to the first endpoint: they fail once the store has failed over, and the store no longer
+// fails over once it has been written to.
+type FailoverChunkStore struct {
+	endpoints []FailoverEndpoint
+
+	mu      sync.Mutex
+	active  int
+	cs      chunks.ChunkStore
+	written bool
+	// errs are the errors of the endpoints that were failed over from
+	errs []error
+}
+
+var _ chunks.ChunkStore = (*FailoverChunkStore)(nil)
+var _ chunks.TableFileStore = (*FailoverChunkStore)(nil)
+var _ nbs.NBSCompressedChunkStore = (*FailoverChunkStore)(nil)
+
+// NewFailoverChunkStore returns a FailoverChunkStore over |endpoints|, having loaded the first of them which loads
+// successfully.
+func NewFailoverChunkStore(ctx context.Context, endpoints []FailoverEndpoint) (*FailoverChunkStore, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("a failover chunk store needs at least one endpoint")
+	}
+	fcs := &FailoverChunkStore{endpoints: endpoints, active: -1}
+	if _, _, err := fcs.failoverFrom(ctx, -1, nil); err != nil {
+		return nil, err
+	}
+	return fcs, nil
+}
+
+// ActiveUrl returns the URL of the endpoint the store is reading from, or an empty string if every endpoint failed.
+func (fcs *FailoverChunkStore) ActiveUrl() string {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+	if fcs.active >= len(fcs.endpoints) {
+		return ""
+	}
+	return fcs.endpoints[fcs.active].Url
+}
+
+// current returns the chunk store of the active endpoint.
+func (fcs *FailoverChunkStore) current() (chunks.ChunkStore, int) {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+	return fcs.cs, fcs.active
+}
+
+// failoverFrom records |err|, the error of the endpoint |from|, and makes the next endpoint that loads successfully
+// the active one. If another caller already failed over from |from|, the endpoint it failed over to is kept. It
+// returns the chunk store and index of the new active endpoint, or an error describing the failures of every endpoint
+// if none is left.
+func (fcs *FailoverChunkStore) failoverFrom(ctx context.Context, from int, err error) (chunks.ChunkStore, int, error) {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+	if from != fcs.active {
+		return fcs.cs, fcs.active, nil
+	}
+	if err != nil {
+		if fcs.written {
+			return nil, from, err
+		}
+		fcs.errs = append(fcs.errs, fmt.Errorf("%s: %w", fcs.endpoints[from].Url, err))
+	}
+	if fcs.cs != nil {
+		_ = fcs.cs.Close()
+		fcs.cs = nil
+	}
+	for i := from + 1; i < len(fcs.endpoints); i++ {
+		if ctx.Err() != nil {
+			return nil, from, ctx.Err()
+		}
+		cs, err := fcs.endpoints[i].Load(ctx)
+		if err != nil {
+			fcs.errs = append(fcs.errs, fmt.Errorf("%s: %w", fcs.endpoints[i].Url, err))
+			continue
+		}
+		fcs.active, fcs.cs = i, cs
+		return cs, i, nil
+	}
+	fcs.active = len(fcs.endpoints)
+	return nil, fcs.active, fmt.Errorf("every endpoint of the remote failed: %s", fcs.endpointErrors())
+}
+
+// endpointErrors describes the errors of the endpoints that were failed over from.
+func (fcs *FailoverChunkStore) endpointErrors() string {
+	msgs := make([]string, len(fcs.errs))
+	for i, err := range fcs.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// read runs |f| against the active endpoint, failing over to the next endpoints until it succeeds or none is left.
+func (fcs *FailoverChunkStore) read(ctx context.Context, f func(cs chunks.ChunkStore) error) error {
+	cs, active := fcs.current()
+	for {
+		if cs == nil {
+			fcs.mu.Lock()
+			defer fcs.mu.Unlock()
+			return fmt.Errorf("every endpoint of the remote failed: %s", fcs.endpointErrors())
+		}
+		err := f(cs)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if cs, active, err = fcs.failoverFrom(ctx, active, err); err != nil {
+			return err
+		}
+	}
+}
+
+// write runs |f| against the primary endpoint, or returns ErrMirrorReadOnly if the store has failed over.
+func (fcs *FailoverChunkStore) write(f func(cs chunks.ChunkStore) error) error {
+	fcs.mu.Lock()
+	if fcs.active != 0 {
+		fcs.mu.Unlock()
+		return fmt.Errorf("%w; %s", ErrMirrorReadOnly, fcs.endpointErrors())
+	}
+	fcs.written = true
+	cs := fcs.cs
+	fcs.mu.Unlock()
+	return f(cs)
+}
+
+func (fcs *FailoverChunkStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	var c chunks.Chunk
+	err := fcs.read(ctx, func(cs chunks.ChunkStore) (err error) {
+		c, err = cs.Get(ctx, h)
+		return err
+	})
+	return c, err
+}
+
+// GetMany implements chunks.ChunkStore. After a failover, only the chunks which weren't found yet are read from the
+// next endpoint.
+func (fcs *FailoverChunkStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	var mu sync.Mutex
+	remaining := hashes.Copy()
+	return fcs.read(ctx, func(cs chunks.ChunkStore) error {
+		mu.Lock()
+		batch := remaining.Copy()
+		mu.Unlock()
+		return cs.GetMany(ctx, batch, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			first := remaining.Has(c.Hash())
+			remaining.Remove(c.Hash())
+			mu.Unlock()
+			if first {
+				found(ctx, c)
+			}
+		})
+	})
+}
+
+// GetManyCompressed implements nbs.NBSCompressedChunkStore. After a failover, only the chunks which weren't found yet
+// are read from the next endpoint.
+func (fcs *FailoverChunkStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.CompressedChunk)) error {
+	var mu sync.Mutex
+	remaining := hashes.Copy()
+	return fcs.read(ctx, func(cs chunks.ChunkStore) error {
+		ccs, ok := cs.(nbs.NBSCompressedChunkStore)
+		if !ok {
+			return errors.New("the chunk store of the endpoint can't read compressed chunks")
+		}
+		mu.Lock()
+		batch := remaining.Copy()
+		mu.Unlock()
+		return ccs.GetManyCompressed(ctx, batch, func(ctx context.Context, c nbs.CompressedChunk) {
+			mu.Lock()
+			first := remaining.Has(c.H)
+			remaining.Remove(c.H)
+			mu.Unlock()
+			if first {
+				found(ctx, c)
+			}
+		})
+	})
+}
+
+func (fcs *FailoverChunkStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	var has bool
+	err := fcs.read(ctx, func(cs chunks.ChunkStore) (err error) {
+		has, err = cs.Has(ctx, h)
+		return err
+	})
+	return has, err
+}
+
+func (fcs *FailoverChunkStore) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	var absent hash.HashSet
+	err := fcs.read(ctx, func(cs chunks.ChunkStore) (err error) {
+		absent, err = cs.HasMany(ctx, hashes)
+		return err
+	})
+	return absent, err
+}
+
+func (fcs *FailoverChunkStore) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCb) error {
+	return fcs.write(func(cs chunks.ChunkStore) error {
+		return cs.Put(ctx, c, getAddrs)
+	})
+}
+
+func (fcs *FailoverChunkStore) Version() string {
+	cs, _ := fcs.current()
+	if cs == nil {
+		return ""
+	}
+	return cs.Version()
+}
+
+func (fcs *FailoverChunkStore) Rebase(ctx context.Context) error {
+	return fcs.read(ctx, func(cs chunks.ChunkStore) error {
+		return cs.Rebase(ctx)
+	})
+}
+
+func (fcs *FailoverChunkStore) Root(ctx context.Context) (hash.Hash, error) {
+	var root hash.Hash
+	err := fcs.read(ctx, func(cs chunks.ChunkStore) (err error) {
+		root, err = cs.Root(ctx)
+		return err
+	})
+	return root, err
+}
+
+func (fcs *FailoverChunkStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	var ok bool
+	err := fcs.write(func(cs chunks.ChunkStore) (err error) {
+		ok, err = cs.Commit(ctx, current, last)
+		return err
+	})
+	return ok, err
+}
+
+func (fcs *FailoverChunkStore) Stats() interface{} {
+	cs, _ := fcs.current()
+	if cs == nil {
+		return nil
+	}
+	return cs.Stats()
+}
+
+func (fcs *FailoverChunkStore) StatsSummary() string {
+	cs, _ := fcs.current()
+	if cs == nil {
+		return ""
+	}
+	return cs.StatsSummary()
+}
+
+func (fcs *FailoverChunkStore) Close() error {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+	if fcs.cs == nil {
+		return nil
+	}
+	err := fcs.cs.Close()
+	fcs.cs = nil
+	return err
+}
+
+func tableFileStore(cs chunks.ChunkStore) (chunks.TableFileStore, error) {
+	tfs, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return nil, errors.New("the chunk store of the endpoint is not a table file store")
+	}
+	return tfs, nil
+}
+
+func (fcs *FailoverChunkStore) Sources(ctx context.Context) (hash.Hash, []chunks.TableFile, []chunks.TableFile, error) {
+	var root hash.Hash
+	var tableFiles, appendixFiles []chunks.TableFile
+	err := fcs.read(ctx, func(cs chunks.ChunkStore) error {
+		tfs, err := tableFileStore(cs)
+		if err != nil {
+			return err
+		}
+		root, tableFiles, appendixFiles, err = tfs.Sources(ctx)
+		return err
+	})
+	return root, tableFiles, appendixFiles, err
+}
+
+func (fcs *FailoverChunkStore) Size(ctx context.Context) (uint64, error) {
+	var size uint64
+	err := fcs.read(ctx, func(cs chunks.ChunkStore) error {
+		tfs, err := tableFileStore(cs)
+		if err != nil {
+			return err
+		}
+		size, err = tfs.Size(ctx)
+		return err
+	})
+	return size, err
+}
+
+func (fcs *FailoverChunkStore) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
+	return fcs.write(func(cs chunks.ChunkStore) error {
+		tfs, err := tableFileStore(cs)
+		if err != nil {
+			return err
+		}
+		return tfs.WriteTableFile(ctx, fileId, numChunks, contentHash, getRd)
+	})
+}
+
+func (fcs *FailoverChunkStore) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int) error {
+	return fcs.write(func(cs chunks.ChunkStore) error {
+		tfs, err := tableFileStore(cs)
+		if err != nil {
+			return err
+		}
+		return tfs.AddTableFilesToManifest(ctx, fileIdToNumChunks)
+	})
+}
+
+func (fcs *FailoverChunkStore) PruneTableFiles(ctx context.Context) error {
+	return fcs.write(func(cs chunks.ChunkStore) error {
+		tfs, err := tableFileStore(cs)
+		if err != nil {
+			return err
+		}
+		return tfs.PruneTableFiles(ctx)
+	})
+}
+
+func (fcs *FailoverChunkStore) SetRootChunk(ctx context.Context, root, previous hash.Hash) error {
+	return fcs.write(func(cs chunks.ChunkStore) error {
+		tfs, err := tableFileStore(cs)
+		if err != nil {
+			return err
+		}
+		return tfs.SetRootChunk(ctx, root, previous)
+	})
+}
+
+func (fcs *FailoverChunkStore) SupportedOperations() chunks.TableFileStoreOps {
+	cs, _ := fcs.current()
+	if tfs, ok := cs.(chunks.TableFileStore); ok {
+		return tfs.SupportedOperations()
+	}
+	return chunks.TableFileStoreOps{}
+}
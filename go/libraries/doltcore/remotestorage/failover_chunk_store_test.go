@@ -0,0 +1,155 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var errEndpointDown = errors.New("endpoint down")
+
+// failingStore is a chunks.ChunkStore whose GetMany fails after it has found |limit| chunks.
+type failingStore struct {
+	chunks.ChunkStore
+	limit int
+}
+
+func (s *failingStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	n := 0
+	var err error
+	getErr := s.ChunkStore.GetMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+		if n >= s.limit {
+			err = errEndpointDown
+			return
+		}
+		n++
+		found(ctx, c)
+	})
+	if getErr != nil {
+		return getErr
+	}
+	return err
+}
+
+func testEndpoints(t *testing.T, n int) ([]FailoverEndpoint, []chunks.ChunkStore, hash.HashSet) {
+	ctx := context.Background()
+	stores := make([]chunks.ChunkStore, n)
+	endpoints := make([]FailoverEndpoint, n)
+	hashes := hash.NewHashSet()
+	for i := range stores {
+		stores[i] = (&chunks.MemoryStorage{}).NewView()
+		i := i
+		endpoints[i] = FailoverEndpoint{
+			Url: fmt.Sprintf("mem://%d", i),
+			Load: func(ctx context.Context) (chunks.ChunkStore, error) {
+				return stores[i], nil
+			},
+		}
+	}
+	for i := 0; i < 10; i++ {
+		c := chunks.NewChunk([]byte(fmt.Sprintf("chunk %d", i)))
+		hashes.Insert(c.Hash())
+		for _, cs := range stores {
+			require.NoError(t, cs.Put(ctx, c, func(ctx context.Context, c chunks.Chunk) (hash.HashSet, error) {
+				return nil, nil
+			}))
+		}
+	}
+	return endpoints, stores, hashes
+}
+
+func TestFailoverChunkStoreLoad(t *testing.T) {
+	ctx := context.Background()
+	endpoints, _, hashes := testEndpoints(t, 2)
+	endpoints[0].Load = func(ctx context.Context) (chunks.ChunkStore, error) {
+		return nil, errEndpointDown
+	}
+
+	fcs, err := NewFailoverChunkStore(ctx, endpoints)
+	require.NoError(t, err)
+	assert.Equal(t, "mem://1", fcs.ActiveUrl())
+
+	absent, err := fcs.HasMany(ctx, hashes)
+	require.NoError(t, err)
+	assert.Empty(t, absent)
+
+	endpoints[1].Load = endpoints[0].Load
+	_, err = NewFailoverChunkStore(ctx, endpoints)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mem://0: endpoint down")
+	assert.Contains(t, err.Error(), "mem://1: endpoint down")
+}
+
+func TestFailoverChunkStoreGetMany(t *testing.T) {
+	ctx := context.Background()
+	endpoints, stores, hashes := testEndpoints(t, 3)
+	// the first endpoint fails after 3 chunks and the second after 4 more, so the last 3 come from the third
+	for i, limit := range []int{3, 4} {
+		flaky := &failingStore{ChunkStore: stores[i], limit: limit}
+		endpoints[i].Load = func(ctx context.Context) (chunks.ChunkStore, error) {
+			return flaky, nil
+		}
+	}
+
+	fcs, err := NewFailoverChunkStore(ctx, endpoints)
+	require.NoError(t, err)
+
+	found := make(map[hash.Hash]int)
+	err = fcs.GetMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+		found[c.Hash()]++
+	})
+	require.NoError(t, err)
+	assert.Len(t, found, hashes.Size())
+	for h, n := range found {
+		assert.True(t, hashes.Has(h))
+		assert.Equal(t, 1, n, "chunk %s was found more than once", h.String())
+	}
+	assert.Equal(t, "mem://2", fcs.ActiveUrl())
+
+	// writes only go to the first endpoint
+	err = fcs.Put(ctx, chunks.NewChunk([]byte("new")), func(ctx context.Context, c chunks.Chunk) (hash.HashSet, error) {
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, ErrMirrorReadOnly)
+}
+
+func TestFailoverChunkStoreNoFailoverAfterWrite(t *testing.T) {
+	ctx := context.Background()
+	endpoints, stores, hashes := testEndpoints(t, 2)
+	flaky := &failingStore{ChunkStore: stores[0], limit: 1}
+	endpoints[0].Load = func(ctx context.Context) (chunks.ChunkStore, error) {
+		return flaky, nil
+	}
+
+	fcs, err := NewFailoverChunkStore(ctx, endpoints)
+	require.NoError(t, err)
+	err = fcs.Put(ctx, chunks.NewChunk([]byte("new")), func(ctx context.Context, c chunks.Chunk) (hash.HashSet, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	err = fcs.GetMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {})
+	assert.ErrorIs(t, err, errEndpointDown)
+	assert.Equal(t, "mem://0", fcs.ActiveUrl())
+}
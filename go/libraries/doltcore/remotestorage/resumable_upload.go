@@ -0,0 +1,133 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+)
+
+// UploadOffsetHeader is the header of requests uploading a part of a table file, giving the offset of the part in the
+// table file, and of the responses to them and to HEAD requests to the upload URL, giving the number of bytes of the
+// table file the server has received. Servers which set it in their responses to HEAD requests support uploading table
+// files in parts, which lets uploads resume after a failure rather than restarting from the first byte.
+const UploadOffsetHeader = "X-Dolt-Upload-Offset"
+
+// uploadPartSize is the size of the parts table files are uploaded in when the server supports it.
+var uploadPartSize uint64 = 16 * 1024 * 1024
+
+var errNoUploadProgress = errors.New("upload of table file part made no progress")
+
+// QueryUploadOffset asks the server at the upload URL of |post| how many bytes of the table file it has received. It
+// returns false if the server doesn't support uploading table files in parts.
+func QueryUploadOffset(ctx context.Context, httpFetcher HTTPFetcher, post *remotesapi.HttpPostTableFile) (uint64, bool, error) {
+	fetcher := globalHttpFetcher
+	if httpFetcher != nil {
+		fetcher = httpFetcher
+	}
+
+	req, err := http.NewRequest(http.MethodHead, post.Url, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := fetcher.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, false, processHttpResp(resp, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	offsetStr := resp.Header.Get(UploadOffsetHeader)
+	if resp.StatusCode != http.StatusOK || offsetStr == "" {
+		return 0, false, nil
+	}
+	offset, err := strconv.ParseUint(offsetStr, 10, 64)
+	if err != nil {
+		return 0, false, nil
+	}
+	return offset, true, nil
+}
+
+// HttpPutUploadPart uploads the |length| bytes read from |body|, which start at |offset| in a table file, to the
+// upload URL of |post|. It returns the number of bytes of the table file the server has received, which is the offset
+// of the next part to upload.
+func HttpPutUploadPart(ctx context.Context, httpFetcher HTTPFetcher, post *remotesapi.HttpPostTableFile, offset uint64, length int64, body io.Reader) (uint64, error) {
+	fetcher := globalHttpFetcher
+	if httpFetcher != nil {
+		fetcher = httpFetcher
+	}
+
+	req, err := http.NewRequest(http.MethodPut, post.Url, io.LimitReader(body, length))
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = length
+	req.Header.Set(UploadOffsetHeader, strconv.FormatUint(offset, 10))
+
+	resp, err := fetcher.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, processHttpResp(resp, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	received, parseErr := strconv.ParseUint(resp.Header.Get(UploadOffsetHeader), 10, 64)
+	if resp.StatusCode == http.StatusConflict && parseErr == nil {
+		// the server has received a different number of bytes than we thought, so we carry on from there
+		return received, nil
+	}
+	if err = processHttpResp(resp, nil); err != nil {
+		return 0, err
+	}
+	if parseErr != nil {
+		return 0, fmt.Errorf("%w: missing %s in response", ErrUploadFailed, UploadOffsetHeader)
+	}
+	return received, nil
+}
+
+// uploadParts uploads the table file of |contentLength| bytes read from the readers returned by |getContent| to the
+// upload URL of |post| in parts, starting at |offset|, the number of bytes the server has already received.
+func (dcs *DoltChunkStore) uploadParts(ctx context.Context, post *remotesapi.HttpPostTableFile, contentLength, offset uint64, getContent func(offset uint64) (io.ReadCloser, error)) error {
+	for offset < contentLength {
+		length := contentLength - offset
+		if length > uploadPartSize {
+			length = uploadPartSize
+		}
+
+		body, err := getContent(offset)
+		if err != nil {
+			return err
+		}
+		received, err := HttpPutUploadPart(ctx, dcs.httpFetcher, post, offset, int64(length), body)
+		_ = body.Close()
+		if err != nil {
+			return err
+		}
+		if received == offset {
+			return errNoUploadProgress
+		}
+		offset = received
+	}
+	return nil
+}
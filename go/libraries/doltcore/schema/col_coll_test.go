@@ -25,10 +25,10 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
-var firstNameCol = Column{"first", 0, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil}
-var lastNameCol = Column{"last", 1, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil}
-var firstNameCapsCol = Column{"FiRsT", 2, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil}
-var lastNameCapsCol = Column{"LAST", 3, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil}
+var firstNameCol = Column{"first", 0, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false}
+var lastNameCol = Column{"last", 1, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false}
+var firstNameCapsCol = Column{"FiRsT", 2, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false}
+var lastNameCapsCol = Column{"LAST", 3, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false}
 
 func TestGetByNameAndTag(t *testing.T) {
 	cols := []Column{firstNameCol, lastNameCol, firstNameCapsCol, lastNameCapsCol}
@@ -102,18 +102,18 @@ func TestGetByNameCaseInsensitive(t *testing.T) {
 
 func TestAppendAndItrInSortOrder(t *testing.T) {
 	cols := []Column{
-		{"0", 0, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"2", 2, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"4", 4, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"3", 3, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"1", 1, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
+		{"0", 0, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"2", 2, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"4", 4, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"3", 3, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"1", 1, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
 	}
 	cols2 := []Column{
-		{"7", 7, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"9", 9, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"5", 5, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"8", 8, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-		{"6", 6, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
+		{"7", 7, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"9", 9, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"5", 5, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"8", 8, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+		{"6", 6, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
 	}
 
 	colColl := NewColCollection(cols...)
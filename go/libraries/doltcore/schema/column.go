@@ -46,6 +46,8 @@ var (
 		false,
 		"",
 		nil,
+		nil,
+		false,
 	}
 )
 
@@ -84,6 +86,14 @@ type Column struct {
 
 	// Constraints are rules that can be checked on each column to say if the columns value is valid
 	Constraints []ColConstraint
+
+	// Dictionary are the strings stored as codes of a dictionary in the rows of this column, if it's dictionary
+	// encoded. Only non-primary key string columns can be.
+	Dictionary []string
+
+	// FixedPointDecimal says whether the values of this DECIMAL column are stored with the fixed-point encoding,
+	// val.Decimal64Enc. Only non-primary key columns with a precision of at most val.MaxDecimal64Precision can be.
+	FixedPointDecimal bool
 }
 
 // NewColumn creates a Column instance with the default type info for the NomsKind
@@ -118,6 +128,8 @@ func NewColumnWithTypeInfo(name string, tag uint64, typeInfo typeinfo.TypeInfo,
 		autoIncrement,
 		comment,
 		constraints,
+		nil,
+		false,
 	}, nil
 }
 
@@ -142,7 +154,33 @@ func (c Column) Equals(other Column) bool {
 		c.IsPartOfPK == other.IsPartOfPK &&
 		c.TypeInfo.Equals(other.TypeInfo) &&
 		c.Default == other.Default &&
-		ColConstraintsAreEqual(c.Constraints, other.Constraints)
+		ColConstraintsAreEqual(c.Constraints, other.Constraints) &&
+		dictionariesAreEqual(c.Dictionary, other.Dictionary) &&
+		c.FixedPointDecimal == other.FixedPointDecimal
+}
+
+// EqualsIgnoringStorage tests equality between two columns, ignoring how they store their values: their Dictionary
+// and whether they use FixedPointDecimal.
+func (c Column) EqualsIgnoringStorage(other Column) bool {
+	c.Dictionary, c.FixedPointDecimal = other.Dictionary, other.FixedPointDecimal
+	return c.Equals(other)
+}
+
+// StorageEquals tests whether two columns store their values the same way.
+func (c Column) StorageEquals(other Column) bool {
+	return dictionariesAreEqual(c.Dictionary, other.Dictionary) && c.FixedPointDecimal == other.FixedPointDecimal
+}
+
+func dictionariesAreEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // Compatible tests compatibility between two columns. Compatible columns have the same tag and can store the same
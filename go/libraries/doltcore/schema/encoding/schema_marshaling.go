@@ -55,6 +55,10 @@ type encodedColumn struct {
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
 
+	FixedPointDecimal bool `noms:"fixed_point_decimal,omitempty" json:"fixed_point_decimal,omitempty"`
+
+	Dictionary []string `noms:"dictionary,omitempty" json:"dictionary,omitempty"`
+
 	// NB: all new fields must have the 'omitempty' annotation. See comment above
 }
 
@@ -100,6 +104,9 @@ func encodeColumn(col schema.Column) encodedColumn {
 		AutoIncrement: col.AutoIncrement,
 		Comment:       col.Comment,
 		Constraints:   encodeAllColConstraints(col.Constraints),
+
+		FixedPointDecimal: col.FixedPointDecimal,
+		Dictionary:        col.Dictionary,
 	}
 }
 
@@ -117,7 +124,13 @@ func (nfd encodedColumn) decodeColumn() (schema.Column, error) {
 		return schema.Column{}, errors.New("cannot decode column due to unknown schema format")
 	}
 	colConstraints := decodeAllColConstraint(nfd.Constraints)
-	return schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, nfd.Default, nfd.AutoIncrement, nfd.Comment, colConstraints...)
+	col, err := schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, nfd.Default, nfd.AutoIncrement, nfd.Comment, colConstraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+	col.FixedPointDecimal = nfd.FixedPointDecimal
+	col.Dictionary = nfd.Dictionary
+	return col, nil
 }
 
 type encodedConstraint struct {
@@ -202,6 +202,10 @@ type testEncodedColumn struct {
 	Comment string `noms:"comment,omitempty" json:"comment,omitempty"`
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
+
+	FixedPointDecimal bool `noms:"fixed_point_decimal,omitempty" json:"fixed_point_decimal,omitempty"`
+
+	Dictionary []string `noms:"dictionary,omitempty" json:"dictionary,omitempty"`
 }
 
 type testEncodedIndex struct {
@@ -279,6 +283,33 @@ func TestSchemaMarshalling(t *testing.T) {
 	}
 }
 
+func TestColumnEncodingMarshalling(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_Default
+	vrw := getTestVRW(nbf)
+
+	pk, err := schema.NewColumnWithTypeInfo("pk", 1, typeinfo.Int64Type, true, "", false, "", schema.NotNullConstraint{})
+	require.NoError(t, err)
+	decType, err := typeinfo.FromSqlType(gmstypes.MustCreateDecimalType(10, 2))
+	require.NoError(t, err)
+	dec, err := schema.NewColumnWithTypeInfo("d", 2, decType, false, "", false, "")
+	require.NoError(t, err)
+	dec.FixedPointDecimal = true
+	str, err := schema.NewColumnWithTypeInfo("s", 3, typeinfo.StringDefaultType, false, "", false, "")
+	require.NoError(t, err)
+	str.Dictionary = []string{"a", "b"}
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(pk, dec, str))
+	require.NoError(t, err)
+	v, err := MarshalSchemaAsNomsValue(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchemaNomsValue(ctx, nbf, v)
+	require.NoError(t, err)
+	assert.True(t, s.GetAllCols().GetByIndex(1).FixedPointDecimal)
+	assert.Equal(t, []string{"a", "b"}, s.GetAllCols().GetByIndex(2).Dictionary)
+	assert.Equal(t, sch.GetValueDescriptor(), s.GetValueDescriptor())
+}
+
 func getTypeinfo(t *testing.T) (ti []typeinfo.TypeInfo) {
 	st := getSqlTypes()
 	ti = make([]typeinfo.TypeInfo, len(st))
@@ -207,6 +207,10 @@ func serializeSchemaColumns(b *fb.Builder, sch schema.Schema) fb.UOffsetT {
 		do := b.CreateString(col.Default)
 		to := b.CreateString(sqlTypeString(col.TypeInfo))
 		no := b.CreateString(col.Name)
+		var dio fb.UOffsetT
+		if len(col.Dictionary) > 0 {
+			dio = serializeColumnDictionary(b, col.Dictionary)
+		}
 
 		serial.ColumnStart(b)
 		serial.ColumnAddName(b, no)
@@ -216,13 +220,16 @@ func serializeSchemaColumns(b *fb.Builder, sch schema.Schema) fb.UOffsetT {
 		// schema.Schema determines display order
 		serial.ColumnAddDisplayOrder(b, int16(i))
 		serial.ColumnAddTag(b, col.Tag)
-		serial.ColumnAddEncoding(b, encodingFromTypeinfo(col.TypeInfo))
+		serial.ColumnAddEncoding(b, encodingFromColumn(col))
 		serial.ColumnAddPrimaryKey(b, col.IsPartOfPK)
 		serial.ColumnAddAutoIncrement(b, col.AutoIncrement)
 		serial.ColumnAddNullable(b, col.IsNullable())
 		serial.ColumnAddGenerated(b, false)
 		serial.ColumnAddVirtual(b, false)
 		serial.ColumnAddHidden(b, false)
+		if dio != 0 {
+			serial.ColumnAddDictionary(b, dio)
+		}
 		offs[i] = serial.ColumnEnd(b)
 	}
 
@@ -234,6 +241,18 @@ func serializeSchemaColumns(b *fb.Builder, sch schema.Schema) fb.UOffsetT {
 	return b.EndVector(len(offs))
 }
 
+func serializeColumnDictionary(b *fb.Builder, dict []string) fb.UOffsetT {
+	offs := make([]fb.UOffsetT, len(dict))
+	for i := range dict {
+		offs[i] = b.CreateString(dict[i])
+	}
+	serial.ColumnStartDictionaryVector(b, len(offs))
+	for i := len(offs) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(offs[i])
+	}
+	return b.EndVector(len(offs))
+}
+
 func serializeHiddenKeylessColumns(b *fb.Builder) (id, card fb.UOffsetT) {
 	// cardinality column
 	no := b.CreateString(keylessCardCol)
@@ -304,6 +323,13 @@ func deserializeColumns(ctx context.Context, s *serial.TableSchema) ([]schema.Co
 		if err != nil {
 			return nil, err
 		}
+		cols[i].FixedPointDecimal = c.Encoding() == serial.EncodingDecimal64
+		if n := c.DictionaryLength(); n > 0 {
+			cols[i].Dictionary = make([]string, n)
+			for j := range cols[i].Dictionary {
+				cols[i].Dictionary[j] = string(c.Dictionary(j))
+			}
+		}
 	}
 	return cols, nil
 }
@@ -342,6 +368,12 @@ func serializeSecondaryIndexes(b *fb.Builder, sch schema.Schema, indexes []schem
 		}
 		po := b.EndVector(len(prefixLengths))
 
+		// the predicate of a partial index is only written when present, keeping the encoding of other indexes unchanged
+		var pro fb.UOffsetT
+		if pred := idx.Predicate(); len(pred) > 0 {
+			pro = b.CreateString(pred.Serialize())
+		}
+
 		serial.IndexStart(b)
 		serial.IndexAddName(b, no)
 		serial.IndexAddComment(b, co)
@@ -352,6 +384,9 @@ func serializeSecondaryIndexes(b *fb.Builder, sch schema.Schema, indexes []schem
 		serial.IndexAddSystemDefined(b, !idx.IsUserDefined())
 		serial.IndexAddPrefixLengths(b, po)
 		serial.IndexAddSpatialKey(b, idx.IsSpatial())
+		if pro != 0 {
+			serial.IndexAddPredicate(b, pro)
+		}
 		offs[i] = serial.IndexEnd(b)
 	}
 
@@ -376,6 +411,11 @@ func deserializeSecondaryIndexes(sch schema.Schema, s *serial.TableSchema) error
 			IsUserDefined: !idx.SystemDefined(),
 			Comment:       string(idx.Comment()),
 		}
+		pred, err := schema.ParseIndexPredicate(string(idx.Predicate()))
+		if err != nil {
+			return err
+		}
+		props.Predicate = pred
 
 		tags := make([]uint64, idx.IndexColumnsLength())
 		for j := range tags {
@@ -393,7 +433,7 @@ func deserializeSecondaryIndexes(sch schema.Schema, s *serial.TableSchema) error
 			}
 		}
 
-		_, err := sch.Indexes().AddIndexByColTags(name, tags, prefixLengths, props)
+		_, err = sch.Indexes().AddIndexByColTags(name, tags, prefixLengths, props)
 		if err != nil {
 			return err
 		}
@@ -483,6 +523,15 @@ func encodingFromTypeinfo(t typeinfo.TypeInfo) serial.Encoding {
 	return schema.EncodingFromSqlType(t.ToSqlType().Type())
 }
 
+// encodingFromColumn returns the encoding the values of |col| are stored with. Unlike the encoding of its type, it
+// is read back when the column is deserialized.
+func encodingFromColumn(col schema.Column) serial.Encoding {
+	if col.FixedPointDecimal {
+		return serial.EncodingDecimal64
+	}
+	return encodingFromTypeinfo(col.TypeInfo)
+}
+
 func constraintsFromSerialColumn(col *serial.Column) (cc []schema.ColConstraint) {
 	if !col.Nullable() || col.PrimaryKey() {
 		cc = append(cc, schema.NotNullConstraint{})
@@ -58,6 +58,8 @@ type Index interface {
 	ToTableTuple(ctx context.Context, fullKey types.Tuple, format *types.NomsBinFormat) (types.Tuple, error)
 	// PrefixLengths returns the prefix lengths for the index
 	PrefixLengths() []uint16
+	// Predicate returns the filter of a partial index, or nil if the index contains every row of its table.
+	Predicate() IndexPredicate
 }
 
 var _ Index = (*indexImpl)(nil)
@@ -72,6 +74,7 @@ type indexImpl struct {
 	isUserDefined bool
 	comment       string
 	prefixLengths []uint16
+	predicate     IndexPredicate
 }
 
 func NewIndex(name string, tags, allTags []uint64, indexColl IndexCollection, props IndexProperties) Index {
@@ -89,6 +92,7 @@ func NewIndex(name string, tags, allTags []uint64, indexColl IndexCollection, pr
 		isSpatial:     props.IsSpatial,
 		isUserDefined: props.IsUserDefined,
 		comment:       props.Comment,
+		predicate:     props.Predicate,
 	}
 }
 
@@ -134,6 +138,7 @@ func (ix *indexImpl) Equals(other Index) bool {
 	return ix.IsUnique() == other.IsUnique() &&
 		ix.IsSpatial() == other.IsSpatial() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
+		ix.Predicate().Equals(other.Predicate()) &&
 		ix.Comment() == other.Comment() &&
 		ix.Name() == other.Name()
 }
@@ -156,6 +161,7 @@ func (ix *indexImpl) DeepEquals(other Index) bool {
 	return ix.IsUnique() == other.IsUnique() &&
 		ix.IsSpatial() == other.IsSpatial() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
+		ix.Predicate().Equals(other.Predicate()) &&
 		ix.Comment() == other.Comment() &&
 		ix.Name() == other.Name()
 }
@@ -278,6 +284,11 @@ func (ix *indexImpl) PrefixLengths() []uint16 {
 	return ix.prefixLengths
 }
 
+// Predicate implements Index.
+func (ix *indexImpl) Predicate() IndexPredicate {
+	return ix.predicate
+}
+
 // copy returns an exact copy of the calling index.
 func (ix *indexImpl) copy() *indexImpl {
 	newIx := *ix
@@ -289,5 +300,9 @@ func (ix *indexImpl) copy() *indexImpl {
 		newIx.prefixLengths = make([]uint16, len(ix.prefixLengths))
 		_ = copy(newIx.prefixLengths, ix.prefixLengths)
 	}
+	if len(ix.predicate) > 0 {
+		newIx.predicate = make(IndexPredicate, len(ix.predicate))
+		_ = copy(newIx.predicate, ix.predicate)
+	}
 	return &newIx
 }
@@ -77,6 +77,8 @@ type IndexProperties struct {
 	IsSpatial     bool
 	IsUserDefined bool
 	Comment       string
+	// Predicate is the filter of a partial index, nil for an index over every row.
+	Predicate IndexPredicate
 }
 
 type indexCollectionImpl struct {
@@ -121,7 +123,7 @@ func (ixc *indexCollectionImpl) AddIndex(indexes ...Index) {
 		if ok {
 			ixc.removeIndex(oldNamedIndex)
 		}
-		oldTaggedIndex := ixc.containsColumnTagCollection(index.tags...)
+		oldTaggedIndex := ixc.containsIndex(index.predicate, index.tags...)
 		if oldTaggedIndex != nil {
 			ixc.removeIndex(oldTaggedIndex)
 		}
@@ -159,6 +161,9 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 			return nil, err
 		}
 	}
+	if err := validateIndexPredicate(props.Predicate, ixc.colColl, tags, prefixLengths, props); err != nil {
+		return nil, err
+	}
 
 	index := &indexImpl{
 		indexColl:     ixc,
@@ -170,6 +175,7 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 		isUserDefined: props.IsUserDefined,
 		comment:       props.Comment,
 		prefixLengths: prefixLengths,
+		predicate:     props.Predicate,
 	}
 	ixc.indexes[indexName] = index
 	for _, tag := range tags {
@@ -194,6 +200,7 @@ func (ixc *indexCollectionImpl) UnsafeAddIndexByColTags(indexName string, tags [
 		isUserDefined: props.IsUserDefined,
 		comment:       props.Comment,
 		prefixLengths: prefixLengths,
+		predicate:     props.Predicate,
 	}
 	ixc.indexes[indexName] = index
 	for _, tag := range tags {
@@ -231,7 +238,7 @@ func (ixc *indexCollectionImpl) Equals(other IndexCollection) bool {
 		return false
 	}
 	for _, index := range ixc.indexes {
-		otherIndex := otherIxc.containsColumnTagCollection(index.tags...)
+		otherIndex := otherIxc.containsIndex(index.predicate, index.tags...)
 		if otherIndex == nil || !index.Equals(otherIndex) {
 			return false
 		}
@@ -359,6 +366,7 @@ func (ixc *indexCollectionImpl) Merge(indexes ...Index) {
 				isUserDefined: index.IsUserDefined(),
 				comment:       index.Comment(),
 				prefixLengths: index.PrefixLengths(),
+				predicate:     index.Predicate(),
 			}
 			ixc.AddIndex(newIndex)
 		}
@@ -409,10 +417,17 @@ func (ixc *indexCollectionImpl) columnNamesToTags(cols []string) ([]uint64, bool
 	return tags, true
 }
 
+// containsColumnTagCollection returns the index over every row of the table with the given columns, if any. Partial
+// indexes are never returned, as they can't back constraints such as foreign keys.
 func (ixc *indexCollectionImpl) containsColumnTagCollection(tags ...uint64) *indexImpl {
+	return ixc.containsIndex(nil, tags...)
+}
+
+// containsIndex returns the index with the given columns and predicate, if any.
+func (ixc *indexCollectionImpl) containsIndex(pred IndexPredicate, tags ...uint64) *indexImpl {
 	tagCount := len(tags)
 	for _, idx := range ixc.indexes {
-		if tagCount == len(idx.tags) {
+		if tagCount == len(idx.tags) && idx.predicate.Equals(pred) {
 			allMatch := true
 			for i, idxTag := range idx.tags {
 				if tags[i] != idxTag {
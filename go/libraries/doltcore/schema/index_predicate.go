@@ -0,0 +1,155 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IndexPredicateOp is the comparison made by an IndexPredicateTerm.
+type IndexPredicateOp string
+
+const (
+	IndexPredicateEq        IndexPredicateOp = "="
+	IndexPredicateNotEq     IndexPredicateOp = "!="
+	IndexPredicateLt        IndexPredicateOp = "<"
+	IndexPredicateLte       IndexPredicateOp = "<="
+	IndexPredicateGt        IndexPredicateOp = ">"
+	IndexPredicateGte       IndexPredicateOp = ">="
+	IndexPredicateIsNull    IndexPredicateOp = "IS NULL"
+	IndexPredicateIsNotNull IndexPredicateOp = "IS NOT NULL"
+)
+
+// IsComparison returns whether the op compares a column to a literal value.
+func (op IndexPredicateOp) IsComparison() bool {
+	switch op {
+	case IndexPredicateEq, IndexPredicateNotEq, IndexPredicateLt, IndexPredicateLte, IndexPredicateGt, IndexPredicateGte:
+		return true
+	default:
+		return false
+	}
+}
+
+func (op IndexPredicateOp) isValid() bool {
+	return op.IsComparison() || op == IndexPredicateIsNull || op == IndexPredicateIsNotNull
+}
+
+// IndexPredicateTerm compares the column with |Tag| to a literal |Value|, or checks it for NULL. |Value| is nil for the
+// IS NULL and IS NOT NULL ops.
+type IndexPredicateTerm struct {
+	Tag   uint64           `json:"tag"`
+	Op    IndexPredicateOp `json:"op"`
+	Value *string          `json:"value,omitempty"`
+}
+
+// IndexPredicate is the filter of a partial index, which only contains the rows for which every one of its terms holds.
+// A nil IndexPredicate is the predicate of an index over every row of its table.
+type IndexPredicate []IndexPredicateTerm
+
+// ParseIndexPredicate decodes an IndexPredicate serialized with IndexPredicate.Serialize.
+func ParseIndexPredicate(s string) (IndexPredicate, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	var pred IndexPredicate
+	if err := json.Unmarshal([]byte(s), &pred); err != nil {
+		return nil, fmt.Errorf("invalid index predicate: %w", err)
+	}
+	return pred, nil
+}
+
+// Serialize encodes the predicate for storage. The empty string is returned for a nil predicate.
+func (p IndexPredicate) Serialize() string {
+	if len(p) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		// the terms are plain strings and integers
+		panic(err)
+	}
+	return string(b)
+}
+
+// Equals returns whether |p| and |other| have the same terms, in the same order.
+func (p IndexPredicate) Equals(other IndexPredicate) bool {
+	if len(p) != len(other) {
+		return false
+	}
+	for i := range p {
+		a, b := p[i], other[i]
+		if a.Tag != b.Tag || a.Op != b.Op || (a.Value == nil) != (b.Value == nil) {
+			return false
+		}
+		if a.Value != nil && *a.Value != *b.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the predicate as a SQL expression, naming its columns from |cols|.
+func (p IndexPredicate) String(cols *ColCollection) string {
+	terms := make([]string, len(p))
+	for i, term := range p {
+		name := fmt.Sprintf("%d", term.Tag)
+		if col, ok := cols.GetByTag(term.Tag); ok {
+			name = col.Name
+		}
+		if term.Value == nil {
+			terms[i] = fmt.Sprintf("`%s` %s", name, term.Op)
+		} else {
+			terms[i] = fmt.Sprintf("`%s` %s '%s'", name, term.Op, strings.ReplaceAll(*term.Value, "'", "''"))
+		}
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// validateIndexPredicate returns an error if |pred| can't be the predicate of an index over |tags|.
+func validateIndexPredicate(pred IndexPredicate, cols *ColCollection, tags []uint64, prefixLengths []uint16, props IndexProperties) error {
+	if len(pred) == 0 {
+		return nil
+	}
+	if props.IsSpatial {
+		return fmt.Errorf("spatial indexes cannot have a predicate")
+	}
+	if len(prefixLengths) > 0 {
+		return fmt.Errorf("indexes with prefix lengths cannot have a predicate")
+	}
+	for _, term := range pred {
+		if !term.Op.isValid() {
+			return fmt.Errorf("invalid index predicate operator: %s", term.Op)
+		}
+		if term.Op.IsComparison() != (term.Value != nil) {
+			return fmt.Errorf("invalid index predicate term for operator %s", term.Op)
+		}
+		indexed := false
+		for _, tag := range tags {
+			indexed = indexed || tag == term.Tag
+		}
+		col, ok := cols.GetByTag(term.Tag)
+		if !ok || !indexed {
+			return fmt.Errorf("index predicates may only reference the columns of their index")
+		}
+		if term.Value != nil {
+			if _, _, err := col.TypeInfo.ToSqlType().Convert(*term.Value); err != nil {
+				return fmt.Errorf("invalid index predicate value for column `%s`: %w", col.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -551,3 +551,53 @@ func (ixc *indexCollectionImpl) clear(_ *testing.T) {
 		ixc.colTagToIndex[key] = nil
 	}
 }
+
+func TestIndexCollectionPartialIndexes(t *testing.T) {
+	colColl := NewColCollection(
+		NewColumn("pk", 1, types.IntKind, true, NotNullConstraint{}),
+		NewColumn("v1", 2, types.IntKind, false),
+		NewColumn("v2", 3, types.IntKind, false),
+	)
+	indexColl := NewIndexCollection(colColl, nil)
+
+	one := "1"
+	pred := IndexPredicate{
+		{Tag: 2, Op: IndexPredicateEq, Value: &one},
+		{Tag: 3, Op: IndexPredicateIsNotNull},
+	}
+	partial, err := indexColl.AddIndexByColTags("idx_partial", []uint64{2, 3}, nil, IndexProperties{IsUserDefined: true, Predicate: pred})
+	require.NoError(t, err)
+	assert.True(t, pred.Equals(partial.Predicate()))
+	assert.Equal(t, "`v1` = '1' AND `v2` IS NOT NULL", pred.String(colColl))
+
+	// partial indexes are never returned as the index of a column set
+	_, ok := indexColl.GetIndexByTags(2, 3)
+	assert.False(t, ok)
+	assert.Len(t, indexColl.GetIndexesByTags(2, 3), 1)
+
+	full, err := indexColl.AddIndexByColTags("idx_full", []uint64{2, 3}, nil, IndexProperties{IsUserDefined: true})
+	require.NoError(t, err)
+	idx, ok := indexColl.GetIndexByTags(2, 3)
+	require.True(t, ok)
+	assert.Equal(t, full, idx)
+	assert.False(t, full.Equals(partial))
+
+	parsed, err := ParseIndexPredicate(pred.Serialize())
+	require.NoError(t, err)
+	assert.True(t, pred.Equals(parsed))
+	parsed, err = ParseIndexPredicate(IndexPredicate(nil).Serialize())
+	require.NoError(t, err)
+	assert.Nil(t, parsed)
+
+	_, err = indexColl.AddIndexByColTags("idx_other_col", []uint64{3}, nil, IndexProperties{Predicate: pred})
+	assert.Error(t, err)
+	notAnInt := "one"
+	_, err = indexColl.AddIndexByColTags("idx_bad_value", []uint64{2}, nil, IndexProperties{
+		Predicate: IndexPredicate{{Tag: 2, Op: IndexPredicateGt, Value: &notAnInt}},
+	})
+	assert.Error(t, err)
+	_, err = indexColl.AddIndexByColTags("idx_missing_value", []uint64{2}, nil, IndexProperties{
+		Predicate: IndexPredicate{{Tag: 2, Op: IndexPredicateGt}},
+	})
+	assert.Error(t, err)
+}
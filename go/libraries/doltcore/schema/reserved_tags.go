@@ -112,4 +112,25 @@ const (
 const (
 	DoltIgnorePatternTag = iota + SystemTableReservedMin + uint64(8000)
 	DoltIgnoreIgnoredTag
+	DoltIgnoreMergePolicyTag
+)
+
+// Tags for the dolt_ignore_columns table
+const (
+	DoltIgnoreColumnsTableTag = iota + SystemTableReservedMin + uint64(8100)
+	DoltIgnoreColumnsColumnTag
+)
+
+// Tags for the dolt_volatile_columns table
+const (
+	DoltVolatileColumnsTableTag = iota + SystemTableReservedMin + uint64(8200)
+	DoltVolatileColumnsColumnTag
+)
+
+// Tags for the dolt_table_provenance table
+const (
+	DoltTableProvenanceTableTag = iota + SystemTableReservedMin + uint64(8300)
+	DoltTableProvenanceSourceURLTag
+	DoltTableProvenanceLicenseTag
+	DoltTableProvenanceCollectedAtTag
 )
@@ -115,6 +115,9 @@ func SchemaFromCols(allCols *ColCollection) (Schema, error) {
 	if len(pkCols) == 0 && !FeatureFlagKeylessSchema {
 		return nil, ErrNoPrimaryKeyColumns
 	}
+	if err := validateDictionaries(allCols); err != nil {
+		return nil, err
+	}
 
 	pkColColl := NewColCollection(pkCols...)
 	nonPKColColl := NewColCollection(nonPKCols...)
@@ -260,9 +263,25 @@ func SchemaFromPKAndNonPKCols(pkCols, nonPKCols *ColCollection) (Schema, error)
 	}
 
 	allColColl := NewColCollection(allCols...)
+	if err := validateDictionaries(allColColl); err != nil {
+		return nil, err
+	}
 	return SchemaFromColCollections(allColColl, pkCols, nonPKCols), nil
 }
 
+// validateDictionaries returns an error if the dictionary of a column of |cols| is invalid, see val.ValidateStringDict.
+func validateDictionaries(cols *ColCollection) error {
+	for _, col := range cols.cols {
+		if len(col.Dictionary) == 0 {
+			continue
+		}
+		if err := val.ValidateStringDict(col.Dictionary); err != nil {
+			return fmt.Errorf("invalid dictionary for column %s: %w", col.Name, err)
+		}
+	}
+	return nil
+}
+
 // GetAllCols gets the collection of all columns (pk and non-pk)
 func (si *schemaImpl) GetAllCols() *ColCollection {
 	return si.allCols
@@ -472,11 +491,26 @@ func (si *schemaImpl) GetValueDescriptor() val.TupleDesc {
 	}
 
 	useCollations := false // We only use collations if a string exists
+	var dicts []*val.StringDict
 	_ = si.GetNonPKCols().Iter(func(tag uint64, col Column) (stop bool, err error) {
 		sqlType := col.TypeInfo.ToSqlType()
 		queryType := sqlType.Type()
+		enc := val.Encoding(EncodingFromSqlType(queryType))
+		if len(col.Dictionary) > 0 && enc == val.StringEnc {
+			if dicts == nil {
+				dicts = make([]*val.StringDict, len(tt), si.GetNonPKCols().Size()+len(tt))
+			}
+			// the dictionaries of schemas are checked by their constructors, see validateDictionaries
+			dicts = append(dicts, val.StringDictOf(col.Dictionary))
+			enc = val.StringDictEnc
+		} else if dicts != nil {
+			dicts = append(dicts, nil)
+		}
+		if col.FixedPointDecimal && enc == val.DecimalEnc {
+			enc = val.Decimal64Enc
+		}
 		tt = append(tt, val.Type{
-			Enc:      val.Encoding(EncodingFromSqlType(queryType)),
+			Enc:      enc,
 			Nullable: col.IsNullable(),
 		})
 		if queryType == query.Type_CHAR || queryType == query.Type_VARCHAR {
@@ -493,10 +527,17 @@ func (si *schemaImpl) GetValueDescriptor() val.TupleDesc {
 			panic(fmt.Errorf("cannot create tuple descriptor from %d collations and %d types", len(collations), len(tt)))
 		}
 		cmp := CollationTupleComparator{Collations: collations}
-		return val.NewTupleDescriptorWithComparator(cmp, tt...)
+		return withStringDicts(val.NewTupleDescriptorWithComparator(cmp, tt...), dicts)
 	} else {
-		return val.NewTupleDescriptor(tt...)
+		return withStringDicts(val.NewTupleDescriptor(tt...), dicts)
+	}
+}
+
+func withStringDicts(td val.TupleDesc, dicts []*val.StringDict) val.TupleDesc {
+	if dicts == nil {
+		return td
 	}
+	return td.WithStringDicts(dicts)
 }
 
 // GetCollation implements the Schema interface.
@@ -49,14 +49,14 @@ var ageVal = types.Uint(53)
 var titleVal = types.NullValue
 
 var pkCols = []Column{
-	{lnColName, lnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil},
-	{fnColName, fnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil},
+	{lnColName, lnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+	{fnColName, fnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
 }
 var nonPkCols = []Column{
-	{addrColName, addrColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-	{ageColName, ageColTag, types.UintKind, false, typeinfo.FromKind(types.UintKind), "", false, "", nil},
-	{titleColName, titleColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-	{reservedColName, reservedColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
+	{addrColName, addrColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+	{ageColName, ageColTag, types.UintKind, false, typeinfo.FromKind(types.UintKind), "", false, "", nil, nil, false},
+	{titleColName, titleColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
+	{reservedColName, reservedColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false},
 }
 
 var allCols = append(append([]Column(nil), pkCols...), nonPkCols...)
@@ -115,6 +115,23 @@ func TestSchemaWithNoPKs(t *testing.T) {
 	})
 }
 
+func TestSchemaWithInvalidDictionary(t *testing.T) {
+	cols := append([]Column(nil), allCols...)
+	cols[len(cols)-1].Dictionary = []string{"a", "b", "a"}
+
+	_, err := SchemaFromCols(NewColCollection(cols...))
+	assert.Error(t, err)
+	_, err = SchemaFromPKAndNonPKCols(NewColCollection(pkCols...), NewColCollection(cols[len(pkCols):]...))
+	assert.Error(t, err)
+
+	cols[len(cols)-1].Dictionary = []string{"a", "b"}
+	sch, err := SchemaFromCols(NewColCollection(cols...))
+	require.NoError(t, err)
+	assert.NotPanics(t, func() {
+		sch.GetValueDescriptor()
+	})
+}
+
 func TestGetSharedCols(t *testing.T) {
 	colColl := NewColCollection(nonPkCols...)
 	sch, _ := SchemaFromCols(colColl)
@@ -204,7 +221,7 @@ func TestValidateForInsert(t *testing.T) {
 	})
 
 	t.Run("Name collision", func(t *testing.T) {
-		cols := append(allCols, Column{titleColName, 100, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil})
+		cols := append(allCols, Column{titleColName, 100, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false})
 		colColl := NewColCollection(cols...)
 
 		err := ValidateForInsert(colColl)
@@ -213,7 +230,7 @@ func TestValidateForInsert(t *testing.T) {
 	})
 
 	t.Run("Case insensitive collision", func(t *testing.T) {
-		cols := append(allCols, Column{strings.ToUpper(titleColName), 100, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil})
+		cols := append(allCols, Column{strings.ToUpper(titleColName), 100, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false})
 		colColl := NewColCollection(cols...)
 
 		err := ValidateForInsert(colColl)
@@ -222,7 +239,7 @@ func TestValidateForInsert(t *testing.T) {
 	})
 
 	t.Run("Tag collision", func(t *testing.T) {
-		cols := append(allCols, Column{"newCol", lnColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil})
+		cols := append(allCols, Column{"newCol", lnColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, nil, false})
 		colColl := NewColCollection(cols...)
 
 		err := ValidateForInsert(colColl)
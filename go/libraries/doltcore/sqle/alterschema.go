@@ -273,6 +273,7 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 				IsSpatial:     index.IsSpatial(),
 				IsUserDefined: index.IsUserDefined(),
 				Comment:       index.Comment(),
+				Predicate:     index.Predicate(),
 			})
 		if err != nil {
 			return nil, err
@@ -339,14 +340,14 @@ func backupFkcIndexesForPkDrop(ctx *sql.Context, tbl string, sch schema.Schema,
 
 	declared, referenced := fkc.KeysForTable(tbl)
 	for _, fk := range declared {
-		if fk.TableIndex == "" {
+		if fk.TableIndex == "" && !fk.NotEnforced {
 			// pk used in fk definition on |tbl|
 			return nil, sql.ErrCantDropIndex.New("PRIMARY", fk.Name)
 		}
 	}
 	for _, fk := range referenced {
-		if fk.ReferencedTableIndex != "" {
-			// if an index doesn't reference primary key, it is unaffected
+		if fk.ReferencedTableIndex != "" || fk.NotEnforced {
+			// if an index doesn't reference primary key, it is unaffected, and virtual foreign keys don't need an index
 			continue
 		}
 		// pk reference by fk definition on |fk.TableName|
@@ -0,0 +1,397 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse/dateparse"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// The values of @@dolt_alter_column_on_error
+const (
+	convertOnErrorAbort = "abort"
+	convertOnErrorNull  = "null"
+	convertOnErrorSkip  = "skip"
+)
+
+// columnConversionBatchSize is the number of rows handed to a conversion thread at a time
+const columnConversionBatchSize = 1024
+
+// canConvertColumn returns whether ModifyColumn can change the type of |oldColumn| to that of |newColumn| itself with
+// convertColumn, instead of the engine rewriting the table through RewriteInserter.
+func (t *AlterableDoltTable) canConvertColumn(oldSchema, newSchema sql.PrimaryKeySchema, oldColumn, newColumn *sql.Column) bool {
+	if !types.IsFormat_DOLT(t.Format()) || oldColumn == nil || newColumn == nil {
+		return false
+	}
+	if orderChanged(oldSchema, newSchema, oldColumn, newColumn) ||
+		isColumnDrop(oldSchema, newSchema) ||
+		isPrimaryKeyChange(oldSchema, newSchema) {
+		return false
+	}
+	// The engine fills in auto increment values and checks new NOT NULL constraints while it rewrites the table
+	if newColumn.AutoIncrement || (oldColumn.Nullable && !newColumn.Nullable) {
+		return false
+	}
+	return t.isIncompatibleTypeChange(oldColumn, newColumn)
+}
+
+// columnConverter converts the values of a column to a new type.
+type columnConverter struct {
+	// idx is the index of the column in the table's rows
+	idx  int
+	name string
+	typ  sql.Type
+	// datetimeFormat is the STR_TO_DATE format of strings converted to date and time types, if any
+	datetimeFormat string
+	onError        string
+	threads        int
+}
+
+// skippedRow is a row left out of a converted table, along with the value that failed to convert.
+type skippedRow struct {
+	row   sql.Row
+	value interface{}
+	err   error
+}
+
+func newColumnConverter(ctx *sql.Context, idx int, column *sql.Column) (*columnConverter, error) {
+	onError, err := ctx.GetSessionVariable(ctx, dsess.AlterColumnOnError)
+	if err != nil {
+		return nil, err
+	}
+	format, err := ctx.GetSessionVariable(ctx, dsess.AlterColumnDatetimeFormat)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := ctx.GetSessionVariable(ctx, dsess.AlterColumnThreads)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &columnConverter{
+		idx:            idx,
+		name:           column.Name,
+		typ:            column.Type,
+		datetimeFormat: format.(string),
+		onError:        onError.(string),
+		threads:        int(threads.(int64)),
+	}
+	if c.threads <= 0 {
+		c.threads = runtime.NumCPU()
+	}
+	return c, nil
+}
+
+// convert returns |v| converted to the column's new type, or an error if it can't be converted without losing data.
+func (c *columnConverter) convert(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if s, ok := v.(string); ok && c.datetimeFormat != "" && sqltypes.IsTime(c.typ) {
+		parsed, err := dateparse.ParseDateWithFormat(s, c.datetimeFormat)
+		if err != nil {
+			return nil, err
+		}
+		v = parsed
+	}
+
+	converted, inRange, err := c.typ.Convert(v)
+	if err != nil {
+		// match the error of the table rewrites of the engine
+		if sql.ErrNotMatchingSRID.Is(err) {
+			err = sql.ErrNotMatchingSRIDWithColName.New(c.name, err)
+		}
+		return nil, err
+	} else if !inRange {
+		return nil, sql.ErrValueOutOfRange.New(v, c.typ)
+	}
+	return converted, nil
+}
+
+// rewrite converts the column in every row of |rows| on |c.threads| threads, and inserts the converted rows with
+// |inserter|. It returns the rows left out of the table when @@dolt_alter_column_on_error is 'skip'.
+func (c *columnConverter) rewrite(ctx *sql.Context, rows sql.RowIter, inserter sql.RowInserter) ([]skippedRow, error) {
+	eg, egCtx := errgroup.WithContext(ctx)
+	sqlCtx := ctx.WithContext(egCtx)
+	batches := make(chan []sql.Row, c.threads)
+	converted := make(chan []sql.Row, c.threads)
+
+	eg.Go(func() error {
+		defer close(batches)
+		batch := make([]sql.Row, 0, columnConversionBatchSize)
+		for {
+			r, err := rows.Next(sqlCtx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			batch = append(batch, r)
+			if len(batch) < columnConversionBatchSize {
+				continue
+			}
+			select {
+			case batches <- batch:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+			batch = make([]sql.Row, 0, columnConversionBatchSize)
+		}
+		if len(batch) > 0 {
+			select {
+			case batches <- batch:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
+		return rows.Close(sqlCtx)
+	})
+
+	var mu sync.Mutex
+	var skipped []skippedRow
+	var workers sync.WaitGroup
+	for i := 0; i < c.threads; i++ {
+		workers.Add(1)
+		eg.Go(func() error {
+			defer workers.Done()
+			for batch := range batches {
+				out := batch[:0]
+				for _, r := range batch {
+					v, err := c.convert(r[c.idx])
+					if err != nil {
+						switch c.onError {
+						case convertOnErrorNull:
+							v = nil
+						case convertOnErrorSkip:
+							mu.Lock()
+							skipped = append(skipped, skippedRow{row: r, value: r[c.idx], err: err})
+							mu.Unlock()
+							continue
+						default:
+							return err
+						}
+					}
+					r[c.idx] = v
+					out = append(out, r)
+				}
+				select {
+				case converted <- out:
+				case <-egCtx.Done():
+					return egCtx.Err()
+				}
+			}
+			return nil
+		})
+	}
+	eg.Go(func() error {
+		workers.Wait()
+		close(converted)
+		return nil
+	})
+
+	// the inserter isn't safe for concurrent use, so a single thread writes every row
+	eg.Go(func() error {
+		for batch := range converted {
+			for _, r := range batch {
+				if err := inserter.Insert(sqlCtx, r); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
+
+// convertColumn changes the column |columnName| to |column|, a column of a different type, and rewrites every row of
+// the table with the column's values converted to the new type. The conversion runs on @@dolt_alter_column_threads
+// threads. A value that can't be converted aborts the statement, is replaced with NULL, or has its row left out of the
+// table and recorded as a constraint violation, as set by @@dolt_alter_column_on_error.
+func (t *AlterableDoltTable) convertColumn(ctx *sql.Context, columnName string, column *sql.Column) error {
+	oldSchema := t.sqlSch
+	idx := oldSchema.Schema.IndexOfColName(columnName)
+	if idx < 0 {
+		return sql.ErrTableColumnNotFound.New(t.Name(), columnName)
+	}
+	oldColumn := oldSchema.Schema[idx]
+
+	newCol := *column
+	newCol.PrimaryKey = oldColumn.PrimaryKey
+	if newCol.Source == "" {
+		newCol.Source = oldColumn.Source
+	}
+	newSchema := sql.PrimaryKeySchema{
+		Schema:     oldSchema.Schema.Copy(),
+		PkOrdinals: oldSchema.PkOrdinals,
+	}
+	newSchema.Schema[idx] = &newCol
+
+	conv, err := newColumnConverter(ctx, idx, &newCol)
+	if err != nil {
+		return err
+	}
+	switch conv.onError {
+	case convertOnErrorNull:
+		if !newCol.Nullable {
+			return fmt.Errorf("cannot convert column %s with @@%s = '%s': the column is NOT NULL",
+				columnName, dsess.AlterColumnOnError, convertOnErrorNull)
+		}
+	case convertOnErrorSkip:
+		if len(oldSchema.PkOrdinals) == 0 {
+			return fmt.Errorf("cannot convert column %s with @@%s = '%s': table %s has no primary key",
+				columnName, dsess.AlterColumnOnError, convertOnErrorSkip, t.Name())
+		}
+		if oldColumn.PrimaryKey {
+			return fmt.Errorf("cannot convert column %s with @@%s = '%s': the column is part of the primary key",
+				columnName, dsess.AlterColumnOnError, convertOnErrorSkip)
+		}
+	}
+
+	partitions, err := t.Partitions(ctx)
+	if err != nil {
+		return err
+	}
+	rows := sql.NewTableRowIter(ctx, t, partitions)
+
+	inserter, err := t.RewriteInserter(ctx, oldSchema, newSchema, oldColumn, &newCol, nil)
+	if err != nil {
+		return err
+	}
+	skipped, err := conv.rewrite(ctx, rows, inserter)
+	if err != nil {
+		return err
+	}
+	if err = inserter.Close(ctx); err != nil {
+		return err
+	}
+
+	ws, err := t.db.GetWorkingSet(ctx)
+	if err != nil {
+		return err
+	}
+	root := ws.WorkingRoot()
+	if len(skipped) > 0 {
+		tbl, ok, err := root.GetTable(ctx, t.tableName)
+		if err != nil {
+			return err
+		} else if !ok {
+			return sql.ErrTableNotFound.New(t.tableName)
+		}
+		tbl, err = t.addConversionViolations(ctx, tbl, &newCol, skipped)
+		if err != nil {
+			return err
+		}
+		root, err = root.PutTable(ctx, t.tableName, tbl)
+		if err != nil {
+			return err
+		}
+		if err = t.setRoot(ctx, root); err != nil {
+			return err
+		}
+	}
+
+	return t.updateFromRoot(ctx, root)
+}
+
+// addConversionViolations records the rows |skipped| by convertColumn as type conversion constraint violations of
+// |tbl|, with NULL in the converted |column|.
+func (t *AlterableDoltTable) addConversionViolations(ctx *sql.Context, tbl *doltdb.Table, column *sql.Column, skipped []skippedRow) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	arts, err := tbl.GetArtifacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, t.db.Name())
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	ns := tbl.NodeStore()
+	kd, vd := sch.GetMapDescriptors()
+	kb, vb := val.NewTupleBuilder(kd), val.NewTupleBuilder(vd)
+	allCols := sch.GetAllCols()
+	edt := durable.ProllyMapFromArtifactIndex(arts).Editor()
+	for _, s := range skipped {
+		s.row[allCols.IndexOf(column.Name)] = nil
+		for i, col := range sch.GetPKCols().GetColumns() {
+			if err = index.PutField(ctx, ns, kb, i, s.row[allCols.TagToIdx[col.Tag]]); err != nil {
+				return nil, err
+			}
+		}
+		for i, col := range sch.GetNonPKCols().GetColumns() {
+			if err = index.PutField(ctx, ns, vb, i, s.row[allCols.TagToIdx[col.Tag]]); err != nil {
+				return nil, err
+			}
+		}
+
+		value := fmt.Sprint(s.value)
+		if b, ok := s.value.([]byte); ok {
+			value = string(b)
+		}
+		vInfo, err := json.Marshal(merge.TypeConvCVMeta{
+			Column: column.Name,
+			Error:  s.err.Error(),
+			Type:   column.Type.String(),
+			Value:  value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		meta := prolly.ConstraintViolationMeta{
+			VInfo: vInfo,
+			// the converted column is NULL even if it's NOT NULL
+			Value: vb.BuildPermissive(ns.Pool()),
+		}
+		err = edt.ReplaceConstraintViolation(ctx, kb.Build(ns.Pool()), headHash, prolly.ArtifactTypeTypeConvViol, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := edt.Flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tbl.SetArtifacts(ctx, durable.ArtifactIndexFromProllyMap(m))
+}
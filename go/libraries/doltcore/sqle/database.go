@@ -181,6 +181,13 @@ func (db Database) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Ta
 		return tbl, ok, nil
 	}
 
+	asOf, err := snapshotAsOf(ctx)
+	if err != nil {
+		return nil, false, err
+	} else if asOf != nil {
+		return db.GetTableInsensitiveAsOf(ctx, tblName, asOf)
+	}
+
 	root, err := db.GetRoot(ctx)
 	if err != nil {
 		return nil, false, err
@@ -243,7 +250,8 @@ func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 		}
 		return tbl, true, nil
 	default:
-		panic(fmt.Sprintf("unexpected table type %T", table))
+		// the writable system tables are read from the root given
+		return table, true, nil
 	}
 }
 
@@ -270,6 +278,22 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		}
 		return dt, true, nil
 
+	case strings.HasPrefix(lwrName, doltdb.DoltColumnDiffTablePrefix):
+		if head == nil {
+			var err error
+			head, err = ds.GetHeadCommit(ctx, db.Name())
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		suffix := tblName[len(doltdb.DoltColumnDiffTablePrefix):]
+		dt, err := dtables.NewRowColumnDiffTable(ctx, suffix, db.ddb, root, head)
+		if err != nil {
+			return nil, false, err
+		}
+		return dt, true, nil
+
 	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
 		suffix := tblName[len(doltdb.DoltCommitDiffTablePrefix):]
 		dt, err := dtables.NewCommitDiffTable(ctx, suffix, db.ddb, root)
@@ -306,7 +330,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		} else if !ok {
 			return nil, false, nil
 		}
-		dt, err := dtables.NewConflictsTable(ctx, suffix, srcTable, root, dtables.RootSetter(db))
+		dt, err := dtables.NewConflictsTable(ctx, db.Name(), suffix, srcTable, root, dtables.RootSetter(db))
 		if err != nil {
 			return nil, false, err
 		}
@@ -366,6 +390,16 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		dt, found = dtables.NewRemoteBranchesTable(ctx, db), true
 	case doltdb.RemotesTableName:
 		dt, found = dtables.NewRemotesTable(ctx, db.ddb), true
+	case doltdb.ProvenanceTableName:
+		if head == nil {
+			var err error
+			head, err = ds.GetHeadCommit(ctx, db.Name())
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		dt, found = dtables.NewProvenanceTable(ctx, db.ddb, head, root), true
 	case doltdb.CommitsTableName:
 		dt, found = dtables.NewCommitsTable(ctx, db.ddb), true
 	case doltdb.CommitAncestorsTableName:
@@ -384,8 +418,56 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		dt, found = dtables.NewStatusTable(ctx, db.name, db.ddb, ws, adapter), true
 	case doltdb.MergeStatusTableName:
 		dt, found = dtables.NewMergeStatusTable(db.name), true
+	case doltdb.MergeStateTableName:
+		dt, found = dtables.NewMergeStateTable(db.name), true
 	case doltdb.TagsTableName:
 		dt, found = dtables.NewTagsTable(ctx, db.ddb), true
+	case doltdb.IndexUsageTableName:
+		if tracker := indexUsageTracker(ctx, db); tracker != nil {
+			branch, _, err := indexUsageBranch(ctx, db)
+			if err != nil {
+				return nil, false, err
+			}
+			dt, found = dtables.NewIndexUsageTable(tracker, branch, root), true
+		}
+	case doltdb.QuotasTableName:
+		if quotas := dsess.DSessFromSess(ctx.Session).Quotas(); quotas != nil {
+			dt, found = dtables.NewQuotasTable(db.BaseName(), db.ddb, quotas), true
+		}
+	case doltdb.RemoteStatusTableName:
+		if pro, ok := dsess.DSessFromSess(ctx.Session).Provider().(fetchSchedulerProvider); ok && pro.FetchScheduler() != nil {
+			dt, found = dtables.NewRemoteStatusTable(db.BaseName(), db.rsr, pro.FetchScheduler()), true
+		}
+	case doltdb.BackupStatusTableName:
+		if pro, ok := dsess.DSessFromSess(ctx.Session).Provider().(backupSchedulerProvider); ok && pro.BackupScheduler() != nil {
+			dt, found = dtables.NewBackupStatusTable(db.BaseName(), pro.BackupScheduler()), true
+		}
+	case doltdb.StorageTableName:
+		dt, found = dtables.NewStorageTable(root), true
+	case doltdb.StorageStatsTableName:
+		dt, found = dtables.NewStorageStatsTable(), true
+	case doltdb.ProjectionCacheTableName:
+		if cache := projectionCache(ctx); cache != nil {
+			dt, found = dtables.NewProjectionCacheTable(db.BaseName(), cache), true
+		}
+	case doltdb.FetchHistoryTableName:
+		if fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(db.BaseName()); err == nil {
+			dt, found = dtables.NewFetchHistoryTable(fs), true
+		}
+	case doltdb.ConflictResolutionsTableName:
+		if fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(db.BaseName()); err == nil {
+			dt, found = dtables.NewConflictResolutionsTable(fs), true
+		}
+	case doltdb.AuditLogTableName:
+		if fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(db.BaseName()); err == nil {
+			dt, found = dtables.NewAuditLogTable(fs), true
+		}
+	case doltdb.WebhookDeadLettersTableName:
+		if fs, err := dsess.DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(db.BaseName()); err == nil {
+			dt, found = dtables.NewWebhookDeadLettersTable(fs), true
+		}
+	case doltdb.ReplicationStatusTableName:
+		dt, found = dtables.NewReplicationStatusTable(db.ddb), true
 	case dtables.AccessTableName:
 		basCtx := branch_control.GetBranchAwareSession(ctx)
 		if basCtx != nil {
@@ -406,6 +488,24 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			return nil, false, err
 		}
 		dt, found = dtables.NewIgnoreTable(ctx, db.ddb, backingTable), true
+	case doltdb.IgnoreColumnsTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.IgnoreColumnsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		dt, found = dtables.NewIgnoreColumnsTable(ctx, backingTable), true
+	case doltdb.VolatileColumnsTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.VolatileColumnsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		dt, found = dtables.NewVolatileColumnsTable(ctx, backingTable), true
+	case doltdb.TableProvenanceTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.TableProvenanceTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		dt, found = dtables.NewTableProvenanceTable(ctx, backingTable), true
 	}
 
 	if found {
@@ -415,6 +515,34 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 	return db.getTable(ctx, root, tblName)
 }
 
+// snapshotTimeLayouts are the layouts of the timestamps @@dolt_snapshot_time can be set to. Other values are resolved
+// as commits.
+var snapshotTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// snapshotAsOf returns the AS OF expression that the reads of the session are pinned to with @@dolt_snapshot_time,
+// or nil if the session isn't pinned.
+func snapshotAsOf(ctx *sql.Context) (interface{}, error) {
+	v, err := ctx.GetSessionVariable(ctx, dsess.SnapshotTime)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, ok := v.(string)
+	if !ok || len(snapshot) == 0 {
+		return nil, nil
+	}
+	for _, layout := range snapshotTimeLayouts {
+		if t, err := time.ParseInLocation(layout, snapshot, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return snapshot, nil
+}
+
 // resolveAsOf resolves given expression to a commit, if one exists.
 func resolveAsOf(ctx *sql.Context, db Database, asOf interface{}) (*doltdb.Commit, *doltdb.RootValue, error) {
 	head := db.rsr.CWBHeadRef()
@@ -608,6 +736,17 @@ func (db Database) GetTableNames(ctx *sql.Context) ([]string, error) {
 // GetAllTableNames returns all user-space tables, including system tables in user space
 // (e.g. dolt_docs, dolt_query_catalog).
 func (db Database) GetAllTableNames(ctx *sql.Context) ([]string, error) {
+	asOf, err := snapshotAsOf(ctx)
+	if err != nil {
+		return nil, err
+	} else if asOf != nil {
+		_, root, err := resolveAsOf(ctx, db, asOf)
+		if err != nil || root == nil {
+			return nil, err
+		}
+		return getAllTableNames(ctx, root)
+	}
+
 	root, err := db.GetRoot(ctx)
 
 	if err != nil {
@@ -664,6 +803,15 @@ func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
 	return sess.SetRoot(ctx, db.name, newRoot)
 }
 
+// setRootForSchemaChange sets the working root of the session to |newRoot|, which changed the schema of |tableName|,
+// and records the change in the audit log of the database.
+func (db Database) setRootForSchemaChange(ctx *sql.Context, tableName string, newRoot *doltdb.RootValue) error {
+	if err := db.SetRoot(ctx, newRoot); err != nil {
+		return err
+	}
+	return dsess.DSessFromSess(ctx.Session).AuditStatement(ctx, db, tableName, dsess.AuditOperationDDL, 0)
+}
+
 // GetHeadRoot returns root value for the current session head
 func (db Database) GetHeadRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
 	sess := dsess.DSessFromSess(ctx.Session)
@@ -715,6 +863,11 @@ func (db Database) dropTable(ctx *sql.Context, tableName string) error {
 		return err
 	}
 
+	newRoot, err = dropVirtualForeignKeys(ctx, newRoot, tableName)
+	if err != nil {
+		return err
+	}
+
 	sch, err := tbl.GetSchema(ctx)
 	if err != nil {
 		return err
@@ -728,7 +881,45 @@ func (db Database) dropTable(ctx *sql.Context, tableName string) error {
 		}
 	}
 
-	return db.SetRoot(ctx, newRoot)
+	return db.setRootForSchemaChange(ctx, tableName, newRoot)
+}
+
+// dropVirtualForeignKeys removes the virtual foreign keys of the dropped table |tableName| from |root|. The engine
+// drops the foreign keys it enforces itself, but doesn't know about virtual foreign keys. As with enforced foreign
+// keys, a table referenced by the virtual foreign key of another table can only be dropped if foreign_key_checks is
+// disabled.
+func dropVirtualForeignKeys(ctx *sql.Context, root *doltdb.RootValue, tableName string) (*doltdb.RootValue, error) {
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	declared, referenced := fkc.KeysForTable(tableName)
+
+	var dropped []doltdb.ForeignKey
+	for _, fk := range declared {
+		if fk.NotEnforced {
+			dropped = append(dropped, fk)
+		}
+	}
+	for _, fk := range referenced {
+		if !fk.NotEnforced || fk.IsSelfReferential() {
+			continue
+		}
+		fkChecks, err := ctx.GetSessionVariable(ctx, "foreign_key_checks")
+		if err != nil {
+			return nil, err
+		}
+		if fkChecks.(int8) == 1 {
+			return nil, sql.ErrForeignKeyDropTable.New(tableName, fk.Name)
+		}
+		dropped = append(dropped, fk)
+	}
+	if len(dropped) == 0 {
+		return root, nil
+	}
+
+	fkc.RemoveKeys(dropped...)
+	return root.PutForeignKeyCollection(ctx, fkc)
 }
 
 // removeTableFromAutoIncrementTracker updates the global auto increment tracking as necessary to deal with the table
@@ -944,7 +1135,7 @@ func (db Database) createDoltTable(ctx *sql.Context, tableName string, root *dol
 		return err
 	}
 
-	return db.SetRoot(ctx, newRoot)
+	return db.setRootForSchemaChange(ctx, tableName, newRoot)
 }
 
 // CreateTemporaryTable creates a table that only exists the length of a session.
@@ -1000,7 +1191,7 @@ func (db Database) RenameTable(ctx *sql.Context, oldName, newName string) error
 		return err
 	}
 
-	return db.SetRoot(ctx, newRoot)
+	return db.setRootForSchemaChange(ctx, oldName, newRoot)
 }
 
 // Flush flushes the current batch of outstanding changes and returns any errors.
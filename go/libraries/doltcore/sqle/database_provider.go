@@ -28,6 +28,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/indexusage"
+	"github.com/dolthub/dolt/go/libraries/doltcore/projcache"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
@@ -47,6 +50,18 @@ type DoltDatabaseProvider struct {
 	externalProcedures sql.ExternalStoredProcedureRegistry
 	InitDatabaseHook   InitDatabaseHook
 	mu                 *sync.RWMutex
+	// indexUsage maps a lower-cased database name to the tracker of its index reads, loaded on first use
+	indexUsage map[string]*indexusage.Tracker
+	// quotas enforces per-database resource quotas, or is nil if there are none
+	quotas *quota.Controller
+	// fetchScheduler fetches the remotes of every database in the background, or is nil if they aren't fetched
+	fetchScheduler *actions.FetchScheduler
+	// backupScheduler backs up every database on a schedule, or is nil if they aren't backed up
+	backupScheduler *actions.BackupScheduler
+	// projections caches the columns read by scans of some of the columns of a table
+	projections *projcache.Cache
+	// snapshots caches the snapshot databases resolved for each database
+	snapshots *snapshotCache
 
 	defaultBranch string
 	fs            filesys.Filesys
@@ -63,6 +78,7 @@ var _ sql.CollatedDatabaseProvider = (*DoltDatabaseProvider)(nil)
 var _ sql.ExternalStoredProcedureProvider = (*DoltDatabaseProvider)(nil)
 var _ sql.TableFunctionProvider = (*DoltDatabaseProvider)(nil)
 var _ dsess.DoltDatabaseProvider = (*DoltDatabaseProvider)(nil)
+var _ dsess.QuotaProvider = (*DoltDatabaseProvider)(nil)
 
 // NewDoltDatabaseProvider returns a new provider, initialized without any databases, along with any
 // errors that occurred while trying to create the database provider.
@@ -119,6 +135,9 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		functions:          funcs,
 		externalProcedures: externalProcedures,
 		mu:                 &sync.RWMutex{},
+		indexUsage:         make(map[string]*indexusage.Tracker),
+		projections:        projcache.New(),
+		snapshots:          newSnapshotCache(),
 		fs:                 fs,
 		defaultBranch:      defaultBranch,
 		dbFactoryUrl:       dbFactoryUrl,
@@ -152,6 +171,77 @@ func (p DoltDatabaseProvider) WithRemoteDialer(provider dbfactory.GRPCDialProvid
 	return p
 }
 
+// WithQuotas returns a copy of this provider which enforces the per-database resource quotas of |quotas|
+func (p DoltDatabaseProvider) WithQuotas(quotas *quota.Controller) DoltDatabaseProvider {
+	p.quotas = quotas
+	return p
+}
+
+// Quotas implements dsess.QuotaProvider
+func (p DoltDatabaseProvider) Quotas() *quota.Controller {
+	return p.quotas
+}
+
+// fetchSchedulerProvider is implemented by database providers that fetch the remotes of their databases in the
+// background.
+type fetchSchedulerProvider interface {
+	FetchScheduler() *actions.FetchScheduler
+}
+
+// WithFetchScheduler returns a copy of this provider whose databases' remotes are fetched by |scheduler|
+func (p DoltDatabaseProvider) WithFetchScheduler(scheduler *actions.FetchScheduler) DoltDatabaseProvider {
+	p.fetchScheduler = scheduler
+	return p
+}
+
+// FetchScheduler returns the scheduler that fetches the remotes of this provider's databases, or nil if there is none
+func (p DoltDatabaseProvider) FetchScheduler() *actions.FetchScheduler {
+	return p.fetchScheduler
+}
+
+// backupSchedulerProvider is implemented by database providers that back up their databases on a schedule.
+type backupSchedulerProvider interface {
+	BackupScheduler() *actions.BackupScheduler
+}
+
+// WithBackupScheduler returns a copy of this provider whose databases are backed up by |scheduler|
+func (p DoltDatabaseProvider) WithBackupScheduler(scheduler *actions.BackupScheduler) DoltDatabaseProvider {
+	p.backupScheduler = scheduler
+	return p
+}
+
+// BackupScheduler returns the scheduler that backs up this provider's databases, or nil if there is none
+func (p DoltDatabaseProvider) BackupScheduler() *actions.BackupScheduler {
+	return p.backupScheduler
+}
+
+// FetchTargets returns every database whose remotes can be fetched in the background.
+func (p DoltDatabaseProvider) FetchTargets() []actions.FetchTarget {
+	var targets []actions.FetchTarget
+	for _, db := range p.DoltDatabases() {
+		// revision databases share the remotes of their base database
+		if _, ok := db.(ReadOnlyDatabase); ok || db.Revision() != "" {
+			continue
+		}
+		targets = append(targets, actions.FetchTarget{Name: db.Name(), DbData: db.DbData()})
+	}
+	return targets
+}
+
+// DatabaseFileSystems returns the file system root of every database with one, keyed by database name.
+func (p DoltDatabaseProvider) DatabaseFileSystems() map[string]filesys.Filesys {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	locations := make(map[string]filesys.Filesys, len(p.dbLocations))
+	for name, loc := range p.dbLocations {
+		if loc != nil {
+			locations[name] = loc
+		}
+	}
+	return locations
+}
+
 func (p DoltDatabaseProvider) FileSystem() filesys.Filesys {
 	return p.fs
 }
@@ -179,6 +269,51 @@ func (p DoltDatabaseProvider) FileSystemForDatabase(dbname string) (filesys.File
 	return dbLocation, nil
 }
 
+// IndexUsage returns the tracker of index reads for the database named |dbName|, loading any usage persisted in the
+// database's directory the first time it is asked for.
+func (p DoltDatabaseProvider) IndexUsage(dbName string) *indexusage.Tracker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := strings.ToLower(dbName)
+	if t, ok := p.indexUsage[key]; ok {
+		return t
+	}
+
+	var dbLocation filesys.Filesys
+	for name, loc := range p.dbLocations {
+		if strings.EqualFold(name, dbName) {
+			dbLocation = loc
+			break
+		}
+	}
+
+	t, err := indexusage.Load(dbLocation)
+	if err != nil {
+		// an unreadable usage file is replaced by the next flush
+		t = indexusage.New(dbLocation)
+	}
+	p.indexUsage[key] = t
+	return t
+}
+
+// IndexUsageTrackers returns the index usage trackers of every database whose usage has been loaded.
+func (p DoltDatabaseProvider) IndexUsageTrackers() []*indexusage.Tracker {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	trackers := make([]*indexusage.Tracker, 0, len(p.indexUsage))
+	for _, t := range p.indexUsage {
+		trackers = append(trackers, t)
+	}
+	return trackers
+}
+
+// ProjectionCache returns the cache of the columns read by scans of the tables of every database.
+func (p DoltDatabaseProvider) ProjectionCache() *projcache.Cache {
+	return p.projections
+}
+
 // Database implements the sql.DatabaseProvider interface
 func (p DoltDatabaseProvider) Database(ctx *sql.Context, name string) (sql.Database, error) {
 	database, b, err := p.SessionDatabase(ctx, name)
@@ -268,6 +403,17 @@ func (p DoltDatabaseProvider) AllDatabases(ctx *sql.Context) (all []sql.Database
 		}
 		all = append(all, db)
 
+		snapshots, err := p.snapshotDatabases(ctx, db)
+		if err != nil {
+			ctx.GetLogger().Warnf("error fetching snapshot databases: %s", err.Error())
+		}
+		for _, snapshot := range snapshots {
+			if strings.ToLower(snapshot.Name()) == currDb {
+				foundDatabase = true
+			}
+			all = append(all, snapshot)
+		}
+
 		if showBranches {
 			revisionDbs, err := p.allRevisionDbs(ctx, db)
 			if err != nil {
@@ -466,18 +612,15 @@ func (p DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name stri
 
 type InitDatabaseHook func(ctx *sql.Context, pro DoltDatabaseProvider, name string, env *env.DoltEnv) error
 
-// ConfigureReplicationDatabaseHook sets up replication for a newly created database as necessary
+// ConfigureReplicationDatabaseHook sets up replication for a newly created database as necessary. The remote URL
+// template configures the first remote of dolt_replicate_to_remote.
 // TODO: consider the replication heads / all heads setting
 func ConfigureReplicationDatabaseHook(ctx *sql.Context, p DoltDatabaseProvider, name string, newEnv *env.DoltEnv) error {
-	_, replicationRemoteName, _ := sql.SystemVariables.GetGlobal(dsess.ReplicateToRemote)
-	if replicationRemoteName == "" {
-		return nil
-	}
-
-	remoteName, ok := replicationRemoteName.(string)
-	if !ok {
+	targets, err := dsess.GetReplicationTargets()
+	if err != nil || len(targets) == 0 {
 		return nil
 	}
+	remoteName := targets[0].Remote
 
 	_, remoteUrlTemplate, _ := sql.SystemVariables.GetGlobal(dsess.ReplicationRemoteURLTemplate)
 	if remoteUrlTemplate == "" {
@@ -494,7 +637,7 @@ func ConfigureReplicationDatabaseHook(ctx *sql.Context, p DoltDatabaseProvider,
 
 	// TODO: params for AWS, others that need them
 	r := env.NewRemote(remoteName, remoteUrl, nil)
-	err := r.Prepare(ctx, newEnv.DoltDB.Format(), p.remoteDialer)
+	err = r.Prepare(ctx, newEnv.DoltDB.Format(), p.remoteDialer)
 	if err != nil {
 		return err
 	}
@@ -689,6 +832,17 @@ func (p DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error
 	}
 
 	delete(p.databases, dbKey)
+	delete(p.indexUsage, strings.ToLower(dbKey))
+	p.projections.Clear(dbKey, "")
+	if p.quotas != nil {
+		p.quotas.Release(dbKey)
+	}
+	if p.fetchScheduler != nil {
+		p.fetchScheduler.Forget(dbKey)
+	}
+	if p.backupScheduler != nil {
+		p.backupScheduler.Forget(dbKey)
+	}
 
 	return p.invalidateDbStateInAllSessions(ctx, name)
 }
@@ -1056,6 +1210,14 @@ func (p DoltDatabaseProvider) SessionDatabase(ctx *sql.Context, name string) (ds
 		return nil, false, err
 	}
 
+	// Neither are snapshot databases
+	if !ok {
+		db, ok, err = p.databaseForSnapshot(ctx, name)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	// A final check: if the database doesn't exist and this is a read replica, attempt to clone it from the remote
 	if !ok {
 		db, err = p.databaseForClone(ctx, name)
@@ -1114,6 +1276,12 @@ func (p DoltDatabaseProvider) TableFunction(_ *sql.Context, name string) (sql.Ta
 	case "dolt_patch":
 		dtf := &PatchTableFunction{}
 		return dtf, nil
+	case "dolt_table_splits":
+		dtf := &TableSplitsTableFunction{}
+		return dtf, nil
+	case "dolt_history_sampled":
+		dtf := &HistorySampledTableFunction{}
+		return dtf, nil
 	}
 
 	return nil, sql.ErrTableFunctionNotFound.New(name)
@@ -0,0 +1,529 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// SampleDateCol is the column of dolt_history_sampled holding the point in time a row was sampled for
+	SampleDateCol = "sample_date"
+
+	// maxHistorySamples is the most commits dolt_history_sampled reads a table at
+	maxHistorySamples = 10000
+)
+
+var _ sql.TableFunction = (*HistorySampledTableFunction)(nil)
+var _ sql.ExecSourceRel = (*HistorySampledTableFunction)(nil)
+
+// HistorySampledTableFunction is the dolt_history_sampled table function. It returns the rows of a table as of a
+// sample of the commits in the history of a revision, like dolt_history_<table> does for every commit, so that trends
+// over a long history can be queried without reading the table at each of its commits. The commits are sampled either
+// every Nth commit in the order of dolt_log, or as the newest commit made at or before each step of an interval over a
+// range of dates. Only the metadata of the commits that aren't sampled is read.
+type HistorySampledTableFunction struct {
+	ctx *sql.Context
+
+	tableNameExpr sql.Expression
+	everyExpr     sql.Expression
+	intervalExpr  sql.Expression
+	startExpr     sql.Expression
+	endExpr       sql.Expression
+	revisionExpr  sql.Expression
+	database      sql.Database
+
+	table  *DoltTable
+	head   *doltdb.Commit
+	sqlSch sql.Schema
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (hs *HistorySampledTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &HistorySampledTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (hs *HistorySampledTableFunction) Database() sql.Database {
+	return hs.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (hs *HistorySampledTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nhs := *hs
+	nhs.database = database
+	return &nhs, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (hs *HistorySampledTableFunction) Name() string {
+	return "dolt_history_sampled"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (hs *HistorySampledTableFunction) Resolved() bool {
+	for _, expr := range hs.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (hs *HistorySampledTableFunction) String() string {
+	args := make([]string, len(hs.Expressions()))
+	for i, expr := range hs.Expressions() {
+		args[i] = expr.String()
+	}
+	return fmt.Sprintf("DOLT_HISTORY_SAMPLED(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (hs *HistorySampledTableFunction) Schema() sql.Schema {
+	return hs.sqlSch
+}
+
+// Children implements the sql.Node interface.
+func (hs *HistorySampledTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (hs *HistorySampledTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return hs, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (hs *HistorySampledTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tableNameVal, err := hs.tableNameExpr.Eval(hs.ctx, nil)
+	if err != nil {
+		return false
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return false
+	}
+
+	return opChecker.UserHasPrivileges(ctx,
+		sql.NewPrivilegedOperation(hs.database.Name(), tableName, "", sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (hs *HistorySampledTableFunction) Expressions() []sql.Expression {
+	exprs := []sql.Expression{hs.tableNameExpr}
+	if hs.everyExpr != nil {
+		exprs = append(exprs, hs.everyExpr)
+	} else {
+		exprs = append(exprs, hs.intervalExpr, hs.startExpr, hs.endExpr)
+	}
+	if hs.revisionExpr != nil {
+		exprs = append(exprs, hs.revisionExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface. The function takes either a table name, a number of
+// commits N and an optional revision, to sample every Nth commit, or a table name, an interval, a start date, an end
+// date and an optional revision, to sample the commit as of each step of the interval from the start to the end.
+func (hs *HistorySampledTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 2 || len(expression) > 5 {
+		return nil, sql.ErrInvalidArgumentNumber.New(hs.Name(), "2 to 5", len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(hs.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(hs.Name(), expr.String())
+		}
+	}
+
+	newHs := *hs
+	newHs.tableNameExpr = expression[0]
+	newHs.everyExpr, newHs.intervalExpr, newHs.startExpr, newHs.endExpr, newHs.revisionExpr = nil, nil, nil, nil, nil
+	if types.IsInteger(expression[1].Type()) {
+		if len(expression) > 3 {
+			return nil, sql.ErrInvalidArgumentNumber.New(hs.Name()+" with a number of commits", "2 or 3", len(expression))
+		}
+		newHs.everyExpr = expression[1]
+		if len(expression) == 3 {
+			newHs.revisionExpr = expression[2]
+		}
+	} else {
+		if len(expression) < 4 {
+			return nil, sql.ErrInvalidArgumentNumber.New(hs.Name()+" with an interval", "4 or 5", len(expression))
+		}
+		newHs.intervalExpr, newHs.startExpr, newHs.endExpr = expression[1], expression[2], expression[3]
+		if len(expression) == 5 {
+			newHs.revisionExpr = expression[4]
+		}
+		if !types.IsText(newHs.intervalExpr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(newHs.Name(), newHs.intervalExpr.String())
+		}
+	}
+
+	if !types.IsText(newHs.tableNameExpr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(newHs.Name(), newHs.tableNameExpr.String())
+	}
+	if newHs.revisionExpr != nil && !types.IsText(newHs.revisionExpr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(newHs.Name(), newHs.revisionExpr.String())
+	}
+
+	if err := newHs.generateSchema(newHs.ctx); err != nil {
+		return nil, err
+	}
+	return &newHs, nil
+}
+
+// generateSchema loads the table at the function's revision, and sets the schema of the function to the schema of
+// dolt_history_<table>, followed by the sample_date column.
+func (hs *HistorySampledTableFunction) generateSchema(ctx *sql.Context) error {
+	if !hs.Resolved() {
+		return nil
+	}
+
+	sqledb, ok := hs.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", hs.database)
+	}
+
+	tableNameVal, err := hs.tableNameExpr.Eval(ctx, nil)
+	if err != nil {
+		return err
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return ErrInvalidTableName.New(hs.tableNameExpr.String())
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	if hs.revisionExpr != nil {
+		revisionVal, err := hs.revisionExpr.Eval(ctx, nil)
+		if err != nil {
+			return err
+		}
+		revision, err := interfaceToString(revisionVal)
+		if err != nil {
+			return err
+		}
+		cs, err := doltdb.NewCommitSpec(revision)
+		if err != nil {
+			return err
+		}
+		headRef, err := sess.CWBHeadRef(ctx, sqledb.Name())
+		if err != nil {
+			return err
+		}
+		hs.head, err = sqledb.DbData().Ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return err
+		}
+	} else {
+		hs.head, err = sess.GetHeadCommit(ctx, sqledb.Name())
+		if err != nil {
+			return err
+		}
+	}
+
+	root, err := hs.head.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	tbl, resolvedName, ok, err := root.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableNotFound.New(tableName)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	hs.table, err = NewDoltTable(resolvedName, sch, tbl, sqledb, editor.Options{})
+	if err != nil {
+		return err
+	}
+
+	// table functions have no table name for the source of their columns
+	hs.sqlSch = append(historyTableSchema("", hs.table), &sql.Column{
+		Name: SampleDateCol,
+		Type: types.Datetime,
+	})
+	return nil
+}
+
+// historySample is a commit that dolt_history_sampled reads its table at.
+type historySample struct {
+	h  hash.Hash
+	cm *doltdb.Commit
+	// at is the point in time the commit was sampled for, or the time of the commit when every Nth commit is sampled
+	at time.Time
+}
+
+// RowIter implements the sql.Node interface
+func (hs *HistorySampledTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	ddb := hs.table.db.DbData().Ddb
+	headHash, err := hs.head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	commits, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{headHash}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []historySample
+	if hs.everyExpr != nil {
+		samples, err = hs.sampleEvery(ctx, commits)
+	} else {
+		samples, err = hs.sampleIntervals(ctx, commits)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tags := append(hs.table.ProjectedTags(), schema.HistoryCommitHashTag, schema.HistoryCommitterTag, schema.HistoryCommitDateTag)
+	return &historySampledIter{table: hs.table, tags: tags, samples: samples}, nil
+}
+
+// sampleEvery samples every Nth commit of |commits|, starting with the first.
+func (hs *HistorySampledTableFunction) sampleEvery(ctx *sql.Context, commits doltdb.CommitItr) ([]historySample, error) {
+	everyVal, err := hs.everyExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	everyVal, _, err = types.Int64.Convert(everyVal)
+	if err != nil {
+		return nil, err
+	}
+	every, ok := everyVal.(int64)
+	if !ok || every < 1 {
+		return nil, sql.ErrInvalidArgumentDetails.New(hs.Name(), hs.everyExpr.String())
+	}
+
+	var samples []historySample
+	for i := int64(0); ; i++ {
+		h, cm, err := commits.Next(ctx)
+		if err == io.EOF {
+			return samples, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if i%every != 0 {
+			continue
+		}
+		if len(samples) == maxHistorySamples {
+			return nil, fmt.Errorf("%s samples more than %d commits; sample fewer", hs.Name(), maxHistorySamples)
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, historySample{h: h, cm: cm, at: meta.Time()})
+	}
+}
+
+// sampleIntervals samples the newest commit of |commits| made at or before each step of the function's interval,
+// from its start date to its end date. The steps before the first commit have no sample.
+func (hs *HistorySampledTableFunction) sampleIntervals(ctx *sql.Context, commits doltdb.CommitItr) ([]historySample, error) {
+	intervalVal, err := hs.intervalExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	intervalStr, err := interfaceToString(intervalVal)
+	if err != nil {
+		return nil, err
+	}
+	step, err := parseSampleInterval(intervalStr)
+	if err != nil {
+		return nil, err
+	}
+	start, err := hs.evalTime(ctx, hs.startExpr)
+	if err != nil {
+		return nil, err
+	}
+	end, err := hs.evalTime(ctx, hs.endExpr)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%s: the end date %s is before the start date %s", hs.Name(), end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	var points []time.Time
+	for t := start; !t.After(end); t = step(t) {
+		if len(points) == maxHistorySamples {
+			return nil, fmt.Errorf("%s samples more than %d points in time; use a longer interval", hs.Name(), maxHistorySamples)
+		}
+		points = append(points, t)
+	}
+
+	// only the metadata of the commits is read to find the newest one as of each point
+	var history []historySample
+	for {
+		h, cm, err := commits.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if meta.Time().After(end) {
+			continue
+		}
+		history = append(history, historySample{h: h, cm: cm, at: meta.Time()})
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].at.Before(history[j].at)
+	})
+
+	var samples []historySample
+	for _, p := range points {
+		// the index of the first commit made after |p|
+		i := sort.Search(len(history), func(i int) bool {
+			return history[i].at.After(p)
+		})
+		if i == 0 {
+			continue
+		}
+		samples = append(samples, historySample{h: history[i-1].h, cm: history[i-1].cm, at: p})
+	}
+	return samples, nil
+}
+
+func (hs *HistorySampledTableFunction) evalTime(ctx *sql.Context, expr sql.Expression) (time.Time, error) {
+	v, err := expr.Eval(ctx, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	v, _, err = types.Datetime.Convert(v)
+	if err != nil {
+		return time.Time{}, sql.ErrInvalidArgumentDetails.New(hs.Name(), expr.String())
+	}
+	t, ok := v.(time.Time)
+	if !ok {
+		return time.Time{}, sql.ErrInvalidArgumentDetails.New(hs.Name(), expr.String())
+	}
+	return t, nil
+}
+
+// parseSampleInterval parses an interval of the form '<n> <unit>', such as '1 week' or '3 months', where the unit is
+// one of second, minute, hour, day, week, month or year, and returns a function adding the interval to a time.
+func parseSampleInterval(s string) (func(time.Time) time.Time, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid interval '%s': expected '<n> <unit>', such as '1 week'", s)
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid interval '%s': '%s' is not a positive integer", s, fields[0])
+	}
+
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "second":
+		return func(t time.Time) time.Time { return t.Add(time.Duration(n) * time.Second) }, nil
+	case "minute":
+		return func(t time.Time) time.Time { return t.Add(time.Duration(n) * time.Minute) }, nil
+	case "hour":
+		return func(t time.Time) time.Time { return t.Add(time.Duration(n) * time.Hour) }, nil
+	case "day":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, n) }, nil
+	case "week":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, 7*n) }, nil
+	case "month":
+		return func(t time.Time) time.Time { return t.AddDate(0, n, 0) }, nil
+	case "year":
+		return func(t time.Time) time.Time { return t.AddDate(n, 0, 0) }, nil
+	default:
+		return nil, fmt.Errorf("invalid interval '%s': unknown unit '%s', expected second, minute, hour, day, week, month or year", s, fields[1])
+	}
+}
+
+// historySampledIter returns the rows of a table at each of a list of sampled commits, with the columns of
+// dolt_history_<table> and the time each commit was sampled for.
+type historySampledIter struct {
+	table   *DoltTable
+	tags    []uint64
+	samples []historySample
+	curr    *historyIter
+	at      time.Time
+}
+
+var _ sql.RowIter = (*historySampledIter)(nil)
+
+func (i *historySampledIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		if i.curr == nil {
+			if len(i.samples) == 0 {
+				return nil, io.EOF
+			}
+			s := i.samples[0]
+			i.samples = i.samples[1:]
+			iter, err := newRowItrForTableAtCommit(ctx, i.table, s.h, s.cm, sql.IndexLookup{}, i.tags)
+			if err != nil {
+				return nil, err
+			}
+			i.curr, i.at = iter, s.at
+		}
+
+		r, err := i.curr.Next(ctx)
+		if err == io.EOF {
+			i.curr = nil
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return append(r, i.at), nil
+	}
+}
+
+func (i *historySampledIter) Close(ctx *sql.Context) error {
+	return nil
+}
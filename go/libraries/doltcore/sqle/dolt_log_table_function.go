@@ -33,6 +33,10 @@ import (
 var _ sql.TableFunction = (*LogTableFunction)(nil)
 var _ sql.ExecSourceRel = (*LogTableFunction)(nil)
 
+// afterCommitFlag is the option of the dolt_log table function which lists the commits after a commit, to page
+// through a log with the last commit of each page.
+const afterCommitFlag = "after-commit"
+
 type LogTableFunction struct {
 	ctx *sql.Context
 
@@ -43,6 +47,7 @@ type LogTableFunction struct {
 	minParents  int
 	showParents bool
 	decoration  string
+	afterCommit string
 
 	database sql.Database
 }
@@ -130,6 +135,10 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, fmt.Sprintf("--%s %s", cli.DecorateFlag, ltf.decoration))
 	}
 
+	if len(ltf.afterCommit) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", afterCommitFlag, ltf.afterCommit))
+	}
+
 	return strings.Join(options, ", ")
 }
 
@@ -221,7 +230,9 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 		return err
 	}
 
-	apr, err := cli.CreateLogArgParser().Parse(args)
+	ap := cli.CreateLogArgParser()
+	ap.SupportsString(afterCommitFlag, "", "commit", "Lists the commits which come after the given commit in the log.")
+	apr, err := ap.Parse(args)
 	if err != nil {
 		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), err.Error())
 	}
@@ -245,6 +256,7 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("invalid --decorate option: %s", decorateOption))
 	}
 	ltf.decoration = decorateOption
+	ltf.afterCommit = apr.GetValueOrDefault(afterCommitFlag, "")
 
 	return nil
 }
@@ -589,9 +601,21 @@ func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *d
 		return nil, err
 	}
 
-	child, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{h}, matchFn)
-	if err != nil {
-		return nil, err
+	var child doltdb.CommitItr
+	if len(ltf.afterCommit) > 0 {
+		after, err := resolveCommitHash(ctx, ddb, ltf.afterCommit)
+		if err != nil {
+			return nil, err
+		}
+		child, err = commitwalk.GetTopologicalOrderIteratorAfter(ctx, ddb, h, after, matchFn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		child, err = commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{h}, matchFn)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &logTableFunctionRowIter{
@@ -623,6 +647,16 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 	if err != nil {
 		return nil, err
 	}
+	if len(ltf.afterCommit) > 0 {
+		after, err := resolveCommitHash(ctx, ddb, ltf.afterCommit)
+		if err != nil {
+			return nil, err
+		}
+		child, err = commitwalk.GetCommitsAfter(ctx, ddb, child, after)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	var headHash hash.Hash
 
@@ -639,6 +673,18 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 	}, nil
 }
 
+func resolveCommitHash(ctx *sql.Context, ddb *doltdb.DoltDB, commitStr string) (hash.Hash, error) {
+	cs, err := doltdb.NewCommitSpec(commitStr)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	cm, err := ddb.Resolve(ctx, cs, nil)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return cm.HashOf()
+}
+
 // Next retrieves the next row. It will return io.EOF if it's the last row.
 // After retrieving the last row, Close will be automatically closed.
 func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
@@ -0,0 +1,357 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/store/prolly"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var _ sql.TableFunction = (*TableSplitsTableFunction)(nil)
+var _ sql.ExecSourceRel = (*TableSplitsTableFunction)(nil)
+
+// TableSplitsTableFunction is the dolt_table_splits table function. It divides the primary key space of a table at
+// a revision into ranges holding close to the same number of rows, so that the reads of a snapshot can be spread
+// evenly over the workers of an external compute engine. Each worker reads the rows of its range with the predicate
+// of the range, using AS OF with the commit the splits were computed for.
+type TableSplitsTableFunction struct {
+	ctx *sql.Context
+
+	tableNameExpr sql.Expression
+	splitsExpr    sql.Expression
+	revisionExpr  sql.Expression
+	database      sql.Database
+}
+
+var tableSplitsSchema = sql.Schema{
+	&sql.Column{Name: "split", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "commit_hash", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "lower_bound", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "upper_bound", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "row_count", Type: types.Uint64, Nullable: false},
+	&sql.Column{Name: "predicate", Type: types.LongText, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (ts *TableSplitsTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &TableSplitsTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (ts *TableSplitsTableFunction) Database() sql.Database {
+	return ts.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (ts *TableSplitsTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nts := *ts
+	nts.database = database
+	return &nts, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (ts *TableSplitsTableFunction) Name() string {
+	return "dolt_table_splits"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (ts *TableSplitsTableFunction) Resolved() bool {
+	for _, expr := range ts.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (ts *TableSplitsTableFunction) String() string {
+	if ts.revisionExpr != nil {
+		return fmt.Sprintf("DOLT_TABLE_SPLITS(%s, %s, %s)", ts.tableNameExpr.String(), ts.splitsExpr.String(), ts.revisionExpr.String())
+	}
+	return fmt.Sprintf("DOLT_TABLE_SPLITS(%s, %s)", ts.tableNameExpr.String(), ts.splitsExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (ts *TableSplitsTableFunction) Schema() sql.Schema {
+	return tableSplitsSchema
+}
+
+// Children implements the sql.Node interface.
+func (ts *TableSplitsTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (ts *TableSplitsTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return ts, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (ts *TableSplitsTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tableNameVal, err := ts.tableNameExpr.Eval(ts.ctx, nil)
+	if err != nil {
+		return false
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return false
+	}
+
+	return opChecker.UserHasPrivileges(ctx,
+		sql.NewPrivilegedOperation(ts.database.Name(), tableName, "", sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (ts *TableSplitsTableFunction) Expressions() []sql.Expression {
+	exprs := []sql.Expression{ts.tableNameExpr, ts.splitsExpr}
+	if ts.revisionExpr != nil {
+		exprs = append(exprs, ts.revisionExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (ts *TableSplitsTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 2 || len(expression) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(ts.Name(), "2 or 3", len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(ts.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(ts.Name(), expr.String())
+		}
+	}
+
+	newTs := *ts
+	newTs.tableNameExpr = expression[0]
+	newTs.splitsExpr = expression[1]
+	if len(expression) == 3 {
+		newTs.revisionExpr = expression[2]
+	}
+
+	if !types.IsText(newTs.tableNameExpr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(newTs.Name(), newTs.tableNameExpr.String())
+	}
+	if !types.IsInteger(newTs.splitsExpr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(newTs.Name(), newTs.splitsExpr.String())
+	}
+	if newTs.revisionExpr != nil && !types.IsText(newTs.revisionExpr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(newTs.Name(), newTs.revisionExpr.String())
+	}
+
+	return &newTs, nil
+}
+
+// evaluateArguments returns the table name, the number of splits and the revision of the function's arguments. The
+// revision defaults to HEAD.
+func (ts *TableSplitsTableFunction) evaluateArguments() (string, int64, string, error) {
+	tableNameVal, err := ts.tableNameExpr.Eval(ts.ctx, nil)
+	if err != nil {
+		return "", 0, "", err
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return "", 0, "", ErrInvalidTableName.New(ts.tableNameExpr.String())
+	}
+
+	splitsVal, err := ts.splitsExpr.Eval(ts.ctx, nil)
+	if err != nil {
+		return "", 0, "", err
+	}
+	splitsVal, _, err = types.Int64.Convert(splitsVal)
+	if err != nil {
+		return "", 0, "", err
+	}
+	splits, ok := splitsVal.(int64)
+	if !ok || splits < 1 {
+		return "", 0, "", sql.ErrInvalidArgumentDetails.New(ts.Name(), ts.splitsExpr.String())
+	}
+
+	revision := "HEAD"
+	if ts.revisionExpr != nil {
+		revisionVal, err := ts.revisionExpr.Eval(ts.ctx, nil)
+		if err != nil {
+			return "", 0, "", err
+		}
+		revision, err = interfaceToString(revisionVal)
+		if err != nil {
+			return "", 0, "", err
+		}
+	}
+
+	return tableName, splits, revision, nil
+}
+
+// RowIter implements the sql.Node interface
+func (ts *TableSplitsTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	tableName, splits, revision, err := ts.evaluateArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb, ok := ts.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", ts.database)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	root, _, commitHash, err := sess.ResolveRootForRef(ctx, sqledb.Name(), revision)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, tableName, ok, err := root.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(tableName)
+	}
+	if !storetypes.IsFormat_DOLT(tbl.Format()) {
+		return nil, fmt.Errorf("%s is not supported for tables in the %s format", ts.Name(), tbl.Format().VersionString())
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, fmt.Errorf("%s requires a primary key, and table %s has none", ts.Name(), tableName)
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tableSplits(ctx, durable.ProllyMapFromIndex(idx), sch, int(splits))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		r[1] = commitHash
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// tableSplits divides the rows of |m| into |splits| ranges of close to the same number of rows, or into one range per
+// row for maps with fewer rows. The bounds of the ranges are the keys at evenly spaced ordinals of the map, which are
+// found with the row counts of its tree nodes without reading the leaves in between.
+func tableSplits(ctx *sql.Context, m prolly.Map, sch schema.Schema, splits int) ([]sql.Row, error) {
+	count, err := m.Count()
+	if err != nil {
+		return nil, err
+	}
+	if splits > count {
+		splits = count
+	}
+	if splits < 1 {
+		splits = 1
+	}
+
+	pkSch, err := schema.SchemaFromCols(sch.GetPKCols())
+	if err != nil {
+		return nil, err
+	}
+	var quoted []string
+	for _, col := range sch.GetPKCols().GetColumns() {
+		quoted = append(quoted, sqlfmt.QuoteIdentifier(col.Name))
+	}
+	cols := "(" + strings.Join(quoted, ",") + ")"
+
+	// bounds[i] is the lower bound of split i and the upper bound of split i-1, with no bound before the first split
+	// or after the last one
+	bounds := make([]string, splits+1)
+	for i := 1; i < splits; i++ {
+		ord := uint64(i * count / splits)
+		iter, err := m.IterOrdinalRange(ctx, ord, ord+1)
+		if err != nil {
+			return nil, err
+		}
+		k, _, err := iter.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i], err = keyTupleString(ctx, m, k, pkSch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows := make([]sql.Row, splits)
+	for i := range rows {
+		var lower, upper interface{}
+		var conds []string
+		if bounds[i] != "" {
+			lower = bounds[i]
+			conds = append(conds, cols+" >= "+bounds[i])
+		}
+		if bounds[i+1] != "" {
+			upper = bounds[i+1]
+			conds = append(conds, cols+" < "+bounds[i+1])
+		}
+		predicate := "TRUE"
+		if len(conds) > 0 {
+			predicate = strings.Join(conds, " AND ")
+		}
+		rowCount := uint64((i+1)*count/splits - i*count/splits)
+		rows[i] = sql.Row{int64(i), nil, lower, upper, rowCount, predicate}
+	}
+	return rows, nil
+}
+
+// keyTupleString returns the key |k| of |m| as a SQL tuple literal.
+func keyTupleString(ctx *sql.Context, m prolly.Map, k val.Tuple, pkSch schema.Schema) (string, error) {
+	kd := m.KeyDesc()
+	r := make(sql.Row, kd.Count())
+	for i := range r {
+		v, err := index.GetField(ctx, kd, i, k, m.NodeStore())
+		if err != nil {
+			return "", err
+		}
+		r[i] = v
+	}
+	return sqlfmt.SqlRowAsTupleString(r, pkSch)
+}
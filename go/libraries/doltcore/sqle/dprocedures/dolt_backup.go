@@ -34,6 +34,9 @@ import (
 const (
 	DoltBackupFuncName = "dolt_backup"
 
+	// DoltBackupWarningCode is the code of the warnings of dolt_backup, the code for an unknown error
+	DoltBackupWarningCode int = 1105
+
 	statusOk  = 1
 	statusErr = 0
 )
@@ -248,5 +251,12 @@ func syncRoots(ctx *sql.Context, dbData env.DbData, sess *dsess.DoltSession, bac
 		return fmt.Errorf("error syncing backup: %w", err)
 	}
 
+	_, err = actions.CompactBackup(ctx, dbData.Ddb, destDb)
+	if err == actions.ErrBackupNotCompactable {
+		ctx.Warn(DoltBackupWarningCode, err.Error())
+	} else if err != nil {
+		return fmt.Errorf("error garbage collecting backup: %w", err)
+	}
+
 	return nil
 }
@@ -27,7 +27,6 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
-	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
@@ -114,7 +113,7 @@ func renameBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseRe
 	force := apr.Contains(cli.ForceFlag)
 
 	if !force {
-		err := validateBranchNotActiveInAnySession(ctx, oldBranchName)
+		err := dsess.ValidateBranchNotActiveInAnySession(ctx, oldBranchName)
 		if err != nil {
 			return err
 		}
@@ -124,7 +123,7 @@ func renameBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseRe
 		return err
 	}
 
-	err := actions.RenameBranch(ctx, dbData, oldBranchName, newBranchName, sess.Provider(), force)
+	err := actions.RenameBranch(ctx, dbData, oldBranchName, newBranchName, sess.Provider(), force, dsess.BranchProtection(ctx))
 	if err != nil {
 		return err
 	}
@@ -155,19 +154,9 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParse
 		return InvalidArgErr
 	}
 
-	// The current branch on CLI can be deleted as user can be on different branch on SQL and delete it from SQL session.
-	// To update current head info on RepoState, we need DoltEnv to load CLI environment.
-	var headOnCLI string
-	fs, err := sess.Provider().FileSystemForDatabase(dbName)
-	if err == nil {
-		if repoState, err := env.LoadRepoState(fs); err == nil {
-			headOnCLI = repoState.Head.Ref.GetPath()
-		}
-	}
-
 	// Verify that we can delete all branches before continuing
 	for _, branchName := range apr.Args {
-		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
+		if err := branch_control.CanDeleteBranch(ctx, branchName); err != nil {
 			return err
 		}
 	}
@@ -180,20 +169,22 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParse
 
 		force := apr.Contains(cli.DeleteForceFlag) || apr.Contains(cli.ForceFlag)
 		if !force {
-			err = validateBranchNotActiveInAnySession(ctx, branchName)
+			err := dsess.ValidateBranchNotActiveInAnySession(ctx, branchName)
 			if err != nil {
 				return err
 			}
 		}
 
-		if headOnCLI == branchName && sqlserver.RunningInServerMode() && !shouldAllowDefaultBranchDeletion(ctx) {
-			return fmt.Errorf("unable to delete branch '%s', because it is the default branch for "+
-				"database '%s'; this can by changed on the command line, by stopping the sql-server, "+
-				"running `dolt checkout <another_branch> and restarting the sql-server", branchName, dbName)
+		// The current branch on CLI can be deleted as user can be on different branch on SQL and delete it from SQL
+		// session, unless it's the default branch of the server.
+		err := dsess.ValidateBranchNotDefault(ctx, dbName, branchName)
+		if err != nil {
+			return err
 		}
 
 		err = actions.DeleteBranch(ctx, dbData, branchName, actions.DeleteOptions{
-			Force: force,
+			Force:      force,
+			Protection: dsess.BranchProtection(ctx),
 		}, dSess.Provider())
 		if err != nil {
 			return err
@@ -203,68 +194,6 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParse
 	return nil
 }
 
-// shouldAllowDefaultBranchDeletion returns true if the default branch deletion check should be
-// bypassed for testing. This should only ever be true for tests that need to invalidate a databases
-// default branch to test recovery from a bad state. We determine if the check should be bypassed by
-// looking for the presence of an undocumented dolt user var, dolt_allow_default_branch_deletion.
-func shouldAllowDefaultBranchDeletion(ctx *sql.Context) bool {
-	_, userVar, _ := ctx.Session.GetUserVariable(ctx, "dolt_allow_default_branch_deletion")
-	return userVar != nil
-}
-
-// validateBranchNotActiveInAnySessions returns an error if the specified branch is currently
-// selected as the active branch for any active server sessions.
-func validateBranchNotActiveInAnySession(ctx *sql.Context, branchName string) error {
-	currentDbName, _, err := getRevisionForRevisionDatabase(ctx, ctx.GetCurrentDatabase())
-	if err != nil {
-		return err
-	}
-
-	if currentDbName == "" {
-		return nil
-	}
-
-	if sqlserver.RunningInServerMode() == false {
-		return nil
-	}
-
-	runningServer, _ := sqlserver.GetRunningServer()
-	if runningServer == nil {
-		return nil
-	}
-	sessionManager := runningServer.SessionManager()
-	branchRef := ref.NewBranchRef(branchName)
-
-	return sessionManager.Iter(func(session sql.Session) (bool, error) {
-		dsess, ok := session.(*dsess.DoltSession)
-		if !ok {
-			return false, fmt.Errorf("unexpected session type: %T", session)
-		}
-
-		sessionDatabase := dsess.Session.GetCurrentDatabase()
-		sessionDbName, _, err := getRevisionForRevisionDatabase(ctx, dsess.GetCurrentDatabase())
-		if err != nil {
-			return false, err
-		}
-
-		if len(sessionDatabase) == 0 || sessionDbName != currentDbName {
-			return false, nil
-		}
-
-		activeBranchRef, err := dsess.CWBHeadRef(ctx, sessionDatabase)
-		if err != nil {
-			return false, err
-		}
-
-		if ref.Equals(branchRef, activeBranchRef) {
-			return false, fmt.Errorf("unsafe to delete or rename branches in use in other sessions; " +
-				"use --force to force the change")
-		}
-
-		return false, nil
-	})
-}
-
 // TODO: the config should be available via the context, it's unnecessary to do an env.Load here and this should be removed
 func loadConfig(ctx *sql.Context) *env.DoltCliConfig {
 	// When executing branch actions from SQL, we don't have access to a DoltEnv like we do from
@@ -332,7 +261,12 @@ func createNewBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgPars
 		return err
 	}
 
-	err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, startPt, apr.Contains(cli.ForceFlag))
+	err = dsess.CheckBranchQuota(ctx, ctx.GetCurrentDatabase(), dbData.Ddb, branchName)
+	if err != nil {
+		return err
+	}
+
+	err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, startPt, apr.Contains(cli.ForceFlag), dsess.BranchProtection(ctx))
 	if err != nil {
 		return err
 	}
@@ -378,7 +312,10 @@ func copyABranch(ctx *sql.Context, dbData env.DbData, srcBr string, destBr strin
 			return err
 		}
 	}
-	err := actions.CopyBranchOnDB(ctx, dbData.Ddb, srcBr, destBr, force)
+	if err := dsess.CheckBranchQuota(ctx, ctx.GetCurrentDatabase(), dbData.Ddb, destBr); err != nil {
+		return err
+	}
+	err := actions.CopyBranchOnDB(ctx, dbData.Ddb, srcBr, destBr, force, dsess.BranchProtection(ctx))
 	if err != nil {
 		if err == doltdb.ErrBranchNotFound {
 			return errors.New(fmt.Sprintf("fatal: A branch named '%s' not found", srcBr))
@@ -386,6 +323,8 @@ func copyABranch(ctx *sql.Context, dbData env.DbData, srcBr string, destBr strin
 			return errors.New(fmt.Sprintf("fatal: A branch named '%s' already exists.", destBr))
 		} else if err == doltdb.ErrInvBranchName {
 			return errors.New(fmt.Sprintf("fatal: '%s' is not a valid branch name.", destBr))
+		} else if errors.Is(err, doltdb.ErrProtectedBranch) {
+			return err
 		} else {
 			return errors.New(fmt.Sprintf("fatal: Unexpected error copying branch from '%s' to '%s'", srcBr, destBr))
 		}
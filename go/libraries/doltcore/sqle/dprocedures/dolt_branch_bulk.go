@@ -0,0 +1,140 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltBranchBulk applies a JSON array of branch operations, given as its only argument, to the current database.
+// Either every operation succeeds or no branch is changed.
+func doltBranchBulk(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltBranchBulk(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltBranchBulk(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if len(args) != 1 {
+		return 1, InvalidArgErr
+	}
+
+	ops, err := actions.ParseBranchOps([]byte(args[0]))
+	if err != nil {
+		return 1, err
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	// The current branch on CLI can be renamed from SQL, see renameBranch.
+	var headOnCLI string
+	fs, err := dSess.Provider().FileSystemForDatabase(dbName)
+	if err == nil {
+		if repoState, err := env.LoadRepoState(fs); err == nil {
+			headOnCLI = repoState.Head.Ref.GetPath()
+		}
+	}
+
+	// Verify that every operation is allowed before changing any branch
+	created := 0
+	for _, op := range ops {
+		switch op.Op {
+		case actions.BranchOpCreate:
+			if err = branch_control.CanCreateBranch(ctx, op.Branch); err != nil {
+				return 1, err
+			}
+			if _, exists, err := dbData.Ddb.HasBranch(ctx, op.Branch); err != nil {
+				return 1, err
+			} else if !exists {
+				created++
+			}
+		case actions.BranchOpDelete:
+			if err = branch_control.CanDeleteBranch(ctx, op.Branch); err != nil {
+				return 1, err
+			}
+			if err = dsess.ValidateBranchNotDefault(ctx, dbName, op.Branch); err != nil {
+				return 1, err
+			}
+		case actions.BranchOpRename:
+			if err = branch_control.CanDeleteBranch(ctx, op.Branch); err != nil {
+				return 1, err
+			}
+			if err = branch_control.CanCreateBranch(ctx, op.NewBranch); err != nil {
+				return 1, err
+			}
+			if op.Force {
+				// the destination branch may be overwritten, see renameBranch
+				if err = branch_control.CanDeleteBranch(ctx, op.NewBranch); err != nil {
+					return 1, err
+				}
+			}
+		}
+		if op.Op != actions.BranchOpCreate && !op.Force {
+			if err = dsess.ValidateBranchNotActiveInAnySession(ctx, op.Branch); err != nil {
+				return 1, err
+			}
+		}
+	}
+
+	if created > 0 {
+		branches, err := dbData.Ddb.GetBranches(ctx)
+		if err != nil {
+			return 1, err
+		}
+		if err = dsess.CheckQuota(ctx, dbName, quota.Branches, uint64(len(branches)+created)); err != nil {
+			return 1, err
+		}
+	}
+
+	if err = actions.ApplyBranchOps(ctx, dbData, ops, dSess.Provider()); err != nil {
+		return 1, err
+	}
+
+	if headOnCLI != "" {
+		newHead := headOnCLI
+		for _, op := range ops {
+			if op.Op == actions.BranchOpRename && op.Branch == newHead {
+				newHead = op.NewBranch
+			}
+		}
+		if newHead != headOnCLI {
+			if repoState, err := env.LoadRepoState(fs); err == nil {
+				repoState.Head.Ref = ref.NewBranchRef(newHead)
+				repoState.Save(fs)
+			}
+		}
+	}
+
+	return 0, commitTransaction(ctx, dSess)
+}
@@ -206,7 +206,11 @@ func checkoutRemoteBranch(ctx *sql.Context, dbName string, dbData env.DbData, br
 		return fmt.Errorf("error: could not find %s", branchName)
 	} else if len(remoteRefs) == 1 {
 		remoteRef := remoteRefs[0]
-		err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, remoteRef.String(), false)
+		err = dsess.CheckBranchQuota(ctx, dbName, dbData.Ddb, branchName)
+		if err != nil {
+			return err
+		}
+		err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, remoteRef.String(), false, dsess.BranchProtection(ctx))
 		if err != nil {
 			return err
 		}
@@ -259,7 +263,12 @@ func checkoutNewBranch(ctx *sql.Context, dbName string, dbData env.DbData, apr *
 		newBranchName = newBranch
 	}
 
-	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, false)
+	err = dsess.CheckBranchQuota(ctx, dbName, dbData.Ddb, newBranchName)
+	if err != nil {
+		return err
+	}
+
+	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, false, dsess.BranchProtection(ctx))
 	if err != nil {
 		return err
 	}
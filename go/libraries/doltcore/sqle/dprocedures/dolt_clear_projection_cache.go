@@ -0,0 +1,67 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/projcache"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// projectionCacheProvider is implemented by database providers that cache the columns read by table scans.
+type projectionCacheProvider interface {
+	ProjectionCache() *projcache.Cache
+}
+
+// doltClearProjectionCache evicts the cached projections of the given tables of the current database, or of every
+// table of the current database if no table is given, and returns the number of projections evicted.
+func doltClearProjectionCache(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltClearProjectionCache(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltClearProjectionCache(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 0, fmt.Errorf("Empty database name.")
+	}
+	// revisions of a database share its cached projections
+	dbName = strings.SplitN(dbName, dsess.DbRevisionDelimiter, 2)[0]
+
+	pro, ok := dsess.DSessFromSess(ctx.Session).Provider().(projectionCacheProvider)
+	if !ok || pro.ProjectionCache() == nil {
+		return 0, nil
+	}
+	cache := pro.ProjectionCache()
+
+	if len(args) == 0 {
+		return cache.Clear(dbName, ""), nil
+	}
+	evicted := 0
+	for _, tbl := range args {
+		if tbl == "" {
+			return 0, fmt.Errorf("dolt_clear_projection_cache: table names cannot be empty")
+		}
+		evicted += cache.Clear(dbName, tbl)
+	}
+	return evicted, nil
+}
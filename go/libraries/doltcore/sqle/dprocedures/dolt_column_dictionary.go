@@ -0,0 +1,166 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// defaultColumnDictionarySize is the most strings in a column dictionary, unless --max-values is given.
+const defaultColumnDictionarySize = 4096
+
+// doltColumnDictionary dictionary encodes a string column of a table, or stores its strings in full again with
+// --disable, and rewrites the rows of the table for the new encoding. It stands in for a column storage option of
+// ALTER TABLE, which the SQL parser has no syntax for. With --background, the rewrite is done outside of the
+// session's transaction, see rewriteColumnDictionary.
+func doltColumnDictionary(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltColumnDictionary(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltColumnDictionary(ctx *sql.Context, args []string) (int, error) {
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+	apr, err := cli.CreateColumnDictionaryArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+	if apr.NArg() != 2 {
+		return 1, fmt.Errorf("dolt_column_dictionary requires a table and a column")
+	}
+	maxValues := apr.GetIntOrDefault(cli.MaxValuesParam, defaultColumnDictionarySize)
+	if apr.Contains(cli.DisableFlag) {
+		if apr.Contains(cli.MaxValuesParam) {
+			return 1, fmt.Errorf("--%s cannot be used with --%s", cli.MaxValuesParam, cli.DisableFlag)
+		}
+		maxValues = 0
+	} else if maxValues <= 0 {
+		return 1, fmt.Errorf("--%s must be positive", cli.MaxValuesParam)
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	root := roots.Working
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, apr.Arg(0))
+	if err != nil {
+		return 1, err
+	}
+	if !ok {
+		return 1, sql.ErrTableNotFound.New(apr.Arg(0))
+	}
+
+	if apr.Contains(cli.BackgroundFlag) {
+		// fail fast if the rewrite can't be done
+		if _, err = creation.CheckColumnDictionary(ctx, tbl, apr.Arg(1), maxValues); err != nil {
+			return 1, err
+		}
+		ddb, ok := dSess.GetDoltDB(ctx, dbName)
+		if !ok {
+			return 1, sql.ErrDatabaseNotFound.New(dbName)
+		}
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return 1, err
+		}
+		meta := &datas.WorkingSetMeta{
+			Name:        dSess.Username(),
+			Email:       dSess.Email(),
+			Description: fmt.Sprintf("dictionary rewrite of %s.%s", tblName, apr.Arg(1)),
+		}
+		logger := ctx.GetLogger()
+		colName := apr.Arg(1)
+		go func() {
+			if err := rewriteColumnDictionary(context.Background(), ddb, ws.Ref(), tblName, colName, maxValues, meta); err != nil {
+				logger.Errorf("dictionary rewrite of %s.%s failed: %v", tblName, colName, err)
+			}
+		}()
+		return 0, nil
+	}
+
+	tbl, err = creation.SetColumnDictionary(ctx, tbl, apr.Arg(1), maxValues)
+	if err != nil {
+		return 1, err
+	}
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return 1, err
+	}
+	if err = dSess.SetRoot(ctx, dbName, root); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// rewriteColumnDictionary sets the dictionary of the column |colName| of the table |tableName| in the working set
+// |wsRef| of |ddb|, and rewrites the rows of the table for it. The rewrite is done against the latest working set, and
+// done again if the working set moved before it could be written. Sessions whose transactions wrote the table
+// meanwhile merge their changes with it when they commit, like with any concurrent write.
+func rewriteColumnDictionary(ctx context.Context, ddb *doltdb.DoltDB, wsRef ref.WorkingSetRef, tableName, colName string, maxValues int, meta *datas.WorkingSetMeta) error {
+	for {
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err != nil {
+			return err
+		}
+		prevHash, err := ws.HashOf()
+		if err != nil {
+			return err
+		}
+
+		root := ws.WorkingRoot()
+		tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return sql.ErrTableNotFound.New(tableName)
+		}
+		tbl, err = creation.SetColumnDictionary(ctx, tbl, colName, maxValues)
+		if err != nil {
+			return err
+		}
+		root, err = root.PutTable(ctx, tblName, tbl)
+		if err != nil {
+			return err
+		}
+
+		meta.Timestamp = uint64(time.Now().Unix())
+		err = ddb.UpdateWorkingSet(ctx, wsRef, ws.WithWorkingRoot(root), prevHash, meta)
+		if !errors.Is(err, datas.ErrOptimisticLockFailed) {
+			return err
+		}
+	}
+}
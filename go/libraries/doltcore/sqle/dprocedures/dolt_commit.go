@@ -17,6 +17,7 @@ package dprocedures
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
@@ -24,8 +25,12 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/store/datas"
 )
 
 var hashType = types.MustCreateString(query.Type_TEXT, 32, sql.Collation_ascii_bin)
@@ -110,6 +115,12 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 		}
 	}
 
+	provenance, err := cli.ParseProvenance(apr)
+	if err != nil {
+		return "", err
+	}
+	msg = doltdb.WithProvenanceTrailers(msg, provenance)
+
 	t := ctx.QueryTime()
 	if commitTimeStr, ok := apr.GetValue(cli.DateParam); ok {
 		var err error
@@ -120,6 +131,29 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 		}
 	}
 
+	if !apr.Contains(cli.NoVerifyFlag) {
+		if err := runHooks(ctx, dbName, actions.HookEvent{Type: actions.PreCommitHook, Message: msg}); err != nil {
+			return "", err
+		}
+	}
+
+	var signer datas.CommitSigner
+	if keyID, ok := apr.GetValue(cli.SignKeyParam); ok || apr.Contains(cli.GpgSignFlag) {
+		gpg, err := actions.ConfiguredGPGSigner(loadConfig(ctx), keyID)
+		if err != nil {
+			return "", err
+		}
+		signer = gpg.CommitSigner()
+	}
+
+	if refresh, err := strconv.ParseBool(loadConfig(ctx).GetStringOrDefault(env.DocsDataDictionaryKey, "false")); err != nil {
+		return "", fmt.Errorf("invalid value for %s: %w", env.DocsDataDictionaryKey, err)
+	} else if refresh {
+		if roots, err = dtables.RefreshDataDictionary(ctx, roots); err != nil {
+			return "", err
+		}
+	}
+
 	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, actions.CommitStagedProps{
 		Message:    msg,
 		Date:       t,
@@ -128,6 +162,7 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 		Force:      apr.Contains(cli.ForceFlag),
 		Name:       name,
 		Email:      email,
+		Signer:     signer,
 	})
 	if err != nil {
 		return "", err
@@ -148,6 +183,10 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 		return "", err
 	}
 
+	if err := runHooks(ctx, dbName, actions.HookEvent{Type: actions.PostCommitHook, Message: msg, Commit: h}); err != nil {
+		ctx.Warn(DoltHookWarningCode, "%s", err.Error())
+	}
+
 	return h.String(), nil
 }
 
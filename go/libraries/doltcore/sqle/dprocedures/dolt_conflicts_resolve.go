@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -30,6 +31,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
@@ -75,7 +78,9 @@ func getProllyRowMaps(ctx *sql.Context, vrw types.ValueReadWriter, ns tree.NodeS
 	return durable.ProllyMapFromIndex(idx), nil
 }
 
-func resolveProllyConflicts(ctx *sql.Context, tbl *doltdb.Table, tblName string, sch schema.Schema) (*doltdb.Table, error) {
+// resolveProllyConflicts takes their version of every conflicting row for which |filter| returns true, or of every
+// conflicting row if |filter| is nil. |filter| is called once for every conflict, in order.
+func resolveProllyConflicts(ctx *sql.Context, tbl *doltdb.Table, tblName string, sch schema.Schema, filter func(prolly.ConflictArtifact) bool) (*doltdb.Table, error) {
 	var err error
 	artifactIdx, err := tbl.GetArtifacts(ctx)
 	if err != nil {
@@ -116,6 +121,9 @@ func resolveProllyConflicts(ctx *sql.Context, tbl *doltdb.Table, tblName string,
 		if err != nil {
 			return nil, err
 		}
+		if filter != nil && !filter(cnfArt) {
+			continue
+		}
 
 		// reload if their root hash changes
 		if theirRoot != cnfArt.TheirRootIsh {
@@ -413,7 +421,7 @@ func ResolveDataConflicts(ctx *sql.Context, dSess *dsess.DoltSession, root *dolt
 
 		if !ours {
 			if tbl.Format() == types.Format_DOLT {
-				tbl, err = resolveProllyConflicts(ctx, tbl, tblName, sch)
+				tbl, err = resolveProllyConflicts(ctx, tbl, tblName, sch, nil)
 			} else {
 				state, _, err := dSess.LookupDbState(ctx, dbName)
 				if err != nil {
@@ -442,6 +450,259 @@ func ResolveDataConflicts(ctx *sql.Context, dSess *dsess.DoltSession, root *dolt
 	return dSess.SetRoot(ctx, dbName, root)
 }
 
+// ResolveKeylessConflictRows resolves the conflicts of the keyless table |tblName| whose row ids, as shown in the
+// dolt_row_id column of its conflicts table, are in |rowIds|. Other conflicts in the table are left as they are.
+func ResolveKeylessConflictRows(ctx *sql.Context, dSess *dsess.DoltSession, root *doltdb.RootValue, dbName string, ours bool, tblName string, rowIds []string) error {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return doltdb.ErrTableNotFound
+	}
+	if tbl.Format() != types.Format_DOLT {
+		return fmt.Errorf("resolving conflicts by row id is not supported for this storage format")
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if !schema.IsKeyless(sch) {
+		return fmt.Errorf("table %s has a primary key; conflicts can only be resolved by row id in keyless tables", tblName)
+	}
+	_, ourSch, theirSch, err := tbl.GetConflictSchemas(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if ours && !schema.ColCollsAreEqual(sch.GetAllCols(), ourSch.GetAllCols()) {
+		return ErrConfSchIncompatible
+	} else if !ours && !schema.ColCollsAreEqual(sch.GetAllCols(), theirSch.GetAllCols()) {
+		return ErrConfSchIncompatible
+	}
+
+	artIdx, err := tbl.GetArtifacts(ctx)
+	if err != nil {
+		return err
+	}
+	artMap := durable.ProllyMapFromArtifactIndex(artIdx)
+
+	pending := set.NewStrSet(rowIds)
+	var resolved []prolly.ConflictArtifact
+	var ids merge.KeylessConflictRowIds
+	filter := func(cnfArt prolly.ConflictArtifact) bool {
+		id := ids.Next(cnfArt.Key)
+		if !pending.Contains(id) {
+			return false
+		}
+		pending.Remove(id)
+		resolved = append(resolved, cnfArt)
+		return true
+	}
+
+	if ours {
+		iter, err := artMap.IterAllConflicts(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			cnfArt, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			filter(cnfArt)
+		}
+	} else {
+		tbl, err = resolveProllyConflicts(ctx, tbl, tblName, sch, filter)
+		if err != nil {
+			return err
+		}
+	}
+	if pending.Size() > 0 {
+		return fmt.Errorf("no conflicts in table %s with %s %s", tblName, merge.KeylessConflictRowIdColumn, pending.AsSortedSlice()[0])
+	}
+
+	ed := artMap.Editor()
+	for _, cnfArt := range resolved {
+		if err = ed.Delete(ctx, cnfArt.ArtKey); err != nil {
+			return err
+		}
+	}
+	artMap, err = ed.Flush(ctx)
+	if err != nil {
+		return err
+	}
+	tbl, err = tbl.SetArtifacts(ctx, durable.ArtifactIndexFromProllyMap(artMap))
+	if err != nil {
+		return err
+	}
+
+	newRoot, err := root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return err
+	}
+	if err = validateConstraintViolations(ctx, root, newRoot, tblName); err != nil {
+		return err
+	}
+	return dSess.SetRoot(ctx, dbName, newRoot)
+}
+
+// ResolveConflictRowsWhere resolves the conflicts of the table |tblName| whose rows in its dolt_conflicts table match
+// the predicate |where|, or all of its conflicts if |where| is empty. Each conflict takes our version if |ours| is true
+// and their version otherwise, unless |side| is given: it's an expression evaluated on the conflict's row to 'ours' or
+// 'theirs'. The resolved conflicts are deleted from the dolt_conflicts table, so that they are journaled like the
+// conflicts resolved by deleting them from it.
+func ResolveConflictRowsWhere(ctx *sql.Context, dSess *dsess.DoltSession, root *doltdb.RootValue, dbName string, ours bool, tblName, where, side string) error {
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return doltdb.ErrTableNotFound
+	}
+	if tbl.Format() != types.Format_DOLT {
+		return fmt.Errorf("resolving conflicts with --%s or --%s is not supported for this storage format", cli.WhereParam, cli.SideParam)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := dSess.Provider().Database(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	confTbl, err := getConflictsTable(ctx, db, tblName)
+	if err != nil {
+		return err
+	}
+	confSch := confTbl.Schema()
+
+	var pred, sideExpr sql.Expression
+	if where != "" {
+		if pred, err = sqlutil.ParseRowExpression(ctx, confSch, where); err != nil {
+			return err
+		}
+	}
+	if side != "" {
+		if sideExpr, err = sqlutil.ParseRowExpression(ctx, confSch, side); err != nil {
+			return err
+		}
+	}
+
+	rows, err := readConflictsTableRows(ctx, confTbl)
+	if err != nil {
+		return err
+	}
+	idIdx := confSch.IndexOfColName("dolt_conflict_id")
+	// takeTheirs holds whether each matching conflict, by dolt_conflict_id, resolves to their version
+	takeTheirs := make(map[string]bool)
+	var anyOurs, anyTheirs bool
+	for _, r := range rows {
+		if pred != nil {
+			res, err := sql.EvaluateCondition(ctx, pred, r)
+			if err != nil {
+				return err
+			}
+			if !sql.IsTrue(res) {
+				continue
+			}
+		}
+		theirs := !ours
+		if sideExpr != nil {
+			v, err := sideExpr.Eval(ctx, r)
+			if err != nil {
+				return err
+			}
+			s, _ := v.(string)
+			switch strings.ToLower(s) {
+			case cli.OursFlag:
+				theirs = false
+			case cli.TheirsFlag:
+				theirs = true
+			default:
+				return fmt.Errorf("--%s must evaluate to '%s' or '%s', but was %v", cli.SideParam, cli.OursFlag, cli.TheirsFlag, v)
+			}
+		}
+		anyTheirs = anyTheirs || theirs
+		anyOurs = anyOurs || !theirs
+		takeTheirs[r[idIdx].(string)] = theirs
+	}
+	if len(takeTheirs) == 0 {
+		return nil
+	}
+
+	_, ourSch, theirSch, err := tbl.GetConflictSchemas(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if anyOurs && !schema.ColCollsAreEqual(sch.GetAllCols(), ourSch.GetAllCols()) {
+		return ErrConfSchIncompatible
+	} else if anyTheirs && !schema.ColCollsAreEqual(sch.GetAllCols(), theirSch.GetAllCols()) {
+		return ErrConfSchIncompatible
+	}
+
+	if anyTheirs {
+		tbl, err = resolveProllyConflicts(ctx, tbl, tblName, sch, func(ca prolly.ConflictArtifact) bool {
+			return takeTheirs[dtables.ConflictID(ca)]
+		})
+		if err != nil {
+			return err
+		}
+		newRoot, err := root.PutTable(ctx, tblName, tbl)
+		if err != nil {
+			return err
+		}
+		if err = validateConstraintViolations(ctx, root, newRoot, tblName); err != nil {
+			return err
+		}
+		if err = dSess.SetRoot(ctx, dbName, newRoot); err != nil {
+			return err
+		}
+
+		// the conflicts table now shows the rows taken as our versions
+		if confTbl, err = getConflictsTable(ctx, db, tblName); err != nil {
+			return err
+		}
+		if rows, err = readConflictsTableRows(ctx, confTbl); err != nil {
+			return err
+		}
+	}
+
+	deleter := confTbl.(sql.DeletableTable).Deleter(ctx)
+	for _, r := range rows {
+		if _, ok := takeTheirs[r[idIdx].(string)]; !ok {
+			continue
+		}
+		if err = deleter.Delete(ctx, r); err != nil {
+			_ = deleter.Close(ctx)
+			return err
+		}
+	}
+	return deleter.Close(ctx)
+}
+
+func getConflictsTable(ctx *sql.Context, db sql.Database, tblName string) (sql.Table, error) {
+	confTbl, ok, err := db.GetTableInsensitive(ctx, doltdb.DoltConfTablePrefix+tblName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(doltdb.DoltConfTablePrefix + tblName)
+	}
+	return confTbl, nil
+}
+
+func readConflictsTableRows(ctx *sql.Context, confTbl sql.Table) ([]sql.Row, error) {
+	partitions, err := confTbl.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, confTbl.Schema(), sql.NewTableRowIter(ctx, confTbl, partitions))
+}
+
 func DoDoltConflictsResolve(ctx *sql.Context, args []string) (int, error) {
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
 		return 1, err
@@ -466,9 +727,12 @@ func DoDoltConflictsResolve(ctx *sql.Context, args []string) (int, error) {
 
 	ours := apr.Contains(cli.OursFlag)
 	theirs := apr.Contains(cli.TheirsFlag)
+	side, hasSide := apr.GetValue(cli.SideParam)
 	if ours && theirs {
 		return 1, fmt.Errorf("specify only either --ours or --theirs")
-	} else if !ours && !theirs {
+	} else if hasSide && (ours || theirs) {
+		return 1, fmt.Errorf("--%s can't be used with --ours or --theirs", cli.SideParam)
+	} else if !ours && !theirs && !hasSide {
 		return 1, fmt.Errorf("--ours or --theirs must be supplied")
 	}
 
@@ -476,6 +740,30 @@ func DoDoltConflictsResolve(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("specify at least one table to resolve conflicts")
 	}
 
+	if where, hasWhere := apr.GetValue(cli.WhereParam); hasWhere || hasSide {
+		if apr.NArg() != 1 || apr.Arg(0) == "." {
+			return 1, fmt.Errorf("--%s and --%s require exactly one table", cli.WhereParam, cli.SideParam)
+		} else if apr.Contains(cli.RowFlag) {
+			return 1, fmt.Errorf("--%s can't be used with --%s or --%s", cli.RowFlag, cli.WhereParam, cli.SideParam)
+		}
+		err = ResolveConflictRowsWhere(ctx, dSess, ws.WorkingRoot(), dbName, ours, apr.Arg(0), where, side)
+		if err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+
+	if rowIds, ok := apr.GetValueList(cli.RowFlag); ok {
+		if apr.NArg() != 1 || apr.Arg(0) == "." {
+			return 1, fmt.Errorf("--%s requires exactly one table", cli.RowFlag)
+		}
+		err = ResolveKeylessConflictRows(ctx, dSess, ws.WorkingRoot(), dbName, ours, apr.Arg(0), rowIds)
+		if err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+
 	// get all tables in conflict
 	tbls := apr.Args
 	if len(tbls) == 1 && tbls[0] == "." {
@@ -0,0 +1,113 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// doltCreatePartialIndex creates a partial index, which only stores the rows of its table matching the predicate given
+// with --where. It's the equivalent of CREATE INDEX ... WHERE, which the SQL parser doesn't support.
+func doltCreatePartialIndex(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltCreatePartialIndex(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltCreatePartialIndex(ctx *sql.Context, args []string) (int, error) {
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+	apr, err := cli.CreatePartialIndexArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+	if apr.NArg() < 3 {
+		return 1, fmt.Errorf("dolt_create_partial_index requires a table, an index name, and at least one column")
+	}
+	where, ok := apr.GetValue(cli.WhereParam)
+	if !ok {
+		return 1, fmt.Errorf("dolt_create_partial_index requires a predicate given with --%s", cli.WhereParam)
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return 1, err
+	} else if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	root := roots.Working
+	if !types.IsFormat_DOLT(root.VRW().Format()) {
+		return 1, fmt.Errorf("partial indexes are only supported by the __DOLT__ storage format")
+	}
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, apr.Arg(0))
+	if err != nil {
+		return 1, err
+	}
+	if !ok {
+		return 1, sql.ErrTableNotFound.New(apr.Arg(0))
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return 1, err
+	}
+	pred, err := sqlutil.ParseIndexPredicate(sch, where)
+	if err != nil {
+		return 1, err
+	}
+
+	ret, err := creation.CreateIndex(
+		ctx,
+		tbl,
+		apr.Arg(1),
+		apr.Args[2:],
+		nil,
+		apr.Contains(cli.UniqueFlag),
+		false,
+		true,
+		"",
+		pred,
+		dbState.EditOpts(),
+	)
+	if err != nil {
+		return 1, err
+	}
+	root, err = root.PutTable(ctx, tblName, ret.NewTable)
+	if err != nil {
+		return 1, err
+	}
+	if err = dSess.SetRoot(ctx, dbName, root); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
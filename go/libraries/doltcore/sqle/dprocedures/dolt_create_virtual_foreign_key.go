@@ -0,0 +1,199 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltCreateVirtualForeignKey creates a virtual foreign key, which is stored, diffed and merged like any other foreign
+// key but never enforced: the rows of its tables aren't checked, and neither table needs an index over its columns.
+// It's the equivalent of FOREIGN KEY ... NOT ENFORCED, which the SQL parser doesn't support.
+func doltCreateVirtualForeignKey(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltCreateVirtualForeignKey(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltCreateVirtualForeignKey(ctx *sql.Context, args []string) (int, error) {
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+	apr, err := cli.CreateVirtualForeignKeyArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+	if apr.NArg() < 3 {
+		return 1, fmt.Errorf("dolt_create_virtual_foreign_key requires a table, a foreign key name, and at least one column")
+	}
+	references, ok := apr.GetValue(cli.ReferencesParam)
+	if !ok {
+		return 1, fmt.Errorf("dolt_create_virtual_foreign_key requires the referenced table given with --%s", cli.ReferencesParam)
+	}
+	parentArg, parentColNames, err := parseForeignKeyReference(references)
+	if err != nil {
+		return 1, err
+	}
+	fkName, colNames := apr.Arg(1), apr.Args[2:]
+	if !doltdb.IsValidForeignKeyName(fkName) {
+		return 1, fmt.Errorf("invalid foreign key name `%s` as it must match the regular expression %s", fkName, doltdb.ForeignKeyNameRegexStr)
+	}
+	if len(colNames) != len(parentColNames) {
+		return 1, sql.ErrForeignKeyColumnCountMismatch.New()
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	root := roots.Working
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, apr.Arg(0))
+	if err != nil {
+		return 1, err
+	} else if !ok {
+		return 1, sql.ErrTableNotFound.New(apr.Arg(0))
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return 1, err
+	}
+	parent, parentName, ok, err := root.GetTableInsensitive(ctx, parentArg)
+	if err != nil {
+		return 1, err
+	} else if !ok {
+		return 1, sql.ErrTableNotFound.New(parentArg)
+	}
+	parentSch, err := parent.GetSchema(ctx)
+	if err != nil {
+		return 1, err
+	}
+
+	cols, err := foreignKeyColumns(tblName, sch, colNames)
+	if err != nil {
+		return 1, err
+	}
+	parentCols, err := foreignKeyColumns(parentName, parentSch, parentColNames)
+	if err != nil {
+		return 1, err
+	}
+	fk := doltdb.ForeignKey{
+		Name:                fkName,
+		TableName:           tblName,
+		ReferencedTableName: parentName,
+		NotEnforced:         true,
+	}
+	for i := range cols {
+		if cols[i].TypeInfo.GetTypeIdentifier() != parentCols[i].TypeInfo.GetTypeIdentifier() {
+			return 1, sql.ErrForeignKeyColumnTypeMismatch.New(cols[i].Name, parentCols[i].Name)
+		}
+		fk.TableColumns = append(fk.TableColumns, cols[i].Tag)
+		fk.ReferencedTableColumns = append(fk.ReferencedTableColumns, parentCols[i].Tag)
+		fk.UnresolvedFKDetails.TableColumns = append(fk.UnresolvedFKDetails.TableColumns, cols[i].Name)
+		fk.UnresolvedFKDetails.ReferencedTableColumns = append(fk.UnresolvedFKDetails.ReferencedTableColumns, parentCols[i].Name)
+	}
+
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return 1, err
+	}
+	if err = fkc.AddKeys(fk); err != nil {
+		return 1, err
+	}
+	root, err = root.PutForeignKeyCollection(ctx, fkc)
+	if err != nil {
+		return 1, err
+	}
+	if err = dSess.SetRoot(ctx, dbName, root); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// foreignKeyColumns returns the columns of |sch| named |names|.
+func foreignKeyColumns(tblName string, sch schema.Schema, names []string) ([]schema.Column, error) {
+	cols := make([]schema.Column, len(names))
+	for i, name := range names {
+		col, ok := sch.GetAllCols().GetByNameCaseInsensitive(name)
+		if !ok {
+			return nil, fmt.Errorf("table `%s` does not have column `%s`", tblName, name)
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// parseForeignKeyReference parses the referenced table and columns of a foreign key written as in a REFERENCES
+// clause, e.g. "parent(id)" or "`parent` (`a`, `b`)".
+func parseForeignKeyReference(ref string) (string, []string, error) {
+	var idents []string
+	var ident strings.Builder
+	quoted, open, closed := false, false, false
+	flush := func() error {
+		s := strings.TrimSpace(ident.String())
+		ident.Reset()
+		if s == "" {
+			return fmt.Errorf("invalid foreign key reference '%s'", ref)
+		}
+		idents = append(idents, s)
+		return nil
+	}
+
+	for i := 0; i < len(ref); i++ {
+		c := ref[i]
+		switch {
+		case c == '`' && quoted && i+1 < len(ref) && ref[i+1] == '`':
+			ident.WriteByte('`')
+			i++
+		case c == '`':
+			quoted = !quoted
+		case quoted:
+			ident.WriteByte(c)
+		case closed && c != ' ':
+			return "", nil, fmt.Errorf("invalid foreign key reference '%s'", ref)
+		case c == '(' && !open:
+			if err := flush(); err != nil {
+				return "", nil, err
+			}
+			open = true
+		case (c == ',' || c == ')') && open && !closed:
+			if err := flush(); err != nil {
+				return "", nil, err
+			}
+			closed = c == ')'
+		case c == '(' || c == ')' || c == ',':
+			return "", nil, fmt.Errorf("invalid foreign key reference '%s'", ref)
+		default:
+			ident.WriteByte(c)
+		}
+	}
+	if quoted || !closed {
+		return "", nil, fmt.Errorf("invalid foreign key reference '%s'", ref)
+	}
+	return idents[0], idents[1:], nil
+}
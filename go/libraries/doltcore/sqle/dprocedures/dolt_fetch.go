@@ -25,6 +25,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
 // doltFetch is the stored procedure version for the CLI command `dolt fetch`.
@@ -57,6 +59,38 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 		return cmdFailure, err
 	}
 
+	if apr.Contains(cli.ParallelFlag) && !apr.Contains(cli.AllFlag) {
+		return cmdFailure, fmt.Errorf("--%s requires --%s", cli.ParallelFlag, cli.AllFlag)
+	}
+	if apr.Contains(cli.AllFlag) && apr.NArg() > 0 {
+		return cmdFailure, fmt.Errorf("--%s does not take a remote or refspecs", cli.AllFlag)
+	}
+	if apr.Contains(cli.DaemonFlag) {
+		return cmdFailure, fmt.Errorf("--%s is not supported in SQL, configure remote_fetch in the sql-server config instead", cli.DaemonFlag)
+	}
+
+	var pullOpts []pull.Option
+	if apr.Contains(cli.VerifyFlag) {
+		pullOpts = append(pullOpts, pull.WithChunkVerification())
+	}
+	if apr.Contains(cli.FsckFlag) {
+		pullOpts = append(pullOpts, pull.WithReachabilityCheck())
+	}
+	if apr.Contains(cli.DeltaFlag) {
+		pullOpts = append(pullOpts, pull.WithDeltaTransfer())
+	}
+	if keyPath, ok := apr.GetValue(cli.TrustedKeyParam); ok {
+		key, err := actions.LoadTrustedKey(filesys.LocalFS, keyPath)
+		if err != nil {
+			return cmdFailure, err
+		}
+		pullOpts = append(pullOpts, pull.WithTrustedKey(key))
+	}
+
+	if apr.Contains(cli.AllFlag) {
+		return doDoltFetchAll(ctx, sess, dbData, apr.Contains(cli.ParallelFlag), pullOpts)
+	}
+
 	remote, refSpecs, err := env.NewFetchOpts(apr.Args, dbData.Rsr)
 	if err != nil {
 		return cmdFailure, err
@@ -67,7 +101,30 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 		return 1, err
 	}
 
-	err = actions.FetchRefSpecs(ctx, dbData, srcDB, refSpecs, remote, ref.UpdateMode{Force: true}, runProgFuncs, stopProgFuncs)
+	err = actions.FetchRefSpecs(ctx, dbData, srcDB, refSpecs, remote, ref.UpdateMode{Force: true}, runProgFuncs, stopProgFuncs, pullOpts...)
+	if err != nil {
+		return cmdFailure, fmt.Errorf("fetch failed: %w", err)
+	}
+	return cmdSuccess, nil
+}
+
+// doDoltFetchAll fetches every remote of |dbData| with |pullOpts|, concurrently if |parallel| is true.
+func doDoltFetchAll(ctx *sql.Context, sess *dsess.DoltSession, dbData env.DbData, parallel bool, pullOpts []pull.Option) (int, error) {
+	remotes, refSpecs, err := env.NewFetchAllOpts(dbData.Rsr)
+	if err != nil {
+		return cmdFailure, err
+	}
+
+	fetches := make([]actions.RemoteFetch, len(remotes))
+	for i, r := range remotes {
+		srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), r, false)
+		if err != nil {
+			return cmdFailure, err
+		}
+		fetches[i] = actions.RemoteFetch{Remote: r, SrcDB: srcDB, RefSpecs: refSpecs[i]}
+	}
+
+	err = actions.FetchRemotes(ctx, dbData, fetches, ref.UpdateMode{Force: true}, parallel, runProgFuncs, stopProgFuncs, pullOpts...)
 	if err != nil {
 		return cmdFailure, fmt.Errorf("fetch failed: %w", err)
 	}
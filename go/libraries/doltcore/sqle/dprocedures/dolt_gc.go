@@ -15,17 +15,19 @@
 package dprocedures
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 const (
@@ -53,8 +55,6 @@ func doltGC(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	return rowToIter(int64(res)), nil
 }
 
-var ErrServerPerformedGC = errors.New("this connection was established when this server performed an online garbage collection. this connection can no longer be used. please reconnect.")
-
 func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 	dbName := ctx.GetCurrentDatabase()
 
@@ -74,59 +74,83 @@ func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 		return cmdFailure, InvalidArgErr
 	}
 
+	if apr.Contains(cli.ShallowFlag) && apr.Contains(cli.NewGenFlag) {
+		return cmdFailure, fmt.Errorf("--%s and --%s are mutually exclusive", cli.ShallowFlag, cli.NewGenFlag)
+	}
+
 	dSess := dsess.DSessFromSess(ctx.Session)
 	ddb, ok := dSess.GetDoltDB(ctx, dbName)
 	if !ok {
 		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
 	}
 
+	done, err := dsess.StartBackgroundJob(ctx, dbName)
+	if err != nil {
+		return cmdFailure, err
+	}
+	defer done()
+
 	if apr.Contains(cli.ShallowFlag) {
 		err = ddb.ShallowGC(ctx)
 		if err != nil {
 			return cmdFailure, err
 		}
 	} else {
-		// TODO: If we got a callback at the beginning and an
-		// (allowed-to-block) callback at the end, we could more
-		// gracefully tear things down.
-		err = ddb.GC(ctx, func() error {
-			killed := make(map[uint32]struct{})
-			processes := ctx.ProcessList.Processes()
-			for _, p := range processes {
-				if p.Connection != ctx.Session.ID() {
-					// Kill any inflight query.
-					ctx.ProcessList.Kill(p.Connection)
-					// Tear down the connection itself.
-					ctx.KillConnection(p.Connection)
-					killed[p.Connection] = struct{}{}
-				}
-			}
+		safepoint := sessionAwareSafepointController{sqlCtx: ctx, ddb: ddb}
+		if apr.Contains(cli.NewGenFlag) {
+			err = ddb.NewGenGC(ctx, safepoint)
+		} else {
+			err = ddb.GC(ctx, safepoint)
+		}
+		if err != nil {
+			return cmdFailure, err
+		}
+	}
 
-			// Look in processes until the connections are actually gone.
-			params := backoff.NewExponentialBackOff()
-			params.InitialInterval = 1 * time.Millisecond
-			params.MaxInterval = 25 * time.Millisecond
-			params.MaxElapsedTime = 3 * time.Second
-			err := backoff.Retry(func() error {
-				processes := ctx.ProcessList.Processes()
-				for _, p := range processes {
-					if _, ok := killed[p.Connection]; ok {
-						return errors.New("unable to establish safepoint.")
-					}
-				}
-				return nil
-			}, params)
-			if err != nil {
-				return err
+	return cmdSuccess, nil
+}
+
+// sessionAwareSafepointController is the safepoint of an online GC run by dolt_gc(). Before the GC finalizes the
+// chunks it keeps, every session of the server writes the roots it holds in memory, so that the GC keeps them too.
+// New writes made during the GC are tracked by the store, so other connections keep running while the GC runs,
+// and only wait for it while it finalizes.
+type sessionAwareSafepointController struct {
+	sqlCtx *sql.Context
+	ddb    *doltdb.DoltDB
+}
+
+var _ types.GCSafepointController = sessionAwareSafepointController{}
+
+// BeginFinalize implements types.GCSafepointController.
+func (sc sessionAwareSafepointController) BeginFinalize(ctx context.Context) error {
+	current := dsess.DSessFromSess(sc.sqlCtx.Session)
+	sessions := []*dsess.DoltSession{current}
+
+	if runningServer, _ := sqlserver.GetRunningServer(); runningServer != nil {
+		err := runningServer.SessionManager().Iter(func(session sql.Session) (bool, error) {
+			dSess, ok := session.(*dsess.DoltSession)
+			if !ok {
+				return false, fmt.Errorf("unexpected session type: %T", session)
+			}
+			if dSess.ID() != current.ID() {
+				sessions = append(sessions, dSess)
 			}
-			ctx.Session.SetTransaction(nil)
-			dsess.DSessFromSess(ctx.Session).SetValidateErr(ErrServerPerformedGC)
-			return nil
+			return false, nil
 		})
 		if err != nil {
-			return cmdFailure, err
+			return err
 		}
 	}
 
-	return cmdSuccess, nil
+	for _, sess := range sessions {
+		if err := sess.WriteGCRoots(ctx, sc.ddb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EndFinalize implements types.GCSafepointController.
+func (sc sessionAwareSafepointController) EndFinalize(context.Context) error {
+	return nil
 }
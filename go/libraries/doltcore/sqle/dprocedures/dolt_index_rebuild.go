@@ -0,0 +1,79 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+)
+
+// doltIndexRebuild rebuilds a secondary index of a table from the table's rows, or every secondary index of the table
+// if no index is given.
+func doltIndexRebuild(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltIndexRebuild(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltIndexRebuild(ctx *sql.Context, args []string) (int, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return 1, fmt.Errorf("dolt_index_rebuild requires a table, and optionally an index")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return 1, err
+	} else if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	root := roots.Working
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, args[0])
+	if err != nil {
+		return 1, err
+	}
+	if !ok {
+		return 1, sql.ErrTableNotFound.New(args[0])
+	}
+
+	tbl, err = creation.RebuildSecondaryIndexes(ctx, tbl, args[1:], dbState.EditOpts())
+	if err != nil {
+		return 1, err
+	}
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return 1, err
+	}
+	if err = dSess.SetRoot(ctx, dbName, root); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
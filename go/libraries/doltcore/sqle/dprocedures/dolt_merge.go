@@ -17,6 +17,7 @@ package dprocedures
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	goerrors "gopkg.in/src-d/go-errors.v1"
@@ -46,6 +47,8 @@ const (
 
 var ErrUncommittedChanges = goerrors.NewKind("cannot merge with uncommitted changes")
 
+var ErrUnresolvedMerge = goerrors.NewKind("cannot continue the merge: tables %s have unresolved conflicts, constraint violations or schema conflicts")
+
 // doltMerge is the stored procedure version for the CLI command `dolt merge`.
 func doltMerge(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	hasConflicts, ff, err := doDoltMerge(ctx, args)
@@ -109,6 +112,14 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 		return noConflictsOrViolations, threeWayMerge, nil
 	}
 
+	if apr.Contains(cli.ContinueFlag) {
+		if !ws.MergeActive() {
+			return noConflictsOrViolations, threeWayMerge, fmt.Errorf("fatal: There is no merge to continue")
+		}
+		err = continueMerge(ctx, sess, dbName, ws, apr)
+		return noConflictsOrViolations, threeWayMerge, err
+	}
+
 	branchName := apr.Arg(0)
 
 	mergeSpec, err := createMergeSpec(ctx, sess, dbName, apr, branchName)
@@ -194,7 +205,7 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 		return ws, noConflictsOrViolations, threeWayMerge, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	ws, err = executeMerge(ctx, spec.Squash, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts())
+	ws, err = executeMerge(ctx, spec, ws, dbState.EditOpts())
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
@@ -242,8 +253,58 @@ func abortMerge(ctx *sql.Context, workingSet *doltdb.WorkingSet, roots doltdb.Ro
 	return workingSet, nil
 }
 
-func executeMerge(ctx *sql.Context, squash bool, head, cm *doltdb.Commit, cmSpec string, ws *doltdb.WorkingSet, opts editor.Options) (*doltdb.WorkingSet, error) {
-	result, err := merge.MergeCommits(ctx, head, cm, opts)
+// continueMerge stages the tables of the active merge of |ws| and commits it, once none of them are left unresolved.
+func continueMerge(ctx *sql.Context, sess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, apr *argparser.ArgParseResults) error {
+	unresolved, err := ws.UnresolvedTables(ctx)
+	if err != nil {
+		return err
+	}
+	if len(unresolved) > 0 {
+		return ErrUnresolvedMerge.New(strings.Join(unresolved, ", "))
+	}
+
+	head, err := sess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	ancestor, err := doltdb.GetCommitAncestor(ctx, head, ws.MergeState().Commit())
+	if err != nil {
+		return err
+	}
+	tables, err := merge.MergedTables(ctx, ws, ancestor)
+	if err != nil {
+		return err
+	}
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	roots, err = actions.StageTables(ctx, roots, tables, false)
+	if err != nil {
+		return err
+	}
+	if err = sess.SetRoots(ctx, dbName, roots); err != nil {
+		return err
+	}
+
+	headRef, err := ws.Ref().ToHeadRef()
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Merge branch '%s' into %s", ws.MergeState().CommitSpecStr(), headRef.GetPath())
+	if userMsg, ok := apr.GetValue(cli.MessageArg); ok {
+		msg = userMsg
+	}
+	args := []string{"-m", msg}
+	if author, ok := apr.GetValue(cli.AuthorParam); ok {
+		args = append(args, "--author", author)
+	}
+	_, err = doDoltCommit(ctx, args)
+	return err
+}
+
+func executeMerge(ctx *sql.Context, spec *merge.MergeSpec, ws *doltdb.WorkingSet, opts editor.Options) (*doltdb.WorkingSet, error) {
+	result, err := merge.MergeCommits(ctx, spec.HeadC, spec.MergeC, opts, spec.CascadeForeignKeys)
 	if err != nil {
 		switch err {
 		case doltdb.ErrUpToDate:
@@ -254,7 +315,7 @@ func executeMerge(ctx *sql.Context, squash bool, head, cm *doltdb.Commit, cmSpec
 			return nil, err
 		}
 	}
-	return mergeRootToWorking(squash, ws, result, cm, cmSpec)
+	return mergeRootToWorking(spec.Squash, ws, result, spec.MergeC, spec.MergeCSpecStr)
 }
 
 func executeFFMerge(ctx *sql.Context, dbName string, squash bool, ws *doltdb.WorkingSet, dbData env.DbData, cm2 *doltdb.Commit) (*doltdb.WorkingSet, error) {
@@ -391,6 +452,7 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 	if err != nil {
 		return nil, err
 	}
+	mergeSpec.CascadeForeignKeys = apr.Contains(cli.FKCascadeFlag)
 
 	return mergeSpec, nil
 }
@@ -0,0 +1,111 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var ErrNotReadReplica = errors.New("this server is not a read replica: dolt_read_replica_remote is not set")
+
+// doltPromote is the stored procedure which promotes a read replica server to a primary, and returns the epoch it
+// replicates at.
+func doltPromote(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	epoch, err := doDoltPromote(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(epoch)), nil
+}
+
+// doDoltPromote pulls every read replica database from its remote one last time, then fences the remotes at an epoch
+// higher than any of them was fenced at, and replicates the commits of the databases to them at that epoch. The
+// replica's previous primary, which replicates at a lower epoch, can no longer push to the remotes. The new settings
+// are persisted, so that the server is still a primary when it restarts. Only remotes stored in a directory or a
+// blobstore, like file://, gs:// and oci:// remotes, can be fenced: promoting a replica of a remote served over
+// remotesapi, like DoltHub or another sql-server, or of an aws:// remote fails with nbs.ErrNoEpoch.
+func doDoltPromote(ctx *sql.Context, args []string) (uint64, error) {
+	if len(args) != 0 {
+		return 0, InvalidArgErr
+	}
+	_, val, _ := sql.SystemVariables.GetGlobal(dsess.ReadReplicaRemote)
+	remote, _ := val.(string)
+	if remote == "" {
+		return 0, ErrNotReadReplica
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	var replicas []dsess.RemoteReadReplicaDatabase
+	for _, db := range dSess.Provider().DoltDatabases() {
+		if rrd, ok := db.(dsess.RemoteReadReplicaDatabase); ok && rrd.ValidReplicaState(ctx) {
+			replicas = append(replicas, rrd)
+		}
+	}
+
+	epoch := dsess.GetReplicationEpoch()
+	for _, rrd := range replicas {
+		if err := rrd.PullFromRemote(ctx); err != nil {
+			return 0, fmt.Errorf("replication error: %w", err)
+		}
+		e, err := rrd.ReplicationEpoch(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("cannot promote a replica of remote '%s': %w", remote, err)
+		}
+		if e > epoch {
+			epoch = e
+		}
+	}
+	epoch++
+	for _, rrd := range replicas {
+		if err := rrd.Promote(ctx, epoch); err != nil {
+			return 0, err
+		}
+	}
+
+	_, val, _ = sql.SystemVariables.GetGlobal(dsess.ReplicateToRemote)
+	replicateTo, _ := val.(string)
+	if replicateTo == "" {
+		replicateTo = remote
+	} else {
+		replicateTo = strings.Join([]string{replicateTo, remote}, ",")
+	}
+
+	settings := []struct {
+		name  string
+		value interface{}
+	}{
+		{dsess.ReadReplicaRemote, ""},
+		{dsess.ReplicateToRemote, replicateTo},
+		{dsess.ReplicationEpoch, int64(epoch)},
+	}
+	persistable, canPersist := ctx.Session.(sql.PersistableSession)
+	for _, s := range settings {
+		if err := sql.SystemVariables.SetGlobal(s.name, s.value); err != nil {
+			return 0, err
+		}
+		if canPersist {
+			if err := persistable.PersistGlobal(s.name, s.value); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return epoch, nil
+}
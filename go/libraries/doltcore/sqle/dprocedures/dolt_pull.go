@@ -29,6 +29,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
@@ -80,6 +82,33 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 		return noConflictsOrViolations, threeWayMerge, err
 	}
 
+	var pullOpts []pull.Option
+	if apr.Contains(cli.VerifyFlag) {
+		pullOpts = append(pullOpts, pull.WithChunkVerification())
+	}
+	if apr.Contains(cli.FsckFlag) {
+		pullOpts = append(pullOpts, pull.WithReachabilityCheck())
+	}
+	if apr.Contains(cli.DeltaFlag) {
+		pullOpts = append(pullOpts, pull.WithDeltaTransfer())
+	}
+	if keyPath, ok := apr.GetValue(cli.TrustedKeyParam); ok {
+		key, err := actions.LoadTrustedKey(filesys.LocalFS, keyPath)
+		if err != nil {
+			return noConflictsOrViolations, threeWayMerge, err
+		}
+		pullOpts = append(pullOpts, pull.WithTrustedKey(key))
+	}
+
+	recordOpt, recordPull := actions.StartTransferRecord(dbData.Rsw, env.TransferPull, pullSpec.Remote.Name)
+	conflicts, fastForward, err := pullFromRemote(ctx, sess, dbName, dbData, apr, pullSpec, append(pullOpts, recordOpt))
+	recordPull(err)
+	return conflicts, fastForward, err
+}
+
+// pullFromRemote fetches the branches of |pullSpec| from its remote with |pullOpts|, and merges its branch into the
+// current branch.
+func pullFromRemote(ctx *sql.Context, sess *dsess.DoltSession, dbName string, dbData env.DbData, apr *argparser.ArgParseResults, pullSpec *env.PullSpec, pullOpts []pull.Option) (int, int, error) {
 	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), pullSpec.Remote, false)
 	if err != nil {
 		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("failed to get remote db; %w", err)
@@ -108,6 +137,10 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 	var conflicts int
 	var fastForward int
 	for _, refSpec := range pullSpec.RefSpecs {
+		if _, ok := refSpec.(ref.NegativeRefSpec); ok {
+			continue
+		}
+
 		rsSeen := false // track invalid refSpecs
 		for _, branchRef := range branchRefs {
 			remoteTrackRef := refSpec.DestRef(branchRef)
@@ -117,18 +150,25 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 			}
 
 			rsSeen = true
+			if ref.IsExcluded(branchRef, pullSpec.RefSpecs) {
+				continue
+			}
 			tmpDir, err := dbData.Rsw.TempTableFilesDir()
 			if err != nil {
 				return noConflictsOrViolations, threeWayMerge, err
 			}
 			// todo: can we pass nil for either of the channels?
-			srcDBCommit, err := actions.FetchRemoteBranch(ctx, tmpDir, pullSpec.Remote, srcDB, dbData.Ddb, branchRef, runProgFuncs, stopProgFuncs)
+			srcDBCommit, err := actions.FetchRemoteBranch(ctx, tmpDir, pullSpec.Remote, srcDB, dbData.Ddb, branchRef, runProgFuncs, stopProgFuncs, pullOpts...)
 			if err != nil {
 				return noConflictsOrViolations, threeWayMerge, err
 			}
 
 			// TODO: this could be replaced with a canFF check to test for error
-			err = dbData.Ddb.FastForward(ctx, remoteTrackRef, srcDBCommit)
+			if refSpec.Forced() {
+				err = dbData.Ddb.SetHeadToCommit(ctx, remoteTrackRef, srcDBCommit)
+			} else {
+				err = dbData.Ddb.FastForward(ctx, remoteTrackRef, srcDBCommit)
+			}
 			if err != nil {
 				return noConflictsOrViolations, threeWayMerge, fmt.Errorf("fetch failed; %w", err)
 			}
@@ -168,7 +208,7 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 	if err != nil {
 		return noConflictsOrViolations, threeWayMerge, err
 	}
-	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, runProgFuncs, stopProgFuncs)
+	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, runProgFuncs, stopProgFuncs, pullOpts...)
 	if err != nil {
 		return conflicts, fastForward, err
 	}
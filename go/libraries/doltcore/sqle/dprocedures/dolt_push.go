@@ -15,6 +15,8 @@
 package dprocedures
 
 import (
+	"context"
+	"crypto/ed25519"
 	"fmt"
 	"strconv"
 
@@ -26,9 +28,13 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/datas"
 )
 
+// DoltPushWarningCode is the code of the warnings of dolt_push, the code for an unknown error
+const DoltPushWarningCode int = 1105
+
 // doltPush is the stored procedure version for the CLI command `dolt push`.
 func doltPush(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	res, err := doDoltPush(ctx, args)
@@ -66,30 +72,98 @@ func doDoltPush(ctx *sql.Context, args []string) (int, error) {
 		return cmdFailure, err
 	}
 
-	opts, err := env.NewPushOpts(ctx, apr, dbData.Rsr, dbData.Ddb, apr.Contains(cli.ForceFlag), apr.Contains(cli.SetUpstreamFlag), pushAutoSetUpRemote)
+	pushes, err := env.NewPushOpts(ctx, apr, dbData.Rsr, dbData.Ddb, apr.Contains(cli.ForceFlag), apr.Contains(cli.SetUpstreamFlag), pushAutoSetUpRemote)
 	if err != nil {
 		return cmdFailure, err
 	}
-	remoteDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), opts.Remote, true)
+	if expected, ok := apr.GetValue(cli.ForceWithLeaseFlag); ok {
+		if apr.Contains(cli.ForceFlag) {
+			return cmdFailure, fmt.Errorf("--%s and --%s cannot be used together", cli.ForceFlag, cli.ForceWithLeaseFlag)
+		}
+		if len(pushes) != 1 {
+			return cmdFailure, fmt.Errorf("--%s can only be used to push a single ref", cli.ForceWithLeaseFlag)
+		}
+		if err := pushes[0].SetLease(ctx, dbData.Ddb, expected); err != nil {
+			return cmdFailure, err
+		}
+	}
+	for _, opts := range pushes {
+		err = actions.CheckPushLicense(ctx, dbData.Ddb, opts, loadConfig(ctx).GetStringOrDefault(env.PushRequireLicenseKey, ""))
+		if err != nil {
+			return cmdFailure, err
+		}
+	}
+
+	var signKey ed25519.PrivateKey
+	if keyPath, ok := apr.GetValue(cli.SignKeyParam); ok {
+		signKey, err = actions.LoadSigningKey(filesys.LocalFS, keyPath)
+		if err != nil {
+			return cmdFailure, err
+		}
+	}
+
+	// the push is made to the remote's URL, and then copied to its mirrors
+	remote := pushes[0].Remote
+	remoteDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote.WithoutMirrors(), true)
 	if err != nil {
-		return 1, actions.HandleInitRemoteStorageClientErr(opts.Remote.Name, opts.Remote.Url, err)
+		return 1, actions.HandleInitRemoteStorageClientErr(remote.Name, remote.Url, err)
+	}
+
+	protection := dsess.BranchProtection(ctx)
+	for _, opts := range pushes {
+		err = actions.CheckPushProtection(ctx, dbData.Ddb, remoteDB, opts, protection)
+		if err != nil {
+			return cmdFailure, err
+		}
+	}
+
+	if !apr.Contains(cli.NoVerifyFlag) {
+		var updates []actions.HookRefUpdate
+		for _, opts := range pushes {
+			refUpdates, err := actions.PushHookUpdates(ctx, dbData.Ddb, remoteDB, opts)
+			if err != nil {
+				return cmdFailure, err
+			}
+			updates = append(updates, refUpdates...)
+		}
+		err = runHooks(ctx, dbName, actions.HookEvent{Type: actions.PrePushHook, Remote: remote, Updates: updates})
+		if err != nil {
+			return cmdFailure, err
+		}
 	}
 
 	tmpDir, err := dbData.Rsw.TempTableFilesDir()
 	if err != nil {
 		return cmdFailure, err
 	}
-	err = actions.DoPush(ctx, dbData.Rsr, dbData.Rsw, dbData.Ddb, remoteDB, tmpDir, opts, runProgFuncs, stopProgFuncs)
-	if err != nil {
+	pushed := false
+	for _, opts := range pushes {
+		err = actions.DoPush(ctx, dbData.Rsr, dbData.Rsw, dbData.Ddb, remoteDB, tmpDir, opts, runProgFuncs, stopProgFuncs)
 		switch err {
+		case nil:
+			pushed = true
 		case doltdb.ErrUpToDate:
-			return cmdSuccess, nil
 		case datas.ErrMergeNeeded:
 			return cmdFailure, fmt.Errorf("%w; the tip of your current branch is behind its remote counterpart", err)
 		default:
 			return cmdFailure, err
 		}
 	}
+	if pushed && signKey != nil {
+		if err = remoteDB.AttestManifest(ctx, signKey); err != nil {
+			return cmdFailure, err
+		}
+	}
+
+	mirrorDB := func(ctx context.Context, mirror env.Remote) (*doltdb.DoltDB, error) {
+		return sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), mirror, true)
+	}
+	for _, opts := range pushes {
+		for _, mirrorErr := range actions.PushToMirrors(ctx, dbData.Rsr, dbData.Rsw, dbData.Ddb, tmpDir, opts, mirrorDB) {
+			ctx.Warn(DoltPushWarningCode, mirrorErr.Error())
+		}
+	}
+
 	// TODO : set upstream should be persisted outside of session
 	return cmdSuccess, nil
 }
@@ -0,0 +1,90 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+)
+
+// doltRebuildDecimals switches the DECIMAL columns of the tables given, or of every table if none are, to the
+// fixed-point decimal encoding where they can use it, and rewrites their rows. It returns the number of tables that
+// were rewritten. See creation.UseFixedPointDecimals.
+func doltRebuildDecimals(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltRebuildDecimals(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltRebuildDecimals(ctx *sql.Context, tableNames []string) (int, error) {
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 0, err
+	}
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 0, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	root := roots.Working
+
+	if len(tableNames) == 0 {
+		var err error
+		tableNames, err = root.GetTableNames(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	rebuilt := 0
+	for _, name := range tableNames {
+		tbl, tblName, ok, err := root.GetTableInsensitive(ctx, name)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, sql.ErrTableNotFound.New(name)
+		}
+		if doltdb.HasDoltPrefix(tblName) {
+			continue
+		}
+
+		tbl, changed, err := creation.UseFixedPointDecimals(ctx, tbl)
+		if err != nil {
+			return 0, err
+		}
+		if !changed {
+			continue
+		}
+		root, err = root.PutTable(ctx, tblName, tbl)
+		if err != nil {
+			return 0, err
+		}
+		rebuilt++
+	}
+
+	if rebuilt > 0 {
+		if err := dSess.SetRoot(ctx, dbName, root); err != nil {
+			return 0, err
+		}
+	}
+	return rebuilt, nil
+}
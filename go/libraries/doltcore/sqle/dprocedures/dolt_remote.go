@@ -149,17 +149,19 @@ func remoteParams(apr *argparser.ArgParseResults, scheme, remoteUrl string) (map
 
 	var err error
 	switch scheme {
-	case dbfactory.AWSScheme:
+	case dbfactory.AWSScheme, dbfactory.S3Scheme:
 		err = cli.AddAWSParams(remoteUrl, apr, params)
 	case dbfactory.OSSScheme:
 		err = cli.AddOSSParams(remoteUrl, apr, params)
 	default:
 		err = cli.VerifyNoAwsParams(apr)
 	}
-
 	if err != nil {
 		return nil, errhand.VerboseErrorFromError(err)
 	}
+	if err = cli.AddCompressionParam(apr, params); err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
 
 	return params, nil
 }
@@ -0,0 +1,132 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// doltSnapshot is the stored procedure that stores the working and staged roots of the session in a named snapshot,
+// which DOLT_RESTORE resets the session to. Test suites use them to reset a database between tests.
+func doltSnapshot(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltSnapshot(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltSnapshot(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+
+	apr, err := cli.CreateSnapshotArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+	if apr.NArg() != 1 {
+		return 1, fmt.Errorf("error: DOLT_SNAPSHOT requires the name of the snapshot")
+	}
+	name := apr.Arg(0)
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	if apr.Contains(cli.DeleteFlag) {
+		if err = ddb.DeleteSnapshot(ctx, name); err != nil {
+			return 1, fmt.Errorf("error: could not delete snapshot '%s': %w", name, err)
+		}
+		return 0, nil
+	}
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+	meta := &datas.WorkingSetMeta{
+		Name:        dSess.Username(),
+		Email:       dSess.Email(),
+		Timestamp:   uint64(time.Now().Unix()),
+		Description: "snapshot " + name,
+	}
+	if err = ddb.PutSnapshot(ctx, name, roots, meta); err != nil {
+		return 1, fmt.Errorf("error: could not create snapshot '%s': %w", name, err)
+	}
+	return 0, nil
+}
+
+// doltRestore is the stored procedure that resets the working and staged roots of the session to the roots of a
+// snapshot made with DOLT_SNAPSHOT. Only the root pointers are swapped, so restoring is as fast for large databases
+// as for small ones. The HEAD of the session doesn't change, and a merge in progress is aborted.
+func doltRestore(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltRestore(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltRestore(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+	if len(args) != 1 {
+		return 1, fmt.Errorf("error: DOLT_RESTORE requires the name of a snapshot")
+	}
+	name := args[0]
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	working, staged, err := ddb.ResolveSnapshot(ctx, name)
+	if err != nil {
+		return 1, fmt.Errorf("error: could not restore snapshot '%s': %w", name, err)
+	}
+
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	ws = ws.WithWorkingRoot(working).WithStagedRoot(staged).ClearMerge()
+	if err = dSess.SetWorkingSet(ctx, dbName, ws); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
@@ -92,6 +92,13 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 		Description: msg,
 	}
 
+	if keyID, ok := apr.GetValue(cli.SignKeyParam); ok || apr.Contains(cli.SignFlag) {
+		props.Signer, err = actions.ConfiguredGPGSigner(loadConfig(ctx), keyID)
+	}
+	if err != nil {
+		return 1, err
+	}
+
 	tagName := apr.Arg(0)
 	startPoint := "head"
 	if len(apr.Args) > 1 {
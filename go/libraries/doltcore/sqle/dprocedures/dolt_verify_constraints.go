@@ -62,19 +62,6 @@ func doDoltConstraintsVerify(ctx *sql.Context, args []string) (int, error) {
 	verifyAll := apr.Contains(cli.AllFlag)
 	outputOnly := apr.Contains(cli.OutputOnlyFlag)
 
-	var comparingRoot *doltdb.RootValue
-	if verifyAll {
-		comparingRoot, err = doltdb.EmptyRootValue(ctx, workingRoot.VRW(), workingRoot.NodeStore())
-		if err != nil {
-			return 1, err
-		}
-	} else {
-		comparingRoot, err = headCommit.GetRootValue(ctx)
-		if err != nil {
-			return 1, err
-		}
-	}
-
 	tableSet := set.NewStrSet(nil)
 	for _, val := range apr.Args {
 		_, tableName, ok, err := workingRoot.GetTableInsensitive(ctx, val)
@@ -87,7 +74,18 @@ func doDoltConstraintsVerify(ctx *sql.Context, args []string) (int, error) {
 		tableSet.Add(tableName)
 	}
 
-	newRoot, tablesWithViolations, err := merge.AddForeignKeyViolations(ctx, workingRoot, comparingRoot, tableSet, h)
+	var newRoot *doltdb.RootValue
+	var tablesWithViolations *set.StrSet
+	if verifyAll {
+		newRoot, tablesWithViolations, err = merge.AddAllForeignKeyViolations(ctx, workingRoot, tableSet, h)
+	} else {
+		var headRoot *doltdb.RootValue
+		headRoot, err = headCommit.GetRootValue(ctx)
+		if err != nil {
+			return 1, err
+		}
+		newRoot, tablesWithViolations, err = merge.AddForeignKeyViolations(ctx, workingRoot, headRoot, tableSet, h)
+	}
 	if err != nil {
 		return 1, err
 	}
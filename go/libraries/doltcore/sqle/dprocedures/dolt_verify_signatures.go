@@ -0,0 +1,149 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// doltVerifySignatures verifies the signatures of commits and tags with gpg, against a list of trusted keys. It returns
+// a row for each commit or tag with the outcome, which is one of good, untrusted, bad, unknown key or unsigned, and
+// the fingerprint of the key that made the signature.
+func doltVerifySignatures(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	rows, err := doDoltVerifySignatures(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func doDoltVerifySignatures(ctx *sql.Context, args []string) ([]sql.Row, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	apr, err := cli.CreateVerifySignaturesArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	trustedKeys, _ := apr.GetValue(cli.TrustedKeyParam)
+	trusted := actions.ConfiguredGPGVerifier(loadConfig(ctx), trustedKeys)
+
+	revisions := apr.Args
+	if len(revisions) == 0 {
+		revisions = []string{"HEAD"}
+	}
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	ddb := dbData.Ddb
+	var rows []sql.Row
+	var heads []hash.Hash
+	for _, rev := range revisions {
+		if !apr.Contains(cli.AllFlag) {
+			tagRef := ref.NewTagRef(rev)
+			if isTag, err := ddb.HasRef(ctx, tagRef); err != nil {
+				return nil, err
+			} else if isTag {
+				t, err := ddb.ResolveTag(ctx, tagRef)
+				if err != nil {
+					return nil, err
+				}
+				v, err := actions.VerifyTagSignature(t, trusted)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, sql.Row{t.Name, string(v.Status), v.Key})
+				continue
+			}
+		}
+
+		cs, err := doltdb.NewCommitSpec(rev)
+		if err != nil {
+			return nil, err
+		}
+		cm, err := ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		if apr.Contains(cli.AllFlag) {
+			h, err := cm.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			heads = append(heads, h)
+			continue
+		}
+		row, err := verifyCommitRow(cm, trusted)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if len(heads) > 0 {
+		commits, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, heads, nil)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			_, cm, err := commits.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			row, err := verifyCommitRow(cm, trusted)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func verifyCommitRow(cm *doltdb.Commit, trusted *actions.GPGVerifier) (sql.Row, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	v, err := actions.VerifyCommitSignature(cm, trusted)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Row{h.String(), string(v.Status), v.Key}, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// DoltHookWarningCode is the code of the warnings holding the output of hooks, the code for an unknown error
+const DoltHookWarningCode int = 1105
+
+// runHooks runs the hooks of the type of |ev| for the database named |dbName|, adding their output to the session's
+// warnings. Databases without a directory, such as in-memory databases, only run registered hooks.
+func runHooks(ctx *sql.Context, dbName string, ev actions.HookEvent) error {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	baseName, _, _ := strings.Cut(dbName, dsess.DbRevisionDelimiter)
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	ev.Database = baseName
+	ev.Branch = headRef.GetPath()
+
+	var fs filesys.Filesys
+	if dbFs, err := dSess.Provider().FileSystemForDatabase(baseName); err == nil {
+		fs = dbFs
+	}
+	out, err := actions.RunHooks(ctx, fs, ev)
+	if err != nil {
+		return err
+	}
+	if out = strings.TrimSpace(out); out != "" {
+		ctx.Warn(DoltHookWarningCode, "%s", out)
+	}
+	return nil
+}
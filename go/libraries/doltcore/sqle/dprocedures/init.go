@@ -23,26 +23,37 @@ var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_add", Schema: int64Schema("status"), Function: doltAdd},
 	{Name: "dolt_backup", Schema: int64Schema("success"), Function: doltBackup},
 	{Name: "dolt_branch", Schema: int64Schema("status"), Function: doltBranch},
+	{Name: "dolt_branch_bulk", Schema: int64Schema("status"), Function: doltBranchBulk},
 	{Name: "dolt_checkout", Schema: int64Schema("status"), Function: doltCheckout},
 	{Name: "dolt_cherry_pick", Schema: stringSchema("hash"), Function: doltCherryPick},
 	{Name: "dolt_clean", Schema: int64Schema("status"), Function: doltClean},
+	{Name: "dolt_clear_projection_cache", Schema: int64Schema("projections"), Function: doltClearProjectionCache},
 	{Name: "dolt_clone", Schema: int64Schema("status"), Function: doltClone},
+	{Name: "dolt_column_dictionary", Schema: int64Schema("status"), Function: doltColumnDictionary},
 	{Name: "dolt_commit", Schema: stringSchema("hash"), Function: doltCommit},
 	{Name: "dolt_commit_hash_out", Schema: stringSchema("hash"), Function: doltCommitHashOut},
 	{Name: "dolt_conflicts_resolve", Schema: int64Schema("status"), Function: doltConflictsResolve},
+	{Name: "dolt_create_partial_index", Schema: int64Schema("status"), Function: doltCreatePartialIndex},
+	{Name: "dolt_create_virtual_foreign_key", Schema: int64Schema("status"), Function: doltCreateVirtualForeignKey},
 	{Name: "dolt_fetch", Schema: int64Schema("success"), Function: doltFetch},
 
 	// dolt_gc is enabled behind a feature flag for now, see dolt_gc.go
 	{Name: "dolt_gc", Schema: int64Schema("success"), Function: doltGC},
 
+	{Name: "dolt_index_rebuild", Schema: int64Schema("status"), Function: doltIndexRebuild},
 	{Name: "dolt_merge", Schema: int64Schema("fast_forward", "conflicts"), Function: doltMerge},
 	{Name: "dolt_pull", Schema: int64Schema("fast_forward", "conflicts"), Function: doltPull},
+	{Name: "dolt_promote", Schema: int64Schema("epoch"), Function: doltPromote},
 	{Name: "dolt_push", Schema: int64Schema("success"), Function: doltPush},
+	{Name: "dolt_rebuild_decimals", Schema: int64Schema("tables"), Function: doltRebuildDecimals},
 	{Name: "dolt_remote", Schema: int64Schema("status"), Function: doltRemote},
 	{Name: "dolt_reset", Schema: int64Schema("status"), Function: doltReset},
+	{Name: "dolt_restore", Schema: int64Schema("status"), Function: doltRestore},
 	{Name: "dolt_revert", Schema: int64Schema("status"), Function: doltRevert},
+	{Name: "dolt_snapshot", Schema: int64Schema("status"), Function: doltSnapshot},
 	{Name: "dolt_tag", Schema: int64Schema("status"), Function: doltTag},
 	{Name: "dolt_verify_constraints", Schema: int64Schema("violations"), Function: doltVerifyConstraints},
+	{Name: "dolt_verify_signatures", Schema: stringSchema("ref", "status", "key"), Function: doltVerifySignatures},
 
 	// Dolt stored procedure aliases
 	// TODO: Add new procedure aliases in doltProcedureAliasSet in go-mysql-server/sql/information_schema/routines.go file
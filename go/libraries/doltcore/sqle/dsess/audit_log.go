@@ -0,0 +1,68 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+)
+
+// AuditOperationDDL is the operation of the audit log entries of schema changes.
+const AuditOperationDDL = "DDL"
+
+// AuditStatement records the statement of |ctx|, which changed |rows| rows of |tableName| with |operation|, in the
+// audit log of |db| if its audit log is enabled. The statements of revision databases are recorded in the log of
+// their base database. Schema changes are recorded once per statement and table, however many times the statement
+// updates the schema.
+func (d *DoltSession) AuditStatement(ctx *sql.Context, db SqlDatabase, tableName, operation string, rows int64) error {
+	baseName, _ := SplitRevisionDbName(db)
+	if !AuditLogEnabled(baseName) {
+		return nil
+	}
+
+	if operation == AuditOperationDDL {
+		key := fmt.Sprintf("%d/%d/%s/%s/%s", ctx.Pid(), ctx.QueryTime().UnixNano(), db.Name(), tableName, ctx.Query())
+		d.mu.Lock()
+		audited := d.lastAuditedSchemaChange == key
+		d.lastAuditedSchemaChange = key
+		d.mu.Unlock()
+		if audited {
+			return nil
+		}
+	}
+
+	var branch string
+	if headRef, err := d.CWBHeadRef(ctx, db.Name()); err == nil && headRef != nil {
+		branch = headRef.GetPath()
+	}
+
+	fs, err := d.provider.FileSystemForDatabase(baseName)
+	if err != nil {
+		return err
+	}
+	return env.AppendAuditLog(fs, env.AuditLogEntry{
+		User:      ctx.Client().User,
+		Timestamp: time.Now().UTC(),
+		Branch:    branch,
+		Operation: operation,
+		Table:     tableName,
+		Query:     ctx.Query(),
+		Rows:      rows,
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// ValidateBranchNotActiveInAnySession returns an error if the specified branch is currently selected as the active
+// branch for any active server sessions.
+func ValidateBranchNotActiveInAnySession(ctx *sql.Context, branchName string) error {
+	currentDbName, err := baseDatabaseName(ctx, ctx.GetCurrentDatabase())
+	if err != nil {
+		return err
+	}
+
+	if currentDbName == "" {
+		return nil
+	}
+
+	if sqlserver.RunningInServerMode() == false {
+		return nil
+	}
+
+	runningServer, _ := sqlserver.GetRunningServer()
+	if runningServer == nil {
+		return nil
+	}
+	sessionManager := runningServer.SessionManager()
+	branchRef := ref.NewBranchRef(branchName)
+
+	return sessionManager.Iter(func(session sql.Session) (bool, error) {
+		dsess, ok := session.(*DoltSession)
+		if !ok {
+			return false, fmt.Errorf("unexpected session type: %T", session)
+		}
+
+		sessionDatabase := dsess.Session.GetCurrentDatabase()
+		sessionDbName, err := baseDatabaseName(ctx, dsess.GetCurrentDatabase())
+		if err != nil {
+			return false, err
+		}
+
+		if len(sessionDatabase) == 0 || sessionDbName != currentDbName {
+			return false, nil
+		}
+
+		activeBranchRef, err := dsess.CWBHeadRef(ctx, sessionDatabase)
+		if err != nil {
+			return false, err
+		}
+
+		if ref.Equals(branchRef, activeBranchRef) {
+			return false, fmt.Errorf("unsafe to delete or rename branches in use in other sessions; " +
+				"use --force to force the change")
+		}
+
+		return false, nil
+	})
+}
+
+// ValidateBranchNotDefault returns an error if the branch |branchName| of the database |dbName| is the default branch
+// of the running sql-server, which is the branch checked out on the command line. Deleting it would leave the server
+// without a branch to start new sessions on.
+func ValidateBranchNotDefault(ctx *sql.Context, dbName, branchName string) error {
+	if !sqlserver.RunningInServerMode() || shouldAllowDefaultBranchDeletion(ctx) {
+		return nil
+	}
+
+	baseName, err := baseDatabaseName(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	fs, err := DSessFromSess(ctx.Session).Provider().FileSystemForDatabase(baseName)
+	if err != nil {
+		return nil
+	}
+	repoState, err := env.LoadRepoState(fs)
+	if err != nil {
+		return nil
+	}
+	if repoState.Head.Ref.GetPath() == branchName {
+		return fmt.Errorf("unable to delete branch '%s', because it is the default branch for "+
+			"database '%s'; this can by changed on the command line, by stopping the sql-server, "+
+			"running `dolt checkout <another_branch> and restarting the sql-server", branchName, baseName)
+	}
+	return nil
+}
+
+// shouldAllowDefaultBranchDeletion returns true if the default branch deletion check should be
+// bypassed for testing. This should only ever be true for tests that need to invalidate a databases
+// default branch to test recovery from a bad state. We determine if the check should be bypassed by
+// looking for the presence of an undocumented dolt user var, dolt_allow_default_branch_deletion.
+func shouldAllowDefaultBranchDeletion(ctx *sql.Context) bool {
+	_, userVar, _ := ctx.Session.GetUserVariable(ctx, "dolt_allow_default_branch_deletion")
+	return userVar != nil
+}
+
+// BranchProtection returns the protection of the branches configured in the dolt config of the user running the
+// server, see env.GetBranchProtection.
+func BranchProtection(ctx *sql.Context) doltdb.BranchProtection {
+	// There's no DoltEnv in a SQL session, so the config is loaded like the CLI loads it.
+	dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, filesys.LocalFS, doltdb.LocalDirDoltDB, "")
+	return env.GetBranchProtection(dEnv.Config)
+}
+
+// baseDatabaseName returns the name of the database |dbName| without its revision, if it's a revision database.
+func baseDatabaseName(ctx *sql.Context, dbName string) (string, error) {
+	sess := DSessFromSess(ctx.Session)
+	db, ok, err := sess.Provider().SessionDatabase(ctx, dbName)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	rdb, ok := db.(RevisionDatabase)
+	if !ok {
+		return dbName, nil
+	}
+	return rdb.BaseName(), nil
+}
@@ -16,15 +16,20 @@ package dsess
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/types"
@@ -243,6 +248,49 @@ func TestGetPersistedValue(t *testing.T) {
 	}
 }
 
+// TestWriteGCRootsDuringSavepoints checks, when run with -race, that a GC collecting the roots of a session doesn't race
+// with the session creating and rolling back savepoints.
+func TestWriteGCRootsDuringSavepoints(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	root, err := doltdb.EmptyRootValue(ctx, ddb.ValueReadWriter(), ddb.NodeStore())
+	require.NoError(t, err)
+
+	sess := DefaultSession(emptyDatabaseProvider())
+	tx := NewDoltTransaction("db", nil, nil, ref.NewWorkingSetRef("heads/main"), env.DbData{Ddb: ddb}, editor.Options{}, sql.ReadWrite)
+	sess.SetTransaction(tx)
+
+	const n = 1000
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("sp%d", i%10)
+			tx.CreateSavepoint(name, root)
+			if _, _, err := ddb.WriteRootValue(ctx, root); !assert.NoError(t, err) {
+				return
+			}
+			if i%3 == 0 {
+				tx.RollbackToSavepoint(name)
+			} else if i%3 == 1 {
+				tx.ClearSavepoint(name)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n/10; i++ {
+			if !assert.NoError(t, sess.WriteGCRoots(ctx, ddb)) {
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
 func emptyDatabaseProvider() DoltDatabaseProvider {
 	return emptyRevisionDatabaseProvider{}
 }
@@ -0,0 +1,135 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
+)
+
+// QuotaProvider is implemented by database providers that enforce per-database resource quotas.
+type QuotaProvider interface {
+	// Quotas returns the quota controller of the provider, or nil if its databases have no quotas.
+	Quotas() *quota.Controller
+}
+
+// Quotas returns the quota controller of the session's provider, or nil if its databases have no quotas.
+func (d *DoltSession) Quotas() *quota.Controller {
+	if qp, ok := d.provider.(QuotaProvider); ok {
+		return qp.Quotas()
+	}
+	return nil
+}
+
+// CheckQuota checks |used|, the usage of |resource| by the database named |dbName| an operation would result in,
+// against the database's quota. An operation past the soft limit is reported as a warning.
+func CheckQuota(ctx *sql.Context, dbName, resource string, used uint64) error {
+	sess, ok := ctx.Session.(*DoltSession)
+	if !ok {
+		return nil
+	}
+	quotas := sess.Quotas()
+	if quotas == nil {
+		return nil
+	}
+
+	warning, err := quotas.Check(quotaDbName(dbName), resource, used)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		ctx.Warn(quota.WarningCode, warning)
+	}
+	return nil
+}
+
+// CheckBranchQuota checks that creating the branch |branchName| in the database named |dbName|, whose DoltDB is
+// |ddb|, doesn't take it past its branch quota. Overwriting an existing branch doesn't count as a new one.
+func CheckBranchQuota(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, branchName string) error {
+	sess, ok := ctx.Session.(*DoltSession)
+	if !ok || sess.Quotas() == nil {
+		return nil
+	}
+
+	if _, exists, err := ddb.HasBranch(ctx, branchName); err != nil {
+		return err
+	} else if exists {
+		return nil
+	}
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	return CheckQuota(ctx, dbName, quota.Branches, uint64(len(branches)+1))
+}
+
+// StartBackgroundJob checks the background job quota of the database named |dbName| before a background job, such as
+// garbage collection, runs for it. If the job may run, it returns a function to call when the job is done, which counts
+// the time it ran against the quota.
+func StartBackgroundJob(ctx *sql.Context, dbName string) (func(), error) {
+	sess, ok := ctx.Session.(*DoltSession)
+	if !ok || sess.Quotas() == nil {
+		return func() {}, nil
+	}
+
+	done, warning, err := sess.Quotas().StartBackgroundJob(quotaDbName(dbName))
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		ctx.Warn(quota.WarningCode, warning)
+	}
+	return done, nil
+}
+
+// ConnectionCount returns the number of connections other than the current one whose current database is the database
+// named |dbName|.
+func ConnectionCount(ctx *sql.Context, dbName string) uint64 {
+	if ctx.ProcessList == nil {
+		return 0
+	}
+	dbName = quotaDbName(dbName)
+	var count uint64
+	for _, p := range ctx.ProcessList.Processes() {
+		if p.Connection != ctx.Session.ID() && strings.EqualFold(quotaDbName(p.Database), dbName) {
+			count++
+		}
+	}
+	return count
+}
+
+// checkDiskQuota checks the disk usage of the database named |dbName| before a transaction writing to it is committed.
+// Disk usage is measured periodically, so a transaction can take a database past its hard limit, and it's the
+// transactions after it that fail.
+func (d *DoltSession) checkDiskQuota(ctx *sql.Context, dbName string) error {
+	quotas := d.Quotas()
+	if quotas == nil {
+		return nil
+	}
+	if dirty, err := d.isDirty(ctx, dbName); err != nil || !dirty {
+		return err
+	}
+	return CheckQuota(ctx, dbName, quota.DiskBytes, quotas.DiskUsage(quotaDbName(dbName)))
+}
+
+// quotaDbName returns the name of the database whose quotas apply to |dbName|, which may be a revision database.
+func quotaDbName(dbName string) string {
+	base, _, _ := strings.Cut(dbName, DbRevisionDelimiter)
+	return base
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
@@ -69,6 +70,10 @@ type DoltSession struct {
 	// If non-nil, this will be returned from ValidateSession.
 	// Used by sqle/cluster to put a session into a terminal err state.
 	validateErr error
+
+	// lastAuditedSchemaChange identifies the last schema change recorded in an audit log by the session, so that a
+	// statement which updates the schema of a table several times is recorded once.
+	lastAuditedSchemaChange string
 }
 
 var _ sql.Session = (*DoltSession)(nil)
@@ -216,6 +221,48 @@ func (d *DoltSession) SetValidateErr(err error) {
 	d.validateErr = err
 }
 
+// WriteGCRoots writes the root values this session holds in memory for the databases stored in |ddb|, so that a
+// garbage collection running while the session is in use keeps the chunks they reference. These are the working,
+// staged and head roots of each database, and the roots the current transaction started from and saved.
+func (d *DoltSession) WriteGCRoots(ctx context.Context, ddb *doltdb.DoltDB) error {
+	var roots []*doltdb.RootValue
+	addWorkingSet := func(ws *doltdb.WorkingSet) {
+		if ws == nil {
+			return
+		}
+		roots = append(roots, ws.WorkingRoot(), ws.StagedRoot())
+		if ms := ws.MergeState(); ms != nil {
+			roots = append(roots, ms.PreMergeWorkingRoot())
+		}
+	}
+
+	d.mu.Lock()
+	for _, dbState := range d.dbStates {
+		if dbState.Err != nil || dbState.dbData.Ddb != ddb {
+			continue
+		}
+		roots = append(roots, dbState.headRoot)
+		addWorkingSet(dbState.WorkingSet)
+	}
+	d.mu.Unlock()
+
+	if tx, ok := d.GetTransaction().(*DoltTransaction); ok && tx.dbData.Ddb == ddb {
+		startState, savepointRoots := tx.GCRoots()
+		addWorkingSet(startState)
+		roots = append(roots, savepointRoots...)
+	}
+
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+		if _, _, err := ddb.WriteRootValue(ctx, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ValidateSession validates a working set if there are a valid sessionState with non-nil working set.
 // If there is no sessionState or its current working set not defined, then no need for validation,
 // so no error is returned.
@@ -413,6 +460,10 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) er
 		return nil
 	}
 
+	if err := d.checkDiskQuota(ctx, dbName); err != nil {
+		return err
+	}
+
 	performDoltCommitVar, err := d.Session.GetSessionVariable(ctx, DoltCommitOnTransactionCommit)
 	if err != nil {
 		return err
@@ -545,14 +596,19 @@ func (d *DoltSession) doCommit(ctx *sql.Context, dbName string, tx sql.Transacti
 }
 
 // PendingCommitAllStaged returns a pending commit with all tables staged. Returns nil if there are no changes to stage.
+// Tables matched by dolt_ignore aren't staged, unless @@dolt_transaction_commit_include_ignored is set.
 func (d *DoltSession) PendingCommitAllStaged(ctx *sql.Context, dbName string, props actions.CommitStagedProps) (*doltdb.PendingCommit, error) {
 	roots, ok := d.GetRoots(ctx, dbName)
 	if !ok {
 		return nil, fmt.Errorf("Couldn't get info for database %s", dbName)
 	}
 
-	var err error
-	roots, err = actions.StageAllTables(ctx, roots, true)
+	includeIgnored, err := d.Session.GetSessionVariable(ctx, DoltCommitIncludeIgnored)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err = actions.StageAllTables(ctx, roots, includeIgnored != SysVarTrue)
 	if err != nil {
 		return nil, err
 	}
@@ -1127,6 +1183,12 @@ func (d *DoltSession) HasDB(_ *sql.Context, dbName string) bool {
 // addDB adds the database given to this session. This establishes a starting root value for this session, as well as
 // other state tracking metadata.
 func (d *DoltSession) addDB(ctx *sql.Context, db SqlDatabase) error {
+	if d.Quotas() != nil {
+		if err := CheckQuota(ctx, db.BaseName(), quota.Connections, ConnectionCount(ctx, db.BaseName())+1); err != nil {
+			return err
+		}
+	}
+
 	DefineSystemVariablesForDB(db.Name())
 
 	sessionState := NewEmptyDatabaseSessionState()
@@ -60,6 +60,10 @@ type RemoteReadReplicaDatabase interface {
 	ValidReplicaState(ctx *sql.Context) bool
 	// PullFromRemote performs a pull from the remote and returns any error encountered
 	PullFromRemote(ctx *sql.Context) error
+	// ReplicationEpoch returns the epoch the remote was fenced at, or 0 if it never was
+	ReplicationEpoch(ctx *sql.Context) (uint64, error)
+	// Promote fences the remote at |epoch| and replicates the commits of this database to it at that epoch
+	Promote(ctx *sql.Context, epoch uint64) error
 }
 
 type DoltDatabaseProvider interface {
@@ -81,6 +81,9 @@ type DoltTransaction struct {
 	savepoints      []savepoint
 	mergeEditOpts   editor.Options
 	tCharacteristic sql.TransactionCharacteristic
+
+	// mu guards |savepoints|, which are read by other sessions collecting GC roots, see GCRoots.
+	mu *sync.Mutex
 }
 
 type savepoint struct {
@@ -105,6 +108,7 @@ func NewDoltTransaction(
 		dbData:          dbData,
 		mergeEditOpts:   mergeEditOpts,
 		tCharacteristic: tCharacteristic,
+		mu:              &sync.Mutex{},
 	}
 }
 
@@ -487,6 +491,8 @@ func (tx *DoltTransaction) validateWorkingSetForCommit(ctx *sql.Context, working
 // CreateSavepoint creates a new savepoint with the name and root value given. If a savepoint with the name given
 // already exists, it's overwritten.
 func (tx *DoltTransaction) CreateSavepoint(name string, root *doltdb.RootValue) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
 		tx.savepoints = append(tx.savepoints[:existing], tx.savepoints[existing+1:]...)
@@ -507,6 +513,8 @@ func (tx *DoltTransaction) findSavepoint(name string) int {
 // RollbackToSavepoint returns the root value associated with the savepoint name given, or nil if no such savepoint can
 // be found. All savepoints created after the one being rolled back to are no longer accessible.
 func (tx *DoltTransaction) RollbackToSavepoint(name string) *doltdb.RootValue {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
 		// Clear out any savepoints past this one
@@ -519,6 +527,8 @@ func (tx *DoltTransaction) RollbackToSavepoint(name string) *doltdb.RootValue {
 // ClearSavepoint removes the savepoint with the name given and returns the root value recorded there, or nil if no
 // savepoint exists with that name.
 func (tx *DoltTransaction) ClearSavepoint(name string) *doltdb.RootValue {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
 	existing := tx.findSavepoint(name)
 	var existingRoot *doltdb.RootValue
 	if existing >= 0 {
@@ -528,6 +538,19 @@ func (tx *DoltTransaction) ClearSavepoint(name string) *doltdb.RootValue {
 	return existingRoot
 }
 
+// GCRoots returns the working set the transaction started with and the roots of its savepoints, which must be kept
+// by a garbage collection running during the transaction. It can be called from other goroutines than the one of the
+// transaction's session.
+func (tx *DoltTransaction) GCRoots() (*doltdb.WorkingSet, []*doltdb.RootValue) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	roots := make([]*doltdb.RootValue, len(tx.savepoints))
+	for i, sp := range tx.savepoints {
+		roots[i] = sp.root
+	}
+	return tx.startState, roots
+}
+
 func (tx DoltTransaction) getWorkingSetMeta(ctx *sql.Context) *datas.WorkingSetMeta {
 	sess := DSessFromSess(ctx.Session)
 	return &datas.WorkingSetMeta{
@@ -29,11 +29,13 @@ const (
 	WorkingKeySuffix       = "_working"
 	StagedKeySuffix        = "_staged"
 	DefaultBranchKeySuffix = "_default_branch"
+	AuditLogKeySuffix      = "_audit_log"
 )
 
 // General system variables
 const (
 	DoltCommitOnTransactionCommit = "dolt_transaction_commit"
+	DoltCommitIncludeIgnored      = "dolt_transaction_commit_include_ignored"
 	TransactionsDisabledSysVar    = "dolt_transactions_disabled"
 	ForceTransactionCommit        = "dolt_force_transaction_commit"
 	CurrentBatchModeKey           = "batch_mode"
@@ -46,10 +48,23 @@ const (
 	ReplicateHeads                = "dolt_replicate_heads"
 	ReplicateAllHeads             = "dolt_replicate_all_heads"
 	AsyncReplication              = "dolt_async_replication"
+	ReplicationEpoch              = "dolt_replication_epoch"
+	ReplicateAccessSampleRate     = "dolt_replicate_access_sample_rate"
+	ReadReplicaWarm               = "dolt_read_replica_warm"
 	AwsCredsFile                  = "aws_credentials_file"
 	AwsCredsProfile               = "aws_credentials_profile"
 	AwsCredsRegion                = "aws_credentials_region"
 	ShowBranchDatabases           = "dolt_show_branch_databases"
+	SnapshotDatabaseTags          = "dolt_snapshot_database_tags"
+	SnapshotDatabaseBranches      = "dolt_snapshot_database_branches"
+	ScanPrefetchDepth             = "dolt_scan_prefetch_depth"
+	SnapshotTime                  = "dolt_snapshot_time"
+	EventSchedulerBranches        = "dolt_event_scheduler_branches"
+	ElideNoopWrites               = "dolt_elide_noop_writes"
+	ProjectionCacheSize           = "dolt_projection_cache_size"
+	AlterColumnOnError            = "dolt_alter_column_on_error"
+	AlterColumnDatetimeFormat     = "dolt_alter_column_datetime_format"
+	AlterColumnThreads            = "dolt_alter_column_threads"
 )
 
 const URLTemplateDatabasePlaceholder = "{database}"
@@ -100,6 +115,14 @@ func DefineSystemVariablesForDB(name string) {
 				Type:              types.NewSystemStringType(DefaultBranchKey(name)),
 				Default:           "",
 			},
+			{
+				Name:              AuditLogKey(name),
+				Scope:             sql.SystemVariableScope_Global,
+				Dynamic:           true,
+				SetVarHintApplies: false,
+				Type:              types.NewSystemBoolType(AuditLogKey(name)),
+				Default:           int8(0),
+			},
 		})
 	}
 }
@@ -124,6 +147,17 @@ func DefaultBranchKey(dbName string) string {
 	return dbName + DefaultBranchKeySuffix
 }
 
+func AuditLogKey(dbName string) string {
+	return dbName + AuditLogKeySuffix
+}
+
+// AuditLogEnabled returns whether the statements which change the database named are recorded in its audit log, as
+// configured by the global ${db_name}_audit_log system variable.
+func AuditLogEnabled(dbName string) bool {
+	_, enabled, ok := sql.SystemVariables.GetGlobal(AuditLogKey(dbName))
+	return ok && enabled == SysVarTrue
+}
+
 func IsHeadKey(key string) (bool, string) {
 	if strings.HasSuffix(key, HeadKeySuffix) {
 		return true, key[:len(key)-len(HeadKeySuffix)]
@@ -180,6 +214,74 @@ func IgnoreReplicationErrors() bool {
 	return skip == SysVarTrue
 }
 
+// ReplicationTarget is a remote which the commits of a database are pushed to, as configured by the
+// dolt_replicate_to_remote system variable.
+type ReplicationTarget struct {
+	Remote string
+	Async  bool
+}
+
+// GetReplicationTargets returns the remotes of the dolt_replicate_to_remote system variable, a comma separated list of
+// remote names. A name followed by :sync or :async is replicated to in that mode, and the other names in the mode of
+// the dolt_async_replication system variable.
+func GetReplicationTargets() ([]ReplicationTarget, error) {
+	_, val, ok := sql.SystemVariables.GetGlobal(ReplicateToRemote)
+	if !ok {
+		return nil, sql.ErrUnknownSystemVariable.New(ReplicateToRemote)
+	}
+	remotes, ok := val.(string)
+	if !ok {
+		return nil, sql.ErrInvalidSystemVariableValue.New(val)
+	}
+	_, asyncVal, _ := sql.SystemVariables.GetGlobal(AsyncReplication)
+	async := asyncVal == SysVarTrue
+
+	var targets []ReplicationTarget
+	for _, remote := range strings.Split(remotes, ",") {
+		remote = strings.TrimSpace(remote)
+		if remote == "" {
+			continue
+		}
+		target := ReplicationTarget{Remote: remote, Async: async}
+		if name, mode, ok := strings.Cut(remote, ":"); ok {
+			target.Remote = strings.TrimSpace(name)
+			switch strings.ToLower(strings.TrimSpace(mode)) {
+			case "sync":
+				target.Async = false
+			case "async":
+				target.Async = true
+			default:
+				return nil, fmt.Errorf("invalid replication mode '%s' for remote '%s' in %s; expected sync or async", mode, target.Remote, ReplicateToRemote)
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// GetReplicationEpoch returns the epoch of the dolt_replication_epoch system variable, which the commits of databases
+// are replicated at. A server has a non-zero epoch once it's promoted from a read replica with dolt_promote().
+func GetReplicationEpoch() uint64 {
+	_, val, _ := sql.SystemVariables.GetGlobal(ReplicationEpoch)
+	epoch, _ := val.(int64)
+	if epoch < 0 {
+		return 0
+	}
+	return uint64(epoch)
+}
+
+// GetReplicateAccessSampleRate returns the rate of the dolt_replicate_access_sample_rate system variable: one in every
+// that many chunks read by a primary is published to its replication remotes, for read replicas to warm up with. Zero
+// disables the sampling.
+func GetReplicateAccessSampleRate() int {
+	_, val, _ := sql.SystemVariables.GetGlobal(ReplicateAccessSampleRate)
+	rate, _ := val.(int64)
+	if rate < 0 {
+		return 0
+	}
+	return int(rate)
+}
+
 // WarnReplicationError logs a warning for the replication error given
 func WarnReplicationError(ctx *sql.Context, err error) {
 	ctx.GetLogger().Warn(fmt.Errorf("replication failure: %w", err))
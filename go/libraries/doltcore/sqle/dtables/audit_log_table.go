@@ -0,0 +1,88 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// AuditLogTable is a sql.Table implementation that implements a system table which shows the statements which changed
+// the rows or the schema of the tables of the database while its audit log was enabled, oldest first.
+type AuditLogTable struct {
+	fs filesys.ReadableFS
+}
+
+var _ sql.Table = (*AuditLogTable)(nil)
+
+// NewAuditLogTable creates an AuditLogTable for the database whose files are in |fs|.
+func NewAuditLogTable(fs filesys.ReadableFS) sql.Table {
+	return &AuditLogTable{fs: fs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// AuditLogTableName
+func (at *AuditLogTable) Name() string {
+	return doltdb.AuditLogTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// AuditLogTableName
+func (at *AuditLogTable) String() string {
+	return doltdb.AuditLogTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the audit log system table.
+func (at *AuditLogTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "user", Type: types.Text, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+		{Name: "timestamp", Type: types.Datetime, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+		{Name: "branch", Type: types.Text, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+		{Name: "operation", Type: types.Text, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+		{Name: "table_name", Type: types.Text, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+		{Name: "query", Type: types.LongText, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+		{Name: "rows_changed", Type: types.Int64, Source: doltdb.AuditLogTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (at *AuditLogTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (at *AuditLogTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (at *AuditLogTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	entries, err := env.LoadAuditLog(at.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(entries))
+	for i, e := range entries {
+		rows[i] = sql.NewRow(e.User, e.Timestamp, e.Branch, e.Operation, e.Table, e.Query, e.Rows)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
+)
+
+var _ sql.RowReplacer = (*backedTableWriter)(nil)
+var _ sql.RowUpdater = (*backedTableWriter)(nil)
+var _ sql.RowInserter = (*backedTableWriter)(nil)
+var _ sql.RowDeleter = (*backedTableWriter)(nil)
+
+// backedTableWriter writes the rows of a system table stored in a table of the same name, creating the table with the
+// schema returned by |newSchema| when the first row is written, like ignoreWriter does for dolt_ignore.
+type backedTableWriter struct {
+	tableName               string
+	newSchema               func() (schema.Schema, error)
+	errDuringStatementBegin error
+	tableWriter             writer.TableWriter
+}
+
+func newBackedTableWriter(tableName string, newSchema func() (schema.Schema, error)) *backedTableWriter {
+	return &backedTableWriter{tableName: tableName, newSchema: newSchema}
+}
+
+// Insert inserts the row given, returning an error if it cannot.
+func (bw *backedTableWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	if err := bw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return bw.tableWriter.Insert(ctx, r)
+}
+
+// Update the given row. Provides both the old and new rows.
+func (bw *backedTableWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := bw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return bw.tableWriter.Update(ctx, old, new)
+}
+
+// Delete deletes the given row.
+func (bw *backedTableWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	if err := bw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return bw.tableWriter.Delete(ctx, r)
+}
+
+// StatementBegin is called before the first operation of a statement. The backing table is created if it doesn't
+// exist yet.
+func (bw *backedTableWriter) StatementBegin(ctx *sql.Context) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+
+	roots, _ := dSess.GetRoots(ctx, dbName)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		bw.errDuringStatementBegin = err
+		return
+	}
+	if !ok {
+		bw.errDuringStatementBegin = fmt.Errorf("no root value found in session")
+		return
+	}
+
+	found, err := roots.Working.HasTable(ctx, bw.tableName)
+	if err != nil {
+		bw.errDuringStatementBegin = err
+		return
+	}
+
+	if !found {
+		newSchema, err := bw.newSchema()
+		if err != nil {
+			bw.errDuringStatementBegin = err
+			return
+		}
+
+		newRootValue, err := roots.Working.CreateEmptyTable(ctx, bw.tableName, newSchema)
+		if err != nil {
+			bw.errDuringStatementBegin = err
+			return
+		}
+
+		err = dbState.WriteSession.SetWorkingSet(ctx, dbState.WorkingSet.WithWorkingRoot(newRootValue))
+		if err != nil {
+			bw.errDuringStatementBegin = err
+			return
+		}
+
+		dSess.SetRoot(ctx, dbName, newRootValue)
+	}
+
+	tableWriter, err := dbState.WriteSession.GetTableWriter(ctx, bw.tableName, dbName, dSess.SetRoot, false)
+	if err != nil {
+		bw.errDuringStatementBegin = err
+		return
+	}
+
+	bw.tableWriter = tableWriter
+	tableWriter.StatementBegin(ctx)
+}
+
+// DiscardChanges is called if a statement encounters an error, and all current changes since the statement beginning
+// should be discarded.
+func (bw *backedTableWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	if bw.tableWriter != nil {
+		return bw.tableWriter.DiscardChanges(ctx, errorEncountered)
+	}
+	return nil
+}
+
+// StatementComplete is called after the last operation of the statement, indicating that it has successfully completed.
+func (bw *backedTableWriter) StatementComplete(ctx *sql.Context) error {
+	return bw.tableWriter.StatementComplete(ctx)
+}
+
+// Close finalizes the write operation, persisting the result.
+func (bw *backedTableWriter) Close(ctx *sql.Context) error {
+	if bw.tableWriter != nil {
+		return bw.tableWriter.Close(ctx)
+	}
+	return nil
+}
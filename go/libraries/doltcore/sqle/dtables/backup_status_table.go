@@ -0,0 +1,114 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// BackupStatusTable is a sql.Table implementation that implements a system table which shows the outcome of the last
+// scheduled backup of the database to each backup remote. Remotes the database hasn't been backed up to yet are shown
+// with no backups.
+type BackupStatusTable struct {
+	dbName    string
+	scheduler *actions.BackupScheduler
+}
+
+var _ sql.Table = (*BackupStatusTable)(nil)
+
+// NewBackupStatusTable creates a BackupStatusTable for the database named |dbName|, which is backed up by |scheduler|.
+func NewBackupStatusTable(dbName string, scheduler *actions.BackupScheduler) sql.Table {
+	return &BackupStatusTable{dbName: dbName, scheduler: scheduler}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// BackupStatusTableName
+func (bt *BackupStatusTable) Name() string {
+	return doltdb.BackupStatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// BackupStatusTableName
+func (bt *BackupStatusTable) String() string {
+	return doltdb.BackupStatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the backup status system table.
+func (bt *BackupStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "remote", Type: types.Text, Source: doltdb.BackupStatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "cron", Type: types.Text, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "retention", Type: types.Int64, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "last_attempt", Type: types.Datetime, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_success", Type: types.Datetime, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_duration_ms", Type: types.Uint64, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_backup", Type: types.Text, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_error", Type: types.Text, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "successes", Type: types.Uint64, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "failures", Type: types.Uint64, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "next_run", Type: types.Datetime, Source: doltdb.BackupStatusTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (bt *BackupStatusTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (bt *BackupStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (bt *BackupStatusTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	statuses := make(map[string]actions.BackupStatus)
+	for _, s := range bt.scheduler.Status(bt.dbName) {
+		statuses[s.Remote] = s
+	}
+
+	schedules := bt.scheduler.Schedules()
+	rows := make([]sql.Row, 0, len(schedules))
+	for _, bs := range schedules {
+		var nextRun interface{}
+		if next := bt.scheduler.NextRun(bs.Remote); !next.IsZero() {
+			nextRun = next
+		}
+
+		s, ok := statuses[bs.Remote]
+		if !ok {
+			rows = append(rows, sql.NewRow(bs.Remote, bs.Cron, int64(bs.Retention), nil, nil, nil, nil, nil, uint64(0), uint64(0), nextRun))
+			continue
+		}
+
+		var lastSuccess, lastBackup, lastError interface{}
+		if !s.LastSuccess.IsZero() {
+			lastSuccess = s.LastSuccess
+			lastBackup = s.LastBackup
+		}
+		if s.LastError != "" {
+			lastError = s.LastError
+		}
+		rows = append(rows, sql.NewRow(bs.Remote, bs.Cron, int64(bs.Retention), s.LastAttempt, lastSuccess,
+			uint64(s.LastDuration.Milliseconds()), lastBackup, lastError, s.Successes, s.Failures, nextRun))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
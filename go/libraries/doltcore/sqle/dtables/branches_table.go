@@ -21,7 +21,10 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
@@ -217,23 +220,79 @@ type branchWriter struct {
 	bt *BranchesTable
 }
 
-// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
-// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
-// is called.
+// Insert creates the branch named by the row given at the commit in its hash column, which may also be any other
+// commit spec, such as the name of another branch. Insert will be called once for each row to process for the insert
+// operation, which may involve many rows. After all rows in an operation have been processed, Close is called.
 func (bWr branchWriter) Insert(ctx *sql.Context, r sql.Row) error {
-	return fmt.Errorf("the dolt_branches table is read-only; use the dolt_branch stored procedure to edit remotes")
+	if bWr.bt.remote {
+		return fmt.Errorf("the %s table is read-only", doltdb.RemoteBranchesTableName)
+	}
+	name, ok := r[0].(string)
+	if !ok || name == "" {
+		return fmt.Errorf("error: cannot branch empty string")
+	}
+	startPt, ok := r[1].(string)
+	if !ok || startPt == "" {
+		return fmt.Errorf("a commit to create branch '%s' at is required in the hash column", name)
+	}
+
+	if err := branch_control.CanCreateBranch(ctx, name); err != nil {
+		return err
+	}
+	dbName := bWr.bt.db.Name()
+	dbData, err := bWr.dbData(ctx)
+	if err != nil {
+		return err
+	}
+	if err = dsess.CheckBranchQuota(ctx, dbName, dbData.Ddb, name); err != nil {
+		return err
+	}
+	return actions.CreateBranchWithStartPt(ctx, dbData, name, startPt, false, dsess.BranchProtection(ctx))
 }
 
 // Update the given row. Provides both the old and new rows.
 func (bWr branchWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
-	return fmt.Errorf("the dolt_branches table is read-only; use the dolt_branch stored procedure to edit remotes")
+	if bWr.bt.remote {
+		return fmt.Errorf("the %s table is read-only", doltdb.RemoteBranchesTableName)
+	}
+	return fmt.Errorf("the %s table can't be updated; use the dolt_branch stored procedure to rename branches", doltdb.BranchesTableName)
 }
 
-// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
-// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
-// Close is called.
+// Delete deletes the branch named by the given row, like the dolt_branch stored procedure does with -d: the branch
+// can't be checked out in any session, and must be merged. Delete will be called once for each row to process for
+// the delete operation, which may involve many rows. After all rows have been processed, Close is called.
 func (bWr branchWriter) Delete(ctx *sql.Context, r sql.Row) error {
-	return fmt.Errorf("the dolt_branches table is read-only; use the dolt_branch stored procedure to edit remotes")
+	if bWr.bt.remote {
+		return fmt.Errorf("the %s table is read-only", doltdb.RemoteBranchesTableName)
+	}
+	name := r[0].(string)
+
+	if err := branch_control.CanDeleteBranch(ctx, name); err != nil {
+		return err
+	}
+	if err := dsess.ValidateBranchNotActiveInAnySession(ctx, name); err != nil {
+		return err
+	}
+	if err := dsess.ValidateBranchNotDefault(ctx, bWr.bt.db.Name(), name); err != nil {
+		return err
+	}
+	dbData, err := bWr.dbData(ctx)
+	if err != nil {
+		return err
+	}
+	return actions.DeleteBranch(ctx, dbData, name, actions.DeleteOptions{
+		Protection: dsess.BranchProtection(ctx),
+	}, dsess.DSessFromSess(ctx.Session).Provider())
+}
+
+// dbData returns the data of the table's database in the current session.
+func (bWr branchWriter) dbData(ctx *sql.Context) (env.DbData, error) {
+	dbName := bWr.bt.db.Name()
+	dbData, ok := dsess.DSessFromSess(ctx.Session).GetDbData(ctx, dbName)
+	if !ok {
+		return env.DbData{}, fmt.Errorf("Could not load database %s", dbName)
+	}
+	return dbData, nil
 }
 
 // StatementBegin implements the interface sql.TableEditor. Currently a no-op.
@@ -0,0 +1,274 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// ConflictResolutionsTable is a sql.Table implementation that implements a system table which shows the journal of
+// the conflicting rows resolved by deleting them from dolt_conflicts tables, oldest first, with the version of each
+// that was kept.
+type ConflictResolutionsTable struct {
+	fs filesys.ReadableFS
+}
+
+var _ sql.Table = (*ConflictResolutionsTable)(nil)
+
+// NewConflictResolutionsTable creates a ConflictResolutionsTable for the database whose files are in |fs|.
+func NewConflictResolutionsTable(fs filesys.ReadableFS) sql.Table {
+	return &ConflictResolutionsTable{fs: fs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ConflictResolutionsTableName
+func (rt *ConflictResolutionsTable) Name() string {
+	return doltdb.ConflictResolutionsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ConflictResolutionsTableName
+func (rt *ConflictResolutionsTable) String() string {
+	return doltdb.ConflictResolutionsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the conflict resolutions system table.
+func (rt *ConflictResolutionsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "branch", Type: types.Text, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "table_name", Type: types.Text, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "conflict_id", Type: types.Text, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "row_key", Type: types.LongText, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "winner", Type: types.Text, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "base", Type: types.LongText, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "ours", Type: types.LongText, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "theirs", Type: types.LongText, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "user", Type: types.Text, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "resolved_at", Type: types.Datetime, Source: doltdb.ConflictResolutionsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (rt *ConflictResolutionsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (rt *ConflictResolutionsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *ConflictResolutionsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	resolutions, err := env.LoadConflictResolutions(rt.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(resolutions))
+	for i, r := range resolutions {
+		rows[i] = sql.NewRow(r.Branch, r.Table, r.ConflictID, nullableJSON(r.Key), string(r.Winner),
+			nullableJSON(r.Base), nullableJSON(r.Ours), nullableJSON(r.Theirs), r.User, r.ResolvedAt)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// nullableJSON returns |doc| compacted, as the journal file is indented, or nil if it's empty.
+func nullableJSON(doc json.RawMessage) interface{} {
+	if len(doc) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, doc); err != nil {
+		return string(doc)
+	}
+	return buf.String()
+}
+
+// conflictVersionCols are the columns of a dolt_conflicts table that hold one version of a conflicting row.
+type conflictVersionCols struct {
+	names []string
+	idxs  []int
+}
+
+func newConflictVersionCols(confSch sql.Schema, prefix string, sch schema.Schema) conflictVersionCols {
+	var vc conflictVersionCols
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if idx := confSch.IndexOfColName(prefix + col.Name); idx >= 0 {
+			vc.names = append(vc.names, col.Name)
+			vc.idxs = append(vc.idxs, idx)
+		}
+		return false, nil
+	})
+	return vc
+}
+
+// json returns the version of the row |r| of a dolt_conflicts table as a JSON object.
+func (vc conflictVersionCols) json(r sql.Row) (json.RawMessage, error) {
+	obj := make(map[string]interface{}, len(vc.names))
+	for i, name := range vc.names {
+		obj[name] = r[vc.idxs[i]]
+	}
+	return json.Marshal(obj)
+}
+
+// conflictResolver turns the rows deleted from a dolt_conflicts table into the entries of the resolution journal.
+type conflictResolver struct {
+	dbName, tblName    string
+	base, ours, theirs conflictVersionCols
+	// pkCols are the names of the primary key columns of the table, or empty if it's keyless
+	pkCols                     map[string]struct{}
+	ourDiffType, theirDiffType int
+	conflictID                 int
+	resolutions                []env.ConflictResolution
+}
+
+func newConflictResolver(dbName string, ct ProllyConflictsTable) *conflictResolver {
+	confSch := ct.sqlSch.Schema
+	cr := &conflictResolver{
+		dbName:        dbName,
+		tblName:       ct.tblName,
+		base:          newConflictVersionCols(confSch, "base_", ct.baseSch),
+		ours:          newConflictVersionCols(confSch, "our_", ct.ourSch),
+		theirs:        newConflictVersionCols(confSch, "their_", ct.theirSch),
+		ourDiffType:   confSch.IndexOfColName("our_diff_type"),
+		theirDiffType: confSch.IndexOfColName("their_diff_type"),
+		conflictID:    confSch.IndexOfColName("dolt_conflict_id"),
+	}
+	cr.pkCols = make(map[string]struct{})
+	_ = ct.ourSch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		cr.pkCols[col.Name] = struct{}{}
+		return false, nil
+	})
+	return cr
+}
+
+// resolved records the resolution of the conflict of the row |r| deleted from the dolt_conflicts table. The kept
+// version is found by comparing the row in the working table, which is the table's our version, to the others.
+func (cr *conflictResolver) resolved(ctx *sql.Context, r sql.Row) error {
+	res := env.ConflictResolution{
+		Table:      cr.tblName,
+		ConflictID: r[cr.conflictID].(string),
+		Winner:     env.ConflictWinnerOurs,
+		User:       ctx.Client().User,
+		ResolvedAt: time.Now().UTC(),
+	}
+
+	var err error
+	ourDiff, theirDiff := r[cr.ourDiffType], r[cr.theirDiffType]
+	if ourDiff != merge.ConflictDiffTypeAdded {
+		if res.Base, err = cr.base.json(r); err != nil {
+			return err
+		}
+	}
+	if ourDiff != merge.ConflictDiffTypeRemoved {
+		if res.Ours, err = cr.ours.json(r); err != nil {
+			return err
+		}
+	}
+	if theirDiff != merge.ConflictDiffTypeRemoved {
+		if res.Theirs, err = cr.theirs.json(r); err != nil {
+			return err
+		}
+	}
+
+	if bytes.Equal(res.Ours, res.Theirs) {
+		res.Winner = env.ConflictWinnerTheirs
+	} else if res.Base != nil && bytes.Equal(res.Ours, res.Base) {
+		res.Winner = env.ConflictWinnerBase
+	}
+
+	if len(cr.pkCols) > 0 {
+		// the key is taken from any version of the row that has it
+		version := cr.base
+		if ourDiff != merge.ConflictDiffTypeRemoved {
+			version = cr.ours
+		} else if theirDiff != merge.ConflictDiffTypeRemoved {
+			version = cr.theirs
+		}
+		key := make(map[string]interface{}, len(cr.pkCols))
+		for i, name := range version.names {
+			if _, ok := cr.pkCols[name]; ok {
+				key[name] = r[version.idxs[i]]
+			}
+		}
+		if res.Key, err = json.Marshal(key); err != nil {
+			return err
+		}
+	}
+
+	cr.resolutions = append(cr.resolutions, res)
+	return nil
+}
+
+// flush adds the resolutions recorded to the resolution journal of the database, and runs its post-resolve hooks.
+// The output and failures of the hooks are added to the session's warnings. Databases without a directory, such as
+// in-memory databases, only run registered hooks.
+func (cr *conflictResolver) flush(ctx *sql.Context) error {
+	if len(cr.resolutions) == 0 {
+		return nil
+	}
+	resolutions := cr.resolutions
+	cr.resolutions = nil
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	baseName, _, _ := strings.Cut(cr.dbName, dsess.DbRevisionDelimiter)
+	var branch string
+	if headRef, err := dSess.CWBHeadRef(ctx, cr.dbName); err == nil && headRef != nil {
+		branch = headRef.GetPath()
+	}
+	for i := range resolutions {
+		resolutions[i].Database = baseName
+		resolutions[i].Branch = branch
+	}
+
+	var fs filesys.Filesys
+	if dbFs, err := dSess.Provider().FileSystemForDatabase(baseName); err == nil {
+		fs = dbFs
+		if err := env.AppendConflictResolutions(fs, resolutions); err != nil {
+			return err
+		}
+	}
+
+	out, err := actions.RunHooks(ctx, fs, actions.HookEvent{
+		Type:        actions.PostResolveHook,
+		Database:    baseName,
+		Branch:      branch,
+		Resolutions: resolutions,
+	})
+	if err != nil {
+		// the conflicts are already resolved, so a failing hook is only reported
+		ctx.Warn(1105, "%s", err.Error())
+	} else if out = strings.TrimSpace(out); out != "" {
+		ctx.Warn(1105, "%s", out)
+	}
+	return nil
+}
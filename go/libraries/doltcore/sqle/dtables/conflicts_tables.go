@@ -27,8 +27,8 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
-// NewConflictsTable returns a new ConflictsTable instance
-func NewConflictsTable(ctx *sql.Context, tblName string, srcTbl sql.Table, root *doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+// NewConflictsTable returns a new ConflictsTable instance for the table named |tblName| of the database named |dbName|
+func NewConflictsTable(ctx *sql.Context, dbName, tblName string, srcTbl sql.Table, root *doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
 	if err != nil {
 		return nil, err
@@ -41,7 +41,7 @@ func NewConflictsTable(ctx *sql.Context, tblName string, srcTbl sql.Table, root
 		if !ok {
 			return nil, fmt.Errorf("%s can not have conflicts because it is not updateable", tblName)
 		}
-		return newProllyConflictsTable(ctx, tbl, upd, tblName, root, rs)
+		return newProllyConflictsTable(ctx, dbName, tbl, upd, tblName, root, rs)
 	}
 
 	return newNomsConflictsTable(ctx, tbl, tblName, root, rs)
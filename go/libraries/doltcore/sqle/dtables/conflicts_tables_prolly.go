@@ -36,7 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/store/val"
 )
 
-func newProllyConflictsTable(ctx *sql.Context, tbl *doltdb.Table, sourceUpdatableTbl sql.UpdatableTable, tblName string, root *doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func newProllyConflictsTable(ctx *sql.Context, dbName string, tbl *doltdb.Table, sourceUpdatableTbl sql.UpdatableTable, tblName string, root *doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	arts, err := tbl.GetArtifacts(ctx)
 	if err != nil {
 		return nil, err
@@ -57,6 +57,7 @@ func newProllyConflictsTable(ctx *sql.Context, tbl *doltdb.Table, sourceUpdatabl
 	}
 
 	return ProllyConflictsTable{
+		dbName:          dbName,
 		tblName:         tblName,
 		sqlSch:          sqlSch,
 		baseSch:         baseSch,
@@ -74,6 +75,7 @@ func newProllyConflictsTable(ctx *sql.Context, tbl *doltdb.Table, sourceUpdatabl
 // ProllyConflictsTable is a sql.Table implementation that uses the merge
 // artifacts table to persist and read conflicts.
 type ProllyConflictsTable struct {
+	dbName                    string
 	tblName                   string
 	sqlSch                    sql.PrimaryKeySchema
 	baseSch, ourSch, theirSch schema.Schema
@@ -129,6 +131,7 @@ type prollyConflictRowIter struct {
 	ourRows prolly.Map
 	keyless bool
 	ourSch  schema.Schema
+	rowIds  merge.KeylessConflictRowIds
 
 	kd                       val.TupleDesc
 	baseVD, oursVD, theirsVD val.TupleDesc
@@ -172,7 +175,7 @@ func newProllyConflictRowIter(ctx *sql.Context, ct ProllyConflictsTable) (*proll
 	} else {
 		o = b + baseVD.Count() - 1
 		t = o + oursVD.Count()
-		n = t + theirsVD.Count() + 4
+		n = t + theirsVD.Count() + 5
 	}
 
 	return &prollyConflictRowIter{
@@ -288,7 +291,7 @@ func (itr *prollyConflictRowIter) putKeylessConflictRowVals(ctx *sql.Context, c
 
 	if c.bV != nil {
 		// Cardinality
-		r[itr.n-3], err = index.GetField(ctx, itr.baseVD, 0, c.bV, ns)
+		r[itr.n-4], err = index.GetField(ctx, itr.baseVD, 0, c.bV, ns)
 		if err != nil {
 			return err
 		}
@@ -301,11 +304,11 @@ func (itr *prollyConflictRowIter) putKeylessConflictRowVals(ctx *sql.Context, c
 			r[itr.b+i] = f
 		}
 	} else {
-		r[itr.n-3] = uint64(0)
+		r[itr.n-4] = uint64(0)
 	}
 
 	if c.oV != nil {
-		r[itr.n-2], err = index.GetField(ctx, itr.oursVD, 0, c.oV, ns)
+		r[itr.n-3], err = index.GetField(ctx, itr.oursVD, 0, c.oV, ns)
 		if err != nil {
 			return err
 		}
@@ -318,13 +321,13 @@ func (itr *prollyConflictRowIter) putKeylessConflictRowVals(ctx *sql.Context, c
 			r[itr.o+i] = f
 		}
 	} else {
-		r[itr.n-2] = uint64(0)
+		r[itr.n-3] = uint64(0)
 	}
 
 	r[itr.o+itr.oursVD.Count()-1] = getDiffType(c.bV, c.oV)
 
 	if c.tV != nil {
-		r[itr.n-1], err = index.GetField(ctx, itr.theirsVD, 0, c.tV, ns)
+		r[itr.n-2], err = index.GetField(ctx, itr.theirsVD, 0, c.tV, ns)
 		if err != nil {
 			return err
 		}
@@ -337,16 +340,24 @@ func (itr *prollyConflictRowIter) putKeylessConflictRowVals(ctx *sql.Context, c
 			r[itr.t+i] = f
 		}
 	} else {
-		r[itr.n-1] = uint64(0)
+		r[itr.n-2] = uint64(0)
 	}
 
 	o := itr.t + itr.theirsVD.Count() - 1
 	r[o] = getDiffType(c.bV, c.tV)
-	r[itr.n-4] = c.id
+	r[itr.n-5] = c.id
+	r[itr.n-1] = itr.rowIds.Next(c.k)
 
 	return nil
 }
 
+// ConflictID returns the dolt_conflict_id of the conflict |ca| in the conflicts table of its table.
+func ConflictID(ca prolly.ConflictArtifact) string {
+	// To ensure that the conflict id is unique, we hash both TheirRootIsh and the key of the table.
+	b := xxh3.Hash128(append(ca.Key[:len(ca.Key):len(ca.Key)], ca.TheirRootIsh[:]...)).Bytes()
+	return base64.RawStdEncoding.EncodeToString(b[:])
+}
+
 type conf struct {
 	k, bV, oV, tV val.Tuple
 	h             hash.Hash
@@ -360,10 +371,7 @@ func (itr *prollyConflictRowIter) nextConflictVals(ctx *sql.Context) (c conf, er
 	}
 	c.k = ca.Key
 	c.h = ca.TheirRootIsh
-
-	// To ensure that the conflict id is unique, we hash both TheirRootIsh and the key of the table.
-	b := xxh3.Hash128(append(ca.Key, c.h[:]...)).Bytes()
-	c.id = base64.RawStdEncoding.EncodeToString(b[:])
+	c.id = ConflictID(ca)
 
 	err = itr.loadTableMaps(ctx, ca.Metadata.BaseRootIsh, ca.TheirRootIsh)
 	if err != nil {
@@ -545,6 +553,7 @@ type prollyConflictDeleter struct {
 	ourDiffTypeIdx int
 	baseColSize    int
 	ourColSize     int
+	resolver       *conflictResolver
 }
 
 func newProllyConflictDeleter(ct ProllyConflictsTable) *prollyConflictDeleter {
@@ -572,6 +581,7 @@ func newProllyConflictDeleter(ct ProllyConflictsTable) *prollyConflictDeleter {
 		ourDiffTypeIdx: ourDiffTypeIdx,
 		baseColSize:    baseColSize,
 		ourColSize:     ourColSize,
+		resolver:       newConflictResolver(ct.dbName, ct),
 	}
 }
 
@@ -599,7 +609,7 @@ func (cd *prollyConflictDeleter) Delete(ctx *sql.Context, r sql.Row) (err error)
 		return err
 	}
 
-	return nil
+	return cd.resolver.resolved(ctx, r)
 }
 
 func (cd *prollyConflictDeleter) putPrimaryKeys(ctx *sql.Context, r sql.Row) error {
@@ -686,7 +696,13 @@ func (cd *prollyConflictDeleter) Close(ctx *sql.Context) error {
 		return err
 	}
 
-	return cd.ct.rs.SetRoot(ctx, updatedRoot)
+	err = cd.ct.rs.SetRoot(ctx, updatedRoot)
+	if err != nil {
+		return err
+	}
+
+	// the resolutions are journaled once they're made, even though the transaction that made them may not commit
+	return cd.resolver.flush(ctx)
 }
 
 type versionMappings struct {
@@ -698,7 +714,7 @@ func calculateConflictSchema(base, ours, theirs schema.Schema) (schema.Schema, *
 	keyless := schema.IsKeyless(ours)
 	n := 4 + ours.GetAllCols().Size() + theirs.GetAllCols().Size() + base.GetAllCols().Size()
 	if keyless {
-		n += 3
+		n += 4
 	}
 
 	cols := make([]schema.Column, n)
@@ -771,6 +787,8 @@ func calculateConflictSchema(base, ours, theirs schema.Schema) (schema.Schema, *
 		i++
 		cols[i] = schema.NewColumn("their_cardinality", uint64(i), types.UintKind, false)
 		i++
+		cols[i] = schema.NewColumn(merge.KeylessConflictRowIdColumn, uint64(i), types.StringKind, false)
+		i++
 	}
 
 	sch, err := schema.NewSchema(schema.NewColCollection(cols...), nil, schema.Collation_Default, nil, nil)
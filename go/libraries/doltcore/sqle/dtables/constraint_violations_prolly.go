@@ -206,6 +206,13 @@ func (itr prollyCVIter) Next(ctx *sql.Context) (sql.Row, error) {
 			return nil, err
 		}
 		r[o] = m
+	case prolly.ArtifactTypeTypeConvViol:
+		var m merge.TypeConvCVMeta
+		err = json.Unmarshal(meta.VInfo, &m)
+		if err != nil {
+			return nil, err
+		}
+		r[o] = m
 	default:
 		panic("json not implemented for artifact type")
 	}
@@ -295,6 +302,8 @@ func mapCVType(artifactType prolly.ArtifactType) (outType uint64) {
 		outType = uint64(merge.CvType_UniqueIndex)
 	case prolly.ArtifactTypeChkConsViol:
 		outType = uint64(merge.CvType_CheckConstraint)
+	case prolly.ArtifactTypeTypeConvViol:
+		outType = uint64(merge.CvType_TypeConversion)
 	default:
 		panic("unhandled cv type")
 	}
@@ -309,6 +318,8 @@ func unmapCVType(in merge.CvType) (out prolly.ArtifactType) {
 		out = prolly.ArtifactTypeUniqueKeyViol
 	case merge.CvType_CheckConstraint:
 		out = prolly.ArtifactTypeChkConsViol
+	case merge.CvType_TypeConversion:
+		out = prolly.ArtifactTypeTypeConvViol
 	default:
 		panic("unhandled cv type")
 	}
@@ -0,0 +1,246 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// GenerateDataDictionary renders the data dictionary of the user tables of |root| as a markdown document: the
+// columns of each table with their types and comments, its foreign keys, and the graph of the foreign keys between
+// the tables. The document only depends on the schemas of |root|, so it's unchanged by changes to table data.
+func GenerateDataDictionary(ctx context.Context, root *doltdb.RootValue) (string, error) {
+	names, err := root.GetTableNames(ctx)
+	if err != nil {
+		return "", err
+	}
+	tblNames := names[:0]
+	for _, name := range names {
+		if !doltdb.HasDoltPrefix(name) {
+			tblNames = append(tblNames, name)
+		}
+	}
+	sort.Strings(tblNames)
+
+	schemas := make(map[string]schema.Schema, len(tblNames))
+	for _, name := range tblNames {
+		tbl, _, err := root.GetTable(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		if schemas[name], err = tbl.GetSchema(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Data Dictionary\n")
+	if len(tblNames) == 0 {
+		sb.WriteString("\nThere are no tables.\n")
+		return sb.String(), nil
+	}
+
+	for _, name := range tblNames {
+		sch := schemas[name]
+		fmt.Fprintf(&sb, "\n## `%s`\n\n", name)
+		sb.WriteString("| Column | Type | Nullable | Key | Default | Comment |\n")
+		sb.WriteString("|--------|------|----------|-----|---------|---------|\n")
+		_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			nullable, key := "YES", ""
+			if !col.IsNullable() {
+				nullable = "NO"
+			}
+			if col.IsPartOfPK {
+				key = "PRI"
+			}
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %s | %s |\n", col.Name, markdownCell(col.TypeInfo.ToSqlType().String()),
+				nullable, key, markdownCell(col.Default), markdownCell(col.Comment))
+			return false, nil
+		})
+
+		declared, _ := fkc.KeysForTable(name)
+		if len(declared) == 0 {
+			continue
+		}
+		sort.Slice(declared, func(i, j int) bool {
+			return declared[i].Name < declared[j].Name
+		})
+		sb.WriteString("\n| Foreign Key | Columns | References | On Update | On Delete |\n")
+		sb.WriteString("|-------------|---------|------------|-----------|-----------|\n")
+		for _, fk := range declared {
+			cols, refCols := foreignKeyColumnNames(fk, sch, schemas[fk.ReferencedTableName])
+			fmt.Fprintf(&sb, "| `%s` | %s | `%s` (%s) | %s | %s |\n", fk.Name, cols, fk.ReferencedTableName, refCols,
+				fk.OnUpdate.String(), fk.OnDelete.String())
+		}
+	}
+
+	fks := fkc.AllKeys()
+	if len(fks) == 0 {
+		return sb.String(), nil
+	}
+	sort.Slice(fks, func(i, j int) bool {
+		if fks[i].TableName != fks[j].TableName {
+			return fks[i].TableName < fks[j].TableName
+		}
+		return fks[i].Name < fks[j].Name
+	})
+	sb.WriteString("\n## Foreign Key Graph\n\n```mermaid\ngraph LR\n")
+	for _, fk := range fks {
+		fmt.Fprintf(&sb, "  %s -->|%s| %s\n", fk.TableName, fk.Name, fk.ReferencedTableName)
+	}
+	sb.WriteString("```\n")
+
+	return sb.String(), nil
+}
+
+// foreignKeyColumnNames returns the column names of |fk| in its table, and in the table it references.
+func foreignKeyColumnNames(fk doltdb.ForeignKey, sch, refSch schema.Schema) (string, string) {
+	if !fk.IsResolved() || sch == nil || refSch == nil {
+		return quotedNames(fk.UnresolvedFKDetails.TableColumns), quotedNames(fk.UnresolvedFKDetails.ReferencedTableColumns)
+	}
+	return quotedNames(columnNames(sch, fk.TableColumns)), quotedNames(columnNames(refSch, fk.ReferencedTableColumns))
+}
+
+func columnNames(sch schema.Schema, tags []uint64) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		if col, ok := sch.GetAllCols().GetByTag(tag); ok {
+			names[i] = col.Name
+		}
+	}
+	return names
+}
+
+func quotedNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "`" + name + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// markdownCell escapes |s| to be the content of a cell of a markdown table.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "<br>"), "\n", "<br>")
+}
+
+// PutDoc sets the text of the doc |docName| in the dolt_docs table of |root|, creating the table if it doesn't exist.
+// Docs can only be written outside of SQL in the __DOLT__ storage format.
+func PutDoc(ctx context.Context, root *doltdb.RootValue, docName, text string) (*doltdb.RootValue, error) {
+	return putDoc(ctx, root, docName, text, nil)
+}
+
+// putDoc is PutDoc, which creates the dolt_docs table with |newSch| if it doesn't exist and |newSch| isn't nil, or
+// with the schema the table is created with in SQL otherwise.
+func putDoc(ctx context.Context, root *doltdb.RootValue, docName, text string, newSch schema.Schema) (*doltdb.RootValue, error) {
+	if !types.IsFormat_DOLT(root.VRW().Format()) {
+		return nil, fmt.Errorf("docs can only be generated in the %s storage format", types.Format_DOLT.VersionString())
+	}
+
+	tbl, ok, err := root.GetTable(ctx, doltdb.DocTableName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if newSch == nil {
+			newSch, err = sqlutil.ToDoltSchema(ctx, root, doltdb.DocTableName, DoltDocsSqlSchema, nil, sql.Collation_Default)
+			if err != nil {
+				return nil, err
+			}
+		}
+		tbl, err = doltdb.NewEmptyTable(ctx, root.VRW(), root.NodeStore(), newSch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.ProllyMapFromIndex(idx)
+	ns := tbl.NodeStore()
+
+	kb := val.NewTupleBuilder(sch.GetKeyDescriptor())
+	vb := val.NewTupleBuilder(sch.GetValueDescriptor())
+	if err = index.PutField(ctx, ns, kb, 0, docName); err != nil {
+		return nil, err
+	}
+	if err = index.PutField(ctx, ns, vb, 0, text); err != nil {
+		return nil, err
+	}
+
+	mut := m.Mutate()
+	if err = mut.Put(ctx, kb.Build(m.Pool()), vb.Build(m.Pool())); err != nil {
+		return nil, err
+	}
+	if m, err = mut.Map(ctx); err != nil {
+		return nil, err
+	}
+	if tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(m)); err != nil {
+		return nil, err
+	}
+
+	return root.PutTable(ctx, doltdb.DocTableName, tbl)
+}
+
+// RefreshDataDictionary regenerates the data dictionary doc from the schemas of the staged root of |roots|, and sets
+// it in both the staged and working roots, so that the next commit holds the dictionary of its own schemas.
+func RefreshDataDictionary(ctx context.Context, roots doltdb.Roots) (doltdb.Roots, error) {
+	text, err := GenerateDataDictionary(ctx, roots.Staged)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+	if roots.Working, err = PutDoc(ctx, roots.Working, doltdb.DataDictionaryDoc, text); err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	// a dolt_docs table that isn't staged yet is created with the schema of the working one, so they're the same table
+	tbl, _, err := roots.Working.GetTable(ctx, doltdb.DocTableName)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+	workingSch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+	if roots.Staged, err = putDoc(ctx, roots.Staged, doltdb.DataDictionaryDoc, text, workingSch); err != nil {
+		return doltdb.Roots{}, err
+	}
+	return roots, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+func TestDataDictionary(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	ctx := context.Background()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	root, err = sqle.ExecuteSql(dEnv, root, `
+CREATE TABLE parent (id int PRIMARY KEY COMMENT 'the id', name varchar(20) DEFAULT 'x' COMMENT 'a | b');
+CREATE TABLE child (id int PRIMARY KEY, pid int, CONSTRAINT fk_parent FOREIGN KEY (pid) REFERENCES parent (id) ON DELETE CASCADE);`)
+	require.NoError(t, err)
+
+	dictionary, err := dtables.GenerateDataDictionary(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, "# Data Dictionary\n"+
+		"\n## `child`\n\n"+
+		"| Column | Type | Nullable | Key | Default | Comment |\n"+
+		"|--------|------|----------|-----|---------|---------|\n"+
+		"| `id` | int | NO | PRI |  |  |\n"+
+		"| `pid` | int | YES |  |  |  |\n"+
+		"\n| Foreign Key | Columns | References | On Update | On Delete |\n"+
+		"|-------------|---------|------------|-----------|-----------|\n"+
+		"| `fk_parent` | `pid` | `parent` (`id`) | NONE SPECIFIED | CASCADE |\n"+
+		"\n## `parent`\n\n"+
+		"| Column | Type | Nullable | Key | Default | Comment |\n"+
+		"|--------|------|----------|-----|---------|---------|\n"+
+		"| `id` | int | NO | PRI |  | the id |\n"+
+		"| `name` | varchar(20) | YES |  | 'x' | a \\| b |\n"+
+		"\n## Foreign Key Graph\n\n```mermaid\ngraph LR\n"+
+		"  child -->|fk_parent| parent\n"+
+		"```\n", dictionary)
+
+	roots := doltdb.Roots{Head: root, Working: root, Staged: root}
+	roots, err = dtables.RefreshDataDictionary(ctx, roots)
+	require.NoError(t, err)
+	for _, r := range []*doltdb.RootValue{roots.Working, roots.Staged} {
+		require.NoError(t, dEnv.UpdateWorkingRoot(ctx, r))
+		rows, err := sqle.ExecuteSelect(dEnv, r, "SELECT doc_text FROM dolt_docs WHERE doc_name = 'DATA_DICTIONARY.md'")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, dictionary, rows[0][0])
+	}
+
+	// the dictionary doesn't describe the system tables
+	again, err := dtables.GenerateDataDictionary(ctx, roots.Staged)
+	require.NoError(t, err)
+	assert.Equal(t, dictionary, again)
+}
@@ -205,6 +205,9 @@ type prollyDiffIter struct {
 	fromConverter, toConverter ProllyRowConverter
 	fromVD, toVD               val.TupleDesc
 	keyless                    bool
+	// reencoded is true if |from| and |to| store some columns with different encodings, so rows with equal values
+	// may still differ in their bytes.
+	reencoded bool
 
 	fromCm commitInfo2
 	toCm   commitInfo2
@@ -301,6 +304,7 @@ func newProllyDiffIter(ctx *sql.Context, dp DiffPartition, targetFromSchema, tar
 		fromVD:        fromVD,
 		toVD:          toVD,
 		keyless:       keyless,
+		reencoded:     onlyEncodingsDiffer(fsch, tsch),
 		fromCm:        fromCm,
 		toCm:          toCm,
 		rows:          make(chan sql.Row, 64),
@@ -336,6 +340,9 @@ func (itr prollyDiffIter) Close(ctx *sql.Context) error {
 
 func (itr prollyDiffIter) queueRows(ctx context.Context) {
 	err := prolly.DiffMaps(ctx, itr.from, itr.to, func(ctx context.Context, d tree.Diff) error {
+		if d.Type == tree.ModifiedDiff && itr.reencoded && equalValues(itr.fromVD, itr.toVD, val.Tuple(d.From), val.Tuple(d.To)) {
+			return nil
+		}
 		dItr, err := itr.makeDiffRowItr(ctx, d)
 		if err != nil {
 			return err
@@ -367,6 +374,34 @@ func (itr prollyDiffIter) queueRows(ctx context.Context) {
 	close(itr.rows)
 }
 
+// onlyEncodingsDiffer returns whether the keyed schemas |from| and |to| have the same value columns, some of which are
+// stored with different encodings.
+func onlyEncodingsDiffer(from, to schema.Schema) bool {
+	if schema.IsKeyless(from) || schema.IsKeyless(to) {
+		return false
+	}
+	ft, tt := from.GetNonPKCols().Tags, to.GetNonPKCols().Tags
+	if len(ft) != len(tt) {
+		return false
+	}
+	for i := range ft {
+		if ft[i] != tt[i] {
+			return false
+		}
+	}
+	return val.EncodingsDiffer(from.GetValueDescriptor(), to.GetValueDescriptor())
+}
+
+// equalValues returns whether the value tuples |from| and |to| hold the same values.
+func equalValues(fromVD, toVD val.TupleDesc, from, to val.Tuple) bool {
+	for i := range toVD.Types {
+		if !val.EqualFields(fromVD, i, from, toVD, i, to) {
+			return false
+		}
+	}
+	return true
+}
+
 // todo(andy): copy string fields
 func (itr prollyDiffIter) makeDiffRowItr(ctx context.Context, d tree.Diff) (*repeatingRowIter, error) {
 	if !itr.keyless {
@@ -0,0 +1,93 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// FetchHistoryTable is a sql.Table implementation that implements a system table which shows the fetches, pulls and
+// pushes of the database, oldest first, with how long they took, how much they transferred, and how they failed.
+type FetchHistoryTable struct {
+	fs filesys.ReadableFS
+}
+
+var _ sql.Table = (*FetchHistoryTable)(nil)
+
+// NewFetchHistoryTable creates a FetchHistoryTable for the database whose files are in |fs|.
+func NewFetchHistoryTable(fs filesys.ReadableFS) sql.Table {
+	return &FetchHistoryTable{fs: fs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// FetchHistoryTableName
+func (ft *FetchHistoryTable) Name() string {
+	return doltdb.FetchHistoryTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// FetchHistoryTableName
+func (ft *FetchHistoryTable) String() string {
+	return doltdb.FetchHistoryTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the fetch history system table.
+func (ft *FetchHistoryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "operation", Type: types.Text, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "remote", Type: types.Text, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "start_time", Type: types.Datetime, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "duration_ms", Type: types.Uint64, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "bytes", Type: types.Uint64, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "chunks", Type: types.Uint64, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "status", Type: types.Text, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "error", Type: types.Text, Source: doltdb.FetchHistoryTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (ft *FetchHistoryTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (ft *FetchHistoryTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (ft *FetchHistoryTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	entries, err := env.LoadFetchHistory(ft.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(entries))
+	for i, e := range entries {
+		status, errStr := "success", interface{}(nil)
+		if e.Error != "" {
+			status, errStr = "failure", e.Error
+		}
+		rows[i] = sql.NewRow(string(e.Operation), e.Remote, e.StartTime, uint64(e.Duration.Milliseconds()), e.Bytes, e.Chunks, status, errStr)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
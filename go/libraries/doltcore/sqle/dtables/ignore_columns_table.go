@@ -0,0 +1,141 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*IgnoreColumnsTable)(nil)
+var _ sql.UpdatableTable = (*IgnoreColumnsTable)(nil)
+var _ sql.DeletableTable = (*IgnoreColumnsTable)(nil)
+var _ sql.InsertableTable = (*IgnoreColumnsTable)(nil)
+var _ sql.ReplaceableTable = (*IgnoreColumnsTable)(nil)
+
+// IgnoreColumnsTable is the system table that stores the columns whose changes are left out of diffs, status and
+// staging. The table name of each row is a pattern, as in dolt_ignore. The dolt_volatile_columns table, which lists
+// the columns merged by last writer wins, has the same schema and is also an IgnoreColumnsTable.
+type IgnoreColumnsTable struct {
+	backingTable sql.Table
+	tableName    string
+	tableTag     uint64
+	columnTag    uint64
+}
+
+// NewIgnoreColumnsTable creates an IgnoreColumnsTable for the dolt_ignore_columns table
+func NewIgnoreColumnsTable(_ *sql.Context, backingTable sql.Table) sql.Table {
+	return &IgnoreColumnsTable{
+		backingTable: backingTable,
+		tableName:    doltdb.IgnoreColumnsTableName,
+		tableTag:     schema.DoltIgnoreColumnsTableTag,
+		columnTag:    schema.DoltIgnoreColumnsColumnTag,
+	}
+}
+
+// NewVolatileColumnsTable creates an IgnoreColumnsTable for the dolt_volatile_columns table
+func NewVolatileColumnsTable(_ *sql.Context, backingTable sql.Table) sql.Table {
+	return &IgnoreColumnsTable{
+		backingTable: backingTable,
+		tableName:    doltdb.VolatileColumnsTableName,
+		tableTag:     schema.DoltVolatileColumnsTableTag,
+		columnTag:    schema.DoltVolatileColumnsColumnTag,
+	}
+}
+
+func (i *IgnoreColumnsTable) Name() string {
+	return i.tableName
+}
+
+func (i *IgnoreColumnsTable) String() string {
+	return i.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_ignore_columns and
+// dolt_volatile_columns system tables.
+func (i *IgnoreColumnsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: i.tableName, PrimaryKey: true},
+		{Name: "column_name", Type: sqlTypes.Text, Source: i.tableName, PrimaryKey: true},
+	}
+}
+
+func (i *IgnoreColumnsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (i *IgnoreColumnsTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return i.backingTable.Partitions(context)
+}
+
+func (i *IgnoreColumnsTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+	return i.backingTable.PartitionRows(context, partition)
+}
+
+// Replacer returns a RowReplacer for this table.
+func (i *IgnoreColumnsTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return newBackedTableWriter(i.tableName, i.backingSchema)
+}
+
+// Updater returns a RowUpdater for this table.
+func (i *IgnoreColumnsTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return newBackedTableWriter(i.tableName, i.backingSchema)
+}
+
+// Inserter returns an Inserter for this table.
+func (i *IgnoreColumnsTable) Inserter(*sql.Context) sql.RowInserter {
+	return newBackedTableWriter(i.tableName, i.backingSchema)
+}
+
+// Deleter returns a RowDeleter for this table.
+func (i *IgnoreColumnsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return newBackedTableWriter(i.tableName, i.backingSchema)
+}
+
+// backingSchema returns the schema of the table which stores the rows of |i|.
+func (i *IgnoreColumnsTable) backingSchema() (schema.Schema, error) {
+	colCollection := schema.NewColCollection(
+		schema.Column{
+			Name:       "table_name",
+			Tag:        i.tableTag,
+			Kind:       types.StringKind,
+			IsPartOfPK: true,
+			TypeInfo:   typeinfo.FromKind(types.StringKind),
+		},
+		schema.Column{
+			Name:       "column_name",
+			Tag:        i.columnTag,
+			Kind:       types.StringKind,
+			IsPartOfPK: true,
+			TypeInfo:   typeinfo.FromKind(types.StringKind),
+		},
+	)
+	return schema.NewSchema(colCollection, nil, schema.Collation_Default, nil, nil)
+}
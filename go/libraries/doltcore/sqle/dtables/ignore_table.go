@@ -16,6 +16,7 @@ package dtables
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
@@ -55,9 +56,16 @@ func (i *IgnoreTable) Schema() sql.Schema {
 	return []*sql.Column{
 		{Name: "pattern", Type: sqlTypes.Text, Source: doltdb.IgnoreTableName, PrimaryKey: true},
 		{Name: "ignored", Type: sqlTypes.Boolean, Source: doltdb.IgnoreTableName, PrimaryKey: false, Nullable: false},
+		{Name: "merge_policy", Type: sqlTypes.Text, Source: doltdb.IgnoreTableName, PrimaryKey: false, Nullable: true},
 	}
 }
 
+// hasLegacySchema returns whether the backing table was created before the merge_policy column was added. Rows of
+// such a table are read with a NULL merge policy, and it's migrated to the current schema the first time it's written.
+func (i *IgnoreTable) hasLegacySchema() bool {
+	return i.backingTable != nil && len(i.backingTable.Schema()) < len(i.Schema())
+}
+
 func (i *IgnoreTable) Collation() sql.CollationID {
 	return sql.Collation_Default
 }
@@ -77,7 +85,31 @@ func (i *IgnoreTable) PartitionRows(context *sql.Context, partition sql.Partitio
 		return sql.RowsToRowIter(), nil
 	}
 
-	return i.backingTable.PartitionRows(context, partition)
+	iter, err := i.backingTable.PartitionRows(context, partition)
+	if err != nil {
+		return nil, err
+	}
+	if i.hasLegacySchema() {
+		return &legacyIgnoreRowIter{iter: iter}, nil
+	}
+	return iter, nil
+}
+
+// legacyIgnoreRowIter reads the rows of a dolt_ignore table without a merge_policy column.
+type legacyIgnoreRowIter struct {
+	iter sql.RowIter
+}
+
+func (itr *legacyIgnoreRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.iter.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(r, nil), nil
+}
+
+func (itr *legacyIgnoreRowIter) Close(ctx *sql.Context) error {
+	return itr.iter.Close(ctx)
 }
 
 // NewIgnoreTable creates an IgnoreTable
@@ -133,6 +165,13 @@ func (iw *ignoreWriter) Insert(ctx *sql.Context, r sql.Row) error {
 	if err := iw.errDuringStatementBegin; err != nil {
 		return err
 	}
+	if err := validateIgnorePattern(r); err != nil {
+		return err
+	}
+	r, err := normalizeMergePolicy(r)
+	if err != nil {
+		return err
+	}
 	return iw.tableWriter.Insert(ctx, r)
 }
 
@@ -141,6 +180,13 @@ func (iw *ignoreWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error
 	if err := iw.errDuringStatementBegin; err != nil {
 		return err
 	}
+	if err := validateIgnorePattern(new); err != nil {
+		return err
+	}
+	new, err := normalizeMergePolicy(new)
+	if err != nil {
+		return err
+	}
 	return iw.tableWriter.Update(ctx, old, new)
 }
 
@@ -154,6 +200,34 @@ func (iw *ignoreWriter) Delete(ctx *sql.Context, r sql.Row) error {
 	return iw.tableWriter.Delete(ctx, r)
 }
 
+// validateIgnorePattern validates the pattern of a dolt_ignore row, which must compile if it's a regular expression.
+func validateIgnorePattern(r sql.Row) error {
+	pattern, ok := r[0].(string)
+	if !ok {
+		return nil
+	}
+	return doltdb.ValidateIgnorePattern(pattern)
+}
+
+// normalizeMergePolicy validates the merge policy of a dolt_ignore row, and stores it in lower case.
+func normalizeMergePolicy(r sql.Row) (sql.Row, error) {
+	name, ok := r[2].(string)
+	if !ok {
+		return r, nil
+	}
+	policy, err := doltdb.ParseIgnoreMergePolicy(name)
+	if err != nil {
+		return nil, err
+	}
+	r = r.Copy()
+	if policy == doltdb.MergePolicyDefault {
+		r[2] = nil
+	} else {
+		r[2] = string(policy)
+	}
+	return r, nil
+}
+
 // StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
 // in some way that it may be returned to in the case of an error.
 func (iw *ignoreWriter) StatementBegin(ctx *sql.Context) {
@@ -187,6 +261,22 @@ func (iw *ignoreWriter) StatementBegin(ctx *sql.Context) {
 		return
 	}
 
+	// A dolt_ignore table without a merge_policy column is recreated with the current schema, keeping its rows.
+	var legacyRows []sql.Row
+	if found && iw.it.hasLegacySchema() {
+		legacyRows, err = readLegacyIgnoreRows(ctx, iw.it)
+		if err != nil {
+			iw.errDuringStatementBegin = err
+			return
+		}
+		roots.Working, err = roots.Working.RemoveTables(ctx, false, false, doltdb.IgnoreTableName)
+		if err != nil {
+			iw.errDuringStatementBegin = err
+			return
+		}
+		found = false
+	}
+
 	if !found {
 		// TODO: This is effectively a duplicate of the schema declaration above in a different format.
 		// We should find a way to not repeat ourselves.
@@ -213,6 +303,17 @@ func (iw *ignoreWriter) StatementBegin(ctx *sql.Context) {
 				Comment:       "",
 				Constraints:   nil,
 			},
+			schema.Column{
+				Name:          "merge_policy",
+				Tag:           schema.DoltIgnoreMergePolicyTag,
+				Kind:          types.StringKind,
+				IsPartOfPK:    false,
+				TypeInfo:      typeinfo.FromKind(types.StringKind),
+				Default:       "",
+				AutoIncrement: false,
+				Comment:       "",
+				Constraints:   nil,
+			},
 		)
 
 		newSchema, err := schema.NewSchema(colCollection, nil, schema.Collation_Default, nil, nil)
@@ -249,10 +350,54 @@ func (iw *ignoreWriter) StatementBegin(ctx *sql.Context) {
 
 	iw.tableWriter = tableWriter
 
+	if len(legacyRows) > 0 {
+		// The migrated rows aren't part of the statement, so they're kept even if it fails.
+		tableWriter.StatementBegin(ctx)
+		for _, r := range legacyRows {
+			if err = tableWriter.Insert(ctx, append(r, nil)); err != nil {
+				iw.errDuringStatementBegin = err
+				return
+			}
+		}
+		if err = tableWriter.StatementComplete(ctx); err != nil {
+			iw.errDuringStatementBegin = err
+			return
+		}
+	}
+
 	tableWriter.StatementBegin(ctx)
 
 }
 
+// readLegacyIgnoreRows reads every row of the backing table of |it|, which has the legacy schema.
+func readLegacyIgnoreRows(ctx *sql.Context, it *IgnoreTable) ([]sql.Row, error) {
+	partIter, err := it.backingTable.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer partIter.Close(ctx)
+
+	var rows []sql.Row
+	for {
+		part, err := partIter.Next(ctx)
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		iter, err := it.backingTable.PartitionRows(ctx, part)
+		if err != nil {
+			return nil, err
+		}
+		partRows, err := sql.RowIterToRows(ctx, nil, iter)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, partRows...)
+	}
+}
+
 // DiscardChanges is called if a statement encounters an error, and all current changes since the statement beginning
 // should be discarded.
 func (iw *ignoreWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
@@ -0,0 +1,115 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/indexusage"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// IndexUsageTable is a sql.Table implementation that implements a system table which shows how often each index has
+// been read on each branch. Secondary indexes of the current branch that have never been read are shown with no reads.
+type IndexUsageTable struct {
+	tracker *indexusage.Tracker
+	branch  string
+	root    *doltdb.RootValue
+}
+
+var _ sql.Table = (*IndexUsageTable)(nil)
+
+// NewIndexUsageTable creates an IndexUsageTable for the usage in |tracker|, where |root| is the root of the current
+// branch, |branch|. |branch| is empty when the current database is pinned to a tag or commit.
+func NewIndexUsageTable(tracker *indexusage.Tracker, branch string, root *doltdb.RootValue) sql.Table {
+	return &IndexUsageTable{tracker: tracker, branch: branch, root: root}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// IndexUsageTableName
+func (t *IndexUsageTable) Name() string {
+	return doltdb.IndexUsageTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// IndexUsageTableName
+func (t *IndexUsageTable) String() string {
+	return doltdb.IndexUsageTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the index usage system table.
+func (t *IndexUsageTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "branch", Type: types.Text, Source: doltdb.IndexUsageTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_name", Type: types.Text, Source: doltdb.IndexUsageTableName, PrimaryKey: true, Nullable: false},
+		{Name: "index_name", Type: types.Text, Source: doltdb.IndexUsageTableName, PrimaryKey: true, Nullable: false},
+		{Name: "read_count", Type: types.Uint64, Source: doltdb.IndexUsageTableName, PrimaryKey: false, Nullable: false},
+		{Name: "last_used", Type: types.Datetime, Source: doltdb.IndexUsageTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (t *IndexUsageTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (t *IndexUsageTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (t *IndexUsageTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	var report []indexusage.Stat
+	if t.branch != "" {
+		var err error
+		report, err = t.tracker.Report(ctx, t.branch, t.root)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stats := t.tracker.Stats()
+	for _, s := range report {
+		if s.Reads == 0 {
+			stats = append(stats, s)
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Branch != stats[j].Branch {
+			return stats[i].Branch < stats[j].Branch
+		}
+		if !strings.EqualFold(stats[i].Table, stats[j].Table) {
+			return strings.ToLower(stats[i].Table) < strings.ToLower(stats[j].Table)
+		}
+		return strings.ToLower(stats[i].Index) < strings.ToLower(stats[j].Index)
+	})
+
+	rows := make([]sql.Row, len(stats))
+	for i, s := range stats {
+		var lastUsed interface{}
+		if s.Reads > 0 {
+			lastUsed = s.LastUsed
+		}
+		rows[i] = sql.NewRow(s.Branch, s.Table, s.Index, s.Reads, lastUsed)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+)
+
+const (
+	// mergeStrategyThreeWay is the strategy of a merge of a commit which isn't a descendant of HEAD, and
+	// mergeStrategyNoFF the strategy of a merge of a descendant of HEAD which doesn't fast-forward.
+	mergeStrategyThreeWay = "three-way"
+	mergeStrategyNoFF     = "no-ff"
+
+	mergeTableSchemaConflict     = "schema conflict"
+	mergeTableConflicts          = "conflicts"
+	mergeTableConstraintViolated = "constraint violations"
+	mergeTableMerged             = "merged"
+)
+
+// MergeStateTable is a sql.Table implementation that implements a system table which shows the state of an active
+// merge, with a row for each table the merge changed or left unresolved. Tables are resolved with
+// dolt_conflicts_resolve(), revalidated with dolt_verify_constraints(), staged with dolt_add(), and the merge is
+// committed with dolt_merge('--continue') once none are left unresolved.
+type MergeStateTable struct {
+	dbName string
+}
+
+var _ sql.Table = (*MergeStateTable)(nil)
+
+// NewMergeStateTable creates a MergeStateTable for the working set of the database named |dbName|.
+func NewMergeStateTable(dbName string) sql.Table {
+	return &MergeStateTable{dbName: dbName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// MergeStateTableName
+func (mt *MergeStateTable) Name() string {
+	return doltdb.MergeStateTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// MergeStateTableName
+func (mt *MergeStateTable) String() string {
+	return doltdb.MergeStateTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the merge state system table.
+func (mt *MergeStateTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: true, Nullable: false},
+		{Name: "status", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "conflicts", Type: types.Uint64, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "constraint_violations", Type: types.Uint64, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "staged", Type: types.Boolean, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "merge_head", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "source", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "ancestor", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "target", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+		{Name: "strategy", Type: types.Text, Source: doltdb.MergeStateTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (mt *MergeStateTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (mt *MergeStateTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (mt *MergeStateTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	ws, err := sess.WorkingSet(ctx, mt.dbName)
+	if err != nil {
+		return nil, err
+	}
+	if !ws.MergeActive() {
+		return sql.RowsToRowIter(), nil
+	}
+	head, err := sess.GetHeadCommit(ctx, mt.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	state := ws.MergeState()
+	mergeCm := state.Commit()
+	mergeHead, err := mergeCm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	target, err := ws.Ref().ToHeadRef()
+	if err != nil {
+		return nil, err
+	}
+
+	ancestorCm, err := doltdb.GetCommitAncestor(ctx, head, mergeCm)
+	if err != nil {
+		return nil, err
+	}
+	ancestorHash, err := ancestorCm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	strategy := mergeStrategyThreeWay
+	if headHash == ancestorHash {
+		strategy = mergeStrategyNoFF
+	}
+
+	tables, err := merge.MergedTables(ctx, ws, ancestorCm)
+	if err != nil {
+		return nil, err
+	}
+	schConflicts := set.NewStrSet(state.TablesWithSchemaConflicts())
+
+	working, staged := ws.WorkingRoot(), ws.StagedRoot()
+	rows := make([]sql.Row, 0, len(tables))
+	for _, name := range tables {
+		var conflicts, violations uint64
+		tbl, ok, err := working.GetTable(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if conflicts, err = tbl.NumRowsInConflict(ctx); err != nil {
+				return nil, err
+			}
+			if violations, err = tbl.NumConstraintViolations(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		status := mergeTableMerged
+		switch {
+		case schConflicts.Contains(name):
+			status = mergeTableSchemaConflict
+		case conflicts > 0:
+			status = mergeTableConflicts
+		case violations > 0:
+			status = mergeTableConstraintViolated
+		}
+
+		workingHash, inWorking, err := working.GetTableHash(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		stagedHash, inStaged, err := staged.GetTableHash(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		isStaged := inWorking == inStaged && workingHash == stagedHash
+
+		rows = append(rows, sql.NewRow(name, status, conflicts, violations, isStaged, mergeHead.String(),
+			state.CommitSpecStr(), ancestorHash.String(), target.String(), strategy))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
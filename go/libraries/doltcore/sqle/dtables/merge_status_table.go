@@ -25,7 +25,6 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
-	"github.com/dolthub/dolt/go/libraries/utils/set"
 )
 
 // MergeStatusTable is a sql.Table implementation that implements a system table
@@ -86,27 +85,11 @@ type MergeStatusIter struct {
 }
 
 func newMergeStatusItr(ctx context.Context, ws *doltdb.WorkingSet) (*MergeStatusIter, error) {
-	wr := ws.WorkingRoot()
-
-	inConflict, err := wr.TablesWithDataConflicts(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	tblsWithViolations, err := wr.TablesWithConstraintViolations(ctx)
+	unmergedTblNames, err := ws.UnresolvedTables(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var schConflicts []string
-	if ws.MergeActive() {
-		schConflicts = ws.MergeState().TablesWithSchemaConflicts()
-	}
-
-	unmergedTblNames := set.NewStrSet(inConflict)
-	unmergedTblNames.Add(tblsWithViolations...)
-	unmergedTblNames.Add(schConflicts...)
-
 	var sourceCommitSpecStr *string
 	var sourceCommitHash *string
 	var target *string
@@ -131,7 +114,7 @@ func newMergeStatusItr(ctx context.Context, ws *doltdb.WorkingSet) (*MergeStatus
 		s3 := curr.String()
 		target = &s3
 
-		s4 := strings.Join(unmergedTblNames.AsSlice(), ", ")
+		s4 := strings.Join(unmergedTblNames, ", ")
 		unmergedTables = &s4
 	}
 
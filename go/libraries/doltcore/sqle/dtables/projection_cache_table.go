@@ -0,0 +1,84 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/projcache"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// ProjectionCacheTable is a sql.Table implementation that implements a system table which shows the projections of
+// the tables of a database held by the projection cache.
+type ProjectionCacheTable struct {
+	dbName string
+	cache  *projcache.Cache
+}
+
+var _ sql.Table = (*ProjectionCacheTable)(nil)
+
+// NewProjectionCacheTable creates a ProjectionCacheTable for the projections of the tables of |dbName| in |cache|.
+func NewProjectionCacheTable(dbName string, cache *projcache.Cache) sql.Table {
+	return &ProjectionCacheTable{dbName: dbName, cache: cache}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ProjectionCacheTableName
+func (t *ProjectionCacheTable) Name() string {
+	return doltdb.ProjectionCacheTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ProjectionCacheTableName
+func (t *ProjectionCacheTable) String() string {
+	return doltdb.ProjectionCacheTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the projection cache system table.
+func (t *ProjectionCacheTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: doltdb.ProjectionCacheTableName, PrimaryKey: true, Nullable: false},
+		{Name: "columns", Type: types.Text, Source: doltdb.ProjectionCacheTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_hash", Type: types.Text, Source: doltdb.ProjectionCacheTableName, PrimaryKey: true, Nullable: false},
+		{Name: "row_count", Type: types.Uint64, Source: doltdb.ProjectionCacheTableName, PrimaryKey: false, Nullable: false},
+		{Name: "bytes", Type: types.Uint64, Source: doltdb.ProjectionCacheTableName, PrimaryKey: false, Nullable: false},
+		{Name: "hits", Type: types.Uint64, Source: doltdb.ProjectionCacheTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (t *ProjectionCacheTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (t *ProjectionCacheTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (t *ProjectionCacheTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	stats := t.cache.Stats(t.dbName)
+	rows := make([]sql.Row, len(stats))
+	for i, s := range stats {
+		rows[i] = sql.NewRow(s.Table, strings.Join(s.Columns, ","), s.TableHash.String(), s.Rows, s.Bytes, s.Hits)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
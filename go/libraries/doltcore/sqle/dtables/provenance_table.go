@@ -0,0 +1,117 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*ProvenanceTable)(nil)
+
+// ProvenanceTable is a sql.Table implementation of the dolt_provenance system table, which shows the provenance of each
+// user table: its row in dolt_table_provenance if it has one, and the provenance trailers of the most recent commit
+// which has them otherwise.
+type ProvenanceTable struct {
+	ddb  *doltdb.DoltDB
+	head *doltdb.Commit
+	root *doltdb.RootValue
+}
+
+// NewProvenanceTable creates a ProvenanceTable
+func NewProvenanceTable(_ *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, root *doltdb.RootValue) sql.Table {
+	return &ProvenanceTable{ddb: ddb, head: head, root: root}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ProvenanceTableName
+func (pt *ProvenanceTable) Name() string {
+	return doltdb.ProvenanceTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ProvenanceTableName
+func (pt *ProvenanceTable) String() string {
+	return doltdb.ProvenanceTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the provenance system table
+func (pt *ProvenanceTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: doltdb.ProvenanceTableName, PrimaryKey: true, Nullable: false},
+		{Name: "source_url", Type: types.Text, Source: doltdb.ProvenanceTableName, PrimaryKey: false, Nullable: true},
+		{Name: "license", Type: types.Text, Source: doltdb.ProvenanceTableName, PrimaryKey: false, Nullable: true},
+		{Name: "collected_at", Type: types.Text, Source: doltdb.ProvenanceTableName, PrimaryKey: false, Nullable: true},
+		{Name: "commit_hash", Type: types.Text, Source: doltdb.ProvenanceTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (pt *ProvenanceTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (pt *ProvenanceTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (pt *ProvenanceTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	provenance, err := actions.GetProvenance(ctx, pt.ddb, pt.head, pt.root)
+	if err != nil {
+		return nil, err
+	}
+	return &provenanceItr{provenance: provenance}, nil
+}
+
+// provenanceItr is a sql.RowIter implementation which iterates over the provenance of each user table.
+type provenanceItr struct {
+	provenance []actions.TableProvenance
+	idx        int
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+func (itr *provenanceItr) Next(*sql.Context) (sql.Row, error) {
+	if itr.idx >= len(itr.provenance) {
+		return nil, io.EOF
+	}
+	p := itr.provenance[itr.idx]
+	itr.idx++
+
+	var commitHash interface{}
+	if !p.Commit.IsEmpty() {
+		commitHash = p.Commit.String()
+	}
+	return sql.NewRow(p.TableName, nullIfEmpty(p.SourceURL), nullIfEmpty(p.License), nullIfEmpty(p.CollectedAt), commitHash), nil
+}
+
+// Close closes the iterator.
+func (itr *provenanceItr) Close(*sql.Context) error {
+	return nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
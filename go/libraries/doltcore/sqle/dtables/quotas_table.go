@@ -0,0 +1,104 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/quota"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// QuotasTable is a sql.Table implementation that implements a system table which shows the usage of each resource
+// with a quota by the database, compared to its soft and hard limits.
+type QuotasTable struct {
+	dbName string
+	ddb    *doltdb.DoltDB
+	quotas *quota.Controller
+}
+
+var _ sql.Table = (*QuotasTable)(nil)
+
+// NewQuotasTable creates a QuotasTable for the database named |dbName|, whose quotas are enforced by |quotas|.
+func NewQuotasTable(dbName string, ddb *doltdb.DoltDB, quotas *quota.Controller) sql.Table {
+	return &QuotasTable{dbName: dbName, ddb: ddb, quotas: quotas}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// QuotasTableName
+func (qt *QuotasTable) Name() string {
+	return doltdb.QuotasTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// QuotasTableName
+func (qt *QuotasTable) String() string {
+	return doltdb.QuotasTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the quotas system table.
+func (qt *QuotasTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "resource", Type: types.Text, Source: doltdb.QuotasTableName, PrimaryKey: true, Nullable: false},
+		{Name: "usage", Type: types.Uint64, Source: doltdb.QuotasTableName, PrimaryKey: false, Nullable: false},
+		{Name: "soft_limit", Type: types.Uint64, Source: doltdb.QuotasTableName, PrimaryKey: false, Nullable: true},
+		{Name: "hard_limit", Type: types.Uint64, Source: doltdb.QuotasTableName, PrimaryKey: false, Nullable: true},
+		{Name: "status", Type: types.Text, Source: doltdb.QuotasTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (qt *QuotasTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (qt *QuotasTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (qt *QuotasTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	branches, err := qt.ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	connections := dsess.ConnectionCount(ctx, qt.dbName)
+	current, _, _ := strings.Cut(ctx.GetCurrentDatabase(), dsess.DbRevisionDelimiter)
+	if strings.EqualFold(current, qt.dbName) {
+		connections++
+	}
+
+	statuses := qt.quotas.Status(qt.dbName, uint64(len(branches)), connections)
+	rows := make([]sql.Row, len(statuses))
+	for i, s := range statuses {
+		rows[i] = sql.NewRow(s.Resource, s.Usage, limitOrNil(s.Limit.Soft), limitOrNil(s.Limit.Hard), s.State())
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func limitOrNil(limit uint64) interface{} {
+	if limit == 0 {
+		return nil
+	}
+	return limit
+}
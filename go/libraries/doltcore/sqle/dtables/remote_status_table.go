@@ -0,0 +1,117 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// RemoteStatusTable is a sql.Table implementation that implements a system table which shows the outcome of the last
+// background fetch of each remote of the database. Remotes that haven't been fetched yet are shown with no fetches.
+type RemoteStatusTable struct {
+	dbName    string
+	rsr       env.RepoStateReader
+	scheduler *actions.FetchScheduler
+}
+
+var _ sql.Table = (*RemoteStatusTable)(nil)
+
+// NewRemoteStatusTable creates a RemoteStatusTable for the database named |dbName|, whose remotes are read from |rsr|
+// and fetched by |scheduler|.
+func NewRemoteStatusTable(dbName string, rsr env.RepoStateReader, scheduler *actions.FetchScheduler) sql.Table {
+	return &RemoteStatusTable{dbName: dbName, rsr: rsr, scheduler: scheduler}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemoteStatusTableName
+func (rt *RemoteStatusTable) Name() string {
+	return doltdb.RemoteStatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemoteStatusTableName
+func (rt *RemoteStatusTable) String() string {
+	return doltdb.RemoteStatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the remote status system table.
+func (rt *RemoteStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "remote", Type: types.Text, Source: doltdb.RemoteStatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "last_attempt", Type: types.Datetime, Source: doltdb.RemoteStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_success", Type: types.Datetime, Source: doltdb.RemoteStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_duration_ms", Type: types.Uint64, Source: doltdb.RemoteStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_error", Type: types.Text, Source: doltdb.RemoteStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "successes", Type: types.Uint64, Source: doltdb.RemoteStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "failures", Type: types.Uint64, Source: doltdb.RemoteStatusTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (rt *RemoteStatusTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (rt *RemoteStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *RemoteStatusTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	remotes, err := rt.rsr.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]actions.RemoteFetchStatus)
+	for _, s := range rt.scheduler.Status(rt.dbName) {
+		statuses[s.Remote] = s
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]sql.Row, 0, len(names))
+	for _, name := range names {
+		s, ok := statuses[name]
+		if !ok {
+			rows = append(rows, sql.NewRow(name, nil, nil, nil, nil, uint64(0), uint64(0)))
+			continue
+		}
+
+		var lastSuccess, lastError interface{}
+		if !s.LastSuccess.IsZero() {
+			lastSuccess = s.LastSuccess
+		}
+		if s.LastError != "" {
+			lastError = s.LastError
+		}
+		rows = append(rows, sql.NewRow(name, s.LastAttempt, lastSuccess, uint64(s.LastDuration.Milliseconds()), lastError, s.Successes, s.Failures))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
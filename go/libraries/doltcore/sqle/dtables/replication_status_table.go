@@ -0,0 +1,111 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ReplicationStatusTable is a sql.Table implementation that implements a system table which shows the state of the
+// replication of each ref of the database to each remote of dolt_replicate_to_remote, for the refs updated since the
+// server started.
+type ReplicationStatusTable struct {
+	ddb *doltdb.DoltDB
+}
+
+var _ sql.Table = (*ReplicationStatusTable)(nil)
+
+// NewReplicationStatusTable creates a ReplicationStatusTable for the replication hooks of |ddb|.
+func NewReplicationStatusTable(ddb *doltdb.DoltDB) sql.Table {
+	return &ReplicationStatusTable{ddb: ddb}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ReplicationStatusTableName
+func (rt *ReplicationStatusTable) Name() string {
+	return doltdb.ReplicationStatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ReplicationStatusTableName
+func (rt *ReplicationStatusTable) String() string {
+	return doltdb.ReplicationStatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the replication status system table.
+func (rt *ReplicationStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "remote", Type: types.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "ref", Type: types.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "mode", Type: types.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "head", Type: types.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "replicated_head", Type: types.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "lag_ms", Type: types.Uint64, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "last_replicated", Type: types.Datetime, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_error", Type: types.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_error_time", Type: types.Datetime, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (rt *ReplicationStatusTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (rt *ReplicationStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *ReplicationStatusTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	now := time.Now()
+	statuses := rt.ddb.ReplicationStatus()
+	rows := make([]sql.Row, len(statuses))
+	for i, s := range statuses {
+		mode := "sync"
+		if s.Async {
+			mode = "async"
+		}
+		var lastError interface{}
+		if s.LastError != nil {
+			lastError = s.LastError.Error()
+		}
+		rows[i] = sql.NewRow(s.Remote, s.Ref, mode, hashOrNil(s.Head), hashOrNil(s.Replicated), uint64(s.Lag(now).Milliseconds()),
+			timeOrNil(s.LastReplicated), lastError, timeOrNil(s.LastErrorAt))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func hashOrNil(h hash.Hash) interface{} {
+	if h.IsEmpty() {
+		return nil
+	}
+	return h.String()
+}
+
+func timeOrNil(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+const (
+	changedColumnsColName      = "changed_columns"
+	changedColumnBitmapColName = "changed_column_bitmap"
+)
+
+var _ sql.Table = (*RowColumnDiffTable)(nil)
+
+// RowColumnDiffTable is a sql.Table implementation of the dolt_column_diff_<table> system tables. They have a row
+// for each row changed by a commit, like dolt_diff_<table>, with the names of the columns the change set instead of
+// the values before and after the change. The changed columns are also given as a bitmap, in which bit i (bit i%8 of
+// byte i/8) is set when the column with ordinal i in the table's current schema changed. Rows that were added or
+// removed change every column.
+type RowColumnDiffTable struct {
+	name string
+	dt   *DiffTable
+	sch  sql.Schema
+
+	// pkOrds are the ordinals in the rows of the diff table of the to and from values of the primary key columns
+	toPkOrds, fromPkOrds []int
+	// colOrds are the ordinals in the rows of the diff table of the to and from values of each column
+	toColOrds, fromColOrds []int
+	colNames               []string
+	colTypes               []sql.Type
+
+	fromCommitOrd, toCommitOrd, toCommitDateOrd, diffTypeOrd int
+}
+
+// NewRowColumnDiffTable creates a RowColumnDiffTable for the table named.
+func NewRowColumnDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, root *doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
+	t, err := NewDiffTable(ctx, tblName, ddb, root, head)
+	if sql.ErrTableNotFound.Is(err) {
+		return nil, sql.ErrTableNotFound.New(doltdb.DoltColumnDiffTablePrefix + tblName)
+	} else if err != nil {
+		return nil, err
+	}
+	dt := t.(*DiffTable)
+	name := doltdb.DoltColumnDiffTablePrefix + dt.name
+	diffSch := dt.Schema()
+
+	rt := &RowColumnDiffTable{
+		name:            name,
+		dt:              dt,
+		fromCommitOrd:   diffSch.IndexOfColName(fromCommit),
+		toCommitOrd:     diffSch.IndexOfColName(toCommit),
+		toCommitDateOrd: diffSch.IndexOfColName(toCommitDate),
+		diffTypeOrd:     diffSch.IndexOfColName(diffTypeColName),
+	}
+
+	for _, col := range dt.targetSch.GetAllCols().GetColumns() {
+		toOrd := diffSch.IndexOfColName(diff.ToColNamer(col.Name))
+		fromOrd := diffSch.IndexOfColName(diff.FromColNamer(col.Name))
+		rt.toColOrds = append(rt.toColOrds, toOrd)
+		rt.fromColOrds = append(rt.fromColOrds, fromOrd)
+		rt.colNames = append(rt.colNames, col.Name)
+		rt.colTypes = append(rt.colTypes, diffSch[toOrd].Type)
+		if col.IsPartOfPK {
+			rt.toPkOrds = append(rt.toPkOrds, toOrd)
+			rt.fromPkOrds = append(rt.fromPkOrds, fromOrd)
+			rt.sch = append(rt.sch, &sql.Column{Name: col.Name, Type: diffSch[toOrd].Type, Source: name, Nullable: false})
+		}
+	}
+	rt.sch = append(rt.sch,
+		&sql.Column{Name: fromCommit, Type: types.Text, Source: name, Nullable: true},
+		&sql.Column{Name: toCommit, Type: types.Text, Source: name, Nullable: true},
+		&sql.Column{Name: toCommitDate, Type: types.Datetime, Source: name, Nullable: true},
+		&sql.Column{Name: diffTypeColName, Type: types.Text, Source: name, Nullable: false},
+		&sql.Column{Name: changedColumnsColName, Type: types.LongText, Source: name, Nullable: false},
+		&sql.Column{Name: changedColumnBitmapColName, Type: types.LongBlob, Source: name, Nullable: false},
+	)
+	return rt, nil
+}
+
+func (rt *RowColumnDiffTable) Name() string {
+	return rt.name
+}
+
+func (rt *RowColumnDiffTable) String() string {
+	return rt.name
+}
+
+func (rt *RowColumnDiffTable) Schema() sql.Schema {
+	return rt.sch
+}
+
+func (rt *RowColumnDiffTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns the partitions of the diff table.
+func (rt *RowColumnDiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	return rt.dt.Partitions(ctx)
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (rt *RowColumnDiffTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	iter, err := rt.dt.PartitionRows(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	return &rowColumnDiffIter{rt: rt, diffIter: iter}, nil
+}
+
+// rowColumnDiffIter converts the rows of a diff table into the rows of a RowColumnDiffTable.
+type rowColumnDiffIter struct {
+	rt       *RowColumnDiffTable
+	diffIter sql.RowIter
+}
+
+var _ sql.RowIter = (*rowColumnDiffIter)(nil)
+
+func (itr *rowColumnDiffIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		r, err := itr.diffIter.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		row, ok, err := itr.rt.columnDiffRow(r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return row, nil
+		}
+	}
+}
+
+func (itr *rowColumnDiffIter) Close(ctx *sql.Context) error {
+	return itr.diffIter.Close(ctx)
+}
+
+// columnDiffRow returns the row for the diff table row |r|. It returns false for modified rows with no changed
+// columns, which can be left by changes to the schema.
+func (rt *RowColumnDiffTable) columnDiffRow(r sql.Row) (sql.Row, bool, error) {
+	diffType, _ := r[rt.diffTypeOrd].(string)
+
+	var changed []string
+	bitmap := make([]byte, (len(rt.colNames)+7)/8)
+	for i, name := range rt.colNames {
+		if diffType == diffTypeModified {
+			cmp, err := rt.colTypes[i].Compare(r[rt.toColOrds[i]], r[rt.fromColOrds[i]])
+			if err != nil {
+				return nil, false, err
+			}
+			if cmp == 0 {
+				continue
+			}
+		}
+		changed = append(changed, name)
+		bitmap[i/8] |= 1 << (i % 8)
+	}
+	if len(changed) == 0 {
+		return nil, false, nil
+	}
+
+	pkOrds := rt.toPkOrds
+	if diffType == diffTypeRemoved {
+		pkOrds = rt.fromPkOrds
+	}
+	row := make(sql.Row, 0, len(rt.sch))
+	for _, ord := range pkOrds {
+		row = append(row, r[ord])
+	}
+	row = append(row, r[rt.fromCommitOrd], r[rt.toCommitOrd], r[rt.toCommitDateOrd], diffType, strings.Join(changed, ","), bitmap)
+	return row, true, nil
+}
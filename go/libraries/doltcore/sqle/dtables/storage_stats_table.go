@@ -0,0 +1,74 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// StorageStatsTable is a sql.Table implementation that implements a system table which shows the statistics of the
+// cache of decompressed chunks shared by every database of the process.
+type StorageStatsTable struct{}
+
+var _ sql.Table = (*StorageStatsTable)(nil)
+
+// NewStorageStatsTable creates a StorageStatsTable.
+func NewStorageStatsTable() sql.Table {
+	return &StorageStatsTable{}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StorageStatsTableName
+func (st *StorageStatsTable) Name() string {
+	return doltdb.StorageStatsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StorageStatsTableName
+func (st *StorageStatsTable) String() string {
+	return doltdb.StorageStatsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the storage stats system table.
+func (st *StorageStatsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "cache_max_bytes", Type: types.Uint64, Source: doltdb.StorageStatsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "cache_bytes", Type: types.Uint64, Source: doltdb.StorageStatsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "cache_chunks", Type: types.Uint64, Source: doltdb.StorageStatsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "cache_hits", Type: types.Uint64, Source: doltdb.StorageStatsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "cache_misses", Type: types.Uint64, Source: doltdb.StorageStatsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (st *StorageStatsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (st *StorageStatsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StorageStatsTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	s := nbs.GetChunkCacheStats()
+	return sql.RowsToRowIter(sql.NewRow(s.MaxSize, s.Size, uint64(s.Chunks), s.Hits, s.Misses)), nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// StorageTable is a sql.Table implementation that implements a system table which shows how the large values of each
+// column of the working set, which are stored out of band as trees of chunks, are stored. Versions of a large value
+// share the chunks they have in common, so the chunks stored for a column can be much smaller than its values.
+type StorageTable struct {
+	root *doltdb.RootValue
+}
+
+var _ sql.Table = (*StorageTable)(nil)
+
+// NewStorageTable creates a StorageTable for the large values of |root|.
+func NewStorageTable(root *doltdb.RootValue) sql.Table {
+	return &StorageTable{root: root}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StorageTableName
+func (st *StorageTable) Name() string {
+	return doltdb.StorageTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StorageTableName
+func (st *StorageTable) String() string {
+	return doltdb.StorageTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the storage system table.
+func (st *StorageTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: doltdb.StorageTableName, PrimaryKey: true, Nullable: false},
+		{Name: "column_name", Type: types.Text, Source: doltdb.StorageTableName, PrimaryKey: true, Nullable: false},
+		{Name: "value_count", Type: types.Uint64, Source: doltdb.StorageTableName, PrimaryKey: false, Nullable: false},
+		{Name: "logical_bytes", Type: types.Uint64, Source: doltdb.StorageTableName, PrimaryKey: false, Nullable: false},
+		{Name: "chunk_count", Type: types.Uint64, Source: doltdb.StorageTableName, PrimaryKey: false, Nullable: false},
+		{Name: "stored_bytes", Type: types.Uint64, Source: doltdb.StorageTableName, PrimaryKey: false, Nullable: false},
+		{Name: "deduped_bytes", Type: types.Uint64, Source: doltdb.StorageTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (st *StorageTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (st *StorageTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StorageTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	if !storetypes.IsFormat_DOLT(st.root.VRW().Format()) {
+		return sql.RowsToRowIter(), nil
+	}
+
+	var rows []sql.Row
+	err := st.root.IterTables(ctx, func(name string, tbl *doltdb.Table, sch schema.Schema) (bool, error) {
+		stats, err := largeValueStorageStats(ctx, tbl, sch)
+		if err != nil {
+			return true, err
+		}
+		for _, s := range stats {
+			rows = append(rows, sql.NewRow(name, s.column, s.values, s.logicalBytes, s.chunks, s.storedBytes, s.logicalBytes-s.leafBytes))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// valueStorageStats are the storage statistics of the large values of a column.
+type valueStorageStats struct {
+	column string
+	// values and logicalBytes are the number and total length of the column's values
+	values       uint64
+	logicalBytes uint64
+	// chunks and storedBytes are the number and total size of the distinct chunks of the column's values
+	chunks      uint64
+	storedBytes uint64
+	// leafBytes is the total length of the contents of the distinct leaf chunks of the column's values
+	leafBytes uint64
+
+	seen    hash.HashSet
+	lengths map[hash.Hash]uint64
+}
+
+// largeValueStorageStats returns the storage statistics of the columns of |tbl| whose values are stored out of band.
+func largeValueStorageStats(ctx context.Context, tbl *doltdb.Table, sch schema.Schema) ([]*valueStorageStats, error) {
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.ProllyMapFromIndex(rowData)
+	_, vd := m.Descriptors()
+
+	// the value tuples of keyless rows start with their cardinality
+	offset := 0
+	if schema.IsKeyless(sch) {
+		offset = 1
+	}
+	var stats []*valueStorageStats
+	var fields []int
+	for i, col := range sch.GetNonPKCols().GetColumns() {
+		switch vd.Types[i+offset].Enc {
+		case val.BytesAddrEnc, val.StringAddrEnc, val.JSONAddrEnc:
+			stats = append(stats, &valueStorageStats{column: col.Name, seen: make(hash.HashSet), lengths: make(map[hash.Hash]uint64)})
+			fields = append(fields, i+offset)
+		}
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+
+	ns := tbl.NodeStore()
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return stats, nil
+		} else if err != nil {
+			return nil, err
+		}
+		for i, field := range fields {
+			b := vd.GetField(field, v)
+			if b == nil {
+				continue
+			}
+			addr := hash.New(b)
+			if addr.IsEmpty() {
+				continue
+			}
+			if err = stats[i].addValue(ctx, ns, addr); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// addValue adds the value stored as the tree with root |addr| to the statistics.
+func (s *valueStorageStats) addValue(ctx context.Context, ns tree.NodeStore, addr hash.Hash) error {
+	s.values++
+	if l, ok := s.lengths[addr]; ok {
+		s.logicalBytes += l
+		return nil
+	}
+
+	root, err := ns.Read(ctx, addr)
+	if err != nil {
+		return err
+	}
+	length := uint64(0)
+	err = tree.WalkNodes(ctx, root, ns, func(ctx context.Context, n tree.Node) error {
+		h := n.HashOf()
+		var leafLen uint64
+		if n.IsLeaf() {
+			leafLen = uint64(len(n.GetValue(0)))
+			length += leafLen
+		}
+		if s.seen.Has(h) {
+			return nil
+		}
+		s.seen.Insert(h)
+		s.chunks++
+		s.storedBytes += uint64(n.Size())
+		s.leafBytes += leafLen
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.lengths[addr] = length
+	s.logicalBytes += length
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*TableProvenanceTable)(nil)
+var _ sql.UpdatableTable = (*TableProvenanceTable)(nil)
+var _ sql.DeletableTable = (*TableProvenanceTable)(nil)
+var _ sql.InsertableTable = (*TableProvenanceTable)(nil)
+var _ sql.ReplaceableTable = (*TableProvenanceTable)(nil)
+
+// TableProvenanceTable is the system table that stores the source URL, license and collection date of tables. The
+// provenance of a table listed here overrides the provenance in the trailers of commit messages.
+type TableProvenanceTable struct {
+	backingTable sql.Table
+}
+
+// NewTableProvenanceTable creates a TableProvenanceTable
+func NewTableProvenanceTable(_ *sql.Context, backingTable sql.Table) sql.Table {
+	return &TableProvenanceTable{backingTable: backingTable}
+}
+
+func (t *TableProvenanceTable) Name() string {
+	return doltdb.TableProvenanceTableName
+}
+
+func (t *TableProvenanceTable) String() string {
+	return doltdb.TableProvenanceTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_table_provenance system table.
+func (t *TableProvenanceTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.TableProvenanceTableName, PrimaryKey: true},
+		{Name: "source_url", Type: sqlTypes.Text, Source: doltdb.TableProvenanceTableName, Nullable: true},
+		{Name: "license", Type: sqlTypes.Text, Source: doltdb.TableProvenanceTableName, Nullable: true},
+		{Name: "collected_at", Type: sqlTypes.Text, Source: doltdb.TableProvenanceTableName, Nullable: true},
+	}
+}
+
+func (t *TableProvenanceTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (t *TableProvenanceTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if t.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return t.backingTable.Partitions(context)
+}
+
+func (t *TableProvenanceTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if t.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+	return t.backingTable.PartitionRows(context, partition)
+}
+
+// Replacer returns a RowReplacer for this table.
+func (t *TableProvenanceTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return newBackedTableWriter(doltdb.TableProvenanceTableName, t.backingSchema)
+}
+
+// Updater returns a RowUpdater for this table.
+func (t *TableProvenanceTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return newBackedTableWriter(doltdb.TableProvenanceTableName, t.backingSchema)
+}
+
+// Inserter returns an Inserter for this table.
+func (t *TableProvenanceTable) Inserter(*sql.Context) sql.RowInserter {
+	return newBackedTableWriter(doltdb.TableProvenanceTableName, t.backingSchema)
+}
+
+// Deleter returns a RowDeleter for this table.
+func (t *TableProvenanceTable) Deleter(*sql.Context) sql.RowDeleter {
+	return newBackedTableWriter(doltdb.TableProvenanceTableName, t.backingSchema)
+}
+
+// backingSchema returns the schema of the table which stores the rows of dolt_table_provenance.
+func (t *TableProvenanceTable) backingSchema() (schema.Schema, error) {
+	colCollection := schema.NewColCollection(
+		schema.Column{
+			Name:       "table_name",
+			Tag:        schema.DoltTableProvenanceTableTag,
+			Kind:       types.StringKind,
+			IsPartOfPK: true,
+			TypeInfo:   typeinfo.FromKind(types.StringKind),
+		},
+		schema.Column{
+			Name:     "source_url",
+			Tag:      schema.DoltTableProvenanceSourceURLTag,
+			Kind:     types.StringKind,
+			TypeInfo: typeinfo.FromKind(types.StringKind),
+		},
+		schema.Column{
+			Name:     "license",
+			Tag:      schema.DoltTableProvenanceLicenseTag,
+			Kind:     types.StringKind,
+			TypeInfo: typeinfo.FromKind(types.StringKind),
+		},
+		schema.Column{
+			Name:     "collected_at",
+			Tag:      schema.DoltTableProvenanceCollectedAtTag,
+			Kind:     types.StringKind,
+			TypeInfo: typeinfo.FromKind(types.StringKind),
+		},
+	)
+	return schema.NewSchema(colCollection, nil, schema.Collation_Default, nil, nil)
+}
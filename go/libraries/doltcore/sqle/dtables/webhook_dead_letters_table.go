@@ -0,0 +1,88 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// WebhookDeadLettersTable is a sql.Table implementation that implements a system table which shows the webhooks of
+// the database that the sql-server gave up delivering, oldest first, with their payloads and the last error.
+type WebhookDeadLettersTable struct {
+	fs filesys.ReadableFS
+}
+
+var _ sql.Table = (*WebhookDeadLettersTable)(nil)
+
+// NewWebhookDeadLettersTable creates a WebhookDeadLettersTable for the database whose files are in |fs|.
+func NewWebhookDeadLettersTable(fs filesys.ReadableFS) sql.Table {
+	return &WebhookDeadLettersTable{fs: fs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// WebhookDeadLettersTableName
+func (wt *WebhookDeadLettersTable) Name() string {
+	return doltdb.WebhookDeadLettersTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// WebhookDeadLettersTableName
+func (wt *WebhookDeadLettersTable) String() string {
+	return doltdb.WebhookDeadLettersTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the webhook dead letters system table.
+func (wt *WebhookDeadLettersTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "url", Type: types.Text, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+		{Name: "event", Type: types.Text, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+		{Name: "ref", Type: types.Text, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+		{Name: "payload", Type: types.LongText, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+		{Name: "attempts", Type: types.Int32, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+		{Name: "error", Type: types.Text, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+		{Name: "failed_at", Type: types.Datetime, Source: doltdb.WebhookDeadLettersTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (wt *WebhookDeadLettersTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (wt *WebhookDeadLettersTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (wt *WebhookDeadLettersTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	letters, err := env.LoadWebhookDeadLetters(wt.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(letters))
+	for i, l := range letters {
+		rows[i] = sql.NewRow(l.URL, l.Event, l.Ref, l.Payload, int32(l.Attempts), l.Error, l.FailedAt)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
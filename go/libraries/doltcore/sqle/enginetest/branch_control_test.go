@@ -320,6 +320,11 @@ var BranchControlBlockTests = []BranchControlBlockTest{
 		Query:       "CALL DOLT_BRANCH('-d', 'other');",
 		ExpectedErr: branch_control.ErrCannotDeleteBranch,
 	},
+	{
+		Name:        "DELETE FROM dolt_branches",
+		Query:       "DELETE FROM dolt_branches WHERE name = 'other';",
+		ExpectedErr: branch_control.ErrCannotDeleteBranch,
+	},
 	{
 		Name:        "DOLT_CLEAN",
 		Query:       "CALL DOLT_CLEAN();",
@@ -355,11 +360,24 @@ var BranchControlBlockTests = []BranchControlBlockTest{
 		Query:       "CALL DOLT_RESET();",
 		ExpectedErr: branch_control.ErrIncorrectPermissions,
 	},
+	{
+		Name: "DOLT_RESTORE",
+		SetUpScript: []string{
+			"CALL DOLT_SNAPSHOT('snap');",
+		},
+		Query:       "CALL DOLT_RESTORE('snap');",
+		ExpectedErr: branch_control.ErrIncorrectPermissions,
+	},
 	{
 		Name:        "DOLT_REVERT",
 		Query:       "CALL DOLT_REVERT();",
 		ExpectedErr: branch_control.ErrIncorrectPermissions,
 	},
+	{
+		Name:        "DOLT_SNAPSHOT",
+		Query:       "CALL DOLT_SNAPSHOT('snap');",
+		ExpectedErr: branch_control.ErrIncorrectPermissions,
+	},
 	{
 		Name:        "DOLT_VERIFY_CONSTRAINTS",
 		Query:       "CALL DOLT_VERIFY_CONSTRAINTS('-a');",
@@ -397,6 +415,12 @@ var BranchControlTests = []BranchControlTest{
 				Query:       "CALL DOLT_BRANCH('otherbranch2');",
 				ExpectedErr: branch_control.ErrCannotCreateBranch,
 			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branches (name, hash) VALUES ('otherbranch2', 'main');",
+				ExpectedErr: branch_control.ErrCannotCreateBranch,
+			},
 			{ // Allow testuser to use the "other" prefix
 				User:  "root",
 				Host:  "localhost",
@@ -1378,6 +1378,27 @@ func TestDoltReset(t *testing.T) {
 	}
 }
 
+func TestDoltSnapshot(t *testing.T) {
+	for _, script := range DoltSnapshotScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltAuditLog(t *testing.T) {
+	defer sql.SystemVariables.SetGlobal(dsess.AuditLogKey("mydb"), int8(0))
+	for _, script := range DoltAuditLogScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 func TestDoltGC(t *testing.T) {
 	t.SkipNow()
 	for _, script := range DoltGC {
@@ -1773,6 +1794,30 @@ func TestLogTableFunctionPrepared(t *testing.T) {
 	}
 }
 
+func TestTableSplitsTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range TableSplitsTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestHistorySampledTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range HistorySampledTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
 func TestCommitDiffSystemTable(t *testing.T) {
 	harness := newDoltHarness(t)
 	defer harness.Close()
@@ -17,6 +17,7 @@ package enginetest
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/enginetest/queries"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -561,6 +562,132 @@ var DoltRevisionDbScripts = []queries.ScriptTest{
 // DoltScripts are script tests specific to Dolt (not the engine in general), e.g. by involving Dolt functions. Break
 // this slice into others with good names as it grows.
 var DoltScripts = []queries.ScriptTest{
+	{
+		Name: "fixed-point decimals",
+		SetUpScript: []string{
+			"create table fixed_dec (id int primary key, d decimal(10,2), index (d));",
+			"insert into fixed_dec values (1, 1.50), (2, -3.25), (3, 10.00), (4, 1.5), (5, NULL);",
+			"create table fixed_dec_big (id int primary key, d decimal(40,10), index (d));",
+			"insert into fixed_dec_big values (1, 123456789012345678901234567890.5), (2, -1.25);",
+			"call dolt_commit('-Am', 'decimals');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_rebuild_decimals('fixed_dec', 'fixed_dec_big')",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select id from fixed_dec order by d, id",
+				Expected: []sql.Row{{5}, {2}, {1}, {4}, {3}},
+			},
+			{
+				Query:    "select d from fixed_dec where id in (1, 2, 3) order by id",
+				Expected: []sql.Row{{"1.50"}, {"-3.25"}, {"10.00"}},
+			},
+			{
+				Query:    "select id from fixed_dec where d = 1.5 order by id",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "select id from fixed_dec where d > 1.5",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select sum(d) from fixed_dec",
+				Expected: []sql.Row{{"9.75"}},
+			},
+			{
+				Query:    "select id from fixed_dec_big where d > 0",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				// only the encoding of the rows changed
+				Query:    "select count(*) from dolt_diff('HEAD', 'WORKING', 'fixed_dec')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from dolt_diff_stat('HEAD', 'WORKING', 'fixed_dec')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "insert into fixed_dec values (6, 99999999.99)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select id from fixed_dec where d > 10",
+				Expected: []sql.Row{{6}},
+			},
+			{
+				Query:    "alter table fixed_dec rename column d to amount",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "select amount from fixed_dec where id = 6",
+				Expected: []sql.Row{{"99999999.99"}},
+			},
+			{
+				Query:    "call dolt_rebuild_decimals()",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "call dolt_rebuild_decimals('missing')",
+				ExpectedErrStr: "table not found: missing",
+			},
+		},
+	},
+	{
+		Name: "column dictionaries",
+		SetUpScript: []string{
+			"create table dict_t (id int primary key, color varchar(20), index (color));",
+			"insert into dict_t values (1, 'red'), (2, 'blue'), (3, 'red'), (4, NULL);",
+			"create table dict_keyless (color varchar(20));",
+			"insert into dict_keyless values ('red'), ('red');",
+			"call dolt_commit('-Am', 'colors');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_column_dictionary('dict_t', 'color')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select id, color from dict_t order by id",
+				Expected: []sql.Row{{1, "red"}, {2, "blue"}, {3, "red"}, {4, nil}},
+			},
+			{
+				Query:    "select id from dict_t where color = 'red' order by id",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				// only the encoding of the rows changed
+				Query:    "select count(*) from dolt_diff('HEAD', 'WORKING', 'dict_t')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "insert into dict_t values (5, 'green')",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select color from dict_t where id = 5",
+				Expected: []sql.Row{{"green"}},
+			},
+			{
+				Query:    "call dolt_column_dictionary('--disable', 'dict_t', 'color')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select id, color from dict_t order by id",
+				Expected: []sql.Row{{1, "red"}, {2, "blue"}, {3, "red"}, {4, nil}, {5, "green"}},
+			},
+			{
+				Query:          "call dolt_column_dictionary('dict_keyless', 'color')",
+				ExpectedErrStr: "columns of keyless tables cannot be dictionary encoded",
+			},
+			{
+				Query:          "call dolt_column_dictionary('dict_t', 'id')",
+				ExpectedErrStr: "primary key column id cannot be dictionary encoded",
+			},
+		},
+	},
 	{
 		Name: "test null filtering in secondary indexes (https://github.com/dolthub/dolt/issues/4199)",
 		SetUpScript: []string{
@@ -937,6 +1064,150 @@ var DoltScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "@@dolt_snapshot_time pins the reads of the session",
+		SetUpScript: []string{
+			"CREATE TABLE snapshot_t (pk int PRIMARY KEY, c int);",
+			"INSERT INTO snapshot_t VALUES (1, 10);",
+			"CALL DOLT_ADD('-A');",
+			"CALL DOLT_COMMIT('-m', 'create snapshot_t');",
+			"CALL DOLT_TAG('v1', 'HEAD');",
+			"INSERT INTO snapshot_t VALUES (2, 20);",
+			"CREATE TABLE snapshot_t2 (pk int PRIMARY KEY);",
+			"CALL DOLT_ADD('-A');",
+			"CALL DOLT_COMMIT('-m', 'add snapshot_t2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SET @@dolt_snapshot_time = 'v1';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT * FROM snapshot_t;",
+				Expected: []sql.Row{{1, 10}},
+			},
+			{
+				Query:       "SELECT * FROM snapshot_t2;",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:    "SHOW TABLES LIKE 'snapshot%';",
+				Expected: []sql.Row{{"snapshot_t"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"create snapshot_t"}},
+			},
+			{
+				Query:    "SELECT * FROM snapshot_t AS OF 'HEAD';",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "SET @@dolt_snapshot_time = '';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT * FROM snapshot_t;",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"add snapshot_t2"}},
+			},
+		},
+	},
+	{
+		Name: "@@dolt_elide_noop_writes skips updates that don't change the row",
+		SetUpScript: []string{
+			"CREATE TABLE elide_t (pk int PRIMARY KEY, c int, s varchar(10));",
+			"INSERT INTO elide_t VALUES (1, 10, 'abc'), (2, 20, 'def');",
+			"CALL DOLT_ADD('-A');",
+			"CALL DOLT_COMMIT('-m', 'create elide_t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT @@dolt_elide_noop_writes;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "UPDATE elide_t SET c = c, s = s;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 0, Info: plan.UpdateInfo{Matched: 2, Updated: 0}}}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "UPDATE elide_t SET c = 11 WHERE pk = 1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "SELECT to_pk, from_c, to_c, diff_type FROM dolt_diff_elide_t WHERE to_commit = 'WORKING';",
+				Expected: []sql.Row{{1, 10, 11, "modified"}},
+			},
+		},
+	},
+	{
+		Name: "updates which keep the primary key update the stored row in place",
+		SetUpScript: []string{
+			"CREATE TABLE counters (pk int PRIMARY KEY, n int, s varchar(10), t text, INDEX idx_s (s), INDEX idx_n (n));",
+			"INSERT INTO counters VALUES (1, 0, 'abc', 'x'), (2, 0, 'def', NULL);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "UPDATE counters SET n = n + 1 WHERE pk = 1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "UPDATE counters SET n = n + 1 WHERE pk = 1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "SELECT * FROM counters ORDER BY pk;",
+				Expected: []sql.Row{{1, 2, "abc", "x"}, {2, 0, "def", nil}},
+			},
+			{
+				Query:    "SELECT pk FROM counters WHERE n = 2;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT pk FROM counters WHERE s = 'abc';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "UPDATE counters SET s = 'abcdef', t = NULL WHERE pk = 1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "UPDATE counters SET n = NULL, t = 'y' WHERE pk = 2;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "SELECT * FROM counters ORDER BY pk;",
+				Expected: []sql.Row{{1, 2, "abcdef", nil}, {2, nil, "def", "y"}},
+			},
+			{
+				Query:    "SELECT pk FROM counters WHERE s = 'abcdef';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT pk FROM counters WHERE n IS NULL;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "UPDATE counters SET pk = 3, n = n + 1 WHERE pk = 1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "SELECT pk, n FROM counters WHERE s = 'abcdef';",
+				Expected: []sql.Row{{3, 3}},
+			},
+			{
+				Query:    "SELECT pk FROM counters WHERE n = 3;",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
 }
 
 func makeLargeInsert(sz int) string {
@@ -2067,6 +2338,54 @@ var DoltBranchScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "Create and delete branches with inserts and deletes on dolt_branches",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create table');",
+			"set @commit1 = hashof('HEAD');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'insert row');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "insert into dolt_branches (name, hash) values ('b1', @commit1), ('b2', 'main');",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
+			},
+			{
+				Query:    "select name, hash = @commit1, hash = hashof('main') from dolt_branches order by name;",
+				Expected: []sql.Row{{"b1", true, false}, {"b2", false, true}, {"main", false, true}},
+			},
+			{
+				Query:          "insert into dolt_branches (name, hash) values ('b1', 'main');",
+				ExpectedErrStr: "fatal: A branch named 'b1' already exists.",
+			},
+			{
+				Query:          "insert into dolt_branches (name, hash) values ('', 'main');",
+				ExpectedErrStr: "error: cannot branch empty string",
+			},
+			{
+				Query:          "insert into dolt_branches (name, hash) values ('b3', 'unknownCommit');",
+				ExpectedErrStr: "fatal: 'unknownCommit' is not a commit and a branch 'b3' cannot be created from it",
+			},
+			{
+				Query:          "update dolt_branches set name = 'b3' where name = 'b1';",
+				ExpectedErrStr: "the dolt_branches table can't be updated; use the dolt_branch stored procedure to rename branches",
+			},
+			{
+				Query:    "delete from dolt_branches where name = 'b2';",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "delete from dolt_branches where name = 'main';",
+				ExpectedErrStr: "attempted to delete checked out branch",
+			},
+			{
+				Query:    "select name from dolt_branches order by name;",
+				Expected: []sql.Row{{"b1"}, {"main"}},
+			},
+		},
+	},
 }
 
 var DoltReset = []queries.ScriptTest{
@@ -2126,6 +2445,178 @@ var DoltReset = []queries.ScriptTest{
 	},
 }
 
+var DoltSnapshotScripts = []queries.ScriptTest{
+	{
+		Name: "DOLT_RESTORE resets the working and staged roots to a snapshot",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'create t');",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL DOLT_ADD('t');",
+			"INSERT INTO t VALUES (3, 3);",
+			"CALL DOLT_SNAPSHOT('fixture');",
+			"DELETE FROM t;",
+			"CREATE TABLE u (pk int primary key);",
+			"CALL DOLT_ADD('.');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_RESTORE('fixture');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "SHOW TABLES LIKE 'u';",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT table_name, staged, status FROM dolt_status ORDER BY staged;",
+				Expected: []sql.Row{{"t", false, "modified"}, {"t", true, "modified"}},
+			},
+			{
+				// a snapshot can be restored any number of times
+				Query:    "DELETE FROM t WHERE pk = 1;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "CALL DOLT_RESTORE('fixture');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) FROM t;",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "DOLT_SNAPSHOT replaces and deletes snapshots",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key);",
+			"CALL DOLT_COMMIT('-Am', 'create t');",
+			"CALL DOLT_SNAPSHOT('s1');",
+			"INSERT INTO t VALUES (1);",
+			"CALL DOLT_SNAPSHOT('s1');",
+			"INSERT INTO t VALUES (2);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_RESTORE('s1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "CALL DOLT_SNAPSHOT('-d', 's1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "CALL DOLT_RESTORE('s1');",
+				ExpectedErrStr: "error: could not restore snapshot 's1': snapshot not found",
+			},
+			{
+				Query:          "CALL DOLT_SNAPSHOT('-d', 's1');",
+				ExpectedErrStr: "error: could not delete snapshot 's1': snapshot not found",
+			},
+			{
+				Query:          "CALL DOLT_SNAPSHOT('bad..name');",
+				ExpectedErrStr: "error: could not create snapshot 'bad..name': not a valid snapshot name",
+			},
+			{
+				Query:          "CALL DOLT_SNAPSHOT();",
+				ExpectedErrStr: "error: DOLT_SNAPSHOT requires the name of the snapshot",
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_branches;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "DOLT_RESTORE aborts a merge in progress",
+		SetUpScript: []string{
+			"SET dolt_allow_commit_conflicts = on;",
+			"CREATE TABLE t (pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'create t');",
+			"CALL DOLT_SNAPSHOT('clean');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"UPDATE t SET c = 2;",
+			"CALL DOLT_COMMIT('-am', 'c = 2');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET c = 3;",
+			"CALL DOLT_COMMIT('-am', 'c = 3');",
+			"CALL DOLT_MERGE('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_RESTORE('clean');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:          "CALL DOLT_MERGE('--abort');",
+				ExpectedErrStr: "fatal: There is no merge to abort",
+			},
+		},
+	},
+}
+
+var DoltAuditLogScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_audit_log records the statements which change tables",
+		SetUpScript: []string{
+			"CREATE TABLE before_audit (pk int primary key);",
+			"INSERT INTO before_audit VALUES (1);",
+			"SET @@GLOBAL.mydb_audit_log = 1;",
+			"CREATE TABLE t (pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1), (2, 2), (3, 3);",
+			"UPDATE t SET c = c + 1 WHERE pk > 1;",
+			"UPDATE t SET c = c WHERE pk = 1;",
+			"DELETE FROM t WHERE pk = 3;",
+			"DELETE FROM t WHERE pk = 10;",
+			"REPLACE INTO t VALUES (1, 10);",
+			"ALTER TABLE t ADD COLUMN d int, ADD INDEX idx_c (c);",
+			"SELECT * FROM t;",
+			"TRUNCATE t;",
+			"DROP TABLE before_audit;",
+			"SET @@GLOBAL.mydb_audit_log = 0;",
+			"INSERT INTO t VALUES (5, 5, 5);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT user, branch, operation, table_name, query, rows_changed FROM dolt_audit_log;",
+				Expected: []sql.Row{
+					{"root", "main", "DDL", "t", "CREATE TABLE t (pk int primary key, c int);", 0},
+					{"root", "main", "INSERT", "t", "INSERT INTO t VALUES (1, 1), (2, 2), (3, 3);", 3},
+					{"root", "main", "UPDATE", "t", "UPDATE t SET c = c + 1 WHERE pk > 1;", 2},
+					{"root", "main", "DELETE", "t", "DELETE FROM t WHERE pk = 3;", 1},
+					{"root", "main", "REPLACE", "t", "REPLACE INTO t VALUES (1, 10);", 2},
+					{"root", "main", "DDL", "t", "ALTER TABLE t ADD COLUMN d int, ADD INDEX idx_c (c);", 0},
+					{"root", "main", "TRUNCATE", "t", "TRUNCATE t;", 2},
+					{"root", "main", "DDL", "before_audit", "DROP TABLE before_audit;", 0},
+				},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_audit_log WHERE timestamp > NOW() - INTERVAL 1 HOUR;",
+				Expected: []sql.Row{{8}},
+			},
+			{
+				Query:          "INSERT INTO dolt_audit_log VALUES ('root', NOW(), 'main', 'DDL', 't', '', 0);",
+				ExpectedErrStr: "table doesn't support INSERT INTO",
+			},
+		},
+	},
+}
+
 func gcSetup() []string {
 	queries := []string{
 		"create table t (pk int primary key);",
@@ -2647,6 +3138,71 @@ var LogTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "paging with --after-commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t 2');",
+
+			"call dolt_checkout('-b', 'new-branch');",
+			"insert into t values (3, 'three', 'four');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t 3');",
+			"insert into t values (4, 'four', 'five');",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into t 4');",
+
+			"call dolt_checkout('main');",
+			"insert into t values (5, 'five', 'six');",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting into t 5');",
+		},
+		/* Commit graph:
+		          3 - 4 (new-branch)
+		         /
+		0 - 1 - 2 - 5 (main)
+		*/
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('--after-commit', @Commit5) LIMIT 2;",
+				Expected: []sql.Row{{"inserting into t 2"}, {"creating table t"}},
+			},
+			{
+				Query:    "SELECT (SELECT count(*) from dolt_log('--after-commit', @Commit5)) = (SELECT count(*) from dolt_log(@Commit2));",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('new-branch', '--after-commit', @Commit3) LIMIT 2;",
+				Expected: []sql.Row{{"inserting into t 2"}, {"creating table t"}},
+			},
+			{
+				Query:    "SELECT (SELECT count(*) from dolt_log('--after-commit', @Commit1)) = (SELECT count(*) from dolt_log(@Commit1)) - 1;",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main', '--after-commit', @Commit4) LIMIT 2;",
+				Expected: []sql.Row{{"inserting into t 5"}, {"inserting into t 2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..new-branch', '--after-commit', @Commit4);",
+				Expected: []sql.Row{{"inserting into t 3"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main...new-branch', '--after-commit', @Commit4);",
+				Expected: []sql.Row{{"inserting into t 5"}, {"inserting into t 3"}},
+			},
+			{
+				Query:          "SELECT message from dolt_log('--after-commit', 'notacommit');",
+				ExpectedErrStr: "branch not found: notacommit",
+			},
+		},
+	},
 	//TODO: figure out how we were returning a commit from the function
 	/*{
 		Name: "min parents, merges, show parents, decorate",
@@ -2737,6 +3293,239 @@ var LogTableFunctionScriptTests = []queries.ScriptTest{
 	},*/
 }
 
+var TableSplitsTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"create table keyless (c1 int);",
+			"call dolt_commit('-Am', 'creating tables');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "select * from dolt_table_splits('t');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_table_splits('t', 2, 'HEAD', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_table_splits('t', 'two');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_table_splits('t', 0);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_table_splits('t', 2, 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_table_splits('doesnotexist', 2);",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:          "select * from dolt_table_splits('keyless', 2);",
+				ExpectedErrStr: "dolt_table_splits requires a primary key, and table keyless has none",
+			},
+		},
+	},
+	{
+		Name: "single column primary key",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"insert into t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, 'e'), (6, 'f'), (7, 'g'), (8, 'h'), (9, 'i'), (10, 'j');",
+			"call dolt_commit('-Am', 'creating table t');",
+			"insert into t values (11, 'k');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select split, lower_bound, upper_bound, row_count, predicate from dolt_table_splits('t', 3);",
+				Expected: []sql.Row{
+					{0, nil, "(4)", uint64(3), "(`pk`) < (4)"},
+					{1, "(4)", "(7)", uint64(3), "(`pk`) >= (4) AND (`pk`) < (7)"},
+					{2, "(7)", nil, uint64(4), "(`pk`) >= (7)"},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_table_splits('t', 3) where commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select count(*) from t as of 'HEAD' where (`pk`) >= (4) AND (`pk`) < (7);",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select count(*), sum(row_count) from dolt_table_splits('t', 100);",
+				Expected: []sql.Row{{10, float64(10)}},
+			},
+			{
+				Query:    "select split, row_count, predicate from dolt_table_splits('t', 1);",
+				Expected: []sql.Row{{0, uint64(10), "TRUE"}},
+			},
+			{
+				Query:    "select commit_hash, sum(row_count) from dolt_table_splits('t', 2, 'WORKING') group by commit_hash;",
+				Expected: []sql.Row{{"WORKING", float64(11)}},
+			},
+		},
+	},
+	{
+		Name: "composite primary key",
+		SetUpScript: []string{
+			"create table t (a int, b varchar(10), c int, primary key (a, b));",
+			"insert into t values (1, 'a', 0), (1, 'b', 0), (2, 'a', 0), (2, 'b', 0);",
+			"call dolt_commit('-Am', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select split, lower_bound, upper_bound, row_count, predicate from dolt_table_splits('t', 2);",
+				Expected: []sql.Row{
+					{0, nil, "(2,'a')", uint64(2), "(`a`,`b`) < (2,'a')"},
+					{1, "(2,'a')", nil, uint64(2), "(`a`,`b`) >= (2,'a')"},
+				},
+			},
+			{
+				Query:    "select count(*) from t where (`a`,`b`) >= (2,'a');",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "empty table",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select split, lower_bound, upper_bound, row_count, predicate from dolt_table_splits('t', 4);",
+				Expected: []sql.Row{{0, nil, nil, uint64(0), "TRUE"}},
+			},
+		},
+	},
+}
+
+var HistorySampledTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "select * from dolt_history_sampled('t');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_history_sampled('t', 2, 'HEAD', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_history_sampled('t', '1 day', '2020-01-01');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_history_sampled('t', 0);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_history_sampled('doesnotexist', 2);",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:          "select * from dolt_history_sampled('t', '1 fortnight', '2020-01-01', '2020-02-01');",
+				ExpectedErrStr: "invalid interval '1 fortnight': unknown unit 'fortnight', expected second, minute, hour, day, week, month or year",
+			},
+			{
+				Query:          "select * from dolt_history_sampled('t', 'one day', '2020-01-01', '2020-02-01');",
+				ExpectedErrStr: "invalid interval 'one day': 'one' is not a positive integer",
+			},
+			{
+				Query:          "select * from dolt_history_sampled('t', '1 day', '2020-02-01', '2020-01-01');",
+				ExpectedErrStr: "dolt_history_sampled: the end date 2020-01-01T00:00:00Z is before the start date 2020-02-01T00:00:00Z",
+			},
+			{
+				Query:          "select * from dolt_history_sampled('t', '1 second', '2000-01-01', '2020-01-01');",
+				ExpectedErrStr: "dolt_history_sampled samples more than 10000 points in time; use a longer interval",
+			},
+		},
+	},
+	{
+		Name: "every nth commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2');",
+			"insert into t values (3, 3);",
+			"call dolt_commit('-am', 'insert 3');",
+			"insert into t values (4, 4);",
+			"call dolt_commit('-am', 'insert 4');",
+			"insert into t values (5, 5);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// HEAD and every second commit before it; the working set isn't sampled
+				Query:    "select count(*) from dolt_history_sampled('t', 2) group by commit_hash order by 1;",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				Query:    "select count(*) from dolt_history_sampled('t', 2, 'HEAD~1') group by commit_hash order by 1;",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				Query:    "select count(distinct commit_hash) from dolt_history_sampled('t', 1);",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select count(*) from dolt_history_sampled('t', 1) where sample_date = commit_date;",
+				Expected: []sql.Row{{10}},
+			},
+			{
+				Query:    "select pk, c1 from dolt_history_sampled('t', 10) order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}, {4, 4}},
+			},
+		},
+	},
+	{
+		Name: "commit as of each interval",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t', '--date', '2023-01-01T00:00:00');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'insert 1', '--date', '2023-01-03T12:00:00');",
+			"insert into t values (2), (3);",
+			"call dolt_commit('-am', 'insert 2 and 3', '--date', '2023-01-10T12:00:00');",
+			"call dolt_branch('other');",
+			"insert into t values (4);",
+			"call dolt_commit('-am', 'insert 4', '--date', '2023-01-20T12:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select sample_date, count(*) from dolt_history_sampled('t', '1 week', '2022-12-25', '2023-01-31') group by sample_date order by 1;",
+				Expected: []sql.Row{
+					{time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC), 1},
+					{time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), 3},
+					{time.Date(2023, 1, 22, 0, 0, 0, 0, time.UTC), 4},
+					{time.Date(2023, 1, 29, 0, 0, 0, 0, time.UTC), 4},
+				},
+			},
+			{
+				Query: "select sample_date, count(*) from dolt_history_sampled('t', '1 month', '2022-12-15', '2023-03-01', 'other') group by sample_date order by 1;",
+				Expected: []sql.Row{
+					{time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), 3},
+					{time.Date(2023, 2, 15, 0, 0, 0, 0, time.UTC), 3},
+				},
+			},
+		},
+	},
+}
+
 var LargeJsonObjectScriptTests = []queries.ScriptTest{
 	{
 		Name: "JSON under max length limit",
@@ -4476,6 +4476,52 @@ var ColumnDiffSystemTableScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_column_diff_<table> shows the columns changed for each row",
+		SetUpScript: []string{
+			"create table t (pk int primary key, price int, qty int);",
+			"insert into t values (1, 10, 100), (2, 20, 200);",
+			"call dolt_commit('-Am', 'create t', '--date', '2023-01-01T00:00:00');",
+			"update t set price = 11 where pk = 1;",
+			"update t set qty = 201 where pk = 2;",
+			"call dolt_commit('-am', 'change price and qty', '--date', '2023-01-02T00:00:00');",
+			"update t set price = 12, qty = 101 where pk = 1;",
+			"delete from t where pk = 2;",
+			"call dolt_commit('-am', 'change row 1 and delete row 2', '--date', '2023-01-03T00:00:00');",
+			"insert into t values (3, 30, 300);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select pk, diff_type, changed_columns, hex(changed_column_bitmap) from dolt_column_diff_t where to_commit = 'WORKING';",
+				Expected: []sql.Row{
+					{3, "added", "pk,price,qty", "07"},
+				},
+			},
+			{
+				Query: "select pk, diff_type, changed_columns, hex(changed_column_bitmap) from dolt_column_diff_t where to_commit <> 'WORKING' order by to_commit_date, pk;",
+				Expected: []sql.Row{
+					{1, "added", "pk,price,qty", "07"},
+					{2, "added", "pk,price,qty", "07"},
+					{1, "modified", "price", "02"},
+					{2, "modified", "qty", "04"},
+					{1, "modified", "price,qty", "06"},
+					{2, "removed", "pk,price,qty", "07"},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_column_diff_t where pk = 1 and find_in_set('price', changed_columns) > 0;",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select count(*) from dolt_column_diff_t where pk = 2 and find_in_set('price', changed_columns) > 0;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:       "select * from dolt_column_diff_missing;",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
 }
 
 var CommitDiffSystemTableScriptTests = []queries.ScriptTest{
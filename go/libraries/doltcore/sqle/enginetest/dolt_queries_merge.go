@@ -1180,6 +1180,123 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_merge_state shows the tables of a merge, which dolt_merge('--continue') commits once they're resolved",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key, val int)",
+			"CREATE TABLE other (pk int primary key)",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0, 0)",
+			"CALL DOLT_COMMIT('-a', '-m', 'Step 1');",
+			"CALL DOLT_CHECKOUT('-b', 'feature-branch')",
+			"UPDATE test SET val=1000 WHERE pk=0;",
+			"INSERT INTO other VALUES (1);",
+			"CALL DOLT_COMMIT('-a', '-m', 'update on feature-branch');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE test SET val=1001 WHERE pk=0;",
+			"CALL DOLT_COMMIT('-a', '-m', 'update on main');",
+			"set dolt_allow_commit_conflicts = on",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * FROM dolt_merge_state;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "CALL DOLT_MERGE('--continue')",
+				ExpectedErrStr: "fatal: There is no merge to continue",
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature-branch')",
+				Expected: []sql.Row{{0, 1}},
+			},
+			{
+				Query: "SELECT table_name, status, conflicts, constraint_violations, source, target, strategy FROM dolt_merge_state;",
+				Expected: []sql.Row{
+					{"other", "merged", uint64(0), uint64(0), "feature-branch", "refs/heads/main", "three-way"},
+					{"test", "conflicts", uint64(1), uint64(0), "feature-branch", "refs/heads/main", "three-way"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_merge_state JOIN dolt_log ON ancestor = commit_hash WHERE message = 'Step 1';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:          "CALL DOLT_MERGE('--continue')",
+				ExpectedErrStr: dprocedures.ErrUnresolvedMerge.New("test").Error(),
+			},
+			{
+				Query:    "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 'test')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT table_name, status, conflicts, staged FROM dolt_merge_state;",
+				Expected: []sql.Row{{"other", "merged", uint64(0), true}, {"test", "merged", uint64(0), false}},
+			},
+			{
+				Query:    "CALL DOLT_ADD('test')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT table_name, staged FROM dolt_merge_state;",
+				Expected: []sql.Row{{"other", true}, {"test", true}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--continue', '-m', 'resolved the merge')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_merge_state;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"resolved the merge"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM test, other;",
+				Expected: []sql.Row{{0, 1000, 1}},
+			},
+		},
+	},
+	{
+		Name: "merge a column dictionary with row changes from the other branch",
+		SetUpScript: []string{
+			"CREATE TABLE colors (pk int primary key, color varchar(20), index (color));",
+			"INSERT INTO colors VALUES (1, 'red'), (2, 'blue');",
+			"CALL DOLT_COMMIT('-Am', 'colors');",
+			"CALL DOLT_BRANCH('other');",
+			"CALL DOLT_COLUMN_DICTIONARY('colors', 'color');",
+			"CALL DOLT_COMMIT('-am', 'dictionary');",
+			"CALL DOLT_CHECKOUT('other');",
+			"INSERT INTO colors VALUES (3, 'green');",
+			"UPDATE colors SET color = 'blue' WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'more colors');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT * FROM colors ORDER BY pk;",
+				Expected: []sql.Row{{1, "blue"}, {2, "blue"}, {3, "green"}},
+			},
+			{
+				Query:    "SELECT pk FROM colors WHERE color = 'blue' ORDER BY pk;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_schema_conflicts;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
 }
 
 var Dolt1MergeScripts = []queries.ScriptTest{
@@ -2029,6 +2146,176 @@ var Dolt1ConflictTableNameTableTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "keyless conflicts can be resolved by row id",
+		SetUpScript: []string{
+			"create table t (col1 int);",
+			"insert into t values (1), (2), (3);",
+			"call dolt_commit('-Am', 'create table');",
+
+			"call dolt_checkout('-b', 'other');",
+			"insert into t values (1), (2), (2);",
+			"call dolt_commit('-Am', 'other commit');",
+
+			"call dolt_checkout('main');",
+			"insert into t values (1), (1), (2);",
+			"delete from t where col1 = 3;",
+			"call dolt_commit('-Am', 'main commit');",
+
+			"set dolt_allow_commit_conflicts = on;",
+			"call dolt_merge('other');",
+			"set @one = (select dolt_row_id from dolt_conflicts_t where base_col1 = 1);",
+			"set @two = (select dolt_row_id from dolt_conflicts_t where base_col1 = 2);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select count(*) from dolt_conflicts_t where dolt_row_id like '%:0';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--theirs', 't', '--row', @two);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select col1, count(*) from t group by col1 order by col1;",
+				Expected: []sql.Row{{1, 3}, {2, 3}},
+			},
+			{
+				Query:    "select base_col1, our_cardinality, their_cardinality, dolt_row_id = @one from dolt_conflicts_t;",
+				Expected: []sql.Row{{1, uint64(3), uint64(2), true}},
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--ours', 't', '--row', 'abc:0');",
+				ExpectedErrStr: "no conflicts in table t with dolt_row_id abc:0",
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--ours', 't', '--row', @one);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select col1, count(*) from t group by col1 order by col1;",
+				Expected: []sql.Row{{1, 3}, {2, 3}},
+			},
+		},
+	},
+	{
+		Name: "conflicts can be resolved by a predicate on their versions",
+		SetUpScript: []string{
+			"create table t (pk int primary key, val varchar(20), updated_at int);",
+			"insert into t values (1, 'base', 0), (2, 'base', 0), (3, 'base', 0), (4, 'base', 0);",
+			"call dolt_commit('-Am', 'create table');",
+
+			"call dolt_checkout('-b', 'other');",
+			"update t set val = 'theirs', updated_at = if(pk % 2 = 1, 5, 1);",
+			"call dolt_commit('-Am', 'other commit');",
+
+			"call dolt_checkout('main');",
+			"update t set val = 'ours', updated_at = if(pk % 2 = 1, 1, 5);",
+			"call dolt_commit('-Am', 'main commit');",
+
+			"set dolt_allow_commit_conflicts = on;",
+			"call dolt_merge('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_conflicts_resolve('--theirs', 't', '--where', 'their_updated_at > our_updated_at');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, "theirs", 5}, {2, "ours", 5}, {3, "theirs", 5}, {4, "ours", 5}},
+			},
+			{
+				Query:    "select our_pk, our_val, their_val from dolt_conflicts_t order by our_pk;",
+				Expected: []sql.Row{{2, "ours", "theirs"}, {4, "ours", "theirs"}},
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--ours', 't', 't2', '--where', 'our_pk = 2');",
+				ExpectedErrStr: "--where and --side require exactly one table",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--ours', 't', '--side', 'ours');",
+				ExpectedErrStr: "--side can't be used with --ours or --theirs",
+			},
+			{
+				Query:       "call dolt_conflicts_resolve('--ours', 't', '--where', 'updated_at > 1');",
+				ExpectedErr: sql.ErrColumnNotFound,
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('t', '--side', 'if(our_pk = 2, ''ours'', ''mine'')');",
+				ExpectedErrStr: "--side must evaluate to 'ours' or 'theirs', but was mine",
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('t', '--side', 'if(our_pk = 2, ''ours'', ''theirs'')');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, "theirs", 5}, {2, "ours", 5}, {3, "theirs", 5}, {4, "theirs", 1}},
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "conflicts can be resolved by a predicate on their versions - keyless",
+		SetUpScript: []string{
+			"create table t (name varchar(20), price int);",
+			"insert into t values ('apple', 1), ('pear', 2);",
+			"call dolt_commit('-Am', 'create table');",
+
+			"call dolt_checkout('-b', 'other');",
+			"insert into t values ('apple', 1), ('pear', 2), ('pear', 2);",
+			"call dolt_commit('-Am', 'other commit');",
+
+			"call dolt_checkout('main');",
+			"delete from t;",
+			"call dolt_commit('-Am', 'main commit');",
+
+			"set dolt_allow_commit_conflicts = on;",
+			"call dolt_merge('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select base_name, our_cardinality, their_cardinality from dolt_conflicts_t order by base_name;",
+				Expected: []sql.Row{{"apple", uint64(0), uint64(2)}, {"pear", uint64(0), uint64(3)}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('t', '--where', 'their_name = ''pear''', '--side', '''theirs''');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{"pear", 2}, {"pear", 2}, {"pear", 2}},
+			},
+			{
+				Query:    "select base_name, our_cardinality, their_cardinality from dolt_conflicts_t;",
+				Expected: []sql.Row{{"apple", uint64(0), uint64(2)}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--ours', 't', '--where', 'their_cardinality = 2');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{"pear", 2}, {"pear", 2}, {"pear", 2}},
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
 	{
 		Name: "Updates on our columns get applied to the source table - keyless",
 		SetUpScript: []string{
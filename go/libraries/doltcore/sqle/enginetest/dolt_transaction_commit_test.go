@@ -487,3 +487,59 @@ func TestDoltTransactionCommitLateFkResolution(t *testing.T) {
 		},
 	})
 }
+
+func TestDoltTransactionCommitIgnoredTables(t *testing.T) {
+	// Tables matched by dolt_ignore are left out of the commits made on transaction commit, unless the session asks for
+	// them with @@dolt_transaction_commit_include_ignored.
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	enginetest.TestTransactionScript(t, harness, queries.TransactionTest{
+		Name: "dolt commit on transaction commit with ignored tables",
+		SetUpScript: []string{
+			"CREATE TABLE x (y BIGINT PRIMARY KEY, z BIGINT);",
+			"INSERT INTO dolt_ignore (pattern, ignored) VALUES ('tmp_*', true);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ SET @@dolt_transaction_commit=1;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ CREATE TABLE tmp_x (pk int PRIMARY KEY);",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client a */ INSERT INTO x VALUES (1,1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ SELECT * FROM x AS OF 'HEAD';",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:       "/* client a */ SELECT * FROM tmp_x AS OF 'HEAD';",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:    "/* client b */ SET @@dolt_transaction_commit=1;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client b */ SET @@dolt_transaction_commit_include_ignored=1;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client b */ CREATE TABLE tmp_y (pk int PRIMARY KEY);",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client b */ SELECT * FROM tmp_y AS OF 'HEAD';",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ SELECT * FROM tmp_x AS OF 'HEAD';",
+				Expected: []sql.Row{},
+			},
+		},
+	})
+}
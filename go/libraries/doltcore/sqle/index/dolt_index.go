@@ -350,6 +350,12 @@ func getSecondaryIndex(ctx context.Context, db, tbl string, t *doltdb.Table, sch
 	}
 	vrw := t.ValueReadWriter()
 
+	// partial indexes only hold the rows matching their predicate, which need not be contiguous in index order
+	order := sql.IndexOrderAsc
+	if len(idx.Predicate()) > 0 {
+		order = sql.IndexOrderNone
+	}
+
 	return &doltIndex{
 		id:                            idx.Name(),
 		tblName:                       tbl,
@@ -364,10 +370,11 @@ func getSecondaryIndex(ctx context.Context, db, tbl string, t *doltdb.Table, sch
 		vrw:                           vrw,
 		ns:                            t.NodeStore(),
 		keyBld:                        keyBld,
-		order:                         sql.IndexOrderAsc,
+		order:                         order,
 		constrainedToLookupExpression: true,
 		doltBinFormat:                 types.IsFormat_DOLT(vrw.Format()),
 		prefixLengths:                 idx.PrefixLengths(),
+		predicate:                     idx.Predicate(),
 	}, nil
 }
 
@@ -502,12 +509,17 @@ type doltIndex struct {
 	doltBinFormat bool
 
 	prefixLengths []uint16
+	predicate     schema.IndexPredicate
 }
 
 var _ DoltIndex = (*doltIndex)(nil)
 
 // CanSupport implements sql.Index
-func (di *doltIndex) CanSupport(...sql.Range) bool {
+func (di *doltIndex) CanSupport(ranges ...sql.Range) bool {
+	if len(di.predicate) > 0 {
+		// partial indexes can only serve lookups of rows which are all in the index
+		return di.rangesImplyPredicate(ranges)
+	}
 	return true
 }
 
@@ -24,6 +24,7 @@ import (
 
 func NewSecondaryKeyBuilder(sch schema.Schema, def schema.Index, idxDesc val.TupleDesc, p pool.BuffPool) (b SecondaryKeyBuilder) {
 	b.builder = val.NewTupleBuilder(idxDesc)
+	b.valDesc = sch.GetValueDescriptor()
 	b.pool = p
 
 	keyless := schema.IsKeyless(sch)
@@ -58,19 +59,24 @@ func NewSecondaryKeyBuilder(sch schema.Schema, def schema.Index, idxDesc val.Tup
 type SecondaryKeyBuilder struct {
 	mapping val.OrdinalMapping
 	split   int
+	valDesc val.TupleDesc
 	builder *val.TupleBuilder
 	pool    pool.BuffPool
 }
 
 // SecondaryKeyFromRow builds a secondary index key from a clustered index row.
-func (b SecondaryKeyBuilder) SecondaryKeyFromRow(k, v val.Tuple) val.Tuple {
+func (b SecondaryKeyBuilder) SecondaryKeyFromRow(k, v val.Tuple) (val.Tuple, error) {
 	for to := range b.mapping {
 		from := b.mapping.MapOrdinal(to)
 		if from < b.split {
 			b.builder.PutRaw(to, k.GetField(from))
 		} else {
 			from -= b.split
-			buf := v.GetField(from)
+			// index keys never hold string dictionary codes or fixed-point decimals
+			buf, err := b.valDesc.GetPlainField(from, v)
+			if err != nil {
+				return nil, err
+			}
 			if b.builder.Desc.Types[to].Enc == val.CellEnc {
 				// convert from WKB to z-order encoding
 				cell := ZCell(deserializeGeometry(buf).(types.GeometryValue))
@@ -79,7 +85,7 @@ func (b SecondaryKeyBuilder) SecondaryKeyFromRow(k, v val.Tuple) val.Tuple {
 			b.builder.PutRaw(to, buf)
 		}
 	}
-	return b.builder.Build(b.pool)
+	return b.builder.Build(b.pool), nil
 }
 
 func NewClusteredKeyBuilder(def schema.Index, sch schema.Schema, keyDesc val.TupleDesc, p pool.BuffPool) (b ClusteredKeyBuilder) {
@@ -0,0 +1,230 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// PartialIndexFilter matches the secondary index keys of the rows contained in a partial index. A nil
+// *PartialIndexFilter is the filter of an index over every row of its table, and matches every key.
+type PartialIndexFilter struct {
+	desc  val.TupleDesc
+	terms []partialIndexTerm
+}
+
+type partialIndexTerm struct {
+	// ord is the position of the term's column in the index key
+	ord   int
+	op    schema.IndexPredicateOp
+	value []byte
+}
+
+// NewPartialIndexFilter returns a filter for the predicate of |def|, whose keys are described by |keyDesc|. Nil is
+// returned if |def| is not a partial index.
+func NewPartialIndexFilter(ctx context.Context, def schema.Index, keyDesc val.TupleDesc) (*PartialIndexFilter, error) {
+	pred := def.Predicate()
+	if len(pred) == 0 {
+		return nil, nil
+	}
+
+	f := &PartialIndexFilter{desc: keyDesc, terms: make([]partialIndexTerm, len(pred))}
+	tb := val.NewTupleBuilder(keyDesc)
+	for i, term := range pred {
+		f.terms[i] = partialIndexTerm{ord: -1, op: term.Op}
+		for ord, tag := range def.IndexedColumnTags() {
+			if tag == term.Tag {
+				f.terms[i].ord = ord
+				break
+			}
+		}
+		if f.terms[i].ord < 0 {
+			return nil, fmt.Errorf("predicate of index %s references a column outside of the index", def.Name())
+		}
+		if term.Value == nil {
+			continue
+		}
+
+		col, _ := def.GetColumn(term.Tag)
+		v, _, err := col.TypeInfo.ToSqlType().Convert(*term.Value)
+		if err != nil {
+			return nil, err
+		}
+		// literals are never large enough to be stored out of band
+		if err = PutField(ctx, nil, tb, f.terms[i].ord, v); err != nil {
+			return nil, err
+		}
+		tup := tb.BuildPermissive(sharePool)
+		f.terms[i].value = tup.GetField(f.terms[i].ord)
+	}
+	return f, nil
+}
+
+// Matches returns whether the row with the secondary index key |key| belongs in the index.
+func (f *PartialIndexFilter) Matches(key val.Tuple) bool {
+	if f == nil {
+		return true
+	}
+	cmp := f.desc.Comparator()
+	for _, term := range f.terms {
+		field := f.desc.GetField(term.ord, key)
+		switch term.op {
+		case schema.IndexPredicateIsNull:
+			if field != nil {
+				return false
+			}
+			continue
+		case schema.IndexPredicateIsNotNull:
+			if field == nil {
+				return false
+			}
+			continue
+		}
+		if field == nil {
+			// comparisons with NULL are never true
+			return false
+		}
+
+		c := cmp.CompareValues(term.ord, field, term.value, f.desc.Types[term.ord])
+		var ok bool
+		switch term.op {
+		case schema.IndexPredicateEq:
+			ok = c == 0
+		case schema.IndexPredicateNotEq:
+			ok = c != 0
+		case schema.IndexPredicateLt:
+			ok = c < 0
+		case schema.IndexPredicateLte:
+			ok = c <= 0
+		case schema.IndexPredicateGt:
+			ok = c > 0
+		case schema.IndexPredicateGte:
+			ok = c >= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// rangesImplyPredicate returns whether every row within |ranges| satisfies the predicate of the partial index |di|,
+// in which case a lookup of the ranges in the index returns the same rows as a scan of the table.
+func (di *doltIndex) rangesImplyPredicate(ranges []sql.Range) bool {
+	for _, rng := range ranges {
+		for _, term := range di.predicate {
+			ord := -1
+			for i, col := range di.columns {
+				if col.Tag == term.Tag {
+					ord = i
+					break
+				}
+			}
+			if ord < 0 || ord >= len(rng) {
+				return false
+			}
+			ok, err := rangeImpliesTerm(rng[ord], term)
+			if err != nil || !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// rangeImpliesTerm returns whether every value within |expr| satisfies |term|.
+func rangeImpliesTerm(expr sql.RangeColumnExpr, term schema.IndexPredicateTerm) (bool, error) {
+	_, lowerNull := expr.LowerBound.(sql.BelowNull)
+	_, upperNull := expr.UpperBound.(sql.AboveNull)
+	switch term.Op {
+	case schema.IndexPredicateIsNull:
+		return lowerNull && upperNull, nil
+	case schema.IndexPredicateIsNotNull:
+		return !lowerNull, nil
+	}
+	if lowerNull {
+		// NULL never satisfies a comparison
+		return false, nil
+	}
+
+	lit, _, err := expr.Typ.Convert(*term.Value)
+	if err != nil {
+		return false, err
+	}
+	lo, loClosed, loOk, err := rangeCutLiteral(expr.LowerBound, expr.Typ, true)
+	if err != nil {
+		return false, err
+	}
+	hi, hiClosed, hiOk, err := rangeCutLiteral(expr.UpperBound, expr.Typ, false)
+	if err != nil {
+		return false, err
+	}
+
+	var loCmp, hiCmp int
+	if loOk {
+		if loCmp, err = expr.Typ.Compare(lo, lit); err != nil {
+			return false, err
+		}
+	}
+	if hiOk {
+		if hiCmp, err = expr.Typ.Compare(hi, lit); err != nil {
+			return false, err
+		}
+	}
+
+	below := hiOk && (hiCmp < 0 || (hiCmp == 0 && !hiClosed))
+	above := loOk && (loCmp > 0 || (loCmp == 0 && !loClosed))
+	switch term.Op {
+	case schema.IndexPredicateEq:
+		return loOk && hiOk && loClosed && hiClosed && loCmp == 0 && hiCmp == 0, nil
+	case schema.IndexPredicateNotEq:
+		return below || above, nil
+	case schema.IndexPredicateLt:
+		return below, nil
+	case schema.IndexPredicateLte:
+		return hiOk && hiCmp <= 0, nil
+	case schema.IndexPredicateGt:
+		return above, nil
+	case schema.IndexPredicateGte:
+		return loOk && loCmp >= 0, nil
+	default:
+		return false, nil
+	}
+}
+
+// rangeCutLiteral returns the value of |cut|, and whether it is included in its range, if the cut binds a value.
+func rangeCutLiteral(cut sql.RangeCut, typ sql.Type, lower bool) (v interface{}, closed bool, ok bool, err error) {
+	switch cut.(type) {
+	case sql.Below, sql.Above:
+	default:
+		return nil, false, false, nil
+	}
+	v, err = getRangeCutValue(cut, typ)
+	if err != nil {
+		return nil, false, false, err
+	}
+	if lower {
+		closed = cut.TypeAsLowerBound() == sql.Closed
+	} else {
+		closed = cut.TypeAsUpperBound() == sql.Closed
+	}
+	return v, closed, true, nil
+}
@@ -60,7 +60,7 @@ func GetField(ctx context.Context, td val.TupleDesc, i int, tup val.Tuple, ns tr
 		v, ok = td.GetFloat64(i, tup)
 	case val.Bit64Enc:
 		v, ok = td.GetBit(i, tup)
-	case val.DecimalEnc:
+	case val.DecimalEnc, val.Decimal64Enc:
 		v, ok = td.GetDecimal(i, tup)
 	case val.YearEnc:
 		v, ok = td.GetYear(i, tup)
@@ -80,6 +80,8 @@ func GetField(ctx context.Context, td val.TupleDesc, i int, tup val.Tuple, ns tr
 		v, ok = td.GetSet(i, tup)
 	case val.StringEnc:
 		v, ok = td.GetString(i, tup)
+	case val.StringDictEnc:
+		v, ok, err = td.GetDictString(i, tup)
 	case val.ByteStringEnc:
 		v, ok = td.GetBytes(i, tup)
 	case val.JSONEnc:
@@ -159,7 +161,7 @@ func PutField(ctx context.Context, ns tree.NodeStore, tb *val.TupleBuilder, i in
 		tb.PutFloat64(i, v.(float64))
 	case val.Bit64Enc:
 		tb.PutBit(i, uint64(convUint(v)))
-	case val.DecimalEnc:
+	case val.DecimalEnc, val.Decimal64Enc:
 		tb.PutDecimal(i, v.(decimal.Decimal))
 	case val.YearEnc:
 		tb.PutYear(i, v.(int16))
@@ -173,7 +175,7 @@ func PutField(ctx context.Context, ns tree.NodeStore, tb *val.TupleBuilder, i in
 		tb.PutEnum(i, v.(uint16))
 	case val.SetEnc:
 		tb.PutSet(i, v.(uint64))
-	case val.StringEnc:
+	case val.StringEnc, val.StringDictEnc:
 		tb.PutString(i, v.(string))
 	case val.ByteStringEnc:
 		if s, ok := v.(string); ok {
@@ -0,0 +1,74 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/indexusage"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// indexUsageProvider is implemented by database providers that track how often indexes are read.
+type indexUsageProvider interface {
+	IndexUsage(dbName string) *indexusage.Tracker
+}
+
+// indexUsageTracker returns the index usage tracker for |db|, or nil if the session's provider doesn't track usage.
+func indexUsageTracker(ctx *sql.Context, db dsess.SqlDatabase) *indexusage.Tracker {
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok {
+		return nil
+	}
+	pro, ok := sess.Provider().(indexUsageProvider)
+	if !ok {
+		return nil
+	}
+	return pro.IndexUsage(db.BaseName())
+}
+
+// indexUsageBranch returns the branch that reads of |db| are counted against. Returns false for databases pinned to a
+// tag or commit, whose indexes can't be dropped.
+func indexUsageBranch(ctx *sql.Context, db dsess.SqlDatabase) (string, bool, error) {
+	switch db.RevisionType() {
+	case dsess.RevisionTypeBranch:
+		return db.Revision(), true, nil
+	case dsess.RevisionTypeNone:
+		headRef, err := dsess.DSessFromSess(ctx.Session).CWBHeadRef(ctx, db.Name())
+		if err != nil || headRef == nil {
+			return "", false, err
+		}
+		return headRef.GetPath(), true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// recordIndexRead counts a lookup into |idx| of |t| in the index usage of its database. Usage is advisory, so a lookup
+// whose branch can't be determined is not counted rather than failed.
+func recordIndexRead(ctx *sql.Context, t *DoltTable, idx index.DoltIndex) {
+	tracker := indexUsageTracker(ctx, t.db)
+	if tracker == nil {
+		return
+	}
+
+	branch, ok, err := indexUsageBranch(ctx, t.db)
+	if err != nil || !ok {
+		return
+	}
+
+	tracker.RecordRead(branch, t.tableName, idx.ID())
+}
@@ -66,6 +66,7 @@ func (idt *IndexedDoltTable) Collation() sql.CollationID {
 }
 
 func (idt *IndexedDoltTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	recordIndexRead(ctx, idt.table, idt.idx)
 	return index.NewRangePartitionIter(ctx, idt.table, lookup, idt.isDoltFormat)
 }
 
@@ -88,7 +89,7 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Partition)
 		}
 	}
 
-	return idt.lb.NewRowIter(ctx, part)
+	return idt.lb.NewRowIter(withScanPrefetch(ctx), part)
 }
 
 func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
@@ -105,7 +106,7 @@ func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition
 		}
 	}
 
-	return idt.lb.NewRowIter(ctx, part)
+	return idt.lb.NewRowIter(withScanPrefetch(ctx), part)
 }
 
 func (idt *IndexedDoltTable) IsTemporary() bool {
@@ -137,6 +138,7 @@ type WritableIndexedDoltTable struct {
 }
 
 func (t *WritableIndexedDoltTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	recordIndexRead(ctx, t.DoltTable, t.idx)
 	return index.NewRangePartitionIter(ctx, t.DoltTable, lookup, t.isDoltFormat)
 }
 
@@ -158,7 +160,7 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 		}
 	}
 
-	return t.lb.NewRowIter(ctx, part)
+	return t.lb.NewRowIter(withScanPrefetch(ctx), part)
 }
 
 // WithProjections implements sql.ProjectedTable
@@ -0,0 +1,91 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/projcache"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// projectionCacheProvider is implemented by database providers that cache the columns read by table scans.
+type projectionCacheProvider interface {
+	ProjectionCache() *projcache.Cache
+}
+
+// projectionCache returns the projection cache of the session's provider, or nil if it doesn't have one.
+func projectionCache(ctx *sql.Context) *projcache.Cache {
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok {
+		return nil
+	}
+	pro, ok := sess.Provider().(projectionCacheProvider)
+	if !ok {
+		return nil
+	}
+	return pro.ProjectionCache()
+}
+
+// projectionCacheSize returns the number of bytes the projection cache may hold, which is zero if it's disabled.
+func projectionCacheSize() uint64 {
+	_, v, ok := sql.SystemVariables.GetGlobal(dsess.ProjectionCacheSize)
+	if !ok {
+		return 0
+	}
+	size, ok := v.(int64)
+	if !ok || size <= 0 {
+		return 0
+	}
+	return uint64(size)
+}
+
+// cachedPartitionRows returns the rows of |partition| of |table| from the projection cache, or returns false if the
+// scan doesn't use the cache. Only scans that read some, but not all, of the columns of a table use the cache. Rows
+// that aren't cached yet are read from |table| and cached as they're returned.
+func (t *DoltTable) cachedPartitionRows(ctx *sql.Context, table *doltdb.Table, partition sql.Partition) (sql.RowIter, bool, error) {
+	p, ok := partition.(doltTablePartition)
+	if !ok || t.db == nil || len(t.projectedCols) == 0 || len(t.projectedCols) >= t.sch.GetAllCols().Size() {
+		return nil, false, nil
+	}
+	if !types.IsFormat_DOLT(table.Format()) {
+		return nil, false, nil
+	}
+	maxSize := projectionCacheSize()
+	if maxSize == 0 {
+		return nil, false, nil
+	}
+	cache := projectionCache(ctx)
+	if cache == nil {
+		return nil, false, nil
+	}
+
+	h, err := table.HashOf()
+	if err != nil {
+		return nil, false, err
+	}
+	key := projcache.NewKey(h, t.projectedCols, p.start, p.end)
+	if iter, ok := cache.Get(key); ok {
+		return iter, true, nil
+	}
+
+	iter, err := partitionRows(ctx, table, t.sqlSch.Schema, t.projectedCols, partition)
+	if err != nil {
+		return nil, false, err
+	}
+	return cache.Wrap(key, t.db.BaseName(), t.tableName, t.Projections(), iter, maxSize), true, nil
+}
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
@@ -30,6 +32,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -39,6 +42,7 @@ type ReadReplicaDatabase struct {
 	srcDB   *doltdb.DoltDB
 	tmpDir  string
 	limiter *limiter
+	warmer  *replicaWarmer
 }
 
 var _ dsess.SqlDatabase = ReadReplicaDatabase{}
@@ -85,12 +89,37 @@ func NewReadReplicaDatabase(ctx context.Context, db Database, remoteName string,
 		tmpDir:   tmpDir,
 		srcDB:    srcDB,
 		limiter:  newLimiter(),
+		warmer:   &replicaWarmer{},
 	}, nil
 }
 
 func (rrd ReadReplicaDatabase) ValidReplicaState(ctx *sql.Context) bool {
 	// srcDB will be nil in the case the remote was specified incorrectly and startup errors are suppressed
-	return rrd.srcDB != nil
+	if rrd.srcDB == nil {
+		return false
+	}
+	// promoting the server to a primary clears dolt_read_replica_remote, and stops the pulls
+	_, remote, _ := sql.SystemVariables.GetGlobal(dsess.ReadReplicaRemote)
+	return remote != ""
+}
+
+// ReplicationEpoch implements dsess.RemoteReadReplicaDatabase.
+func (rrd ReadReplicaDatabase) ReplicationEpoch(ctx *sql.Context) (uint64, error) {
+	return rrd.srcDB.ReplicationEpoch(ctx)
+}
+
+// Promote implements dsess.RemoteReadReplicaDatabase. The remote is fenced before any commit is pushed to it, so that
+// the previous primary can't push once this database has.
+func (rrd ReadReplicaDatabase) Promote(ctx *sql.Context, epoch uint64) error {
+	if err := rrd.srcDB.FenceReplicationEpoch(ctx, epoch); err != nil {
+		return err
+	}
+	hook := doltdb.NewPushOnWriteHook(rrd.remote.Name, rrd.srcDB, rrd.tmpDir)
+	if err := hook.SetLogger(ctx, cli.CliErr); err != nil {
+		return err
+	}
+	rrd.ddb.PrependCommitHook(ctx, hook)
+	return nil
 }
 
 // InitialDBState implements dsess.SessionDatabase
@@ -213,9 +242,74 @@ func (rrd ReadReplicaDatabase) PullFromRemote(ctx *sql.Context) error {
 		return fmt.Errorf("%w: dolt_replicate_heads not set", ErrInvalidReplicateHeadsSetting)
 	}
 
+	if ReadReplicaWarm() {
+		rrd.warmFromPrimary(ctx)
+	}
 	return nil
 }
 
+// warmFromPrimary prefetches the chunks of the access log published by the primary to the remote, so that the chunks
+// that are hot on the primary are already cached when load shifts to this replica. The chunks are read in the
+// background, and each access log is only warmed once.
+func (rrd ReadReplicaDatabase) warmFromPrimary(ctx *sql.Context) {
+	if rrd.warmer == nil || !rrd.warmer.due() {
+		return
+	}
+	hashes, err := rrd.srcDB.ReadChunkAccessLog(ctx)
+	if errors.Is(err, nbs.ErrNoAccessLog) {
+		return
+	} else if err != nil {
+		dsess.WarnReplicationError(ctx, err)
+		return
+	}
+	rrd.warmer.warm(rrd.ddb, hashes)
+}
+
+// replicaWarmer tracks the access logs a read replica warmed up with.
+type replicaWarmer struct {
+	mu      sync.Mutex
+	checked time.Time
+	warming bool
+	last    hash.Hash
+}
+
+// due returns whether the access log of the primary should be checked again, which is at most as often as the primary
+// publishes it.
+func (w *replicaWarmer) due() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.warming || time.Since(w.checked) < accessLogPublishInterval {
+		return false
+	}
+	w.checked = time.Now()
+	return true
+}
+
+// warm reads the chunks |hashes| that are in |ddb| in the background, unless they were the last ones warmed.
+func (w *replicaWarmer) warm(ddb *doltdb.DoltDB, hashes []hash.Hash) {
+	log := make([]byte, 0, len(hashes)*hash.ByteLen)
+	for _, h := range hashes {
+		log = append(log, h[:]...)
+	}
+	digest := hash.Of(log)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.warming || digest == w.last {
+		return
+	}
+	w.warming = true
+	go func() {
+		_, err := ddb.WarmChunks(context.Background(), hashes)
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.warming = false
+		if err == nil {
+			w.last = digest
+		}
+	}()
+}
+
 // CreateLocalBranchFromRemote pulls the given branch from the remote database and creates a local tracking branch for
 // it. This is only used for initializing a new local branch being pulled from a remote during connection
 // initialization, and doesn't do the full work of remote synchronization that happens on transaction start.
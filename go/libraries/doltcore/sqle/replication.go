@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -26,63 +27,115 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
-func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, logger io.Writer) (doltdb.CommitHook, error) {
-	_, val, ok := sql.SystemVariables.GetGlobal(dsess.ReplicateToRemote)
-	if !ok {
-		return nil, sql.ErrUnknownSystemVariable.New(dsess.ReplicateToRemote)
-	} else if val == "" {
-		return nil, nil
-	}
-
-	remoteName, ok := val.(string)
-	if !ok {
-		return nil, sql.ErrInvalidSystemVariableValue.New(val)
-	}
-
+func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, target dsess.ReplicationTarget, logger io.Writer) (doltdb.CommitHook, error) {
 	remotes, err := dEnv.GetRemotes()
 	if err != nil {
 		return nil, err
 	}
 
-	rem, ok := remotes[remoteName]
+	rem, ok := remotes[target.Remote]
 	if !ok {
-		return nil, fmt.Errorf("%w: '%s'", env.ErrRemoteNotFound, remoteName)
+		return nil, fmt.Errorf("%w: '%s'", env.ErrRemoteNotFound, target.Remote)
 	}
 
 	ddb, err := rem.GetRemoteDB(ctx, types.Format_Default, dEnv)
 	if err != nil {
 		return nil, err
 	}
+	if err = ddb.SetReplicationEpoch(ctx, dsess.GetReplicationEpoch()); err != nil {
+		return nil, err
+	}
+	if rate := dsess.GetReplicateAccessSampleRate(); rate > 0 {
+		dEnv.DoltDB.SampleChunkAccesses(rate)
+		if err = startAccessLogPublisher(bThreads, target.Remote, dEnv.DoltDB, ddb, logger); err != nil {
+			return nil, err
+		}
+	}
 
 	tmpDir, err := dEnv.TempTableFilesDir()
 	if err != nil {
 		return nil, err
 	}
-	if _, val, ok = sql.SystemVariables.GetGlobal(dsess.AsyncReplication); ok && val == dsess.SysVarTrue {
-		return doltdb.NewAsyncPushOnWriteHook(bThreads, ddb, tmpDir, logger)
+	if target.Async {
+		return doltdb.NewAsyncPushOnWriteHook(bThreads, target.Remote, ddb, tmpDir, logger)
 	}
 
-	return doltdb.NewPushOnWriteHook(ddb, tmpDir), nil
+	return doltdb.NewPushOnWriteHook(target.Remote, ddb, tmpDir), nil
+}
+
+// accessLogPublishInterval is how often a primary publishes the chunks it read to its replication remotes, and how often
+// read replicas check for a new access log to warm up with.
+var accessLogPublishInterval = 5 * time.Second
+
+// startAccessLogPublisher periodically publishes the chunks sampled from the reads of |srcDB| to |destDB|, the storage
+// of the replication remote |remote|, so that the read replicas pulling from it can prefetch the chunks that are hot on
+// this server. Failures are logged to |logger| once, and retried at the next interval.
+func startAccessLogPublisher(bThreads *sql.BackgroundThreads, remote string, srcDB, destDB *doltdb.DoltDB, logger io.Writer) error {
+	return bThreads.Add("access log publisher for "+remote, func(ctx context.Context) {
+		ticker := time.NewTicker(accessLogPublishInterval)
+		defer ticker.Stop()
+		var published []hash.Hash
+		var failing bool
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			hashes := srcDB.SampledChunkAccesses()
+			if len(hashes) == 0 || equalHashes(hashes, published) {
+				continue
+			}
+			if err := destDB.PublishChunkAccessLog(ctx, hashes); err != nil {
+				if !failing {
+					fmt.Fprintf(logger, "failed to publish chunk access log to remote '%s': %s\n", remote, err.Error())
+				}
+				failing = true
+				continue
+			}
+			published, failing = hashes, false
+		}
+	})
+}
+
+func equalHashes(a, b []hash.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
-// GetCommitHooks creates a list of hooks to execute on database commit. If doltdb.SkipReplicationErrorsKey is set,
-// replace misconfigured hooks with doltdb.LogHook instances that prints a warning when trying to execute.
+// GetCommitHooks creates a list of hooks to execute on database commit, one for each remote of
+// dolt_replicate_to_remote. If doltdb.SkipReplicationErrorsKey is set, replace misconfigured hooks with
+// doltdb.LogHook instances that prints a warning when trying to execute.
 func GetCommitHooks(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, logger io.Writer) ([]doltdb.CommitHook, error) {
 	postCommitHooks := make([]doltdb.CommitHook, 0)
 
-	if hook, err := getPushOnWriteHook(ctx, bThreads, dEnv, logger); err != nil {
-		path, _ := dEnv.FS.Abs(".")
-		err = fmt.Errorf("failure loading hook for database at %s; %w", path, err)
-		if dsess.IgnoreReplicationErrors() {
-			postCommitHooks = append(postCommitHooks, doltdb.NewLogHook([]byte(err.Error()+"\n")))
+	targets, err := dsess.GetReplicationTargets()
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		if hook, err := getPushOnWriteHook(ctx, bThreads, dEnv, target, logger); err != nil {
+			path, _ := dEnv.FS.Abs(".")
+			err = fmt.Errorf("failure loading hook for database at %s; %w", path, err)
+			if dsess.IgnoreReplicationErrors() {
+				postCommitHooks = append(postCommitHooks, doltdb.NewLogHook([]byte(err.Error()+"\n")))
+			} else {
+				return nil, err
+			}
 		} else {
-			return nil, err
+			postCommitHooks = append(postCommitHooks, hook)
 		}
-	} else if hook != nil {
-		postCommitHooks = append(postCommitHooks, hook)
 	}
 
 	for _, h := range postCommitHooks {
@@ -22,9 +22,11 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -253,7 +255,7 @@ func DoltTablePartitionToRowIter(ctx *sql.Context, name string, table *doltdb.Ta
 		if end > uint64(c) {
 			end = uint64(c)
 		}
-		iter, err := idx.IterOrdinalRange(ctx, start, end)
+		iter, err := idx.IterOrdinalRange(withScanPrefetch(ctx), start, end)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -287,3 +289,20 @@ func DoltTablePartitionToRowIter(ctx *sql.Context, name string, table *doltdb.Ta
 
 	return pkSch.Schema, rowIter, nil
 }
+
+// withScanPrefetch returns |ctx| with the leaf prefetching requested by the session's dolt_scan_prefetch_depth, which
+// applies to the map iterators created with it.
+func withScanPrefetch(ctx *sql.Context) *sql.Context {
+	if ctx.Session == nil {
+		return ctx
+	}
+	v, err := ctx.GetSessionVariable(ctx, dsess.ScanPrefetchDepth)
+	if err != nil {
+		return ctx
+	}
+	depth, ok := v.(int64)
+	if !ok || depth <= 0 {
+		return ctx
+	}
+	return ctx.WithContext(tree.WithScanPrefetch(ctx, int(depth)))
+}
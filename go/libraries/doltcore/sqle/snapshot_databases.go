@@ -0,0 +1,271 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Snapshot databases are read-only databases named like |mydb_v1_2_0| that expose the most recent tags, and any
+// branches matching a pattern, of a database. They exist for clients that can't use the |mydb/rev| revision syntax.
+// They are not tracked by the provider: the set of snapshots of a database is resolved from its refs, and resolved
+// again only once the refs or the settings change, so they follow refs as they move.
+
+// ErrSnapshotDatabaseNameCollision is returned when two of the refs of a database map to the same snapshot name.
+var ErrSnapshotDatabaseNameCollision = errors.NewKind("snapshot database name %s is derived from both %s and %s; rename one of them or change %s and %s")
+
+// snapshotDatabaseSettings returns the number of tags and the branch pattern configured for snapshot databases.
+func snapshotDatabaseSettings() (numTags int64, branchPattern string) {
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.SnapshotDatabaseTags); ok {
+		numTags, _ = val.(int64)
+	}
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.SnapshotDatabaseBranches); ok {
+		branchPattern, _ = val.(string)
+	}
+	return numTags, branchPattern
+}
+
+// snapshotDatabasesEnabled returns whether any snapshot databases are configured.
+func snapshotDatabasesEnabled() bool {
+	numTags, branchPattern := snapshotDatabaseSettings()
+	return numTags > 0 || branchPattern != ""
+}
+
+// snapshotDatabaseName returns the name of the snapshot database for the ref named |refName| in the database |dbName|.
+// Any character that can't appear in an unquoted identifier is replaced with an underscore.
+func snapshotDatabaseName(dbName, refName string) string {
+	sb := strings.Builder{}
+	sb.WriteString(dbName)
+	sb.WriteRune('_')
+	for _, r := range refName {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
+
+// snapshotCache holds the snapshot databases last resolved for each database, keyed by its lower-cased name.
+type snapshotCache struct {
+	mu      sync.Mutex
+	entries map[string]snapshotCacheEntry
+}
+
+// snapshotCacheEntry is valid for as long as the database's storage, the root of its refs and the settings are the ones
+// it was resolved with.
+type snapshotCacheEntry struct {
+	ddb           *doltdb.DoltDB
+	root          hash.Hash
+	numTags       int64
+	branchPattern string
+	snapshots     []ReadOnlyDatabase
+}
+
+func newSnapshotCache() *snapshotCache {
+	return &snapshotCache{entries: make(map[string]snapshotCacheEntry)}
+}
+
+// snapshotDatabases returns the snapshot databases currently exposed for |srcDb|, sorted by name.
+func (p DoltDatabaseProvider) snapshotDatabases(ctx *sql.Context, srcDb dsess.SqlDatabase) ([]ReadOnlyDatabase, error) {
+	numTags, branchPattern := snapshotDatabaseSettings()
+	if numTags <= 0 && branchPattern == "" {
+		return nil, nil
+	}
+
+	if replicaDb, ok := srcDb.(ReadReplicaDatabase); ok {
+		srcDb = replicaDb.Database
+	}
+	db, ok := srcDb.(Database)
+	if !ok {
+		return nil, nil
+	}
+	ddb := db.DbData().Ddb
+	root, err := ddb.NomsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(db.Name())
+	p.snapshots.mu.Lock()
+	entry, ok := p.snapshots.entries[key]
+	p.snapshots.mu.Unlock()
+	if ok && entry.ddb == ddb && entry.root == root && entry.numTags == numTags && entry.branchPattern == branchPattern {
+		return entry.snapshots, nil
+	}
+
+	snapshots, err := resolveSnapshotDatabases(ctx, db, numTags, branchPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	p.snapshots.mu.Lock()
+	p.snapshots.entries[key] = snapshotCacheEntry{
+		ddb:           ddb,
+		root:          root,
+		numTags:       numTags,
+		branchPattern: branchPattern,
+		snapshots:     snapshots,
+	}
+	p.snapshots.mu.Unlock()
+	return snapshots, nil
+}
+
+// resolveSnapshotDatabases returns the snapshot databases of the |numTags| newest tags of |db| and of its branches
+// matching |branchPattern|, sorted by name. It returns ErrSnapshotDatabaseNameCollision if two refs map to the same name.
+func resolveSnapshotDatabases(ctx *sql.Context, db Database, numTags int64, branchPattern string) ([]ReadOnlyDatabase, error) {
+	ddb := db.DbData().Ddb
+
+	var snapshots []ReadOnlyDatabase
+	sources := make(map[string]string)
+	add := func(snapshot ReadOnlyDatabase, source string) error {
+		lowerName := strings.ToLower(snapshot.name)
+		if other, ok := sources[lowerName]; ok {
+			return ErrSnapshotDatabaseNameCollision.New(snapshot.name, other, source, dsess.SnapshotDatabaseTags, dsess.SnapshotDatabaseBranches)
+		}
+		sources[lowerName] = source
+		snapshots = append(snapshots, snapshot)
+		return nil
+	}
+
+	if numTags > 0 {
+		tags, err := newestTags(ctx, ddb, int(numTags))
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			snapshot, err := revisionDbForTag(ctx, db, tag)
+			if err != nil {
+				return nil, err
+			}
+			snapshot.name = snapshotDatabaseName(db.Name(), tag)
+			if err = add(snapshot, fmt.Sprintf("tag '%s'", tag)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if branchPattern != "" {
+		branches, err := ddb.GetBranches(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			if matched, err := path.Match(branchPattern, branch.GetPath()); err != nil {
+				return nil, err
+			} else if !matched {
+				continue
+			}
+
+			// Branch snapshots are pinned to the head of the branch at the time the name is resolved
+			cm, err := ddb.ResolveCommitRef(ctx, branch)
+			if err != nil {
+				return nil, err
+			}
+			h, err := cm.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			snapshot, err := revisionDbForCommit(ctx, db, h.String())
+			if err != nil {
+				return nil, err
+			}
+			snapshot.name = snapshotDatabaseName(db.Name(), branch.GetPath())
+			if err = add(snapshot, fmt.Sprintf("branch '%s'", branch.GetPath())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].name < snapshots[j].name
+	})
+
+	return snapshots, nil
+}
+
+// newestTags returns the names of the |n| most recently created tags in |ddb|.
+func newestTags(ctx *sql.Context, ddb *doltdb.DoltDB, n int) ([]string, error) {
+	tagRefs, err := ddb.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*doltdb.Tag, 0, len(tagRefs))
+	for _, r := range tagRefs {
+		tag, err := ddb.ResolveTag(ctx, r.(ref.TagRef))
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Meta.Timestamp > tags[j].Meta.Timestamp
+	})
+	if len(tags) > n {
+		tags = tags[:n]
+	}
+
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names, nil
+}
+
+// databaseForSnapshot returns the snapshot database with the name given, if there is one.
+func (p DoltDatabaseProvider) databaseForSnapshot(ctx *sql.Context, name string) (dsess.SqlDatabase, bool, error) {
+	if !snapshotDatabasesEnabled() || strings.Contains(name, dsess.DbRevisionDelimiter) {
+		return nil, false, nil
+	}
+
+	lowerName := strings.ToLower(name)
+	var candidates []dsess.SqlDatabase
+	p.mu.RLock()
+	for dbName, db := range p.databases {
+		if strings.HasPrefix(lowerName, dbName+"_") {
+			candidates = append(candidates, db)
+		}
+	}
+	p.mu.RUnlock()
+
+	for _, db := range candidates {
+		snapshots, err := p.snapshotDatabases(ctx, db)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, snapshot := range snapshots {
+			if strings.ToLower(snapshot.Name()) == lowerName {
+				return snapshot, true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
@@ -73,7 +73,11 @@ func GenerateCreateTableForeignKeyDefinition(fk doltdb.ForeignKey, sch, parentSc
 	if fk.OnUpdate != doltdb.ForeignKeyReferentialAction_DefaultAction {
 		onUpdate = fk.OnUpdate.String()
 	}
-	return sql.GenerateCreateTableForiegnKeyDefinition(fk.Name, fkCols, fk.ReferencedTableName, parentCols, onDelete, onUpdate)
+	def := sql.GenerateCreateTableForiegnKeyDefinition(fk.Name, fkCols, fk.ReferencedTableName, parentCols, onDelete, onUpdate)
+	if fk.NotEnforced {
+		def += " NOT ENFORCED"
+	}
+	return def
 }
 
 // GenerateCreateTableCheckConstraintClause returns check constraint clause definition for CREATE TABLE statement with indentation of 2 spaces
@@ -202,6 +206,9 @@ func AlterTableDropIndexStmt(tableName string, idx schema.Index) string {
 }
 
 func AlterTableAddForeignKeyStmt(fk doltdb.ForeignKey, sch, parentSch schema.Schema) string {
+	if fk.NotEnforced {
+		return createVirtualForeignKeyStmt(fk, sch, parentSch)
+	}
 	var b strings.Builder
 	b.WriteString("ALTER TABLE ")
 	b.WriteString(QuoteIdentifier(fk.TableName))
@@ -225,6 +232,24 @@ func AlterTableAddForeignKeyStmt(fk doltdb.ForeignKey, sch, parentSch schema.Sch
 	return b.String()
 }
 
+// createVirtualForeignKeyStmt returns the call of dolt_create_virtual_foreign_key creating |fk|, which can't be
+// declared with ALTER TABLE.
+func createVirtualForeignKeyStmt(fk doltdb.ForeignKey, sch, parentSch schema.Schema) string {
+	args := []string{quoteAndEscapeString(fk.TableName), quoteAndEscapeString(fk.Name)}
+	for _, tag := range fk.TableColumns {
+		c, _ := sch.GetAllCols().GetByTag(tag)
+		args = append(args, quoteAndEscapeString(c.Name))
+	}
+	var parentCols []string
+	for _, tag := range fk.ReferencedTableColumns {
+		c, _ := parentSch.GetAllCols().GetByTag(tag)
+		parentCols = append(parentCols, QuoteIdentifier(c.Name))
+	}
+	references := QuoteIdentifier(fk.ReferencedTableName) + "(" + strings.Join(parentCols, ",") + ")"
+	args = append(args, "'--references'", quoteAndEscapeString(references))
+	return "CALL DOLT_CREATE_VIRTUAL_FOREIGN_KEY(" + strings.Join(args, ", ") + ");"
+}
+
 func AlterTableDropForeignKeyStmt(fk doltdb.ForeignKey) string {
 	var b strings.Builder
 	b.WriteString("ALTER TABLE ")
@@ -91,7 +91,7 @@ func BasicSelectTests() []SelectTest {
 	var headCommitHash string
 	switch types.Format_Default {
 	case types.Format_DOLT:
-		headCommitHash = "a0gt4vif0b0bf19g89k87gs55qqlqpod"
+		headCommitHash = "m1gkfp9ii4hiqhpmgcfet5sojvopo4da"
 	case types.Format_LD_1:
 		headCommitHash = "73hc2robs4v0kt9taoe3m5hd49dmrgun"
 	}
@@ -0,0 +1,139 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil
+
+import (
+	"fmt"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// ParseIndexPredicate parses the WHERE clause |expr| of a partial index on a table with the schema |sch|. The
+// predicate must be a conjunction of comparisons between a column and a literal, and of IS NULL and IS NOT NULL
+// checks of a column.
+func ParseIndexPredicate(sch schema.Schema, expr string) (schema.IndexPredicate, error) {
+	stmt, err := sqlparser.Parse("SELECT * FROM t WHERE " + expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index predicate: %w", err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.Where == nil {
+		return nil, fmt.Errorf("invalid index predicate: %s", expr)
+	}
+
+	var pred schema.IndexPredicate
+	if err = appendPredicateTerms(sch, sel.Where.Expr, &pred); err != nil {
+		return nil, err
+	}
+	return pred, nil
+}
+
+// flippedPredicateOps are the ops of comparisons whose literal comes before their column.
+var flippedPredicateOps = map[schema.IndexPredicateOp]schema.IndexPredicateOp{
+	schema.IndexPredicateEq:    schema.IndexPredicateEq,
+	schema.IndexPredicateNotEq: schema.IndexPredicateNotEq,
+	schema.IndexPredicateLt:    schema.IndexPredicateGt,
+	schema.IndexPredicateLte:   schema.IndexPredicateGte,
+	schema.IndexPredicateGt:    schema.IndexPredicateLt,
+	schema.IndexPredicateGte:   schema.IndexPredicateLte,
+}
+
+func appendPredicateTerms(sch schema.Schema, expr sqlparser.Expr, pred *schema.IndexPredicate) error {
+	switch e := expr.(type) {
+	case *sqlparser.ParenExpr:
+		return appendPredicateTerms(sch, e.Expr, pred)
+
+	case *sqlparser.AndExpr:
+		if err := appendPredicateTerms(sch, e.Left, pred); err != nil {
+			return err
+		}
+		return appendPredicateTerms(sch, e.Right, pred)
+
+	case *sqlparser.IsExpr:
+		tag, err := predicateColumnTag(sch, e.Expr)
+		if err != nil {
+			return err
+		}
+		switch e.Operator {
+		case sqlparser.IsNullStr:
+			*pred = append(*pred, schema.IndexPredicateTerm{Tag: tag, Op: schema.IndexPredicateIsNull})
+		case sqlparser.IsNotNullStr:
+			*pred = append(*pred, schema.IndexPredicateTerm{Tag: tag, Op: schema.IndexPredicateIsNotNull})
+		default:
+			return fmt.Errorf("unsupported index predicate: %s", sqlparser.String(e))
+		}
+		return nil
+
+	case *sqlparser.ComparisonExpr:
+		op := schema.IndexPredicateOp(e.Operator)
+		if _, ok := flippedPredicateOps[op]; !ok {
+			return fmt.Errorf("unsupported index predicate: %s", sqlparser.String(e))
+		}
+		col, lit := e.Left, e.Right
+		if _, ok := col.(*sqlparser.ColName); !ok {
+			col, lit = lit, col
+			op = flippedPredicateOps[op]
+		}
+		tag, err := predicateColumnTag(sch, col)
+		if err != nil {
+			return err
+		}
+		v, err := predicateLiteral(lit)
+		if err != nil {
+			return err
+		}
+		*pred = append(*pred, schema.IndexPredicateTerm{Tag: tag, Op: op, Value: &v})
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported index predicate: %s", sqlparser.String(expr))
+	}
+}
+
+func predicateColumnTag(sch schema.Schema, expr sqlparser.Expr) (uint64, error) {
+	name, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		return 0, fmt.Errorf("index predicates must compare a column to a literal: %s", sqlparser.String(expr))
+	}
+	col, ok := sch.GetAllCols().GetByNameCaseInsensitive(name.Name.String())
+	if !ok {
+		return 0, fmt.Errorf("column `%s` does not exist for the table", name.Name.String())
+	}
+	return col.Tag, nil
+}
+
+func predicateLiteral(expr sqlparser.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *sqlparser.SQLVal:
+		switch e.Type {
+		case sqlparser.StrVal, sqlparser.IntVal, sqlparser.FloatVal:
+			return string(e.Val), nil
+		}
+	case sqlparser.BoolVal:
+		if e {
+			return "1", nil
+		}
+		return "0", nil
+	case *sqlparser.UnaryExpr:
+		if e.Operator == sqlparser.UMinusStr {
+			if v, ok := e.Expr.(*sqlparser.SQLVal); ok && (v.Type == sqlparser.IntVal || v.Type == sqlparser.FloatVal) {
+				return "-" + string(v.Val), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("index predicates must compare a column to a literal: %s", sqlparser.String(expr))
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// ParseRowExpression parses the SQL expression |expr| over the columns of the rows with the schema |sch|, so that it
+// can be evaluated on each of the rows without running a query. Columns are referenced by name, and only built-in
+// functions which aren't aggregates can be called.
+func ParseRowExpression(ctx *sql.Context, sch sql.Schema, expr string) (sql.Expression, error) {
+	stmt, err := sqlparser.Parse("SELECT " + expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, fmt.Errorf("invalid expression: %s", expr)
+	}
+	aliased, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, fmt.Errorf("invalid expression: %s", expr)
+	}
+
+	e, err := parse.ExprToExpression(ctx, aliased.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := function.NewRegistry()
+	e, _, err = transform.Expr(e, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+		switch e := e.(type) {
+		case *expression.UnresolvedColumn:
+			idx := sch.IndexOfColName(e.Name())
+			if idx < 0 {
+				return nil, transform.SameTree, sql.ErrColumnNotFound.New(e.Name())
+			}
+			col := sch[idx]
+			return expression.NewGetFieldWithTable(idx, col.Type, col.Source, col.Name, col.Nullable), transform.NewTree, nil
+		case *expression.UnresolvedFunction:
+			if e.IsAggregate || e.Window != nil {
+				return nil, transform.SameTree, fmt.Errorf("aggregate and window functions can't be used in expression: %s", expr)
+			}
+			fn, err := functions.Function(ctx, strings.ToLower(e.Name()))
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			f, err := fn.NewInstance(e.Arguments)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			return f, transform.NewTree, nil
+		default:
+			return e, transform.SameTree, nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !e.Resolved() {
+		return nil, fmt.Errorf("unsupported expression: %s", expr)
+	}
+	return e, nil
+}
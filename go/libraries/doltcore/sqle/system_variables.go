@@ -15,6 +15,8 @@
 package sqle
 
 import (
+	"math"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
@@ -94,6 +96,30 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 			Default:           int8(0),
 		},
+		{ // The epoch replication hooks push at. Remotes fenced at a higher epoch by dolt_promote() reject their pushes.
+			Name:              dsess.ReplicationEpoch,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.ReplicationEpoch, 0, math.MaxInt64, false),
+			Default:           int64(0),
+		},
+		{ // One in every this many chunks read is published to the replication remotes, for read replicas to warm up with.
+			Name:              dsess.ReplicateAccessSampleRate,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.ReplicateAccessSampleRate, 0, math.MaxInt32, false),
+			Default:           int64(0),
+		},
+		{ // If true, read replicas prefetch the chunks published by their primary after they pull.
+			Name:              dsess.ReadReplicaWarm,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.ReadReplicaWarm),
+			Default:           int8(0),
+		},
 		{ // If true, causes a Dolt commit to occur when you commit a transaction.
 			Name:              dsess.DoltCommitOnTransactionCommit,
 			Scope:             sql.SystemVariableScope_Both,
@@ -102,6 +128,14 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.DoltCommitOnTransactionCommit),
 			Default:           int8(0),
 		},
+		{ // If true, the Dolt commits made on transaction commit include the tables matched by dolt_ignore.
+			Name:              dsess.DoltCommitIncludeIgnored,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.DoltCommitIncludeIgnored),
+			Default:           int8(0),
+		},
 		{
 			Name:              dsess.TransactionsDisabledSysVar,
 			Scope:             sql.SystemVariableScope_Session,
@@ -166,6 +200,87 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.ShowBranchDatabases),
 			Default:           int8(0),
 		},
+		{
+			Name:              dsess.SnapshotDatabaseTags,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.SnapshotDatabaseTags, 0, math.MaxInt32, false),
+			Default:           int64(0),
+		},
+		{
+			Name:              dsess.SnapshotDatabaseBranches,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.SnapshotDatabaseBranches),
+			Default:           "",
+		},
+		{ // The number of leaf chunks read ahead of index and table scans, or 0 to disable prefetching.
+			Name:              dsess.ScanPrefetchDepth,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.ScanPrefetchDepth, 0, 1024, false),
+			Default:           int64(4),
+		},
+		{ // A timestamp or commit that every read of the session resolves through, like AS OF, until it's cleared.
+			Name:              dsess.SnapshotTime,
+			Scope:             sql.SystemVariableScope_Session,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.SnapshotTime),
+			Default:           "",
+		},
+		{ // The comma-separated branches, or db/branch names, that the sql-server runs the recurring events of.
+			Name:              dsess.EventSchedulerBranches,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.EventSchedulerBranches),
+			Default:           "",
+		},
+		{ // If true, updates that write a row identical to the existing row are skipped by the table writers.
+			Name:              dsess.ElideNoopWrites,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.ElideNoopWrites),
+			Default:           int8(1),
+		},
+		{ // The number of bytes of columns the projection cache may hold for scans of a few columns, or 0 to disable it.
+			Name:              dsess.ProjectionCacheSize,
+			Scope:             sql.SystemVariableScope_Global,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.ProjectionCacheSize, 0, math.MaxInt64, false),
+			Default:           int64(0),
+		},
+		{ // What ALTER TABLE ... MODIFY COLUMN does with a row whose value can't be converted to the new column type:
+			// abort the statement, write NULL, or leave the row out of the table and record a constraint violation.
+			Name:              dsess.AlterColumnOnError,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemEnumType(dsess.AlterColumnOnError, "abort", "null", "skip"),
+			Default:           "abort",
+		},
+		{ // The STR_TO_DATE format of strings converted to date and time columns by ALTER TABLE ... MODIFY COLUMN.
+			Name:              dsess.AlterColumnDatetimeFormat,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.AlterColumnDatetimeFormat),
+			Default:           "",
+		},
+		{ // The number of threads converting rows for ALTER TABLE ... MODIFY COLUMN, or 0 for one per CPU.
+			Name:              dsess.AlterColumnThreads,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.AlterColumnThreads, 0, 1024, false),
+			Default:           int64(0),
+		},
 	})
 }
 
@@ -176,3 +291,11 @@ func ReadReplicaForcePull() bool {
 	}
 	return forcePull == dsess.SysVarTrue
 }
+
+func ReadReplicaWarm() bool {
+	_, warm, ok := sql.SystemVariables.GetGlobal(dsess.ReadReplicaWarm)
+	if !ok {
+		panic("dolt system variables not loaded")
+	}
+	return warm == dsess.SysVarTrue
+}
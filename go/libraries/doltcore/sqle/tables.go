@@ -445,6 +445,12 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 		return nil, err
 	}
 
+	if iter, ok, err := t.cachedPartitionRows(ctx, table, partition); err != nil {
+		return nil, err
+	} else if ok {
+		return iter, nil
+	}
+
 	return partitionRows(ctx, table, t.sqlSch.Schema, t.projectedCols, partition)
 }
 
@@ -480,7 +486,7 @@ type doltTableInterface interface {
 }
 
 func (t *WritableDoltTable) setRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
-	return t.db.SetRoot(ctx, newRoot)
+	return t.db.setRootForSchemaChange(ctx, t.tableName, newRoot)
 }
 
 func (t *WritableDoltTable) IndexedAccess(lookup sql.IndexLookup) sql.IndexedTable {
@@ -508,6 +514,11 @@ func (t *WritableDoltTable) Inserter(ctx *sql.Context) sql.RowInserter {
 	return te
 }
 
+// auditWrites records a statement which wrote rows of the table in the audit log of the database.
+func (t *WritableDoltTable) auditWrites(ctx *sql.Context, operation string, rows int64) error {
+	return dsess.DSessFromSess(ctx.Session).AuditStatement(ctx, t.db, t.tableName, operation, rows)
+}
+
 func (t *WritableDoltTable) getTableEditor(ctx *sql.Context) (ed writer.TableWriter, err error) {
 	ds := dsess.DSessFromSess(ctx.Session)
 
@@ -531,6 +542,9 @@ func (t *WritableDoltTable) getTableEditor(ctx *sql.Context) (ed writer.TableWri
 	if err != nil {
 		return nil, err
 	}
+	if dsess.AuditLogEnabled(t.db.BaseName()) {
+		ed = writer.NewAuditingWriter(ed, t.auditWrites)
+	}
 	if batched {
 		t.ed = ed
 	}
@@ -602,7 +616,12 @@ func (t *WritableDoltTable) Truncate(ctx *sql.Context) (int, error) {
 		return 0, err
 	}
 
-	err = t.setRoot(ctx, newRoot)
+	err = t.db.SetRoot(ctx, newRoot)
+	if err != nil {
+		return 0, err
+	}
+
+	err = sess.AuditStatement(ctx, t.db, t.tableName, "TRUNCATE", int64(numOfRows))
 	if err != nil {
 		return 0, err
 	}
@@ -708,9 +727,22 @@ func (t *WritableDoltTable) Updater(ctx *sql.Context) sql.RowUpdater {
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
+	if elideNoopWrites(ctx) {
+		return writer.NewNoopElidingWriter(te, t.sqlSchema().Schema)
+	}
 	return te
 }
 
+// elideNoopWrites returns whether the session's dolt_elide_noop_writes is set, skipping the updates that don't
+// change the row they update.
+func elideNoopWrites(ctx *sql.Context) bool {
+	v, err := ctx.GetSessionVariable(ctx, dsess.ElideNoopWrites)
+	if err != nil {
+		return false
+	}
+	return v == dsess.SysVarTrue
+}
+
 // AutoIncrementSetter implements sql.AutoIncrementTable
 func (t *WritableDoltTable) AutoIncrementSetter(ctx *sql.Context) sql.AutoIncrementSetter {
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
@@ -789,6 +821,7 @@ func (t *DoltTable) GetDeclaredForeignKeys(ctx *sql.Context) ([]sql.ForeignKeyCo
 	}
 
 	declaredFks, _ := fkc.KeysForTable(t.tableName)
+	declaredFks = enforcedForeignKeys(declaredFks)
 	toReturn := make([]sql.ForeignKeyConstraint, len(declaredFks))
 
 	for i, fk := range declaredFks {
@@ -841,6 +874,7 @@ func (t *DoltTable) GetReferencedForeignKeys(ctx *sql.Context) ([]sql.ForeignKey
 	}
 
 	_, referencedByFk := fkc.KeysForTable(t.tableName)
+	referencedByFk = enforcedForeignKeys(referencedByFk)
 	toReturn := make([]sql.ForeignKeyConstraint, len(referencedByFk))
 
 	for i, fk := range referencedByFk {
@@ -880,6 +914,57 @@ func (t *DoltTable) GetReferencedForeignKeys(ctx *sql.Context) ([]sql.ForeignKey
 	return toReturn, nil
 }
 
+// GetVirtualForeignKeys returns the virtual foreign keys declared by this table. They aren't returned by
+// GetDeclaredForeignKeys, as the engine would enforce them.
+func (t *DoltTable) GetVirtualForeignKeys(ctx *sql.Context) ([]sql.ForeignKeyConstraint, error) {
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	declaredFks, _ := fkc.KeysForTable(t.tableName)
+	var toReturn []sql.ForeignKeyConstraint
+	for _, fk := range declaredFks {
+		if !fk.NotEnforced {
+			continue
+		}
+		parent, ok, err := root.GetTable(ctx, fk.ReferencedTableName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot find table %s referenced in foreign key %s", fk.ReferencedTableName, fk.Name)
+		}
+		parentSch, err := parent.GetSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cst, err := toForeignKeyConstraint(fk, t.db.Name(), t.sch, parentSch)
+		if err != nil {
+			return nil, err
+		}
+		toReturn = append(toReturn, cst)
+	}
+
+	return toReturn, nil
+}
+
+// enforcedForeignKeys filters the virtual foreign keys out of |fks|.
+func enforcedForeignKeys(fks []doltdb.ForeignKey) []doltdb.ForeignKey {
+	enforced := make([]doltdb.ForeignKey, 0, len(fks))
+	for _, fk := range fks {
+		if !fk.NotEnforced {
+			enforced = append(enforced, fk)
+		}
+	}
+	return enforced
+}
+
 // CreateIndexForForeignKey implements sql.ForeignKeyTable
 func (t *DoltTable) CreateIndexForForeignKey(ctx *sql.Context, idx sql.IndexDef) error {
 	return fmt.Errorf("no foreign key operations on a read-only table")
@@ -1169,6 +1254,10 @@ func (t *AlterableDoltTable) ShouldRewriteTable(
 	oldColumn *sql.Column,
 	newColumn *sql.Column,
 ) bool {
+	if t.canConvertColumn(oldSchema, newSchema, oldColumn, newColumn) {
+		// ModifyColumn rewrites the table itself, see convertColumn
+		return false
+	}
 	return t.isIncompatibleTypeChange(oldColumn, newColumn) ||
 		orderChanged(oldSchema, newSchema, oldColumn, newColumn) ||
 		isColumnDrop(oldSchema, newSchema) ||
@@ -1288,6 +1377,10 @@ func (t *AlterableDoltTable) RewriteInserter(
 	if isColumnDrop(oldSchema, newSchema) {
 		newSch = schema.CopyIndexes(oldSch, newSch)
 		droppedCol := getDroppedColumn(oldSchema, newSchema)
+		// the engine checks the foreign keys it enforces, but doesn't know about virtual foreign keys
+		if err = checkVirtualForeignKeysForDroppedColumn(ctx, ws.WorkingRoot(), t.tableName, oldSch, droppedCol.Name); err != nil {
+			return nil, err
+		}
 		for _, index := range newSch.Indexes().IndexesWithColumn(droppedCol.Name) {
 			_, err = newSch.Indexes().RemoveIndex(index.Name())
 			if err != nil {
@@ -1329,7 +1422,7 @@ func (t *AlterableDoltTable) RewriteInserter(
 				prefixLengths = nil
 			}
 
-			newSch.Indexes().AddIndexByColNames(
+			_, err = newSch.Indexes().AddIndexByColNames(
 				index.Name(),
 				colNames,
 				prefixLengths,
@@ -1338,7 +1431,12 @@ func (t *AlterableDoltTable) RewriteInserter(
 					IsSpatial:     index.IsSpatial(),
 					IsUserDefined: index.IsUserDefined(),
 					Comment:       index.Comment(),
+					Predicate:     index.Predicate(),
 				})
+			if err != nil && len(index.Predicate()) > 0 {
+				// the values of a partial index's predicate must remain valid for the modified column
+				return nil, err
+			}
 		}
 	} else {
 		newSch = schema.CopyIndexes(oldSch, newSch)
@@ -1544,6 +1642,35 @@ func (t *AlterableDoltTable) DropColumn(*sql.Context, string) error {
 	return fmt.Errorf("not implemented: AlterableDoltTable.DropColumn()")
 }
 
+// checkVirtualForeignKeysForDroppedColumn returns an error if the column |colName| of |tableName| is used by a
+// virtual foreign key.
+func checkVirtualForeignKeysForDroppedColumn(ctx *sql.Context, root *doltdb.RootValue, tableName string, sch schema.Schema, colName string) error {
+	col, ok := sch.GetAllCols().GetByName(colName)
+	if !ok {
+		return nil
+	}
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return err
+	}
+	declared, referenced := fkc.KeysForTable(tableName)
+	for _, fk := range declared {
+		for _, tag := range fk.TableColumns {
+			if fk.NotEnforced && tag == col.Tag {
+				return sql.ErrForeignKeyDropColumn.New(colName, fk.Name)
+			}
+		}
+	}
+	for _, fk := range referenced {
+		for _, tag := range fk.ReferencedTableColumns {
+			if fk.NotEnforced && tag == col.Tag {
+				return sql.ErrForeignKeyDropColumn.New(colName, fk.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // dropColumnData drops values for the specified column from the underlying storage layer
 func (t *AlterableDoltTable) dropColumnData(ctx *sql.Context, updatedTable *doltdb.Table, sch schema.Schema, columnName string) (*doltdb.Table, error) {
 	nomsRowData, err := updatedTable.GetNomsRowData(ctx)
@@ -1605,7 +1732,8 @@ func (t *AlterableDoltTable) dropColumnData(ctx *sql.Context, updatedTable *dolt
 }
 
 // ModifyColumn implements sql.AlterableTable. ModifyColumn operations are only used for operations that change only
-// the schema of a table, not the data. For those operations, |RewriteInserter| is used.
+// the schema of a table, not the data. For those operations, |RewriteInserter| is used, except for the column type
+// changes that ModifyColumn converts itself with convertColumn.
 func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, column *sql.Column, order *sql.ColumnOrder) error {
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
 		return err
@@ -1636,12 +1764,18 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 		return err
 	}
 
-	// TODO: move this logic into ShouldRewrite
 	if !existingCol.TypeInfo.Equals(col.TypeInfo) {
+		if types.IsFormat_DOLT(t.Format()) {
+			// the values of the column have to be converted, see canConvertColumn
+			return t.convertColumn(ctx, columnName, column)
+		}
+		// TODO: move this logic into ShouldRewrite
 		if existingCol.Kind != col.Kind {
 			panic("table cannot be modified in place")
 		}
 	}
+	// the rows aren't rewritten, so the column keeps the encoding its values are stored with
+	col.Dictionary, col.FixedPointDecimal = existingCol.Dictionary, existingCol.FixedPointDecimal
 
 	updatedTable, err := modifyColumn(ctx, table, existingCol, col, order)
 	if err != nil {
@@ -1801,6 +1935,7 @@ func (t *AlterableDoltTable) CreateIndex(ctx *sql.Context, idx sql.IndexDef) err
 		idx.Constraint == sql.IndexConstraint_Spatial,
 		true,
 		idx.Comment,
+		nil,
 		t.opts,
 	)
 	if err != nil {
@@ -2176,6 +2311,7 @@ func (t *AlterableDoltTable) CreateIndexForForeignKey(ctx *sql.Context, idx sql.
 		idx.Constraint == sql.IndexConstraint_Spatial,
 		false,
 		"",
+		nil,
 		t.opts,
 	)
 	if err != nil {
@@ -2602,6 +2738,10 @@ func findIndexWithPrefix(sch schema.Schema, prefixCols []string) (schema.Index,
 	colLen := len(prefixCols)
 	var indexesWithLen []idxWithLen
 	for _, idx := range indexes {
+		if len(idx.Predicate()) > 0 {
+			// partial indexes don't contain every row, so they can't back a foreign key
+			continue
+		}
 		idxCols := lowercaseSlice(idx.ColumnNames())
 		if ok, prefixCount := colsAreIndexSubset(prefixCols, idxCols); ok && prefixCount == colLen {
 			indexesWithLen = append(indexesWithLen, idxWithLen{idx, len(idxCols)})
@@ -275,6 +275,7 @@ func (t *TempTable) CreateIndex(ctx *sql.Context, idx sql.IndexDef) error {
 		idx.Constraint == sql.IndexConstraint_Spatial,
 		true,
 		idx.Comment,
+		nil,
 		t.opts,
 	)
 	if err != nil {
@@ -0,0 +1,107 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// StatementAuditor records a statement which wrote |rows| rows with |operation|, which is INSERT, UPDATE, DELETE or
+// REPLACE.
+type StatementAuditor func(ctx *sql.Context, operation string, rows int64) error
+
+// auditingWriter is a TableWriter that counts the rows written by each statement, and passes the count of each
+// statement which wrote rows to its auditor when the statement completes.
+type auditingWriter struct {
+	TableWriter
+	audit                     StatementAuditor
+	inserts, updates, deletes int64
+}
+
+var _ TableWriter = (*auditingWriter)(nil)
+
+// NewAuditingWriter returns a TableWriter that passes the number of rows each statement writes with |wr| to |audit|.
+// The rows a foreign key cascades to |wr| are counted with the statement that cascaded them.
+func NewAuditingWriter(wr TableWriter, audit StatementAuditor) TableWriter {
+	return &auditingWriter{TableWriter: wr, audit: audit}
+}
+
+// StatementBegin implements sql.EditOpenerCloser.
+func (w *auditingWriter) StatementBegin(ctx *sql.Context) {
+	w.reset()
+	w.TableWriter.StatementBegin(ctx)
+}
+
+// DiscardChanges implements sql.EditOpenerCloser.
+func (w *auditingWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	w.reset()
+	return w.TableWriter.DiscardChanges(ctx, errorEncountered)
+}
+
+// StatementComplete implements sql.EditOpenerCloser.
+func (w *auditingWriter) StatementComplete(ctx *sql.Context) error {
+	if err := w.TableWriter.StatementComplete(ctx); err != nil {
+		return err
+	}
+	inserts, updates, deletes := w.inserts, w.updates, w.deletes
+	w.reset()
+
+	var operation string
+	switch {
+	case inserts > 0 && deletes > 0:
+		operation = "REPLACE"
+	case inserts > 0:
+		// INSERT ... ON DUPLICATE KEY UPDATE statements also update rows
+		operation = "INSERT"
+	case updates > 0:
+		operation = "UPDATE"
+	case deletes > 0:
+		operation = "DELETE"
+	default:
+		return nil
+	}
+	return w.audit(ctx, operation, inserts+updates+deletes)
+}
+
+func (w *auditingWriter) reset() {
+	w.inserts, w.updates, w.deletes = 0, 0, 0
+}
+
+// Insert implements sql.RowInserter.
+func (w *auditingWriter) Insert(ctx *sql.Context, row sql.Row) error {
+	if err := w.TableWriter.Insert(ctx, row); err != nil {
+		return err
+	}
+	w.inserts++
+	return nil
+}
+
+// Update implements sql.RowUpdater.
+func (w *auditingWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) error {
+	if err := w.TableWriter.Update(ctx, oldRow, newRow); err != nil {
+		return err
+	}
+	w.updates++
+	return nil
+}
+
+// Delete implements sql.RowDeleter.
+func (w *auditingWriter) Delete(ctx *sql.Context, row sql.Row) error {
+	if err := w.TableWriter.Delete(ctx, row); err != nil {
+		return err
+	}
+	w.deletes++
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"bytes"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// noopElidingWriter is a TableWriter that skips the updates that write a row identical to the row they replace,
+// so that they don't rewrite the row's tuples or show up as modifications in diffs.
+type noopElidingWriter struct {
+	TableWriter
+	sch sql.Schema
+}
+
+var _ TableWriter = noopElidingWriter{}
+
+// NewNoopElidingWriter returns a TableWriter that skips the updates of |wr| that don't change the row. |sch| is the
+// schema of the rows written.
+func NewNoopElidingWriter(wr TableWriter, sch sql.Schema) TableWriter {
+	return noopElidingWriter{TableWriter: wr, sch: sch}
+}
+
+// Update implements sql.RowUpdater.
+func (w noopElidingWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) error {
+	if identicalRows(w.sch, oldRow, newRow) {
+		return nil
+	}
+	return w.TableWriter.Update(ctx, oldRow, newRow)
+}
+
+// identicalRows returns whether |left| and |right| store the same values. Unlike sql.Row.Equals, strings must match
+// exactly, as a change in case or trailing spaces is a change to the row even when the collation compares the
+// values as equal.
+func identicalRows(sch sql.Schema, left, right sql.Row) bool {
+	if len(left) != len(right) || len(left) != len(sch) {
+		return false
+	}
+	for i := range left {
+		if !identicalValues(sch[i].Type, left[i], right[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// identicalFields returns whether |left| and |right| store the same values in the fields of |mapping|, which maps
+// the fields of an index to the fields of rows of schema |sch|.
+func identicalFields(sch sql.Schema, mapping val.OrdinalMapping, left, right sql.Row) bool {
+	if sch == nil {
+		return false
+	}
+	for to := range mapping {
+		from := mapping.MapOrdinal(to)
+		if !identicalValues(sch[from].Type, left[from], right[from]) {
+			return false
+		}
+	}
+	return true
+}
+
+func identicalValues(typ sql.Type, l, r interface{}) bool {
+	if l == nil || r == nil {
+		return l == r
+	}
+	switch lv := l.(type) {
+	case string:
+		rv, ok := r.(string)
+		return ok && lv == rv
+	case []byte:
+		rv, ok := r.([]byte)
+		return ok && bytes.Equal(lv, rv)
+	default:
+		cmp, err := typ.Compare(l, r)
+		return err == nil && cmp == 0
+	}
+}
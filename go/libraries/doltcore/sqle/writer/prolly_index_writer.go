@@ -46,6 +46,7 @@ func getPrimaryProllyWriter(ctx context.Context, t *doltdb.Table, sqlSch sql.Sch
 		keyMap: keyMap,
 		valBld: val.NewTupleBuilder(valDesc),
 		valMap: valMap,
+		sqlSch: sqlSch,
 	}, nil
 }
 
@@ -93,6 +94,9 @@ type prollyIndexWriter struct {
 
 	valBld *val.TupleBuilder
 	valMap val.OrdinalMapping
+
+	// sqlSch is the schema of the rows written
+	sqlSch sql.Schema
 }
 
 var _ indexWriter = prollyIndexWriter{}
@@ -139,14 +143,10 @@ func (m prollyIndexWriter) Insert(ctx context.Context, sqlRow sql.Row) error {
 		return err
 	}
 
-	for to := range m.valMap {
-		from := m.valMap.MapOrdinal(to)
-		if err := index.PutField(ctx, m.mut.NodeStore(), m.valBld, to, sqlRow[from]); err != nil {
-			return err
-		}
+	v, err := m.valueFromRow(ctx, sqlRow)
+	if err != nil {
+		return err
 	}
-	v := m.valBld.Build(sharePool)
-
 	return m.mut.Put(ctx, k, v)
 }
 
@@ -160,13 +160,14 @@ func (m prollyIndexWriter) Delete(ctx context.Context, sqlRow sql.Row) error {
 }
 
 func (m prollyIndexWriter) Update(ctx context.Context, oldRow sql.Row, newRow sql.Row) error {
+	if identicalFields(m.sqlSch, m.keyMap, oldRow, newRow) {
+		return m.updateValue(ctx, oldRow, newRow)
+	}
+
 	oldKey, err := m.keyFromRow(ctx, oldRow)
 	if err != nil {
 		return err
 	}
-
-	// todo(andy): we can skip building, deleting |oldKey|
-	//  if we know the key fields are unchanged
 	if err := m.mut.Delete(ctx, oldKey); err != nil {
 		return err
 	}
@@ -184,17 +185,71 @@ func (m prollyIndexWriter) Update(ctx context.Context, oldRow sql.Row, newRow sq
 		return m.uniqueKeyError(ctx, keyStr, newKey, true)
 	}
 
-	for to := range m.valMap {
-		from := m.valMap.MapOrdinal(to)
-		if err = index.PutField(ctx, m.mut.NodeStore(), m.valBld, to, newRow[from]); err != nil {
-			return err
+	v, err := m.valueFromRow(ctx, newRow)
+	if err != nil {
+		return err
+	}
+	return m.mut.Put(ctx, newKey, v)
+}
+
+// updateValue updates the value of a row whose key is unchanged. When the fields which changed keep their size, as
+// they do for counters and other fixed width columns, they're overwritten in a copy of the stored value instead of
+// encoding the whole row again.
+func (m prollyIndexWriter) updateValue(ctx context.Context, oldRow sql.Row, newRow sql.Row) error {
+	key, err := m.keyFromRow(ctx, newRow)
+	if err != nil {
+		return err
+	}
+
+	var stored val.Tuple
+	err = m.mut.Get(ctx, key, func(_, v val.Tuple) error {
+		stored = v
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if stored != nil {
+		inPlace := true
+		for to := range m.valMap {
+			from := m.valMap.MapOrdinal(to)
+			if identicalValues(m.sqlSch[from].Type, oldRow[from], newRow[from]) {
+				continue
+			}
+			if newRow[from] == nil {
+				inPlace = false
+				break
+			}
+			if err = index.PutField(ctx, m.mut.NodeStore(), m.valBld, to, newRow[from]); err != nil {
+				m.valBld.Recycle()
+				return err
+			}
 		}
+		if inPlace {
+			if v, ok := m.valBld.BuildInPlace(sharePool, stored); ok {
+				return m.mut.Put(ctx, key, v)
+			}
+		}
+		m.valBld.Recycle()
 	}
-	v := m.valBld.Build(sharePool)
 
-	return m.mut.Put(ctx, newKey, v)
+	v, err := m.valueFromRow(ctx, newRow)
+	if err != nil {
+		return err
+	}
+	return m.mut.Put(ctx, key, v)
 }
 
+func (m prollyIndexWriter) valueFromRow(ctx context.Context, sqlRow sql.Row) (val.Tuple, error) {
+	for to := range m.valMap {
+		from := m.valMap.MapOrdinal(to)
+		if err := index.PutField(ctx, m.mut.NodeStore(), m.valBld, to, sqlRow[from]); err != nil {
+			return nil, err
+		}
+	}
+	return m.valBld.Build(sharePool), nil
+}
 func (m prollyIndexWriter) Commit(ctx context.Context) error {
 	return m.mut.Checkpoint(ctx)
 }
@@ -263,6 +318,12 @@ type prollySecondaryIndexWriter struct {
 	pkMap val.OrdinalMapping
 	// pkBld builds key tuples for primary key index
 	pkBld *val.TupleBuilder
+
+	// filter matches the keys of the rows in a partial index
+	filter *index.PartialIndexFilter
+
+	// sqlSch is the schema of the rows written
+	sqlSch sql.Schema
 }
 
 var _ indexWriter = prollySecondaryIndexWriter{}
@@ -323,10 +384,24 @@ func (m prollySecondaryIndexWriter) Insert(ctx context.Context, sqlRow sql.Row)
 	if err != nil {
 		return err
 	}
+	if !m.filter.Matches(k) {
+		return nil
+	}
 	return m.mut.Put(ctx, k, val.EmptyTuple)
 }
 
 func (m prollySecondaryIndexWriter) checkForUniqueKeyErr(ctx context.Context, sqlRow sql.Row) error {
+	if m.filter != nil {
+		// rows outside of a partial index can't collide with the rows in it
+		k, err := m.keyFromRow(ctx, sqlRow)
+		if err != nil {
+			return err
+		}
+		if !m.filter.Matches(k) {
+			return nil
+		}
+	}
+
 	ns := m.mut.NodeStore()
 	for to := range m.keyMap[:m.idxCols] {
 		from := m.keyMap.MapOrdinal(to)
@@ -382,13 +457,15 @@ func (m prollySecondaryIndexWriter) Delete(ctx context.Context, sqlRow sql.Row)
 }
 
 func (m prollySecondaryIndexWriter) Update(ctx context.Context, oldRow sql.Row, newRow sql.Row) error {
+	if identicalFields(m.sqlSch, m.keyMap, oldRow, newRow) {
+		// the update doesn't touch this index
+		return nil
+	}
+
 	oldKey, err := m.keyFromRow(ctx, oldRow)
 	if err != nil {
 		return err
 	}
-
-	// todo(andy): we can skip building, deleting |oldKey|
-	//  if we know the key fields are unchanged
 	if err := m.mut.Delete(ctx, oldKey); err != nil {
 		return err
 	}
@@ -403,6 +480,9 @@ func (m prollySecondaryIndexWriter) Update(ctx context.Context, oldRow sql.Row,
 	if err != nil {
 		return err
 	}
+	if !m.filter.Matches(newKey) {
+		return nil
+	}
 	return m.mut.Put(ctx, newKey, val.EmptyTuple)
 }
 
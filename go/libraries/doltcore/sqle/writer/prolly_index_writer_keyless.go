@@ -192,6 +192,9 @@ type prollyKeylessSecondaryWriter struct {
 	prefixBld *val.TupleBuilder
 	hashBld   *val.TupleBuilder
 	keyMap    val.OrdinalMapping
+
+	// filter matches the keys of the rows in a partial index
+	filter *index.PartialIndexFilter
 }
 
 var _ indexWriter = prollyKeylessSecondaryWriter{}
@@ -255,6 +258,10 @@ func (writer prollyKeylessSecondaryWriter) Insert(ctx context.Context, sqlRow sq
 	}
 	writer.keyBld.PutHash128(len(writer.keyBld.Desc.Types)-1, hashId.GetField(0))
 	indexKey := writer.keyBld.Build(sharePool)
+	if !writer.filter.Matches(indexKey) {
+		writer.prefixBld.Recycle()
+		return nil
+	}
 
 	if writer.unique {
 		prefixKey := writer.prefixBld.Build(sharePool)
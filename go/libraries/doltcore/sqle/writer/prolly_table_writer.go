@@ -75,6 +75,11 @@ func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, sqlSch
 		keyMap, _ := ordinalMappingsFromSchema(sqlSch, def.Schema())
 		keyDesc, _ := idxMap.Descriptors()
 
+		filter, err := index.NewPartialIndexFilter(ctx, def, keyDesc)
+		if err != nil {
+			return nil, err
+		}
+
 		// mapping from secondary index key to primary key
 		pkMap := makeIndexToIndexMapping(def.Schema().GetPKCols(), sch.GetPKCols())
 		writers[defName] = prollySecondaryIndexWriter{
@@ -87,6 +92,8 @@ func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, sqlSch
 			keyBld:        val.NewTupleBuilder(keyDesc),
 			pkMap:         pkMap,
 			pkBld:         val.NewTupleBuilder(pkDesc),
+			filter:        filter,
+			sqlSch:        sqlSch,
 		}
 	}
 
@@ -113,6 +120,10 @@ func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, sqlS
 
 		keyMap, _ := ordinalMappingsFromSchema(sqlSch, def.Schema())
 		keyDesc, _ := m.Descriptors()
+		filter, err := index.NewPartialIndexFilter(ctx, def, keyDesc)
+		if err != nil {
+			return nil, err
+		}
 
 		writers[defName] = prollyKeylessSecondaryWriter{
 			name:          defName,
@@ -125,6 +136,7 @@ func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, sqlS
 			prefixBld:     val.NewTupleBuilder(keyDesc.PrefixDesc(def.Count())),
 			hashBld:       val.NewTupleBuilder(val.NewTupleDescriptor(val.Type{Enc: val.Hash128Enc})),
 			keyMap:        keyMap,
+			filter:        filter,
 		}
 	}
 
@@ -0,0 +1,117 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creation
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// UseFixedPointDecimals switches the DECIMAL columns of |tbl| that can be stored with the fixed-point decimal
+// encoding to it, and rewrites the rows of the table for it. It returns whether any column was switched. Only non
+// primary key columns with a precision of at most val.MaxDecimal64Precision can be switched, and keyless tables, whose
+// rows are addressed by the hash of their values, are left as they are. Index keys always hold decimals in their
+// plain encoding, so secondary indexes aren't rewritten. Versions of dolt that predate the encoding can't read the
+// tables it's used for.
+func UseFixedPointDecimals(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, bool, error) {
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return nil, false, fmt.Errorf("fixed-point decimals are only supported in the %s storage format", types.Format_DOLT.VersionString())
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if schema.IsKeyless(sch) {
+		return tbl, false, nil
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	switched := false
+	for i, col := range cols {
+		if canUseFixedPointDecimal(col) && !col.FixedPointDecimal {
+			cols[i].FixedPointDecimal = true
+			switched = true
+		}
+	}
+	if !switched {
+		return tbl, false, nil
+	}
+	newSch, err := schema.NewSchema(schema.NewColCollection(cols...), sch.GetPkOrdinals(), sch.GetCollation(), sch.Indexes(), sch.Checks())
+	if err != nil {
+		return nil, false, err
+	}
+	m, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	primary := durable.ProllyMapFromIndex(m)
+	_, from := primary.Descriptors()
+	to := newSch.GetValueDescriptor()
+	p := primary.Pool()
+	mut := primary.Mutate()
+	iter, err := primary.IterAll(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, false, err
+		}
+		newVal, ok, err := val.RewriteEncodings(p, from, to, v)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			if err = mut.Put(ctx, k, newVal); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	primary, err = mut.Map(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tbl, err = tbl.UpdateSchema(ctx, newSch)
+	if err != nil {
+		return nil, false, err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(primary))
+	if err != nil {
+		return nil, false, err
+	}
+	return tbl, true, nil
+}
+
+// canUseFixedPointDecimal returns whether the values of |col| can be stored with the fixed-point decimal encoding.
+func canUseFixedPointDecimal(col schema.Column) bool {
+	if col.IsPartOfPK {
+		return false
+	}
+	dt, ok := col.TypeInfo.ToSqlType().(sql.DecimalType)
+	return ok && int(dt.Precision()) <= val.MaxDecimal64Precision
+}
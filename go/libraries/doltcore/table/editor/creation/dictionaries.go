@@ -0,0 +1,189 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// SetColumnDictionary dictionary encodes the column |colName| of |tbl| with the |maxValues| most frequent strings of
+// the column, or stores its strings in full again if |maxValues| is zero. The rows of the table are rewritten for
+// the new encoding. Only non primary key CHAR and VARCHAR columns of keyed tables in the new storage format can be
+// dictionary encoded: the rows of keyless tables are addressed by the hash of their values, which must not depend on
+// how the values are encoded.
+func SetColumnDictionary(ctx context.Context, tbl *doltdb.Table, colName string, maxValues int) (*doltdb.Table, error) {
+	col, err := CheckColumnDictionary(ctx, tbl, colName, maxValues)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	primary := durable.ProllyMapFromIndex(m)
+
+	col.Dictionary = nil
+	if maxValues > 0 {
+		field := sch.GetNonPKCols().TagToIdx[col.Tag]
+		col.Dictionary, err = mostFrequentStrings(ctx, primary, field, maxValues)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	for i := range cols {
+		if cols[i].Tag == col.Tag {
+			cols[i] = col
+		}
+	}
+	newSch, err := schema.NewSchema(schema.NewColCollection(cols...), sch.GetPkOrdinals(), sch.GetCollation(), sch.Indexes(), sch.Checks())
+	if err != nil {
+		return nil, err
+	}
+
+	// keys and secondary indexes never hold dictionary codes, only the values of the primary index are rewritten
+	_, from := primary.Descriptors()
+	to := newSch.GetValueDescriptor()
+	p := primary.Pool()
+
+	mut := primary.Mutate()
+	iter, err := primary.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		newVal, ok, err := val.RewriteEncodings(p, from, to, v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if err = mut.Put(ctx, k, newVal); err != nil {
+				return nil, err
+			}
+		}
+	}
+	primary, err = mut.Map(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.UpdateSchema(ctx, newSch)
+	if err != nil {
+		return nil, err
+	}
+	return tbl.UpdateRows(ctx, durable.IndexFromProllyMap(primary))
+}
+
+// CheckColumnDictionary returns the column |colName| of |tbl| if SetColumnDictionary can set its dictionary to
+// |maxValues| strings, or the reason it can't.
+func CheckColumnDictionary(ctx context.Context, tbl *doltdb.Table, colName string, maxValues int) (schema.Column, error) {
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return schema.Column{}, fmt.Errorf("column dictionaries are only supported in the %s storage format", types.Format_DOLT.VersionString())
+	}
+	if maxValues < 0 || maxValues > val.MaxStringDictSize {
+		return schema.Column{}, fmt.Errorf("column dictionaries can have between 0 and %d values", val.MaxStringDictSize)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return schema.Column{}, err
+	}
+	if schema.IsKeyless(sch) {
+		return schema.Column{}, fmt.Errorf("columns of keyless tables cannot be dictionary encoded")
+	}
+	col, ok := sch.GetNonPKCols().LowerNameToCol[strings.ToLower(colName)]
+	if !ok {
+		if _, ok = sch.GetPKCols().LowerNameToCol[strings.ToLower(colName)]; ok {
+			return schema.Column{}, fmt.Errorf("primary key column %s cannot be dictionary encoded", colName)
+		}
+		return schema.Column{}, fmt.Errorf("column %s not found", colName)
+	}
+	if typ := col.TypeInfo.ToSqlType().Type(); typ != query.Type_CHAR && typ != query.Type_VARCHAR {
+		return schema.Column{}, fmt.Errorf("column %s is not a CHAR or VARCHAR column", col.Name)
+	}
+	return col, nil
+}
+
+// mostFrequentStrings returns up to |n| of the most frequent strings of the value |field| of |primary|, most frequent
+// first.
+func mostFrequentStrings(ctx context.Context, primary prolly.Map, field, n int) ([]string, error) {
+	_, vd := primary.Descriptors()
+	counts := make(map[string]int)
+	iter, err := primary.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		var s string
+		var ok bool
+		if vd.Types[field].Enc == val.StringDictEnc {
+			s, ok, err = vd.GetDictString(field, v)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			s, ok = vd.GetString(field, v)
+		}
+		if ok {
+			counts[s]++
+		}
+	}
+
+	strs := make([]string, 0, len(counts))
+	for s := range counts {
+		strs = append(strs, s)
+	}
+	sort.Slice(strs, func(i, j int) bool {
+		if counts[strs[i]] != counts[strs[j]] {
+			return counts[strs[i]] > counts[strs[j]]
+		}
+		return strs[i] < strs[j]
+	})
+	if len(strs) > n {
+		strs = strs[:n]
+	}
+	return strs, nil
+}
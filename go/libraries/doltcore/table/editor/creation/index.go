@@ -53,6 +53,7 @@ func CreateIndex(
 	isSpatial bool,
 	isUserDefined bool,
 	comment string,
+	predicate schema.IndexPredicate,
 	opts editor.Options,
 ) (*CreateIndexReturn, error) {
 	sch, err := table.GetSchema(ctx)
@@ -85,16 +86,21 @@ func CreateIndex(
 		return nil, fmt.Errorf("invalid index name `%s` as they must match the regular expression %s", indexName, doltdb.IndexNameRegexStr)
 	}
 
-	// if an index was already created for the column set but was not generated by the user then we replace it
-	existingIndex, ok := sch.Indexes().GetIndexByColumnNames(realColNames...)
-	if ok && !existingIndex.IsUserDefined() {
-		_, err = sch.Indexes().RemoveIndex(existingIndex.Name())
-		if err != nil {
-			return nil, err
-		}
-		table, err = table.DeleteIndexRowData(ctx, existingIndex.Name())
-		if err != nil {
-			return nil, err
+	// if an index was already created for the column set but was not generated by the user then we replace it.
+	// partial indexes never replace another index, as they can't back a foreign key.
+	var existingIndex schema.Index
+	if len(predicate) == 0 {
+		var ok bool
+		existingIndex, ok = sch.Indexes().GetIndexByColumnNames(realColNames...)
+		if ok && !existingIndex.IsUserDefined() {
+			_, err = sch.Indexes().RemoveIndex(existingIndex.Name())
+			if err != nil {
+				return nil, err
+			}
+			table, err = table.DeleteIndexRowData(ctx, existingIndex.Name())
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -108,6 +114,7 @@ func CreateIndex(
 			IsSpatial:     isSpatial,
 			IsUserDefined: isUserDefined,
 			Comment:       comment,
+			Predicate:     predicate,
 		},
 	)
 	if err != nil {
@@ -166,6 +173,39 @@ func BuildSecondaryIndex(ctx context.Context, tbl *doltdb.Table, idx schema.Inde
 	}
 }
 
+// RebuildSecondaryIndexes rebuilds the secondary indexes of |tbl| named |indexNames| from the table's rows, or every
+// secondary index of the table if no names are given. It returns the table with the rebuilt indexes.
+func RebuildSecondaryIndexes(ctx context.Context, tbl *doltdb.Table, indexNames []string, opts editor.Options) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []schema.Index
+	if len(indexNames) == 0 {
+		indexes = sch.Indexes().AllIndexes()
+	}
+	for _, name := range indexNames {
+		idx, ok := sch.Indexes().GetByNameCaseInsensitive(name)
+		if !ok {
+			return nil, fmt.Errorf("the index `%s` does not exist", name)
+		}
+		indexes = append(indexes, idx)
+	}
+
+	for _, idx := range indexes {
+		rows, err := BuildSecondaryIndex(ctx, tbl, idx, opts)
+		if err != nil {
+			return nil, err
+		}
+		tbl, err = tbl.SetIndexRows(ctx, idx.Name(), rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tbl, nil
+}
+
 // BuildSecondaryProllyIndex builds secondary index data for the given primary
 // index row data |primary|. |sch| is the current schema of the table.
 func BuildSecondaryProllyIndex(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore, sch schema.Schema, idx schema.Index, primary prolly.Map) (durable.Index, error) {
@@ -189,6 +229,10 @@ func BuildSecondaryProllyIndex(ctx context.Context, vrw types.ValueReadWriter, n
 	p := primary.Pool()
 	mut := secondary.Mutate()
 	secondaryBld := index.NewSecondaryKeyBuilder(sch, idx, secondary.KeyDesc(), p)
+	filter, err := index.NewPartialIndexFilter(ctx, idx, secondary.KeyDesc())
+	if err != nil {
+		return nil, err
+	}
 
 	iter, err := primary.IterAll(ctx)
 	if err != nil {
@@ -204,7 +248,13 @@ func BuildSecondaryProllyIndex(ctx context.Context, vrw types.ValueReadWriter, n
 			return nil, err
 		}
 
-		idxKey := secondaryBld.SecondaryKeyFromRow(k, v)
+		idxKey, err := secondaryBld.SecondaryKeyFromRow(k, v)
+		if err != nil {
+			return nil, err
+		}
+		if !filter.Matches(idxKey) {
+			continue
+		}
 		idxVal := val.EmptyTuple
 		if err = mut.Put(ctx, idxKey, idxVal); err != nil {
 			return nil, err
@@ -242,6 +292,10 @@ func BuildUniqueProllyIndex(ctx context.Context, vrw types.ValueReadWriter, ns t
 
 	prefixDesc := secondary.KeyDesc().PrefixDesc(idx.Count())
 	secondaryBld := index.NewSecondaryKeyBuilder(sch, idx, secondary.KeyDesc(), p)
+	filter, err := index.NewPartialIndexFilter(ctx, idx, secondary.KeyDesc())
+	if err != nil {
+		return nil, err
+	}
 
 	mut := secondary.Mutate()
 	for {
@@ -253,10 +307,13 @@ func BuildUniqueProllyIndex(ctx context.Context, vrw types.ValueReadWriter, ns t
 			return nil, err
 		}
 
-		idxKey := secondaryBld.SecondaryKeyFromRow(k, v)
+		idxKey, err := secondaryBld.SecondaryKeyFromRow(k, v)
+		if err != nil {
+			return nil, err
+		}
 		idxVal := val.EmptyTuple
 
-		if prefixDesc.HasNulls(idxKey) {
+		if prefixDesc.HasNulls(idxKey) || !filter.Matches(idxKey) {
 			continue
 		}
 
@@ -0,0 +1,510 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	fb "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+const (
+	// DefaultBatchSize is the number of rows in each record batch written by an ArrowWriter.
+	DefaultBatchSize = 64 * 1024
+	// MaxBatchBytes is the number of bytes of values after which an ArrowWriter writes a record batch, even if it has
+	// fewer than DefaultBatchSize rows. It keeps the batches of wide rows under the default message size limit of gRPC
+	// clients, for Arrow Flight.
+	MaxBatchBytes = 2 * 1024 * 1024
+)
+
+// The parts of the Arrow columnar format spec used by the writer. See
+// https://arrow.apache.org/docs/format/Columnar.html#serialization-and-interprocess-communication-ipc
+const (
+	metadataVersionV5 = 4
+
+	messageHeaderSchema      = 1
+	messageHeaderRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeBinary        = 4
+	typeUtf8          = 5
+	typeDate          = 8
+	typeTimestamp     = 10
+
+	precisionDouble  = 2
+	dateUnitDay      = 0
+	dateUnitMilli    = 1
+	timeUnitMicro    = 2
+	continuationMark = 0xFFFFFFFF
+)
+
+// MessageWriter writes the messages of an Arrow stream. Each message is the flatbuffer Message |meta|, followed by
+// the buffers of the message in |body|.
+type MessageWriter interface {
+	WriteMessage(meta, body []byte) error
+	// Close is called after the last message of the stream.
+	Close() error
+}
+
+// ArrowWriter is a table.SqlRowWriter that writes rows as an Arrow stream. Rows are gathered into columns, and written
+// as a record batch every DefaultBatchSize rows, so that readers like pyarrow, pandas and Polars can load the results
+// without parsing them row by row.
+//
+// Integers, floats, dates, datetimes and binary strings are written as the matching Arrow types. Every other type is
+// written as a UTF-8 string, in the same form as the other result formats.
+type ArrowWriter struct {
+	msgs      MessageWriter
+	sch       sql.Schema
+	cols      []*column
+	rows      int
+	batchSize int
+}
+
+var _ table.SqlRowWriter = (*ArrowWriter)(nil)
+
+// NewArrowSqlWriter returns a writer of the rows of |sch| to |wr| in the Arrow IPC streaming format, and writes the
+// schema of the stream.
+func NewArrowSqlWriter(wr io.WriteCloser, sch sql.Schema) (*ArrowWriter, error) {
+	return NewArrowMessageWriter(ipcStreamWriter{wr}, sch)
+}
+
+// NewArrowMessageWriter returns a writer of the rows of |sch| as the messages of an Arrow stream to |msgs|, and writes
+// the schema of the stream.
+func NewArrowMessageWriter(msgs MessageWriter, sch sql.Schema) (*ArrowWriter, error) {
+	aw := &ArrowWriter{
+		msgs:      msgs,
+		sch:       sch,
+		cols:      newColumns(sch),
+		batchSize: DefaultBatchSize,
+	}
+	if err := msgs.WriteMessage(schemaMessage(sch, aw.cols), nil); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+// EncapsulatedSchema returns the schema of the Arrow stream of the rows of |sch|, as an encapsulated IPC message.
+func EncapsulatedSchema(sch sql.Schema) []byte {
+	return encapsulate(schemaMessage(sch, newColumns(sch)), nil)
+}
+
+func newColumns(sch sql.Schema) []*column {
+	cols := make([]*column, len(sch))
+	for i, col := range sch {
+		cols[i] = newColumn(col.Type)
+	}
+	return cols
+}
+
+// WriteSqlRow implements table.SqlRowWriter.
+func (aw *ArrowWriter) WriteSqlRow(ctx context.Context, r sql.Row) error {
+	if len(r) != len(aw.cols) {
+		return fmt.Errorf("row has %d columns, expected %d", len(r), len(aw.cols))
+	}
+	for i, v := range r {
+		if err := aw.cols[i].append(v); err != nil {
+			return fmt.Errorf("error writing column %s: %w", aw.sch[i].Name, err)
+		}
+	}
+	aw.rows++
+	if aw.rows >= aw.batchSize || aw.batchBytes() >= MaxBatchBytes {
+		return aw.flush()
+	}
+	return nil
+}
+
+// batchBytes returns the number of bytes of the values of the rows gathered.
+func (aw *ArrowWriter) batchBytes() int {
+	n := 0
+	for _, col := range aw.cols {
+		n += len(col.values) + len(col.offsets)
+	}
+	return n
+}
+
+// Close implements table.SqlRowWriter. It writes the remaining rows and the end of the stream.
+func (aw *ArrowWriter) Close(ctx context.Context) error {
+	if err := aw.flush(); err != nil {
+		return err
+	}
+	return aw.msgs.Close()
+}
+
+// flush writes the rows gathered as a record batch.
+func (aw *ArrowWriter) flush() error {
+	if aw.rows == 0 {
+		return nil
+	}
+
+	var body []byte
+	var nodes, buffers [][2]int64
+	for _, col := range aw.cols {
+		nodes = append(nodes, [2]int64{int64(col.length), int64(col.nulls)})
+		for _, buf := range col.buffers() {
+			buffers = append(buffers, [2]int64{int64(len(body)), int64(len(buf))})
+			body = append(body, buf...)
+			body = append(body, make([]byte, padding(len(body)))...)
+		}
+		col.reset()
+	}
+
+	meta := recordBatchMessage(int64(aw.rows), nodes, buffers, int64(len(body)))
+	aw.rows = 0
+	return aw.msgs.WriteMessage(meta, body)
+}
+
+// ipcStreamWriter writes the messages of an Arrow stream to a writer in the IPC streaming format.
+type ipcStreamWriter struct {
+	wr io.WriteCloser
+}
+
+func (w ipcStreamWriter) WriteMessage(meta, body []byte) error {
+	_, err := w.wr.Write(encapsulate(meta, body))
+	return err
+}
+
+// Close writes the end of the stream and closes the writer.
+func (w ipcStreamWriter) Close() error {
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:4], continuationMark)
+	if _, err := w.wr.Write(eos[:]); err != nil {
+		return err
+	}
+	return w.wr.Close()
+}
+
+// encapsulate returns the encapsulated message with the flatbuffer |meta| and the body |body|.
+func encapsulate(meta, body []byte) []byte {
+	metaLen := len(meta) + padding(8+len(meta))
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:4], continuationMark)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(metaLen))
+
+	buf := make([]byte, 0, 8+metaLen+len(body))
+	buf = append(buf, prefix[:]...)
+	buf = append(buf, meta...)
+	buf = append(buf, make([]byte, metaLen-len(meta))...)
+	buf = append(buf, body...)
+	return buf
+}
+
+func schemaMessage(sch sql.Schema, cols []*column) []byte {
+	b := fb.NewBuilder(1024)
+
+	fields := make([]fb.UOffsetT, len(cols))
+	for i, col := range cols {
+		name := b.CreateString(sch[i].Name)
+		typ := col.buildType(b)
+		b.StartVector(4, 0, 4)
+		children := b.EndVector(0)
+
+		// table Field { name, nullable, type (union), dictionary, children, custom_metadata }
+		b.StartObject(7)
+		b.PrependUOffsetTSlot(0, name, 0)
+		b.PrependBoolSlot(1, true, false)
+		b.PrependByteSlot(2, col.arrowType(), 0)
+		b.PrependUOffsetTSlot(3, typ, 0)
+		b.PrependUOffsetTSlot(5, children, 0)
+		fields[i] = b.EndObject()
+	}
+	b.StartVector(4, len(fields), 4)
+	for i := len(fields) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(fields[i])
+	}
+	fieldsVec := b.EndVector(len(fields))
+
+	// table Schema { endianness, fields, custom_metadata, features }
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldsVec, 0)
+	schema := b.EndObject()
+
+	return finishMessage(b, messageHeaderSchema, schema, 0)
+}
+
+func recordBatchMessage(length int64, nodes, buffers [][2]int64, bodyLen int64) []byte {
+	b := fb.NewBuilder(1024)
+
+	// struct FieldNode { length, null_count }
+	b.StartVector(16, len(nodes), 8)
+	for i := len(nodes) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(nodes[i][1])
+		b.PrependInt64(nodes[i][0])
+	}
+	nodesVec := b.EndVector(len(nodes))
+
+	// struct Buffer { offset, length }
+	b.StartVector(16, len(buffers), 8)
+	for i := len(buffers) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(buffers[i][1])
+		b.PrependInt64(buffers[i][0])
+	}
+	buffersVec := b.EndVector(len(buffers))
+
+	// table RecordBatch { length, nodes, buffers, compression }
+	b.StartObject(4)
+	b.PrependInt64Slot(0, length, 0)
+	b.PrependUOffsetTSlot(1, nodesVec, 0)
+	b.PrependUOffsetTSlot(2, buffersVec, 0)
+	rb := b.EndObject()
+
+	return finishMessage(b, messageHeaderRecordBatch, rb, bodyLen)
+}
+
+// finishMessage finishes |b| with a Message table holding the header |header| of type |headerType|.
+func finishMessage(b *fb.Builder, headerType byte, header fb.UOffsetT, bodyLen int64) []byte {
+	// table Message { version, header (union), bodyLength, custom_metadata }
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataVersionV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, bodyLen, 0)
+	b.Finish(b.EndObject())
+	return b.FinishedBytes()
+}
+
+// padding returns the number of bytes needed to align |n| bytes to 8 bytes.
+func padding(n int) int {
+	return (8 - n%8) % 8
+}
+
+type columnKind byte
+
+const (
+	kindUtf8 columnKind = iota
+	kindBinary
+	kindInt64
+	kindUint64
+	kindFloat64
+	kindDate32
+	kindTimestamp
+)
+
+// column gathers the values of a column of a record batch in the layout of its Arrow type.
+type column struct {
+	kind   columnKind
+	typ    sql.Type
+	length int
+	nulls  int
+
+	validity []byte
+	// values holds the values of fixed width types, and the data of variable width types
+	values []byte
+	// offsets holds the offsets into values of variable width types
+	offsets []byte
+}
+
+func newColumn(typ sql.Type) *column {
+	kind := kindUtf8
+	switch {
+	case types.IsSigned(typ):
+		kind = kindInt64
+	case types.IsUnsigned(typ):
+		kind = kindUint64
+	case types.IsFloat(typ):
+		kind = kindFloat64
+	case types.IsDateType(typ):
+		kind = kindDate32
+	case types.IsTime(typ):
+		kind = kindTimestamp
+	case types.IsBinaryType(typ):
+		kind = kindBinary
+	}
+	c := &column{kind: kind, typ: typ}
+	c.reset()
+	return c
+}
+
+func (c *column) reset() {
+	c.length = 0
+	c.nulls = 0
+	c.validity = c.validity[:0]
+	c.values = c.values[:0]
+	c.offsets = c.offsets[:0]
+	if c.variableWidth() {
+		c.offsets = binary.LittleEndian.AppendUint32(c.offsets, 0)
+	}
+}
+
+func (c *column) variableWidth() bool {
+	return c.kind == kindUtf8 || c.kind == kindBinary
+}
+
+// buffers returns the buffers of the column, in the order of its Arrow type's layout.
+func (c *column) buffers() [][]byte {
+	if c.variableWidth() {
+		return [][]byte{c.validity, c.offsets, c.values}
+	}
+	return [][]byte{c.validity, c.values}
+}
+
+func (c *column) append(v interface{}) error {
+	if c.length%8 == 0 {
+		c.validity = append(c.validity, 0)
+	}
+	if v != nil {
+		c.validity[c.length/8] |= 1 << (c.length % 8)
+	} else {
+		c.nulls++
+	}
+	c.length++
+
+	switch c.kind {
+	case kindInt64:
+		var i int64
+		if v != nil {
+			cv, _, err := types.Int64.Convert(v)
+			if err != nil {
+				return err
+			}
+			i = cv.(int64)
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, uint64(i))
+	case kindUint64:
+		var u uint64
+		if v != nil {
+			cv, _, err := types.Uint64.Convert(v)
+			if err != nil {
+				return err
+			}
+			u = cv.(uint64)
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, u)
+	case kindFloat64:
+		var f float64
+		if v != nil {
+			cv, _, err := types.Float64.Convert(v)
+			if err != nil {
+				return err
+			}
+			f = cv.(float64)
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, math.Float64bits(f))
+	case kindDate32:
+		var days int64
+		if v != nil {
+			t, err := c.toTime(v)
+			if err != nil {
+				return err
+			}
+			days = t.Unix() / (24 * 60 * 60)
+			if t.Unix() < 0 && t.Unix()%(24*60*60) != 0 {
+				days--
+			}
+		}
+		c.values = binary.LittleEndian.AppendUint32(c.values, uint32(int32(days)))
+	case kindTimestamp:
+		var micros int64
+		if v != nil {
+			t, err := c.toTime(v)
+			if err != nil {
+				return err
+			}
+			micros = t.UnixMicro()
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, uint64(micros))
+	case kindBinary:
+		if v != nil {
+			switch b := v.(type) {
+			case []byte:
+				c.values = append(c.values, b...)
+			case string:
+				c.values = append(c.values, b...)
+			default:
+				return fmt.Errorf("unexpected binary value of type %T", v)
+			}
+		}
+		c.offsets = binary.LittleEndian.AppendUint32(c.offsets, uint32(len(c.values)))
+	default:
+		if v != nil {
+			s, err := sqlutil.SqlColToStr(c.typ, v)
+			if err != nil {
+				return err
+			}
+			c.values = append(c.values, s...)
+		}
+		c.offsets = binary.LittleEndian.AppendUint32(c.offsets, uint32(len(c.values)))
+	}
+	return nil
+}
+
+func (c *column) toTime(v interface{}) (time.Time, error) {
+	cv, _, err := c.typ.Convert(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, ok := cv.(time.Time)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unexpected time value of type %T", cv)
+	}
+	return t, nil
+}
+
+// arrowType returns the Arrow type of the column, as the value of the Type union in the schema.
+func (c *column) arrowType() byte {
+	switch c.kind {
+	case kindInt64, kindUint64:
+		return typeInt
+	case kindFloat64:
+		return typeFloatingPoint
+	case kindDate32:
+		return typeDate
+	case kindTimestamp:
+		return typeTimestamp
+	case kindBinary:
+		return typeBinary
+	default:
+		return typeUtf8
+	}
+}
+
+// buildType builds the table of the column's Arrow type in |b|.
+func (c *column) buildType(b *fb.Builder) fb.UOffsetT {
+	switch c.kind {
+	case kindInt64, kindUint64:
+		// table Int { bitWidth, is_signed }
+		b.StartObject(2)
+		b.PrependInt32Slot(0, 64, 0)
+		b.PrependBoolSlot(1, c.kind == kindInt64, false)
+	case kindFloat64:
+		// table FloatingPoint { precision }
+		b.StartObject(1)
+		b.PrependInt16Slot(0, precisionDouble, 0)
+	case kindDate32:
+		// table Date { unit = MILLISECOND }
+		b.StartObject(1)
+		b.PrependInt16Slot(0, dateUnitDay, dateUnitMilli)
+	case kindTimestamp:
+		// table Timestamp { unit, timezone }
+		b.StartObject(2)
+		b.PrependInt16Slot(0, timeUnitMicro, 0)
+	default:
+		// table Utf8 {} and table Binary {}
+		b.StartObject(0)
+	}
+	return b.EndObject()
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	fb "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+// message is an encapsulated message read back from a stream.
+type message struct {
+	headerType byte
+	header     fb.Table
+	body       []byte
+}
+
+func readMessages(t *testing.T, stream []byte) []message {
+	var msgs []message
+	for {
+		require.True(t, len(stream) >= 8)
+		require.Equal(t, uint32(continuationMark), binary.LittleEndian.Uint32(stream))
+		metaLen := int(binary.LittleEndian.Uint32(stream[4:]))
+		if metaLen == 0 {
+			require.Len(t, stream, 8, "unexpected data after the end of the stream")
+			return msgs
+		}
+		require.Zero(t, (8+metaLen)%8, "metadata isn't aligned")
+
+		meta := stream[8 : 8+metaLen]
+		msg := fb.Table{Bytes: meta, Pos: fb.GetUOffsetT(meta)}
+		assert.Equal(t, int16(metadataVersionV5), msg.GetInt16(msg.Pos+fb.UOffsetT(msg.Offset(4))))
+		var m message
+		m.headerType = msg.GetByte(msg.Pos + fb.UOffsetT(msg.Offset(6)))
+		m.header = fb.Table{Bytes: meta, Pos: msg.Indirect(msg.Pos + fb.UOffsetT(msg.Offset(8)))}
+		var bodyLen int64
+		if o := msg.Offset(10); o != 0 {
+			bodyLen = msg.GetInt64(msg.Pos + fb.UOffsetT(o))
+		}
+		m.body = stream[8+metaLen : 8+metaLen+int(bodyLen)]
+		msgs = append(msgs, m)
+		stream = stream[8+metaLen+int(bodyLen):]
+	}
+}
+
+// recordBatchBuffers returns the length of the record batch |m| and its buffers.
+func recordBatchBuffers(m message) (int64, [][]byte) {
+	rb := m.header
+	length := rb.GetInt64(rb.Pos + fb.UOffsetT(rb.Offset(4)))
+	o := fb.UOffsetT(rb.Offset(8))
+	start := rb.Vector(o)
+	var bufs [][]byte
+	for i := 0; i < rb.VectorLen(o); i++ {
+		pos := start + fb.UOffsetT(i*16)
+		offset := rb.GetInt64(pos)
+		l := rb.GetInt64(pos + 8)
+		bufs = append(bufs, m.body[offset:offset+l])
+	}
+	return length, bufs
+}
+
+func TestArrowWriter(t *testing.T) {
+	ctx := context.Background()
+	sch := sql.Schema{
+		&sql.Column{Name: "id", Type: types.Int64},
+		&sql.Column{Name: "name", Type: types.Text, Nullable: true},
+	}
+
+	var buf bytes.Buffer
+	wr, err := NewArrowSqlWriter(iohelp.NopWrCloser(&buf), sch)
+	require.NoError(t, err)
+	wr.batchSize = 2
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(1), "one"}))
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(2), nil}))
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(-3), "three"}))
+	require.NoError(t, wr.Close(ctx))
+
+	msgs := readMessages(t, buf.Bytes())
+	require.Len(t, msgs, 3)
+	assert.Equal(t, byte(messageHeaderSchema), msgs[0].headerType)
+	assert.Equal(t, byte(messageHeaderRecordBatch), msgs[1].headerType)
+	assert.Equal(t, byte(messageHeaderRecordBatch), msgs[2].headerType)
+
+	// schema fields
+	schema := msgs[0].header
+	o := fb.UOffsetT(schema.Offset(6))
+	require.Equal(t, 2, schema.VectorLen(o))
+	var names []string
+	var fieldTypes []byte
+	for i := 0; i < 2; i++ {
+		field := fb.Table{Bytes: schema.Bytes, Pos: schema.Indirect(schema.Vector(o) + fb.UOffsetT(i*4))}
+		names = append(names, string(field.ByteVector(field.Pos+fb.UOffsetT(field.Offset(4)))))
+		fieldTypes = append(fieldTypes, field.GetByte(field.Pos+fb.UOffsetT(field.Offset(8))))
+	}
+	assert.Equal(t, []string{"id", "name"}, names)
+	assert.Equal(t, []byte{typeInt, typeUtf8}, fieldTypes)
+
+	// first batch: (1, 'one'), (2, NULL)
+	length, bufs := recordBatchBuffers(msgs[1])
+	assert.Equal(t, int64(2), length)
+	require.Len(t, bufs, 5)
+	assert.Equal(t, []byte{0b11}, bufs[0])
+	assert.Equal(t, int64(1), int64(binary.LittleEndian.Uint64(bufs[1])))
+	assert.Equal(t, int64(2), int64(binary.LittleEndian.Uint64(bufs[1][8:])))
+	assert.Equal(t, []byte{0b01}, bufs[2])
+	assert.Equal(t, []uint32{0, 3, 3}, []uint32{
+		binary.LittleEndian.Uint32(bufs[3]),
+		binary.LittleEndian.Uint32(bufs[3][4:]),
+		binary.LittleEndian.Uint32(bufs[3][8:]),
+	})
+	assert.Equal(t, "one", string(bufs[4]))
+
+	// second batch: (-3, 'three')
+	length, bufs = recordBatchBuffers(msgs[2])
+	assert.Equal(t, int64(1), length)
+	assert.Equal(t, int64(-3), int64(binary.LittleEndian.Uint64(bufs[1])))
+	assert.Equal(t, "three", string(bufs[4]))
+}
+
+func TestArrowWriterEmpty(t *testing.T) {
+	ctx := context.Background()
+	sch := sql.Schema{&sql.Column{Name: "id", Type: types.Int64}}
+
+	var buf bytes.Buffer
+	wr, err := NewArrowSqlWriter(iohelp.NopWrCloser(&buf), sch)
+	require.NoError(t, err)
+	require.NoError(t, wr.Close(ctx))
+
+	msgs := readMessages(t, buf.Bytes())
+	require.Len(t, msgs, 1)
+	assert.Equal(t, byte(messageHeaderSchema), msgs[0].headerType)
+}
+
+func TestArrowWriterMaxBatchBytes(t *testing.T) {
+	ctx := context.Background()
+	sch := sql.Schema{&sql.Column{Name: "v", Type: types.LongText}}
+	v := string(bytes.Repeat([]byte{'a'}, MaxBatchBytes/2))
+
+	var buf bytes.Buffer
+	wr, err := NewArrowSqlWriter(iohelp.NopWrCloser(&buf), sch)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{v}))
+	}
+	require.NoError(t, wr.Close(ctx))
+
+	msgs := readMessages(t, buf.Bytes())
+	require.Len(t, msgs, 3)
+	length, _ := recordBatchBuffers(msgs[1])
+	assert.Equal(t, int64(2), length)
+	length, _ = recordBatchBuffers(msgs[2])
+	assert.Equal(t, int64(1), length)
+}
+
+func TestEncapsulatedSchema(t *testing.T) {
+	sch := sql.Schema{
+		&sql.Column{Name: "id", Type: types.Int64},
+		&sql.Column{Name: "at", Type: types.Datetime},
+	}
+
+	var buf bytes.Buffer
+	_, err := NewArrowSqlWriter(iohelp.NopWrCloser(&buf), sch)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), EncapsulatedSchema(sch))
+}
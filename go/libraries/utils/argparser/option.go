@@ -25,6 +25,9 @@ const (
 	OptionalFlag OptionType = iota
 	OptionalValue
 	OptionalEmptyValue
+	// OptionalAttachedValue is an option whose value is optional, and can only be given in the same argument as the
+	// option, as in --name=value, so that the argument after the option is never taken as its value.
+	OptionalAttachedValue
 )
 
 type ValidationFunc func(string) error
@@ -157,6 +157,15 @@ func (ap *ArgParser) SupportsOptionalString(name, abbrev, valDesc, desc string)
 	return ap
 }
 
+// SupportsAttachedOptionalString adds support for a new string argument with the description given, whose value is
+// optional and must be attached to the option, as in --name=value. If it's given without a value, its value is empty.
+func (ap *ArgParser) SupportsAttachedOptionalString(name, valDesc, desc string) *ArgParser {
+	opt := &Option{name, "", valDesc, OptionalAttachedValue, desc, nil, false}
+	ap.SupportOption(opt)
+
+	return ap
+}
+
 // SupportsValidatedString adds support for a new string argument with the description given and defined validation function.
 func (ap *ArgParser) SupportsValidatedString(name, abbrev, valDesc, desc string, validator ValidationFunc) *ArgParser {
 	opt := &Option{name, abbrev, valDesc, OptionalValue, desc, validator, false}
@@ -239,7 +248,7 @@ func (ap *ArgParser) matchModalOptions(arg string) (matches []*Option, rest stri
 func (ap *ArgParser) sortedValueOptions() []string {
 	vos := make([]string, 0, len(ap.Supported))
 	for s, opt := range ap.nameOrAbbrevToOpt {
-		if (opt.OptType == OptionalValue || opt.OptType == OptionalEmptyValue) && s != "" {
+		if (opt.OptType == OptionalValue || opt.OptType == OptionalEmptyValue || opt.OptType == OptionalAttachedValue) && s != "" {
 			vos = append(vos, s)
 		}
 	}
@@ -326,7 +335,9 @@ func (ap *ArgParser) Parse(args []string) (*ArgParseResults, error) {
 			return nil, errors.New("error: multiple values provided for `" + opt.Name + "'")
 		}
 
-		if value == nil {
+		if value == nil && opt.OptType == OptionalAttachedValue {
+			value = new(string)
+		} else if value == nil {
 			i++
 			valueStr := ""
 			if i >= len(args) {
@@ -93,6 +93,20 @@ func TestArgParser(t *testing.T) {
 			map[string]string{},
 			[]string{},
 		},
+		{
+			NewArgParserWithVariableArgs("test").SupportsAttachedOptionalString("lease", "", ""),
+			[]string{"--lease", "arg1"},
+			nil,
+			map[string]string{"lease": ""},
+			[]string{"arg1"},
+		},
+		{
+			NewArgParserWithVariableArgs("test").SupportsAttachedOptionalString("lease", "", ""),
+			[]string{"--lease=value", "arg1"},
+			nil,
+			map[string]string{"lease": "value"},
+			[]string{"arg1"},
+		},
 		{
 			NewArgParserWithMaxArgs("test", 1),
 			[]string{"foo", "bar"},
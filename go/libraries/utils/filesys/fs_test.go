@@ -117,6 +117,16 @@ func TestFilesystems(t *testing.T) {
 			dataRead, err = fs.ReadFile(fp2)
 			require.NoError(t, err)
 			require.Equal(t, dataRead, data)
+
+			// Test appending to the tmp file
+			wrc, err = fs.OpenForWriteAppend(fp2, os.ModePerm)
+			require.NoError(t, err)
+			_, err = wrc.Write([]byte("appended"))
+			require.NoError(t, err)
+			require.NoError(t, wrc.Close())
+			dataRead, err = fs.ReadFile(fp2)
+			require.NoError(t, err)
+			require.Equal(t, dataRead, append(data, []byte("appended")...))
 		})
 	}
 }
@@ -0,0 +1,104 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iohelp
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// PipeConn is a net.Conn built from a reader and a writer, such as the stdio of a child process or of the current
+// process. Deadlines are not supported and are silently ignored.
+type PipeConn struct {
+	r io.ReadCloser
+	w io.WriteCloser
+
+	closeOnce sync.Once
+	closeErr  error
+	onClose   func() error
+	done      chan struct{}
+}
+
+var _ net.Conn = (*PipeConn)(nil)
+
+// NewPipeConn returns a PipeConn which reads from |r| and writes to |w|. If |onClose| is non-nil, it is called after
+// both |r| and |w| are closed and its error is returned from Close.
+func NewPipeConn(r io.ReadCloser, w io.WriteCloser, onClose func() error) *PipeConn {
+	return &PipeConn{r: r, w: w, onClose: onClose, done: make(chan struct{})}
+}
+
+func (c *PipeConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *PipeConn) Write(b []byte) (int, error) {
+	return c.w.Write(b)
+}
+
+// Close closes both halves of the pipe. It is safe to call more than once.
+func (c *PipeConn) Close() error {
+	c.closeOnce.Do(func() {
+		werr := c.w.Close()
+		rerr := c.r.Close()
+		if c.onClose != nil {
+			c.closeErr = c.onClose()
+		}
+		if c.closeErr == nil {
+			c.closeErr = werr
+		}
+		if c.closeErr == nil {
+			c.closeErr = rerr
+		}
+		close(c.done)
+	})
+	return c.closeErr
+}
+
+// Done returns a channel which is closed once Close has been called.
+func (c *PipeConn) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *PipeConn) LocalAddr() net.Addr {
+	return pipeAddr{}
+}
+
+func (c *PipeConn) RemoteAddr() net.Addr {
+	return pipeAddr{}
+}
+
+func (c *PipeConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *PipeConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *PipeConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string {
+	return "pipe"
+}
+
+func (pipeAddr) String() string {
+	return "pipe"
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports the OpenTelemetry spans of Dolt to an OTLP collector.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+// DefaultServiceName is the service name spans are exported with if none is configured.
+const DefaultServiceName = "dolt"
+
+var enabled atomic.Bool
+
+// Enabled returns whether the spans of the process are exported. Callers can skip creating spans that are costly
+// when they aren't.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// OTLPConfig is the configuration of the export of spans to an OTLP collector over HTTP.
+type OTLPConfig struct {
+	// Endpoint is the http or https URL of the collector, e.g. http://localhost:4318. Spans are sent to its
+	// /v1/traces path unless the URL has a path. If it's empty, the standard OTEL_EXPORTER_OTLP_ENDPOINT and
+	// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables are used, and localhost:4318 otherwise.
+	Endpoint string
+	// Headers are sent with every export, e.g. to authenticate with the collector.
+	Headers map[string]string
+	// SampleRatio is the fraction of traces that are sampled, between 0 and 1. Spans whose parent is sampled are
+	// always sampled, so traces propagated from clients are complete.
+	SampleRatio float64
+	// ServiceName is the service.name resource of the spans. It's DefaultServiceName if empty.
+	ServiceName string
+}
+
+// Validate returns an error if the endpoint of the config isn't an http or https URL, or if its sample ratio isn't
+// between 0 and 1.
+func (cfg OTLPConfig) Validate() error {
+	if cfg.Endpoint != "" {
+		u, err := url.Parse(cfg.Endpoint)
+		if err != nil {
+			return fmt.Errorf("otlp_endpoint: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("otlp_endpoint: %q is not an http or https URL", cfg.Endpoint)
+		}
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return fmt.Errorf("sample_ratio: is %v but must be between 0 and 1", cfg.SampleRatio)
+	}
+	return nil
+}
+
+// StartOTLP makes the spans of the process be exported to the OTLP collector of |cfg|, and trace contexts be
+// propagated in W3C Trace Context headers. The returned function flushes the spans not exported yet and stops the
+// export.
+func StartOTLP(ctx context.Context, version string, cfg OTLPConfig) (func(context.Context) error, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		u, _ := url.Parse(cfg.Endpoint)
+		opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if u.Path != "" && u.Path != "/" {
+			opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+		}
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exp, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	tp := tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(exp),
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(cfg.SampleRatio))),
+		tracesdk.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	enabled.Store(true)
+	return tp.Shutdown, nil
+}
@@ -22,6 +22,8 @@ import (
 	"hash/maphash"
 	"log"
 	"math/rand"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
 	"runtime"
@@ -86,11 +88,21 @@ func appendLocalTest(tests []BlobstoreTest) []BlobstoreTest {
 	return append(tests, BlobstoreTest{"local", NewLocalBlobstore(dir), 10, 20})
 }
 
+func appendOCITest(tests []BlobstoreTest) []BlobstoreTest {
+	srv := httptest.NewServer(newFakeRegistry())
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		panic(err)
+	}
+	return append(tests, BlobstoreTest{"oci", NewOCIBlobstore(srv.Client(), u.Host, uuid.New().String(), "", OCICredentials{}), 4, 4})
+}
+
 func newBlobStoreTests() []BlobstoreTest {
 	var tests []BlobstoreTest
 	tests = append(tests, BlobstoreTest{"inmem", NewInMemoryBlobstore(""), 10, 20})
 	tests = appendLocalTest(tests)
 	tests = appendGCSTest(tests)
+	tests = appendOCITest(tests)
 
 	return tests
 }
@@ -0,0 +1,572 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+const (
+	ociManifestMediaType  = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType     = "application/vnd.oci.empty.v1+json"
+	ociArtifactType       = "application/vnd.dolthub.dolt.v1"
+	ociBlobMediaType      = "application/vnd.dolthub.dolt.blob.v1"
+	ociTitleAnnotation    = "org.opencontainers.image.title"
+	ociDefaultTag         = "dolt"
+	ociEmptyConfigContent = "{}"
+)
+
+// OCICredentials are used to authenticate with an OCI registry. Empty credentials
+// request anonymous tokens.
+type OCICredentials struct {
+	Username string
+	Password string
+}
+
+// OCIBlobstore provides a Blobstore implementation backed by an OCI distribution registry.
+// Every blob is stored as a layer of a single OCI image manifest, named by a title annotation
+// with its key, so a database is one tagged artifact in a registry repository. Blob versions
+// are the digests of their layers.
+//
+// Registries do not offer conditional manifest writes, so CheckAndPut is only atomic with
+// respect to writers in this process. Concurrent pushes from different hosts can race.
+type OCIBlobstore struct {
+	client   *http.Client
+	baseURL  string
+	registry string
+	repo     string
+	tag      string
+	creds    OCICredentials
+
+	tokenMu sync.Mutex
+	token   string
+
+	// mu serializes manifest updates from this process
+	mu sync.Mutex
+	// digests caches the layers of immutable keys, which are all keys not listed in |mutable|
+	digests map[string]ociDescriptor
+	mutable map[string]struct{}
+}
+
+var _ Blobstore = &OCIBlobstore{}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	ArtifactType  string          `json:"artifactType,omitempty"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// NewOCIBlobstore creates a new instance of an OCIBlobstore for the repository |repo| on
+// |registry|, storing blobs in the manifest tagged |tag|. Registries on localhost are
+// reached over plain http, all others over https. Blobs are assumed to never change once
+// written, except for those named in |mutableKeys|, whose locations are never cached.
+func NewOCIBlobstore(client *http.Client, registry, repo, tag string, creds OCICredentials, mutableKeys ...string) *OCIBlobstore {
+	repo = strings.Trim(repo, "/")
+	if tag == "" {
+		tag = ociDefaultTag
+	}
+	scheme := "https"
+	host := strings.Split(registry, ":")[0]
+	if host == "localhost" || host == "127.0.0.1" {
+		scheme = "http"
+	}
+	mutable := make(map[string]struct{}, len(mutableKeys))
+	for _, k := range mutableKeys {
+		mutable[k] = struct{}{}
+	}
+	return &OCIBlobstore{
+		client:   client,
+		baseURL:  scheme + "://" + registry + "/v2/" + repo,
+		registry: registry,
+		repo:     repo,
+		tag:      tag,
+		creds:    creds,
+		digests:  make(map[string]ociDescriptor),
+		mutable:  mutable,
+	}
+}
+
+func (bs *OCIBlobstore) Path() string {
+	return path.Join(bs.registry, bs.repo) + ":" + bs.tag
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *OCIBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok, err := bs.lookup(ctx, key)
+	return ok, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *OCIBlobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	desc, ok, err := bs.lookup(ctx, key)
+	if err != nil {
+		return nil, "", err
+	} else if !ok {
+		return nil, "", NotFound{"oci://" + bs.Path() + "/" + key}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bs.baseURL+"/blobs/"+desc.Digest, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if !br.isAllRange() {
+		// suffix ranges can't have a length, so ranges are sent with absolute offsets
+		br = br.positiveRange(desc.Size)
+		req.Header.Set("Range", httpRange(br))
+	}
+	resp, err := bs.do(req, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, "", ociError(resp)
+	}
+	if resp.StatusCode == http.StatusOK && !br.isAllRange() {
+		// the registry ignored the range, so apply it ourselves
+		return sliceBody(resp.Body, br), desc.Digest, nil
+	}
+	return &deferredEOFReader{ReadCloser: resp.Body}, desc.Digest, nil
+}
+
+// Put sets the blob and the version for a key
+func (bs *OCIBlobstore) Put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	desc, err := bs.uploadBlob(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	desc.Annotations = map[string]string{ociTitleAnnotation: key}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if err := bs.updateManifest(ctx, key, desc); err != nil {
+		return "", err
+	}
+	return desc.Digest, nil
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key
+func (bs *OCIBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, reader io.Reader) (string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	desc, err := bs.uploadBlob(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	desc.Annotations = map[string]string{ociTitleAnnotation: key}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	m, err := bs.fetchManifest(ctx)
+	if err != nil {
+		return "", err
+	}
+	var actual string
+	if curr, ok := findLayer(m, key); ok {
+		actual = curr.Digest
+	}
+	if actual != expectedVersion {
+		return "", CheckAndPutError{key, expectedVersion, actual}
+	}
+
+	if err := bs.putManifest(ctx, withLayer(m, key, desc)); err != nil {
+		return "", err
+	}
+	return desc.Digest, nil
+}
+
+// Concatenate creates a new blob named |key| by concatenating |sources|. Registries have no
+// server side composition, so the sources are downloaded and uploaded again as one layer.
+func (bs *OCIBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	var buf bytes.Buffer
+	for _, src := range sources {
+		data, _, err := GetBytes(ctx, bs, src, AllRange)
+		if err != nil {
+			return "", err
+		}
+		buf.Write(data)
+	}
+	return bs.Put(ctx, key, &buf)
+}
+
+func (bs *OCIBlobstore) lookup(ctx context.Context, key string) (ociDescriptor, bool, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if desc, ok := bs.digests[key]; ok {
+		return desc, true, nil
+	}
+	m, err := bs.fetchManifest(ctx)
+	if err != nil {
+		return ociDescriptor{}, false, err
+	}
+	bs.cacheLayers(m)
+	desc, ok := findLayer(m, key)
+	return desc, ok, nil
+}
+
+// updateManifest adds or replaces the layer for |key| in the tagged manifest. Callers must hold |bs.mu|.
+func (bs *OCIBlobstore) updateManifest(ctx context.Context, key string, desc ociDescriptor) error {
+	m, err := bs.fetchManifest(ctx)
+	if err != nil {
+		return err
+	}
+	return bs.putManifest(ctx, withLayer(m, key, desc))
+}
+
+// fetchManifest returns the tagged manifest, or a new, empty manifest if the tag does not exist yet.
+func (bs *OCIBlobstore) fetchManifest(ctx context.Context) (ociManifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bs.baseURL+"/manifests/"+bs.tag, nil)
+	if err != nil {
+		return ociManifest{}, err
+	}
+	req.Header.Set("Accept", ociManifestMediaType)
+	resp, err := bs.do(req, nil)
+	if err != nil {
+		return ociManifest{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ociManifest{
+			SchemaVersion: 2,
+			MediaType:     ociManifestMediaType,
+			ArtifactType:  ociArtifactType,
+			Config: ociDescriptor{
+				MediaType: ociEmptyMediaType,
+				Digest:    ociDigest([]byte(ociEmptyConfigContent)),
+				Size:      int64(len(ociEmptyConfigContent)),
+			},
+		}, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return ociManifest{}, ociError(resp)
+	}
+
+	var m ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return ociManifest{}, fmt.Errorf("invalid manifest for %s: %w", bs.Path(), err)
+	}
+	return m, nil
+}
+
+func (bs *OCIBlobstore) putManifest(ctx context.Context, m ociManifest) error {
+	// the config blob must exist before a manifest can reference it
+	if _, err := bs.uploadBlob(ctx, []byte(ociEmptyConfigContent)); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, bs.baseURL+"/manifests/"+bs.tag, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ociManifestMediaType)
+	resp, err := bs.do(req, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return ociError(resp)
+	}
+	bs.cacheLayers(m)
+	return nil
+}
+
+// cacheLayers records the digests of the immutable layers of |m|. Callers must hold |bs.mu|.
+func (bs *OCIBlobstore) cacheLayers(m ociManifest) {
+	for _, l := range m.Layers {
+		key := l.Annotations[ociTitleAnnotation]
+		if _, ok := bs.mutable[key]; ok || key == "" {
+			continue
+		}
+		bs.digests[key] = l
+	}
+}
+
+// uploadBlob uploads |data| as a blob unless the registry already has it.
+func (bs *OCIBlobstore) uploadBlob(ctx context.Context, data []byte) (ociDescriptor, error) {
+	desc := ociDescriptor{
+		MediaType: ociBlobMediaType,
+		Digest:    ociDigest(data),
+		Size:      int64(len(data)),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, bs.baseURL+"/blobs/"+desc.Digest, nil)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	resp, err := bs.do(req, nil)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, bs.baseURL+"/blobs/uploads/", nil)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	resp, err = bs.do(req, nil)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return ociDescriptor{}, ociError(resp)
+	}
+
+	loc, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return ociDescriptor{}, fmt.Errorf("invalid upload location from %s: %w", bs.registry, err)
+	}
+	q := loc.Query()
+	q.Set("digest", desc.Digest)
+	loc.RawQuery = q.Encode()
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, loc.String(), nil)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err = bs.do(req, data)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return ociDescriptor{}, ociError(resp)
+	}
+	return desc, nil
+}
+
+// do sends |req| with |body|, authenticating with a bearer token if the registry challenges.
+func (bs *OCIBlobstore) do(req *http.Request, body []byte) (*http.Response, error) {
+	send := func() (*http.Response, error) {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			req.ContentLength = int64(len(body))
+		}
+		bs.tokenMu.Lock()
+		token := bs.token
+		bs.tokenMu.Unlock()
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if bs.creds.Username != "" {
+			req.SetBasicAuth(bs.creds.Username, bs.creds.Password)
+		}
+		return bs.client.Do(req)
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	token, err := bs.fetchToken(req.Context(), challenge)
+	if err != nil {
+		return nil, err
+	}
+	bs.tokenMu.Lock()
+	bs.token = token
+	bs.tokenMu.Unlock()
+	return send()
+}
+
+// fetchToken implements the registry token authentication flow for a Bearer |challenge|.
+func (bs *OCIBlobstore) fetchToken(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("unsupported authentication challenge from %s: %q", bs.registry, challenge)
+	}
+	params := parseChallenge(strings.TrimPrefix(challenge, "Bearer "))
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid authentication realm from %s: %q", bs.registry, challenge)
+	}
+	q := realm.Query()
+	if s, ok := params["service"]; ok {
+		q.Set("service", s)
+	}
+	scope, ok := params["scope"]
+	if !ok {
+		scope = "repository:" + bs.repo + ":pull,push"
+	}
+	q.Set("scope", scope)
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if bs.creds.Username != "" {
+		req.SetBasicAuth(bs.creds.Username, bs.creds.Password)
+	}
+	resp, err := bs.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", ociError(resp)
+	}
+
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", err
+	}
+	if tok.Token != "" {
+		return tok.Token, nil
+	}
+	if tok.AccessToken != "" {
+		return tok.AccessToken, nil
+	}
+	return "", errors.New("registry returned an empty token")
+}
+
+// parseChallenge parses the comma separated key="value" pairs of a WWW-Authenticate challenge.
+func parseChallenge(s string) map[string]string {
+	params := make(map[string]string)
+	for len(s) > 0 {
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		k := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+		var v string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				break
+			}
+			v, s = s[1:end+1], s[end+2:]
+		} else if comma := strings.IndexByte(s, ','); comma >= 0 {
+			v, s = s[:comma], s[comma:]
+		} else {
+			v, s = s, ""
+		}
+		params[k] = v
+		s = strings.TrimLeft(s, ", ")
+	}
+	return params
+}
+
+func findLayer(m ociManifest, key string) (ociDescriptor, bool) {
+	for _, l := range m.Layers {
+		if l.Annotations[ociTitleAnnotation] == key {
+			return l, true
+		}
+	}
+	return ociDescriptor{}, false
+}
+
+func withLayer(m ociManifest, key string, desc ociDescriptor) ociManifest {
+	layers := make([]ociDescriptor, 0, len(m.Layers)+1)
+	for _, l := range m.Layers {
+		if l.Annotations[ociTitleAnnotation] != key {
+			layers = append(layers, l)
+		}
+	}
+	m.Layers = append(layers, desc)
+	return m
+}
+
+func ociDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func ociError(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+	return fmt.Errorf("registry request %s %s failed: %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+}
+
+// httpRange returns the value of a Range header selecting |br|, which must be a positive range.
+func httpRange(br BlobRange) string {
+	if br.length == 0 {
+		return fmt.Sprintf("bytes=%d-", br.offset)
+	}
+	return fmt.Sprintf("bytes=%d-%d", br.offset, br.offset+br.length-1)
+}
+
+// deferredEOFReader holds back the io.EOF which response bodies return along with their last bytes until the next
+// read, so that a read of a whole blob doesn't fail.
+type deferredEOFReader struct {
+	io.ReadCloser
+	eof bool
+}
+
+func (r *deferredEOFReader) Read(p []byte) (int, error) {
+	if r.eof {
+		return 0, io.EOF
+	}
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF && n > 0 {
+		r.eof = true
+		err = nil
+	}
+	return n, err
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// sliceBody returns the portion of |body| selected by |br|, which must be a positive range.
+func sliceBody(body io.ReadCloser, br BlobRange) io.ReadCloser {
+	// a short read surfaces as io.EOF from the returned reader
+	_, _ = io.CopyN(io.Discard, body, br.offset)
+	return limitedReadCloser{io.LimitReader(body, br.length), body}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry implements the subset of the OCI distribution API used by OCIBlobstore.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	token     string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{blobs: make(map[string][]byte), manifests: make(map[string][]byte)}
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path == "/token" {
+		w.Write([]byte(`{"token":"` + r.token + `"}`))
+		return
+	}
+	if r.token != "" && req.Header.Get("Authorization") != "Bearer "+r.token {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+req.Host+`/token",service="fake"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := req.URL.Path
+	switch {
+	case strings.Contains(p, "/blobs/uploads/") && req.Method == http.MethodPost:
+		w.Header().Set("Location", p+uuid.New().String())
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(p, "/blobs/uploads/") && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if ociDigest(data) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(p, "/blobs/"):
+		data, ok := r.blobs[p[strings.LastIndex(p, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(data))
+	case strings.Contains(p, "/manifests/") && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		r.manifests[p] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(p, "/manifests/"):
+		data, ok := r.manifests[p]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestOCIBlobstore(t testing.TB, reg *fakeRegistry) *OCIBlobstore {
+	srv := httptest.NewServer(reg)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	// httptest listens on 127.0.0.1, which is reached over plain http
+	return NewOCIBlobstore(srv.Client(), u.Host, "org/"+uuid.New().String(), "", OCICredentials{})
+}
+
+func TestOCIBlobstoreTokenAuth(t *testing.T) {
+	reg := newFakeRegistry()
+	reg.token = "secret"
+	bs := newTestOCIBlobstore(t, reg)
+	ctx := context.Background()
+
+	ver, err := PutBytes(ctx, bs, "table", []byte("abcdefgh"))
+	require.NoError(t, err)
+
+	data, getVer, err := GetBytes(ctx, bs, "table", NewBlobRange(2, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cde"), data)
+	assert.Equal(t, ver, getVer)
+
+	data, _, err = GetBytes(ctx, bs, "table", NewBlobRange(-2, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("gh"), data)
+}
+
+func TestOCIBlobstoreMutableKeys(t *testing.T) {
+	reg := newFakeRegistry()
+	writer := newTestOCIBlobstore(t, reg)
+	reader := NewOCIBlobstore(writer.client, writer.registry, writer.repo, writer.tag, OCICredentials{}, "manifest")
+	ctx := context.Background()
+
+	ver, err := CheckAndPutBytes(ctx, writer, "", "manifest", []byte("one"))
+	require.NoError(t, err)
+	data, _, err := GetBytes(ctx, reader, "manifest", AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("one"), data)
+
+	_, err = CheckAndPutBytes(ctx, writer, ver, "manifest", []byte("two"))
+	require.NoError(t, err)
+	data, _, err = GetBytes(ctx, reader, "manifest", AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("two"), data)
+
+	_, err = CheckAndPutBytes(ctx, writer, ver, "manifest", []byte("three"))
+	assert.True(t, IsCheckAndPutError(err))
+}
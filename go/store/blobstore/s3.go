@@ -0,0 +1,164 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3API is the subset of the S3 client used by S3Blobstore.
+type S3API interface {
+	HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+}
+
+// S3Blobstore provides an AWS S3 implementation of the Blobstore interface. Versions are object ETags. S3 has no
+// conditional writes, so CheckAndPut compares the ETag before writing and isn't atomic; a single writer per key is
+// expected.
+type S3Blobstore struct {
+	client     S3API
+	bucketName string
+	prefix     string
+}
+
+var _ Blobstore = &S3Blobstore{}
+
+// NewS3Blobstore creates a new instance of a S3Blobstore
+func NewS3Blobstore(client S3API, bucketName, prefix string) *S3Blobstore {
+	return &S3Blobstore{client: client, bucketName: bucketName, prefix: normalizePrefix(prefix)}
+}
+
+func (bs *S3Blobstore) Path() string {
+	return path.Join(bs.bucketName, bs.prefix)
+}
+
+func (bs *S3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.head(ctx, key)
+	if IsNotFoundError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (bs *S3Blobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	input := &s3.GetObjectInput{Bucket: aws.String(bs.bucketName), Key: aws.String(bs.absKey(key))}
+	if !br.isAllRange() {
+		if br.offset < 0 {
+			head, err := bs.head(ctx, key)
+			if err != nil {
+				return nil, "", err
+			}
+			br = br.positiveRange(aws.Int64Value(head.ContentLength))
+		}
+		if br.length == 0 {
+			input.Range = aws.String(fmt.Sprintf("bytes=%d-", br.offset))
+		} else {
+			input.Range = aws.String(fmt.Sprintf("bytes=%d-%d", br.offset, br.offset+br.length-1))
+		}
+	}
+
+	out, err := bs.client.GetObjectWithContext(ctx, input)
+	if err != nil {
+		if isS3NotFoundErr(err) {
+			return nil, "", NotFound{"s3://" + path.Join(bs.bucketName, bs.absKey(key))}
+		}
+		return nil, "", err
+	}
+	return out.Body, aws.StringValue(out.ETag), nil
+}
+
+func (bs *S3Blobstore) Put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	// PutObject needs a seekable body to sign the request
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	out, err := bs.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(out.ETag), nil
+}
+
+func (bs *S3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, reader io.Reader) (string, error) {
+	var actual string
+	head, err := bs.head(ctx, key)
+	if err == nil {
+		actual = aws.StringValue(head.ETag)
+	} else if !IsNotFoundError(err) {
+		return "", err
+	}
+	if actual != expectedVersion {
+		return "", CheckAndPutError{Key: key, ExpectedVersion: expectedVersion, ActualVersion: actual}
+	}
+	return bs.Put(ctx, key, reader)
+}
+
+// Concatenate reads |sources| and writes them to |key|. S3 can only compose objects server side from parts of at
+// least 5MB, which table file records often aren't, so the sources are copied through the client.
+func (bs *S3Blobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	var buf bytes.Buffer
+	for _, src := range sources {
+		rc, _, err := bs.Get(ctx, src, AllRange)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(&buf, rc)
+		cerr := rc.Close()
+		if err != nil {
+			return "", err
+		} else if cerr != nil {
+			return "", cerr
+		}
+	}
+	return bs.Put(ctx, key, &buf)
+}
+
+func (bs *S3Blobstore) head(ctx context.Context, key string) (*s3.HeadObjectOutput, error) {
+	out, err := bs.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+	})
+	if isS3NotFoundErr(err) {
+		return nil, NotFound{"s3://" + path.Join(bs.bucketName, bs.absKey(key))}
+	}
+	return out, err
+}
+
+func (bs *S3Blobstore) absKey(key string) string {
+	return path.Join(bs.prefix, key)
+}
+
+func isS3NotFoundErr(err error) bool {
+	if rerr, ok := err.(awserr.RequestFailure); ok {
+		return rerr.StatusCode() == http.StatusNotFound
+	}
+	return false
+}
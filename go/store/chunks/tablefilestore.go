@@ -16,6 +16,7 @@ package chunks
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/dolthub/dolt/go/store/hash"
@@ -70,3 +71,36 @@ type TableFileStore interface {
 	// SupportedOperations returns a description of the support TableFile operations. Some stores only support reading table files, not writing.
 	SupportedOperations() TableFileStoreOps
 }
+
+// ResumableTableFileWriter is implemented by TableFileStores which can resume writing a table file where an
+// interrupted write of it left off, rather than writing it again from its first byte.
+type ResumableTableFileWriter interface {
+	// WriteTableFileResumable writes the table file of |contentLength| bytes read from the readers returned by |getRd|
+	// to the TableFileStore. |getRd| is called with the offset into the table file that the returned reader must start
+	// at, which is after the bytes that have already been written when a write is resumed.
+	WriteTableFileResumable(ctx context.Context, fileId string, numChunks int, contentHash []byte, contentLength uint64, getRd func(offset uint64) (io.ReadCloser, error)) error
+}
+
+// ReaderFromOffset advances |rd| by |offset| bytes, seeking if it's an io.Seeker, so that it can be returned by the
+// getRd function of a WriteTableFileResumable call.
+func ReaderFromOffset(rd io.ReadCloser, offset uint64) (io.ReadCloser, error) {
+	if offset == 0 {
+		return rd, nil
+	}
+	if seeker, ok := rd.(io.Seeker); ok {
+		if _, err := seeker.Seek(int64(offset), io.SeekStart); err != nil {
+			_ = rd.Close()
+			return nil, err
+		}
+		return rd, nil
+	}
+	n, err := io.CopyN(io.Discard, rd, int64(offset))
+	if err != nil {
+		_ = rd.Close()
+		if err == io.EOF {
+			return nil, fmt.Errorf("table file of %d bytes can't be read from offset %d", n, offset)
+		}
+		return nil, err
+	}
+	return rd, nil
+}
@@ -169,6 +169,10 @@ func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, pa
 	nameoff := builder.CreateString(opts.Meta.Name)
 	emailoff := builder.CreateString(opts.Meta.Email)
 	descoff := builder.CreateString(opts.Meta.Description)
+	var sigoff flatbuffers.UOffsetT
+	if opts.Meta.Signature != "" {
+		sigoff = builder.CreateString(opts.Meta.Signature)
+	}
 	serial.CommitStart(builder)
 	serial.CommitAddRoot(builder, vaddroff)
 	serial.CommitAddHeight(builder, maxheight+1)
@@ -179,6 +183,9 @@ func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, pa
 	serial.CommitAddDescription(builder, descoff)
 	serial.CommitAddTimestampMillis(builder, opts.Meta.Timestamp)
 	serial.CommitAddUserTimestampMillis(builder, opts.Meta.UserTimestamp)
+	if opts.Meta.Signature != "" {
+		serial.CommitAddSignature(builder, sigoff)
+	}
 
 	bytes := serial.FinishMessage(builder, serial.CommitEnd(builder), []byte(serial.CommitFileID))
 	return bytes, maxheight + 1
@@ -217,6 +224,14 @@ func newCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.Valu
 		if err != nil {
 			return nil, err
 		}
+		if opts.Signer != nil {
+			meta := *opts.Meta
+			meta.Signature, err = opts.Signer(CommitSigningPayload(r.TargetHash(), opts.Parents, &meta))
+			if err != nil {
+				return nil, err
+			}
+			opts.Meta = &meta
+		}
 		bs, height := commit_flatbuffer(r.TargetHash(), opts, heights, parentClosureAddr)
 		v := types.SerialMessage(bs)
 		addr, err := v.Hash(vrw.Format())
@@ -226,6 +241,10 @@ func newCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.Valu
 		return &Commit{v, addr, height}, nil
 	}
 
+	if opts.Signer != nil || opts.Meta.Signature != "" {
+		return nil, ErrSigningUnsupported
+	}
+
 	metaSt, err := opts.Meta.toNomsStruct(vrw.Format())
 	if err != nil {
 		return nil, err
@@ -557,6 +576,7 @@ func GetCommitMeta(ctx context.Context, cv types.Value) (*CommitMeta, error) {
 		ret.Description = string(cmsg.Description())
 		ret.Timestamp = cmsg.TimestampMillis()
 		ret.UserTimestamp = cmsg.UserTimestampMillis()
+		ret.Signature = string(cmsg.Signature())
 		return ret, nil
 	}
 	c, ok := cv.(types.Struct)
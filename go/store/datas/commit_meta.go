@@ -51,6 +51,9 @@ type CommitMeta struct {
 	Timestamp     uint64
 	Description   string
 	UserTimestamp int64
+	// Signature is the signature of the commit made by its author's key, or empty if the commit isn't signed. It is
+	// only stored in the __DOLT__ format.
+	Signature string
 }
 
 // NewCommitMeta creates a CommitMeta instance from a name, email, and description and uses the current time for the
@@ -80,7 +83,7 @@ func NewCommitMetaWithUserTS(name, email, desc string, userTS time.Time) (*Commi
 	ms := uint64(CommitNowFunc().UnixMilli())
 	userMS := userTS.UnixMilli()
 
-	return &CommitMeta{n, e, ms, d, userMS}, nil
+	return &CommitMeta{Name: n, Email: e, Timestamp: ms, Description: d, UserTimestamp: userMS}, nil
 }
 
 func getRequiredFromSt(st types.Struct, k string) (types.Value, error) {
@@ -127,11 +130,11 @@ func CommitMetaFromNomsSt(st types.Struct) (*CommitMeta, error) {
 	}
 
 	return &CommitMeta{
-		string(n.(types.String)),
-		string(e.(types.String)),
-		uint64(ts.(types.Uint)),
-		string(d.(types.String)),
-		int64(userTS.(types.Int)),
+		Name:          string(n.(types.String)),
+		Email:         string(e.(types.String)),
+		Timestamp:     uint64(ts.(types.Uint)),
+		Description:   string(d.(types.String)),
+		UserTimestamp: int64(userTS.(types.Int)),
 	}, nil
 }
 
@@ -33,4 +33,8 @@ type CommitOptions struct {
 	Parents []hash.Hash
 
 	Meta *CommitMeta
+
+	// Signer, if provided, signs the commit. Its signature is stored in the
+	// commit's metadata.
+	Signer CommitSigner
 }
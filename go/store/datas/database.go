@@ -39,6 +39,21 @@ type DatasetsMap interface {
 	IterAll(ctx context.Context, cb func(id string, addr hash.Hash) error) error
 }
 
+// DatasetUpdate is the update of a single dataset made by Database.UpdateDatasets.
+type DatasetUpdate struct {
+	// ID is the ID of the dataset to update.
+	ID string
+	// Prev is the address the dataset must have before the update, or the
+	// empty hash if the dataset must not exist.
+	Prev hash.Hash
+	// Head is the address of the new head of the dataset, or the empty hash
+	// to delete the dataset.
+	Head hash.Hash
+	// WorkingSet, if not nil, is written as a new working set and made the
+	// head of the dataset instead of Head.
+	WorkingSet *WorkingSetSpec
+}
+
 // Database provides versioned storage for noms values. While Values can be
 // directly read and written from a Database, it is generally more appropriate
 // to read data by inspecting the Head of a Dataset and write new data by
@@ -127,6 +142,13 @@ type Database interface {
 	// Delete returns an 'ErrMergeNeeded' error.
 	Delete(ctx context.Context, ds Dataset) (Dataset, error)
 
+	// UpdateDatasets atomically applies |updates| to the map at the root of the
+	// Database: either every dataset is updated or none is. Each update is a
+	// compare-and-set of its dataset; if any dataset doesn't have the address
+	// its update expects, no dataset is updated and UpdateDatasets returns an
+	// 'ErrMergeNeeded' error.
+	UpdateDatasets(ctx context.Context, updates []DatasetUpdate) error
+
 	// SetHead ignores any lineage constraints (e.g. the current head being
 	// an ancestor of the new Commit) and force-sets a mapping from
 	// datasetID: addr in this database. addr can point to a Commit or a
@@ -180,7 +202,11 @@ type GarbageCollector interface {
 
 	// GC traverses the database starting at the Root and removes
 	// all unreferenced data from persistent storage.
-	GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error
+	GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepoint types.GCSafepointController) error
+
+	// NewGenGC removes the unreferenced data of the new generation of persistent storage, leaving the old
+	// generation as it is. It only walks the data that isn't in the old generation.
+	NewGenGC(ctx context.Context, refs hash.HashSet, safepoint types.GCSafepointController) error
 }
 
 // CanUsePuller returns true if a datas.Puller can be used to pull data from one Database into another.  Not all
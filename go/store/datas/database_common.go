@@ -35,8 +35,9 @@ import (
 
 type database struct {
 	*types.ValueStore
-	rt rootTracker
-	ns tree.NodeStore
+	rt     rootTracker
+	ns     tree.NodeStore
+	refLog *refUpdateLog
 }
 
 const (
@@ -60,6 +61,7 @@ func newDatabase(vs *types.ValueStore, ns tree.NodeStore) *database {
 		ValueStore: vs, // ValueStore is responsible for closing |cs|
 		rt:         vs,
 		ns:         ns,
+		refLog:     newRefUpdateLog(),
 	}
 }
 
@@ -264,6 +266,7 @@ func (db *database) readHead(ctx context.Context, addr hash.Hash) (dsHead, error
 }
 
 func (db *database) Close() error {
+	db.refLog.close()
 	return db.ValueStore.Close()
 }
 
@@ -785,69 +788,90 @@ func (db *database) CommitWithWorkingSet(
 	return commitDS, workingSetDS, nil
 }
 
-func (db *database) Delete(ctx context.Context, ds Dataset) (Dataset, error) {
-	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID()) })
-}
-
-func (db *database) update(ctx context.Context,
-	edit func(context.Context, types.Map) (types.Map, error),
-	editFB func(context.Context, prolly.AddressMap) (prolly.AddressMap, error)) error {
-	var (
-		err      error
-		root     hash.Hash
-		datasets types.Map
-	)
-	for {
-		root, err = db.rt.Root(ctx)
-		if err != nil {
-			return err
-		}
-
-		var newRootHash hash.Hash
+func (db *database) UpdateDatasets(ctx context.Context, updates []DatasetUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
 
-		if db.Format().UsesFlatbuffers() {
-			datasets, err := db.loadDatasetsRefmap(ctx, root)
+	addrs := make([]hash.Hash, len(updates))
+	refs := make([]types.Ref, len(updates))
+	for i, u := range updates {
+		if u.WorkingSet != nil {
+			addr, ref, err := newWorkingSet(ctx, db, u.WorkingSet.Meta, u.WorkingSet.WorkingRoot, u.WorkingSet.StagedRoot, u.WorkingSet.MergeState)
 			if err != nil {
 				return err
 			}
-
-			datasets, err = editFB(ctx, datasets)
+			addrs[i], refs[i] = addr, ref
+		} else if !u.Head.IsEmpty() {
+			head, err := db.readHead(ctx, u.Head)
 			if err != nil {
 				return err
 			}
-
-			data := storeroot_flatbuffer(datasets)
-			r, err := db.WriteValue(ctx, types.SerialMessage(data))
+			if head == nil {
+				return fmt.Errorf("UpdateDatasets failed: head %s of dataset %s not found", u.Head.String(), u.ID)
+			}
+			ref, err := types.NewRef(head.value(), db.Format())
 			if err != nil {
 				return err
 			}
+			addrs[i], refs[i] = u.Head, ref
+		}
+	}
 
-			newRootHash = r.TargetHash()
-		} else {
-			datasets, err = db.loadDatasetsNomsMap(ctx, root)
+	return db.update(ctx, func(ctx context.Context, datasets types.Map) (types.Map, error) {
+		ed := datasets.Edit()
+		for i, u := range updates {
+			success, err := assertDatasetHash(ctx, datasets, u.ID, u.Prev)
 			if err != nil {
-				return err
+				return types.Map{}, err
 			}
-
-			datasets, err = edit(ctx, datasets)
+			if !success {
+				return types.Map{}, ErrMergeNeeded
+			}
+			if addrs[i].IsEmpty() {
+				ed.Remove(types.String(u.ID))
+			} else {
+				ed.Set(types.String(u.ID), refs[i])
+			}
+		}
+		return ed.Map(ctx)
+	}, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
+		for _, u := range updates {
+			curr, err := am.Get(ctx, u.ID)
 			if err != nil {
-				return err
+				return prolly.AddressMap{}, err
+			}
+			if curr != u.Prev {
+				return prolly.AddressMap{}, ErrMergeNeeded
+			}
+		}
+		ae := am.Editor()
+		for i, u := range updates {
+			var err error
+			if addrs[i].IsEmpty() {
+				err = ae.Delete(ctx, u.ID)
+			} else {
+				err = ae.Update(ctx, u.ID, addrs[i])
 			}
-
-			newRoot, err := db.WriteValue(ctx, datasets)
 			if err != nil {
-				return err
+				return prolly.AddressMap{}, err
 			}
-
-			newRootHash = newRoot.TargetHash()
 		}
+		return ae.Flush(ctx)
+	})
+}
 
-		err = db.tryCommitChunks(ctx, newRootHash, root)
-		if err != ErrOptimisticLockFailed {
-			break
-		}
-	}
-	return err
+func (db *database) Delete(ctx context.Context, ds Dataset) (Dataset, error) {
+	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID()) })
+}
+
+// update applies |edit| or |editFB|, depending on the storage format, to the datasets map of the
+// current root and commits the result. Edits are retried against the latest root until the commit
+// succeeds or an edit returns an error; see refUpdateLog.
+func (db *database) update(ctx context.Context,
+	edit func(context.Context, types.Map) (types.Map, error),
+	editFB func(context.Context, prolly.AddressMap) (prolly.AddressMap, error)) error {
+	return db.refLog.apply(ctx, db, edit, editFB)
 }
 
 func (db *database) doDelete(ctx context.Context, datasetIDstr string) error {
@@ -891,8 +915,12 @@ func (db *database) doDelete(ctx context.Context, datasetIDstr string) error {
 }
 
 // GC traverses the database starting at the Root and removes all unreferenced data from persistent storage.
-func (db *database) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error {
-	return db.ValueStore.GC(ctx, oldGenRefs, newGenRefs, safepointF)
+func (db *database) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepoint types.GCSafepointController) error {
+	return db.ValueStore.GC(ctx, oldGenRefs, newGenRefs, safepoint)
+}
+
+func (db *database) NewGenGC(ctx context.Context, refs hash.HashSet, safepoint types.GCSafepointController) error {
+	return db.ValueStore.NewGenGC(ctx, refs, safepoint)
 }
 
 func (db *database) tryCommitChunks(ctx context.Context, newRootHash hash.Hash, currentRootHash hash.Hash) error {
@@ -23,6 +23,8 @@ package datas
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -657,3 +659,157 @@ func (suite *DatabaseSuite) TestMetaOption() {
 	meta, err := GetCommitMeta(ctx, mustHead(ds))
 	suite.Equal("arv", meta.Name)
 }
+
+func (suite *DatabaseSuite) TestConcurrentCommitsToDifferentDatasets() {
+	ctx := context.Background()
+	const n = 16
+
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ds, err := suite.db.GetDataset(ctx, fmt.Sprintf("ds%d", i))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for j := 0; j < 4; j++ {
+				ds, err = CommitValue(ctx, suite.db, ds, types.String(fmt.Sprintf("%d-%d", i, j)))
+				if err != nil {
+					errs[i] = err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		suite.NoError(errs[i])
+		ds, err := suite.db.GetDataset(ctx, fmt.Sprintf("ds%d", i))
+		suite.NoError(err)
+		suite.True(mustHeadValue(ds).Equals(types.String(fmt.Sprintf("%d-3", i))))
+	}
+}
+
+func (suite *DatabaseSuite) TestRefLogIsolatesFailedEdits() {
+	ctx := context.Background()
+	ds, err := suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	ds, err = CommitValue(ctx, suite.db, ds, types.String("a"))
+	suite.NoError(err)
+	stale := ds
+
+	ds, err = CommitValue(ctx, suite.db, ds, types.String("b"))
+	suite.NoError(err)
+
+	// a commit based on a stale head fails without affecting a concurrent commit to another dataset
+	var wg sync.WaitGroup
+	var staleErr, otherErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, staleErr = CommitValue(ctx, suite.db, stale, types.String("c"))
+	}()
+	go func() {
+		defer wg.Done()
+		other, err := suite.db.GetDataset(ctx, "ds2")
+		if err != nil {
+			otherErr = err
+			return
+		}
+		_, otherErr = CommitValue(ctx, suite.db, other, types.String("d"))
+	}()
+	wg.Wait()
+
+	suite.ErrorIs(staleErr, ErrMergeNeeded)
+	suite.NoError(otherErr)
+
+	ds, err = suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	suite.True(mustHeadValue(ds).Equals(types.String("b")))
+	ds, err = suite.db.GetDataset(ctx, "ds2")
+	suite.NoError(err)
+	suite.True(mustHeadValue(ds).Equals(types.String("d")))
+}
+
+func (suite *DatabaseSuite) TestRefLogDropsCanceledEdits() {
+	ctx := context.Background()
+	ds, err := suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	ds, err = CommitValue(ctx, suite.db, ds, types.String("a"))
+	suite.NoError(err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = CommitValue(canceled, suite.db, ds, types.String("b"))
+	suite.ErrorIs(err, context.Canceled)
+
+	// the canceled edit doesn't fail or hold up later edits
+	other, err := suite.db.GetDataset(ctx, "ds2")
+	suite.NoError(err)
+	_, err = CommitValue(ctx, suite.db, other, types.String("c"))
+	suite.NoError(err)
+
+	ds, err = suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	suite.True(mustHeadValue(ds).Equals(types.String("a")))
+}
+
+func (suite *DatabaseSuite) TestRefLogReportsResultOfCanceledEdits() {
+	ctx := context.Background()
+	const n = 16
+
+	// writers whose contexts are canceled while their edits are pending get an error only if
+	// their edit wasn't committed
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ds, err := suite.db.GetDataset(ctx, fmt.Sprintf("ds%d", i))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			commit, err := suite.db.BuildNewCommit(ctx, ds, types.String(fmt.Sprintf("%d", i)), CommitOptions{})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if _, err = suite.db.WriteValue(ctx, commit.NomsValue()); err != nil {
+				errs[i] = err
+				return
+			}
+			writerCtx, cancel := context.WithCancel(ctx)
+			go cancel()
+			errs[i] = suite.db.doCommit(writerCtx, ds.ID(), hash.Hash{}, commit.NomsValue())
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		ds, err := suite.db.GetDataset(ctx, fmt.Sprintf("ds%d", i))
+		suite.NoError(err)
+		if errs[i] == nil {
+			suite.True(mustHeadValue(ds).Equals(types.String(fmt.Sprintf("%d", i))))
+		} else {
+			suite.ErrorIs(errs[i], context.Canceled)
+			suite.False(ds.HasHead())
+		}
+	}
+}
+
+func (suite *DatabaseSuite) TestRefLogFailsEditsAfterClose() {
+	ctx := context.Background()
+	ds, err := suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+
+	suite.db.refLog.close()
+	_, err = CommitValue(ctx, suite.db, ds, types.String("a"))
+	suite.ErrorIs(err, errDatabaseClosed)
+	suite.False(suite.db.refLog.folding)
+}
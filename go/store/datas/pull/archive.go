@@ -0,0 +1,174 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrInvalidArchive is the error returned from ReadArchive when its input is not an archive written by WriteArchive.
+var ErrInvalidArchive = errors.New("not a valid database archive")
+
+// archiveManifestName is the name of the first entry of an archive, which holds its archiveManifest.
+const archiveManifestName = "manifest.json"
+
+// archiveManifest describes the database an archive was written from. Every table file it lists follows it in the
+// archive, in an entry named by its file id.
+type archiveManifest struct {
+	Version    string             `json:"version"`
+	Root       string             `json:"root"`
+	TableFiles []archiveTableFile `json:"table_files"`
+}
+
+type archiveTableFile struct {
+	ID        string `json:"id"`
+	NumChunks int    `json:"num_chunks"`
+}
+
+// WriteArchive writes the root and table files of |srcCS| to |w| as a tar archive, which can be served as a single
+// file, e.g. from a CDN, and read into an empty database with ReadArchive.
+func WriteArchive(ctx context.Context, srcCS chunks.ChunkStore, w io.Writer) error {
+	srcTS, ok := srcCS.(chunks.TableFileStore)
+	if !ok {
+		return errors.New("src db is not a Table File Store")
+	}
+
+	root, sourceFiles, appendixFiles, err := srcTS.Sources(ctx)
+	if err != nil {
+		return err
+	}
+	tblFiles := filterAppendicesFromSourceFiles(appendixFiles, sourceFiles)
+	if len(tblFiles) == 0 {
+		return ErrNoData
+	}
+
+	m := archiveManifest{Version: srcCS.Version(), Root: root.String()}
+	for _, tf := range tblFiles {
+		m.TableFiles = append(m.TableFiles, archiveTableFile{ID: tf.FileID(), NumChunks: tf.NumChunks()})
+	}
+	bs, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	err = tw.WriteHeader(&tar.Header{Name: archiveManifestName, Mode: 0644, Size: int64(len(bs))})
+	if err != nil {
+		return err
+	}
+	if _, err = tw.Write(bs); err != nil {
+		return err
+	}
+
+	for _, tf := range tblFiles {
+		err = func() error {
+			rd, size, err := tf.Open(ctx)
+			if err != nil {
+				return err
+			}
+			defer rd.Close()
+
+			err = tw.WriteHeader(&tar.Header{Name: tf.FileID(), Mode: 0644, Size: int64(size)})
+			if err != nil {
+				return err
+			}
+			_, err = io.CopyN(tw, rd, int64(size))
+			return err
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// ReadArchive writes the table files of the archive read from |r| to |sinkCS|, and sets its root to the archive's
+// root. |sinkCS| should be empty.
+func ReadArchive(ctx context.Context, r io.Reader, sinkCS chunks.ChunkStore) error {
+	sinkTS, ok := sinkCS.(chunks.TableFileStore)
+	if !ok {
+		return errors.New("sink db is not a Table File Store")
+	}
+
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+	if hdr.Name != archiveManifestName {
+		return fmt.Errorf("%w: first entry is %s, expected %s", ErrInvalidArchive, hdr.Name, archiveManifestName)
+	}
+	var m archiveManifest
+	if err = json.NewDecoder(tr).Decode(&m); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+	root, ok := hash.MaybeParse(m.Root)
+	if !ok {
+		return fmt.Errorf("%w: invalid root %s", ErrInvalidArchive, m.Root)
+	}
+	if m.Version != sinkCS.Version() {
+		return fmt.Errorf("%w; archive version is %v and sink version is %v", ErrFormatMismatch, m.Version, sinkCS.Version())
+	}
+
+	numChunks := make(map[string]int, len(m.TableFiles))
+	for _, tf := range m.TableFiles {
+		numChunks[tf.ID] = tf.NumChunks
+	}
+
+	fileIDToNumChunks := make(map[string]int, len(m.TableFiles))
+	for {
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		n, ok := numChunks[hdr.Name]
+		if !ok {
+			return fmt.Errorf("%w: unexpected entry %s", ErrInvalidArchive, hdr.Name)
+		}
+		read := false
+		err = sinkTS.WriteTableFile(ctx, hdr.Name, n, nil, func() (io.ReadCloser, uint64, error) {
+			// the archive is read as a stream, so table files can't be reread
+			if read {
+				return nil, 0, fmt.Errorf("failed to write table file %s from archive", hdr.Name)
+			}
+			read = true
+			return io.NopCloser(tr), uint64(hdr.Size), nil
+		})
+		if err != nil {
+			return err
+		}
+		fileIDToNumChunks[hdr.Name] = n
+	}
+	if len(fileIDToNumChunks) != len(numChunks) {
+		return fmt.Errorf("%w: archive is missing %d table files", ErrInvalidArchive, len(numChunks)-len(fileIDToNumChunks))
+	}
+
+	if err = sinkTS.AddTableFilesToManifest(ctx, fileIDToNumChunks); err != nil {
+		return err
+	}
+	return sinkTS.SetRootChunk(ctx, root, hash.Hash{})
+}
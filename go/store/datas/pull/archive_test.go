@@ -0,0 +1,92 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/util/clienttest"
+)
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	makeDB := func() (*types.ValueStore, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	vals := make([]types.Value, 1000)
+	for i := range vals {
+		vals[i] = types.String(fmt.Sprintf("archived row %d", i))
+	}
+	l, err := types.NewList(ctx, vs, vals...)
+	require.NoError(t, err)
+	ref, err := vs.WriteValue(ctx, l)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	_, err = datas.CommitValue(ctx, db, ds, ref)
+	require.NoError(t, err)
+
+	srcCS := datas.ChunkStoreFromDatabase(db)
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(ctx, srcCS, &buf))
+
+	t.Run("round trip", func(t *testing.T) {
+		sinkVS, sinkDB := makeDB()
+		sinkCS := datas.ChunkStoreFromDatabase(sinkDB)
+		require.NoError(t, ReadArchive(ctx, bytes.NewReader(buf.Bytes()), sinkCS))
+
+		srcRoot, err := srcCS.Root(ctx)
+		require.NoError(t, err)
+		sinkRoot, err := sinkCS.Root(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, srcRoot, sinkRoot)
+
+		require.NoError(t, sinkVS.Rebase(ctx))
+		v, err := sinkVS.ReadValue(ctx, ref.TargetHash())
+		require.NoError(t, err)
+		assert.True(t, l.Equals(v))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		_, sinkDB := makeDB()
+		err := ReadArchive(ctx, bytes.NewReader(buf.Bytes()[:buf.Len()/2]), datas.ChunkStoreFromDatabase(sinkDB))
+		assert.Error(t, err)
+	})
+	t.Run("not an archive", func(t *testing.T) {
+		_, sinkDB := makeDB()
+		err := ReadArchive(ctx, bytes.NewReader([]byte("not a tar file")), datas.ChunkStoreFromDatabase(sinkDB))
+		assert.True(t, errors.Is(err, ErrInvalidArchive))
+	})
+}
@@ -26,11 +26,13 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 var ErrNoData = errors.New("no data")
 
-func Clone(ctx context.Context, srcCS, sinkCS chunks.ChunkStore, eventCh chan<- TableFileEvent) error {
+// Clone copies the table files of |srcCS| to |sinkCS|, which must be empty. Of the |opts|, only WithTrustedKey applies.
+func Clone(ctx context.Context, srcCS, sinkCS chunks.ChunkStore, eventCh chan<- TableFileEvent, opts ...Option) error {
 	srcTS, srcOK := srcCS.(chunks.TableFileStore)
 
 	if !srcOK {
@@ -53,6 +55,12 @@ func Clone(ctx context.Context, srcCS, sinkCS chunks.ChunkStore, eventCh chan<-
 		return errors.New("sink db is not a Table File Store")
 	}
 
+	if o := newOptions(opts); o.trustedKey != nil {
+		if err = nbs.VerifySources(ctx, srcCS, o.trustedKey); err != nil {
+			return err
+		}
+	}
+
 	return clone(ctx, srcTS, sinkTS, eventCh)
 }
 
@@ -0,0 +1,208 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// When pulling with delta transfer, every chunk being pulled may have a counterpart: a chunk the sink already has
+// which is likely an older version of it. A chunk is fetched as a delta against its counterpart, and the children of
+// the counterpart are the counterparts of the chunk's children, aligned by position. A small edit to a tree changes
+// the chunks on one path from its root, and each of them is aligned with the chunk it replaced.
+
+// chunkGetter fetches |hashes| from the source, calling |found| with each chunk and the number of bytes transferred
+// to get it.
+type chunkGetter func(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.CompressedChunk, int)) error
+
+func (p *Puller) getCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.CompressedChunk, int)) error {
+	return p.srcChunkStore.GetManyCompressed(ctx, hashes, func(ctx context.Context, c nbs.CompressedChunk) {
+		found(ctx, c, len(c.FullCompressedChunk))
+	})
+}
+
+// chunkGetter returns the chunkGetter used to fetch |batch|. With delta transfer, it negotiates which counterparts
+// the sink has and can be used as delta bases.
+func (p *Puller) chunkGetter(ctx context.Context, batch hash.HashSet) (chunkGetter, error) {
+	if p.deltaSrc == nil {
+		return p.getCompressed, nil
+	}
+
+	candidates := make(hash.HashSet)
+	for h := range batch {
+		if base, ok := p.counterparts[h]; ok {
+			candidates.Insert(base)
+		}
+	}
+	absent, err := p.sinkDBCS.HasMany(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	bases := make(map[hash.Hash]hash.Hash, len(batch))
+	for h := range batch {
+		if base, ok := p.counterparts[h]; ok && !absent.Has(base) {
+			bases[h] = base
+		}
+	}
+
+	return func(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.CompressedChunk, int)) error {
+		return p.getDeltas(ctx, hashes, bases, found)
+	}, nil
+}
+
+// getDeltas fetches |hashes| as deltas against |bases|, rebuilding each chunk from its base in the sink.
+func (p *Puller) getDeltas(ctx context.Context, hashes hash.HashSet, bases map[hash.Hash]hash.Hash, found func(context.Context, nbs.CompressedChunk, int)) error {
+	targets := make(map[hash.Hash]hash.Hash, len(hashes))
+	for h := range hashes {
+		targets[h] = bases[h]
+	}
+
+	var mu sync.Mutex
+	var applyErr error
+	err := p.deltaSrc.GetManyDeltas(ctx, targets, func(ctx context.Context, dc nbs.DeltaChunk) {
+		var base chunks.Chunk
+		var err error
+		if dc.IsDelta() {
+			base, err = p.sinkDBCS.Get(ctx, dc.Base)
+		}
+		var cmp nbs.CompressedChunk
+		if err == nil {
+			cmp, err = dc.ToCompressedChunk(base)
+		}
+		if err != nil {
+			mu.Lock()
+			applyErr = err
+			mu.Unlock()
+			return
+		}
+		found(ctx, cmp, dc.Size())
+	})
+	if err != nil {
+		return err
+	}
+	return applyErr
+}
+
+// alignRoots finds the counterparts of the chunks being pulled. A root, usually a commit, is aligned with the child
+// the sink has whose number of children is closest to its own, usually its parent commit.
+func (p *Puller) alignRoots(ctx context.Context) error {
+	for root := range p.hashes {
+		c, err := p.srcChunkStore.Get(ctx, root)
+		if err != nil {
+			return err
+		}
+		children, err := p.childAddrs(c)
+		if err != nil {
+			return err
+		}
+		absent, err := p.sinkDBCS.HasMany(ctx, hash.NewHashSet(children...))
+		if err != nil {
+			return err
+		}
+
+		best, bestDiff := hash.Hash{}, -1
+		for _, h := range children {
+			if absent.Has(h) {
+				continue
+			}
+			cand, err := p.sinkDBCS.Get(ctx, h)
+			if err != nil {
+				return err
+			}
+			candChildren, err := p.childAddrs(cand)
+			if err != nil {
+				return err
+			}
+			diff := len(candChildren) - len(children)
+			if diff < 0 {
+				diff = -diff
+			}
+			if bestDiff == -1 || diff < bestDiff {
+				best, bestDiff = h, diff
+			}
+		}
+		if bestDiff != -1 {
+			p.counterparts[root] = best
+		}
+	}
+	return nil
+}
+
+// alignChildren records the counterparts of the |novel| children of |chnk|, which is being pulled. It must not be
+// called concurrently with chunkGetter.
+func (p *Puller) alignChildren(ctx context.Context, chnk chunks.Chunk, children []hash.Hash, novel hash.HashSet) error {
+	counterpart, ok := p.counterparts[chnk.Hash()]
+	if !ok {
+		return nil
+	}
+	delete(p.counterparts, chnk.Hash())
+	if novel.Size() == 0 {
+		return nil
+	}
+
+	c, err := p.sinkDBCS.Get(ctx, counterpart)
+	if err != nil || c.IsEmpty() {
+		return err
+	}
+	baseChildren, err := p.childAddrs(c)
+	if err != nil {
+		return err
+	}
+
+	for i, h := range children {
+		if !novel.Has(h) {
+			continue
+		}
+		if base, ok := alignedChild(children, baseChildren, i); ok && base != h {
+			p.counterparts[h] = base
+		}
+	}
+	return nil
+}
+
+// alignedChild returns the child of |baseChildren| at the position corresponding to |children|[|i|]. If a child was
+// inserted or removed, children after the first difference are aligned from the end.
+func alignedChild(children, baseChildren []hash.Hash, i int) (hash.Hash, bool) {
+	if len(children) == len(baseChildren) {
+		return baseChildren[i], true
+	}
+
+	prefix := 0
+	for prefix < len(children) && prefix < len(baseChildren) && children[prefix] == baseChildren[prefix] {
+		prefix++
+	}
+	if j := len(baseChildren) - (len(children) - i); j >= prefix && j >= 0 {
+		return baseChildren[j], true
+	}
+	if i < len(baseChildren) {
+		return baseChildren[i], true
+	}
+	return hash.Hash{}, false
+}
+
+func (p *Puller) childAddrs(c chunks.Chunk) ([]hash.Hash, error) {
+	var children []hash.Hash
+	err := p.waf(c, func(h hash.Hash, _ bool) error {
+		children = append(children, h)
+		return nil
+	})
+	return children, err
+}
@@ -0,0 +1,361 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// bloomBitsPerHash and bloomProbes size the bloom filters of spilled runs for a false positive rate of about 1%.
+const (
+	bloomBitsPerHash = 10
+	bloomProbes      = 7
+)
+
+// spillSet is a set of hashes which keeps at most |limit| of them in memory. Beyond that, hashes are written to sorted
+// runs in temporary files. Every run has a bloom filter, so that looking up a hash which isn't in the set rarely
+// reads the disk. Runs are merged as they're written, so that there are only ever logarithmically many of them.
+type spillSet struct {
+	dir   string
+	limit int
+	mem   hash.HashSet
+	runs  []*hashRun
+	size  int
+}
+
+func newSpillSet(dir string, limit int, hashes hash.HashSet) (*spillSet, error) {
+	s := &spillSet{dir: dir, limit: limit, mem: make(hash.HashSet)}
+	for h := range hashes {
+		if err := s.Insert(h); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Size returns the number of hashes in the set.
+func (s *spillSet) Size() int {
+	return s.size
+}
+
+// Has returns true if |h| is in the set.
+func (s *spillSet) Has(h hash.Hash) (bool, error) {
+	if s.mem.Has(h) {
+		return true, nil
+	}
+	for _, r := range s.runs {
+		if ok, err := r.has(h); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Insert adds |h|, which must not already be in the set, to the set.
+func (s *spillSet) Insert(h hash.Hash) error {
+	s.mem.Insert(h)
+	s.size++
+	if s.limit <= 0 || s.mem.Size() < s.limit {
+		return nil
+	}
+
+	hs := make(hash.HashSlice, 0, s.mem.Size())
+	for h := range s.mem {
+		hs = append(hs, h)
+	}
+	sort.Sort(hs)
+	r, err := writeHashRun(s.dir, hs)
+	if err != nil {
+		return err
+	}
+	s.mem = make(hash.HashSet)
+	s.runs = append(s.runs, r)
+
+	// merge runs like the digits of a binary counter, so each hash is rewritten logarithmically many times
+	for len(s.runs) > 1 && s.runs[len(s.runs)-2].count <= s.runs[len(s.runs)-1].count {
+		a, b := s.runs[len(s.runs)-2], s.runs[len(s.runs)-1]
+		merged, err := mergeHashRuns(s.dir, a, b)
+		if err != nil {
+			return err
+		}
+		a.close()
+		b.close()
+		s.runs = append(s.runs[:len(s.runs)-2], merged)
+	}
+	return nil
+}
+
+// Close removes the temporary files of the set.
+func (s *spillSet) Close() {
+	for _, r := range s.runs {
+		r.close()
+	}
+	s.runs = nil
+}
+
+// hashRun is a sorted file of hashes and the bloom filter of its hashes.
+type hashRun struct {
+	f     *os.File
+	count int
+	bloom bloomFilter
+}
+
+func writeHashRun(dir string, hs hash.HashSlice) (*hashRun, error) {
+	r, err := newHashRun(dir, len(hs))
+	if err != nil {
+		return nil, err
+	}
+	wr := bufio.NewWriter(r.f)
+	for _, h := range hs {
+		r.bloom.insert(h)
+		if _, err = wr.Write(h[:]); err != nil {
+			r.close()
+			return nil, err
+		}
+	}
+	if err = wr.Flush(); err != nil {
+		r.close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func newHashRun(dir string, count int) (*hashRun, error) {
+	f, err := os.CreateTemp(dir, "pull_hashes_*")
+	if err != nil {
+		return nil, err
+	}
+	return &hashRun{f: f, count: count, bloom: newBloomFilter(count)}, nil
+}
+
+// mergeHashRuns writes the hashes of |a| and |b| to a new run.
+func mergeHashRuns(dir string, a, b *hashRun) (*hashRun, error) {
+	r, err := newHashRun(dir, a.count+b.count)
+	if err != nil {
+		return nil, err
+	}
+	err = func() error {
+		ra := bufio.NewReader(io.NewSectionReader(a.f, 0, int64(a.count*hash.ByteLen)))
+		rb := bufio.NewReader(io.NewSectionReader(b.f, 0, int64(b.count*hash.ByteLen)))
+		wr := bufio.NewWriter(r.f)
+
+		ha, okA, err := readHash(ra)
+		if err != nil {
+			return err
+		}
+		hb, okB, err := readHash(rb)
+		if err != nil {
+			return err
+		}
+		for okA || okB {
+			var h hash.Hash
+			if okB && (!okA || hb.Less(ha)) {
+				h = hb
+				hb, okB, err = readHash(rb)
+			} else {
+				h = ha
+				ha, okA, err = readHash(ra)
+			}
+			if err != nil {
+				return err
+			}
+			r.bloom.insert(h)
+			if _, err = wr.Write(h[:]); err != nil {
+				return err
+			}
+		}
+		return wr.Flush()
+	}()
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func readHash(rd io.Reader) (h hash.Hash, ok bool, err error) {
+	_, err = io.ReadFull(rd, h[:])
+	if errors.Is(err, io.EOF) {
+		return h, false, nil
+	}
+	return h, err == nil, err
+}
+
+func (r *hashRun) has(h hash.Hash) (bool, error) {
+	if !r.bloom.mayHave(h) {
+		return false, nil
+	}
+
+	var buf hash.Hash
+	var err error
+	i := sort.Search(r.count, func(i int) bool {
+		if err != nil {
+			return true
+		}
+		if _, err = r.f.ReadAt(buf[:], int64(i*hash.ByteLen)); err != nil {
+			return true
+		}
+		return !buf.Less(h)
+	})
+	if err != nil || i == r.count {
+		return false, err
+	}
+	if _, err = r.f.ReadAt(buf[:], int64(i*hash.ByteLen)); err != nil {
+		return false, err
+	}
+	return buf == h, nil
+}
+
+func (r *hashRun) close() {
+	_ = r.f.Close()
+	_ = os.Remove(r.f.Name())
+}
+
+type bloomFilter []uint64
+
+func newBloomFilter(count int) bloomFilter {
+	return make(bloomFilter, (count*bloomBitsPerHash+63)/64)
+}
+
+// probes calls |cb| with the bits of |h|. Hashes are uniformly distributed, so their bytes are used as the hashes of
+// the filter.
+func (b bloomFilter) probes(h hash.Hash, cb func(bit uint64)) {
+	m := uint64(len(b)) * 64
+	x := binary.BigEndian.Uint64(h[0:8])
+	y := binary.BigEndian.Uint64(h[8:16]) | 1
+	for i := uint64(0); i < bloomProbes; i++ {
+		cb((x + i*y) % m)
+	}
+}
+
+func (b bloomFilter) insert(h hash.Hash) {
+	b.probes(h, func(bit uint64) {
+		b[bit/64] |= 1 << (bit % 64)
+	})
+}
+
+func (b bloomFilter) mayHave(h hash.Hash) bool {
+	ok := true
+	b.probes(h, func(bit uint64) {
+		ok = ok && b[bit/64]&(1<<(bit%64)) != 0
+	})
+	return ok
+}
+
+// spillQueue is a pool of hashes which keeps at most |limit| of them in memory. Beyond that, hashes are appended to a
+// temporary file, in segments that are read back once the hashes in memory have been taken.
+type spillQueue struct {
+	dir   string
+	limit int
+	mem   hash.HashSlice
+	f     *os.File
+	// segments are the number of hashes in each segment of |f|
+	segments []int
+	size     int
+}
+
+func newSpillQueue(dir string, limit int, hashes hash.HashSet) (*spillQueue, error) {
+	q := &spillQueue{dir: dir, limit: limit}
+	for h := range hashes {
+		if err := q.Push(h); err != nil {
+			q.Close()
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// Size returns the number of hashes in the queue.
+func (q *spillQueue) Size() int {
+	return q.size
+}
+
+// Push adds |h| to the queue.
+func (q *spillQueue) Push(h hash.Hash) error {
+	q.mem = append(q.mem, h)
+	q.size++
+	if q.limit <= 0 || len(q.mem) < q.limit {
+		return nil
+	}
+
+	if q.f == nil {
+		f, err := os.CreateTemp(q.dir, "pull_queue_*")
+		if err != nil {
+			return err
+		}
+		q.f = f
+	}
+	buf := make([]byte, 0, len(q.mem)*hash.ByteLen)
+	for _, h := range q.mem {
+		buf = append(buf, h[:]...)
+	}
+	if _, err := q.f.WriteAt(buf, int64(q.spilled()*hash.ByteLen)); err != nil {
+		return err
+	}
+	q.segments = append(q.segments, len(q.mem))
+	q.mem = q.mem[:0]
+	return nil
+}
+
+// Pop takes up to |n| hashes from the queue.
+func (q *spillQueue) Pop(n int) (hash.HashSet, error) {
+	if len(q.mem) == 0 && len(q.segments) > 0 {
+		// read back the last segment, and drop it from the file
+		cnt := q.segments[len(q.segments)-1]
+		q.segments = q.segments[:len(q.segments)-1]
+		buf := make([]byte, cnt*hash.ByteLen)
+		if _, err := q.f.ReadAt(buf, int64(q.spilled()*hash.ByteLen)); err != nil {
+			return nil, err
+		}
+		if err := q.f.Truncate(int64(q.spilled() * hash.ByteLen)); err != nil {
+			return nil, err
+		}
+		for i := 0; i < cnt; i++ {
+			q.mem = append(q.mem, hash.New(buf[i*hash.ByteLen:(i+1)*hash.ByteLen]))
+		}
+	}
+
+	if n > len(q.mem) {
+		n = len(q.mem)
+	}
+	batch := hash.NewHashSet(q.mem[len(q.mem)-n:]...)
+	q.mem = q.mem[:len(q.mem)-n]
+	q.size -= n
+	return batch, nil
+}
+
+func (q *spillQueue) spilled() (cnt int) {
+	for _, s := range q.segments {
+		cnt += s
+	}
+	return
+}
+
+// Close removes the temporary file of the queue.
+func (q *spillQueue) Close() {
+	if q.f != nil {
+		_ = q.f.Close()
+		_ = os.Remove(q.f.Name())
+		q.f = nil
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func testHashes(n int) []hash.Hash {
+	hs := make([]hash.Hash, n)
+	for i := range hs {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(i))
+		hs[i] = hash.Of(b[:])
+	}
+	return hs
+}
+
+func TestSpillSet(t *testing.T) {
+	dir := t.TempDir()
+	hs := testHashes(1000)
+
+	s, err := newSpillSet(dir, 16, hash.NewHashSet(hs[:10]...))
+	require.NoError(t, err)
+	defer s.Close()
+	for _, h := range hs[10:500] {
+		require.NoError(t, s.Insert(h))
+	}
+	assert.Equal(t, 500, s.Size())
+	assert.LessOrEqual(t, len(s.runs), 5)
+
+	for i, h := range hs {
+		ok, err := s.Has(h)
+		require.NoError(t, err)
+		assert.Equal(t, i < 500, ok, "hash %d", i)
+	}
+
+	s.Close()
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestSpillQueue(t *testing.T) {
+	dir := t.TempDir()
+	hs := testHashes(1000)
+
+	q, err := newSpillQueue(dir, 16, hash.NewHashSet(hs[:10]...))
+	require.NoError(t, err)
+	defer q.Close()
+	for _, h := range hs[10:] {
+		require.NoError(t, q.Push(h))
+	}
+	assert.Equal(t, len(hs), q.Size())
+
+	popped := make(hash.HashSet)
+	for q.Size() > 0 {
+		b, err := q.Pop(100)
+		require.NoError(t, err)
+		require.NotZero(t, b.Size())
+		assert.LessOrEqual(t, b.Size(), 100)
+		popped.InsertAll(b)
+	}
+	assert.True(t, hash.NewHashSet(hs...).Equals(popped))
+
+	b, err := q.Pop(100)
+	require.NoError(t, err)
+	assert.Zero(t, b.Size())
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"crypto/ed25519"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// Option configures a Puller created with NewPuller, or a Clone.
+type Option func(*options)
+
+// options are the settings of a Puller, as set by the Options it's created with.
+type options struct {
+	verifyChunks      bool
+	reachabilityCheck bool
+	deltaTransfer     bool
+	trustedKey        ed25519.PublicKey
+	codec             nbs.ChunkCodec
+	walkMemLimit      int
+	totals            *TransferTotals
+	shared            *SharedFetch
+}
+
+// defaultWalkMemoryLimit is the number of discovered chunk addresses a Puller keeps in memory when no limit was set.
+const defaultWalkMemoryLimit = 8 * 1024 * 1024
+
+func newOptions(opts []Option) options {
+	o := options{walkMemLimit: defaultWalkMemoryLimit}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithChunkVerification has the Puller recompute the hash of every fetched chunk after decompression, and fail the
+// pull if it does not match the requested address.
+func WithChunkVerification() Option {
+	return func(o *options) {
+		o.verifyChunks = true
+	}
+}
+
+// WithReachabilityCheck has the Puller check that every chunk reachable from the pulled roots is in the sink, or in a
+// table file written by the pull, before the pulled table files are added to the sink's manifest.
+func WithReachabilityCheck() Option {
+	return func(o *options) {
+		o.reachabilityCheck = true
+	}
+}
+
+// WithDeltaTransfer has the Puller fetch chunks as deltas against chunks the sink already has, if the source is a
+// nbs.DeltaChunkSource. Chunks are fetched in full otherwise.
+func WithDeltaTransfer() Option {
+	return func(o *options) {
+		o.deltaTransfer = true
+	}
+}
+
+// WithTrustedKey has the Puller, or Clone, check that the source's root and table files match a manifest attestation
+// signed with |key| before fetching anything. See nbs.ManifestAttestation.
+func WithTrustedKey(key ed25519.PublicKey) Option {
+	return func(o *options) {
+		o.trustedKey = key
+	}
+}
+
+// WithChunkCodec has the Puller recompress chunks that aren't already compressed with |codec| before writing them to
+// the sink. nbs.DefaultCodec writes chunks as they were fetched.
+func WithChunkCodec(codec nbs.ChunkCodec) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// WithWalkMemoryLimit has the Puller keep at most |hashes| of the chunk addresses it discovers walking the source in
+// memory, and spill the others to temporary files. A limit of zero keeps every address in memory. Pullers keep
+// defaultWalkMemoryLimit addresses in memory otherwise.
+func WithWalkMemoryLimit(hashes int) Option {
+	return func(o *options) {
+		o.walkMemLimit = hashes
+	}
+}
+
+// WithTransferTotals has the Puller add the bytes and chunks it transferred to |totals| once its Pull completes. A nil
+// |totals| adds them nowhere.
+func WithTransferTotals(totals *TransferTotals) Option {
+	return func(o *options) {
+		o.totals = totals
+	}
+}
+
+// TransferTotals sums the bytes and chunks transferred by every Puller created with WithTransferTotals, e.g. to
+// record the totals of a fetch or push which runs a Puller for each ref.
+type TransferTotals struct {
+	mu            sync.Mutex
+	fetchedBytes  uint64
+	fetchedChunks uint64
+	sentBytes     uint64
+}
+
+func (t *TransferTotals) add(s Stats) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fetchedBytes += s.FetchedSourceBytes
+	t.fetchedChunks += s.FetchedSourceChunks
+	t.sentBytes += s.FinishedSendBytes
+}
+
+// FetchedBytes returns the bytes of the chunks fetched from the sources of the Pullers.
+func (t *TransferTotals) FetchedBytes() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.fetchedBytes
+}
+
+// FetchedChunks returns the number of chunks fetched from the sources of the Pullers.
+func (t *TransferTotals) FetchedChunks() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.fetchedChunks
+}
+
+// SentBytes returns the bytes of the table files written to the sinks of the Pullers.
+func (t *TransferTotals) SentBytes() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sentBytes
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Phase is one of the stages of work a Puller goes through. Phases overlap: chunks are written to table files and
+// uploaded while the tree walk is still discovering new chunks.
+type Phase int
+
+const (
+	// PhaseTreeWalk walks fetched chunks to discover the addresses of the chunks they reference.
+	PhaseTreeWalk Phase = iota
+	// PhaseChunkFetch fetches chunks from the source chunk store.
+	PhaseChunkFetch
+	// PhaseTableFileWrite writes fetched chunks into temporary table files.
+	PhaseTableFileWrite
+	// PhaseUpload uploads completed table files to the sink chunk store.
+	PhaseUpload
+	// PhaseManifestUpdate adds the uploaded table files to the sink's manifest.
+	PhaseManifestUpdate
+
+	numPhases
+)
+
+// Phases is every Phase in the order that they begin.
+var Phases = []Phase{PhaseTreeWalk, PhaseChunkFetch, PhaseTableFileWrite, PhaseUpload, PhaseManifestUpdate}
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseTreeWalk:
+		return "tree walk"
+	case PhaseChunkFetch:
+		return "chunk fetch"
+	case PhaseTableFileWrite:
+		return "table file write"
+	case PhaseUpload:
+		return "upload"
+	case PhaseManifestUpdate:
+		return "manifest update"
+	default:
+		return "unknown"
+	}
+}
+
+// Unit returns the unit that the Done and Total counts of a PhaseStats for this phase are measured in.
+func (p Phase) Unit() string {
+	switch p {
+	case PhaseUpload:
+		return "bytes"
+	case PhaseManifestUpdate:
+		return "updates"
+	default:
+		return "chunks"
+	}
+}
+
+// PhaseStats is the progress of a single Phase of a pull.
+type PhaseStats struct {
+	Phase Phase
+	// Done is the amount of work completed so far, measured in Phase.Unit().
+	Done uint64
+	// Total is the amount of work known about so far. It can grow as the pull discovers more chunks.
+	Total uint64
+	// Started is true once any work in this phase has begun.
+	Started bool
+	// Finished is true once all work in this phase is complete.
+	Finished bool
+	// ETA is the estimated time remaining in this phase, extrapolated from its rate so far. It is zero if the phase
+	// hasn't started, is finished, or hasn't made enough progress to estimate.
+	ETA time.Duration
+}
+
+// phaseTimes records when each Phase of a pull started and finished, as unix nanos.
+type phaseTimes struct {
+	started  [numPhases]int64
+	finished [numPhases]int64
+}
+
+func (t *phaseTimes) start(p Phase) {
+	atomic.CompareAndSwapInt64(&t.started[p], 0, time.Now().UnixNano())
+}
+
+func (t *phaseTimes) finish(p Phase) {
+	t.start(p)
+	atomic.CompareAndSwapInt64(&t.finished[p], 0, time.Now().UnixNano())
+}
+
+// read returns the PhaseStats for |p| given its current progress.
+func (t *phaseTimes) read(p Phase, done, total uint64) PhaseStats {
+	ps := PhaseStats{Phase: p, Done: done, Total: total}
+	started := atomic.LoadInt64(&t.started[p])
+	ps.Started = started != 0
+	ps.Finished = atomic.LoadInt64(&t.finished[p]) != 0
+	if ps.Started && !ps.Finished && done > 0 && total > done {
+		elapsed := time.Duration(time.Now().UnixNano() - started)
+		ps.ETA = time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+	}
+	return ps
+}
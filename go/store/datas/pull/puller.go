@@ -28,6 +28,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 
@@ -36,6 +40,8 @@ import (
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/store/datas/pull")
+
 // ErrDBUpToDate is the error code returned from NewPuller in the event that there is no work to do.
 var ErrDBUpToDate = errors.New("the database does not need to be pulled as it's already up to date")
 
@@ -43,11 +49,36 @@ var ErrDBUpToDate = errors.New("the database does not need to be pulled as it's
 // the event that the source ChunkStore does not implement `NBSCompressedChunkStore`.
 var ErrIncompatibleSourceChunkStore = errors.New("the chunk store of the source database does not implement NBSCompressedChunkStore.")
 
+// ErrFormatMismatch is the error returned from NewPuller when the source and sink chunk stores are in different
+// storage formats. Chunks can't be copied between formats; pulling across formats requires migrating the pulled
+// values, see migrate.CloneRemote.
+var ErrFormatMismatch = errors.New("cannot pull between databases in different storage formats")
+
+// ErrChunkHashMismatch is the error returned from Pull when chunk verification is enabled and the content of a fetched
+// chunk does not hash to the address it was requested by.
+var ErrChunkHashMismatch = errors.New("fetched chunk does not match its address")
+
+// ErrUnreachableChunk is the error returned from Pull when the reachability check is enabled and a chunk reachable from
+// a pulled root is neither in the sink nor fetched by the pull.
+var ErrUnreachableChunk = errors.New("chunk reachable from a pulled root is missing from the sink")
+
 const (
 	maxChunkWorkers       = 2
 	outstandingTableFiles = 2
+	// maxFetchRetries is the number of times a failed batch fetch from the source is retried before the pull fails.
+	maxFetchRetries = 5
 )
 
+// newFetchBackOff returns the policy used to space out retries of a failed batch fetch. Each batch gets its own
+// policy, so every batch has a budget of |maxFetchRetries| retries.
+var newFetchBackOff = func() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 250 * time.Millisecond
+	b.MaxInterval = 10 * time.Second
+	b.MaxElapsedTime = 0
+	return backoff.WithMaxRetries(b, maxFetchRetries)
+}
+
 // FilledWriters store CmpChunkTableWriter that have been filled and are ready to be flushed.  In the future will likely
 // add the md5 of the data to this structure to be used to verify table upload calls.
 type FilledWriters struct {
@@ -76,8 +107,36 @@ type Puller struct {
 
 	pushLog *log.Logger
 
+	// verifyChunks causes every fetched chunk to be rehashed after decompression. See WithChunkVerification.
+	verifyChunks bool
+
+	// fetched maps every chunk written by this Puller to the chunks it references, and deferred holds the chunks
+	// claimed by other Pullers. Both are only kept when the reachability check is enabled. See WithReachabilityCheck.
+	fetched  map[hash.Hash][]hash.Hash
+	deferred hash.HashSet
+
+	// shared is set when chunks are fetched together with other Pullers. See WithSharedFetch.
+	shared *SharedFetch
+	// claim holds the chunks this Puller claimed in |shared|, and waitFor the claims of other Pullers it skipped
+	// chunks for.
+	claim   *fetchClaim
+	waitFor map[*fetchClaim]struct{}
+
+	// walkMemLimit is the most discovered chunk addresses kept in memory by the walk. See WithWalkMemoryLimit.
+	walkMemLimit int
+
+	// codec is the codec chunks are written to the sink with. See WithChunkCodec.
+	codec nbs.ChunkCodec
+
+	// deltaSrc is set when chunks are fetched as deltas. See WithDeltaTransfer.
+	deltaSrc nbs.DeltaChunkSource
+	// counterparts maps chunks being pulled to a chunk in the sink they are fetched as a delta against.
+	counterparts map[hash.Hash]hash.Hash
+
 	statsCh chan Stats
 	stats   *stats
+	// totals are added the stats of the pull once it completes. See WithTransferTotals.
+	totals *TransferTotals
 }
 
 // NewPuller creates a new Puller instance to do the syncing.  If a nil puller is returned without error that means
@@ -90,7 +149,10 @@ func NewPuller(
 	walkAddrs WalkAddrs,
 	hashes []hash.Hash,
 	statsCh chan Stats,
+	opts ...Option,
 ) (*Puller, error) {
+	o := newOptions(opts)
+
 	// Sanity Check
 	hs := hash.NewHashSet(hashes...)
 	missing, err := srcCS.HasMany(ctx, hs)
@@ -111,7 +173,13 @@ func NewPuller(
 	}
 
 	if srcCS.Version() != sinkCS.Version() {
-		return nil, fmt.Errorf("cannot pull from src to sink; src version is %v and sink version is %v", srcCS.Version(), sinkCS.Version())
+		return nil, fmt.Errorf("%w; src version is %v and sink version is %v", ErrFormatMismatch, srcCS.Version(), sinkCS.Version())
+	}
+
+	if o.trustedKey != nil {
+		if err = nbs.VerifySources(ctx, srcCS, o.trustedKey); err != nil {
+			return nil, err
+		}
 	}
 
 	srcChunkStore, ok := srcCS.(nbs.NBSCompressedChunkStore)
@@ -145,8 +213,28 @@ func NewPuller(
 		wr:            wr,
 		chunksPerTF:   chunksPerTF,
 		pushLog:       pushLogger,
+		verifyChunks:  o.verifyChunks,
+		codec:         o.codec,
+		walkMemLimit:  o.walkMemLimit,
 		statsCh:       statsCh,
 		stats:         &stats{},
+		totals:        o.totals,
+	}
+
+	if ds, ok := srcCS.(nbs.DeltaChunkSource); ok && o.deltaTransfer {
+		p.deltaSrc = ds
+		p.counterparts = make(map[hash.Hash]hash.Hash)
+	}
+
+	if o.reachabilityCheck {
+		p.fetched = make(map[hash.Hash][]hash.Hash)
+		p.deferred = make(hash.HashSet)
+	}
+
+	if o.shared != nil {
+		p.shared = o.shared
+		p.claim = newFetchClaim()
+		p.waitFor = make(map[*fetchClaim]struct{})
 	}
 
 	if lcs, ok := sinkCS.(chunks.LoggingChunkStore); ok {
@@ -276,6 +364,13 @@ type stats struct {
 
 	sendBytesPerSecF          float64
 	fetchedSourceBytesPerSecF float64
+
+	discoveredChunks uint64
+	walkedChunks     uint64
+	writtenChunks    uint64
+	manifestUpdates  uint64
+
+	phases phaseTimes
 }
 
 type Stats struct {
@@ -287,6 +382,9 @@ type Stats struct {
 	FetchedSourceChunks      uint64
 	FetchedSourceBytes       uint64
 	FetchedSourceBytesPerSec float64
+
+	// Phases is the progress of each Phase of the pull, in the order of Phases.
+	Phases []PhaseStats
 }
 
 func (s *stats) read() Stats {
@@ -298,10 +396,22 @@ func (s *stats) read() Stats {
 	ret.FetchedSourceChunks = atomic.LoadUint64(&s.fetchedSourceChunks)
 	ret.FetchedSourceBytes = atomic.LoadUint64(&s.fetchedSourceBytes)
 	ret.FetchedSourceBytesPerSec = math.Float64frombits(atomic.LoadUint64(&s.fetchedSourceBytesPerSec))
+	ret.Phases = []PhaseStats{
+		s.phases.read(PhaseTreeWalk, atomic.LoadUint64(&s.walkedChunks), atomic.LoadUint64(&s.discoveredChunks)),
+		s.phases.read(PhaseChunkFetch, ret.FetchedSourceChunks, ret.TotalSourceChunks),
+		s.phases.read(PhaseTableFileWrite, atomic.LoadUint64(&s.writtenChunks), ret.TotalSourceChunks),
+		s.phases.read(PhaseUpload, ret.FinishedSendBytes, ret.BufferedSendBytes),
+		s.phases.read(PhaseManifestUpdate, atomic.LoadUint64(&s.manifestUpdates), 1),
+	}
 	return ret
 }
 
 func (p *Puller) uploadTempTableFile(ctx context.Context, tmpTblFile tempTblFile) error {
+	ctx, span := tracer.Start(ctx, "puller.uploadTableFile", trace.WithAttributes(
+		attribute.Int("num_chunks", tmpTblFile.numChunks),
+		attribute.Int64("num_bytes", int64(tmpTblFile.contentLen))))
+	defer span.End()
+
 	fileSize := tmpTblFile.contentLen
 	defer func() {
 		_ = tmpTblFile.read.Remove()
@@ -311,6 +421,10 @@ func (p *Puller) uploadTempTableFile(ctx context.Context, tmpTblFile tempTblFile
 	// we can add bytes on to our bufferedSendBytes when
 	// we have to retry a table file write.
 	var localUploaded uint64
+	p.stats.phases.start(PhaseUpload)
+	if rtfw, ok := p.sinkDBCS.(chunks.ResumableTableFileWriter); ok {
+		return p.uploadTempTableFileResumable(ctx, rtfw, tmpTblFile)
+	}
 	return p.sinkDBCS.(chunks.TableFileStore).WriteTableFile(ctx, tmpTblFile.id, tmpTblFile.numChunks, tmpTblFile.contentHash, func() (io.ReadCloser, uint64, error) {
 		rc, err := tmpTblFile.read.Reader()
 		if err != nil {
@@ -333,6 +447,42 @@ func (p *Puller) uploadTempTableFile(ctx context.Context, tmpTblFile tempTblFile
 	})
 }
 
+// uploadTempTableFileResumable uploads |tmpTblFile| with |rtfw|, which may resume a failed upload from the bytes the
+// sink received rather than from the first byte.
+func (p *Puller) uploadTempTableFileResumable(ctx context.Context, rtfw chunks.ResumableTableFileWriter, tmpTblFile tempTblFile) error {
+	fileSize := tmpTblFile.contentLen
+
+	// The bytes of the table file before readOffset + localUploaded have
+	// been counted as finished. When a retry resumes from an earlier
+	// offset, the bytes in between are treated as rebuffered.
+	var started bool
+	var readOffset, localUploaded uint64
+	return rtfw.WriteTableFileResumable(ctx, tmpTblFile.id, tmpTblFile.numChunks, tmpTblFile.contentHash, fileSize, func(offset uint64) (io.ReadCloser, error) {
+		rc, err := tmpTblFile.read.Reader()
+		if err != nil {
+			return nil, err
+		}
+		rc, err = chunks.ReaderFromOffset(rc, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		if !started {
+			// So far, we've added all the bytes for the compressed chunk data.
+			// We add the remaining bytes here --- bytes for the index and the
+			// table file footer.
+			atomic.AddUint64(&p.stats.bufferedSendBytes, fileSize-tmpTblFile.chunksLen)
+			started = true
+		} else if sentTo := readOffset + localUploaded; offset < sentTo {
+			atomic.AddUint64(&p.stats.bufferedSendBytes, sentTo-offset)
+		}
+		readOffset, localUploaded = offset, 0
+		fWithStats := countingReader{countingReader{rc, &localUploaded}, &p.stats.finishedSendBytes}
+
+		return fWithStats, nil
+	})
+}
+
 func (p *Puller) processCompletedTables(ctx context.Context, completedTables <-chan FilledWriters) error {
 	fileIdToNumChunks := make(map[string]int)
 
@@ -372,17 +522,115 @@ LOOP:
 			return ctx.Err()
 		}
 	}
+	p.stats.phases.finish(PhaseUpload)
+
+	if p.fetched != nil {
+		if err := p.checkReachability(ctx); err != nil {
+			return err
+		}
+	}
 
-	return p.sinkDBCS.(chunks.TableFileStore).AddTableFilesToManifest(ctx, fileIdToNumChunks)
+	p.stats.phases.start(PhaseManifestUpdate)
+	err := p.sinkDBCS.(chunks.TableFileStore).AddTableFilesToManifest(ctx, fileIdToNumChunks)
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&p.stats.manifestUpdates, 1)
+	p.stats.phases.finish(PhaseManifestUpdate)
+
+	return nil
+}
+
+// checkReachability walks the chunk graph from the pulled roots and returns ErrUnreachableChunk for the first chunk
+// that is neither written by this pull nor in the sink. Chunks written by this pull are walked through the refs
+// recorded when they were fetched. Chunks the sink already had are read back from it and walked in full, so that a
+// sink left with dangling refs by an earlier, torn pull is caught too. Chunks claimed by other Pullers of a
+// SharedFetch are checked by those Pullers.
+func (p *Puller) checkReachability(ctx context.Context) error {
+	const batchSize = 64 * 1024
+	visited := p.hashes.Copy()
+	next := p.hashes.Copy()
+
+	var mu sync.Mutex
+	var walkErr error
+	for next.Size() > 0 {
+		frontier := make(hash.HashSet)
+		visit := func(h hash.Hash, _ bool) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if !visited.Has(h) {
+				visited.Insert(h)
+				frontier.Insert(h)
+			}
+			return nil
+		}
+
+		inSink := make(hash.HashSet)
+		for h := range next {
+			if refs, ok := p.fetched[h]; ok {
+				for _, r := range refs {
+					_ = visit(r, false)
+				}
+			} else if !p.deferred.Has(h) {
+				inSink.Insert(h)
+			}
+		}
+
+		remainder, batches := batchNovel(inSink, batchSize)
+		for _, b := range append(batches, remainder) {
+			if b.Size() == 0 {
+				continue
+			}
+			missing, err := p.sinkDBCS.HasMany(ctx, b)
+			if err != nil {
+				return err
+			}
+			for h := range missing {
+				return fmt.Errorf("%w: %s", ErrUnreachableChunk, h.String())
+			}
+			err = p.sinkDBCS.GetMany(ctx, b, func(ctx context.Context, c *chunks.Chunk) {
+				if err := p.waf(*c, visit); err != nil {
+					mu.Lock()
+					walkErr = err
+					mu.Unlock()
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if walkErr != nil {
+				return walkErr
+			}
+		}
+		next = frontier
+	}
+	return nil
 }
 
 // Pull executes the sync operation
 func (p *Puller) Pull(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "puller.Pull")
+	defer span.End()
+
 	if p.statsCh != nil {
 		c := emitStats(p.stats, p.statsCh)
 		defer c()
 	}
 
+	err := p.pull(ctx)
+	if p.totals != nil {
+		p.totals.add(p.stats.read())
+	}
+	if p.shared != nil {
+		p.shared.release(p.claim, err)
+		if err == nil {
+			err = p.shared.wait(ctx, p.waitFor)
+		}
+	}
+	return err
+}
+
+func (p *Puller) pull(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	completedTables := make(chan FilledWriters, 8)
@@ -396,32 +644,56 @@ func (p *Puller) Pull(ctx context.Context) error {
 			return err
 		}
 
+		if p.deltaSrc != nil {
+			if err = p.alignRoots(ctx); err != nil {
+				return err
+			}
+		}
+
 		const batchSize = 64 * 1024
 		// refs are added to |visited| on first sight
-		visited := p.hashes
-		// |absent| are visited, un-batched refs
-		absent := p.hashes.Copy()
-		// |batches| are visited, un-fetched refs
-		batches := make([]hash.HashSet, 0, 64)
-
-		for absent.Size() > 0 || len(batches) > 0 {
-			if absent.Size() >= batchSize {
-				var bb []hash.HashSet
-				absent, bb = batchNovel(absent, batchSize)
-				batches = append(batches, bb...)
-			}
-			if len(batches) == 0 {
-				batches = append(batches, absent)
-				absent = make(hash.HashSet)
-			}
+		visited, err := newSpillSet(p.tempDir, p.walkMemLimit, p.hashes)
+		if err != nil {
+			return err
+		}
+		defer visited.Close()
+		atomic.AddUint64(&p.stats.discoveredChunks, uint64(visited.Size()))
+		p.stats.phases.start(PhaseTreeWalk)
+		p.stats.phases.start(PhaseChunkFetch)
+		// |absent| are visited, un-fetched refs
+		absent, err := newSpillQueue(p.tempDir, p.walkMemLimit, p.hashes)
+		if err != nil {
+			return err
+		}
+		defer absent.Close()
 
-			b := batches[len(batches)-1]
-			batches = batches[:len(batches)-1]
+		for absent.Size() > 0 {
+			b, err := absent.Pop(batchSize)
+			if err != nil {
+				return err
+			}
 
+			batchLen := b.Size()
 			b, err = p.sinkDBCS.HasMany(ctx, b)
 			if err != nil {
 				return err
-			} else if b.Size() == 0 {
+			}
+			if p.shared != nil {
+				var unclaimed hash.HashSet
+				if p.deferred != nil {
+					unclaimed = b.Copy()
+				}
+				// chunks claimed by another Puller are walked by it
+				p.shared.claim(b, p.claim, p.waitFor)
+				for h := range unclaimed {
+					if !b.Has(h) {
+						p.deferred.Insert(h)
+					}
+				}
+			}
+			// chunks the sink already has don't need to be walked
+			atomic.AddUint64(&p.stats.walkedChunks, uint64(batchLen-b.Size()))
+			if b.Size() == 0 {
 				continue
 			}
 
@@ -431,6 +703,9 @@ func (p *Puller) Pull(ctx context.Context) error {
 			}
 		}
 
+		p.stats.phases.finish(PhaseTreeWalk)
+		p.stats.phases.finish(PhaseChunkFetch)
+
 		if p.wr != nil && p.wr.ChunkCount() > 0 {
 			select {
 			case completedTables <- FilledWriters{p.wr}:
@@ -438,6 +713,7 @@ func (p *Puller) Pull(ctx context.Context) error {
 				return ctx.Err()
 			}
 		}
+		p.stats.phases.finish(PhaseTableFileWrite)
 		close(completedTables)
 		return nil
 	})
@@ -445,6 +721,47 @@ func (p *Puller) Pull(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// fetchBatch gets every chunk in |batch| from the source with |get|, calling |found| exactly once per chunk. A failed
+// fetch is retried with backoff, asking only for the chunks that have not been received yet. The error is returned
+// once the batch's retry budget is exhausted, or immediately if it is permanent.
+func (p *Puller) fetchBatch(ctx context.Context, batch hash.HashSet, get chunkGetter, found func(nbs.CompressedChunk, int)) error {
+	var mu sync.Mutex
+	pending := batch.Copy()
+
+	op := func() error {
+		mu.Lock()
+		req := pending.Copy()
+		mu.Unlock()
+
+		err := get(ctx, req, func(ctx context.Context, c nbs.CompressedChunk, size int) {
+			mu.Lock()
+			novel := pending.Has(c.H)
+			pending.Remove(c.H)
+			mu.Unlock()
+			// a retried request may race with chunks still arriving from the failed one
+			if novel {
+				found(c, size)
+			}
+		})
+		if err != nil && ctx.Err() != nil {
+			return backoff.Permanent(ctx.Err())
+		}
+		return err
+	}
+
+	notify := func(err error, d time.Duration) {
+		mu.Lock()
+		remaining := pending.Size()
+		mu.Unlock()
+		p.Logf("fetching %d of %d chunks failed, retrying in %v: %v", remaining, batch.Size(), d, err)
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.Int("remaining", remaining),
+			attribute.String("error", err.Error())))
+	}
+
+	return backoff.RetryNotify(op, backoff.WithContext(newFetchBackOff(), ctx), notify)
+}
+
 // batchNovel returns a slice of |batch| size HashSets and partial |remainder| HashSet.
 func batchNovel(absent hash.HashSet, batch int) (remainder hash.HashSet, batches []hash.HashSet) {
 	curr := make(hash.HashSet, batch)
@@ -459,15 +776,23 @@ func batchNovel(absent hash.HashSet, batch int) (remainder hash.HashSet, batches
 	return
 }
 
-func (p *Puller) getCmp(ctx context.Context, batch, absent, visited hash.HashSet, completedTables chan FilledWriters) error {
+func (p *Puller) getCmp(ctx context.Context, batch hash.HashSet, absent *spillQueue, visited *spillSet, completedTables chan FilledWriters) error {
+	ctx, span := tracer.Start(ctx, "puller.getCmp", trace.WithAttributes(attribute.Int("num_hashes", len(batch))))
+	defer span.End()
+
 	found := make(chan nbs.CompressedChunk, 4096)
 	processed := make(chan CmpChnkAndRefs, 4096)
 
+	get, err := p.chunkGetter(ctx, batch)
+	if err != nil {
+		return err
+	}
+
 	atomic.AddUint64(&p.stats.totalSourceChunks, uint64(len(batch)))
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
-		err := p.srcChunkStore.GetManyCompressed(ctx, batch, func(ctx context.Context, c nbs.CompressedChunk) {
-			atomic.AddUint64(&p.stats.fetchedSourceBytes, uint64(len(c.FullCompressedChunk)))
+		err := p.fetchBatch(ctx, batch, get, func(c nbs.CompressedChunk, size int) {
+			atomic.AddUint64(&p.stats.fetchedSourceBytes, uint64(size))
 			atomic.AddUint64(&p.stats.fetchedSourceChunks, uint64(1))
 			select {
 			case found <- c:
@@ -494,17 +819,49 @@ func (p *Puller) getCmp(ctx context.Context, batch, absent, visited hash.HashSet
 				if err != nil {
 					return err
 				}
+				if p.verifyChunks {
+					if actual := hash.Of(chnk.Data()); actual != cmpChnk.H {
+						return fmt.Errorf("%w: expected %s, got %s", ErrChunkHashMismatch, cmpChnk.H.String(), actual.String())
+					}
+				}
+				var children []hash.Hash
+				novel := make(hash.HashSet)
 				err = p.waf(chnk, func(h hash.Hash, _ bool) error {
-					if !visited.Has(h) {
+					if p.deltaSrc != nil || p.fetched != nil {
+						children = append(children, h)
+					}
+					seen, err := visited.Has(h)
+					if err != nil {
+						return err
+					}
+					if !seen {
 						// first sight of |h|
-						visited.Insert(h)
-						absent.Insert(h)
+						if err = visited.Insert(h); err != nil {
+							return err
+						}
+						if err = absent.Push(h); err != nil {
+							return err
+						}
+						novel.Insert(h)
+						atomic.AddUint64(&p.stats.discoveredChunks, 1)
 					}
 					return nil
 				})
 				if err != nil {
 					return err
 				}
+				if p.fetched != nil {
+					p.fetched[cmpChnk.H] = children
+				}
+				if p.deltaSrc != nil {
+					if err = p.alignChildren(ctx, chnk, children, novel); err != nil {
+						return err
+					}
+				}
+				atomic.AddUint64(&p.stats.walkedChunks, 1)
+				if p.codec != nbs.DefaultCodec && !chnk.IsEmpty() && cmpChnk.Codec() != p.codec {
+					cmpChnk = nbs.ChunkToCompressedChunkWithCodec(chnk, p.codec)
+				}
 				select {
 				case processed <- CmpChnkAndRefs{cmpChnk: cmpChnk}:
 				case <-ctx.Done():
@@ -530,10 +887,12 @@ func (p *Puller) getCmp(ctx context.Context, batch, absent, visited hash.HashSet
 				}
 				seen++
 
+				p.stats.phases.start(PhaseTableFileWrite)
 				err := p.wr.AddCmpChunk(cmpAndRef.cmpChnk)
 				if err != nil {
 					return err
 				}
+				atomic.AddUint64(&p.stats.writtenChunks, 1)
 
 				atomic.AddUint64(&p.stats.bufferedSendBytes, uint64(len(cmpAndRef.cmpChnk.FullCompressedChunk)))
 
@@ -566,7 +925,7 @@ func (p *Puller) getCmp(ctx context.Context, batch, absent, visited hash.HashSet
 		return nil
 	})
 
-	err := eg.Wait()
+	err = eg.Wait()
 	if err != nil {
 		return err
 	}
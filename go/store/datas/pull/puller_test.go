@@ -18,15 +18,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
 	"testing"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/d"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -315,9 +319,11 @@ func testPuller(t *testing.T, makeDB datasFactory) {
 			statsCh := make(chan Stats, 16)
 			wg := new(sync.WaitGroup)
 			wg.Add(1)
+			var lastStats Stats
 			go func() {
 				defer wg.Done()
 				for evt := range statsCh {
+					lastStats = evt
 					jsonBytes, err := json.Marshal(evt)
 					if err == nil {
 						t.Logf("stats: %s\n", string(jsonBytes))
@@ -341,6 +347,14 @@ func testPuller(t *testing.T, makeDB datasFactory) {
 			require.NoError(t, err)
 			wg.Wait()
 
+			require.Len(t, lastStats.Phases, len(Phases))
+			for i, ps := range lastStats.Phases {
+				assert.Equal(t, Phases[i], ps.Phase)
+				assert.True(t, ps.Finished, "phase %s not finished", ps.Phase)
+				assert.Equal(t, ps.Total, ps.Done, "phase %s incomplete", ps.Phase)
+				assert.Zero(t, ps.ETA)
+			}
+
 			sinkDS, err := sinkdb.GetDataset(ctx, "ds")
 			require.NoError(t, err)
 			sinkDS, err = sinkdb.FastForward(ctx, sinkDS, rootAddr)
@@ -424,3 +438,413 @@ func mustTuple(val types.Tuple, err error) types.Tuple {
 	d.PanicIfError(err)
 	return val
 }
+
+// corruptingChunkStore returns the compressed form of |replacement| in place of the chunk at |target|.
+type corruptingChunkStore struct {
+	nbs.NBSCompressedChunkStore
+	target      hash.Hash
+	replacement chunks.Chunk
+}
+
+func (cs corruptingChunkStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.CompressedChunk)) error {
+	return cs.NBSCompressedChunkStore.GetManyCompressed(ctx, hashes, func(ctx context.Context, c nbs.CompressedChunk) {
+		if c.H == cs.target {
+			c = nbs.ChunkToCompressedChunk(cs.replacement)
+			c.H = cs.target
+		}
+		found(ctx, c)
+	})
+}
+
+func TestPullerChunkVerification(t *testing.T) {
+	ctx := context.Background()
+	makeDB := func() (types.ValueReadWriter, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	ref, err := vs.WriteValue(ctx, types.String("pulled value"))
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = datas.CommitValue(ctx, db, ds, ref)
+	require.NoError(t, err)
+	rootAddr, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+	replacement, err := types.EncodeValue(types.String("corrupted value"), vs.Format())
+	require.NoError(t, err)
+
+	srcCS := corruptingChunkStore{
+		NBSCompressedChunkStore: datas.ChunkStoreFromDatabase(db).(nbs.NBSCompressedChunkStore),
+		target:                  ref.TargetHash(),
+		replacement:             replacement,
+	}
+	waf, err := types.WalkAddrsForChunkStore(srcCS)
+	require.NoError(t, err)
+
+	pull := func(opts ...Option) error {
+		_, sinkdb := makeDB()
+		tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(tmpDir, os.ModePerm))
+		plr, err := NewPuller(ctx, tmpDir, 128, srcCS, datas.ChunkStoreFromDatabase(sinkdb), waf, []hash.Hash{rootAddr}, nil, opts...)
+		require.NoError(t, err)
+		return plr.Pull(ctx)
+	}
+
+	t.Run("unverified", func(t *testing.T) {
+		require.NoError(t, pull())
+	})
+	t.Run("verified", func(t *testing.T) {
+		err := pull(WithChunkVerification())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrChunkHashMismatch))
+		assert.Contains(t, err.Error(), ref.TargetHash().String())
+	})
+}
+
+var errTransientFetch = errors.New("transient fetch error")
+
+// flakyChunkStore fails its first |failures| GetManyCompressed calls after delivering one chunk of the request.
+type flakyChunkStore struct {
+	nbs.NBSCompressedChunkStore
+	mu       *sync.Mutex
+	failures *int
+}
+
+func (cs flakyChunkStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, nbs.CompressedChunk)) error {
+	cs.mu.Lock()
+	fail := *cs.failures > 0
+	if fail {
+		*cs.failures--
+	}
+	cs.mu.Unlock()
+	if !fail {
+		return cs.NBSCompressedChunkStore.GetManyCompressed(ctx, hashes, found)
+	}
+
+	for h := range hashes {
+		err := cs.NBSCompressedChunkStore.GetManyCompressed(ctx, hash.NewHashSet(h), found)
+		if err != nil {
+			return err
+		}
+		break
+	}
+	return errTransientFetch
+}
+
+func TestPullerFetchRetries(t *testing.T) {
+	defer func(f func() backoff.BackOff) { newFetchBackOff = f }(newFetchBackOff)
+	newFetchBackOff = func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, maxFetchRetries)
+	}
+
+	ctx := context.Background()
+	makeDB := func() (types.ValueReadWriter, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	l, err := types.NewList(ctx, vs, types.String("a"), types.String("b"), types.String("c"))
+	require.NoError(t, err)
+	ref, err := vs.WriteValue(ctx, l)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = datas.CommitValue(ctx, db, ds, ref)
+	require.NoError(t, err)
+	rootAddr, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+
+	pull := func(failures int) (datas.Database, error) {
+		srcCS := flakyChunkStore{
+			NBSCompressedChunkStore: datas.ChunkStoreFromDatabase(db).(nbs.NBSCompressedChunkStore),
+			mu:                      &sync.Mutex{},
+			failures:                &failures,
+		}
+		waf, err := types.WalkAddrsForChunkStore(srcCS)
+		require.NoError(t, err)
+		_, sinkdb := makeDB()
+		tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(tmpDir, os.ModePerm))
+		plr, err := NewPuller(ctx, tmpDir, 128, srcCS, datas.ChunkStoreFromDatabase(sinkdb), waf, []hash.Hash{rootAddr}, nil)
+		require.NoError(t, err)
+		return sinkdb, plr.Pull(ctx)
+	}
+
+	t.Run("within budget", func(t *testing.T) {
+		sinkdb, err := pull(maxFetchRetries)
+		require.NoError(t, err)
+		present, err := datas.ChunkStoreFromDatabase(sinkdb).Has(ctx, ref.TargetHash())
+		require.NoError(t, err)
+		assert.True(t, present)
+	})
+	t.Run("budget exhausted", func(t *testing.T) {
+		_, err := pull(math.MaxInt32)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errTransientFetch))
+	})
+}
+
+func TestPullerDeltaTransfer(t *testing.T) {
+	ctx := context.Background()
+	makeDB := func() (types.ValueReadWriter, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	vals := make([]types.Value, 5000)
+	for i := range vals {
+		vals[i] = types.String(fmt.Sprintf("row %d of a list that is large enough to span many chunks", i))
+	}
+	l1, err := types.NewList(ctx, vs, vals...)
+	require.NoError(t, err)
+	commit := func(v types.Value) hash.Hash {
+		ref, err := vs.WriteValue(ctx, v)
+		require.NoError(t, err)
+		ds, err := db.GetDataset(ctx, "ds")
+		require.NoError(t, err)
+		ds, err = datas.CommitValue(ctx, db, ds, ref)
+		require.NoError(t, err)
+		addr, ok := ds.MaybeHeadAddr()
+		require.True(t, ok)
+		return addr
+	}
+	// commits are aligned by position with their parents, so |c1| has a parent like |c2|
+	commit(types.String("an initial value"))
+	c1 := commit(l1)
+	l2, err := l1.Edit().Set(2500, types.String("an edited row")).List(ctx)
+	require.NoError(t, err)
+	c2 := commit(l2)
+
+	srcCS := datas.ChunkStoreFromDatabase(db)
+	waf, err := types.WalkAddrsForChunkStore(srcCS)
+	require.NoError(t, err)
+	pull := func(sinkCS chunks.ChunkStore, root hash.Hash, opts ...Option) uint64 {
+		tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(tmpDir, os.ModePerm))
+		plr, err := NewPuller(ctx, tmpDir, 128, srcCS, sinkCS, waf, []hash.Hash{root}, nil, opts...)
+		require.NoError(t, err)
+		require.NoError(t, plr.Pull(ctx))
+		return plr.stats.read().FetchedSourceBytes
+	}
+
+	fullVS, fullDB := makeDB()
+	deltaVS, deltaDB := makeDB()
+	pull(datas.ChunkStoreFromDatabase(fullDB), c1)
+	pull(datas.ChunkStoreFromDatabase(deltaDB), c1)
+
+	fullBytes := pull(datas.ChunkStoreFromDatabase(fullDB), c2)
+	deltaBytes := pull(datas.ChunkStoreFromDatabase(deltaDB), c2, WithDeltaTransfer())
+	assert.Less(t, deltaBytes*2, fullBytes)
+
+	for _, sinkVS := range []types.ValueReadWriter{fullVS, deltaVS} {
+		v, err := sinkVS.ReadValue(ctx, c2)
+		require.NoError(t, err)
+		require.NotNil(t, v)
+		ref, err := types.NewRef(l2, vs.Format())
+		require.NoError(t, err)
+		pulled, err := sinkVS.ReadValue(ctx, ref.TargetHash())
+		require.NoError(t, err)
+		assert.True(t, l2.Equals(pulled))
+	}
+}
+
+func TestPullerSharedFetch(t *testing.T) {
+	ctx := context.Background()
+	makeDB := func() (types.ValueReadWriter, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	vals := make([]types.Value, 5000)
+	for i := range vals {
+		vals[i] = types.String(fmt.Sprintf("row %d of a list that is large enough to span many chunks", i))
+	}
+	l, err := types.NewList(ctx, vs, vals...)
+	require.NoError(t, err)
+	ref, err := vs.WriteValue(ctx, l)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = datas.CommitValue(ctx, db, ds, ref)
+	require.NoError(t, err)
+	root, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+
+	srcCS := datas.ChunkStoreFromDatabase(db)
+	waf, err := types.WalkAddrsForChunkStore(srcCS)
+	require.NoError(t, err)
+	newPuller := func(sinkCS chunks.ChunkStore, opts ...Option) *Puller {
+		tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(tmpDir, os.ModePerm))
+		plr, err := NewPuller(ctx, tmpDir, 128, srcCS, sinkCS, waf, []hash.Hash{root}, nil, opts...)
+		require.NoError(t, err)
+		return plr
+	}
+
+	_, soloDB := makeDB()
+	solo := newPuller(datas.ChunkStoreFromDatabase(soloDB))
+	require.NoError(t, solo.Pull(ctx))
+	soloChunks := solo.stats.read().FetchedSourceChunks
+
+	sharedVS, sharedDB := makeDB()
+	sinkCS := datas.ChunkStoreFromDatabase(sharedDB)
+	sf := NewSharedFetch()
+	pullers := []*Puller{newPuller(sinkCS, WithSharedFetch(sf)), newPuller(sinkCS, WithSharedFetch(sf))}
+	var wg sync.WaitGroup
+	errs := make([]error, len(pullers))
+	for i := range pullers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = pullers[i].Pull(ctx)
+		}(i)
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+
+	sharedChunks := pullers[0].stats.read().FetchedSourceChunks + pullers[1].stats.read().FetchedSourceChunks
+	assert.Equal(t, soloChunks, sharedChunks)
+
+	pulled, err := sharedVS.ReadValue(ctx, ref.TargetHash())
+	require.NoError(t, err)
+	assert.True(t, l.Equals(pulled))
+}
+
+func TestPullerReachabilityCheck(t *testing.T) {
+	ctx := context.Background()
+	makeDB := func() (types.ValueReadWriter, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	// a list of refs, so that the chunk of the list references the chunks of its values
+	vals := make([]types.Value, 100)
+	for i := range vals {
+		r, err := vs.WriteValue(ctx, types.String(fmt.Sprintf("row %d of a list whose values are stored in their own chunks", i)))
+		require.NoError(t, err)
+		vals[i] = r
+	}
+	l, err := types.NewList(ctx, vs, vals...)
+	require.NoError(t, err)
+	ref, err := vs.WriteValue(ctx, l)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = datas.CommitValue(ctx, db, ds, ref)
+	require.NoError(t, err)
+	root, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+
+	srcCS := datas.ChunkStoreFromDatabase(db)
+	waf, err := types.WalkAddrsForChunkStore(srcCS)
+	require.NoError(t, err)
+	listChunk, err := srcCS.Get(ctx, ref.TargetHash())
+	require.NoError(t, err)
+
+	// a sink torn by an earlier pull, which has the root of the list but none of its children
+	tornSink := func() chunks.ChunkStore {
+		_, sinkdb := makeDB()
+		sinkCS := datas.ChunkStoreFromDatabase(sinkdb)
+		err := sinkCS.Put(ctx, listChunk, func(context.Context, chunks.Chunk) (hash.HashSet, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return sinkCS
+	}
+	pull := func(sinkCS chunks.ChunkStore, opts ...Option) error {
+		tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(tmpDir, os.ModePerm))
+		plr, err := NewPuller(ctx, tmpDir, 128, srcCS, sinkCS, waf, []hash.Hash{root}, nil, opts...)
+		require.NoError(t, err)
+		return plr.Pull(ctx)
+	}
+
+	t.Run("complete sink", func(t *testing.T) {
+		_, sinkdb := makeDB()
+		require.NoError(t, pull(datas.ChunkStoreFromDatabase(sinkdb), WithReachabilityCheck()))
+	})
+	t.Run("unchecked", func(t *testing.T) {
+		require.NoError(t, pull(tornSink()))
+	})
+	t.Run("checked", func(t *testing.T) {
+		sinkCS := tornSink()
+		err := pull(sinkCS, WithReachabilityCheck())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrUnreachableChunk))
+		ok, err := sinkCS.Has(ctx, root)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestPullerWalkMemoryLimit(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, 4, newOptions([]Option{WithWalkMemoryLimit(4)}).walkMemLimit)
+	assert.Equal(t, defaultWalkMemoryLimit, newOptions(nil).walkMemLimit)
+
+	makeDB := func() (types.ValueReadWriter, datas.Database) {
+		dir := filepath.Join(os.TempDir(), uuid.New().String())
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		vs := types.NewValueStore(st)
+		return vs, datas.NewTypesDatabase(vs, tree.NewNodeStore(st))
+	}
+
+	vs, db := makeDB()
+	vals := make([]types.Value, 5000)
+	for i := range vals {
+		vals[i] = types.String(fmt.Sprintf("row %d of a list that is large enough to span many chunks", i))
+	}
+	l, err := types.NewList(ctx, vs, vals...)
+	require.NoError(t, err)
+	ref, err := vs.WriteValue(ctx, l)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = datas.CommitValue(ctx, db, ds, ref)
+	require.NoError(t, err)
+	rootAddr, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+
+	srcCS := datas.ChunkStoreFromDatabase(db)
+	waf, err := types.WalkAddrsForChunkStore(srcCS)
+	require.NoError(t, err)
+	sinkVS, sinkDB := makeDB()
+	tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+	require.NoError(t, os.MkdirAll(tmpDir, os.ModePerm))
+	plr, err := NewPuller(ctx, tmpDir, 128, srcCS, datas.ChunkStoreFromDatabase(sinkDB), waf, []hash.Hash{rootAddr}, nil, WithWalkMemoryLimit(4))
+	require.NoError(t, err)
+	require.NoError(t, plr.Pull(ctx))
+
+	pulled, err := sinkVS.ReadValue(ctx, ref.TargetHash())
+	require.NoError(t, err)
+	assert.True(t, l.Equals(pulled))
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// SharedFetch coordinates Pullers that pull into the same sink concurrently, e.g. when fetching the same refs from
+// several remotes at once, so that a chunk reachable from more than one of them is only fetched once. The first
+// Puller to reach a chunk claims it, and the others skip it. A Puller that skipped chunks claimed by others waits
+// for them to be written to the sink before its Pull returns, so that the sink is never left with dangling refs.
+type SharedFetch struct {
+	mu     sync.Mutex
+	claims map[hash.Hash]*fetchClaim
+}
+
+// fetchClaim is held by a Puller for the chunks it claimed. |done| is closed once they are in the sink or the
+// Puller failed, in which case |err| is set.
+type fetchClaim struct {
+	owned hash.HashSet
+	done  chan struct{}
+	err   error
+}
+
+// NewSharedFetch returns a SharedFetch to coordinate concurrent Pullers into the same sink.
+func NewSharedFetch() *SharedFetch {
+	return &SharedFetch{claims: make(map[hash.Hash]*fetchClaim)}
+}
+
+// WithSharedFetch has the Puller share the chunks it fetches with the other Pullers created with |sf|.
+func WithSharedFetch(sf *SharedFetch) Option {
+	return func(o *options) {
+		o.shared = sf
+	}
+}
+
+func newFetchClaim() *fetchClaim {
+	return &fetchClaim{owned: make(hash.HashSet), done: make(chan struct{})}
+}
+
+// claim removes the chunks in |batch| that other Pullers have claimed, adding their claims to |waitFor|, and claims
+// the rest for |c|.
+func (sf *SharedFetch) claim(batch hash.HashSet, c *fetchClaim, waitFor map[*fetchClaim]struct{}) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	for h := range batch {
+		other, ok := sf.claims[h]
+		if !ok {
+			sf.claims[h] = c
+			c.owned.Insert(h)
+		} else if other != c {
+			waitFor[other] = struct{}{}
+			batch.Remove(h)
+		}
+	}
+}
+
+// release marks the chunks claimed by |c| as done, with the result |err| of the Pull that claimed them.
+func (sf *SharedFetch) release(c *fetchClaim, err error) {
+	sf.mu.Lock()
+	for h := range c.owned {
+		delete(sf.claims, h)
+	}
+	sf.mu.Unlock()
+
+	c.err = err
+	close(c.done)
+}
+
+// wait blocks until every claim in |waitFor| is released, and returns an error if any of them failed.
+func (sf *SharedFetch) wait(ctx context.Context, waitFor map[*fetchClaim]struct{}) error {
+	for c := range waitFor {
+		select {
+		case <-c.done:
+			if c.err != nil {
+				return fmt.Errorf("a concurrent fetch of shared chunks failed: %w", c.err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// errDatabaseClosed is returned for edits made to a database after it's closed.
+var errDatabaseClosed = errors.New("database is closed")
+
+// refUpdateLog decouples concurrent writers of datasets. Rather than each writer loading
+// the root, editing it and racing every other writer to commit a new root, writers append
+// their edit to the log, and a single background folder drains the log and folds the
+// pending edits into one new root. Each edit still sees and checks the latest value of its
+// datasets, so per-ref semantics are unchanged, but N concurrent writers of N branches
+// produce one root update instead of up to N² failed ones.
+type refUpdateLog struct {
+	// mu guards |pending|, |folding| and |closed|. |folding| is set while the folder is
+	// running, and |closed| once the database is closed.
+	mu      sync.Mutex
+	pending []*refUpdate
+	folding bool
+	closed  bool
+
+	// ctx is the context edits are folded under. It's canceled when the database closes,
+	// which stops the folder; |wg| waits for it to exit.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type refUpdate struct {
+	// ctx is the context of the writer, an edit is dropped if it's done before the edit is
+	// committed. Edits are applied and committed with the folder's own context.
+	ctx    context.Context
+	edit   func(context.Context, types.Map) (types.Map, error)
+	editFB func(context.Context, prolly.AddressMap) (prolly.AddressMap, error)
+
+	err  error
+	done chan struct{}
+}
+
+func newRefUpdateLog() *refUpdateLog {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &refUpdateLog{ctx: ctx, cancel: cancel}
+}
+
+// apply appends an edit to the log and returns once it has been committed to the root, or
+// failed. If |ctx| is done before the folder takes the edit, the edit is removed from the
+// log and apply returns the error of |ctx|. Otherwise apply waits for the folder and returns
+// the edit's result, so that an error is never returned for an edit which was committed.
+func (l *refUpdateLog) apply(ctx context.Context, db *database,
+	edit func(context.Context, types.Map) (types.Map, error),
+	editFB func(context.Context, prolly.AddressMap) (prolly.AddressMap, error)) error {
+	u := &refUpdate{ctx: ctx, edit: edit, editFB: editFB, done: make(chan struct{})}
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return errDatabaseClosed
+	}
+	l.pending = append(l.pending, u)
+	if !l.folding {
+		l.folding = true
+		l.wg.Add(1)
+		go l.foldAll(db)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-u.done:
+		return u.err
+	case <-ctx.Done():
+	}
+	if l.remove(u) {
+		return ctx.Err()
+	}
+	<-u.done
+	return u.err
+}
+
+// remove removes |u| from the pending edits, returning false if the folder already took it.
+func (l *refUpdateLog) remove(u *refUpdate) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, p := range l.pending {
+		if p == u {
+			l.pending = append(l.pending[:i], l.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// foldAll folds the log into the root until it's empty. Edits are folded under the log's own
+// context, so that a writer going away doesn't fail the edits of the others.
+func (l *refUpdateLog) foldAll(db *database) {
+	defer l.wg.Done()
+	for {
+		l.mu.Lock()
+		batch := l.pending
+		l.pending = nil
+		if len(batch) == 0 {
+			l.folding = false
+			l.mu.Unlock()
+			return
+		}
+		l.mu.Unlock()
+
+		db.fold(l.ctx, batch)
+	}
+}
+
+// close stops the folder and waits for it to exit. Edits being folded fail with the error of
+// the canceled context, and edits made after close fail with errDatabaseClosed.
+func (l *refUpdateLog) close() {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	l.cancel()
+	l.wg.Wait()
+}
+
+// fold applies |batch| to the current root and commits the result, retrying edits which
+// succeeded if another writer moved the root in the meantime. An edit which returns an error,
+// or whose writer's context is done, is dropped from the batch and its error is reported to
+// its writer, without affecting the other edits.
+func (db *database) fold(ctx context.Context, batch []*refUpdate) {
+	all := batch
+	defer func() {
+		for _, u := range all {
+			close(u.done)
+		}
+	}()
+
+	for {
+		root, err := db.rt.Root(ctx)
+		if err != nil {
+			failAll(batch, err)
+			return
+		}
+
+		var applied []*refUpdate
+		var newRootHash hash.Hash
+		if db.Format().UsesFlatbuffers() {
+			datasets, err := db.loadDatasetsRefmap(ctx, root)
+			if err != nil {
+				failAll(batch, err)
+				return
+			}
+			for _, u := range batch {
+				if u.skip() {
+					continue
+				}
+				edited, err := u.editFB(ctx, datasets)
+				if err != nil {
+					u.err = err
+					continue
+				}
+				datasets = edited
+				applied = append(applied, u)
+			}
+			if len(applied) == 0 {
+				return
+			}
+			r, err := db.WriteValue(ctx, types.SerialMessage(storeroot_flatbuffer(datasets)))
+			if err != nil {
+				failAll(applied, err)
+				return
+			}
+			newRootHash = r.TargetHash()
+		} else {
+			datasets, err := db.loadDatasetsNomsMap(ctx, root)
+			if err != nil {
+				failAll(batch, err)
+				return
+			}
+			for _, u := range batch {
+				if u.skip() {
+					continue
+				}
+				edited, err := u.edit(ctx, datasets)
+				if err != nil {
+					u.err = err
+					continue
+				}
+				datasets = edited
+				applied = append(applied, u)
+			}
+			if len(applied) == 0 {
+				return
+			}
+			r, err := db.WriteValue(ctx, datasets)
+			if err != nil {
+				failAll(applied, err)
+				return
+			}
+			newRootHash = r.TargetHash()
+		}
+
+		err = db.tryCommitChunks(ctx, newRootHash, root)
+		if err != ErrOptimisticLockFailed {
+			if err != nil {
+				failAll(applied, err)
+			}
+			return
+		}
+		batch = applied
+	}
+}
+
+// skip returns true if |u| failed, or if its writer's context is done, in which case that is
+// its error.
+func (u *refUpdate) skip() bool {
+	if u.err == nil {
+		u.err = u.ctx.Err()
+	}
+	return u.err != nil
+}
+
+func failAll(batch []*refUpdate, err error) {
+	for _, u := range batch {
+		if u.err == nil {
+			u.err = err
+		}
+	}
+}
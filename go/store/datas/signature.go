@@ -0,0 +1,98 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrSigningUnsupported is returned when signing a commit or tag in a database that doesn't use the __DOLT__ format,
+// which is the only format signatures are stored in.
+var ErrSigningUnsupported = errors.New("signed commits and tags are only supported in the __DOLT__ format")
+
+// CommitSigner signs the signing payload of a commit, returning the signature to store in its metadata.
+type CommitSigner func(payload []byte) (string, error)
+
+// CommitSigningPayload returns the bytes that are signed to sign a commit of the root value |root| with the parents
+// |parents| and the metadata |meta|. It covers everything the commit's address is computed from, other than the
+// signature itself and the values derived from its parents.
+func CommitSigningPayload(root hash.Hash, parents []hash.Hash, meta *CommitMeta) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "root %s\n", root.String())
+	for _, p := range parents {
+		fmt.Fprintf(&buf, "parent %s\n", p.String())
+	}
+	fmt.Fprintf(&buf, "author %s <%s> %d\n", meta.Name, meta.Email, meta.UserTimestamp)
+	fmt.Fprintf(&buf, "committer %s <%s> %d\n", meta.Name, meta.Email, meta.Timestamp)
+	buf.WriteString("\n")
+	buf.WriteString(meta.Description)
+	return buf.Bytes()
+}
+
+// TagSigningPayload returns the bytes that are signed to sign a tag named |name| of the commit |commitAddr| with the
+// metadata |meta|.
+func TagSigningPayload(name string, commitAddr hash.Hash, meta *TagMeta) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "object %s\n", commitAddr.String())
+	fmt.Fprintf(&buf, "tag %s\n", name)
+	fmt.Fprintf(&buf, "tagger %s <%s> %d\n", meta.Name, meta.Email, meta.UserTimestamp)
+	fmt.Fprintf(&buf, "timestamp %d\n", meta.Timestamp)
+	buf.WriteString("\n")
+	buf.WriteString(meta.Description)
+	return buf.Bytes()
+}
+
+// GetCommitSignature returns the signature of the commit |cv| and the payload it was made over. The signature is
+// empty if the commit isn't signed.
+func GetCommitSignature(cv types.Value) (signature string, payload []byte, err error) {
+	sm, ok := cv.(types.SerialMessage)
+	if !ok {
+		// commits in other formats can't be signed
+		return "", nil, nil
+	}
+	data := []byte(sm)
+	if serial.GetFileID(data) != serial.CommitFileID {
+		return "", nil, errors.New("GetCommitSignature: provided value is not a commit.")
+	}
+	var cmsg serial.Commit
+	err = serial.InitCommitRoot(&cmsg, data, serial.MessagePrefixSz)
+	if err != nil {
+		return "", nil, err
+	}
+	signature = string(cmsg.Signature())
+	if signature == "" {
+		return "", nil, nil
+	}
+
+	meta := &CommitMeta{
+		Name:          string(cmsg.Name()),
+		Email:         string(cmsg.Email()),
+		Description:   string(cmsg.Description()),
+		Timestamp:     cmsg.TimestampMillis(),
+		UserTimestamp: cmsg.UserTimestampMillis(),
+	}
+	parentBytes := cmsg.ParentAddrsBytes()
+	parents := make([]hash.Hash, len(parentBytes)/hash.ByteLen)
+	for i := range parents {
+		parents[i] = hash.New(parentBytes[i*hash.ByteLen : (i+1)*hash.ByteLen])
+	}
+	return signature, CommitSigningPayload(hash.New(cmsg.RootBytes()), parents, meta), nil
+}
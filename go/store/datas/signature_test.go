@@ -0,0 +1,99 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestSignedCommit(t *testing.T) {
+	ctx := context.Background()
+	storage := &chunks.MemoryStorage{}
+	db := NewDatabase(storage.NewViewWithFormat(types.Format_DOLT.VersionString()))
+	ds, err := db.GetDataset(ctx, "main")
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("bob", "bob@example.com", "unsigned")
+	require.NoError(t, err)
+	ds, err = db.Commit(ctx, ds, types.String("a"), CommitOptions{Meta: meta})
+	require.NoError(t, err)
+	sig, payload, err := GetCommitSignature(mustHead(ds))
+	require.NoError(t, err)
+	assert.Empty(t, sig)
+	assert.Nil(t, payload)
+
+	var signed []byte
+	meta, err = NewCommitMeta("bob", "bob@example.com", "signed")
+	require.NoError(t, err)
+	ds, err = db.Commit(ctx, ds, types.String("b"), CommitOptions{
+		Meta: meta,
+		Signer: func(payload []byte) (string, error) {
+			signed = payload
+			return "test signature", nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(signed), "parent ")
+	assert.Contains(t, string(signed), "author bob <bob@example.com>")
+
+	head := mustHead(ds)
+	readMeta, err := GetCommitMeta(ctx, head)
+	require.NoError(t, err)
+	assert.Equal(t, "test signature", readMeta.Signature)
+	assert.Equal(t, "signed", readMeta.Description)
+	assert.Empty(t, meta.Signature, "the caller's metadata should not be modified")
+
+	sig, payload, err = GetCommitSignature(head)
+	require.NoError(t, err)
+	assert.Equal(t, "test signature", sig)
+	assert.Equal(t, signed, payload)
+
+	addr, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+	tagDs, err := db.GetDataset(ctx, "refs/tags/v1")
+	require.NoError(t, err)
+	tagMeta := NewTagMeta("bob", "bob@example.com", "a signed tag")
+	tagMeta.Signature = "tag signature"
+	tagDs, err = db.Tag(ctx, tagDs, addr, TagOptions{Meta: tagMeta})
+	require.NoError(t, err)
+	readTagMeta, _, err := tagDs.HeadTag()
+	require.NoError(t, err)
+	assert.Equal(t, "tag signature", readTagMeta.Signature)
+}
+
+func TestSignedCommitUnsupportedFormat(t *testing.T) {
+	ctx := context.Background()
+	storage := &chunks.MemoryStorage{}
+	db := NewDatabase(storage.NewViewWithFormat(types.Format_LD_1.VersionString()))
+	ds, err := db.GetDataset(ctx, "main")
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("bob", "bob@example.com", "signed")
+	require.NoError(t, err)
+	_, err = db.Commit(ctx, ds, types.String("a"), CommitOptions{
+		Meta: meta,
+		Signer: func(payload []byte) (string, error) {
+			return "test signature", nil
+		},
+	})
+	assert.ErrorIs(t, err, ErrSigningUnsupported)
+}
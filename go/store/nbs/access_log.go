@@ -0,0 +1,242 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// accessLogFileName is the name of the file an access log is stored in, next to the manifest of the store.
+const accessLogFileName = "manifest.access"
+
+// accessLogSize is the number of sampled chunk reads a store remembers.
+const accessLogSize = 4096
+
+// ErrNoAccessLog is returned when a store has no access log, or can't store one.
+var ErrNoAccessLog = errors.New("no chunk access log found")
+
+// AccessLogStore is implemented by chunk stores that can sample the chunks read from them, and store a log of the
+// chunks read from another store next to their manifest. A primary publishes the chunks it reads to the remote its
+// read replicas pull from, so that they can prefetch the chunks that are hot on the primary.
+type AccessLogStore interface {
+	// SampleAccesses samples one in every |rate| chunks read from the store. Zero stops the sampling.
+	SampleAccesses(rate int)
+	// SampledAccesses returns the distinct chunks sampled from the latest reads of the store, most recent first.
+	SampledAccesses() []hash.Hash
+	// ReadAccessLog returns the access log stored in the store, or ErrNoAccessLog if it has none.
+	ReadAccessLog(ctx context.Context) ([]hash.Hash, error)
+	// WriteAccessLog replaces the access log stored in the store with |hashes|.
+	WriteAccessLog(ctx context.Context, hashes []hash.Hash) error
+}
+
+// accessLogPersister is implemented by manifests which can store an access log alongside them.
+type accessLogPersister interface {
+	readAccessLog(ctx context.Context) ([]byte, error)
+	writeAccessLog(ctx context.Context, data []byte) error
+}
+
+// accessSampler records a sample of the chunks read from a store in a ring of the latest accessLogSize samples.
+type accessSampler struct {
+	rate  atomic.Uint64
+	count atomic.Uint64
+
+	mu   sync.Mutex
+	ring []hash.Hash
+	next int
+}
+
+func newAccessSampler() *accessSampler {
+	return &accessSampler{}
+}
+
+func (s *accessSampler) setRate(rate int) {
+	if rate < 0 {
+		rate = 0
+	}
+	s.rate.Store(uint64(rate))
+}
+
+// sample records |h| if it's one in every |rate| chunks read.
+func (s *accessSampler) sample(h hash.Hash) {
+	rate := s.rate.Load()
+	if rate == 0 || s.count.Add(1)%rate != 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.record(h)
+}
+
+// sampleMany records one in every |rate| chunks of |hashes|.
+func (s *accessSampler) sampleMany(hashes hash.HashSet) {
+	rate := s.rate.Load()
+	if rate == 0 || len(hashes) == 0 {
+		return
+	}
+	count := s.count.Add(uint64(len(hashes))) - uint64(len(hashes))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h := range hashes {
+		if count++; count%rate == 0 {
+			s.record(h)
+		}
+	}
+}
+
+func (s *accessSampler) record(h hash.Hash) {
+	if len(s.ring) < accessLogSize {
+		s.ring = append(s.ring, h)
+		return
+	}
+	s.ring[s.next] = h
+	s.next = (s.next + 1) % accessLogSize
+}
+
+// sampled returns the distinct chunks sampled, most recent first.
+func (s *accessSampler) sampled() []hash.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(hash.HashSet, len(s.ring))
+	hashes := make([]hash.Hash, 0, len(s.ring))
+	for i := 1; i <= len(s.ring); i++ {
+		h := s.ring[(s.next-i+len(s.ring))%len(s.ring)]
+		if !seen.Has(h) {
+			seen.Insert(h)
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
+}
+
+func encodeAccessLog(hashes []hash.Hash) []byte {
+	var buf bytes.Buffer
+	for _, h := range hashes {
+		buf.WriteString(h.String())
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func parseAccessLog(data []byte) ([]hash.Hash, error) {
+	lines := strings.Fields(string(data))
+	hashes := make([]hash.Hash, len(lines))
+	for i, line := range lines {
+		h, ok := hash.MaybeParse(line)
+		if !ok {
+			return nil, fmt.Errorf("invalid chunk access log: '%s' is not a chunk address", line)
+		}
+		hashes[i] = h
+	}
+	return hashes, nil
+}
+
+func readAccessLog(ctx context.Context, m manifest) ([]hash.Hash, error) {
+	ap, ok := m.(accessLogPersister)
+	if !ok {
+		return nil, ErrNoAccessLog
+	}
+	data, err := ap.readAccessLog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseAccessLog(data)
+}
+
+func writeAccessLog(ctx context.Context, m manifest, hashes []hash.Hash) error {
+	ap, ok := m.(accessLogPersister)
+	if !ok {
+		return ErrNoAccessLog
+	}
+	return ap.writeAccessLog(ctx, encodeAccessLog(hashes))
+}
+
+func readAccessLogFile(dir string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(dir, accessLogFileName))
+	if os.IsNotExist(err) {
+		return nil, ErrNoAccessLog
+	}
+	return data, err
+}
+
+// writeAccessLogFile replaces the access log in |dir| with a rename, so that replicas never read a partial log.
+func writeAccessLogFile(dir string, data []byte) error {
+	f, err := os.CreateTemp(dir, accessLogFileName+".*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(dir, accessLogFileName))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+func (fm fileManifest) readAccessLog(_ context.Context) ([]byte, error) {
+	return readAccessLogFile(fm.dir)
+}
+
+func (fm fileManifest) writeAccessLog(_ context.Context, data []byte) error {
+	return writeAccessLogFile(fm.dir, data)
+}
+
+func (jm *journalManifest) readAccessLog(_ context.Context) ([]byte, error) {
+	return readAccessLogFile(jm.dir)
+}
+
+func (jm *journalManifest) writeAccessLog(_ context.Context, data []byte) error {
+	return writeAccessLogFile(jm.dir, data)
+}
+
+func (j *chunkJournal) readAccessLog(ctx context.Context) ([]byte, error) {
+	return j.backing.readAccessLog(ctx)
+}
+
+func (j *chunkJournal) writeAccessLog(ctx context.Context, data []byte) error {
+	return j.backing.writeAccessLog(ctx, data)
+}
+
+func (bsm blobstoreManifest) readAccessLog(ctx context.Context) ([]byte, error) {
+	rd, _, err := bsm.bs.Get(ctx, accessLogFileName, blobstore.AllRange)
+	if blobstore.IsNotFoundError(err) {
+		return nil, ErrNoAccessLog
+	} else if err != nil {
+		return nil, err
+	}
+	defer rd.Close()
+	return io.ReadAll(rd)
+}
+
+func (bsm blobstoreManifest) writeAccessLog(ctx context.Context, data []byte) error {
+	_, err := bsm.bs.Put(ctx, accessLogFileName, bytes.NewReader(data))
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestAccessLog(t *testing.T) {
+	ctx := context.Background()
+	st, _, _ := makeTestLocalStore(t, defaultMaxTables)
+	defer st.Close()
+
+	c1, c2, c3 := chunks.NewChunk([]byte("one")), chunks.NewChunk([]byte("two")), chunks.NewChunk([]byte("three"))
+	for _, c := range []chunks.Chunk{c1, c2, c3} {
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+	}
+
+	// reads aren't sampled until sampling is enabled
+	_, err := st.Get(ctx, c1.Hash())
+	require.NoError(t, err)
+	assert.Empty(t, st.SampledAccesses())
+
+	st.SampleAccesses(1)
+	for _, c := range []chunks.Chunk{c1, c2, c1} {
+		_, err = st.Get(ctx, c.Hash())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []hash.Hash{c1.Hash(), c2.Hash()}, st.SampledAccesses())
+
+	err = st.GetMany(ctx, hash.NewHashSet(c3.Hash()), func(context.Context, *chunks.Chunk) {})
+	require.NoError(t, err)
+	assert.Equal(t, []hash.Hash{c3.Hash(), c1.Hash(), c2.Hash()}, st.SampledAccesses())
+
+	_, err = st.ReadAccessLog(ctx)
+	assert.ErrorIs(t, err, ErrNoAccessLog)
+	require.NoError(t, st.WriteAccessLog(ctx, st.SampledAccesses()))
+	log, err := st.ReadAccessLog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []hash.Hash{c3.Hash(), c1.Hash(), c2.Hash()}, log)
+}
+
+func TestAccessSampler(t *testing.T) {
+	s := newAccessSampler()
+	s.setRate(3)
+	var hashes []hash.Hash
+	for i := 0; i < 9; i++ {
+		h := hash.Of([]byte{byte(i)})
+		hashes = append(hashes, h)
+		s.sample(h)
+	}
+	assert.Equal(t, []hash.Hash{hashes[8], hashes[5], hashes[2]}, s.sampled())
+
+	more := hash.NewHashSet()
+	for i := 9; i < 15; i++ {
+		more.Insert(hash.Of([]byte{byte(i)}))
+	}
+	s.sampleMany(more)
+	assert.Len(t, s.sampled(), 5)
+
+	s.setRate(1)
+	for i := 0; i < accessLogSize+10; i++ {
+		s.sample(hash.Of([]byte{byte(i), byte(i >> 8)}))
+	}
+	sampled := s.sampled()
+	assert.Len(t, sampled, accessLogSize)
+	n := accessLogSize + 9
+	assert.Equal(t, hash.Of([]byte{byte(n), byte(n >> 8)}), sampled[0])
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// attestationFileName is the name of the file a ManifestAttestation is stored in, next to the manifest it attests to.
+const attestationFileName = "manifest.sig"
+
+// ErrNoAttestation is returned when a store has no attestation, or can't store one.
+var ErrNoAttestation = errors.New("no signed manifest attestation found")
+
+// ErrBadAttestationSignature is returned when an attestation was not signed by the trusted key.
+var ErrBadAttestationSignature = errors.New("manifest attestation is not signed by the trusted key")
+
+// AttestedTable is a table file listed in a ManifestAttestation. A table file's id is the hash of its index, so it
+// identifies the chunks the file contains.
+type AttestedTable struct {
+	ID        string `json:"id"`
+	NumChunks int    `json:"chunks"`
+}
+
+// ManifestAttestation is a signed statement of the root and table files of a store's manifest. A client that
+// trusts the signing key can check that the store it's fetching from is the one that was signed, which protects
+// against tampered mirrors.
+type ManifestAttestation struct {
+	Root      hash.Hash       `json:"root"`
+	Tables    []AttestedTable `json:"tables"`
+	Signature []byte          `json:"signature"`
+}
+
+// NewManifestAttestation returns an unsigned attestation of |root| and the table files |sources|.
+func NewManifestAttestation(root hash.Hash, sources []chunks.TableFile) ManifestAttestation {
+	tables := make([]AttestedTable, len(sources))
+	for i, tf := range sources {
+		tables[i] = AttestedTable{ID: tf.FileID(), NumChunks: tf.NumChunks()}
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].ID < tables[j].ID
+	})
+	return ManifestAttestation{Root: root, Tables: tables}
+}
+
+// payload returns the bytes that are signed.
+func (ma ManifestAttestation) payload() []byte {
+	var sb strings.Builder
+	sb.WriteString(ma.Root.String())
+	sb.WriteByte('\n')
+	for _, t := range ma.Tables {
+		fmt.Fprintf(&sb, "%s:%d\n", t.ID, t.NumChunks)
+	}
+	return []byte(sb.String())
+}
+
+// Sign signs |ma| with |key|.
+func (ma *ManifestAttestation) Sign(key ed25519.PrivateKey) {
+	ma.Signature = ed25519.Sign(key, ma.payload())
+}
+
+// Verify returns ErrBadAttestationSignature if |ma| wasn't signed with the private key of |key|.
+func (ma ManifestAttestation) Verify(key ed25519.PublicKey) error {
+	if !ed25519.Verify(key, ma.payload(), ma.Signature) {
+		return ErrBadAttestationSignature
+	}
+	return nil
+}
+
+// AttestationMismatchError is returned by ManifestAttestation.Check when the sources of a store don't match its
+// attestation.
+type AttestationMismatchError struct {
+	// ExpectedRoot and ActualRoot are set if the root doesn't match.
+	ExpectedRoot, ActualRoot hash.Hash
+	// Unattested are table files in the store that are not in the attestation, or whose chunk count differs.
+	Unattested []string
+	// Missing are table files in the attestation that are not in the store.
+	Missing []string
+}
+
+func (e *AttestationMismatchError) Error() string {
+	var parts []string
+	if e.ExpectedRoot != e.ActualRoot {
+		parts = append(parts, fmt.Sprintf("root is %s, attested root is %s", e.ActualRoot.String(), e.ExpectedRoot.String()))
+	}
+	if len(e.Unattested) > 0 {
+		parts = append(parts, fmt.Sprintf("table files not matching the attestation: %s", strings.Join(e.Unattested, ", ")))
+	}
+	if len(e.Missing) > 0 {
+		parts = append(parts, fmt.Sprintf("attested table files not found: %s", strings.Join(e.Missing, ", ")))
+	}
+	return "manifest does not match its attestation: " + strings.Join(parts, "; ")
+}
+
+// Check returns an *AttestationMismatchError if |root| and |sources| are not exactly what |ma| attests to.
+func (ma ManifestAttestation) Check(root hash.Hash, sources []chunks.TableFile) error {
+	attested := make(map[string]int, len(ma.Tables))
+	for _, t := range ma.Tables {
+		attested[t.ID] = t.NumChunks
+	}
+
+	mismatch := &AttestationMismatchError{ExpectedRoot: ma.Root, ActualRoot: root}
+	for _, tf := range sources {
+		if n, ok := attested[tf.FileID()]; !ok || n != tf.NumChunks() {
+			mismatch.Unattested = append(mismatch.Unattested, tf.FileID())
+		}
+		delete(attested, tf.FileID())
+	}
+	for id := range attested {
+		mismatch.Missing = append(mismatch.Missing, id)
+	}
+	sort.Strings(mismatch.Unattested)
+	sort.Strings(mismatch.Missing)
+
+	if root != ma.Root || len(mismatch.Unattested) > 0 || len(mismatch.Missing) > 0 {
+		return mismatch
+	}
+	return nil
+}
+
+// AttestationStore is implemented by chunk stores that can store a ManifestAttestation next to their manifest.
+type AttestationStore interface {
+	// ReadAttestation returns the store's attestation, or ErrNoAttestation if it has none.
+	ReadAttestation(ctx context.Context) (ManifestAttestation, error)
+	// WriteAttestation replaces the store's attestation with |ma|.
+	WriteAttestation(ctx context.Context, ma ManifestAttestation) error
+}
+
+// attestationPersister is implemented by manifests which can store an attestation alongside them.
+type attestationPersister interface {
+	readAttestation(ctx context.Context) ([]byte, error)
+	writeAttestation(ctx context.Context, data []byte) error
+}
+
+// AttestSources signs the current root and table files of |cs| with |key| and stores the attestation in |cs|.
+func AttestSources(ctx context.Context, cs chunks.ChunkStore, key ed25519.PrivateKey) error {
+	tfs, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return fmt.Errorf("%w: the store does not support manifest attestations", ErrNoAttestation)
+	}
+	as, ok := cs.(AttestationStore)
+	if !ok {
+		return fmt.Errorf("%w: the store does not support manifest attestations", ErrNoAttestation)
+	}
+
+	root, sources, appendix, err := tfs.Sources(ctx)
+	if err != nil {
+		return err
+	}
+	ma := NewManifestAttestation(root, append(sources, appendix...))
+	ma.Sign(key)
+	return as.WriteAttestation(ctx, ma)
+}
+
+// VerifySources checks that the current root and table files of |cs| are attested to by an attestation signed
+// with |key|.
+func VerifySources(ctx context.Context, cs chunks.ChunkStore, key ed25519.PublicKey) error {
+	tfs, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return fmt.Errorf("%w: the store does not support manifest attestations", ErrNoAttestation)
+	}
+	as, ok := cs.(AttestationStore)
+	if !ok {
+		return fmt.Errorf("%w: the store does not support manifest attestations", ErrNoAttestation)
+	}
+
+	ma, err := as.ReadAttestation(ctx)
+	if err != nil {
+		return err
+	}
+	if err = ma.Verify(key); err != nil {
+		return err
+	}
+
+	root, sources, appendix, err := tfs.Sources(ctx)
+	if err != nil {
+		return err
+	}
+	return ma.Check(root, append(sources, appendix...))
+}
+
+func readAttestation(ctx context.Context, m manifest) (ManifestAttestation, error) {
+	ap, ok := m.(attestationPersister)
+	if !ok {
+		return ManifestAttestation{}, ErrNoAttestation
+	}
+	data, err := ap.readAttestation(ctx)
+	if err != nil {
+		return ManifestAttestation{}, err
+	}
+	var ma ManifestAttestation
+	if err = json.Unmarshal(data, &ma); err != nil {
+		return ManifestAttestation{}, fmt.Errorf("invalid manifest attestation: %w", err)
+	}
+	return ma, nil
+}
+
+func writeAttestation(ctx context.Context, m manifest, ma ManifestAttestation) error {
+	ap, ok := m.(attestationPersister)
+	if !ok {
+		return ErrNoAttestation
+	}
+	data, err := json.Marshal(ma)
+	if err != nil {
+		return err
+	}
+	return ap.writeAttestation(ctx, data)
+}
+
+func (fm fileManifest) readAttestation(_ context.Context) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(fm.dir, attestationFileName))
+	if os.IsNotExist(err) {
+		return nil, ErrNoAttestation
+	}
+	return data, err
+}
+
+func (fm fileManifest) writeAttestation(_ context.Context, data []byte) error {
+	return os.WriteFile(filepath.Join(fm.dir, attestationFileName), data, 0644)
+}
+
+func (bsm blobstoreManifest) readAttestation(ctx context.Context) ([]byte, error) {
+	rd, _, err := bsm.bs.Get(ctx, attestationFileName, blobstore.AllRange)
+	if blobstore.IsNotFoundError(err) {
+		return nil, ErrNoAttestation
+	} else if err != nil {
+		return nil, err
+	}
+	defer rd.Close()
+	return io.ReadAll(rd)
+}
+
+func (bsm blobstoreManifest) writeAttestation(ctx context.Context, data []byte) error {
+	_, err := bsm.bs.Put(ctx, attestationFileName, bytes.NewReader(data))
+	return err
+}
+
+func (jm *journalManifest) readAttestation(_ context.Context) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(jm.dir, attestationFileName))
+	if os.IsNotExist(err) {
+		return nil, ErrNoAttestation
+	}
+	return data, err
+}
+
+func (jm *journalManifest) writeAttestation(_ context.Context, data []byte) error {
+	return os.WriteFile(filepath.Join(jm.dir, attestationFileName), data, 0644)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestAttestation(t *testing.T) {
+	ctx := context.Background()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	st, _, _ := makeTestLocalStore(t, defaultMaxTables)
+	defer st.Close()
+	populateLocalStore(t, st, 4)
+
+	err = VerifySources(ctx, st, pub)
+	assert.ErrorIs(t, err, ErrNoAttestation)
+
+	require.NoError(t, AttestSources(ctx, st, priv))
+	assert.NoError(t, VerifySources(ctx, st, pub))
+	assert.ErrorIs(t, VerifySources(ctx, st, otherPub), ErrBadAttestationSignature)
+
+	root, sources, _, err := st.Sources(ctx)
+	require.NoError(t, err)
+	ma, err := st.ReadAttestation(ctx)
+	require.NoError(t, err)
+
+	err = ma.Check(root, sources[1:])
+	var mismatch *AttestationMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, []string{sources[0].FileID()}, mismatch.Missing)
+	assert.Empty(t, mismatch.Unattested)
+
+	ma.Tables = ma.Tables[1:]
+	err = ma.Check(root, sources)
+	require.ErrorAs(t, err, &mismatch)
+	assert.Len(t, mismatch.Unattested, 1)
+	assert.Empty(t, mismatch.Missing)
+}
@@ -620,7 +620,7 @@ func (fc *fakeConjoiner) conjoinRequired(ts tableSet) bool {
 	return true
 }
 
-func (fc *fakeConjoiner) chooseConjoinees(specs []tableSpec) (conjoinees, keepers []tableSpec, err error) {
+func (fc *fakeConjoiner) chooseConjoinees(specs []tableSpec, sizes map[addr]uint64) (conjoinees, keepers []tableSpec, err error) {
 	d.PanicIfTrue(len(fc.canned) == 0)
 	cur := fc.canned[0]
 	fc.canned = fc.canned[1:]
@@ -0,0 +1,111 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+const (
+	// bloomBitsPerChunk and bloomHashCount give a false positive rate of roughly 1%.
+	bloomBitsPerChunk = 10
+	bloomHashCount    = 7
+)
+
+// prefixBloomFilter is a bloom filter over the address prefixes of a table file. It is built
+// from the table index when the table is opened, and lets has and hasMany skip probing the
+// index for addresses which are certainly absent. Address prefixes are already uniformly
+// distributed, so the probe positions are derived from the prefix by double hashing.
+type prefixBloomFilter struct {
+	bits []uint64
+	mask uint64
+}
+
+// newPrefixBloomFilter returns a filter containing |prefixes|, or nil if |prefixes| is empty.
+func newPrefixBloomFilter(prefixes []uint64) *prefixBloomFilter {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	// round the filter size up to a power of two so probes can be masked instead of divided
+	n := uint64(len(prefixes)) * bloomBitsPerChunk
+	size := uint64(1) << bits.Len64(n-1)
+	if size < 64 {
+		size = 64
+	}
+	f := &prefixBloomFilter{
+		bits: make([]uint64, size/64),
+		mask: size - 1,
+	}
+	for _, p := range prefixes {
+		f.add(p)
+	}
+	return f
+}
+
+func (f *prefixBloomFilter) add(prefix uint64) {
+	h1, h2 := prefix, bits.RotateLeft64(prefix, 32)|1
+	for i := uint64(0); i < bloomHashCount; i++ {
+		pos := (h1 + i*h2) & f.mask
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// mayContain returns false if |prefix| is certainly not in the filter.
+func (f *prefixBloomFilter) mayContain(prefix uint64) bool {
+	if f == nil {
+		return true
+	}
+	h1, h2 := prefix, bits.RotateLeft64(prefix, 32)|1
+	for i := uint64(0); i < bloomHashCount; i++ {
+		pos := (h1 + i*h2) & f.mask
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+var bloomProbes, bloomRejects, bloomFalsePositives atomic.Uint64
+
+// BloomFilterMetrics are process-wide counters for the table file bloom filters consulted by
+// has and hasMany, useful for tuning the filters' size.
+type BloomFilterMetrics struct {
+	// Probes is the number of addresses checked against a filter.
+	Probes uint64
+	// Rejects is the number of addresses a filter ruled out without an index lookup.
+	Rejects uint64
+	// FalsePositives is the number of addresses a filter passed which were then not found in
+	// the table index.
+	FalsePositives uint64
+}
+
+// GetBloomFilterMetrics returns the current values of the bloom filter counters.
+func GetBloomFilterMetrics() BloomFilterMetrics {
+	return BloomFilterMetrics{
+		Probes:         bloomProbes.Load(),
+		Rejects:        bloomRejects.Load(),
+		FalsePositives: bloomFalsePositives.Load(),
+	}
+}
+
+// FalsePositiveRate returns the fraction of addresses which passed the filter but were absent.
+func (m BloomFilterMetrics) FalsePositiveRate() float64 {
+	negatives := m.Rejects + m.FalsePositives
+	if negatives == 0 {
+		return 0
+	}
+	return float64(m.FalsePositives) / float64(negatives)
+}
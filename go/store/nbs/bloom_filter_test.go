@@ -0,0 +1,93 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixBloomFilter(t *testing.T) {
+	const n = 100_000
+	prefixes := make([]uint64, n)
+	for i := range prefixes {
+		prefixes[i] = rand.Uint64()
+	}
+	f := newPrefixBloomFilter(prefixes)
+	require.NotNil(t, f)
+
+	for _, p := range prefixes {
+		assert.True(t, f.mayContain(p))
+	}
+
+	var falsePositives int
+	for i := 0; i < n; i++ {
+		if f.mayContain(rand.Uint64()) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/n, 0.03)
+}
+
+func TestPrefixBloomFilterEmpty(t *testing.T) {
+	f := newPrefixBloomFilter(nil)
+	assert.Nil(t, f)
+	assert.True(t, f.mayContain(rand.Uint64()))
+}
+
+func TestTableReaderHasManyBloomMetrics(t *testing.T) {
+	ctx := context.Background()
+	chunks := [][]byte{
+		[]byte("hello2"),
+		[]byte("goodbye2"),
+		[]byte("badbye2"),
+	}
+	tableData, _, err := buildTable(chunks)
+	require.NoError(t, err)
+	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	tr, err := newTableReader(ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	require.NoError(t, err)
+	defer tr.close()
+
+	addrs := []hasRecord{}
+	for i := 0; i < 100; i++ {
+		a := computeAddr([]byte{byte(i), 'x'})
+		addrs = append(addrs, hasRecord{a: &a, prefix: a.Prefix(), order: i})
+	}
+	present := computeAddr(chunks[0])
+	addrs = append(addrs, hasRecord{a: &present, prefix: present.Prefix(), order: len(addrs)})
+	sort.Sort(hasRecordByPrefix(addrs))
+
+	before := GetBloomFilterMetrics()
+	remaining, err := tr.hasMany(addrs)
+	require.NoError(t, err)
+	assert.True(t, remaining)
+	after := GetBloomFilterMetrics()
+
+	// hasMany stops probing once it is past the last prefix of the table
+	probes := after.Probes - before.Probes
+	assert.LessOrEqual(t, probes, uint64(len(addrs)))
+	assert.Greater(t, after.Rejects-before.Rejects, probes*9/10)
+
+	for _, r := range addrs {
+		assert.Equal(t, *r.a == present, r.has)
+	}
+}
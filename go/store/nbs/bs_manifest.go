@@ -100,17 +100,22 @@ func (bsm blobstoreManifest) UpdateGCGen(ctx context.Context, lastLock addr, new
 	return updateBSWithChecker(ctx, bsm.bs, checker, lastLock, newContents, writeHook)
 }
 
+// updateBSWithChecker replaces the manifest in |bs| with a check and set on the version it read. Blobstores have no
+// locks, so |writeHook| runs after the version is read and before it's checked and set: the manifest is only replaced
+// if it wasn't replaced since |writeHook| ran.
 func updateBSWithChecker(ctx context.Context, bs blobstore.Blobstore, validate manifestChecker, lastLock addr, newContents manifestContents, writeHook func() error) (mc manifestContents, err error) {
-	if writeHook != nil {
-		panic("Write hooks not supported")
-	}
-
 	ver, contents, err := manifestVersionAndContents(ctx, bs)
 
 	if err != nil && !blobstore.IsNotFoundError(err) {
 		return manifestContents{}, err
 	}
 
+	if writeHook != nil {
+		if err = writeHook(); err != nil {
+			return manifestContents{}, err
+		}
+	}
+
 	// this is where we assert that gcGen is correct
 	err = validate(contents, newContents)
 	if err != nil {
@@ -0,0 +1,181 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// chunkCacheEntryOverhead approximates the memory used by a cached chunk besides its data.
+const chunkCacheEntryOverhead = 96
+
+// sharedChunkCache caches the decompressed chunks read by every NomsBlockStore in the process. Chunks are content
+// addressed, so a chunk read through one store is valid for all of them.
+var sharedChunkCache = newChunkCache(0)
+
+// SetChunkCacheSize sets the number of bytes of decompressed chunks the NomsBlockStores of the process may cache,
+// evicting the least recently used chunks to fit. Zero disables the cache.
+func SetChunkCacheSize(size uint64) {
+	sharedChunkCache.setMaxSize(size)
+}
+
+// ChunkCacheStats are the statistics of the chunk cache shared by the NomsBlockStores of the process.
+type ChunkCacheStats struct {
+	// MaxSize is the number of bytes the cache may hold, or zero if it's disabled.
+	MaxSize uint64
+	// Size is the number of bytes the cache holds.
+	Size uint64
+	// Chunks is the number of chunks the cache holds.
+	Chunks int
+	// Hits and Misses count the chunk reads that were and weren't served by the cache while it was enabled.
+	Hits, Misses uint64
+}
+
+// GetChunkCacheStats returns the statistics of the chunk cache shared by the NomsBlockStores of the process.
+func GetChunkCacheStats() ChunkCacheStats {
+	return sharedChunkCache.stats()
+}
+
+type chunkCacheEntry struct {
+	lruEntry *list.Element
+	data     []byte
+}
+
+type chunkCache struct {
+	maxSize atomic.Uint64
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+
+	mu        sync.Mutex
+	totalSize uint64
+	lru       list.List
+	cache     map[addr]chunkCacheEntry
+}
+
+func newChunkCache(maxSize uint64) *chunkCache {
+	cc := &chunkCache{cache: map[addr]chunkCacheEntry{}}
+	cc.maxSize.Store(maxSize)
+	return cc
+}
+
+func (cc *chunkCache) enabled() bool {
+	return cc.maxSize.Load() > 0
+}
+
+// get returns the data of the chunk |a| and true if it's cached, moving it to the back of the lru.
+func (cc *chunkCache) get(a addr) ([]byte, bool) {
+	if !cc.enabled() {
+		return nil, false
+	}
+
+	cc.mu.Lock()
+	entry, ok := cc.cache[a]
+	if ok {
+		cc.lru.MoveToBack(entry.lruEntry)
+	}
+	cc.mu.Unlock()
+
+	if ok {
+		cc.hits.Add(1)
+	} else {
+		cc.misses.Add(1)
+	}
+	return entry.data, ok
+}
+
+// put caches |data| as the chunk |a|, evicting chunks from the front of the lru until the cache fits its max size.
+func (cc *chunkCache) put(a addr, data []byte) {
+	maxSize := cc.maxSize.Load()
+	size := uint64(len(data)) + chunkCacheEntryOverhead
+	if size > maxSize {
+		return
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if _, ok := cc.cache[a]; ok {
+		return
+	}
+	cc.cache[a] = chunkCacheEntry{lruEntry: cc.lru.PushBack(a), data: data}
+	cc.totalSize += size
+	cc.evict(maxSize)
+}
+
+// evict removes chunks from the front of the lru until the cache holds no more than |maxSize| bytes.
+func (cc *chunkCache) evict(maxSize uint64) {
+	for el := cc.lru.Front(); el != nil && cc.totalSize > maxSize; {
+		a := el.Value.(addr)
+		next := el.Next()
+		cc.totalSize -= uint64(len(cc.cache[a].data)) + chunkCacheEntryOverhead
+		delete(cc.cache, a)
+		cc.lru.Remove(el)
+		el = next
+	}
+}
+
+func (cc *chunkCache) setMaxSize(maxSize uint64) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.maxSize.Store(maxSize)
+	cc.evict(maxSize)
+}
+
+// getMany calls |found| for every chunk of |hashes| that's cached and returns the hashes of the rest.
+func (cc *chunkCache) getMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) hash.HashSet {
+	if !cc.enabled() {
+		return hashes
+	}
+
+	remaining := make(hash.HashSet, len(hashes))
+	for h := range hashes {
+		if data, ok := cc.get(addr(h)); ok {
+			c := chunks.NewChunkWithHash(h, data)
+			found(ctx, &c)
+		} else {
+			remaining.Insert(h)
+		}
+	}
+	return remaining
+}
+
+// caching returns a callback which caches the chunks passed to it before passing them to |found|.
+func (cc *chunkCache) caching(found func(context.Context, *chunks.Chunk)) func(context.Context, *chunks.Chunk) {
+	if !cc.enabled() {
+		return found
+	}
+	return func(ctx context.Context, c *chunks.Chunk) {
+		cc.put(addr(c.Hash()), c.Data())
+		found(ctx, c)
+	}
+}
+
+func (cc *chunkCache) stats() ChunkCacheStats {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return ChunkCacheStats{
+		MaxSize: cc.maxSize.Load(),
+		Size:    cc.totalSize,
+		Chunks:  len(cc.cache),
+		Hits:    cc.hits.Load(),
+		Misses:  cc.misses.Load(),
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestChunkCache(t *testing.T) {
+	data := [][]byte{[]byte("abc"), []byte("def"), []byte("ghi")}
+	addrs := make([]addr, len(data))
+	for i, d := range data {
+		addrs[i] = computeAddr(d)
+	}
+	entrySize := uint64(len(data[0])) + chunkCacheEntryOverhead
+
+	t.Run("Disabled", func(t *testing.T) {
+		cc := newChunkCache(0)
+		cc.put(addrs[0], data[0])
+		_, ok := cc.get(addrs[0])
+		assert.False(t, ok)
+		assert.Equal(t, ChunkCacheStats{}, cc.stats())
+	})
+
+	t.Run("HitsAndMisses", func(t *testing.T) {
+		cc := newChunkCache(10 * entrySize)
+		_, ok := cc.get(addrs[0])
+		assert.False(t, ok)
+		cc.put(addrs[0], data[0])
+		d, ok := cc.get(addrs[0])
+		require.True(t, ok)
+		assert.Equal(t, data[0], d)
+
+		s := cc.stats()
+		assert.Equal(t, uint64(1), s.Hits)
+		assert.Equal(t, uint64(1), s.Misses)
+		assert.Equal(t, 1, s.Chunks)
+		assert.Equal(t, entrySize, s.Size)
+	})
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		cc := newChunkCache(2 * entrySize)
+		cc.put(addrs[0], data[0])
+		cc.put(addrs[1], data[1])
+		_, ok := cc.get(addrs[0])
+		require.True(t, ok)
+		cc.put(addrs[2], data[2])
+
+		_, ok = cc.get(addrs[1])
+		assert.False(t, ok)
+		_, ok = cc.get(addrs[0])
+		assert.True(t, ok)
+		_, ok = cc.get(addrs[2])
+		assert.True(t, ok)
+		assert.Equal(t, 2*entrySize, cc.stats().Size)
+	})
+
+	t.Run("SetMaxSize", func(t *testing.T) {
+		cc := newChunkCache(3 * entrySize)
+		for i := range data {
+			cc.put(addrs[i], data[i])
+		}
+		cc.setMaxSize(entrySize)
+		assert.Equal(t, 1, cc.stats().Chunks)
+		_, ok := cc.get(addrs[2])
+		assert.True(t, ok)
+
+		cc.setMaxSize(0)
+		assert.Equal(t, 0, cc.stats().Chunks)
+		assert.Equal(t, uint64(0), cc.stats().Size)
+	})
+
+	t.Run("GetMany", func(t *testing.T) {
+		ctx := context.Background()
+		cc := newChunkCache(10 * entrySize)
+		hashes := make(hash.HashSet)
+		for _, a := range addrs {
+			hashes.Insert(hash.Hash(a))
+		}
+
+		var found []hash.Hash
+		remaining := cc.getMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+			found = append(found, c.Hash())
+		})
+		assert.Empty(t, found)
+		assert.Equal(t, hashes, remaining)
+
+		cache := cc.caching(func(ctx context.Context, c *chunks.Chunk) {})
+		c := chunks.NewChunk(data[1])
+		cache(ctx, &c)
+
+		remaining = cc.getMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+			found = append(found, c.Hash())
+			assert.Equal(t, data[1], c.Data())
+		})
+		assert.Equal(t, []hash.Hash{hash.Hash(addrs[1])}, found)
+		assert.Len(t, remaining, 2)
+		assert.False(t, remaining.Has(hash.Hash(addrs[1])))
+	})
+}
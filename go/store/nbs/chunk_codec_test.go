@@ -0,0 +1,62 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+func TestChunkCodecs(t *testing.T) {
+	chnk := chunks.NewChunk([]byte(strings.Repeat("the quick brown fox jumps over the lazy dog. ", 100)))
+
+	snappy := ChunkToCompressedChunk(chnk)
+	assert.Equal(t, SnappyCodec, snappy.Codec())
+	zstd := ChunkToCompressedChunkWithCodec(chnk, ZstdCodec)
+	assert.Equal(t, ZstdCodec, zstd.Codec())
+
+	for _, cmp := range []CompressedChunk{snappy, zstd} {
+		decoded, err := cmp.ToChunk()
+		require.NoError(t, err)
+		assert.Equal(t, chnk.Data(), decoded.Data())
+
+		n, err := decodedLen(cmp.CompressedData)
+		require.NoError(t, err)
+		assert.Equal(t, len(chnk.Data()), n)
+
+		read, err := NewCompressedChunk(cmp.H, cmp.FullCompressedChunk)
+		require.NoError(t, err)
+		assert.Equal(t, cmp.CompressedData, read.CompressedData)
+	}
+
+	empty := ChunkToCompressedChunkWithCodec(chunks.EmptyChunk, ZstdCodec)
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestParseChunkCodec(t *testing.T) {
+	codec, err := ParseChunkCodec("ZSTD")
+	require.NoError(t, err)
+	assert.Equal(t, ZstdCodec, codec)
+	codec, err = ParseChunkCodec("snappy")
+	require.NoError(t, err)
+	assert.Equal(t, SnappyCodec, codec)
+	_, err = ParseChunkCodec("lz4")
+	assert.Error(t, err)
+}
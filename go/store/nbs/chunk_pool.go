@@ -0,0 +1,211 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ChunkPool is a directory of table files shared by the NomsBlockStores of several databases on the same filesystem,
+// such as the forks of a database hosted by a single sql-server. Table files added to a member store with
+// AddTableFilesToManifest, as they are when the database is pushed to, are hard linked into the pool. When a member
+// store is asked by HasMany for chunks it doesn't have, but which are in a pooled table file, that table file is hard
+// linked into the store and added to its manifest, so the chunks are not uploaded again. A pooled table file takes up
+// space on disk only once, however many stores it is linked into.
+//
+// Table files are never removed from the pool. Garbage collecting a member store rewrites the chunks it keeps into
+// table files of its own, which are not pooled.
+type ChunkPool struct {
+	dir string
+	q   MemoryQuotaProvider
+
+	mu      sync.RWMutex
+	sources map[addr]chunkSource
+}
+
+// NewChunkPool opens the ChunkPool in |dir|, creating the directory if it does not exist.
+func NewChunkPool(ctx context.Context, dir string, q MemoryQuotaProvider) (*ChunkPool, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ChunkPool{dir: dir, q: q, sources: make(map[addr]chunkSource)}
+	for _, e := range entries {
+		if e.IsDir() || !ValidateAddr(e.Name()) {
+			continue
+		}
+		name, err := parseAddr(e.Name())
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		count, err := tableFileChunkCount(filepath.Join(dir, e.Name()))
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		src, err := newFileTableReader(ctx, dir, name, count, q)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.sources[name] = src
+	}
+	return p, nil
+}
+
+// Dir returns the directory the pool's table files are in.
+func (p *ChunkPool) Dir() string {
+	return p.dir
+}
+
+// Close closes the pool's table files.
+func (p *ChunkPool) Close() (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, src := range p.sources {
+		if cerr := src.close(); err == nil {
+			err = cerr
+		}
+		delete(p.sources, name)
+	}
+	return err
+}
+
+// add hard links the table file |name| in |dir| into the pool.
+func (p *ChunkPool) add(ctx context.Context, dir string, name addr, chunkCount uint32) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.sources[name]; ok {
+		return nil
+	}
+
+	err := os.Link(filepath.Join(dir, name.String()), filepath.Join(p.dir, name.String()))
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	src, err := newFileTableReader(ctx, p.dir, name, chunkCount, p.q)
+	if err != nil {
+		return err
+	}
+	p.sources[name] = src
+	return nil
+}
+
+// find sets hasRecord.has for each of |reqs| that is in a pooled table file, and returns those table files with
+// their chunk counts.
+func (p *ChunkPool) find(reqs []hasRecord) (map[addr]uint32, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	found := make(map[addr]uint32)
+	for i := range reqs {
+		if reqs[i].has {
+			continue
+		}
+		for name, src := range p.sources {
+			ok, err := src.has(*reqs[i].a)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			reqs[i].has = true
+			if _, ok := found[name]; !ok {
+				count, err := src.count()
+				if err != nil {
+					return nil, err
+				}
+				found[name] = count
+			}
+			break
+		}
+	}
+	return found, nil
+}
+
+// link hard links the pooled table file |name| into |dir|.
+func (p *ChunkPool) link(dir string, name addr) error {
+	err := os.Link(filepath.Join(p.dir, name.String()), filepath.Join(dir, name.String()))
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+func tableFileChunkCount(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	count, _, err := ReadTableFooter(f)
+	return count, err
+}
+
+// SetChunkPool makes the store share the table files it is sent through AddTableFilesToManifest with |pool|, and
+// answer HasMany with the chunks in |pool|'s table files. |pool| must be on the same filesystem as the store. See
+// ChunkPool.
+func (nbs *NomsBlockStore) SetChunkPool(pool *ChunkPool) error {
+	if _, ok := nbs.Path(); !ok {
+		return errors.New("chunk pools are only supported by local stores")
+	}
+	nbs.pool = pool
+	return nil
+}
+
+// adoptPooled links the pooled table files holding any of |absent| into the store and returns the chunks that are
+// still absent.
+func (nbs *NomsBlockStore) adoptPooled(ctx context.Context, absent hash.HashSet) (hash.HashSet, error) {
+	reqs := toHasRecords(absent)
+	files, err := nbs.pool.find(reqs)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return absent, nil
+	}
+
+	dir, _ := nbs.Path()
+	updates := make(map[hash.Hash]uint32, len(files))
+	for name, count := range files {
+		if err = nbs.pool.link(dir, name); err != nil {
+			return nil, err
+		}
+		updates[hash.Hash(name)] = count
+	}
+	if _, err = nbs.UpdateManifest(ctx, updates); err != nil {
+		return nil, err
+	}
+
+	remaining := hash.HashSet{}
+	for _, r := range reqs {
+		if !r.has {
+			remaining.Insert(hash.New(r.a[:]))
+		}
+	}
+	return remaining, nil
+}
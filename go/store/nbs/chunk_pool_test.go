@@ -0,0 +1,82 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+func TestChunkPool(t *testing.T) {
+	ctx := context.Background()
+	poolDir := filepath.Join(tempfiles.MovableTempFileProvider.GetTempDir(), "pool_"+uuid.New().String()[:8])
+	pool, err := NewChunkPool(ctx, poolDir, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pushed, pushedDir, _ := makeTestLocalStore(t, 8)
+	defer pushed.Close()
+	require.NoError(t, pushed.SetChunkPool(pool))
+	fork, forkDir, _ := makeTestLocalStore(t, 8)
+	defer fork.Close()
+	require.NoError(t, fork.SetChunkPool(pool))
+
+	const numTableFiles = 3
+	ftd := populateLocalStore(t, pushed, numTableFiles)
+	hashes := hash.HashSet{}
+	for i := 0; i < numTableFiles; i++ {
+		for j := 0; j < i+1; j++ {
+			hashes.Insert(hash.Of([]byte(fmt.Sprintf("%d:%d:%d", i, j, 0))))
+		}
+	}
+	unpooled := hash.Of([]byte("not in any table file"))
+	hashes.Insert(unpooled)
+
+	absent, err := fork.HasMany(ctx, hashes)
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(unpooled), absent)
+
+	for h := range hashes {
+		c, err := fork.Get(ctx, h)
+		require.NoError(t, err)
+		assert.Equal(t, h != unpooled, !c.IsEmpty())
+	}
+
+	// every table file is stored once, and shared by both stores
+	for fileID := range ftd {
+		pooledInfo, err := os.Stat(filepath.Join(poolDir, fileID))
+		require.NoError(t, err)
+		for _, dir := range []string{pushedDir, forkDir} {
+			info, err := os.Stat(filepath.Join(dir, fileID))
+			require.NoError(t, err)
+			assert.True(t, os.SameFile(pooledInfo, info))
+		}
+	}
+
+	reopened, err := NewChunkPool(ctx, poolDir, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer reopened.Close()
+	assert.Len(t, reopened.sources, numTableFiles)
+}
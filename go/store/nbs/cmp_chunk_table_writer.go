@@ -22,8 +22,6 @@ import (
 	"io"
 	"os"
 	"sort"
-
-	"github.com/golang/snappy"
 )
 
 const defaultTableSinkBlockSize = 2 * 1024 * 1024
@@ -78,7 +76,7 @@ func (tw *CmpChunkTableWriter) AddCmpChunk(c CompressedChunk) error {
 		panic("NBS blocks cannot be zero length")
 	}
 
-	uncmpLen, err := snappy.DecodedLen(c.CompressedData)
+	uncmpLen, err := decodedLen(c.CompressedData)
 
 	if err != nil {
 		return err
@@ -34,25 +34,80 @@ type conjoinStrategy interface {
 	// conjoinRequired returns true if |conjoin| should be called.
 	conjoinRequired(ts tableSet) bool
 
-	// chooseConjoinees chooses which chunkSources to conjoin from |sources|
-	chooseConjoinees(specs []tableSpec) (conjoinees, keepers []tableSpec, err error)
+	// chooseConjoinees chooses which chunkSources to conjoin from |sources|. |sizes| holds the physical sizes of
+	// the table files that are known, keyed by table file name.
+	chooseConjoinees(specs []tableSpec, sizes map[addr]uint64) (conjoinees, keepers []tableSpec, err error)
 }
 
+// ConjoinPolicy configures when a NomsBlockStore conjoins its table files, and which of them it conjoins.
+type ConjoinPolicy struct {
+	// MaxTables is the number of table files a store may have before it conjoins some of them.
+	MaxTables int
+	// TargetFileSize is the size, in bytes, that conjoined table files are grown to. Table files that are at least
+	// this large are never conjoined. Zero means table files are conjoined regardless of their size.
+	TargetFileSize uint64
+}
+
+// DefaultConjoinPolicy is the ConjoinPolicy of a NomsBlockStore unless it's configured otherwise.
+var DefaultConjoinPolicy = ConjoinPolicy{MaxTables: defaultMaxTables}
+
+func (p ConjoinPolicy) conjoiner() inlineConjoiner {
+	return inlineConjoiner{maxTables: p.MaxTables, targetSize: p.TargetFileSize}
+}
+
+// ErrConjoinUnsupported is returned when conjoining the table files of a store that isn't a TableFileConjoiner.
+var ErrConjoinUnsupported = errors.New("the store does not support conjoining table files")
+
+// TableFileConjoiner is a store whose conjoining of table files can be configured and triggered.
+type TableFileConjoiner interface {
+	// SetConjoinPolicy changes the ConjoinPolicy of the store.
+	SetConjoinPolicy(p ConjoinPolicy)
+	// ConjoinTableFiles conjoins the table files of the store that are smaller than |targetSize| bytes, and returns
+	// the number of table files in the store afterwards.
+	ConjoinTableFiles(ctx context.Context, targetSize uint64) (int, error)
+}
+
+var _ TableFileConjoiner = (*NomsBlockStore)(nil)
+
 type inlineConjoiner struct {
-	maxTables int
+	maxTables  int
+	targetSize uint64
 }
 
 var _ conjoinStrategy = inlineConjoiner{}
 
 func (c inlineConjoiner) conjoinRequired(ts tableSet) bool {
-	return ts.Size() > c.maxTables && len(ts.upstream) >= 2
+	if ts.Size() <= c.maxTables || len(ts.upstream) < 2 {
+		return false
+	}
+	if c.targetSize == 0 {
+		return true
+	}
+	small := 0
+	for _, cs := range ts.upstream {
+		if cs.currentSize() < c.targetSize {
+			small++
+		}
+	}
+	return small >= 2
 }
 
 // chooseConjoinees implements conjoinStrategy. Current approach is to choose the smallest N tables which,
-// when removed and replaced with the conjoinment, will leave the conjoinment as the smallest table.
-func (c inlineConjoiner) chooseConjoinees(upstream []tableSpec) (conjoinees, keepers []tableSpec, err error) {
-	sorted := make([]tableSpec, len(upstream))
-	copy(sorted, upstream)
+// when removed and replaced with the conjoinment, will leave the conjoinment as the smallest table. If the
+// conjoiner has a target size, tables at least that large are kept and the conjoinment stops growing once
+// it would exceed the target size.
+func (c inlineConjoiner) chooseConjoinees(upstream []tableSpec, sizes map[addr]uint64) (conjoinees, keepers []tableSpec, err error) {
+	sorted := make([]tableSpec, 0, len(upstream))
+	for _, spec := range upstream {
+		if c.targetSize > 0 && sizes[spec.name] >= c.targetSize {
+			keepers = append(keepers, spec)
+		} else {
+			sorted = append(sorted, spec)
+		}
+	}
+	if len(sorted) < 2 {
+		return nil, upstream, nil
+	}
 
 	sort.Slice(sorted, func(i, j int) bool {
 		return sorted[i].chunkCount < sorted[j].chunkCount
@@ -60,15 +115,20 @@ func (c inlineConjoiner) chooseConjoinees(upstream []tableSpec) (conjoinees, kee
 
 	i := 2
 	sum := sorted[0].chunkCount + sorted[1].chunkCount
+	size := sizes[sorted[0].name] + sizes[sorted[1].name]
 	for i < len(sorted) {
 		next := sorted[i].chunkCount
 		if sum <= next {
 			break
 		}
+		if c.targetSize > 0 && size+sizes[sorted[i].name] > c.targetSize {
+			break
+		}
 		sum += next
+		size += sizes[sorted[i].name]
 		i++
 	}
-	return sorted[:i], sorted[i:], nil
+	return sorted[:i], append(sorted[i:], keepers...), nil
 }
 
 type noopConjoiner struct{}
@@ -79,7 +139,7 @@ func (c noopConjoiner) conjoinRequired(ts tableSet) bool {
 	return false
 }
 
-func (c noopConjoiner) chooseConjoinees(sources []tableSpec) (conjoinees, keepers []tableSpec, err error) {
+func (c noopConjoiner) chooseConjoinees(sources []tableSpec, sizes map[addr]uint64) (conjoinees, keepers []tableSpec, err error) {
 	keepers = sources
 	return
 }
@@ -90,11 +150,13 @@ func (c noopConjoiner) chooseConjoinees(sources []tableSpec) (conjoinees, keeper
 // actually conjoin any upstream tables, usually because some out-of-
 // process actor has already landed a conjoin of its own. Callers must
 // handle this, likely by rebasing against upstream and re-evaluating the
-// situation.
-func conjoin(ctx context.Context, s conjoinStrategy, upstream manifestContents, mm manifestUpdater, p tablePersister, stats *Stats) (manifestContents, cleanupFunc, error) {
+// situation. |sizes| holds the physical sizes of the upstream tables that
+// are known, keyed by table file name.
+func conjoin(ctx context.Context, s conjoinStrategy, upstream manifestContents, sizes map[addr]uint64, mm manifestUpdater, p tablePersister, stats *Stats) (manifestContents, cleanupFunc, error) {
 	var conjoined tableSpec
 	var conjoinees, keepers, appendixSpecs []tableSpec
 	var cleanup cleanupFunc
+	original := upstream
 
 	for {
 		if conjoinees == nil {
@@ -106,9 +168,11 @@ func conjoin(ctx context.Context, s conjoinStrategy, upstream manifestContents,
 			}
 
 			var err error
-			conjoinees, keepers, err = s.chooseConjoinees(upstream.specs)
+			conjoinees, keepers, err = s.chooseConjoinees(upstream.specs, sizes)
 			if err != nil {
 				return manifestContents{}, nil, err
+			} else if len(conjoinees) == 0 {
+				return original, func() {}, nil
 			}
 
 			conjoined, cleanup, err = conjoinTables(ctx, conjoinees, p, stats)
@@ -206,7 +206,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 			t.Run(c.name, func(t *testing.T) {
 				fm, p, upstream := setup(startLock, startRoot, c.precompact)
 
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, fm, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, fm, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -227,7 +227,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 					specs := append([]tableSpec{}, upstream.specs...)
 					fm.set(constants.FormatLD1String, computeAddr([]byte("lock2")), startRoot, append(specs, newTable), nil)
 				}}
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, u, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, u, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -247,7 +247,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 				u := updatePreemptManifest{fm, func() {
 					fm.set(constants.FormatLD1String, computeAddr([]byte("lock2")), startRoot, upstream.specs[1:], nil)
 				}}
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, u, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, u, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -289,7 +289,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 			t.Run(c.name, func(t *testing.T) {
 				fm, p, upstream := setupAppendix(startLock, startRoot, c.precompact, c.appendix)
 
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, fm, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, fm, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -313,7 +313,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 					fm.set(constants.FormatLD1String, computeAddr([]byte("lock2")), startRoot, append(specs, newTable), upstream.appendix)
 				}}
 
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, u, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, u, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -338,7 +338,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 					fm.set(constants.FormatLD1String, computeAddr([]byte("lock2")), startRoot, append(specs, upstream.specs...), append(app, newTable))
 				}}
 
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, u, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, u, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -362,7 +362,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 				u := updatePreemptManifest{fm, func() {
 					fm.set(constants.FormatLD1String, computeAddr([]byte("lock2")), startRoot, upstream.specs[len(c.appendix)+1:], upstream.appendix[:])
 				}}
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, u, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, u, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -386,7 +386,7 @@ func testConjoin(t *testing.T, factory func(t *testing.T) tablePersister) {
 					fm.set(constants.FormatLD1String, computeAddr([]byte("lock2")), startRoot, specs, append([]tableSpec{}, newTable))
 				}}
 
-				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, u, p, stats)
+				_, _, err := conjoin(context.Background(), inlineConjoiner{}, upstream, nil, u, p, stats)
 				require.NoError(t, err)
 				exists, newUpstream, err := fm.ParseIfExists(context.Background(), stats, nil)
 				require.NoError(t, err)
@@ -409,3 +409,42 @@ func (u updatePreemptManifest) Update(ctx context.Context, lastLock addr, newCon
 	}
 	return u.manifest.Update(ctx, lastLock, newContents, stats, writeHook)
 }
+
+func TestChooseConjoineesWithTargetSize(t *testing.T) {
+	spec := func(name string, count uint32) tableSpec {
+		return tableSpec{name: computeAddr([]byte(name)), chunkCount: count}
+	}
+	a, b, c, d := spec("a", 2), spec("b", 3), spec("c", 4), spec("d", 100)
+	sizes := map[addr]uint64{a.name: 10, b.name: 20, c.name: 30, d.name: 1000}
+	names := func(specs []tableSpec) (n []addr) {
+		for _, s := range specs {
+			n = append(n, s.name)
+		}
+		return
+	}
+
+	t.Run("no target size", func(t *testing.T) {
+		conjoinees, keepers, err := inlineConjoiner{}.chooseConjoinees([]tableSpec{d, c, b, a}, sizes)
+		require.NoError(t, err)
+		assert.Equal(t, names([]tableSpec{a, b, c}), names(conjoinees))
+		assert.Equal(t, names([]tableSpec{d}), names(keepers))
+	})
+	t.Run("conjoinment stops at target size", func(t *testing.T) {
+		conjoinees, keepers, err := inlineConjoiner{targetSize: 40}.chooseConjoinees([]tableSpec{d, c, b, a}, sizes)
+		require.NoError(t, err)
+		assert.Equal(t, names([]tableSpec{a, b}), names(conjoinees))
+		assert.ElementsMatch(t, names([]tableSpec{c, d}), names(keepers))
+	})
+	t.Run("tables at target size are kept", func(t *testing.T) {
+		conjoinees, keepers, err := inlineConjoiner{targetSize: 30}.chooseConjoinees([]tableSpec{d, c, b, a}, sizes)
+		require.NoError(t, err)
+		assert.Equal(t, names([]tableSpec{a, b}), names(conjoinees))
+		assert.ElementsMatch(t, names([]tableSpec{c, d}), names(keepers))
+	})
+	t.Run("nothing to conjoin", func(t *testing.T) {
+		conjoinees, keepers, err := inlineConjoiner{targetSize: 15}.chooseConjoinees([]tableSpec{d, c, b, a}, sizes)
+		require.NoError(t, err)
+		assert.Empty(t, conjoinees)
+		assert.Len(t, keepers, 4)
+	})
+}
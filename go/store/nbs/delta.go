@@ -0,0 +1,244 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrCorruptDelta is returned when a delta can't be applied to its base.
+var ErrCorruptDelta = errors.New("corrupt chunk delta")
+
+// deltaBlockSize is the length of the runs of a base chunk that are indexed when looking for copies. Matches shorter
+// than this are sent as literal bytes.
+const deltaBlockSize = 16
+
+// DeltaChunk is a chunk as sent by a DeltaChunkSource: either as a delta against a base chunk that the receiver
+// already has, or in full.
+type DeltaChunk struct {
+	H hash.Hash
+	// Base is the address of the chunk that Delta applies to. It is empty if the chunk is sent in full.
+	Base hash.Hash
+	// Delta is the snappy compressed delta from Base to the chunk.
+	Delta []byte
+	// Full is the chunk, set if Base is empty.
+	Full CompressedChunk
+}
+
+// IsDelta returns whether |dc| is sent as a delta.
+func (dc DeltaChunk) IsDelta() bool {
+	return !dc.Base.IsEmpty()
+}
+
+// Size returns the number of bytes it takes to send |dc|.
+func (dc DeltaChunk) Size() int {
+	if dc.IsDelta() {
+		return len(dc.Delta)
+	}
+	return len(dc.Full.FullCompressedChunk)
+}
+
+// ToCompressedChunk returns the chunk |dc| describes. |base| must be the chunk at dc.Base if |dc| is a delta.
+func (dc DeltaChunk) ToCompressedChunk(base chunks.Chunk) (CompressedChunk, error) {
+	if !dc.IsDelta() {
+		return dc.Full, nil
+	}
+	if base.Hash() != dc.Base {
+		return CompressedChunk{}, fmt.Errorf("%w: base of %s is %s, not %s", ErrCorruptDelta, dc.H.String(), dc.Base.String(), base.Hash().String())
+	}
+
+	delta, err := snappy.Decode(nil, dc.Delta)
+	if err != nil {
+		return CompressedChunk{}, err
+	}
+	data, err := ApplyDelta(base.Data(), delta)
+	if err != nil {
+		return CompressedChunk{}, err
+	}
+	if h := hash.Of(data); h != dc.H {
+		return CompressedChunk{}, fmt.Errorf("%w: expected %s, got %s", ErrCorruptDelta, dc.H.String(), h.String())
+	}
+
+	return ChunkToCompressedChunk(chunks.NewChunkWithHash(dc.H, data)), nil
+}
+
+// DeltaChunkSource is implemented by chunk stores that can send chunks as deltas against chunks the receiver already
+// has, which is much smaller than the full chunk when only a few bytes have changed.
+type DeltaChunkSource interface {
+	// GetManyDeltas calls |found| once for every chunk in |targets|, which maps the address of each requested chunk to
+	// a base chunk that the receiver has, or to the empty hash. A chunk is sent in full if it has no base, if the
+	// source doesn't have its base, or if the delta is no smaller than the chunk.
+	GetManyDeltas(ctx context.Context, targets map[hash.Hash]hash.Hash, found func(context.Context, DeltaChunk)) error
+}
+
+// getManyDeltas implements DeltaChunkSource for |cs|.
+func getManyDeltas(ctx context.Context, cs NBSCompressedChunkStore, targets map[hash.Hash]hash.Hash, found func(context.Context, DeltaChunk)) error {
+	hashes := make(hash.HashSet, len(targets))
+	for h := range targets {
+		hashes.Insert(h)
+	}
+
+	var mu sync.Mutex
+	var deltaErr error
+	err := cs.GetManyCompressed(ctx, hashes, func(ctx context.Context, cmp CompressedChunk) {
+		dc := DeltaChunk{H: cmp.H, Full: cmp}
+		if base := targets[cmp.H]; !base.IsEmpty() {
+			var err error
+			dc, err = deltaAgainst(ctx, cs, cmp, base)
+			if err != nil {
+				mu.Lock()
+				deltaErr = err
+				mu.Unlock()
+				return
+			}
+		}
+		found(ctx, dc)
+	})
+	if err != nil {
+		return err
+	}
+	return deltaErr
+}
+
+// deltaAgainst returns |cmp| as a delta against |base|, or in full if that is smaller or |cs| doesn't have |base|.
+func deltaAgainst(ctx context.Context, cs chunks.ChunkStore, cmp CompressedChunk, base hash.Hash) (DeltaChunk, error) {
+	full := DeltaChunk{H: cmp.H, Full: cmp}
+
+	baseChunk, err := cs.Get(ctx, base)
+	if err != nil {
+		return DeltaChunk{}, err
+	} else if baseChunk.IsEmpty() {
+		return full, nil
+	}
+	chnk, err := cmp.ToChunk()
+	if err != nil {
+		return DeltaChunk{}, err
+	}
+
+	delta := snappy.Encode(nil, EncodeDelta(baseChunk.Data(), chnk.Data()))
+	if len(delta) >= len(cmp.FullCompressedChunk) {
+		return full, nil
+	}
+	return DeltaChunk{H: cmp.H, Base: base, Delta: delta}, nil
+}
+
+// EncodeDelta returns a delta that ApplyDelta turns |base| into |target| with. The delta is a sequence of operations,
+// each either copying a range of |base| or inserting literal bytes.
+func EncodeDelta(base, target []byte) []byte {
+	blocks := make(map[[deltaBlockSize]byte]int, len(base)/deltaBlockSize)
+	for off := 0; off+deltaBlockSize <= len(base); off += deltaBlockSize {
+		var blk [deltaBlockSize]byte
+		copy(blk[:], base[off:])
+		if _, ok := blocks[blk]; !ok {
+			blocks[blk] = off
+		}
+	}
+
+	delta := binary.AppendUvarint(nil, uint64(len(target)))
+	pending := 0
+	for i := 0; i+deltaBlockSize <= len(target); {
+		var blk [deltaBlockSize]byte
+		copy(blk[:], target[i:])
+		off, ok := blocks[blk]
+		if !ok {
+			i++
+			continue
+		}
+
+		// extend the match backwards over pending literal bytes, and forwards as far as it goes
+		start, baseStart := i, off
+		for start > pending && baseStart > 0 && target[start-1] == base[baseStart-1] {
+			start--
+			baseStart--
+		}
+		end, baseEnd := i+deltaBlockSize, off+deltaBlockSize
+		for end < len(target) && baseEnd < len(base) && target[end] == base[baseEnd] {
+			end++
+			baseEnd++
+		}
+
+		delta = appendDeltaInsert(delta, target[pending:start])
+		delta = appendDeltaCopy(delta, baseStart, end-start)
+		i, pending = end, end
+	}
+
+	return appendDeltaInsert(delta, target[pending:])
+}
+
+// ApplyDelta returns the result of applying |delta|, as returned by EncodeDelta, to |base|.
+func ApplyDelta(base, delta []byte) ([]byte, error) {
+	n, k := binary.Uvarint(delta)
+	if k <= 0 {
+		return nil, ErrCorruptDelta
+	}
+	delta = delta[k:]
+
+	// |n| is only a hint, it's not trusted to size the buffer
+	hint := uint64(len(base) + len(delta))
+	if n < hint {
+		hint = n
+	}
+	out := make([]byte, 0, hint)
+	for len(delta) > 0 {
+		op, k := binary.Uvarint(delta)
+		if k <= 0 {
+			return nil, ErrCorruptDelta
+		}
+		delta = delta[k:]
+
+		l := op >> 1
+		if op&1 == 1 {
+			off, k := binary.Uvarint(delta)
+			if k <= 0 || off > uint64(len(base)) || l > uint64(len(base))-off {
+				return nil, ErrCorruptDelta
+			}
+			delta = delta[k:]
+			out = append(out, base[off:off+l]...)
+		} else {
+			if l > uint64(len(delta)) {
+				return nil, ErrCorruptDelta
+			}
+			out = append(out, delta[:l]...)
+			delta = delta[l:]
+		}
+	}
+
+	if uint64(len(out)) != n {
+		return nil, ErrCorruptDelta
+	}
+	return out, nil
+}
+
+func appendDeltaInsert(delta, literal []byte) []byte {
+	if len(literal) == 0 {
+		return delta
+	}
+	delta = binary.AppendUvarint(delta, uint64(len(literal))<<1)
+	return append(delta, literal...)
+}
+
+func appendDeltaCopy(delta []byte, off, length int) []byte {
+	delta = binary.AppendUvarint(delta, uint64(length)<<1|1)
+	return binary.AppendUvarint(delta, uint64(off))
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkDelta(t *testing.T) {
+	base := []byte(strings.Repeat("the quick brown fox jumps over the lazy dog. ", 40))
+	target := []byte(strings.Replace(string(base), "lazy", "sleepy", 3))
+	target = append([]byte("prefix "), target...)
+
+	delta := EncodeDelta(base, target)
+	assert.Less(t, len(delta), len(target)/4)
+	applied, err := ApplyDelta(base, delta)
+	require.NoError(t, err)
+	assert.Equal(t, target, applied)
+
+	applied, err = ApplyDelta(nil, EncodeDelta(nil, target))
+	require.NoError(t, err)
+	assert.Equal(t, target, applied)
+
+	_, err = ApplyDelta(base[:10], delta)
+	assert.ErrorIs(t, err, ErrCorruptDelta)
+}
@@ -0,0 +1,250 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// epochFileName is the name of the file the fencing epoch of a store is stored in, next to its manifest.
+const epochFileName = "manifest.epoch"
+
+// ErrFencedEpoch is returned when a store is written at an epoch lower than the one it was fenced at.
+var ErrFencedEpoch = errors.New("the database was fenced at a newer epoch")
+
+// ErrNoEpoch is returned when a store can't store a fencing epoch. Only stores with a file or blobstore manifest can,
+// so remotes served over remotesapi, like DoltHub and sql-servers, and aws:// remotes with a DynamoDB manifest can't
+// be fenced.
+var ErrNoEpoch = errors.New("the database does not support fencing epochs")
+
+// EpochStore is implemented by chunk stores that can be fenced. A store is fenced at an epoch, which only ever goes up,
+// and a writer of the store can set the epoch it writes at. Once the store is fenced at a higher epoch, the writer's
+// updates of the root fail with ErrFencedEpoch. This keeps a writer that was superseded by another one from
+// updating the store after the other one took over.
+type EpochStore interface {
+	// ReadEpoch returns the epoch the store was fenced at, or 0 if it never was.
+	ReadEpoch(ctx context.Context) (uint64, error)
+	// FenceEpoch fences the store at |epoch|. It returns ErrFencedEpoch if the store is already fenced at |epoch| or
+	// a higher one.
+	FenceEpoch(ctx context.Context, epoch uint64) error
+	// SetWriterEpoch makes updates of the root of the store fail once it's fenced at an epoch higher than |epoch|.
+	SetWriterEpoch(epoch uint64)
+}
+
+// epochPersister is implemented by manifests which can store a fencing epoch alongside them.
+type epochPersister interface {
+	readEpoch(ctx context.Context) (uint64, error)
+	// fenceEpoch raises the stored epoch to |epoch| with the same exclusive access to the manifest its updates have.
+	fenceEpoch(ctx context.Context, epoch uint64) error
+}
+
+func readEpoch(ctx context.Context, m manifest) (uint64, error) {
+	ep, ok := m.(epochPersister)
+	if !ok {
+		return 0, ErrNoEpoch
+	}
+	return ep.readEpoch(ctx)
+}
+
+func fenceEpoch(ctx context.Context, m manifest, epoch uint64) error {
+	ep, ok := m.(epochPersister)
+	if !ok {
+		return ErrNoEpoch
+	}
+	return ep.fenceEpoch(ctx, epoch)
+}
+
+// checkEpoch returns ErrFencedEpoch if the store of |m| is fenced at an epoch higher than |epoch|.
+func checkEpoch(ctx context.Context, m manifest, epoch uint64) error {
+	current, err := readEpoch(ctx, m)
+	if err != nil {
+		return err
+	}
+	if current > epoch {
+		return fmt.Errorf("%w: writing at epoch %d, fenced at epoch %d", ErrFencedEpoch, epoch, current)
+	}
+	return nil
+}
+
+// raiseEpoch returns the encoded |epoch| if it's higher than the |current| encoded one.
+func raiseEpoch(current []byte, epoch uint64) ([]byte, error) {
+	prev, err := parseEpoch(current)
+	if err != nil {
+		return nil, err
+	}
+	if prev >= epoch {
+		return nil, fmt.Errorf("%w: cannot fence at epoch %d, already fenced at epoch %d", ErrFencedEpoch, epoch, prev)
+	}
+	return []byte(strconv.FormatUint(epoch, 10)), nil
+}
+
+func parseEpoch(data []byte) (uint64, error) {
+	s := strings.TrimSpace(string(data))
+	if s == "" {
+		return 0, nil
+	}
+	epoch, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fencing epoch '%s': %w", s, err)
+	}
+	return epoch, nil
+}
+
+func readEpochFile(dir string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(dir, epochFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return parseEpoch(data)
+}
+
+// fenceEpochFile raises the epoch stored in |dir|. Callers must hold the manifest's file lock.
+func fenceEpochFile(dir string, epoch uint64) error {
+	path := filepath.Join(dir, epochFileName)
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	data, err := raiseEpoch(current, epoch)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (fm fileManifest) readEpoch(_ context.Context) (uint64, error) {
+	return readEpochFile(fm.dir)
+}
+
+func (fm fileManifest) fenceEpoch(_ context.Context, epoch uint64) (err error) {
+	if err = tryFileLock(fm.lock); err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := fm.lock.Unlock(); err == nil {
+			err = cerr
+		}
+	}()
+	return fenceEpochFile(fm.dir, epoch)
+}
+
+func (jm *journalManifest) readEpoch(_ context.Context) (uint64, error) {
+	return readEpochFile(jm.dir)
+}
+
+// fenceEpoch implements epochPersister. A journalManifest holds its file lock for as long as it's open, so only the
+// process writing to the store can fence it.
+func (jm *journalManifest) fenceEpoch(_ context.Context, epoch uint64) error {
+	if jm.readOnly() {
+		return errReadOnlyManifest
+	}
+	return fenceEpochFile(jm.dir, epoch)
+}
+
+func (j *chunkJournal) readEpoch(ctx context.Context) (uint64, error) {
+	return j.backing.readEpoch(ctx)
+}
+
+func (j *chunkJournal) fenceEpoch(ctx context.Context, epoch uint64) error {
+	return j.backing.fenceEpoch(ctx, epoch)
+}
+
+func (bsm blobstoreManifest) readEpoch(ctx context.Context) (uint64, error) {
+	epoch, _, err := bsm.readEpochVersion(ctx)
+	return epoch, err
+}
+
+func (bsm blobstoreManifest) readEpochVersion(ctx context.Context) (uint64, string, error) {
+	rd, ver, err := bsm.bs.Get(ctx, epochFileName, blobstore.AllRange)
+	if blobstore.IsNotFoundError(err) {
+		return 0, "", nil
+	} else if err != nil {
+		return 0, "", err
+	}
+	defer rd.Close()
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return 0, "", err
+	}
+	epoch, err := parseEpoch(data)
+	return epoch, ver, err
+}
+
+// fenceEpoch implements epochPersister. The epoch is replaced with a check and set on its version, since blobstores
+// have no locks. Writers check the epoch after reading the version of the manifest they replace, see
+// updateBSWithChecker, so the manifest is rewritten once the epoch is raised: writers which checked the epoch before
+// then fail to replace it, and check it again when they retry.
+func (bsm blobstoreManifest) fenceEpoch(ctx context.Context, epoch uint64) error {
+	current, ver, err := bsm.readEpochVersion(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := raiseEpoch([]byte(strconv.FormatUint(current, 10)), epoch)
+	if err != nil {
+		return err
+	}
+	_, err = bsm.bs.CheckAndPut(ctx, ver, epochFileName, bytes.NewReader(data))
+	if blobstore.IsCheckAndPutError(err) {
+		return fmt.Errorf("%w: the epoch changed while fencing at epoch %d", ErrFencedEpoch, epoch)
+	} else if err != nil {
+		return err
+	}
+	return bsm.rewriteManifest(ctx)
+}
+
+// rewriteManifest replaces the manifest with its own contents, to change its version. A store without a manifest was
+// never written to, and is left as it is.
+func (bsm blobstoreManifest) rewriteManifest(ctx context.Context) error {
+	ver, contents, err := manifestVersionAndContents(ctx, bsm.bs)
+	if blobstore.IsNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if err = writeManifest(buf, contents); err != nil {
+		return err
+	}
+	_, err = bsm.bs.CheckAndPut(ctx, ver, manifestFile, buf)
+	if blobstore.IsCheckAndPutError(err) {
+		// a writer replaced the manifest since it was read, which changed its version just the same
+		return nil
+	}
+	return err
+}
+
+// epochWriteHook returns a manifest write hook which checks that the store isn't fenced at an epoch higher than its
+// writer epoch, or nil if it has no writer epoch. Callers must acquire lock |nbs.mu|.
+func (nbs *NomsBlockStore) epochWriteHook(ctx context.Context) func() error {
+	if !nbs.hasWriterEpoch {
+		return nil
+	}
+	m, epoch := nbs.mm.m, nbs.writerEpoch
+	return func() error {
+		return checkEpoch(ctx, m, epoch)
+	}
+}
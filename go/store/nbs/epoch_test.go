@@ -0,0 +1,120 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestFileStoreEpoch(t *testing.T) {
+	ctx := context.Background()
+	primary, nomsDir, q := makeTestLocalStore(t, defaultMaxTables)
+	defer primary.Close()
+	promoted, err := newLocalStore(ctx, types.Format_Default.VersionString(), nomsDir, defaultMemTableSize, defaultMaxTables, q)
+	require.NoError(t, err)
+	defer promoted.Close()
+
+	testStoreEpoch(t, primary, promoted)
+}
+
+func TestBlobstoreEpoch(t *testing.T) {
+	ctx := context.Background()
+	bs := blobstore.NewInMemoryBlobstore("")
+	primary, err := NewBSStore(ctx, types.Format_Default.VersionString(), bs, defaultMemTableSize, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer primary.Close()
+	promoted, err := NewBSStore(ctx, types.Format_Default.VersionString(), bs, defaultMemTableSize, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer promoted.Close()
+
+	testStoreEpoch(t, primary, promoted)
+}
+
+// testStoreEpoch checks that |primary| and |promoted|, two stores of the same database, can only update its root at
+// the epoch it was last fenced at.
+func testStoreEpoch(t *testing.T, primary, promoted *NomsBlockStore) {
+	ctx := context.Background()
+	commit := func(st *NomsBlockStore, data string) error {
+		c := chunks.NewChunk([]byte(data))
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+		require.NoError(t, st.Rebase(ctx))
+		last, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, c.Hash(), last)
+		if err == nil {
+			assert.True(t, ok)
+		}
+		return err
+	}
+
+	epoch, err := primary.ReadEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), epoch)
+
+	primary.SetWriterEpoch(0)
+	require.NoError(t, commit(primary, "first"))
+
+	require.NoError(t, promoted.FenceEpoch(ctx, 1))
+	assert.ErrorIs(t, promoted.FenceEpoch(ctx, 1), ErrFencedEpoch)
+	epoch, err = primary.ReadEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), epoch)
+
+	assert.ErrorIs(t, commit(primary, "split brain"), ErrFencedEpoch)
+
+	promoted.SetWriterEpoch(1)
+	require.NoError(t, commit(promoted, "second"))
+
+	// stores without a writer epoch aren't fenced
+	primary.hasWriterEpoch = false
+	require.NoError(t, commit(primary, "third"))
+}
+
+// TestBlobstoreEpochFencedDuringUpdate checks that a writer of a blobstore which checked its epoch before the store was
+// fenced can't replace the manifest after it was.
+func TestBlobstoreEpochFencedDuringUpdate(t *testing.T) {
+	ctx := context.Background()
+	bs := blobstore.NewInMemoryBlobstore("")
+	bsm := blobstoreManifest{bs}
+	first := manifestContents{nbfVers: types.Format_Default.VersionString(), lock: computeAddr([]byte("first"))}
+	_, err := bsm.Update(ctx, addr{}, first, &Stats{}, nil)
+	require.NoError(t, err)
+
+	next := first
+	next.lock = computeAddr([]byte("split brain"))
+	check := func() error {
+		return checkEpoch(ctx, bsm, 0)
+	}
+	upstream, err := bsm.Update(ctx, first.lock, next, &Stats{}, func() error {
+		if err := check(); err != nil {
+			return err
+		}
+		// the store is fenced after the writer checked its epoch, but before it replaces the manifest
+		return bsm.fenceEpoch(ctx, 1)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first.lock, upstream.lock)
+
+	_, err = bsm.Update(ctx, upstream.lock, next, &Stats{}, check)
+	assert.ErrorIs(t, err, ErrFencedEpoch)
+}
@@ -29,6 +29,11 @@ import (
 var _ chunks.ChunkStore = (*GenerationalNBS)(nil)
 var _ chunks.GenerationalCS = (*GenerationalNBS)(nil)
 var _ chunks.TableFileStore = (*GenerationalNBS)(nil)
+var _ DeltaChunkSource = (*GenerationalNBS)(nil)
+var _ AttestationStore = (*GenerationalNBS)(nil)
+var _ EpochStore = (*GenerationalNBS)(nil)
+var _ AccessLogStore = (*GenerationalNBS)(nil)
+var _ TableFileConjoiner = (*GenerationalNBS)(nil)
 
 type GenerationalNBS struct {
 	oldGen *NomsBlockStore
@@ -39,6 +44,8 @@ func NewGenerationalCS(oldGen, newGen *NomsBlockStore) *GenerationalNBS {
 	if oldGen.Version() != "" && oldGen.Version() != newGen.Version() {
 		panic("oldgen and newgen chunkstore versions vary")
 	}
+	// the chunks read from either generation are sampled together
+	oldGen.sampler = newGen.sampler
 
 	return &GenerationalNBS{
 		oldGen: oldGen,
@@ -119,6 +126,58 @@ func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.H
 	return gcs.newGen.GetManyCompressed(ctx, notInOldGen, found)
 }
 
+// GetManyDeltas implements DeltaChunkSource.
+func (gcs *GenerationalNBS) GetManyDeltas(ctx context.Context, targets map[hash.Hash]hash.Hash, found func(context.Context, DeltaChunk)) error {
+	return getManyDeltas(ctx, gcs, targets, found)
+}
+
+// ReadAttestation implements AttestationStore. The attestation of a GenerationalNBS covers the table files of both
+// generations, and is stored with the new generation's manifest.
+func (gcs *GenerationalNBS) ReadAttestation(ctx context.Context) (ManifestAttestation, error) {
+	return gcs.newGen.ReadAttestation(ctx)
+}
+
+// WriteAttestation implements AttestationStore.
+func (gcs *GenerationalNBS) WriteAttestation(ctx context.Context, ma ManifestAttestation) error {
+	return gcs.newGen.WriteAttestation(ctx, ma)
+}
+
+// ReadEpoch implements EpochStore. The root of a GenerationalNBS is the root of its new generation, which is where
+// its epoch is stored.
+func (gcs *GenerationalNBS) ReadEpoch(ctx context.Context) (uint64, error) {
+	return gcs.newGen.ReadEpoch(ctx)
+}
+
+// FenceEpoch implements EpochStore.
+func (gcs *GenerationalNBS) FenceEpoch(ctx context.Context, epoch uint64) error {
+	return gcs.newGen.FenceEpoch(ctx, epoch)
+}
+
+// SetWriterEpoch implements EpochStore.
+func (gcs *GenerationalNBS) SetWriterEpoch(epoch uint64) {
+	gcs.newGen.SetWriterEpoch(epoch)
+}
+
+// SampleAccesses implements AccessLogStore. Both generations share the sampler of the new generation.
+func (gcs *GenerationalNBS) SampleAccesses(rate int) {
+	gcs.newGen.SampleAccesses(rate)
+}
+
+// SampledAccesses implements AccessLogStore.
+func (gcs *GenerationalNBS) SampledAccesses() []hash.Hash {
+	return gcs.newGen.SampledAccesses()
+}
+
+// ReadAccessLog implements AccessLogStore. The access log is stored with the new generation's manifest.
+func (gcs *GenerationalNBS) ReadAccessLog(ctx context.Context) ([]hash.Hash, error) {
+	return gcs.newGen.ReadAccessLog(ctx)
+}
+
+// WriteAccessLog implements AccessLogStore.
+func (gcs *GenerationalNBS) WriteAccessLog(ctx context.Context, hashes []hash.Hash) error {
+	return gcs.newGen.WriteAccessLog(ctx, hashes)
+}
+
 // Has returns true iff the value at the address |h| is contained in the store
 func (gcs *GenerationalNBS) Has(ctx context.Context, h hash.Hash) (bool, error) {
 	has, err := gcs.oldGen.Has(ctx, h)
@@ -312,6 +371,30 @@ func (gcs *GenerationalNBS) Size(ctx context.Context) (uint64, error) {
 	return oldSize + newSize, nil
 }
 
+// SetConjoinPolicy changes the ConjoinPolicy of the new and old gen stores
+func (gcs *GenerationalNBS) SetConjoinPolicy(p ConjoinPolicy) {
+	gcs.oldGen.SetConjoinPolicy(p)
+	gcs.newGen.SetConjoinPolicy(p)
+}
+
+// ConjoinTableFiles conjoins the table files smaller than |targetSize| bytes in the new and old gen stores, and returns
+// the number of table files in both of them afterwards
+func (gcs *GenerationalNBS) ConjoinTableFiles(ctx context.Context, targetSize uint64) (int, error) {
+	oldCount, err := gcs.oldGen.ConjoinTableFiles(ctx, targetSize)
+
+	if err != nil {
+		return 0, err
+	}
+
+	newCount, err := gcs.newGen.ConjoinTableFiles(ctx, targetSize)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return oldCount + newCount, nil
+}
+
 // WriteTableFile will read a table file from the provided reader and write it to the new gen TableFileStore
 func (gcs *GenerationalNBS) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
 	return gcs.newGen.WriteTableFile(ctx, fileId, numChunks, contentHash, getRd)
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/fslock"
@@ -44,8 +45,14 @@ type chunkJournal struct {
 	contents  manifestContents
 	backing   *journalManifest
 	persister *fsTablePersister
+
+	// onRootCommit, if set, is called after each root hash update with the manifest contents and the journal offset
+	// following the root hash record. See JournalReplicator.
+	onRootCommit atomic.Pointer[rootCommitHook]
 }
 
+type rootCommitHook func(contents manifestContents, end int64)
+
 var _ tablePersister = &chunkJournal{}
 var _ tableFilePersister = &chunkJournal{}
 var _ manifest = &chunkJournal{}
@@ -292,6 +299,9 @@ func (j *chunkJournal) Update(ctx context.Context, lastLock addr, next manifestC
 		return manifestContents{}, err
 	}
 	j.contents = next
+	if hook := j.onRootCommit.Load(); hook != nil {
+		(*hook)(next, j.wr.currentSize())
+	}
 
 	return j.contents, nil
 }
@@ -408,7 +418,7 @@ func (c journalConjoiner) conjoinRequired(ts tableSet) bool {
 	return c.child.conjoinRequired(ts)
 }
 
-func (c journalConjoiner) chooseConjoinees(upstream []tableSpec) (conjoinees, keepers []tableSpec, err error) {
+func (c journalConjoiner) chooseConjoinees(upstream []tableSpec, sizes map[addr]uint64) (conjoinees, keepers []tableSpec, err error) {
 	var stash tableSpec // don't conjoin journal
 	pruned := make([]tableSpec, 0, len(upstream))
 	for _, ts := range upstream {
@@ -418,7 +428,7 @@ func (c journalConjoiner) chooseConjoinees(upstream []tableSpec) (conjoinees, ke
 			pruned = append(pruned, ts)
 		}
 	}
-	conjoinees, keepers, err = c.child.chooseConjoinees(pruned)
+	conjoinees, keepers, err = c.child.chooseConjoinees(pruned, sizes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -553,3 +563,28 @@ func containsJournalSpec(specs []tableSpec) (ok bool) {
 	}
 	return
 }
+
+// ReadJournalRoots returns the root hashes recorded in the chunk journal of the NBS directory |dir|, in the order they
+// were committed, or nil if the store doesn't have a chunk journal. Roots committed before the journal was last
+// rewritten by garbage collection are not recorded.
+func ReadJournalRoots(ctx context.Context, dir string) ([]hash.Hash, error) {
+	f, err := os.Open(filepath.Join(dir, chunkJournalName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var roots []hash.Hash
+	_, err = processJournalRecords(ctx, f, 0, func(o int64, r journalRec) error {
+		if r.kind == rootHashJournalRecKind {
+			roots = append(roots, hash.Hash(r.address))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
@@ -0,0 +1,557 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// A journal replica is a write-ahead log of a chunk journal kept in a blobstore. The journal is copied in segments,
+// each holding the bytes of the journal from the end of the previous segment to the end of a root hash record. A
+// segment is followed by a meta blob listing the root hashes committed in it, the table files each of them needs and
+// when they were committed. The meta blob is written last, so a segment without one is ignored. Table files are
+// copied once, before the first segment that needs them.
+//
+// A replica can be restored to the end of any root hash record it holds, which recreates the chunk journal as it was
+// when the root hash was committed.
+
+const (
+	journalSegmentPrefix = "journal-"
+	journalSegmentMeta   = ".meta"
+	replicaTablePrefix   = "table-"
+
+	// DefaultJournalReplicationInterval is how often a JournalReplicator copies new journal records by default.
+	DefaultJournalReplicationInterval = time.Second
+)
+
+var ErrJournalReplicationUnsupported = errors.New("chunk store does not use a chunk journal")
+var ErrJournalRewritten = errors.New("chunk journal was rewritten by garbage collection; journal replication stopped")
+var ErrNoJournalRestorePoint = errors.New("journal replica has no restore point at the requested offset")
+
+// JournalRestorePoint is a root hash a journal replica can be restored to.
+type JournalRestorePoint struct {
+	// Offset is the offset of the end of the root hash record in the chunk journal.
+	Offset int64
+	// Root is the root hash of the chunk store.
+	Root hash.Hash
+	// Time is when the root hash was committed.
+	Time time.Time
+}
+
+type replicaSpec struct {
+	Name   string `json:"name"`
+	Chunks uint32 `json:"chunks"`
+}
+
+type replicaRoot struct {
+	Offset int64         `json:"offset"`
+	Root   string        `json:"root"`
+	Time   time.Time     `json:"time"`
+	Specs  []replicaSpec `json:"specs"`
+}
+
+type replicaOldGen struct {
+	Root  string        `json:"root"`
+	Specs []replicaSpec `json:"specs"`
+}
+
+type journalSegment struct {
+	Start      int64          `json:"start"`
+	End        int64          `json:"end"`
+	NbfVersion string         `json:"nbf_version"`
+	OldGen     *replicaOldGen `json:"oldgen,omitempty"`
+	Roots      []replicaRoot  `json:"roots"`
+}
+
+func journalSegmentKey(start int64) string {
+	return fmt.Sprintf("%s%020d", journalSegmentPrefix, start)
+}
+
+func replicaTableKey(name string) string {
+	return replicaTablePrefix + name
+}
+
+func toReplicaSpecs(specs []tableSpec) []replicaSpec {
+	rs := make([]replicaSpec, len(specs))
+	for i, s := range specs {
+		rs[i] = replicaSpec{Name: s.name.String(), Chunks: s.chunkCount}
+	}
+	return rs
+}
+
+func fromReplicaSpecs(rs []replicaSpec) ([]tableSpec, error) {
+	specs := make([]tableSpec, len(rs))
+	for i, s := range rs {
+		a, err := parseAddr(s.Name)
+		if err != nil {
+			return nil, err
+		}
+		specs[i] = tableSpec{name: a, chunkCount: s.Chunks}
+	}
+	return specs, nil
+}
+
+// readJournalSegments returns the segments of the journal replica in |bs|, in journal order.
+func readJournalSegments(ctx context.Context, bs blobstore.Blobstore) ([]journalSegment, error) {
+	var segs []journalSegment
+	var start int64
+	for {
+		key := journalSegmentKey(start) + journalSegmentMeta
+		ok, err := bs.Exists(ctx, key)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return segs, nil
+		}
+
+		data, _, err := blobstore.GetBytes(ctx, bs, key, blobstore.AllRange)
+		if err != nil {
+			return nil, err
+		}
+		var seg journalSegment
+		if err = json.Unmarshal(data, &seg); err != nil {
+			return nil, fmt.Errorf("invalid journal replica segment %s: %w", key, err)
+		}
+		if seg.Start != start || seg.End <= seg.Start {
+			return nil, fmt.Errorf("invalid journal replica segment %s: bad range [%d, %d)", key, seg.Start, seg.End)
+		}
+		segs = append(segs, seg)
+		start = seg.End
+	}
+}
+
+// ReadJournalReplica returns the points the journal replica in |bs| can be restored to, in journal order.
+func ReadJournalReplica(ctx context.Context, bs blobstore.Blobstore) ([]JournalRestorePoint, error) {
+	segs, err := readJournalSegments(ctx, bs)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []JournalRestorePoint
+	for _, seg := range segs {
+		for _, r := range seg.Roots {
+			root, ok := hash.MaybeParse(r.Root)
+			if !ok {
+				return nil, fmt.Errorf("invalid root hash in journal replica: %s", r.Root)
+			}
+			points = append(points, JournalRestorePoint{Offset: r.Offset, Root: root, Time: r.Time})
+		}
+	}
+	return points, nil
+}
+
+// RestoreJournalReplica writes the chunk journal, table files and manifests of the journal replica in |bs|, as they
+// were when the root hash ending at |offset| was committed, to the empty NBS directory |dir|. Table files of the old
+// generation of the replicated store are written to |dir|/oldgen.
+func RestoreJournalReplica(ctx context.Context, bs blobstore.Blobstore, dir string, offset int64) error {
+	segs, err := readJournalSegments(ctx, bs)
+	if err != nil {
+		return err
+	}
+
+	var point *replicaRoot
+	var last int
+	for i := range segs {
+		for j := range segs[i].Roots {
+			if segs[i].Roots[j].Offset == offset {
+				point, last = &segs[i].Roots[j], i
+			}
+		}
+	}
+	if point == nil {
+		return ErrNoJournalRestorePoint
+	}
+	root, ok := hash.MaybeParse(point.Root)
+	if !ok {
+		return fmt.Errorf("invalid root hash in journal replica: %s", point.Root)
+	}
+
+	specs, err := fromReplicaSpecs(point.Specs)
+	if err != nil {
+		return err
+	}
+	if err = restoreReplicaTables(ctx, bs, dir, specs); err != nil {
+		return err
+	}
+	if err = restoreReplicaJournal(ctx, bs, filepath.Join(dir, chunkJournalName), segs[:last+1], offset); err != nil {
+		return err
+	}
+	if err = writeReplicaManifest(ctx, dir, segs[last].NbfVersion, root, specs); err != nil {
+		return err
+	}
+
+	oldgenDir := filepath.Join(dir, "oldgen")
+	if err = os.MkdirAll(oldgenDir, os.ModePerm); err != nil {
+		return err
+	}
+	if og := segs[last].OldGen; og != nil && len(og.Specs) > 0 {
+		ogRoot, ok := hash.MaybeParse(og.Root)
+		if !ok {
+			return fmt.Errorf("invalid root hash in journal replica: %s", og.Root)
+		}
+		ogSpecs, err := fromReplicaSpecs(og.Specs)
+		if err != nil {
+			return err
+		}
+		if err = restoreReplicaTables(ctx, bs, oldgenDir, ogSpecs); err != nil {
+			return err
+		}
+		if err = writeReplicaManifest(ctx, oldgenDir, segs[last].NbfVersion, ogRoot, ogSpecs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func restoreReplicaTables(ctx context.Context, bs blobstore.Blobstore, dir string, specs []tableSpec) error {
+	for _, s := range specs {
+		if s.name == journalAddr {
+			continue
+		}
+		if err := copyBlobToFile(ctx, bs, replicaTableKey(s.name.String()), blobstore.AllRange, filepath.Join(dir, s.name.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreReplicaJournal writes the journal held by |segs| to |path|, up to |offset|.
+func restoreReplicaJournal(ctx context.Context, bs blobstore.Blobstore, path string, segs []journalSegment, offset int64) (err error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	for _, seg := range segs {
+		length := seg.End - seg.Start
+		if seg.End > offset {
+			length = offset - seg.Start
+		}
+		rc, _, err := bs.Get(ctx, journalSegmentKey(seg.Start), blobstore.NewBlobRange(0, length))
+		if err != nil {
+			return err
+		}
+		n, err := io.Copy(f, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		} else if n != length {
+			return fmt.Errorf("journal replica segment %s is truncated", journalSegmentKey(seg.Start))
+		}
+	}
+	return f.Sync()
+}
+
+func copyBlobToFile(ctx context.Context, bs blobstore.Blobstore, key string, br blobstore.BlobRange, path string) (err error) {
+	rc, _, err := bs.Get(ctx, key, br)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	if _, err = io.Copy(f, rc); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+func writeReplicaManifest(ctx context.Context, dir, nbfVers string, root hash.Hash, specs []tableSpec) error {
+	m, err := getFileManifest(ctx, dir, syncFlush)
+	if err != nil {
+		return err
+	}
+	contents := manifestContents{
+		nbfVers: nbfVers,
+		root:    root,
+		specs:   specs,
+		lock:    generateLockHash(root, specs, nil),
+	}
+	_, err = m.Update(ctx, addr{}, contents, &Stats{}, nil)
+	return err
+}
+
+// JournalReplicator copies the records of a chunk journal to a journal replica in a blobstore as root hashes are
+// committed to it. Records are copied in the background every interval, and once more when the replicator is closed.
+// Replication resumes from the end of an existing replica, which must have been made from the same journal.
+// Replication stops if the journal is rewritten by garbage collection.
+type JournalReplicator struct {
+	journal  *chunkJournal
+	bs       blobstore.Blobstore
+	path     string
+	dir      string
+	oldgen   *replicaOldGen
+	oldDir   string
+	nbfVers  string
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending []replicaRoot
+	end     int64
+	tables  map[string]struct{}
+	// err is a failure replication can't recover from
+	err error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// ReplicateJournal starts copying the chunk journal of |nbs| to a journal replica in |bs| every |interval|. The
+// replicator must be closed to stop it.
+func (nbs *NomsBlockStore) ReplicateJournal(ctx context.Context, bs blobstore.Blobstore, interval time.Duration) (*JournalReplicator, error) {
+	return nbs.replicateJournal(ctx, bs, interval, nil, "")
+}
+
+// ReplicateJournal starts copying the chunk journal of the new generation of |gcs|, and the table files of its old
+// generation, to a journal replica in |bs| every |interval|. The replicator must be closed to stop it.
+func (gcs *GenerationalNBS) ReplicateJournal(ctx context.Context, bs blobstore.Blobstore, interval time.Duration) (*JournalReplicator, error) {
+	oldDir, ok := gcs.oldGen.Path()
+	if !ok {
+		return nil, ErrJournalReplicationUnsupported
+	}
+	gcs.oldGen.mu.RLock()
+	oldgen := &replicaOldGen{Root: gcs.oldGen.upstream.root.String(), Specs: toReplicaSpecs(gcs.oldGen.upstream.specs)}
+	gcs.oldGen.mu.RUnlock()
+	return gcs.newGen.replicateJournal(ctx, bs, interval, oldgen, oldDir)
+}
+
+func (nbs *NomsBlockStore) replicateJournal(ctx context.Context, bs blobstore.Blobstore, interval time.Duration, oldgen *replicaOldGen, oldDir string) (*JournalReplicator, error) {
+	j, ok := nbs.p.(*chunkJournal)
+	if !ok {
+		return nil, ErrJournalReplicationUnsupported
+	}
+	if interval <= 0 {
+		interval = DefaultJournalReplicationInterval
+	}
+
+	segs, err := readJournalSegments(ctx, bs)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &JournalReplicator{
+		journal:  j,
+		bs:       bs,
+		path:     j.path,
+		dir:      j.Path(),
+		oldgen:   oldgen,
+		oldDir:   oldDir,
+		nbfVers:  nbs.Version(),
+		interval: interval,
+		tables:   make(map[string]struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, seg := range segs {
+		r.end = seg.End
+		for _, root := range seg.Roots {
+			for _, s := range root.Specs {
+				r.tables[s.Name] = struct{}{}
+			}
+		}
+		if seg.OldGen != nil {
+			for _, s := range seg.OldGen.Specs {
+				r.tables[s.Name] = struct{}{}
+			}
+		}
+	}
+
+	// root hash updates are made under |nbs.mu|
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if err = j.maybeInit(ctx); err != nil {
+		return nil, err
+	}
+	end := j.wr.currentSize()
+	if end < r.end {
+		return nil, fmt.Errorf("journal replica %s extends past the end of the chunk journal; it was made from another journal", bs.Path())
+	} else if end > r.end {
+		r.pending = append(r.pending, replicaRoot{
+			Offset: end,
+			Root:   j.contents.root.String(),
+			Time:   time.Now(),
+			Specs:  toReplicaSpecs(j.contents.specs),
+		})
+	}
+	hook := rootCommitHook(r.rootCommitted)
+	j.onRootCommit.Store(&hook)
+
+	go r.run()
+	return r, nil
+}
+
+// rootCommitted is called by the chunk journal after each root hash update.
+func (r *JournalReplicator) rootCommitted(contents manifestContents, end int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := r.end
+	if len(r.pending) > 0 {
+		last = r.pending[len(r.pending)-1].Offset
+	}
+	if end < last {
+		r.err = ErrJournalRewritten
+		return
+	}
+	r.pending = append(r.pending, replicaRoot{
+		Offset: end,
+		Root:   contents.root.String(),
+		Time:   time.Now(),
+		Specs:  toReplicaSpecs(contents.specs),
+	})
+}
+
+func (r *JournalReplicator) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			// failed copies are retried on the next tick
+			_ = r.replicate(context.Background())
+		}
+	}
+}
+
+// Close stops the replicator after copying the journal records committed so far.
+func (r *JournalReplicator) Close() error {
+	r.journal.onRootCommit.Store(nil)
+	close(r.stop)
+	<-r.done
+	return r.replicate(context.Background())
+}
+
+// ReplicatedOffset returns the offset of the chunk journal up to which it has been copied to the replica.
+func (r *JournalReplicator) ReplicatedOffset() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.end
+}
+
+// replicate copies a segment holding the root hashes committed since the last segment, and the table files they need.
+func (r *JournalReplicator) replicate(ctx context.Context) error {
+	r.mu.Lock()
+	roots, start, err := r.pending, r.end, r.err
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	} else if len(roots) == 0 {
+		return nil
+	}
+	end := roots[len(roots)-1].Offset
+
+	for _, root := range roots {
+		for _, s := range root.Specs {
+			if s.Name == journalAddr.String() {
+				continue
+			}
+			if err = r.copyTable(ctx, r.dir, s.Name); err != nil {
+				return err
+			}
+		}
+	}
+	if r.oldgen != nil {
+		for _, s := range r.oldgen.Specs {
+			if err = r.copyTable(ctx, r.oldDir, s.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	f, err := os.Open(r.path)
+	if err != nil {
+		return err
+	}
+	data := make([]byte, end-start)
+	_, err = f.ReadAt(data, start)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if _, err = blobstore.PutBytes(ctx, r.bs, journalSegmentKey(start), data); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(journalSegment{
+		Start:      start,
+		End:        end,
+		NbfVersion: r.nbfVers,
+		OldGen:     r.oldgen,
+		Roots:      roots,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = blobstore.PutBytes(ctx, r.bs, journalSegmentKey(start)+journalSegmentMeta, meta); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = r.pending[len(roots):]
+	r.end = end
+	return nil
+}
+
+func (r *JournalReplicator) copyTable(ctx context.Context, dir, name string) error {
+	r.mu.Lock()
+	_, ok := r.tables[name]
+	r.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	_, err = r.bs.Put(ctx, replicaTableKey(name), f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tables[name] = struct{}{}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestJournalReplication(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_Default.VersionString()
+	st, err := NewLocalJournalingStore(ctx, nbf, t.TempDir(), NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, st.Close())
+	}()
+
+	bs := blobstore.NewInMemoryBlobstore("")
+	// only replicate when the replicator is closed
+	r, err := st.ReplicateJournal(ctx, bs, time.Hour)
+	require.NoError(t, err)
+
+	var committed []chunks.Chunk
+	for i := 0; i < 3; i++ {
+		c := chunks.NewChunk([]byte(fmt.Sprintf("chunk %d", i)))
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+		last, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, c.Hash(), last)
+		require.NoError(t, err)
+		require.True(t, ok)
+		committed = append(committed, c)
+	}
+	require.NoError(t, r.Close())
+
+	points, err := ReadJournalReplica(ctx, bs)
+	require.NoError(t, err)
+	require.Len(t, points, len(committed))
+	assert.Equal(t, points[len(points)-1].Offset, r.ReplicatedOffset())
+
+	for i, p := range points {
+		assert.Equal(t, committed[i].Hash(), p.Root)
+		if i > 0 {
+			assert.Less(t, points[i-1].Offset, p.Offset)
+			assert.False(t, p.Time.Before(points[i-1].Time))
+		}
+
+		dir := t.TempDir()
+		require.NoError(t, RestoreJournalReplica(ctx, bs, dir, p.Offset))
+		restored, err := NewLocalJournalingStore(ctx, nbf, dir, NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+
+		root, err := restored.Root(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, p.Root, root)
+		for j, c := range committed {
+			ok, err := restored.Has(ctx, c.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, j <= i, ok)
+		}
+		require.NoError(t, restored.Close())
+	}
+
+	err = RestoreJournalReplica(ctx, bs, t.TempDir(), points[0].Offset+1)
+	assert.ErrorIs(t, err, ErrNoJournalRestorePoint)
+}
+
+func TestJournalReplicationResumes(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_Default.VersionString()
+	dir := t.TempDir()
+	bs := blobstore.NewInMemoryBlobstore("")
+
+	for i := 0; i < 2; i++ {
+		st, err := NewLocalJournalingStore(ctx, nbf, dir, NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		r, err := st.ReplicateJournal(ctx, bs, time.Hour)
+		require.NoError(t, err)
+
+		c := chunks.NewChunk([]byte(fmt.Sprintf("chunk %d", i)))
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+		last, err := st.Root(ctx)
+		require.NoError(t, err)
+		_, err = st.Commit(ctx, c.Hash(), last)
+		require.NoError(t, err)
+
+		require.NoError(t, r.Close())
+		require.NoError(t, st.Close())
+	}
+
+	points, err := ReadJournalReplica(ctx, bs)
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Less(t, points[0].Offset, points[1].Offset)
+}
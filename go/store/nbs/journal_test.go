@@ -16,6 +16,7 @@ package nbs
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"os"
 	"testing"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/utils/file"
 	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -131,3 +133,34 @@ func randBuf(n int) (b []byte) {
 	rand.Read(b)
 	return
 }
+
+func TestReadJournalRoots(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	roots, err := ReadJournalRoots(ctx, dir)
+	require.NoError(t, err)
+	assert.Nil(t, roots)
+
+	st, err := NewLocalJournalingStore(ctx, types.Format_Default.VersionString(), dir, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, st.Close())
+	}()
+
+	var committed []hash.Hash
+	for i := 0; i < 3; i++ {
+		c := chunks.NewChunk([]byte(fmt.Sprintf("root %d", i)))
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+		last, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, c.Hash(), last)
+		require.NoError(t, err)
+		require.True(t, ok)
+		committed = append(committed, c.Hash())
+	}
+
+	roots, err = ReadJournalRoots(ctx, dir)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(roots), len(committed))
+	assert.Equal(t, committed, roots[len(roots)-len(committed):])
+}
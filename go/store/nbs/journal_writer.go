@@ -500,6 +500,20 @@ func (wr *journalWriter) recordCount() uint32 {
 	return wr.ranges.count()
 }
 
+// chunkLengths calls |cb| with the length of every chunk record in the journal.
+func (wr *journalWriter) chunkLengths(cb func(length uint32)) {
+	wr.lock.RLock()
+	defer wr.lock.RUnlock()
+	wr.ranges.novel.Iter(func(_ addr, r Range) (stop bool) {
+		cb(r.Length)
+		return
+	})
+	wr.ranges.cached.Iter(func(_ addr16, r Range) (stop bool) {
+		cb(r.Length)
+		return
+	})
+}
+
 func (wr *journalWriter) Close() (err error) {
 	wr.lock.Lock()
 	defer wr.lock.Unlock()
@@ -40,6 +40,10 @@ func NewNBSMetricWrapper(nbs *NomsBlockStore) *NBSMetricWrapper {
 
 var _ chunks.TableFileStore = &NBSMetricWrapper{}
 var _ chunks.ChunkStoreGarbageCollector = &NBSMetricWrapper{}
+var _ DeltaChunkSource = &NBSMetricWrapper{}
+var _ AttestationStore = &NBSMetricWrapper{}
+var _ EpochStore = &NBSMetricWrapper{}
+var _ AccessLogStore = &NBSMetricWrapper{}
 
 // Sources retrieves the current root hash, a list of all the table files,
 // and a list of the appendix table files.
@@ -95,3 +99,54 @@ func (nbsMW *NBSMetricWrapper) GetManyCompressed(ctx context.Context, hashes has
 	atomic.AddInt32(&nbsMW.TotalChunkGets, int32(len(hashes)))
 	return nbsMW.nbs.GetManyCompressed(ctx, hashes, found)
 }
+
+// GetManyDeltas implements DeltaChunkSource.
+func (nbsMW *NBSMetricWrapper) GetManyDeltas(ctx context.Context, targets map[hash.Hash]hash.Hash, found func(context.Context, DeltaChunk)) error {
+	atomic.AddInt32(&nbsMW.TotalChunkGets, int32(len(targets)))
+	return getManyDeltas(ctx, nbsMW.nbs, targets, found)
+}
+
+// ReadAttestation implements AttestationStore.
+func (nbsMW *NBSMetricWrapper) ReadAttestation(ctx context.Context) (ManifestAttestation, error) {
+	return nbsMW.nbs.ReadAttestation(ctx)
+}
+
+// WriteAttestation implements AttestationStore.
+func (nbsMW *NBSMetricWrapper) WriteAttestation(ctx context.Context, ma ManifestAttestation) error {
+	return nbsMW.nbs.WriteAttestation(ctx, ma)
+}
+
+// ReadEpoch implements EpochStore.
+func (nbsMW *NBSMetricWrapper) ReadEpoch(ctx context.Context) (uint64, error) {
+	return nbsMW.nbs.ReadEpoch(ctx)
+}
+
+// FenceEpoch implements EpochStore.
+func (nbsMW *NBSMetricWrapper) FenceEpoch(ctx context.Context, epoch uint64) error {
+	return nbsMW.nbs.FenceEpoch(ctx, epoch)
+}
+
+// SetWriterEpoch implements EpochStore.
+func (nbsMW *NBSMetricWrapper) SetWriterEpoch(epoch uint64) {
+	nbsMW.nbs.SetWriterEpoch(epoch)
+}
+
+// SampleAccesses implements AccessLogStore.
+func (nbsMW *NBSMetricWrapper) SampleAccesses(rate int) {
+	nbsMW.nbs.SampleAccesses(rate)
+}
+
+// SampledAccesses implements AccessLogStore.
+func (nbsMW *NBSMetricWrapper) SampledAccesses() []hash.Hash {
+	return nbsMW.nbs.SampledAccesses()
+}
+
+// ReadAccessLog implements AccessLogStore.
+func (nbsMW *NBSMetricWrapper) ReadAccessLog(ctx context.Context) ([]hash.Hash, error) {
+	return nbsMW.nbs.ReadAccessLog(ctx)
+}
+
+// WriteAccessLog implements AccessLogStore.
+func (nbsMW *NBSMetricWrapper) WriteAccessLog(ctx context.Context, hashes []hash.Hash) error {
+	return nbsMW.nbs.WriteAccessLog(ctx, hashes)
+}
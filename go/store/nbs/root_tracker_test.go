@@ -187,7 +187,7 @@ func TestChunkStoreManifestFirstWriteByOtherProcess(t *testing.T) {
 	newRoot, chunks, err := interloperWrite(fm, p, []byte("new root"), []byte("hello2"), []byte("goodbye2"), []byte("badbye2"))
 	require.NoError(t, err)
 
-	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, mm, p, q, inlineConjoiner{defaultMaxTables}, defaultMemTableSize)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, mm, p, q, DefaultConjoinPolicy.conjoiner(), defaultMemTableSize)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -233,7 +233,7 @@ func TestChunkStoreManifestPreemptiveOptimisticLockFail(t *testing.T) {
 	q := NewUnlimitedMemQuotaProvider()
 	p := newFakeTablePersister(q)
 
-	c := inlineConjoiner{defaultMaxTables}
+	c := DefaultConjoinPolicy.conjoiner()
 
 	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, mm, p, q, c, defaultMemTableSize)
 	require.NoError(t, err)
@@ -282,7 +282,7 @@ func TestChunkStoreCommitLocksOutFetch(t *testing.T) {
 	mm := manifestManager{upm, newManifestCache(defaultManifestCacheSize), newManifestLocks()}
 	q := NewUnlimitedMemQuotaProvider()
 	p := newFakeTablePersister(q)
-	c := inlineConjoiner{defaultMaxTables}
+	c := DefaultConjoinPolicy.conjoiner()
 
 	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, mm, p, q, c, defaultMemTableSize)
 	require.NoError(t, err)
@@ -328,7 +328,7 @@ func TestChunkStoreSerializeCommits(t *testing.T) {
 	q := NewUnlimitedMemQuotaProvider()
 	p := newFakeTablePersister(q)
 
-	c := inlineConjoiner{defaultMaxTables}
+	c := DefaultConjoinPolicy.conjoiner()
 
 	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, manifestManager{upm, mc, l}, p, q, c, defaultMemTableSize)
 	require.NoError(t, err)
@@ -392,7 +392,7 @@ func makeStoreWithFakes(t *testing.T) (fm *fakeManifest, p tablePersister, q Mem
 	mm := manifestManager{fm, newManifestCache(0), newManifestLocks()}
 	q = NewUnlimitedMemQuotaProvider()
 	p = newFakeTablePersister(q)
-	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, mm, p, q, inlineConjoiner{defaultMaxTables}, 0)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatLD1String, mm, p, q, DefaultConjoinPolicy.conjoiner(), 0)
 	require.NoError(t, err)
 	return
 }
@@ -101,11 +101,25 @@ type NomsBlockStore struct {
 	mtSize   uint64
 	putCount uint64
 
+	// pool is the ChunkPool the store shares table files through, if any. See SetChunkPool.
+	pool *ChunkPool
+
+	// writerEpoch is the epoch the root is updated at, if hasWriterEpoch is set. See SetWriterEpoch.
+	writerEpoch    uint64
+	hasWriterEpoch bool
+
+	// sampler samples the chunks read from the store. See SampleAccesses.
+	sampler *accessSampler
+
 	stats *Stats
 }
 
 var _ chunks.TableFileStore = &NomsBlockStore{}
 var _ chunks.ChunkStoreGarbageCollector = &NomsBlockStore{}
+var _ DeltaChunkSource = &NomsBlockStore{}
+var _ AttestationStore = &NomsBlockStore{}
+var _ EpochStore = &NomsBlockStore{}
+var _ AccessLogStore = &NomsBlockStore{}
 
 type Range struct {
 	Offset uint64
@@ -163,8 +177,12 @@ func (nbs *NomsBlockStore) GetChunkLocations(hashes hash.HashSet) (map[hash.Hash
 }
 
 func (nbs *NomsBlockStore) conjoinIfRequired(ctx context.Context) (bool, error) {
-	if nbs.c.conjoinRequired(nbs.tables) {
-		newUpstream, cleanup, err := conjoin(ctx, nbs.c, nbs.upstream, nbs.mm, nbs.p, nbs.stats)
+	return nbs.conjoinIfRequiredBy(ctx, nbs.c)
+}
+
+func (nbs *NomsBlockStore) conjoinIfRequiredBy(ctx context.Context, c conjoinStrategy) (bool, error) {
+	if c.conjoinRequired(nbs.tables) {
+		newUpstream, cleanup, err := conjoin(ctx, c, nbs.upstream, nbs.tables.upstreamSizes(), nbs.mm, nbs.p, nbs.stats)
 		if err != nil {
 			return false, err
 		}
@@ -188,6 +206,55 @@ func (nbs *NomsBlockStore) conjoinIfRequired(ctx context.Context) (bool, error)
 	}
 }
 
+// SetConjoinPolicy changes the ConjoinPolicy of this store. Stores that never conjoin their table files, like those
+// returned by WithoutConjoiner, are unaffected.
+func (nbs *NomsBlockStore) SetConjoinPolicy(p ConjoinPolicy) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	switch nbs.c.(type) {
+	case inlineConjoiner:
+		nbs.c = p.conjoiner()
+	case journalConjoiner:
+		nbs.c = journalConjoiner{child: p.conjoiner()}
+	}
+}
+
+// ConjoinTableFiles conjoins the table files of this store that are smaller than |targetSize| bytes until no more
+// than one of them is left, regardless of the store's ConjoinPolicy. Conjoined table files grow to about
+// |targetSize| bytes. It returns the number of table files in the store afterwards.
+func (nbs *NomsBlockStore) ConjoinTableFiles(ctx context.Context, targetSize uint64) (n int, err error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	err = nbs.waitForGC(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	nbs.mm.LockForUpdate()
+	defer func() {
+		unlockErr := nbs.mm.UnlockForUpdate()
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	var c conjoinStrategy = inlineConjoiner{maxTables: 1, targetSize: targetSize}
+	if _, ok := nbs.c.(journalConjoiner); ok {
+		c = journalConjoiner{child: c}
+	}
+
+	for {
+		before := nbs.tables.Size()
+		didConjoin, err := nbs.conjoinIfRequiredBy(ctx, c)
+		if err != nil {
+			return 0, err
+		} else if !didConjoin || nbs.tables.Size() >= before {
+			return nbs.tables.Size(), nil
+		}
+	}
+}
+
 func (nbs *NomsBlockStore) UpdateManifest(ctx context.Context, updates map[hash.Hash]uint32) (mi ManifestInfo, err error) {
 	nbs.mu.Lock()
 	defer nbs.mu.Unlock()
@@ -487,7 +554,7 @@ func NewAWSStoreWithMMapIndex(ctx context.Context, nbfVerStr string, table, ns,
 		q,
 	}
 	mm := makeManifestManager(newDynamoManifest(table, ns, ddb))
-	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
+	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, DefaultConjoinPolicy.conjoiner(), memTableSize)
 }
 
 func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string, s3 s3svc, ddb ddbsvc, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
@@ -503,7 +570,7 @@ func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string
 		q,
 	}
 	mm := makeManifestManager(newDynamoManifest(table, ns, ddb))
-	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
+	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, DefaultConjoinPolicy.conjoiner(), memTableSize)
 }
 
 // NewGCSStore returns an nbs implementation backed by a GCSBlobstore
@@ -521,7 +588,7 @@ func NewBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, m
 	mm := makeManifestManager(blobstoreManifest{bs})
 
 	p := &blobstorePersister{bs, s3BlockSize, q}
-	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
+	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, DefaultConjoinPolicy.conjoiner(), memTableSize)
 }
 
 func NewLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
@@ -545,7 +612,7 @@ func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 		return nil, err
 	}
 	p := newFSTablePersister(dir, q)
-	c := conjoinStrategy(inlineConjoiner{maxTables})
+	c := conjoinStrategy(inlineConjoiner{maxTables: maxTables, targetSize: DefaultConjoinPolicy.TargetFileSize})
 
 	return newNomsBlockStore(ctx, nbfVerStr, makeManifestManager(m), p, q, c, memTableSize)
 }
@@ -568,7 +635,7 @@ func NewLocalJournalingStore(ctx context.Context, nbfVers, dir string, q MemoryQ
 	}
 
 	mm := makeManifestManager(journal)
-	c := journalConjoiner{child: inlineConjoiner{defaultMaxTables}}
+	c := journalConjoiner{child: DefaultConjoinPolicy.conjoiner()}
 
 	// |journal| serves as the manifest and tablePersister
 	return newNomsBlockStore(ctx, nbfVers, mm, journal, q, c, defaultMemTableSize)
@@ -597,6 +664,7 @@ func newNomsBlockStore(ctx context.Context, nbfVerStr string, mm manifestManager
 		tables:   newTableSet(p, q),
 		upstream: manifestContents{nbfVers: nbfVerStr},
 		mtSize:   memTableSize,
+		sampler:  newAccessSampler(),
 		stats:    NewStats(),
 	}
 	nbs.cond = sync.NewCond(&nbs.mu)
@@ -644,6 +712,7 @@ func (nbs *NomsBlockStore) WithoutConjoiner() *NomsBlockStore {
 		upstream: nbs.upstream,
 		mtSize:   nbs.mtSize,
 		putCount: nbs.putCount,
+		sampler:  nbs.sampler,
 		stats:    nbs.stats,
 	}
 }
@@ -776,6 +845,7 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		nbs.stats.GetLatency.SampleTimeSince(t1)
 		nbs.stats.ChunksPerGet.Sample(1)
 	}()
+	nbs.sampler.sample(h)
 
 	a := addr(h)
 	data, tables, err := func() ([]byte, chunkReader, error) {
@@ -801,6 +871,10 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		return chunks.NewChunkWithHash(h, data), nil
 	}
 
+	if data, ok := sharedChunkCache.get(a); ok {
+		return chunks.NewChunkWithHash(h, data), nil
+	}
+
 	data, err = tables.get(ctx, a, nbs.stats)
 
 	if err != nil {
@@ -808,6 +882,7 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 	}
 
 	if data != nil {
+		sharedChunkCache.put(a, data)
 		return chunks.NewChunkWithHash(h, data), nil
 	}
 
@@ -817,6 +892,9 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 func (nbs *NomsBlockStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
 	ctx, span := tracer.Start(ctx, "nbs.GetMany", trace.WithAttributes(attribute.Int("num_hashes", len(hashes))))
 	span.End()
+	nbs.sampler.sampleMany(hashes)
+	hashes = sharedChunkCache.getMany(ctx, hashes, found)
+	found = sharedChunkCache.caching(found)
 	return nbs.getManyWithFunc(ctx, hashes, func(ctx context.Context, cr chunkReader, eg *errgroup.Group, reqs []getRecord, stats *Stats) (bool, error) {
 		return cr.getMany(ctx, eg, reqs, found, nbs.stats)
 	})
@@ -830,6 +908,11 @@ func (nbs *NomsBlockStore) GetManyCompressed(ctx context.Context, hashes hash.Ha
 	})
 }
 
+// GetManyDeltas implements DeltaChunkSource.
+func (nbs *NomsBlockStore) GetManyDeltas(ctx context.Context, targets map[hash.Hash]hash.Hash, found func(context.Context, DeltaChunk)) error {
+	return getManyDeltas(ctx, nbs, targets, found)
+}
+
 func (nbs *NomsBlockStore) getManyWithFunc(
 	ctx context.Context,
 	hashes hash.HashSet,
@@ -968,8 +1051,12 @@ func (nbs *NomsBlockStore) HasMany(ctx context.Context, hashes hash.HashSet) (ha
 	nbs.stats.AddressesPerHas.SampleLen(hashes.Size())
 
 	nbs.mu.RLock()
-	defer nbs.mu.RUnlock()
-	return nbs.hasMany(toHasRecords(hashes))
+	absent, err := nbs.hasMany(toHasRecords(hashes))
+	nbs.mu.RUnlock()
+	if err != nil || nbs.pool == nil || absent.Size() == 0 {
+		return absent, err
+	}
+	return nbs.adoptPooled(ctx, absent)
 }
 
 func (nbs *NomsBlockStore) hasMany(reqs []hasRecord) (hash.HashSet, error) {
@@ -1248,7 +1335,7 @@ func (nbs *NomsBlockStore) updateManifest(ctx context.Context, current, last has
 		appendix: appendixSpecs,
 	}
 
-	upstream, err := nbs.mm.Update(ctx, nbs.upstream.lock, newContents, nbs.stats, nil)
+	upstream, err := nbs.mm.Update(ctx, nbs.upstream.lock, newContents, nbs.stats, nbs.epochWriteHook(ctx))
 	if err != nil {
 		return err
 	}
@@ -1360,6 +1447,53 @@ func (nbs *NomsBlockStore) Sources(ctx context.Context) (hash.Hash, []chunks.Tab
 	return contents.GetRoot(), allTableFiles, appendixTableFiles, nil
 }
 
+// ReadAttestation implements AttestationStore.
+func (nbs *NomsBlockStore) ReadAttestation(ctx context.Context) (ManifestAttestation, error) {
+	return readAttestation(ctx, nbs.mm.m)
+}
+
+// WriteAttestation implements AttestationStore.
+func (nbs *NomsBlockStore) WriteAttestation(ctx context.Context, ma ManifestAttestation) error {
+	return writeAttestation(ctx, nbs.mm.m, ma)
+}
+
+// ReadEpoch implements EpochStore.
+func (nbs *NomsBlockStore) ReadEpoch(ctx context.Context) (uint64, error) {
+	return readEpoch(ctx, nbs.mm.m)
+}
+
+// FenceEpoch implements EpochStore.
+func (nbs *NomsBlockStore) FenceEpoch(ctx context.Context, epoch uint64) error {
+	return fenceEpoch(ctx, nbs.mm.m, epoch)
+}
+
+// SetWriterEpoch implements EpochStore.
+func (nbs *NomsBlockStore) SetWriterEpoch(epoch uint64) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	nbs.writerEpoch, nbs.hasWriterEpoch = epoch, true
+}
+
+// SampleAccesses implements AccessLogStore.
+func (nbs *NomsBlockStore) SampleAccesses(rate int) {
+	nbs.sampler.setRate(rate)
+}
+
+// SampledAccesses implements AccessLogStore.
+func (nbs *NomsBlockStore) SampledAccesses() []hash.Hash {
+	return nbs.sampler.sampled()
+}
+
+// ReadAccessLog implements AccessLogStore.
+func (nbs *NomsBlockStore) ReadAccessLog(ctx context.Context) ([]hash.Hash, error) {
+	return readAccessLog(ctx, nbs.mm.m)
+}
+
+// WriteAccessLog implements AccessLogStore.
+func (nbs *NomsBlockStore) WriteAccessLog(ctx context.Context, hashes []hash.Hash) error {
+	return writeAccessLog(ctx, nbs.mm.m, hashes)
+}
+
 func getTableFiles(css map[addr]chunkSource, contents manifestContents, numSpecs int, specFunc func(mc manifestContents, idx int) tableSpec) ([]chunks.TableFile, error) {
 	tableFiles := make([]chunks.TableFile, 0)
 	if numSpecs == 0 {
@@ -1474,7 +1608,16 @@ func (nbs *NomsBlockStore) AddTableFilesToManifest(ctx context.Context, fileIdTo
 	}
 
 	_, err := nbs.UpdateManifest(ctx, fileIdHashToNumChunks)
-	return err
+	if err != nil || nbs.pool == nil {
+		return err
+	}
+
+	// pooling is best effort, the table files are already in the store
+	dir, _ := nbs.Path()
+	for h, numChunks := range fileIdHashToNumChunks {
+		_ = nbs.pool.add(ctx, dir, addr(h), numChunks)
+	}
+	return nil
 }
 
 // PruneTableFiles deletes old table files that are no longer referenced in the manifest.
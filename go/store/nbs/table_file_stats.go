@@ -0,0 +1,156 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrInspectUnsupported = errors.New("chunk store does not support inspecting its table files")
+
+// TableFileStats are the statistics of a table file, or of the chunk journal, of a chunk store.
+type TableFileStats struct {
+	// Name is the name of the table file, which is also the hash GetChunkLocations uses for it.
+	Name string
+	// Generation is "oldgen" or "newgen" for the table files of a GenerationalNBS, and empty otherwise.
+	Generation string
+	// Journal is true for the chunk journal.
+	Journal bool
+	// Chunks is the number of chunks in the table file.
+	Chunks uint32
+	// Size is the size of the table file, in bytes.
+	Size uint64
+	// UncompressedSize is the total size of the chunks of the table file when they're uncompressed.
+	UncompressedSize uint64
+	// ChunkSizes is a histogram of the compressed sizes of the chunks. ChunkSizes[i] is the number of chunks of at least
+	// 2^i and less than 2^(i+1) bytes.
+	ChunkSizes []uint32
+	// ModTime is when the table file was last written, or the zero time if it isn't stored in a local directory.
+	ModTime time.Time
+}
+
+func (s *TableFileStats) addChunk(length uint32) {
+	i := bits.Len32(length) - 1
+	if i < 0 {
+		i = 0
+	}
+	for len(s.ChunkSizes) <= i {
+		s.ChunkSizes = append(s.ChunkSizes, 0)
+	}
+	s.ChunkSizes[i]++
+}
+
+// TableFileInspector is implemented by chunk stores which can report the statistics of their table files.
+type TableFileInspector interface {
+	// InspectTableFiles returns the statistics of every table file of the chunk store, ordered by name.
+	InspectTableFiles(ctx context.Context) ([]TableFileStats, error)
+	// GetChunkLocations returns the ranges of |hashes| in the table files that store them, keyed by table file.
+	GetChunkLocations(hashes hash.HashSet) (map[hash.Hash]map[hash.Hash]Range, error)
+}
+
+var _ TableFileInspector = (*NomsBlockStore)(nil)
+var _ TableFileInspector = (*GenerationalNBS)(nil)
+
+// InspectTableFiles implements TableFileInspector.
+func (nbs *NomsBlockStore) InspectTableFiles(ctx context.Context) ([]TableFileStats, error) {
+	nbs.mu.RLock()
+	defer nbs.mu.RUnlock()
+
+	dir, local := nbs.Path()
+	var stats []TableFileStats
+	for _, css := range []chunkSourceSet{nbs.tables.upstream, nbs.tables.novel} {
+		for _, cs := range css {
+			s, err := inspectChunkSource(cs)
+			if err != nil {
+				return nil, err
+			}
+			if local {
+				if fi, err := os.Stat(filepath.Join(dir, s.Name)); err == nil {
+					s.ModTime = fi.ModTime()
+				}
+			}
+			stats = append(stats, s)
+		}
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+	return stats, nil
+}
+
+func inspectChunkSource(cs chunkSource) (TableFileStats, error) {
+	s := TableFileStats{Name: cs.hash().String(), Size: cs.currentSize()}
+
+	var err error
+	s.Chunks, err = cs.count()
+	if err != nil {
+		return TableFileStats{}, err
+	}
+	s.UncompressedSize, err = cs.uncompressedLen()
+	if err != nil {
+		return TableFileStats{}, err
+	}
+
+	if jcs, ok := cs.(journalChunkSource); ok {
+		s.Journal = true
+		jcs.journal.chunkLengths(s.addChunk)
+		return s, nil
+	}
+
+	idx, err := cs.index()
+	if err != nil {
+		return TableFileStats{}, err
+	}
+	for i := uint32(0); i < idx.chunkCount(); i++ {
+		e, err := idx.indexEntry(i, nil)
+		if err != nil {
+			return TableFileStats{}, err
+		}
+		s.addChunk(e.Length())
+	}
+	return s, nil
+}
+
+// InspectTableFiles implements TableFileInspector.
+func (gcs *GenerationalNBS) InspectTableFiles(ctx context.Context) ([]TableFileStats, error) {
+	oldStats, err := gcs.oldGen.InspectTableFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newStats, err := gcs.newGen.InspectTableFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]TableFileStats, 0, len(oldStats)+len(newStats))
+	for _, s := range oldStats {
+		s.Generation = "oldgen"
+		stats = append(stats, s)
+	}
+	for _, s := range newStats {
+		s.Generation = "newgen"
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableFileStatsChunkSizes(t *testing.T) {
+	var s TableFileStats
+	for _, l := range []uint32{0, 1, 2, 3, 4, 1023, 1024} {
+		s.addChunk(l)
+	}
+	assert.Equal(t, []uint32{2, 2, 1, 0, 0, 0, 0, 0, 0, 1, 1}, s.ChunkSizes)
+}
+
+func TestInspectTableFiles(t *testing.T) {
+	ctx := context.Background()
+	st, _, _ := makeTestLocalStore(t, defaultMaxTables)
+	defer func() {
+		require.NoError(t, st.Close())
+	}()
+
+	fileToData := populateLocalStore(t, st, 3)
+
+	stats, err := st.InspectTableFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+
+	chunks := make(map[string]uint32)
+	for i, s := range stats {
+		if i > 0 {
+			assert.Less(t, stats[i-1].Name, s.Name)
+		}
+		require.Contains(t, fileToData, s.Name)
+		assert.Equal(t, uint64(len(fileToData[s.Name])), s.Size)
+		assert.False(t, s.Journal)
+		assert.False(t, s.ModTime.IsZero())
+		assert.NotZero(t, s.UncompressedSize)
+
+		var histogramChunks uint32
+		for _, n := range s.ChunkSizes {
+			histogramChunks += n
+		}
+		assert.Equal(t, s.Chunks, histogramChunks)
+		chunks[s.Name] = s.Chunks
+	}
+
+	var total uint32
+	for _, n := range chunks {
+		total += n
+	}
+	assert.Equal(t, uint32(1+2+3), total)
+}
@@ -28,7 +28,6 @@ import (
 	"io"
 	"sort"
 
-	"github.com/golang/snappy"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -38,7 +37,8 @@ import (
 // Do not read more than 128MB at a time.
 const maxReadSize = 128 * 1024 * 1024
 
-// CompressedChunk represents a chunk of data in a table file which is still compressed via snappy.
+// CompressedChunk represents a chunk of data in a table file which is still compressed, via snappy or zstd. See
+// ChunkCodec.
 type CompressedChunk struct {
 	// H is the hash of the chunk
 	H hash.Hash
@@ -46,7 +46,7 @@ type CompressedChunk struct {
 	// FullCompressedChunk is the entirety of the compressed chunk data including the crc
 	FullCompressedChunk []byte
 
-	// CompressedData is just the snappy encoded byte buffer that stores the chunk data
+	// CompressedData is just the encoded byte buffer that stores the chunk data
 	CompressedData []byte
 }
 
@@ -64,9 +64,9 @@ func NewCompressedChunk(h hash.Hash, buff []byte) (CompressedChunk, error) {
 	return CompressedChunk{H: h, FullCompressedChunk: buff, CompressedData: compressedData}, nil
 }
 
-// ToChunk snappy decodes the compressed data and returns a chunks.Chunk
+// ToChunk decodes the compressed data and returns a chunks.Chunk
 func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
-	data, err := snappy.Decode(nil, cmp.CompressedData)
+	data, err := decodeChunkData(cmp.CompressedData)
 
 	if err != nil {
 		return chunks.Chunk{}, err
@@ -75,15 +75,9 @@ func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
 	return chunks.NewChunkWithHash(cmp.H, data), nil
 }
 
+// ChunkToCompressedChunk snappy encodes |chunk|. See ChunkToCompressedChunkWithCodec.
 func ChunkToCompressedChunk(chunk chunks.Chunk) CompressedChunk {
-	compressed := snappy.Encode(nil, chunk.Data())
-	length := len(compressed)
-	// todo: this append allocates a new buffer and copies |compressed|.
-	//  This is costly, but maybe better, as it allows us to reclaim the
-	//  extra space allocated in snappy.Encode (see snappy.MaxEncodedLen).
-	compressed = append(compressed, []byte{0, 0, 0, 0}...)
-	binary.BigEndian.PutUint32(compressed[length:], crc(compressed[:length]))
-	return CompressedChunk{H: chunk.Hash(), FullCompressedChunk: compressed, CompressedData: compressed[:length]}
+	return ChunkToCompressedChunkWithCodec(chunk, SnappyCodec)
 }
 
 // Hash returns the hash of the data
@@ -142,6 +136,7 @@ type tableReaderAt interface {
 // more chunks together into a single read request to backing storage.
 type tableReader struct {
 	prefixes  []uint64
+	filter    *prefixBloomFilter
 	idx       tableIndex
 	r         tableReaderAt
 	blockSize uint64
@@ -157,6 +152,7 @@ func newTableReader(index tableIndex, r tableReaderAt, blockSize uint64) (tableR
 	}
 	return tableReader{
 		prefixes:  p,
+		filter:    newPrefixBloomFilter(p),
 		idx:       index,
 		r:         r,
 		blockSize: blockSize,
@@ -171,11 +167,26 @@ func (tr tableReader) hasMany(addrs []hasRecord) (bool, error) {
 	filterLen := uint32(tr.idx.chunkCount())
 
 	var remaining bool
+	var probes, rejects, falsePositives uint64
+	defer func() {
+		bloomProbes.Add(probes)
+		bloomRejects.Add(rejects)
+		bloomFalsePositives.Add(falsePositives)
+	}()
+
 	for i, addr := range addrs {
 		if addr.has {
 			continue
 		}
 
+		// consult the bloom filter before scanning the prefixes
+		probes++
+		if !tr.filter.mayContain(addr.prefix) {
+			rejects++
+			remaining = true
+			continue
+		}
+
 		for filterIdx < filterLen && addr.prefix > tr.prefixes[filterIdx] {
 			filterIdx++
 		}
@@ -185,6 +196,7 @@ func (tr tableReader) hasMany(addrs []hasRecord) (bool, error) {
 		}
 
 		if addr.prefix != tr.prefixes[filterIdx] {
+			falsePositives++
 			remaining = true
 			continue
 		}
@@ -202,6 +214,7 @@ func (tr tableReader) hasMany(addrs []hasRecord) (bool, error) {
 		}
 
 		if !addrs[i].has {
+			falsePositives++
 			remaining = true
 		}
 	}
@@ -223,7 +236,15 @@ func (tr tableReader) index() (tableIndex, error) {
 
 // returns true iff |h| can be found in this table.
 func (tr tableReader) has(h addr) (bool, error) {
+	bloomProbes.Add(1)
+	if !tr.filter.mayContain(h.Prefix()) {
+		bloomRejects.Add(1)
+		return false, nil
+	}
 	_, ok, err := tr.idx.lookup(&h)
+	if err == nil && !ok {
+		bloomFalsePositives.Add(1)
+	}
 	return ok, err
 }
 
@@ -685,6 +706,7 @@ func (tr tableReader) clone() (tableReader, error) {
 	}
 	return tableReader{
 		prefixes:  tr.prefixes,
+		filter:    tr.filter,
 		idx:       idx,
 		r:         r,
 		blockSize: tr.blockSize,
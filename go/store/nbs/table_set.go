@@ -279,6 +279,15 @@ func (ts tableSet) close() error {
 	return firstErr
 }
 
+// upstreamSizes returns the physical sizes of the upstream tables in this tableSet, keyed by table name.
+func (ts tableSet) upstreamSizes() map[addr]uint64 {
+	sizes := make(map[addr]uint64, len(ts.upstream))
+	for name, cs := range ts.upstream {
+		sizes[name] = cs.currentSize()
+	}
+	return sizes
+}
+
 // Size returns the number of tables in this tableSet.
 func (ts tableSet) Size() int {
 	return len(ts.novel) + len(ts.upstream)
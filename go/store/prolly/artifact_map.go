@@ -41,6 +41,8 @@ const (
 	ArtifactTypeUniqueKeyViol
 	// ArtifactTypeChkConsViol is the type for check constraint violations.
 	ArtifactTypeChkConsViol
+	// ArtifactTypeTypeConvViol is the type for rows whose values could not be converted to a new column type.
+	ArtifactTypeTypeConvViol
 	artifactMapPendingBufferSize = 650_000
 )
 
@@ -191,7 +193,7 @@ func (m ArtifactMap) IterAll(ctx context.Context) (ArtifactIter, error) {
 }
 
 func (m ArtifactMap) IterAllCVs(ctx context.Context) (ArtifactIter, error) {
-	itr, err := m.iterAllOfTypes(ctx, ArtifactTypeForeignKeyViol, ArtifactTypeUniqueKeyViol, ArtifactTypeChkConsViol)
+	itr, err := m.iterAllOfTypes(ctx, ArtifactTypeForeignKeyViol, ArtifactTypeUniqueKeyViol, ArtifactTypeChkConsViol, ArtifactTypeTypeConvViol)
 	if err != nil {
 		return nil, err
 	}
@@ -441,6 +443,7 @@ func (itr *ConflictArtifactIter) Next(ctx context.Context) (ConflictArtifact, er
 	}
 
 	return ConflictArtifact{
+		ArtKey:       art.ArtKey,
 		Key:          art.Key,
 		TheirRootIsh: art.TheirRootIsh,
 		Metadata:     parsedMeta,
@@ -449,6 +452,8 @@ func (itr *ConflictArtifactIter) Next(ctx context.Context) (ConflictArtifact, er
 
 // ConflictArtifact is the decoded conflict from the artifacts table
 type ConflictArtifact struct {
+	// ArtKey is the key of the conflict in the artifacts table
+	ArtKey       val.Tuple
 	Key          val.Tuple
 	TheirRootIsh hash.Hash
 	Metadata     ConflictMetadata
@@ -495,7 +500,7 @@ var _ ArtifactIter = multiArtifactTypeItr{}
 
 // newMultiArtifactTypeItr creates an iter that iterates an artifact if its type exists in |types|.
 func newMultiArtifactTypeItr(itr ArtifactIter, types []ArtifactType) multiArtifactTypeItr {
-	members := make([]bool, 5)
+	members := make([]bool, ArtifactTypeTypeConvViol+1)
 	for _, t := range types {
 		members[uint8(t)] = true
 	}
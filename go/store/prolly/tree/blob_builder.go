@@ -54,6 +54,32 @@ func NewBlobBuilder(chunkSize int) (*BlobBuilder, error) {
 	}, nil
 }
 
+// NewContentDefinedBlobBuilder returns a BlobBuilder which splits blobs
+// larger than |chunkSize| at boundaries chosen from their contents, rather
+// than at fixed offsets. Leaf boundaries are found with a rolling hash of
+// the blob's bytes, and the boundaries of internal levels with the addresses
+// of their children, as for the nodes of other prolly trees. An edit to a blob
+// then only changes the chunks around it and their path to the root, so the
+// versions of a large value share their unchanged chunks, which are neither
+// stored nor pushed again. Blobs of at most |chunkSize| bytes are stored in a
+// single leaf, as by NewBlobBuilder.
+func NewContentDefinedBlobBuilder(chunkSize int) (*BlobBuilder, error) {
+	b, err := NewBlobBuilder(chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	b.contentDefined = true
+	return b, nil
+}
+
+func mustNewContentDefinedBlobBuilder(chunkSize int) *BlobBuilder {
+	b, err := NewContentDefinedBlobBuilder(chunkSize)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
 type blobNodeWriter interface {
 	Write(ctx context.Context, r io.Reader) (hash.Hash, uint64, error)
 }
@@ -71,6 +97,14 @@ type BlobBuilder struct {
 	buf      []byte
 	vals     [][]byte
 	subtrees []uint64
+
+	// contentDefined is set for builders which split large blobs at
+	// content-defined boundaries, and splitContent when the blob being
+	// built is split that way.
+	contentDefined bool
+	splitContent   bool
+	levels         []*blobLevel
+	zeroKeys       [][]byte
 }
 
 func (b *BlobBuilder) SetNodeStore(ns NodeStore) {
@@ -82,6 +116,7 @@ func (b *BlobBuilder) SetNodeStore(ns NodeStore) {
 func (b *BlobBuilder) Reset() {
 	b.wr = nil
 	b.topLevel = 0
+	b.splitContent = false
 }
 
 // Init calculates tree dimensions for a given blob.
@@ -99,6 +134,11 @@ func (b *BlobBuilder) Init(dataSize int) {
 		}
 		return
 	}
+	if b.contentDefined {
+		// the shape of the tree depends on the blob's contents
+		b.splitContent = true
+		return
+	}
 
 	b.wr = &blobLeafWriter{
 		bb:  b,
@@ -146,6 +186,9 @@ func (b *BlobBuilder) expand(numAddrs int) {
 // io.EOF, when every writer in the chain completes its chunk and we return the
 // root node.
 func (b *BlobBuilder) Chunk(ctx context.Context, r io.Reader) (Node, hash.Hash, error) {
+	if b.splitContent {
+		return b.chunkContentDefined(ctx, r)
+	}
 	if b.wr == nil {
 		return Node{}, hash.Hash{}, nil
 	}
@@ -221,7 +264,8 @@ func (lw *blobLevelWriter) Write(ctx context.Context, r io.Reader) (hash.Hash, u
 }
 
 // Write the blob node. Called by level and leaf writers. Will store lastN if
-// the level corresponds to our root level.
+// the level corresponds to our root level, or always when splitting content,
+// where the root is the last node written.
 func (b *BlobBuilder) write(ctx context.Context, keys, vals [][]byte, subtrees []uint64, level int) (hash.Hash, error) {
 	msg := b.S.Serialize(keys, vals, subtrees, level)
 	node, err := NodeFromBytes(msg)
@@ -232,12 +276,123 @@ func (b *BlobBuilder) write(ctx context.Context, keys, vals [][]byte, subtrees [
 	if err != nil {
 		return hash.Hash{}, err
 	}
-	if level == b.topLevel {
+	if level == b.topLevel || b.splitContent {
 		b.lastN = node
 	}
 	return h, nil
 }
 
+// blobLevel accumulates the children of the next node of a level of a blob
+// split at content-defined boundaries.
+type blobLevel struct {
+	addrs    []hash.Hash
+	subtrees []uint64
+	splitter nodeSplitter
+}
+
+// chunkContentDefined builds the blob tree of the contents of |r|, splitting
+// leaves with a rolling hash of their bytes, and internal nodes with the
+// addresses of their children. Unlike the fixed size writers, the height of
+// the tree isn't known until the Reader is exhausted.
+func (b *BlobBuilder) chunkContentDefined(ctx context.Context, r io.Reader) (Node, hash.Hash, error) {
+	b.levels = b.levels[:0]
+	splitter := newRollingHashSplitter(0)
+	rs := splitter.(*rollingHashSplitter)
+
+	chunk := make([]byte, 0, maxChunkSize+1)
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		for _, byt := range buf[:n] {
+			chunk = append(chunk, byt)
+			rs.hashByte(byt)
+			if !splitter.CrossedBoundary() {
+				continue
+			}
+			if werr := b.writeContentLeaf(ctx, chunk); werr != nil {
+				return Node{}, hash.Hash{}, werr
+			}
+			chunk = chunk[:0]
+			splitter.Reset()
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return Node{}, hash.Hash{}, err
+		}
+	}
+	if len(chunk) > 0 {
+		if err := b.writeContentLeaf(ctx, chunk); err != nil {
+			return Node{}, hash.Hash{}, err
+		}
+	}
+
+	// complete the partial node of every level, bottom up
+	for level := 0; level < len(b.levels); level++ {
+		lvl := b.levels[level]
+		if level == len(b.levels)-1 && len(lvl.addrs) == 1 {
+			// the only node of the top level is the root
+			return b.lastN, lvl.addrs[0], nil
+		}
+		if len(lvl.addrs) > 0 {
+			if err := b.writeContentLevel(ctx, level); err != nil {
+				return Node{}, hash.Hash{}, err
+			}
+		}
+	}
+	return Node{}, hash.Hash{}, nil
+}
+
+func (b *BlobBuilder) writeContentLeaf(ctx context.Context, chunk []byte) error {
+	h, err := b.write(ctx, zeroKeys, [][]byte{chunk}, leafSubtrees, 0)
+	if err != nil {
+		return err
+	}
+	return b.appendContentLevel(ctx, 0, h, 1)
+}
+
+// appendContentLevel adds the node |h|, with |subtree| leaves, to the
+// children of the next node of level |level|+1, writing that node if |h|
+// crosses a boundary.
+func (b *BlobBuilder) appendContentLevel(ctx context.Context, level int, h hash.Hash, subtree uint64) error {
+	if level == len(b.levels) {
+		b.levels = append(b.levels, &blobLevel{splitter: newKeySplitter(uint8(level))})
+	}
+	lvl := b.levels[level]
+	lvl.addrs = append(lvl.addrs, h)
+	lvl.subtrees = append(lvl.subtrees, subtree)
+	if err := lvl.splitter.Append(h[:], nil); err != nil {
+		return err
+	}
+	if !lvl.splitter.CrossedBoundary() {
+		return nil
+	}
+	return b.writeContentLevel(ctx, level)
+}
+
+// writeContentLevel writes the node of level |level|+1 from the children
+// accumulated for it.
+func (b *BlobBuilder) writeContentLevel(ctx context.Context, level int) error {
+	lvl := b.levels[level]
+	for len(b.zeroKeys) < len(lvl.addrs) {
+		b.zeroKeys = append(b.zeroKeys, zeroKey)
+	}
+	vals := make([][]byte, len(lvl.addrs))
+	total := uint64(0)
+	for i := range lvl.addrs {
+		vals[i] = lvl.addrs[i][:]
+		total += lvl.subtrees[i]
+	}
+	h, err := b.write(ctx, b.zeroKeys[:len(vals)], vals, lvl.subtrees, level+1)
+	if err != nil {
+		return err
+	}
+	lvl.addrs = lvl.addrs[:0]
+	lvl.subtrees = lvl.subtrees[:0]
+	lvl.splitter.Reset()
+	return b.appendContentLevel(ctx, level+1, h, total)
+}
+
 const bytePeekLength = 128
 
 type ByteArray struct {
@@ -200,6 +200,79 @@ func TestWriteImmutableTree(t *testing.T) {
 	}
 }
 
+func TestContentDefinedBlobBuilder(t *testing.T) {
+	ctx := context.Background()
+	ns := NewTestNodeStore()
+
+	base := make([]byte, 2_000_000)
+	testRand.Read(base)
+	tail := make([]byte, 100_000)
+	testRand.Read(tail)
+	appended := append(append([]byte{}, base...), tail...)
+	inserted := append(append(append([]byte{}, base[:1_000_000]...), []byte("inserted bytes")...), base[1_000_000:]...)
+
+	build := func(t *testing.T, buf []byte) (hash.Hash, map[hash.Hash]int) {
+		b, err := NewContentDefinedBlobBuilder(DefaultFixedChunkLength)
+		require.NoError(t, err)
+		b.SetNodeStore(ns)
+		b.Init(len(buf))
+		root, addr, err := b.Chunk(ctx, bytes.NewReader(buf))
+		require.NoError(t, err)
+		require.Equal(t, addr, root.HashOf())
+
+		leaves := make(map[hash.Hash]int)
+		var read []byte
+		err = WalkNodes(ctx, root, ns, func(ctx context.Context, n Node) error {
+			if n.IsLeaf() {
+				leaves[n.HashOf()] = n.Size()
+				read = append(read, n.GetValue(0)...)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, buf, read)
+
+		cnt, err := root.TreeCount()
+		require.NoError(t, err)
+		assert.Equal(t, len(leaves), cnt)
+		return addr, leaves
+	}
+
+	baseAddr, baseLeaves := build(t, base)
+	assert.True(t, len(baseLeaves) > 100)
+	againAddr, _ := build(t, base)
+	assert.Equal(t, baseAddr, againAddr)
+
+	newLeaves := func(leaves map[hash.Hash]int) (cnt int) {
+		for h := range leaves {
+			if _, ok := baseLeaves[h]; !ok {
+				cnt++
+			}
+		}
+		return
+	}
+	t.Run("append", func(t *testing.T) {
+		_, leaves := build(t, appended)
+		// the last leaf of |base| ends at its end, rather than at a boundary
+		assert.True(t, newLeaves(leaves) <= len(leaves)-len(baseLeaves)+1)
+	})
+	t.Run("insert", func(t *testing.T) {
+		_, leaves := build(t, inserted)
+		assert.True(t, newLeaves(leaves) <= 2)
+	})
+	t.Run("small blobs are a single leaf", func(t *testing.T) {
+		addr, leaves := build(t, base[:DefaultFixedChunkLength])
+		assert.Equal(t, 1, len(leaves))
+		fixed, err := NewBlobBuilder(DefaultFixedChunkLength)
+		require.NoError(t, err)
+		fixed.SetNodeStore(ns)
+		fixed.Init(DefaultFixedChunkLength)
+		_, fixedAddr, err := fixed.Chunk(ctx, bytes.NewReader(base[:DefaultFixedChunkLength]))
+		require.NoError(t, err)
+		assert.Equal(t, fixedAddr, addr)
+	})
+}
+
 func expectedLevel(size, chunk int) int {
 	if size <= chunk {
 		return 0
@@ -308,7 +308,7 @@ func (t StaticMap[K, V, O]) IterAll(ctx context.Context) (*OrderedTreeIter[K, V]
 		return &OrderedTreeIter[K, V]{curr: nil}, nil
 	}
 
-	return &OrderedTreeIter[K, V]{curr: c, stop: stop, step: c.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: c, stop: stop, step: forwardStep(ctx, c)}, nil
 }
 
 func (t StaticMap[K, V, O]) IterAllReverse(ctx context.Context) (*OrderedTreeIter[K, V], error) {
@@ -368,7 +368,7 @@ func (t StaticMap[K, V, O]) IterOrdinalRange(ctx context.Context, start, stop ui
 		return curr.compare(hi) >= 0
 	}
 
-	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: lo.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: forwardStep(ctx, lo)}, nil
 }
 
 func (t StaticMap[K, V, O]) FetchOrdinalRange(ctx context.Context, start, stop uint64) (*orderedLeafSpanIter[K, V], error) {
@@ -420,7 +420,7 @@ func (t StaticMap[K, V, O]) IterKeyRange(ctx context.Context, start, stop K) (*O
 		return &OrderedTreeIter[K, V]{curr: nil}, nil
 	}
 
-	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: lo.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: forwardStep(ctx, lo)}, nil
 }
 
 func (t StaticMap[K, V, O]) GetKeyRangeCardinality(ctx context.Context, start, stop K) (uint64, error) {
@@ -513,7 +513,7 @@ func OrderedTreeIterFromCursors[K, V ~[]byte](
 		start = nil // empty range
 	}
 
-	return &OrderedTreeIter[K, V]{curr: start, stop: stopFn, step: start.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: start, stop: stopFn, step: forwardStep(ctx, start)}, nil
 }
 
 func (it *OrderedTreeIter[K, V]) Next(ctx context.Context) (key K, value V, err error) {
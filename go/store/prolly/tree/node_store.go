@@ -16,6 +16,7 @@ package tree
 
 import (
 	"context"
+	"os"
 	"sync"
 
 	"github.com/dolthub/dolt/go/store/prolly/message"
@@ -63,8 +64,23 @@ var sharedCache = newChunkCache(cacheSize)
 
 var sharedPool = pool.NewBuffPool()
 
+func init() {
+	if v := os.Getenv("DOLT_CONTENT_DEFINED_BLOBS"); v != "" {
+		contentDefinedBlobs = true
+	}
+}
+
+// contentDefinedBlobs makes the NodeStores split large blobs at content-defined
+// boundaries, see |NewContentDefinedBlobBuilder|. It's opt-in, because it changes
+// the addresses of the blobs written, so that the same value written by different
+// versions of Dolt would have different hashes.
+var contentDefinedBlobs = false
+
 var blobBuilderPool = sync.Pool{
 	New: func() any {
+		if contentDefinedBlobs {
+			return mustNewContentDefinedBlobBuilder(DefaultFixedChunkLength)
+		}
 		return mustNewBlobBuilder(DefaultFixedChunkLength)
 	},
 }
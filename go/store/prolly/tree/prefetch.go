@@ -0,0 +1,127 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// maxPrefetchesInFlight is the most batches of leaf reads an iterator has in flight at once.
+const maxPrefetchesInFlight = 2
+
+type scanPrefetchKeyT struct{}
+
+// scanPrefetchKey is the context key used to request leaf prefetching from iterators.
+var scanPrefetchKey = scanPrefetchKeyT{}
+
+// WithScanPrefetch returns a context that instructs forward iterators created with it to read up to |depth| leaf
+// nodes ahead of the one being iterated in the background, so that sequential scans over data that isn't cached
+// don't stall on every leaf. A |depth| of zero disables prefetching.
+func WithScanPrefetch(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, scanPrefetchKey, depth)
+}
+
+// ScanPrefetchDepth returns the leaf prefetch depth requested on |ctx|, or zero if none was.
+func ScanPrefetchDepth(ctx context.Context) int {
+	depth, ok := ctx.Value(scanPrefetchKey).(int)
+	if !ok || depth < 0 {
+		return 0
+	}
+	return depth
+}
+
+// forwardStep returns the function which moves the leaf Cursor |cur| forward. If leaf prefetching was requested on
+// |ctx|, it also reads the leaves ahead of |cur| in the background.
+func forwardStep(ctx context.Context, cur *Cursor) func(context.Context) error {
+	depth := ScanPrefetchDepth(ctx)
+	if depth == 0 || cur == nil || cur.parent == nil {
+		return cur.advance
+	}
+
+	p := &leafPrefetcher{
+		ctx:      ctx,
+		cur:      cur,
+		depth:    depth,
+		inflight: make(chan struct{}, maxPrefetchesInFlight),
+	}
+	p.prefetch()
+
+	return func(ctx context.Context) error {
+		if err := cur.advance(ctx); err != nil {
+			return err
+		}
+		if cur.atNodeStart() {
+			// |cur| moved to a new leaf
+			p.prefetch()
+		}
+		return nil
+	}
+}
+
+// leafPrefetcher reads the siblings following the leaf of a Cursor, using the addresses of its parent node. Nodes
+// read are added to the NodeStore's cache, where the Cursor finds them once it gets to them. Leaves under the next
+// parent node are prefetched once the Cursor gets to it.
+type leafPrefetcher struct {
+	ctx   context.Context
+	cur   *Cursor
+	depth int
+	// next is the index in the current parent node of the next leaf to read
+	next     int
+	inflight chan struct{}
+}
+
+func (p *leafPrefetcher) prefetch() {
+	parent := p.cur.parent
+	if parent.outOfBounds() {
+		return
+	}
+	if parent.atNodeStart() {
+		// first leaf of a new parent node
+		p.next = 0
+	}
+
+	start, stop := p.next, parent.idx+1+p.depth
+	if start <= parent.idx {
+		start = parent.idx + 1
+	}
+	if stop > parent.nd.Count() {
+		stop = parent.nd.Count()
+	}
+	if start >= stop {
+		return
+	}
+
+	select {
+	case p.inflight <- struct{}{}:
+	default:
+		// earlier reads are still in flight, try again at the next leaf
+		return
+	}
+
+	addrs := make(hash.HashSlice, 0, stop-start)
+	for i := start; i < stop; i++ {
+		addrs = append(addrs, parent.nd.getAddress(i))
+	}
+	p.next = stop
+
+	ns := p.cur.nrw
+	go func() {
+		defer func() { <-p.inflight }()
+		// prefetching is best effort, the Cursor reads the leaf itself if this fails
+		_, _ = ns.ReadMany(p.ctx, addrs)
+	}()
+}
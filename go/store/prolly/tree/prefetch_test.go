@@ -0,0 +1,100 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+type prefetchRecorder struct {
+	NodeStore
+	mu      sync.Mutex
+	fetched []Node
+}
+
+func (r *prefetchRecorder) ReadMany(ctx context.Context, addrs hash.HashSlice) ([]Node, error) {
+	nodes, err := r.NodeStore.ReadMany(ctx, addrs)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fetched = append(r.fetched, nodes...)
+	return nodes, nil
+}
+
+func (r *prefetchRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.fetched)
+}
+
+func TestScanPrefetch(t *testing.T) {
+	root, items, ns := randomTree(t, 10_000)
+	require.True(t, root.Level() > 0)
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := context.Background()
+		rec := &prefetchRecorder{NodeStore: ns}
+		cur, err := newCursorAtStart(ctx, rec, root)
+		require.NoError(t, err)
+
+		step := forwardStep(ctx, cur)
+		for cur.Valid() {
+			require.NoError(t, step(ctx))
+		}
+		assert.Equal(t, 0, rec.count())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		ctx := WithScanPrefetch(context.Background(), 4)
+		rec := &prefetchRecorder{NodeStore: ns}
+		cur, err := newCursorAtStart(ctx, rec, root)
+		require.NoError(t, err)
+
+		step := forwardStep(ctx, cur)
+		i := 0
+		for cur.Valid() {
+			assert.Equal(t, items[i][0], cur.CurrentKey())
+			require.NoError(t, step(ctx))
+			i++
+		}
+		assert.Equal(t, len(items), i)
+
+		require.Eventually(t, func() bool {
+			return rec.count() > 0
+		}, time.Second, time.Millisecond)
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		for _, nd := range rec.fetched {
+			assert.True(t, nd.IsLeaf())
+		}
+	})
+}
+
+func TestScanPrefetchDepth(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, 0, ScanPrefetchDepth(ctx))
+	assert.Equal(t, 8, ScanPrefetchDepth(WithScanPrefetch(ctx, 8)))
+	assert.Equal(t, 0, ScanPrefetchDepth(WithScanPrefetch(ctx, -1)))
+}
@@ -549,8 +549,20 @@ func makeBatches(hss []hash.HashSet, count int) [][]hash.Hash {
 	return res
 }
 
+// GCSafepointController is notified at the points of a GC where the users of a ValueStore must make any chunks
+// they still reference reachable, or stop using the ValueStore.
+type GCSafepointController interface {
+	// BeginFinalize is called before the GC finalizes the set of chunks it keeps. Writes are not blocked yet, and
+	// every chunk written up to this point is kept along with the chunks it references, so values held in memory
+	// which are not reachable from the root can be kept by writing them.
+	BeginFinalize(ctx context.Context) error
+	// EndFinalize is called once the set of chunks to keep is final, before the collected chunks are removed.
+	// Writes are blocked while it runs.
+	EndFinalize(ctx context.Context) error
+}
+
 // GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore
-func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error {
+func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepoint GCSafepointController) error {
 	lvs.versOnce.Do(lvs.expectVersion)
 
 	lvs.transitionToOldGenGC()
@@ -594,7 +606,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 			return err
 		}
 
-		err = lvs.gc(ctx, newGenRefs, oldGen.HasMany, newGen, newGen, safepointF, lvs.transitionToFinalizingGC)
+		err = lvs.gc(ctx, newGenRefs, oldGen.HasMany, newGen, newGen, safepoint, lvs.transitionToFinalizingGC)
 		newGen.EndGC()
 		if err != nil {
 			return err
@@ -624,7 +636,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 
 		newGenRefs.Insert(root)
 
-		err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, collector, collector, safepointF, lvs.transitionToFinalizingGC)
+		err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, collector, collector, safepoint, lvs.transitionToFinalizingGC)
 		collector.EndGC()
 		if err != nil {
 			return err
@@ -644,11 +656,65 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 	return nil
 }
 
+// NewGenGC collects the garbage of the new generation of a generational
+// ChunkStore, without promoting any chunks to the old generation or
+// collecting it. Chunks in the old generation are not visited, so the work
+// done is bounded by the size of the new generation. Every chunk reachable
+// from |refs| and the root which isn't in the old generation is kept.
+func (lvs *ValueStore) NewGenGC(ctx context.Context, refs hash.HashSet, safepoint GCSafepointController) error {
+	gcs, ok := lvs.cs.(chunks.GenerationalCS)
+	if !ok {
+		return chunks.ErrUnsupportedOperation
+	}
+
+	lvs.versOnce.Do(lvs.expectVersion)
+
+	lvs.transitionToOldGenGC()
+	defer lvs.transitionToNoGC()
+
+	oldGen := gcs.OldGen()
+	newGen := gcs.NewGen()
+
+	err := newGen.BeginGC(lvs.gcAddChunk)
+	if err != nil {
+		return err
+	}
+
+	root, err := lvs.Root(ctx)
+	if err != nil {
+		newGen.EndGC()
+		return err
+	}
+
+	if root == (hash.Hash{}) {
+		// empty root
+		newGen.EndGC()
+		return nil
+	}
+
+	refs.Insert(root)
+	refs.InsertAll(lvs.transitionToNewGenGC())
+
+	err = lvs.gc(ctx, refs, oldGen.HasMany, newGen, newGen, safepoint, lvs.transitionToFinalizingGC)
+	newGen.EndGC()
+	if err != nil {
+		return err
+	}
+
+	lvs.decodedChunks.Purge()
+
+	if tfs, ok := lvs.cs.(chunks.TableFileStore); ok {
+		return tfs.PruneTableFiles(ctx)
+	}
+
+	return nil
+}
+
 func (lvs *ValueStore) gc(ctx context.Context,
 	toVisit hash.HashSet,
 	hashFilter HashFilterFunc,
 	src, dest chunks.ChunkStoreGarbageCollector,
-	safepointF func() error,
+	safepoint GCSafepointController,
 	finalize func() hash.HashSet) error {
 	keepChunks := make(chan []hash.Hash, gcBuffSize)
 
@@ -675,7 +741,7 @@ func (lvs *ValueStore) gc(ctx context.Context,
 	eg.Go(func() error {
 		defer walker.Close()
 
-		err := lvs.gcProcessRefs(ctx, toVisit, keepHashes, walker, hashFilter, safepointF, finalize)
+		err := lvs.gcProcessRefs(ctx, toVisit, keepHashes, walker, hashFilter, safepoint, finalize)
 		if err != nil {
 			return err
 		}
@@ -698,7 +764,7 @@ func (lvs *ValueStore) gc(ctx context.Context,
 func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 	initialToVisit hash.HashSet, keepHashes func(hs []hash.Hash) error,
 	walker *parallelRefWalker, hashFilter HashFilterFunc,
-	safepointF func() error,
+	safepoint GCSafepointController,
 	finalize func() hash.HashSet) error {
 	visited := make(hash.HashSet)
 
@@ -771,6 +837,13 @@ func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 		}
 	}
 
+	if safepoint != nil {
+		err = safepoint.BeginFinalize(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	final := finalize()
 	finalCopy := final.Copy()
 	for h, _ := range finalCopy {
@@ -788,8 +861,8 @@ func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 		return err
 	}
 
-	if safepointF != nil {
-		return safepointF()
+	if safepoint != nil {
+		return safepoint.EndFinalize(ctx)
 	}
 	return nil
 }
@@ -94,6 +94,8 @@ const (
 	StringAddrEnc = Encoding(serial.EncodingStringAddr)
 	JSONAddrEnc   = Encoding(serial.EncodingJSONAddr)
 	CellEnc       = Encoding(serial.EncodingCell)
+	// Decimal64Enc is a decimal whose coefficient fits in an int64, see Decimal64.
+	Decimal64Enc = Encoding(serial.EncodingDecimal64)
 
 	sentinel Encoding = 127
 )
@@ -105,6 +107,8 @@ const (
 	DecimalEnc    = Encoding(serial.EncodingDecimal)
 	JSONEnc       = Encoding(serial.EncodingJSON)
 	GeometryEnc   = Encoding(serial.EncodingGeometry)
+	// StringDictEnc stores the strings of a StringDict as their code in it, and other strings as StringEnc does.
+	StringDictEnc = Encoding(serial.EncodingStringDict)
 
 	// TODO
 	//  CharEnc
@@ -162,6 +166,8 @@ func sizeFromType(t Type) (ByteSize, bool) {
 		return stringAddrEnc, true
 	case JSONAddrEnc:
 		return jsonAddrEnc, true
+	case Decimal64Enc:
+		return decimal64Size, true
 	default:
 		return 0, false
 	}
@@ -618,3 +618,167 @@ func zero(buf []byte) {
 		buf[i] = 0
 	}
 }
+
+func TestStringDict(t *testing.T) {
+	dict, err := NewStringDict([]string{"active", "inactive"})
+	assert.NoError(t, err)
+	_, err = NewStringDict([]string{"a", "a"})
+	assert.Error(t, err)
+
+	plain := NewTupleDescriptor(Type{Enc: Int64Enc}, Type{Enc: StringEnc, Nullable: true})
+	desc := NewTupleDescriptor(Type{Enc: Int64Enc}, Type{Enc: StringDictEnc, Nullable: true}).
+		WithStringDicts([]*StringDict{nil, dict})
+
+	tb := NewTupleBuilder(desc)
+	tb.PutInt64(0, 1)
+	tb.PutString(1, "inactive")
+	coded := tb.Build(testPool)
+	assert.Equal(t, dictCodeSize, len(coded.GetField(1)))
+	s, ok, err := desc.GetDictString(1, coded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "inactive", s)
+
+	// strings not in the dictionary are stored in full
+	tb.PutInt64(0, 2)
+	tb.PutString(1, "pending")
+	inline := tb.Build(testPool)
+	s, ok, err = desc.GetDictString(1, inline)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "pending", s)
+
+	// rows written before the column was dictionary encoded stay readable
+	legacy := NewTuple(testPool, encInt(3), encStr("active"))
+	s, ok, err = desc.GetDictString(1, legacy)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "active", s)
+
+	rewritten, ok, err := RewriteEncodings(testPool, plain, desc, legacy)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, dictCodeSize, len(rewritten.GetField(1)))
+	_, ok, err = RewriteEncodings(testPool, plain, desc, inline)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	decoded, ok, err := RewriteEncodings(testPool, desc, plain, coded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	s, ok = plain.GetString(1, decoded)
+	assert.True(t, ok)
+	assert.Equal(t, "inactive", s)
+
+	nulls := NewTuple(testPool, encInt(4), nil)
+	_, ok, err = RewriteEncodings(testPool, plain, desc, nulls)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	for tup, exp := range map[*Tuple][]byte{&coded: encStr("inactive"), &inline: encStr("pending"), &nulls: nil} {
+		b, err := desc.GetPlainField(1, *tup)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, b)
+	}
+
+	// a code the dictionary doesn't have is an error
+	small, err := NewStringDict([]string{"active"})
+	assert.NoError(t, err)
+	other := NewTupleDescriptor(Type{Enc: Int64Enc}, Type{Enc: StringDictEnc, Nullable: true}).
+		WithStringDicts([]*StringDict{nil, small})
+	_, _, err = other.GetDictString(1, coded)
+	assert.ErrorIs(t, err, ErrStringDictCode)
+	_, err = other.GetPlainField(1, coded)
+	assert.ErrorIs(t, err, ErrStringDictCode)
+	_, err = ConvertField(other, 1, plain, 1, coded.GetField(1))
+	assert.ErrorIs(t, err, ErrStringDictCode)
+}
+
+func TestDecimal64(t *testing.T) {
+	enc64 := func(s string) []byte {
+		d, ok := NewDecimal64(decimalFromString(s))
+		assert.True(t, ok)
+		buf := make([]byte, decimal64Size)
+		writeDecimal64(buf, d)
+		return buf
+	}
+
+	decimals := []string{"0", "1", "-1", "-3.7e0", ".22", "-.7863294659345624", "99999.999994", "600e-2", "-999999999999999999"}
+	for _, s := range decimals {
+		dec := decimalFromString(s)
+		actual := readDecimal64(enc64(s)).Decimal()
+		assert.True(t, dec.Equal(actual), "%s != %s", dec.String(), actual.String())
+	}
+	_, ok := NewDecimal64(decimalFromString("99999999999999999999"))
+	assert.False(t, ok)
+
+	// like DecimalEnc, fields keep their exponent
+	assert.Equal(t, "1.50", readDecimal64(enc64("1.50")).Decimal().StringFixed(2))
+	assert.Equal(t, int32(-2), readDecimal64(enc64("1.50")).Decimal().Exponent())
+
+	cmp := func(l, r string) int {
+		return compare(Type{Enc: Decimal64Enc}, enc64(l), enc64(r))
+	}
+	assert.Equal(t, -1, cmp("1.25", "1.50"))
+	assert.Equal(t, 1, cmp("-1.25", "-1.50"))
+	assert.Equal(t, 0, cmp("1.5", "1.50"))
+	assert.Equal(t, 1, cmp("10", "9.99"))
+
+	cmpDec := func(l, r string) int {
+		return compare(Type{Enc: DecimalEnc}, encDecimal(decimalFromString(l)), encDecimal(decimalFromString(r)))
+	}
+	assert.Equal(t, -1, cmpDec("1.25", "1.50"))
+	assert.Equal(t, 1, cmpDec("-1.25", "-1.50"))
+	assert.Equal(t, -1, cmpDec("-1.25", "0.00"))
+	assert.Equal(t, 1, cmpDec("123456789012345678901234567890.50", "1.25"))
+	assert.Equal(t, 0, cmpDec("1.5", "1.50"))
+
+	d := func(s string) Decimal64 {
+		v, ok := NewDecimal64(decimalFromString(s))
+		assert.True(t, ok)
+		return v
+	}
+	sum, ok := d("1.25").Add(d("-3.5"))
+	assert.True(t, ok)
+	assert.Equal(t, d("-2.25"), sum)
+	sum, ok = d("1.25").Add(d("1.75"))
+	assert.True(t, ok)
+	assert.Equal(t, d("3.00"), sum)
+	diff, ok := d("10").Sub(d("0.01"))
+	assert.True(t, ok)
+	assert.Equal(t, d("9.99"), diff)
+	prod, ok := d("1.5").Mul(d("-2.2"))
+	assert.True(t, ok)
+	assert.Equal(t, d("-3.30"), prod)
+	_, ok = d("999999999999999999").Mul(d("123"))
+	assert.False(t, ok)
+	_, ok = d("9223372036854775807").Add(d("1"))
+	assert.False(t, ok)
+
+	desc := NewTupleDescriptor(Type{Enc: Int64Enc}, Type{Enc: Decimal64Enc, Nullable: true})
+	var total DecimalSum
+	tb := NewTupleBuilder(desc)
+	for i, s := range []string{"9223372036854775807", "1", "-0.5"} {
+		tb.PutInt64(0, int64(i))
+		tb.PutDecimal(1, decimalFromString(s))
+		total.AddField(desc, 1, tb.Build(testPool))
+	}
+	total.AddField(desc, 1, NewTuple(testPool, encInt(3), nil))
+	assert.True(t, decimalFromString("9223372036854775807.5").Equal(total.Sum()))
+
+	plain := NewTupleDescriptor(Type{Enc: Int64Enc}, Type{Enc: DecimalEnc, Nullable: true})
+	legacy := NewTuple(testPool, encInt(1), encDecimal(decimalFromString("12.340")))
+	rewritten, ok, err := RewriteEncodings(testPool, plain, desc, legacy)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, enc64("12.340"), rewritten.GetField(1))
+	v, ok := desc.GetDecimal(1, rewritten)
+	assert.True(t, ok)
+	assert.True(t, decimalFromString("12.34").Equal(v))
+	pf, err := desc.GetPlainField(1, rewritten)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, compare(Type{Enc: DecimalEnc}, pf, encDecimal(decimalFromString("12.34"))))
+
+	_, _, err = RewriteEncodings(testPool, plain, desc, NewTuple(testPool, encInt(2), encDecimal(decimalFromString("99999999999999999999"))))
+	assert.Error(t, err)
+}
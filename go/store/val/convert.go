@@ -0,0 +1,159 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package val
+
+import (
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/pool"
+)
+
+// GetPlainField returns the ith field of |tup| in the encoding its type is stored with when the column doesn't ask for
+// another one: StringEnc in place of StringDictEnc, and DecimalEnc in place of Decimal64Enc. Index keys only hold
+// plain fields. All other fields are returned as they are.
+func (td TupleDesc) GetPlainField(i int, tup Tuple) ([]byte, error) {
+	b := td.GetField(i, tup)
+	if b == nil {
+		return b, nil
+	}
+	switch td.Types[i].Enc {
+	case StringDictEnc:
+		if len(b) != dictCodeSize || b[0] != dictCodeMarker {
+			return b, nil
+		}
+		v, err := readDictString(b, td.StringDict(i))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, len(v)+1)
+		writeString(buf, v)
+		return buf, nil
+	case Decimal64Enc:
+		v := readDecimal64(b).Decimal()
+		buf := make([]byte, sizeOfDecimal(v))
+		writeDecimal(buf, v)
+		return buf, nil
+	default:
+		return b, nil
+	}
+}
+
+// ConvertField re-encodes |b|, the ith field of a tuple described by |from|, for the jth field of |to|. It converts
+// between StringEnc and StringDictEnc, between string dictionaries, and between DecimalEnc and Decimal64Enc. Fields
+// whose encoding doesn't change are returned as they are.
+func ConvertField(from TupleDesc, i int, to TupleDesc, j int, b []byte) ([]byte, error) {
+	fe, te := from.Types[i].Enc, to.Types[j].Enc
+	if b == nil || (fe == te && (fe != StringDictEnc || from.StringDict(i).equals(to.StringDict(j)))) {
+		return b, nil
+	}
+
+	switch {
+	case isStringEnc(fe) && isStringEnc(te):
+		v, err := readDictString(b, from.StringDict(i))
+		if err != nil {
+			return nil, err
+		}
+		var nb []byte
+		if te == StringDictEnc {
+			nb = make([]byte, sizeOfDictString(to.StringDict(j), v))
+			writeDictString(nb, to.StringDict(j), v)
+		} else {
+			nb = make([]byte, len(v)+1)
+			writeString(nb, v)
+		}
+		return nb, nil
+	case isDecimalEnc(fe) && isDecimalEnc(te):
+		return convertDecimalField(fe, te, b)
+	default:
+		return nil, fmt.Errorf("cannot convert a field of encoding %d to encoding %d", fe, te)
+	}
+}
+
+// RewriteEncodings re-encodes the fields of |tup|, written with |from|, for |to|, where the two descriptors store a
+// field with different encodings. See ConvertField. It returns whether any field was rewritten.
+func RewriteEncodings(p pool.BuffPool, from, to TupleDesc, tup Tuple) (Tuple, bool, error) {
+	var fields [][]byte
+	for i := range to.Types {
+		if i >= tup.Count() {
+			break
+		}
+		b := tup.GetField(i)
+		nb, err := ConvertField(from, i, to, i, b)
+		if err != nil {
+			return nil, false, err
+		}
+		if string(nb) == string(b) {
+			continue
+		}
+
+		if fields == nil {
+			fields = make([][]byte, tup.Count())
+			for j := range fields {
+				fields[j] = tup.GetField(j)
+			}
+		}
+		fields[i] = nb
+	}
+
+	if fields == nil {
+		return tup, false, nil
+	}
+	return NewTuple(p, fields...), true, nil
+}
+
+// EqualFields returns whether the ith field of |l|, described by |ld|, and the jth field of |r|, described by |rd|,
+// hold the same value. The fields may be stored with different encodings, see ConvertField.
+func EqualFields(ld TupleDesc, i int, l Tuple, rd TupleDesc, j int, r Tuple) bool {
+	lb, rb := ld.GetField(i, l), rd.GetField(j, r)
+	if (lb == nil) != (rb == nil) {
+		return false
+	}
+	cb, err := ConvertField(ld, i, rd, j, lb)
+	if err != nil {
+		return false
+	}
+	return rd.CompareField(cb, j, r) == 0
+}
+
+// EncodingsDiffer returns true if |l| and |r| store any of their common fields with different encodings, so that
+// equal values may be stored as different bytes.
+func EncodingsDiffer(l, r TupleDesc) bool {
+	for i := 0; i < len(l.Types) && i < len(r.Types); i++ {
+		if FieldEncodingsDiffer(l, i, r, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldEncodingsDiffer returns true if the ith field of |l| and the jth field of |r| are stored with different
+// encodings, or with different string dictionaries.
+func FieldEncodingsDiffer(l TupleDesc, i int, r TupleDesc, j int) bool {
+	le, re := l.Types[i].Enc, r.Types[j].Enc
+	return le != re || (le == StringDictEnc && !l.StringDict(i).equals(r.StringDict(j)))
+}
+
+// ConvertibleEncodings returns true if fields stored with |l| can be re-encoded with |r| by ConvertField.
+func ConvertibleEncodings(l, r Encoding) bool {
+	return l == r || (isStringEnc(l) && isStringEnc(r)) || (isDecimalEnc(l) && isDecimalEnc(r))
+}
+
+func isStringEnc(enc Encoding) bool {
+	return enc == StringEnc || enc == StringDictEnc
+}
+
+func isDecimalEnc(enc Encoding) bool {
+	return enc == DecimalEnc || enc == Decimal64Enc
+}
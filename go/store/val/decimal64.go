@@ -0,0 +1,265 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package val
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal64Enc fields are encoded as a 1-byte exponent followed by the coefficient as a little-endian int64. Like
+// DecimalEnc fields, values keep the exponent they're written with, which is the scale of their column, so equal
+// values of a column are stored as the same bytes. Reading a field doesn't go through big.Int, and fields are compared
+// and added as integers. Columns only use Decimal64Enc if their schema says so, otherwise their decimals are stored
+// with DecimalEnc.
+const decimal64Size ByteSize = int8Size + int64Size
+
+// MaxDecimal64Precision is the largest precision of the DECIMAL columns whose values can be stored with Decimal64Enc.
+// The coefficient of any value of such a column fits in an int64.
+const MaxDecimal64Precision = 18
+
+// Decimal64 is a decimal whose coefficient fits in an int64, as stored by Decimal64Enc. Arithmetic on Decimal64s is
+// done on their coefficients, and reports an overflow instead of allocating a larger coefficient.
+type Decimal64 struct {
+	coef int64
+	exp  int8
+}
+
+// NewDecimal64 returns |v| as a Decimal64, or false if its coefficient doesn't fit in an int64.
+func NewDecimal64(v decimal.Decimal) (Decimal64, bool) {
+	c := v.Coefficient()
+	if !c.IsInt64() {
+		return Decimal64{}, false
+	}
+	return newDecimal64(c.Int64(), int64(v.Exponent()))
+}
+
+// newDecimal64 returns a Decimal64 of |coef| and |exp|, or false if |exp| doesn't fit in an int8.
+func newDecimal64(coef int64, exp int64) (Decimal64, bool) {
+	if exp < math.MinInt8 || exp > math.MaxInt8 {
+		return Decimal64{}, false
+	}
+	return Decimal64{coef: coef, exp: int8(exp)}, true
+}
+
+// Decimal returns |d| as a decimal.Decimal.
+func (d Decimal64) Decimal() decimal.Decimal {
+	return decimal.New(d.coef, int32(d.exp))
+}
+
+// Cmp compares |d| and |o|.
+func (d Decimal64) Cmp(o Decimal64) int {
+	if l, r, ok := alignDecimal64(d, o); ok {
+		return compareInt64(l, r)
+	}
+	return d.Decimal().Cmp(o.Decimal())
+}
+
+// Add returns |d| + |o|, or false if the sum doesn't fit in a Decimal64.
+func (d Decimal64) Add(o Decimal64) (Decimal64, bool) {
+	l, r, ok := alignDecimal64(d, o)
+	if !ok {
+		return Decimal64{}, false
+	}
+	s := l + r
+	if (l > 0 && r > 0 && s < 0) || (l < 0 && r < 0 && s >= 0) {
+		return Decimal64{}, false
+	}
+	return newDecimal64(s, int64(minInt8(d.exp, o.exp)))
+}
+
+// Sub returns |d| - |o|, or false if the difference doesn't fit in a Decimal64.
+func (d Decimal64) Sub(o Decimal64) (Decimal64, bool) {
+	if o.coef == math.MinInt64 {
+		return Decimal64{}, false
+	}
+	return d.Add(Decimal64{coef: -o.coef, exp: o.exp})
+}
+
+// Mul returns |d| * |o|, or false if the product doesn't fit in a Decimal64.
+func (d Decimal64) Mul(o Decimal64) (Decimal64, bool) {
+	hi, lo := bits.Mul64(absInt64(d.coef), absInt64(o.coef))
+	if hi != 0 || lo > math.MaxInt64 {
+		return Decimal64{}, false
+	}
+	p := int64(lo)
+	if (d.coef < 0) != (o.coef < 0) {
+		p = -p
+	}
+	return newDecimal64(p, int64(d.exp)+int64(o.exp))
+}
+
+// alignDecimal64 returns the coefficients of |l| and |r| scaled to the smaller of their exponents, or false if
+// either doesn't fit in an int64 once it's scaled.
+func alignDecimal64(l, r Decimal64) (int64, int64, bool) {
+	if l.exp == r.exp {
+		return l.coef, r.coef, true
+	}
+	if l.exp > r.exp {
+		c, ok := scaleInt64(l.coef, int(l.exp)-int(r.exp))
+		return c, r.coef, ok
+	}
+	c, ok := scaleInt64(r.coef, int(r.exp)-int(l.exp))
+	return l.coef, c, ok
+}
+
+// scaleInt64 returns |c| * 10^|n|, or false if it doesn't fit in an int64.
+func scaleInt64(c int64, n int) (int64, bool) {
+	for ; n > 0 && c != 0; n-- {
+		if c > math.MaxInt64/10 || c < math.MinInt64/10 {
+			return 0, false
+		}
+		c *= 10
+	}
+	return c, true
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
+
+func minInt8(l, r int8) int8 {
+	if l < r {
+		return l
+	}
+	return r
+}
+
+func readDecimal64(val []byte) Decimal64 {
+	expectSize(val, decimal64Size)
+	return Decimal64{
+		exp:  readInt8(val[:int8Size]),
+		coef: readInt64(val[int8Size:]),
+	}
+}
+
+func writeDecimal64(buf []byte, val Decimal64) {
+	expectSize(buf, decimal64Size)
+	writeInt8(buf[:int8Size], val.exp)
+	writeInt64(buf[int8Size:], val.coef)
+}
+
+func compareDecimal64(l, r Decimal64) int {
+	return l.Cmp(r)
+}
+
+// GetDecimal64 reads a Decimal64 from the ith field of the Tuple, which must use Decimal64Enc.
+// If the ith field is NULL, |ok| is set to false.
+func (td TupleDesc) GetDecimal64(i int, tup Tuple) (v Decimal64, ok bool) {
+	td.expectEncoding(i, Decimal64Enc)
+	b := td.GetField(i, tup)
+	if b != nil {
+		v, ok = readDecimal64(b), true
+	}
+	return
+}
+
+// DecimalSum adds up the decimal fields of a column. The fields of a Decimal64Enc column are added as integers, the
+// sum only goes through decimal.Decimal once it no longer fits in a Decimal64, or for fields of other encodings.
+type DecimalSum struct {
+	small Decimal64
+	large decimal.Decimal
+}
+
+// AddField adds the ith field of |tup|, which must be a decimal, to the sum. NULL fields are skipped.
+func (s *DecimalSum) AddField(td TupleDesc, i int, tup Tuple) {
+	td.expectEncoding(i, DecimalEnc, Decimal64Enc)
+	b := td.GetField(i, tup)
+	if b == nil {
+		return
+	}
+	if td.Types[i].Enc == Decimal64Enc {
+		s.Add(readDecimal64(b))
+		return
+	}
+	s.large = s.large.Add(readDecimal(b))
+}
+
+// Add adds |v| to the sum.
+func (s *DecimalSum) Add(v Decimal64) {
+	if sum, ok := s.small.Add(v); ok {
+		s.small = sum
+		return
+	}
+	s.large = s.large.Add(v.Decimal())
+}
+
+// Sum returns the sum.
+func (s *DecimalSum) Sum() decimal.Decimal {
+	return s.small.Decimal().Add(s.large)
+}
+
+// compareDecimalFields compares two DecimalEnc fields. Fields with the same exponent are compared by their sign and
+// the bytes of their coefficient, without going through big.Int.
+func compareDecimalFields(l, r []byte) int {
+	if readInt32(l[:int32Size]) != readInt32(r[:int32Size]) {
+		return compareDecimal(readDecimal(l), readDecimal(r))
+	}
+	ls, rs := readInt8(l[int32Size:int32Size+int8Size]), readInt8(r[int32Size:int32Size+int8Size])
+	if ls != rs {
+		return compareInt8(ls, rs)
+	}
+	c := compareMagnitudes(l[int32Size+int8Size:], r[int32Size+int8Size:])
+	if ls < 0 {
+		return -c
+	}
+	return c
+}
+
+// compareMagnitudes compares two big-endian unsigned integers, which may be padded with leading zeros.
+func compareMagnitudes(l, r []byte) int {
+	l, r = bytes.TrimLeft(l, "\x00"), bytes.TrimLeft(r, "\x00")
+	if len(l) != len(r) {
+		return compareInt64(int64(len(l)), int64(len(r)))
+	}
+	return bytes.Compare(l, r)
+}
+
+// convertDecimalField re-encodes the decimal field |b| of encoding |from| for encoding |to|.
+func convertDecimalField(from, to Encoding, b []byte) ([]byte, error) {
+	var v decimal.Decimal
+	switch from {
+	case DecimalEnc:
+		v = readDecimal(b)
+	case Decimal64Enc:
+		v = readDecimal64(b).Decimal()
+	default:
+		return nil, fmt.Errorf("cannot convert a field of encoding %d to a decimal", from)
+	}
+
+	switch to {
+	case DecimalEnc:
+		nb := make([]byte, sizeOfDecimal(v))
+		writeDecimal(nb, v)
+		return nb, nil
+	case Decimal64Enc:
+		d, ok := NewDecimal64(v)
+		if !ok {
+			return nil, fmt.Errorf("decimal %s does not fit in a 64 bit decimal", v.String())
+		}
+		nb := make([]byte, decimal64Size)
+		writeDecimal64(nb, d)
+		return nb, nil
+	default:
+		return nil, fmt.Errorf("cannot convert a decimal to a field of encoding %d", to)
+	}
+}
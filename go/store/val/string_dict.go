@@ -0,0 +1,167 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package val
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// dictCodeMarker starts the fields of StringDictEnc holding a code. It never starts a UTF-8 string, so fields
+	// written with StringEnc are valid StringDictEnc fields.
+	dictCodeMarker byte = 0xff
+	dictCodeSize        = 3
+
+	// MaxStringDictSize is the most strings a StringDict can hold.
+	MaxStringDictSize = 1 << 16
+)
+
+// ErrStringDictCode is returned when a StringDictEnc field holds a code its dictionary doesn't have, which happens if
+// the field is read with another dictionary than the one it was written with.
+var ErrStringDictCode = errors.New("string dictionary code out of range")
+
+// StringDict is the dictionary of a StringDictEnc field. It's part of the schema of the column the field is stored
+// for, so its strings can only be appended to without rewriting the column.
+type StringDict struct {
+	values []string
+	codes  map[string]uint16
+}
+
+// NewStringDict returns a StringDict coding |values| by their index, or an error if |values| can't be a dictionary,
+// see ValidateStringDict.
+func NewStringDict(values []string) (*StringDict, error) {
+	if err := ValidateStringDict(values); err != nil {
+		return nil, err
+	}
+	return StringDictOf(values), nil
+}
+
+// ValidateStringDict returns an error if |values| has more than MaxStringDictSize strings, or a string more than once.
+func ValidateStringDict(values []string) error {
+	if len(values) > MaxStringDictSize {
+		return fmt.Errorf("string dictionary has %d values, the most it can have is %d", len(values), MaxStringDictSize)
+	}
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			return fmt.Errorf("string dictionary has duplicate value '%s'", v)
+		}
+		seen[v] = struct{}{}
+	}
+	return nil
+}
+
+// StringDictOf returns a StringDict coding |values| by their index, for |values| checked with ValidateStringDict.
+// Should |values| be invalid, fields are still read as they were written: only the first of duplicate strings and the
+// first MaxStringDictSize strings are coded, and other strings are stored inline.
+func StringDictOf(values []string) *StringDict {
+	d := &StringDict{values: values, codes: make(map[string]uint16, len(values))}
+	for i, v := range values {
+		if i >= MaxStringDictSize {
+			break
+		}
+		if _, ok := d.codes[v]; !ok {
+			d.codes[v] = uint16(i)
+		}
+	}
+	return d
+}
+
+// Values returns the strings of |d|, in code order.
+func (d *StringDict) Values() []string {
+	return d.values
+}
+
+func sizeOfDictString(d *StringDict, val string) ByteSize {
+	if _, ok := d.code(val); ok {
+		return dictCodeSize
+	}
+	return ByteSize(len(val)) + 1
+}
+
+func writeDictString(buf []byte, d *StringDict, val string) {
+	if code, ok := d.code(val); ok {
+		expectSize(buf, dictCodeSize)
+		buf[0] = dictCodeMarker
+		WriteUint16(buf[1:], code)
+		return
+	}
+	writeString(buf, val)
+}
+
+func readDictString(val []byte, d *StringDict) (string, error) {
+	if len(val) == dictCodeSize && val[0] == dictCodeMarker {
+		code := ReadUint16(val[1:])
+		if d == nil || int(code) >= len(d.values) {
+			return "", fmt.Errorf("%w: %d", ErrStringDictCode, code)
+		}
+		return d.values[code], nil
+	}
+	return readString(val), nil
+}
+
+func (d *StringDict) equals(other *StringDict) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	if len(d.values) != len(other.values) {
+		return false
+	}
+	for i := range d.values {
+		if d.values[i] != other.values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *StringDict) code(val string) (uint16, bool) {
+	if d == nil {
+		return 0, false
+	}
+	code, ok := d.codes[val]
+	return code, ok
+}
+
+// WithStringDicts returns a copy of |td| which reads and writes its StringDictEnc fields with |dicts|, indexed by
+// field.
+func (td TupleDesc) WithStringDicts(dicts []*StringDict) TupleDesc {
+	if len(dicts) != len(td.Types) {
+		panic(fmt.Sprintf("cannot use %d string dictionaries for %d fields", len(dicts), len(td.Types)))
+	}
+	td.dicts = dicts
+	return td
+}
+
+// GetDictString reads a string from the ith field of the Tuple, which must use StringDictEnc.
+// If the ith field is NULL, |ok| is set to false.
+func (td TupleDesc) GetDictString(i int, tup Tuple) (v string, ok bool, err error) {
+	td.expectEncoding(i, StringDictEnc)
+	b := td.GetField(i, tup)
+	if b != nil {
+		v, err = readDictString(b, td.StringDict(i))
+		ok = err == nil
+	}
+	return
+}
+
+// StringDict returns the dictionary of the ith field, or nil if it doesn't have one.
+func (td TupleDesc) StringDict(i int) *StringDict {
+	if td.dicts == nil {
+		return nil
+	}
+	return td.dicts[i]
+}
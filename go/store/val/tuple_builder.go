@@ -15,6 +15,7 @@
 package val
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -104,6 +105,26 @@ func (tb *TupleBuilder) BuildPrefix(pool pool.BuffPool, k int) (tup Tuple) {
 	return
 }
 
+// BuildInPlace materializes a copy of |tup| with the fields written to the TupleBuilder replacing its fields,
+// without re-encoding the fields of |tup| left unwritten. It returns false if a written field isn't the size of
+// the field it replaces, in which case the Tuple must be built in full. The TupleBuilder is recycled either way.
+func (tb *TupleBuilder) BuildInPlace(pool pool.BuffPool, tup Tuple) (Tuple, bool) {
+	defer tb.Recycle()
+	values := tb.fields[:tb.Desc.Count()]
+	for i, v := range values {
+		if v != nil && len(v) != len(tup.GetField(i)) {
+			return nil, false
+		}
+	}
+	tup = cloneTuple(pool, tup)
+	for i, v := range values {
+		if v != nil {
+			copy(tup.GetField(i), v)
+		}
+	}
+	return tup, true
+}
+
 // Recycle resets the TupleBuilder so it can build a new Tuple.
 func (tb *TupleBuilder) Recycle() {
 	for i := 0; i < tb.Desc.Count(); i++ {
@@ -219,8 +240,21 @@ func (tb *TupleBuilder) PutBit(i int, v uint64) {
 	tb.pos += bit64Size
 }
 
+// PutDecimal writes a decimal to the ith field of the Tuple being built. If the field uses Decimal64Enc, the
+// coefficient of |v| must fit in an int64.
 func (tb *TupleBuilder) PutDecimal(i int, v decimal.Decimal) {
-	tb.Desc.expectEncoding(i, DecimalEnc)
+	tb.Desc.expectEncoding(i, DecimalEnc, Decimal64Enc)
+	if tb.Desc.Types[i].Enc == Decimal64Enc {
+		d, ok := NewDecimal64(v)
+		if !ok {
+			panic(fmt.Sprintf("decimal %s does not fit in a 64 bit decimal", v.String()))
+		}
+		tb.ensureCapacity(decimal64Size)
+		tb.fields[i] = tb.buf[tb.pos : tb.pos+decimal64Size]
+		writeDecimal64(tb.fields[i], d)
+		tb.pos += decimal64Size
+		return
+	}
 	sz := sizeOfDecimal(v)
 	tb.ensureCapacity(sz)
 	tb.fields[i] = tb.buf[tb.pos : tb.pos+sz]
@@ -280,7 +314,16 @@ func (tb *TupleBuilder) PutSet(i int, v uint64) {
 
 // PutString writes a string to the ith field of the Tuple being built.
 func (tb *TupleBuilder) PutString(i int, v string) {
-	tb.Desc.expectEncoding(i, StringEnc)
+	tb.Desc.expectEncoding(i, StringEnc, StringDictEnc)
+	if tb.Desc.Types[i].Enc == StringDictEnc {
+		d := tb.Desc.StringDict(i)
+		sz := sizeOfDictString(d, v)
+		tb.ensureCapacity(sz)
+		tb.fields[i] = tb.buf[tb.pos : tb.pos+sz]
+		writeDictString(tb.fields[i], d, v)
+		tb.pos += sz
+		return
+	}
 	sz := ByteSize(len(v)) + 1
 	tb.ensureCapacity(sz)
 	tb.fields[i] = tb.buf[tb.pos : tb.pos+sz]
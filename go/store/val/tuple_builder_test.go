@@ -32,6 +32,9 @@ func TestTupleBuilder(t *testing.T) {
 	t.Run("build large tuple", func(t *testing.T) {
 		testBuildLargeTuple(t)
 	})
+	t.Run("build in place", func(t *testing.T) {
+		testBuildInPlace(t)
+	})
 }
 
 func smokeTestTupleBuilder(t *testing.T) {
@@ -201,6 +204,50 @@ func testBuildLargeTuple(t *testing.T) {
 	tb.PutByteString(11, []byte(s2))
 }
 
+func testBuildInPlace(t *testing.T) {
+	desc := NewTupleDescriptor(
+		Type{Enc: Int64Enc},
+		Type{Enc: StringEnc, Nullable: true},
+		Type{Enc: Int32Enc, Nullable: true},
+	)
+
+	tb := NewTupleBuilder(desc)
+	tb.PutInt64(0, 1)
+	tb.PutString(1, "abc")
+	tb.PutInt32(2, 7)
+	tup := tb.Build(testPool)
+
+	tb.PutInt64(0, 2)
+	tb.PutString(1, "xyz")
+	updated, ok := tb.BuildInPlace(testPool, tup)
+	assert.True(t, ok)
+	i64, ok := desc.GetInt64(0, updated)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), i64)
+	s, ok := desc.GetString(1, updated)
+	assert.True(t, ok)
+	assert.Equal(t, "xyz", s)
+	i32, ok := desc.GetInt32(2, updated)
+	assert.True(t, ok)
+	assert.Equal(t, int32(7), i32)
+
+	// |tup| is left unchanged
+	i64, _ = desc.GetInt64(0, tup)
+	assert.Equal(t, int64(1), i64)
+
+	// fields which change size can't be replaced in place
+	tb.PutString(1, "abcd")
+	_, ok = tb.BuildInPlace(testPool, tup)
+	assert.False(t, ok)
+
+	// nor can NULL fields
+	tb.PutInt64(0, 1)
+	tup = tb.Build(testPool)
+	tb.PutInt32(2, 7)
+	_, ok = tb.BuildInPlace(testPool, tup)
+	assert.False(t, ok)
+}
+
 type testCompare struct{}
 
 var _ TupleComparator = testCompare{}
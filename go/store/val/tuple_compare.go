@@ -116,7 +116,9 @@ func compare(typ Type, left, right []byte) int {
 	case Bit64Enc:
 		return compareBit64(readBit64(left), readBit64(right))
 	case DecimalEnc:
-		return compareDecimal(readDecimal(left), readDecimal(right))
+		return compareDecimalFields(left, right)
+	case Decimal64Enc:
+		return compareDecimal64(readDecimal64(left), readDecimal64(right))
 	case YearEnc:
 		return compareYear(readYear(left), readYear(right))
 	case DateEnc:
@@ -133,6 +135,9 @@ func compare(typ Type, left, right []byte) int {
 		return compareString(readString(left), readString(right))
 	case ByteStringEnc:
 		return compareByteString(readByteString(left), readByteString(right))
+	case StringDictEnc:
+		// codes aren't ordered like their strings, so only equality is meaningful
+		return compareByteString(left, right)
 	case Hash128Enc:
 		return compareHash128(readHash128(left), readHash128(right))
 	case BytesAddrEnc:
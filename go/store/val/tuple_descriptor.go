@@ -44,6 +44,8 @@ type TupleDesc struct {
 	Types []Type
 	cmp   TupleComparator
 	fast  FixedAccess
+	// dicts are the dictionaries of StringDictEnc fields. See WithStringDicts.
+	dicts []*StringDict
 }
 
 // NewTupleDescriptor makes a TupleDescriptor from |types|.
@@ -114,7 +116,11 @@ func (td TupleDesc) AddressFieldCount() (n int) {
 
 // PrefixDesc returns a descriptor for the first n types.
 func (td TupleDesc) PrefixDesc(n int) TupleDesc {
-	return NewTupleDescriptorWithComparator(td.cmp.Prefix(n), td.Types[:n]...)
+	prefix := NewTupleDescriptorWithComparator(td.cmp.Prefix(n), td.Types[:n]...)
+	if td.dicts != nil {
+		prefix.dicts = td.dicts[:n]
+	}
+	return prefix
 }
 
 // GetField returns the ith field of |tup|.
@@ -308,15 +314,18 @@ func (td TupleDesc) GetBit(i int, tup Tuple) (v uint64, ok bool) {
 	return
 }
 
-// GetDecimal reads a float64 from the ith field of the Tuple.
+// GetDecimal reads a decimal from the ith field of the Tuple.
 // If the ith field is NULL, |ok| is set to false.
 func (td TupleDesc) GetDecimal(i int, tup Tuple) (v decimal.Decimal, ok bool) {
-	td.expectEncoding(i, DecimalEnc)
+	td.expectEncoding(i, DecimalEnc, Decimal64Enc)
 	b := td.GetField(i, tup)
-	if b != nil {
-		v, ok = readDecimal(b), true
+	if b == nil {
+		return
 	}
-	return
+	if td.Types[i].Enc == Decimal64Enc {
+		return readDecimal64(b).Decimal(), true
+	}
+	return readDecimal(b), true
 }
 
 // GetYear reads an int16 from the ith field of the Tuple.
@@ -515,6 +524,13 @@ func (td TupleDesc) FormatValue(i int, value []byte) string {
 	if value == nil {
 		return "NULL"
 	}
+	if td.Types[i].Enc == StringDictEnc {
+		s, err := readDictString(value, td.StringDict(i))
+		if err != nil {
+			return err.Error()
+		}
+		return s
+	}
 	return formatValue(td.Types[i].Enc, value)
 }
 func formatValue(enc Encoding, value []byte) string {
@@ -556,6 +572,8 @@ func formatValue(enc Encoding, value []byte) string {
 	case DecimalEnc:
 		v := readDecimal(value)
 		return v.String()
+	case Decimal64Enc:
+		return readDecimal64(value).Decimal().String()
 	case YearEnc:
 		v := readYear(value)
 		return strconv.Itoa(int(v))
@@ -600,6 +618,9 @@ func (td TupleDesc) Equals(other TupleDesc) bool {
 		if typ != other.Types[i] {
 			return false
 		}
+		if typ.Enc == StringDictEnc && !td.StringDict(i).equals(other.StringDict(i)) {
+			return false
+		}
 	}
 	return true
 }
@@ -36,6 +36,12 @@ func main() {
 	grpcPortParam := flag.Int("grpc-port", -1, "the port the grpc server will listen on; default 50051")
 	httpPortParam := flag.Int("http-port", -1, "the port the http server will listen on; default 80; if http-port is equal to grpc-port, both services will serve over the same port")
 	httpHostParam := flag.String("http-host", "", "hostname to use in the host component of the URLs that the server generates; default ''; if '', server will echo the :authority header")
+	maxDownloadsParam := flag.Int("max-concurrent-downloads", 0, "the most table file downloads served at once; default 0, unlimited")
+	maxClientDownloadsParam := flag.Int("max-concurrent-downloads-per-client", 0, "the most table file downloads served to a single client at once; default 0, unlimited")
+	maxQueuedDownloadsParam := flag.Int("max-queued-downloads", 0, "the most table file downloads waiting to be served before more are rejected; default 0, unlimited")
+	queueTimeoutParam := flag.Duration("download-queue-timeout", 0, "how long a table file download waits to be served before it's rejected; default 0, unlimited")
+	clientBytesPerSecParam := flag.Int64("client-bytes-per-second", 0, "the rate at which table files are sent to a single client; default 0, unlimited")
+	tokenFileParam := flag.String("token-file", "", "file of the tokens which clients authenticate with, and of the fetches and pushes each of them can do; default '', any client can do anything")
 	flag.Parse()
 
 	if dirParam != nil && len(*dirParam) > 0 {
@@ -81,6 +87,25 @@ func main() {
 		dbCache = NewLocalCSCache(fs)
 	}
 
+	var loadShedding *remotesrv.LoadSheddingConfig
+	if *maxDownloadsParam > 0 || *maxClientDownloadsParam > 0 || *maxQueuedDownloadsParam > 0 || *queueTimeoutParam > 0 || *clientBytesPerSecParam > 0 {
+		loadShedding = &remotesrv.LoadSheddingConfig{
+			MaxConcurrentDownloads:          *maxDownloadsParam,
+			MaxConcurrentDownloadsPerClient: *maxClientDownloadsParam,
+			MaxQueuedDownloads:              *maxQueuedDownloadsParam,
+			QueueTimeout:                    *queueTimeoutParam,
+			ClientBytesPerSecond:            *clientBytesPerSecParam,
+		}
+	}
+
+	var authorizer remotesrv.Authorizer
+	if *tokenFileParam != "" {
+		authorizer, err = remotesrv.NewTokenFileAuthorizer(*tokenFileParam)
+		if err != nil {
+			log.Fatalln("could not load token file:", err.Error())
+		}
+	}
+
 	server, err := remotesrv.NewServer(remotesrv.ServerArgs{
 		HttpHost:       *httpHostParam,
 		HttpListenAddr: fmt.Sprintf(":%d", *httpPortParam),
@@ -88,6 +113,8 @@ func main() {
 		FS:             fs,
 		DBCache:        dbCache,
 		ReadOnly:       *readOnlyParam,
+		LoadShedding:   loadShedding,
+		Authorizer:     authorizer,
 	})
 	if err != nil {
 		log.Fatalf("error creating remotesrv Server: %v\n", err)
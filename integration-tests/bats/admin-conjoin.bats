@@ -0,0 +1,58 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    export DOLT_DISABLE_CHUNK_JOURNAL=true
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt add test
+    dolt commit -m "create table"
+    for i in 1 2 3; do
+        dolt sql -q "INSERT INTO test VALUES ($i, $i)"
+        dolt commit -am "insert $i"
+    done
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+table_files() {
+    ls .dolt/noms | grep -E '^[0-9a-v]{32}$' | wc -l | tr -d ' '
+}
+
+@test "admin-conjoin: conjoins every table file without --target-size" {
+    [ "$(table_files)" -gt 1 ]
+
+    run dolt admin conjoin
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "table files left" ]] || false
+    [ "$(table_files)" -eq 1 ]
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 5 ]
+}
+
+@test "admin-conjoin: leaves table files larger than --target-size alone" {
+    before=$(table_files)
+
+    run dolt admin conjoin --target-size 1B
+    [ "$status" -eq 0 ]
+    [ "$(table_files)" -eq "$before" ]
+
+    run dolt admin conjoin --target-size 1GB
+    [ "$status" -eq 0 ]
+    [ "$(table_files)" -eq 1 ]
+}
+
+@test "admin-conjoin: invalid --target-size" {
+    run dolt admin conjoin --target-size lots
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid --target-size" ]] || false
+}
@@ -0,0 +1,59 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "create table" --date 2024-01-01T10:00:00
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "insert 1" --date 2024-01-02T10:00:00
+    dolt branch old
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "insert 2" --date 2024-01-03T10:00:00
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "admin-restore: --as-of restores branches that were reset and deleted" {
+    dolt reset --hard HEAD~1
+    dolt branch -D old
+
+    run dolt admin restore --as-of "2024-01-03 12:00" --list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "main:" ]] || false
+    [[ "$output" =~ "old: restored" ]] || false
+    run dolt sql -q "select count(*) from test" -r csv
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt admin restore --as-of "2024-01-03 12:00"
+    [ "$status" -eq 0 ]
+    run dolt sql -q "select group_concat(pk) from test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,2" ]] || false
+    run dolt branch
+    [[ "$output" =~ "old" ]] || false
+
+    run dolt admin restore --as-of "2024-01-03 12:00"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "already as of" ]] || false
+}
+
+@test "admin-restore: --as-of moves branches back and fails before the first commit" {
+    dolt checkout -b later
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "insert 3" --date 2024-01-05T10:00:00
+
+    run dolt admin restore --as-of "2024-01-01 12:00"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "later:" ]] || false
+    run dolt sql -q "select count(*) from test" -r csv
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt admin restore --as-of 2000-01-01
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no commits made at or before" ]] || false
+}
@@ -0,0 +1,44 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt add test
+    dolt commit -m "create table"
+    dolt sql -q "INSERT INTO test VALUES (1, 1), (2, 2)"
+    dolt commit -am "insert rows"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "admin-storage: inspect prints the statistics of every table file" {
+    run dolt admin storage inspect
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "chunks:" ]] || false
+    [[ "$output" =~ "uncompressed:" ]] || false
+    [[ "$output" =~ "chunk sizes:" ]] || false
+    [[ "$output" =~ "modified:" ]] || false
+    [[ "$output" =~ "table files," ]] || false
+    [[ ! "$output" =~ "refs:" ]] || false
+}
+
+@test "admin-storage: inspect --refs prints the refs whose chunks are in each table file" {
+    run dolt admin storage inspect --refs
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs:" ]] || false
+    [[ "$output" =~ "refs/heads/main:" ]] || false
+}
+
+@test "admin-storage: inspect table files without the chunk journal" {
+    export DOLT_DISABLE_CHUNK_JOURNAL=true
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt commit -am "insert another row"
+
+    run dolt admin storage inspect
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "table file " ]] || false
+}
@@ -0,0 +1,74 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt add test
+    dolt commit -m "create table"
+    for i in 1 2 3; do
+        dolt sql -q "INSERT INTO test VALUES ($i, $i)"
+        dolt commit -am "insert $i"
+    done
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "admin-truncate-history: commit becomes a root and data is unchanged" {
+    run dolt admin truncate-history --before HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "insert 3" ]] || false
+    [[ "$output" =~ "insert 2" ]] || false
+    [[ ! "$output" =~ "insert 1" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "admin-truncate-history: rewrites other branches and leaves unrelated ones alone" {
+    dolt branch old HEAD~3
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt commit -am "insert 4"
+    dolt checkout main
+
+    run dolt admin truncate-history --before HEAD~1 --skip-gc
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/heads/feature" ]] || false
+    [[ ! "$output" =~ "refs/heads/old" ]] || false
+
+    run dolt log --oneline feature
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt log --oneline old
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "create table" ]] || false
+}
+
+@test "admin-truncate-history: --archive keeps the old history in a tag" {
+    run dolt admin truncate-history --before HEAD~1 --archive pre-truncate
+    [ "$status" -eq 0 ]
+
+    run dolt log --oneline pre-truncate
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "insert 2" ]] || false
+    [[ "$output" =~ "insert 1" ]] || false
+    [[ "$output" =~ "create table" ]] || false
+}
+
+@test "admin-truncate-history: requires --before" {
+    run dolt admin truncate-history
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--before is required" ]] || false
+}
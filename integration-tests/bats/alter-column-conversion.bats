@@ -0,0 +1,114 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    skip_nbf_not_dolt
+
+    dolt sql <<SQL
+CREATE TABLE t (pk int PRIMARY KEY, i int, s varchar(20), INDEX idx_i (i));
+INSERT INTO t VALUES (1, 10, '2023/01/02'), (2, 300, '2023/02/03'), (3, NULL, 'not a date');
+SQL
+    dolt commit -Am "create t"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "alter-column-conversion: int to varchar" {
+    dolt sql -q "ALTER TABLE t MODIFY COLUMN i varchar(10)"
+
+    run dolt sql -q "SELECT pk, i FROM t WHERE i = '300'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,300" ]] || false
+
+    run dolt sql -q "SHOW CREATE TABLE t"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`i` varchar(10)' ]] || false
+}
+
+@test "alter-column-conversion: varchar to datetime with a format" {
+    dolt sql -q "DELETE FROM t WHERE pk = 3"
+    dolt sql <<SQL
+SET @@dolt_alter_column_datetime_format = '%Y/%m/%d';
+ALTER TABLE t MODIFY COLUMN s datetime;
+SQL
+
+    run dolt sql -q "SELECT pk, s FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,2023-01-02 00:00:00" ]] || false
+    [[ "$output" =~ "2,2023-02-03 00:00:00" ]] || false
+}
+
+@test "alter-column-conversion: values that can't be converted abort by default" {
+    run dolt sql -q "ALTER TABLE t MODIFY COLUMN i tinyint"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "300 out of range for tinyint" ]] || false
+
+    run dolt sql -q "ALTER TABLE t MODIFY COLUMN s varchar(5)"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "too large" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "alter-column-conversion: values that can't be converted are replaced with NULL" {
+    dolt sql <<SQL
+SET @@dolt_alter_column_on_error = 'null';
+SET @@dolt_alter_column_threads = 2;
+ALTER TABLE t MODIFY COLUMN i tinyint;
+SQL
+
+    run dolt sql -q "SELECT pk, i FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,10" ]] || false
+    [[ "$output" =~ "2," ]] || false
+    [[ ! "$output" =~ "300" ]] || false
+
+    run dolt sql -q "SELECT pk FROM t WHERE i IS NULL ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    [ "${lines[2]}" = "3" ]
+}
+
+@test "alter-column-conversion: NULL can't replace values of a NOT NULL column" {
+    run dolt sql <<SQL
+SET @@dolt_alter_column_on_error = 'null';
+ALTER TABLE t MODIFY COLUMN pk tinyint NOT NULL;
+SQL
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "the column is NOT NULL" ]] || false
+}
+
+@test "alter-column-conversion: rows that can't be converted are skipped to the constraint violations table" {
+    dolt sql <<SQL
+SET @@dolt_force_transaction_commit = 1;
+SET @@dolt_alter_column_on_error = 'skip';
+ALTER TABLE t MODIFY COLUMN i tinyint;
+SQL
+
+    run dolt sql -q "SELECT pk, i FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "$output" =~ "1,10" ]] || false
+    [[ ! "$output" =~ "2," ]] || false
+
+    run dolt sql -q "SELECT violation_type, pk, i, s, json_unquote(json_extract(violation_info, '$.Value')) FROM dolt_constraint_violations_t" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ 'type conversion,2,,2023/02/03,300' ]] || false
+}
+
+@test "alter-column-conversion: rows of keyless tables can't be skipped" {
+    dolt sql -q "CREATE TABLE keyless (i int)"
+    run dolt sql <<SQL
+SET @@dolt_alter_column_on_error = 'skip';
+ALTER TABLE keyless MODIFY COLUMN i tinyint;
+SQL
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "has no primary key" ]] || false
+}
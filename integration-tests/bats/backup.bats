@@ -251,3 +251,84 @@ teardown() {
     [ "${#lines[@]}" -eq 2 ]
     [[ "$output" =~ "t1" ]] || false
 }
+
+@test "backup: restore-table restores a dropped table" {
+    cd repo1
+    dolt sql -q "insert into t1 values (1), (2)"
+    dolt commit -am "add rows"
+    dolt backup add bac1 file://../bac1
+    dolt backup sync bac1
+
+    dolt sql -q "drop table t1"
+    dolt commit -am "drop t1"
+
+    run dolt backup restore-table bac1 t1
+    [ "$status" -eq 0 ]
+    run dolt sql -q "select a from t1 order by a" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "2" ]
+
+    run dolt backup restore-table bac1 t1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table 't1' already exists" ]] || false
+
+    run dolt backup restore-table --as t1_before --at main~1 bac1 t1
+    [ "$status" -eq 0 ]
+    run dolt sql -q "select count(*) from t1_before" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt backup restore-table bac1 missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "No table named 'missing'" ]] || false
+}
+
+@test "backup: remote restore-table restores a table from a remote" {
+    cd repo1
+    dolt sql -q "drop table t1"
+    dolt commit -am "drop t1"
+
+    run dolt remote restore-table --as t2 origin t1
+    [ "$status" -eq 0 ]
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t2" ]] || false
+    [[ ! "$output" =~ "t1" ]] || false
+
+    run dolt remote restore-table unknown t1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote: 'unknown'" ]] || false
+}
+
+@test "backup: sync garbage collects the backup after the database is garbage collected" {
+    cd repo1
+    dolt checkout -b tmp
+    dolt sql -q "insert into t1 values (1), (2), (3)"
+    dolt commit -am "tmp"
+    dolt checkout main
+    dolt backup add bac1 file://../bac1
+    dolt backup sync bac1
+
+    run dolt backup sync bac1
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Garbage collected the backup" ]] || false
+
+    dolt branch -D tmp
+    dolt gc
+    run dolt backup sync bac1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Garbage collected the backup" ]] || false
+
+    run dolt backup sync bac1
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Garbage collected the backup" ]] || false
+
+    cd ..
+    dolt backup restore file://./bac1 repo2
+    cd repo2
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "main" ]] || false
+    [[ ! "$output" =~ "tmp" ]] || false
+}
@@ -0,0 +1,140 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt commit -Am "create test"
+    dolt branch b1
+    dolt branch b2
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "branch-bulk: dolt branch --bulk-file applies every operation" {
+    cat > ops.json <<JSON
+[
+  {"op": "create", "branch": "c1"},
+  {"op": "create", "branch": "c2", "start_point": "b1"},
+  {"op": "delete", "branch": "b2"},
+  {"op": "rename", "branch": "b1", "new_branch": "r1"}
+]
+JSON
+    run dolt branch --bulk-file ops.json
+    [ "$status" -eq 0 ]
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c1" ]] || false
+    [[ "$output" =~ "c2" ]] || false
+    [[ "$output" =~ "r1" ]] || false
+    [[ ! "$output" =~ "b1" ]] || false
+    [[ ! "$output" =~ "b2" ]] || false
+}
+
+@test "branch-bulk: no branch is changed if an operation fails" {
+    cat > ops.json <<JSON
+[
+  {"op": "create", "branch": "c1"},
+  {"op": "delete", "branch": "b2"},
+  {"op": "delete", "branch": "missing"}
+]
+JSON
+    run dolt branch --bulk-file ops.json
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch operation 2 (delete 'missing') failed" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "c1" ]] || false
+    [[ "$output" =~ "b2" ]] || false
+}
+
+@test "branch-bulk: operations see the changes made before them" {
+    cat > ops.json <<JSON
+[
+  {"op": "create", "branch": "c1"},
+  {"op": "rename", "branch": "c1", "new_branch": "c2"},
+  {"op": "create", "branch": "c3", "start_point": "c2"}
+]
+JSON
+    dolt branch --bulk-file ops.json
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "c1" ]] || false
+    [[ "$output" =~ "c2" ]] || false
+    [[ "$output" =~ "c3" ]] || false
+
+    dolt checkout c3
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "branch-bulk: renaming the current branch checks out the new name" {
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    echo '[{"op": "rename", "branch": "main", "new_branch": "trunk"}]' > ops.json
+    dolt branch --bulk-file ops.json
+
+    run dolt branch --show-current
+    [ "$status" -eq 0 ]
+    [ "$output" = "trunk" ]
+
+    # the working set moves with the branch
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:" ]] || false
+}
+
+@test "branch-bulk: unmerged branches are only deleted with force" {
+    dolt checkout b1
+    dolt commit --allow-empty -m "unmerged"
+    dolt checkout main
+
+    echo '[{"op": "delete", "branch": "b1"}]' > ops.json
+    run dolt branch --bulk-file ops.json
+    [ "$status" -eq 1 ]
+
+    echo '[{"op": "delete", "branch": "b1", "force": true}]' > ops.json
+    dolt branch --bulk-file ops.json
+    run dolt branch
+    [[ ! "$output" =~ "b1" ]] || false
+}
+
+@test "branch-bulk: invalid operations are rejected" {
+    echo '[{"op": "copy", "branch": "b1"}]' > ops.json
+    run dolt branch --bulk-file ops.json
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown op 'copy'" ]] || false
+
+    echo '[{"op": "rename", "branch": "b1"}]' > ops.json
+    run dolt branch --bulk-file ops.json
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "new_branch is required" ]] || false
+
+    echo '[{"op": "delete", "branch": "main"}]' > ops.json
+    run dolt branch --bulk-file ops.json
+    [ "$status" -eq 1 ]
+}
+
+@test "branch-bulk: CALL dolt_branch_bulk" {
+    run dolt sql -q "CALL dolt_branch_bulk('[{\"op\": \"create\", \"branch\": \"c1\"}, {\"op\": \"delete\", \"branch\": \"b1\"}]')"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT name FROM dolt_branches ORDER BY name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c1" ]] || false
+    [[ ! "$output" =~ "b1" ]] || false
+
+    run dolt sql -q "CALL dolt_branch_bulk('[{\"op\": \"create\", \"branch\": \"c2\"}, {\"op\": \"create\", \"branch\": \"c1\"}]')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    run dolt sql -q "SELECT name FROM dolt_branches ORDER BY name" -r csv
+    [[ ! "$output" =~ "c2" ]] || false
+}
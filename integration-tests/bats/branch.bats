@@ -143,4 +143,48 @@ teardown() {
     run dolt branch -D main
     [ "$status" -ne 0 ]
     [[ "$output" =~ "Cannot delete checked out branch 'main'" ]] || false
-}
\ No newline at end of file
+}
+@test "branch: protected branches can't be renamed or force copied over" {
+    dolt commit --allow-empty -m "first"
+    dolt branch old
+    dolt commit --allow-empty -m "second"
+    dolt branch prot
+    dolt commit --allow-empty -m "third"
+    dolt branch -c main ahead
+    dolt checkout -b diverged old
+    dolt commit --allow-empty -m "diverged"
+    dolt checkout main
+    dolt config --local --add branch.protected prot
+
+    run dolt branch -m prot renamed
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'prot' is protected and can't be deleted" ]] || false
+
+    run dolt branch -m -f diverged prot
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'prot' is protected and can only be fast-forwarded" ]] || false
+    dolt branch | grep diverged
+
+    run dolt branch -c -f diverged prot
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'prot' is protected and can only be fast-forwarded" ]] || false
+
+    run dolt branch -f prot diverged
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'prot' is protected and can only be fast-forwarded" ]] || false
+
+    run dolt sql -q "CALL dolt_branch('-m', 'prot', 'renamed')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'prot' is protected and can't be deleted" ]] || false
+
+    run dolt sql -q "CALL dolt_branch('-c', '-f', 'diverged', 'prot')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'prot' is protected and can only be fast-forwarded" ]] || false
+
+    # fast-forwarding a protected branch is allowed
+    dolt branch -c -f ahead prot
+    [ "$(dolt log prot -n 1 --oneline | grep -c third)" -eq 1 ]
+
+    dolt config --local --unset branch.protected
+    dolt branch -m prot renamed
+}
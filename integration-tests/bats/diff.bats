@@ -363,6 +363,45 @@ SQL
     [[ ! "$output" =~ "- | 2" ]] || false
 }
 
+@test "diff: --predict-conflicts marks rows also changed on the other side of a merge base diff" {
+    dolt sql -q "create table t (pk int primary key, c int)"
+    dolt sql -q "insert into t values (1, 1), (2, 2), (3, 3)"
+    dolt add -A && dolt commit -m "base"
+    dolt branch other
+
+    dolt sql -q "update t set c = 10 where pk = 1"
+    dolt sql -q "update t set c = 20 where pk = 2"
+    dolt commit -am "main changes"
+
+    dolt checkout other
+    dolt sql -q "update t set c = 100 where pk = 1"
+    dolt sql -q "delete from t where pk = 3"
+    dolt commit -am "other changes"
+    dolt checkout main
+
+    run dolt diff --predict-conflicts --merge-base other main t -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"to_row":{"c":10,"pk":1,"would_conflict":true}' ]] || false
+    [[ "$output" =~ '"to_row":{"c":20,"pk":2,"would_conflict":false}' ]] || false
+
+    run dolt diff --predict-conflicts main...other t
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "would_conflict" ]] || false
+
+    run dolt diff --predict-conflicts main...other t -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"to_row":{"c":100,"pk":1,"would_conflict":true}' ]] || false
+    [[ "$output" =~ '"from_row":{"c":3,"pk":3,"would_conflict":false},"to_row":{}' ]] || false
+
+    run dolt diff --predict-conflicts other main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "requires a diff against a merge base" ]] || false
+
+    run dolt diff --predict-conflicts --merge-base other main -r sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot be used with sql output" ]] || false
+}
+
 @test "diff: data and schema changes" {
     dolt sql <<SQL
 drop table test;
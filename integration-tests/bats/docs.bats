@@ -152,3 +152,47 @@ TXT
     [[ "$output" =~ "-  0. You just DO WHAT THE FUCK YOU WANT TO"               ]] || false
     [[ "$output" =~ "+  0. You just DO WHAT THE F*CK YOU WANT TO"               ]] || false
 }
+
+@test "docs: generate-dictionary renders the schemas of the current commit" {
+    dolt sql <<SQL
+CREATE TABLE parent (id int PRIMARY KEY COMMENT 'the parent id', name varchar(20) COMMENT 'display name');
+CREATE TABLE child (id int PRIMARY KEY, pid int, CONSTRAINT fk_parent FOREIGN KEY (pid) REFERENCES parent (id));
+SQL
+    dolt add -A && dolt commit -m "add tables"
+    dolt sql -q "ALTER TABLE child ADD COLUMN uncommitted int COMMENT 'not committed'"
+
+    dolt docs generate-dictionary
+    run dolt docs print DATA_DICTIONARY.md
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '| `id` | int | NO | PRI |  | the parent id |' ]] || false
+    [[ "$output" =~ '| `name` | varchar(20) | YES |  |  | display name |' ]] || false
+    [[ "$output" =~ '| `fk_parent` | `pid` | `parent` (`id`) |' ]] || false
+    [[ "$output" =~ 'child -->|fk_parent| parent' ]] || false
+    [[ ! "$output" =~ "not committed" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "dolt_docs" ]] || false
+}
+
+@test "docs: data dictionary is regenerated on commit when configured" {
+    dolt config --local --add docs.datadictionary true
+    dolt sql -q "CREATE TABLE t (id int PRIMARY KEY COMMENT 'first comment')"
+    dolt add t && dolt commit -m "add t"
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    run dolt sql -q "SELECT doc_text LIKE '%first comment%' FROM dolt_docs AS OF 'HEAD' WHERE doc_name = 'DATA_DICTIONARY.md'" -r csv
+    [[ "$output" =~ "true" ]] || false
+
+    dolt sql -q "ALTER TABLE t MODIFY id int COMMENT 'second comment'; CALL dolt_commit('-am', 'change comment')"
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    run dolt sql -q "SELECT doc_text LIKE '%second comment%' FROM dolt_docs AS OF 'HEAD' WHERE doc_name = 'DATA_DICTIONARY.md'" -r csv
+    [[ "$output" =~ "true" ]] || false
+
+    # changes to data don't change the dictionary
+    dolt sql -q "INSERT INTO t VALUES (1)"
+    dolt commit -am "add data"
+    run dolt diff --stat HEAD~1 HEAD dolt_docs
+    [ "$output" = "" ]
+}
@@ -0,0 +1,65 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk int primary key, v int)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1), (2, 2)"
+    dolt add test
+    dolt commit -m "add test"
+
+    mkdir ../remote-$$
+    dolt remote add origin file://../remote-$$
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+    rm -rf ../remote-$$
+}
+
+@test "fetch-history: push, fetch and pull are recorded" {
+    dolt push origin main
+
+    run dolt sql -q "SELECT operation, remote, status, error FROM dolt_fetch_history" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "push,origin,success," ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM dolt_fetch_history WHERE bytes > 0 AND chunks > 0 AND duration_ms >= 0" -r csv
+    [ "${lines[1]}" = "1" ]
+
+    dolt fetch origin
+    dolt pull origin main
+
+    run dolt sql -q "SELECT operation FROM dolt_fetch_history" -r csv
+    [ "${#lines[@]}" -eq 4 ]
+    [ "${lines[1]}" = "push" ]
+    [ "${lines[2]}" = "fetch" ]
+    [ "${lines[3]}" = "pull" ]
+}
+
+@test "fetch-history: failed transfers are recorded with their error" {
+    dolt push origin main
+
+    run dolt fetch origin missing-branch
+    [ "$status" -ne 0 ]
+
+    run dolt sql -q "SELECT operation, status FROM dolt_fetch_history" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[2]}" = "fetch,failure" ]
+
+    run dolt sql -q "SELECT error FROM dolt_fetch_history WHERE status = 'failure'" -r csv
+    [[ "$output" =~ "missing-branch" ]] || false
+}
+
+@test "fetch-history: sql procedures are recorded" {
+    dolt sql -q "CALL dolt_push('origin', 'main')"
+    dolt sql -q "CALL dolt_fetch('origin')"
+
+    run dolt sql -q "SELECT operation, remote, status FROM dolt_fetch_history" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "push,origin,success" ]
+    [ "${lines[2]}" = "fetch,origin,success" ]
+}
@@ -0,0 +1,103 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (pk BIGINT PRIMARY KEY, v1 BIGINT, v2 VARCHAR(20), UNIQUE INDEX(v1), INDEX(v2));
+INSERT INTO test VALUES (1, 1, 'a'), (2, 2, 'b'), (3, 3, 'a');
+CREATE TABLE keyless (c1 INT, c2 INT, INDEX(c2));
+INSERT INTO keyless VALUES (1, 1), (1, 1), (2, 3);
+SQL
+    dolt add -A
+    dolt commit -m "initial"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "fsck: no problems in a consistent repository" {
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (4, 4, 'c')"
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No problems found." ]] || false
+
+    run dolt fsck --quick
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
+@test "fsck: checks many tables" {
+    for i in $(seq 1 50); do
+        echo "CREATE TABLE t$i (pk INT PRIMARY KEY, v INT, INDEX(v)); INSERT INTO t$i VALUES (1, $i), (2, $i);"
+    done | dolt sql
+    dolt commit -Am "add tables"
+
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
+@test "fsck: checks tags and remote refs with --all-refs" {
+    dolt tag v1
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt push origin main
+
+    run dolt fsck --all-refs
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
+@test "fsck: reports constraint violations" {
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (5, 5, 'd')"
+    dolt commit -am "main"
+    dolt checkout other
+    dolt sql -q "INSERT INTO test VALUES (6, 5, 'e')"
+    dolt commit -am "other"
+    dolt checkout main
+    dolt merge other
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "constraint violation: refs/heads/main (working), table test: 2 unresolved constraint violations" ]] || false
+    [[ "$output" =~ "1 problems found" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_constraint_violations_test"
+    run dolt fsck
+    [ "$status" -eq 0 ]
+}
+
+@test "fsck: reports child rows violating their foreign keys" {
+    dolt sql <<SQL
+CREATE TABLE parent (pk INT PRIMARY KEY);
+CREATE TABLE child (pk INT PRIMARY KEY, v INT, CONSTRAINT fk_v FOREIGN KEY (v) REFERENCES parent(pk));
+INSERT INTO parent VALUES (1);
+SET foreign_key_checks = 0;
+INSERT INTO child VALUES (1, 1), (2, 2), (3, 3), (4, NULL);
+SQL
+    dolt commit -Am "orphaned children"
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "foreign key violation: refs/heads/main (head), table child: 2 rows violate foreign key fk_v referencing table parent" ]] || false
+    [[ "$output" =~ "1 problems found" ]] || false
+
+    run dolt fsck --quick
+    [ "$status" -eq 0 ]
+
+    dolt sql -q "INSERT INTO parent VALUES (2), (3)"
+    dolt commit -am "parents"
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
+@test "fsck: rejects arguments" {
+    run dolt fsck main
+    [ "$status" -ne 0 ]
+}
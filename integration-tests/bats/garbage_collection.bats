@@ -162,6 +162,48 @@ SQL
     [ "$BEFORE" -gt "$AFTER" ]
 }
 
+@test "garbage_collection: new generation gc" {
+    dolt sql <<SQL
+CREATE TABLE test (pk int PRIMARY KEY);
+INSERT INTO test VALUES
+    (1),(2),(3),(4),(5);
+SQL
+    dolt add .
+    dolt commit -m "added values 1 - 5"
+    dolt gc
+
+    # make some garbage after the full gc
+    dolt sql -q "INSERT INTO test VALUES (6),(7),(8);"
+    dolt reset --hard
+
+    # leave data in the working set
+    dolt sql -q "INSERT INTO test VALUES (11),(12),(13),(14),(15);"
+
+    BEFORE=$(du -c .dolt/noms/ | grep total | sed 's/[^0-9]*//g')
+
+    run dolt gc --new-gen
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT sum(pk) FROM test;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "80" ]] || false
+
+    run dolt sql -q "SELECT sum(pk) FROM test AS OF 'HEAD';"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "15" ]] || false
+
+    AFTER=$(du -c .dolt/noms/ | grep total | sed 's/[^0-9]*//g')
+
+    # assert space was reclaimed
+    echo "$BEFORE"
+    echo "$AFTER"
+    [ "$BEFORE" -gt "$AFTER" ]
+
+    run dolt gc --new-gen --shallow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "mutually exclusive" ]] || false
+}
+
 setup_merge() {
     dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c0 TEXT);"
     dolt sql -q "CREATE TABLE quiz (pk int PRIMARY KEY, c0 TEXT);"
@@ -0,0 +1,90 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    mkdir -p .dolt/hooks
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add .
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+write_hook() {
+    printf '#!/bin/sh\n%s\n' "$2" > .dolt/hooks/$1
+    chmod +x .dolt/hooks/$1
+}
+
+@test "hooks: pre-commit hook can block a commit" {
+    write_hook pre-commit 'case "$DOLT_COMMIT_MESSAGE" in WIP*) echo "refusing to commit WIP on $DOLT_BRANCH"; exit 1;; esac'
+
+    run dolt commit -m "WIP: test"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "refusing to commit WIP on main" ]] || false
+    [[ "$output" =~ "pre-commit hook" ]] || false
+
+    run dolt log -n 1
+    [[ ! "$output" =~ "WIP" ]] || false
+
+    dolt commit --no-verify -m "WIP: test"
+    run dolt log -n 1
+    [[ "$output" =~ "WIP: test" ]] || false
+}
+
+@test "hooks: hooks which aren't executable don't run" {
+    write_hook pre-commit 'exit 1'
+    chmod -x .dolt/hooks/pre-commit
+
+    run dolt commit -m "test"
+    [ "$status" -eq 0 ]
+}
+
+@test "hooks: post-commit hook output is shown" {
+    write_hook post-commit 'echo "committed $DOLT_COMMIT"'
+
+    run dolt commit -m "test"
+    [ "$status" -eq 0 ]
+    head=$(dolt sql -q "SELECT hashof('main')" -r csv | tail -n 1)
+    [[ "$output" =~ "committed $head" ]] || false
+}
+
+@test "hooks: pre-push hook can block a push" {
+    mkdir remote
+    dolt remote add origin file://./remote
+    dolt commit -m "test"
+    write_hook pre-push 'echo "pushing to $1 $2"; cat; exit 1'
+
+    run dolt push origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pushing to origin file://" ]] || false
+    [[ "$output" =~ "refs/heads/main "[0-9a-v]{32}" refs/heads/main 00000000000000000000000000000000" ]] || false
+
+    run dolt branch -r
+    [[ ! "$output" =~ "origin/main" ]] || false
+
+    dolt push --no-verify origin main
+    run dolt branch -r
+    [[ "$output" =~ "origin/main" ]] || false
+}
+
+@test "hooks: hooks run for dolt_commit and dolt_push" {
+    mkdir remote
+    dolt remote add origin file://./remote
+    write_hook pre-commit 'echo "no commits allowed"; exit 1'
+
+    run dolt sql -q "CALL dolt_commit('-m', 'test')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no commits allowed" ]] || false
+
+    dolt sql -q "CALL dolt_commit('--no-verify', '-m', 'test')"
+
+    write_hook pre-push 'echo "no pushes allowed"; exit 1'
+    run dolt sql -q "CALL dolt_push('origin', 'main')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no pushes allowed" ]] || false
+
+    dolt sql -q "CALL dolt_push('--no-verify', 'origin', 'main')"
+}
@@ -0,0 +1,105 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    skip_nbf_not_dolt
+
+    dolt sql <<SQL
+CREATE TABLE t (pk int PRIMARY KEY, c int, updated_at int, KEY (updated_at));
+INSERT INTO t VALUES (1, 10, 100), (2, 20, 200);
+SQL
+    dolt add t
+    dolt commit -m "create t"
+    dolt sql -q "INSERT INTO dolt_ignore_columns VALUES ('t', 'updated_at')"
+    dolt add dolt_ignore_columns
+    dolt commit -m "ignore t.updated_at"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "ignore-columns: changes to only ignored columns are hidden" {
+    dolt sql -q "UPDATE t SET updated_at = updated_at + 1"
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt sql -q "SELECT count(*) FROM dolt_status" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "ignore-columns: diff leaves out ignored columns" {
+    dolt sql -q "UPDATE t SET c = 11, updated_at = 101 WHERE pk = 1"
+    dolt sql -q "UPDATE t SET updated_at = 201 WHERE pk = 2"
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:" ]] || false
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "11" ]] || false
+    [[ ! "$output" =~ "updated_at" ]] || false
+    [[ ! "$output" =~ "101" ]] || false
+    [[ ! "$output" =~ "20" ]] || false
+}
+
+@test "ignore-columns: staging keeps the committed values of ignored columns" {
+    dolt sql -q "UPDATE t SET c = 11, updated_at = 101 WHERE pk = 1"
+    dolt sql -q "UPDATE t SET updated_at = 201 WHERE pk = 2"
+    dolt sql -q "INSERT INTO t VALUES (3, 30, 300)"
+
+    dolt add t
+    dolt commit -m "update t"
+
+    run dolt sql -q "SELECT * FROM t AS OF 'HEAD' ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,11,100" ]] || false
+    [[ "$output" =~ "2,20,200" ]] || false
+    [[ "$output" =~ "3,30,300" ]] || false
+
+    run dolt sql -q "SELECT pk FROM t AS OF 'HEAD' WHERE updated_at = 100" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    # the working set keeps its values of the ignored column
+    run dolt sql -q "SELECT * FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,11,101" ]] || false
+    [[ "$output" =~ "2,20,201" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "ignore-columns: table names are patterns" {
+    dolt sql <<SQL
+CREATE TABLE cache_a (pk int PRIMARY KEY, blob_col text);
+INSERT INTO cache_a VALUES (1, 'a');
+INSERT INTO dolt_ignore_columns VALUES ('cache_*', 'blob_col');
+SQL
+    dolt add -A
+    dolt commit -m "add cache_a"
+
+    dolt sql -q "UPDATE cache_a SET blob_col = 'b'"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "ignore-columns: dropping an ignored column is a change" {
+    dolt sql -q "ALTER TABLE t DROP COLUMN updated_at"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:" ]] || false
+}
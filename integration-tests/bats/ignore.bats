@@ -5,7 +5,7 @@ setup() {
     setup_common
 
     dolt sql <<SQL
-INSERT INTO dolt_ignore VALUES
+INSERT INTO dolt_ignore (pattern, ignored) VALUES
   ("ignoreme", true),
   ("dontignore", false),
 
@@ -24,6 +24,7 @@ SQL
 
 teardown() {
     assert_feature_version
+    dolt config --global --unset ignore.patterns > /dev/null 2>&1 || true
     teardown_common
 }
 
@@ -62,8 +63,6 @@ get_conflict_tables() {
 }
 
 @test "ignore: simple matches" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 CREATE TABLE dontignore (pk int);
@@ -81,8 +80,6 @@ SQL
 }
 
 @test "ignore: specific overrides" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE please_ignore (pk int);
 CREATE TABLE do_not_ignore (pk int);
@@ -102,8 +99,6 @@ SQL
 }
 
 @test "ignore: conflict" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE commit_ignore (pk int);
 SQL
@@ -118,8 +113,6 @@ SQL
 }
 
 @test "ignore: question mark" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE test (pk int);
 CREATE TABLE test1 (pk int);
@@ -136,9 +129,109 @@ SQL
     [[ ! -z $(echo "$staged" | grep "test11$") ]] || false
 }
 
-@test "ignore: don't stash ignored tables" {
-    skip_nbf_ld_1
+@test "ignore: regular expression patterns" {
+    dolt sql <<SQL
+INSERT INTO dolt_ignore (pattern, ignored) VALUES
+  ("re:tmp_[0-9]+", true),
+  ("re:log_(a|b)", true);
+CREATE TABLE tmp_123 (pk int);
+CREATE TABLE tmp_abc (pk int);
+CREATE TABLE log_a (pk int);
+CREATE TABLE log_ab (pk int);
+SQL
+
+    dolt add -A
+
+    staged=$(get_staged_tables)
+    ignored=$(get_ignored_tables)
+
+    [[ ! -z $(echo "$ignored" | grep "tmp_123") ]] || false
+    [[ ! -z $(echo "$staged" | grep "tmp_abc") ]] || false
+    [[ ! -z $(echo "$ignored" | grep "log_a") ]] || false
+    [[ ! -z $(echo "$staged" | grep "log_ab") ]] || false
+}
+
+@test "ignore: invalid regular expression patterns are rejected" {
+    run dolt sql -q "INSERT INTO dolt_ignore (pattern, ignored) VALUES ('re:tmp_(', true)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid dolt_ignore pattern 're:tmp_('" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_ignore WHERE pattern = 're:tmp_('" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "ignore: regular expression patterns conflict with opposite patterns" {
+    dolt sql <<SQL
+INSERT INTO dolt_ignore (pattern, ignored) VALUES ("re:also_.*", false);
+CREATE TABLE also_ignore (pk int);
+SQL
+
+    run dolt add -A
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the table also_ignore matches conflicting patterns in dolt_ignore" ]] || false
+    [[ "$output" =~ "not ignored: re:also_.*" ]] || false
+}
+
+@test "ignore: negated patterns" {
+    dolt sql <<SQL
+INSERT INTO dolt_ignore (pattern, ignored) VALUES
+  ("imp*", true),
+  ("!important_*", true),
+  ("!commit_ignore", true);
+CREATE TABLE important_data (pk int);
+CREATE TABLE impermanent (pk int);
+CREATE TABLE commit_ignore (pk int);
+SQL
+
+    dolt add -A
+
+    ignored=$(get_ignored_tables)
+    staged=$(get_staged_tables)
+
+    [[ ! -z $(echo "$staged" | grep "important_data") ]] || false
+    [[ ! -z $(echo "$ignored" | grep "impermanent") ]] || false
+    # the negated pattern is applied after the conflicting patterns matching the table
+    [[ ! -z $(echo "$staged" | grep "commit_ignore") ]] || false
+}
+
+@test "ignore: patterns set in the config" {
+    dolt config --global --add ignore.patterns "scratch_*, tmp_*"
+    dolt config --local --add ignore.patterns "!tmp_keep"
+    dolt branch other
+    dolt config --local --add ignore.branch.other "!scratch_*"
 
+    dolt sql <<SQL
+CREATE TABLE scratch_t (pk int);
+CREATE TABLE tmp_t (pk int);
+CREATE TABLE tmp_keep (pk int);
+SQL
+
+    dolt add -A
+
+    ignored=$(get_ignored_tables)
+    staged=$(get_staged_tables)
+
+    [[ ! -z $(echo "$ignored" | grep "scratch_t") ]] || false
+    [[ ! -z $(echo "$ignored" | grep "tmp_t") ]] || false
+    # the database config overrides the global config
+    [[ ! -z $(echo "$staged" | grep "tmp_keep") ]] || false
+
+    # the dolt_ignore table overrides the config
+    dolt sql -q "INSERT INTO dolt_ignore (pattern, ignored) VALUES ('tmp_t', false)"
+    dolt add -A
+    staged=$(get_staged_tables)
+    [[ ! -z $(echo "$staged" | grep "tmp_t") ]] || false
+
+    # the branch config overrides the database and global configs
+    dolt commit -m "commit"
+    dolt checkout other
+    dolt add -A
+    staged=$(get_staged_tables)
+    [[ ! -z $(echo "$staged" | grep "scratch_t") ]] || false
+}
+
+@test "ignore: don't stash ignored tables" {
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 SQL
@@ -150,8 +243,6 @@ SQL
 }
 
 @test "ignore: error when trying to stash table with dolt_ignore conflict" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE commit_ignore (pk int);
 SQL
@@ -165,8 +256,6 @@ SQL
 }
 
 @test "ignore: stash ignored and untracked tables when --all is passed" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 CREATE TABLE dontignore (pk int);
@@ -190,8 +279,6 @@ SQL
 }
 
 @test "ignore: stash table with dolt_ignore conflict when --all is passed" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE commit_ignore (pk int);
 SQL
@@ -211,8 +298,6 @@ SQL
 }
 
 @test "ignore: allow staging ignored files if 'add --force' is supplied" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 SQL
@@ -225,8 +310,6 @@ SQL
 }
 
 @test "ignore: don't auto-stage ignored files" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 CREATE TABLE nomatch (pk int);
@@ -243,8 +326,6 @@ SQL
 }
 
 @test "ignore: dolt status doesn't show ignored files when --ignored is not supplied" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 CREATE TABLE nomatch (pk int);
@@ -261,8 +342,6 @@ SQL
 }
 
 @test "ignore: dolt status shows ignored files when --ignored is not supplied" {
-    skip_nbf_ld_1
-
     dolt sql <<SQL
 CREATE TABLE ignoreme (pk int);
 CREATE TABLE nomatch (pk int);
@@ -276,4 +355,92 @@ SQL
     [[ "$output" =~ "Ignored tables" ]] || false
     [[ "$output" =~ "ignoreme" ]] || false
 
-}
\ No newline at end of file
+}
+setup_ignored_table_merge() {
+    dolt sql <<SQL
+INSERT INTO dolt_ignore VALUES ("scratch_*", true, "$1");
+CREATE TABLE scratch_t (pk int primary key, c int);
+INSERT INTO scratch_t VALUES (1, 1), (2, 2);
+SQL
+    dolt add -A --force
+    dolt commit -m "add scratch_t"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE scratch_t SET c = 10 WHERE pk = 1"
+    dolt sql -q "INSERT INTO scratch_t VALUES (3, 30)"
+    dolt add --force scratch_t
+    dolt commit -m "change scratch_t on other"
+
+    dolt checkout main
+    dolt sql -q "UPDATE scratch_t SET c = 100 WHERE pk = 1"
+    dolt sql -q "INSERT INTO scratch_t VALUES (4, 400)"
+    dolt add --force scratch_t
+    dolt commit -m "change scratch_t on main"
+}
+
+@test "ignore: merge policy exclude keeps our version of ignored tables" {
+    skip_nbf_ld_1
+    setup_ignored_table_merge exclude
+
+    run dolt merge other -m "merge other"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT pk, c FROM scratch_t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,100" ]] || false
+    [[ "$output" =~ "4,400" ]] || false
+    [[ ! "$output" =~ "3,30" ]] || false
+}
+
+@test "ignore: merge policy theirs resolves conflicts in ignored tables with their version" {
+    skip_nbf_ld_1
+    setup_ignored_table_merge theirs
+
+    run dolt merge other -m "merge other"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT pk, c FROM scratch_t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3,30" ]] || false
+    [[ "$output" =~ "4,400" ]] || false
+
+    run dolt sql -q "SELECT c FROM scratch_t WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "10" ]
+}
+
+@test "ignore: merge policy ours resolves conflicts in ignored tables with our version" {
+    skip_nbf_ld_1
+    setup_ignored_table_merge ours
+
+    run dolt merge other -m "merge other"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT pk, c FROM scratch_t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,100" ]] || false
+    [[ "$output" =~ "3,30" ]] || false
+    [[ "$output" =~ "4,400" ]] || false
+}
+
+@test "ignore: ignored tables without a merge policy can conflict" {
+    skip_nbf_ld_1
+    setup_ignored_table_merge ""
+
+    run dolt merge other -m "merge other"
+    [[ "$output" =~ "CONFLICT" ]] || false
+}
+
+@test "ignore: invalid merge policy" {
+    run dolt sql -q "INSERT INTO dolt_ignore VALUES ('scratch_*', true, 'mine')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid dolt_ignore merge policy 'mine'" ]] || false
+
+    dolt sql -q "INSERT INTO dolt_ignore VALUES ('scratch_*', true, 'THEIRS')"
+    run dolt sql -q "SELECT merge_policy FROM dolt_ignore WHERE pattern = 'scratch_*'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "theirs" ]] || false
+}
@@ -1296,3 +1296,63 @@ DELIM
     [ $status -eq 0 ]
     [[ "$output" =~ '1,0,0,0,0,0,0,0,0,0,0,0000-00-00,00:00:00,0000-00-00 00:00:00,0000-00-00 00:00:00,0,first,""' ]] || false
 }
+
+@test "import-update-tables: --skip-unchanged skips rows identical to the existing rows" {
+    dolt sql < 1pk5col-ints-sch.sql
+    dolt table import -u test 1pk5col-ints.csv
+    dolt commit -Am "import rows"
+
+    run dolt table import -u --skip-unchanged test 1pk5col-ints.csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 0, Modifications: 0, Had No Effect: 2" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    cat <<DELIM > overlapping.csv
+pk,c1,c2,c3,c4,c5
+0,1,2,3,4,5
+1,1,2,3,4,6
+2,1,2,3,4,5
+DELIM
+
+    run dolt table import -u --skip-unchanged test overlapping.csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 1, Modifications: 1, Had No Effect: 1" ]] || false
+
+    run dolt sql -r csv -q "select pk, c5 from test order by pk"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0,5" ]] || false
+    [[ "$output" =~ "1,6" ]] || false
+    [[ "$output" =~ "2,5" ]] || false
+}
+
+@test "import-update-tables: --skip-unchanged compares only the imported columns" {
+    dolt sql < 1pk5col-ints-sch.sql
+    dolt table import -u test 1pk5col-ints.csv
+    dolt commit -Am "import rows"
+
+    cat <<DELIM > partial.csv
+pk,c5
+0,5
+1,7
+DELIM
+
+    run dolt table import -u --skip-unchanged test partial.csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 0, Modifications: 1, Had No Effect: 1" ]] || false
+
+    run dolt sql -r csv -q "select * from test order by pk"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0,1,2,3,4,5" ]] || false
+    [[ "$output" =~ "1,1,2,3,4,7" ]] || false
+}
+
+@test "import-update-tables: --skip-unchanged requires --update-table" {
+    dolt sql < 1pk5col-ints-sch.sql
+
+    run dolt table import -r --skip-unchanged test 1pk5col-ints.csv
+    [ $status -eq 1 ]
+    [[ "$output" =~ "skip-unchanged is only supported for update operations" ]] || false
+}
@@ -45,6 +45,190 @@ teardown() {
     [[ "${#lines[@]}" == "2" ]] || false
 }
 
+@test "index: rebuild every index of a table" {
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, col1 int UNIQUE, col2 int, INDEX (col2));"
+    dolt sql -q "INSERT INTO t VALUES (1, 100, 10), (2, 200, 20);"
+
+    run dolt index rebuild t
+    [ "$status" -eq "0" ]
+
+    run dolt index cat t col2 -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[0]}" =~ "col2,pk" ]] || false
+    [[ "${lines[1]}" =~ "10,1" ]] || false
+    [[ "${lines[2]}" =~ "20,2" ]] || false
+
+    run dolt index rebuild t nonexistent
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "the index \`nonexistent\` does not exist" ]] || false
+}
+
+@test "index: dolt_index_rebuild procedure" {
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, col1 int UNIQUE, col2 int, INDEX (col2));"
+    dolt sql -q "INSERT INTO t VALUES (1, 100, 10), (2, 200, 20);"
+
+    dolt sql -q "CALL dolt_index_rebuild('t', 'col1');"
+    dolt sql -q "CALL dolt_index_rebuild('T');"
+
+    run dolt sql -q "SELECT pk FROM t WHERE col2 = 20" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "2" ]] || false
+
+    run dolt index cat t col1 -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" =~ "100,1" ]] || false
+    [[ "${lines[2]}" =~ "200,2" ]] || false
+
+    run dolt sql -q "CALL dolt_index_rebuild('t', 'nonexistent');"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "does not exist" ]] || false
+
+    run dolt sql -q "CALL dolt_index_rebuild();"
+    [ "$status" -eq "1" ]
+}
+
+@test "index: dolt_create_partial_index maintains only matching rows" {
+    skip_nbf_not_dolt
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, flag int, v int);"
+    dolt sql -q "INSERT INTO t VALUES (1, 0, 10), (2, 1, 20), (3, 1, 30), (4, NULL, 40);"
+    dolt sql -q "CALL dolt_create_partial_index('--where', 'flag = 1', 't', 'flagged', 'flag');"
+
+    run dolt index ls t
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ 'flagged(flag) WHERE `flag` = '"'1'" ]] || false
+
+    run dolt index cat t flagged -r csv
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[1]}" = "1,2" ]] || false
+    [[ "${lines[2]}" = "1,3" ]] || false
+
+    dolt sql -q "INSERT INTO t VALUES (5, 1, 50), (6, 2, 60);"
+    dolt sql -q "UPDATE t SET flag = 0 WHERE pk = 2;"
+    dolt sql -q "UPDATE t SET flag = 1 WHERE pk = 1;"
+    dolt sql -q "DELETE FROM t WHERE pk = 3;"
+
+    run dolt index cat t flagged -r csv
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[1]}" = "1,1" ]] || false
+    [[ "${lines[2]}" = "1,5" ]] || false
+
+    run dolt sql -q "EXPLAIN SELECT pk FROM t WHERE flag = 1"
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "IndexedTableAccess" ]] || false
+
+    run dolt sql -q "EXPLAIN SELECT pk FROM t WHERE flag = 2"
+    [ "$status" -eq "0" ]
+    ! [[ "$output" =~ "IndexedTableAccess" ]] || false
+
+    run dolt sql -q "SELECT pk FROM t WHERE flag = 1 ORDER BY pk" -r csv
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[1]}" = "1" ]] || false
+    [[ "${lines[2]}" = "5" ]] || false
+
+    run dolt sql -q "SELECT pk FROM t WHERE flag >= 0 ORDER BY pk" -r csv
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 5 ]
+
+    run dolt fsck
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
+@test "index: unique partial index only enforces uniqueness among matching rows" {
+    skip_nbf_not_dolt
+    dolt sql -q "CREATE TABLE users (id int PRIMARY KEY, email varchar(100), deleted tinyint);"
+    dolt sql -q "INSERT INTO users VALUES (1, 'a@example.com', 1), (2, 'a@example.com', 0);"
+    dolt sql -q "CALL dolt_create_partial_index('--unique', '--where', 'deleted = 0', 'users', 'live_email', 'email', 'deleted');"
+
+    dolt sql -q "INSERT INTO users VALUES (3, 'a@example.com', 1);"
+    run dolt sql -q "INSERT INTO users VALUES (4, 'a@example.com', 0);"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "duplicate unique key" ]] || false
+
+    run dolt sql -q "UPDATE users SET deleted = 0 WHERE id = 3;"
+    [ "$status" -eq "1" ]
+
+    dolt sql -q "UPDATE users SET deleted = 1 WHERE id = 2;"
+    dolt sql -q "UPDATE users SET deleted = 0 WHERE id = 3;"
+    run dolt sql -q "SELECT id FROM users WHERE email = 'a@example.com' AND deleted = 0" -r csv
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[1]}" = "3" ]] || false
+
+    run dolt sql -q "INSERT INTO users VALUES (5, 'b@example.com', 0), (6, 'b@example.com', 0);"
+    [ "$status" -eq "1" ]
+}
+
+@test "index: dolt_create_partial_index rejects invalid predicates" {
+    skip_nbf_not_dolt
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, a int, b int);"
+
+    run dolt sql -q "CALL dolt_create_partial_index('t', 'idx', 'a');"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "requires a predicate" ]] || false
+
+    run dolt sql -q "CALL dolt_create_partial_index('--where', 'b = 1', 't', 'idx', 'a');"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "may only reference the columns of their index" ]] || false
+
+    run dolt sql -q "CALL dolt_create_partial_index('--where', 'a = 1 OR a = 2', 't', 'idx', 'a');"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "unsupported index predicate" ]] || false
+
+    run dolt sql -q "CALL dolt_create_partial_index('--where', 'a = b', 't', 'idx', 'a', 'b');"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "must compare a column to a literal" ]] || false
+}
+
+@test "index: partial indexes are not used for foreign keys" {
+    skip_nbf_not_dolt
+    dolt sql -q "CREATE TABLE parent (pk int PRIMARY KEY);"
+    dolt sql -q "CREATE TABLE child (pk int PRIMARY KEY, parent_v int);"
+    dolt sql -q "CALL dolt_create_partial_index('--where', 'parent_v IS NOT NULL', 'child', 'has_parent', 'parent_v');"
+
+    run dolt sql -q "ALTER TABLE child ADD FOREIGN KEY (parent_v) REFERENCES parent (pk);"
+    [ "$status" -eq "0" ]
+
+    run dolt index ls child
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "has_parent(parent_v) WHERE" ]] || false
+    [[ "$output" =~ "parent_v(parent_v)" ]] || false
+}
+
+@test "index: partial indexes merge" {
+    skip_nbf_not_dolt
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, flag int);"
+    dolt sql -q "INSERT INTO t VALUES (1, 1), (2, 0);"
+    dolt commit -Am "create t"
+
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO t VALUES (3, 1), (4, 0);"
+    dolt sql -q "UPDATE t SET flag = 1 WHERE pk = 2;"
+    dolt commit -am "other rows"
+
+    dolt checkout main
+    dolt sql -q "CALL dolt_create_partial_index('--where', 'flag = 1', 't', 'flagged', 'flag');"
+    dolt sql -q "INSERT INTO t VALUES (5, 1);"
+    dolt commit -am "partial index"
+
+    dolt merge other -m "merge other"
+
+    run dolt index cat t flagged -r csv
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 5 ]
+    [[ "${lines[1]}" = "1,1" ]] || false
+    [[ "${lines[2]}" = "1,2" ]] || false
+    [[ "${lines[3]}" = "1,3" ]] || false
+    [[ "${lines[4]}" = "1,5" ]] || false
+
+    run dolt fsck
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "No problems found." ]] || false
+}
+
 @test "index: Permissive index names" {
     dolt sql <<SQL
 CREATE TABLE test(
@@ -305,6 +305,73 @@ teardown() {
     [[ $output =~ "commit dolt" ]] || [[ $output =~ "commit do1t" ]] || [[ $output =~ "commit d0lt" ]] || [[ $output =~ "commit d01t" ]] || false
 }
 
+@test "init: --template copies the tables of a template database" {
+    set_dolt_user "baz", "baz@bash.com"
+
+    mkdir template && cd template
+    dolt init
+    dolt sql <<SQL
+CREATE TABLE people (id INT PRIMARY KEY, name VARCHAR(20));
+INSERT INTO dolt_ignore (pattern, ignored) VALUES ('tmp_*', true);
+SQL
+    dolt sql --save "count people" -q "SELECT count(*) FROM people"
+    dolt add -A
+    dolt commit -m "template tables"
+    cd ..
+
+    mkdir new_db && cd new_db
+    run dolt init --template ../template
+    [ $status -eq 0 ]
+
+    run dolt ls --all
+    [ $status -eq 0 ]
+    [[ "$output" =~ "people" ]] || false
+    [[ "$output" =~ "dolt_query_catalog" ]] || false
+
+    run dolt sql -q "SELECT pattern FROM dolt_ignore" -r csv
+    [[ "$output" =~ "tmp_*" ]] || false
+
+    run dolt sql -x "count people" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    # the template's history isn't copied
+    run dolt log --oneline
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "Initialize data repository from template ../template" ]] || false
+    [[ ! "$output" =~ "template tables" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "init: --template accepts a remote url" {
+    set_dolt_user "baz", "baz@bash.com"
+
+    mkdir template && cd template
+    dolt init
+    dolt sql -q "CREATE TABLE t (pk INT PRIMARY KEY)"
+    dolt commit -Am "add t"
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+
+    mkdir new_db && cd new_db
+    run dolt init --template file://../remote
+    [ $status -eq 0 ]
+
+    run dolt ls
+    [[ "$output" =~ "t" ]] || false
+}
+
+@test "init: --template fails for a missing template" {
+    set_dolt_user "baz", "baz@bash.com"
+
+    run dolt init --template /no/such/template
+    [ $status -ne 0 ]
+}
+
 assert_valid_repository () {
   run dolt log
   [ "$status" -eq 0 ]
@@ -133,6 +133,48 @@ SQL
     [[ "${lines[1]}" =~ "9,9,9" ]] || false
 }
 
+@test "merge: --continue stages and commits a merge once its conflicts are resolved" {
+    dolt branch other
+
+    dolt sql -q "INSERT INTO test1 VALUES (0,10,10);"
+    dolt commit -am "added rows to test1 on main"
+
+    dolt checkout other
+    dolt sql -q "INSERT INTO test1 VALUES (0,20,20);"
+    dolt sql -q "INSERT INTO test2 VALUES (1,1,1);"
+    dolt commit -am "added rows to test1 and test2 on other"
+
+    dolt checkout main
+    run dolt merge other
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT table_name, status, conflicts FROM dolt_merge_state" -r csv
+    log_status_eq 0
+    [[ "${lines[1]}" = "test1,conflicts,1" ]] || false
+    [[ "${lines[2]}" = "test2,merged,0" ]] || false
+
+    run dolt merge --continue
+    log_status_eq 1
+    [[ "$output" =~ "test1" ]] || false
+
+    dolt conflicts resolve --ours test1
+    dolt merge --continue -m "merged other"
+
+    run dolt log -n 1
+    [[ "$output" =~ "merged other" ]] || false
+    [[ "$output" =~ "Merge:" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt sql -q "SELECT * FROM test2" -r csv
+    [[ "${lines[1]}" = "1,1,1" ]] || false
+
+    run dolt merge --continue
+    log_status_eq 1
+    [[ "$output" =~ "There is no merge to continue" ]] || false
+}
+
 @test "merge: --abort leaves clean working, staging roots" {
     dolt branch other
 
@@ -1033,3 +1075,124 @@ SQL
     [ $status -eq 0 ]
     [[ "$output" =~ "2 tables changed, 3 rows added(+), 1 rows modified(*), 1 rows deleted(-)" ]] || false
 }
+
+@test "merge: --fk-cascade deletes child rows of parent rows deleted by the merge" {
+    dolt sql <<SQL
+CREATE table parent (pk int PRIMARY KEY, col1 int);
+CREATE table child (pk int PRIMARY KEY, parent_fk int, FOREIGN KEY (parent_fk) REFERENCES parent(pk) ON DELETE CASCADE);
+CREATE table grandchild (pk int PRIMARY KEY, child_fk int, FOREIGN KEY (child_fk) REFERENCES child(pk) ON DELETE CASCADE);
+INSERT INTO parent VALUES (1, 1), (2, 2);
+INSERT INTO child VALUES (1, 1), (2, 2);
+INSERT INTO grandchild VALUES (1, 1);
+SQL
+    dolt commit -Am "create tables with data"
+    dolt branch right
+    dolt sql -q "DELETE FROM parent where pk = 1;"
+    dolt commit -am "delete pk = 1 from left"
+
+    dolt checkout right
+    dolt sql -q "INSERT INTO child VALUES (3, 1);"
+    dolt sql -q "INSERT INTO grandchild VALUES (3, 3);"
+    dolt commit -am "add children of 1 to right"
+
+    dolt checkout main
+    run dolt merge right --fk-cascade -m "merge right"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CASCADE (foreign key): 1 rows deleted from child" ]] || false
+    [[ "$output" =~ "CASCADE (foreign key): 1 rows deleted from grandchild" ]] || false
+    [[ ! "$output" =~ "CONSTRAINT VIOLATION" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk FROM child ORDER BY pk;"
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[1]}" = "2" ]] || false
+    run dolt sql -r csv -q "SELECT count(*) FROM grandchild;"
+    [[ "${lines[1]}" = "0" ]] || false
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_constraint_violations;"
+    [[ "${lines[1]}" = "0" ]] || false
+}
+
+@test "merge: --fk-cascade sets child foreign key columns to NULL for ON DELETE SET NULL" {
+    dolt sql <<SQL
+CREATE table parent (pk int PRIMARY KEY, col1 int);
+CREATE table child (pk int PRIMARY KEY, parent_fk int, FOREIGN KEY (parent_fk) REFERENCES parent(pk) ON DELETE SET NULL);
+INSERT INTO parent VALUES (1, 1);
+SQL
+    dolt commit -Am "create tables with data"
+    dolt branch right
+    dolt sql -q "DELETE FROM parent where pk = 1;"
+    dolt commit -am "delete pk = 1 from left"
+
+    dolt checkout right
+    dolt sql -q "INSERT INTO child VALUES (1, 1);"
+    dolt commit -am "add child of 1 to right"
+
+    dolt checkout main
+    run dolt sql -r csv -q "CALL dolt_merge('right', '--fk-cascade');"
+    [ $status -eq 0 ]
+
+    run dolt sql -r csv -q "SELECT pk, parent_fk FROM child;"
+    [[ "${lines[1]}" = "1," ]] || false
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_constraint_violations;"
+    [[ "${lines[1]}" = "0" ]] || false
+}
+
+@test "merge: without --fk-cascade child rows of deleted parent rows are violations" {
+    dolt sql <<SQL
+CREATE table parent (pk int PRIMARY KEY, col1 int);
+CREATE table child (pk int PRIMARY KEY, parent_fk int, FOREIGN KEY (parent_fk) REFERENCES parent(pk) ON DELETE CASCADE);
+INSERT INTO parent VALUES (1, 1);
+SQL
+    dolt commit -Am "create tables with data"
+    dolt branch right
+    dolt sql -q "DELETE FROM parent where pk = 1;"
+    dolt commit -am "delete pk = 1 from left"
+
+    dolt checkout right
+    dolt sql -q "INSERT INTO child VALUES (1, 1);"
+    dolt commit -am "add child of 1 to right"
+
+    dolt checkout main
+    run dolt merge right -m "merge right"
+    [[ "$output" =~ "CONSTRAINT VIOLATION (content): Merge created constraint violation in child" ]] || false
+
+    run dolt sql -r csv -q "SELECT violation_type, pk, parent_fk from dolt_constraint_violations_child;"
+    [[ "${lines[1]}" = "foreign key,1,1" ]] || false
+}
+
+@test "merge: column comments changed on either side are merged" {
+    dolt sql -q "ALTER TABLE test1 MODIFY c1 int COMMENT 'base comment'"
+    dolt commit -am "comment c1"
+    dolt branch right
+
+    dolt sql -q "ALTER TABLE test1 MODIFY c2 int COMMENT 'left comment'"
+    dolt commit -am "comment c2 on left"
+
+    dolt checkout right
+    dolt sql -q "ALTER TABLE test1 MODIFY c1 int COMMENT 'right comment'; ALTER TABLE test1 MODIFY pk int COMMENT 'key'"
+    dolt commit -am "comment c1 and pk on right"
+
+    dolt checkout main
+    dolt merge right -m "merge right"
+    run dolt schema show test1
+    [[ "$output" =~ "\`pk\` int NOT NULL COMMENT 'key'" ]] || false
+    [[ "$output" =~ "\`c1\` int COMMENT 'right comment'" ]] || false
+    [[ "$output" =~ "\`c2\` int COMMENT 'left comment'" ]] || false
+}
+
+@test "merge: conflicting column comment changes are schema conflicts" {
+    dolt branch right
+    dolt sql -q "ALTER TABLE test1 MODIFY c1 int COMMENT 'left comment'"
+    dolt commit -am "comment c1 on left"
+
+    dolt checkout right
+    dolt sql -q "ALTER TABLE test1 MODIFY c1 int COMMENT 'right comment'"
+    dolt commit -am "comment c1 on right"
+
+    dolt checkout main
+    run dolt merge right -m "merge right"
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -r csv -q "SELECT table_name, description FROM dolt_schema_conflicts"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test1,different comments for column 'c1': 'left comment' and 'right comment'" ]] || false
+}
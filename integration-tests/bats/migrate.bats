@@ -347,3 +347,94 @@ SQL
    [[ $output =~ "CONSTRAINT \`j_chk\` CHECK ((\`j\` = 0))" ]] || false
    [[ $output =~ ") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci" ]] || false
 }
+
+@test "migrate: clone --migrate converts an old format remote" {
+    dolt sql <<SQL
+CREATE TABLE test (pk int primary key, c0 int, c1 int);
+INSERT INTO test VALUES (0,0,0), (1,1,1);
+CALL dadd('-A');
+CALL dcommit('-am', 'added table test');
+SQL
+    CHECKSUM=$(checksum_table test head)
+    mkdir ../remote
+    dolt remote add origin file://../remote
+    dolt push origin main
+
+    cd ..
+    dolt clone --migrate file://./remote migrated
+    cd migrated
+
+    [[ $(cat ./.dolt/noms/manifest | cut -f 2 -d :) = "$TARGET_NBF" ]] || false
+
+    run checksum_table test head
+    [[ "$output" =~ "$CHECKSUM" ]] || false
+
+    run dolt branch -a
+    [ $status -eq 0 ]
+    [[ "$output" =~ "main" ]] || false
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "migrate: reports progress for each migrated commit" {
+    dolt sql <<SQL
+CREATE TABLE test (pk int primary key, c0 int);
+INSERT INTO test VALUES (0,0);
+CALL dadd('-A');
+CALL dcommit('-am', 'added table test');
+INSERT INTO test VALUES (1,1);
+CALL dcommit('-am', 'added a row');
+SQL
+
+    run dolt migrate
+    [ $status -eq 0 ]
+    [[ "$output" =~ "commits done: 1, tables done: 1" ]] || false
+    [[ "$output" =~ "commits done: 2, tables done: 2" ]] || false
+    [[ "$output" =~ "chunks written:" ]] || false
+    [ ! -f .dolt/migration_state.json ]
+}
+
+@test "migrate: migrate one branch at a time" {
+    dolt sql <<SQL
+CREATE TABLE test (pk int primary key, c0 int);
+INSERT INTO test VALUES (0,0);
+CALL dadd('-A');
+CALL dcommit('-am', 'added table test');
+CALL dbranch('other');
+INSERT INTO test VALUES (1,1);
+CALL dcommit('-am', 'added a row on main');
+SQL
+    dolt checkout other
+    dolt sql -q "INSERT INTO test VALUES (2,2)"
+    dolt commit -am "added a row on other"
+    dolt checkout main
+    CHECKSUM_MAIN=$(checksum_table test main)
+    CHECKSUM_OTHER=$(checksum_table test other)
+
+    run dolt migrate --branch other
+    [ $status -eq 0 ]
+    [[ "$output" =~ "migrated branches other" ]] || false
+    [[ $(cat ./.dolt/noms/manifest | cut -f 2 -d :) = "__LD_1__" ]] || false
+    [ -f .dolt/migration_state.json ]
+
+    run dolt migrate --branch missing
+    [ $status -ne 0 ]
+    [[ "$output" =~ "branch 'missing' not found" ]] || false
+
+    # commits migrated with the first branch are not migrated again
+    run dolt migrate
+    [ $status -eq 0 ]
+    [[ "$output" =~ "resuming migration" ]] || false
+    [[ "$output" =~ "commits done: 1," ]] || false
+    [[ ! "$output" =~ "commits done: 2," ]] || false
+    [ ! -f .dolt/migration_state.json ]
+
+    [[ $(cat ./.dolt/noms/manifest | cut -f 2 -d :) = "$TARGET_NBF" ]] || false
+    run checksum_table test main
+    [[ "$output" =~ "$CHECKSUM_MAIN" ]] || false
+    run checksum_table test other
+    [[ "$output" =~ "$CHECKSUM_OTHER" ]] || false
+}
@@ -50,6 +50,7 @@ teardown() {
     [[ "$output" =~ "login - Login to a dolt remote host." ]] || false
     [[ "$output" =~ "creds - Commands for managing credentials." ]] || false
     [[ "$output" =~ "ls - List tables in the working set." ]] || false
+    [[ "$output" =~ "lint - Check the database for common problems." ]] || false
     [[ "$output" =~ "schema - Commands for showing and importing table schemas." ]] || false
     [[ "$output" =~ "table - Commands for copying, renaming, deleting, and exporting tables." ]] || false
     [[ "$output" =~ "tag - Create, list, delete tags." ]] || false
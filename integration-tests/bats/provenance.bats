@@ -0,0 +1,108 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    skip_nbf_not_dolt
+
+    dolt sql <<SQL
+CREATE TABLE a (pk int PRIMARY KEY);
+CREATE TABLE b (pk int PRIMARY KEY);
+SQL
+    dolt add .
+    dolt commit -m "create tables"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "provenance: dolt commit records provenance trailers" {
+    dolt sql -q "INSERT INTO a VALUES (1)"
+    dolt commit -am "add a row" --source-url https://example.com/a.csv --license CC-BY-4.0 --collected-at 2023-05-01
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Source-URL: https://example.com/a.csv" ]] || false
+    [[ "$output" =~ "License: CC-BY-4.0" ]] || false
+    [[ "$output" =~ "Collected-At: 2023-05-01" ]] || false
+
+    run dolt commit --allow-empty -m "bad date" --collected-at yesterday
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--collected-at 'yesterday' is not in a supported format" ]] || false
+}
+
+@test "provenance: dolt_commit records provenance trailers" {
+    dolt sql -q "CALL dolt_commit('--allow-empty', '-m', 'empty', '--license', 'MIT')"
+
+    run dolt sql -q "SELECT message FROM dolt_log LIMIT 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "License: MIT" ]] || false
+
+    # amending keeps the message and replaces its trailers
+    dolt sql -q "CALL dolt_commit('--amend', '--license', 'Apache-2.0')"
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "empty" ]] || false
+    [[ "$output" =~ "License: Apache-2.0" ]] || false
+    [[ ! "$output" =~ "License: MIT" ]] || false
+}
+
+@test "provenance: dolt_provenance aggregates table and commit provenance" {
+    run dolt sql -q "SELECT table_name, license, commit_hash FROM dolt_provenance" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "a,," ]] || false
+    [[ "$output" =~ "b,," ]] || false
+
+    dolt commit --allow-empty -m "license" --license CC-BY-4.0 --source-url https://example.com
+    head=$(dolt sql -q "SELECT HASHOF('HEAD')" -r csv | tail -n 1)
+    dolt sql -q "INSERT INTO dolt_table_provenance (table_name, license) VALUES ('b', 'MIT')"
+
+    run dolt sql -q "SELECT * FROM dolt_provenance ORDER BY table_name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "a,https://example.com,CC-BY-4.0,,$head" ]] || false
+    # the row of a table in dolt_table_provenance wins as a whole
+    [[ "$output" =~ "b,,MIT,," ]] || false
+
+    run dolt sql -q "SELECT table_name FROM dolt_provenance AS OF 'HEAD~1' WHERE license IS NOT NULL" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+}
+
+@test "provenance: push.requirelicense blocks pushes of unlicensed tables" {
+    mkdir ../remote
+    dolt remote add origin file://../remote
+    dolt config --local --add push.requirelicense origin
+
+    run dolt push origin main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "pushes to 'origin' require a license for every table" ]] || false
+    [[ "$output" =~ "these tables have none: a, b" ]] || false
+
+    run dolt sql -q "CALL dolt_push('origin', 'main')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "these tables have none: a, b" ]] || false
+
+    dolt sql -q "INSERT INTO dolt_table_provenance (table_name, license) VALUES ('a', 'MIT')"
+    dolt add .
+    dolt commit -m "license a"
+    run dolt push origin main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "these tables have none: b" ]] || false
+
+    dolt commit --allow-empty -m "license everything" --license CC0-1.0
+    dolt push origin main
+
+    # only the configured remotes are affected
+    mkdir ../other
+    dolt remote add other file://../other
+    dolt config --local --add push.requirelicense other
+    dolt sql -q "INSERT INTO dolt_table_provenance (table_name, source_url) VALUES ('b', 'https://example.com')"
+    dolt add .
+    dolt commit -m "b has no license"
+    dolt push origin main
+    run dolt push other main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "these tables have none: b" ]] || false
+}
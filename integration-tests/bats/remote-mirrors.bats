@@ -0,0 +1,99 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    TMPDIRS=$(pwd)/tmpdirs
+    mkdir -p $TMPDIRS/{repo,primary,mirror}
+    cd $TMPDIRS/repo
+    dolt init
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt commit -Am "create table"
+    dolt remote add origin file://$TMPDIRS/primary
+    dolt remote add-mirror origin file://$TMPDIRS/mirror
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "remote-mirrors: add-mirror and remove-mirror" {
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin file://$TMPDIRS/mirror (mirror)" ]] || false
+
+    run dolt remote add-mirror origin file://$TMPDIRS/mirror
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "is already a mirror of remote 'origin'" ]] || false
+
+    run dolt remote add-mirror nope file://$TMPDIRS/mirror
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote: 'nope'" ]] || false
+
+    dolt remote remove-mirror origin file://$TMPDIRS/mirror
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "(mirror)" ]] || false
+
+    run dolt remote remove-mirror origin file://$TMPDIRS/mirror
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "is not a mirror of remote 'origin'" ]] || false
+}
+
+@test "remote-mirrors: pushes are copied to mirrors and pulls fail over to them" {
+    dolt push origin main
+    cd $TMPDIRS
+    dolt clone file://$TMPDIRS/mirror clone
+    cd clone
+    dolt remote remove origin
+    dolt remote add origin file://$TMPDIRS/primary
+    dolt remote add-mirror origin file://$TMPDIRS/mirror
+
+    cd $TMPDIRS/repo
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "insert 1"
+    dolt sql -q "call dolt_push('origin', 'main')"
+
+    mv $TMPDIRS/primary $TMPDIRS/primary.down
+    cd $TMPDIRS/clone
+    dolt pull origin
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    # pushes are only made to the remote itself
+    cd $TMPDIRS/repo
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "insert 2"
+    run dolt push origin main
+    [ "$status" -eq 1 ]
+}
+
+@test "remote-mirrors: a mirror that can't be pushed to only warns" {
+    mkdir $TMPDIRS/corrupt
+    echo garbage > $TMPDIRS/corrupt/manifest
+    dolt remote add-mirror origin file://$TMPDIRS/corrupt
+    run dolt push origin main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "warning: failed to push to mirror 'file://$TMPDIRS/corrupt' of remote 'origin': corrupt manifest" ]] || false
+    [ -f $TMPDIRS/mirror/manifest ]
+}
+
+@test "remote-mirrors: clone --mirrors fails over to the mirrors" {
+    dolt push origin main
+    rm -rf $TMPDIRS/primary
+    mkdir $TMPDIRS/primary
+    echo garbage > $TMPDIRS/primary/manifest
+
+    cd $TMPDIRS
+    dolt clone --mirrors file://$TMPDIRS/mirror file://$TMPDIRS/primary clone
+    cd clone
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin file://$TMPDIRS/primary" ]] || false
+    [[ "$output" =~ "origin file://$TMPDIRS/mirror (mirror)" ]] || false
+    run dolt sql -q "SHOW TABLES"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test" ]] || false
+}
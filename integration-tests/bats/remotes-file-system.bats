@@ -119,6 +119,43 @@ SQL
     [[ "$output" =~ "remotes/something/main" ]] || false
 }
 
+@test "remotes-file-system: fetch --all --parallel fetches every remote" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1), (2, 2)"
+    dolt add test
+    dolt commit -m "test commit"
+
+    mkdir remote1 remote2
+    dolt remote add origin file://remote1
+    dolt remote add other file://remote2
+    dolt push origin main
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt commit -am "feature commit"
+    dolt push other feature
+
+    cd dolt-repo-clones
+    dolt clone file://../remote1 test-repo
+    cd test-repo
+    dolt remote add other file://../remote2
+
+    run dolt fetch --parallel
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--parallel requires --all" ]] || false
+
+    run dolt fetch --all --parallel
+    [ "$status" -eq 0 ]
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+    [[ "$output" =~ "remotes/other/feature" ]] || false
+
+    run dolt log other/feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature commit" ]] || false
+}
+
 @test "remotes-file-system: fetch displays and updates branch list" {
     # create a new branch
     run dolt checkout -b tester
@@ -176,3 +213,230 @@ SQL
     [ ! -d test-repo ]
     cd ..
 }
+
+@test "remotes-file-system: clone seeded from an archive catches up with the remote" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1)"
+    dolt add test
+    dolt commit -m "archived commit"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin main
+
+    # archive the remote as it is now
+    cd dolt-repo-clones
+    dolt clone file://../remotedir archived
+    cd archived
+    dolt admin archive create ../../db.tar
+    cd ../..
+
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt commit -am "commit after archive"
+    dolt push origin main
+
+    cd dolt-repo-clones
+    run dolt clone --from-archive ../db.tar file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "seeding clone from ../db.tar" ]] || false
+
+    cd test-repo
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "commit after archive" ]] || false
+    [[ "$output" =~ "archived commit" ]] || false
+
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "remotes-file-system: clone from an invalid archive fails" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "test commit"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin main
+
+    echo "not an archive" > bad.tar
+    cd dolt-repo-clones
+    run dolt clone --from-archive ../bad.tar file://../remotedir test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not a valid database archive" ]] || false
+    [ ! -d test-repo ]
+}
+
+@test "remotes-file-system: zstd compression requires allow-incompatible-compression" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 text)"
+    dolt sql -q "INSERT INTO test VALUES (1, repeat('abc', 100))"
+    dolt add test
+    dolt commit -m "test commit"
+    mkdir remotedir
+
+    run dolt remote add --compression zstd origin file://remotedir
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "allow-incompatible-compression" ]] || false
+
+    run dolt sql -q "call dolt_remote('add', '--compression', 'zstd', 'origin', 'file://remotedir')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "allow-incompatible-compression" ]] || false
+
+    dolt remote add --compression zstd --allow-incompatible-compression origin file://remotedir
+    dolt push origin main
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    run dolt sql -q "SELECT length(c1) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "300" ]] || false
+}
+
+@test "remotes-file-system: push --force-with-lease only overwrites the remote branch where it was last fetched" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "create table"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin main
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "pushed by someone else"
+    dolt push origin main
+    cd ../..
+
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "rewritten history"
+    run dolt push --force-with-lease origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "(stale info)" ]] || false
+
+    head=$(dolt sql -q "SELECT hashof('main')" -r csv | tail -n 1)
+    run dolt push --force-with-lease=$head origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "stale info" ]] || false
+
+    run dolt sql -q "CALL dolt_push('--force-with-lease', 'origin', 'main')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "stale info" ]] || false
+
+    run dolt push -f --force-with-lease origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+
+    dolt fetch origin
+    dolt push --force-with-lease origin main
+
+    cd dolt-repo-clones/test-repo
+    dolt fetch origin
+    run dolt log -n 1 origin/main
+    [[ "$output" =~ "rewritten history" ]] || false
+}
+
+@test "remotes-file-system: push and fetch with refspec patterns, forced and negative refspecs" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "create table"
+    dolt branch feature/a
+    dolt branch feature/b
+    dolt branch wip/x
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+
+    dolt push origin 'refs/heads/feature/*:refs/heads/feature/*' '^refs/heads/feature/b'
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/feature/a" ]] || false
+    [[ ! "$output" =~ "remotes/origin/feature/b" ]] || false
+
+    dolt remote set-push origin '+refs/heads/*:refs/heads/*' '^wip/*'
+    run dolt remote -v
+    [[ "$output" =~ "+refs/heads/*:refs/heads/* ^wip/* (push)" ]] || false
+    dolt push origin
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/feature/b" ]] || false
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+    [[ ! "$output" =~ "remotes/origin/wip/x" ]] || false
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd ../
+    dolt checkout feature/a
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "feature change"
+    dolt checkout main
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "main change"
+    dolt push origin
+
+    cd dolt-repo-clones/test-repo
+    dolt remote set-fetch origin 'refs/heads/*:refs/remotes/origin/*' '^refs/heads/feature/*'
+    dolt fetch
+    run dolt log -n 1 origin/main
+    [[ "$output" =~ "main change" ]] || false
+    run dolt log -n 1 origin/feature/a
+    [[ ! "$output" =~ "feature change" ]] || false
+
+    dolt remote set-fetch origin
+    dolt fetch origin '^main'
+    run dolt log -n 1 origin/feature/a
+    [[ "$output" =~ "feature change" ]] || false
+
+    run dolt remote set-fetch origin '^main'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only exclude branches" ]] || false
+    cd ../..
+
+    dolt reset --hard HEAD~1
+    run dolt push origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "non-fast-forward" ]] || false
+    dolt push origin +main
+    run dolt log -n 1 origin/main
+    [[ ! "$output" =~ "main change" ]] || false
+
+    run dolt push origin 'refs/heads/nope/*:refs/heads/nope/*'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no refs match" ]] || false
+}
+
+@test "remotes-file-system: protected branches can't be deleted or force pushed" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "create table"
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "second commit"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin main
+    dolt config --local --add branch.protected main
+
+    dolt reset --hard HEAD~1
+    run dolt push --force origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is protected and can only be fast-forwarded" ]] || false
+
+    run dolt sql -q "CALL dolt_push('--force', 'origin', 'main')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is protected" ]] || false
+
+    run dolt push origin :main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch 'main' is protected and can't be deleted" ]] || false
+
+    dolt checkout -b other
+    run dolt branch -D main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Cannot delete protected branch 'main'" ]] || false
+
+    # other branches are unprotected
+    dolt push origin other
+    dolt push origin :other
+    dolt config --local --unset branch.protected
+    dolt push --force origin main
+    dolt branch -D main
+}
@@ -120,6 +120,37 @@ teardown() {
     [[ "$output" =~ "test" ]] || false
 }
 
+@test "remotes-sql-server: read replica warms up with the chunks read by the primary" {
+    skiponwindows "Missing dependencies"
+
+    cd repo1
+    dolt commit -am "cm"
+    dolt push remote1 main
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote remote1
+    dolt config --local --add sqlserver.global.dolt_replicate_access_sample_rate 1
+    start_sql_server repo1
+
+    dolt sql-client --use-db repo1 -P $PORT -u dolt -q "insert into test values (3); call dolt_commit('-am', 'more'); select * from test; select * from dolt_log"
+    # the sampled reads are published periodically
+    for i in $(seq 1 20); do
+        [ -f ../rem1/manifest.access ] && break
+        sleep 0.5
+    done
+    stop_sql_server 1 && sleep 0.5
+    [ -f ../rem1/manifest.access ] || false
+    [ "$(wc -l < ../rem1/manifest.access)" -gt 0 ]
+
+    cd ../repo2
+    dolt config --local --add sqlserver.global.dolt_read_replica_remote remote1
+    dolt config --local --add sqlserver.global.dolt_replicate_heads main
+    dolt config --local --add sqlserver.global.dolt_read_replica_warm 1
+    start_sql_server repo2 && sleep 1
+
+    run dolt sql-client --use-db repo2 -P $PORT -u dolt -q "select * from test" --result-format csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
 @test "remotes-sql-server: pull remote not found error" {
     skiponwindows "Missing dependencies"
 
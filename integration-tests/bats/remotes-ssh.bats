@@ -0,0 +1,59 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    # a stand-in for ssh which runs the remote command locally
+    mkdir bin
+    cat > bin/fake-ssh <<'SH'
+#!/bin/sh
+if [ "$1" = "-p" ]; then shift 2; fi
+shift
+exec sh -c "$*"
+SH
+    chmod +x bin/fake-ssh
+    export DOLT_SSH="$(pwd)/bin/fake-ssh"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "remotes-ssh: clone, push and pull over ssh" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1)"
+    dolt add -A
+    dolt commit -m "seed"
+
+    remote="$(pwd)"
+    cd dolt-repo-clones
+    dolt clone "ssh://localhost$remote" test-repo
+    cd test-repo
+
+    run dolt sql -q "SELECT c1 FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt commit -am "from the clone"
+    dolt push origin main
+
+    cd "$remote"
+    dolt reset --hard
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "remotes-ssh: transfer rejects a path which is not a repository" {
+    mkdir not-a-repo
+    cd dolt-repo-clones
+    run dolt clone "ssh://localhost$(pwd)/../not-a-repo" test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "is not a dolt repository" ]] || false
+}
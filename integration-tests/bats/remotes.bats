@@ -312,6 +312,20 @@ SQL
     [[ "$output" =~ "usage:" ]] || false
 }
 
+@test "remotes: add an s3 remote with a custom endpoint" {
+    run dolt remote add --endpoint http://localhost:9000 --aws-creds-type static --aws-access-key-id minio --aws-secret-access-key minio123 test-remote s3://bucket/db
+    [ "$status" -eq 0 ]
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "s3://bucket/db" ]] || false
+    [[ "$output" =~ '"endpoint":"http://localhost:9000"' ]] || false
+    [[ "$output" =~ '"aws-creds-type":"static"' ]] || false
+
+    run dolt remote add --endpoint http://localhost:9000 other-remote http://localhost:50051/test-org/test-repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only valid for aws" ]] || false
+}
+
 @test "remotes: remove a remote" {
     dolt remote add test-remote http://localhost:50051/test-org/test-repo
     run dolt remote remove test-remote
@@ -739,6 +739,109 @@ SQL
     [[ "$output" =~ "t1" ]] || false
 }
 
+@test "replication: push to several remotes with per-remote modes" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote "backup1:sync, remote1:async"
+
+    run dolt sql -r csv <<SQL
+create table t1 (a int primary key);
+call dolt_commit('-Am', 'cm');
+select remote, ref, mode, replicated_head = head from dolt_replication_status where mode = 'sync';
+select sleep(2);
+select remote, ref, mode, replicated_head = head, lag_ms, last_error is null from dolt_replication_status where mode = 'async';
+SQL
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "backup1,refs/heads/main,sync,true" ]] || false
+    [[ "$output" =~ "remote1,refs/heads/main,async,true,0,true" ]] || false
+
+    cd ..
+    for remote in bac1 rem1; do
+        dolt clone file://./$remote clone_$remote
+        cd clone_$remote
+        run dolt ls
+        [ "$status" -eq 0 ]
+        [[ "$output" =~ "t1" ]] || false
+        cd ..
+    done
+}
+
+@test "replication: replication status is empty without replication" {
+    cd repo1
+    run dolt sql -q "call dolt_commit('--allow-empty', '-m', 'cm'); select count(*) from dolt_replication_status" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[2]}" = "count(*)" ]
+    [ "${lines[3]}" = "0" ]
+}
+
+@test "replication: unknown replication mode errors" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote "remote1:eventually"
+
+    run dolt sql -q "show tables"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid replication mode 'eventually' for remote 'remote1'" ]] || false
+}
+
+@test "replication: promote a read replica and fence the previous primary" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote remote1
+    dolt sql -q "create table t1 (a int primary key); call dolt_commit('-Am', 'cm')"
+
+    cd ..
+    dolt clone file://./rem1 repo2
+    cd repo2
+    dolt config --local --add sqlserver.global.dolt_read_replica_remote origin
+    dolt config --local --add sqlserver.global.dolt_replicate_heads main
+
+    run dolt admin promote
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "promoted to primary at epoch 1" ]] || false
+    run dolt config --local --get sqlserver.global.dolt_replication_epoch
+    [ "$output" = "1" ]
+
+    # the previous primary commits locally, but the remote rejects its pushes
+    cd ../repo1
+    run dolt sql -r csv <<SQL
+insert into t1 values (1);
+call dolt_commit('-am', 'split brain');
+select remote, last_error from dolt_replication_status;
+SQL
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "the database was fenced at a newer epoch: writing at epoch 0, fenced at epoch 1" ]] || false
+
+    cd ../repo2
+    dolt sql -q "insert into t1 values (2); call dolt_commit('-am', 'promoted')"
+
+    cd ..
+    dolt clone file://./rem1 repo3
+    cd repo3
+    run dolt sql -q "select * from t1" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt admin promote
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "this server is not a read replica" ]] || false
+}
+
+@test "replication: dolt_promote fences at a newer epoch than the remote's" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_read_replica_remote remote1
+    dolt config --local --add sqlserver.global.dolt_replicate_heads main
+    echo 3 > ../rem1/manifest.epoch
+
+    run dolt sql -q "call dolt_promote()" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "epoch" ]] || false
+    [ "${lines[1]}" = "4" ]
+    [ "$(cat ../rem1/manifest.epoch)" = "4" ]
+
+    run dolt sql -q "call dolt_promote()"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "this server is not a read replica" ]] || false
+}
+
 @test "replication: local clone" {
     run dolt clone file://./repo1/.dolt/noms repo2
     [ "$status" -eq 0 ]
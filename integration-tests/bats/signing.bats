@@ -0,0 +1,112 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    if ! command -v gpg >/dev/null; then
+        skip "gpg is not installed"
+    fi
+    setup_common
+    export GNUPGHOME=$BATS_TMPDIR/signing-gnupg-$$
+    mkdir -p $GNUPGHOME
+    chmod 700 $GNUPGHOME
+    gpg --batch --passphrase '' --quick-gen-key "Signer <signer@dolthub.com>" ed25519 sign never
+    gpg --batch --passphrase '' --quick-gen-key "Other <other@dolthub.com>" ed25519 sign never
+    KEY=$(gpg --with-colons --list-keys signer@dolthub.com | awk -F: '/^fpr/ { print $10; exit }')
+    OTHER=$(gpg --with-colons --list-keys other@dolthub.com | awk -F: '/^fpr/ { print $10; exit }')
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add .
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+    gpgconf --kill gpg-agent || true
+    rm -rf $GNUPGHOME
+}
+
+@test "signing: commit -S requires a configured key" {
+    run dolt commit -S -m "signed"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no signing key is configured" ]] || false
+
+    run dolt commit --sign-key nobody@dolthub.com -m "signed"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to sign the data" ]] || false
+}
+
+@test "signing: log --show-signature shows the status of each commit's signature" {
+    dolt config --local --add user.signingkey signer@dolthub.com
+    dolt commit -S -m "signed"
+
+    # the keyring ultimately trusts the keys it generated
+    run dolt log --show-signature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Good signature from key $KEY" ]] || false
+    [[ "$output" =~ "No signature" ]] || false
+
+    dolt config --local --add signing.trustedkeys $OTHER
+    run dolt log --show-signature -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Good signature from untrusted key $KEY" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "signature" ]] || false
+}
+
+@test "signing: commits are signed and verified with the program of gpg.program" {
+    cat > $GNUPGHOME/gpg-wrapper <<SCRIPT
+#!/bin/sh
+echo "\$@" >> $GNUPGHOME/gpg-wrapper.log
+exec gpg "\$@"
+SCRIPT
+    chmod +x $GNUPGHOME/gpg-wrapper
+    dolt config --local --add gpg.program $GNUPGHOME/gpg-wrapper
+    dolt config --local --add user.signingkey signer@dolthub.com
+    dolt commit -S -m "signed"
+
+    run dolt sql -r csv -q "call dolt_verify_signatures()"
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ ",good,$KEY" ]] || false
+
+    run cat $GNUPGHOME/gpg-wrapper.log
+    [[ "$output" =~ "-bsau signer@dolthub.com" ]] || false
+    [[ "$output" =~ "--verify" ]] || false
+}
+
+@test "signing: dolt_verify_signatures verifies commits and tags" {
+    dolt commit --sign-key other@dolthub.com -m "signed by another key"
+    dolt config --local --add user.signingkey signer@dolthub.com
+    dolt sql -q "call dolt_commit('-S', '--allow-empty', '-m', 'signed')"
+    dolt tag -s v1 -m "signed tag"
+    dolt tag v2 -m "unsigned tag"
+
+    run dolt sql -r csv -q "call dolt_verify_signatures('--trusted-key', '$KEY', '--all')"
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ ",good,$KEY" ]] || false
+    [[ "${lines[2]}" =~ ",untrusted,$OTHER" ]] || false
+    [[ "${lines[3]}" =~ ",unsigned," ]] || false
+
+    run dolt sql -r csv -q "call dolt_verify_signatures('--trusted-key', '$KEY', 'v1', 'v2')"
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ "v1,good,$KEY" ]] || false
+    [[ "${lines[2]}" =~ "v2,unsigned," ]] || false
+
+    # signatures are kept when cloned
+    dolt remote add origin file://$BATS_TMPDIR/signing-remote-$$
+    dolt push origin main
+    cd $BATS_TMPDIR
+    dolt clone file://$BATS_TMPDIR/signing-remote-$$ signing-clone-$$
+    cd signing-clone-$$
+    run dolt sql -r csv -q "call dolt_verify_signatures('--trusted-key', '$KEY')"
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ ",good,$KEY" ]] || false
+
+    # signatures of keys missing from the keyring can't be checked
+    gpg --batch --yes --delete-secret-and-public-key $KEY
+    run dolt sql -r csv -q "call dolt_verify_signatures()"
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" =~ ",unknown key," ]] || false
+    cd ..
+    rm -rf signing-clone-$$ signing-remote-$$
+}
@@ -189,3 +189,41 @@ SQL
   [ "$status" -eq 0 ]
   [[ "$output" =~ "$EXPECTED" ]] || false
 }
+
+@test "sql-batch: chunked transactions resume from checkpoint" {
+  cat > script.sql <<SQL
+INSERT INTO test VALUES (1,1,1,1,1,1);
+INSERT INTO test VALUES (2,1,1,1,1,1);
+INSERT INTO test VALUES (3,1,1,1,1,1);
+INSERT INTO test VALUES (1,1,1,1,1,1);
+INSERT INTO test VALUES (4,1,1,1,1,1);
+SQL
+
+  run dolt sql -b --chunk-statements 2 --checkpoint checkpoint.json < script.sql
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "duplicate primary key" ]] || false
+  [[ "$output" =~ "2 statements were committed" ]] || false
+
+  # the failed chunk was rolled back
+  run dolt sql -r csv -q 'SELECT pk FROM test ORDER BY pk;'
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "$(echo -e "pk\n1\n2")" ]] || false
+  [[ ! "$output" =~ "3" ]] || false
+
+  sed -i.bak 's/^INSERT INTO test VALUES (1,1,1,1,1,1);$/INSERT INTO test VALUES (10,1,1,1,1,1);/' script.sql
+  sed -i.bak '1s/(10,/(1,/' script.sql
+  run dolt sql -b --chunk-statements 2 --checkpoint checkpoint.json < script.sql
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "Resuming after 2 committed statements" ]] || false
+  [ ! -f checkpoint.json ]
+
+  run dolt sql -r csv -q 'SELECT pk FROM test ORDER BY pk;'
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "$(echo -e "pk\n1\n2\n3\n4\n10")" ]] || false
+}
+
+@test "sql-batch: chunk flags require batch mode" {
+  run dolt sql --chunk-statements 2 < /dev/null
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "only used with --batch" ]] || false
+}
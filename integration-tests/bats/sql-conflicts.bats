@@ -236,3 +236,42 @@ SQL
   [ "$status" -eq 0 ]
   [[ "$output" =~ "$EXPECTED" ]] || false
 }
+
+@test "sql-conflicts: resolving conflicts is journaled in dolt_conflict_resolutions and runs post-resolve hooks" {
+  dolt sql -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0),(1,1,1),(2,2,2)"
+  dolt commit -am "initial values"
+  dolt branch feature_branch
+  dolt sql -q "UPDATE one_pk SET c1=10"
+  dolt commit -am "changed main"
+  dolt checkout feature_branch
+  dolt sql -q "UPDATE one_pk SET c1=20"
+  dolt commit -am "changed feature_branch"
+  dolt checkout main
+
+  mkdir -p .dolt/hooks
+  cat > .dolt/hooks/post-resolve <<'HOOK'
+#!/bin/sh
+echo "resolved on $DOLT_BRANCH"
+cat
+HOOK
+  chmod +x .dolt/hooks/post-resolve
+
+  run dolt sql <<SQL
+SET autocommit = 0;
+CALL DOLT_MERGE('feature_branch');
+UPDATE dolt_conflicts_one_pk SET our_c1 = their_c1 WHERE our_pk1 = 1;
+UPDATE dolt_conflicts_one_pk SET our_c1 = base_c1 WHERE our_pk1 = 2;
+DELETE FROM dolt_conflicts_one_pk;
+SHOW WARNINGS;
+COMMIT;
+SQL
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "resolved on main" ]] || false
+  [[ "$output" =~ 'ours {"pk1":0}' ]] || false
+
+  run dolt sql -r csv -q "SELECT branch, table_name, row_key, winner FROM dolt_conflict_resolutions ORDER BY row_key"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ 'main,one_pk,"{""pk1"":0}",ours' ]] || false
+  [[ "$output" =~ 'main,one_pk,"{""pk1"":1}",theirs' ]] || false
+  [[ "$output" =~ 'main,one_pk,"{""pk1"":2}",base' ]] || false
+}
@@ -0,0 +1,123 @@
+#!/usr/bin/env bats
+#
+# Tests for sql-server running with --flight-sql-port, which serves query
+# results over Arrow Flight SQL. The tests read them with pyarrow.
+
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    skiponwindows "tests are flaky on Windows"
+    python3 -c "import pyarrow.flight" 2>/dev/null || skip "pyarrow is not installed"
+    setup_common
+    dolt sql <<SQL
+create table t (id int primary key, v varchar(10), f double);
+insert into t values (1, 'one', 1.5), (2, null, 2.5), (3, 'three', null);
+SQL
+    dolt add t
+    dolt commit -m "add t"
+    FLIGHT_PORT=$( definePORT )
+    start_sql_server_with_args --host 0.0.0.0 --user dolt --flight-sql-port $FLIGHT_PORT
+}
+
+teardown() {
+    stop_sql_server 1
+    teardown_common
+}
+
+# flight_query runs the query $3 as the user $1 with the password $2 in the
+# database $4, and prints the table of its results.
+flight_query() {
+    python3 - "$FLIGHT_PORT" "$@" <<'PY'
+import sys
+import pyarrow.flight as flight
+
+port, user, password, query, database = sys.argv[1:6]
+
+def field(num, value):
+    # a length delimited protobuf field
+    n, length = len(value), b""
+    while n >= 0x80:
+        length += bytes([n & 0x7F | 0x80])
+        n >>= 7
+    return bytes([num << 3 | 2]) + length + bytes([n]) + value
+
+cmd = field(1, b"type.googleapis.com/arrow.flight.protocol.sql.CommandStatementQuery") + field(2, field(1, query.encode()))
+client = flight.FlightClient("grpc://localhost:" + port)
+token = client.authenticate_basic_token(user, password)
+options = flight.FlightCallOptions(headers=[token, (b"database", database.encode())])
+info = client.get_flight_info(flight.FlightDescriptor.for_command(cmd), options)
+table = client.do_get(info.endpoints[0].ticket, options).read_all()
+print(table.num_rows, table.column_names)
+for name in table.column_names:
+    print(name, table.column(name).to_pylist())
+PY
+}
+
+# flight_tables prints the table of the tables of the database $1, which it
+# lists with the GetTables catalog command as the user dolt.
+flight_tables() {
+    python3 - "$FLIGHT_PORT" "$@" <<'PY'
+import sys
+import pyarrow.flight as flight
+
+port, catalog = sys.argv[1:3]
+
+def field(num, value):
+    # a length delimited protobuf field
+    n, length = len(value), b""
+    while n >= 0x80:
+        length += bytes([n & 0x7F | 0x80])
+        n >>= 7
+    return bytes([num << 3 | 2]) + length + bytes([n]) + value
+
+cmd = field(1, b"type.googleapis.com/arrow.flight.protocol.sql.CommandGetTables") + field(2, field(1, catalog.encode()))
+client = flight.FlightClient("grpc://localhost:" + port)
+token = client.authenticate_basic_token("dolt", "")
+options = flight.FlightCallOptions(headers=[token])
+info = client.get_flight_info(flight.FlightDescriptor.for_command(cmd), options)
+table = client.do_get(info.endpoints[0].ticket, options).read_all()
+for name in table.column_names:
+    print(name, table.column(name).to_pylist())
+PY
+}
+
+@test "sql-server-flightsql: reads query results as arrow tables" {
+    run flight_query dolt "" "select * from t order by id" "dolt_repo_$$"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3 ['id', 'v', 'f']" ]] || false
+    [[ "$output" =~ "id [1, 2, 3]" ]] || false
+    [[ "$output" =~ "v ['one', None, 'three']" ]] || false
+    [[ "$output" =~ "f [1.5, 2.5, None]" ]] || false
+
+    run flight_query dolt "" "select count(*) as c from t as of 'HEAD'" "dolt_repo_$$/main"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "c [3]" ]] || false
+}
+
+@test "sql-server-flightsql: authenticates as sql users" {
+    run flight_query dolt "wrong" "select 1" "dolt_repo_$$"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Unauthenticated" ]] || false
+
+    dolt sql-client -P $PORT -u dolt --use-db "dolt_repo_$$" <<SQL
+create user reader@'%' identified by 'pw';
+grant select on \`dolt_repo_$$\`.t to reader@'%';
+SQL
+    run flight_query reader pw "select id from t" "dolt_repo_$$"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "id [1, 2, 3]" ]] || false
+
+    run flight_query reader pw "select * from dolt_log" "dolt_repo_$$"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Access denied" ]] || false
+}
+
+@test "sql-server-flightsql: lists tables with catalog commands" {
+    dolt sql-client -P $PORT -u dolt --use-db "dolt_repo_$$" -q "create view v as select id from t"
+    run flight_tables "dolt_repo_$$"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "catalog_name ['dolt_repo_$$', 'dolt_repo_$$']" ]] || false
+    [[ "$output" =~ "table_name ['t', 'v']" ]] || false
+    [[ "$output" =~ "table_type ['TABLE', 'VIEW']" ]] || false
+}
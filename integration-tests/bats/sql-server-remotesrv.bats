@@ -104,6 +104,86 @@ SQL
     [[ "$status" != 0 ]] || false
 }
 
+@test "sql-server-remotesrv: the remotesapi server accepts authenticated pushes with --remotesapi-read-write" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table vals (i int);'
+    dolt add vals
+    dolt commit -m 'create vals table.'
+    export DOLT_REMOTE_PASSWORD="pass0"
+
+    dolt sql-server --port 3307 -u user0 -p $DOLT_REMOTE_PASSWORD --remotesapi-port 50051 --remotesapi-read-write &
+    srv_pid=$!
+    sleep 2 # wait for server to start so we don't lock it out
+    cd ../
+
+    dolt clone http://localhost:50051/remote remote_cloned -u user0
+
+    cd remote_cloned
+    dolt sql -q 'insert into vals values (1), (2), (3), (4), (5);'
+    dolt commit -am 'insert some values'
+
+    run dolt push origin main:other
+    [[ "$status" != 0 ]] || false
+    [[ "$output" =~ "Unauthenticated" ]] || false
+
+    dolt push --user user0 origin main:other
+
+    cd ../remote
+    run dolt sql-client --port 3307 -u user0 -p $DOLT_REMOTE_PASSWORD -q "select count(*) from vals as of 'other'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 5 " ]] || false
+}
+
+@test "sql-server-remotesrv: push --force-with-lease is rejected by the remotesapi server when the branch has moved" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table vals (i int);'
+    dolt add vals
+    dolt commit -m 'create vals table.'
+    export DOLT_REMOTE_PASSWORD="pass0"
+
+    dolt sql-server --port 3307 -u user0 -p $DOLT_REMOTE_PASSWORD --remotesapi-port 50051 --remotesapi-read-write &
+    srv_pid=$!
+    sleep 2 # wait for server to start so we don't lock it out
+    cd ../
+
+    dolt clone http://localhost:50051/remote first -u user0
+    dolt clone http://localhost:50051/remote second -u user0
+
+    cd second
+    dolt sql -q 'insert into vals values (1);'
+    dolt commit -am 'pushed first'
+    dolt push --user user0 origin main
+
+    cd ../first
+    dolt sql -q 'insert into vals values (2);'
+    dolt commit -am 'rewritten'
+    run dolt push --user user0 --force-with-lease origin main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "stale info" ]] || false
+
+    dolt fetch --user user0 origin
+    dolt push --user user0 --force-with-lease origin main
+
+    cd ../remote
+    run dolt sql-client --port 3307 -u user0 -p $DOLT_REMOTE_PASSWORD -q "select * from vals"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 2 " ]] || false
+    [[ ! "$output" =~ "| 1 " ]] || false
+}
+
+@test "sql-server-remotesrv: --remotesapi-read-write needs a user to authenticate pushes" {
+    mkdir remote
+    cd remote
+    dolt init
+    run dolt sql-server --port 3307 --remotesapi-port 50051 --remotesapi-read-write
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "user is provided to authenticate pushes" ]] || false
+}
+
 @test "sql-server-remotesrv: remotesapi listen error stops process" {
     mkdir remote_one
     mkdir remote_two
@@ -1903,3 +1903,186 @@ s.close()
     [ $status -eq 0 ]
     [[ "$output" =~ "0x1C4C4E338AD7442184509D5182816AC3" ]] || false
 }
+
+@test "sql-server: per-database quotas are enforced and shown in dolt_quotas" {
+    cd repo1
+    echo "
+quotas:
+  - database: repo1
+    max_branches:
+      soft: 2
+      hard: 3
+" > server.yaml
+
+    start_sql_server_with_config repo1 server.yaml
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT resource, \`usage\`, soft_limit, hard_limit, status FROM dolt_quotas WHERE resource = 'branches'"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "branches,1,2,3,ok" ]] || false
+
+    dolt sql-client -P $PORT -u dolt --use-db repo1 -q "call dolt_branch('b1')"
+
+    # past the soft limit, branches are created with a warning
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 -q "call dolt_branch('b2'); show warnings"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "soft limit of 2" ]] || false
+
+    # past the hard limit, they're not created at all
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 -q "call dolt_branch('b3')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "hard limit of 3" ]] || false
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT resource, \`usage\`, status FROM dolt_quotas WHERE resource = 'branches'"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "branches,3,warning" ]] || false
+
+    # databases without quotas aren't limited
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT count(*) FROM dolt_quotas WHERE soft_limit IS NOT NULL OR hard_limit IS NOT NULL"
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "sql-server: remotes are fetched in the background and shown in dolt_remote_status" {
+    mkdir remote1
+    cd repo2
+    dolt remote add remote1 file://../remote1
+    dolt push remote1 main
+
+    cd ..
+    rm -rf repo1
+    dolt clone file://./remote1 repo1
+
+    cd repo2
+    dolt sql -q "create table test (a int)"
+    dolt add .
+    dolt commit -am "new commit"
+    dolt push remote1 main
+    dolt checkout -b feature
+    dolt push remote1 feature
+
+    cd ../repo1
+    echo "
+remote_fetch:
+  interval_seconds: 1
+" > server.yaml
+
+    start_sql_server_with_config repo1 server.yaml
+
+    # the first fetch happens at startup
+    for i in {1..20}; do
+        run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT remote, IF(successes > 0, 'yes', 'no'), last_error FROM dolt_remote_status"
+        [[ "$output" =~ "origin,yes," ]] && break
+        sleep 0.5
+    done
+    [ $status -eq 0 ]
+    [[ "$output" =~ "origin,yes," ]] || false
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 -q "SELECT name FROM dolt_remote_branches"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 -q "call dolt_fetch('--daemon')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "not supported in SQL" ]] || false
+}
+
+@test "sql-server: chunk cache size is configured and its counters are shown in dolt_storage_stats" {
+    cd repo1
+    dolt sql -q "create table t (pk int primary key, c varchar(20)); insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "add t"
+    echo "
+performance:
+  chunk_cache_size: 16777216
+" > server.yaml
+
+    start_sql_server_with_config repo1 server.yaml
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 -q "SELECT * FROM t"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "two" ]] || false
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT cache_max_bytes, cache_chunks > 0, cache_misses > 0 FROM dolt_storage_stats"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "16777216,1,1" ]] || false
+}
+
+@test "sql-server: chunk journal is replicated and restored with dolt admin restore" {
+    cd repo1
+    dolt sql -q "create table t (pk int primary key); insert into t values (1);"
+    dolt commit -Am "add t"
+    replica="file://$BATS_TMPDIR/journal-replica-$$"
+    echo "
+journal_replication:
+  url: $replica
+  interval_millis: 100
+" > server.yaml
+
+    start_sql_server_with_config repo1 server.yaml
+
+    dolt sql-client -P $PORT -u dolt --use-db repo1 -q "insert into t values (2)"
+    sleep 1.1
+    ts=$(date -u +%Y-%m-%dT%H:%M:%S)
+    sleep 1.1
+    dolt sql-client -P $PORT -u dolt --use-db repo1 -q "insert into t values (3)"
+    stop_sql_server 1
+
+    run dolt admin restore --from "$replica/repo1" --list
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -ge 3 ]
+
+    mkdir ../latest && cd ../latest
+    run dolt admin restore --from "$replica/repo1"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Restored root" ]] || false
+    run dolt sql -q "select count(*) from t" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    run dolt log -n 1
+    [[ "$output" =~ "add t" ]] || false
+
+    run dolt admin restore --from "$replica/repo1"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    mkdir ../earlier && cd ../earlier
+    run dolt admin restore --from "$replica/repo1" --to-timestamp "$ts"
+    [ $status -eq 0 ]
+    run dolt sql -q "select group_concat(pk) from t" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1,2" ]] || false
+    [[ ! "$output" =~ "3" ]] || false
+
+    cd ..
+    run dolt admin restore --from "$replica/repo1" --to-timestamp 2000-01-01
+    [ $status -ne 0 ]
+    [[ "$output" =~ "no root committed at or before" ]] || false
+}
+
+@test "sql-server: webhooks that can't be delivered are shown in dolt_webhook_dead_letters" {
+    cd repo1
+    port=$( definePORT )
+    echo "
+webhooks:
+  - url: http://localhost:$port/dolt
+    events: [commit, tag]
+    max_attempts: 1
+" > server.yaml
+
+    start_sql_server_with_config repo1 server.yaml
+
+    dolt sql-client -P $PORT -u dolt --use-db repo1 -q "create table t (pk int primary key); call dolt_commit('-Am', 'add t'); call dolt_branch('b1'); call dolt_tag('v1')"
+
+    for i in {1..20}; do
+        run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT event, ref, attempts FROM dolt_webhook_dead_letters ORDER BY failed_at"
+        [[ "$output" =~ "tag,refs/tags/v1,1" ]] && break
+        sleep 0.5
+    done
+    [ $status -eq 0 ]
+    [[ "$output" =~ "commit,refs/heads/main,1" ]] || false
+    [[ "$output" =~ "tag,refs/tags/v1,1" ]] || false
+    # branch events aren't sent to the URL
+    [[ ! "$output" =~ "refs/heads/b1" ]] || false
+
+    run dolt sql-client -P $PORT -u dolt --use-db repo1 --result-format csv -q "SELECT json_extract(payload, '$.tables_changed') FROM dolt_webhook_dead_letters WHERE event = 'commit'"
+    [ $status -eq 0 ]
+    [[ "$output" =~ '["t"]' ]] || false
+}
@@ -1088,6 +1088,34 @@ SQL
     [[ "$output" =~ "{}" ]] || false
 }
 
+@test "sql: arrow output format" {
+    dolt sql <<SQL
+    CREATE TABLE test (
+    a int primary key,
+    b float,
+    c varchar(80),
+    d datetime
+);
+INSERT INTO test VALUES (1, 1.5, '1', '2020-01-01'), (2, 2.5, '2', '2020-02-02'), (3, NULL, '3', '2020-03-03');
+SQL
+
+    dolt sql -r arrow -q "select * from test order by a" > out.arrows
+    # every message of the stream starts with a continuation marker, and the stream ends with an empty message
+    run od -An -tx1 -N4 out.arrows
+    [[ "$output" =~ "ff ff ff ff" ]] || false
+    run sh -c "tail -c 8 out.arrows | od -An -tx1"
+    [[ "$output" =~ "ff ff ff ff 00 00 00 00" ]] || false
+
+    if python3 -c "import pyarrow" 2>/dev/null; then
+        run python3 -c "import pyarrow.ipc as ipc; t = ipc.open_stream(open('out.arrows', 'rb')).read_all(); print(t.num_rows, t.column_names, t.column('a').to_pylist(), t.column('b').to_pylist())"
+        [ $status -eq 0 ]
+        [[ "$output" =~ "3 ['a', 'b', 'c', 'd'] [1, 2, 3] [1.5, 2.5, None]" ]] || false
+    fi
+
+    run dolt sql -r arrow -q "select * from test where a > 10"
+    [ $status -eq 0 ]
+}
+
 @test "sql: output for escaped longtext exports properly" {
  dolt sql <<SQL
     CREATE TABLE test (
@@ -1513,6 +1541,58 @@ SQL
     [ "${#lines[@]}" -eq 12 ] # one line for above output, 11 dbs
 }
 
+@test "sql: dolt_snapshot_database_tags and dolt_snapshot_database_branches" {
+    mkdir new && cd new
+
+    dolt sql <<SQL
+create database db1;
+use db1;
+create table t1 (a int primary key);
+insert into t1 values (1);
+call dolt_commit('-Am', 'first');
+call dolt_tag('v1.0.0');
+insert into t1 values (2);
+call dolt_commit('-am', 'second');
+call dolt_tag('v1.1.0');
+call dolt_branch('release/2023');
+call dolt_branch('other');
+SQL
+
+    run dolt sql -r csv -q "show databases"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "db1_" ]] || false
+
+    dolt sql -q "set @@persist.dolt_snapshot_database_tags = 1"
+    dolt sql -q "set @@persist.dolt_snapshot_database_branches = 'release/*'"
+
+    run dolt sql -r csv -q "show databases"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "db1_v1_1_0" ]] || false
+    [[ "$output" =~ "db1_release_2023" ]] || false
+    [[ ! "$output" =~ "db1_v1_0_0" ]] || false
+    [[ ! "$output" =~ "db1_other" ]] || false
+
+    run dolt sql -r csv -q "select count(*) from db1_v1_1_0.t1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "insert into db1_v1_1_0.t1 values (3)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "read-only" ]] || false
+
+    # branch snapshots follow the branch head
+    dolt sql -q 'use `db1/release/2023`; insert into t1 values (3); call dolt_commit("-am", "third")'
+    run dolt sql -r csv -q "select count(*) from db1_release_2023.t1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    # a tag and a branch whose snapshots would have the same name are rejected
+    dolt sql -q "use db1; call dolt_tag('release_2023')"
+    run dolt sql -r csv -q "select count(*) from db1_release_2023.t1"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "snapshot database name db1_release_2023 is derived from both tag 'release_2023' and branch 'release/2023'" ]] || false
+}
+
 @test "sql: run outside a dolt directory" {
     mkdir new && cd new
 
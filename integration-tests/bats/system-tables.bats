@@ -565,17 +565,37 @@ SQL
     [[ "$output" =~ "1,commit C" ]] || false
 }
 
-@test "system-tables: dolt_branches is read-only" {
+@test "system-tables: dolt_branches creates and deletes branches" {
+    dolt sql -q "INSERT INTO dolt_branches (name,hash) VALUES ('branch1', 'main');"
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "branch1" ]] || false
+
+    run dolt sql -q "INSERT INTO dolt_branches (name,hash) VALUES ('branch1', 'main');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_branches WHERE name = 'branch1'"
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "branch1" ]] || false
+
     run dolt sql -q "DELETE FROM dolt_branches"
     [ "$status" -ne 0 ]
-    [[ "$output" =~ "read-only" ]] || false
+    [[ "$output" =~ "checked out branch" ]] || false
 
-    run dolt sql -q "INSERT INTO dolt_branches (name,hash) VALUES ('branch1', 'main');"
+    dolt branch branch2
+    dolt config --local --add branch.protected branch2
+    run dolt sql -q "DELETE FROM dolt_branches WHERE name = 'branch2'"
     [ "$status" -ne 0 ]
-    [[ "$output" =~ "read-only" ]] || false
+    [[ "$output" =~ "protected" ]] || false
 
     run dolt sql -q "UPDATE dolt_branches SET name = 'branch1' WHERE name = 'main'"
     [ "$status" -ne 0 ]
+    [[ "$output" =~ "can't be updated" ]] || false
+
+    run dolt sql -q "INSERT INTO dolt_remote_branches (name,hash) VALUES ('branch1', 'main');"
+    [ "$status" -ne 0 ]
     [[ "$output" =~ "read-only" ]] || false
 }
 
@@ -613,3 +633,106 @@ SQL
     [[ "$output" =~ "tag v2 from branch1" ]] || false
     [[ "$output" =~ "tag v3 from branch1" ]] || false
 }
+
+@test "system-tables: query dolt_index_usage" {
+    dolt sql -q "CREATE TABLE test(pk int primary key, a int, b int, index idx_a (a), index idx_b (b))"
+    dolt sql -q "INSERT INTO test VALUES (1,1,1), (2,2,2)"
+
+    dolt sql -q "SELECT * FROM test WHERE a = 1"
+    dolt sql -q "SELECT * FROM test WHERE a = 2"
+
+    run dolt sql -q "SELECT branch, table_name, index_name, read_count FROM dolt_index_usage WHERE index_name != 'PRIMARY'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "main,test,idx_a,2" ]] || false
+    [[ "$output" =~ "main,test,idx_b,0" ]] || false
+
+    dolt checkout -b branch1
+    dolt sql -q "SELECT * FROM test WHERE b = 1"
+
+    run dolt sql -q "SELECT branch, table_name, index_name, read_count FROM dolt_index_usage WHERE index_name != 'PRIMARY'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "main,test,idx_a,2" ]] || false
+    [[ "$output" =~ "branch1,test,idx_a,0" ]] || false
+    [[ "$output" =~ "branch1,test,idx_b,1" ]] || false
+    [[ ! "$output" =~ "main,test,idx_b" ]] || false
+
+    run dolt lint --unused-indexes
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Indexes never read on branch 'branch1'" ]] || false
+    [[ "$output" =~ "test.idx_a" ]] || false
+    [[ ! "$output" =~ "test.idx_b" ]] || false
+    [[ "$output" =~ "ALTER TABLE \`test\` DROP INDEX \`idx_a\`;" ]] || false
+
+    dolt checkout main
+    run dolt lint
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test.idx_b" ]] || false
+    [[ ! "$output" =~ "test.idx_a" ]] || false
+}
+
+@test "system-tables: query dolt_projection_cache" {
+    dolt sql -q "CREATE TABLE wide (pk int primary key, a int, b varchar(20), c int, d int)"
+    dolt sql -q "INSERT INTO wide VALUES (1,1,'one',1,1), (2,2,'two',2,2), (3,3,'three',3,3)"
+
+    run dolt sql -r csv <<SQL
+SET GLOBAL dolt_projection_cache_size = 1048576;
+SELECT sum(a), count(b) FROM wide;
+SELECT sum(a), count(b) FROM wide;
+SELECT table_name, row_count, hits FROM dolt_projection_cache;
+CALL dolt_clear_projection_cache('wide');
+SELECT count(*) AS cached FROM dolt_projection_cache;
+SQL
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "6,3" ]] || false
+    [[ "$output" =~ "wide,3,1" ]] || false
+    [ "${lines[${#lines[@]}-1]}" = "0" ]
+
+    # the cache is disabled by default
+    run dolt sql -r csv <<SQL
+SELECT sum(a), count(b) FROM wide;
+SELECT count(*) AS cached FROM dolt_projection_cache;
+SQL
+    [ "$status" -eq 0 ]
+    [ "${lines[${#lines[@]}-1]}" = "0" ]
+}
+
+@test "system-tables: query dolt_storage" {
+    dolt sql -q "CREATE TABLE docs (pk int primary key, body longtext, note varchar(20))"
+    body=$(head -c 300000 /dev/urandom | base64 -w0)
+    dolt sql <<SQL
+INSERT INTO docs VALUES (1, '$body', 'a');
+INSERT INTO docs SELECT 2, CONCAT(body, 'appended'), 'b' FROM docs WHERE pk = 1;
+INSERT INTO docs SELECT 3, body, 'c' FROM docs WHERE pk = 1;
+SQL
+
+    run dolt sql -q "SELECT table_name, column_name, value_count, logical_bytes FROM dolt_storage" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "docs,body,3,$((${#body} * 3 + 8))" ]] || false
+    [[ ! "$output" =~ "note" ]] || false
+
+    # the appended and copied values share the chunks of the first value
+    run dolt sql -q "SELECT IF(deduped_bytes > 2 * ${#body} - 40000 AND stored_bytes < 2 * ${#body}, 'shared', 'copied') FROM dolt_storage WHERE table_name = 'docs'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "shared" ]] || false
+}
+
+@test "system-tables: dolt_storage shows values sharing content-defined chunks with DOLT_CONTENT_DEFINED_BLOBS" {
+    dolt sql -q "CREATE TABLE docs (pk int primary key, body longtext)"
+    body=$(head -c 300000 /dev/urandom | base64 -w0)
+    dolt sql <<SQL
+INSERT INTO docs VALUES (1, '$body');
+INSERT INTO docs SELECT 2, CONCAT('prepended', body) FROM docs WHERE pk = 1;
+SQL
+
+    # blobs are split at fixed offsets by default, so a prepend changes every chunk
+    run dolt sql -q "SELECT deduped_bytes FROM dolt_storage WHERE table_name = 'docs'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "deduped_bytes" ]] || false
+    [ "${lines[1]}" -eq 0 ]
+
+    DOLT_CONTENT_DEFINED_BLOBS=1 dolt sql -q "INSERT INTO docs SELECT 3, CONCAT('prepended', body) FROM docs WHERE pk = 1"
+    DOLT_CONTENT_DEFINED_BLOBS=1 dolt sql -q "INSERT INTO docs SELECT 4, CONCAT('prepended again', body) FROM docs WHERE pk = 1"
+    run dolt sql -q "SELECT IF(deduped_bytes > ${#body} - 40000, 'shared', 'copied') FROM dolt_storage WHERE table_name = 'docs'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "shared" ]] || false
+}
@@ -0,0 +1,93 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE sales_2021 (pk int primary key, v int);
+CREATE TABLE sales_2022 (pk int primary key, v int);
+CREATE TABLE sales_2023 (pk int primary key, v int);
+CREATE TABLE customers (pk int primary key, v int);
+SQL
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "table-patterns: add --dry-run lists the matching tables" {
+    run dolt add --dry-run "sales_*"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[0]}" = "sales_2021" ]
+    [ "${lines[2]}" = "sales_2023" ]
+
+    run dolt status
+    [[ ! "$output" =~ "Changes to be committed" ]] || false
+}
+
+@test "table-patterns: add stages the matching tables" {
+    dolt add "sales_202?"
+
+    run dolt diff --cached --dry-run
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ ! "$output" =~ "customers" ]] || false
+}
+
+@test "table-patterns: patterns which match no table are an error" {
+    run dolt add "orders_*"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no tables match pattern 'orders_*'" ]] || false
+}
+
+@test "table-patterns: diff and checkout accept patterns" {
+    dolt add -A
+    dolt commit -m "add tables"
+    dolt sql -q "INSERT INTO sales_2021 VALUES (1, 1); INSERT INTO sales_2022 VALUES (1, 1); INSERT INTO customers VALUES (1, 1);"
+
+    run dolt diff "sales_*"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "sales_2021" ]] || false
+    [[ "$output" =~ "sales_2022" ]] || false
+    [[ ! "$output" =~ "customers" ]] || false
+
+    run dolt checkout --dry-run "sales_*"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    dolt checkout "sales_*"
+    run dolt sql -q "SELECT COUNT(*) FROM sales_2021" -r csv
+    [ "${lines[1]}" = "0" ]
+    run dolt sql -q "SELECT COUNT(*) FROM customers" -r csv
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "table-patterns: table export writes each matching table to a directory" {
+    dolt sql -q "INSERT INTO sales_2021 VALUES (1, 1); INSERT INTO sales_2022 VALUES (2, 2);"
+
+    run dolt table export --dry-run "sales_*"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    dolt table export "sales_*" exported
+    [ -f exported/sales_2021.csv ]
+    [ -f exported/sales_2022.csv ]
+    [ -f exported/sales_2023.csv ]
+    [ ! -f exported/customers.csv ]
+
+    run cat exported/sales_2022.csv
+    [[ "$output" =~ "2,2" ]] || false
+}
+
+@test "table-patterns: constraint verify accepts patterns" {
+    run dolt constraints verify --dry-run "sales_*3" customers
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = "customers" ]
+    [ "${lines[1]}" = "sales_2023" ]
+
+    run dolt constraints verify "sales_*"
+    [ "$status" -eq 0 ]
+}
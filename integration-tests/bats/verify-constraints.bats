@@ -180,3 +180,42 @@ SQL
     [[ ! "$output" =~ "child4,2" ]] || false
     [[ "${#lines[@]}" = "1" ]] || false
 }
+
+@test "verify-constraints: CLI --all checks keyless children and primary key foreign keys" {
+    dolt sql <<SQL
+CREATE TABLE parent (id BIGINT PRIMARY KEY, v1 BIGINT, INDEX (v1));
+CREATE TABLE keyless_child (v1 BIGINT, CONSTRAINT fk_keyless FOREIGN KEY (v1) REFERENCES parent (v1));
+CREATE TABLE pk_child (id BIGINT PRIMARY KEY, CONSTRAINT fk_pk FOREIGN KEY (id) REFERENCES parent (id));
+INSERT INTO parent VALUES (1, 10), (2, 20);
+SET foreign_key_checks=0;
+INSERT INTO keyless_child VALUES (10), (30), (40), (NULL);
+INSERT INTO pk_child VALUES (1), (3);
+SET foreign_key_checks=1;
+SQL
+    dolt add -A
+    dolt commit --force -m "more fk violations"
+
+    run dolt constraints verify keyless_child pk_child
+    [ "$status" -eq "0" ]
+
+    run dolt constraints verify --all keyless_child pk_child
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "fk_keyless" ]] || false
+    [[ "$output" =~ "fk_pk" ]] || false
+    run dolt sql -q "SELECT * FROM dolt_constraint_violations" -r=csv
+    [[ "$output" =~ "keyless_child,2" ]] || false
+    [[ "$output" =~ "pk_child,1" ]] || false
+    [[ "${#lines[@]}" = "3" ]] || false
+    run dolt sql -q "SELECT id FROM dolt_constraint_violations_pk_child" -r=csv
+    [[ "$output" =~ "3" ]] || false
+    [[ ! "$output" =~ "1" ]] || false
+}
+
+@test "verify-constraints: dolt_verify_constraints --all" {
+    run dolt sql -r=csv -q "CALL dolt_verify_constraints('--all', 'child3', 'child4')"
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1" ]] || false
+    run dolt sql -q "SELECT * FROM dolt_constraint_violations" -r=csv
+    [[ "$output" =~ "child3,2" ]] || false
+    [[ "$output" =~ "child4,2" ]] || false
+}
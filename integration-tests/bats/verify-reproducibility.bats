@@ -0,0 +1,70 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "verify-reproducibility: deterministic script is reproducible" {
+    cat > build.sql <<SQL
+CREATE TABLE people (id INT PRIMARY KEY, name VARCHAR(20), INDEX(name));
+INSERT INTO people VALUES (1, 'alice'), (2, 'bob');
+CALL dolt_commit('-Am', 'add people', '--date', '2023-01-01T00:00:00Z');
+CALL dolt_branch('other');
+INSERT INTO people VALUES (3, 'carol');
+SQL
+
+    run dolt verify-reproducibility build.sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ "build.sql is reproducible" ]] || false
+    [[ "$output" =~ "main" ]] || false
+    [[ "$output" =~ "other" ]] || false
+
+    # no database is created in the current directory
+    [ ! -d .dolt ]
+}
+
+@test "verify-reproducibility: nondeterministic defaults are flagged" {
+    cat > build.sql <<SQL
+CREATE TABLE events (id INT PRIMARY KEY, created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6));
+INSERT INTO events (id) VALUES (1);
+SQL
+
+    run dolt verify-reproducibility build.sql
+    [ $status -eq 1 ]
+    [[ "$output" =~ "build.sql is not reproducible" ]] || false
+    [[ "$output" =~ "table events: the rows differ" ]] || false
+    [[ "$output" =~ "column created_at has a nondeterministic default" ]] || false
+}
+
+@test "verify-reproducibility: nondeterministic statements and commit times are flagged" {
+    cat > build.sql <<SQL
+CREATE TABLE t (id INT PRIMARY KEY, r VARCHAR(36));
+INSERT INTO t VALUES (1, 'fixed');
+CALL dolt_commit('-Am', 'add t');
+INSERT INTO t VALUES (2, UUID());
+SQL
+
+    run dolt verify-reproducibility build.sql
+    [ $status -eq 1 ]
+    [[ "$output" =~ "branch main: the commits have the same data but different metadata" ]] || false
+    [[ "$output" =~ "branch main, working set: table t: the rows differ" ]] || false
+    [[ "$output" =~ "line 4: INSERT INTO t VALUES (2, UUID());" ]] || false
+}
+
+@test "verify-reproducibility: errors in the script are reported" {
+    echo "CREATE TABLE t (id INT PRIMARY KEY); INSERT INTO missing VALUES (1);" > build.sql
+
+    run dolt verify-reproducibility build.sql
+    [ $status -eq 1 ]
+    [[ "$output" =~ "failed to run build.sql" ]] || false
+    [[ "$output" =~ "missing" ]] || false
+
+    run dolt verify-reproducibility does-not-exist.sql
+    [ $status -eq 1 ]
+    [[ "$output" =~ "failed to read does-not-exist.sql" ]] || false
+}
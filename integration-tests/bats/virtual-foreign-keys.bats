@@ -0,0 +1,114 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE parent (id int PRIMARY KEY, v int);
+CREATE TABLE child (pk int PRIMARY KEY, parent_id int);
+INSERT INTO parent VALUES (1, 1), (2, 2);
+INSERT INTO child VALUES (1, 1), (2, 2), (3, NULL);
+CALL dolt_create_virtual_foreign_key('child', 'fk_parent', 'parent_id', '--references', 'parent(id)');
+SQL
+    dolt commit -Am "create tables"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "virtual-foreign-keys: rows are not checked against the parent table" {
+    dolt sql -q "INSERT INTO child VALUES (4, 99)"
+    dolt sql -q "DELETE FROM parent WHERE id = 1"
+
+    run dolt sql -q "SELECT pk FROM child ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 5 ]
+
+    dolt commit -am "orphan rows"
+    run dolt sql -q "SELECT count(*) FROM dolt_constraint_violations" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "child" ]] || false
+}
+
+@test "virtual-foreign-keys: schema show includes the foreign key" {
+    run dolt schema show child
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'CONSTRAINT `fk_parent` FOREIGN KEY (`parent_id`) REFERENCES `parent` (`id`) NOT ENFORCED' ]] || false
+}
+
+@test "virtual-foreign-keys: invalid declarations" {
+    run dolt sql -q "CALL dolt_create_virtual_foreign_key('child', 'fk2', 'parent_id')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--references" ]] || false
+
+    run dolt sql -q "CALL dolt_create_virtual_foreign_key('child', 'fk2', 'parent_id', 'pk', '--references', 'parent(id)')"
+    [ "$status" -ne 0 ]
+
+    run dolt sql -q "CALL dolt_create_virtual_foreign_key('child', 'fk2', 'missing', '--references', 'parent(id)')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "does not have column" ]] || false
+
+    run dolt sql -q "CALL dolt_create_virtual_foreign_key('child', 'fk2', 'parent_id', '--references', 'missing(id)')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not found" ]] || false
+}
+
+@test "virtual-foreign-keys: referenced tables and columns can't be dropped" {
+    run dolt sql -q "DROP TABLE parent"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "referenced in foreign key \`fk_parent\`" ]] || false
+
+    run dolt sql -q "ALTER TABLE parent DROP COLUMN id"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "used in foreign key \`fk_parent\`" ]] || false
+
+    run dolt sql -q "ALTER TABLE child DROP COLUMN parent_id"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "used in foreign key \`fk_parent\`" ]] || false
+
+    dolt sql -q "ALTER TABLE child DROP FOREIGN KEY fk_parent"
+    dolt sql -q "DROP TABLE parent"
+}
+
+@test "virtual-foreign-keys: sql diff and patch recreate the foreign key" {
+    dolt sql -q "CREATE TABLE child2 (pk int PRIMARY KEY, parent_id int)"
+    dolt sql -q "CALL dolt_create_virtual_foreign_key('child2', 'fk_parent2', 'parent_id', '--references', 'parent(id)')"
+
+    run dolt diff -r sql
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CALL DOLT_CREATE_VIRTUAL_FOREIGN_KEY('child2', 'fk_parent2', 'parent_id', '--references', '\`parent\`(\`id\`)');" ]] || false
+    [[ ! "$output" =~ "NOT ENFORCED" ]] || false
+
+    dolt sql -q "ALTER TABLE child DROP FOREIGN KEY fk_parent"
+    run dolt diff -r sql
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'ALTER TABLE `child` DROP FOREIGN KEY `fk_parent`;' ]] || false
+}
+
+@test "virtual-foreign-keys: merge foreign keys from other branches" {
+    dolt checkout -b other
+    dolt sql -q "CALL dolt_create_virtual_foreign_key('parent', 'fk_self', 'v', '--references', 'parent(id)')"
+    dolt commit -am "add fk_self"
+    dolt checkout main
+    dolt sql -q "INSERT INTO parent VALUES (3, 3)"
+    dolt commit -am "add a parent"
+
+    dolt merge other -m "merge other"
+    run dolt schema show parent
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'CONSTRAINT `fk_self` FOREIGN KEY (`v`) REFERENCES `parent` (`id`) NOT ENFORCED' ]] || false
+}
+
+@test "virtual-foreign-keys: lint counts the rows without a parent row" {
+    run dolt lint --virtual-foreign-keys
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No virtual foreign keys violated" ]] || false
+
+    dolt sql -q "INSERT INTO child VALUES (4, 99), (5, 98)"
+    run dolt lint --virtual-foreign-keys
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "child.fk_parent: 2 rows without a parent row in parent" ]] || false
+}
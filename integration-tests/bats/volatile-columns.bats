@@ -0,0 +1,138 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    skip_nbf_not_dolt
+
+    dolt sql <<SQL
+CREATE TABLE t (pk int PRIMARY KEY, c int, updated_at int);
+INSERT INTO t VALUES (1, 10, 100), (2, 20, 200);
+INSERT INTO dolt_volatile_columns VALUES ('t', 'updated_at');
+SQL
+    dolt add .
+    dolt commit -m "create t" --date "2023-01-01T00:00:00"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "volatile-columns: changes to only volatile columns are shown by default" {
+    dolt sql -q "UPDATE t SET updated_at = updated_at + 1"
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:" ]] || false
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "updated_at" ]] || false
+    [[ "$output" =~ "101" ]] || false
+}
+
+@test "volatile-columns: --ignore-volatile hides changes to only volatile columns" {
+    dolt sql -q "UPDATE t SET updated_at = updated_at + 1"
+
+    run dolt status --ignore-volatile
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt diff --ignore-volatile
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    dolt add t
+    run dolt status --ignore-volatile
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    # volatile columns are still staged and committed
+    dolt commit -m "touch t"
+    run dolt sql -q "SELECT * FROM t AS OF 'HEAD' ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,10,101" ]] || false
+    [[ "$output" =~ "2,20,201" ]] || false
+}
+
+@test "volatile-columns: --ignore-volatile leaves out volatile columns" {
+    dolt sql -q "UPDATE t SET c = 11, updated_at = 101 WHERE pk = 1"
+    dolt sql -q "UPDATE t SET updated_at = 201 WHERE pk = 2"
+
+    run dolt status --ignore-volatile
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:" ]] || false
+
+    run dolt diff --ignore-volatile
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "11" ]] || false
+    [[ ! "$output" =~ "updated_at" ]] || false
+    [[ ! "$output" =~ "101" ]] || false
+    [[ ! "$output" =~ "20" ]] || false
+}
+
+@test "volatile-columns: merge takes volatile columns from the newer commit" {
+    dolt branch other
+
+    dolt sql -q "UPDATE t SET c = 11, updated_at = 101 WHERE pk = 1"
+    dolt sql -q "INSERT INTO t VALUES (3, 30, 301)"
+    dolt commit -am "main" --date "2023-01-03T00:00:00"
+
+    dolt checkout other
+    dolt sql -q "UPDATE t SET updated_at = 102 WHERE pk = 1"
+    dolt sql -q "UPDATE t SET c = 21, updated_at = 202 WHERE pk = 2"
+    dolt sql -q "INSERT INTO t VALUES (3, 30, 302)"
+    dolt commit -am "other" --date "2023-01-02T00:00:00"
+
+    # main was committed last, so its values win
+    run dolt merge main -m "merge main"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,11,101" ]] || false
+    [[ "$output" =~ "2,21,202" ]] || false
+    [[ "$output" =~ "3,30,301" ]] || false
+
+    dolt checkout main
+    run dolt merge other -m "merge other"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,11,101" ]] || false
+    [[ "$output" =~ "2,21,202" ]] || false
+    [[ "$output" =~ "3,30,301" ]] || false
+}
+
+@test "volatile-columns: other columns changed on both sides still conflict" {
+    dolt branch other
+    dolt sql -q "UPDATE t SET c = 11, updated_at = 101 WHERE pk = 1"
+    dolt commit -am "main" --date "2023-01-03T00:00:00"
+
+    dolt checkout other
+    dolt sql -q "UPDATE t SET c = 12, updated_at = 102 WHERE pk = 1"
+    dolt commit -am "other" --date "2023-01-02T00:00:00"
+
+    run dolt merge main -m "merge main"
+    [[ "$output" =~ "CONFLICT" ]] || false
+}
+
+@test "volatile-columns: table names are patterns" {
+    dolt sql <<SQL
+CREATE TABLE t2 (pk int PRIMARY KEY, c int, updated_at int);
+INSERT INTO t2 VALUES (1, 10, 100);
+DELETE FROM dolt_volatile_columns;
+INSERT INTO dolt_volatile_columns VALUES ('t*', 'updated_at');
+SQL
+    dolt commit -Am "add t2"
+    dolt sql -q "UPDATE t2 SET updated_at = 101"
+    dolt sql -q "UPDATE t SET updated_at = 101"
+
+    run dolt status --ignore-volatile
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
@@ -17,7 +17,6 @@ package main
 import (
 	"context"
 	"database/sql"
-	sqldriver "database/sql/driver"
 	"fmt"
 	"strings"
 	"testing"
@@ -41,12 +40,20 @@ func TestConcurrentGC(t *testing.T) {
 	t.Run("WithCommits", func(t *testing.T) {
 		gct.run(t)
 	})
+	gct.commit = false
+	gct.savepoints = true
+	t.Run("WithSavepoints", func(t *testing.T) {
+		gct.run(t)
+	})
 }
 
 type gcTest struct {
 	numThreads int
 	duration   time.Duration
 	commit     bool
+	// savepoints makes each update in a transaction which creates and rolls back savepoints, whose roots are
+	// kept by dolt_gc() while the other sessions change them.
+	savepoints bool
 }
 
 func (gct gcTest) createDB(t *testing.T, ctx context.Context, db *sql.DB) {
@@ -74,6 +81,9 @@ func (gct gcTest) doUpdate(t *testing.T, ctx context.Context, db *sql.DB, i int)
 		return nil
 	}
 	defer conn.Close()
+	if gct.savepoints {
+		return gct.doSavepointUpdate(t, ctx, conn, i)
+	}
 	_, err = conn.ExecContext(ctx, "update vals set val = val+1 where id = ?", i)
 	if err != nil {
 		if !assert.NotContains(t, err.Error(), "dangling ref") {
@@ -105,18 +115,47 @@ func (gct gcTest) doUpdate(t *testing.T, ctx context.Context, db *sql.DB, i int)
 	return nil
 }
 
+func (gct gcTest) doSavepointUpdate(t *testing.T, ctx context.Context, conn *sql.Conn, i int) error {
+	for _, q := range []string{
+		"start transaction",
+		"savepoint before_update",
+		"update vals set val = val+1 where id = ?",
+		"savepoint after_update",
+		"update vals set val = val+1 where id = ?",
+		"rollback to savepoint after_update",
+		"release savepoint before_update",
+		"commit",
+	} {
+		var args []any
+		if strings.Contains(q, "?") {
+			args = append(args, i)
+		}
+		_, err := conn.ExecContext(ctx, q, args...)
+		if err != nil {
+			if !assert.NotContains(t, err.Error(), "dangling ref") {
+				return err
+			}
+			if !assert.NotContains(t, err.Error(), "is unexpected noms value") {
+				return err
+			}
+			if !assert.NotContains(t, err.Error(), "interface conversion: types.Value is nil") {
+				return err
+			}
+			t.Logf("err in Exec %s: %v", q, err)
+			_, _ = conn.ExecContext(ctx, "rollback")
+			return nil
+		}
+	}
+	return nil
+}
+
 func (gct gcTest) doGC(t *testing.T, ctx context.Context, db *sql.DB) error {
 	conn, err := db.Conn(ctx)
 	if err != nil {
 		t.Logf("err in Conn for dolt_gc: %v", err)
 		return nil
 	}
-	defer func() {
-		// After calling dolt_gc, the connection is bad. Remove it from the connection pool.
-		conn.Raw(func(_ any) error {
-			return sqldriver.ErrBadConn
-		})
-	}()
+	defer conn.Close()
 	b := time.Now()
 	_, err = conn.ExecContext(ctx, "call dolt_gc()")
 	if err != nil {
@@ -231,3 +270,58 @@ func (gct gcTest) run(t *testing.T) {
 
 	gct.finalize(t, context.Background(), db)
 }
+
+func TestGCKeepsConnections(t *testing.T) {
+	u, err := driver.NewDoltUser()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		u.Cleanup()
+	})
+
+	rs, err := u.MakeRepoStore()
+	require.NoError(t, err)
+
+	repo, err := rs.MakeRepo("gc_keeps_connections_test")
+	require.NoError(t, err)
+
+	server := MakeServer(t, repo, &driver.Server{})
+	server.DBName = "gc_keeps_connections_test"
+
+	db, err := server.DB(driver.Connection{User: "root"})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, "create table vals (id int primary key, val int)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "insert into vals values (0, 0)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "call dolt_commit('-Am', 'create vals table')")
+	require.NoError(t, err)
+
+	// Leave uncommitted changes in an open transaction, which aren't
+	// reachable from anything but the session.
+	_, err = conn.ExecContext(ctx, "set autocommit = 0")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "insert into vals values (1, 1), (2, 2)")
+	require.NoError(t, err)
+
+	gcConn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer gcConn.Close()
+	_, err = gcConn.ExecContext(ctx, "call dolt_gc()")
+	require.NoError(t, err)
+
+	// Both connections are still usable, and the uncommitted changes
+	// survived the GC.
+	var cnt int
+	require.NoError(t, conn.QueryRowContext(ctx, "select count(*) from vals").Scan(&cnt))
+	require.Equal(t, 3, cnt)
+	_, err = conn.ExecContext(ctx, "commit")
+	require.NoError(t, err)
+	require.NoError(t, gcConn.QueryRowContext(ctx, "select count(*) from vals").Scan(&cnt))
+	require.Equal(t, 3, cnt)
+}